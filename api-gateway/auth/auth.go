@@ -0,0 +1,282 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+
+	authpb "auth-service/pb"
+	"auth-service/pkg/jwt"
+	"shared/logging"
+)
+
+// ErrUnauthenticated is returned when a request carries no valid token
+var ErrUnauthenticated = errors.New("authentication required")
+
+// Principal is the caller a verified access token belongs to
+type Principal struct {
+	UserID string
+	Roles  []string
+	// Token is the raw access token, forwarded to the backend services
+	Token string
+	// ExpiresAt is when the token expires
+	ExpiresAt time.Time
+}
+
+func (p *Principal) HasRole(role string) bool {
+	return slices.Contains(p.Roles, role)
+}
+
+type contextKey struct{}
+
+// WithPrincipal returns a copy of ctx carrying principal
+func WithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, contextKey{}, principal)
+}
+
+// FromContext returns the principal of the caller, if they are authenticated
+func FromContext(ctx context.Context) (*Principal, bool) {
+	principal, ok := ctx.Value(contextKey{}).(*Principal)
+	return principal, ok && principal != nil
+}
+
+// Verifier checks access tokens issued by auth-service. It is shared by the
+// HTTP middleware and the websocket handshake, so queries and subscriptions
+// authenticate the same way.
+//
+// Tokens are always checked locally against the signing secret. Tokens that
+// pass are also validated by AuthService.ValidateToken, which rejects revoked
+// tokens, revoked sessions and suspended users, when opening a websocket and,
+// in remote mode, on every request; its answers are cached for cacheTTL.
+type Verifier struct {
+	tokens   *jwt.Manager
+	client   authpb.AuthServiceClient
+	remote   bool
+	cacheTTL time.Duration
+	cookie   SessionCookie
+
+	mu    sync.Mutex
+	cache map[string]cachedPrincipal
+}
+
+type cachedPrincipal struct {
+	principal *Principal
+	expiresAt time.Time
+}
+
+// maxCachedTokens bounds the remote validation cache; it is cleared when full
+const maxCachedTokens = 10000
+
+// NewVerifier creates a verifier checking token signatures with tokens. With
+// remote, every request is also validated with client; without, only
+// websockets are. client may be nil to verify tokens locally only. A cookie
+// that is enabled also authenticates requests by their session cookie.
+func NewVerifier(tokens *jwt.Manager, client authpb.AuthServiceClient, remote bool, cacheTTL time.Duration, cookie SessionCookie) *Verifier {
+	return &Verifier{
+		tokens:   tokens,
+		client:   client,
+		remote:   remote,
+		cacheTTL: cacheTTL,
+		cookie:   cookie,
+		cache:    make(map[string]cachedPrincipal),
+	}
+}
+
+// Verify checks an Authorization header value, with or without its Bearer
+// scheme, and returns the principal it carries
+func (v *Verifier) Verify(ctx context.Context, authorization string) (*Principal, error) {
+	return v.verify(ctx, authorization, v.remote)
+}
+
+func (v *Verifier) verify(ctx context.Context, authorization string, remote bool) (*Principal, error) {
+	token := strings.TrimSpace(authorization)
+	if scheme, rest, ok := strings.Cut(token, " "); ok && strings.EqualFold(scheme, "Bearer") {
+		token = strings.TrimSpace(rest)
+	}
+	if token == "" {
+		return nil, ErrUnauthenticated
+	}
+
+	claims, err := v.tokens.Verify(token)
+	if err != nil {
+		return nil, err
+	}
+	if claims.UserID == "" {
+		return nil, errors.New("token has no user")
+	}
+
+	var expiresAt time.Time
+	if claims.ExpiresAt != nil {
+		expiresAt = claims.ExpiresAt.Time
+	}
+
+	if !remote || v.client == nil {
+		return &Principal{
+			UserID:    claims.UserID,
+			Roles:     claims.Roles,
+			Token:     token,
+			ExpiresAt: expiresAt,
+		}, nil
+	}
+
+	if principal, ok := v.cached(token); ok {
+		return principal, nil
+	}
+
+	resp, err := v.client.ValidateToken(ctx, &authpb.ValidateTokenRequest{Token: token})
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate token: %w", err)
+	}
+	if !resp.Valid {
+		return nil, errors.New(resp.Message)
+	}
+
+	principal := &Principal{
+		UserID:    resp.UserId,
+		Roles:     resp.Roles,
+		Token:     token,
+		ExpiresAt: expiresAt,
+	}
+
+	cachedUntil := time.Now().Add(v.cacheTTL)
+	if !expiresAt.IsZero() && expiresAt.Before(cachedUntil) {
+		cachedUntil = expiresAt
+	}
+	v.store(token, principal, cachedUntil)
+
+	return principal, nil
+}
+
+func (v *Verifier) cached(token string) (*Principal, bool) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	entry, ok := v.cache[token]
+	if !ok {
+		return nil, false
+	}
+	if time.Now().After(entry.expiresAt) {
+		delete(v.cache, token)
+		return nil, false
+	}
+	return entry.principal, true
+}
+
+func (v *Verifier) store(token string, principal *Principal, expiresAt time.Time) {
+	if v.cacheTTL <= 0 {
+		return
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if len(v.cache) >= maxCachedTokens {
+		clear(v.cache)
+	}
+	v.cache[token] = cachedPrincipal{principal: principal, expiresAt: expiresAt}
+}
+
+// Middleware authenticates requests with an Authorization header or, in
+// cookie mode, their session cookie. Requests without either pass through
+// anonymously and are turned away by @auth fields; an invalid token in the
+// header is rejected outright, while a stale cookie is ignored.
+func (v *Verifier) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if v.cookie.Enabled() {
+			r = r.WithContext(context.WithValue(r.Context(), sessionKey{}, &session{cookie: v.cookie, header: w.Header()}))
+		}
+
+		header := r.Header.Get("Authorization")
+		if header == "" {
+			v.authenticateCookie(w, r, next)
+			return
+		}
+
+		principal, err := v.Verify(r.Context(), header)
+		if err != nil {
+			w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+			http.Error(w, "invalid token", http.StatusUnauthorized)
+			return
+		}
+
+		ctx := logging.WithUser(r.Context(), principal.UserID)
+		next.ServeHTTP(w, r.WithContext(WithPrincipal(ctx, principal)))
+	})
+}
+
+// authenticateCookie serves r as the user of its session cookie, if it has
+// a valid one, and anonymously otherwise. Requests the cookie authenticates
+// must pass the CSRF check.
+func (v *Verifier) authenticateCookie(w http.ResponseWriter, r *http.Request, next http.Handler) {
+	if !v.cookie.Enabled() {
+		next.ServeHTTP(w, r)
+		return
+	}
+	cookie, err := r.Cookie(v.cookie.Name)
+	if err != nil || cookie.Value == "" {
+		next.ServeHTTP(w, r)
+		return
+	}
+
+	principal, err := v.Verify(r.Context(), cookie.Value)
+	if err != nil {
+		next.ServeHTTP(w, r)
+		return
+	}
+	if !v.cookie.validCSRF(r) {
+		http.Error(w, "invalid CSRF token", http.StatusForbidden)
+		return
+	}
+
+	ctx := logging.WithUser(r.Context(), principal.UserID)
+	next.ServeHTTP(w, r.WithContext(WithPrincipal(ctx, principal)))
+}
+
+// websocketTokenKeys are the connection_init payload fields a token is read
+// from: Authorization, as the header would carry it, or the bare token
+// fields of common clients
+var websocketTokenKeys = []string{"Authorization", "authorization", "authToken", "token", "accessToken"}
+
+// WebsocketInit authenticates a subscription connection from the token in
+// its connection_init payload, at the top level or under "headers". Browsers
+// cannot set headers on websockets, so the payload takes precedence over the
+// principal the middleware derived from the upgrade request. Connections
+// outlive requests, so either token is validated with AuthService whatever
+// the validation mode.
+func (v *Verifier) WebsocketInit(ctx context.Context, payload transport.InitPayload) (context.Context, *transport.InitPayload, error) {
+	authorization := websocketToken(payload)
+	if authorization == "" {
+		principal, ok := FromContext(ctx)
+		if !ok {
+			return ctx, nil, nil
+		}
+		authorization = principal.Token
+	}
+
+	principal, err := v.verify(ctx, authorization, true)
+	if err != nil {
+		return ctx, nil, errors.New("invalid token")
+	}
+
+	ctx = logging.WithUser(ctx, principal.UserID)
+	return WithPrincipal(ctx, principal), nil, nil
+}
+
+func websocketToken(payload transport.InitPayload) string {
+	for _, key := range websocketTokenKeys {
+		if token := payload.GetString(key); token != "" {
+			return token
+		}
+	}
+	if headers, ok := payload["headers"].(map[string]any); ok {
+		return websocketToken(headers)
+	}
+	return ""
+}
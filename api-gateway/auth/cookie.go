@@ -0,0 +1,105 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"net/http"
+	"time"
+)
+
+// CSRFHeader is the header requests authenticated by the session cookie
+// echo the CSRF cookie in
+const CSRFHeader = "X-CSRF-Token"
+
+// SessionCookie is the cookie-based auth mode, for web clients that keep
+// access tokens out of reach of scripts. Logins set the access token in an
+// HttpOnly cookie, and requests without an Authorization header are
+// authenticated from it.
+//
+// Browsers also send the cookie with requests other sites make, so those
+// requests are protected from CSRF with a double-submit token: logins set a
+// second cookie, which scripts can read, and requests authenticated by the
+// session cookie must echo it in X-CSRF-Token unless they are GET, HEAD or
+// OPTIONS. Other sites can neither read the cookie nor send the header
+// without the gateway's CORS approval.
+type SessionCookie struct {
+	// Name is the name of the access token cookie, e.g. "muzeeng_session";
+	// the CSRF cookie is Name + "_csrf". Empty disables cookie sessions.
+	Name   string
+	Domain string
+	// Secure cookies are only sent over HTTPS
+	Secure   bool
+	SameSite http.SameSite
+}
+
+// Enabled reports whether requests may be authenticated by the cookie
+func (c SessionCookie) Enabled() bool {
+	return c.Name != ""
+}
+
+func (c SessionCookie) csrfName() string {
+	return c.Name + "_csrf"
+}
+
+// validCSRF reports whether r echoes its CSRF cookie, as requests that may
+// change something must
+func (c SessionCookie) validCSRF(r *http.Request) bool {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+
+	cookie, err := r.Cookie(c.csrfName())
+	if err != nil || cookie.Value == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(cookie.Value), []byte(r.Header.Get(CSRFHeader))) == 1
+}
+
+func (c SessionCookie) cookie(name, value string, maxAge int, httpOnly bool) *http.Cookie {
+	return &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     "/",
+		Domain:   c.Domain,
+		MaxAge:   maxAge,
+		Secure:   c.Secure,
+		HttpOnly: httpOnly,
+		SameSite: c.SameSite,
+	}
+}
+
+// session is where the cookies of a request's response are set
+type session struct {
+	cookie SessionCookie
+	header http.Header
+}
+
+type sessionKey struct{}
+
+// StartSession sets the cookies of a session for token, which expires in
+// ttl, on the response of the request ctx belongs to. It does nothing
+// outside cookie mode.
+func StartSession(ctx context.Context, token string, ttl time.Duration) {
+	s, ok := ctx.Value(sessionKey{}).(*session)
+	if !ok {
+		return
+	}
+
+	maxAge := int(ttl.Seconds())
+	s.header.Add("Set-Cookie", s.cookie.cookie(s.cookie.Name, token, maxAge, true).String())
+	s.header.Add("Set-Cookie", s.cookie.cookie(s.cookie.csrfName(), rand.Text(), maxAge, false).String())
+}
+
+// EndSession clears the cookies of the session, if the request ctx belongs
+// to has one
+func EndSession(ctx context.Context) {
+	s, ok := ctx.Value(sessionKey{}).(*session)
+	if !ok {
+		return
+	}
+
+	s.header.Add("Set-Cookie", s.cookie.cookie(s.cookie.Name, "", -1, true).String())
+	s.header.Add("Set-Cookie", s.cookie.cookie(s.cookie.csrfName(), "", -1, false).String())
+}
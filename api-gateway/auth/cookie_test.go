@@ -0,0 +1,44 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestValidCSRF(t *testing.T) {
+	c := SessionCookie{Name: "muzeeng_session"}
+
+	tests := []struct {
+		name   string
+		method string
+		cookie string
+		header string
+		valid  bool
+	}{
+		{name: "matching header", method: http.MethodPost, cookie: "token", header: "token", valid: true},
+		{name: "missing header", method: http.MethodPost, cookie: "token"},
+		{name: "wrong header", method: http.MethodPost, cookie: "token", header: "other"},
+		{name: "missing cookie", method: http.MethodPost, header: "token"},
+		{name: "both empty", method: http.MethodPost},
+		{name: "get", method: http.MethodGet, valid: true},
+		{name: "head", method: http.MethodHead, valid: true},
+		{name: "options", method: http.MethodOptions, valid: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(tt.method, "/graphql", nil)
+			if tt.cookie != "" {
+				r.AddCookie(&http.Cookie{Name: c.csrfName(), Value: tt.cookie})
+			}
+			if tt.header != "" {
+				r.Header.Set(CSRFHeader, tt.header)
+			}
+
+			if got := c.validCSRF(r); got != tt.valid {
+				t.Errorf("validCSRF() = %v; want %v", got, tt.valid)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// Client is the device a request comes from, as auth-service records it on
+// the sessions it starts
+type Client struct {
+	IP        string
+	UserAgent string
+}
+
+type clientKey struct{}
+
+// WithClient returns a copy of ctx carrying the client the request comes from
+func WithClient(ctx context.Context, client Client) context.Context {
+	return context.WithValue(ctx, clientKey{}, client)
+}
+
+// IdempotencyHeader is the HTTP header of a client's idempotency key. It is
+// passed on to backends as their idempotency-key metadata, so a retried
+// createPost, createComment, likePost, followUser or register does not write
+// twice.
+const IdempotencyHeader = "Idempotency-Key"
+
+type idempotencyKey struct{}
+
+// IdempotencyKeys passes the Idempotency-Key header of requests on to the
+// backend calls made for them
+func IdempotencyKeys(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if key := r.Header.Get(IdempotencyHeader); key != "" {
+			r = r.WithContext(context.WithValue(r.Context(), idempotencyKey{}, key))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// outgoing adds the principal's token to the metadata of a call to a backend
+// service, unless the caller already set one, the client the request comes
+// from and its idempotency key
+func outgoing(ctx context.Context) context.Context {
+	if client, ok := ctx.Value(clientKey{}).(Client); ok {
+		ctx = metadata.AppendToOutgoingContext(ctx, "x-client-ip", client.IP, "x-client-user-agent", client.UserAgent)
+	}
+	if key, ok := ctx.Value(idempotencyKey{}).(string); ok {
+		ctx = metadata.AppendToOutgoingContext(ctx, "idempotency-key", key)
+	}
+
+	principal, ok := FromContext(ctx)
+	if !ok {
+		return ctx
+	}
+
+	if md, ok := metadata.FromOutgoingContext(ctx); ok && len(md.Get("authorization")) > 0 {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+principal.Token)
+}
+
+// UnaryClientInterceptor forwards the caller's token on every unary call, so
+// resolvers do not have to
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(outgoing(ctx), method, req, reply, cc, opts...)
+	}
+}
+
+// StreamClientInterceptor forwards the caller's token on every streaming call
+func StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(outgoing(ctx), desc, cc, method, opts...)
+	}
+}
@@ -0,0 +1,102 @@
+// Package cache keeps the results of public queries in Redis for a short
+// time, so hot profiles and posts viewed by anonymous visitors do not cost a
+// round trip to the owning service every time.
+//
+// Every entry is tagged with the objects it was built from, e.g. "post:<id>",
+// and the events that change an object drop the entries tagged with it.
+// An entry loaded while its object changes can outlive the event that
+// changed it, which the TTL bounds.
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"shared/logging"
+)
+
+const prefix = "gwcache:"
+
+// Cache stores entries in Redis for ttl. A nil *Cache caches nothing.
+type Cache struct {
+	redis *redis.Client
+	ttl   time.Duration
+}
+
+func New(client *redis.Client, ttl time.Duration) *Cache {
+	return &Cache{redis: client, ttl: ttl}
+}
+
+// Load returns the value cached under key, or calls load and caches what it
+// returns under the tags it is built from. Errors are not cached, and a cache
+// Redis cannot serve falls back to load.
+func Load[T any](ctx context.Context, c *Cache, key string, load func(ctx context.Context) (T, error), tags func(T) []string) (T, error) {
+	if c == nil {
+		return load(ctx)
+	}
+
+	var value T
+	data, err := c.redis.Get(ctx, prefix+key).Bytes()
+	if err == nil {
+		if err := json.Unmarshal(data, &value); err == nil {
+			return value, nil
+		}
+	} else if !errors.Is(err, redis.Nil) {
+		logging.FromContext(ctx).Warn().Err(err).Str("key", key).Msg("failed to read cache")
+	}
+
+	value, err = load(ctx)
+	if err != nil {
+		return value, err
+	}
+
+	if err := c.store(ctx, key, value, tags(value)); err != nil {
+		logging.FromContext(ctx).Warn().Err(err).Str("key", key).Msg("failed to write cache")
+	}
+	return value, nil
+}
+
+func (c *Cache) store(ctx context.Context, key string, value any, tags []string) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to encode cache entry: %w", err)
+	}
+
+	_, err = c.redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, prefix+key, data, c.ttl)
+		for _, tag := range tags {
+			pipe.SAdd(ctx, prefix+"tag:"+tag, prefix+key)
+			pipe.Expire(ctx, prefix+"tag:"+tag, c.ttl)
+		}
+		return nil
+	})
+	return err
+}
+
+// Invalidate drops the entries tagged with any of tags
+func (c *Cache) Invalidate(ctx context.Context, tags ...string) error {
+	if c == nil {
+		return nil
+	}
+
+	for _, tag := range tags {
+		tagKey := prefix + "tag:" + tag
+		keys, err := c.redis.SMembers(ctx, tagKey).Result()
+		if err != nil {
+			return fmt.Errorf("failed to read cache tag %s: %w", tag, err)
+		}
+		if err := c.redis.Del(ctx, append(keys, tagKey)...).Err(); err != nil {
+			return fmt.Errorf("failed to drop cache entries of %s: %w", tag, err)
+		}
+	}
+	return nil
+}
+
+// PostTag and UserTag are the tags of entries built from a post or user
+func PostTag(id fmt.Stringer) string { return "post:" + id.String() }
+func UserTag(id fmt.Stringer) string { return "user:" + id.String() }
@@ -0,0 +1,113 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/nats-io/nats.go"
+	"google.golang.org/protobuf/proto"
+
+	commentevents "comment-service/events"
+	followevents "follow-service/events"
+	likeevents "like-service/events"
+	postevents "post-service/events"
+	"shared/eventschema"
+	eventspb "shared/eventschema/pb"
+	"shared/logging"
+	"shared/tracing"
+	userevents "user-service/events"
+)
+
+// invalidations maps each event to the tags of the objects it changes
+var invalidations = map[string]func(msg *nats.Msg) ([]string, error){
+	postevents.PostCreated: decodeEvent(func(e *eventspb.PostCreated) []string {
+		// postsCount of the author
+		return []string{UserTag(eventschema.UUID(e.UserId))}
+	}),
+	postevents.PostUpdated: decodeEvent(func(e *eventspb.PostUpdated) []string {
+		return []string{PostTag(eventschema.UUID(e.PostId))}
+	}),
+	postevents.PostDeleted: decodeEvent(func(e *eventspb.PostDeleted) []string {
+		return []string{PostTag(eventschema.UUID(e.PostId)), UserTag(eventschema.UUID(e.UserId))}
+	}),
+	postevents.PostReposted: decode(func(e postevents.PostRepostedEvent) []string {
+		return []string{PostTag(e.PostID)}
+	}),
+	postevents.PostUnreposted: decode(func(e postevents.PostUnrepostedEvent) []string {
+		return []string{PostTag(e.PostID)}
+	}),
+	commentevents.CommentAdded: decodeEvent(func(e *eventspb.CommentAdded) []string {
+		return []string{PostTag(eventschema.UUID(e.PostId))}
+	}),
+	commentevents.CommentReplied: decode(func(e commentevents.CommentRepliedEvent) []string {
+		return []string{PostTag(e.PostID)}
+	}),
+	likeevents.PostLiked: decode(func(e likeevents.PostLikedEvent) []string {
+		return []string{PostTag(e.PostID)}
+	}),
+	userevents.UserUpdated: decode(func(e userevents.UserUpdatedEvent) []string {
+		// Also drops the user's posts, which anonymous visitors may no
+		// longer see once the account is private
+		return []string{UserTag(e.UserID)}
+	}),
+	followevents.UserFollowed: decode(func(e followevents.UserFollowedEvent) []string {
+		return []string{UserTag(e.FollowerID), UserTag(e.FollowingID)}
+	}),
+	followevents.UserUnfollowed: decode(func(e followevents.UserUnfollowedEvent) []string {
+		return []string{UserTag(e.FollowerID), UserTag(e.FollowingID)}
+	}),
+}
+
+func decode[E any](tags func(E) []string) func(msg *nats.Msg) ([]string, error) {
+	return func(msg *nats.Msg) ([]string, error) {
+		var event E
+		if err := json.Unmarshal(msg.Data, &event); err != nil {
+			return nil, fmt.Errorf("failed to decode event: %w", err)
+		}
+		return tags(event), nil
+	}
+}
+
+// decodeEvent decodes the events that have a schema in shared/eventschema
+func decodeEvent[E any, P interface {
+	*E
+	proto.Message
+}](tags func(P) []string) func(msg *nats.Msg) ([]string, error) {
+	return func(msg *nats.Msg) ([]string, error) {
+		event := P(new(E))
+		if _, err := eventschema.Decode(msg.Subject, msg.Header, msg.Data, event); err != nil {
+			return nil, fmt.Errorf("failed to decode event: %w", err)
+		}
+		return tags(event), nil
+	}
+}
+
+// Subscribe invalidates entries as the events changing them are published.
+// The cache is shared, so gateway replicas take turns in a queue group.
+func (c *Cache) Subscribe(ctx context.Context, nc *nats.Conn) ([]*nats.Subscription, error) {
+	var subs []*nats.Subscription
+	for subject, tagsOf := range invalidations {
+		sub, err := nc.QueueSubscribe(subject, "gateway-cache", func(msg *nats.Msg) {
+			ctx, span := tracing.StartProcess(ctx, msg.Subject, msg.Header)
+			ctx = logging.Extract(ctx, msg.Subject, msg.Header)
+			defer span.End()
+
+			tags, err := tagsOf(msg)
+			if err == nil {
+				err = c.Invalidate(ctx, tags...)
+			}
+			if err != nil {
+				logging.FromContext(ctx).Error().Err(err).Msg("failed to invalidate cache")
+			}
+		})
+		if err != nil {
+			for _, sub := range subs {
+				sub.Unsubscribe()
+			}
+			return nil, fmt.Errorf("failed to subscribe to %s: %w", subject, err)
+		}
+		subs = append(subs, sub)
+	}
+	return subs, nil
+}
@@ -0,0 +1,322 @@
+// Package config holds where the gateway finds the services it calls, how
+// it connects to them and which browsers may call it.
+//
+// Settings are read, in increasing precedence, from the defaults below, the
+// YAML file named by GATEWAY_CONFIG and the environment:
+//
+//	<NAME>_SERVICE_ADDR          address of a service, e.g. USER_SERVICE_ADDR
+//	SERVICE_DISCOVERY            static (default), dns or consul
+//	CONSUL_ADDR                  Consul HTTP API, e.g. http://consul:8500
+//	GRPC_TLS                     true to connect to all services over TLS
+//	GRPC_TLS_CA_FILE             CA bundle to verify services with
+//	GRPC_TLS_CERT_FILE           client certificate, for mutual TLS
+//	GRPC_TLS_KEY_FILE            key of the client certificate
+//	GRPC_TLS_SPIFFE_IDS          SPIFFE IDs or trust domains services must present
+//	NATS_URL                     NATS server
+//	WEBSOCKET_ALLOWED_ORIGINS    comma-separated origins browsers may subscribe from
+//	WEBSOCKET_MAX_SUBSCRIPTIONS  subscriptions a connection may run at once
+//	CORS_ALLOWED_ORIGINS         comma-separated origins browsers may call from
+//	CORS_ALLOWED_HEADERS         comma-separated headers they may send
+//	CORS_ALLOW_CREDENTIALS       true to let them send cookies
+//	CORS_MAX_AGE                 how long preflights are cached, e.g. 10m
+//	CONTENT_SECURITY_POLICY      Content-Security-Policy of every response
+//	HSTS_MAX_AGE                 Strict-Transport-Security max-age, e.g. 8760h
+//
+// A file can also set discovery and TLS per service; see config.example.yaml.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/credentials"
+	"gopkg.in/yaml.v3"
+
+	"api-gateway/httpsec"
+	"shared/mtls"
+)
+
+// Discovery modes
+const (
+	// Static dials the configured address as is
+	Static = "static"
+	// DNS resolves the host of the address to all of its records, e.g. the
+	// replicas behind a headless Kubernetes service, and re-resolves it when
+	// connections fail
+	DNS = "dns"
+	// Consul watches the passing instances registered in Consul under the
+	// service's name
+	Consul = "consul"
+)
+
+// Names are the services the gateway calls, keyed as in the config file
+var Names = []string{"auth", "user", "post", "comment", "like", "follow", "notification", "feed", "search", "moderation", "audit"}
+
+var defaultAddresses = map[string]string{
+	"auth":         "auth-service:50051",
+	"user":         "user-service:50052",
+	"post":         "post-service:50053",
+	"feed":         "feed-service:50054",
+	"follow":       "follow-service:50055",
+	"comment":      "comment-service:50056",
+	"like":         "like-service:50057",
+	"notification": "notification-service:50058",
+	"search":       "search-service:50062",
+	"moderation":   "moderation-service:50063",
+	"audit":        "audit-service:50064",
+}
+
+type Config struct {
+	Discovery string `yaml:"discovery"`
+	Consul    struct {
+		Address string `yaml:"address"`
+	} `yaml:"consul"`
+	// TLS applies to every service that does not set its own
+	TLS      TLS                `yaml:"tls"`
+	Services map[string]Service `yaml:"services"`
+	NATSURL  string             `yaml:"natsUrl"`
+	// Websocket guards the connections subscriptions run over
+	Websocket Websocket `yaml:"websocket"`
+	// CORS is which web frontends may call the gateway from their origin
+	CORS CORS `yaml:"cors"`
+	// SecurityHeaders are set on every response
+	SecurityHeaders SecurityHeaders `yaml:"securityHeaders"`
+}
+
+// CORS is converted to httpsec.CORS, which documents its fields
+type CORS struct {
+	AllowedOrigins   []string      `yaml:"allowedOrigins"`
+	AllowedHeaders   []string      `yaml:"allowedHeaders"`
+	ExposedHeaders   []string      `yaml:"exposedHeaders"`
+	AllowCredentials bool          `yaml:"allowCredentials"`
+	MaxAge           time.Duration `yaml:"maxAge"`
+}
+
+// SecurityHeaders is converted to httpsec.Headers, which documents its
+// fields
+type SecurityHeaders struct {
+	ContentSecurityPolicy string        `yaml:"contentSecurityPolicy"`
+	HSTSMaxAge            time.Duration `yaml:"hstsMaxAge"`
+}
+
+type Websocket struct {
+	// AllowedOrigins are the origins browsers may open connections from,
+	// e.g. https://muzeeng.com or https://*.muzeeng.com, or "*" for any.
+	// Empty allows only the gateway's own origin.
+	AllowedOrigins []string `yaml:"allowedOrigins"`
+	// MaxSubscriptions is how many subscriptions a connection may run at
+	// once; 0 is unlimited
+	MaxSubscriptions int `yaml:"maxSubscriptions"`
+}
+
+type Service struct {
+	Address string `yaml:"address"`
+	// Discovery overrides the default mode for this service
+	Discovery string `yaml:"discovery"`
+	// ConsulName is the name the service is registered under in Consul,
+	// "<name>-service" by default
+	ConsulName string `yaml:"consulName"`
+	TLS        *TLS   `yaml:"tls"`
+}
+
+type TLS struct {
+	Enabled  bool   `yaml:"enabled"`
+	CAFile   string `yaml:"caFile"`
+	CertFile string `yaml:"certFile"`
+	KeyFile  string `yaml:"keyFile"`
+	// ServerName overrides the name the certificate is verified against:
+	// the host of the address, or the Consul name, by default
+	ServerName string `yaml:"serverName"`
+	// SPIFFEIDs are the IDs, or trust domains, the service's certificate
+	// must name; they replace the check of its name
+	SPIFFEIDs          []string `yaml:"spiffeIds"`
+	InsecureSkipVerify bool     `yaml:"insecureSkipVerify"`
+}
+
+// Load reads the configuration from its defaults, file and environment
+func Load() (*Config, error) {
+	cfg := &Config{
+		Discovery: Static,
+		Services:  make(map[string]Service),
+		NATSURL:   "nats://nats:4222",
+	}
+	cfg.Websocket.MaxSubscriptions = 20
+	cfg.CORS = CORS{
+		AllowedHeaders: []string{"Authorization", "Content-Type", "Idempotency-Key", "X-CSRF-Token", "X-Request-ID"},
+		ExposedHeaders: []string{"Retry-After", "X-Request-ID"},
+		MaxAge:         10 * time.Minute,
+	}
+	cfg.SecurityHeaders.ContentSecurityPolicy = "frame-ancestors 'none'"
+	cfg.Consul.Address = "http://consul:8500"
+
+	if path := os.Getenv("GATEWAY_CONFIG"); path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read config: %w", err)
+		}
+		if err := yaml.Unmarshal(data, cfg); err != nil {
+			return nil, fmt.Errorf("failed to parse config %s: %w", path, err)
+		}
+	}
+
+	setFromEnv(&cfg.Discovery, "SERVICE_DISCOVERY")
+	setFromEnv(&cfg.Consul.Address, "CONSUL_ADDR")
+	setFromEnv(&cfg.NATSURL, "NATS_URL")
+	if os.Getenv("GRPC_TLS") == "true" {
+		cfg.TLS.Enabled = true
+	}
+	setFromEnv(&cfg.TLS.CAFile, "GRPC_TLS_CA_FILE")
+	setFromEnv(&cfg.TLS.CertFile, "GRPC_TLS_CERT_FILE")
+	setFromEnv(&cfg.TLS.KeyFile, "GRPC_TLS_KEY_FILE")
+	if ids := mtls.FromEnv().SPIFFEIDs; len(ids) > 0 {
+		cfg.TLS.SPIFFEIDs = ids
+	}
+	setListFromEnv(&cfg.Websocket.AllowedOrigins, "WEBSOCKET_ALLOWED_ORIGINS")
+	if limit := os.Getenv("WEBSOCKET_MAX_SUBSCRIPTIONS"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil {
+			return nil, fmt.Errorf("invalid WEBSOCKET_MAX_SUBSCRIPTIONS %q", limit)
+		}
+		cfg.Websocket.MaxSubscriptions = n
+	}
+	if err := cfg.Websocket.validate(); err != nil {
+		return nil, fmt.Errorf("invalid websocket config: %w", err)
+	}
+
+	setListFromEnv(&cfg.CORS.AllowedOrigins, "CORS_ALLOWED_ORIGINS")
+	setListFromEnv(&cfg.CORS.AllowedHeaders, "CORS_ALLOWED_HEADERS")
+	if value := os.Getenv("CORS_ALLOW_CREDENTIALS"); value != "" {
+		cfg.CORS.AllowCredentials = value == "true"
+	}
+	if err := setDurationFromEnv(&cfg.CORS.MaxAge, "CORS_MAX_AGE"); err != nil {
+		return nil, err
+	}
+	if err := httpsec.CORS(cfg.CORS).Validate(); err != nil {
+		return nil, fmt.Errorf("invalid cors config: %w", err)
+	}
+	setFromEnv(&cfg.SecurityHeaders.ContentSecurityPolicy, "CONTENT_SECURITY_POLICY")
+	if err := setDurationFromEnv(&cfg.SecurityHeaders.HSTSMaxAge, "HSTS_MAX_AGE"); err != nil {
+		return nil, err
+	}
+	if cfg.SecurityHeaders.HSTSMaxAge < 0 {
+		return nil, errors.New("invalid securityHeaders config: hstsMaxAge must not be negative")
+	}
+
+	for name := range cfg.Services {
+		if !slices.Contains(Names, name) {
+			return nil, fmt.Errorf("unknown service %q in config", name)
+		}
+	}
+	for _, name := range Names {
+		svc := cfg.Services[name]
+		if svc.Address == "" {
+			svc.Address = defaultAddresses[name]
+		}
+		setFromEnv(&svc.Address, strings.ToUpper(name)+"_SERVICE_ADDR")
+		if svc.Discovery == "" {
+			svc.Discovery = cfg.Discovery
+		}
+		if svc.ConsulName == "" {
+			svc.ConsulName = name + "-service"
+		}
+		if svc.TLS == nil {
+			svc.TLS = &cfg.TLS
+		}
+		if err := svc.validate(); err != nil {
+			return nil, fmt.Errorf("invalid config of %s service: %w", name, err)
+		}
+		cfg.Services[name] = svc
+	}
+
+	return cfg, nil
+}
+
+func (w Websocket) validate() error {
+	for _, origin := range w.AllowedOrigins {
+		if err := httpsec.ValidateOrigin(origin); err != nil {
+			return err
+		}
+	}
+	if w.MaxSubscriptions < 0 {
+		return errors.New("maxSubscriptions must not be negative")
+	}
+	return nil
+}
+
+func (s Service) validate() error {
+	switch s.Discovery {
+	case Static, DNS:
+		if s.Address == "" {
+			return errors.New("address is required")
+		}
+	case Consul:
+	default:
+		return fmt.Errorf("unknown discovery %q: must be static, dns or consul", s.Discovery)
+	}
+	if (s.TLS.CertFile == "") != (s.TLS.KeyFile == "") {
+		return errors.New("tls certFile and keyFile must be set together")
+	}
+	return nil
+}
+
+// Target is the gRPC dial target of the service
+func (s Service) Target() string {
+	switch s.Discovery {
+	case DNS:
+		return "dns:///" + s.Address
+	case Consul:
+		return "consul:///" + s.ConsulName
+	}
+	return "passthrough:///" + s.Address
+}
+
+// Credentials returns the transport credentials to dial the service with
+func (s Service) Credentials() (credentials.TransportCredentials, error) {
+	return mtls.Config{
+		Enabled:            s.TLS.Enabled,
+		CertFile:           s.TLS.CertFile,
+		KeyFile:            s.TLS.KeyFile,
+		CAFile:             s.TLS.CAFile,
+		ServerName:         s.TLS.ServerName,
+		SPIFFEIDs:          s.TLS.SPIFFEIDs,
+		InsecureSkipVerify: s.TLS.InsecureSkipVerify,
+	}.ClientCredentials()
+}
+
+func setFromEnv(field *string, key string) {
+	if value := os.Getenv(key); value != "" {
+		*field = value
+	}
+}
+
+// setListFromEnv replaces field with the comma-separated values of key
+func setListFromEnv(field *[]string, key string) {
+	value := os.Getenv(key)
+	if value == "" {
+		return
+	}
+	*field = nil
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			*field = append(*field, item)
+		}
+	}
+}
+
+func setDurationFromEnv(field *time.Duration, key string) error {
+	value := os.Getenv(key)
+	if value == "" {
+		return nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return fmt.Errorf("invalid %s %q", key, value)
+	}
+	*field = d
+	return nil
+}
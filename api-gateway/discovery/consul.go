@@ -0,0 +1,177 @@
+// Package discovery resolves consul:///<service> dial targets to the passing
+// instances Consul knows for the service, following changes with blocking
+// queries so replicas are added and removed as they come and go.
+package discovery
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/resolver"
+)
+
+const (
+	// watchWait bounds each blocking query; Consul answers earlier when the
+	// instances change
+	watchWait = 5 * time.Minute
+	// retryMin and retryMax bound the backoff after a failed query
+	retryMin = time.Second
+	retryMax = 30 * time.Second
+)
+
+// RegisterConsul registers the consul resolver, querying the Consul HTTP API
+// at addr, e.g. http://consul:8500. It must be called before dialing.
+func RegisterConsul(addr string) {
+	resolver.Register(&consulBuilder{
+		addr:   strings.TrimRight(addr, "/"),
+		client: &http.Client{Timeout: watchWait + 10*time.Second},
+	})
+}
+
+type consulBuilder struct {
+	addr   string
+	client *http.Client
+}
+
+func (b *consulBuilder) Scheme() string {
+	return "consul"
+}
+
+func (b *consulBuilder) Build(target resolver.Target, cc resolver.ClientConn, _ resolver.BuildOptions) (resolver.Resolver, error) {
+	service := strings.TrimPrefix(target.Endpoint(), "/")
+	if service == "" {
+		return nil, fmt.Errorf("consul target %q names no service", target.URL.String())
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r := &consulResolver{
+		builder: b,
+		service: service,
+		cc:      cc,
+		cancel:  cancel,
+	}
+	go r.watch(ctx)
+	return r, nil
+}
+
+type consulResolver struct {
+	builder *consulBuilder
+	service string
+	cc      resolver.ClientConn
+	cancel  context.CancelFunc
+}
+
+// ResolveNow is a no-op: the watch already reports every change
+func (r *consulResolver) ResolveNow(resolver.ResolveNowOptions) {}
+
+func (r *consulResolver) Close() {
+	r.cancel()
+}
+
+func (r *consulResolver) watch(ctx context.Context) {
+	var index uint64
+	retry := retryMin
+
+	for ctx.Err() == nil {
+		addrs, next, err := r.query(ctx, index)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			log.Printf("Consul lookup of %s failed: %v", r.service, err)
+			r.cc.ReportError(err)
+			select {
+			case <-time.After(retry):
+			case <-ctx.Done():
+				return
+			}
+			retry = min(2*retry, retryMax)
+			continue
+		}
+		retry = retryMin
+
+		// Consul indexes only grow; start over if it was reset
+		if next < index {
+			next = 0
+		}
+		if next == index {
+			continue
+		}
+		index = next
+
+		if len(addrs) == 0 {
+			r.cc.ReportError(fmt.Errorf("no passing instances of %s in Consul", r.service))
+			continue
+		}
+		if err := r.cc.UpdateState(resolver.State{Addresses: addrs}); err != nil {
+			log.Printf("Failed to update instances of %s: %v", r.service, err)
+		}
+	}
+}
+
+// consulEntry is the part of /v1/health/service entries the resolver uses
+type consulEntry struct {
+	Node struct {
+		Address string
+	}
+	Service struct {
+		Address string
+		Port    int
+	}
+}
+
+// query returns the passing instances of the service once their index
+// differs from index, along with the new index
+func (r *consulResolver) query(ctx context.Context, index uint64) ([]resolver.Address, uint64, error) {
+	q := url.Values{
+		"passing": {"true"},
+		"index":   {strconv.FormatUint(index, 10)},
+		"wait":    {watchWait.String()},
+	}
+	u := r.builder.addr + "/v1/health/service/" + url.PathEscape(r.service) + "?" + q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	resp, err := r.builder.client.Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, 0, fmt.Errorf("consul returned %s", resp.Status)
+	}
+
+	var entries []consulEntry
+	if err := json.NewDecoder(resp.Body).Decode(&entries); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode consul response: %w", err)
+	}
+
+	next, err := strconv.ParseUint(resp.Header.Get("X-Consul-Index"), 10, 64)
+	if err != nil {
+		return nil, 0, fmt.Errorf("invalid X-Consul-Index: %w", err)
+	}
+
+	addrs := make([]resolver.Address, 0, len(entries))
+	for _, e := range entries {
+		host := e.Service.Address
+		if host == "" {
+			// Instances registered without an address run on their node
+			host = e.Node.Address
+		}
+		addrs = append(addrs, resolver.Address{
+			Addr: net.JoinHostPort(host, strconv.Itoa(e.Service.Port)),
+		})
+	}
+	return addrs, next, nil
+}
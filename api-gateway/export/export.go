@@ -0,0 +1,159 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"api-gateway/auth"
+	commentpb "comment-service/pb"
+	followpb "follow-service/pb"
+	likepb "like-service/pb"
+	notificationpb "notification-service/pb"
+	postpb "post-service/pb"
+	userpb "user-service/pb"
+)
+
+// Path is where the caller's data export is served
+const Path = "/export"
+
+// archive is the document a data export is served as. Each section is the
+// JSON document the owning service exported.
+type archive struct {
+	ExportedAt    time.Time       `json:"exported_at"`
+	User          json.RawMessage `json:"user"`
+	Posts         json.RawMessage `json:"posts"`
+	Comments      json.RawMessage `json:"comments"`
+	Likes         json.RawMessage `json:"likes"`
+	Follows       json.RawMessage `json:"follows"`
+	Notifications json.RawMessage `json:"notifications"`
+}
+
+// Handler serves the caller's data, gathered from every service that holds
+// some, as a single JSON download. It must be wrapped in the auth middleware.
+type Handler struct {
+	users         userpb.UserServiceClient
+	posts         postpb.PostServiceClient
+	comments      commentpb.CommentServiceClient
+	likes         likepb.LikeServiceClient
+	follows       followpb.FollowServiceClient
+	notifications notificationpb.NotificationServiceClient
+}
+
+func NewHandler(
+	users userpb.UserServiceClient,
+	posts postpb.PostServiceClient,
+	comments commentpb.CommentServiceClient,
+	likes likepb.LikeServiceClient,
+	follows followpb.FollowServiceClient,
+	notifications notificationpb.NotificationServiceClient,
+) *Handler {
+	return &Handler{
+		users:         users,
+		posts:         posts,
+		comments:      comments,
+		likes:         likes,
+		follows:       follows,
+		notifications: notifications,
+	}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", "GET")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	principal, ok := auth.FromContext(r.Context())
+	if !ok {
+		http.Error(w, "authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	doc := archive{ExportedAt: time.Now().UTC()}
+	sections := []struct {
+		name   string
+		dst    *json.RawMessage
+		export func(ctx context.Context) ([]byte, error)
+	}{
+		{"user", &doc.User, func(ctx context.Context) ([]byte, error) {
+			resp, err := h.users.ExportMyData(ctx, &userpb.ExportMyDataRequest{})
+			return resp.GetData(), err
+		}},
+		{"posts", &doc.Posts, func(ctx context.Context) ([]byte, error) {
+			resp, err := h.posts.ExportMyData(ctx, &postpb.ExportMyDataRequest{})
+			return resp.GetData(), err
+		}},
+		{"comments", &doc.Comments, func(ctx context.Context) ([]byte, error) {
+			resp, err := h.comments.ExportMyData(ctx, &commentpb.ExportMyDataRequest{})
+			return resp.GetData(), err
+		}},
+		{"likes", &doc.Likes, func(ctx context.Context) ([]byte, error) {
+			resp, err := h.likes.ExportMyData(ctx, &likepb.ExportMyDataRequest{})
+			return resp.GetData(), err
+		}},
+		{"follows", &doc.Follows, func(ctx context.Context) ([]byte, error) {
+			resp, err := h.follows.ExportMyData(ctx, &followpb.ExportMyDataRequest{})
+			return resp.GetData(), err
+		}},
+		{"notifications", &doc.Notifications, func(ctx context.Context) ([]byte, error) {
+			resp, err := h.notifications.ExportMyData(ctx, &notificationpb.ExportMyDataRequest{})
+			return resp.GetData(), err
+		}},
+	}
+
+	// Every section is needed, so the export fails as a whole when any
+	// service does
+	var wg sync.WaitGroup
+	errs := make([]error, len(sections))
+	for i, section := range sections {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			data, err := section.export(r.Context())
+			if err != nil {
+				errs[i] = fmt.Errorf("failed to export %s: %w", section.name, err)
+				return
+			}
+			*section.dst = data
+		}()
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+	}
+
+	body, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	filename := fmt.Sprintf("muzeeng-export-%s-%s.json", principal.UserID, doc.ExportedAt.Format("20060102"))
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	w.Header().Set("Cache-Control", "no-store")
+	w.Write(body)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	switch status.Code(err) {
+	case codes.Unauthenticated:
+		http.Error(w, "authentication required", http.StatusUnauthorized)
+	default:
+		log.Printf("Failed to export data: %v", err)
+		http.Error(w, "failed to export data", http.StatusBadGateway)
+	}
+}
@@ -3,6 +3,7 @@ module api-gateway
 go 1.25.1
 
 require (
+	audit-service v0.0.0-00010101000000-000000000000
 	auth-service v0.0.0-00010101000000-000000000000
 	comment-service v0.0.0-00010101000000-000000000000
 	feed-service v0.0.0-00010101000000-000000000000
@@ -10,23 +11,50 @@ require (
 	github.com/99designs/gqlgen v0.17.81
 	github.com/google/uuid v1.6.0
 	github.com/nats-io/nats.go v1.46.1
+	github.com/redis/go-redis/v9 v9.14.0
 	github.com/vektah/gqlparser/v2 v2.5.30
+	go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp v0.63.0
+	go.opentelemetry.io/otel v1.38.0
+	go.opentelemetry.io/otel/trace v1.38.0
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20250825161204-c5933d9347a5
+	google.golang.org/protobuf v1.36.9
+	gopkg.in/yaml.v3 v3.0.1
 	like-service v0.0.0-00010101000000-000000000000
+	moderation-service v0.0.0-00010101000000-000000000000
 	notification-service v0.0.0-00010101000000-000000000000
 	post-service v0.0.0-00010101000000-000000000000
+	search-service v0.0.0-00010101000000-000000000000
+	shared v0.0.0-00010101000000-000000000000
 	user-service v0.0.0-00010101000000-000000000000
 )
 
 require (
+	github.com/cenkalti/backoff/v5 v5.0.3 // indirect
+	github.com/cespare/xxhash/v2 v2.3.0 // indirect
+	github.com/dgryski/go-rendezvous v0.0.0-20200823014737-9f7001d12a5f // indirect
+	github.com/felixge/httpsnoop v1.0.4 // indirect
+	github.com/go-logr/logr v1.4.3 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	github.com/golang-jwt/jwt/v5 v5.3.0 // indirect
+	github.com/grpc-ecosystem/grpc-gateway/v2 v2.27.2 // indirect
 	github.com/klauspost/compress v1.18.0 // indirect
+	github.com/mattn/go-colorable v0.1.14 // indirect
+	github.com/mattn/go-isatty v0.0.20 // indirect
 	github.com/nats-io/nkeys v0.4.11 // indirect
 	github.com/nats-io/nuid v1.0.1 // indirect
+	github.com/rs/zerolog v1.34.0 // indirect
+	go.opentelemetry.io/auto/sdk v1.1.0 // indirect
+	go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc v0.63.0 // indirect
+	go.opentelemetry.io/otel/exporters/otlp/otlptrace v1.38.0 // indirect
+	go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc v1.38.0 // indirect
+	go.opentelemetry.io/otel/metric v1.38.0 // indirect
+	go.opentelemetry.io/otel/sdk v1.38.0 // indirect
+	go.opentelemetry.io/proto/otlp v1.7.1 // indirect
 	golang.org/x/crypto v0.42.0 // indirect
 	golang.org/x/net v0.44.0 // indirect
 	golang.org/x/sys v0.36.0 // indirect
 	golang.org/x/text v0.29.0 // indirect
-	google.golang.org/genproto/googleapis/rpc v0.0.0-20250707201910-8d1bb00bc6a7 // indirect
-	google.golang.org/protobuf v1.36.9 // indirect
+	google.golang.org/genproto/googleapis/api v0.0.0-20250825161204-c5933d9347a5 // indirect
 )
 
 require (
@@ -53,3 +81,11 @@ replace notification-service => ../notification-service
 replace user-service => ../user-service
 
 replace feed-service => ../feed-service
+
+replace search-service => ../search-service
+
+replace moderation-service => ../moderation-service
+
+replace audit-service => ../audit-service
+
+replace shared => ../shared
@@ -0,0 +1,116 @@
+package graph
+
+import (
+	"github.com/google/uuid"
+
+	"api-gateway/graph/model"
+)
+
+// serviceCallCost is the cost of a field resolved with its own call to a
+// backing service, as opposed to one read from its parent or batched by a
+// loader
+const serviceCallCost = 5
+
+// Complexity prices the fields of the schema for the complexity limit.
+// Fields not listed here cost 1 plus their selections. A page costs its
+// selections once per element it may return, so nesting pages multiplies:
+// 20 posts with 5 comments each cost at least 100 comments.
+func Complexity() ComplexityRoot {
+	var c ComplexityRoot
+
+	c.Query.GetUserPosts = func(childComplexity int, _ uuid.UUID, first *int32, _ *string) int {
+		return page(childComplexity, first, 10)
+	}
+	c.Query.GetFeed = func(childComplexity int, first *int32, _ *string, _ *bool) int {
+		return page(childComplexity, first, 10)
+	}
+	c.Query.ExploreFeed = func(childComplexity int, first *int32, _ *string) int {
+		return page(childComplexity, first, 10)
+	}
+	c.Query.GetPostComments = func(childComplexity int, _ uuid.UUID, first *int32, _ *string) int {
+		return page(childComplexity, first, 10)
+	}
+	c.Query.GetFollowers = func(childComplexity int, _ uuid.UUID, first *int32, _ *string) int {
+		return page(childComplexity, first, 10)
+	}
+	c.Query.GetFollowing = func(childComplexity int, _ uuid.UUID, first *int32, _ *string) int {
+		return page(childComplexity, first, 10)
+	}
+	c.Query.GetNotifications = func(childComplexity int, first *int32, _ *string) int {
+		return page(childComplexity, first, 10)
+	}
+	c.Query.FollowRequests = func(childComplexity int, first *int32, _ *string) int {
+		return page(childComplexity, first, 10)
+	}
+	c.Query.FollowSuggestions = func(childComplexity int, first *int32, _ *string) int {
+		return page(childComplexity, first, 10)
+	}
+	c.Query.MutualFollowers = func(childComplexity int, _ uuid.UUID, first *int32) int {
+		return page(childComplexity, first, 3)
+	}
+	c.Query.WebhookDeliveries = func(childComplexity int, _ uuid.UUID, first *int32) int {
+		return page(childComplexity, first, 20)
+	}
+	c.Query.Search = func(childComplexity int, _ string, _ *model.SearchType, first *int32, _ *string) int {
+		return page(childComplexity, first, 10)
+	}
+	c.Query.PostsByHashtag = func(childComplexity int, _ string, first *int32, _ *string) int {
+		return page(childComplexity, first, 10)
+	}
+	c.Query.Drafts = func(childComplexity int, first *int32, _ *string) int {
+		return page(childComplexity, first, 10)
+	}
+	c.Query.TrendingHashtags = func(childComplexity int, limit *int32, _ *int32) int {
+		return page(childComplexity, limit, 10)
+	}
+	c.Query.Reports = func(childComplexity int, _ *model.ReportStatus, _ *model.ReportReason, _ *model.ModerationTarget, limit *int32) int {
+		return page(childComplexity, limit, 20)
+	}
+	c.Query.ModerationAuditLog = func(childComplexity int, _ *uuid.UUID, _ *model.ModerationTarget, _ *uuid.UUID, limit *int32) int {
+		return page(childComplexity, limit, 20)
+	}
+	c.Query.PostLikers = func(childComplexity int, _ uuid.UUID, first *int32, _ *string) int {
+		return page(childComplexity, first, 20)
+	}
+	c.Query.LikedPosts = func(childComplexity int, _ uuid.UUID, first *int32, _ *string) int {
+		return page(childComplexity, first, 20)
+	}
+	c.Query.AuditLog = func(childComplexity int, _ *uuid.UUID, _ *string, _ *string, _ []string, _ *string, _ *string, _ *string, _ *string, first *int32, _ *string) int {
+		return page(childComplexity, first, 20)
+	}
+
+	c.Post.Comments = func(childComplexity int, first *int32, _ *string) int {
+		return page(childComplexity, first, 5)
+	}
+	c.Comment.Replies = func(childComplexity int, first *int32, _ *string) int {
+		return page(childComplexity, first, 5)
+	}
+	c.Post.EditHistory = func(childComplexity int, first *int32, _ *string) int {
+		return page(childComplexity, first, 10)
+	}
+
+	// Looked up per post
+	c.Post.IsLiked = serviceCall
+	c.Post.IsReposted = serviceCall
+	c.Post.QuotedPost = serviceCall
+	c.Post.Poll = serviceCall
+	c.Query.GetPostLikes = func(childComplexity int, _ uuid.UUID) int {
+		return serviceCallCost + childComplexity
+	}
+
+	return c
+}
+
+// page is the cost of a paginated field returning up to first elements,
+// defaultFirst when not given
+func page(childComplexity int, first *int32, defaultFirst int) int {
+	n := defaultFirst
+	if first != nil && *first > 0 {
+		n = int(*first)
+	}
+	return serviceCallCost + n*childComplexity
+}
+
+func serviceCall(childComplexity int) int {
+	return serviceCallCost + childComplexity
+}
@@ -0,0 +1,76 @@
+package graph
+
+import (
+	"context"
+	"strings"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// DepthLimit rejects operations that nest selections deeper than Max, such
+// as posts quoting posts quoting posts, before any resolver runs. Fragments
+// count as the selections they spread; introspection fields are not counted.
+type DepthLimit struct {
+	Max int
+}
+
+var _ interface {
+	graphql.HandlerExtension
+	graphql.OperationContextMutator
+} = DepthLimit{}
+
+func (DepthLimit) ExtensionName() string {
+	return "DepthLimit"
+}
+
+func (DepthLimit) Validate(graphql.ExecutableSchema) error {
+	return nil
+}
+
+func (d DepthLimit) MutateOperationContext(ctx context.Context, oc *graphql.OperationContext) *gqlerror.Error {
+	if d.Max <= 0 || oc.Operation == nil {
+		return nil
+	}
+
+	depth := selectionDepth(oc.Operation.SelectionSet, oc.Doc.Fragments, d.Max+1)
+	if depth <= d.Max {
+		return nil
+	}
+
+	err := gqlerror.Errorf("operation is nested deeper than the limit of %d", d.Max)
+	err.Extensions = map[string]any{
+		"code": "DEPTH_LIMIT_EXCEEDED",
+	}
+	return err
+}
+
+// selectionDepth returns the depth of the deepest field in set, stopping once
+// it reaches limit. Operations have been validated, so fragments do not
+// spread themselves.
+func selectionDepth(set ast.SelectionSet, fragments ast.FragmentDefinitionList, limit int) int {
+	if limit <= 0 {
+		return 0
+	}
+
+	deepest := 0
+	for _, sel := range set {
+		var depth int
+		switch sel := sel.(type) {
+		case *ast.Field:
+			if strings.HasPrefix(sel.Name, "__") {
+				continue
+			}
+			depth = 1 + selectionDepth(sel.SelectionSet, fragments, limit-1)
+		case *ast.InlineFragment:
+			depth = selectionDepth(sel.SelectionSet, fragments, limit)
+		case *ast.FragmentSpread:
+			if fragment := fragments.ForName(sel.Name); fragment != nil {
+				depth = selectionDepth(fragment.SelectionSet, fragments, limit)
+			}
+		}
+		deepest = max(deepest, depth)
+	}
+	return deepest
+}
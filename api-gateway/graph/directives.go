@@ -0,0 +1,45 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+
+	"api-gateway/auth"
+	"api-gateway/graph/model"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// Directives enforces @auth and @hasRole against the principal the auth
+// middleware put in the request context
+func Directives() DirectiveRoot {
+	return DirectiveRoot{
+		Auth:    authDirective,
+		HasRole: hasRoleDirective,
+	}
+}
+
+func authDirective(ctx context.Context, obj any, next graphql.Resolver) (any, error) {
+	if _, ok := auth.FromContext(ctx); !ok {
+		return nil, auth.ErrUnauthenticated
+	}
+	return next(ctx)
+}
+
+func hasRoleDirective(ctx context.Context, obj any, next graphql.Resolver, roles []model.Role) (any, error) {
+	principal, ok := auth.FromContext(ctx)
+	if !ok {
+		return nil, auth.ErrUnauthenticated
+	}
+
+	for _, role := range roles {
+		if principal.HasRole(string(role)) {
+			return next(ctx)
+		}
+	}
+	return nil, &gqlerror.Error{
+		Message:    fmt.Sprintf("one of the roles %v is required", roles),
+		Extensions: map[string]any{"code": codePermissionDenied},
+	}
+}
@@ -0,0 +1,116 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strings"
+	"unicode"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"api-gateway/auth"
+	"shared/logging"
+)
+
+// Codes of the gateway's own errors. Errors of backends carry the reason of
+// their ErrorInfo, which uses the same names for the same codes.
+const (
+	codeUnauthenticated  = "UNAUTHENTICATED"
+	codePermissionDenied = "PERMISSION_DENIED"
+	codeInvalidArgument  = "INVALID_ARGUMENT"
+)
+
+// ErrorPresenter gives errors a machine-readable code in extensions.code and
+// passes on the details of backend errors:
+//
+//	code             the reason of the ErrorInfo, e.g. NOT_FOUND or
+//	                 ACCOUNT_LOCKED; the name of the gRPC code without one
+//	fieldViolations  the invalid fields of a BadRequest, as {field, description}
+//	retryAfter       the delay of a RetryInfo, in seconds
+//
+// e.g. {"code": "ACCOUNT_LOCKED", "retryAfter": 540} for a login refused by a
+// lockout, so clients can say when to try again. Backend errors show the
+// backend's message instead of "rpc error: code = ... desc = ...". Internal
+// errors are logged and shown without their message, which may hold
+// database errors.
+func ErrorPresenter(ctx context.Context, err error) *gqlerror.Error {
+	presented := graphql.DefaultErrorPresenter(ctx, err)
+
+	if errors.Is(err, auth.ErrUnauthenticated) {
+		setExtension(presented, "code", codeUnauthenticated)
+		return presented
+	}
+
+	var grpcErr interface{ GRPCStatus() *status.Status }
+	if !errors.As(err, &grpcErr) {
+		return presented
+	}
+	st := grpcErr.GRPCStatus()
+
+	switch st.Code() {
+	case codes.Internal, codes.Unknown, codes.DataLoss:
+		logging.FromContext(ctx).Error().Err(err).Msg("backend call failed")
+		presented.Message = "internal error"
+	default:
+		presented.Message = strings.Replace(presented.Message, st.Err().Error(), st.Message(), 1)
+	}
+
+	setExtension(presented, "code", reasonOf(st.Code()))
+	for _, detail := range st.Details() {
+		switch detail := detail.(type) {
+		case *errdetails.ErrorInfo:
+			setExtension(presented, "code", detail.Reason)
+		case *errdetails.BadRequest:
+			violations := make([]map[string]any, len(detail.FieldViolations))
+			for i, v := range detail.FieldViolations {
+				violations[i] = map[string]any{"field": v.Field, "description": v.Description}
+			}
+			setExtension(presented, "fieldViolations", violations)
+		case *errdetails.RetryInfo:
+			retryAfter := int(math.Ceil(detail.RetryDelay.AsDuration().Seconds()))
+			setExtension(presented, "retryAfter", retryAfter)
+		}
+	}
+	return presented
+}
+
+// invalidInput is a resolver's error for an argument it cannot use, shaped
+// like the field violations of backend errors
+func invalidInput(field, description string) *gqlerror.Error {
+	return &gqlerror.Error{
+		Message: description,
+		Extensions: map[string]any{
+			"code": codeInvalidArgument,
+			"fieldViolations": []map[string]any{
+				{"field": field, "description": description},
+			},
+		},
+	}
+}
+
+// reasonOf is the code of backend errors without an ErrorInfo: the name of
+// their gRPC code, e.g. UNAVAILABLE when a backend cannot be reached
+func reasonOf(code codes.Code) string {
+	var b strings.Builder
+	prev := ' '
+	for _, r := range code.String() {
+		if unicode.IsUpper(r) && unicode.IsLower(prev) {
+			b.WriteByte('_')
+		}
+		b.WriteRune(unicode.ToUpper(r))
+		prev = r
+	}
+	return b.String()
+}
+
+func setExtension(err *gqlerror.Error, key string, value any) {
+	if err.Extensions == nil {
+		err.Extensions = map[string]any{}
+	}
+	err.Extensions[key] = value
+}
@@ -2,20 +2,48 @@ package helpers
 
 import (
 	"api-gateway/graph/model"
-	"context"
-	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
-	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
+	auditpb "audit-service/pb"
 	commentpb "comment-service/pb"
 	followpb "follow-service/pb"
+	moderationpb "moderation-service/pb"
 	notificationpb "notification-service/pb"
 	postpb "post-service/pb"
 	userpb "user-service/pb"
 )
 
+// mediaBaseURL prefixes media IDs to form Media.url
+var mediaBaseURL = "/media"
+
+// SetMediaBaseURL sets the public URL the gateway serves media under
+func SetMediaBaseURL(url string) {
+	mediaBaseURL = strings.TrimRight(url, "/")
+}
+
+// profileImageBaseURL prefixes profile image IDs to form their URLs
+var profileImageBaseURL = "/profile-images"
+
+// SetProfileImageBaseURL sets the public URL the gateway serves avatars and
+// cover images under
+func SetProfileImageBaseURL(url string) {
+	profileImageBaseURL = strings.TrimRight(url, "/")
+}
+
+// ProfileImageURL is the URL of a profile image, nil when none is set. Each
+// new image has a new ID, so the URL changes whenever the image does.
+func ProfileImageURL(id *string) *string {
+	if id == nil || *id == "" {
+		return nil
+	}
+	url := profileImageBaseURL + "/" + *id
+	return &url
+}
+
 // NotificationAdded is the resolver for the notificationAdded field.
 func ParseUUIDPtr(s string) *uuid.UUID {
 	if s == "" {
@@ -28,22 +56,19 @@ func ParseUUIDPtr(s string) *uuid.UUID {
 	return &id
 }
 
-func GetTokenFromContext(ctx context.Context) string {
-	token, ok := ctx.Value("token").(string)
-	if !ok || token == "" {
-		return ""
+func StringPtr(s string) *string {
+	if s == "" {
+		return nil
 	}
-	return token
-}
-
-func AddTokenToContext(ctx context.Context, token string) context.Context {
-	return metadata.AppendToOutgoingContext(ctx, "authorization", token)
+	return &s
 }
 
-func StringPtr(s string) *string {
-	if s == "" {
+// TimestampPtr formats an optional timestamp, returning nil when it is unset
+func TimestampPtr(ts *timestamppb.Timestamp) *string {
+	if ts == nil {
 		return nil
 	}
+	s := ts.AsTime().Format(time.RFC3339)
 	return &s
 }
 
@@ -74,7 +99,7 @@ func buildPostConnection(posts []*postpb.Post, limit int) *model.PostConnection
 	for i, p := range posts {
 		edges[i] = &model.PostEdge{
 			Cursor: p.CreatedAt.AsTime().Format(time.RFC3339),
-			Node:   protoPostToModel(p),
+			Node:   ProtoPostToModel(p),
 		}
 	}
 
@@ -169,29 +194,29 @@ func buildFollowConnection(edgesData []*followpb.FollowEdge, limit int) *model.F
 	}
 }
 
-func BuildNotificationConnection(notifications []*notificationpb.Notification, unreadCount int32, limit int) *model.NotificationConnection {
-	hasNextPage := len(notifications) > limit
+func BuildNotificationConnection(notificationEdges []*notificationpb.NotificationEdge, unreadCount int32, limit int) *model.NotificationConnection {
+	hasNextPage := len(notificationEdges) > limit
 	if hasNextPage {
-		notifications = notifications[:limit]
+		notificationEdges = notificationEdges[:limit]
 	}
 
-	edges := make([]*model.NotificationEdge, len(notifications))
-	totalCount := len(notifications)
+	edges := make([]*model.NotificationEdge, len(notificationEdges))
+	totalCount := len(notificationEdges)
 
-	for i, n := range notifications {
+	for i, e := range notificationEdges {
 		edges[i] = &model.NotificationEdge{
-			Cursor: n.CreatedAt.AsTime().Format(time.RFC3339),
-			Node:   protoNotificationToModel(n),
+			Cursor: e.Cursor,
+			Node:   protoNotificationToModel(e.Node),
 		}
 	}
 
 	var endCursor *string
 	var startCursor *string
-	if len(notifications) > 0 {
-		startStr := notifications[0].CreatedAt.AsTime().Format(time.RFC3339)
+	if len(notificationEdges) > 0 {
+		startStr := notificationEdges[0].Cursor
 		startCursor = &startStr
 
-		lastStr := notifications[len(notifications)-1].CreatedAt.AsTime().Format(time.RFC3339)
+		lastStr := notificationEdges[len(notificationEdges)-1].Cursor
 		endCursor = &lastStr
 	}
 
@@ -209,7 +234,7 @@ func BuildNotificationConnection(notifications []*notificationpb.Notification, u
 }
 
 // Converts gRPC post response to GraphQL model
-func protoPostToModel(p *postpb.Post) *model.Post {
+func ProtoPostToModel(p *postpb.Post) *model.Post {
 	if p == nil {
 		return nil
 	}
@@ -225,10 +250,111 @@ func protoPostToModel(p *postpb.Post) *model.Post {
 		UpdatedAt:     p.UpdatedAt.String(),
 		LikesCount:    p.LikesCount,
 		CommentsCount: p.CommentsCount,
+		RepostsCount:  p.RepostsCount,
 		IsLiked:       p.IsLiked,
+		IsReposted:    p.IsReposted,
+		Mentions:      ProtoPostMentionsToModel(p.Mentions),
+		Media:         ProtoMediaToModel(p.Media),
+		QuotedPost:    ProtoPostToModel(p.QuotedPost),
+		DeletedAt:     TimestampPtr(p.DeletedAt),
+		IsEdited:      p.EditedAt != nil,
+		Status:        ProtoPostStatusToModel(p.Status),
+		PublishAt:     TimestampPtr(p.PublishAt),
+		Visibility:    ProtoPostVisibilityToModel(p.Visibility),
+	}
+}
+
+// ProtoPostStatusToModel converts a post status; posts from before statuses
+// existed are published
+func ProtoPostStatusToModel(s postpb.PostStatus) model.PostStatus {
+	if s == postpb.PostStatus_POST_STATUS_UNSPECIFIED {
+		return model.PostStatusPublished
+	}
+	return model.PostStatus(strings.TrimPrefix(s.String(), "POST_STATUS_"))
+}
+
+// ProtoPostVisibilityToModel converts a post visibility; posts from before
+// visibilities existed are public
+func ProtoPostVisibilityToModel(v postpb.PostVisibility) model.PostVisibility {
+	if v == postpb.PostVisibility_POST_VISIBILITY_UNSPECIFIED {
+		return model.PostVisibilityPublic
+	}
+	return model.PostVisibility(strings.TrimPrefix(v.String(), "POST_VISIBILITY_"))
+}
+
+// FeedPostVisibilityToModel converts the visibility of a feed entry
+func FeedPostVisibilityToModel(v string) model.PostVisibility {
+	if v == "" {
+		return model.PostVisibilityPublic
+	}
+	return model.PostVisibility(v)
+}
+
+// PostVisibilityToProto converts a requested visibility, leaving the default
+// to post-service when none is given
+func PostVisibilityToProto(v *model.PostVisibility) postpb.PostVisibility {
+	if v == nil {
+		return postpb.PostVisibility_POST_VISIBILITY_UNSPECIFIED
+	}
+	return postpb.PostVisibility(postpb.PostVisibility_value["POST_VISIBILITY_"+string(*v)])
+}
+
+// ProtoPollToModel converts the poll of a post
+func ProtoPollToModel(p *postpb.Poll) *model.Poll {
+	options := make([]*model.PollOption, len(p.Options))
+	for i, o := range p.Options {
+		options[i] = &model.PollOption{
+			ID:         uuid.MustParse(o.Id),
+			Text:       o.Text,
+			VotesCount: o.VotesCount,
+		}
+	}
+
+	var votedOptionID *uuid.UUID
+	if p.VotedOptionId != nil {
+		votedOptionID = ParseUUIDPtr(*p.VotedOptionId)
+	}
+
+	return &model.Poll{
+		PostID:        uuid.MustParse(p.PostId),
+		Options:       options,
+		ExpiresAt:     p.ExpiresAt.AsTime().Format(time.RFC3339),
+		IsExpired:     p.IsExpired,
+		TotalVotes:    p.TotalVotes,
+		VotedOptionID: votedOptionID,
 	}
 }
 
+// ProtoMediaToModel converts the attachments of a post, never returning nil
+func ProtoMediaToModel(media []*postpb.Media) []*model.Media {
+	result := make([]*model.Media, 0, len(media))
+	for _, m := range media {
+		result = append(result, ProtoUploadToModel(m))
+	}
+	return result
+}
+
+// ProtoUploadToModel converts a single upload
+func ProtoUploadToModel(m *postpb.Media) *model.Media {
+	id, _ := uuid.Parse(m.Id)
+	return &model.Media{
+		ID:        id,
+		URL:       mediaBaseURL + "/" + m.Id,
+		MimeType:  m.MimeType,
+		SizeBytes: int32(m.SizeBytes),
+	}
+}
+
+// ProtoPostMentionsToModel converts the mentions of a post, never returning nil
+func ProtoPostMentionsToModel(mentions []*postpb.Mention) []*model.Mention {
+	result := make([]*model.Mention, 0, len(mentions))
+	for _, m := range mentions {
+		userID, _ := uuid.Parse(m.UserId)
+		result = append(result, &model.Mention{UserID: userID, Username: m.Username})
+	}
+	return result
+}
+
 // Converts gRPC comment response to GraphQL model
 func protoCommentToModel(c *commentpb.Comment) *model.Comment {
 	if c == nil {
@@ -240,13 +366,27 @@ func protoCommentToModel(c *commentpb.Comment) *model.Comment {
 	userID, _ := uuid.Parse(c.UserId)
 
 	return &model.Comment{
-		ID:        id,
-		PostID:    postID,
-		UserID:    userID,
-		Content:   c.Content,
-		CreatedAt: c.CreatedAt.String(),
-		UpdatedAt: c.UpdatedAt.String(),
+		ID:              id,
+		PostID:          postID,
+		UserID:          userID,
+		Content:         c.Content,
+		Mentions:        ProtoCommentMentionsToModel(c.Mentions),
+		ParentCommentID: ParseUUIDPtr(c.GetParentCommentId()),
+		RepliesCount:    c.RepliesCount,
+		CreatedAt:       c.CreatedAt.String(),
+		UpdatedAt:       c.UpdatedAt.String(),
+		DeletedAt:       TimestampPtr(c.DeletedAt),
+	}
+}
+
+// ProtoCommentMentionsToModel converts the mentions of a comment, never returning nil
+func ProtoCommentMentionsToModel(mentions []*commentpb.Mention) []*model.Mention {
+	result := make([]*model.Mention, 0, len(mentions))
+	for _, m := range mentions {
+		userID, _ := uuid.Parse(m.UserId)
+		result = append(result, &model.Mention{UserID: userID, Username: m.Username})
 	}
+	return result
 }
 
 func protoNotificationToModel(n *notificationpb.Notification) *model.Notification {
@@ -285,7 +425,22 @@ func protoNotificationToModel(n *notificationpb.Notification) *model.Notificatio
 		}
 	}
 
-	notifType := model.NotificationType(n.Type)
+	notifType := model.NotificationType(n.Type.String())
+
+	var group *model.NotificationGroup
+	if n.Group != nil {
+		latestActorIDs := make([]uuid.UUID, 0, len(n.Group.LatestActorIds))
+		for _, raw := range n.Group.LatestActorIds {
+			if aid, err := uuid.Parse(raw); err == nil {
+				latestActorIDs = append(latestActorIDs, aid)
+			}
+		}
+		group = &model.NotificationGroup{
+			Key:            n.Group.GroupKey,
+			ActorCount:     n.Group.ActorCount,
+			LatestActorIds: latestActorIDs,
+		}
+	}
 
 	return &model.Notification{
 		ID:        id,
@@ -296,6 +451,7 @@ func protoNotificationToModel(n *notificationpb.Notification) *model.Notificatio
 		RelatedID: relatedID,
 		IsRead:    n.IsRead,
 		CreatedAt: n.CreatedAt.String(),
+		Group:     group,
 	}
 }
 
@@ -303,17 +459,6 @@ func protoNotificationToModel(n *notificationpb.Notification) *model.Notificatio
 // Cursor-Based Pagination Resolvers
 // --------------------
 
-func ParseCursor(after *string) (*time.Time, error) {
-	if after == nil || *after == "" {
-		return nil, nil
-	}
-	t, err := time.Parse(time.RFC3339, *after)
-	if err != nil {
-		return nil, fmt.Errorf("invalid cursor: %w", err)
-	}
-	return &t, nil
-}
-
 // Converts gRPC user response to GraphQL model
 func ProtoUserToModel(u *userpb.User) *model.User {
 	if u == nil {
@@ -330,7 +475,7 @@ func ProtoUserToModel(u *userpb.User) *model.User {
 	return &model.User{
 		ID:             id,
 		Username:       u.Username,
-		Email:          u.Email,
+		Email:          StringPtr(u.Email),
 		Bio:            u.Bio,
 		CreatedAt:      u.CreatedAt.AsTime().Format(time.RFC3339),
 		UpdatedAt:      u.UpdatedAt.AsTime().Format(time.RFC3339),
@@ -338,5 +483,274 @@ func ProtoUserToModel(u *userpb.User) *model.User {
 		FollowingCount: int32(u.FollowingCount),
 		PostsCount:     int32(u.PostsCount),
 		IsFollowing:    isFollowing,
+		IsPrivate:      u.IsPrivate,
+		AvatarURL:      ProfileImageURL(u.AvatarId),
+		CoverURL:       ProfileImageURL(u.CoverId),
+		DisplayName:    u.DisplayName,
+		Location:       u.Location,
+		Website:        u.Website,
+		BirthDate:      u.BirthDate,
+		Pronouns:       u.Pronouns,
+		HideEmail:      u.HideEmail,
+		HideBirthDate:  u.HideBirthDate,
+	}
+}
+
+var profileImageKinds = map[userpb.ProfileImageKind]model.ProfileImageKind{
+	userpb.ProfileImageKind_PROFILE_IMAGE_KIND_AVATAR: model.ProfileImageKindAvatar,
+	userpb.ProfileImageKind_PROFILE_IMAGE_KIND_COVER:  model.ProfileImageKindCover,
+}
+
+var profileImageStatuses = map[userpb.ProfileImageStatus]model.ProfileImageStatus{
+	userpb.ProfileImageStatus_PROFILE_IMAGE_STATUS_PENDING: model.ProfileImageStatusPending,
+	userpb.ProfileImageStatus_PROFILE_IMAGE_STATUS_READY:   model.ProfileImageStatusReady,
+	userpb.ProfileImageStatus_PROFILE_IMAGE_STATUS_FAILED:  model.ProfileImageStatusFailed,
+}
+
+func ProtoProfileImageToModel(img *userpb.ProfileImage) *model.ProfileImage {
+	id, _ := uuid.Parse(img.Id)
+	return &model.ProfileImage{
+		ID:        id,
+		Kind:      profileImageKinds[img.Kind],
+		Status:    profileImageStatuses[img.Status],
+		URL:       profileImageBaseURL + "/" + img.Id,
+		CreatedAt: img.CreatedAt.AsTime().Format(time.RFC3339),
+	}
+}
+
+// webhookEventTypes maps GraphQL webhook event enums to the notification-service event names
+var webhookEventTypes = map[model.WebhookEventType]string{
+	model.WebhookEventTypePostCreated:    "post.created",
+	model.WebhookEventTypeCommentAdded:   "comment.added",
+	model.WebhookEventTypeUserFollowed:   "user.followed",
+	model.WebhookEventTypeUserUnfollowed: "user.unfollowed",
+}
+
+func WebhookEventTypeToProto(t model.WebhookEventType) string {
+	return webhookEventTypes[t]
+}
+
+func ProtoWebhookEventTypeToModel(s string) model.WebhookEventType {
+	for t, name := range webhookEventTypes {
+		if name == s {
+			return t
+		}
+	}
+	return model.WebhookEventType(s)
+}
+
+// Converts gRPC webhook response to GraphQL model
+func ProtoWebhookToModel(w *notificationpb.Webhook) *model.Webhook {
+	if w == nil {
+		return nil
+	}
+
+	id, _ := uuid.Parse(w.Id)
+
+	eventTypes := make([]model.WebhookEventType, len(w.EventTypes))
+	for i, t := range w.EventTypes {
+		eventTypes[i] = ProtoWebhookEventTypeToModel(t)
+	}
+
+	return &model.Webhook{
+		ID:         id,
+		URL:        w.Url,
+		EventTypes: eventTypes,
+		IsActive:   w.IsActive,
+		Secret:     w.Secret,
+		CreatedAt:  w.CreatedAt.AsTime().Format(time.RFC3339),
+	}
+}
+
+// Converts gRPC webhook delivery response to GraphQL model
+func ProtoWebhookDeliveryToModel(d *notificationpb.WebhookDelivery) *model.WebhookDelivery {
+	if d == nil {
+		return nil
+	}
+
+	id, _ := uuid.Parse(d.Id)
+	eventID, _ := uuid.Parse(d.EventId)
+
+	return &model.WebhookDelivery{
+		ID:         id,
+		EventID:    eventID,
+		EventType:  ProtoWebhookEventTypeToModel(d.EventType),
+		Attempt:    d.Attempt,
+		StatusCode: d.StatusCode,
+		Success:    d.Success,
+		Error:      d.Error,
+		DurationMs: int32(d.DurationMs),
+		CreatedAt:  d.CreatedAt.AsTime().Format(time.RFC3339),
+	}
+}
+
+func DevicePlatformToProto(p model.DevicePlatform) notificationpb.DevicePlatform {
+	return notificationpb.DevicePlatform(notificationpb.DevicePlatform_value[string(p)])
+}
+
+func NotificationTypeToProto(t model.NotificationType) notificationpb.NotificationType {
+	return notificationpb.NotificationType(notificationpb.NotificationType_value[string(t)])
+}
+
+// Converts gRPC device response to GraphQL model
+func ProtoDeviceToModel(d *notificationpb.Device) *model.Device {
+	if d == nil {
+		return nil
+	}
+
+	id, _ := uuid.Parse(d.Id)
+
+	return &model.Device{
+		ID:         id,
+		Platform:   model.DevicePlatform(d.Platform.String()),
+		Token:      d.Token,
+		CreatedAt:  d.CreatedAt.AsTime().Format(time.RFC3339),
+		LastSeenAt: d.LastSeenAt.AsTime().Format(time.RFC3339),
+	}
+}
+
+// Converts gRPC push preferences response to GraphQL model
+func ProtoPushPreferencesToModel(p *notificationpb.PushPreferences) []*model.PushPreference {
+	preferences := make([]*model.PushPreference, len(p.Preferences))
+	for i, pref := range p.Preferences {
+		preferences[i] = &model.PushPreference{
+			Type:    model.NotificationType(pref.Type.String()),
+			Enabled: pref.Enabled,
+		}
+	}
+	return preferences
+}
+
+// Converts gRPC notification preferences response to GraphQL model
+func ProtoNotificationPreferencesToModel(p *notificationpb.NotificationPreferences) *model.NotificationPreferences {
+	disabledTypes := make([]model.NotificationType, len(p.DisabledTypes))
+	for i, t := range p.DisabledTypes {
+		disabledTypes[i] = model.NotificationType(t.String())
+	}
+
+	var quietHours *model.QuietHours
+	if p.QuietHours != nil {
+		quietHours = &model.QuietHours{
+			Start:    p.QuietHours.Start,
+			End:      p.QuietHours.End,
+			TimeZone: p.QuietHours.TimeZone,
+		}
+	}
+
+	var updatedAt *string
+	if p.UpdatedAt != nil {
+		t := p.UpdatedAt.AsTime().Format(time.RFC3339)
+		updatedAt = &t
+	}
+
+	return &model.NotificationPreferences{
+		DisabledTypes: disabledTypes,
+		QuietHours:    quietHours,
+		UpdatedAt:     updatedAt,
+	}
+}
+
+// ModerationTargetToProto converts the kind of a report or moderation target
+func ModerationTargetToProto(t model.ModerationTarget) moderationpb.TargetType {
+	return moderationpb.TargetType(moderationpb.TargetType_value["TARGET_TYPE_"+string(t)])
+}
+
+// ReportReasonToProto converts the reason a report is filed for
+func ReportReasonToProto(r model.ReportReason) moderationpb.ReportReason {
+	return moderationpb.ReportReason(moderationpb.ReportReason_value["REPORT_REASON_"+string(r)])
+}
+
+// ProtoReportToModel converts a report filed with moderation-service
+func ProtoReportToModel(r *moderationpb.Report) *model.Report {
+	if r == nil {
+		return nil
+	}
+
+	id, _ := uuid.Parse(r.Id)
+	targetID, _ := uuid.Parse(r.TargetId)
+
+	report := &model.Report{
+		ID:              id,
+		TargetType:      model.ModerationTarget(strings.TrimPrefix(r.TargetType.String(), "TARGET_TYPE_")),
+		TargetID:        targetID,
+		Reason:          model.ReportReason(strings.TrimPrefix(r.Reason.String(), "REPORT_REASON_")),
+		Details:         r.Details,
+		Status:          model.ReportStatus(strings.TrimPrefix(r.Status.String(), "REPORT_STATUS_")),
+		CreatedAt:       r.CreatedAt.AsTime().Format(time.RFC3339),
+		ReviewStartedAt: TimestampPtr(r.ReviewStartedAt),
+		ResolutionNote:  r.ResolutionNote,
+		ResolvedAt:      TimestampPtr(r.ResolvedAt),
+	}
+	if r.ReporterId != nil {
+		report.ReporterID = ParseUUIDPtr(*r.ReporterId)
+	}
+	if r.ReviewerId != nil {
+		report.ReviewerID = ParseUUIDPtr(*r.ReviewerId)
+	}
+	if r.Resolution != moderationpb.ReportResolution_REPORT_RESOLUTION_UNSPECIFIED {
+		resolution := model.ReportResolution(strings.TrimPrefix(r.Resolution.String(), "REPORT_RESOLUTION_"))
+		report.Resolution = &resolution
+	}
+	if r.ResolvedBy != nil {
+		report.ResolvedBy = ParseUUIDPtr(*r.ResolvedBy)
+	}
+	if r.ActionId != nil {
+		report.ActionID = ParseUUIDPtr(*r.ActionId)
+	}
+	return report
+}
+
+// ProtoModerationActionToModel converts an audit log entry of moderation-service
+func ProtoModerationActionToModel(a *moderationpb.ModerationAction) *model.ModerationAction {
+	if a == nil {
+		return nil
+	}
+
+	id, _ := uuid.Parse(a.Id)
+	adminID, _ := uuid.Parse(a.AdminId)
+	targetID, _ := uuid.Parse(a.TargetId)
+
+	return &model.ModerationAction{
+		ID:              id,
+		AdminID:         adminID,
+		Action:          model.ModerationActionType(strings.TrimPrefix(a.Action.String(), "ACTION_TYPE_")),
+		TargetType:      model.ModerationTarget(strings.TrimPrefix(a.TargetType.String(), "TARGET_TYPE_")),
+		TargetID:        targetID,
+		Reason:          a.Reason,
+		SuspendedUntil:  TimestampPtr(a.SuspendedUntil),
+		ReportsResolved: a.ReportsResolved,
+		CreatedAt:       a.CreatedAt.AsTime().Format(time.RFC3339),
+	}
+}
+
+// ProtoAuditEntryToModel converts an operation recorded by audit-service
+func ProtoAuditEntryToModel(e *auditpb.AuditEntry) *model.AuditEntry {
+	if e == nil {
+		return nil
+	}
+
+	id, _ := uuid.Parse(e.Id)
+
+	entry := &model.AuditEntry{
+		ID:         id,
+		Service:    e.Service,
+		Action:     e.Action,
+		TargetType: e.TargetType,
+		TargetID:   e.TargetId,
+		IPAddress:  e.IpAddress,
+		UserAgent:  e.UserAgent,
+		Changes:    make([]*model.FieldChange, len(e.Changes)),
+		OccurredAt: e.OccurredAt.AsTime().Format(time.RFC3339),
+	}
+	if e.ActorId != nil {
+		entry.ActorID = ParseUUIDPtr(*e.ActorId)
+	}
+	for i, c := range e.Changes {
+		entry.Changes[i] = &model.FieldChange{
+			Field:    c.Field,
+			OldValue: c.OldValue,
+			NewValue: c.NewValue,
+		}
 	}
+	return entry
 }
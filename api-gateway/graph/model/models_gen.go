@@ -11,6 +11,34 @@ import (
 	"github.com/google/uuid"
 )
 
+type AuditEntry struct {
+	ID uuid.UUID `json:"id"`
+	// Service that performed the operation
+	Service string `json:"service"`
+	// e.g. LOGIN, PASSWORD_CHANGED, ROLES_CHANGED, PROFILE_UPDATED
+	Action string `json:"action"`
+	// Null when nobody was signed in, e.g. for a failed login
+	ActorID *uuid.UUID `json:"actorId,omitempty"`
+	Actor   *User      `json:"actor,omitempty"`
+	// e.g. USER, EMAIL, SIGNING_KEY
+	TargetType string         `json:"targetType"`
+	TargetID   string         `json:"targetId"`
+	IPAddress  string         `json:"ipAddress"`
+	UserAgent  string         `json:"userAgent"`
+	Changes    []*FieldChange `json:"changes"`
+	OccurredAt string         `json:"occurredAt"`
+}
+
+type AuditEntryConnection struct {
+	Edges    []*AuditEntryEdge `json:"edges"`
+	PageInfo *PageInfo         `json:"pageInfo"`
+}
+
+type AuditEntryEdge struct {
+	Cursor string      `json:"cursor"`
+	Node   *AuditEntry `json:"node"`
+}
+
 type AuthResponse struct {
 	AccessToken  string  `json:"accessToken"`
 	RefreshToken string  `json:"refreshToken"`
@@ -25,13 +53,19 @@ type ChangePasswordInput struct {
 }
 
 type Comment struct {
-	ID        uuid.UUID `json:"id"`
-	PostID    uuid.UUID `json:"postId"`
-	UserID    uuid.UUID `json:"userId"`
-	User      *User     `json:"user"`
-	Content   string    `json:"content"`
-	CreatedAt string    `json:"createdAt"`
-	UpdatedAt string    `json:"updatedAt"`
+	ID              uuid.UUID          `json:"id"`
+	PostID          uuid.UUID          `json:"postId"`
+	UserID          uuid.UUID          `json:"userId"`
+	User            *User              `json:"user"`
+	Author          *User              `json:"author,omitempty"`
+	Content         string             `json:"content"`
+	Mentions        []*Mention         `json:"mentions"`
+	ParentCommentID *uuid.UUID         `json:"parentCommentId,omitempty"`
+	RepliesCount    int32              `json:"repliesCount"`
+	Replies         *CommentConnection `json:"replies"`
+	CreatedAt       string             `json:"createdAt"`
+	UpdatedAt       string             `json:"updatedAt"`
+	DeletedAt       *string            `json:"deletedAt,omitempty"`
 }
 
 type CommentConnection struct {
@@ -46,12 +80,33 @@ type CommentEdge struct {
 }
 
 type CreateCommentInput struct {
-	PostID  uuid.UUID `json:"postId"`
-	Content string    `json:"content"`
+	PostID          uuid.UUID  `json:"postId"`
+	Content         string     `json:"content"`
+	ParentCommentID *uuid.UUID `json:"parentCommentId,omitempty"`
 }
 
 type CreatePostInput struct {
-	Content string `json:"content"`
+	Content      string          `json:"content"`
+	MediaIds     []uuid.UUID     `json:"mediaIds,omitempty"`
+	QuotedPostID *uuid.UUID      `json:"quotedPostId,omitempty"`
+	Visibility   *PostVisibility `json:"visibility,omitempty"`
+	Poll         *PollInput      `json:"poll,omitempty"`
+}
+
+type Device struct {
+	ID         uuid.UUID      `json:"id"`
+	Platform   DevicePlatform `json:"platform"`
+	Token      string         `json:"token"`
+	CreatedAt  string         `json:"createdAt"`
+	LastSeenAt string         `json:"lastSeenAt"`
+}
+
+// A field an audited operation changed. Values of secret fields, such as
+// passwords, are null.
+type FieldChange struct {
+	Field    string  `json:"field"`
+	OldValue *string `json:"oldValue,omitempty"`
+	NewValue *string `json:"newValue,omitempty"`
 }
 
 type FollowConnection struct {
@@ -66,6 +121,28 @@ type FollowEdge struct {
 	FollowedAt string `json:"followedAt"`
 }
 
+// The current user's relation to another user, as follow-service holds it
+type FollowStatus struct {
+	UserID         uuid.UUID `json:"userId"`
+	IsFollowing    bool      `json:"isFollowing"`
+	FollowersCount int32     `json:"followersCount"`
+	FollowingCount int32     `json:"followingCount"`
+}
+
+type FollowSuggestionConnection struct {
+	Edges    []*FollowSuggestionEdge `json:"edges"`
+	PageInfo *PageInfo               `json:"pageInfo"`
+}
+
+type FollowSuggestionEdge struct {
+	Cursor string `json:"cursor"`
+	Node   *User  `json:"node"`
+	// Users the current user follows who follow this user
+	MutualFollows int32 `json:"mutualFollows"`
+	// Accounts both the current user and this user follow
+	SharedInterests int32 `json:"sharedInterests"`
+}
+
 type HealthCheckResponse struct {
 	Status    string           `json:"status"`
 	Timestamp string           `json:"timestamp"`
@@ -78,14 +155,74 @@ type LikeInfo struct {
 	RecentLikers         []*User `json:"recentLikers"`
 }
 
+type LikeStatus struct {
+	PostID  uuid.UUID `json:"postId"`
+	IsLiked bool      `json:"isLiked"`
+}
+
+type LikedPostConnection struct {
+	Edges      []*LikedPostEdge `json:"edges"`
+	PageInfo   *PageInfo        `json:"pageInfo"`
+	TotalCount int32            `json:"totalCount"`
+}
+
+type LikedPostEdge struct {
+	Cursor  string `json:"cursor"`
+	Node    *Post  `json:"node"`
+	LikedAt string `json:"likedAt"`
+}
+
+type LikerConnection struct {
+	Edges      []*LikerEdge `json:"edges"`
+	PageInfo   *PageInfo    `json:"pageInfo"`
+	TotalCount int32        `json:"totalCount"`
+}
+
+type LikerEdge struct {
+	Cursor  string `json:"cursor"`
+	Node    *User  `json:"node"`
+	LikedAt string `json:"likedAt"`
+}
+
 type LoginInput struct {
 	Email    string `json:"email"`
 	Password string `json:"password"`
 }
 
+type Media struct {
+	ID        uuid.UUID `json:"id"`
+	URL       string    `json:"url"`
+	MimeType  string    `json:"mimeType"`
+	SizeBytes int32     `json:"sizeBytes"`
+}
+
+type Mention struct {
+	UserID   uuid.UUID `json:"userId"`
+	Username string    `json:"username"`
+}
+
+type ModerationAction struct {
+	ID         uuid.UUID            `json:"id"`
+	AdminID    uuid.UUID            `json:"adminId"`
+	Admin      *User                `json:"admin,omitempty"`
+	Action     ModerationActionType `json:"action"`
+	TargetType ModerationTarget     `json:"targetType"`
+	TargetID   uuid.UUID            `json:"targetId"`
+	Reason     string               `json:"reason"`
+	// When a suspension ends; null for bans and other actions
+	SuspendedUntil  *string `json:"suspendedUntil,omitempty"`
+	ReportsResolved int32   `json:"reportsResolved"`
+	CreatedAt       string  `json:"createdAt"`
+}
+
 type Mutation struct {
 }
 
+type MutualFollowers struct {
+	Users      []*User `json:"users"`
+	TotalCount int32   `json:"totalCount"`
+}
+
 type Notification struct {
 	ID        uuid.UUID        `json:"id"`
 	UserID    uuid.UUID        `json:"userId"`
@@ -96,6 +233,9 @@ type Notification struct {
 	RelatedID *uuid.UUID       `json:"relatedId,omitempty"`
 	IsRead    bool             `json:"isRead"`
 	CreatedAt string           `json:"createdAt"`
+	// Set when several events are folded into this notification, e.g. "X and 12
+	// others liked your post". actorId is then the most recent actor.
+	Group *NotificationGroup `json:"group,omitempty"`
 }
 
 type NotificationConnection struct {
@@ -110,6 +250,34 @@ type NotificationEdge struct {
 	Node   *Notification `json:"node"`
 }
 
+type NotificationGroup struct {
+	Key        string `json:"key"`
+	ActorCount int32  `json:"actorCount"`
+	// Most recent first
+	LatestActorIds []uuid.UUID `json:"latestActorIds"`
+}
+
+type NotificationPreferences struct {
+	DisabledTypes []NotificationType `json:"disabledTypes"`
+	// While quiet hours last, notifications are still recorded but not pushed
+	QuietHours *QuietHours `json:"quietHours,omitempty"`
+	UpdatedAt  *string     `json:"updatedAt,omitempty"`
+}
+
+type NotificationPreferencesInput struct {
+	// Types that are not recorded at all
+	DisabledTypes []NotificationType `json:"disabledTypes"`
+	QuietHours    *QuietHoursInput   `json:"quietHours,omitempty"`
+}
+
+type NotificationReadState struct {
+	// The notification marked read; null when all notifications were
+	NotificationID *uuid.UUID `json:"notificationId,omitempty"`
+	// The number of the user's notifications left unread
+	UnreadCount int32  `json:"unreadCount"`
+	ReadAt      string `json:"readAt"`
+}
+
 type PageInfo struct {
 	EndCursor       *string `json:"endCursor,omitempty"`
 	HasNextPage     bool    `json:"hasNextPage"`
@@ -117,23 +285,59 @@ type PageInfo struct {
 	HasPreviousPage bool    `json:"hasPreviousPage"`
 }
 
+type Poll struct {
+	PostID        uuid.UUID     `json:"postId"`
+	Options       []*PollOption `json:"options"`
+	ExpiresAt     string        `json:"expiresAt"`
+	IsExpired     bool          `json:"isExpired"`
+	TotalVotes    int32         `json:"totalVotes"`
+	VotedOptionID *uuid.UUID    `json:"votedOptionId,omitempty"`
+}
+
+type PollInput struct {
+	Options   []string `json:"options"`
+	ExpiresAt string   `json:"expiresAt"`
+}
+
+type PollOption struct {
+	ID         uuid.UUID `json:"id"`
+	Text       string    `json:"text"`
+	VotesCount int32     `json:"votesCount"`
+}
+
 type Post struct {
-	ID            uuid.UUID          `json:"id"`
-	UserID        uuid.UUID          `json:"userId"`
-	User          *User              `json:"user"`
-	Content       string             `json:"content"`
-	CreatedAt     string             `json:"createdAt"`
-	UpdatedAt     string             `json:"updatedAt"`
-	LikesCount    int32              `json:"likesCount"`
-	CommentsCount int32              `json:"commentsCount"`
-	IsLiked       *bool              `json:"isLiked,omitempty"`
-	Comments      *CommentConnection `json:"comments"`
+	ID            uuid.UUID               `json:"id"`
+	UserID        uuid.UUID               `json:"userId"`
+	User          *User                   `json:"user"`
+	Author        *User                   `json:"author,omitempty"`
+	Content       string                  `json:"content"`
+	CreatedAt     string                  `json:"createdAt"`
+	UpdatedAt     string                  `json:"updatedAt"`
+	LikesCount    int32                   `json:"likesCount"`
+	CommentsCount int32                   `json:"commentsCount"`
+	RepostsCount  int32                   `json:"repostsCount"`
+	IsLiked       *bool                   `json:"isLiked,omitempty"`
+	IsReposted    *bool                   `json:"isReposted,omitempty"`
+	Mentions      []*Mention              `json:"mentions"`
+	Media         []*Media                `json:"media"`
+	QuotedPost    *Post                   `json:"quotedPost,omitempty"`
+	DeletedAt     *string                 `json:"deletedAt,omitempty"`
+	IsEdited      bool                    `json:"isEdited"`
+	Status        PostStatus              `json:"status"`
+	PublishAt     *string                 `json:"publishAt,omitempty"`
+	Visibility    PostVisibility          `json:"visibility"`
+	Poll          *Poll                   `json:"poll,omitempty"`
+	EditHistory   *PostRevisionConnection `json:"editHistory"`
+	Comments      *CommentConnection      `json:"comments"`
 }
 
 type PostConnection struct {
 	Edges      []*PostEdge `json:"edges"`
 	PageInfo   *PageInfo   `json:"pageInfo"`
 	TotalCount int32       `json:"totalCount"`
+	// Strategy that ranked a page of the feed, for analysing ranking experiments.
+	// Null for other lists.
+	RankingStrategy *string `json:"rankingStrategy,omitempty"`
 }
 
 type PostEdge struct {
@@ -141,9 +345,84 @@ type PostEdge struct {
 	Node   *Post  `json:"node"`
 }
 
+type PostRevision struct {
+	ID       uuid.UUID `json:"id"`
+	PostID   uuid.UUID `json:"postId"`
+	EditorID uuid.UUID `json:"editorId"`
+	Content  string    `json:"content"`
+	EditedAt string    `json:"editedAt"`
+}
+
+type PostRevisionConnection struct {
+	Edges      []*PostRevisionEdge `json:"edges"`
+	PageInfo   *PageInfo           `json:"pageInfo"`
+	TotalCount int32               `json:"totalCount"`
+}
+
+type PostRevisionEdge struct {
+	Cursor string        `json:"cursor"`
+	Node   *PostRevision `json:"node"`
+}
+
+type PostSearchHit struct {
+	Post *Post `json:"post"`
+	// Plain-text excerpt of the post around the best match
+	Snippet string `json:"snippet"`
+}
+
+// A user is online while a WebSocket connection of theirs is open
+type Presence struct {
+	UserID uuid.UUID `json:"userId"`
+	Online bool      `json:"online"`
+	// The time of the user's latest heartbeat; null if they never connected
+	LastSeenAt *string `json:"lastSeenAt,omitempty"`
+}
+
+type ProfileImage struct {
+	ID        uuid.UUID          `json:"id"`
+	Kind      ProfileImageKind   `json:"kind"`
+	Status    ProfileImageStatus `json:"status"`
+	URL       string             `json:"url"`
+	CreatedAt string             `json:"createdAt"`
+}
+
+type PushPreference struct {
+	Type    NotificationType `json:"type"`
+	Enabled bool             `json:"enabled"`
+}
+
+type PushPreferenceInput struct {
+	Type    NotificationType `json:"type"`
+	Enabled bool             `json:"enabled"`
+}
+
 type Query struct {
 }
 
+type QuietHours struct {
+	Start    string `json:"start"`
+	End      string `json:"end"`
+	TimeZone string `json:"timeZone"`
+}
+
+type QuietHoursInput struct {
+	// HH:MM
+	Start string `json:"start"`
+	// HH:MM; an end before the start runs over midnight
+	End string `json:"end"`
+	// IANA time zone, defaults to UTC
+	TimeZone *string `json:"timeZone,omitempty"`
+}
+
+type RegisterDeviceInput struct {
+	Platform DevicePlatform `json:"platform"`
+	// FCM registration token, APNs device token or WebPush subscription endpoint
+	Token string `json:"token"`
+	// WebPush subscription keys, required for WEBPUSH
+	P256dh *string `json:"p256dh,omitempty"`
+	Auth   *string `json:"auth,omitempty"`
+}
+
 type RegisterInput struct {
 	Username string  `json:"username"`
 	Email    string  `json:"email"`
@@ -151,30 +430,99 @@ type RegisterInput struct {
 	Bio      *string `json:"bio,omitempty"`
 }
 
+type RegisterWebhookInput struct {
+	URL        string             `json:"url"`
+	EventTypes []WebhookEventType `json:"eventTypes"`
+}
+
+type Report struct {
+	ID uuid.UUID `json:"id"`
+	// Null for anonymous reports
+	ReporterID      *uuid.UUID        `json:"reporterId,omitempty"`
+	Reporter        *User             `json:"reporter,omitempty"`
+	TargetType      ModerationTarget  `json:"targetType"`
+	TargetID        uuid.UUID         `json:"targetId"`
+	Reason          ReportReason      `json:"reason"`
+	Details         string            `json:"details"`
+	Status          ReportStatus      `json:"status"`
+	CreatedAt       string            `json:"createdAt"`
+	ReviewerID      *uuid.UUID        `json:"reviewerId,omitempty"`
+	ReviewStartedAt *string           `json:"reviewStartedAt,omitempty"`
+	Resolution      *ReportResolution `json:"resolution,omitempty"`
+	ResolutionNote  string            `json:"resolutionNote"`
+	ResolvedBy      *uuid.UUID        `json:"resolvedBy,omitempty"`
+	ResolvedAt      *string           `json:"resolvedAt,omitempty"`
+	// The audit log entry of the action that resolved the report
+	ActionID *uuid.UUID `json:"actionId,omitempty"`
+}
+
 type Response struct {
 	Success bool   `json:"success"`
 	Message string `json:"message"`
 }
 
+type SaveDraftInput struct {
+	PostID       *uuid.UUID      `json:"postId,omitempty"`
+	Content      string          `json:"content"`
+	MediaIds     []uuid.UUID     `json:"mediaIds,omitempty"`
+	QuotedPostID *uuid.UUID      `json:"quotedPostId,omitempty"`
+	Visibility   *PostVisibility `json:"visibility,omitempty"`
+}
+
+type SearchResults struct {
+	Posts []*PostSearchHit `json:"posts"`
+	Users []*User          `json:"users"`
+	// Cursor for the next page; only set for POSTS and USERS searches
+	EndCursor   *string `json:"endCursor,omitempty"`
+	HasNextPage bool    `json:"hasNextPage"`
+}
+
 type ServiceStatus struct {
 	Name    string `json:"name"`
 	Status  string `json:"status"`
 	Latency *int32 `json:"latency,omitempty"`
 }
 
+// A sign-in and the tokens refreshed from it. The device, IP address and user
+// agent are those of the last sign-in or refresh.
+type Session struct {
+	ID         uuid.UUID `json:"id"`
+	DeviceName string    `json:"deviceName"`
+	IPAddress  string    `json:"ipAddress"`
+	UserAgent  string    `json:"userAgent"`
+	SignedInAt string    `json:"signedInAt"`
+	LastSeenAt string    `json:"lastSeenAt"`
+	ExpiresAt  string    `json:"expiresAt"`
+	// Whether this is the session the request is made with
+	Current bool `json:"current"`
+}
+
 type Subscription struct {
 }
 
+type TrendingHashtag struct {
+	Tag        string `json:"tag"`
+	PostsCount int32  `json:"postsCount"`
+}
+
 type UpdateProfileInput struct {
-	Username *string `json:"username,omitempty"`
-	Email    *string `json:"email,omitempty"`
-	Bio      *string `json:"bio,omitempty"`
+	Username      *string `json:"username,omitempty"`
+	Email         *string `json:"email,omitempty"`
+	Bio           *string `json:"bio,omitempty"`
+	IsPrivate     *bool   `json:"isPrivate,omitempty"`
+	DisplayName   *string `json:"displayName,omitempty"`
+	Location      *string `json:"location,omitempty"`
+	Website       *string `json:"website,omitempty"`
+	BirthDate     *string `json:"birthDate,omitempty"`
+	Pronouns      *string `json:"pronouns,omitempty"`
+	HideEmail     *bool   `json:"hideEmail,omitempty"`
+	HideBirthDate *bool   `json:"hideBirthDate,omitempty"`
 }
 
 type User struct {
 	ID             uuid.UUID `json:"id"`
 	Username       string    `json:"username"`
-	Email          string    `json:"email"`
+	Email          *string   `json:"email,omitempty"`
 	Bio            *string   `json:"bio,omitempty"`
 	CreatedAt      string    `json:"createdAt"`
 	UpdatedAt      string    `json:"updatedAt"`
@@ -182,6 +530,16 @@ type User struct {
 	FollowingCount int32     `json:"followingCount"`
 	PostsCount     int32     `json:"postsCount"`
 	IsFollowing    *bool     `json:"isFollowing,omitempty"`
+	IsPrivate      bool      `json:"isPrivate"`
+	AvatarURL      *string   `json:"avatarUrl,omitempty"`
+	CoverURL       *string   `json:"coverUrl,omitempty"`
+	DisplayName    *string   `json:"displayName,omitempty"`
+	Location       *string   `json:"location,omitempty"`
+	Website        *string   `json:"website,omitempty"`
+	BirthDate      *string   `json:"birthDate,omitempty"`
+	Pronouns       *string   `json:"pronouns,omitempty"`
+	HideEmail      bool      `json:"hideEmail"`
+	HideBirthDate  bool      `json:"hideBirthDate"`
 }
 
 type UserEdge struct {
@@ -189,23 +547,238 @@ type UserEdge struct {
 	Node   *User  `json:"node"`
 }
 
+type UsernameLookup struct {
+	User       *User `json:"user"`
+	Redirected bool  `json:"redirected"`
+}
+
+type Webhook struct {
+	ID         uuid.UUID          `json:"id"`
+	URL        string             `json:"url"`
+	EventTypes []WebhookEventType `json:"eventTypes"`
+	IsActive   bool               `json:"isActive"`
+	// Signing secret, only returned by registerWebhook
+	Secret    *string `json:"secret,omitempty"`
+	CreatedAt string  `json:"createdAt"`
+}
+
+type WebhookDelivery struct {
+	ID         uuid.UUID        `json:"id"`
+	EventID    uuid.UUID        `json:"eventId"`
+	EventType  WebhookEventType `json:"eventType"`
+	Attempt    int32            `json:"attempt"`
+	StatusCode *int32           `json:"statusCode,omitempty"`
+	Success    bool             `json:"success"`
+	Error      *string          `json:"error,omitempty"`
+	DurationMs int32            `json:"durationMs"`
+	CreatedAt  string           `json:"createdAt"`
+}
+
+type DevicePlatform string
+
+const (
+	DevicePlatformFcm     DevicePlatform = "FCM"
+	DevicePlatformApns    DevicePlatform = "APNS"
+	DevicePlatformWebpush DevicePlatform = "WEBPUSH"
+)
+
+var AllDevicePlatform = []DevicePlatform{
+	DevicePlatformFcm,
+	DevicePlatformApns,
+	DevicePlatformWebpush,
+}
+
+func (e DevicePlatform) IsValid() bool {
+	switch e {
+	case DevicePlatformFcm, DevicePlatformApns, DevicePlatformWebpush:
+		return true
+	}
+	return false
+}
+
+func (e DevicePlatform) String() string {
+	return string(e)
+}
+
+func (e *DevicePlatform) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = DevicePlatform(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid DevicePlatform", str)
+	}
+	return nil
+}
+
+func (e DevicePlatform) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *DevicePlatform) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e DevicePlatform) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type ModerationActionType string
+
+const (
+	ModerationActionTypeRemovePost     ModerationActionType = "REMOVE_POST"
+	ModerationActionTypeRemoveComment  ModerationActionType = "REMOVE_COMMENT"
+	ModerationActionTypeSuspendUser    ModerationActionType = "SUSPEND_USER"
+	ModerationActionTypeUnsuspendUser  ModerationActionType = "UNSUSPEND_USER"
+	ModerationActionTypeDismissReports ModerationActionType = "DISMISS_REPORTS"
+	ModerationActionTypeResolveReport  ModerationActionType = "RESOLVE_REPORT"
+)
+
+var AllModerationActionType = []ModerationActionType{
+	ModerationActionTypeRemovePost,
+	ModerationActionTypeRemoveComment,
+	ModerationActionTypeSuspendUser,
+	ModerationActionTypeUnsuspendUser,
+	ModerationActionTypeDismissReports,
+	ModerationActionTypeResolveReport,
+}
+
+func (e ModerationActionType) IsValid() bool {
+	switch e {
+	case ModerationActionTypeRemovePost, ModerationActionTypeRemoveComment, ModerationActionTypeSuspendUser, ModerationActionTypeUnsuspendUser, ModerationActionTypeDismissReports, ModerationActionTypeResolveReport:
+		return true
+	}
+	return false
+}
+
+func (e ModerationActionType) String() string {
+	return string(e)
+}
+
+func (e *ModerationActionType) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ModerationActionType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ModerationActionType", str)
+	}
+	return nil
+}
+
+func (e ModerationActionType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *ModerationActionType) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e ModerationActionType) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type ModerationTarget string
+
+const (
+	ModerationTargetPost    ModerationTarget = "POST"
+	ModerationTargetComment ModerationTarget = "COMMENT"
+	ModerationTargetUser    ModerationTarget = "USER"
+)
+
+var AllModerationTarget = []ModerationTarget{
+	ModerationTargetPost,
+	ModerationTargetComment,
+	ModerationTargetUser,
+}
+
+func (e ModerationTarget) IsValid() bool {
+	switch e {
+	case ModerationTargetPost, ModerationTargetComment, ModerationTargetUser:
+		return true
+	}
+	return false
+}
+
+func (e ModerationTarget) String() string {
+	return string(e)
+}
+
+func (e *ModerationTarget) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ModerationTarget(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ModerationTarget", str)
+	}
+	return nil
+}
+
+func (e ModerationTarget) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *ModerationTarget) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e ModerationTarget) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
 type NotificationType string
 
 const (
-	NotificationTypeLike    NotificationType = "LIKE"
-	NotificationTypeComment NotificationType = "COMMENT"
-	NotificationTypeFollow  NotificationType = "FOLLOW"
+	NotificationTypeLike          NotificationType = "LIKE"
+	NotificationTypeComment       NotificationType = "COMMENT"
+	NotificationTypeFollow        NotificationType = "FOLLOW"
+	NotificationTypeMention       NotificationType = "MENTION"
+	NotificationTypeRepost        NotificationType = "REPOST"
+	NotificationTypeReply         NotificationType = "REPLY"
+	NotificationTypeFollowRequest NotificationType = "FOLLOW_REQUEST"
+	// Activity on the account such as a stolen session; cannot be disabled
+	NotificationTypeSecurity NotificationType = "SECURITY"
 )
 
 var AllNotificationType = []NotificationType{
 	NotificationTypeLike,
 	NotificationTypeComment,
 	NotificationTypeFollow,
+	NotificationTypeMention,
+	NotificationTypeRepost,
+	NotificationTypeReply,
+	NotificationTypeFollowRequest,
+	NotificationTypeSecurity,
 }
 
 func (e NotificationType) IsValid() bool {
 	switch e {
-	case NotificationTypeLike, NotificationTypeComment, NotificationTypeFollow:
+	case NotificationTypeLike, NotificationTypeComment, NotificationTypeFollow, NotificationTypeMention, NotificationTypeRepost, NotificationTypeReply, NotificationTypeFollowRequest, NotificationTypeSecurity:
 		return true
 	}
 	return false
@@ -246,48 +819,48 @@ func (e NotificationType) MarshalJSON() ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
-type Role string
+type OAuthProvider string
 
 const (
-	RoleUser  Role = "USER"
-	RoleAdmin Role = "ADMIN"
+	OAuthProviderGoogle OAuthProvider = "GOOGLE"
+	OAuthProviderGithub OAuthProvider = "GITHUB"
 )
 
-var AllRole = []Role{
-	RoleUser,
-	RoleAdmin,
+var AllOAuthProvider = []OAuthProvider{
+	OAuthProviderGoogle,
+	OAuthProviderGithub,
 }
 
-func (e Role) IsValid() bool {
+func (e OAuthProvider) IsValid() bool {
 	switch e {
-	case RoleUser, RoleAdmin:
+	case OAuthProviderGoogle, OAuthProviderGithub:
 		return true
 	}
 	return false
 }
 
-func (e Role) String() string {
+func (e OAuthProvider) String() string {
 	return string(e)
 }
 
-func (e *Role) UnmarshalGQL(v any) error {
+func (e *OAuthProvider) UnmarshalGQL(v any) error {
 	str, ok := v.(string)
 	if !ok {
 		return fmt.Errorf("enums must be strings")
 	}
 
-	*e = Role(str)
+	*e = OAuthProvider(str)
 	if !e.IsValid() {
-		return fmt.Errorf("%s is not a valid Role", str)
+		return fmt.Errorf("%s is not a valid OAuthProvider", str)
 	}
 	return nil
 }
 
-func (e Role) MarshalGQL(w io.Writer) {
+func (e OAuthProvider) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
-func (e *Role) UnmarshalJSON(b []byte) error {
+func (e *OAuthProvider) UnmarshalJSON(b []byte) error {
 	s, err := strconv.Unquote(string(b))
 	if err != nil {
 		return err
@@ -295,7 +868,591 @@ func (e *Role) UnmarshalJSON(b []byte) error {
 	return e.UnmarshalGQL(s)
 }
 
-func (e Role) MarshalJSON() ([]byte, error) {
+func (e OAuthProvider) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type PostStatus string
+
+const (
+	PostStatusDraft     PostStatus = "DRAFT"
+	PostStatusScheduled PostStatus = "SCHEDULED"
+	PostStatusPublished PostStatus = "PUBLISHED"
+)
+
+var AllPostStatus = []PostStatus{
+	PostStatusDraft,
+	PostStatusScheduled,
+	PostStatusPublished,
+}
+
+func (e PostStatus) IsValid() bool {
+	switch e {
+	case PostStatusDraft, PostStatusScheduled, PostStatusPublished:
+		return true
+	}
+	return false
+}
+
+func (e PostStatus) String() string {
+	return string(e)
+}
+
+func (e *PostStatus) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = PostStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid PostStatus", str)
+	}
+	return nil
+}
+
+func (e PostStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *PostStatus) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e PostStatus) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type PostVisibility string
+
+const (
+	PostVisibilityPublic        PostVisibility = "PUBLIC"
+	PostVisibilityFollowersOnly PostVisibility = "FOLLOWERS_ONLY"
+	PostVisibilityPrivate       PostVisibility = "PRIVATE"
+)
+
+var AllPostVisibility = []PostVisibility{
+	PostVisibilityPublic,
+	PostVisibilityFollowersOnly,
+	PostVisibilityPrivate,
+}
+
+func (e PostVisibility) IsValid() bool {
+	switch e {
+	case PostVisibilityPublic, PostVisibilityFollowersOnly, PostVisibilityPrivate:
+		return true
+	}
+	return false
+}
+
+func (e PostVisibility) String() string {
+	return string(e)
+}
+
+func (e *PostVisibility) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = PostVisibility(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid PostVisibility", str)
+	}
+	return nil
+}
+
+func (e PostVisibility) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *PostVisibility) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e PostVisibility) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type ProfileImageKind string
+
+const (
+	ProfileImageKindAvatar ProfileImageKind = "AVATAR"
+	ProfileImageKindCover  ProfileImageKind = "COVER"
+)
+
+var AllProfileImageKind = []ProfileImageKind{
+	ProfileImageKindAvatar,
+	ProfileImageKindCover,
+}
+
+func (e ProfileImageKind) IsValid() bool {
+	switch e {
+	case ProfileImageKindAvatar, ProfileImageKindCover:
+		return true
+	}
+	return false
+}
+
+func (e ProfileImageKind) String() string {
+	return string(e)
+}
+
+func (e *ProfileImageKind) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ProfileImageKind(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ProfileImageKind", str)
+	}
+	return nil
+}
+
+func (e ProfileImageKind) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *ProfileImageKind) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e ProfileImageKind) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type ProfileImageStatus string
+
+const (
+	ProfileImageStatusPending ProfileImageStatus = "PENDING"
+	ProfileImageStatusReady   ProfileImageStatus = "READY"
+	ProfileImageStatusFailed  ProfileImageStatus = "FAILED"
+)
+
+var AllProfileImageStatus = []ProfileImageStatus{
+	ProfileImageStatusPending,
+	ProfileImageStatusReady,
+	ProfileImageStatusFailed,
+}
+
+func (e ProfileImageStatus) IsValid() bool {
+	switch e {
+	case ProfileImageStatusPending, ProfileImageStatusReady, ProfileImageStatusFailed:
+		return true
+	}
+	return false
+}
+
+func (e ProfileImageStatus) String() string {
+	return string(e)
+}
+
+func (e *ProfileImageStatus) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ProfileImageStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ProfileImageStatus", str)
+	}
+	return nil
+}
+
+func (e ProfileImageStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *ProfileImageStatus) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e ProfileImageStatus) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type ReportReason string
+
+const (
+	ReportReasonSpam           ReportReason = "SPAM"
+	ReportReasonHarassment     ReportReason = "HARASSMENT"
+	ReportReasonHateSpeech     ReportReason = "HATE_SPEECH"
+	ReportReasonViolence       ReportReason = "VIOLENCE"
+	ReportReasonNudity         ReportReason = "NUDITY"
+	ReportReasonMisinformation ReportReason = "MISINFORMATION"
+	ReportReasonSelfHarm       ReportReason = "SELF_HARM"
+	ReportReasonImpersonation  ReportReason = "IMPERSONATION"
+	ReportReasonOther          ReportReason = "OTHER"
+)
+
+var AllReportReason = []ReportReason{
+	ReportReasonSpam,
+	ReportReasonHarassment,
+	ReportReasonHateSpeech,
+	ReportReasonViolence,
+	ReportReasonNudity,
+	ReportReasonMisinformation,
+	ReportReasonSelfHarm,
+	ReportReasonImpersonation,
+	ReportReasonOther,
+}
+
+func (e ReportReason) IsValid() bool {
+	switch e {
+	case ReportReasonSpam, ReportReasonHarassment, ReportReasonHateSpeech, ReportReasonViolence, ReportReasonNudity, ReportReasonMisinformation, ReportReasonSelfHarm, ReportReasonImpersonation, ReportReasonOther:
+		return true
+	}
+	return false
+}
+
+func (e ReportReason) String() string {
+	return string(e)
+}
+
+func (e *ReportReason) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ReportReason(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ReportReason", str)
+	}
+	return nil
+}
+
+func (e ReportReason) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *ReportReason) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e ReportReason) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type ReportResolution string
+
+const (
+	ReportResolutionActioned  ReportResolution = "ACTIONED"
+	ReportResolutionDismissed ReportResolution = "DISMISSED"
+)
+
+var AllReportResolution = []ReportResolution{
+	ReportResolutionActioned,
+	ReportResolutionDismissed,
+}
+
+func (e ReportResolution) IsValid() bool {
+	switch e {
+	case ReportResolutionActioned, ReportResolutionDismissed:
+		return true
+	}
+	return false
+}
+
+func (e ReportResolution) String() string {
+	return string(e)
+}
+
+func (e *ReportResolution) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ReportResolution(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ReportResolution", str)
+	}
+	return nil
+}
+
+func (e ReportResolution) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *ReportResolution) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e ReportResolution) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type ReportStatus string
+
+const (
+	ReportStatusOpen      ReportStatus = "OPEN"
+	ReportStatusReviewing ReportStatus = "REVIEWING"
+	ReportStatusResolved  ReportStatus = "RESOLVED"
+)
+
+var AllReportStatus = []ReportStatus{
+	ReportStatusOpen,
+	ReportStatusReviewing,
+	ReportStatusResolved,
+}
+
+func (e ReportStatus) IsValid() bool {
+	switch e {
+	case ReportStatusOpen, ReportStatusReviewing, ReportStatusResolved:
+		return true
+	}
+	return false
+}
+
+func (e ReportStatus) String() string {
+	return string(e)
+}
+
+func (e *ReportStatus) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ReportStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ReportStatus", str)
+	}
+	return nil
+}
+
+func (e ReportStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *ReportStatus) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e ReportStatus) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type Role string
+
+const (
+	RoleUser  Role = "USER"
+	RoleAdmin Role = "ADMIN"
+)
+
+var AllRole = []Role{
+	RoleUser,
+	RoleAdmin,
+}
+
+func (e Role) IsValid() bool {
+	switch e {
+	case RoleUser, RoleAdmin:
+		return true
+	}
+	return false
+}
+
+func (e Role) String() string {
+	return string(e)
+}
+
+func (e *Role) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = Role(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid Role", str)
+	}
+	return nil
+}
+
+func (e Role) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *Role) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e Role) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type SearchType string
+
+const (
+	SearchTypeAll   SearchType = "ALL"
+	SearchTypePosts SearchType = "POSTS"
+	SearchTypeUsers SearchType = "USERS"
+)
+
+var AllSearchType = []SearchType{
+	SearchTypeAll,
+	SearchTypePosts,
+	SearchTypeUsers,
+}
+
+func (e SearchType) IsValid() bool {
+	switch e {
+	case SearchTypeAll, SearchTypePosts, SearchTypeUsers:
+		return true
+	}
+	return false
+}
+
+func (e SearchType) String() string {
+	return string(e)
+}
+
+func (e *SearchType) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = SearchType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid SearchType", str)
+	}
+	return nil
+}
+
+func (e SearchType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *SearchType) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e SearchType) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+// Webhooks only receive events about their owner. The delivered body is
+// {id, type, created_at, data}, with data as listed for each type.
+type WebhookEventType string
+
+const (
+	// The owner created a post; data is {post_id, user_id, content, created_at}
+	WebhookEventTypePostCreated WebhookEventType = "POST_CREATED"
+	// The owner commented; data is {comment_id, post_id, user_id, content, created_at}
+	WebhookEventTypeCommentAdded WebhookEventType = "COMMENT_ADDED"
+	// The owner followed someone or was followed; data is {follower_id, following_id, occurred_at}
+	WebhookEventTypeUserFollowed WebhookEventType = "USER_FOLLOWED"
+	// The owner unfollowed someone or was unfollowed; data is {follower_id, following_id, occurred_at}
+	WebhookEventTypeUserUnfollowed WebhookEventType = "USER_UNFOLLOWED"
+)
+
+var AllWebhookEventType = []WebhookEventType{
+	WebhookEventTypePostCreated,
+	WebhookEventTypeCommentAdded,
+	WebhookEventTypeUserFollowed,
+	WebhookEventTypeUserUnfollowed,
+}
+
+func (e WebhookEventType) IsValid() bool {
+	switch e {
+	case WebhookEventTypePostCreated, WebhookEventTypeCommentAdded, WebhookEventTypeUserFollowed, WebhookEventTypeUserUnfollowed:
+		return true
+	}
+	return false
+}
+
+func (e WebhookEventType) String() string {
+	return string(e)
+}
+
+func (e *WebhookEventType) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = WebhookEventType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid WebhookEventType", str)
+	}
+	return nil
+}
+
+func (e WebhookEventType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *WebhookEventType) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e WebhookEventType) MarshalJSON() ([]byte, error) {
 	var buf bytes.Buffer
 	e.MarshalGQL(&buf)
 	return buf.Bytes(), nil
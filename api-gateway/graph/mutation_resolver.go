@@ -1,22 +1,33 @@
 package graph
 
 import (
+	"api-gateway/auth"
 	"api-gateway/graph/helpers"
 	"api-gateway/graph/model"
 	"context"
 	"fmt"
+	"io"
+	"strings"
+	"time"
 
 	authpb "auth-service/pb"
 	commentpb "comment-service/pb"
+	feedpb "feed-service/pb"
 	followpb "follow-service/pb"
 	likepb "like-service/pb"
+	moderationpb "moderation-service/pb"
 	notificationpb "notification-service/pb"
 	postpb "post-service/pb"
 	userpb "user-service/pb"
 
+	"github.com/99designs/gqlgen/graphql"
 	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// uploadChunkSize is the size of the chunks uploads are streamed in
+const uploadChunkSize = 64 << 10
+
 // Register is the resolver for the register field.
 func (r *mutationResolver) register(ctx context.Context, input model.RegisterInput) (*model.AuthResponse, error) {
 
@@ -30,6 +41,8 @@ func (r *mutationResolver) register(ctx context.Context, input model.RegisterInp
 		return nil, fmt.Errorf("registration failed: %w", err)
 	}
 
+	auth.StartSession(ctx, resp.AccessToken, time.Duration(resp.ExpiresIn)*time.Second)
+
 	message := helpers.StringPtr(resp.Message)
 	return &model.AuthResponse{
 		AccessToken:  resp.AccessToken,
@@ -37,7 +50,7 @@ func (r *mutationResolver) register(ctx context.Context, input model.RegisterInp
 		User: &model.User{
 			ID:             uuid.MustParse(resp.User.Id),
 			Username:       resp.User.Username,
-			Email:          resp.User.Email,
+			Email:          &resp.User.Email,
 			Bio:            resp.User.Bio,
 			CreatedAt:      resp.User.CreatedAt.String(),
 			UpdatedAt:      resp.User.UpdatedAt.String(),
@@ -60,6 +73,41 @@ func (r *mutationResolver) login(ctx context.Context, input model.LoginInput) (*
 		return nil, fmt.Errorf("login failed: %w", err)
 	}
 
+	auth.StartSession(ctx, resp.AccessToken, time.Duration(resp.ExpiresIn)*time.Second)
+
+	message := helpers.StringPtr(resp.Message)
+	return &model.AuthResponse{
+		AccessToken:  resp.AccessToken,
+		RefreshToken: resp.RefreshToken,
+		User: &model.User{
+			ID:             uuid.MustParse(resp.User.Id),
+			Username:       resp.User.Username,
+			Email:          &resp.User.Email,
+			Bio:            resp.User.Bio,
+			CreatedAt:      resp.User.CreatedAt.String(),
+			UpdatedAt:      resp.User.UpdatedAt.String(),
+			FollowersCount: int32(resp.User.FollowersCount),
+			FollowingCount: int32(resp.User.FollowingCount),
+			PostsCount:     int32(resp.User.PostsCount),
+		},
+		ExpiresIn: int32(resp.ExpiresIn),
+		Message:   message,
+	}, nil
+}
+
+// LoginWithOAuth is the resolver for the loginWithOAuth field.
+func (r *mutationResolver) loginWithOAuth(ctx context.Context, provider model.OAuthProvider, code string, redirectURI *string) (*model.AuthResponse, error) {
+	resp, err := r.AuthClient.OAuthLogin(ctx, &authpb.OAuthLoginRequest{
+		Provider:    strings.ToLower(string(provider)),
+		Code:        code,
+		RedirectUri: redirectURI,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("login failed: %w", err)
+	}
+
+	auth.StartSession(ctx, resp.AccessToken, time.Duration(resp.ExpiresIn)*time.Second)
+
 	message := helpers.StringPtr(resp.Message)
 	return &model.AuthResponse{
 		AccessToken:  resp.AccessToken,
@@ -67,7 +115,7 @@ func (r *mutationResolver) login(ctx context.Context, input model.LoginInput) (*
 		User: &model.User{
 			ID:             uuid.MustParse(resp.User.Id),
 			Username:       resp.User.Username,
-			Email:          resp.User.Email,
+			Email:          &resp.User.Email,
 			Bio:            resp.User.Bio,
 			CreatedAt:      resp.User.CreatedAt.String(),
 			UpdatedAt:      resp.User.UpdatedAt.String(),
@@ -89,6 +137,8 @@ func (r *mutationResolver) refreshToken(ctx context.Context, refreshToken string
 		return nil, fmt.Errorf("token refresh failed: %w", err)
 	}
 
+	auth.StartSession(ctx, resp.AccessToken, time.Duration(resp.ExpiresIn)*time.Second)
+
 	message := helpers.StringPtr(resp.Message)
 	return &model.AuthResponse{
 		AccessToken:  resp.AccessToken,
@@ -96,7 +146,7 @@ func (r *mutationResolver) refreshToken(ctx context.Context, refreshToken string
 		User: &model.User{
 			ID:             uuid.MustParse(resp.User.Id),
 			Username:       resp.User.Username,
-			Email:          resp.User.Email,
+			Email:          &resp.User.Email,
 			Bio:            resp.User.Bio,
 			CreatedAt:      resp.User.CreatedAt.String(),
 			UpdatedAt:      resp.User.UpdatedAt.String(),
@@ -111,14 +161,12 @@ func (r *mutationResolver) refreshToken(ctx context.Context, refreshToken string
 
 // Logout is the resolver for the logout field.
 func (r *mutationResolver) logout(ctx context.Context) (*model.Response, error) {
-	token := helpers.GetTokenFromContext(ctx)
-	ctx = helpers.AddTokenToContext(ctx, token)
-
 	resp, err := r.AuthClient.Logout(ctx, &authpb.LogoutRequest{})
 	if err != nil {
 		return nil, fmt.Errorf("logout failed: %w", err)
 	}
 
+	auth.EndSession(ctx)
 	return &model.Response{
 		Success: resp.Success,
 		Message: resp.Message,
@@ -127,36 +175,28 @@ func (r *mutationResolver) logout(ctx context.Context) (*model.Response, error)
 
 // UpdateProfile is the resolver for the updateProfile field.
 func (r *mutationResolver) updateProfile(ctx context.Context, input model.UpdateProfileInput) (*model.User, error) {
-	token := helpers.GetTokenFromContext(ctx)
-	ctx = helpers.AddTokenToContext(ctx, token)
-
 	resp, err := r.UserClient.UpdateProfile(ctx, &userpb.UpdateProfileRequest{
-		Username: input.Username,
-		Email:    input.Email,
-		Bio:      input.Bio,
+		Username:      input.Username,
+		Email:         input.Email,
+		Bio:           input.Bio,
+		IsPrivate:     input.IsPrivate,
+		DisplayName:   input.DisplayName,
+		Location:      input.Location,
+		Website:       input.Website,
+		BirthDate:     input.BirthDate,
+		Pronouns:      input.Pronouns,
+		HideEmail:     input.HideEmail,
+		HideBirthDate: input.HideBirthDate,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to update profile: %w", err)
 	}
 
-	return &model.User{
-		ID:             uuid.MustParse(resp.Id),
-		Username:       resp.Username,
-		Email:          resp.Email,
-		Bio:            resp.Bio,
-		CreatedAt:      resp.CreatedAt.String(),
-		UpdatedAt:      resp.UpdatedAt.String(),
-		FollowersCount: int32(resp.FollowersCount),
-		FollowingCount: int32(resp.FollowingCount),
-		PostsCount:     int32(resp.PostsCount),
-	}, nil
+	return helpers.ProtoUserToModel(resp), nil
 }
 
 // ChangePassword is the resolver for the changePassword field.
 func (r *mutationResolver) changePassword(ctx context.Context, input model.ChangePasswordInput) (*model.Response, error) {
-	token := helpers.GetTokenFromContext(ctx)
-	ctx = helpers.AddTokenToContext(ctx, token)
-
 	resp, err := r.AuthClient.ChangePassword(ctx, &authpb.ChangePasswordRequest{
 		CurrentPassword: input.CurrentPassword,
 		NewPassword:     input.NewPassword,
@@ -171,13 +211,130 @@ func (r *mutationResolver) changePassword(ctx context.Context, input model.Chang
 	}, nil
 }
 
+// DeleteAccount is the resolver for the deleteAccount field.
+func (r *mutationResolver) deleteAccount(ctx context.Context, password string) (*model.Response, error) {
+	resp, err := r.AuthClient.DeleteAccount(ctx, &authpb.DeleteAccountRequest{
+		Password: password,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete account: %w", err)
+	}
+
+	return &model.Response{
+		Success: resp.Success,
+		Message: resp.Message,
+	}, nil
+}
+
+// RevokeSession is the resolver for the revokeSession field.
+func (r *mutationResolver) revokeSession(ctx context.Context, sessionID uuid.UUID) (*model.Response, error) {
+	resp, err := r.AuthClient.RevokeSession(ctx, &authpb.RevokeSessionRequest{
+		SessionId: sessionID.String(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to revoke session: %w", err)
+	}
+
+	return &model.Response{
+		Success: resp.Success,
+		Message: resp.Message,
+	}, nil
+}
+
+// UploadMedia streams an uploaded file to post-service in chunks
+func (r *mutationResolver) uploadMedia(ctx context.Context, file graphql.Upload) (*model.Media, error) {
+	stream, err := r.PostClient.UploadMedia(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload media: %w", err)
+	}
+
+	buf := make([]byte, uploadChunkSize)
+	for {
+		n, err := file.File.Read(buf)
+		if n > 0 {
+			if err := stream.Send(&postpb.UploadMediaRequest{Chunk: buf[:n]}); err != nil {
+				// The server's reason for ending the stream comes from CloseAndRecv
+				break
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read upload: %w", err)
+		}
+	}
+
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload media: %w", err)
+	}
+	return helpers.ProtoUploadToModel(resp), nil
+}
+
+// uploadProfileImage streams an uploaded avatar or cover image to
+// user-service in chunks, the first carrying its kind
+func (r *mutationResolver) uploadProfileImage(ctx context.Context, file graphql.Upload, kind userpb.ProfileImageKind) (*model.ProfileImage, error) {
+	stream, err := r.UserClient.UploadProfileImage(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload image: %w", err)
+	}
+
+	buf := make([]byte, uploadChunkSize)
+	for {
+		n, err := file.File.Read(buf)
+		if n > 0 {
+			if err := stream.Send(&userpb.UploadProfileImageRequest{Kind: kind, Chunk: buf[:n]}); err != nil {
+				// The server's reason for ending the stream comes from CloseAndRecv
+				break
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read upload: %w", err)
+		}
+	}
+
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload image: %w", err)
+	}
+	return helpers.ProtoProfileImageToModel(resp), nil
+}
+
 // CreatePost is the resolver for the createPost field.
 func (r *mutationResolver) createPost(ctx context.Context, input model.CreatePostInput) (*model.Post, error) {
-	token := helpers.GetTokenFromContext(ctx)
-	ctx = helpers.AddTokenToContext(ctx, token)
+	mediaIDs := make([]string, len(input.MediaIds))
+	for i, id := range input.MediaIds {
+		mediaIDs[i] = id.String()
+	}
+
+	var quotedPostID *string
+	if input.QuotedPostID != nil {
+		id := input.QuotedPostID.String()
+		quotedPostID = &id
+	}
+
+	var poll *postpb.PollInput
+	if input.Poll != nil {
+		expiresAt, err := time.Parse(time.RFC3339, input.Poll.ExpiresAt)
+		if err != nil {
+			return nil, invalidInput("input.poll.expiresAt", "poll expiresAt must be an RFC 3339 time")
+		}
+		poll = &postpb.PollInput{
+			Options:   input.Poll.Options,
+			ExpiresAt: timestamppb.New(expiresAt),
+		}
+	}
 
 	resp, err := r.PostClient.CreatePost(ctx, &postpb.CreatePostRequest{
-		Content: input.Content,
+		Content:      input.Content,
+		MediaIds:     mediaIDs,
+		QuotedPostId: quotedPostID,
+		Visibility:   helpers.PostVisibilityToProto(input.Visibility),
+		Poll:         poll,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create post: %w", err)
@@ -191,14 +348,17 @@ func (r *mutationResolver) createPost(ctx context.Context, input model.CreatePos
 		UpdatedAt:     resp.UpdatedAt.String(),
 		LikesCount:    int32(resp.LikesCount),
 		CommentsCount: int32(resp.CommentsCount),
+		RepostsCount:  int32(resp.RepostsCount),
+		Mentions:      helpers.ProtoPostMentionsToModel(resp.Mentions),
+		Media:         helpers.ProtoMediaToModel(resp.Media),
+		QuotedPost:    helpers.ProtoPostToModel(resp.QuotedPost),
+		Status:        helpers.ProtoPostStatusToModel(resp.Status),
+		Visibility:    helpers.ProtoPostVisibilityToModel(resp.Visibility),
 	}, nil
 }
 
 // UpdatePost is the resolver for the updatePost field.
 func (r *mutationResolver) updatePost(ctx context.Context, postID uuid.UUID, content string) (*model.Post, error) {
-	token := helpers.GetTokenFromContext(ctx)
-	ctx = helpers.AddTokenToContext(ctx, token)
-
 	resp, err := r.PostClient.UpdatePost(ctx, &postpb.UpdatePostRequest{
 		PostId:  postID.String(),
 		Content: content,
@@ -215,14 +375,77 @@ func (r *mutationResolver) updatePost(ctx context.Context, postID uuid.UUID, con
 		UpdatedAt:     resp.UpdatedAt.String(),
 		LikesCount:    int32(resp.LikesCount),
 		CommentsCount: int32(resp.CommentsCount),
+		RepostsCount:  int32(resp.RepostsCount),
+		Mentions:      helpers.ProtoPostMentionsToModel(resp.Mentions),
+		Media:         helpers.ProtoMediaToModel(resp.Media),
+		QuotedPost:    helpers.ProtoPostToModel(resp.QuotedPost),
+		IsEdited:      resp.EditedAt != nil,
+		Status:        helpers.ProtoPostStatusToModel(resp.Status),
+		Visibility:    helpers.ProtoPostVisibilityToModel(resp.Visibility),
 	}, nil
 }
 
+// saveDraft creates or updates a draft of the current user
+func (r *mutationResolver) saveDraft(ctx context.Context, input model.SaveDraftInput) (*model.Post, error) {
+	req := &postpb.SaveDraftRequest{
+		Content:    input.Content,
+		MediaIds:   make([]string, len(input.MediaIds)),
+		Visibility: helpers.PostVisibilityToProto(input.Visibility),
+	}
+	for i, id := range input.MediaIds {
+		req.MediaIds[i] = id.String()
+	}
+	if input.PostID != nil {
+		id := input.PostID.String()
+		req.PostId = &id
+	}
+	if input.QuotedPostID != nil {
+		id := input.QuotedPostID.String()
+		req.QuotedPostId = &id
+	}
+
+	resp, err := r.PostClient.SaveDraft(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save draft: %w", err)
+	}
+
+	return helpers.ProtoPostToModel(resp), nil
+}
+
+// schedulePost schedules or publishes a draft of the current user
+func (r *mutationResolver) schedulePost(ctx context.Context, postID uuid.UUID, publishAt *string) (*model.Post, error) {
+	req := &postpb.SchedulePostRequest{PostId: postID.String()}
+	if publishAt != nil {
+		t, err := time.Parse(time.RFC3339, *publishAt)
+		if err != nil {
+			return nil, invalidInput("publishAt", "publishAt must be an RFC 3339 time")
+		}
+		req.PublishAt = timestamppb.New(t)
+	}
+
+	resp, err := r.PostClient.SchedulePost(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to schedule post: %w", err)
+	}
+
+	return helpers.ProtoPostToModel(resp), nil
+}
+
+// votePoll votes in the poll of a post as the current user
+func (r *mutationResolver) votePoll(ctx context.Context, postID uuid.UUID, optionID uuid.UUID) (*model.Poll, error) {
+	resp, err := r.PostClient.VotePoll(ctx, &postpb.VotePollRequest{
+		PostId:   postID.String(),
+		OptionId: optionID.String(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to vote: %w", err)
+	}
+
+	return helpers.ProtoPollToModel(resp), nil
+}
+
 // DeletePost is the resolver for the deletePost field.
 func (r *mutationResolver) deletePost(ctx context.Context, postID uuid.UUID) (*model.Response, error) {
-	token := helpers.GetTokenFromContext(ctx)
-	ctx = helpers.AddTokenToContext(ctx, token)
-
 	resp, err := r.PostClient.DeletePost(ctx, &postpb.DeletePostRequest{
 		PostId: postID.String(),
 	})
@@ -238,32 +461,36 @@ func (r *mutationResolver) deletePost(ctx context.Context, postID uuid.UUID) (*m
 
 // CreateComment is the resolver for the createComment field.
 func (r *mutationResolver) createComment(ctx context.Context, input model.CreateCommentInput) (*model.Comment, error) {
-	token := helpers.GetTokenFromContext(ctx)
-	ctx = helpers.AddTokenToContext(ctx, token)
+	var parentCommentID *string
+	if input.ParentCommentID != nil {
+		id := input.ParentCommentID.String()
+		parentCommentID = &id
+	}
 
 	resp, err := r.CommentClient.CreateComment(ctx, &commentpb.CreateCommentRequest{
-		PostId:  input.PostID.String(),
-		Content: input.Content,
+		PostId:          input.PostID.String(),
+		Content:         input.Content,
+		ParentCommentId: parentCommentID,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create comment: %w", err)
 	}
 
 	return &model.Comment{
-		ID:        uuid.MustParse(resp.Id),
-		PostID:    uuid.MustParse(resp.PostId),
-		UserID:    uuid.MustParse(resp.UserId),
-		Content:   resp.Content,
-		CreatedAt: resp.CreatedAt.String(),
-		UpdatedAt: resp.UpdatedAt.String(),
+		ID:              uuid.MustParse(resp.Id),
+		PostID:          uuid.MustParse(resp.PostId),
+		UserID:          uuid.MustParse(resp.UserId),
+		Content:         resp.Content,
+		Mentions:        helpers.ProtoCommentMentionsToModel(resp.Mentions),
+		ParentCommentID: helpers.ParseUUIDPtr(resp.GetParentCommentId()),
+		RepliesCount:    resp.RepliesCount,
+		CreatedAt:       resp.CreatedAt.String(),
+		UpdatedAt:       resp.UpdatedAt.String(),
 	}, nil
 }
 
 // UpdateComment is the resolver for the updateComment field.
 func (r *mutationResolver) updateComment(ctx context.Context, commentID uuid.UUID, content string) (*model.Comment, error) {
-	token := helpers.GetTokenFromContext(ctx)
-	ctx = helpers.AddTokenToContext(ctx, token)
-
 	resp, err := r.CommentClient.UpdateComment(ctx, &commentpb.UpdateCommentRequest{
 		CommentId: commentID.String(),
 		Content:   content,
@@ -273,20 +500,20 @@ func (r *mutationResolver) updateComment(ctx context.Context, commentID uuid.UUI
 	}
 
 	return &model.Comment{
-		ID:        uuid.MustParse(resp.Id),
-		PostID:    uuid.MustParse(resp.PostId),
-		UserID:    uuid.MustParse(resp.UserId),
-		Content:   resp.Content,
-		CreatedAt: resp.CreatedAt.String(),
-		UpdatedAt: resp.UpdatedAt.String(),
+		ID:              uuid.MustParse(resp.Id),
+		PostID:          uuid.MustParse(resp.PostId),
+		UserID:          uuid.MustParse(resp.UserId),
+		Content:         resp.Content,
+		Mentions:        helpers.ProtoCommentMentionsToModel(resp.Mentions),
+		ParentCommentID: helpers.ParseUUIDPtr(resp.GetParentCommentId()),
+		RepliesCount:    resp.RepliesCount,
+		CreatedAt:       resp.CreatedAt.String(),
+		UpdatedAt:       resp.UpdatedAt.String(),
 	}, nil
 }
 
 // DeleteComment is the resolver for the deleteComment field.
 func (r *mutationResolver) deleteComment(ctx context.Context, commentID uuid.UUID) (*model.Response, error) {
-	token := helpers.GetTokenFromContext(ctx)
-	ctx = helpers.AddTokenToContext(ctx, token)
-
 	resp, err := r.CommentClient.DeleteComment(ctx, &commentpb.DeleteCommentRequest{
 		CommentId: commentID.String(),
 	})
@@ -302,9 +529,6 @@ func (r *mutationResolver) deleteComment(ctx context.Context, commentID uuid.UUI
 
 // LikePost is the resolver for the likePost field.
 func (r *mutationResolver) likePost(ctx context.Context, postID uuid.UUID) (*model.Response, error) {
-	token := helpers.GetTokenFromContext(ctx)
-	ctx = helpers.AddTokenToContext(ctx, token)
-
 	resp, err := r.LikeClient.LikePost(ctx, &likepb.LikePostRequest{
 		PostId: postID.String(),
 	})
@@ -320,9 +544,6 @@ func (r *mutationResolver) likePost(ctx context.Context, postID uuid.UUID) (*mod
 
 // UnlikePost is the resolver for the unlikePost field.
 func (r *mutationResolver) unlikePost(ctx context.Context, postID uuid.UUID) (*model.Response, error) {
-	token := helpers.GetTokenFromContext(ctx)
-	ctx = helpers.AddTokenToContext(ctx, token)
-
 	resp, err := r.LikeClient.UnlikePost(ctx, &likepb.UnlikePostRequest{
 		PostId: postID.String(),
 	})
@@ -336,11 +557,38 @@ func (r *mutationResolver) unlikePost(ctx context.Context, postID uuid.UUID) (*m
 	}, nil
 }
 
+// Repost is the resolver for the repost field.
+func (r *mutationResolver) repost(ctx context.Context, postID uuid.UUID) (*model.Response, error) {
+	resp, err := r.PostClient.Repost(ctx, &postpb.RepostRequest{
+		PostId: postID.String(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to repost: %w", err)
+	}
+
+	return &model.Response{
+		Success: resp.Success,
+		Message: resp.Message,
+	}, nil
+}
+
+// UndoRepost is the resolver for the undoRepost field.
+func (r *mutationResolver) undoRepost(ctx context.Context, postID uuid.UUID) (*model.Response, error) {
+	resp, err := r.PostClient.UndoRepost(ctx, &postpb.UndoRepostRequest{
+		PostId: postID.String(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to undo repost: %w", err)
+	}
+
+	return &model.Response{
+		Success: resp.Success,
+		Message: resp.Message,
+	}, nil
+}
+
 // FollowUser is the resolver for the followUser field.
 func (r *mutationResolver) followUser(ctx context.Context, userID uuid.UUID) (*model.Response, error) {
-	token := helpers.GetTokenFromContext(ctx)
-	ctx = helpers.AddTokenToContext(ctx, token)
-
 	resp, err := r.FollowClient.FollowUser(ctx, &followpb.FollowUserRequest{
 		FollowingId: userID.String(),
 	})
@@ -356,9 +604,6 @@ func (r *mutationResolver) followUser(ctx context.Context, userID uuid.UUID) (*m
 
 // UnfollowUser is the resolver for the unfollowUser field.
 func (r *mutationResolver) unfollowUser(ctx context.Context, userID uuid.UUID) (*model.Response, error) {
-	token := helpers.GetTokenFromContext(ctx)
-	ctx = helpers.AddTokenToContext(ctx, token)
-
 	resp, err := r.FollowClient.UnfollowUser(ctx, &followpb.UnfollowUserRequest{
 		FollowingId: userID.String(),
 	})
@@ -372,11 +617,38 @@ func (r *mutationResolver) unfollowUser(ctx context.Context, userID uuid.UUID) (
 	}, nil
 }
 
+// ApproveFollowRequest is the resolver for the approveFollowRequest field.
+func (r *mutationResolver) approveFollowRequest(ctx context.Context, userID uuid.UUID) (*model.Response, error) {
+	resp, err := r.FollowClient.ApproveFollowRequest(ctx, &followpb.ApproveFollowRequestRequest{
+		RequesterId: userID.String(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to approve follow request: %w", err)
+	}
+
+	return &model.Response{
+		Success: resp.Success,
+		Message: resp.Message,
+	}, nil
+}
+
+// RejectFollowRequest is the resolver for the rejectFollowRequest field.
+func (r *mutationResolver) rejectFollowRequest(ctx context.Context, userID uuid.UUID) (*model.Response, error) {
+	resp, err := r.FollowClient.RejectFollowRequest(ctx, &followpb.RejectFollowRequestRequest{
+		RequesterId: userID.String(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to reject follow request: %w", err)
+	}
+
+	return &model.Response{
+		Success: resp.Success,
+		Message: resp.Message,
+	}, nil
+}
+
 // MarkNotificationRead is the resolver for the markNotificationRead field.
 func (r *mutationResolver) markNotificationRead(ctx context.Context, notificationID uuid.UUID) (*model.Response, error) {
-	token := helpers.GetTokenFromContext(ctx)
-	ctx = helpers.AddTokenToContext(ctx, token)
-
 	resp, err := r.NotificationClient.MarkRead(ctx, &notificationpb.MarkReadRequest{
 		NotificationId: notificationID.String(),
 	})
@@ -392,9 +664,6 @@ func (r *mutationResolver) markNotificationRead(ctx context.Context, notificatio
 
 // MarkAllNotificationsRead is the resolver for the markAllNotificationsRead field.
 func (r *mutationResolver) markAllNotificationsRead(ctx context.Context) (*model.Response, error) {
-	token := helpers.GetTokenFromContext(ctx)
-	ctx = helpers.AddTokenToContext(ctx, token)
-
 	resp, err := r.NotificationClient.MarkAllRead(ctx, &notificationpb.MarkAllReadRequest{})
 	if err != nil {
 		return nil, fmt.Errorf("failed to mark all notifications as read: %w", err)
@@ -405,3 +674,337 @@ func (r *mutationResolver) markAllNotificationsRead(ctx context.Context) (*model
 		Message: resp.Message,
 	}, nil
 }
+
+// MarkFeedItemsSeen is the resolver for the markFeedItemsSeen field.
+func (r *mutationResolver) markFeedItemsSeen(ctx context.Context, postIds []uuid.UUID) (*model.Response, error) {
+	ids := make([]string, len(postIds))
+	for i, id := range postIds {
+		ids[i] = id.String()
+	}
+
+	resp, err := r.FeedClient.MarkFeedItemsSeen(ctx, &feedpb.MarkFeedItemsSeenRequest{
+		PostIds: ids,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to mark feed items as seen: %w", err)
+	}
+
+	return &model.Response{
+		Success: resp.Success,
+		Message: resp.Message,
+	}, nil
+}
+
+// RegisterWebhook is the resolver for the registerWebhook field.
+func (r *mutationResolver) registerWebhook(ctx context.Context, input model.RegisterWebhookInput) (*model.Webhook, error) {
+	eventTypes := make([]string, len(input.EventTypes))
+	for i, t := range input.EventTypes {
+		eventTypes[i] = helpers.WebhookEventTypeToProto(t)
+	}
+
+	resp, err := r.NotificationClient.RegisterWebhook(ctx, &notificationpb.RegisterWebhookRequest{
+		Url:        input.URL,
+		EventTypes: eventTypes,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to register webhook: %w", err)
+	}
+
+	return helpers.ProtoWebhookToModel(resp), nil
+}
+
+// DeleteWebhook is the resolver for the deleteWebhook field.
+func (r *mutationResolver) deleteWebhook(ctx context.Context, webhookID uuid.UUID) (*model.Response, error) {
+	resp, err := r.NotificationClient.DeleteWebhook(ctx, &notificationpb.DeleteWebhookRequest{
+		WebhookId: webhookID.String(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete webhook: %w", err)
+	}
+
+	return &model.Response{
+		Success: resp.Success,
+		Message: resp.Message,
+	}, nil
+}
+
+// DeleteNotification is the resolver for the deleteNotification field.
+func (r *mutationResolver) deleteNotification(ctx context.Context, notificationID uuid.UUID) (*model.Response, error) {
+	resp, err := r.NotificationClient.DeleteNotification(ctx, &notificationpb.DeleteNotificationRequest{
+		NotificationId: notificationID.String(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete notification: %w", err)
+	}
+
+	return &model.Response{
+		Success: resp.Success,
+		Message: resp.Message,
+	}, nil
+}
+
+// RegisterDevice is the resolver for the registerDevice field.
+func (r *mutationResolver) registerDevice(ctx context.Context, input model.RegisterDeviceInput) (*model.Device, error) {
+	resp, err := r.NotificationClient.RegisterDevice(ctx, &notificationpb.RegisterDeviceRequest{
+		Platform: helpers.DevicePlatformToProto(input.Platform),
+		Token:    input.Token,
+		P256Dh:   input.P256dh,
+		Auth:     input.Auth,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to register device: %w", err)
+	}
+
+	return helpers.ProtoDeviceToModel(resp), nil
+}
+
+// UnregisterDevice is the resolver for the unregisterDevice field.
+func (r *mutationResolver) unregisterDevice(ctx context.Context, deviceToken string) (*model.Response, error) {
+	resp, err := r.NotificationClient.UnregisterDevice(ctx, &notificationpb.UnregisterDeviceRequest{
+		Token: deviceToken,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to unregister device: %w", err)
+	}
+
+	return &model.Response{
+		Success: resp.Success,
+		Message: resp.Message,
+	}, nil
+}
+
+// UpdatePushPreferences is the resolver for the updatePushPreferences field.
+func (r *mutationResolver) updatePushPreferences(ctx context.Context, preferences []*model.PushPreferenceInput) ([]*model.PushPreference, error) {
+	req := &notificationpb.UpdatePushPreferencesRequest{
+		Preferences: make([]*notificationpb.PushPreference, len(preferences)),
+	}
+	for i, p := range preferences {
+		req.Preferences[i] = &notificationpb.PushPreference{
+			Type:    helpers.NotificationTypeToProto(p.Type),
+			Enabled: p.Enabled,
+		}
+	}
+
+	resp, err := r.NotificationClient.UpdatePushPreferences(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update push preferences: %w", err)
+	}
+
+	return helpers.ProtoPushPreferencesToModel(resp), nil
+}
+
+// UpdateNotificationPreferences is the resolver for the updateNotificationPreferences field.
+func (r *mutationResolver) updateNotificationPreferences(ctx context.Context, input model.NotificationPreferencesInput) (*model.NotificationPreferences, error) {
+	req := &notificationpb.UpdatePreferencesRequest{
+		DisabledTypes: make([]notificationpb.NotificationType, len(input.DisabledTypes)),
+	}
+	for i, t := range input.DisabledTypes {
+		req.DisabledTypes[i] = helpers.NotificationTypeToProto(t)
+	}
+	if q := input.QuietHours; q != nil {
+		req.QuietHours = &notificationpb.QuietHours{
+			Start: q.Start,
+			End:   q.End,
+		}
+		if q.TimeZone != nil {
+			req.QuietHours.TimeZone = *q.TimeZone
+		}
+	}
+
+	resp, err := r.NotificationClient.UpdatePreferences(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update notification preferences: %w", err)
+	}
+
+	return helpers.ProtoNotificationPreferencesToModel(resp), nil
+}
+
+// reportPost reports a post to moderators
+func (r *mutationResolver) reportPost(ctx context.Context, postID uuid.UUID, reason model.ReportReason, details *string, showReporter *bool) (*model.Report, error) {
+	req := &moderationpb.ReportPostRequest{
+		PostId: postID.String(),
+		Reason: helpers.ReportReasonToProto(reason),
+	}
+	if details != nil {
+		req.Details = *details
+	}
+	if showReporter != nil {
+		req.ShowReporter = *showReporter
+	}
+
+	resp, err := r.ModerationClient.ReportPost(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to report post: %w", err)
+	}
+
+	return helpers.ProtoReportToModel(resp), nil
+}
+
+// reportComment reports a comment to moderators
+func (r *mutationResolver) reportComment(ctx context.Context, commentID uuid.UUID, reason model.ReportReason, details *string, showReporter *bool) (*model.Report, error) {
+	req := &moderationpb.ReportCommentRequest{
+		CommentId: commentID.String(),
+		Reason:    helpers.ReportReasonToProto(reason),
+	}
+	if details != nil {
+		req.Details = *details
+	}
+	if showReporter != nil {
+		req.ShowReporter = *showReporter
+	}
+
+	resp, err := r.ModerationClient.ReportComment(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to report comment: %w", err)
+	}
+
+	return helpers.ProtoReportToModel(resp), nil
+}
+
+// reportUser reports a user to moderators
+func (r *mutationResolver) reportUser(ctx context.Context, userID uuid.UUID, reason model.ReportReason, details *string, showReporter *bool) (*model.Report, error) {
+	req := &moderationpb.ReportUserRequest{
+		UserId: userID.String(),
+		Reason: helpers.ReportReasonToProto(reason),
+	}
+	if details != nil {
+		req.Details = *details
+	}
+	if showReporter != nil {
+		req.ShowReporter = *showReporter
+	}
+
+	resp, err := r.ModerationClient.ReportUser(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to report user: %w", err)
+	}
+
+	return helpers.ProtoReportToModel(resp), nil
+}
+
+// reviewReport marks a report as being reviewed by the calling admin
+func (r *mutationResolver) reviewReport(ctx context.Context, reportID uuid.UUID) (*model.Report, error) {
+	resp, err := r.ModerationClient.ReviewReport(ctx, &moderationpb.ReviewReportRequest{ReportId: reportID.String()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to review report: %w", err)
+	}
+
+	return helpers.ProtoReportToModel(resp), nil
+}
+
+// resolveReport settles a single report
+func (r *mutationResolver) resolveReport(ctx context.Context, reportID uuid.UUID, resolution model.ReportResolution, note *string) (*model.Report, error) {
+	req := &moderationpb.ResolveReportRequest{
+		ReportId:   reportID.String(),
+		Resolution: moderationpb.ReportResolution(moderationpb.ReportResolution_value["REPORT_RESOLUTION_"+string(resolution)]),
+	}
+	if note != nil {
+		req.Note = *note
+	}
+
+	resp, err := r.ModerationClient.ResolveReport(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve report: %w", err)
+	}
+
+	return helpers.ProtoReportToModel(resp), nil
+}
+
+// removePost takes down any user's post as an admin
+func (r *mutationResolver) removePost(ctx context.Context, postID uuid.UUID, reason string) (*model.ModerationAction, error) {
+	resp, err := r.ModerationClient.RemovePost(ctx, &moderationpb.RemovePostRequest{
+		PostId: postID.String(),
+		Reason: reason,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to remove post: %w", err)
+	}
+
+	return helpers.ProtoModerationActionToModel(resp), nil
+}
+
+// removeComment takes down any user's comment as an admin
+func (r *mutationResolver) removeComment(ctx context.Context, commentID uuid.UUID, reason string) (*model.ModerationAction, error) {
+	resp, err := r.ModerationClient.RemoveComment(ctx, &moderationpb.RemoveCommentRequest{
+		CommentId: commentID.String(),
+		Reason:    reason,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to remove comment: %w", err)
+	}
+
+	return helpers.ProtoModerationActionToModel(resp), nil
+}
+
+// suspendUser suspends a user until a time, or bans them without one
+func (r *mutationResolver) suspendUser(ctx context.Context, userID uuid.UUID, reason string, until *string) (*model.ModerationAction, error) {
+	req := &moderationpb.SuspendUserRequest{
+		UserId: userID.String(),
+		Reason: reason,
+	}
+	if until != nil {
+		t, err := time.Parse(time.RFC3339, *until)
+		if err != nil {
+			return nil, invalidInput("until", "until must be an RFC 3339 time")
+		}
+		req.Until = timestamppb.New(t)
+	}
+
+	resp, err := r.ModerationClient.SuspendUser(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to suspend user: %w", err)
+	}
+
+	return helpers.ProtoModerationActionToModel(resp), nil
+}
+
+func (r *mutationResolver) unsuspendUser(ctx context.Context, userID uuid.UUID, reason *string) (*model.ModerationAction, error) {
+	req := &moderationpb.UnsuspendUserRequest{UserId: userID.String()}
+	if reason != nil {
+		req.Reason = *reason
+	}
+
+	resp, err := r.ModerationClient.UnsuspendUser(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unsuspend user: %w", err)
+	}
+
+	return helpers.ProtoModerationActionToModel(resp), nil
+}
+
+// dismissReports resolves the unresolved reports on a target without acting on it
+func (r *mutationResolver) dismissReports(ctx context.Context, targetType model.ModerationTarget, targetID uuid.UUID, reason *string) (*model.ModerationAction, error) {
+	req := &moderationpb.DismissReportsRequest{
+		TargetType: helpers.ModerationTargetToProto(targetType),
+		TargetId:   targetID.String(),
+	}
+	if reason != nil {
+		req.Reason = *reason
+	}
+
+	resp, err := r.ModerationClient.DismissReports(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dismiss reports: %w", err)
+	}
+
+	return helpers.ProtoModerationActionToModel(resp), nil
+}
+
+// setUserRoles replaces a user's roles
+func (r *mutationResolver) setUserRoles(ctx context.Context, userID uuid.UUID, roles []model.Role) ([]model.Role, error) {
+	req := &authpb.SetUserRolesRequest{UserId: userID.String()}
+	for _, role := range roles {
+		req.Roles = append(req.Roles, string(role))
+	}
+
+	resp, err := r.AuthClient.SetUserRoles(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set user roles: %w", err)
+	}
+
+	result := make([]model.Role, len(resp.Roles))
+	for i, role := range resp.Roles {
+		result[i] = model.Role(role)
+	}
+	return result, nil
+}
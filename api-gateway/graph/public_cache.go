@@ -0,0 +1,67 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"api-gateway/auth"
+	"api-gateway/cache"
+	"api-gateway/graph/model"
+)
+
+// publicCache returns the cache for the request, which is only used for
+// anonymous callers: what a signed-in user sees of a profile or post depends
+// on who they are
+func (r *Resolver) publicCache(ctx context.Context) *cache.Cache {
+	if _, ok := auth.FromContext(ctx); ok {
+		return nil
+	}
+	return r.Cache
+}
+
+func (r *queryResolver) cachedProfile(ctx context.Context, userID uuid.UUID) (*model.User, error) {
+	load := func(ctx context.Context) (*model.User, error) {
+		user, err := r.getProfile(ctx, userID)
+		if err == nil {
+			// Only shown to signed-in users, so not worth keeping
+			user.Email = nil
+		}
+		return user, err
+	}
+	return cache.Load(ctx, r.publicCache(ctx), "profile:"+userID.String(), load, func(user *model.User) []string {
+		return []string{cache.UserTag(user.ID)}
+	})
+}
+
+func (r *queryResolver) cachedPost(ctx context.Context, postID uuid.UUID) (*model.Post, error) {
+	load := func(ctx context.Context) (*model.Post, error) {
+		return r.getPost(ctx, postID)
+	}
+	return cache.Load(ctx, r.publicCache(ctx), "post:"+postID.String(), load, func(post *model.Post) []string {
+		tags := []string{cache.PostTag(post.ID), cache.UserTag(post.UserID)}
+		if post.QuotedPost != nil {
+			tags = append(tags, cache.PostTag(post.QuotedPost.ID))
+		}
+		return tags
+	})
+}
+
+func (r *queryResolver) cachedPostComments(ctx context.Context, postID uuid.UUID, first *int32, after *string) (*model.CommentConnection, error) {
+	load := func(ctx context.Context) (*model.CommentConnection, error) {
+		return r.getPostComments(ctx, postID, first, after)
+	}
+	key := fmt.Sprintf("comments:%s:%d:%s", postID, deref(first), deref(after))
+	return cache.Load(ctx, r.publicCache(ctx), key, load, func(*model.CommentConnection) []string {
+		return []string{cache.PostTag(postID)}
+	})
+}
+
+func deref[T any](p *T) T {
+	var zero T
+	if p == nil {
+		return zero
+	}
+	return *p
+}
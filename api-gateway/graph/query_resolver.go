@@ -1,53 +1,115 @@
 package graph
 
 import (
+	"api-gateway/auth"
 	"api-gateway/graph/helpers"
 	"api-gateway/graph/model"
+	"api-gateway/loader"
 	"context"
+	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
+	auditpb "audit-service/pb"
+	authpb "auth-service/pb"
+	followpb "follow-service/pb"
 	likepb "like-service/pb"
+	moderationpb "moderation-service/pb"
+	notificationpb "notification-service/pb"
 	postpb "post-service/pb"
+	searchpb "search-service/pb"
 	userpb "user-service/pb"
 )
 
-// HealthCheck is the resolver for the healthCheck field.
+// healthCheckTimeout bounds the health check of each backend
+const healthCheckTimeout = 2 * time.Second
+
+// HealthCheck is the resolver for the healthCheck field. Every backend is
+// checked concurrently over grpc.health.v1; the gateway is "ok" only when all
+// of them are serving.
 func (r *queryResolver) healthCheck(ctx context.Context) (*model.HealthCheckResponse, error) {
+	services := make([]*model.ServiceStatus, len(r.Backends))
+
+	var wg sync.WaitGroup
+	for i, backend := range r.Backends {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			services[i] = checkBackend(ctx, backend)
+		}()
+	}
+	wg.Wait()
+
+	status := "ok"
+	for _, service := range services {
+		if service.Status != "healthy" {
+			status = "degraded"
+		}
+	}
+
 	return &model.HealthCheckResponse{
-		Status:    "ok",
+		Status:    status,
 		Timestamp: time.Now().Format(time.RFC3339),
-		Services: []*model.ServiceStatus{
-			{Name: "AuthService", Status: "healthy", Latency: helpers.Int32Ptr(10)},
-			{Name: "UserService", Status: "healthy", Latency: helpers.Int32Ptr(15)},
-			{Name: "PostService", Status: "healthy", Latency: helpers.Int32Ptr(12)},
-		},
+		Services:  services,
 	}, nil
 }
 
+// checkBackend reports a backend as healthy, unhealthy when it answers
+// NOT_SERVING, or unreachable; latency is only known for answers
+func checkBackend(ctx context.Context, backend Backend) *model.ServiceStatus {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	start := time.Now()
+	resp, err := backend.Health.Check(ctx, &healthpb.HealthCheckRequest{Service: backend.Service})
+	if err != nil {
+		return &model.ServiceStatus{Name: backend.Name, Status: "unreachable"}
+	}
+
+	latency := helpers.Int32Ptr(int32(time.Since(start).Milliseconds()))
+	if resp.Status != healthpb.HealthCheckResponse_SERVING {
+		return &model.ServiceStatus{Name: backend.Name, Status: "unhealthy", Latency: latency}
+	}
+	return &model.ServiceStatus{Name: backend.Name, Status: "healthy", Latency: latency}
+}
+
 // Me is the resolver for the me field.
 func (r *queryResolver) me(ctx context.Context) (*model.User, error) {
-	token := helpers.GetTokenFromContext(ctx)
-	ctx = helpers.AddTokenToContext(ctx, token)
-
 	resp, err := r.UserClient.GetMe(ctx, &userpb.GetMeRequest{})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get current user: %w", err)
 	}
 
-	return &model.User{
-		ID:             uuid.MustParse(resp.Id),
-		Username:       resp.Username,
-		Email:          resp.Email,
-		Bio:            resp.Bio,
-		CreatedAt:      resp.CreatedAt.String(),
-		UpdatedAt:      resp.UpdatedAt.String(),
-		FollowersCount: int32(resp.FollowersCount),
-		FollowingCount: int32(resp.FollowingCount),
-		PostsCount:     int32(resp.PostsCount),
-	}, nil
+	return helpers.ProtoUserToModel(resp), nil
+}
+
+// MySessions is the resolver for the mySessions field.
+func (r *queryResolver) mySessions(ctx context.Context) ([]*model.Session, error) {
+	resp, err := r.AuthClient.ListSessions(ctx, &authpb.ListSessionsRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+
+	sessions := make([]*model.Session, len(resp.Sessions))
+	for i, s := range resp.Sessions {
+		sessions[i] = &model.Session{
+			ID:         uuid.MustParse(s.Id),
+			DeviceName: s.DeviceName,
+			IPAddress:  s.IpAddress,
+			UserAgent:  s.UserAgent,
+			SignedInAt: s.SignedInAt.AsTime().Format(time.RFC3339),
+			LastSeenAt: s.LastSeenAt.AsTime().Format(time.RFC3339),
+			ExpiresAt:  s.ExpiresAt.AsTime().Format(time.RFC3339),
+			Current:    s.Current,
+		}
+	}
+	return sessions, nil
 }
 
 // GetProfile is the resolver for the getProfile field.
@@ -59,24 +121,40 @@ func (r *queryResolver) getProfile(ctx context.Context, userID uuid.UUID) (*mode
 		return nil, fmt.Errorf("failed to get profile: %w", err)
 	}
 
-	return &model.User{
-		ID:             uuid.MustParse(resp.Id),
-		Username:       resp.Username,
-		Email:          resp.Email,
-		Bio:            resp.Bio,
-		CreatedAt:      resp.CreatedAt.String(),
-		UpdatedAt:      resp.UpdatedAt.String(),
-		FollowersCount: int32(resp.FollowersCount),
-		FollowingCount: int32(resp.FollowingCount),
-		PostsCount:     int32(resp.PostsCount),
-	}, nil
+	return helpers.ProtoUserToModel(resp), nil
+}
+
+// getProfileByUsername finds a user by their username or, while it is still
+// reserved for them, by one they have changed
+func (r *queryResolver) getProfileByUsername(ctx context.Context, username string) (*model.UsernameLookup, error) {
+	resp, err := r.UserClient.GetUsersByUsernames(ctx, &userpb.GetUsersByUsernamesRequest{
+		Usernames: []string{username},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get profile: %w", err)
+	}
+
+	if len(resp.Users) > 0 {
+		return &model.UsernameLookup{User: helpers.ProtoUserToModel(resp.Users[0])}, nil
+	}
+	if len(resp.FormerUsernames) > 0 {
+		return &model.UsernameLookup{
+			User:       helpers.ProtoUserToModel(resp.FormerUsernames[0].User),
+			Redirected: true,
+		}, nil
+	}
+	return nil, nil
 }
 
 // GetPost is the resolver for the getPost field.
 func (r *queryResolver) getPost(ctx context.Context, postID uuid.UUID) (*model.Post, error) {
-	resp, err := r.PostClient.GetPost(ctx, &postpb.GetPostRequest{
-		PostId: postID.String(),
-	})
+	req := &postpb.GetPostRequest{PostId: postID.String()}
+	// Authors can open their own drafts
+	if principal, ok := auth.FromContext(ctx); ok {
+		req.RequestingUserId = &principal.UserID
+	}
+
+	resp, err := r.PostClient.GetPost(ctx, req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get post: %w", err)
 	}
@@ -89,13 +167,18 @@ func (r *queryResolver) getPost(ctx context.Context, postID uuid.UUID) (*model.P
 		UpdatedAt:     resp.UpdatedAt.String(),
 		LikesCount:    int32(resp.LikesCount),
 		CommentsCount: int32(resp.CommentsCount),
+		RepostsCount:  int32(resp.RepostsCount),
+		Mentions:      helpers.ProtoPostMentionsToModel(resp.Mentions),
+		Media:         helpers.ProtoMediaToModel(resp.Media),
+		QuotedPost:    helpers.ProtoPostToModel(resp.QuotedPost),
+		IsEdited:      resp.EditedAt != nil,
+		Status:        helpers.ProtoPostStatusToModel(resp.Status),
+		PublishAt:     helpers.TimestampPtr(resp.PublishAt),
+		Visibility:    helpers.ProtoPostVisibilityToModel(resp.Visibility),
 	}, nil
 }
 
 func (r *queryResolver) getPostLikes(ctx context.Context, postID uuid.UUID) (*model.LikeInfo, error) {
-	token := helpers.GetTokenFromContext(ctx)
-	ctx = helpers.AddTokenToContext(ctx, token)
-
 	resp, err := r.LikeClient.GetPostLikes(ctx, &likepb.GetPostLikesRequest{
 		PostId: postID.String(),
 	})
@@ -103,15 +186,24 @@ func (r *queryResolver) getPostLikes(ctx context.Context, postID uuid.UUID) (*mo
 		return nil, fmt.Errorf("failed to get likes: %w", err)
 	}
 
-	recentLikers := make([]*model.User, len(resp.GetRecentLikerIds()))
-	for i, userID := range resp.GetRecentLikerIds() {
-		userResp, err := r.UserClient.GetProfile(ctx, &userpb.GetProfileRequest{
-			UserId: userID,
-		})
-		if err != nil {
-			return nil, fmt.Errorf("failed to fetch user %s: %w", userID, err)
+	likerIDs := make([]uuid.UUID, 0, len(resp.GetRecentLikerIds()))
+	for _, id := range resp.GetRecentLikerIds() {
+		if likerID, err := uuid.Parse(id); err == nil {
+			likerIDs = append(likerIDs, likerID)
+		}
+	}
+
+	// Likers whose account is gone are left out
+	users, errs := loader.For(ctx, r.UserClient).Users.LoadMany(ctx, likerIDs)
+	recentLikers := make([]*model.User, 0, len(users))
+	for i, user := range users {
+		if errors.Is(errs[i], loader.ErrNotFound) {
+			continue
+		}
+		if errs[i] != nil {
+			return nil, fmt.Errorf("failed to fetch recent likers: %w", errs[i])
 		}
-		recentLikers[i] = helpers.ProtoUserToModel(userResp)
+		recentLikers = append(recentLikers, user)
 	}
 
 	return &model.LikeInfo{
@@ -119,3 +211,724 @@ func (r *queryResolver) getPostLikes(ctx context.Context, postID uuid.UUID) (*mo
 		RecentLikers: recentLikers,
 	}, nil
 }
+
+// postLikers pages through the likers of a post. like-service answers "post
+// not found" for posts the caller cannot see.
+func (r *queryResolver) postLikers(ctx context.Context, postID uuid.UUID, first *int32, after *string) (*model.LikerConnection, error) {
+	req := &likepb.GetPostLikersRequest{
+		PostId: postID.String(),
+		First:  deref(first),
+	}
+	if after != nil && *after != "" {
+		req.After = after
+	}
+
+	resp, err := r.LikeClient.GetPostLikers(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get likers: %w", err)
+	}
+
+	likerIDs := make([]uuid.UUID, len(resp.Edges))
+	for i, e := range resp.Edges {
+		likerIDs[i] = uuid.MustParse(e.UserId)
+	}
+
+	// Likers whose account is gone are left out
+	users, errs := loader.For(ctx, r.UserClient).Users.LoadMany(ctx, likerIDs)
+	edges := make([]*model.LikerEdge, 0, len(resp.Edges))
+	for i, e := range resp.Edges {
+		if errors.Is(errs[i], loader.ErrNotFound) {
+			continue
+		}
+		if errs[i] != nil {
+			return nil, fmt.Errorf("failed to fetch likers: %w", errs[i])
+		}
+		edges = append(edges, &model.LikerEdge{
+			Cursor:  e.Cursor,
+			Node:    users[i],
+			LikedAt: e.LikedAt.AsTime().Format(time.RFC3339),
+		})
+	}
+
+	return &model.LikerConnection{
+		Edges: edges,
+		PageInfo: &model.PageInfo{
+			EndCursor:       resp.PageInfo.EndCursor,
+			HasNextPage:     resp.PageInfo.HasNextPage,
+			StartCursor:     resp.PageInfo.StartCursor,
+			HasPreviousPage: resp.PageInfo.HasPreviousPage,
+		},
+		TotalCount: resp.TotalCount,
+	}, nil
+}
+
+// likedPosts pages through the posts a user liked. like-service returns the
+// post IDs of a page and the posts are fetched in one GetPostsByIds call,
+// which leaves out the posts the caller may not see.
+func (r *queryResolver) likedPosts(ctx context.Context, userID uuid.UUID, first *int32, after *string) (*model.LikedPostConnection, error) {
+	if err := r.checkCanSeeLikes(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	req := &likepb.GetUserLikedPostsRequest{
+		UserId: userID.String(),
+		First:  deref(first),
+	}
+	if after != nil && *after != "" {
+		req.After = after
+	}
+
+	resp, err := r.LikeClient.GetUserLikedPosts(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get liked posts: %w", err)
+	}
+
+	posts := make(map[string]*model.Post, len(resp.Edges))
+	if len(resp.Edges) > 0 {
+		postReq := &postpb.GetPostsByIdsRequest{PostIds: make([]string, len(resp.Edges))}
+		for i, e := range resp.Edges {
+			postReq.PostIds[i] = e.PostId
+		}
+		if principal, ok := auth.FromContext(ctx); ok {
+			postReq.RequestingUserId = &principal.UserID
+		}
+
+		postResp, err := r.PostClient.GetPostsByIds(ctx, postReq)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch liked posts: %w", err)
+		}
+		for _, p := range postResp.Posts {
+			posts[p.Id] = helpers.ProtoPostToModel(p)
+		}
+	}
+
+	edges := make([]*model.LikedPostEdge, 0, len(resp.Edges))
+	for _, e := range resp.Edges {
+		post, ok := posts[e.PostId]
+		if !ok {
+			continue
+		}
+		edges = append(edges, &model.LikedPostEdge{
+			Cursor:  e.Cursor,
+			Node:    post,
+			LikedAt: e.LikedAt.AsTime().Format(time.RFC3339),
+		})
+	}
+
+	return &model.LikedPostConnection{
+		Edges: edges,
+		PageInfo: &model.PageInfo{
+			EndCursor:       resp.PageInfo.EndCursor,
+			HasNextPage:     resp.PageInfo.HasNextPage,
+			StartCursor:     resp.PageInfo.StartCursor,
+			HasPreviousPage: resp.PageInfo.HasPreviousPage,
+		},
+		TotalCount: resp.TotalCount,
+	}, nil
+}
+
+// checkCanSeeLikes refuses to list the likes of a private account to anyone
+// but the account and its approved followers, as post-service does for its
+// posts
+func (r *queryResolver) checkCanSeeLikes(ctx context.Context, userID uuid.UUID) error {
+	user, err := r.loadUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !user.IsPrivate {
+		return nil
+	}
+
+	principal, ok := auth.FromContext(ctx)
+	if ok && principal.UserID == userID.String() {
+		return nil
+	}
+	if ok {
+		resp, err := r.FollowClient.IsFollowing(ctx, &followpb.IsFollowingRequest{
+			FollowerId:  principal.UserID,
+			FollowingId: userID.String(),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to check follow status: %w", err)
+		}
+		if resp.IsFollowing {
+			return nil
+		}
+	}
+
+	return &gqlerror.Error{
+		Message:    "this account is private",
+		Extensions: map[string]any{"code": codePermissionDenied},
+	}
+}
+
+// Webhooks is the resolver for the webhooks field.
+func (r *queryResolver) webhooks(ctx context.Context) ([]*model.Webhook, error) {
+	resp, err := r.NotificationClient.ListWebhooks(ctx, &notificationpb.ListWebhooksRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhooks: %w", err)
+	}
+
+	webhooks := make([]*model.Webhook, len(resp.Webhooks))
+	for i, w := range resp.Webhooks {
+		webhooks[i] = helpers.ProtoWebhookToModel(w)
+	}
+
+	return webhooks, nil
+}
+
+// PushPreferences is the resolver for the pushPreferences field.
+func (r *queryResolver) pushPreferences(ctx context.Context) ([]*model.PushPreference, error) {
+	resp, err := r.NotificationClient.GetPushPreferences(ctx, &notificationpb.GetPushPreferencesRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get push preferences: %w", err)
+	}
+
+	return helpers.ProtoPushPreferencesToModel(resp), nil
+}
+
+// UnreadNotificationsCount is the resolver for the unreadNotificationsCount field.
+func (r *queryResolver) unreadNotificationsCount(ctx context.Context) (int32, error) {
+	resp, err := r.NotificationClient.GetUnreadCount(ctx, &notificationpb.GetUnreadCountRequest{})
+	if err != nil {
+		return 0, fmt.Errorf("failed to get unread notifications count: %w", err)
+	}
+
+	return resp.Count, nil
+}
+
+// IsFollowing is the resolver for the isFollowing field.
+func (r *queryResolver) isFollowing(ctx context.Context, userID uuid.UUID) (bool, error) {
+	principal, ok := auth.FromContext(ctx)
+	if !ok {
+		return false, auth.ErrUnauthenticated
+	}
+
+	resp, err := r.FollowClient.IsFollowing(ctx, &followpb.IsFollowingRequest{
+		FollowerId:  principal.UserID,
+		FollowingId: userID.String(),
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to check follow status: %w", err)
+	}
+	return resp.IsFollowing, nil
+}
+
+// mutualFollowers lists the users the current user follows who follow
+// userID. Users whose account is gone are left out of users but not of the
+// count.
+func (r *queryResolver) mutualFollowers(ctx context.Context, userID uuid.UUID, first *int32) (*model.MutualFollowers, error) {
+	resp, err := r.FollowClient.GetMutualFollowers(ctx, &followpb.GetMutualFollowersRequest{
+		UserId: userID.String(),
+		First:  deref(first),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get mutual followers: %w", err)
+	}
+
+	ids := make([]uuid.UUID, len(resp.UserIds))
+	for i, id := range resp.UserIds {
+		ids[i] = uuid.MustParse(id)
+	}
+
+	loaded, errs := loader.For(ctx, r.UserClient).Users.LoadMany(ctx, ids)
+	users := make([]*model.User, 0, len(loaded))
+	for i, user := range loaded {
+		if errors.Is(errs[i], loader.ErrNotFound) {
+			continue
+		}
+		if errs[i] != nil {
+			return nil, fmt.Errorf("failed to fetch mutual followers: %w", errs[i])
+		}
+		users = append(users, user)
+	}
+
+	return &model.MutualFollowers{
+		Users:      users,
+		TotalCount: resp.TotalCount,
+	}, nil
+}
+
+// maxStatusBatch bounds the users of one followStatus query and the posts of
+// one likeStatus query
+const maxStatusBatch = 100
+
+// FollowStatus is the resolver for the followStatus field. The follow
+// statuses and the counts of all users are fetched with one call each, made
+// concurrently.
+func (r *queryResolver) followStatus(ctx context.Context, userIDs []uuid.UUID) ([]*model.FollowStatus, error) {
+	principal, ok := auth.FromContext(ctx)
+	if !ok {
+		return nil, auth.ErrUnauthenticated
+	}
+	if len(userIDs) > maxStatusBatch {
+		return nil, invalidInput("userIds", fmt.Sprintf("at most %d users may be looked up at once", maxStatusBatch))
+	}
+	if len(userIDs) == 0 {
+		return []*model.FollowStatus{}, nil
+	}
+
+	ids := make([]string, len(userIDs))
+	for i, id := range userIDs {
+		ids[i] = id.String()
+	}
+
+	var (
+		statuses             *followpb.GetFollowStatusResponse
+		counts               *followpb.GetFollowersCountsResponse
+		statusErr, countsErr error
+		wg                   sync.WaitGroup
+	)
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		statuses, statusErr = r.FollowClient.GetFollowStatus(ctx, &followpb.GetFollowStatusRequest{
+			UserId:        principal.UserID,
+			TargetUserIds: ids,
+		})
+	}()
+	go func() {
+		defer wg.Done()
+		counts, countsErr = r.FollowClient.GetFollowersCounts(ctx, &followpb.GetFollowersCountsRequest{UserIds: ids})
+	}()
+	wg.Wait()
+	if statusErr != nil {
+		return nil, fmt.Errorf("failed to get follow status: %w", statusErr)
+	}
+	if countsErr != nil {
+		return nil, fmt.Errorf("failed to get follow counts: %w", countsErr)
+	}
+
+	following := make(map[string]bool, len(statuses.Statuses))
+	for _, s := range statuses.Statuses {
+		following[s.UserId] = s.IsFollowing
+	}
+	byUser := make(map[string]*followpb.UserFollowCounts, len(counts.Counts))
+	for _, c := range counts.Counts {
+		byUser[c.UserId] = c
+	}
+
+	result := make([]*model.FollowStatus, len(userIDs))
+	for i, id := range userIDs {
+		result[i] = &model.FollowStatus{
+			UserID:      id,
+			IsFollowing: following[ids[i]],
+		}
+		if c, ok := byUser[ids[i]]; ok {
+			result[i].FollowersCount = c.FollowersCount
+			result[i].FollowingCount = c.FollowingCount
+		}
+	}
+	return result, nil
+}
+
+// LikeStatus is the resolver for the likeStatus field. All posts are looked
+// up with one call.
+func (r *queryResolver) likeStatus(ctx context.Context, postIDs []uuid.UUID) ([]*model.LikeStatus, error) {
+	principal, ok := auth.FromContext(ctx)
+	if !ok {
+		return nil, auth.ErrUnauthenticated
+	}
+	if len(postIDs) > maxStatusBatch {
+		return nil, invalidInput("postIds", fmt.Sprintf("at most %d posts may be looked up at once", maxStatusBatch))
+	}
+	if len(postIDs) == 0 {
+		return []*model.LikeStatus{}, nil
+	}
+
+	ids := make([]string, len(postIDs))
+	for i, id := range postIDs {
+		ids[i] = id.String()
+	}
+
+	resp, err := r.LikeClient.GetPostLikesByUsers(ctx, &likepb.GetPostLikesByUsersRequest{
+		PostIds: ids,
+		UserId:  principal.UserID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get like status: %w", err)
+	}
+
+	liked := make(map[string]bool, len(resp.Likes))
+	for _, l := range resp.Likes {
+		liked[l.PostId] = l.IsLiked
+	}
+
+	result := make([]*model.LikeStatus, len(postIDs))
+	for i, id := range postIDs {
+		result[i] = &model.LikeStatus{
+			PostID:  id,
+			IsLiked: liked[ids[i]],
+		}
+	}
+	return result, nil
+}
+
+// NotificationPreferences is the resolver for the notificationPreferences field.
+func (r *queryResolver) notificationPreferences(ctx context.Context) (*model.NotificationPreferences, error) {
+	resp, err := r.NotificationClient.GetPreferences(ctx, &notificationpb.GetPreferencesRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notification preferences: %w", err)
+	}
+
+	return helpers.ProtoNotificationPreferencesToModel(resp), nil
+}
+
+// maxPresenceUsers bounds the users of one presence query or
+// presenceChanged subscription, as notification-service does
+const maxPresenceUsers = 100
+
+// Presence is the resolver for the presence field.
+func (r *queryResolver) presence(ctx context.Context, userIDs []uuid.UUID) ([]*model.Presence, error) {
+	if len(userIDs) > maxPresenceUsers {
+		return nil, invalidInput("userIds", fmt.Sprintf("at most %d users may be looked up at once", maxPresenceUsers))
+	}
+
+	ids := make([]string, len(userIDs))
+	for i, id := range userIDs {
+		ids[i] = id.String()
+	}
+
+	resp, err := r.NotificationClient.GetPresence(ctx, &notificationpb.GetPresenceRequest{UserIds: ids})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get presence: %w", err)
+	}
+
+	presences := make([]*model.Presence, len(resp.Presences))
+	for i, p := range resp.Presences {
+		presences[i] = &model.Presence{
+			UserID:     uuid.MustParse(p.UserId),
+			Online:     p.Online,
+			LastSeenAt: helpers.TimestampPtr(p.LastSeenAt),
+		}
+	}
+	return presences, nil
+}
+
+// WebhookDeliveries is the resolver for the webhookDeliveries field.
+func (r *queryResolver) webhookDeliveries(ctx context.Context, webhookID uuid.UUID, first *int32) ([]*model.WebhookDelivery, error) {
+	limit := int32(20)
+	if first != nil && *first > 0 {
+		limit = *first
+	}
+
+	resp, err := r.NotificationClient.GetWebhookDeliveries(ctx, &notificationpb.GetWebhookDeliveriesRequest{
+		WebhookId: webhookID.String(),
+		First:     limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook deliveries: %w", err)
+	}
+
+	deliveries := make([]*model.WebhookDelivery, len(resp.Deliveries))
+	for i, d := range resp.Deliveries {
+		deliveries[i] = helpers.ProtoWebhookDeliveryToModel(d)
+	}
+
+	return deliveries, nil
+}
+
+// Search is the resolver for the search field. Hits from search-service are
+// hydrated from post-service and user-service, so counts are current; hits
+// deleted since they were indexed are skipped.
+func (r *queryResolver) search(ctx context.Context, query string, typeArg *model.SearchType, first *int32, after *string) (*model.SearchResults, error) {
+	searchType := model.SearchTypeAll
+	if typeArg != nil {
+		searchType = *typeArg
+	}
+
+	req := &searchpb.SearchRequest{Query: query, After: after}
+	if first != nil {
+		req.First = *first
+	}
+
+	results := &model.SearchResults{
+		Posts: []*model.PostSearchHit{},
+		Users: []*model.User{},
+	}
+
+	var postHits []*searchpb.PostHit
+	var userHits []*searchpb.UserHit
+	switch searchType {
+	case model.SearchTypePosts:
+		resp, err := r.SearchClient.SearchPosts(ctx, req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to search posts: %w", err)
+		}
+		postHits, results.EndCursor, results.HasNextPage = resp.Hits, resp.EndCursor, resp.HasNextPage
+	case model.SearchTypeUsers:
+		resp, err := r.SearchClient.SearchUsers(ctx, req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to search users: %w", err)
+		}
+		userHits, results.EndCursor, results.HasNextPage = resp.Hits, resp.EndCursor, resp.HasNextPage
+	default:
+		resp, err := r.SearchClient.SearchAll(ctx, req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to search: %w", err)
+		}
+		postHits, userHits = resp.Posts, resp.Users
+	}
+
+	// Fetch the users behind both kinds of hits in one call
+	userIDs := make([]string, 0, len(postHits)+len(userHits))
+	for _, hit := range userHits {
+		userIDs = append(userIDs, hit.UserId)
+	}
+	for _, hit := range postHits {
+		userIDs = append(userIDs, hit.UserId)
+	}
+
+	users := make(map[string]*model.User)
+	if len(userIDs) > 0 {
+		resp, err := r.UserClient.GetUsersByIds(ctx, &userpb.GetUsersByIdsRequest{UserIds: userIDs})
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch users: %w", err)
+		}
+		for _, u := range resp.Users {
+			users[u.Id] = helpers.ProtoUserToModel(u)
+		}
+	}
+
+	for _, hit := range userHits {
+		if user, ok := users[hit.UserId]; ok {
+			results.Users = append(results.Users, user)
+		}
+	}
+
+	for _, hit := range postHits {
+		post, err := r.getPost(ctx, uuid.MustParse(hit.PostId))
+		if err != nil {
+			continue
+		}
+		post.User = users[hit.UserId]
+		results.Posts = append(results.Posts, &model.PostSearchHit{
+			Post:    post,
+			Snippet: hit.Snippet,
+		})
+	}
+
+	return results, nil
+}
+
+func (r *queryResolver) trendingHashtags(ctx context.Context, limit *int32, windowHours *int32) ([]*model.TrendingHashtag, error) {
+	req := &postpb.GetTrendingHashtagsRequest{}
+	if limit != nil {
+		req.Limit = *limit
+	}
+	if windowHours != nil {
+		req.WindowHours = *windowHours
+	}
+
+	resp, err := r.PostClient.GetTrendingHashtags(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get trending hashtags: %w", err)
+	}
+
+	hashtags := make([]*model.TrendingHashtag, len(resp.Hashtags))
+	for i, h := range resp.Hashtags {
+		hashtags[i] = &model.TrendingHashtag{
+			Tag:        h.Tag,
+			PostsCount: h.PostsCount,
+		}
+	}
+	return hashtags, nil
+}
+
+// drafts lists the current user's drafts and scheduled posts
+func (r *queryResolver) drafts(ctx context.Context, first *int32, after *string) (*model.PostConnection, error) {
+	req := &postpb.ListDraftsRequest{}
+	if first != nil {
+		req.First = *first
+	}
+	if after != nil && *after != "" {
+		req.After = after
+	}
+
+	resp, err := r.PostClient.ListDrafts(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list drafts: %w", err)
+	}
+
+	edges := make([]*model.PostEdge, len(resp.Edges))
+	for i, e := range resp.Edges {
+		edges[i] = &model.PostEdge{
+			Cursor: e.Cursor,
+			Node:   helpers.ProtoPostToModel(e.Node),
+		}
+	}
+
+	pageInfo := &model.PageInfo{}
+	if resp.PageInfo != nil {
+		pageInfo.EndCursor = resp.PageInfo.EndCursor
+		pageInfo.HasNextPage = resp.PageInfo.HasNextPage
+		pageInfo.StartCursor = resp.PageInfo.StartCursor
+		pageInfo.HasPreviousPage = resp.PageInfo.HasPreviousPage
+	}
+
+	return &model.PostConnection{
+		Edges:      edges,
+		PageInfo:   pageInfo,
+		TotalCount: resp.TotalCount,
+	}, nil
+}
+
+func (r *queryResolver) postsByHashtag(ctx context.Context, tag string, first *int32, after *string) (*model.PostConnection, error) {
+	req := &postpb.GetPostsByHashtagRequest{Tag: tag}
+	if first != nil {
+		req.First = *first
+	}
+	if after != nil && *after != "" {
+		req.After = after
+	}
+
+	resp, err := r.PostClient.GetPostsByHashtag(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get posts by hashtag: %w", err)
+	}
+
+	edges := make([]*model.PostEdge, len(resp.Edges))
+	for i, e := range resp.Edges {
+		edges[i] = &model.PostEdge{
+			Cursor: e.Cursor,
+			Node:   helpers.ProtoPostToModel(e.Node),
+		}
+	}
+
+	pageInfo := &model.PageInfo{}
+	if resp.PageInfo != nil {
+		pageInfo.EndCursor = resp.PageInfo.EndCursor
+		pageInfo.HasNextPage = resp.PageInfo.HasNextPage
+		pageInfo.StartCursor = resp.PageInfo.StartCursor
+		pageInfo.HasPreviousPage = resp.PageInfo.HasPreviousPage
+	}
+
+	return &model.PostConnection{
+		Edges:      edges,
+		PageInfo:   pageInfo,
+		TotalCount: resp.TotalCount,
+	}, nil
+}
+
+// reports lists reports for admins to review
+func (r *queryResolver) reports(ctx context.Context, status *model.ReportStatus, reason *model.ReportReason, targetType *model.ModerationTarget, limit *int32) ([]*model.Report, error) {
+	req := &moderationpb.ListReportsRequest{}
+	if status != nil {
+		s := moderationpb.ReportStatus(moderationpb.ReportStatus_value["REPORT_STATUS_"+string(*status)])
+		req.Status = &s
+	}
+	if reason != nil {
+		rr := helpers.ReportReasonToProto(*reason)
+		req.Reason = &rr
+	}
+	if targetType != nil {
+		t := helpers.ModerationTargetToProto(*targetType)
+		req.TargetType = &t
+	}
+	if limit != nil {
+		req.Limit = *limit
+	}
+
+	resp, err := r.ModerationClient.ListReports(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reports: %w", err)
+	}
+
+	reports := make([]*model.Report, len(resp.Reports))
+	for i, report := range resp.Reports {
+		reports[i] = helpers.ProtoReportToModel(report)
+	}
+	return reports, nil
+}
+
+// moderationAuditLog lists the actions admins have taken
+func (r *queryResolver) moderationAuditLog(ctx context.Context, adminID *uuid.UUID, targetType *model.ModerationTarget, targetID *uuid.UUID, limit *int32) ([]*model.ModerationAction, error) {
+	req := &moderationpb.ListAuditLogRequest{}
+	if adminID != nil {
+		id := adminID.String()
+		req.AdminId = &id
+	}
+	if targetType != nil {
+		t := helpers.ModerationTargetToProto(*targetType)
+		req.TargetType = &t
+	}
+	if targetID != nil {
+		id := targetID.String()
+		req.TargetId = &id
+	}
+	if limit != nil {
+		req.Limit = *limit
+	}
+
+	resp, err := r.ModerationClient.ListAuditLog(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit log: %w", err)
+	}
+
+	actions := make([]*model.ModerationAction, len(resp.Actions))
+	for i, action := range resp.Actions {
+		actions[i] = helpers.ProtoModerationActionToModel(action)
+	}
+	return actions, nil
+}
+
+// auditLog pages through the operations recorded by audit-service
+func (r *queryResolver) auditLog(ctx context.Context, actorID *uuid.UUID, targetType *string, targetID *string, actions []string, service *string, ipAddress *string, since *string, until *string, first *int32, after *string) (*model.AuditEntryConnection, error) {
+	req := &auditpb.GetAuditLogRequest{
+		TargetType: targetType,
+		TargetId:   targetID,
+		Actions:    actions,
+		Service:    service,
+		IpAddress:  ipAddress,
+	}
+	if actorID != nil {
+		id := actorID.String()
+		req.ActorId = &id
+	}
+	if since != nil {
+		t, err := time.Parse(time.RFC3339, *since)
+		if err != nil {
+			return nil, invalidInput("since", "since must be an RFC 3339 time")
+		}
+		req.Since = timestamppb.New(t)
+	}
+	if until != nil {
+		t, err := time.Parse(time.RFC3339, *until)
+		if err != nil {
+			return nil, invalidInput("until", "until must be an RFC 3339 time")
+		}
+		req.Until = timestamppb.New(t)
+	}
+	if first != nil {
+		req.First = *first
+	}
+	if after != nil && *after != "" {
+		req.After = after
+	}
+
+	resp, err := r.AuditClient.GetAuditLog(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get audit log: %w", err)
+	}
+
+	edges := make([]*model.AuditEntryEdge, len(resp.Edges))
+	for i, e := range resp.Edges {
+		edges[i] = &model.AuditEntryEdge{
+			Cursor: e.Cursor,
+			Node:   helpers.ProtoAuditEntryToModel(e.Node),
+		}
+	}
+
+	pageInfo := &model.PageInfo{}
+	if resp.PageInfo != nil {
+		pageInfo.EndCursor = resp.PageInfo.EndCursor
+		pageInfo.HasNextPage = resp.PageInfo.HasNextPage
+		pageInfo.StartCursor = resp.PageInfo.StartCursor
+		pageInfo.HasPreviousPage = resp.PageInfo.HasPreviousPage
+	}
+
+	return &model.AuditEntryConnection{
+		Edges:    edges,
+		PageInfo: pageInfo,
+	}, nil
+}
@@ -6,6 +6,7 @@ package graph
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"time"
@@ -13,18 +14,30 @@ import (
 	"github.com/google/uuid"
 	"github.com/nats-io/nats.go"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
-	"google.golang.org/grpc/metadata"
-
+	"google.golang.org/grpc/backoff"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+
+	"api-gateway/auth"
+	"api-gateway/cache"
+	"api-gateway/config"
 	"api-gateway/graph/helpers"
 	"api-gateway/graph/model"
+	"api-gateway/loader"
+	"api-gateway/resilience"
+	auditpb "audit-service/pb"
 	authpb "auth-service/pb"
 	commentpb "comment-service/pb"
 	feedpb "feed-service/pb"
 	followpb "follow-service/pb"
 	likepb "like-service/pb"
+	moderationpb "moderation-service/pb"
 	notificationpb "notification-service/pb"
 	postpb "post-service/pb"
+	searchpb "search-service/pb"
+	"shared/logging"
+	"shared/tracing"
 	userpb "user-service/pb"
 )
 
@@ -38,53 +51,105 @@ type Resolver struct {
 	FollowClient       followpb.FollowServiceClient
 	NotificationClient notificationpb.NotificationServiceClient
 	FeedClient         feedpb.FeedServiceClient
+	SearchClient       searchpb.SearchServiceClient
+	ModerationClient   moderationpb.ModerationServiceClient
+	AuditClient        auditpb.AuditServiceClient
 	NatsConn           *nats.Conn
+	// Cache holds public queries of anonymous callers; nil disables it
+	Cache *cache.Cache
+	// Backends reported by the healthCheck query
+	Backends []Backend
+}
+
+// Backend is a service whose grpc.health.v1 status the gateway reports
+type Backend struct {
+	Name    string
+	Service string
+	Health  healthpb.HealthClient
 }
 
 // NewResolver initializes gRPC clients and NATS connection
-func NewResolver(ctx context.Context) (*Resolver, error) {
-	dial := func(addr string) (*grpc.ClientConn, error) {
-		conn, err := grpc.Dial(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+func NewResolver(ctx context.Context, cfg *config.Config) (*Resolver, error) {
+	dial := func(name string) (*grpc.ClientConn, error) {
+		svc := cfg.Services[name]
+		creds, err := svc.Credentials()
 		if err != nil {
-			return nil, fmt.Errorf("failed to connect to %s: %w", addr, err)
+			return nil, fmt.Errorf("invalid TLS config of %s service: %w", name, err)
+		}
+
+		conn, err := grpc.Dial(svc.Target(),
+			grpc.WithTransportCredentials(creds),
+			// Spread calls over all instances discovery finds
+			grpc.WithDefaultServiceConfig(`{"loadBalancingConfig": [{"round_robin": {}}]}`),
+			// Give up on connection attempts instead of letting calls wait on
+			// an unreachable service
+			grpc.WithConnectParams(grpc.ConnectParams{
+				Backoff:           backoff.DefaultConfig,
+				MinConnectTimeout: 5 * time.Second,
+			}),
+			// Calls time out, reads are retried and a failing service is
+			// cut off by a circuit breaker
+			resilience.New(name+"-service").DialOption(),
+			// Calls carry the token of the user the request is made for
+			grpc.WithUnaryInterceptor(auth.UnaryClientInterceptor()),
+			grpc.WithStreamInterceptor(auth.StreamClientInterceptor()),
+			// and the trace and request ID of the GraphQL request
+			tracing.DialOption(),
+			grpc.WithChainUnaryInterceptor(logging.UnaryClientInterceptor()),
+			grpc.WithChainStreamInterceptor(logging.StreamClientInterceptor()),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to %s service at %s: %w", name, svc.Target(), err)
 		}
 		return conn, nil
 	}
 
-	authConn, err := dial("auth-service:50051")
+	authConn, err := dial("auth")
+	if err != nil {
+		return nil, err
+	}
+	userConn, err := dial("user")
+	if err != nil {
+		return nil, err
+	}
+	postConn, err := dial("post")
+	if err != nil {
+		return nil, err
+	}
+	commentConn, err := dial("comment")
 	if err != nil {
 		return nil, err
 	}
-	userConn, err := dial("user-service:50052")
+	likeConn, err := dial("like")
 	if err != nil {
 		return nil, err
 	}
-	postConn, err := dial("post-service:50053")
+	followConn, err := dial("follow")
 	if err != nil {
 		return nil, err
 	}
-	commentConn, err := dial("comment-service:50055")
+	notifConn, err := dial("notification")
 	if err != nil {
 		return nil, err
 	}
-	likeConn, err := dial("like-service:50057")
+	feedConn, err := dial("feed")
 	if err != nil {
 		return nil, err
 	}
-	followConn, err := dial("follow-service:50060")
+	searchConn, err := dial("search")
 	if err != nil {
 		return nil, err
 	}
-	notifConn, err := dial("notification-service:50058")
+	moderationConn, err := dial("moderation")
 	if err != nil {
 		return nil, err
 	}
-	feedConn, err := dial("feed-service:50054")
+	auditConn, err := dial("audit")
 	if err != nil {
 		return nil, err
 	}
 
-	nc, err := nats.Connect("nats://nats:4222")
+	nc, err := nats.Connect(cfg.NATSURL)
 	if err != nil {
 		log.Printf("⚠️ Warning: Failed to connect to NATS: %v", err)
 	}
@@ -98,97 +163,217 @@ func NewResolver(ctx context.Context) (*Resolver, error) {
 		FollowClient:       followpb.NewFollowServiceClient(followConn),
 		NotificationClient: notificationpb.NewNotificationServiceClient(notifConn),
 		FeedClient:         feedpb.NewFeedServiceClient(feedConn),
+		SearchClient:       searchpb.NewSearchServiceClient(searchConn),
+		ModerationClient:   moderationpb.NewModerationServiceClient(moderationConn),
+		AuditClient:        auditpb.NewAuditServiceClient(auditConn),
 		NatsConn:           nc,
+		Backends: []Backend{
+			{"AuthService", authpb.AuthService_ServiceDesc.ServiceName, healthpb.NewHealthClient(authConn)},
+			{"UserService", userpb.UserService_ServiceDesc.ServiceName, healthpb.NewHealthClient(userConn)},
+			{"PostService", postpb.PostService_ServiceDesc.ServiceName, healthpb.NewHealthClient(postConn)},
+			{"CommentService", commentpb.CommentService_ServiceDesc.ServiceName, healthpb.NewHealthClient(commentConn)},
+			{"LikeService", likepb.LikeService_ServiceDesc.ServiceName, healthpb.NewHealthClient(likeConn)},
+			{"FollowService", followpb.FollowService_ServiceDesc.ServiceName, healthpb.NewHealthClient(followConn)},
+			{"NotificationService", notificationpb.NotificationService_ServiceDesc.ServiceName, healthpb.NewHealthClient(notifConn)},
+			{"FeedService", feedpb.FeedService_ServiceDesc.ServiceName, healthpb.NewHealthClient(feedConn)},
+			{"SearchService", searchpb.SearchService_ServiceDesc.ServiceName, healthpb.NewHealthClient(searchConn)},
+			{"ModerationService", moderationpb.ModerationService_ServiceDesc.ServiceName, healthpb.NewHealthClient(moderationConn)},
+			{"AuditService", auditpb.AuditService_ServiceDesc.ServiceName, healthpb.NewHealthClient(auditConn)},
+		},
 	}, nil
 }
 
-// Adds the JWT token from context to gRPC metadata
-func (r *Resolver) getAuthContext(ctx context.Context) context.Context {
-	token, ok := ctx.Value("token").(string)
-	if !ok || token == "" {
-		return ctx
+// loadUser fetches a user through the request's user loader, so the users of
+// a whole list are fetched in one call
+func (r *Resolver) loadUser(ctx context.Context, userID uuid.UUID) (*model.User, error) {
+	user, err := loader.For(ctx, r.UserClient).Users.Load(ctx, userID)
+	if errors.Is(err, loader.ErrNotFound) {
+		return nil, fmt.Errorf("user %s not found", userID)
 	}
-	return metadata.AppendToOutgoingContext(ctx, "authorization", token)
+	return user, err
 }
 
+// authorOf returns the author of a post or comment, loading it unless the
+// resolver that built the object already did
+func (r *Resolver) authorOf(ctx context.Context, user *model.User, userID uuid.UUID) (*model.User, error) {
+	if user != nil {
+		return user, nil
+	}
+	return r.loadUser(ctx, userID)
+}
+
+// optionalAuthorOf is authorOf for the nullable author field, where an
+// author that no longer exists resolves to null instead of failing the field
+func (r *Resolver) optionalAuthorOf(ctx context.Context, user *model.User, userID uuid.UUID) (*model.User, error) {
+	if user != nil {
+		return user, nil
+	}
+	return r.actorOf(ctx, &userID)
+}
+
+// actorOf returns the user who did something, such as the actor of a
+// notification or the filer of a report; users that no longer exist are left
+// out
+func (r *Resolver) actorOf(ctx context.Context, actorID *uuid.UUID) (*model.User, error) {
+	if actorID == nil {
+		return nil, nil
+	}
+
+	user, err := loader.For(ctx, r.UserClient).Users.Load(ctx, *actorID)
+	if errors.Is(err, loader.ErrNotFound) {
+		return nil, nil
+	}
+	return user, err
+}
+
+// feedStreamTimeout bounds a streamed feed page like GRPC_CALL_TIMEOUT's
+// default bounds unary calls
+const feedStreamTimeout = 5 * time.Second
+
 // GetFeed implements cursor-based pagination for feed posts (uses FeedService)
-func (r *Resolver) getFeed(ctx context.Context, first *int32, after *string) (*model.PostConnection, error) {
+func (r *Resolver) getFeed(ctx context.Context, first *int32, after *string, excludeSeen *bool) (*model.PostConnection, error) {
 	limit := 10
 	if first != nil && *first > 0 {
 		limit = int(*first)
 	}
 
-	afterTime, err := helpers.ParseCursor(after)
-	if err != nil {
-		return nil, fmt.Errorf("invalid pagination cursor: %w", err)
+	req := &feedpb.StreamFeedRequest{
+		First:       int32(limit),
+		ExcludeSeen: excludeSeen != nil && *excludeSeen,
 	}
-
-	req := &feedpb.GetFeedRequest{
-		First: int32(limit + 1),
-	}
-	if afterTime != nil {
-		afterStr := afterTime.Format(time.RFC3339)
-		req.After = &afterStr
+	// Cursors are opaque and signed by the owning service; pass them through as-is
+	if after != nil && *after != "" {
+		req.After = after
 	}
 
-	resp, err := r.FeedClient.GetFeed(r.getAuthContext(ctx), req)
+	// Streams get no call timeout from the resilience layer
+	ctx, cancel := context.WithTimeout(ctx, feedStreamTimeout)
+	defer cancel()
+
+	stream, err := r.FeedClient.StreamFeed(ctx, req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch feed from FeedService: %w", err)
 	}
 
-	edges := make([]*model.PostEdge, len(resp.Edges))
-	for i, e := range resp.Edges {
-		edges[i] = &model.PostEdge{
-			Cursor: e.Node.CreatedAt.String(),
-			Node: &model.Post{
-				ID:         uuid.MustParse(e.Node.Id),
-				UserID:     uuid.MustParse(e.Node.UserId),
-				Content:    e.Node.Content,
-				CreatedAt:  e.Node.CreatedAt.String(),
-				LikesCount: int32(e.Node.LikesCount),
-			},
+	// The authors of each chunk are fetched while later chunks are still
+	// being hydrated, so they are loaded by the time the posts' user fields
+	// are resolved
+	users := loader.For(ctx, r.UserClient).Users
+	var edges []*model.PostEdge
+	var resp *feedpb.FeedChunk
+	for {
+		resp, err = stream.Recv()
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch feed from FeedService: %w", err)
+		}
+
+		chunk := feedPostEdges(resp.Edges)
+		authorIDs := make([]uuid.UUID, len(chunk))
+		for i, edge := range chunk {
+			authorIDs[i] = edge.Node.UserID
+		}
+		users.Prefetch(ctx, authorIDs)
+		edges = append(edges, chunk...)
+
+		if resp.PageInfo != nil {
+			break
 		}
 	}
 
+	// feed-service pages the feed itself and knows whether more posts follow
 	var endCursor *string
-	hasNextPage := false
 	if len(edges) > 0 {
 		last := edges[len(edges)-1].Cursor
 		endCursor = &last
-		hasNextPage = len(edges) > limit
+	}
+
+	var rankingStrategy *string
+	if resp.RankingStrategy != "" {
+		rankingStrategy = &resp.RankingStrategy
 	}
 
 	return &model.PostConnection{
 		Edges: edges,
 		PageInfo: &model.PageInfo{
 			EndCursor:   endCursor,
-			HasNextPage: hasNextPage,
+			HasNextPage: resp.PageInfo.HasNextPage,
 		},
+		RankingStrategy: rankingStrategy,
 	}, nil
 }
 
-// GetUserPosts implements cursor-based pagination for a user's posts
-func (r *Resolver) getUserPosts(ctx context.Context, userID uuid.UUID, first *int32, after *string) (*model.PostConnection, error) {
+// exploreFeed pages through posts trending across the network (uses FeedService)
+func (r *Resolver) exploreFeed(ctx context.Context, first *int32, after *string) (*model.PostConnection, error) {
 	limit := 10
 	if first != nil && *first > 0 {
 		limit = int(*first)
 	}
 
-	afterTime, err := helpers.ParseCursor(after)
+	req := &feedpb.GetExploreFeedRequest{
+		First: int32(limit),
+	}
+	if after != nil && *after != "" {
+		req.After = after
+	}
+
+	resp, err := r.FeedClient.GetExploreFeed(ctx, req)
 	if err != nil {
-		return nil, fmt.Errorf("invalid cursor: %w", err)
+		return nil, fmt.Errorf("failed to fetch explore feed from FeedService: %w", err)
+	}
+
+	pageInfo := &model.PageInfo{}
+	if resp.PageInfo != nil {
+		pageInfo.EndCursor = resp.PageInfo.EndCursor
+		pageInfo.HasNextPage = resp.PageInfo.HasNextPage
+		pageInfo.StartCursor = resp.PageInfo.StartCursor
+		pageInfo.HasPreviousPage = resp.PageInfo.HasPreviousPage
+	}
+
+	return &model.PostConnection{
+		Edges:    feedPostEdges(resp.Edges),
+		PageInfo: pageInfo,
+	}, nil
+}
+
+// feedPostEdges converts FeedService post edges
+func feedPostEdges(pbEdges []*feedpb.PostEdge) []*model.PostEdge {
+	edges := make([]*model.PostEdge, len(pbEdges))
+	for i, e := range pbEdges {
+		edges[i] = &model.PostEdge{
+			Cursor: e.Cursor,
+			Node: &model.Post{
+				ID:         uuid.MustParse(e.Node.Id),
+				UserID:     uuid.MustParse(e.Node.UserId),
+				Content:    e.Node.Content,
+				CreatedAt:  e.Node.CreatedAt.String(),
+				LikesCount: int32(e.Node.LikesCount),
+				Status:     model.PostStatusPublished,
+				Visibility: helpers.FeedPostVisibilityToModel(e.Node.Visibility),
+				// Feed entries carry no mentions or media
+				Mentions: []*model.Mention{},
+				Media:    []*model.Media{},
+			},
+		}
+	}
+	return edges
+}
+
+// GetUserPosts implements cursor-based pagination for a user's posts
+func (r *Resolver) getUserPosts(ctx context.Context, userID uuid.UUID, first *int32, after *string) (*model.PostConnection, error) {
+	limit := 10
+	if first != nil && *first > 0 {
+		limit = int(*first)
 	}
 
 	req := &postpb.GetUserPostsRequest{
 		UserId: userID.String(),
 		First:  int32(limit + 1),
 	}
-	if afterTime != nil {
-		afterStr := afterTime.Format(time.RFC3339)
-		req.After = &afterStr
+	if after != nil && *after != "" {
+		req.After = after
 	}
 
-	resp, err := r.PostClient.GetUserPosts(r.getAuthContext(ctx), req)
+	resp, err := r.PostClient.GetUserPosts(ctx, req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch user posts: %w", err)
 	}
@@ -196,13 +381,20 @@ func (r *Resolver) getUserPosts(ctx context.Context, userID uuid.UUID, first *in
 	edges := make([]*model.PostEdge, len(resp.Edges))
 	for i, e := range resp.Edges {
 		edges[i] = &model.PostEdge{
-			Cursor: e.Node.CreatedAt.String(),
+			Cursor: e.Cursor,
 			Node: &model.Post{
-				ID:         uuid.MustParse(e.Node.Id),
-				UserID:     uuid.MustParse(e.Node.UserId),
-				Content:    e.Node.Content,
-				CreatedAt:  e.Node.CreatedAt.String(),
-				LikesCount: int32(e.Node.LikesCount),
+				ID:           uuid.MustParse(e.Node.Id),
+				UserID:       uuid.MustParse(e.Node.UserId),
+				Content:      e.Node.Content,
+				CreatedAt:    e.Node.CreatedAt.String(),
+				LikesCount:   int32(e.Node.LikesCount),
+				RepostsCount: int32(e.Node.RepostsCount),
+				Mentions:     helpers.ProtoPostMentionsToModel(e.Node.Mentions),
+				Media:        helpers.ProtoMediaToModel(e.Node.Media),
+				QuotedPost:   helpers.ProtoPostToModel(e.Node.QuotedPost),
+				IsEdited:     e.Node.EditedAt != nil,
+				Status:       helpers.ProtoPostStatusToModel(e.Node.Status),
+				Visibility:   helpers.ProtoPostVisibilityToModel(e.Node.Visibility),
 			},
 		}
 	}
@@ -231,21 +423,18 @@ func (r *Resolver) getPostComments(ctx context.Context, postID uuid.UUID, first
 		limit = int(*first)
 	}
 
-	afterTime, err := helpers.ParseCursor(after)
-	if err != nil {
-		return nil, fmt.Errorf("invalid cursor: %w", err)
-	}
-
 	req := &commentpb.GetPostCommentsRequest{
 		PostId: postID.String(),
 		First:  int32(limit + 1),
 	}
-	if afterTime != nil {
-		afterStr := afterTime.Format(time.RFC3339)
-		req.After = &afterStr
+	if after != nil && *after != "" {
+		req.After = after
+	}
+	if principal, ok := auth.FromContext(ctx); ok {
+		req.RequestingUserId = &principal.UserID
 	}
 
-	resp, err := r.CommentClient.GetPostComments(r.getAuthContext(ctx), req)
+	resp, err := r.CommentClient.GetPostComments(ctx, req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch comments: %w", err)
 	}
@@ -253,13 +442,16 @@ func (r *Resolver) getPostComments(ctx context.Context, postID uuid.UUID, first
 	edges := make([]*model.CommentEdge, len(resp.Edges))
 	for i, e := range resp.Edges {
 		edges[i] = &model.CommentEdge{
-			Cursor: e.Node.CreatedAt.String(),
+			Cursor: e.Cursor,
 			Node: &model.Comment{
-				ID:        uuid.MustParse(e.Node.Id),
-				PostID:    uuid.MustParse(e.Node.PostId),
-				UserID:    uuid.MustParse(e.Node.UserId),
-				Content:   e.Node.Content,
-				CreatedAt: e.Node.CreatedAt.String(),
+				ID:           uuid.MustParse(e.Node.Id),
+				PostID:       uuid.MustParse(e.Node.PostId),
+				UserID:       uuid.MustParse(e.Node.UserId),
+				Content:      e.Node.Content,
+				Mentions:     helpers.ProtoCommentMentionsToModel(e.Node.Mentions),
+				RepliesCount: e.Node.RepliesCount,
+				CreatedAt:    e.Node.CreatedAt.String(),
+				DeletedAt:    helpers.TimestampPtr(e.Node.DeletedAt),
 			},
 		}
 	}
@@ -281,41 +473,157 @@ func (r *Resolver) getPostComments(ctx context.Context, postID uuid.UUID, first
 	}, nil
 }
 
-func (r *Resolver) getFollowers(ctx context.Context, userID uuid.UUID, first *int32, after *string) (*model.FollowConnection, error) {
+// getCommentReplies pages through the replies to a comment
+func (r *Resolver) getCommentReplies(ctx context.Context, commentID uuid.UUID, first *int32, after *string) (*model.CommentConnection, error) {
+	limit := 5
+	if first != nil && *first > 0 {
+		limit = int(*first)
+	}
+
+	req := &commentpb.GetCommentRepliesRequest{
+		CommentId: commentID.String(),
+		First:     int32(limit),
+	}
+	if after != nil && *after != "" {
+		req.After = after
+	}
+	if principal, ok := auth.FromContext(ctx); ok {
+		req.RequestingUserId = &principal.UserID
+	}
+
+	resp, err := r.CommentClient.GetCommentReplies(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch replies: %w", err)
+	}
+
+	edges := make([]*model.CommentEdge, len(resp.Edges))
+	for i, e := range resp.Edges {
+		reply := e.Node
+		edges[i] = &model.CommentEdge{
+			Cursor: e.Cursor,
+			Node: &model.Comment{
+				ID:              uuid.MustParse(reply.Id),
+				PostID:          uuid.MustParse(reply.PostId),
+				UserID:          uuid.MustParse(reply.UserId),
+				Content:         reply.Content,
+				Mentions:        helpers.ProtoCommentMentionsToModel(reply.Mentions),
+				ParentCommentID: helpers.ParseUUIDPtr(reply.GetParentCommentId()),
+				RepliesCount:    reply.RepliesCount,
+				CreatedAt:       reply.CreatedAt.String(),
+				UpdatedAt:       reply.UpdatedAt.String(),
+				DeletedAt:       helpers.TimestampPtr(reply.DeletedAt),
+			},
+		}
+	}
+
+	return &model.CommentConnection{
+		Edges: edges,
+		PageInfo: &model.PageInfo{
+			EndCursor:       resp.PageInfo.EndCursor,
+			HasNextPage:     resp.PageInfo.HasNextPage,
+			StartCursor:     resp.PageInfo.StartCursor,
+			HasPreviousPage: resp.PageInfo.HasPreviousPage,
+		},
+		TotalCount: resp.TotalCount,
+	}, nil
+}
+
+// getPostRevisions pages through the earlier versions of a post
+func (r *Resolver) getPostRevisions(ctx context.Context, postID uuid.UUID, first *int32, after *string) (*model.PostRevisionConnection, error) {
 	limit := 10
 	if first != nil && *first > 0 {
 		limit = int(*first)
 	}
 
-	afterTime, err := helpers.ParseCursor(after)
+	req := &postpb.GetPostRevisionsRequest{
+		PostId: postID.String(),
+		First:  int32(limit),
+	}
+	if after != nil && *after != "" {
+		req.After = after
+	}
+	if principal, ok := auth.FromContext(ctx); ok {
+		req.RequestingUserId = &principal.UserID
+	}
+
+	resp, err := r.PostClient.GetPostRevisions(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch post revisions: %w", err)
+	}
+
+	edges := make([]*model.PostRevisionEdge, len(resp.Edges))
+	for i, e := range resp.Edges {
+		edges[i] = &model.PostRevisionEdge{
+			Cursor: e.Cursor,
+			Node: &model.PostRevision{
+				ID:       uuid.MustParse(e.Node.Id),
+				PostID:   uuid.MustParse(e.Node.PostId),
+				EditorID: uuid.MustParse(e.Node.EditorId),
+				Content:  e.Node.Content,
+				EditedAt: e.Node.EditedAt.AsTime().Format(time.RFC3339),
+			},
+		}
+	}
+
+	return &model.PostRevisionConnection{
+		Edges: edges,
+		PageInfo: &model.PageInfo{
+			EndCursor:       resp.PageInfo.EndCursor,
+			HasNextPage:     resp.PageInfo.HasNextPage,
+			StartCursor:     resp.PageInfo.StartCursor,
+			HasPreviousPage: resp.PageInfo.HasPreviousPage,
+		},
+		TotalCount: resp.TotalCount,
+	}, nil
+}
+
+// getPoll returns the poll of a post, or nil when it has none
+func (r *Resolver) getPoll(ctx context.Context, postID uuid.UUID) (*model.Poll, error) {
+	req := &postpb.GetPollResultsRequest{PostId: postID.String()}
+	if principal, ok := auth.FromContext(ctx); ok {
+		req.RequestingUserId = &principal.UserID
+	}
+
+	resp, err := r.PostClient.GetPollResults(ctx, req)
 	if err != nil {
-		return nil, fmt.Errorf("invalid cursor: %w", err)
+		if status.Code(err) == codes.NotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to fetch poll: %w", err)
+	}
+
+	return helpers.ProtoPollToModel(resp), nil
+}
+
+func (r *Resolver) getFollowers(ctx context.Context, userID uuid.UUID, first *int32, after *string) (*model.FollowConnection, error) {
+	limit := 10
+	if first != nil && *first > 0 {
+		limit = int(*first)
 	}
 
 	req := &followpb.GetFollowersRequest{
 		UserId: userID.String(),
 		First:  int32(limit + 1),
 	}
-	if afterTime != nil {
-		afterStr := afterTime.Format(time.RFC3339)
-		req.After = &afterStr
+	if after != nil && *after != "" {
+		req.After = after
 	}
 
-	resp, err := r.FollowClient.GetFollowers(r.getAuthContext(ctx), req)
+	resp, err := r.FollowClient.GetFollowers(ctx, req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch followers: %w", err)
 	}
 
 	edges := make([]*model.FollowEdge, len(resp.Edges))
 	for i, e := range resp.Edges {
-		cursor := e.FollowedAt.AsTime().Format(time.RFC3339)
+		followedAt := e.FollowedAt.AsTime().Format(time.RFC3339)
 
 		edges[i] = &model.FollowEdge{
-			Cursor:     cursor,
-			FollowedAt: cursor,
+			Cursor:     e.Cursor,
+			FollowedAt: followedAt,
 			Node: &model.User{
 				ID:        uuid.MustParse(e.UserId),
-				CreatedAt: cursor,
+				CreatedAt: followedAt,
 			},
 		}
 	}
@@ -351,35 +659,29 @@ func (r *Resolver) getFollowing(ctx context.Context, userID uuid.UUID, first *in
 		limit = int(*first)
 	}
 
-	afterTime, err := helpers.ParseCursor(after)
-	if err != nil {
-		return nil, fmt.Errorf("invalid cursor: %w", err)
-	}
-
 	req := &followpb.GetFollowingRequest{
 		UserId: userID.String(),
 		First:  int32(limit + 1),
 	}
-	if afterTime != nil {
-		afterStr := afterTime.Format(time.RFC3339)
-		req.After = &afterStr
+	if after != nil && *after != "" {
+		req.After = after
 	}
 
-	resp, err := r.FollowClient.GetFollowing(r.getAuthContext(ctx), req)
+	resp, err := r.FollowClient.GetFollowing(ctx, req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch following: %w", err)
 	}
 
 	edges := make([]*model.FollowEdge, len(resp.Edges))
 	for i, e := range resp.Edges {
-		cursor := e.FollowedAt.AsTime().Format(time.RFC3339)
+		followedAt := e.FollowedAt.AsTime().Format(time.RFC3339)
 
 		edges[i] = &model.FollowEdge{
-			Cursor:     cursor,
-			FollowedAt: cursor,
+			Cursor:     e.Cursor,
+			FollowedAt: followedAt,
 			Node: &model.User{
 				ID:        uuid.MustParse(e.UserId),
-				CreatedAt: e.FollowedAt.AsTime().Format(time.RFC3339),
+				CreatedAt: followedAt,
 			},
 		}
 	}
@@ -409,36 +711,111 @@ func (r *Resolver) getFollowing(ctx context.Context, userID uuid.UUID, first *in
 	}, nil
 }
 
-func (r *Resolver) getNotifications(ctx context.Context, first *int32, after *string) (*model.NotificationConnection, error) {
+// followRequests lists the users waiting for the current user to approve
+// their follow request
+func (r *Resolver) followRequests(ctx context.Context, first *int32, after *string) (*model.FollowConnection, error) {
+	limit := 10
+	if first != nil && *first > 0 {
+		limit = int(*first)
+	}
+
+	req := &followpb.ListFollowRequestsRequest{
+		First: int32(limit),
+	}
+	if after != nil && *after != "" {
+		req.After = after
+	}
+
+	resp, err := r.FollowClient.ListFollowRequests(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch follow requests: %w", err)
+	}
+
+	edges := make([]*model.FollowEdge, len(resp.Edges))
+	for i, e := range resp.Edges {
+		requestedAt := e.FollowedAt.AsTime().Format(time.RFC3339)
+
+		edges[i] = &model.FollowEdge{
+			Cursor:     e.Cursor,
+			FollowedAt: requestedAt,
+			Node: &model.User{
+				ID:        uuid.MustParse(e.UserId),
+				CreatedAt: requestedAt,
+			},
+		}
+	}
+
+	return &model.FollowConnection{
+		Edges: edges,
+		PageInfo: &model.PageInfo{
+			StartCursor:     resp.PageInfo.StartCursor,
+			EndCursor:       resp.PageInfo.EndCursor,
+			HasNextPage:     resp.PageInfo.HasNextPage,
+			HasPreviousPage: resp.PageInfo.HasPreviousPage,
+		},
+		TotalCount: resp.TotalCount,
+	}, nil
+}
+
+// followSuggestions lists users the current user may want to follow
+func (r *Resolver) followSuggestions(ctx context.Context, first *int32, after *string) (*model.FollowSuggestionConnection, error) {
 	limit := 10
 	if first != nil && *first > 0 {
 		limit = int(*first)
 	}
 
-	afterTime, err := helpers.ParseCursor(after)
+	req := &followpb.GetFollowSuggestionsRequest{
+		First: int32(limit),
+	}
+	if after != nil && *after != "" {
+		req.After = after
+	}
+
+	resp, err := r.FollowClient.GetFollowSuggestions(ctx, req)
 	if err != nil {
-		return nil, fmt.Errorf("invalid cursor: %w", err)
+		return nil, fmt.Errorf("failed to fetch follow suggestions: %w", err)
+	}
+
+	edges := make([]*model.FollowSuggestionEdge, len(resp.Edges))
+	for i, e := range resp.Edges {
+		edges[i] = &model.FollowSuggestionEdge{
+			Cursor:          e.Cursor,
+			Node:            &model.User{ID: uuid.MustParse(e.UserId)},
+			MutualFollows:   e.MutualFollows,
+			SharedInterests: e.SharedInterests,
+		}
+	}
+
+	return &model.FollowSuggestionConnection{
+		Edges: edges,
+		PageInfo: &model.PageInfo{
+			StartCursor:     resp.PageInfo.StartCursor,
+			EndCursor:       resp.PageInfo.EndCursor,
+			HasNextPage:     resp.PageInfo.HasNextPage,
+			HasPreviousPage: resp.PageInfo.HasPreviousPage,
+		},
+	}, nil
+}
+
+func (r *Resolver) getNotifications(ctx context.Context, first *int32, after *string) (*model.NotificationConnection, error) {
+	limit := 10
+	if first != nil && *first > 0 {
+		limit = int(*first)
 	}
 
 	req := &notificationpb.GetNotificationsRequest{
 		First: int32(limit + 1), // fetch one extra to detect next page
 	}
-	if afterTime != nil {
-		afterStr := afterTime.Format(time.RFC3339)
-		req.After = &afterStr
+	if after != nil && *after != "" {
+		req.After = after
 	}
 
-	resp, err := r.NotificationClient.GetNotifications(r.getAuthContext(ctx), req)
+	resp, err := r.NotificationClient.GetNotifications(ctx, req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch notifications: %w", err)
 	}
 
-	notifications := make([]*notificationpb.Notification, len(resp.Edges))
-	for i, edge := range resp.Edges {
-		notifications[i] = edge.Node
-	}
-
-	return helpers.BuildNotificationConnection(notifications, resp.UnreadCount, limit), nil
+	return helpers.BuildNotificationConnection(resp.Edges, resp.UnreadCount, limit), nil
 }
 
 // Publishes a notification message to NATS
@@ -7,10 +7,42 @@ package graph
 import (
 	"api-gateway/graph/model"
 	"context"
+	userpb "user-service/pb"
 
+	"github.com/99designs/gqlgen/graphql"
 	"github.com/google/uuid"
 )
 
+// Actor is the resolver for the actor field.
+func (r *auditEntryResolver) Actor(ctx context.Context, obj *model.AuditEntry) (*model.User, error) {
+	return r.actorOf(ctx, obj.ActorID)
+}
+
+// User is the resolver for the user field.
+func (r *commentResolver) User(ctx context.Context, obj *model.Comment) (*model.User, error) {
+	return r.authorOf(ctx, obj.User, obj.UserID)
+}
+
+// Author is the resolver for the author field.
+func (r *commentResolver) Author(ctx context.Context, obj *model.Comment) (*model.User, error) {
+	return r.optionalAuthorOf(ctx, obj.User, obj.UserID)
+}
+
+// Replies is the resolver for the replies field.
+func (r *commentResolver) Replies(ctx context.Context, obj *model.Comment, first *int32, after *string) (*model.CommentConnection, error) {
+	return r.getCommentReplies(ctx, obj.ID, first, after)
+}
+
+// Node is the resolver for the node field.
+func (r *followEdgeResolver) Node(ctx context.Context, obj *model.FollowEdge) (*model.User, error) {
+	return r.loadUser(ctx, obj.Node.ID)
+}
+
+// Admin is the resolver for the admin field.
+func (r *moderationActionResolver) Admin(ctx context.Context, obj *model.ModerationAction) (*model.User, error) {
+	return r.actorOf(ctx, &obj.AdminID)
+}
+
 // Register is the resolver for the register field.
 func (r *mutationResolver) Register(ctx context.Context, input model.RegisterInput) (*model.AuthResponse, error) {
 	return r.register(ctx, input)
@@ -21,6 +53,11 @@ func (r *mutationResolver) Login(ctx context.Context, input model.LoginInput) (*
 	return r.login(ctx, input)
 }
 
+// LoginWithOAuth is the resolver for the loginWithOAuth field.
+func (r *mutationResolver) LoginWithOAuth(ctx context.Context, provider model.OAuthProvider, code string, redirectURI *string) (*model.AuthResponse, error) {
+	return r.loginWithOAuth(ctx, provider, code, redirectURI)
+}
+
 // RefreshToken is the resolver for the refreshToken field.
 func (r *mutationResolver) RefreshToken(ctx context.Context, refreshToken string) (*model.AuthResponse, error) {
 	return r.refreshToken(ctx, refreshToken)
@@ -36,11 +73,36 @@ func (r *mutationResolver) UpdateProfile(ctx context.Context, input model.Update
 	return r.updateProfile(ctx, input)
 }
 
+// UpdateAvatar is the resolver for the updateAvatar field.
+func (r *mutationResolver) UpdateAvatar(ctx context.Context, file graphql.Upload) (*model.ProfileImage, error) {
+	return r.uploadProfileImage(ctx, file, userpb.ProfileImageKind_PROFILE_IMAGE_KIND_AVATAR)
+}
+
+// UpdateCoverImage is the resolver for the updateCoverImage field.
+func (r *mutationResolver) UpdateCoverImage(ctx context.Context, file graphql.Upload) (*model.ProfileImage, error) {
+	return r.uploadProfileImage(ctx, file, userpb.ProfileImageKind_PROFILE_IMAGE_KIND_COVER)
+}
+
 // ChangePassword is the resolver for the changePassword field.
 func (r *mutationResolver) ChangePassword(ctx context.Context, input model.ChangePasswordInput) (*model.Response, error) {
 	return r.changePassword(ctx, input)
 }
 
+// DeleteAccount is the resolver for the deleteAccount field.
+func (r *mutationResolver) DeleteAccount(ctx context.Context, password string) (*model.Response, error) {
+	return r.deleteAccount(ctx, password)
+}
+
+// RevokeSession is the resolver for the revokeSession field.
+func (r *mutationResolver) RevokeSession(ctx context.Context, sessionID uuid.UUID) (*model.Response, error) {
+	return r.revokeSession(ctx, sessionID)
+}
+
+// UploadMedia is the resolver for the uploadMedia field.
+func (r *mutationResolver) UploadMedia(ctx context.Context, file graphql.Upload) (*model.Media, error) {
+	return r.uploadMedia(ctx, file)
+}
+
 // CreatePost is the resolver for the createPost field.
 func (r *mutationResolver) CreatePost(ctx context.Context, input model.CreatePostInput) (*model.Post, error) {
 	return r.createPost(ctx, input)
@@ -56,6 +118,21 @@ func (r *mutationResolver) DeletePost(ctx context.Context, postID uuid.UUID) (*m
 	return r.deletePost(ctx, postID)
 }
 
+// SaveDraft is the resolver for the saveDraft field.
+func (r *mutationResolver) SaveDraft(ctx context.Context, input model.SaveDraftInput) (*model.Post, error) {
+	return r.saveDraft(ctx, input)
+}
+
+// SchedulePost is the resolver for the schedulePost field.
+func (r *mutationResolver) SchedulePost(ctx context.Context, postID uuid.UUID, publishAt *string) (*model.Post, error) {
+	return r.schedulePost(ctx, postID, publishAt)
+}
+
+// VotePoll is the resolver for the votePoll field.
+func (r *mutationResolver) VotePoll(ctx context.Context, postID uuid.UUID, optionID uuid.UUID) (*model.Poll, error) {
+	return r.votePoll(ctx, postID, optionID)
+}
+
 // CreateComment is the resolver for the createComment field.
 func (r *mutationResolver) CreateComment(ctx context.Context, input model.CreateCommentInput) (*model.Comment, error) {
 	return r.createComment(ctx, input)
@@ -81,6 +158,16 @@ func (r *mutationResolver) UnlikePost(ctx context.Context, postID uuid.UUID) (*m
 	return r.unlikePost(ctx, postID)
 }
 
+// Repost is the resolver for the repost field.
+func (r *mutationResolver) Repost(ctx context.Context, postID uuid.UUID) (*model.Response, error) {
+	return r.repost(ctx, postID)
+}
+
+// UndoRepost is the resolver for the undoRepost field.
+func (r *mutationResolver) UndoRepost(ctx context.Context, postID uuid.UUID) (*model.Response, error) {
+	return r.undoRepost(ctx, postID)
+}
+
 // FollowUser is the resolver for the followUser field.
 func (r *mutationResolver) FollowUser(ctx context.Context, userID uuid.UUID) (*model.Response, error) {
 	return r.followUser(ctx, userID)
@@ -91,6 +178,16 @@ func (r *mutationResolver) UnfollowUser(ctx context.Context, userID uuid.UUID) (
 	return r.unfollowUser(ctx, userID)
 }
 
+// ApproveFollowRequest is the resolver for the approveFollowRequest field.
+func (r *mutationResolver) ApproveFollowRequest(ctx context.Context, userID uuid.UUID) (*model.Response, error) {
+	return r.approveFollowRequest(ctx, userID)
+}
+
+// RejectFollowRequest is the resolver for the rejectFollowRequest field.
+func (r *mutationResolver) RejectFollowRequest(ctx context.Context, userID uuid.UUID) (*model.Response, error) {
+	return r.rejectFollowRequest(ctx, userID)
+}
+
 // MarkNotificationRead is the resolver for the markNotificationRead field.
 func (r *mutationResolver) MarkNotificationRead(ctx context.Context, notificationID uuid.UUID) (*model.Response, error) {
 	return r.markNotificationRead(ctx, notificationID)
@@ -101,6 +198,126 @@ func (r *mutationResolver) MarkAllNotificationsRead(ctx context.Context) (*model
 	return r.markAllNotificationsRead(ctx)
 }
 
+// DeleteNotification is the resolver for the deleteNotification field.
+func (r *mutationResolver) DeleteNotification(ctx context.Context, notificationID uuid.UUID) (*model.Response, error) {
+	return r.deleteNotification(ctx, notificationID)
+}
+
+// MarkFeedItemsSeen is the resolver for the markFeedItemsSeen field.
+func (r *mutationResolver) MarkFeedItemsSeen(ctx context.Context, postIds []uuid.UUID) (*model.Response, error) {
+	return r.markFeedItemsSeen(ctx, postIds)
+}
+
+// RegisterWebhook is the resolver for the registerWebhook field.
+func (r *mutationResolver) RegisterWebhook(ctx context.Context, input model.RegisterWebhookInput) (*model.Webhook, error) {
+	return r.registerWebhook(ctx, input)
+}
+
+// DeleteWebhook is the resolver for the deleteWebhook field.
+func (r *mutationResolver) DeleteWebhook(ctx context.Context, webhookID uuid.UUID) (*model.Response, error) {
+	return r.deleteWebhook(ctx, webhookID)
+}
+
+// RegisterDevice is the resolver for the registerDevice field.
+func (r *mutationResolver) RegisterDevice(ctx context.Context, input model.RegisterDeviceInput) (*model.Device, error) {
+	return r.registerDevice(ctx, input)
+}
+
+// UnregisterDevice is the resolver for the unregisterDevice field.
+func (r *mutationResolver) UnregisterDevice(ctx context.Context, token string) (*model.Response, error) {
+	return r.unregisterDevice(ctx, token)
+}
+
+// UpdatePushPreferences is the resolver for the updatePushPreferences field.
+func (r *mutationResolver) UpdatePushPreferences(ctx context.Context, preferences []*model.PushPreferenceInput) ([]*model.PushPreference, error) {
+	return r.updatePushPreferences(ctx, preferences)
+}
+
+// UpdateNotificationPreferences is the resolver for the updateNotificationPreferences field.
+func (r *mutationResolver) UpdateNotificationPreferences(ctx context.Context, input model.NotificationPreferencesInput) (*model.NotificationPreferences, error) {
+	return r.updateNotificationPreferences(ctx, input)
+}
+
+// ReportPost is the resolver for the reportPost field.
+func (r *mutationResolver) ReportPost(ctx context.Context, postID uuid.UUID, reason model.ReportReason, details *string, showReporter *bool) (*model.Report, error) {
+	return r.reportPost(ctx, postID, reason, details, showReporter)
+}
+
+// ReportComment is the resolver for the reportComment field.
+func (r *mutationResolver) ReportComment(ctx context.Context, commentID uuid.UUID, reason model.ReportReason, details *string, showReporter *bool) (*model.Report, error) {
+	return r.reportComment(ctx, commentID, reason, details, showReporter)
+}
+
+// ReportUser is the resolver for the reportUser field.
+func (r *mutationResolver) ReportUser(ctx context.Context, userID uuid.UUID, reason model.ReportReason, details *string, showReporter *bool) (*model.Report, error) {
+	return r.reportUser(ctx, userID, reason, details, showReporter)
+}
+
+// ReviewReport is the resolver for the reviewReport field.
+func (r *mutationResolver) ReviewReport(ctx context.Context, reportID uuid.UUID) (*model.Report, error) {
+	return r.reviewReport(ctx, reportID)
+}
+
+// ResolveReport is the resolver for the resolveReport field.
+func (r *mutationResolver) ResolveReport(ctx context.Context, reportID uuid.UUID, resolution model.ReportResolution, note *string) (*model.Report, error) {
+	return r.resolveReport(ctx, reportID, resolution, note)
+}
+
+// RemovePost is the resolver for the removePost field.
+func (r *mutationResolver) RemovePost(ctx context.Context, postID uuid.UUID, reason string) (*model.ModerationAction, error) {
+	return r.removePost(ctx, postID, reason)
+}
+
+// RemoveComment is the resolver for the removeComment field.
+func (r *mutationResolver) RemoveComment(ctx context.Context, commentID uuid.UUID, reason string) (*model.ModerationAction, error) {
+	return r.removeComment(ctx, commentID, reason)
+}
+
+// SuspendUser is the resolver for the suspendUser field.
+func (r *mutationResolver) SuspendUser(ctx context.Context, userID uuid.UUID, reason string, until *string) (*model.ModerationAction, error) {
+	return r.suspendUser(ctx, userID, reason, until)
+}
+
+// UnsuspendUser is the resolver for the unsuspendUser field.
+func (r *mutationResolver) UnsuspendUser(ctx context.Context, userID uuid.UUID, reason *string) (*model.ModerationAction, error) {
+	return r.unsuspendUser(ctx, userID, reason)
+}
+
+// DismissReports is the resolver for the dismissReports field.
+func (r *mutationResolver) DismissReports(ctx context.Context, targetType model.ModerationTarget, targetID uuid.UUID, reason *string) (*model.ModerationAction, error) {
+	return r.dismissReports(ctx, targetType, targetID, reason)
+}
+
+// SetUserRoles is the resolver for the setUserRoles field.
+func (r *mutationResolver) SetUserRoles(ctx context.Context, userID uuid.UUID, roles []model.Role) ([]model.Role, error) {
+	return r.setUserRoles(ctx, userID, roles)
+}
+
+// Actor is the resolver for the actor field.
+func (r *notificationResolver) Actor(ctx context.Context, obj *model.Notification) (*model.User, error) {
+	return r.actorOf(ctx, obj.ActorID)
+}
+
+// User is the resolver for the user field.
+func (r *postResolver) User(ctx context.Context, obj *model.Post) (*model.User, error) {
+	return r.authorOf(ctx, obj.User, obj.UserID)
+}
+
+// Author is the resolver for the author field.
+func (r *postResolver) Author(ctx context.Context, obj *model.Post) (*model.User, error) {
+	return r.optionalAuthorOf(ctx, obj.User, obj.UserID)
+}
+
+// Poll is the resolver for the poll field.
+func (r *postResolver) Poll(ctx context.Context, obj *model.Post) (*model.Poll, error) {
+	return r.getPoll(ctx, obj.ID)
+}
+
+// EditHistory is the resolver for the editHistory field.
+func (r *postResolver) EditHistory(ctx context.Context, obj *model.Post, first *int32, after *string) (*model.PostRevisionConnection, error) {
+	return r.getPostRevisions(ctx, obj.ID, first, after)
+}
+
 // HealthCheck is the resolver for the healthCheck field.
 func (r *queryResolver) HealthCheck(ctx context.Context) (*model.HealthCheckResponse, error) {
 	return r.healthCheck(ctx)
@@ -111,14 +328,24 @@ func (r *queryResolver) Me(ctx context.Context) (*model.User, error) {
 	return r.me(ctx)
 }
 
+// MySessions is the resolver for the mySessions field.
+func (r *queryResolver) MySessions(ctx context.Context) ([]*model.Session, error) {
+	return r.mySessions(ctx)
+}
+
 // GetProfile is the resolver for the getProfile field.
 func (r *queryResolver) GetProfile(ctx context.Context, userID uuid.UUID) (*model.User, error) {
-	return r.getProfile(ctx, userID)
+	return r.cachedProfile(ctx, userID)
+}
+
+// GetProfileByUsername is the resolver for the getProfileByUsername field.
+func (r *queryResolver) GetProfileByUsername(ctx context.Context, username string) (*model.UsernameLookup, error) {
+	return r.getProfileByUsername(ctx, username)
 }
 
 // GetPost is the resolver for the getPost field.
 func (r *queryResolver) GetPost(ctx context.Context, postID uuid.UUID) (*model.Post, error) {
-	return r.getPost(ctx, postID)
+	return r.cachedPost(ctx, postID)
 }
 
 // GetUserPosts is the resolver for the getUserPosts field.
@@ -127,13 +354,18 @@ func (r *queryResolver) GetUserPosts(ctx context.Context, userID uuid.UUID, firs
 }
 
 // GetFeed is the resolver for the getFeed field.
-func (r *queryResolver) GetFeed(ctx context.Context, first *int32, after *string) (*model.PostConnection, error) {
-	return r.getFeed(ctx, first, after)
+func (r *queryResolver) GetFeed(ctx context.Context, first *int32, after *string, excludeSeen *bool) (*model.PostConnection, error) {
+	return r.getFeed(ctx, first, after, excludeSeen)
+}
+
+// ExploreFeed is the resolver for the exploreFeed field.
+func (r *queryResolver) ExploreFeed(ctx context.Context, first *int32, after *string) (*model.PostConnection, error) {
+	return r.exploreFeed(ctx, first, after)
 }
 
 // GetPostComments is the resolver for the getPostComments field.
 func (r *queryResolver) GetPostComments(ctx context.Context, postID uuid.UUID, first *int32, after *string) (*model.CommentConnection, error) {
-	return r.getPostComments(ctx, postID, first, after)
+	return r.cachedPostComments(ctx, postID, first, after)
 }
 
 // GetPostLikes is the resolver for the getPostLikes field.
@@ -141,6 +373,16 @@ func (r *queryResolver) GetPostLikes(ctx context.Context, postID uuid.UUID) (*mo
 	return r.getPostLikes(ctx, postID)
 }
 
+// PostLikers is the resolver for the postLikers field.
+func (r *queryResolver) PostLikers(ctx context.Context, postID uuid.UUID, first *int32, after *string) (*model.LikerConnection, error) {
+	return r.postLikers(ctx, postID, first, after)
+}
+
+// LikedPosts is the resolver for the likedPosts field.
+func (r *queryResolver) LikedPosts(ctx context.Context, userID uuid.UUID, first *int32, after *string) (*model.LikedPostConnection, error) {
+	return r.likedPosts(ctx, userID, first, after)
+}
+
 // GetFollowers is the resolver for the getFollowers field.
 func (r *queryResolver) GetFollowers(ctx context.Context, userID uuid.UUID, first *int32, after *string) (*model.FollowConnection, error) {
 	return r.getFollowers(ctx, userID, first, after)
@@ -156,11 +398,116 @@ func (r *queryResolver) GetNotifications(ctx context.Context, first *int32, afte
 	return r.getNotifications(ctx, first, after)
 }
 
+// FollowRequests is the resolver for the followRequests field.
+func (r *queryResolver) FollowRequests(ctx context.Context, first *int32, after *string) (*model.FollowConnection, error) {
+	return r.followRequests(ctx, first, after)
+}
+
+// FollowSuggestions is the resolver for the followSuggestions field.
+func (r *queryResolver) FollowSuggestions(ctx context.Context, first *int32, after *string) (*model.FollowSuggestionConnection, error) {
+	return r.followSuggestions(ctx, first, after)
+}
+
+// MutualFollowers is the resolver for the mutualFollowers field.
+func (r *queryResolver) MutualFollowers(ctx context.Context, userID uuid.UUID, first *int32) (*model.MutualFollowers, error) {
+	return r.mutualFollowers(ctx, userID, first)
+}
+
+// IsFollowing is the resolver for the isFollowing field.
+func (r *queryResolver) IsFollowing(ctx context.Context, userID uuid.UUID) (bool, error) {
+	return r.isFollowing(ctx, userID)
+}
+
+// FollowStatus is the resolver for the followStatus field.
+func (r *queryResolver) FollowStatus(ctx context.Context, userIds []uuid.UUID) ([]*model.FollowStatus, error) {
+	return r.followStatus(ctx, userIds)
+}
+
+// LikeStatus is the resolver for the likeStatus field.
+func (r *queryResolver) LikeStatus(ctx context.Context, postIds []uuid.UUID) ([]*model.LikeStatus, error) {
+	return r.likeStatus(ctx, postIds)
+}
+
+// Webhooks is the resolver for the webhooks field.
+func (r *queryResolver) Webhooks(ctx context.Context) ([]*model.Webhook, error) {
+	return r.webhooks(ctx)
+}
+
+// PushPreferences is the resolver for the pushPreferences field.
+func (r *queryResolver) PushPreferences(ctx context.Context) ([]*model.PushPreference, error) {
+	return r.pushPreferences(ctx)
+}
+
+// UnreadNotificationsCount is the resolver for the unreadNotificationsCount field.
+func (r *queryResolver) UnreadNotificationsCount(ctx context.Context) (int32, error) {
+	return r.unreadNotificationsCount(ctx)
+}
+
+// NotificationPreferences is the resolver for the notificationPreferences field.
+func (r *queryResolver) NotificationPreferences(ctx context.Context) (*model.NotificationPreferences, error) {
+	return r.notificationPreferences(ctx)
+}
+
+// Presence is the resolver for the presence field.
+func (r *queryResolver) Presence(ctx context.Context, userIds []uuid.UUID) ([]*model.Presence, error) {
+	return r.presence(ctx, userIds)
+}
+
+// WebhookDeliveries is the resolver for the webhookDeliveries field.
+func (r *queryResolver) WebhookDeliveries(ctx context.Context, webhookID uuid.UUID, first *int32) ([]*model.WebhookDelivery, error) {
+	return r.webhookDeliveries(ctx, webhookID, first)
+}
+
+// Search is the resolver for the search field.
+func (r *queryResolver) Search(ctx context.Context, query string, typeArg *model.SearchType, first *int32, after *string) (*model.SearchResults, error) {
+	return r.search(ctx, query, typeArg, first, after)
+}
+
+// TrendingHashtags is the resolver for the trendingHashtags field.
+func (r *queryResolver) TrendingHashtags(ctx context.Context, limit *int32, windowHours *int32) ([]*model.TrendingHashtag, error) {
+	return r.trendingHashtags(ctx, limit, windowHours)
+}
+
+// PostsByHashtag is the resolver for the postsByHashtag field.
+func (r *queryResolver) PostsByHashtag(ctx context.Context, tag string, first *int32, after *string) (*model.PostConnection, error) {
+	return r.postsByHashtag(ctx, tag, first, after)
+}
+
+// Drafts is the resolver for the drafts field.
+func (r *queryResolver) Drafts(ctx context.Context, first *int32, after *string) (*model.PostConnection, error) {
+	return r.drafts(ctx, first, after)
+}
+
+// Reports is the resolver for the reports field.
+func (r *queryResolver) Reports(ctx context.Context, status *model.ReportStatus, reason *model.ReportReason, targetType *model.ModerationTarget, limit *int32) ([]*model.Report, error) {
+	return r.reports(ctx, status, reason, targetType, limit)
+}
+
+// ModerationAuditLog is the resolver for the moderationAuditLog field.
+func (r *queryResolver) ModerationAuditLog(ctx context.Context, adminID *uuid.UUID, targetType *model.ModerationTarget, targetID *uuid.UUID, limit *int32) ([]*model.ModerationAction, error) {
+	return r.moderationAuditLog(ctx, adminID, targetType, targetID, limit)
+}
+
+// AuditLog is the resolver for the auditLog field.
+func (r *queryResolver) AuditLog(ctx context.Context, actorID *uuid.UUID, targetType *string, targetID *string, actions []string, service *string, ipAddress *string, since *string, until *string, first *int32, after *string) (*model.AuditEntryConnection, error) {
+	return r.auditLog(ctx, actorID, targetType, targetID, actions, service, ipAddress, since, until, first, after)
+}
+
+// Reporter is the resolver for the reporter field.
+func (r *reportResolver) Reporter(ctx context.Context, obj *model.Report) (*model.User, error) {
+	return r.actorOf(ctx, obj.ReporterID)
+}
+
 // NotificationAdded is the resolver for the notificationAdded field.
 func (r *subscriptionResolver) NotificationAdded(ctx context.Context) (<-chan *model.Notification, error) {
 	return r.notificationAdded(ctx)
 }
 
+// NotificationReadStateChanged is the resolver for the notificationReadStateChanged field.
+func (r *subscriptionResolver) NotificationReadStateChanged(ctx context.Context) (<-chan *model.NotificationReadState, error) {
+	return r.notificationReadStateChanged(ctx)
+}
+
 // PostAdded is the resolver for the postAdded field.
 func (r *subscriptionResolver) PostAdded(ctx context.Context, userID uuid.UUID) (<-chan *model.Post, error) {
 	return r.postAdded(ctx, userID)
@@ -171,15 +518,53 @@ func (r *subscriptionResolver) CommentAdded(ctx context.Context, postID uuid.UUI
 	return r.commentAdded(ctx, postID)
 }
 
+// FeedUpdated is the resolver for the feedUpdated field.
+func (r *subscriptionResolver) FeedUpdated(ctx context.Context, after *string) (<-chan *model.PostEdge, error) {
+	return r.feedUpdated(ctx, after)
+}
+
+// PresenceChanged is the resolver for the presenceChanged field.
+func (r *subscriptionResolver) PresenceChanged(ctx context.Context, userIds []uuid.UUID) (<-chan *model.Presence, error) {
+	return r.presenceChanged(ctx, userIds)
+}
+
+// AuditEntry returns AuditEntryResolver implementation.
+func (r *Resolver) AuditEntry() AuditEntryResolver { return &auditEntryResolver{r} }
+
+// Comment returns CommentResolver implementation.
+func (r *Resolver) Comment() CommentResolver { return &commentResolver{r} }
+
+// FollowEdge returns FollowEdgeResolver implementation.
+func (r *Resolver) FollowEdge() FollowEdgeResolver { return &followEdgeResolver{r} }
+
+// ModerationAction returns ModerationActionResolver implementation.
+func (r *Resolver) ModerationAction() ModerationActionResolver { return &moderationActionResolver{r} }
+
 // Mutation returns MutationResolver implementation.
 func (r *Resolver) Mutation() MutationResolver { return &mutationResolver{r} }
 
+// Notification returns NotificationResolver implementation.
+func (r *Resolver) Notification() NotificationResolver { return &notificationResolver{r} }
+
+// Post returns PostResolver implementation.
+func (r *Resolver) Post() PostResolver { return &postResolver{r} }
+
 // Query returns QueryResolver implementation.
 func (r *Resolver) Query() QueryResolver { return &queryResolver{r} }
 
+// Report returns ReportResolver implementation.
+func (r *Resolver) Report() ReportResolver { return &reportResolver{r} }
+
 // Subscription returns SubscriptionResolver implementation.
 func (r *Resolver) Subscription() SubscriptionResolver { return &subscriptionResolver{r} }
 
+type auditEntryResolver struct{ *Resolver }
+type commentResolver struct{ *Resolver }
+type followEdgeResolver struct{ *Resolver }
+type moderationActionResolver struct{ *Resolver }
 type mutationResolver struct{ *Resolver }
+type notificationResolver struct{ *Resolver }
+type postResolver struct{ *Resolver }
 type queryResolver struct{ *Resolver }
+type reportResolver struct{ *Resolver }
 type subscriptionResolver struct{ *Resolver }
@@ -1,26 +1,42 @@
 package graph
 
 import (
+	"api-gateway/auth"
 	"api-gateway/graph/helpers"
 	"api-gateway/graph/model"
 	"context"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"shared/logging"
+	"strconv"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/nats-io/nats.go"
 )
 
+const (
+	// feedUpdatesStream is the stream feed-service keeps users' feed updates
+	// in, for an hour and up to 100 per user
+	feedUpdatesStream = "FEED_UPDATES"
+	// feedUpdatesBatch is how many feed updates are fetched at a time. The
+	// next batch is fetched once the client has taken this one, so updates
+	// for a slow client wait in the stream rather than in the gateway.
+	feedUpdatesBatch = 10
+	// feedUpdatesWait is how long one fetch waits for updates
+	feedUpdatesWait = 30 * time.Second
+)
+
 func (r *subscriptionResolver) notificationAdded(ctx context.Context) (<-chan *model.Notification, error) {
-	token := helpers.GetTokenFromContext(ctx)
-	if token == "" {
-		return nil, fmt.Errorf("authentication required")
+	principal, ok := auth.FromContext(ctx)
+	if !ok {
+		return nil, auth.ErrUnauthenticated
 	}
 
-	userID := "user-id-from-token"
-
 	ch := make(chan *model.Notification, 1)
-	subject := fmt.Sprintf("notifications.%s", userID)
+	subject := fmt.Sprintf("notifications.%s", principal.UserID)
 
 	sub, err := r.NatsConn.Subscribe(subject, func(msg *nats.Msg) {
 		var notif struct {
@@ -68,11 +84,58 @@ func (r *subscriptionResolver) notificationAdded(ctx context.Context) (<-chan *m
 	return ch, nil
 }
 
+// notificationReadStateChanged pushes the current user's notifications being
+// marked read, with the unread count that leaves, as notification-service
+// announces them
+func (r *subscriptionResolver) notificationReadStateChanged(ctx context.Context) (<-chan *model.NotificationReadState, error) {
+	principal, ok := auth.FromContext(ctx)
+	if !ok {
+		return nil, auth.ErrUnauthenticated
+	}
+
+	ch := make(chan *model.NotificationReadState, 1)
+	subject := fmt.Sprintf("notifications.read.%s", principal.UserID)
+
+	sub, err := r.NatsConn.Subscribe(subject, func(msg *nats.Msg) {
+		var state struct {
+			NotificationID string    `json:"notification_id"`
+			UnreadCount    int32     `json:"unread_count"`
+			ReadAt         time.Time `json:"read_at"`
+		}
+
+		if err := json.Unmarshal(msg.Data, &state); err != nil {
+			return
+		}
+
+		select {
+		case ch <- &model.NotificationReadState{
+			NotificationID: helpers.ParseUUIDPtr(state.NotificationID),
+			UnreadCount:    state.UnreadCount,
+			ReadAt:         state.ReadAt.Format(time.RFC3339),
+		}:
+		case <-ctx.Done():
+			return
+		}
+	})
+
+	if err != nil {
+		close(ch)
+		return nil, fmt.Errorf("failed to subscribe to notification read state: %w", err)
+	}
+
+	go func() {
+		<-ctx.Done()
+		sub.Unsubscribe()
+		close(ch)
+	}()
+
+	return ch, nil
+}
+
 // PostAdded is the resolver for the postAdded field.
 func (r *subscriptionResolver) postAdded(ctx context.Context, userID uuid.UUID) (<-chan *model.Post, error) {
-	token := helpers.GetTokenFromContext(ctx)
-	if token == "" {
-		return nil, fmt.Errorf("authentication required")
+	if _, ok := auth.FromContext(ctx); !ok {
+		return nil, auth.ErrUnauthenticated
 	}
 
 	ch := make(chan *model.Post, 1)
@@ -103,6 +166,10 @@ func (r *subscriptionResolver) postAdded(ctx context.Context, userID uuid.UUID)
 			UpdatedAt:     post.UpdatedAt,
 			LikesCount:    int32(post.LikesCount),
 			CommentsCount: int32(post.CommentsCount),
+			Status:        model.PostStatusPublished,
+			Visibility:    model.PostVisibilityPublic,
+			Mentions:      []*model.Mention{},
+			Media:         []*model.Media{},
 		}:
 		case <-ctx.Done():
 			return
@@ -149,6 +216,7 @@ func (r *subscriptionResolver) commentAdded(ctx context.Context, postID uuid.UUI
 			PostID:    uuid.MustParse(comment.PostID),
 			UserID:    uuid.MustParse(comment.UserID),
 			Content:   comment.Content,
+			Mentions:  []*model.Mention{},
 			CreatedAt: comment.CreatedAt,
 			UpdatedAt: comment.UpdatedAt,
 		}:
@@ -170,3 +238,204 @@ func (r *subscriptionResolver) commentAdded(ctx context.Context, postID uuid.UUI
 
 	return ch, nil
 }
+
+// feedUpdated pushes posts as feed-service fans them out to the caller's
+// feed. Updates are read from the stream through a consumer of the caller's
+// subject, starting after the update whose cursor is after, or with the next
+// update without one. Updates dropped from the stream by its limits are
+// skipped.
+func (r *subscriptionResolver) feedUpdated(ctx context.Context, after *string) (<-chan *model.PostEdge, error) {
+	principal, ok := auth.FromContext(ctx)
+	if !ok {
+		return nil, auth.ErrUnauthenticated
+	}
+
+	start := nats.DeliverNew()
+	if after != nil && *after != "" {
+		seq, err := decodeFeedUpdateCursor(*after)
+		if err != nil {
+			return nil, invalidInput("after", "invalid cursor")
+		}
+		start = nats.StartSequence(seq + 1)
+	}
+
+	js, err := r.NatsConn.JetStream()
+	if err != nil {
+		return nil, fmt.Errorf("failed to subscribe to feed updates: %w", err)
+	}
+
+	subject := fmt.Sprintf("feed.updates.%s", principal.UserID)
+	sub, err := js.PullSubscribe(subject, "",
+		nats.BindStream(feedUpdatesStream),
+		start,
+		// Consumers left behind by a gateway that went away are removed
+		nats.InactiveThreshold(5*time.Minute),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to subscribe to feed updates: %w", err)
+	}
+
+	ch := make(chan *model.PostEdge, feedUpdatesBatch)
+
+	go func() {
+		defer close(ch)
+		defer sub.Unsubscribe()
+
+		for ctx.Err() == nil {
+			fetchCtx, cancel := context.WithTimeout(ctx, feedUpdatesWait)
+			msgs, err := sub.Fetch(feedUpdatesBatch, nats.Context(fetchCtx))
+			cancel()
+			if err != nil && ctx.Err() == nil && !errors.Is(err, context.DeadlineExceeded) {
+				logging.FromContext(ctx).Warn().Err(err).Msg("failed to fetch feed updates")
+				select {
+				case <-time.After(time.Second):
+				case <-ctx.Done():
+				}
+				continue
+			}
+
+			for _, msg := range msgs {
+				edge, err := feedUpdateEdge(msg)
+				if err != nil {
+					msg.Ack()
+					continue
+				}
+
+				select {
+				case ch <- edge:
+					msg.Ack()
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return ch, nil
+}
+
+// feedUpdateEdge decodes a feed update into an edge whose cursor is the
+// update's position in the stream
+func feedUpdateEdge(msg *nats.Msg) (*model.PostEdge, error) {
+	meta, err := msg.Metadata()
+	if err != nil {
+		return nil, err
+	}
+
+	var update struct {
+		PostID     string `json:"post_id"`
+		UserID     string `json:"user_id"`
+		Content    string `json:"content"`
+		Visibility string `json:"visibility"`
+		CreatedAt  string `json:"created_at"`
+	}
+	if err := json.Unmarshal(msg.Data, &update); err != nil {
+		return nil, err
+	}
+	postID, err := uuid.Parse(update.PostID)
+	if err != nil {
+		return nil, err
+	}
+	userID, err := uuid.Parse(update.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.PostEdge{
+		Cursor: encodeFeedUpdateCursor(meta.Sequence.Stream),
+		Node: &model.Post{
+			ID:         postID,
+			UserID:     userID,
+			Content:    update.Content,
+			CreatedAt:  update.CreatedAt,
+			UpdatedAt:  update.CreatedAt,
+			Status:     model.PostStatusPublished,
+			Visibility: helpers.FeedPostVisibilityToModel(update.Visibility),
+			Mentions:   []*model.Mention{},
+			Media:      []*model.Media{},
+		},
+	}, nil
+}
+
+// Feed update cursors are the stream sequence of the update. They only
+// select where the caller's own updates resume, so they are not signed.
+func encodeFeedUpdateCursor(seq uint64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte("feed-update:" + strconv.FormatUint(seq, 10)))
+}
+
+func decodeFeedUpdateCursor(c string) (uint64, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(c)
+	if err != nil {
+		return 0, err
+	}
+	var seq uint64
+	if _, err := fmt.Sscanf(string(raw), "feed-update:%d", &seq); err != nil {
+		return 0, err
+	}
+	return seq, nil
+}
+
+// presenceChanged pushes the users of userIDs going online or offline, as
+// notification-service announces them
+func (r *subscriptionResolver) presenceChanged(ctx context.Context, userIDs []uuid.UUID) (<-chan *model.Presence, error) {
+	if _, ok := auth.FromContext(ctx); !ok {
+		return nil, auth.ErrUnauthenticated
+	}
+	if len(userIDs) > maxPresenceUsers {
+		return nil, invalidInput("userIds", fmt.Sprintf("at most %d users may be watched at once", maxPresenceUsers))
+	}
+
+	ch := make(chan *model.Presence, 1)
+
+	handler := func(msg *nats.Msg) {
+		var presence struct {
+			UserID     string     `json:"user_id"`
+			Online     bool       `json:"online"`
+			LastSeenAt *time.Time `json:"last_seen_at"`
+		}
+
+		if err := json.Unmarshal(msg.Data, &presence); err != nil {
+			return
+		}
+		userID, err := uuid.Parse(presence.UserID)
+		if err != nil {
+			return
+		}
+
+		var lastSeenAt *string
+		if presence.LastSeenAt != nil {
+			s := presence.LastSeenAt.Format(time.RFC3339)
+			lastSeenAt = &s
+		}
+
+		select {
+		case ch <- &model.Presence{UserID: userID, Online: presence.Online, LastSeenAt: lastSeenAt}:
+		case <-ctx.Done():
+			return
+		}
+	}
+
+	subs := make([]*nats.Subscription, 0, len(userIDs))
+	unsubscribe := func() {
+		for _, sub := range subs {
+			sub.Unsubscribe()
+		}
+	}
+	for _, userID := range userIDs {
+		sub, err := r.NatsConn.Subscribe(fmt.Sprintf("presence.changed.%s", userID), handler)
+		if err != nil {
+			unsubscribe()
+			close(ch)
+			return nil, fmt.Errorf("failed to subscribe to presence: %w", err)
+		}
+		subs = append(subs, sub)
+	}
+
+	go func() {
+		<-ctx.Done()
+		unsubscribe()
+		close(ch)
+	}()
+
+	return ch, nil
+}
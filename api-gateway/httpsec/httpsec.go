@@ -0,0 +1,185 @@
+// Package httpsec wraps the gateway's HTTP routes with the headers browsers
+// rely on: CORS for the web frontend's origins, and security headers on
+// every response.
+package httpsec
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CORS is which cross-origin callers browsers let read the gateway's
+// responses
+type CORS struct {
+	// AllowedOrigins are the origins allowed, as for AllowedOrigin. Empty
+	// allows none, so only the gateway's own origin can call it.
+	AllowedOrigins []string
+	// AllowedHeaders are the request headers callers may send
+	AllowedHeaders []string
+	// ExposedHeaders are the response headers callers may read
+	ExposedHeaders []string
+	// AllowCredentials lets callers send cookies, for cookie sessions
+	AllowCredentials bool
+	// MaxAge is how long browsers may cache a preflight
+	MaxAge time.Duration
+}
+
+// allowedMethods are the methods of every route the gateway serves
+const allowedMethods = "GET, HEAD, POST, OPTIONS"
+
+// Handler answers preflights and sets the CORS headers of requests from
+// allowed origins. Preflights end here; other requests, allowed or not, are
+// served as usual and the browser decides whether the caller sees them.
+func (c CORS) Handler(next http.Handler) http.Handler {
+	allowedHeaders := strings.Join(c.AllowedHeaders, ", ")
+	exposedHeaders := strings.Join(c.ExposedHeaders, ", ")
+	maxAge := strconv.Itoa(int(c.MaxAge.Seconds()))
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Origin")
+		header := r.Header.Get("Origin")
+		if header == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		preflight := r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != ""
+		origin, err := url.Parse(header)
+		allowed := err == nil && AllowedOrigin(c.AllowedOrigins, origin)
+
+		if allowed {
+			if c.AllowCredentials || !isWildcard(c.AllowedOrigins) {
+				w.Header().Set("Access-Control-Allow-Origin", header)
+			} else {
+				w.Header().Set("Access-Control-Allow-Origin", "*")
+			}
+			if c.AllowCredentials {
+				w.Header().Set("Access-Control-Allow-Credentials", "true")
+			}
+		}
+
+		if !preflight {
+			if allowed && exposedHeaders != "" {
+				w.Header().Set("Access-Control-Expose-Headers", exposedHeaders)
+			}
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Add("Vary", "Access-Control-Request-Method")
+		w.Header().Add("Vary", "Access-Control-Request-Headers")
+		if allowed {
+			w.Header().Set("Access-Control-Allow-Methods", allowedMethods)
+			if allowedHeaders != "" {
+				w.Header().Set("Access-Control-Allow-Headers", allowedHeaders)
+			}
+			w.Header().Set("Access-Control-Max-Age", maxAge)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// Validate checks the origins and that any origin is not combined with
+// credentials, which would let every site act as the user
+func (c CORS) Validate() error {
+	for _, origin := range c.AllowedOrigins {
+		if err := ValidateOrigin(origin); err != nil {
+			return err
+		}
+	}
+	if c.AllowCredentials && isWildcard(c.AllowedOrigins) {
+		return fmt.Errorf("origin * cannot be allowed with credentials")
+	}
+	if c.MaxAge < 0 {
+		return fmt.Errorf("max age must not be negative")
+	}
+	return nil
+}
+
+func isWildcard(allowed []string) bool {
+	for _, pattern := range allowed {
+		if pattern == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowedOrigin reports whether allowed lists origin, e.g.
+// "https://muzeeng.com", matches one of its wildcards, e.g.
+// "https://*.muzeeng.com", or allowed holds "*"
+func AllowedOrigin(allowed []string, origin *url.URL) bool {
+	for _, pattern := range allowed {
+		if originMatches(pattern, origin) {
+			return true
+		}
+	}
+	return false
+}
+
+func originMatches(pattern string, origin *url.URL) bool {
+	if pattern == "*" {
+		return true
+	}
+	allowed, err := url.Parse(pattern)
+	if err != nil || !strings.EqualFold(allowed.Scheme, origin.Scheme) {
+		return false
+	}
+	if domain, ok := strings.CutPrefix(allowed.Host, "*."); ok {
+		return strings.HasSuffix(strings.ToLower(origin.Host), "."+strings.ToLower(domain))
+	}
+	return strings.EqualFold(allowed.Host, origin.Host)
+}
+
+// ValidateOrigin checks an allowed origin: "*", or a scheme and host whose
+// leftmost label may be "*"
+func ValidateOrigin(pattern string) error {
+	if pattern == "*" {
+		return nil
+	}
+	allowed, err := url.Parse(pattern)
+	if err != nil || allowed.Scheme == "" || allowed.Host == "" || strings.Trim(allowed.Path, "/") != "" {
+		return fmt.Errorf("invalid origin %q: must be a scheme and host, e.g. https://muzeeng.com", pattern)
+	}
+	if strings.Contains(strings.TrimPrefix(allowed.Host, "*."), "*") {
+		return fmt.Errorf("invalid origin %q: only the leftmost label may be *", pattern)
+	}
+	return nil
+}
+
+// Headers are the security headers set on every response
+type Headers struct {
+	// ContentSecurityPolicy is sent as is; empty sends none
+	ContentSecurityPolicy string
+	// HSTSMaxAge has browsers use HTTPS only for this long; 0 sends no
+	// Strict-Transport-Security, for gateways served over plain HTTP
+	HSTSMaxAge time.Duration
+}
+
+// Handler sets the security headers before the response is written, so
+// handlers may still override them
+func (h Headers) Handler(next http.Handler) http.Handler {
+	hsts := ""
+	if h.HSTSMaxAge > 0 {
+		hsts = fmt.Sprintf("max-age=%d; includeSubDomains", int(h.HSTSMaxAge.Seconds()))
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header := w.Header()
+		header.Set("X-Content-Type-Options", "nosniff")
+		header.Set("X-Frame-Options", "DENY")
+		header.Set("Referrer-Policy", "strict-origin-when-cross-origin")
+		header.Set("Cross-Origin-Opener-Policy", "same-origin")
+		if h.ContentSecurityPolicy != "" {
+			header.Set("Content-Security-Policy", h.ContentSecurityPolicy)
+		}
+		if hsts != "" {
+			header.Set("Strict-Transport-Security", hsts)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
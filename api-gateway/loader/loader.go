@@ -0,0 +1,160 @@
+package loader
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrNotFound is returned for keys the fetch function did not return a value
+// for
+var ErrNotFound = errors.New("not found")
+
+// FetchFunc loads the values of keys in one call. Keys missing from the
+// result are reported as ErrNotFound.
+type FetchFunc[K comparable, V any] func(ctx context.Context, keys []K) (map[K]V, error)
+
+// Loader batches the lookups made within wait of each other into one fetch
+// and remembers the results, so resolving the same field on many objects
+// costs a single call to the owning service. A Loader lives for one request.
+type Loader[K comparable, V any] struct {
+	fetch    FetchFunc[K, V]
+	wait     time.Duration
+	maxBatch int
+
+	mu      sync.Mutex
+	results map[K]*result[V]
+	pending *batch[K, V]
+}
+
+type result[V any] struct {
+	done  chan struct{}
+	value V
+	err   error
+}
+
+type batch[K comparable, V any] struct {
+	keys    []K
+	results []*result[V]
+	// ctx is the context of the first lookup, whose credentials the fetch
+	// is made with
+	ctx context.Context
+}
+
+func New[K comparable, V any](fetch FetchFunc[K, V], wait time.Duration, maxBatch int) *Loader[K, V] {
+	return &Loader[K, V]{
+		fetch:    fetch,
+		wait:     wait,
+		maxBatch: maxBatch,
+		results:  make(map[K]*result[V]),
+	}
+}
+
+// Load returns the value for key, waiting for the batch it joins
+func (l *Loader[K, V]) Load(ctx context.Context, key K) (V, error) {
+	res := l.enqueue(ctx, key)
+
+	select {
+	case <-res.done:
+		return res.value, res.err
+	case <-ctx.Done():
+		var zero V
+		return zero, ctx.Err()
+	}
+}
+
+// LoadMany returns the values for keys in order, fetched in as few batches as
+// possible, along with the error of each key
+func (l *Loader[K, V]) LoadMany(ctx context.Context, keys []K) ([]V, []error) {
+	results := make([]*result[V], len(keys))
+	for i, key := range keys {
+		results[i] = l.enqueue(ctx, key)
+	}
+
+	values := make([]V, len(keys))
+	errs := make([]error, len(keys))
+	for i, res := range results {
+		select {
+		case <-res.done:
+			values[i], errs[i] = res.value, res.err
+		case <-ctx.Done():
+			errs[i] = ctx.Err()
+		}
+	}
+	return values, errs
+}
+
+// Prefetch starts loading keys without waiting for them, so a caller that
+// learns of keys early can have them fetched by the time they are loaded
+func (l *Loader[K, V]) Prefetch(ctx context.Context, keys []K) {
+	for _, key := range keys {
+		l.enqueue(ctx, key)
+	}
+}
+
+// enqueue returns the result for key, adding key to the pending batch if it
+// has not been requested yet
+func (l *Loader[K, V]) enqueue(ctx context.Context, key K) *result[V] {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if res, ok := l.results[key]; ok {
+		return res
+	}
+
+	res := &result[V]{done: make(chan struct{})}
+	l.results[key] = res
+
+	if l.pending == nil {
+		b := &batch[K, V]{ctx: context.WithoutCancel(ctx)}
+		l.pending = b
+		time.AfterFunc(l.wait, func() { l.dispatch(b) })
+	}
+
+	b := l.pending
+	b.keys = append(b.keys, key)
+	b.results = append(b.results, res)
+	if len(b.keys) >= l.maxBatch {
+		go l.dispatch(b)
+	}
+
+	return res
+}
+
+// dispatch fetches a batch, once, and completes its results
+func (l *Loader[K, V]) dispatch(b *batch[K, V]) {
+	l.mu.Lock()
+	if l.pending != b {
+		// Already dispatched because it filled up
+		l.mu.Unlock()
+		return
+	}
+	l.pending = nil
+	l.mu.Unlock()
+
+	values, err := l.fetch(b.ctx, b.keys)
+
+	for i, key := range b.keys {
+		res := b.results[i]
+		if err != nil {
+			res.err = err
+		} else if value, ok := values[key]; ok {
+			res.value = value
+		} else {
+			res.err = ErrNotFound
+		}
+		close(res.done)
+	}
+
+	if err != nil {
+		// Let a later lookup retry instead of caching the failure
+		l.mu.Lock()
+		for i, key := range b.keys {
+			if l.results[key] == b.results[i] {
+				delete(l.results, key)
+			}
+		}
+		l.mu.Unlock()
+	}
+}
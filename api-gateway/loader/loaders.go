@@ -0,0 +1,76 @@
+package loader
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	"api-gateway/graph/helpers"
+	"api-gateway/graph/model"
+	userpb "user-service/pb"
+)
+
+const (
+	// batchWait is how long a loader collects lookups before fetching. The
+	// resolvers of one list run concurrently, so this only has to cover
+	// their scheduling.
+	batchWait = 2 * time.Millisecond
+	// maxUserBatch bounds the IDs sent in one GetUsersByIds call
+	maxUserBatch = 100
+)
+
+// Loaders holds the loaders of one request
+type Loaders struct {
+	Users *Loader[uuid.UUID, *model.User]
+}
+
+func NewLoaders(users userpb.UserServiceClient) *Loaders {
+	return &Loaders{
+		Users: New(fetchUsers(users), batchWait, maxUserBatch),
+	}
+}
+
+func fetchUsers(users userpb.UserServiceClient) FetchFunc[uuid.UUID, *model.User] {
+	return func(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*model.User, error) {
+		userIDs := make([]string, len(ids))
+		for i, id := range ids {
+			userIDs[i] = id.String()
+		}
+
+		resp, err := users.GetUsersByIds(ctx, &userpb.GetUsersByIdsRequest{UserIds: userIDs})
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch users: %w", err)
+		}
+
+		result := make(map[uuid.UUID]*model.User, len(resp.Users))
+		for _, u := range resp.Users {
+			user := helpers.ProtoUserToModel(u)
+			result[user.ID] = user
+		}
+		return result, nil
+	}
+}
+
+type contextKey struct{}
+
+// Middleware gives every request its own loaders, so results are shared
+// between the fields of one request but never between users
+func Middleware(users userpb.UserServiceClient, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), contextKey{}, NewLoaders(users))
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// For returns the loaders of the request ctx belongs to. Outside a request,
+// e.g. in background work, it returns fresh loaders that cache nothing
+// beyond the call.
+func For(ctx context.Context, users userpb.UserServiceClient) *Loaders {
+	if loaders, ok := ctx.Value(contextKey{}).(*Loaders); ok {
+		return loaders
+	}
+	return NewLoaders(users)
+}
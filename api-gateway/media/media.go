@@ -0,0 +1,85 @@
+package media
+
+import (
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	postpb "post-service/pb"
+)
+
+// Path is the prefix media is served under; the rest of the path is the ID
+const Path = "/media/"
+
+// Handler serves uploaded media by streaming it from post-service
+type Handler struct {
+	posts postpb.PostServiceClient
+}
+
+func NewHandler(posts postpb.PostServiceClient) *Handler {
+	return &Handler{posts: posts}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	mediaID := strings.TrimPrefix(r.URL.Path, Path)
+	stream, err := h.posts.GetMediaContent(r.Context(), &postpb.GetMediaContentRequest{MediaId: mediaID})
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	// Errors such as an unknown ID only surface with the first message
+	first, err := stream.Recv()
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	// Uploads never change, so clients and proxies may cache them for good
+	w.Header().Set("Content-Type", first.MimeType)
+	w.Header().Set("Content-Length", strconv.FormatInt(first.SizeBytes, 10))
+	w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	if r.Method == http.MethodHead {
+		return
+	}
+
+	if _, err := w.Write(first.Chunk); err != nil {
+		return
+	}
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			return
+		}
+		if err != nil {
+			// Headers are already sent; the short body tells the client
+			log.Printf("Failed to stream media %s: %v", mediaID, err)
+			return
+		}
+		if _, err := w.Write(chunk.Chunk); err != nil {
+			return
+		}
+	}
+}
+
+func writeError(w http.ResponseWriter, r *http.Request, err error) {
+	switch status.Code(err) {
+	case codes.NotFound, codes.InvalidArgument:
+		http.NotFound(w, r)
+	default:
+		log.Printf("Failed to load media: %v", err)
+		http.Error(w, "failed to load media", http.StatusBadGateway)
+	}
+}
@@ -0,0 +1,74 @@
+package media
+
+import (
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	userpb "user-service/pb"
+)
+
+// ProfileImagePath is the prefix avatars and cover images are served under;
+// the rest of the path is the image ID
+const ProfileImagePath = "/profile-images/"
+
+// ProfileImageHandler serves avatars and cover images by streaming them from
+// user-service
+type ProfileImageHandler struct {
+	users userpb.UserServiceClient
+}
+
+func NewProfileImageHandler(users userpb.UserServiceClient) *ProfileImageHandler {
+	return &ProfileImageHandler{users: users}
+}
+
+func (h *ProfileImageHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	imageID := strings.TrimPrefix(r.URL.Path, ProfileImagePath)
+	stream, err := h.users.GetProfileImageContent(r.Context(), &userpb.GetProfileImageContentRequest{ImageId: imageID})
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	// Errors such as an unknown ID only surface with the first message
+	first, err := stream.Recv()
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	// A new image gets a new ID, so an image never changes under its URL
+	w.Header().Set("Content-Type", first.MimeType)
+	w.Header().Set("Content-Length", strconv.FormatInt(first.SizeBytes, 10))
+	w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	if r.Method == http.MethodHead {
+		return
+	}
+
+	if _, err := w.Write(first.Chunk); err != nil {
+		return
+	}
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			return
+		}
+		if err != nil {
+			// Headers are already sent; the short body tells the client
+			log.Printf("Failed to stream profile image %s: %v", imageID, err)
+			return
+		}
+		if _, err := w.Write(chunk.Chunk); err != nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,57 @@
+// Package presence reports the users connected to the gateway's WebSocket
+// transport to notification-service, which tracks who is online. Each
+// connection publishes a heartbeat for its user on presence.heartbeat when it
+// is opened and every interval after that, until it is closed.
+// notification-service keeps a user online until PRESENCE_TTL passes without
+// a heartbeat, so the interval must be well below it.
+package presence
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/nats-io/nats.go"
+
+	"shared/logging"
+)
+
+const heartbeatSubject = "presence.heartbeat"
+
+// Reporter publishes the heartbeats of connections
+type Reporter struct {
+	conn     *nats.Conn
+	interval time.Duration
+}
+
+func NewReporter(conn *nats.Conn, interval time.Duration) *Reporter {
+	return &Reporter{conn: conn, interval: interval}
+}
+
+// Track publishes heartbeats for userID until ctx, the connection's context,
+// is done. Without a NATS connection presence is not reported.
+func (r *Reporter) Track(ctx context.Context, userID string) {
+	if r.conn == nil {
+		return
+	}
+
+	payload, err := json.Marshal(map[string]string{"user_id": userID})
+	if err != nil {
+		return
+	}
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := r.conn.Publish(heartbeatSubject, payload); err != nil {
+			logging.FromContext(ctx).Warn().Err(err).Msg("failed to publish presence heartbeat")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+
+	"api-gateway/auth"
+	"shared/logging"
+)
+
+// authMutations are the mutations limited by the auth bucket
+var authMutations = map[string]bool{
+	"login":    true,
+	"register": true,
+}
+
+// Limits are the buckets of each kind of operation
+type Limits struct {
+	Queries   Limit
+	Mutations Limit
+	// Auth limits operations calling login or register, per client IP
+	Auth Limit
+}
+
+type requestInfo struct {
+	clientIP string
+	header   http.Header
+}
+
+type requestKey struct{}
+
+// Middleware records the client IP of every request for Extension, and the
+// response headers it sets Retry-After on. With trustProxy, the last address
+// in X-Forwarded-For, the one the gateway's proxy added, is the client's.
+// The IP and user agent are also passed on to backend calls, so auth-service
+// can show where each session signed in from.
+func Middleware(trustProxy bool, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		info := &requestInfo{clientIP: clientIP(r, trustProxy), header: w.Header()}
+		ctx := context.WithValue(r.Context(), requestKey{}, info)
+		ctx = auth.WithClient(ctx, auth.Client{IP: info.clientIP, UserAgent: r.UserAgent()})
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func clientIP(r *http.Request, trustProxy bool) string {
+	if trustProxy {
+		if forwarded := r.Header.Values("X-Forwarded-For"); len(forwarded) > 0 {
+			addrs := strings.Split(forwarded[len(forwarded)-1], ",")
+			if ip := strings.TrimSpace(addrs[len(addrs)-1]); ip != "" {
+				return ip
+			}
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// Extension turns away operations of callers that have used up their
+// bucket with a RATE_LIMITED error carrying the seconds until they may retry.
+// Subscriptions are not limited; their websocket stays open instead.
+// Limiting fails open: if Redis cannot be reached, operations are let through.
+type Extension struct {
+	Limiter *Limiter
+	Limits  Limits
+}
+
+var _ interface {
+	graphql.HandlerExtension
+	graphql.OperationInterceptor
+} = Extension{}
+
+func (Extension) ExtensionName() string {
+	return "RateLimit"
+}
+
+func (Extension) Validate(graphql.ExecutableSchema) error {
+	return nil
+}
+
+func (e Extension) InterceptOperation(ctx context.Context, next graphql.OperationHandler) graphql.ResponseHandler {
+	oc := graphql.GetOperationContext(ctx)
+	if oc.Operation == nil || oc.Operation.Operation == ast.Subscription {
+		return next(ctx)
+	}
+
+	info, _ := ctx.Value(requestKey{}).(*requestInfo)
+	bucket, limit, authCalls := e.bucketOf(oc)
+	if authCalls > 1 {
+		// Each login or register would otherwise cost one token between them
+		return graphql.OneShot(&graphql.Response{
+			Errors: gqlerror.List{{
+				Message: "at most one login or register may run per operation",
+				Extensions: map[string]any{
+					"code": "TOO_MANY_AUTH_MUTATIONS",
+				},
+			}},
+		})
+	}
+	key := e.keyOf(ctx, bucket, info)
+	if key == "" {
+		return next(ctx)
+	}
+
+	allowed, wait, err := e.Limiter.Allow(ctx, key, limit)
+	if err != nil {
+		logging.FromContext(ctx).Warn().Err(err).Str("bucket", bucket).Msg("rate limiting unavailable, allowing operation")
+		return next(ctx)
+	}
+	if allowed {
+		return next(ctx)
+	}
+
+	retryAfter := retryAfterSeconds(wait)
+	if info != nil {
+		info.header.Set("Retry-After", strconv.Itoa(retryAfter))
+	}
+	logging.FromContext(ctx).Info().Str("bucket", bucket).Int("retry_after", retryAfter).Msg("rate limited operation")
+
+	return graphql.OneShot(&graphql.Response{
+		Errors: gqlerror.List{{
+			Message: fmt.Sprintf("rate limit exceeded, retry in %ds", retryAfter),
+			Extensions: map[string]any{
+				"code":       "RATE_LIMITED",
+				"retryAfter": retryAfter,
+			},
+		}},
+	})
+}
+
+// bucketOf returns the bucket an operation draws from, and how many login
+// and register fields it runs. Fields are collected the way they are
+// executed, so those in fragments and under several aliases all count.
+func (e Extension) bucketOf(oc *graphql.OperationContext) (string, Limit, int) {
+	if oc.Operation.Operation != ast.Mutation {
+		return "query", e.Limits.Queries, 0
+	}
+	calls := 0
+	for _, field := range graphql.CollectFields(oc, oc.Operation.SelectionSet, []string{"Mutation"}) {
+		if authMutations[field.Name] {
+			calls++
+		}
+	}
+	if calls > 0 {
+		return "auth", e.Limits.Auth, calls
+	}
+	return "mutation", e.Limits.Mutations, 0
+}
+
+// keyOf returns the bucket key of the caller: the user when authenticated,
+// except for the auth bucket, which is always per IP
+func (e Extension) keyOf(ctx context.Context, bucket string, info *requestInfo) string {
+	if principal, ok := auth.FromContext(ctx); ok && bucket != "auth" {
+		return bucket + ":user:" + principal.UserID
+	}
+	if info == nil || info.clientIP == "" {
+		return ""
+	}
+	return bucket + ":ip:" + info.clientIP
+}
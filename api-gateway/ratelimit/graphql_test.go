@@ -0,0 +1,84 @@
+package ratelimit
+
+import (
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/parser"
+)
+
+func TestBucketOf(t *testing.T) {
+	limits := Limits{
+		Queries:   Limit{Burst: 600},
+		Mutations: Limit{Burst: 120},
+		Auth:      Limit{Burst: 10},
+	}
+
+	tests := []struct {
+		name      string
+		query     string
+		bucket    string
+		authCalls int
+	}{
+		{
+			name:   "query",
+			query:  `query { me { id } }`,
+			bucket: "query",
+		},
+		{
+			name:   "other mutation",
+			query:  `mutation { createPost(input: {content: "hi"}) { id } }`,
+			bucket: "mutation",
+		},
+		{
+			name:      "login",
+			query:     `mutation { login(input: {email: "a", password: "b"}) { accessToken } }`,
+			bucket:    "auth",
+			authCalls: 1,
+		},
+		{
+			name: "aliases",
+			query: `mutation {
+				a: register(input: {username: "a"}) { accessToken }
+				b: register(input: {username: "b"}) { accessToken }
+				c: login(input: {email: "c"}) { accessToken }
+			}`,
+			bucket:    "auth",
+			authCalls: 3,
+		},
+		{
+			name: "fragment",
+			query: `mutation { ...F }
+			fragment F on Mutation { login(input: {email: "a"}) { accessToken } }`,
+			bucket:    "auth",
+			authCalls: 1,
+		},
+		{
+			name:      "inline fragment",
+			query:     `mutation { ... on Mutation { register(input: {username: "a"}) { accessToken } } }`,
+			bucket:    "auth",
+			authCalls: 1,
+		},
+		{
+			name:   "skipped",
+			query:  `mutation { login(input: {email: "a"}) @skip(if: true) { accessToken } createPost(input: {content: "hi"}) { id } }`,
+			bucket: "mutation",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := parser.ParseQuery(&ast.Source{Input: tt.query})
+			if err != nil {
+				t.Fatalf("failed to parse query: %v", err)
+			}
+			oc := &graphql.OperationContext{Doc: doc, Operation: doc.Operations[0]}
+
+			bucket, _, authCalls := Extension{Limits: limits}.bucketOf(oc)
+			if bucket != tt.bucket || authCalls != tt.authCalls {
+				t.Errorf("bucketOf() = %q, %d; want %q, %d", bucket, authCalls, tt.bucket, tt.authCalls)
+			}
+		})
+	}
+}
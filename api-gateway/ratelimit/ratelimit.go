@@ -0,0 +1,123 @@
+// Package ratelimit limits how often a caller may query the gateway, with
+// token buckets kept in Redis so that all gateway replicas share them.
+//
+// Authenticated callers are limited per user and anonymous ones per client
+// IP. Queries, mutations and the login and register mutations draw from
+// separate buckets, so a client polling its feed cannot starve its own
+// writes, and password guessing is held to a much lower rate.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Limit is a token bucket: Burst requests may be made at once, and the bucket
+// refills at Burst per Per
+type Limit struct {
+	Burst int
+	Per   time.Duration
+}
+
+// Enabled reports whether l limits anything. The zero Limit does not.
+func (l Limit) Enabled() bool {
+	return l.Burst > 0 && l.Per > 0
+}
+
+func (l Limit) String() string {
+	if !l.Enabled() {
+		return "off"
+	}
+	return fmt.Sprintf("%d/%s", l.Burst, l.Per)
+}
+
+// ParseLimit parses a limit written as "<requests>/<duration>", e.g. "60/1m",
+// or "off"
+func ParseLimit(s string) (Limit, error) {
+	if s == "off" {
+		return Limit{}, nil
+	}
+
+	requests, per, ok := strings.Cut(s, "/")
+	if !ok {
+		return Limit{}, fmt.Errorf("invalid rate limit %q: expected <requests>/<duration> or off", s)
+	}
+	burst, err := strconv.Atoi(requests)
+	if err != nil || burst <= 0 {
+		return Limit{}, fmt.Errorf("invalid rate limit %q: requests must be a positive integer", s)
+	}
+	d, err := time.ParseDuration(per)
+	if err != nil || d <= 0 {
+		return Limit{}, fmt.Errorf("invalid rate limit %q: duration must be positive", s)
+	}
+	return Limit{Burst: burst, Per: d}, nil
+}
+
+// takeToken refills the bucket at KEYS[1] for the time since it was last
+// used and takes a token if one is left. It returns whether the request is
+// allowed and, if not, how many milliseconds until a token is available.
+// Redis' clock is used, so replicas with skewed clocks agree.
+var takeToken = redis.NewScript(`
+local burst = tonumber(ARGV[1])
+local per_ms = tonumber(ARGV[2])
+
+local t = redis.call('TIME')
+local now = t[1] * 1000 + math.floor(t[2] / 1000)
+
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'at')
+local tokens = tonumber(state[1]) or burst
+local at = tonumber(state[2]) or now
+
+tokens = math.min(burst, tokens + (now - at) * burst / per_ms)
+
+local allowed = 0
+local wait_ms = 0
+if tokens >= 1 then
+  tokens = tokens - 1
+  allowed = 1
+else
+  wait_ms = math.ceil((1 - tokens) * per_ms / burst)
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'at', now)
+redis.call('PEXPIRE', KEYS[1], per_ms)
+return {allowed, wait_ms}
+`)
+
+// Limiter takes tokens from buckets in Redis
+type Limiter struct {
+	redis  *redis.Client
+	prefix string
+}
+
+func NewLimiter(client *redis.Client) *Limiter {
+	return &Limiter{redis: client, prefix: "ratelimit:"}
+}
+
+// Allow takes a token from the bucket key limited by limit. When none is
+// left it returns false and how long until one is.
+func (l *Limiter) Allow(ctx context.Context, key string, limit Limit) (bool, time.Duration, error) {
+	if !limit.Enabled() {
+		return true, 0, nil
+	}
+
+	res, err := takeToken.Run(ctx, l.redis, []string{l.prefix + key}, limit.Burst, limit.Per.Milliseconds()).Int64Slice()
+	if err != nil {
+		return false, 0, fmt.Errorf("failed to take rate limit token: %w", err)
+	}
+	if len(res) != 2 {
+		return false, 0, fmt.Errorf("unexpected rate limit script result %v", res)
+	}
+	return res[0] == 1, time.Duration(res[1]) * time.Millisecond, nil
+}
+
+// retryAfterSeconds rounds wait up to whole seconds, as Retry-After expects
+func retryAfterSeconds(wait time.Duration) int {
+	return int(math.Max(1, math.Ceil(wait.Seconds())))
+}
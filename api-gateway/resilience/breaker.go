@@ -0,0 +1,92 @@
+package resilience
+
+import (
+	"log"
+	"sync"
+	"time"
+)
+
+type breakerState int
+
+const (
+	closed breakerState = iota
+	open
+	halfOpen
+)
+
+// breaker opens after threshold consecutive failures and stays open for
+// cooldown. Then a single trial call is let through: its success closes the
+// breaker, its failure opens it again.
+type breaker struct {
+	service   string
+	threshold int
+	cooldown  time.Duration
+
+	mu       sync.Mutex
+	state    breakerState
+	failures int
+	openedAt time.Time
+	// trial is set while the trial call of a half-open breaker is in flight
+	trial bool
+}
+
+func newBreaker(service string, threshold int, cooldown time.Duration) *breaker {
+	return &breaker{service: service, threshold: threshold, cooldown: cooldown}
+}
+
+// allow reports whether a call may be made now
+func (b *breaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case open:
+		if time.Since(b.openedAt) < b.cooldown {
+			return false
+		}
+		b.state = halfOpen
+		b.trial = true
+		return true
+	case halfOpen:
+		if b.trial {
+			return false
+		}
+		b.trial = true
+		return true
+	}
+	return true
+}
+
+// record counts the outcome of a call allow let through
+func (b *breaker) record(success bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if success {
+		if b.state != closed {
+			log.Printf("Circuit breaker of %s closed", b.service)
+		}
+		b.state = closed
+		b.failures = 0
+		b.trial = false
+		return
+	}
+
+	b.failures++
+	if b.state == halfOpen || b.failures >= b.threshold {
+		if b.state == closed {
+			log.Printf("Circuit breaker of %s opened after %d consecutive failures", b.service, b.failures)
+		}
+		b.state = open
+		b.openedAt = time.Now()
+		b.trial = false
+	}
+}
+
+// abandon forgets a call whose caller gave up, so a half-open breaker lets
+// another trial call through
+func (b *breaker) abandon() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.trial = false
+}
@@ -0,0 +1,181 @@
+// Package resilience keeps one slow or failing service from hanging every
+// GraphQL request. Each gRPC client connection gets an interceptor that
+//
+//   - gives calls without an earlier deadline GRPC_CALL_TIMEOUT (default 5s),
+//   - retries reads that fail with Unavailable up to GRPC_RETRY_ATTEMPTS
+//     times in total (default 3), backing off exponentially with jitter,
+//   - opens a circuit breaker after GRPC_BREAKER_FAILURES consecutive
+//     failures (default 5), failing calls fast for GRPC_BREAKER_COOLDOWN
+//     (default 10s) before letting a single trial call through.
+//
+// Only unary calls are covered; streams live as long as their subscription.
+package resilience
+
+import (
+	"context"
+	"log"
+	"math/rand/v2"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+
+	"shared/logging"
+)
+
+const (
+	baseBackoff = 50 * time.Millisecond
+	maxBackoff  = time.Second
+)
+
+// readPrefixes are the method name prefixes of calls that change nothing and
+// may safely be retried
+var readPrefixes = []string{"Get", "List", "Search", "Is", "Inspect", "Validate", "Check"}
+
+// Client applies timeouts, retries and a circuit breaker to the calls made to
+// one service
+type Client struct {
+	service  string
+	timeout  time.Duration
+	attempts int
+	breaker  *breaker
+}
+
+// New creates the resilience layer of the connection to service, configured
+// from the environment
+func New(service string) *Client {
+	return &Client{
+		service:  service,
+		timeout:  durationEnv("GRPC_CALL_TIMEOUT", 5*time.Second),
+		attempts: intEnv("GRPC_RETRY_ATTEMPTS", 3),
+		breaker: newBreaker(
+			service,
+			intEnv("GRPC_BREAKER_FAILURES", 5),
+			durationEnv("GRPC_BREAKER_COOLDOWN", 10*time.Second),
+		),
+	}
+}
+
+// DialOption installs the interceptor on a client connection
+func (c *Client) DialOption() grpc.DialOption {
+	return grpc.WithChainUnaryInterceptor(c.UnaryClientInterceptor())
+}
+
+// UnaryClientInterceptor applies the timeout, retries and circuit breaker to
+// unary calls. Health checks get the timeout but bypass the breaker, so the
+// healthCheck query reports how a service is really doing.
+func (c *Client) UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		callCtx := ctx
+		if deadline, ok := ctx.Deadline(); !ok || time.Until(deadline) > c.timeout {
+			var cancel context.CancelFunc
+			callCtx, cancel = context.WithTimeout(ctx, c.timeout)
+			defer cancel()
+		}
+
+		if isHealthCheck(method) {
+			return invoker(callCtx, method, req, reply, cc, opts...)
+		}
+
+		attempts := 1
+		if isRead(method) {
+			attempts = max(1, c.attempts)
+		}
+
+		var err error
+		for attempt := 1; ; attempt++ {
+			if !c.breaker.allow() {
+				if err != nil {
+					// Opened by the previous attempt
+					return err
+				}
+				return status.Errorf(codes.Unavailable, "%s is unavailable: circuit breaker open", c.service)
+			}
+
+			err = invoker(callCtx, method, req, reply, cc, opts...)
+			// A caller that gave up says nothing about the service
+			if ctx.Err() == nil {
+				c.breaker.record(!isFailure(err))
+			} else {
+				c.breaker.abandon()
+			}
+
+			if status.Code(err) != codes.Unavailable || attempt >= attempts {
+				return err
+			}
+
+			wait := backoff(attempt)
+			logging.FromContext(ctx).Debug().Err(err).Str("method", method).Int("attempt", attempt).Dur("backoff", wait).Msg("retrying call")
+			select {
+			case <-time.After(wait):
+			case <-callCtx.Done():
+				return err
+			}
+		}
+	}
+}
+
+// backoff returns a random wait of up to baseBackoff*2^(attempt-1), capped at
+// maxBackoff, so retries of many callers spread out
+func backoff(attempt int) time.Duration {
+	d := baseBackoff << (attempt - 1)
+	if d <= 0 || d > maxBackoff {
+		d = maxBackoff
+	}
+	return time.Duration(rand.Int64N(int64(d)) + 1)
+}
+
+// isFailure reports whether err means the service is unhealthy, as opposed
+// to the request being turned away
+func isFailure(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.Internal, codes.Unknown:
+		return true
+	}
+	return false
+}
+
+func isRead(method string) bool {
+	name := method[strings.LastIndex(method, "/")+1:]
+	for _, prefix := range readPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func isHealthCheck(method string) bool {
+	return strings.HasPrefix(method, "/"+healthpb.Health_ServiceDesc.ServiceName+"/")
+}
+
+func durationEnv(key string, defaultValue time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		log.Printf("Invalid %s %q, using %s", key, value, defaultValue)
+		return defaultValue
+	}
+	return d
+}
+
+func intEnv(key string, defaultValue int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n <= 0 {
+		log.Printf("Invalid %s %q, using %d", key, value, defaultValue)
+		return defaultValue
+	}
+	return n
+}
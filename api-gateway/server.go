@@ -2,12 +2,33 @@ package main
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log"
 	"net/http"
 	"os"
+	"strconv"
+	"sync"
 	"time"
 
+	"api-gateway/auth"
+	"api-gateway/cache"
+	"api-gateway/config"
+	"api-gateway/discovery"
+	"api-gateway/export"
 	"api-gateway/graph"
+	"api-gateway/graph/helpers"
+	"api-gateway/httpsec"
+	"api-gateway/loader"
+	"api-gateway/media"
+	"api-gateway/presence"
+	"api-gateway/ratelimit"
+	"api-gateway/sitemap"
+	gqltracing "api-gateway/tracing"
+	"api-gateway/wsconn"
+	"auth-service/pkg/jwt"
+	"shared/logging"
+	"shared/tracing"
 
 	"github.com/99designs/gqlgen/graphql/handler"
 	"github.com/99designs/gqlgen/graphql/handler/extension"
@@ -15,40 +36,138 @@ import (
 	"github.com/99designs/gqlgen/graphql/handler/transport"
 	"github.com/99designs/gqlgen/graphql/playground"
 	"github.com/gorilla/websocket"
+	"github.com/redis/go-redis/v9"
 	"github.com/vektah/gqlparser/v2/ast"
 )
 
 const defaultPort = "8080"
 
 func main() {
+	logging.Init("api-gateway")
+
 	port := os.Getenv("PORT")
 	if port == "" {
 		port = defaultPort
 	}
 
+	// Export traces when OTEL_EXPORTER_OTLP_ENDPOINT is set
+	if _, err := tracing.Init(context.Background(), "api-gateway"); err != nil {
+		log.Fatalf("failed to initialize tracing: %v", err)
+	}
+
 	// Initialize resolver with gRPC clients and NATS
 	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
 	defer cancel()
 
-	resolver, err := graph.NewResolver(ctx)
+	// Service addresses, TLS and discovery
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("failed to load config: %v", err)
+	}
+	discovery.RegisterConsul(cfg.Consul.Address)
+
+	resolver, err := graph.NewResolver(ctx, cfg)
 	if err != nil {
 		log.Fatalf("failed to initialize resolver: %v", err)
 	}
 
+	// Redis is only connected to when rate limiting or caching uses it
+	redisClient := sync.OnceValue(func() *redis.Client {
+		return connectRedis(ctx)
+	})
+
+	// Public queries of anonymous callers are cached for
+	// RESPONSE_CACHE_TTL, e.g. 30s; unset or 0 disables caching
+	if ttl := getEnv("RESPONSE_CACHE_TTL", "0"); ttl != "0" {
+		cacheTTL, err := time.ParseDuration(ttl)
+		if err != nil || cacheTTL < 0 {
+			log.Fatalf("invalid RESPONSE_CACHE_TTL %q", ttl)
+		}
+		resolver.Cache = cache.New(redisClient(), cacheTTL)
+		if _, err := resolver.Cache.Subscribe(context.Background(), resolver.NatsConn); err != nil {
+			log.Fatalf("failed to subscribe to cache invalidations: %v", err)
+		}
+		log.Printf("Caching public queries for %s", cacheTTL)
+	}
+
 	// --- Create GraphQL server ---
-	srv := handler.New(graph.NewExecutableSchema(graph.Config{Resolvers: resolver}))
+	srv := handler.New(graph.NewExecutableSchema(graph.Config{
+		Resolvers:  resolver,
+		Directives: graph.Directives(),
+		Complexity: graph.Complexity(),
+	}))
+	srv.SetErrorPresenter(graph.ErrorPresenter)
+
+	// Access tokens are verified here, for queries and subscriptions alike,
+	// with the secret auth-service signs them with, or with the public keys it
+	// publishes when JWT_ALGORITHM is RS256. AUTH_VALIDATION=remote also
+	// checks them with auth-service, which knows about revocations.
+	tokens, err := tokenManager()
+	if err != nil {
+		log.Fatalf("invalid JWT configuration: %v", err)
+	}
+	var authRemote bool
+	switch mode := getEnv("AUTH_VALIDATION", "local"); mode {
+	case "local":
+	case "remote":
+		authRemote = true
+	default:
+		log.Fatalf("invalid AUTH_VALIDATION %q: must be local or remote", mode)
+	}
+	authCacheTTL, err := time.ParseDuration(getEnv("AUTH_CACHE_TTL", "30s"))
+	if err != nil {
+		log.Fatalf("invalid AUTH_CACHE_TTL: %v", err)
+	}
+	sessionCookie, err := sessionCookie()
+	if err != nil {
+		log.Fatalf("invalid session cookie configuration: %v", err)
+	}
+	verifier := auth.NewVerifier(tokens, resolver.AuthClient, authRemote, authCacheTTL, sessionCookie)
+	if sessionCookie.Enabled() {
+		log.Printf("Authenticating browsers by their %s cookie", sessionCookie.Name)
+	}
 
 	// Add transports
 	srv.AddTransport(transport.Options{})
 	srv.AddTransport(transport.GET{})
 	srv.AddTransport(transport.POST{})
-	srv.AddTransport(transport.MultipartForm{})
+	// Room for the largest upload post-service accepts (50MB videos)
+	srv.AddTransport(transport.MultipartForm{MaxUploadSize: 64 << 20})
+
+	// Authenticated WebSocket connections keep their user online, sending a
+	// heartbeat every PRESENCE_HEARTBEAT_INTERVAL
+	heartbeatInterval, err := time.ParseDuration(getEnv("PRESENCE_HEARTBEAT_INTERVAL", "20s"))
+	if err != nil {
+		log.Fatalf("invalid PRESENCE_HEARTBEAT_INTERVAL: %v", err)
+	}
+	if heartbeatInterval <= 0 {
+		log.Fatalf("invalid PRESENCE_HEARTBEAT_INTERVAL: must be positive")
+	}
+	reporter := presence.NewReporter(resolver.NatsConn, heartbeatInterval)
 
-	// WebSocket transport for subscriptions
+	// WebSocket transport for subscriptions. Browsers may only connect from
+	// the allowed origins, and authenticated connections close when their
+	// token expires.
 	srv.AddTransport(transport.Websocket{
 		KeepAlivePingInterval: 10 * time.Second,
+		InitFunc: func(ctx context.Context, payload transport.InitPayload) (context.Context, *transport.InitPayload, error) {
+			ctx, ack, err := verifier.WebsocketInit(ctx, payload)
+			if err != nil {
+				return ctx, ack, err
+			}
+			principal, ok := auth.FromContext(ctx)
+			if !ok {
+				return wsconn.Open(ctx, time.Time{}), ack, nil
+			}
+			ctx = wsconn.Open(ctx, principal.ExpiresAt)
+			go reporter.Track(ctx, principal.UserID)
+			return ctx, ack, nil
+		},
+		CloseFunc: func(ctx context.Context, _ int) {
+			wsconn.Close(ctx)
+		},
 		Upgrader: websocket.Upgrader{
-			CheckOrigin:     func(r *http.Request) bool { return true },
+			CheckOrigin:     wsconn.CheckOrigin(cfg.Websocket.AllowedOrigins),
 			ReadBufferSize:  1024,
 			WriteBufferSize: 1024,
 		},
@@ -60,10 +179,78 @@ func main() {
 	srv.Use(extension.Introspection{})
 	srv.Use(extension.AutomaticPersistedQuery{
 		Cache: lru.New[string](100)})
+	srv.Use(gqltracing.Extension{})
+	srv.Use(wsconn.SubscriptionLimit{Max: cfg.Websocket.MaxSubscriptions})
+
+	// Turn away operations that would fan out into more backend calls than
+	// any client needs; 0 disables a limit
+	maxComplexity, err := strconv.Atoi(getEnv("GRAPHQL_MAX_COMPLEXITY", "2000"))
+	if err != nil {
+		log.Fatalf("invalid GRAPHQL_MAX_COMPLEXITY: %v", err)
+	}
+	maxDepth, err := strconv.Atoi(getEnv("GRAPHQL_MAX_DEPTH", "12"))
+	if err != nil {
+		log.Fatalf("invalid GRAPHQL_MAX_DEPTH: %v", err)
+	}
+	if maxComplexity > 0 {
+		srv.Use(extension.FixedComplexityLimit(maxComplexity))
+	}
+	srv.Use(graph.DepthLimit{Max: maxDepth})
+
+	// Rate limits per user, or per IP for anonymous callers, as
+	// <requests>/<duration> or off
+	var limits ratelimit.Limits
+	for _, l := range []struct {
+		env, defaultValue string
+		limit             *ratelimit.Limit
+	}{
+		{"RATE_LIMIT_QUERIES", "600/1m", &limits.Queries},
+		{"RATE_LIMIT_MUTATIONS", "120/1m", &limits.Mutations},
+		{"RATE_LIMIT_AUTH", "10/1m", &limits.Auth},
+	} {
+		if *l.limit, err = ratelimit.ParseLimit(getEnv(l.env, l.defaultValue)); err != nil {
+			log.Fatalf("invalid %s: %v", l.env, err)
+		}
+	}
+	if limits.Queries.Enabled() || limits.Mutations.Enabled() || limits.Auth.Enabled() {
+		srv.Use(ratelimit.Extension{Limiter: ratelimit.NewLimiter(redisClient()), Limits: limits})
+		log.Printf("Rate limiting queries at %s, mutations at %s and login/register at %s", limits.Queries, limits.Mutations, limits.Auth)
+	}
+	trustProxy := getEnv("TRUST_PROXY_HEADERS", "false") == "true"
 
 	// --- HTTP handlers ---
 	http.Handle("/", playground.Handler("GraphQL Playground", "/query"))
-	http.Handle("/query", srv)
+	http.Handle("/query", gqltracing.Handler(logging.Handler(ratelimit.Middleware(trustProxy, verifier.Middleware(auth.IdempotencyKeys(loader.Middleware(resolver.UserClient, srv)))))))
+
+	// Public sitemap for the web frontend, regenerated in the background
+	sitemapInterval, err := time.ParseDuration(getEnv("SITEMAP_REFRESH_INTERVAL", "6h"))
+	if err != nil {
+		log.Fatalf("invalid SITEMAP_REFRESH_INTERVAL: %v", err)
+	}
+	sitemapGen := sitemap.NewGenerator(resolver.UserClient, resolver.PostClient, getEnv("SITEMAP_BASE_URL", "http://localhost:3000"))
+	sitemapGen.Start(context.Background(), sitemapInterval)
+	http.Handle("/sitemap.xml", sitemapGen)
+	http.Handle("/sitemaps/", sitemapGen)
+
+	// Uploaded media, streamed from post-service. MEDIA_BASE_URL is the
+	// public address of this route, used to build Media.url.
+	helpers.SetMediaBaseURL(getEnv("MEDIA_BASE_URL", "http://localhost:8080/media"))
+	http.Handle(media.Path, logging.Handler(media.NewHandler(resolver.PostClient)))
+
+	// Avatars and cover images, streamed from user-service.
+	// PROFILE_IMAGE_BASE_URL is the public address of this route.
+	helpers.SetProfileImageBaseURL(getEnv("PROFILE_IMAGE_BASE_URL", "http://localhost:8080/profile-images"))
+	http.Handle(media.ProfileImagePath, logging.Handler(media.NewProfileImageHandler(resolver.UserClient)))
+
+	// The caller's data from every service, as a single JSON download
+	http.Handle(export.Path, logging.Handler(verifier.Middleware(export.NewHandler(
+		resolver.UserClient,
+		resolver.PostClient,
+		resolver.CommentClient,
+		resolver.LikeClient,
+		resolver.FollowClient,
+		resolver.NotificationClient,
+	))))
 
 	// Health check endpoint
 	http.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
@@ -71,6 +258,83 @@ func main() {
 		w.Write([]byte("OK"))
 	})
 
+	// Every route answers browsers with security headers, and with CORS
+	// headers when they call from an allowed origin
+	routes := httpsec.Headers(cfg.SecurityHeaders).Handler(httpsec.CORS(cfg.CORS).Handler(http.DefaultServeMux))
+
 	log.Printf("🚀 Server running at http://localhost:%s/", port)
-	log.Fatal(http.ListenAndServe(":"+port, nil))
+	log.Fatal(http.ListenAndServe(":"+port, routes))
+}
+
+// connectRedis connects to the Redis shared by the gateway replicas
+func connectRedis(ctx context.Context) *redis.Client {
+	redisDB, err := strconv.Atoi(getEnv("REDIS_DB", "0"))
+	if err != nil {
+		log.Fatalf("invalid REDIS_DB: %v", err)
+	}
+	client := redis.NewClient(&redis.Options{
+		Addr:     getEnv("REDIS_URL", "redis:6379"),
+		Password: getEnv("REDIS_PASSWORD", ""),
+		DB:       redisDB,
+		PoolSize: 10,
+	})
+	if err := client.Ping(ctx).Err(); err != nil {
+		log.Fatalf("failed to connect to Redis: %v", err)
+	}
+	return client
+}
+
+// sessionCookie is the cookie-based auth mode, enabled by naming the cookie
+// in AUTH_COOKIE_NAME. AUTH_COOKIE_SAMESITE is lax, strict or none; none
+// needs AUTH_COOKIE_SECURE, which is the default.
+func sessionCookie() (auth.SessionCookie, error) {
+	cookie := auth.SessionCookie{
+		Name:   getEnv("AUTH_COOKIE_NAME", ""),
+		Domain: getEnv("AUTH_COOKIE_DOMAIN", ""),
+		Secure: getEnv("AUTH_COOKIE_SECURE", "true") == "true",
+	}
+	switch sameSite := getEnv("AUTH_COOKIE_SAMESITE", "lax"); sameSite {
+	case "lax":
+		cookie.SameSite = http.SameSiteLaxMode
+	case "strict":
+		cookie.SameSite = http.SameSiteStrictMode
+	case "none":
+		if !cookie.Secure {
+			return cookie, errors.New("AUTH_COOKIE_SAMESITE none requires AUTH_COOKIE_SECURE")
+		}
+		cookie.SameSite = http.SameSiteNoneMode
+	default:
+		return cookie, fmt.Errorf("unknown AUTH_COOKIE_SAMESITE %q: must be lax, strict or none", sameSite)
+	}
+	return cookie, nil
+}
+
+// tokenManager verifies tokens with JWT_SECRET, or under RS256 with the keys
+// at JWKS_URL and JWT_SECRET only if JWT_ALLOW_HS256 is true
+func tokenManager() (*jwt.Manager, error) {
+	secret := getEnv("JWT_SECRET", "your-secret-key")
+	switch algorithm := getEnv("JWT_ALGORITHM", "HS256"); algorithm {
+	case "HS256":
+		return jwt.NewManager(secret), nil
+	case "RS256":
+		refresh, err := time.ParseDuration(getEnv("JWKS_REFRESH", "10m"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid JWKS_REFRESH: %w", err)
+		}
+		if getEnv("JWT_ALLOW_HS256", "false") != "true" {
+			secret = ""
+		}
+		keys := jwt.NewRemoteKeySet(getEnv("JWKS_URL", "http://auth-service:8080/.well-known/jwks.json"), refresh)
+		return jwt.NewVerifyingManager(keys, secret), nil
+	default:
+		return nil, fmt.Errorf("unsupported JWT_ALGORITHM %q", algorithm)
+	}
+}
+
+// helper to read environment variables
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
 }
@@ -0,0 +1,229 @@
+package sitemap
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	postpb "post-service/pb"
+	userpb "user-service/pb"
+)
+
+const (
+	// MaxURLsPerShard is the sitemaps.org protocol limit for a single sitemap file
+	MaxURLsPerShard = 50000
+
+	pageSize  = 5000
+	xmlNS     = "http://www.sitemaps.org/schemas/sitemap/0.9"
+	shardPath = "/sitemaps/"
+)
+
+type urlSet struct {
+	XMLName xml.Name   `xml:"urlset"`
+	XMLNS   string     `xml:"xmlns,attr"`
+	URLs    []urlEntry `xml:"url"`
+}
+
+type urlEntry struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+type sitemapIndex struct {
+	XMLName  xml.Name       `xml:"sitemapindex"`
+	XMLNS    string         `xml:"xmlns,attr"`
+	Sitemaps []sitemapEntry `xml:"sitemap"`
+}
+
+type sitemapEntry struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+// Generator periodically builds sitemap shards for public profiles and posts
+// and serves the latest snapshot over HTTP
+type Generator struct {
+	userClient userpb.UserServiceClient
+	postClient postpb.PostServiceClient
+	baseURL    string
+
+	mu     sync.RWMutex
+	index  []byte
+	shards map[string][]byte
+}
+
+// NewGenerator creates a sitemap generator. baseURL is the public web frontend
+// origin that page URLs are built from (e.g. https://muzeeng.com)
+func NewGenerator(userClient userpb.UserServiceClient, postClient postpb.PostServiceClient, baseURL string) *Generator {
+	return &Generator{
+		userClient: userClient,
+		postClient: postClient,
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		shards:     make(map[string][]byte),
+	}
+}
+
+// Start generates the sitemap immediately and then on every interval until ctx is done
+func (g *Generator) Start(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			if err := g.Generate(ctx); err != nil {
+				log.Printf("⚠️ Sitemap generation failed: %v", err)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Generate walks all public profiles and posts and atomically replaces the served shards
+func (g *Generator) Generate(ctx context.Context) error {
+	profileURLs, err := g.collectProfiles(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to collect profiles: %w", err)
+	}
+
+	postURLs, err := g.collectPosts(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to collect posts: %w", err)
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	shards := make(map[string][]byte)
+	index := sitemapIndex{XMLNS: xmlNS}
+
+	for _, group := range []struct {
+		name string
+		urls []urlEntry
+	}{
+		{"profiles", profileURLs},
+		{"posts", postURLs},
+	} {
+		for i := 0; i*MaxURLsPerShard < len(group.urls); i++ {
+			end := (i + 1) * MaxURLsPerShard
+			if end > len(group.urls) {
+				end = len(group.urls)
+			}
+
+			body, err := marshal(urlSet{XMLNS: xmlNS, URLs: group.urls[i*MaxURLsPerShard : end]})
+			if err != nil {
+				return err
+			}
+
+			name := fmt.Sprintf("%s-%d.xml", group.name, i+1)
+			shards[name] = body
+			index.Sitemaps = append(index.Sitemaps, sitemapEntry{
+				Loc:     g.baseURL + shardPath + name,
+				LastMod: now,
+			})
+		}
+	}
+
+	indexBody, err := marshal(index)
+	if err != nil {
+		return err
+	}
+
+	g.mu.Lock()
+	g.index = indexBody
+	g.shards = shards
+	g.mu.Unlock()
+
+	log.Printf("🗺️ Sitemap generated: %d profiles, %d posts, %d shards", len(profileURLs), len(postURLs), len(shards))
+	return nil
+}
+
+func (g *Generator) collectProfiles(ctx context.Context) ([]urlEntry, error) {
+	var urls []urlEntry
+	var afterID *string
+
+	for {
+		resp, err := g.userClient.ListPublicProfiles(ctx, &userpb.ListPublicProfilesRequest{
+			Limit:   pageSize,
+			AfterId: afterID,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, p := range resp.Profiles {
+			urls = append(urls, urlEntry{
+				Loc:     fmt.Sprintf("%s/users/%s", g.baseURL, url.PathEscape(p.Username)),
+				LastMod: p.UpdatedAt.AsTime().UTC().Format(time.RFC3339),
+			})
+		}
+
+		if resp.NextAfterId == nil {
+			return urls, nil
+		}
+		afterID = resp.NextAfterId
+	}
+}
+
+func (g *Generator) collectPosts(ctx context.Context) ([]urlEntry, error) {
+	var urls []urlEntry
+	var afterID *string
+
+	for {
+		resp, err := g.postClient.ListPublicPosts(ctx, &postpb.ListPublicPostsRequest{
+			Limit:   pageSize,
+			AfterId: afterID,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, p := range resp.Posts {
+			urls = append(urls, urlEntry{
+				Loc:     fmt.Sprintf("%s/posts/%s", g.baseURL, p.Id),
+				LastMod: p.UpdatedAt.AsTime().UTC().Format(time.RFC3339),
+			})
+		}
+
+		if resp.NextAfterId == nil {
+			return urls, nil
+		}
+		afterID = resp.NextAfterId
+	}
+}
+
+// ServeHTTP serves /sitemap.xml (the index) and /sitemaps/<shard>.xml
+func (g *Generator) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	g.mu.RLock()
+	var body []byte
+	if r.URL.Path == "/sitemap.xml" {
+		body = g.index
+	} else if strings.HasPrefix(r.URL.Path, shardPath) {
+		body = g.shards[strings.TrimPrefix(r.URL.Path, shardPath)]
+	}
+	g.mu.RUnlock()
+
+	if body == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+	w.Write(body)
+}
+
+func marshal(v interface{}) ([]byte, error) {
+	body, err := xml.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal sitemap: %w", err)
+	}
+	return append([]byte(xml.Header), body...), nil
+}
@@ -0,0 +1,61 @@
+// Package tracing traces the GraphQL requests the gateway serves over HTTP.
+// Exporting traces and carrying them across gRPC and NATS is left to
+// shared/tracing.
+package tracing
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/v2/ast"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Handler starts a span for every GraphQL request, continuing the trace of
+// a client that sent a traceparent header
+func Handler(next http.Handler) http.Handler {
+	return otelhttp.NewHandler(next, "graphql")
+}
+
+// Extension names the span of a request after its operation, e.g.
+// "query GetFeed", so requests can be told apart in trace views.
+// Subscriptions share the span of their websocket connection, which keeps its
+// name.
+type Extension struct{}
+
+var _ interface {
+	graphql.HandlerExtension
+	graphql.OperationInterceptor
+} = Extension{}
+
+func (Extension) ExtensionName() string {
+	return "Tracing"
+}
+
+func (Extension) Validate(graphql.ExecutableSchema) error {
+	return nil
+}
+
+func (Extension) InterceptOperation(ctx context.Context, next graphql.OperationHandler) graphql.ResponseHandler {
+	oc := graphql.GetOperationContext(ctx)
+	if oc.Operation == nil || oc.Operation.Operation == ast.Subscription {
+		return next(ctx)
+	}
+
+	name := oc.OperationName
+	if name == "" {
+		name = "anonymous"
+	}
+
+	span := trace.SpanFromContext(ctx)
+	span.SetName(string(oc.Operation.Operation) + " " + name)
+	span.SetAttributes(
+		attribute.String("graphql.operation.type", string(oc.Operation.Operation)),
+		attribute.String("graphql.operation.name", oc.OperationName),
+	)
+
+	return next(ctx)
+}
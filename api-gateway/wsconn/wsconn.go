@@ -0,0 +1,137 @@
+// Package wsconn guards the websocket connections subscriptions run over:
+// which origins may open them, how long they stay open and how many
+// subscriptions each may run at once.
+package wsconn
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+
+	"api-gateway/httpsec"
+)
+
+// CheckOrigin returns the origin check of the websocket upgrader. Requests
+// without an Origin header, which only browsers send, pass. A browser's
+// origin passes when httpsec.AllowedOrigin allows it or, with nothing
+// allowed, when it is the gateway's own origin.
+func CheckOrigin(allowed []string) func(r *http.Request) bool {
+	return func(r *http.Request) bool {
+		header := r.Header.Get("Origin")
+		if header == "" {
+			return true
+		}
+		origin, err := url.Parse(header)
+		if err != nil {
+			return false
+		}
+
+		if len(allowed) == 0 {
+			return strings.EqualFold(origin.Host, r.Host)
+		}
+		return httpsec.AllowedOrigin(allowed, origin)
+	}
+}
+
+// connection is the state of an open connection, kept in the context its
+// subscriptions run in
+type connection struct {
+	cancel context.CancelFunc
+
+	mu            sync.Mutex
+	subscriptions int
+}
+
+type connectionKey struct{}
+
+// Open returns the context of a new connection, derived from the context of
+// its upgrade request. A non-zero closeAt, when the token the connection was
+// authenticated with expires, closes the connection then, and the client
+// reconnects with a fresh token. Pass the context to Close once the
+// connection is closed.
+func Open(ctx context.Context, closeAt time.Time) context.Context {
+	var cancel context.CancelFunc
+	if closeAt.IsZero() {
+		ctx, cancel = context.WithCancel(ctx)
+	} else {
+		ctx, cancel = context.WithDeadline(ctx, closeAt)
+	}
+	return context.WithValue(ctx, connectionKey{}, &connection{cancel: cancel})
+}
+
+// Close releases the connection ctx was opened for
+func Close(ctx context.Context) {
+	if conn, ok := ctx.Value(connectionKey{}).(*connection); ok {
+		conn.cancel()
+	}
+}
+
+func (c *connection) acquire(limit int) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.subscriptions >= limit {
+		return false
+	}
+	c.subscriptions++
+	return true
+}
+
+func (c *connection) release() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.subscriptions--
+}
+
+// SubscriptionLimit turns away subscriptions beyond Max running at once on a
+// connection with a TOO_MANY_SUBSCRIPTIONS error. A subscription counts
+// until it completes or the client stops it. Operations outside connections
+// opened with Open are not limited, nor is anything when Max is 0.
+type SubscriptionLimit struct {
+	Max int
+}
+
+var _ interface {
+	graphql.HandlerExtension
+	graphql.OperationInterceptor
+} = SubscriptionLimit{}
+
+func (SubscriptionLimit) ExtensionName() string {
+	return "SubscriptionLimit"
+}
+
+func (SubscriptionLimit) Validate(graphql.ExecutableSchema) error {
+	return nil
+}
+
+func (l SubscriptionLimit) InterceptOperation(ctx context.Context, next graphql.OperationHandler) graphql.ResponseHandler {
+	oc := graphql.GetOperationContext(ctx)
+	conn, ok := ctx.Value(connectionKey{}).(*connection)
+	if l.Max <= 0 || !ok || oc.Operation == nil || oc.Operation.Operation != ast.Subscription {
+		return next(ctx)
+	}
+
+	if !conn.acquire(l.Max) {
+		return graphql.OneShot(&graphql.Response{
+			Errors: gqlerror.List{{
+				Message: fmt.Sprintf("too many subscriptions, at most %d per connection", l.Max),
+				Extensions: map[string]any{
+					"code":  "TOO_MANY_SUBSCRIPTIONS",
+					"limit": l.Max,
+				},
+			}},
+		})
+	}
+	// The context of a subscription ends with it
+	context.AfterFunc(ctx, conn.release)
+	return next(ctx)
+}
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"time"
+
+	"github.com/joho/godotenv"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+
+	"audit-service/config"
+	"audit-service/db"
+	"audit-service/handler"
+	"audit-service/interceptor"
+	"audit-service/migrations"
+	natsClient "audit-service/nats"
+	pb "audit-service/pb"
+	"audit-service/repository"
+	"audit-service/rpcerror"
+	"audit-service/subscriber"
+	"shared/chaos"
+	"shared/cursor"
+	"shared/lifecycle"
+	"shared/logging"
+	"shared/migrate"
+	"shared/mtls"
+	"shared/tracing"
+)
+
+func main() {
+	logging.Init("audit-service")
+
+	migrateFlag := flag.Bool("migrate", false, "apply pending schema migrations before serving")
+	flag.Parse()
+
+	if err := godotenv.Load(); err != nil {
+		log.Println("failed to load Audit .env")
+	}
+
+	// The supervisor health-checks the dependencies below and closes them
+	// in order on shutdown
+	supervisor := lifecycle.New(pb.AuditService_ServiceDesc.ServiceName)
+
+	// Load database configuration
+	dbCfg, err := config.LoadDatabaseConfig("AUDIT_")
+	if err != nil {
+		log.Fatalf("Failed to load Audit database config: %v", err)
+	}
+
+	// Connect to the database
+	dbConn, err := database.NewConnection(database.Config{
+		Host:         dbCfg.Host,
+		Port:         dbCfg.Port,
+		User:         dbCfg.User,
+		Password:     dbCfg.Password,
+		DBName:       dbCfg.DBName,
+		SSLMode:      dbCfg.SSLMode,
+		MaxOpenConns: dbCfg.MaxOpenConns,
+		MaxIdleConns: dbCfg.MaxIdleConns,
+		MaxLifetime:  dbCfg.MaxLifetime,
+		ReplicaDSNs:  dbCfg.ReplicaDSNs,
+	})
+	if err != nil {
+		log.Fatalf("Failed to connect to Audit database: %v", err)
+	}
+	supervisor.Add(lifecycle.Dependency{Name: "database", Check: dbConn.HealthCheck, Close: dbConn.Close})
+
+	// Apply pending schema migrations with -migrate or DB_MIGRATE=true;
+	// without either they are only reported
+	if err := migrate.OnStartup(context.Background(), dbConn.DB, "audit-service", migrations.Files, *migrateFlag || os.Getenv("DB_MIGRATE") == "true"); err != nil {
+		log.Fatalf("Failed to migrate Audit database: %v", err)
+	}
+
+	log.Println("Successfully connected to database")
+
+	// Load other service-level configs
+	grpcPort := getEnv("GRPC_PORT", "50064")
+	jwtSecret := getEnv("JWT_SECRET", "your-secret-key")
+	// Tokens are verified with JWT_SECRET, or with auth-service's public keys
+	// when JWT_ALGORITHM is RS256
+	tokenKeys, err := interceptor.KeysFromEnv(jwtSecret)
+	if err != nil {
+		log.Fatalf("Invalid JWT configuration: %v", err)
+	}
+
+	// Fault injection for resilience testing; inert unless CHAOS_ENABLED=true
+	chaosInjector, err := chaos.FromEnv()
+	if err != nil {
+		log.Fatalf("Invalid chaos configuration: %v", err)
+	}
+
+	// Export traces when OTEL_EXPORTER_OTLP_ENDPOINT is set
+	shutdownTracing, err := tracing.Init(context.Background(), "audit-service")
+	if err != nil {
+		log.Fatalf("Failed to initialize tracing: %v", err)
+	}
+	supervisor.OnShutdown("tracing", shutdownTracing)
+
+	// Pagination cursors are signed so clients cannot forge positions
+	if err := cursor.SetSecret(os.Getenv("CURSOR_SECRET")); err != nil {
+		log.Fatalf("Invalid CURSOR_SECRET: %v", err)
+	}
+
+	// Initialize NATS client
+	nats, err := natsClient.NewClient(natsClient.Config{
+		URL:           getEnv("NATS_URL", "nats://nats:4222"),
+		MaxReconnects: 10,
+		ReconnectWait: 2 * time.Second,
+		ClientID:      getEnv("NATS_CLIENT_ID", "audit-service"),
+		Chaos:         chaosInjector,
+	})
+	if err != nil {
+		log.Fatalf("Failed to initialize NATS client: %v", err)
+	}
+	supervisor.Add(lifecycle.Dependency{Name: "nats", Check: nats.HealthCheck, Drain: nats.Drain})
+	log.Println("NATS client initialized successfully")
+
+	// Initialize repository and handler
+	auditRepo := repository.NewAuditRepository(dbConn)
+	auditHandler := handler.NewAuditHandler(auditRepo)
+
+	// Record the audit events other services publish
+	subscriber.NewAuditSubscriber(nats, auditRepo, context.Background()).Start()
+
+	// Reading the audit log requires ADMIN
+	authInterceptor := interceptor.NewAuthInterceptor(tokenKeys, nil)
+	authInterceptor.AddAdminMethods([]string{
+		"/audit.AuditService/GetAuditLog",
+	})
+	authInterceptor.AddPublicMethods(lifecycle.HealthMethods)
+
+	// TLS, and mutual TLS with a CA bundle, from GRPC_TLS_*
+	tlsOption, err := mtls.ServerOption()
+	if err != nil {
+		log.Fatalf("Failed to load TLS config: %v", err)
+	}
+
+	// Create gRPC server
+	grpcServer := grpc.NewServer(
+		tlsOption,
+		tracing.ServerOption(),
+		grpc.ChainUnaryInterceptor(logging.UnaryServerInterceptor(), rpcerror.UnaryServerInterceptor(), chaosInjector.Unary(), authInterceptor.Unary()),
+	)
+
+	// Register the gRPC service
+	pb.RegisterAuditServiceServer(grpcServer, auditHandler)
+	supervisor.Register(grpcServer)
+
+	// Enable reflection for debugging tools like grpcurl
+	reflection.Register(grpcServer)
+
+	// Start listening for connections
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%s", grpcPort))
+	if err != nil {
+		log.Fatalf("Failed to listen on port %s: %v", grpcPort, err)
+	}
+
+	log.Printf("Audit Service gRPC server listening on port %s", grpcPort)
+
+	// Serve requests
+	if err := supervisor.Serve(grpcServer, listener); err != nil {
+		log.Fatalf("Failed to serve: %v", err)
+	}
+}
+
+// small helpers for optional env vars
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
@@ -0,0 +1,28 @@
+package events
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"audit-service/model"
+)
+
+// AuditRecorded is published by auth-service and user-service for every
+// sensitive operation they perform
+const AuditRecorded = "audit.recorded"
+
+// AuditRecordedEvent describes one sensitive operation. ID is chosen by the
+// publisher, so an event delivered twice is recorded once.
+type AuditRecordedEvent struct {
+	ID         uuid.UUID       `json:"id"`
+	Service    string          `json:"service"`
+	Action     string          `json:"action"`
+	ActorID    *uuid.UUID      `json:"actor_id,omitempty"`
+	TargetType string          `json:"target_type"`
+	TargetID   string          `json:"target_id"`
+	IPAddress  string          `json:"ip_address"`
+	UserAgent  string          `json:"user_agent"`
+	Changes    []models.Change `json:"changes,omitempty"`
+	OccurredAt time.Time       `json:"occurred_at"`
+}
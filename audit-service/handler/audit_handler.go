@@ -0,0 +1,137 @@
+package handler
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"audit-service/model"
+	pb "audit-service/pb"
+	"audit-service/repository"
+	"audit-service/rpcerror"
+	"shared/cursor"
+)
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+type AuditHandler struct {
+	pb.UnimplementedAuditServiceServer
+	repo repository.AuditRepository
+}
+
+func NewAuditHandler(repo repository.AuditRepository) *AuditHandler {
+	return &AuditHandler{repo: repo}
+}
+
+// GetAuditLog pages through the audit log, newest first. The ADMIN role is
+// enforced by the interceptor.
+func (h *AuditHandler) GetAuditLog(ctx context.Context, req *pb.GetAuditLogRequest) (*pb.AuditLogConnection, error) {
+	filter := models.EntryFilter{
+		TargetType: nonEmpty(req.TargetType),
+		TargetID:   nonEmpty(req.TargetId),
+		Actions:    req.Actions,
+		Service:    nonEmpty(req.Service),
+		IPAddress:  nonEmpty(req.IpAddress),
+	}
+	if req.ActorId != nil && *req.ActorId != "" {
+		actorID, err := uuid.Parse(*req.ActorId)
+		if err != nil {
+			return nil, rpcerror.InvalidField("actor_id", "invalid actor_id")
+		}
+		filter.ActorID = &actorID
+	}
+	if req.Since != nil {
+		if err := req.Since.CheckValid(); err != nil {
+			return nil, rpcerror.InvalidField("since", "invalid since")
+		}
+		since := req.Since.AsTime()
+		filter.Since = &since
+	}
+	if req.Until != nil {
+		if err := req.Until.CheckValid(); err != nil {
+			return nil, rpcerror.InvalidField("until", "invalid until")
+		}
+		until := req.Until.AsTime()
+		filter.Until = &until
+	}
+
+	first := req.First
+	if first <= 0 {
+		first = defaultPageSize
+	}
+	if first > maxPageSize {
+		first = maxPageSize
+	}
+
+	connection, err := h.repo.List(ctx, filter, first, req.After)
+	if err != nil {
+		if errors.Is(err, cursor.ErrInvalid) {
+			return nil, rpcerror.InvalidField("after", "invalid cursor")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get audit log: %v", err)
+	}
+
+	return connectionToProto(connection), nil
+}
+
+func nonEmpty(s *string) *string {
+	if s == nil || *s == "" {
+		return nil
+	}
+	return s
+}
+
+// Helper functions for proto conversion
+
+func entryToProto(e *models.Entry) *pb.AuditEntry {
+	entry := &pb.AuditEntry{
+		Id:         e.ID.String(),
+		Service:    e.Service,
+		Action:     e.Action,
+		TargetType: e.TargetType,
+		TargetId:   e.TargetID,
+		IpAddress:  e.IPAddress,
+		UserAgent:  e.UserAgent,
+		Changes:    make([]*pb.FieldChange, len(e.Changes)),
+		OccurredAt: timestamppb.New(e.OccurredAt),
+	}
+	if e.ActorID != nil {
+		actorID := e.ActorID.String()
+		entry.ActorId = &actorID
+	}
+	for i, c := range e.Changes {
+		entry.Changes[i] = &pb.FieldChange{
+			Field:    c.Field,
+			OldValue: c.Old,
+			NewValue: c.New,
+		}
+	}
+	return entry
+}
+
+func connectionToProto(conn *models.EntryConnection) *pb.AuditLogConnection {
+	edges := make([]*pb.AuditEntryEdge, len(conn.Edges))
+	for i, edge := range conn.Edges {
+		edges[i] = &pb.AuditEntryEdge{
+			Cursor: edge.Cursor,
+			Node:   entryToProto(&edge.Node),
+		}
+	}
+
+	return &pb.AuditLogConnection{
+		Edges: edges,
+		PageInfo: &pb.PageInfo{
+			HasNextPage:     conn.PageInfo.HasNextPage,
+			HasPreviousPage: conn.PageInfo.HasPreviousPage,
+			StartCursor:     conn.PageInfo.StartCursor,
+			EndCursor:       conn.PageInfo.EndCursor,
+		},
+	}
+}
@@ -0,0 +1,236 @@
+package interceptor
+
+import (
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Keys are the keys tokens are verified, and for services calling others on
+// their own behalf signed, with
+type Keys struct {
+	// Secret verifies and signs HS256 tokens; empty refuses them
+	Secret string
+	// PublicKeys verify RS256 tokens; nil refuses them
+	PublicKeys *KeySet
+	// SigningKey signs RS256 tokens, preferred to Secret when set
+	SigningKey *rsa.PrivateKey
+}
+
+// KeysFromEnv reads the token keys from the environment. With JWT_ALGORITHM
+// RS256, tokens are verified with the public keys auth-service publishes at
+// JWKS_URL, HS256 tokens signed with secret are only accepted if
+// JWT_ALLOW_HS256 is true, and the key in JWT_PRIVATE_KEY_FILE, if any, signs
+// the service's own tokens. Otherwise secret alone is used.
+func KeysFromEnv(secret string) (Keys, error) {
+	switch algorithm := os.Getenv("JWT_ALGORITHM"); algorithm {
+	case "", "HS256":
+		return Keys{Secret: secret}, nil
+	case "RS256":
+		url := os.Getenv("JWKS_URL")
+		if url == "" {
+			url = "http://auth-service:8080/.well-known/jwks.json"
+		}
+		refresh := 10 * time.Minute
+		if value := os.Getenv("JWKS_REFRESH"); value != "" {
+			var err error
+			if refresh, err = time.ParseDuration(value); err != nil {
+				return Keys{}, fmt.Errorf("invalid JWKS_REFRESH: %w", err)
+			}
+		}
+
+		keys := Keys{PublicKeys: NewKeySet(url, refresh)}
+		if allow, _ := strconv.ParseBool(os.Getenv("JWT_ALLOW_HS256")); allow {
+			keys.Secret = secret
+		}
+		if keyFile := os.Getenv("JWT_PRIVATE_KEY_FILE"); keyFile != "" {
+			key, err := loadPrivateKey(keyFile)
+			if err != nil {
+				return Keys{}, err
+			}
+			keys.SigningKey = key
+		}
+		return keys, nil
+	default:
+		return Keys{}, fmt.Errorf("unsupported JWT_ALGORITHM %q", algorithm)
+	}
+}
+
+// Sign signs claims with SigningKey, or with Secret if there is none
+func (k Keys) Sign(claims jwt.Claims) (string, error) {
+	if k.SigningKey != nil {
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+		token.Header["kid"] = thumbprint(&k.SigningKey.PublicKey)
+		return token.SignedString(k.SigningKey)
+	}
+	if k.Secret == "" {
+		return "", errors.New("no key to sign tokens with")
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(k.Secret))
+}
+
+// keyFunc returns the key a token is verified with, by its algorithm and key
+// ID
+func (k Keys) keyFunc(token *jwt.Token) (interface{}, error) {
+	switch token.Method.(type) {
+	case *jwt.SigningMethodHMAC:
+		if k.Secret == "" {
+			return nil, fmt.Errorf("%v tokens are not accepted", token.Header["alg"])
+		}
+		return []byte(k.Secret), nil
+	case *jwt.SigningMethodRSA:
+		if k.PublicKeys == nil {
+			return nil, fmt.Errorf("%v tokens are not accepted", token.Header["alg"])
+		}
+		keyID, _ := token.Header["kid"].(string)
+		return k.PublicKeys.PublicKey(keyID)
+	default:
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
+}
+
+// keySetMinRefetch is how soon after a fetch a token with an unknown key ID
+// may cause another, so forged key IDs cannot flood auth-service
+const keySetMinRefetch = 30 * time.Second
+
+// KeySet is the set of public keys auth-service publishes as a JWKS. The keys
+// are refetched after refresh, or when a token names a key not seen yet, as
+// happens right after auth-service's key is rotated. If a fetch fails, the
+// keys fetched last are kept.
+type KeySet struct {
+	url     string
+	refresh time.Duration
+	client  *http.Client
+
+	mu        sync.Mutex
+	keys      map[string]*rsa.PublicKey
+	fetchedAt time.Time
+}
+
+func NewKeySet(url string, refresh time.Duration) *KeySet {
+	return &KeySet{
+		url:     url,
+		refresh: refresh,
+		client:  &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+// PublicKey returns the key with the given ID
+func (s *KeySet) PublicKey(keyID string) (*rsa.PublicKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key, ok := s.keys[keyID]
+	stale := time.Since(s.fetchedAt) > s.refresh
+	if stale || (!ok && time.Since(s.fetchedAt) > keySetMinRefetch) {
+		keys, err := s.fetch()
+		s.fetchedAt = time.Now()
+		if err != nil && s.keys == nil {
+			return nil, err
+		}
+		if err == nil {
+			s.keys = keys
+		}
+		key, ok = s.keys[keyID]
+	}
+	if !ok {
+		return nil, fmt.Errorf("unknown signing key %q", keyID)
+	}
+	return key, nil
+}
+
+// jwk is an RSA public key in JSON Web Key form
+type jwk struct {
+	KeyType string `json:"kty"`
+	KeyID   string `json:"kid"`
+	N       string `json:"n"`
+	E       string `json:"e"`
+}
+
+func (s *KeySet) fetch() (map[string]*rsa.PublicKey, error) {
+	resp, err := s.client.Get(s.url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch JWKS: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch JWKS: %s", resp.Status)
+	}
+
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return nil, fmt.Errorf("failed to decode JWKS: %w", err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.KeyType != "RSA" {
+			continue
+		}
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("invalid modulus of key %q: %w", k.KeyID, err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, fmt.Errorf("invalid exponent of key %q: %w", k.KeyID, err)
+		}
+		exponent := new(big.Int).SetBytes(e)
+		if !exponent.IsInt64() || exponent.Int64() < 3 || exponent.Int64() > 1<<31-1 {
+			return nil, fmt.Errorf("invalid exponent of key %q", k.KeyID)
+		}
+		keys[k.KeyID] = &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exponent.Int64())}
+	}
+	return keys, nil
+}
+
+// thumbprint returns the RFC 7638 thumbprint of key, the key ID auth-service
+// publishes it under
+func thumbprint(key *rsa.PublicKey) string {
+	canonical := fmt.Sprintf(`{"e":%q,"kty":"RSA","n":%q}`,
+		base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		base64.RawURLEncoding.EncodeToString(key.N.Bytes()))
+	sum := sha256.Sum256([]byte(canonical))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// loadPrivateKey reads a PEM encoded RSA private key, in PKCS #1 or PKCS #8
+// form
+func loadPrivateKey(path string) (*rsa.PrivateKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read private key: %w", err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("private key is not PEM encoded")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key: %w", err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("private key is not an RSA key")
+	}
+	return key, nil
+}
@@ -0,0 +1,25 @@
+-- ========================================
+-- Audit Service Schema (Standalone)
+-- ========================================
+
+-- ========================================
+-- Audit Log (one row per sensitive operation, never updated or deleted)
+-- ========================================
+CREATE TABLE IF NOT EXISTS audit_service_entries (
+    id UUID PRIMARY KEY,
+    service VARCHAR(64) NOT NULL,
+    action VARCHAR(64) NOT NULL,
+    actor_id UUID,
+    target_type VARCHAR(32) NOT NULL,
+    target_id VARCHAR(255) NOT NULL DEFAULT '',
+    ip_address VARCHAR(64) NOT NULL DEFAULT '',
+    user_agent TEXT NOT NULL DEFAULT '',
+    changes JSONB NOT NULL DEFAULT '[]',
+    occurred_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    recorded_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_entries_occurred_at ON audit_service_entries(occurred_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_entries_actor ON audit_service_entries(actor_id, occurred_at DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_entries_target ON audit_service_entries(target_type, target_id, occurred_at DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_entries_action ON audit_service_entries(action, occurred_at DESC);
@@ -0,0 +1,96 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Entry is one recorded sensitive operation. Entries are never updated or
+// deleted.
+type Entry struct {
+	ID uuid.UUID `json:"id" db:"id"`
+	// Service is the service that performed the operation
+	Service string `json:"service" db:"service"`
+	Action  string `json:"action" db:"action"`
+	// ActorID is who performed the operation, nil when nobody was signed in
+	ActorID    *uuid.UUID `json:"actor_id,omitempty" db:"actor_id"`
+	TargetType string     `json:"target_type" db:"target_type"`
+	TargetID   string     `json:"target_id" db:"target_id"`
+	IPAddress  string     `json:"ip_address" db:"ip_address"`
+	UserAgent  string     `json:"user_agent" db:"user_agent"`
+	Changes    Changes    `json:"changes" db:"changes"`
+	OccurredAt time.Time  `json:"occurred_at" db:"occurred_at"`
+	RecordedAt time.Time  `json:"recorded_at" db:"recorded_at"`
+}
+
+// Change is one field an operation changed. Old and New are nil for fields
+// whose values are secret, such as passwords.
+type Change struct {
+	Field string  `json:"field"`
+	Old   *string `json:"old,omitempty"`
+	New   *string `json:"new,omitempty"`
+}
+
+// Changes is a list of changes stored as a JSON array
+type Changes []Change
+
+// Value implements driver.Valuer. The JSON is passed as text so that
+// Postgres parses it rather than receiving it as bytea.
+func (c Changes) Value() (driver.Value, error) {
+	if c == nil {
+		return "[]", nil
+	}
+	data, err := json.Marshal([]Change(c))
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// Scan implements sql.Scanner
+func (c *Changes) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*c = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(v, (*[]Change)(c))
+	case string:
+		return json.Unmarshal([]byte(v), (*[]Change)(c))
+	default:
+		return errors.New("unsupported type for Changes")
+	}
+}
+
+// EntryFilter narrows the audit log; nil and empty fields match everything
+type EntryFilter struct {
+	ActorID    *uuid.UUID
+	TargetType *string
+	TargetID   *string
+	Actions    []string
+	Service    *string
+	IPAddress  *string
+	Since      *time.Time
+	Until      *time.Time
+}
+
+type EntryEdge struct {
+	Cursor string `json:"cursor"`
+	Node   Entry  `json:"node"`
+}
+
+type PageInfo struct {
+	EndCursor       *string `json:"end_cursor,omitempty"`
+	HasNextPage     bool    `json:"has_next_page"`
+	StartCursor     *string `json:"start_cursor,omitempty"`
+	HasPreviousPage bool    `json:"has_previous_page"`
+}
+
+type EntryConnection struct {
+	Edges    []EntryEdge `json:"edges"`
+	PageInfo PageInfo    `json:"page_info"`
+}
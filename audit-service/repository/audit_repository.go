@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lib/pq"
+
+	"audit-service/db"
+	"audit-service/model"
+	"shared/cursor"
+)
+
+type AuditRepository interface {
+	Record(ctx context.Context, entry *models.Entry) error
+	List(ctx context.Context, filter models.EntryFilter, first int32, after *string) (*models.EntryConnection, error)
+}
+
+type auditRepository struct {
+	db *database.DB
+}
+
+func NewAuditRepository(db *database.DB) AuditRepository {
+	return &auditRepository{db: db}
+}
+
+const entryColumns = `id, service, action, actor_id, target_type, target_id, ip_address, user_agent, changes, occurred_at, recorded_at`
+
+// Record appends an entry to the audit log. Recording an entry again is a
+// no-op, so redelivered events are not recorded twice.
+func (r *auditRepository) Record(ctx context.Context, entry *models.Entry) error {
+	query := `
+		INSERT INTO audit_service_entries (id, service, action, actor_id, target_type, target_id, ip_address, user_agent, changes, occurred_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (id) DO NOTHING
+	`
+	_, err := r.db.Conn(ctx).ExecContext(ctx, query,
+		entry.ID, entry.Service, entry.Action, entry.ActorID, entry.TargetType, entry.TargetID,
+		entry.IPAddress, entry.UserAgent, entry.Changes, entry.OccurredAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to record audit entry: %w", err)
+	}
+	return nil
+}
+
+// List returns a page of entries matching filter, newest first
+func (r *auditRepository) List(ctx context.Context, filter models.EntryFilter, first int32, after *string) (*models.EntryConnection, error) {
+	query := `SELECT ` + entryColumns + ` FROM audit_service_entries WHERE TRUE`
+	args := []interface{}{}
+	if filter.ActorID != nil {
+		args = append(args, *filter.ActorID)
+		query += fmt.Sprintf(" AND actor_id = $%d", len(args))
+	}
+	if filter.TargetType != nil {
+		args = append(args, *filter.TargetType)
+		query += fmt.Sprintf(" AND target_type = $%d", len(args))
+	}
+	if filter.TargetID != nil {
+		args = append(args, *filter.TargetID)
+		query += fmt.Sprintf(" AND target_id = $%d", len(args))
+	}
+	if len(filter.Actions) > 0 {
+		args = append(args, pq.Array(filter.Actions))
+		query += fmt.Sprintf(" AND action = ANY($%d)", len(args))
+	}
+	if filter.Service != nil {
+		args = append(args, *filter.Service)
+		query += fmt.Sprintf(" AND service = $%d", len(args))
+	}
+	if filter.IPAddress != nil {
+		args = append(args, *filter.IPAddress)
+		query += fmt.Sprintf(" AND ip_address = $%d", len(args))
+	}
+	if filter.Since != nil {
+		args = append(args, *filter.Since)
+		query += fmt.Sprintf(" AND occurred_at >= $%d", len(args))
+	}
+	if filter.Until != nil {
+		args = append(args, *filter.Until)
+		query += fmt.Sprintf(" AND occurred_at < $%d", len(args))
+	}
+	query, args, err := cursor.Keyset{TimeColumn: "occurred_at", IDColumn: "id"}.Page(query, args, after, int(first))
+	if err != nil {
+		return nil, err
+	}
+
+	entries := []models.Entry{}
+	if err := r.db.ReadDB().SelectContext(ctx, &entries, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to list audit entries: %w", err)
+	}
+
+	entries, hasNextPage := cursor.Trim(entries, int(first))
+
+	edges := make([]models.EntryEdge, len(entries))
+	for i, entry := range entries {
+		edges[i] = models.EntryEdge{
+			Cursor: cursor.EncodeKeyset(entry.OccurredAt, entry.ID),
+			Node:   entry,
+		}
+	}
+
+	pageInfo := models.PageInfo{
+		HasNextPage:     hasNextPage,
+		HasPreviousPage: after != nil && *after != "",
+	}
+	if len(edges) > 0 {
+		startCursor := edges[0].Cursor
+		endCursor := edges[len(edges)-1].Cursor
+		pageInfo.StartCursor = &startCursor
+		pageInfo.EndCursor = &endCursor
+	}
+
+	return &models.EntryConnection{Edges: edges, PageInfo: pageInfo}, nil
+}
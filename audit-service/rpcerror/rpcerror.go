@@ -0,0 +1,118 @@
+// Package rpcerror builds gRPC errors that carry machine-readable details, so
+// callers can tell errors apart without parsing their messages:
+//
+//	ErrorInfo   every error has one; its reason is a stable code such as
+//	            NOT_FOUND, or a more specific one such as ACCOUNT_LOCKED
+//	BadRequest  lists the request fields that are not valid, and why
+//
+// Handlers return New or InvalidField errors where they know more than the
+// status code says. The server interceptors give every other error an
+// ErrorInfo with the reason of its code.
+package rpcerror
+
+import (
+	"context"
+	"strings"
+	"unicode"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// Domain is the ErrorInfo domain of this service's errors
+const Domain = "audit.muzeeng"
+
+// FieldViolation is a request field that is not valid, and why
+type FieldViolation struct {
+	Field       string
+	Description string
+}
+
+// New returns an error with code and msg whose ErrorInfo has reason
+func New(code codes.Code, reason, msg string) error {
+	return withDetails(status.New(code, msg), &errdetails.ErrorInfo{Reason: reason, Domain: Domain})
+}
+
+// InvalidField returns an InvalidArgument error for one field. description
+// is also the error's message, e.g. "post_id is required".
+func InvalidField(field, description string) error {
+	return InvalidFields(FieldViolation{Field: field, Description: description})
+}
+
+// InvalidFields returns an InvalidArgument error listing every violation,
+// with the description of the first as its message
+func InvalidFields(violations ...FieldViolation) error {
+	msg := "invalid request"
+	badRequest := &errdetails.BadRequest{}
+	for i, v := range violations {
+		if i == 0 {
+			msg = v.Description
+		}
+		badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       v.Field,
+			Description: v.Description,
+		})
+	}
+	return withDetails(status.New(codes.InvalidArgument, msg),
+		&errdetails.ErrorInfo{Reason: Reason(codes.InvalidArgument), Domain: Domain},
+		badRequest,
+	)
+}
+
+// Reason is the ErrorInfo reason of errors that have no more specific one:
+// the name of their code, e.g. INVALID_ARGUMENT for codes.InvalidArgument
+func Reason(code codes.Code) string {
+	var b strings.Builder
+	prev := ' '
+	for _, r := range code.String() {
+		if unicode.IsUpper(r) && unicode.IsLower(prev) {
+			b.WriteByte('_')
+		}
+		b.WriteRune(unicode.ToUpper(r))
+		prev = r
+	}
+	return b.String()
+}
+
+// UnaryServerInterceptor gives the errors of unary calls an ErrorInfo if they
+// have none
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		resp, err := handler(ctx, req)
+		return resp, withReason(err)
+	}
+}
+
+// StreamServerInterceptor gives the errors of streaming calls an ErrorInfo if
+// they have none
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return withReason(handler(srv, ss))
+	}
+}
+
+func withReason(err error) error {
+	if err == nil {
+		return nil
+	}
+	st := status.Convert(err)
+	for _, detail := range st.Details() {
+		if _, ok := detail.(*errdetails.ErrorInfo); ok {
+			return err
+		}
+	}
+	return withDetails(st, &errdetails.ErrorInfo{Reason: Reason(st.Code()), Domain: Domain})
+}
+
+// withDetails returns st with details, or without them if they cannot be
+// encoded, which leaves the error still meaningful by its code
+func withDetails(st *status.Status, details ...protoadapt.MessageV1) error {
+	detailed, err := st.WithDetails(details...)
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
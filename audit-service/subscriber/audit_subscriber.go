@@ -0,0 +1,96 @@
+package subscriber
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/nats-io/nats.go"
+
+	"audit-service/events"
+	"audit-service/model"
+	natsClient "audit-service/nats"
+	"audit-service/repository"
+	"shared/logging"
+	"shared/tracing"
+)
+
+// subscribeRetry is the wait between attempts to subscribe while the stream
+// does not exist yet
+const subscribeRetry = 5 * time.Second
+
+// AuditSubscriber records the audit events other services publish. It
+// consumes the JetStream stream through a durable consumer, so events
+// published while the service is down are still recorded, and recording one
+// twice is harmless.
+type AuditSubscriber struct {
+	natsClient *natsClient.Client
+	repo       repository.AuditRepository
+	ctx        context.Context
+}
+
+func NewAuditSubscriber(
+	natsClient *natsClient.Client,
+	repo repository.AuditRepository,
+	ctx context.Context,
+) *AuditSubscriber {
+	return &AuditSubscriber{
+		natsClient: natsClient,
+		repo:       repo,
+		ctx:        ctx,
+	}
+}
+
+// Start subscribes in the background. The stream is created by
+// notification-service, so subscribing is retried until it exists.
+func (s *AuditSubscriber) Start() {
+	go func() {
+		for {
+			_, err := s.natsClient.SubscribeDurable(events.AuditRecorded, "audit-service-entries", "audit-workers", s.handle)
+			if err == nil {
+				log.Println("Audit subscriber started successfully")
+				return
+			}
+
+			log.Printf("Failed to start audit subscriber, retrying: %v", err)
+			select {
+			case <-time.After(subscribeRetry):
+			case <-s.ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+func (s *AuditSubscriber) handle(msg *nats.Msg) {
+	ctx, span := tracing.StartProcess(s.ctx, msg.Subject, msg.Header)
+	ctx = logging.Extract(ctx, msg.Subject, msg.Header)
+	defer span.End()
+
+	var event events.AuditRecordedEvent
+	if err := natsClient.DecodeEvent(msg, &event); err != nil {
+		logging.FromContext(ctx).Error().Err(err).Msg("failed to decode audit event")
+		msg.Nak()
+		return
+	}
+
+	entry := &models.Entry{
+		ID:         event.ID,
+		Service:    event.Service,
+		Action:     event.Action,
+		ActorID:    event.ActorID,
+		TargetType: event.TargetType,
+		TargetID:   event.TargetID,
+		IPAddress:  event.IPAddress,
+		UserAgent:  event.UserAgent,
+		Changes:    event.Changes,
+		OccurredAt: event.OccurredAt,
+	}
+	if err := s.repo.Record(ctx, entry); err != nil {
+		logging.FromContext(ctx).Error().Err(err).Str("action", event.Action).Msg("failed to record audit entry")
+		msg.Nak()
+		return
+	}
+
+	msg.Ack()
+}
@@ -2,32 +2,60 @@ package main
 
 import (
 	"context"
+	"crypto/rsa"
+	"flag"
 	"fmt"
 	"log"
 	"net"
+	"net/http"
 	"os"
-	"os/signal"
-	"syscall"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
+	"github.com/redis/go-redis/v9"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
 
 	"auth-service/config"
 	"auth-service/db"
 	"auth-service/handler"
+	"auth-service/keyring"
+	"auth-service/lockout"
+	"auth-service/migrations"
+	natsClient "auth-service/nats"
+	"auth-service/oauth"
 	pb "auth-service/pb"
 	"auth-service/pkg/jwt"
+	"auth-service/publisher"
 	"auth-service/repository"
+	"auth-service/rpcerror"
+	"auth-service/subscriber"
+	"shared/chaos"
+	"shared/idempotency"
+	"shared/lifecycle"
+	"shared/logging"
+	"shared/migrate"
+	"shared/mtls"
+	"shared/tracing"
 )
 
 func main() {
+	logging.Init("auth-service")
+
+	migrateFlag := flag.Bool("migrate", false, "apply pending schema migrations before serving")
+	flag.Parse()
+
 	// Load environment variables
 	if err := godotenv.Load(); err != nil {
 		log.Println("No Auth .env file found")
 	}
 
+	// The supervisor health-checks the dependencies below and closes them
+	// in order on shutdown
+	supervisor := lifecycle.New(pb.AuthService_ServiceDesc.ServiceName)
+
 	// Load Database Config
 	dbConfig, err := config.LoadDatabaseConfig("AUTH_")
 	if err != nil {
@@ -44,11 +72,18 @@ func main() {
 		MaxOpenConns: dbConfig.MaxOpenConns,
 		MaxIdleConns: dbConfig.MaxIdleConns,
 		MaxLifetime:  dbConfig.MaxLifetime,
+		ReplicaDSNs:  dbConfig.ReplicaDSNs,
 	})
 	if err != nil {
 		log.Fatalf("Failed to connect to Auth-database: %v", err)
 	}
-	defer db.Close()
+	supervisor.Add(lifecycle.Dependency{Name: "database", Check: db.HealthCheck, Close: db.Close})
+
+	// Apply pending schema migrations with -migrate or DB_MIGRATE=true;
+	// without either they are only reported
+	if err := migrate.OnStartup(context.Background(), db.DB, "auth-service", migrations.Files, *migrateFlag || os.Getenv("DB_MIGRATE") == "true"); err != nil {
+		log.Fatalf("Failed to migrate Auth-database: %v", err)
+	}
 	log.Println("Connected to Auth-database")
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -63,15 +98,76 @@ func main() {
 	if jwtSecret == "" {
 		log.Fatal("JWT_SECRET environment variable is required")
 	}
-	jwtManager := jwt.NewManager(jwtSecret)
+
+	// Fault injection for resilience testing; inert unless CHAOS_ENABLED=true
+	chaosInjector, err := chaos.FromEnv()
+	if err != nil {
+		log.Fatalf("Invalid chaos configuration: %v", err)
+	}
+
+	// Export traces when OTEL_EXPORTER_OTLP_ENDPOINT is set
+	shutdownTracing, err := tracing.Init(context.Background(), "auth-service")
+	if err != nil {
+		log.Fatalf("Failed to initialize tracing: %v", err)
+	}
+	supervisor.OnShutdown("tracing", shutdownTracing)
+
+	// Account deletion is announced on NATS for the other services to clean up
+	nats, err := natsClient.NewClient(natsClient.Config{
+		URL:           getEnv("NATS_URL", "nats://nats:4222"),
+		MaxReconnects: 10,
+		ReconnectWait: 2 * time.Second,
+		ClientID:      getEnv("NATS_CLIENT_ID", "auth-service"),
+		Chaos:         chaosInjector,
+	})
+	if err != nil {
+		log.Fatalf("Failed to initialize NATS client: %v", err)
+	}
+	supervisor.Add(lifecycle.Dependency{Name: "nats", Check: nats.HealthCheck, Drain: nats.Drain})
+	log.Println("NATS client initialized successfully")
+
+	// Failed logins are counted in Redis, shared by all replicas
+	redisClient := redis.NewClient(&redis.Options{
+		Addr:     getEnv("REDIS_URL", "redis:6379"),
+		Password: getEnv("REDIS_PASSWORD", ""),
+		DB:       getEnvAsInt("REDIS_DB", 0),
+	})
+	supervisor.Add(lifecycle.Dependency{Name: "redis", Check: func(ctx context.Context) error { return redisClient.Ping(ctx).Err() }, Close: redisClient.Close})
+	if err := redisClient.Ping(ctx).Err(); err != nil {
+		log.Fatalf("Failed to connect to Redis: %v", err)
+	}
+	log.Println("Redis connected successfully")
+
+	loginLockout := lockout.NewTracker(redisClient, lockout.Policy{
+		MaxAccountFailures: getEnvAsInt("LOGIN_MAX_ACCOUNT_FAILURES", 5),
+		MaxIPFailures:      getEnvAsInt("LOGIN_MAX_IP_FAILURES", 20),
+		Window:             getEnvAsDuration("LOGIN_FAILURE_WINDOW", 15*time.Minute),
+		BaseLockout:        getEnvAsDuration("LOGIN_LOCKOUT", time.Minute),
+		MaxLockout:         getEnvAsDuration("LOGIN_MAX_LOCKOUT", time.Hour),
+	})
 
 	// Token expiration configs
 	accessExpiry := getEnvAsDuration("ACCESS_TOKEN_EXPIRY", 15*time.Minute)
 	refreshExpiry := getEnvAsDuration("REFRESH_TOKEN_EXPIRY", 7*24*time.Hour)
 
 	// Repository & Handler
-	authRepo := repository.NewAuthRepository(db.DB)
-	authHandler := handler.NewAuthHandler(authRepo, jwtManager, accessExpiry, refreshExpiry)
+	authRepo := repository.NewAuthRepository(db)
+
+	// Replaced signing keys verify tokens for as long as any token lives
+	jwtManager, keyRing, err := newJWTManager(context.Background(), jwtSecret, authRepo, refreshExpiry)
+	if err != nil {
+		log.Fatalf("Invalid JWT configuration: %v", err)
+	}
+	if keyRing != nil {
+		go keyRing.Run(supervisor.Context())
+	}
+
+	authHandler := handler.NewAuthHandler(authRepo, publisher.NewEventPublisher(nats), jwtManager, keyRing, oauthProviders(), loginLockout, accessExpiry, refreshExpiry)
+
+	// Usernames are changed in user-service, and copied from its events
+	subscriberCtx, stopSubscribers := context.WithCancel(context.Background())
+	defer stopSubscribers()
+	subscriber.NewUsernameSubscriber(nats, authRepo, subscriberCtx).Start()
 
 	// Start gRPC Server
 	port := getEnv("GRPC_PORT", "50051")
@@ -81,27 +177,53 @@ func main() {
 		log.Fatalf("Failed to listen on port %s: %v", port, err)
 	}
 
-	server := grpc.NewServer()
+	// Retried registrations that carry an idempotency-key header get the
+	// first call's response instead of creating another account
+	idempotencyStore, err := idempotency.NewStore(db.WriteDB(), "auth_idempotency_keys", rpcerror.Domain, []byte(os.Getenv("IDEMPOTENCY_SECRET")))
+	if err != nil {
+		log.Fatalf("Invalid IDEMPOTENCY_SECRET: %v", err)
+	}
+	idempotencyCtx, stopIdempotency := context.WithCancel(supervisor.Context())
+	defer stopIdempotency()
+	go idempotencyStore.Run(idempotencyCtx)
+	idempotent := idempotencyStore.UnaryServerInterceptor(func(context.Context) string { return "" }, "/auth.AuthService/Register")
+
+	// TLS, and mutual TLS with a CA bundle, from GRPC_TLS_*
+	tlsOption, err := mtls.ServerOption()
+	if err != nil {
+		log.Fatalf("Failed to load TLS config: %v", err)
+	}
+
+	server := grpc.NewServer(
+		tlsOption,
+		tracing.ServerOption(),
+		grpc.ChainUnaryInterceptor(logging.UnaryServerInterceptor(), rpcerror.UnaryServerInterceptor(), chaosInjector.Unary(), idempotent),
+	)
 	pb.RegisterAuthServiceServer(server, authHandler)
 
+	supervisor.Register(server)
+
 	// Enable server reflection for debugging
 	reflection.Register(server)
 
-	// Graceful shutdown handling
+	// Publish the public keys services verify RS256 tokens with
+	jwksPort := getEnv("JWKS_PORT", "8080")
+	mux := http.NewServeMux()
+	mux.Handle("/.well-known/jwks.json", jwtManager.JWKSHandler(5*time.Minute))
+	jwksServer := &http.Server{Addr: ":" + jwksPort, Handler: mux, ReadHeaderTimeout: 5 * time.Second}
 	go func() {
-		log.Printf("Auth Service running on port %s", port)
-		if err := server.Serve(listener); err != nil {
-			log.Fatalf("gRPC server error: %v", err)
+		log.Printf("JWKS endpoint running on port %s", jwksPort)
+		if err := jwksServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatalf("JWKS server error: %v", err)
 		}
 	}()
 
-	// Wait for interrupt signal
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-	<-quit
-	log.Println("Shutting down gRPC Auth server...")
-	server.GracefulStop()
-	log.Println("Auth Server stopped cleanly")
+	supervisor.OnShutdown("jwks server", jwksServer.Shutdown)
+
+	log.Printf("Auth Service running on port %s", port)
+	if err := supervisor.Serve(server, listener); err != nil {
+		log.Fatalf("gRPC server error: %v", err)
+	}
 }
 
 // Helper functions
@@ -125,3 +247,87 @@ func getEnvAsDuration(key string, defaultVal time.Duration) time.Duration {
 	}
 	return dur
 }
+
+func getEnvAsInt(key string, defaultVal int) int {
+	val := os.Getenv(key)
+	if val == "" {
+		return defaultVal
+	}
+	n, err := strconv.Atoi(val)
+	if err != nil {
+		log.Printf("Invalid integer for %s, using default %d", key, defaultVal)
+		return defaultVal
+	}
+	return n
+}
+
+// oauthProviders returns the identity providers whose client is configured.
+// OAUTH_REDIRECT_URI is where the web frontend receives the codes.
+// newJWTManager signs tokens with secret unless JWT_ALGORITHM is RS256. RS256
+// tokens are signed with the key in JWT_PRIVATE_KEY_FILE, and also verified
+// with the keys in JWT_VERIFICATION_KEY_FILES. Without a key file the keys are
+// kept in the database, and a key ring rotating them is returned too. HS256
+// tokens signed with secret are still accepted if JWT_ALLOW_HS256 is true.
+func newJWTManager(ctx context.Context, secret string, repo repository.AuthRepository, retention time.Duration) (*jwt.Manager, *keyring.Ring, error) {
+	switch algorithm := getEnv("JWT_ALGORITHM", "HS256"); algorithm {
+	case "HS256":
+		return jwt.NewManager(secret), nil, nil
+	case "RS256":
+		if getEnv("JWT_ALLOW_HS256", "false") != "true" {
+			secret = ""
+		}
+
+		keyFile := getEnv("JWT_PRIVATE_KEY_FILE", "")
+		if keyFile == "" {
+			ring := keyring.New(repo, getEnvAsDuration("JWT_KEY_RELOAD", time.Minute), retention)
+			manager, err := ring.Manager(ctx, secret)
+			if err != nil {
+				return nil, nil, err
+			}
+			return manager, ring, nil
+		}
+
+		key, err := jwt.LoadPrivateKey(keyFile)
+		if err != nil {
+			return nil, nil, err
+		}
+		var others []*rsa.PublicKey
+		for _, file := range strings.Split(getEnv("JWT_VERIFICATION_KEY_FILES", ""), ",") {
+			if file = strings.TrimSpace(file); file == "" {
+				continue
+			}
+			other, err := jwt.LoadPublicKey(file)
+			if err != nil {
+				return nil, nil, fmt.Errorf("%s: %w", file, err)
+			}
+			others = append(others, other)
+		}
+		return jwt.NewRSAManager(jwt.NewSigningKey(key), others, secret), nil, nil
+	default:
+		return nil, nil, fmt.Errorf("unsupported JWT_ALGORITHM %q", algorithm)
+	}
+}
+
+func oauthProviders() oauth.Providers {
+	redirectURI := getEnv("OAUTH_REDIRECT_URI", "http://localhost:3000/oauth/callback")
+	timeout := getEnvAsDuration("OAUTH_TIMEOUT", 10*time.Second)
+
+	providers := oauth.Providers{}
+	if clientID := os.Getenv("GOOGLE_CLIENT_ID"); clientID != "" {
+		providers["google"] = oauth.NewGoogle(oauth.ClientConfig{
+			ClientID:     clientID,
+			ClientSecret: os.Getenv("GOOGLE_CLIENT_SECRET"),
+			RedirectURI:  redirectURI,
+			Timeout:      timeout,
+		})
+	}
+	if clientID := os.Getenv("GITHUB_CLIENT_ID"); clientID != "" {
+		providers["github"] = oauth.NewGitHub(oauth.ClientConfig{
+			ClientID:     clientID,
+			ClientSecret: os.Getenv("GITHUB_CLIENT_SECRET"),
+			RedirectURI:  redirectURI,
+			Timeout:      timeout,
+		})
+	}
+	return providers
+}
@@ -0,0 +1,71 @@
+package events
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	UserDeleted        = "user.deleted"
+	RefreshTokenReused = "security.refresh_token_reused"
+	// AuditRecorded is shared with user-service and recorded by audit-service
+	AuditRecorded = "audit.recorded"
+	// UsernameChanged is published by user-service when a username changes
+	UsernameChanged = "user.username_changed"
+)
+
+// Event payloads
+
+// UserDeletedEvent is published when a user deletes their account. Every
+// service holding data of the user removes it on receipt.
+type UserDeletedEvent struct {
+	UserID    uuid.UUID `json:"user_id"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+// UsernameChangedEvent holds the fields auth-service uses of user-service's
+// user.username_changed events. ReservedUntil is unset for a change of case
+// only.
+type UsernameChangedEvent struct {
+	UserID        uuid.UUID  `json:"user_id"`
+	OldUsername   string     `json:"old_username"`
+	NewUsername   string     `json:"new_username"`
+	ReservedUntil *time.Time `json:"reserved_until,omitempty"`
+}
+
+// RefreshTokenReusedEvent is published when a refresh token that was already
+// exchanged is presented again. Either the token was stolen or the client
+// holding it was, so the whole session has been revoked.
+type RefreshTokenReusedEvent struct {
+	UserID     uuid.UUID `json:"user_id"`
+	SessionID  uuid.UUID `json:"session_id"`
+	DeviceName string    `json:"device_name"`
+	IPAddress  string    `json:"ip_address"`
+	// ReusedFromIP is the address the used token was presented from
+	ReusedFromIP string    `json:"reused_from_ip"`
+	DetectedAt   time.Time `json:"detected_at"`
+}
+
+// AuditRecordedEvent describes a sensitive operation for audit-service to
+// record. ActorID is nil when nobody was signed in, e.g. for a failed login.
+type AuditRecordedEvent struct {
+	ID         uuid.UUID     `json:"id"`
+	Service    string        `json:"service"`
+	Action     string        `json:"action"`
+	ActorID    *uuid.UUID    `json:"actor_id,omitempty"`
+	TargetType string        `json:"target_type"`
+	TargetID   string        `json:"target_id"`
+	IPAddress  string        `json:"ip_address"`
+	UserAgent  string        `json:"user_agent"`
+	Changes    []FieldChange `json:"changes,omitempty"`
+	OccurredAt time.Time     `json:"occurred_at"`
+}
+
+// FieldChange is one field an audited operation changed. Old and New are
+// left out for secret fields, such as passwords.
+type FieldChange struct {
+	Field string  `json:"field"`
+	Old   *string `json:"old,omitempty"`
+	New   *string `json:"new,omitempty"`
+}
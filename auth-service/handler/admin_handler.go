@@ -0,0 +1,372 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"auth-service/events"
+	"auth-service/model"
+	pb "auth-service/pb"
+	"auth-service/pkg/jwt"
+	"auth-service/rpcerror"
+	"shared/logging"
+)
+
+func (h *AuthHandler) RevokeUserTokens(ctx context.Context, req *pb.RevokeUserTokensRequest) (*pb.Response, error) {
+	adminID, err := h.requireAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, rpcerror.InvalidField("user_id", "invalid user_id")
+	}
+
+	if err := h.repo.RevokeAllUserRefreshTokens(ctx, userID); err != nil {
+		return nil, status.Error(codes.Internal, "failed to revoke refresh tokens")
+	}
+	h.audit(ctx, AuditTokensRevoked, &adminID, auditTargetUser, userID.String())
+
+	return &pb.Response{
+		Success: true,
+		Message: "Refresh tokens revoked successfully",
+	}, nil
+}
+
+func (h *AuthHandler) SuspendUser(ctx context.Context, req *pb.SuspendUserRequest) (*pb.Response, error) {
+	adminID, err := h.requireAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, rpcerror.InvalidField("user_id", "invalid user_id")
+	}
+
+	var until *time.Time
+	if req.Until != nil {
+		if err := req.Until.CheckValid(); err != nil {
+			return nil, rpcerror.InvalidField("until", "invalid until")
+		}
+		t := req.Until.AsTime()
+		if !t.After(time.Now()) {
+			return nil, rpcerror.InvalidField("until", "until must be in the future")
+		}
+		until = &t
+	}
+
+	if _, err := h.repo.GetUserByID(ctx, userID); err != nil {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+
+	err = h.repo.WithTx(ctx, func(ctx context.Context) error {
+		if err := h.repo.SuspendUser(ctx, userID, req.Reason, adminID, until); err != nil {
+			return status.Error(codes.Internal, "failed to suspend user")
+		}
+		// Suspended users must not be able to mint new access tokens
+		if err := h.repo.RevokeAllUserRefreshTokens(ctx, userID); err != nil {
+			return status.Error(codes.Internal, "failed to revoke refresh tokens")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	changes := []events.FieldChange{{Field: "reason", New: &req.Reason}}
+	if until != nil {
+		untilText := until.Format(time.RFC3339)
+		changes = append(changes, events.FieldChange{Field: "until", New: &untilText})
+	}
+	h.audit(ctx, AuditUserSuspended, &adminID, auditTargetUser, userID.String(), changes...)
+
+	return &pb.Response{
+		Success: true,
+		Message: "User suspended successfully",
+	}, nil
+}
+
+func (h *AuthHandler) UnsuspendUser(ctx context.Context, req *pb.UnsuspendUserRequest) (*pb.Response, error) {
+	adminID, err := h.requireAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, rpcerror.InvalidField("user_id", "invalid user_id")
+	}
+
+	if err := h.repo.UnsuspendUser(ctx, userID); err != nil {
+		return nil, status.Error(codes.Internal, "failed to unsuspend user")
+	}
+	h.audit(ctx, AuditUserUnsuspended, &adminID, auditTargetUser, userID.String())
+
+	return &pb.Response{
+		Success: true,
+		Message: "User unsuspended successfully",
+	}, nil
+}
+
+// SetUserRoles replaces the roles of a user. Access tokens already issued
+// keep the roles they were issued with until the user next refreshes.
+func (h *AuthHandler) SetUserRoles(ctx context.Context, req *pb.SetUserRolesRequest) (*pb.SetUserRolesResponse, error) {
+	adminID, err := h.requireAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, rpcerror.InvalidField("user_id", "invalid user_id")
+	}
+
+	var roles []models.Role
+	for _, name := range req.Roles {
+		role := models.Role(strings.ToUpper(name))
+		if role != models.RoleUser && role != models.RoleAdmin {
+			return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("unknown role %q", name))
+		}
+		if !slices.Contains(roles, role) {
+			roles = append(roles, role)
+		}
+	}
+	if len(roles) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "at least one role is required")
+	}
+	// An admin removing their own ADMIN role could leave nobody to undo it
+	if userID == adminID && !slices.Contains(roles, models.RoleAdmin) {
+		return nil, status.Error(codes.FailedPrecondition, "admins cannot remove their own ADMIN role")
+	}
+	slices.Sort(roles)
+
+	if _, err := h.repo.GetUserByID(ctx, userID); err != nil {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+
+	var previous []models.Role
+	err = h.repo.WithTx(ctx, func(ctx context.Context) error {
+		var err error
+		previous, err = h.repo.GetUserRoles(ctx, userID)
+		if err != nil {
+			return status.Error(codes.Internal, "failed to get user roles")
+		}
+		if err := h.repo.SetUserRoles(ctx, userID, roles); err != nil {
+			return status.Error(codes.Internal, "failed to set user roles")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	slices.Sort(previous)
+
+	names := rolesToStrings(roles)
+	if !slices.Equal(previous, roles) {
+		h.audit(ctx, AuditRolesChanged, &adminID, auditTargetUser, userID.String(),
+			changed("roles", strings.Join(rolesToStrings(previous), ","), strings.Join(names, ",")))
+	}
+
+	return &pb.SetUserRolesResponse{Roles: names}, nil
+}
+
+func rolesToStrings(roles []models.Role) []string {
+	names := make([]string, len(roles))
+	for i, role := range roles {
+		names[i] = string(role)
+	}
+	return names
+}
+
+// PurgeExpiredTokens deletes refresh tokens that can no longer be used and
+// blacklist entries for access tokens that have expired anyway
+func (h *AuthHandler) PurgeExpiredTokens(ctx context.Context, req *pb.PurgeExpiredTokensRequest) (*pb.PurgeExpiredTokensResponse, error) {
+	if _, err := h.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	refreshDeleted, err := h.repo.DeleteExpiredRefreshTokens(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to purge refresh tokens")
+	}
+
+	blacklistDeleted, err := h.repo.DeleteExpiredBlacklistedTokens(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to purge token blacklist")
+	}
+
+	return &pb.PurgeExpiredTokensResponse{
+		RefreshTokensDeleted:     refreshDeleted,
+		BlacklistedTokensDeleted: blacklistDeleted,
+	}, nil
+}
+
+// RotateSigningKey creates a new signing key. It is only available when the
+// keys are kept in the database; keys from files are rotated by replacing
+// the files.
+func (h *AuthHandler) RotateSigningKey(ctx context.Context, req *pb.RotateSigningKeyRequest) (*pb.RotateSigningKeyResponse, error) {
+	adminID, err := h.requireAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if h.keyRing == nil {
+		return nil, status.Error(codes.FailedPrecondition, "signing keys are not managed by auth-service")
+	}
+
+	key, err := h.keyRing.Rotate(ctx)
+	if err != nil {
+		logging.FromContext(ctx).Error().Err(err).Msg("failed to rotate signing key")
+		return nil, status.Error(codes.Internal, "failed to rotate signing key")
+	}
+	h.audit(ctx, AuditSigningKeyRotated, &adminID, auditTargetSigningKey, key.ID)
+
+	logging.FromContext(ctx).Info().Str("key_id", key.ID).Stringer("admin_id", adminID).Time("activates_at", key.ActivatesAt).Msg("rotated signing key")
+	return &pb.RotateSigningKeyResponse{
+		KeyId:       key.ID,
+		ActivatesAt: timestamppb.New(key.ActivatesAt),
+	}, nil
+}
+
+// importedPasswordHash is stored for imported accounts. It is not a valid
+// bcrypt hash, so password login stays impossible until the owner sets one.
+const importedPasswordHash = "!imported"
+
+const maxImportBatch = 1000
+
+// ImportUsers creates accounts for users migrated from another platform with
+// their original creation times. A user whose id or email already exists is
+// mapped onto that account instead of creating a duplicate.
+func (h *AuthHandler) ImportUsers(ctx context.Context, req *pb.ImportUsersRequest) (*pb.ImportUsersResponse, error) {
+	adminID, err := h.requireAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(req.Users) > maxImportBatch {
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("at most %d users can be imported per request", maxImportBatch))
+	}
+
+	results := make([]*pb.ImportUserResult, 0, len(req.Users))
+	for _, u := range req.Users {
+		userID, err := uuid.Parse(u.Id)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("invalid user id %q", u.Id))
+		}
+		if u.Username == "" || u.Email == "" || u.CreatedAt == nil {
+			return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("username, email and created_at are required for user %s", u.Id))
+		}
+
+		result, err := h.importUser(ctx, adminID, userID, u)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+
+	return &pb.ImportUsersResponse{Results: results}, nil
+}
+
+func (h *AuthHandler) importUser(ctx context.Context, adminID, userID uuid.UUID, u *pb.ImportedUser) (*pb.ImportUserResult, error) {
+	result := &pb.ImportUserResult{Id: u.Id}
+
+	if existing, _ := h.repo.GetUserByID(ctx, userID); existing != nil {
+		result.UserId = existing.ID.String()
+		result.Outcome = pb.ImportUserOutcome_IMPORT_USER_OUTCOME_EXISTING
+		return result, nil
+	}
+	if existing, _ := h.repo.GetUserByEmail(ctx, u.Email); existing != nil {
+		result.UserId = existing.ID.String()
+		result.Outcome = pb.ImportUserOutcome_IMPORT_USER_OUTCOME_EXISTING
+		return result, nil
+	}
+	if existing, _ := h.repo.GetUserByUsername(ctx, u.Username); existing != nil || h.usernameReserved(ctx, u.Username) {
+		result.Outcome = pb.ImportUserOutcome_IMPORT_USER_OUTCOME_CONFLICT
+		return result, nil
+	}
+
+	createdAt := u.CreatedAt.AsTime()
+	user := &models.User{
+		ID:           userID,
+		Username:     u.Username,
+		Email:        u.Email,
+		PasswordHash: importedPasswordHash,
+		Bio:          u.Bio,
+		CreatedAt:    createdAt,
+		UpdatedAt:    createdAt,
+	}
+	userRole := &models.UserRole{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Role:      models.RoleUser,
+		CreatedAt: createdAt,
+	}
+
+	err := h.repo.WithTx(ctx, func(ctx context.Context) error {
+		if err := h.repo.CreateUser(ctx, user); err != nil {
+			return status.Error(codes.Internal, fmt.Sprintf("failed to create user %s", u.Id))
+		}
+		if err := h.repo.CreateUserRole(ctx, userRole); err != nil {
+			return status.Error(codes.Internal, fmt.Sprintf("failed to create role for user %s", u.Id))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	h.audit(ctx, AuditUserImported, &adminID, auditTargetUser, userID.String())
+
+	result.UserId = userID.String()
+	result.Outcome = pb.ImportUserOutcome_IMPORT_USER_OUTCOME_CREATED
+	return result, nil
+}
+
+// requireAdmin verifies the bearer token in the request metadata and returns
+// the caller's user ID if the token carries the ADMIN role
+func (h *AuthHandler) requireAdmin(ctx context.Context) (uuid.UUID, error) {
+	_, claims, err := h.accessToken(ctx)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	if !slices.Contains(claims.Roles, string(models.RoleAdmin)) {
+		return uuid.Nil, status.Error(codes.PermissionDenied, "admin role required")
+	}
+
+	adminID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		return uuid.Nil, status.Error(codes.Unauthenticated, "invalid user ID in token")
+	}
+
+	return adminID, nil
+}
+
+// accessToken returns the access token the call was made with and its claims
+func (h *AuthHandler) accessToken(ctx context.Context) (string, *jwt.Claims, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok || len(md["authorization"]) == 0 {
+		return "", nil, status.Error(codes.Unauthenticated, "authorization token is not provided")
+	}
+
+	token := md["authorization"][0]
+	if !strings.HasPrefix(token, "Bearer ") {
+		return "", nil, status.Error(codes.Unauthenticated, "invalid authorization format")
+	}
+	token = strings.TrimPrefix(token, "Bearer ")
+
+	claims, err := h.jwtManager.Verify(token)
+	if err != nil {
+		return "", nil, status.Error(codes.Unauthenticated, "invalid access token")
+	}
+
+	return token, claims, nil
+}
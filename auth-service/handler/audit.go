@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"auth-service/events"
+	"shared/logging"
+)
+
+// Actions recorded in the audit log
+const (
+	AuditLogin             = "LOGIN"
+	AuditLoginFailed       = "LOGIN_FAILED"
+	AuditPasswordChanged   = "PASSWORD_CHANGED"
+	AuditAccountDeleted    = "ACCOUNT_DELETED"
+	AuditRolesChanged      = "ROLES_CHANGED"
+	AuditUserSuspended     = "USER_SUSPENDED"
+	AuditUserUnsuspended   = "USER_UNSUSPENDED"
+	AuditTokensRevoked     = "TOKENS_REVOKED"
+	AuditUserImported      = "USER_IMPORTED"
+	AuditSigningKeyRotated = "SIGNING_KEY_ROTATED"
+)
+
+const (
+	auditService = "auth-service"
+	// auditTargetEmail is the target of failed logins to unknown accounts
+	auditTargetEmail      = "EMAIL"
+	auditTargetUser       = "USER"
+	auditTargetSigningKey = "SIGNING_KEY"
+)
+
+// audit publishes a sensitive operation for audit-service to record, with
+// the client it was made from. Auditing is best effort, like the login
+// lockout: a failure to publish is logged and the operation goes ahead.
+func (h *AuthHandler) audit(ctx context.Context, action string, actorID *uuid.UUID, targetType, targetID string, changes ...events.FieldChange) {
+	event := events.AuditRecordedEvent{
+		ID:         uuid.New(),
+		Service:    auditService,
+		Action:     action,
+		ActorID:    actorID,
+		TargetType: targetType,
+		TargetID:   targetID,
+		IPAddress:  clientIP(ctx),
+		UserAgent:  clientUserAgent(ctx),
+		Changes:    changes,
+		OccurredAt: time.Now(),
+	}
+	if err := h.publisher.PublishAuditRecorded(ctx, event); err != nil {
+		logging.FromContext(ctx).Error().Err(err).Str("action", action).Msg("failed to publish audit event")
+	}
+}
+
+// changed describes a field changed from old to new
+func changed(field, old, new string) events.FieldChange {
+	return events.FieldChange{Field: field, Old: &old, New: &new}
+}
@@ -11,24 +11,39 @@ import (
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"auth-service/events"
+	"auth-service/keyring"
+	"auth-service/lockout"
 	"auth-service/model"
+	"auth-service/oauth"
 	pb "auth-service/pb"
 	"auth-service/pkg/jwt"
+	"auth-service/publisher"
 	"auth-service/repository"
+	"auth-service/rpcerror"
+	"shared/logging"
 )
 
 type AuthHandler struct {
 	pb.UnimplementedAuthServiceServer
 	repo          repository.AuthRepository
+	publisher     *publisher.EventPublisher
 	jwtManager    *jwt.Manager
+	keyRing       *keyring.Ring // nil unless signing keys are kept in the database
+	providers     oauth.Providers
+	lockout       *lockout.Tracker
 	accessExpiry  time.Duration
 	refreshExpiry time.Duration
 }
 
-func NewAuthHandler(repo repository.AuthRepository, jwtManager *jwt.Manager, accessExpiry, refreshExpiry time.Duration) *AuthHandler {
+func NewAuthHandler(repo repository.AuthRepository, publisher *publisher.EventPublisher, jwtManager *jwt.Manager, keyRing *keyring.Ring, providers oauth.Providers, lockout *lockout.Tracker, accessExpiry, refreshExpiry time.Duration) *AuthHandler {
 	return &AuthHandler{
 		repo:          repo,
+		publisher:     publisher,
 		jwtManager:    jwtManager,
+		keyRing:       keyRing,
+		providers:     providers,
+		lockout:       lockout,
 		accessExpiry:  accessExpiry,
 		refreshExpiry: refreshExpiry,
 	}
@@ -48,6 +63,9 @@ func (h *AuthHandler) Register(ctx context.Context, req *pb.RegisterRequest) (*p
 	if existingUser != nil {
 		return nil, status.Error(codes.AlreadyExists, "username already taken")
 	}
+	if h.usernameReserved(ctx, req.Username) {
+		return nil, rpcerror.New(codes.AlreadyExists, ReasonUsernameReserved, "username is reserved by its former owner")
+	}
 
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
 	if err != nil {
@@ -71,41 +89,42 @@ func (h *AuthHandler) Register(ctx context.Context, req *pb.RegisterRequest) (*p
 		user.Bio = req.Bio
 	}
 
-	if err := h.repo.CreateUser(ctx, user); err != nil {
-		return nil, status.Error(codes.Internal, "failed to create user")
-	}
-
 	userRole := &models.UserRole{
 		ID:        uuid.New(),
 		UserID:    user.ID,
 		Role:      models.RoleUser,
 		CreatedAt: now,
 	}
-	if err := h.repo.CreateUserRole(ctx, userRole); err != nil {
-		return nil, status.Error(codes.Internal, "failed to create user role")
-	}
 
 	roles := []string{string(models.RoleUser)}
-	accessToken, err := h.jwtManager.Generate(user.ID.String(), roles, h.accessExpiry)
+	refreshTokenModel := h.newSession(ctx, user.ID, now)
+	accessToken, err := h.jwtManager.Generate(user.ID.String(), refreshTokenModel.SessionID.String(), roles, h.accessExpiry)
 	if err != nil {
 		return nil, status.Error(codes.Internal, "failed to generate access token")
 	}
 
-	refreshToken, err := h.jwtManager.GenerateRefreshToken(user.ID.String(), h.refreshExpiry)
+	refreshToken, err := h.jwtManager.GenerateRefreshToken(user.ID.String(), refreshTokenModel.SessionID.String(), h.refreshExpiry)
 	if err != nil {
 		return nil, status.Error(codes.Internal, "failed to generate refresh token")
 	}
+	refreshTokenModel.Token = refreshToken
 
-	refreshTokenModel := &models.RefreshToken{
-		ID:        uuid.New(),
-		UserID:    user.ID,
-		Token:     refreshToken,
-		ExpiresAt: now.Add(h.refreshExpiry),
-		CreatedAt: now,
-		IsRevoked: false,
-	}
-	if err := h.repo.CreateRefreshToken(ctx, refreshTokenModel); err != nil {
-		return nil, status.Error(codes.Internal, "failed to store refresh token")
+	// The user, their role and their first refresh token are created together
+	// so a failure part way through never leaves a user who cannot log in
+	err = h.repo.WithTx(ctx, func(ctx context.Context) error {
+		if err := h.repo.CreateUser(ctx, user); err != nil {
+			return status.Error(codes.Internal, "failed to create user")
+		}
+		if err := h.repo.CreateUserRole(ctx, userRole); err != nil {
+			return status.Error(codes.Internal, "failed to create user role")
+		}
+		if err := h.repo.CreateRefreshToken(ctx, refreshTokenModel); err != nil {
+			return status.Error(codes.Internal, "failed to store refresh token")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return &pb.AuthResponse{
@@ -122,13 +141,25 @@ func (h *AuthHandler) Login(ctx context.Context, req *pb.LoginRequest) (*pb.Auth
 		return nil, status.Error(codes.InvalidArgument, "email and password are required")
 	}
 
+	ip := clientIP(ctx)
+	if err := h.checkLoginLockout(ctx, req.Email, ip); err != nil {
+		return nil, err
+	}
+
 	user, err := h.repo.GetUserByEmail(ctx, req.Email)
 	if err != nil {
-		return nil, status.Error(codes.NotFound, "invalid email or password")
+		h.audit(ctx, AuditLoginFailed, nil, auditTargetEmail, req.Email)
+		return nil, h.loginFailed(ctx, req.Email, ip, status.Error(codes.NotFound, "invalid email or password"))
 	}
 
 	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(req.Password)); err != nil {
-		return nil, status.Error(codes.Unauthenticated, "invalid email or password")
+		h.audit(ctx, AuditLoginFailed, nil, auditTargetUser, user.ID.String())
+		return nil, h.loginFailed(ctx, req.Email, ip, status.Error(codes.Unauthenticated, "invalid email or password"))
+	}
+	h.loginSucceeded(ctx, req.Email)
+
+	if err := h.checkNotSuspended(ctx, user.ID); err != nil {
+		return nil, err
 	}
 
 	roles, err := h.repo.GetUserRoles(ctx, user.ID)
@@ -141,28 +172,21 @@ func (h *AuthHandler) Login(ctx context.Context, req *pb.LoginRequest) (*pb.Auth
 		rolesStr[i] = string(role)
 	}
 
-	accessToken, err := h.jwtManager.Generate(user.ID.String(), rolesStr, h.accessExpiry)
+	refreshTokenModel := h.newSession(ctx, user.ID, time.Now())
+	accessToken, err := h.jwtManager.Generate(user.ID.String(), refreshTokenModel.SessionID.String(), rolesStr, h.accessExpiry)
 	if err != nil {
 		return nil, status.Error(codes.Internal, "failed to generate access token")
 	}
 
-	refreshToken, err := h.jwtManager.GenerateRefreshToken(user.ID.String(), h.refreshExpiry)
+	refreshToken, err := h.jwtManager.GenerateRefreshToken(user.ID.String(), refreshTokenModel.SessionID.String(), h.refreshExpiry)
 	if err != nil {
 		return nil, status.Error(codes.Internal, "failed to generate refresh token")
 	}
-
-	now := time.Now()
-	refreshTokenModel := &models.RefreshToken{
-		ID:        uuid.New(),
-		UserID:    user.ID,
-		Token:     refreshToken,
-		ExpiresAt: now.Add(h.refreshExpiry),
-		CreatedAt: now,
-		IsRevoked: false,
-	}
+	refreshTokenModel.Token = refreshToken
 	if err := h.repo.CreateRefreshToken(ctx, refreshTokenModel); err != nil {
 		return nil, status.Error(codes.Internal, "failed to store refresh token")
 	}
+	h.audit(ctx, AuditLogin, &user.ID, auditTargetUser, user.ID.String())
 
 	return &pb.AuthResponse{
 		AccessToken:  accessToken,
@@ -183,9 +207,9 @@ func (h *AuthHandler) RefreshToken(ctx context.Context, req *pb.RefreshTokenRequ
 		return nil, status.Error(codes.Unauthenticated, "invalid refresh token")
 	}
 
-	_, err = h.repo.GetRefreshToken(ctx, req.RefreshToken)
+	oldRefreshToken, err := h.repo.GetRefreshToken(ctx, req.RefreshToken)
 	if err != nil {
-		return nil, status.Error(codes.Unauthenticated, "refresh token not found or expired")
+		return nil, h.checkRefreshTokenReuse(ctx, req.RefreshToken)
 	}
 
 	userID, err := uuid.Parse(claims.UserID)
@@ -198,6 +222,10 @@ func (h *AuthHandler) RefreshToken(ctx context.Context, req *pb.RefreshTokenRequ
 		return nil, status.Error(codes.NotFound, "user not found")
 	}
 
+	if err := h.checkNotSuspended(ctx, user.ID); err != nil {
+		return nil, err
+	}
+
 	roles, err := h.repo.GetUserRoles(ctx, user.ID)
 	if err != nil {
 		return nil, status.Error(codes.Internal, "failed to get user roles")
@@ -208,31 +236,37 @@ func (h *AuthHandler) RefreshToken(ctx context.Context, req *pb.RefreshTokenRequ
 		rolesStr[i] = string(role)
 	}
 
-	accessToken, err := h.jwtManager.Generate(user.ID.String(), rolesStr, h.accessExpiry)
+	newRefreshTokenModel := h.continueSession(ctx, oldRefreshToken, time.Now())
+	accessToken, err := h.jwtManager.Generate(user.ID.String(), newRefreshTokenModel.SessionID.String(), rolesStr, h.accessExpiry)
 	if err != nil {
 		return nil, status.Error(codes.Internal, "failed to generate access token")
 	}
 
-	newRefreshToken, err := h.jwtManager.GenerateRefreshToken(user.ID.String(), h.refreshExpiry)
+	newRefreshToken, err := h.jwtManager.GenerateRefreshToken(user.ID.String(), newRefreshTokenModel.SessionID.String(), h.refreshExpiry)
 	if err != nil {
 		return nil, status.Error(codes.Internal, "failed to generate refresh token")
 	}
+	newRefreshTokenModel.Token = newRefreshToken
 
-	if err := h.repo.RevokeRefreshToken(ctx, req.RefreshToken); err != nil {
-		return nil, status.Error(codes.Internal, "failed to revoke old refresh token")
-	}
-
-	now := time.Now()
-	newRefreshTokenModel := &models.RefreshToken{
-		ID:        uuid.New(),
-		UserID:    user.ID,
-		Token:     newRefreshToken,
-		ExpiresAt: now.Add(h.refreshExpiry),
-		CreatedAt: now,
-		IsRevoked: false,
+	err = h.repo.WithTx(ctx, func(ctx context.Context) error {
+		rotated, err := h.repo.RotateRefreshToken(ctx, req.RefreshToken)
+		if err != nil {
+			return status.Error(codes.Internal, "failed to revoke old refresh token")
+		}
+		if !rotated {
+			// A concurrent refresh exchanged the token first
+			return errRefreshTokenReused
+		}
+		if err := h.repo.CreateRefreshToken(ctx, newRefreshTokenModel); err != nil {
+			return status.Error(codes.Internal, "failed to store refresh token")
+		}
+		return nil
+	})
+	if err == errRefreshTokenReused {
+		return nil, h.checkRefreshTokenReuse(ctx, req.RefreshToken)
 	}
-	if err := h.repo.CreateRefreshToken(ctx, newRefreshTokenModel); err != nil {
-		return nil, status.Error(codes.Internal, "failed to store refresh token")
+	if err != nil {
+		return nil, err
 	}
 
 	return &pb.AuthResponse{
@@ -251,7 +285,7 @@ func (h *AuthHandler) Logout(ctx context.Context, req *pb.LogoutRequest) (*pb.Re
 
 	userID, err := uuid.Parse(req.UserId)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+		return nil, rpcerror.InvalidField("user_id", "invalid user_id")
 	}
 
 	claims, err := h.jwtManager.Verify(req.AccessToken)
@@ -295,7 +329,7 @@ func (h *AuthHandler) ChangePassword(ctx context.Context, req *pb.ChangePassword
 
 	userID, err := uuid.Parse(req.UserId)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+		return nil, rpcerror.InvalidField("user_id", "invalid user_id")
 	}
 
 	user, err := h.repo.GetUserByID(ctx, userID)
@@ -312,17 +346,87 @@ func (h *AuthHandler) ChangePassword(ctx context.Context, req *pb.ChangePassword
 		return nil, status.Error(codes.Internal, "failed to hash new password")
 	}
 
-	if err := h.repo.UpdateUserPassword(ctx, userID, string(hashedPassword)); err != nil {
-		return nil, status.Error(codes.Internal, "failed to update password")
+	err = h.repo.WithTx(ctx, func(ctx context.Context) error {
+		if err := h.repo.UpdateUserPassword(ctx, userID, string(hashedPassword)); err != nil {
+			return status.Error(codes.Internal, "failed to update password")
+		}
+		if err := h.repo.RevokeAllUserRefreshTokens(ctx, userID); err != nil {
+			return status.Error(codes.Internal, "failed to revoke refresh tokens")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
+	h.audit(ctx, AuditPasswordChanged, &userID, auditTargetUser, userID.String(), events.FieldChange{Field: "password"})
 
-	if err := h.repo.RevokeAllUserRefreshTokens(ctx, userID); err != nil {
-		return nil, status.Error(codes.Internal, "failed to revoke refresh tokens")
+	return &pb.Response{
+		Success: true,
+		Message: "Password changed successfully",
+	}, nil
+}
+
+// DeleteAccount deletes the caller's account after checking their password,
+// if they have one. Their access token is revoked with it, and the
+// user.deleted event has every other service remove the user's data.
+func (h *AuthHandler) DeleteAccount(ctx context.Context, req *pb.DeleteAccountRequest) (*pb.Response, error) {
+	token, claims, err := h.accessToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "invalid user ID in token")
 	}
 
+	user, err := h.repo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+
+	// Accounts created through an identity provider have no password to check
+	if user.PasswordHash != "" {
+		if req.Password == "" {
+			return nil, rpcerror.InvalidField("password", "password is required")
+		}
+		if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(req.Password)); err != nil {
+			return nil, status.Error(codes.Unauthenticated, "password is incorrect")
+		}
+	}
+
+	expiresAt := time.Now().Add(h.accessExpiry)
+	if claims.ExpiresAt != nil {
+		expiresAt = claims.ExpiresAt.Time
+	}
+
+	// The event is published last so that a failure to publish it keeps the
+	// account, and the user can try again
+	err = h.repo.WithTx(ctx, func(ctx context.Context) error {
+		if err := h.repo.DeleteUser(ctx, userID); err != nil {
+			return status.Error(codes.Internal, "failed to delete account")
+		}
+		if err := h.repo.AddTokenToBlacklist(ctx, token, expiresAt); err != nil {
+			return status.Error(codes.Internal, "failed to blacklist token")
+		}
+		if err := h.publisher.PublishUserDeleted(ctx, events.UserDeletedEvent{
+			UserID:    userID,
+			DeletedAt: time.Now(),
+		}); err != nil {
+			return status.Error(codes.Internal, fmt.Sprintf("failed to publish user deleted event: %v", err))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logging.FromContext(ctx).Info().Stringer("user_id", userID).Msg("deleted account")
+	h.audit(ctx, AuditAccountDeleted, &userID, auditTargetUser, userID.String())
+
 	return &pb.Response{
 		Success: true,
-		Message: "Password changed successfully",
+		Message: "Account deleted successfully",
 	}, nil
 }
 
@@ -370,6 +474,40 @@ func (h *AuthHandler) ValidateToken(ctx context.Context, req *pb.ValidateTokenRe
 		}, nil
 	}
 
+	// Tokens issued before sessions were tracked carry no session and stay
+	// valid until they expire
+	if claims.SessionID != "" {
+		sessionID, err := uuid.Parse(claims.SessionID)
+		if err != nil {
+			return &pb.ValidateTokenResponse{
+				Valid:   false,
+				Message: "invalid session ID in token",
+			}, nil
+		}
+		active, err := h.repo.TouchSession(ctx, sessionID, time.Now())
+		if err != nil {
+			return nil, status.Error(codes.Internal, "failed to check session")
+		}
+		if !active {
+			return &pb.ValidateTokenResponse{
+				Valid:   false,
+				Message: "session has been revoked",
+			}, nil
+		}
+	}
+
+	suspended, err := h.repo.IsUserSuspended(ctx, userID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to check user suspension")
+	}
+
+	if suspended {
+		return &pb.ValidateTokenResponse{
+			Valid:   false,
+			Message: "user is suspended",
+		}, nil
+	}
+
 	return &pb.ValidateTokenResponse{
 		Valid:   true,
 		UserId:  claims.UserID,
@@ -378,6 +516,18 @@ func (h *AuthHandler) ValidateToken(ctx context.Context, req *pb.ValidateTokenRe
 	}, nil
 }
 
+// checkNotSuspended rejects token issuance for suspended accounts
+func (h *AuthHandler) checkNotSuspended(ctx context.Context, userID uuid.UUID) error {
+	suspended, err := h.repo.IsUserSuspended(ctx, userID)
+	if err != nil {
+		return status.Error(codes.Internal, "failed to check user suspension")
+	}
+	if suspended {
+		return status.Error(codes.PermissionDenied, "account is suspended")
+	}
+	return nil
+}
+
 // Helper function to convert models.User to pb.User
 func convertUserToProto(user *models.User) *pb.User {
 	pbUser := &pb.User{
@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"auth-service/lockout"
+	"auth-service/rpcerror"
+	"shared/logging"
+)
+
+// Reasons of the ErrorInfo detail of a login refused by a lockout
+const (
+	ReasonAccountLocked = "ACCOUNT_LOCKED"
+	ReasonIPLocked      = "TOO_MANY_LOGIN_ATTEMPTS"
+)
+
+// checkLoginLockout refuses a login to a locked out account or from a locked
+// out IP. Lockouts are skipped if Redis cannot be reached, so an outage does
+// not keep everyone from logging in.
+func (h *AuthHandler) checkLoginLockout(ctx context.Context, email, ip string) error {
+	locked, err := h.lockout.Check(ctx, email, ip)
+	if err != nil {
+		logging.FromContext(ctx).Warn().Err(err).Msg("login lockout unavailable, allowing login")
+		return nil
+	}
+	if locked != nil {
+		return lockoutError(locked)
+	}
+	return nil
+}
+
+// loginFailed records a failed login and returns err, or the lockout error
+// if this failure caused one
+func (h *AuthHandler) loginFailed(ctx context.Context, email, ip string, err error) error {
+	locked, lockErr := h.lockout.Fail(ctx, email, ip)
+	if lockErr != nil {
+		logging.FromContext(ctx).Warn().Err(lockErr).Msg("failed to record login failure")
+		return err
+	}
+	if locked != nil {
+		logging.FromContext(ctx).Warn().Str("scope", string(locked.Scope)).Str("ip", ip).Dur("lockout", locked.RetryAfter).Msg("login locked out")
+		return lockoutError(locked)
+	}
+	return err
+}
+
+// loginSucceeded clears the failures of the account logged in to
+func (h *AuthHandler) loginSucceeded(ctx context.Context, email string) {
+	if err := h.lockout.Succeed(ctx, email); err != nil {
+		logging.FromContext(ctx).Warn().Err(err).Msg("failed to reset login failures")
+	}
+}
+
+// lockoutError is a ResourceExhausted error carrying an ErrorInfo with the
+// reason and a RetryInfo with the time left, for clients to show when they
+// may try again
+func lockoutError(locked *lockout.Lockout) error {
+	reason, what := ReasonAccountLocked, "this account"
+	if locked.Scope == lockout.ScopeIP {
+		reason, what = ReasonIPLocked, "this address"
+	}
+	// Round up, so clients retrying when told are not refused again
+	retryAfter := time.Duration(math.Ceil(locked.RetryAfter.Seconds())) * time.Second
+
+	st := status.New(codes.ResourceExhausted, fmt.Sprintf("too many failed logins from %s, try again in %s", what, retryAfter))
+	st, err := st.WithDetails(
+		&errdetails.ErrorInfo{Reason: reason, Domain: rpcerror.Domain},
+		&errdetails.RetryInfo{RetryDelay: durationpb.New(retryAfter)},
+	)
+	if err != nil {
+		return status.Error(codes.ResourceExhausted, "too many failed logins, try again later")
+	}
+	return st.Err()
+}
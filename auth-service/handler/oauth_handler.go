@@ -0,0 +1,204 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"auth-service/model"
+	"auth-service/oauth"
+	pb "auth-service/pb"
+	"shared/logging"
+)
+
+// maxUsernameAttempts is how many numbered variants of a provider's
+// username are tried before giving up on a free one
+const maxUsernameAttempts = 20
+
+// minUsernameLength and maxUsernameLength are the bounds user-service
+// enforces on usernames
+const (
+	minUsernameLength = 3
+	maxUsernameLength = 30
+)
+
+var usernameUnsafe = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// OAuthLogin signs a user in with an identity provider. The provider account
+// is matched to the user it is linked to, or linked to the user with the same
+// verified email, or else a new user is created for it.
+func (h *AuthHandler) OAuthLogin(ctx context.Context, req *pb.OAuthLoginRequest) (*pb.AuthResponse, error) {
+	if req.Provider == "" || req.Code == "" {
+		return nil, status.Error(codes.InvalidArgument, "provider and code are required")
+	}
+
+	provider, ok := h.providers.Get(req.Provider)
+	if !ok {
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("unsupported provider %q", req.Provider))
+	}
+	providerName := strings.ToLower(req.Provider)
+
+	identity, err := provider.Exchange(ctx, req.Code, req.GetRedirectUri())
+	if err != nil {
+		if errors.Is(err, oauth.ErrInvalidCode) {
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+		logging.FromContext(ctx).Error().Err(err).Str("provider", providerName).Msg("failed to exchange authorization code")
+		return nil, status.Error(codes.Unavailable, "identity provider is unavailable")
+	}
+	if identity.Subject == "" {
+		return nil, status.Error(codes.Unavailable, "identity provider returned no user")
+	}
+
+	user, err := h.repo.GetUserByOAuthIdentity(ctx, providerName, identity.Subject)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to look up identity")
+	}
+
+	message := "Login successful"
+	if user == nil {
+		if identity.Email == "" || !identity.EmailVerified {
+			return nil, status.Error(codes.FailedPrecondition, "identity provider did not return a verified email")
+		}
+
+		var created bool
+		user, created, err = h.linkOAuthIdentity(ctx, providerName, identity)
+		if err != nil {
+			return nil, err
+		}
+		if created {
+			message = "Registration successful"
+		}
+	}
+
+	if err := h.checkNotSuspended(ctx, user.ID); err != nil {
+		return nil, err
+	}
+
+	roles, err := h.repo.GetUserRoles(ctx, user.ID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to get user roles")
+	}
+
+	rolesStr := make([]string, len(roles))
+	for i, role := range roles {
+		rolesStr[i] = string(role)
+	}
+
+	refreshTokenModel := h.newSession(ctx, user.ID, time.Now())
+	accessToken, err := h.jwtManager.Generate(user.ID.String(), refreshTokenModel.SessionID.String(), rolesStr, h.accessExpiry)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to generate access token")
+	}
+
+	refreshToken, err := h.jwtManager.GenerateRefreshToken(user.ID.String(), refreshTokenModel.SessionID.String(), h.refreshExpiry)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to generate refresh token")
+	}
+	refreshTokenModel.Token = refreshToken
+	if err := h.repo.CreateRefreshToken(ctx, refreshTokenModel); err != nil {
+		return nil, status.Error(codes.Internal, "failed to store refresh token")
+	}
+	h.audit(ctx, AuditLogin, &user.ID, auditTargetUser, user.ID.String())
+
+	return &pb.AuthResponse{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		User:         convertUserToProto(user),
+		ExpiresIn:    int32(h.accessExpiry.Seconds()),
+		Message:      message,
+	}, nil
+}
+
+// linkOAuthIdentity links a provider account seen for the first time to the
+// user with its email, creating the user if there is none. It reports
+// whether the user was created.
+func (h *AuthHandler) linkOAuthIdentity(ctx context.Context, provider string, identity *oauth.Identity) (*models.User, bool, error) {
+	now := time.Now()
+	user, _ := h.repo.GetUserByEmail(ctx, identity.Email)
+	created := user == nil
+
+	if created {
+		user = &models.User{
+			ID:        uuid.New(),
+			Username:  h.freeUsername(ctx, identity.Username),
+			Email:     identity.Email,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+	}
+
+	err := h.repo.WithTx(ctx, func(ctx context.Context) error {
+		if created {
+			if err := h.repo.CreateUser(ctx, user); err != nil {
+				return status.Error(codes.Internal, "failed to create user")
+			}
+			userRole := &models.UserRole{
+				ID:        uuid.New(),
+				UserID:    user.ID,
+				Role:      models.RoleUser,
+				CreatedAt: now,
+			}
+			if err := h.repo.CreateUserRole(ctx, userRole); err != nil {
+				return status.Error(codes.Internal, "failed to create user role")
+			}
+		}
+		if err := h.repo.CreateOAuthIdentity(ctx, &models.OAuthIdentity{
+			Provider:  provider,
+			Subject:   identity.Subject,
+			UserID:    user.ID,
+			Email:     identity.Email,
+			CreatedAt: now,
+		}); err != nil {
+			return status.Error(codes.Internal, "failed to link identity")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	logging.FromContext(ctx).Info().Stringer("user_id", user.ID).Str("provider", provider).Bool("created", created).Msg("linked oauth identity")
+	return user, created, nil
+}
+
+// freeUsername turns a provider's username suggestion into a valid one no
+// one has taken or reserved, numbering it if needed
+func (h *AuthHandler) freeUsername(ctx context.Context, suggestion string) string {
+	base := usernameUnsafe.ReplaceAllString(strings.ToLower(suggestion), "")
+	if base == "" {
+		base = "user"
+	}
+	if len(base) < minUsernameLength {
+		base = "user_" + base
+	}
+
+	for i := 0; i < maxUsernameAttempts; i++ {
+		suffix := ""
+		if i > 0 {
+			suffix = strconv.Itoa(i + 1)
+		}
+		candidate := withUsernameSuffix(base, suffix)
+		if existing, _ := h.repo.GetUserByUsername(ctx, candidate); existing == nil && !h.usernameReserved(ctx, candidate) {
+			return candidate
+		}
+	}
+	return withUsernameSuffix(base, strings.ReplaceAll(uuid.NewString(), "-", "")[:8])
+}
+
+// withUsernameSuffix appends suffix to base, cutting base short so the
+// result is no longer than a username may be
+func withUsernameSuffix(base, suffix string) string {
+	if len(base)+len(suffix) > maxUsernameLength {
+		base = base[:maxUsernameLength-len(suffix)]
+	}
+	return base + suffix
+}
@@ -0,0 +1,201 @@
+package handler
+
+import (
+	"context"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"auth-service/model"
+	pb "auth-service/pb"
+	"auth-service/rpcerror"
+)
+
+// ListSessions returns the active sessions of the caller, most recently used
+// first
+func (h *AuthHandler) ListSessions(ctx context.Context, req *pb.ListSessionsRequest) (*pb.ListSessionsResponse, error) {
+	_, claims, err := h.accessToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "invalid user ID in token")
+	}
+
+	sessions, err := h.repo.ListSessions(ctx, userID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to list sessions")
+	}
+
+	resp := &pb.ListSessionsResponse{Sessions: make([]*pb.Session, len(sessions))}
+	for i, s := range sessions {
+		resp.Sessions[i] = &pb.Session{
+			Id:         s.SessionID.String(),
+			DeviceName: s.DeviceName,
+			IpAddress:  s.IPAddress,
+			UserAgent:  s.UserAgent,
+			SignedInAt: timestamppb.New(s.SignedInAt),
+			LastSeenAt: timestamppb.New(s.LastSeenAt),
+			ExpiresAt:  timestamppb.New(s.ExpiresAt),
+			Current:    s.SessionID.String() == claims.SessionID,
+		}
+	}
+	return resp, nil
+}
+
+// RevokeSession signs the caller out of one of their sessions. Its refresh
+// token stops working right away and ValidateToken rejects its access
+// tokens from then on.
+func (h *AuthHandler) RevokeSession(ctx context.Context, req *pb.RevokeSessionRequest) (*pb.Response, error) {
+	_, claims, err := h.accessToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "invalid user ID in token")
+	}
+
+	sessionID, err := uuid.Parse(req.SessionId)
+	if err != nil {
+		return nil, rpcerror.InvalidField("session_id", "invalid session_id")
+	}
+
+	revoked, err := h.repo.RevokeSession(ctx, userID, sessionID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to revoke session")
+	}
+	if !revoked {
+		return nil, status.Error(codes.NotFound, "session not found")
+	}
+
+	return &pb.Response{
+		Success: true,
+		Message: "Session revoked successfully",
+	}, nil
+}
+
+// newSession starts a session for a sign-in by the client of ctx. The caller
+// sets the token.
+func (h *AuthHandler) newSession(ctx context.Context, userID uuid.UUID, now time.Time) *models.RefreshToken {
+	token := &models.RefreshToken{
+		ID:         uuid.New(),
+		UserID:     userID,
+		ExpiresAt:  now.Add(h.refreshExpiry),
+		CreatedAt:  now,
+		SessionID:  uuid.New(),
+		SignedInAt: now,
+		LastSeenAt: now,
+	}
+	setClient(ctx, token)
+	return token
+}
+
+// continueSession returns the refresh token replacing old in its session.
+// The caller sets the token.
+func (h *AuthHandler) continueSession(ctx context.Context, old *models.RefreshToken, now time.Time) *models.RefreshToken {
+	token := &models.RefreshToken{
+		ID:         uuid.New(),
+		UserID:     old.UserID,
+		ExpiresAt:  now.Add(h.refreshExpiry),
+		CreatedAt:  now,
+		SessionID:  old.SessionID,
+		SignedInAt: old.SignedInAt,
+		DeviceName: old.DeviceName,
+		IPAddress:  old.IPAddress,
+		UserAgent:  old.UserAgent,
+		LastSeenAt: now,
+	}
+	setClient(ctx, token)
+	return token
+}
+
+// setClient records the client of ctx on a session
+func setClient(ctx context.Context, token *models.RefreshToken) {
+	if ip := clientIP(ctx); ip != "" {
+		token.IPAddress = ip
+	}
+	if userAgent := clientUserAgent(ctx); userAgent != "" {
+		token.UserAgent = userAgent
+		token.DeviceName = deviceName(userAgent)
+	}
+}
+
+// clientIP returns the IP address of the end user a call is made for. Calls
+// through the gateway carry it in x-client-ip; calls made directly come
+// from the peer.
+func clientIP(ctx context.Context) string {
+	if ip := incomingMetadata(ctx, "x-client-ip"); ip != "" {
+		return ip
+	}
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	if host, _, err := net.SplitHostPort(p.Addr.String()); err == nil {
+		return host
+	}
+	return p.Addr.String()
+}
+
+// clientUserAgent returns the user agent of the end user a call is made for,
+// which the gateway passes in x-client-user-agent
+func clientUserAgent(ctx context.Context) string {
+	if userAgent := incomingMetadata(ctx, "x-client-user-agent"); userAgent != "" {
+		return userAgent
+	}
+	return incomingMetadata(ctx, "user-agent")
+}
+
+func incomingMetadata(ctx context.Context, key string) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// deviceName gives a user agent a readable name such as "Firefox on Linux"
+func deviceName(userAgent string) string {
+	browsers := []struct{ token, name string }{
+		// Edge and Opera also claim to be Chrome, and Chrome to be Safari
+		{"Edg/", "Edge"},
+		{"OPR/", "Opera"},
+		{"Firefox/", "Firefox"},
+		{"Chrome/", "Chrome"},
+		{"Safari/", "Safari"},
+		{"grpc-", "gRPC client"},
+		{"curl/", "curl"},
+	}
+	systems := []struct{ token, name string }{
+		{"Android", "Android"},
+		{"iPhone", "iOS"},
+		{"iPad", "iPadOS"},
+		{"Windows", "Windows"},
+		{"Mac OS X", "macOS"},
+		{"CrOS", "ChromeOS"},
+		{"Linux", "Linux"},
+	}
+
+	browser := "Unknown browser"
+	for _, b := range browsers {
+		if strings.Contains(userAgent, b.token) {
+			browser = b.name
+			break
+		}
+	}
+	for _, s := range systems {
+		if strings.Contains(userAgent, s.token) {
+			return browser + " on " + s.name
+		}
+	}
+	return browser
+}
@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"auth-service/events"
+	"auth-service/model"
+	"shared/logging"
+)
+
+// errRefreshTokenReused is returned inside the refresh transaction when the
+// token was exchanged by someone else in the meantime
+var errRefreshTokenReused = errors.New("refresh token already used")
+
+// checkRefreshTokenReuse returns the error of a refresh with a token that is
+// not active. A token a refresh already replaced should only ever be held by
+// whoever it was stolen from, or whoever stole it, and it is impossible to
+// tell which of the two is asking, so the whole session it belongs to is
+// revoked and the user is warned.
+func (h *AuthHandler) checkRefreshTokenReuse(ctx context.Context, token string) error {
+	rotated, err := h.repo.GetRotatedRefreshToken(ctx, token)
+	if err != nil {
+		return status.Error(codes.Internal, "failed to check refresh token")
+	}
+	if rotated == nil {
+		return status.Error(codes.Unauthenticated, "refresh token not found or expired")
+	}
+
+	if _, err := h.repo.RevokeSession(ctx, rotated.UserID, rotated.SessionID); err != nil {
+		return status.Error(codes.Internal, "failed to revoke session")
+	}
+
+	var presenter models.RefreshToken
+	setClient(ctx, &presenter)
+
+	log := logging.FromContext(ctx)
+	log.Warn().Stringer("user_id", rotated.UserID).Stringer("session_id", rotated.SessionID).Str("ip", presenter.IPAddress).Msg("refresh token reused, session revoked")
+	if err := h.publisher.PublishRefreshTokenReused(ctx, events.RefreshTokenReusedEvent{
+		UserID:       rotated.UserID,
+		SessionID:    rotated.SessionID,
+		DeviceName:   rotated.DeviceName,
+		IPAddress:    rotated.IPAddress,
+		ReusedFromIP: presenter.IPAddress,
+		DetectedAt:   time.Now(),
+	}); err != nil {
+		log.Error().Err(err).Msg("failed to publish refresh token reused event")
+	}
+
+	return status.Error(codes.Unauthenticated, "refresh token has already been used; the session has been revoked")
+}
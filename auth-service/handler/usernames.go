@@ -0,0 +1,24 @@
+package handler
+
+import (
+	"context"
+
+	"shared/logging"
+)
+
+// ReasonUsernameReserved is the reason of the ErrorInfo detail of a
+// registration refused because the username is reserved for the user who
+// gave it up
+const ReasonUsernameReserved = "USERNAME_RESERVED"
+
+// usernameReserved reports whether username is reserved for the user who
+// gave it up in user-service. A reservation that cannot be checked counts as
+// one, so a username still leading to someone is never handed out.
+func (h *AuthHandler) usernameReserved(ctx context.Context, username string) bool {
+	reserved, err := h.repo.IsUsernameReserved(ctx, username)
+	if err != nil {
+		logging.FromContext(ctx).Warn().Err(err).Msg("failed to check reserved usernames")
+		return true
+	}
+	return reserved
+}
@@ -0,0 +1,176 @@
+// Package keyring keeps auth-service's RS256 signing keys in its database, so
+// that all replicas sign with the same key and rotating it needs no
+// redeploy. A new key is published for verification a while before it signs
+// anything, so that every replica, and every service caching the JWKS, knows
+// it by the time tokens signed with it show up. A replaced key keeps
+// verifying tokens until those signed with it have expired.
+package keyring
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"time"
+
+	models "auth-service/model"
+	"auth-service/pkg/jwt"
+	"auth-service/repository"
+	"shared/logging"
+)
+
+// keyBits is the size of the keys the ring generates
+const keyBits = 2048
+
+type Ring struct {
+	repo repository.AuthRepository
+	// reload is how often replicas pick up rotated keys. A new key starts
+	// signing two reloads after it is created.
+	reload time.Duration
+	// retention is how long a replaced key keeps verifying tokens, the
+	// lifetime of the longest-lived token
+	retention time.Duration
+	tokens    *jwt.Manager
+}
+
+func New(repo repository.AuthRepository, reload, retention time.Duration) *Ring {
+	return &Ring{
+		repo:      repo,
+		reload:    reload,
+		retention: retention,
+	}
+}
+
+// Manager loads the keys, creating the first one if there are none yet, and
+// returns a manager signing with them. HS256 tokens are verified with
+// legacySecret, or refused if it is empty.
+func (r *Ring) Manager(ctx context.Context, legacySecret string) (*jwt.Manager, error) {
+	stored, err := r.repo.ListSigningKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(stored) == 0 {
+		key, err := generate(time.Now())
+		if err != nil {
+			return nil, err
+		}
+		created, err := r.repo.CreateFirstSigningKey(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		if created {
+			logging.FromContext(ctx).Info().Str("key_id", key.ID).Msg("created first signing key")
+		}
+	}
+
+	current, others, err := r.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r.tokens = jwt.NewRSAManager(current, others, legacySecret)
+	return r.tokens, nil
+}
+
+// Run reloads the keys every reload interval until ctx is done, so that the
+// manager returned by Manager picks up keys rotated by any replica
+func (r *Ring) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.reload)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := r.Reload(ctx); err != nil {
+				logging.FromContext(ctx).Error().Err(err).Msg("failed to reload signing keys")
+			}
+		}
+	}
+}
+
+// Reload loads the keys into the manager returned by Manager
+func (r *Ring) Reload(ctx context.Context) error {
+	current, others, err := r.load(ctx)
+	if err != nil {
+		return err
+	}
+	r.tokens.SetKeys(current, others)
+	return nil
+}
+
+// Rotate creates a new signing key, which is published right away and
+// replaces the current one two reload intervals later
+func (r *Ring) Rotate(ctx context.Context) (*models.SigningKey, error) {
+	key, err := generate(time.Now().Add(2 * r.reload))
+	if err != nil {
+		return nil, err
+	}
+	if err := r.repo.CreateSigningKey(ctx, key); err != nil {
+		return nil, err
+	}
+	if err := r.Reload(ctx); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// load deletes keys past their retention and returns the current signing
+// key, the latest one already active, with the public halves of the others
+func (r *Ring) load(ctx context.Context) (jwt.SigningKey, []*rsa.PublicKey, error) {
+	now := time.Now()
+	if _, err := r.repo.DeleteRetiredSigningKeys(ctx, now.Add(-r.retention)); err != nil {
+		return jwt.SigningKey{}, nil, err
+	}
+	stored, err := r.repo.ListSigningKeys(ctx)
+	if err != nil {
+		return jwt.SigningKey{}, nil, err
+	}
+	if len(stored) == 0 {
+		return jwt.SigningKey{}, nil, errors.New("no signing keys")
+	}
+
+	// Keys are listed in the order they activate. Should none be active yet,
+	// the first is used rather than signing nothing.
+	current := 0
+	for i, key := range stored {
+		if !key.ActivatesAt.After(now) {
+			current = i
+		}
+	}
+
+	var signingKey jwt.SigningKey
+	others := make([]*rsa.PublicKey, 0, len(stored)-1)
+	for i, key := range stored {
+		privateKey, err := jwt.ParsePrivateKey([]byte(key.PrivateKey))
+		if err != nil {
+			return jwt.SigningKey{}, nil, fmt.Errorf("invalid signing key %s: %w", key.ID, err)
+		}
+		if i == current {
+			signingKey = jwt.SigningKey{ID: key.ID, Key: privateKey}
+		} else {
+			others = append(others, &privateKey.PublicKey)
+		}
+	}
+	return signingKey, others, nil
+}
+
+// generate creates a signing key activating at activatesAt
+func generate(activatesAt time.Time) (*models.SigningKey, error) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, keyBits)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate signing key: %w", err)
+	}
+	encoded, err := jwt.EncodePrivateKey(privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.SigningKey{
+		ID:          jwt.NewSigningKey(privateKey).ID,
+		PrivateKey:  string(encoded),
+		CreatedAt:   time.Now(),
+		ActivatesAt: activatesAt,
+	}, nil
+}
@@ -0,0 +1,148 @@
+// Package lockout throttles password guessing. Failed logins are counted per
+// account and per client IP in Redis, so that all auth-service replicas share
+// them. Once either reaches its limit it is locked out for a while, and every
+// further failure doubles the lockout up to a maximum.
+package lockout
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Policy is how many failures lock an account or IP out, and for how long
+type Policy struct {
+	// MaxAccountFailures and MaxIPFailures are the failures allowed before a
+	// lockout. An IP is allowed more, as several users may share it.
+	MaxAccountFailures int
+	MaxIPFailures      int
+	// Window is how long failures are remembered after the last one
+	Window time.Duration
+	// BaseLockout is the first lockout, doubled by each further failure up
+	// to MaxLockout
+	BaseLockout time.Duration
+	MaxLockout  time.Duration
+}
+
+// Scope is what a lockout applies to
+type Scope string
+
+const (
+	ScopeAccount Scope = "account"
+	ScopeIP      Scope = "ip"
+)
+
+// Lockout is an account or IP that may not log in for RetryAfter
+type Lockout struct {
+	Scope      Scope
+	RetryAfter time.Duration
+}
+
+// recordFailure counts a failure at KEYS[1] and, once there are ARGV[1] of
+// them, locks KEYS[2] out for ARGV[3] doubled for each failure past the
+// limit, capped at ARGV[4]. The count outlives the lockout by the window so
+// that failing again right after it escalates. It returns the lockout in
+// milliseconds, 0 if none.
+var recordFailure = redis.NewScript(`
+local max = tonumber(ARGV[1])
+local window_ms = tonumber(ARGV[2])
+local base_ms = tonumber(ARGV[3])
+local max_ms = tonumber(ARGV[4])
+
+local failures = redis.call('INCR', KEYS[1])
+local lock_ms = 0
+if failures >= max then
+  lock_ms = math.floor(math.min(base_ms * 2 ^ (failures - max), max_ms))
+  redis.call('SET', KEYS[2], '1', 'PX', lock_ms)
+end
+redis.call('PEXPIRE', KEYS[1], window_ms + lock_ms)
+return lock_ms
+`)
+
+// Tracker records failed logins and reports lockouts
+type Tracker struct {
+	redis  *redis.Client
+	policy Policy
+	prefix string
+}
+
+func NewTracker(client *redis.Client, policy Policy) *Tracker {
+	return &Tracker{redis: client, policy: policy, prefix: "auth:login:"}
+}
+
+// Check returns the lockout keeping account or ip from logging in, or nil
+func (t *Tracker) Check(ctx context.Context, account, ip string) (*Lockout, error) {
+	pipe := t.redis.Pipeline()
+	accountTTL := pipe.PTTL(ctx, t.lockKey(ScopeAccount, account))
+	var ipTTL *redis.DurationCmd
+	if ip != "" {
+		ipTTL = pipe.PTTL(ctx, t.lockKey(ScopeIP, ip))
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, fmt.Errorf("failed to check login lockout: %w", err)
+	}
+
+	// The longer lockout is the one that matters
+	var lockout *Lockout
+	if ttl := accountTTL.Val(); ttl > 0 {
+		lockout = &Lockout{Scope: ScopeAccount, RetryAfter: ttl}
+	}
+	if ipTTL != nil {
+		if ttl := ipTTL.Val(); ttl > 0 && (lockout == nil || ttl > lockout.RetryAfter) {
+			lockout = &Lockout{Scope: ScopeIP, RetryAfter: ttl}
+		}
+	}
+	return lockout, nil
+}
+
+// Fail records a failed login to account from ip and returns the lockout it
+// caused, or nil
+func (t *Tracker) Fail(ctx context.Context, account, ip string) (*Lockout, error) {
+	lockout, err := t.fail(ctx, ScopeAccount, account, t.policy.MaxAccountFailures)
+	if err != nil || ip == "" {
+		return lockout, err
+	}
+
+	ipLockout, err := t.fail(ctx, ScopeIP, ip, t.policy.MaxIPFailures)
+	if err != nil {
+		return lockout, err
+	}
+	if ipLockout != nil && (lockout == nil || ipLockout.RetryAfter > lockout.RetryAfter) {
+		lockout = ipLockout
+	}
+	return lockout, nil
+}
+
+// Succeed forgets the failures of account. Those of the IP are kept, or
+// logging in to an account of one's own would reset them.
+func (t *Tracker) Succeed(ctx context.Context, account string) error {
+	if err := t.redis.Del(ctx, t.failuresKey(ScopeAccount, account)).Err(); err != nil {
+		return fmt.Errorf("failed to reset login failures: %w", err)
+	}
+	return nil
+}
+
+func (t *Tracker) fail(ctx context.Context, scope Scope, subject string, max int) (*Lockout, error) {
+	keys := []string{t.failuresKey(scope, subject), t.lockKey(scope, subject)}
+	lockMS, err := recordFailure.Run(ctx, t.redis, keys,
+		max, t.policy.Window.Milliseconds(), t.policy.BaseLockout.Milliseconds(), t.policy.MaxLockout.Milliseconds(),
+	).Int64()
+	if err != nil {
+		return nil, fmt.Errorf("failed to record login failure: %w", err)
+	}
+	if lockMS == 0 {
+		return nil, nil
+	}
+	return &Lockout{Scope: scope, RetryAfter: time.Duration(lockMS) * time.Millisecond}, nil
+}
+
+func (t *Tracker) failuresKey(scope Scope, subject string) string {
+	return t.prefix + "failures:" + string(scope) + ":" + strings.ToLower(subject)
+}
+
+func (t *Tracker) lockKey(scope Scope, subject string) string {
+	return t.prefix + "locked:" + string(scope) + ":" + strings.ToLower(subject)
+}
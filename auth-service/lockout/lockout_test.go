@@ -0,0 +1,81 @@
+package lockout
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestFailDoublesLockoutUpToMax(t *testing.T) {
+	tracker := newTestTracker(t, Policy{
+		MaxAccountFailures: 3,
+		MaxIPFailures:      100,
+		Window:             time.Hour,
+		BaseLockout:        time.Minute,
+		MaxLockout:         5 * time.Minute,
+	})
+
+	// The lockout after each failure: none until the limit, then doubling
+	// from the base up to the maximum
+	want := []time.Duration{0, 0, time.Minute, 2 * time.Minute, 4 * time.Minute, 5 * time.Minute, 5 * time.Minute}
+	for i, want := range want {
+		lockout, err := tracker.Fail(context.Background(), "alice@example.com", "203.0.113.7")
+		if err != nil {
+			t.Fatalf("Fail() error = %v", err)
+		}
+		var got time.Duration
+		if lockout != nil {
+			got = lockout.RetryAfter
+			if lockout.Scope != ScopeAccount {
+				t.Errorf("failure %d: Scope = %q; want %q", i+1, lockout.Scope, ScopeAccount)
+			}
+		}
+		if got != want {
+			t.Errorf("failure %d: RetryAfter = %v; want %v", i+1, got, want)
+		}
+	}
+}
+
+func TestCheck(t *testing.T) {
+	tracker := newTestTracker(t, Policy{
+		MaxAccountFailures: 1,
+		MaxIPFailures:      2,
+		Window:             time.Hour,
+		BaseLockout:        time.Minute,
+		MaxLockout:         time.Hour,
+	})
+	ctx := context.Background()
+
+	if lockout, err := tracker.Check(ctx, "alice", "203.0.113.7"); err != nil || lockout != nil {
+		t.Fatalf("Check() = %v, %v; want nil, nil", lockout, err)
+	}
+
+	if _, err := tracker.Fail(ctx, "alice", "203.0.113.7"); err != nil {
+		t.Fatalf("Fail() error = %v", err)
+	}
+	// Accounts are matched case-insensitively
+	lockout, err := tracker.Check(ctx, "ALICE", "198.51.100.1")
+	if err != nil || lockout == nil || lockout.Scope != ScopeAccount {
+		t.Fatalf("Check() = %v, %v; want an account lockout", lockout, err)
+	}
+
+	// The second failure from the IP, whichever the account, locks it out
+	if _, err := tracker.Fail(ctx, "bob", "203.0.113.7"); err != nil {
+		t.Fatalf("Fail() error = %v", err)
+	}
+	lockout, err = tracker.Check(ctx, "carol", "203.0.113.7")
+	if err != nil || lockout == nil || lockout.Scope != ScopeIP {
+		t.Fatalf("Check() = %v, %v; want an IP lockout", lockout, err)
+	}
+}
+
+func newTestTracker(t *testing.T, policy Policy) *Tracker {
+	t.Helper()
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+	return NewTracker(client, policy)
+}
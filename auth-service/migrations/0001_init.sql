@@ -68,6 +68,16 @@ CREATE TABLE IF NOT EXISTS auth_user_roles (
     UNIQUE(user_id, role)
 );
 
+-- ========================================
+-- User Suspensions Table
+-- ========================================
+CREATE TABLE IF NOT EXISTS auth_user_suspensions (
+    user_id UUID PRIMARY KEY REFERENCES auth_users(id) ON DELETE CASCADE,
+    reason TEXT NOT NULL DEFAULT '',
+    suspended_by UUID,
+    suspended_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
 -- ========================================
 -- Indexes for Performance
 -- ========================================
@@ -91,7 +101,7 @@ BEGIN
 END;
 $$ LANGUAGE plpgsql;
 
-CREATE TRIGGER trigger_update_auth_users_updated_at
+CREATE OR REPLACE TRIGGER trigger_update_auth_users_updated_at
 BEFORE UPDATE ON auth_users
 FOR EACH ROW
 EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,16 @@
+-- ========================================
+-- OAuth Identities
+-- ========================================
+-- Identity provider accounts linked to local users. Users created through a
+-- provider have an empty password_hash, so they cannot sign in with a
+-- password.
+CREATE TABLE IF NOT EXISTS auth_oauth_identities (
+    provider VARCHAR(32) NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    user_id UUID NOT NULL REFERENCES auth_users(id) ON DELETE CASCADE,
+    email VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (provider, subject)
+);
+
+CREATE INDEX IF NOT EXISTS idx_auth_oauth_identities_user_id ON auth_oauth_identities(user_id);
@@ -0,0 +1,16 @@
+-- ========================================
+-- Sessions
+-- ========================================
+-- A session starts at sign-in and lives on through every refresh token issued
+-- from it. The client columns describe whoever last used the session.
+ALTER TABLE auth_refresh_tokens ADD COLUMN IF NOT EXISTS session_id UUID;
+UPDATE auth_refresh_tokens SET session_id = id WHERE session_id IS NULL;
+ALTER TABLE auth_refresh_tokens ALTER COLUMN session_id SET NOT NULL;
+
+ALTER TABLE auth_refresh_tokens ADD COLUMN IF NOT EXISTS signed_in_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW();
+ALTER TABLE auth_refresh_tokens ADD COLUMN IF NOT EXISTS device_name VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE auth_refresh_tokens ADD COLUMN IF NOT EXISTS ip_address VARCHAR(64) NOT NULL DEFAULT '';
+ALTER TABLE auth_refresh_tokens ADD COLUMN IF NOT EXISTS user_agent TEXT NOT NULL DEFAULT '';
+ALTER TABLE auth_refresh_tokens ADD COLUMN IF NOT EXISTS last_seen_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW();
+
+CREATE INDEX IF NOT EXISTS idx_auth_refresh_tokens_session_id ON auth_refresh_tokens(session_id);
@@ -0,0 +1,7 @@
+-- ========================================
+-- Refresh Token Rotation
+-- ========================================
+-- rotated_at is set on refresh tokens replaced by a refresh. They are kept
+-- until they expire, so that presenting one again is recognised as theft of
+-- the session rather than an unknown token.
+ALTER TABLE auth_refresh_tokens ADD COLUMN IF NOT EXISTS rotated_at TIMESTAMP WITH TIME ZONE;
@@ -0,0 +1,15 @@
+-- ========================================
+-- Signing Keys
+-- ========================================
+-- RS256 signing keys managed by auth-service itself, shared by its replicas.
+-- The key signing tokens is the latest one whose activates_at has passed.
+-- A key is kept, and published for verification, until the tokens signed
+-- with it have expired.
+CREATE TABLE IF NOT EXISTS auth_signing_keys (
+    id VARCHAR(64) PRIMARY KEY,
+    private_key TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    activates_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_auth_signing_keys_activates_at ON auth_signing_keys(activates_at);
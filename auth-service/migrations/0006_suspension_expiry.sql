@@ -0,0 +1,6 @@
+-- ========================================
+-- Suspension Expiry
+-- ========================================
+-- A suspension with expires_at lifts itself at that time; one without is a
+-- ban, lifted only by an admin.
+ALTER TABLE auth_user_suspensions ADD COLUMN IF NOT EXISTS expires_at TIMESTAMP WITH TIME ZONE;
@@ -0,0 +1,18 @@
+-- ========================================
+-- Idempotency Keys
+-- ========================================
+-- Write calls carrying an idempotency-key header, with the response a retry
+-- gets back. response is NULL while the first call is running; expired keys
+-- are pruned.
+CREATE TABLE IF NOT EXISTS auth_idempotency_keys (
+    scope VARCHAR(64) NOT NULL,
+    method VARCHAR(255) NOT NULL,
+    key VARCHAR(255) NOT NULL,
+    request_hash BYTEA NOT NULL,
+    response BYTEA,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (scope, method, key)
+);
+
+CREATE INDEX IF NOT EXISTS idx_auth_idempotency_keys_expires_at ON auth_idempotency_keys(expires_at);
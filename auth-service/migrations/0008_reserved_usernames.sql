@@ -0,0 +1,13 @@
+-- ========================================
+-- Reserved Usernames
+-- ========================================
+-- Usernames users gave up in user-service, kept from new accounts until
+-- reserved_until so they still lead to their former owner. username is
+-- lowercased, as reservations hold in any case.
+CREATE TABLE IF NOT EXISTS auth_reserved_usernames (
+    username VARCHAR(255) PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES auth_users(id) ON DELETE CASCADE,
+    reserved_until TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_auth_reserved_usernames_user_id ON auth_reserved_usernames(user_id);
@@ -19,6 +19,8 @@ type User struct {
 	PostsCount     int32     `json:"posts_count" db:"posts_count"`
 }
 
+// RefreshToken is the current token of a session. Refreshing replaces it
+// with a new one of the same session.
 type RefreshToken struct {
 	ID        uuid.UUID `json:"id" db:"id"`
 	UserID    uuid.UUID `json:"user_id" db:"user_id"`
@@ -26,6 +28,16 @@ type RefreshToken struct {
 	ExpiresAt time.Time `json:"expires_at" db:"expires_at"`
 	CreatedAt time.Time `json:"created_at" db:"created_at"`
 	IsRevoked bool      `json:"is_revoked" db:"is_revoked"`
+	// Session the token belongs to, from the sign-in that started it
+	SessionID  uuid.UUID `json:"session_id" db:"session_id"`
+	SignedInAt time.Time `json:"signed_in_at" db:"signed_in_at"`
+	// The client that last used the session
+	DeviceName string    `json:"device_name" db:"device_name"`
+	IPAddress  string    `json:"ip_address" db:"ip_address"`
+	UserAgent  string    `json:"user_agent" db:"user_agent"`
+	LastSeenAt time.Time `json:"last_seen_at" db:"last_seen_at"`
+	// RotatedAt is set when a refresh replaced the token
+	RotatedAt *time.Time `json:"rotated_at,omitempty" db:"rotated_at"`
 }
 
 type TokenBlacklist struct {
@@ -48,3 +60,23 @@ type UserRole struct {
 	Role      Role      `json:"role" db:"role"`
 	CreatedAt time.Time `json:"created_at" db:"created_at"`
 }
+
+// OAuthIdentity links an identity provider account to a user
+type OAuthIdentity struct {
+	Provider  string    `json:"provider" db:"provider"`
+	Subject   string    `json:"subject" db:"subject"`
+	UserID    uuid.UUID `json:"user_id" db:"user_id"`
+	Email     string    `json:"email" db:"email"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// SigningKey is an RS256 signing key. ID is the thumbprint of the key, which
+// tokens signed with it carry as kid.
+type SigningKey struct {
+	ID         string    `json:"id" db:"id"`
+	PrivateKey string    `json:"-" db:"private_key"` // PEM encoded
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+	// ActivatesAt is when the key starts signing tokens. It is published for
+	// verification from its creation on.
+	ActivatesAt time.Time `json:"activates_at" db:"activates_at"`
+}
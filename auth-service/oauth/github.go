@@ -0,0 +1,59 @@
+package oauth
+
+import (
+	"context"
+	"strconv"
+)
+
+const (
+	githubTokenURL  = "https://github.com/login/oauth/access_token"
+	githubUserURL   = "https://api.github.com/user"
+	githubEmailsURL = "https://api.github.com/user/emails"
+)
+
+// GitHub signs users in with their GitHub account. The client must request
+// the user:email scope, as most users keep their email private.
+type GitHub struct {
+	client client
+}
+
+func NewGitHub(cfg ClientConfig) *GitHub {
+	return &GitHub{client: newClient(cfg, githubTokenURL)}
+}
+
+func (g *GitHub) Exchange(ctx context.Context, code, redirectURI string) (*Identity, error) {
+	accessToken, err := g.client.exchange(ctx, code, redirectURI)
+	if err != nil {
+		return nil, err
+	}
+
+	var user struct {
+		ID    int64  `json:"id"`
+		Login string `json:"login"`
+	}
+	if err := g.client.get(ctx, githubUserURL, accessToken, &user); err != nil {
+		return nil, err
+	}
+
+	var emails []struct {
+		Email    string `json:"email"`
+		Primary  bool   `json:"primary"`
+		Verified bool   `json:"verified"`
+	}
+	if err := g.client.get(ctx, githubEmailsURL, accessToken, &emails); err != nil {
+		return nil, err
+	}
+
+	identity := &Identity{
+		Subject:  strconv.FormatInt(user.ID, 10),
+		Username: user.Login,
+	}
+	for _, e := range emails {
+		if e.Primary {
+			identity.Email = e.Email
+			identity.EmailVerified = e.Verified
+			break
+		}
+	}
+	return identity, nil
+}
@@ -0,0 +1,49 @@
+package oauth
+
+import (
+	"context"
+	"strings"
+)
+
+const (
+	googleTokenURL    = "https://oauth2.googleapis.com/token"
+	googleUserInfoURL = "https://openidconnect.googleapis.com/v1/userinfo"
+)
+
+// Google signs users in with their Google account. The client must request
+// the openid and email scopes.
+type Google struct {
+	client client
+}
+
+func NewGoogle(cfg ClientConfig) *Google {
+	return &Google{client: newClient(cfg, googleTokenURL)}
+}
+
+func (g *Google) Exchange(ctx context.Context, code, redirectURI string) (*Identity, error) {
+	accessToken, err := g.client.exchange(ctx, code, redirectURI)
+	if err != nil {
+		return nil, err
+	}
+
+	var info struct {
+		Sub           string `json:"sub"`
+		Email         string `json:"email"`
+		EmailVerified bool   `json:"email_verified"`
+		GivenName     string `json:"given_name"`
+	}
+	if err := g.client.get(ctx, googleUserInfoURL, accessToken, &info); err != nil {
+		return nil, err
+	}
+
+	username := info.GivenName
+	if username == "" {
+		username, _, _ = strings.Cut(info.Email, "@")
+	}
+	return &Identity{
+		Subject:       info.Sub,
+		Email:         info.Email,
+		EmailVerified: info.EmailVerified,
+		Username:      username,
+	}, nil
+}
@@ -0,0 +1,132 @@
+// Package oauth signs users in through third-party identity providers. A
+// provider exchanges the authorization code a user brings back from its
+// consent page for the identity of that user.
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// ErrInvalidCode is returned when a provider rejects an authorization code,
+// which happens when it is wrong, expired or already used
+var ErrInvalidCode = errors.New("invalid or expired authorization code")
+
+// Identity is a user as a provider knows them
+type Identity struct {
+	// Subject is the provider's stable ID for the user
+	Subject string
+	Email   string
+	// EmailVerified is whether the provider vouches that the user owns Email.
+	// Only a verified email links the identity to an existing account.
+	EmailVerified bool
+	// Username is a suggestion for the local username of a new account
+	Username string
+}
+
+// Provider exchanges authorization codes for identities
+type Provider interface {
+	Exchange(ctx context.Context, code, redirectURI string) (*Identity, error)
+}
+
+// ClientConfig is the OAuth client registered with a provider
+type ClientConfig struct {
+	ClientID     string
+	ClientSecret string
+	// RedirectURI is used for codes sent without one; it must match the one
+	// the code was issued for
+	RedirectURI string
+	Timeout     time.Duration
+}
+
+// Providers are the configured providers by name
+type Providers map[string]Provider
+
+// Get returns the provider called name, if it is configured
+func (p Providers) Get(name string) (Provider, bool) {
+	provider, ok := p[strings.ToLower(name)]
+	return provider, ok
+}
+
+// client is the code exchange shared by every provider
+type client struct {
+	cfg      ClientConfig
+	tokenURL string
+	http     *http.Client
+}
+
+func newClient(cfg ClientConfig, tokenURL string) client {
+	return client{cfg: cfg, tokenURL: tokenURL, http: &http.Client{Timeout: cfg.Timeout}}
+}
+
+// exchange trades an authorization code for an access token
+func (c client) exchange(ctx context.Context, code, redirectURI string) (string, error) {
+	if redirectURI == "" {
+		redirectURI = c.cfg.RedirectURI
+	}
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {redirectURI},
+		"client_id":     {c.cfg.ClientID},
+		"client_secret": {c.cfg.ClientSecret},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to exchange authorization code: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Some providers answer a bad code with 200 and an error field
+	var token struct {
+		AccessToken string `json:"access_token"`
+		Error       string `json:"error"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 64*1024)).Decode(&token); err != nil && resp.StatusCode < 300 {
+		return "", fmt.Errorf("failed to decode token response: %w", err)
+	}
+	if resp.StatusCode >= 500 {
+		return "", fmt.Errorf("token endpoint answered %s", resp.Status)
+	}
+	if resp.StatusCode >= 400 || token.Error != "" || token.AccessToken == "" {
+		return "", ErrInvalidCode
+	}
+	return token.AccessToken, nil
+}
+
+// get fetches a provider API resource with an access token into v
+func (c client) get(ctx context.Context, endpoint, accessToken string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to fetch %s: %w", endpoint, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		io.Copy(io.Discard, resp.Body)
+		return fmt.Errorf("%s answered %s", endpoint, resp.Status)
+	}
+	return json.NewDecoder(io.LimitReader(resp.Body, 1024*1024)).Decode(v)
+}
@@ -1,8 +1,10 @@
 package jwt
 
 import (
+	"crypto/rsa"
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -12,29 +14,100 @@ import (
 type Claims struct {
 	UserID string   `json:"user_id"`
 	Roles  []string `json:"roles"`
+	// SessionID is the sign-in both tokens of a pair belong to. Tokens issued
+	// before sessions were tracked have none.
+	SessionID string `json:"sid,omitempty"`
 	jwt.RegisteredClaims
 }
 
-// Manager handles JWT creation and verification.
+// Manager handles JWT creation and verification. Tokens are signed with
+// RS256 when the manager has a private key and with HS256 otherwise. HS256
+// tokens are only accepted by managers holding the secret, so a service
+// verifying RS256 tokens alone has nothing worth stealing.
 type Manager struct {
-	secretKey string
+	// secretKey signs or verifies HS256 tokens; nil refuses them
+	secretKey []byte
+	// publicKeys verify RS256 tokens signed elsewhere
+	publicKeys KeySource
+
+	// mu guards the keys of a manager signing RS256 tokens, which are
+	// replaced when they are rotated
+	mu sync.RWMutex
+	// signingKey signs RS256 tokens
+	signingKey *SigningKey
+	// keys verify RS256 tokens: the signing key, and the keys tokens signed
+	// before and during a rotation may carry
+	keys staticKeys
 }
 
-// NewManager creates a new JWT manager instance.
+// SigningKey is an RSA key tokens are signed with. Its ID, the kid header of
+// the tokens, is the thumbprint of the key so that every holder of the key
+// derives the same one.
+type SigningKey struct {
+	ID  string
+	Key *rsa.PrivateKey
+}
+
+func NewSigningKey(key *rsa.PrivateKey) SigningKey {
+	return SigningKey{ID: Thumbprint(&key.PublicKey), Key: key}
+}
+
+// NewManager creates a manager signing and verifying HS256 tokens with a
+// shared secret.
 func NewManager(secretKey string) *Manager {
 	return &Manager{
-		secretKey: secretKey,
+		secretKey: []byte(secretKey),
+	}
+}
+
+// NewRSAManager creates a manager signing RS256 tokens with key and accepting
+// those signed with it or with any of others. HS256 tokens issued before the
+// switch are verified with legacySecret, or refused if it is empty.
+func NewRSAManager(key SigningKey, others []*rsa.PublicKey, legacySecret string) *Manager {
+	m := &Manager{}
+	m.SetKeys(key, others)
+	if legacySecret != "" {
+		m.secretKey = []byte(legacySecret)
+	}
+	return m
+}
+
+// NewVerifyingManager creates a manager that only verifies tokens: RS256
+// ones with the public keys of keys, and HS256 ones with secret unless it is
+// empty.
+func NewVerifyingManager(keys KeySource, secret string) *Manager {
+	m := &Manager{publicKeys: keys}
+	if secret != "" {
+		m.secretKey = []byte(secret)
+	}
+	return m
+}
+
+// SetKeys makes key the signing key of an RS256 manager. Tokens signed with
+// it or with any of others are accepted, so that tokens signed with previous
+// keys keep working until they expire.
+func (m *Manager) SetKeys(key SigningKey, others []*rsa.PublicKey) {
+	keys := staticKeys{key.ID: &key.Key.PublicKey}
+	for _, other := range others {
+		keys[Thumbprint(other)] = other
 	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.signingKey = &key
+	m.keys = keys
 }
 
-// Generate creates a signed JWT access token containing user ID and roles.
-func (m *Manager) Generate(userID string, roles []string, expiry time.Duration) (string, error) {
+// Generate creates a signed JWT access token containing user ID, session ID
+// and roles.
+func (m *Manager) Generate(userID, sessionID string, roles []string, expiry time.Duration) (string, error) {
 	now := time.Now()
 	expiration := now.Add(expiry)
 
 	claims := Claims{
-		UserID: userID,
-		Roles:  roles,
+		UserID:    userID,
+		Roles:     roles,
+		SessionID: sessionID,
 		RegisteredClaims: jwt.RegisteredClaims{
 			Issuer:    "auth-service",
 			Subject:   userID,
@@ -43,8 +116,7 @@ func (m *Manager) Generate(userID string, roles []string, expiry time.Duration)
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	signedToken, err := token.SignedString([]byte(m.secretKey))
+	signedToken, err := m.sign(claims)
 	if err != nil {
 		return "", fmt.Errorf("failed to sign token: %w", err)
 	}
@@ -53,12 +125,13 @@ func (m *Manager) Generate(userID string, roles []string, expiry time.Duration)
 }
 
 // GenerateRefreshToken creates a refresh token that does not contain roles.
-func (m *Manager) GenerateRefreshToken(userID string, expiry time.Duration) (string, error) {
+func (m *Manager) GenerateRefreshToken(userID, sessionID string, expiry time.Duration) (string, error) {
 	now := time.Now()
 	expiration := now.Add(expiry)
 
 	claims := Claims{
-		UserID: userID,
+		UserID:    userID,
+		SessionID: sessionID,
 		RegisteredClaims: jwt.RegisteredClaims{
 			Issuer:    "auth-service",
 			Subject:   userID,
@@ -67,8 +140,7 @@ func (m *Manager) GenerateRefreshToken(userID string, expiry time.Duration) (str
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	signedToken, err := token.SignedString([]byte(m.secretKey))
+	signedToken, err := m.sign(claims)
 	if err != nil {
 		return "", fmt.Errorf("failed to sign refresh token: %w", err)
 	}
@@ -78,12 +150,7 @@ func (m *Manager) GenerateRefreshToken(userID string, expiry time.Duration) (str
 
 // Verify parses and validates a JWT token and returns the Claims if valid.
 func (m *Manager) Verify(tokenString string) (*Claims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(t *jwt.Token) (interface{}, error) {
-		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
-		}
-		return []byte(m.secretKey), nil
-	})
+	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, m.keyFunc)
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse token: %w", err)
@@ -100,3 +167,47 @@ func (m *Manager) Verify(tokenString string) (*Claims, error) {
 
 	return claims, nil
 }
+
+// sign signs claims with RS256 if the manager has a private key and with
+// HS256 otherwise
+func (m *Manager) sign(claims Claims) (string, error) {
+	m.mu.RLock()
+	signingKey := m.signingKey
+	m.mu.RUnlock()
+
+	if signingKey != nil {
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+		token.Header["kid"] = signingKey.ID
+		return token.SignedString(signingKey.Key)
+	}
+	if m.secretKey == nil {
+		return "", errors.New("manager has no signing key")
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(m.secretKey)
+}
+
+// keyFunc returns the key a token is verified with, by its algorithm and key
+// ID
+func (m *Manager) keyFunc(t *jwt.Token) (interface{}, error) {
+	switch t.Method.(type) {
+	case *jwt.SigningMethodHMAC:
+		if m.secretKey == nil {
+			return nil, fmt.Errorf("%v tokens are not accepted", t.Header["alg"])
+		}
+		return m.secretKey, nil
+	case *jwt.SigningMethodRSA:
+		keyID, _ := t.Header["kid"].(string)
+		if m.publicKeys != nil {
+			return m.publicKeys.PublicKey(keyID)
+		}
+
+		m.mu.RLock()
+		defer m.mu.RUnlock()
+		if m.keys == nil {
+			return nil, fmt.Errorf("%v tokens are not accepted", t.Header["alg"])
+		}
+		return m.keys.PublicKey(keyID)
+	default:
+		return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
+	}
+}
@@ -0,0 +1,122 @@
+package jwt
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestVerifyKeyIDs(t *testing.T) {
+	previous := newSigningKey(t)
+	current := newSigningKey(t)
+	unrelated := newSigningKey(t)
+
+	// The manager signs with current and still accepts tokens signed with
+	// previous before the rotation
+	m := NewRSAManager(current, []*rsa.PublicKey{&previous.Key.PublicKey}, "")
+
+	tests := []struct {
+		name  string
+		token string
+		valid bool
+	}{
+		{name: "current key", token: signToken(t, current, current.ID), valid: true},
+		{name: "rotated key", token: signToken(t, previous, previous.ID), valid: true},
+		{name: "unknown key", token: signToken(t, unrelated, unrelated.ID)},
+		{name: "no kid", token: signToken(t, current, "")},
+		{name: "kid of another key", token: signToken(t, unrelated, current.ID)},
+		{name: "hs256 without legacy secret", token: hs256Token(t, "secret")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := m.Verify(tt.token)
+			if tt.valid && err != nil {
+				t.Errorf("Verify() error = %v; want nil", err)
+			}
+			if !tt.valid && err == nil {
+				t.Error("Verify() error = nil; want an error")
+			}
+		})
+	}
+}
+
+func TestSetKeysDropsRetiredKey(t *testing.T) {
+	previous := newSigningKey(t)
+	current := newSigningKey(t)
+
+	m := NewRSAManager(previous, nil, "")
+	token, err := m.Generate("user", "session", nil, time.Hour)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	m.SetKeys(current, []*rsa.PublicKey{&previous.Key.PublicKey})
+	if _, err := m.Verify(token); err != nil {
+		t.Errorf("Verify() after rotation error = %v; want nil", err)
+	}
+
+	m.SetKeys(current, nil)
+	if _, err := m.Verify(token); err == nil {
+		t.Error("Verify() after retiring the key error = nil; want an error")
+	}
+}
+
+func TestVerifyingManagerUnknownKey(t *testing.T) {
+	key := newSigningKey(t)
+	m := NewVerifyingManager(staticKeys{key.ID: &key.Key.PublicKey}, "")
+
+	if _, err := m.Verify(signToken(t, key, key.ID)); err != nil {
+		t.Errorf("Verify() error = %v; want nil", err)
+	}
+	other := newSigningKey(t)
+	if _, err := m.Verify(signToken(t, other, other.ID)); err == nil {
+		t.Error("Verify() with an unknown kid error = nil; want an error")
+	}
+}
+
+func newSigningKey(t *testing.T) SigningKey {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	return NewSigningKey(key)
+}
+
+// signToken signs a valid access token with key, naming keyID in its kid
+// header
+func signToken(t *testing.T, key SigningKey, keyID string) string {
+	t.Helper()
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, testClaims())
+	if keyID != "" {
+		token.Header["kid"] = keyID
+	}
+	signed, err := token.SignedString(key.Key)
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+	return signed
+}
+
+func hs256Token(t *testing.T, secret string) string {
+	t.Helper()
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, testClaims()).SignedString([]byte(secret))
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+	return signed
+}
+
+func testClaims() Claims {
+	return Claims{
+		UserID: "user",
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   "user",
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+	}
+}
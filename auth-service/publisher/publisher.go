@@ -0,0 +1,60 @@
+package publisher
+
+import (
+	"context"
+	"encoding/json"
+
+	"auth-service/events"
+	natsClient "auth-service/nats"
+	"shared/logging"
+)
+
+type EventPublisher struct {
+	nats *natsClient.Client
+}
+
+func NewEventPublisher(nats *natsClient.Client) *EventPublisher {
+	return &EventPublisher{nats: nats}
+}
+
+func (p *EventPublisher) PublishUserDeleted(ctx context.Context, event events.UserDeletedEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	if err := p.nats.Publish(ctx, events.UserDeleted, data); err != nil {
+		return err
+	}
+
+	logging.FromContext(ctx).Info().Str("subject", events.UserDeleted).Stringer("user_id", event.UserID).Msg("published event")
+	return nil
+}
+
+func (p *EventPublisher) PublishRefreshTokenReused(ctx context.Context, event events.RefreshTokenReusedEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	if err := p.nats.Publish(ctx, events.RefreshTokenReused, data); err != nil {
+		return err
+	}
+
+	logging.FromContext(ctx).Info().Str("subject", events.RefreshTokenReused).Stringer("user_id", event.UserID).Msg("published event")
+	return nil
+}
+
+func (p *EventPublisher) PublishAuditRecorded(ctx context.Context, event events.AuditRecordedEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	if err := p.nats.Publish(ctx, events.AuditRecorded, data); err != nil {
+		return err
+	}
+
+	logging.FromContext(ctx).Info().Str("subject", events.AuditRecorded).Str("action", event.Action).Msg("published event")
+	return nil
+}
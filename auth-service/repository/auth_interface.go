@@ -9,6 +9,10 @@ import (
 )
 
 type AuthRepository interface {
+	// WithTx runs fn in a single transaction; repository calls made with the
+	// context passed to fn join it
+	WithTx(ctx context.Context, fn func(ctx context.Context) error) error
+
 	// User operations
 	CreateUser(ctx context.Context, user *models.User) error
 	GetUserByID(ctx context.Context, id uuid.UUID) (*models.User, error)
@@ -16,21 +20,48 @@ type AuthRepository interface {
 	GetUserByUsername(ctx context.Context, username string) (*models.User, error)
 	UpdateUserPassword(ctx context.Context, userID uuid.UUID, passwordHash string) error
 	UpdateUser(ctx context.Context, user *models.User) error
+	DeleteUser(ctx context.Context, userID uuid.UUID) error
+
+	// Username operations
+	RenameUser(ctx context.Context, userID uuid.UUID, oldUsername, newUsername string, reservedUntil *time.Time) (bool, error)
+	IsUsernameReserved(ctx context.Context, username string) (bool, error)
+
+	// OAuth identity operations
+	CreateOAuthIdentity(ctx context.Context, identity *models.OAuthIdentity) error
+	GetUserByOAuthIdentity(ctx context.Context, provider, subject string) (*models.User, error)
 
 	// Refresh token operations
 	CreateRefreshToken(ctx context.Context, token *models.RefreshToken) error
 	GetRefreshToken(ctx context.Context, token string) (*models.RefreshToken, error)
-	RevokeRefreshToken(ctx context.Context, token string) error
+	RotateRefreshToken(ctx context.Context, token string) (bool, error)
+	GetRotatedRefreshToken(ctx context.Context, token string) (*models.RefreshToken, error)
 	RevokeAllUserRefreshTokens(ctx context.Context, userID uuid.UUID) error
-	DeleteExpiredRefreshTokens(ctx context.Context) error
+	DeleteExpiredRefreshTokens(ctx context.Context) (int64, error)
+
+	// Session operations
+	ListSessions(ctx context.Context, userID uuid.UUID) ([]models.RefreshToken, error)
+	RevokeSession(ctx context.Context, userID, sessionID uuid.UUID) (bool, error)
+	TouchSession(ctx context.Context, sessionID uuid.UUID, seenAt time.Time) (bool, error)
 
 	// Token blacklist operations
 	AddTokenToBlacklist(ctx context.Context, token string, expiresAt time.Time) error
 	IsTokenBlacklisted(ctx context.Context, token string) (bool, error)
-	DeleteExpiredBlacklistedTokens(ctx context.Context) error
+	DeleteExpiredBlacklistedTokens(ctx context.Context) (int64, error)
 
 	// User role operations
 	CreateUserRole(ctx context.Context, userRole *models.UserRole) error
 	GetUserRoles(ctx context.Context, userID uuid.UUID) ([]models.Role, error)
 	HasRole(ctx context.Context, userID uuid.UUID, role models.Role) (bool, error)
+	SetUserRoles(ctx context.Context, userID uuid.UUID, roles []models.Role) error
+
+	// Signing key operations
+	ListSigningKeys(ctx context.Context) ([]models.SigningKey, error)
+	CreateSigningKey(ctx context.Context, key *models.SigningKey) error
+	CreateFirstSigningKey(ctx context.Context, key *models.SigningKey) (bool, error)
+	DeleteRetiredSigningKeys(ctx context.Context, retiredBefore time.Time) (int64, error)
+
+	// Suspension operations
+	SuspendUser(ctx context.Context, userID uuid.UUID, reason string, suspendedBy uuid.UUID, until *time.Time) error
+	UnsuspendUser(ctx context.Context, userID uuid.UUID) error
+	IsUserSuspended(ctx context.Context, userID uuid.UUID) (bool, error)
 }
@@ -6,19 +6,24 @@ import (
 	"fmt"
 	"time"
 
+	"auth-service/db"
 	"auth-service/model"
 	"github.com/google/uuid"
-	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
 type authRepository struct {
-	db *sqlx.DB
+	db *database.DB
 }
 
-func NewAuthRepository(db *sqlx.DB) AuthRepository {
+func NewAuthRepository(db *database.DB) AuthRepository {
 	return &authRepository{db: db}
 }
 
+func (r *authRepository) WithTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return r.db.WithTx(ctx, fn)
+}
+
 func (r *authRepository) CreateUser(ctx context.Context, user *models.User) error {
 	query := `
 		INSERT INTO auth_users (id, username, email, password_hash, bio, created_at, updated_at, 
@@ -27,7 +32,7 @@ func (r *authRepository) CreateUser(ctx context.Context, user *models.User) erro
 		RETURNING id, created_at, updated_at
 	`
 
-	err := r.db.QueryRowContext(
+	err := r.db.Conn(ctx).QueryRowContext(
 		ctx, query,
 		user.ID, user.Username, user.Email, user.PasswordHash, user.Bio,
 		user.CreatedAt, user.UpdatedAt, user.FollowersCount, user.FollowingCount, user.PostsCount,
@@ -48,7 +53,7 @@ func (r *authRepository) GetUserByID(ctx context.Context, id uuid.UUID) (*models
 		WHERE id = $1
 	`
 
-	err := r.db.GetContext(ctx, &user, query, id)
+	err := r.db.Conn(ctx).GetContext(ctx, &user, query, id)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, fmt.Errorf("user not found")
@@ -67,7 +72,7 @@ func (r *authRepository) GetUserByEmail(ctx context.Context, email string) (*mod
 		WHERE email = $1
 	`
 
-	err := r.db.GetContext(ctx, &user, query, email)
+	err := r.db.Conn(ctx).GetContext(ctx, &user, query, email)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, fmt.Errorf("user not found")
@@ -86,7 +91,7 @@ func (r *authRepository) GetUserByUsername(ctx context.Context, username string)
 		WHERE username = $1
 	`
 
-	err := r.db.GetContext(ctx, &user, query, username)
+	err := r.db.Conn(ctx).GetContext(ctx, &user, query, username)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, fmt.Errorf("user not found")
@@ -103,7 +108,7 @@ func (r *authRepository) UpdateUserPassword(ctx context.Context, userID uuid.UUI
 		WHERE id = $3
 	`
 
-	result, err := r.db.ExecContext(ctx, query, passwordHash, time.Now(), userID)
+	result, err := r.db.Conn(ctx).ExecContext(ctx, query, passwordHash, time.Now(), userID)
 	if err != nil {
 		return fmt.Errorf("failed to update password: %w", err)
 	}
@@ -129,7 +134,7 @@ func (r *authRepository) UpdateUser(ctx context.Context, user *models.User) erro
 	`
 
 	user.UpdatedAt = time.Now()
-	result, err := r.db.ExecContext(
+	result, err := r.db.Conn(ctx).ExecContext(
 		ctx, query,
 		user.Username, user.Email, user.Bio, user.UpdatedAt,
 		user.FollowersCount, user.FollowingCount, user.PostsCount, user.ID,
@@ -153,15 +158,37 @@ func (r *authRepository) UpdateUser(ctx context.Context, user *models.User) erro
 
 // Refresh token operations
 
+// DeleteUser deletes a user; their refresh tokens, roles and suspensions go
+// with them
+func (r *authRepository) DeleteUser(ctx context.Context, userID uuid.UUID) error {
+	result, err := r.db.Conn(ctx).ExecContext(ctx, `DELETE FROM auth_users WHERE id = $1`, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete user: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("user not found")
+	}
+
+	return nil
+}
+
 func (r *authRepository) CreateRefreshToken(ctx context.Context, token *models.RefreshToken) error {
 	query := `
-		INSERT INTO auth_refresh_tokens (id, user_id, token, expires_at, created_at, is_revoked)
-		VALUES ($1, $2, $3, $4, $5, $6)
+		INSERT INTO auth_refresh_tokens (id, user_id, token, expires_at, created_at, is_revoked,
+			session_id, signed_in_at, device_name, ip_address, user_agent, last_seen_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
 	`
 
-	_, err := r.db.ExecContext(
+	_, err := r.db.Conn(ctx).ExecContext(
 		ctx, query,
 		token.ID, token.UserID, token.Token, token.ExpiresAt, token.CreatedAt, token.IsRevoked,
+		token.SessionID, token.SignedInAt, token.DeviceName, token.IPAddress, token.UserAgent, token.LastSeenAt,
 	)
 
 	if err != nil {
@@ -173,12 +200,13 @@ func (r *authRepository) CreateRefreshToken(ctx context.Context, token *models.R
 func (r *authRepository) GetRefreshToken(ctx context.Context, token string) (*models.RefreshToken, error) {
 	var refreshToken models.RefreshToken
 	query := `
-		SELECT id, user_id, token, expires_at, created_at, is_revoked
+		SELECT id, user_id, token, expires_at, created_at, is_revoked,
+		       session_id, signed_in_at, device_name, ip_address, user_agent, last_seen_at, rotated_at
 		FROM auth_refresh_tokens
 		WHERE token = $1 AND is_revoked = false AND expires_at > NOW()
 	`
 
-	err := r.db.GetContext(ctx, &refreshToken, query, token)
+	err := r.db.Conn(ctx).GetContext(ctx, &refreshToken, query, token)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, fmt.Errorf("refresh token not found or expired")
@@ -188,30 +216,6 @@ func (r *authRepository) GetRefreshToken(ctx context.Context, token string) (*mo
 	return &refreshToken, nil
 }
 
-func (r *authRepository) RevokeRefreshToken(ctx context.Context, token string) error {
-	query := `
-		UPDATE auth_refresh_tokens
-		SET is_revoked = true
-		WHERE token = $1
-	`
-
-	result, err := r.db.ExecContext(ctx, query, token)
-	if err != nil {
-		return fmt.Errorf("failed to revoke refresh token: %w", err)
-	}
-
-	rows, err := result.RowsAffected()
-	if err != nil {
-		return fmt.Errorf("failed to get rows affected: %w", err)
-	}
-
-	if rows == 0 {
-		return fmt.Errorf("refresh token not found")
-	}
-
-	return nil
-}
-
 func (r *authRepository) RevokeAllUserRefreshTokens(ctx context.Context, userID uuid.UUID) error {
 	query := `
 		UPDATE auth_refresh_tokens
@@ -219,24 +223,24 @@ func (r *authRepository) RevokeAllUserRefreshTokens(ctx context.Context, userID
 		WHERE user_id = $1 AND is_revoked = false
 	`
 
-	_, err := r.db.ExecContext(ctx, query, userID)
+	_, err := r.db.Conn(ctx).ExecContext(ctx, query, userID)
 	if err != nil {
 		return fmt.Errorf("failed to revoke user refresh tokens: %w", err)
 	}
 	return nil
 }
 
-func (r *authRepository) DeleteExpiredRefreshTokens(ctx context.Context) error {
+func (r *authRepository) DeleteExpiredRefreshTokens(ctx context.Context) (int64, error) {
 	query := `
 		DELETE FROM auth_refresh_tokens
-		WHERE expires_at < NOW() OR is_revoked = true
+		WHERE expires_at < NOW() OR (is_revoked = true AND rotated_at IS NULL)
 	`
 
-	_, err := r.db.ExecContext(ctx, query)
+	result, err := r.db.Conn(ctx).ExecContext(ctx, query)
 	if err != nil {
-		return fmt.Errorf("failed to delete expired refresh tokens: %w", err)
+		return 0, fmt.Errorf("failed to delete expired refresh tokens: %w", err)
 	}
-	return nil
+	return result.RowsAffected()
 }
 
 // Token blacklist operations
@@ -247,7 +251,7 @@ func (r *authRepository) AddTokenToBlacklist(ctx context.Context, token string,
 		VALUES ($1, $2, $3, $4)
 	`
 
-	_, err := r.db.ExecContext(ctx, query, uuid.New(), token, expiresAt, time.Now())
+	_, err := r.db.Conn(ctx).ExecContext(ctx, query, uuid.New(), token, expiresAt, time.Now())
 	if err != nil {
 		return fmt.Errorf("failed to add token to blacklist: %w", err)
 	}
@@ -263,24 +267,24 @@ func (r *authRepository) IsTokenBlacklisted(ctx context.Context, token string) (
 		)
 	`
 
-	err := r.db.GetContext(ctx, &exists, query, token)
+	err := r.db.Conn(ctx).GetContext(ctx, &exists, query, token)
 	if err != nil {
 		return false, fmt.Errorf("failed to check token blacklist: %w", err)
 	}
 	return exists, nil
 }
 
-func (r *authRepository) DeleteExpiredBlacklistedTokens(ctx context.Context) error {
+func (r *authRepository) DeleteExpiredBlacklistedTokens(ctx context.Context) (int64, error) {
 	query := `
 		DELETE FROM auth_token_blacklist
 		WHERE expires_at < NOW()
 	`
 
-	_, err := r.db.ExecContext(ctx, query)
+	result, err := r.db.Conn(ctx).ExecContext(ctx, query)
 	if err != nil {
-		return fmt.Errorf("failed to delete expired blacklisted tokens: %w", err)
+		return 0, fmt.Errorf("failed to delete expired blacklisted tokens: %w", err)
 	}
-	return nil
+	return result.RowsAffected()
 }
 
 // User role operations
@@ -291,7 +295,7 @@ func (r *authRepository) CreateUserRole(ctx context.Context, userRole *models.Us
 		VALUES ($1, $2, $3, $4)
 	`
 
-	_, err := r.db.ExecContext(ctx, query, userRole.ID, userRole.UserID, userRole.Role, userRole.CreatedAt)
+	_, err := r.db.Conn(ctx).ExecContext(ctx, query, userRole.ID, userRole.UserID, userRole.Role, userRole.CreatedAt)
 	if err != nil {
 		return fmt.Errorf("failed to create user role: %w", err)
 	}
@@ -306,7 +310,7 @@ func (r *authRepository) GetUserRoles(ctx context.Context, userID uuid.UUID) ([]
 		WHERE user_id = $1
 	`
 
-	err := r.db.SelectContext(ctx, &roles, query, userID)
+	err := r.db.Conn(ctx).SelectContext(ctx, &roles, query, userID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user roles: %w", err)
 	}
@@ -322,9 +326,74 @@ func (r *authRepository) HasRole(ctx context.Context, userID uuid.UUID, role mod
 		)
 	`
 
-	err := r.db.GetContext(ctx, &exists, query, userID, role)
+	err := r.db.Conn(ctx).GetContext(ctx, &exists, query, userID, role)
 	if err != nil {
 		return false, fmt.Errorf("failed to check user role: %w", err)
 	}
 	return exists, nil
 }
+
+// SetUserRoles replaces the roles of a user. Roles the user keeps keep
+// their creation time.
+func (r *authRepository) SetUserRoles(ctx context.Context, userID uuid.UUID, roles []models.Role) error {
+	names := make([]string, len(roles))
+	for i, role := range roles {
+		names[i] = string(role)
+	}
+
+	deleteQuery := `DELETE FROM auth_user_roles WHERE user_id = $1 AND NOT (role::text = ANY($2))`
+	if _, err := r.db.Conn(ctx).ExecContext(ctx, deleteQuery, userID, pq.Array(names)); err != nil {
+		return fmt.Errorf("failed to remove user roles: %w", err)
+	}
+
+	insertQuery := `
+		INSERT INTO auth_user_roles (id, user_id, role, created_at)
+		VALUES ($1, $2, $3, NOW())
+		ON CONFLICT (user_id, role) DO NOTHING
+	`
+	for _, role := range roles {
+		if _, err := r.db.Conn(ctx).ExecContext(ctx, insertQuery, uuid.New(), userID, role); err != nil {
+			return fmt.Errorf("failed to add user role: %w", err)
+		}
+	}
+	return nil
+}
+
+// SuspendUser suspends a user until until, or indefinitely if it is nil,
+// replacing any earlier suspension
+func (r *authRepository) SuspendUser(ctx context.Context, userID uuid.UUID, reason string, suspendedBy uuid.UUID, until *time.Time) error {
+	query := `
+		INSERT INTO auth_user_suspensions (user_id, reason, suspended_by, suspended_at, expires_at)
+		VALUES ($1, $2, $3, NOW(), $4)
+		ON CONFLICT (user_id) DO UPDATE
+		SET reason = EXCLUDED.reason, suspended_by = EXCLUDED.suspended_by, suspended_at = EXCLUDED.suspended_at,
+		    expires_at = EXCLUDED.expires_at
+	`
+
+	_, err := r.db.Conn(ctx).ExecContext(ctx, query, userID, reason, suspendedBy, until)
+	if err != nil {
+		return fmt.Errorf("failed to suspend user: %w", err)
+	}
+	return nil
+}
+
+func (r *authRepository) UnsuspendUser(ctx context.Context, userID uuid.UUID) error {
+	query := `DELETE FROM auth_user_suspensions WHERE user_id = $1`
+
+	_, err := r.db.Conn(ctx).ExecContext(ctx, query, userID)
+	if err != nil {
+		return fmt.Errorf("failed to unsuspend user: %w", err)
+	}
+	return nil
+}
+
+func (r *authRepository) IsUserSuspended(ctx context.Context, userID uuid.UUID) (bool, error) {
+	var exists bool
+	query := `SELECT EXISTS(SELECT 1 FROM auth_user_suspensions WHERE user_id = $1 AND (expires_at IS NULL OR expires_at > NOW()))`
+
+	err := r.db.Conn(ctx).GetContext(ctx, &exists, query, userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to check user suspension: %w", err)
+	}
+	return exists, nil
+}
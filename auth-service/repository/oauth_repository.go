@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	models "auth-service/model"
+)
+
+// CreateOAuthIdentity links an identity provider account to a user
+func (r *authRepository) CreateOAuthIdentity(ctx context.Context, identity *models.OAuthIdentity) error {
+	query := `
+		INSERT INTO auth_oauth_identities (provider, subject, user_id, email, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+	_, err := r.db.Conn(ctx).ExecContext(ctx, query,
+		identity.Provider, identity.Subject, identity.UserID, identity.Email, identity.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create oauth identity: %w", err)
+	}
+	return nil
+}
+
+// GetUserByOAuthIdentity returns the user an identity provider account is
+// linked to, or nil when it is not linked to anyone
+func (r *authRepository) GetUserByOAuthIdentity(ctx context.Context, provider, subject string) (*models.User, error) {
+	var user models.User
+	query := `
+		SELECT u.id, u.username, u.email, u.password_hash, u.bio, u.created_at, u.updated_at,
+		       u.followers_count, u.following_count, u.posts_count
+		FROM auth_oauth_identities i
+		JOIN auth_users u ON u.id = i.user_id
+		WHERE i.provider = $1 AND i.subject = $2
+	`
+
+	err := r.db.Conn(ctx).GetContext(ctx, &user, query, provider, subject)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	return &user, nil
+}
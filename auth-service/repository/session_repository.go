@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	models "auth-service/model"
+
+	"github.com/google/uuid"
+)
+
+// ListSessions returns the current refresh token of each of a user's active
+// sessions, most recently used first
+func (r *authRepository) ListSessions(ctx context.Context, userID uuid.UUID) ([]models.RefreshToken, error) {
+	query := `
+		SELECT id, user_id, token, expires_at, created_at, is_revoked,
+		       session_id, signed_in_at, device_name, ip_address, user_agent, last_seen_at, rotated_at
+		FROM auth_refresh_tokens
+		WHERE user_id = $1 AND is_revoked = false AND expires_at > NOW()
+		ORDER BY last_seen_at DESC
+	`
+
+	var sessions []models.RefreshToken
+	if err := r.db.Conn(ctx).SelectContext(ctx, &sessions, query, userID); err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+	return sessions, nil
+}
+
+// RevokeSession revokes a session of a user, reporting whether it was active
+func (r *authRepository) RevokeSession(ctx context.Context, userID, sessionID uuid.UUID) (bool, error) {
+	query := `
+		UPDATE auth_refresh_tokens
+		SET is_revoked = true
+		WHERE user_id = $1 AND session_id = $2 AND is_revoked = false AND expires_at > NOW()
+	`
+
+	result, err := r.db.Conn(ctx).ExecContext(ctx, query, userID, sessionID)
+	if err != nil {
+		return false, fmt.Errorf("failed to revoke session: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	return rows > 0, nil
+}
+
+// TouchSession records that a session was used at seenAt and reports whether
+// it is still active. A revoked or expired session is left untouched.
+func (r *authRepository) TouchSession(ctx context.Context, sessionID uuid.UUID, seenAt time.Time) (bool, error) {
+	query := `
+		UPDATE auth_refresh_tokens
+		SET last_seen_at = GREATEST(last_seen_at, $2)
+		WHERE session_id = $1 AND is_revoked = false AND expires_at > NOW()
+	`
+
+	result, err := r.db.Conn(ctx).ExecContext(ctx, query, sessionID, seenAt)
+	if err != nil {
+		return false, fmt.Errorf("failed to touch session: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	return rows > 0, nil
+}
+
+// RotateRefreshToken revokes a refresh token being replaced by a refresh,
+// reporting whether it was still active. A token that was not has already
+// been used.
+func (r *authRepository) RotateRefreshToken(ctx context.Context, token string) (bool, error) {
+	query := `
+		UPDATE auth_refresh_tokens
+		SET is_revoked = true, rotated_at = NOW()
+		WHERE token = $1 AND is_revoked = false
+	`
+
+	result, err := r.db.Conn(ctx).ExecContext(ctx, query, token)
+	if err != nil {
+		return false, fmt.Errorf("failed to rotate refresh token: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	return rows > 0, nil
+}
+
+// GetRotatedRefreshToken returns a refresh token that a refresh replaced and
+// has not expired, or nil when the token is not one
+func (r *authRepository) GetRotatedRefreshToken(ctx context.Context, token string) (*models.RefreshToken, error) {
+	var refreshToken models.RefreshToken
+	query := `
+		SELECT id, user_id, token, expires_at, created_at, is_revoked,
+		       session_id, signed_in_at, device_name, ip_address, user_agent, last_seen_at, rotated_at
+		FROM auth_refresh_tokens
+		WHERE token = $1 AND rotated_at IS NOT NULL AND expires_at > NOW()
+	`
+
+	err := r.db.Conn(ctx).GetContext(ctx, &refreshToken, query, token)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get refresh token: %w", err)
+	}
+	return &refreshToken, nil
+}
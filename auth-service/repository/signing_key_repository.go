@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	models "auth-service/model"
+)
+
+// ListSigningKeys returns all signing keys, in the order they activate
+func (r *authRepository) ListSigningKeys(ctx context.Context) ([]models.SigningKey, error) {
+	query := `
+		SELECT id, private_key, created_at, activates_at
+		FROM auth_signing_keys
+		ORDER BY activates_at, id
+	`
+
+	var keys []models.SigningKey
+	if err := r.db.Conn(ctx).SelectContext(ctx, &keys, query); err != nil {
+		return nil, fmt.Errorf("failed to list signing keys: %w", err)
+	}
+	return keys, nil
+}
+
+func (r *authRepository) CreateSigningKey(ctx context.Context, key *models.SigningKey) error {
+	query := `
+		INSERT INTO auth_signing_keys (id, private_key, created_at, activates_at)
+		VALUES ($1, $2, $3, $4)
+	`
+
+	_, err := r.db.Conn(ctx).ExecContext(ctx, query, key.ID, key.PrivateKey, key.CreatedAt, key.ActivatesAt)
+	if err != nil {
+		return fmt.Errorf("failed to create signing key: %w", err)
+	}
+	return nil
+}
+
+// CreateFirstSigningKey creates key unless there is a signing key already,
+// reporting whether it did. The table is locked so that replicas starting
+// together agree on a single first key.
+func (r *authRepository) CreateFirstSigningKey(ctx context.Context, key *models.SigningKey) (bool, error) {
+	created := false
+	err := r.db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := r.db.Conn(ctx).ExecContext(ctx, `LOCK TABLE auth_signing_keys IN SHARE ROW EXCLUSIVE MODE`); err != nil {
+			return fmt.Errorf("failed to lock signing keys: %w", err)
+		}
+
+		var exists bool
+		if err := r.db.Conn(ctx).GetContext(ctx, &exists, `SELECT EXISTS (SELECT 1 FROM auth_signing_keys)`); err != nil {
+			return fmt.Errorf("failed to check signing keys: %w", err)
+		}
+		if exists {
+			return nil
+		}
+
+		created = true
+		return r.CreateSigningKey(ctx, key)
+	})
+	return created, err
+}
+
+// DeleteRetiredSigningKeys deletes the keys that a newer key replaced before
+// retiredBefore
+func (r *authRepository) DeleteRetiredSigningKeys(ctx context.Context, retiredBefore time.Time) (int64, error) {
+	query := `
+		DELETE FROM auth_signing_keys k
+		WHERE EXISTS (
+			SELECT 1 FROM auth_signing_keys n
+			WHERE n.activates_at > k.activates_at AND n.activates_at <= $1
+		)
+	`
+
+	result, err := r.db.Conn(ctx).ExecContext(ctx, query, retiredBefore)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete retired signing keys: %w", err)
+	}
+	return result.RowsAffected()
+}
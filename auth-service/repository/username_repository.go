@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RenameUser sets the username of a user to the one user-service changed it
+// to. Only a user still under oldUsername is renamed, so a change applied
+// before is not applied again. When reservedUntil is set, oldUsername is
+// kept from new accounts until then; the new username, if it was reserved
+// for the user, is freed.
+func (r *authRepository) RenameUser(ctx context.Context, userID uuid.UUID, oldUsername, newUsername string, reservedUntil *time.Time) (bool, error) {
+	renamed := false
+	err := r.WithTx(ctx, func(ctx context.Context) error {
+		result, err := r.db.Conn(ctx).ExecContext(ctx, `
+			UPDATE auth_users SET username = $3 WHERE id = $1 AND username = $2
+		`, userID, oldUsername, newUsername)
+		if err != nil {
+			return fmt.Errorf("failed to rename user: %w", err)
+		}
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get rows affected: %w", err)
+		}
+		if rows == 0 {
+			return nil
+		}
+		renamed = true
+
+		_, err = r.db.Conn(ctx).ExecContext(ctx, `
+			DELETE FROM auth_reserved_usernames WHERE username = $2 AND user_id = $1
+		`, userID, strings.ToLower(newUsername))
+		if err != nil {
+			return fmt.Errorf("failed to free reserved username: %w", err)
+		}
+
+		if reservedUntil == nil {
+			return nil
+		}
+		_, err = r.db.Conn(ctx).ExecContext(ctx, `
+			INSERT INTO auth_reserved_usernames (username, user_id, reserved_until)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (username) DO UPDATE
+			SET user_id = EXCLUDED.user_id, reserved_until = EXCLUDED.reserved_until
+		`, strings.ToLower(oldUsername), userID, *reservedUntil)
+		if err != nil {
+			return fmt.Errorf("failed to reserve username: %w", err)
+		}
+		return nil
+	})
+	return renamed, err
+}
+
+// IsUsernameReserved reports whether username, in any case, is reserved for
+// the user who gave it up
+func (r *authRepository) IsUsernameReserved(ctx context.Context, username string) (bool, error) {
+	var reserved bool
+	err := r.db.Conn(ctx).GetContext(ctx, &reserved, `
+		SELECT EXISTS (
+			SELECT 1 FROM auth_reserved_usernames WHERE username = $1 AND reserved_until > NOW()
+		)
+	`, strings.ToLower(username))
+	if err != nil {
+		return false, fmt.Errorf("failed to check reserved usernames: %w", err)
+	}
+	return reserved, nil
+}
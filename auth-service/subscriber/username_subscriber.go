@@ -0,0 +1,86 @@
+// Package subscriber consumes the events of other services that auth-service
+// acts on
+package subscriber
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/nats-io/nats.go"
+
+	"auth-service/events"
+	natsClient "auth-service/nats"
+	"auth-service/repository"
+	"shared/logging"
+	"shared/tracing"
+)
+
+// subscribeRetry is how long to wait before subscribing again when the
+// stream does not exist yet
+const subscribeRetry = 5 * time.Second
+
+// UsernameSubscriber keeps the usernames of accounts in step with the
+// profiles of user-service, where usernames are changed, and reserves the
+// usernames given up from new accounts
+type UsernameSubscriber struct {
+	natsClient *natsClient.Client
+	repo       repository.AuthRepository
+	ctx        context.Context
+}
+
+func NewUsernameSubscriber(natsClient *natsClient.Client, repo repository.AuthRepository, ctx context.Context) *UsernameSubscriber {
+	return &UsernameSubscriber{
+		natsClient: natsClient,
+		repo:       repo,
+		ctx:        ctx,
+	}
+}
+
+// Start subscribes in the background, retrying until the stream, created by
+// notification-service, exists
+func (s *UsernameSubscriber) Start() {
+	go func() {
+		for {
+			_, err := s.natsClient.SubscribeDurable(events.UsernameChanged, "auth-service-username-changes", "auth-workers", s.handle)
+			if err == nil {
+				log.Println("Username subscriber started successfully")
+				return
+			}
+
+			log.Printf("Failed to start username subscriber, retrying: %v", err)
+			select {
+			case <-time.After(subscribeRetry):
+			case <-s.ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+func (s *UsernameSubscriber) handle(msg *nats.Msg) {
+	ctx, span := tracing.StartProcess(s.ctx, msg.Subject, msg.Header)
+	ctx = logging.Extract(ctx, msg.Subject, msg.Header)
+	defer span.End()
+
+	var event events.UsernameChangedEvent
+	if err := natsClient.DecodeEvent(msg, &event); err != nil {
+		logging.FromContext(ctx).Error().Err(err).Msg("failed to decode username changed event")
+		msg.Nak()
+		return
+	}
+
+	renamed, err := s.repo.RenameUser(ctx, event.UserID, event.OldUsername, event.NewUsername, event.ReservedUntil)
+	if err != nil {
+		logging.FromContext(ctx).Error().Err(err).Stringer("user_id", event.UserID).Msg("failed to apply username change")
+		msg.Nak()
+		return
+	}
+
+	if renamed {
+		logging.FromContext(ctx).Info().Stringer("user_id", event.UserID).Str("username", event.NewUsername).Msg("applied username change")
+	} else {
+		logging.FromContext(ctx).Debug().Stringer("user_id", event.UserID).Msg("skipped username change already applied or of an unknown account")
+	}
+	msg.Ack()
+}
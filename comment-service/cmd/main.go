@@ -2,12 +2,11 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"log"
 	"net"
 	"os"
-	"os/signal"
-	"syscall"
 	"time"
 
 	"github.com/joho/godotenv"
@@ -15,20 +14,44 @@ import (
 	"google.golang.org/grpc/reflection"
 
 	"comment-service/config"
+	"comment-service/contentfilter"
 	"comment-service/db"
 	"comment-service/handler"
 	"comment-service/interceptor"
+	"comment-service/migrations"
 	natsClient "comment-service/nats"
+	"comment-service/outbox"
 	pb "comment-service/pb"
 	"comment-service/publisher"
 	"comment-service/repository"
+	"comment-service/rpcerror"
+	"comment-service/subscriber"
+	postpb "post-service/pb"
+	"shared/chaos"
+	"shared/cursor"
+	"shared/idempotency"
+	"shared/lifecycle"
+	"shared/logging"
+	"shared/migrate"
+	"shared/mtls"
+	"shared/tracing"
+	userpb "user-service/pb"
 )
 
 func main() {
+	logging.Init("comment-service")
+
+	migrateFlag := flag.Bool("migrate", false, "apply pending schema migrations before serving")
+	flag.Parse()
 
 	if err := godotenv.Load(); err != nil {
 		log.Println("failed to load Comment .env file")
 	}
+
+	// The supervisor health-checks the dependencies below and closes them
+	// in order on shutdown
+	supervisor := lifecycle.New(pb.CommentService_ServiceDesc.ServiceName)
+
 	// Load database configuration
 	dbCfg, err := config.LoadDatabaseConfig("")
 	if err != nil {
@@ -46,17 +69,55 @@ func main() {
 		MaxOpenConns: dbCfg.MaxOpenConns,
 		MaxIdleConns: dbCfg.MaxIdleConns,
 		MaxLifetime:  dbCfg.MaxLifetime,
+		ReplicaDSNs:  dbCfg.ReplicaDSNs,
 	})
 	if err != nil {
 		log.Fatalf("Failed to connect to Comment database: %v", err)
 	}
-	defer dbConn.Close()
+	supervisor.Add(lifecycle.Dependency{Name: "database", Check: dbConn.HealthCheck, Close: dbConn.Close})
+
+	// Apply pending schema migrations with -migrate or DB_MIGRATE=true;
+	// without either they are only reported
+	if err := migrate.OnStartup(context.Background(), dbConn.DB, "comment-service", migrations.Files, *migrateFlag || os.Getenv("DB_MIGRATE") == "true"); err != nil {
+		log.Fatalf("Failed to migrate Comment database: %v", err)
+	}
 
 	log.Println("Successfully connected to Comment database")
 
 	// Load other service-level configs
 	grpcPort := getEnv("GRPC_PORT", "50056")
 	jwtSecret := getEnv("JWT_SECRET", "your-secret-key")
+	// Tokens are verified with JWT_SECRET, or with auth-service's public keys
+	// when JWT_ALGORITHM is RS256
+	tokenKeys, err := interceptor.KeysFromEnv(jwtSecret)
+	if err != nil {
+		log.Fatalf("Invalid JWT configuration: %v", err)
+	}
+
+	// Fault injection for resilience testing; inert unless CHAOS_ENABLED=true
+	chaosInjector, err := chaos.FromEnv()
+	if err != nil {
+		log.Fatalf("Invalid chaos configuration: %v", err)
+	}
+
+	// Screen comment content before it is stored; off unless a
+	// CONTENT_FILTER_* variable is set
+	contentFilter, err := contentfilter.FromEnv()
+	if err != nil {
+		log.Fatalf("Invalid content filter configuration: %v", err)
+	}
+
+	// Export traces when OTEL_EXPORTER_OTLP_ENDPOINT is set
+	shutdownTracing, err := tracing.Init(context.Background(), "comment-service")
+	if err != nil {
+		log.Fatalf("Failed to initialize tracing: %v", err)
+	}
+	supervisor.OnShutdown("tracing", shutdownTracing)
+
+	// Pagination cursors are signed so clients cannot forge positions
+	if err := cursor.SetSecret(os.Getenv("CURSOR_SECRET")); err != nil {
+		log.Fatalf("Invalid CURSOR_SECRET: %v", err)
+	}
 	natsURL := getEnv("NATS_URL", "nats://nats:4222")
 	natsClientID := getEnv("NATS_CLIENT_ID", "comment-service")
 
@@ -66,57 +127,105 @@ func main() {
 		MaxReconnects: 10,
 		ReconnectWait: 2 * time.Second,
 		ClientID:      natsClientID,
+		Chaos:         chaosInjector,
 	}
 
 	nats, err := natsClient.NewClient(natsCfg)
 	if err != nil {
 		log.Fatalf("Failed to initialize NATS client: %v", err)
 	}
-	defer nats.Close()
+	supervisor.Add(lifecycle.Dependency{Name: "nats", Check: nats.HealthCheck, Drain: nats.Drain})
 	log.Println("NATS client initialized successfully")
 
-	// Initialize event publisher
-	eventPublisher := publisher.NewEventPublisher(nats)
+	// Events are stored in the outbox with the changes they describe and
+	// relayed to JetStream once committed
+	eventPublisher := publisher.NewEventPublisher(outbox.New(dbConn))
+	relayCtx, stopRelay := context.WithCancel(supervisor.Context())
+	defer stopRelay()
+	// Events that keep failing to publish are set aside in the dead-letter
+	// stream, so they stop holding back the rest
+	if err := nats.EnsureStream(outbox.DeadLetterStream, []string{outbox.DeadLetterSubjects}, outbox.DeadLetterRetention); err != nil {
+		log.Fatalf("Failed to create dead-letter stream: %v", err)
+	}
+	relay := outbox.NewRelay(dbConn, nats)
+	go relay.Run(relayCtx)
+	// Published, failed and dead-lettered events are logged every
+	// OUTBOX_STATS_INTERVAL
+	go logRelayStats(relayCtx, relay, getEnvAsDuration("OUTBOX_STATS_INTERVAL", 5*time.Minute))
+
+	// Other services are dialed over TLS when GRPC_TLS is set
+	clientTLS, err := mtls.DialOption()
+	if err != nil {
+		log.Fatalf("Failed to load TLS config: %v", err)
+	}
+
+	// Mentions are resolved to users through user-service
+	userConn, err := grpc.NewClient(getEnv("USER_SERVICE_ADDR", "user-service:50052"), clientTLS, tracing.DialOption(), grpc.WithChainUnaryInterceptor(logging.UnaryClientInterceptor()))
+	if err != nil {
+		log.Fatalf("Failed to connect to user service: %v", err)
+	}
+	defer userConn.Close()
+
+	// Comments are only shown and accepted on posts post-service shows the
+	// caller
+	postConn, err := grpc.NewClient(getEnv("POST_SERVICE_ADDR", "post-service:50053"), clientTLS, tracing.DialOption(), grpc.WithChainUnaryInterceptor(logging.UnaryClientInterceptor()))
+	if err != nil {
+		log.Fatalf("Failed to connect to post service: %v", err)
+	}
+	defer postConn.Close()
 
 	// Initialize repository and handler
-	commentRepo := repository.NewCommentRepository(dbConn.DB)
-	commentHandler := handler.NewCommentHandler(commentRepo, eventPublisher)
+	commentRepo := repository.NewCommentRepository(dbConn)
+	commentHandler := handler.NewCommentHandler(commentRepo, eventPublisher, userpb.NewUserServiceClient(userConn), postpb.NewPostServiceClient(postConn), contentFilter)
+
+	// Comments are soft-deleted along with their post or their user
+	subscriber.NewPostSubscriber(nats, commentRepo, context.Background()).Start()
+	subscriber.NewUserSubscriber(nats, commentRepo, context.Background()).Start()
 
 	// Initialize auth interceptor (allowing public routes)
-	authInterceptor := interceptor.NewAuthInterceptor(jwtSecret, []string{
+	authInterceptor := interceptor.NewAuthInterceptor(tokenKeys, []string{
 		"/comment.CommentService/GetPostComments",
+		"/comment.CommentService/GetCommentReplies",
 		"/comment.CommentService/GetComment",
 	})
+	authInterceptor.AddAdminMethods([]string{
+		"/comment.CommentService/PurgeDeletedComments",
+		"/comment.CommentService/RemoveComment",
+		"/comment.CommentService/GetCommentsCounts",
+	})
+	authInterceptor.AddPublicMethods(lifecycle.HealthMethods)
+
+	// Retries of writes that carry an idempotency-key header get the first
+	// call's response instead of writing again. Keys are per caller.
+	idempotencyStore, err := idempotency.NewStore(dbConn.WriteDB(), "comment_service_idempotency_keys", rpcerror.Domain, []byte(os.Getenv("IDEMPOTENCY_SECRET")))
+	if err != nil {
+		log.Fatalf("Invalid IDEMPOTENCY_SECRET: %v", err)
+	}
+	idempotencyCtx, stopIdempotency := context.WithCancel(supervisor.Context())
+	defer stopIdempotency()
+	go idempotencyStore.Run(idempotencyCtx)
+	idempotent := idempotencyStore.UnaryServerInterceptor(func(ctx context.Context) string {
+		userID, _ := interceptor.GetUserIDFromContext(ctx)
+		return userID
+	}, "/comment.CommentService/CreateComment")
+
+	// TLS, and mutual TLS with a CA bundle, from GRPC_TLS_*
+	tlsOption, err := mtls.ServerOption()
+	if err != nil {
+		log.Fatalf("Failed to load TLS config: %v", err)
+	}
 
 	// Create gRPC server with interceptors
 	grpcServer := grpc.NewServer(
-		grpc.UnaryInterceptor(authInterceptor.Unary()),
-		grpc.StreamInterceptor(authInterceptor.Stream()),
+		tlsOption,
+		tracing.ServerOption(),
+		grpc.ChainUnaryInterceptor(logging.UnaryServerInterceptor(), rpcerror.UnaryServerInterceptor(), chaosInjector.Unary(), authInterceptor.Unary(), idempotent),
+		grpc.ChainStreamInterceptor(logging.StreamServerInterceptor(), rpcerror.StreamServerInterceptor(), chaosInjector.Stream(), authInterceptor.Stream()),
 	)
 
-	// Graceful shutdown handling
-	go func() {
-		sigChan := make(chan os.Signal, 1)
-		signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
-		<-sigChan
-
-		log.Println("Comment server Shutting down gracefully...")
-		grpcServer.GracefulStop()
-
-		ctx, cancel := context.WithTimeout(context.Background(), dbCfg.MaxLifetime)
-		defer cancel()
-
-		if err := dbConn.HealthCheck(ctx); err == nil {
-			_ = dbConn.Close()
-			log.Println("Comment Database connection closed")
-		}
-
-		log.Println("Server stopped")
-		os.Exit(0)
-	}()
-
 	// Register the gRPC service
 	pb.RegisterCommentServiceServer(grpcServer, commentHandler)
+	supervisor.Register(grpcServer)
 
 	// Enable reflection for debugging tools like grpcurl
 	reflection.Register(grpcServer)
@@ -130,7 +239,7 @@ func main() {
 	log.Printf("Comment Service gRPC server listening on port %s", grpcPort)
 
 	// Serve requests
-	if err := grpcServer.Serve(listener); err != nil {
+	if err := supervisor.Serve(grpcServer, listener); err != nil {
 		log.Fatalf("Failed to serve: %v", err)
 	}
 }
@@ -142,3 +251,43 @@ func getEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+func getEnvAsDuration(key string, defaultVal time.Duration) time.Duration {
+	if val, err := time.ParseDuration(os.Getenv(key)); err == nil && val > 0 {
+		return val
+	}
+	return defaultVal
+}
+
+// logRelayStats logs the events the outbox relay published, failed to publish
+// and dead-lettered in each interval until ctx is cancelled
+func logRelayStats(ctx context.Context, relay *outbox.Relay, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	last := relay.Stats()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		stats := relay.Stats()
+		published, failed, deadLettered := stats.Published-last.Published, stats.Failed-last.Failed, stats.DeadLettered-last.DeadLettered
+		last = stats
+		if published+failed+deadLettered == 0 {
+			continue
+		}
+
+		event := logging.FromContext(ctx).Info()
+		if failed > 0 {
+			event = logging.FromContext(ctx).Warn()
+		}
+		event.
+			Int64("published", published).
+			Int64("failed", failed).
+			Int64("dead_lettered", deadLettered).
+			Msg("outbox relay stats")
+	}
+}
@@ -0,0 +1,244 @@
+// Package contentfilter screens user-written text before it is stored. A
+// Pipeline runs its filters in order and settles on the strictest action any
+// matching filter is configured with. It does nothing unless at least one
+// filter is configured:
+//
+//	CONTENT_FILTER_WORDS          comma-separated words, e.g. profanity
+//	CONTENT_FILTER_WORDS_FILE     file of words, one per line ("#" starts a comment)
+//	CONTENT_FILTER_WORDS_ACTION   action for a word match (default reject)
+//	CONTENT_FILTER_DOMAINS        comma-separated blocked link domains; subdomains match too
+//	CONTENT_FILTER_DOMAINS_ACTION action for a blocked link (default reject)
+//	CONTENT_FILTER_API_URL        external moderation API, see APIFilter
+//	CONTENT_FILTER_API_KEY        bearer token sent to the API
+//	CONTENT_FILTER_API_TIMEOUT    timeout of an API call (default 2s)
+//	CONTENT_FILTER_API_ACTION     action for content the API flags (default flag)
+//	CONTENT_FILTER_API_FAIL_OPEN  allow content when the API cannot be reached (default true)
+//
+// Actions are reject, flag and shadow_hide.
+package contentfilter
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Action is what happens to content a filter matches. Actions are ordered
+// from least to most strict.
+type Action int
+
+const (
+	ActionAllow Action = iota
+	// ActionFlag stores and shows the content, and reports it for review
+	ActionFlag
+	// ActionShadowHide stores the content but shows it only to its author,
+	// and reports it for review
+	ActionShadowHide
+	// ActionReject refuses to store the content
+	ActionReject
+)
+
+func (a Action) String() string {
+	switch a {
+	case ActionFlag:
+		return "FLAG"
+	case ActionShadowHide:
+		return "SHADOW_HIDE"
+	case ActionReject:
+		return "REJECT"
+	}
+	return "ALLOW"
+}
+
+// ParseAction parses reject, flag or shadow_hide
+func ParseAction(s string) (Action, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "reject":
+		return ActionReject, nil
+	case "flag":
+		return ActionFlag, nil
+	case "shadow_hide", "shadow-hide":
+		return ActionShadowHide, nil
+	}
+	return ActionAllow, fmt.Errorf("unknown content filter action %q", s)
+}
+
+// Filter inspects text and returns why it matched, or nothing when it did not
+type Filter interface {
+	Name() string
+	Check(ctx context.Context, text string) ([]string, error)
+}
+
+// Match is one reason a filter matched
+type Match struct {
+	Filter string
+	Reason string
+	Action Action
+}
+
+// Verdict is the outcome of running a Pipeline
+type Verdict struct {
+	Action  Action
+	Matches []Match
+}
+
+// Reasons lists the matches as "filter: reason"
+func (v Verdict) Reasons() []string {
+	reasons := make([]string, len(v.Matches))
+	for i, m := range v.Matches {
+		reasons[i] = m.Filter + ": " + m.Reason
+	}
+	return reasons
+}
+
+type stage struct {
+	filter Filter
+	action Action
+}
+
+// Pipeline runs filters in the order they were added. A nil Pipeline allows
+// everything, so callers can use it without checking whether filtering is
+// enabled.
+type Pipeline struct {
+	stages []stage
+}
+
+// Add appends a filter and the action taken when it matches
+func (p *Pipeline) Add(filter Filter, action Action) {
+	p.stages = append(p.stages, stage{filter: filter, action: action})
+}
+
+// Check runs the filters over text. It stops at the first filter that
+// rejects the content, since nothing stricter can follow.
+func (p *Pipeline) Check(ctx context.Context, text string) (Verdict, error) {
+	var verdict Verdict
+	if p == nil || strings.TrimSpace(text) == "" {
+		return verdict, nil
+	}
+
+	for _, s := range p.stages {
+		reasons, err := s.filter.Check(ctx, text)
+		if err != nil {
+			return Verdict{}, fmt.Errorf("content filter %s failed: %w", s.filter.Name(), err)
+		}
+		for _, reason := range reasons {
+			verdict.Matches = append(verdict.Matches, Match{Filter: s.filter.Name(), Reason: reason, Action: s.action})
+		}
+		if len(reasons) > 0 && s.action > verdict.Action {
+			verdict.Action = s.action
+		}
+		if verdict.Action == ActionReject {
+			break
+		}
+	}
+	return verdict, nil
+}
+
+// FromEnv builds a Pipeline from the CONTENT_FILTER_* variables. It returns
+// nil when no filter is configured.
+func FromEnv() (*Pipeline, error) {
+	p := &Pipeline{}
+
+	words := splitList(os.Getenv("CONTENT_FILTER_WORDS"))
+	if path := os.Getenv("CONTENT_FILTER_WORDS_FILE"); path != "" {
+		fromFile, err := readWords(path)
+		if err != nil {
+			return nil, err
+		}
+		words = append(words, fromFile...)
+	}
+	if len(words) > 0 {
+		action, err := actionFromEnv("CONTENT_FILTER_WORDS_ACTION", ActionReject)
+		if err != nil {
+			return nil, err
+		}
+		p.Add(NewWordFilter(words), action)
+	}
+
+	if domains := splitList(os.Getenv("CONTENT_FILTER_DOMAINS")); len(domains) > 0 {
+		action, err := actionFromEnv("CONTENT_FILTER_DOMAINS_ACTION", ActionReject)
+		if err != nil {
+			return nil, err
+		}
+		p.Add(NewDomainFilter(domains), action)
+	}
+
+	if url := os.Getenv("CONTENT_FILTER_API_URL"); url != "" {
+		action, err := actionFromEnv("CONTENT_FILTER_API_ACTION", ActionFlag)
+		if err != nil {
+			return nil, err
+		}
+		timeout := 2 * time.Second
+		if raw := os.Getenv("CONTENT_FILTER_API_TIMEOUT"); raw != "" {
+			if timeout, err = time.ParseDuration(raw); err != nil || timeout <= 0 {
+				return nil, fmt.Errorf("invalid CONTENT_FILTER_API_TIMEOUT %q", raw)
+			}
+		}
+		failOpen := true
+		if raw := os.Getenv("CONTENT_FILTER_API_FAIL_OPEN"); raw != "" {
+			if failOpen, err = strconv.ParseBool(raw); err != nil {
+				return nil, fmt.Errorf("invalid CONTENT_FILTER_API_FAIL_OPEN %q", raw)
+			}
+		}
+		p.Add(NewAPIFilter(url, os.Getenv("CONTENT_FILTER_API_KEY"), timeout, failOpen), action)
+	}
+
+	if len(p.stages) == 0 {
+		return nil, nil
+	}
+
+	names := make([]string, len(p.stages))
+	for i, s := range p.stages {
+		names[i] = s.filter.Name() + "=" + strings.ToLower(s.action.String())
+	}
+	log.Printf("Content filters enabled: %s", strings.Join(names, ", "))
+	return p, nil
+}
+
+func actionFromEnv(key string, defaultAction Action) (Action, error) {
+	raw := os.Getenv(key)
+	if raw == "" {
+		return defaultAction, nil
+	}
+	action, err := ParseAction(raw)
+	if err != nil {
+		return ActionAllow, fmt.Errorf("invalid %s: %w", key, err)
+	}
+	return action, nil
+}
+
+func readWords(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read content filter words: %w", err)
+	}
+	defer f.Close()
+
+	var words []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		if line = strings.TrimSpace(line); line != "" {
+			words = append(words, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read content filter words: %w", err)
+	}
+	return words, nil
+}
+
+func splitList(raw string) []string {
+	var values []string
+	for _, value := range strings.Split(raw, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
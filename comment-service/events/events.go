@@ -4,16 +4,78 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"shared/eventschema"
 )
 
 const (
-	CommentAdded = "post.comment.added"
+	// CommentAdded carries the payload of shared/eventschema
+	CommentAdded = eventschema.CommentAdded
+	// CommentDeleted is published when a counted comment is deleted, so
+	// post-service can count it down
+	CommentDeleted = "post.comment.deleted"
+	// CommentReplied is published for replies, alongside CommentAdded
+	CommentReplied = "comment.replied"
+	// MentionCreated is shared with post-service, which publishes it for
+	// mentions in posts
+	MentionCreated = "mention.created"
+	// ContentFlagged is shared with post-service, which publishes it for
+	// flagged posts
+	ContentFlagged = "content.flagged"
+	// PostDeleted is published by post-service; the comments on the post
+	// are deleted with it
+	PostDeleted = eventschema.PostDeleted
+	// UserDeleted is published by auth-service; the comments of the user
+	// are deleted with them
+	UserDeleted = "user.deleted"
 )
 
-type CommentAddedEvent struct {
-	CommentID  uuid.UUID `json:"comment_id"`
-	PostID     uuid.UUID `json:"post_id"`
-	PostUserID uuid.UUID `json:"post_user_id"`
-	Content    string    `json:"content"`
-	CreatedAt  time.Time `json:"created_at"`
+// CommentDeletedEvent is published when a comment or reply is deleted by
+// its author or removed by a moderator
+type CommentDeletedEvent struct {
+	CommentID uuid.UUID `json:"comment_id"`
+	PostID    uuid.UUID `json:"post_id"`
+	UserID    uuid.UUID `json:"user_id"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+// CommentRepliedEvent is published when a comment is posted as a reply to
+// another comment
+type CommentRepliedEvent struct {
+	ReplyID         uuid.UUID `json:"reply_id"`
+	CommentID       uuid.UUID `json:"comment_id"`
+	CommentAuthorID uuid.UUID `json:"comment_author_id"`
+	PostID          uuid.UUID `json:"post_id"`
+	UserID          uuid.UUID `json:"user_id"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+// MentionCreatedEvent is published once per user newly mentioned in a comment
+type MentionCreatedEvent struct {
+	MentionID       uuid.UUID  `json:"mention_id"`
+	PostID          uuid.UUID  `json:"post_id"`
+	CommentID       *uuid.UUID `json:"comment_id,omitempty"`
+	AuthorID        uuid.UUID  `json:"author_id"`
+	MentionedUserID uuid.UUID  `json:"mentioned_user_id"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+// UserDeletedEvent is published by auth-service when a user deletes their
+// account
+type UserDeletedEvent struct {
+	UserID    uuid.UUID `json:"user_id"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+// ContentFlaggedEvent is published when a content filter flags or
+// shadow-hides a post or comment, for moderators to review. ContentType is
+// POST or COMMENT, Action is FLAG or SHADOW_HIDE, and PostID is the post a
+// comment is on.
+type ContentFlaggedEvent struct {
+	ContentType string    `json:"content_type"`
+	ContentID   uuid.UUID `json:"content_id"`
+	PostID      uuid.UUID `json:"post_id"`
+	UserID      uuid.UUID `json:"user_id"`
+	Action      string    `json:"action"`
+	Reasons     []string  `json:"reasons"`
+	FlaggedAt   time.Time `json:"flagged_at"`
 }
@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "comment-service/pb"
+	"comment-service/rpcerror"
+	"shared/logging"
+)
+
+const purgeBatchSize = 500
+
+// RemoveComment deletes a comment whoever wrote it, for moderators taking
+// comments down. Its replies stay, as for any deletion.
+func (h *CommentHandler) RemoveComment(ctx context.Context, req *pb.RemoveCommentRequest) (*pb.Response, error) {
+	commentID, err := uuid.Parse(req.CommentId)
+	if err != nil {
+		return nil, rpcerror.InvalidField("comment_id", "invalid comment_id format")
+	}
+
+	if err := h.deleteComment(ctx, commentID, nil); err != nil {
+		return nil, err
+	}
+
+	return &pb.Response{
+		Success: true,
+		Message: "comment removed successfully",
+	}, nil
+}
+
+// PurgeDeletedComments hard-deletes comments soft-deleted before
+// deleted_before. It is run on a schedule by scheduler-service.
+func (h *CommentHandler) PurgeDeletedComments(ctx context.Context, req *pb.PurgeDeletedCommentsRequest) (*pb.PurgeDeletedCommentsResponse, error) {
+	if req.DeletedBefore == nil {
+		return nil, rpcerror.InvalidField("deleted_before", "deleted_before is required")
+	}
+	deletedBefore := req.DeletedBefore.AsTime()
+
+	// Each batch frees the parents of the replies it purged, so keep going
+	// until a batch finds nothing
+	var purged int64
+	for {
+		n, err := h.repo.PurgeDeletedComments(ctx, deletedBefore, purgeBatchSize)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to purge comments after %d purged: %v", purged, err)
+		}
+		if n == 0 {
+			break
+		}
+		purged += n
+	}
+
+	logging.FromContext(ctx).Info().Int64("purged", purged).Time("deleted_before", deletedBefore).Msg("purged deleted comments")
+	return &pb.PurgeDeletedCommentsResponse{Purged: purged}, nil
+}
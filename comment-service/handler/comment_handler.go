@@ -2,14 +2,23 @@ package handler
 
 import (
 	"context"
-	"log"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"time"
 
+	"comment-service/contentfilter"
 	"comment-service/events"
+	"comment-service/interceptor"
 	"comment-service/model"
 	pb "comment-service/pb"
 	"comment-service/publisher"
 	"comment-service/repository"
+	"comment-service/rpcerror"
+	postpb "post-service/pb"
+	"shared/cursor"
+	eventspb "shared/eventschema/pb"
+	userpb "user-service/pb"
 
 	"github.com/google/uuid"
 	"google.golang.org/grpc/codes"
@@ -21,65 +30,154 @@ type CommentHandler struct {
 	pb.UnimplementedCommentServiceServer
 	repo      repository.CommentRepository
 	publisher *publisher.EventPublisher
+	users     userpb.UserServiceClient
+	// posts says whether the caller may see the post a comment is on
+	posts postpb.PostServiceClient
+	// filter screens content before it is stored; nil allows everything
+	filter *contentfilter.Pipeline
 }
 
-func NewCommentHandler(repo repository.CommentRepository, pub *publisher.EventPublisher) *CommentHandler {
+func NewCommentHandler(repo repository.CommentRepository, pub *publisher.EventPublisher, users userpb.UserServiceClient, posts postpb.PostServiceClient, filter *contentfilter.Pipeline) *CommentHandler {
 	return &CommentHandler{
 		repo:      repo,
 		publisher: pub,
+		users:     users,
+		posts:     posts,
+		filter:    filter,
 	}
 }
 
 // CreateComment handles the creation of a new comment
 func (h *CommentHandler) CreateComment(ctx context.Context, req *pb.CreateCommentRequest) (*pb.Comment, error) {
 	if req.PostId == "" {
-		return nil, status.Error(codes.InvalidArgument, "post_id is required")
+		return nil, rpcerror.InvalidField("post_id", "post_id is required")
 	}
 	if req.UserId == "" {
-		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+		return nil, rpcerror.InvalidField("user_id", "user_id is required")
 	}
 	if req.Content == "" {
-		return nil, status.Error(codes.InvalidArgument, "content is required")
+		return nil, rpcerror.InvalidField("content", "content is required")
 	}
 	if len(req.Content) > 2000 {
-		return nil, status.Error(codes.InvalidArgument, "content must be less than 2000 characters")
+		return nil, rpcerror.InvalidField("content", "content must be less than 2000 characters")
 	}
 
 	postID, err := uuid.Parse(req.PostId)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid post_id format")
+		return nil, rpcerror.InvalidField("post_id", "invalid post_id format")
 	}
 
 	userID, err := uuid.Parse(req.UserId)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid user_id format")
+		return nil, rpcerror.InvalidField("user_id", "invalid user_id format")
+	}
+
+	var parentID *uuid.UUID
+	if req.ParentCommentId != nil && *req.ParentCommentId != "" {
+		id, err := uuid.Parse(*req.ParentCommentId)
+		if err != nil {
+			return nil, rpcerror.InvalidField("parent_comment_id", "invalid parent_comment_id format")
+		}
+		parentID = &id
+	}
+
+	post, err := h.visiblePost(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+
+	verdict, err := h.screen(ctx, req.Content)
+	if err != nil {
+		return nil, err
 	}
 
 	now := time.Now()
 	comment := &models.Comment{
-		ID:        uuid.New(),
-		PostID:    postID,
-		UserID:    userID,
-		Content:   req.Content,
-		CreatedAt: now,
-		UpdatedAt: now,
-	}
-
-	event := events.CommentAddedEvent{
-		CommentID:  comment.ID,
-		PostID:     comment.PostID,
-		PostUserID: comment.UserID,
+		ID:              uuid.New(),
+		PostID:          postID,
+		UserID:          userID,
+		ParentCommentID: parentID,
+		Content:         req.Content,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	if verdict.Action == contentfilter.ActionShadowHide {
+		comment.ShadowHiddenAt = &now
+	}
+
+	event := &eventspb.CommentAdded{
+		CommentId:  comment.ID.String(),
+		PostId:     comment.PostID.String(),
+		UserId:     comment.UserID.String(),
+		PostUserId: post.UserId,
 		Content:    comment.Content,
-		CreatedAt:  time.Now(),
-	}
+		CreatedAt:  timestamppb.New(comment.CreatedAt),
+	}
+
+	mentions := h.resolveMentions(ctx, comment.Content)
+
+	var parent *models.Comment
+	var added []models.Mention
+	err = h.repo.WithTx(ctx, func(ctx context.Context) error {
+		if parentID != nil {
+			parent, err = h.repo.GetByID(ctx, *parentID)
+			if err != nil || (parent.ShadowHiddenAt != nil && parent.UserID != userID) {
+				return status.Error(codes.NotFound, "parent comment not found")
+			}
+			if parent.PostID != postID {
+				return status.Error(codes.InvalidArgument, "parent comment belongs to another post")
+			}
+		}
 
-	if err := h.publisher.PublishCommentAdded(event); err != nil {
-		log.Printf("Failed to publish post created event: %v", err)
-	}
+		if err := h.repo.Create(ctx, comment); err != nil {
+			return status.Errorf(codes.Internal, "failed to create comment: %v", err)
+		}
+		added, err = h.repo.SetMentions(ctx, comment.ID, mentions, comment.CreatedAt)
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to create comment: %v", err)
+		}
+		if err := h.reportFlagged(ctx, comment, verdict); err != nil {
+			return status.Errorf(codes.Internal, "failed to create comment: %v", err)
+		}
+		// A shadow-hidden comment is not counted or announced, so nobody
+		// learns it exists
+		if comment.ShadowHiddenAt != nil {
+			return nil
+		}
+		if parent != nil {
+			if err := h.repo.IncrementRepliesCount(ctx, parent.ID); err != nil {
+				return status.Errorf(codes.Internal, "failed to create comment: %v", err)
+			}
+		}
 
-	if err := h.repo.Create(ctx, comment); err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to create comment: %v", err)
+		// Events are stored with the comment and relayed once it is
+		// committed, so post counters never count a comment that failed to
+		// save
+		if err := h.publisher.PublishCommentAdded(ctx, event); err != nil {
+			return status.Errorf(codes.Internal, "failed to create comment: %v", err)
+		}
+		if parent != nil {
+			replied := events.CommentRepliedEvent{
+				ReplyID:         comment.ID,
+				CommentID:       parent.ID,
+				CommentAuthorID: parent.UserID,
+				PostID:          comment.PostID,
+				UserID:          comment.UserID,
+				CreatedAt:       comment.CreatedAt,
+			}
+			if err := h.publisher.PublishCommentReplied(ctx, replied); err != nil {
+				return status.Errorf(codes.Internal, "failed to create comment: %v", err)
+			}
+		}
+		if err := h.publishMentions(ctx, comment, added); err != nil {
+			return status.Errorf(codes.Internal, "failed to create comment: %v", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
+	comment.Mentions = mentions
 
 	return commentToProto(comment), nil
 }
@@ -87,12 +185,16 @@ func (h *CommentHandler) CreateComment(ctx context.Context, req *pb.CreateCommen
 // GetPostComments retrieves comments for a specific post with pagination
 func (h *CommentHandler) GetPostComments(ctx context.Context, req *pb.GetPostCommentsRequest) (*pb.CommentConnection, error) {
 	if req.PostId == "" {
-		return nil, status.Error(codes.InvalidArgument, "post_id is required")
+		return nil, rpcerror.InvalidField("post_id", "post_id is required")
 	}
 
 	postID, err := uuid.Parse(req.PostId)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid post_id format")
+		return nil, rpcerror.InvalidField("post_id", "invalid post_id format")
+	}
+
+	if _, err := h.visiblePost(ctx, postID); err != nil {
+		return nil, err
 	}
 
 	first := req.First
@@ -100,91 +202,161 @@ func (h *CommentHandler) GetPostComments(ctx context.Context, req *pb.GetPostCom
 		first = 10
 	}
 
-	connection, err := h.repo.GetPostComments(ctx, postID, first, req.After)
+	viewerID := requestingUser(ctx)
+
+	connection, err := h.repo.GetPostComments(ctx, postID, first, req.After, viewerID)
 	if err != nil {
+		if errors.Is(err, cursor.ErrInvalid) {
+			return nil, rpcerror.InvalidField("after", "invalid cursor")
+		}
 		return nil, status.Errorf(codes.Internal, "failed to get comments: %v", err)
 	}
 
 	return commentConnectionToProto(connection), nil
 }
 
+// GetCommentReplies retrieves the replies to a comment with pagination
+func (h *CommentHandler) GetCommentReplies(ctx context.Context, req *pb.GetCommentRepliesRequest) (*pb.CommentConnection, error) {
+	if req.CommentId == "" {
+		return nil, rpcerror.InvalidField("comment_id", "comment_id is required")
+	}
+
+	commentID, err := uuid.Parse(req.CommentId)
+	if err != nil {
+		return nil, rpcerror.InvalidField("comment_id", "invalid comment_id format")
+	}
+
+	viewerID := requestingUser(ctx)
+
+	// Replies are only shown to those who may see the comment and its post
+	comment, err := h.repo.GetByID(ctx, commentID)
+	if err != nil || (comment.ShadowHiddenAt != nil && (viewerID == nil || comment.UserID != *viewerID)) {
+		return nil, status.Error(codes.NotFound, "comment not found")
+	}
+	if _, err := h.visiblePost(ctx, comment.PostID); err != nil {
+		return nil, err
+	}
+
+	first := req.First
+	if first <= 0 {
+		first = 10
+	}
+
+	connection, err := h.repo.GetReplies(ctx, commentID, first, req.After, viewerID)
+	if err != nil {
+		if errors.Is(err, cursor.ErrInvalid) {
+			return nil, rpcerror.InvalidField("after", "invalid cursor")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get replies: %v", err)
+	}
+
+	return commentConnectionToProto(connection), nil
+}
+
 // UpdateComment updates an existing comment
 func (h *CommentHandler) UpdateComment(ctx context.Context, req *pb.UpdateCommentRequest) (*pb.Comment, error) {
 	if req.CommentId == "" {
-		return nil, status.Error(codes.InvalidArgument, "comment_id is required")
+		return nil, rpcerror.InvalidField("comment_id", "comment_id is required")
 	}
 	if req.UserId == "" {
-		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+		return nil, rpcerror.InvalidField("user_id", "user_id is required")
 	}
 	if req.Content == "" {
-		return nil, status.Error(codes.InvalidArgument, "content is required")
+		return nil, rpcerror.InvalidField("content", "content is required")
 	}
 	if len(req.Content) > 2000 {
-		return nil, status.Error(codes.InvalidArgument, "content must be less than 2000 characters")
+		return nil, rpcerror.InvalidField("content", "content must be less than 2000 characters")
 	}
 
 	commentID, err := uuid.Parse(req.CommentId)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid comment_id format")
+		return nil, rpcerror.InvalidField("comment_id", "invalid comment_id format")
 	}
 
 	userID, err := uuid.Parse(req.UserId)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid user_id format")
+		return nil, rpcerror.InvalidField("user_id", "invalid user_id format")
 	}
 
-	exists, err := h.repo.CheckOwnership(ctx, commentID, userID)
+	verdict, err := h.screen(ctx, req.Content)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to verify ownership: %v", err)
-	}
-	if !exists {
-		return nil, status.Error(codes.PermissionDenied, "you don't have permission to update this comment")
+		return nil, err
 	}
 
-	comment, err := h.repo.GetByID(ctx, commentID)
-	if err != nil {
-		return nil, status.Error(codes.NotFound, "comment not found")
-	}
+	mentions := h.resolveMentions(ctx, req.Content)
 
-	comment.Content = req.Content
-	comment.UpdatedAt = time.Now()
+	var comment *models.Comment
+	var added []models.Mention
+	err = h.repo.WithTx(ctx, func(ctx context.Context) error {
+		exists, err := h.repo.CheckOwnership(ctx, commentID, userID)
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to verify ownership: %v", err)
+		}
+		if !exists {
+			return status.Error(codes.PermissionDenied, "you don't have permission to update this comment")
+		}
 
-	if err := h.repo.Update(ctx, comment); err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to update comment: %v", err)
+		comment, err = h.repo.GetByID(ctx, commentID)
+		if err != nil {
+			return status.Error(codes.NotFound, "comment not found")
+		}
+		// Others may already have seen the comment, so an edit that would
+		// hide it is refused instead
+		if verdict.Action == contentfilter.ActionShadowHide && comment.ShadowHiddenAt == nil {
+			return rejected(verdict)
+		}
+
+		comment.Content = req.Content
+		comment.UpdatedAt = time.Now()
+
+		if err := h.repo.Update(ctx, comment); err != nil {
+			return status.Errorf(codes.Internal, "failed to update comment: %v", err)
+		}
+		added, err = h.repo.SetMentions(ctx, comment.ID, mentions, comment.UpdatedAt)
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to update comment: %v", err)
+		}
+		if err := h.reportFlagged(ctx, comment, verdict); err != nil {
+			return status.Errorf(codes.Internal, "failed to update comment: %v", err)
+		}
+		if comment.ShadowHiddenAt != nil {
+			return nil
+		}
+		if err := h.publishMentions(ctx, comment, added); err != nil {
+			return status.Errorf(codes.Internal, "failed to update comment: %v", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
+	comment.Mentions = mentions
 
 	return commentToProto(comment), nil
 }
 
-// DeleteComment removes a comment
+// DeleteComment soft-deletes a comment; the row is purged after the
+// retention window
 func (h *CommentHandler) DeleteComment(ctx context.Context, req *pb.DeleteCommentRequest) (*pb.Response, error) {
 	if req.CommentId == "" {
-		return nil, status.Error(codes.InvalidArgument, "comment_id is required")
+		return nil, rpcerror.InvalidField("comment_id", "comment_id is required")
 	}
 	if req.UserId == "" {
-		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+		return nil, rpcerror.InvalidField("user_id", "user_id is required")
 	}
 
 	commentID, err := uuid.Parse(req.CommentId)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid comment_id format")
+		return nil, rpcerror.InvalidField("comment_id", "invalid comment_id format")
 	}
 
 	userID, err := uuid.Parse(req.UserId)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid user_id format")
+		return nil, rpcerror.InvalidField("user_id", "invalid user_id format")
 	}
 
-	exists, err := h.repo.CheckOwnership(ctx, commentID, userID)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to verify ownership: %v", err)
-	}
-	if !exists {
-		return nil, status.Error(codes.PermissionDenied, "you don't have permission to delete this comment")
-	}
-
-	if err := h.repo.Delete(ctx, commentID); err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to delete comment: %v", err)
+	if err := h.deleteComment(ctx, commentID, &userID); err != nil {
+		return nil, err
 	}
 
 	return &pb.Response{
@@ -193,17 +365,156 @@ func (h *CommentHandler) DeleteComment(ctx context.Context, req *pb.DeleteCommen
 	}, nil
 }
 
+// deleteComment soft-deletes a comment. With ownerID set, only a comment of
+// that user is deleted.
+func (h *CommentHandler) deleteComment(ctx context.Context, commentID uuid.UUID, ownerID *uuid.UUID) error {
+	return h.repo.WithTx(ctx, func(ctx context.Context) error {
+		if ownerID != nil {
+			exists, err := h.repo.CheckOwnership(ctx, commentID, *ownerID)
+			if err != nil {
+				return status.Errorf(codes.Internal, "failed to verify ownership: %v", err)
+			}
+			if !exists {
+				return status.Error(codes.PermissionDenied, "you don't have permission to delete this comment")
+			}
+		}
+
+		comment, err := h.repo.GetByID(ctx, commentID)
+		if err != nil {
+			return status.Error(codes.NotFound, "comment not found")
+		}
+
+		// Replies to the comment stay, under a "comment deleted" placeholder
+		if err := h.repo.Delete(ctx, commentID); err != nil {
+			return status.Errorf(codes.Internal, "failed to delete comment: %v", err)
+		}
+		// Shadow-hidden comments were never counted
+		if comment.ShadowHiddenAt != nil {
+			return nil
+		}
+		if comment.ParentCommentID != nil {
+			if err := h.repo.DecrementRepliesCount(ctx, *comment.ParentCommentID); err != nil {
+				return status.Errorf(codes.Internal, "failed to delete comment: %v", err)
+			}
+		}
+		deleted := events.CommentDeletedEvent{
+			CommentID: comment.ID,
+			PostID:    comment.PostID,
+			UserID:    comment.UserID,
+			DeletedAt: time.Now(),
+		}
+		if err := h.publisher.PublishCommentDeleted(ctx, deleted); err != nil {
+			return status.Errorf(codes.Internal, "failed to delete comment: %v", err)
+		}
+		return nil
+	})
+}
+
+// maxCountBatch is the most posts GetCommentsCounts counts at once
+const maxCountBatch = 1000
+
+// GetCommentsCounts returns the number of comments and replies on each post,
+// for post-service to reconcile its comment counters with
+func (h *CommentHandler) GetCommentsCounts(ctx context.Context, req *pb.GetCommentsCountsRequest) (*pb.GetCommentsCountsResponse, error) {
+	if len(req.PostIds) > maxCountBatch {
+		return nil, rpcerror.InvalidField("post_ids", fmt.Sprintf("at most %d post_ids can be counted per request", maxCountBatch))
+	}
+
+	postIDs := make([]uuid.UUID, len(req.PostIds))
+	for i, idStr := range req.PostIds {
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			return nil, rpcerror.InvalidField(fmt.Sprintf("post_ids[%d]", i), fmt.Sprintf("invalid post_id format at index %d", i))
+		}
+		postIDs[i] = id
+	}
+
+	counts, err := h.repo.GetCountsByPosts(ctx, postIDs)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get comment counts: %v", err)
+	}
+
+	pbCounts := make([]*pb.PostCommentsCount, len(postIDs))
+	for i, postID := range postIDs {
+		pbCounts[i] = &pb.PostCommentsCount{
+			PostId: postID.String(),
+			Count:  counts[postID],
+		}
+	}
+
+	return &pb.GetCommentsCountsResponse{Counts: pbCounts}, nil
+}
+
+// ExportMyData returns the caller's comments and replies as a JSON document
+func (h *CommentHandler) ExportMyData(ctx context.Context, req *pb.ExportMyDataRequest) (*pb.DataExport, error) {
+	raw, err := interceptor.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "user not authenticated")
+	}
+	userID, err := uuid.Parse(raw)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "invalid user ID in token")
+	}
+
+	comments, err := h.repo.GetByUser(ctx, userID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to export comments: %v", err)
+	}
+
+	data, err := json.Marshal(map[string]interface{}{"comments": comments})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to encode export: %v", err)
+	}
+	return &pb.DataExport{Data: data}, nil
+}
+
+// requestingUser returns the user a request is made for, from its verified
+// token, or nil for anonymous requests. The requesting_user_id of public
+// requests is ignored, as any caller could set it.
+func requestingUser(ctx context.Context) *uuid.UUID {
+	raw, err := interceptor.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil
+	}
+	userID, err := uuid.Parse(raw)
+	if err != nil {
+		return nil
+	}
+	return &userID
+}
+
 // Helper functions for proto conversion
 
 func commentToProto(c *models.Comment) *pb.Comment {
-	return &pb.Comment{
-		Id:        c.ID.String(),
-		PostId:    c.PostID.String(),
-		UserId:    c.UserID.String(),
-		Content:   c.Content,
-		CreatedAt: timestamppb.New(c.CreatedAt),
-		UpdatedAt: timestamppb.New(c.UpdatedAt),
+	comment := &pb.Comment{
+		Id:           c.ID.String(),
+		PostId:       c.PostID.String(),
+		UserId:       c.UserID.String(),
+		Content:      c.Content,
+		CreatedAt:    timestamppb.New(c.CreatedAt),
+		UpdatedAt:    timestamppb.New(c.UpdatedAt),
+		Mentions:     mentionsToProto(c.Mentions),
+		RepliesCount: c.RepliesCount,
+	}
+	if c.DeletedAt != nil {
+		comment.DeletedAt = timestamppb.New(*c.DeletedAt)
+	}
+	if c.ParentCommentID != nil {
+		parentID := c.ParentCommentID.String()
+		comment.ParentCommentId = &parentID
+	}
+	return comment
+}
+
+func mentionsToProto(mentions []models.Mention) []*pb.Mention {
+	out := make([]*pb.Mention, len(mentions))
+	for i, m := range mentions {
+		out[i] = &pb.Mention{
+			UserId:   m.UserID.String(),
+			Username: m.Username,
+		}
 	}
+	return out
 }
 
 func commentConnectionToProto(conn *models.CommentConnection) *pb.CommentConnection {
@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"comment-service/contentfilter"
+	"comment-service/events"
+	"comment-service/model"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// screen runs the content filters over content. Content they reject is
+// refused with InvalidArgument, and when they cannot run the request fails
+// with Unavailable, so nothing is stored unchecked.
+func (h *CommentHandler) screen(ctx context.Context, content string) (contentfilter.Verdict, error) {
+	verdict, err := h.filter.Check(ctx, content)
+	if err != nil {
+		return verdict, status.Error(codes.Unavailable, err.Error())
+	}
+	if verdict.Action == contentfilter.ActionReject {
+		return verdict, rejected(verdict)
+	}
+	return verdict, nil
+}
+
+func rejected(verdict contentfilter.Verdict) error {
+	return status.Error(codes.InvalidArgument, "content rejected by the content filter: "+strings.Join(verdict.Reasons(), ", "))
+}
+
+// reportFlagged emits a ContentFlagged event when the filters flagged or
+// shadow-hid comment
+func (h *CommentHandler) reportFlagged(ctx context.Context, comment *models.Comment, verdict contentfilter.Verdict) error {
+	if verdict.Action != contentfilter.ActionFlag && verdict.Action != contentfilter.ActionShadowHide {
+		return nil
+	}
+	return h.publisher.PublishContentFlagged(ctx, events.ContentFlaggedEvent{
+		ContentType: "COMMENT",
+		ContentID:   comment.ID,
+		PostID:      comment.PostID,
+		UserID:      comment.UserID,
+		Action:      verdict.Action.String(),
+		Reasons:     verdict.Reasons(),
+		FlaggedAt:   time.Now(),
+	})
+}
@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"comment-service/events"
+	"comment-service/mention"
+	"comment-service/model"
+	"shared/logging"
+	userpb "user-service/pb"
+)
+
+// resolveMentions turns the @usernames in content into users. As in
+// post-service, the comment is still saved when user-service cannot be reached.
+func (h *CommentHandler) resolveMentions(ctx context.Context, content string) []models.Mention {
+	usernames := mention.Extract(content)
+	if len(usernames) == 0 {
+		return nil
+	}
+
+	resp, err := h.users.GetUsersByUsernames(ctx, &userpb.GetUsersByUsernamesRequest{Usernames: usernames})
+	if err != nil {
+		logging.FromContext(ctx).Error().Err(err).Msg("failed to resolve mentions")
+		return nil
+	}
+
+	byName := make(map[string]*userpb.User, len(resp.Users)+len(resp.FormerUsernames))
+	for _, u := range resp.Users {
+		byName[strings.ToLower(u.Username)] = u
+	}
+	// A user mentioned by a username they have changed is mentioned under
+	// their current one
+	for _, former := range resp.FormerUsernames {
+		byName[strings.ToLower(former.Username)] = former.User
+	}
+
+	// Keep the order in which users were mentioned, and mention each once
+	var mentions []models.Mention
+	seen := make(map[uuid.UUID]bool)
+	for _, username := range usernames {
+		u, ok := byName[strings.ToLower(username)]
+		if !ok {
+			continue
+		}
+		userID, err := uuid.Parse(u.Id)
+		if err != nil || seen[userID] {
+			continue
+		}
+		seen[userID] = true
+		mentions = append(mentions, models.Mention{UserID: userID, Username: u.Username})
+	}
+	return mentions
+}
+
+// publishMentions notifies users newly mentioned in a comment, except its
+// author
+func (h *CommentHandler) publishMentions(ctx context.Context, comment *models.Comment, added []models.Mention) error {
+	for _, m := range added {
+		if m.UserID == comment.UserID {
+			continue
+		}
+
+		event := events.MentionCreatedEvent{
+			MentionID:       m.ID,
+			PostID:          comment.PostID,
+			CommentID:       &comment.ID,
+			AuthorID:        comment.UserID,
+			MentionedUserID: m.UserID,
+			CreatedAt:       time.Now(),
+		}
+		if err := h.publisher.PublishMentionCreated(ctx, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
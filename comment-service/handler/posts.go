@@ -0,0 +1,37 @@
+package handler
+
+import (
+	"context"
+
+	postpb "post-service/pb"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// visiblePost looks a post up in post-service as the caller. Posts the caller
+// may not see are reported as not found, so their comments are neither shown
+// nor accepted.
+func (h *CommentHandler) visiblePost(ctx context.Context, postID uuid.UUID) (*postpb.Post, error) {
+	post, err := h.posts.GetPost(forwardToken(ctx), &postpb.GetPostRequest{PostId: postID.String()})
+	switch status.Code(err) {
+	case codes.OK:
+		return post, nil
+	case codes.NotFound, codes.PermissionDenied:
+		return nil, status.Error(codes.NotFound, "post not found")
+	default:
+		return nil, status.Errorf(codes.Unavailable, "failed to look up post: %v", err)
+	}
+}
+
+// forwardToken passes the caller's token on to post-service, which only
+// shows a post to the user the token is for
+func forwardToken(ctx context.Context) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get("authorization"); len(values) > 0 {
+		return metadata.AppendToOutgoingContext(ctx, "authorization", values[0])
+	}
+	return ctx
+}
@@ -0,0 +1,62 @@
+// Package mention finds the @username mentions in user-written content
+package mention
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// MaxPerContent caps how many users one post or comment can mention, so a
+// single write cannot fan out into an unbounded number of notifications
+const MaxPerContent = 20
+
+// Extract returns the distinct usernames mentioned in content, in order of
+// first mention and with duplicates differing only in case removed. A mention
+// is '@' followed by letters, digits, '_', '.' or '-', not preceded by a word
+// character, so e-mail addresses are not mentions. Trailing '.' and '-' are
+// treated as punctuation.
+func Extract(content string) []string {
+	var usernames []string
+	seen := make(map[string]bool)
+
+	prev := ' '
+	for i := 0; i < len(content); {
+		r, size := utf8.DecodeRuneInString(content[i:])
+		if r != '@' || isWordRune(prev) {
+			prev = r
+			i += size
+			continue
+		}
+
+		end := i + size
+		for end < len(content) {
+			next, n := utf8.DecodeRuneInString(content[end:])
+			if !isWordRune(next) && next != '.' && next != '-' {
+				break
+			}
+			end += n
+		}
+
+		username := strings.TrimRight(content[i+size:end], ".-")
+		if key := strings.ToLower(username); username != "" && !seen[key] {
+			seen[key] = true
+			usernames = append(usernames, username)
+			if len(usernames) == MaxPerContent {
+				break
+			}
+		}
+
+		prev = '@'
+		if end > i+size {
+			prev, _ = utf8.DecodeLastRuneInString(content[:end])
+		}
+		i = end
+	}
+
+	return usernames
+}
+
+func isWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
@@ -0,0 +1,85 @@
+-- ========================================
+-- Comment Service Schema (Standalone)
+-- ========================================
+
+-- Enable UUID extension
+CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
+
+-- ========================================
+-- Comments Table
+-- ========================================
+CREATE TABLE IF NOT EXISTS comment_service_comments (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    post_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    content TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    -- Set on replies; deleting a comment deletes its replies
+    parent_comment_id UUID REFERENCES comment_service_comments(id) ON DELETE CASCADE,
+    replies_count INTEGER NOT NULL DEFAULT 0,
+    CONSTRAINT check_content_not_empty CHECK (length(trim(content)) > 0),
+    CONSTRAINT check_replies_count_non_negative CHECK (replies_count >= 0)
+);
+
+-- ========================================
+-- Mentions Table
+-- ========================================
+-- Users mentioned in a comment, resolved through user-service when the
+-- comment is written. username keeps the name the content refers to.
+CREATE TABLE IF NOT EXISTS comment_service_comment_mentions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    comment_id UUID NOT NULL REFERENCES comment_service_comments(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL,
+    username VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT comment_mentions_unique_comment_user UNIQUE (comment_id, user_id)
+);
+
+-- ========================================
+-- Outbox Table
+-- ========================================
+-- Events waiting to be relayed to JetStream. They are written in the
+-- transaction of the change they describe and deleted once the stream
+-- acknowledges them; event_id lets the stream discard resent copies.
+CREATE TABLE IF NOT EXISTS comment_service_outbox (
+    id BIGSERIAL PRIMARY KEY,
+    event_id UUID NOT NULL UNIQUE,
+    subject VARCHAR(255) NOT NULL,
+    payload BYTEA NOT NULL,
+    headers JSONB NOT NULL DEFAULT '{}',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- ========================================
+-- Indexes for Performance
+-- ========================================
+CREATE INDEX IF NOT EXISTS idx_comment_service_comments_post_id ON comment_service_comments(post_id);
+CREATE INDEX IF NOT EXISTS idx_comment_service_comments_user_id ON comment_service_comments(user_id);
+CREATE INDEX IF NOT EXISTS idx_comment_service_comments_created_at ON comment_service_comments(created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_comment_service_comments_post_created ON comment_service_comments(post_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_comment_service_comment_mentions_user_id ON comment_service_comment_mentions(user_id);
+
+-- Replies are paged oldest first by (created_at, id)
+CREATE INDEX IF NOT EXISTS idx_comment_service_comments_parent_created ON comment_service_comments(parent_comment_id, created_at, id);
+
+-- ========================================
+-- Function: Update 'updated_at' Column
+-- ========================================
+CREATE OR REPLACE FUNCTION update_updated_at_column()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.updated_at = NOW();
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+-- ========================================
+-- Trigger: Automatically Update 'updated_at'
+-- ========================================
+CREATE OR REPLACE TRIGGER trigger_update_comments_updated_at
+BEFORE UPDATE ON comment_service_comments
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,10 @@
+-- ========================================
+-- Soft Deletes
+-- ========================================
+-- Deleted comments keep their row as a tombstone until the purge job removes
+-- it, so a deleted comment that has replies shows as a "comment deleted"
+-- placeholder above them. Reads skip rows with deleted_at set.
+ALTER TABLE comment_service_comments ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITH TIME ZONE;
+
+-- The purge job scans tombstones by deletion time
+CREATE INDEX IF NOT EXISTS idx_comment_service_comments_deleted_at ON comment_service_comments(deleted_at) WHERE deleted_at IS NOT NULL;
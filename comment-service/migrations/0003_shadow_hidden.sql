@@ -0,0 +1,5 @@
+-- ========================================
+-- Shadow-hidden Comments
+-- ========================================
+-- Comments a content filter shadow-hides are shown only to their author
+ALTER TABLE comment_service_comments ADD COLUMN IF NOT EXISTS shadow_hidden_at TIMESTAMP WITH TIME ZONE;
@@ -6,13 +6,33 @@ import (
 	"github.com/google/uuid"
 )
 
+// Comment is a comment on a post, or a reply to another comment when
+// ParentCommentID is set
 type Comment struct {
+	ID              uuid.UUID  `json:"id" db:"id"`
+	PostID          uuid.UUID  `json:"post_id" db:"post_id"`
+	UserID          uuid.UUID  `json:"user_id" db:"user_id"`
+	ParentCommentID *uuid.UUID `json:"parent_comment_id,omitempty" db:"parent_comment_id"`
+	Content         string     `json:"content" db:"content"`
+	CreatedAt       time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at" db:"updated_at"`
+	RepliesCount    int32      `json:"replies_count" db:"replies_count"`
+	// DeletedAt is set on tombstones of deleted comments, which are only read
+	// as "comment deleted" placeholders above their replies
+	DeletedAt *time.Time `json:"deleted_at,omitempty" db:"deleted_at"`
+	// ShadowHiddenAt is set on comments a content filter shadow-hid, which
+	// only their author sees and which are never announced
+	ShadowHiddenAt *time.Time `json:"shadow_hidden_at,omitempty" db:"shadow_hidden_at"`
+	Mentions       []Mention  `json:"mentions,omitempty" db:"-"`
+}
+
+// Mention is a user mentioned in a comment, with the username the content
+// refers to
+type Mention struct {
 	ID        uuid.UUID `json:"id" db:"id"`
-	PostID    uuid.UUID `json:"post_id" db:"post_id"`
+	CommentID uuid.UUID `json:"comment_id" db:"comment_id"`
 	UserID    uuid.UUID `json:"user_id" db:"user_id"`
-	Content   string    `json:"content" db:"content"`
-	CreatedAt time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+	Username  string    `json:"username" db:"username"`
 }
 
 type CommentEdge struct {
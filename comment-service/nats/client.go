@@ -1,10 +1,18 @@
 package nats
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"log"
 	"time"
 
 	"github.com/nats-io/nats.go"
+
+	"shared/chaos"
+	"shared/logging"
+	"shared/tracing"
 )
 
 type Config struct {
@@ -12,10 +20,13 @@ type Config struct {
 	MaxReconnects int
 	ReconnectWait time.Duration
 	ClientID      string
+	Chaos         *chaos.Injector
 }
 
 type Client struct {
-	conn *nats.Conn
+	conn  *nats.Conn
+	js    nats.JetStreamContext
+	chaos *chaos.Injector
 }
 
 func NewClient(cfg Config) (*Client, error) {
@@ -37,11 +48,92 @@ func NewClient(cfg Config) (*Client, error) {
 		return nil, err
 	}
 
-	return &Client{conn: conn}, nil
+	js, err := conn.JetStream()
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to create JetStream context: %w", err)
+	}
+
+	return &Client{conn: conn, js: js, chaos: cfg.Chaos}, nil
 }
 
-func (c *Client) Publish(subject string, data []byte) error {
-	return c.conn.Publish(subject, data)
+// Publish sends data on subject, carrying the trace of ctx in its headers. A
+// message dropped by chaos injection is reported as sent, as a message lost
+// in transit would be.
+func (c *Client) Publish(ctx context.Context, subject string, data []byte) error {
+	if c.chaos.Drop(subject) {
+		return nil
+	}
+
+	msg := &nats.Msg{Subject: subject, Data: data, Header: nats.Header{}}
+	logging.Inject(ctx, msg.Header)
+	span := tracing.StartPublish(ctx, subject, msg.Header)
+	defer span.End()
+
+	return c.conn.PublishMsg(msg)
+}
+
+// PublishDurable sends msg to JetStream and waits until a stream has stored
+// it. msgID lets the stream discard a copy sent again after a lost
+// acknowledgement. A message dropped by chaos injection is reported as not
+// acknowledged, so it is sent again like any other lost message.
+func (c *Client) PublishDurable(ctx context.Context, msg *nats.Msg, msgID string) error {
+	if c.chaos.Drop(msg.Subject) {
+		return errors.New("message dropped by chaos injection")
+	}
+	_, err := c.js.PublishMsg(msg, nats.MsgId(msgID), nats.Context(ctx))
+	return err
+}
+
+// EnsureStream creates a stream retaining messages on subjects for maxAge,
+// or updates an existing stream of that name to match
+func (c *Client) EnsureStream(streamName string, subjects []string, maxAge time.Duration) error {
+	cfg := &nats.StreamConfig{
+		Name:      streamName,
+		Subjects:  subjects,
+		Storage:   nats.FileStorage,
+		MaxAge:    maxAge,
+		Retention: nats.LimitsPolicy,
+	}
+
+	_, err := c.js.AddStream(cfg)
+	if errors.Is(err, nats.ErrStreamNameAlreadyInUse) {
+		_, err = c.js.UpdateStream(cfg)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to ensure stream %s: %w", streamName, err)
+	}
+
+	log.Printf("Stream ready: %s (retention %s)", streamName, maxAge)
+	return nil
+}
+
+// SubscribeDurable consumes subject from its JetStream stream through the
+// durable consumer durableName, shared by the members of queueGroup. Messages
+// are redelivered until acknowledged, up to 3 times. A message dropped by
+// chaos injection is not acknowledged, so it is redelivered after AckWait.
+func (c *Client) SubscribeDurable(subject, durableName, queueGroup string, handler nats.MsgHandler) (*nats.Subscription, error) {
+	sub, err := c.js.QueueSubscribe(
+		subject,
+		queueGroup,
+		func(msg *nats.Msg) {
+			if c.chaos.Drop(msg.Subject) {
+				return
+			}
+			handler(msg)
+		},
+		nats.Durable(durableName),
+		nats.ManualAck(),
+		nats.AckExplicit(),
+		nats.MaxDeliver(3),
+		nats.AckWait(30*time.Second),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create durable subscription to %s: %w", subject, err)
+	}
+
+	log.Printf("Durable subscription created: %s (durable: %s, queue: %s)", subject, durableName, queueGroup)
+	return sub, nil
 }
 
 func (c *Client) Subscribe(subject string, handler nats.MsgHandler) (*nats.Subscription, error) {
@@ -53,3 +145,31 @@ func (c *Client) Close() {
 		c.conn.Close()
 	}
 }
+
+// Drain stops delivering messages to subscriptions, waits for the handlers
+// still running and for buffered publishes to be sent, and then closes the
+// connection. It closes the connection at once when ctx is done first.
+func (c *Client) Drain(ctx context.Context) error {
+	if err := c.conn.Drain(); err != nil {
+		c.conn.Close()
+		return err
+	}
+	for !c.conn.IsClosed() {
+		select {
+		case <-ctx.Done():
+			c.conn.Close()
+			return ctx.Err()
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+	return nil
+}
+
+// HealthCheck makes a round trip to the NATS server
+func (c *Client) HealthCheck(ctx context.Context) error {
+	return c.conn.FlushWithContext(ctx)
+}
+
+func DecodeEvent(msg *nats.Msg, v interface{}) error {
+	return json.Unmarshal(msg.Data, v)
+}
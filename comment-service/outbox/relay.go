@@ -0,0 +1,216 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/nats-io/nats.go"
+
+	database "comment-service/db"
+	natsClient "comment-service/nats"
+)
+
+const (
+	// pollInterval is how often the outbox is checked for new events
+	pollInterval = 500 * time.Millisecond
+	// batchSize bounds the events sent in one transaction
+	batchSize = 100
+	// publishTimeout bounds the wait for JetStream to acknowledge an event
+	publishTimeout = 5 * time.Second
+	// retryMax caps the backoff after a failed publish
+	retryMax = 30 * time.Second
+	// maxAttempts is how many times an event is published before it is
+	// dead-lettered, so it stops holding back the events stored after it
+	maxAttempts = 10
+)
+
+const (
+	// DeadLetterStream retains events that could not be published, on their
+	// subject prefixed with "deadletter.", for DeadLetterRetention
+	DeadLetterStream    = "DEAD_LETTERS"
+	DeadLetterSubjects  = "deadletter.>"
+	DeadLetterRetention = 30 * 24 * time.Hour
+
+	deadLetterPrefix = "deadletter."
+	// Headers of a dead letter, besides those of the event
+	deadLetterSubjectHeader  = "x-dead-letter-subject"
+	deadLetterErrorHeader    = "x-dead-letter-error"
+	deadLetterAttemptsHeader = "x-dead-letter-attempts"
+)
+
+// relayLockID is the advisory lock held by the relay sending a batch, so
+// replicas take turns and events leave in the order they were stored
+const relayLockID = 0x636f6d6d5f6f7574 // "comm_out"
+
+// RelayStats counts the events a relay published, its failed attempts and
+// the events it gave up on
+type RelayStats struct {
+	Published    int64
+	Failed       int64
+	DeadLettered int64
+}
+
+// Relay sends committed events from the outbox to JetStream, oldest first
+type Relay struct {
+	db   *database.DB
+	nats *natsClient.Client
+
+	published    atomic.Int64
+	failed       atomic.Int64
+	deadLettered atomic.Int64
+}
+
+func NewRelay(db *database.DB, nats *natsClient.Client) *Relay {
+	return &Relay{db: db, nats: nats}
+}
+
+// Run relays events until ctx is cancelled. An event that fails to publish
+// holds back the ones stored after it, so consumers see them in order; it is
+// tried again with backoff, and after maxAttempts sent to DeadLetterStream
+// instead. An event that cannot be dead-lettered either, e.g. while JetStream
+// is down, keeps being tried.
+func (r *Relay) Run(ctx context.Context) {
+	wait := pollInterval
+	for {
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return
+		}
+
+		sent, err := r.relayBatch(ctx)
+		switch {
+		case err != nil:
+			if ctx.Err() != nil {
+				return
+			}
+			log.Printf("Failed to relay outbox events: %v", err)
+			wait = min(2*max(wait, pollInterval), retryMax)
+		case sent == batchSize:
+			// More are waiting
+			wait = 0
+		default:
+			wait = pollInterval
+		}
+	}
+}
+
+type outboxEvent struct {
+	ID       int64  `db:"id"`
+	EventID  string `db:"event_id"`
+	Subject  string `db:"subject"`
+	Payload  []byte `db:"payload"`
+	Headers  []byte `db:"headers"`
+	Attempts int    `db:"attempts"`
+}
+
+// relayBatch publishes the oldest events and removes those JetStream
+// acknowledged or that were dead-lettered, returning how many were removed
+func (r *Relay) relayBatch(ctx context.Context) (int, error) {
+	sent := 0
+	var publishErr error
+	err := r.db.WithTransaction(ctx, func(tx *sqlx.Tx) error {
+		var locked bool
+		if err := tx.GetContext(ctx, &locked, `SELECT pg_try_advisory_xact_lock($1)`, relayLockID); err != nil {
+			return err
+		}
+		if !locked {
+			// Another replica is relaying
+			return nil
+		}
+
+		var batch []outboxEvent
+		query := `
+			SELECT id, event_id, subject, payload, headers, attempts
+			FROM comment_service_outbox
+			ORDER BY id
+			LIMIT $1
+		`
+		if err := tx.SelectContext(ctx, &batch, query, batchSize); err != nil {
+			return err
+		}
+
+		for _, event := range batch {
+			if publishErr = r.publish(ctx, event); publishErr != nil {
+				r.failed.Add(1)
+				if event.Attempts+1 >= maxAttempts {
+					deadLetterErr := r.deadLetter(ctx, event, publishErr)
+					if deadLetterErr == nil {
+						log.Printf("Dead-lettered event %d on %s after %d attempts: %v", event.ID, event.Subject, event.Attempts+1, publishErr)
+						if _, err := tx.ExecContext(ctx, `DELETE FROM comment_service_outbox WHERE id = $1`, event.ID); err != nil {
+							return err
+						}
+						r.deadLettered.Add(1)
+						publishErr = nil
+						sent++
+						continue
+					}
+					publishErr = fmt.Errorf("%w, and dead-lettering failed: %v", publishErr, deadLetterErr)
+				}
+				_, err := tx.ExecContext(ctx,
+					`UPDATE comment_service_outbox SET attempts = attempts + 1, last_error = $2 WHERE id = $1`,
+					event.ID, publishErr.Error(),
+				)
+				if err != nil {
+					return err
+				}
+				publishErr = fmt.Errorf("event %d on %s, attempt %d: %w", event.ID, event.Subject, event.Attempts+1, publishErr)
+				break
+			}
+			if _, err := tx.ExecContext(ctx, `DELETE FROM comment_service_outbox WHERE id = $1`, event.ID); err != nil {
+				return err
+			}
+			r.published.Add(1)
+			sent++
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return sent, publishErr
+}
+
+func (r *Relay) publish(ctx context.Context, event outboxEvent) error {
+	msg := &nats.Msg{Subject: event.Subject, Data: event.Payload, Header: nats.Header{}}
+	if err := json.Unmarshal(event.Headers, &msg.Header); err != nil {
+		return fmt.Errorf("invalid headers: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, publishTimeout)
+	defer cancel()
+	return r.nats.PublishDurable(ctx, msg, event.EventID)
+}
+
+// deadLetter sends an event that could not be published to DeadLetterStream,
+// with the subject it was meant for and why it failed
+func (r *Relay) deadLetter(ctx context.Context, event outboxEvent, cause error) error {
+	msg := &nats.Msg{Subject: deadLetterPrefix + event.Subject, Data: event.Payload}
+	// Headers that failed to decode are dropped; the error says so
+	_ = json.Unmarshal(event.Headers, &msg.Header)
+	if msg.Header == nil {
+		msg.Header = nats.Header{}
+	}
+	msg.Header.Set(deadLetterSubjectHeader, event.Subject)
+	msg.Header.Set(deadLetterErrorHeader, cause.Error())
+	msg.Header.Set(deadLetterAttemptsHeader, strconv.Itoa(event.Attempts+1))
+
+	ctx, cancel := context.WithTimeout(ctx, publishTimeout)
+	defer cancel()
+	return r.nats.PublishDurable(ctx, msg, event.EventID)
+}
+
+// Stats returns the relay's counts since start
+func (r *Relay) Stats() RelayStats {
+	return RelayStats{
+		Published:    r.published.Load(),
+		Failed:       r.failed.Load(),
+		DeadLettered: r.deadLettered.Load(),
+	}
+}
@@ -23,12 +23,13 @@ const (
 )
 
 type CreateCommentRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	PostId        string                 `protobuf:"bytes,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
-	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Content       string                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	PostId          string                 `protobuf:"bytes,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
+	UserId          string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Content         string                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	ParentCommentId *string                `protobuf:"bytes,4,opt,name=parent_comment_id,json=parentCommentId,proto3,oneof" json:"parent_comment_id,omitempty"` // Set to reply to a comment on the same post
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *CreateCommentRequest) Reset() {
@@ -82,13 +83,23 @@ func (x *CreateCommentRequest) GetContent() string {
 	return ""
 }
 
+func (x *CreateCommentRequest) GetParentCommentId() string {
+	if x != nil && x.ParentCommentId != nil {
+		return *x.ParentCommentId
+	}
+	return ""
+}
+
 type GetPostCommentsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	PostId        string                 `protobuf:"bytes,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
-	First         int32                  `protobuf:"varint,2,opt,name=first,proto3" json:"first,omitempty"`
-	After         *string                `protobuf:"bytes,3,opt,name=after,proto3,oneof" json:"after,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	PostId string                 `protobuf:"bytes,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
+	First  int32                  `protobuf:"varint,2,opt,name=first,proto3" json:"first,omitempty"`
+	After  *string                `protobuf:"bytes,3,opt,name=after,proto3,oneof" json:"after,omitempty"`
+	// Ignored: the viewer is the user of the request's token, if any, who sees
+	// their own shadow-hidden comments
+	RequestingUserId *string `protobuf:"bytes,4,opt,name=requesting_user_id,json=requestingUserId,proto3,oneof" json:"requesting_user_id,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *GetPostCommentsRequest) Reset() {
@@ -142,6 +153,83 @@ func (x *GetPostCommentsRequest) GetAfter() string {
 	return ""
 }
 
+func (x *GetPostCommentsRequest) GetRequestingUserId() string {
+	if x != nil && x.RequestingUserId != nil {
+		return *x.RequestingUserId
+	}
+	return ""
+}
+
+type GetCommentRepliesRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	CommentId string                 `protobuf:"bytes,1,opt,name=comment_id,json=commentId,proto3" json:"comment_id,omitempty"`
+	First     int32                  `protobuf:"varint,2,opt,name=first,proto3" json:"first,omitempty"`
+	After     *string                `protobuf:"bytes,3,opt,name=after,proto3,oneof" json:"after,omitempty"`
+	// Ignored: the viewer is the user of the request's token, if any, who sees
+	// their own shadow-hidden replies
+	RequestingUserId *string `protobuf:"bytes,4,opt,name=requesting_user_id,json=requestingUserId,proto3,oneof" json:"requesting_user_id,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *GetCommentRepliesRequest) Reset() {
+	*x = GetCommentRepliesRequest{}
+	mi := &file_proto_comment_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCommentRepliesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCommentRepliesRequest) ProtoMessage() {}
+
+func (x *GetCommentRepliesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_comment_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCommentRepliesRequest.ProtoReflect.Descriptor instead.
+func (*GetCommentRepliesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_comment_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetCommentRepliesRequest) GetCommentId() string {
+	if x != nil {
+		return x.CommentId
+	}
+	return ""
+}
+
+func (x *GetCommentRepliesRequest) GetFirst() int32 {
+	if x != nil {
+		return x.First
+	}
+	return 0
+}
+
+func (x *GetCommentRepliesRequest) GetAfter() string {
+	if x != nil && x.After != nil {
+		return *x.After
+	}
+	return ""
+}
+
+func (x *GetCommentRepliesRequest) GetRequestingUserId() string {
+	if x != nil && x.RequestingUserId != nil {
+		return *x.RequestingUserId
+	}
+	return ""
+}
+
 type UpdateCommentRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	CommentId     string                 `protobuf:"bytes,1,opt,name=comment_id,json=commentId,proto3" json:"comment_id,omitempty"`
@@ -153,7 +241,7 @@ type UpdateCommentRequest struct {
 
 func (x *UpdateCommentRequest) Reset() {
 	*x = UpdateCommentRequest{}
-	mi := &file_proto_comment_proto_msgTypes[2]
+	mi := &file_proto_comment_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -165,7 +253,7 @@ func (x *UpdateCommentRequest) String() string {
 func (*UpdateCommentRequest) ProtoMessage() {}
 
 func (x *UpdateCommentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_comment_proto_msgTypes[2]
+	mi := &file_proto_comment_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -178,7 +266,7 @@ func (x *UpdateCommentRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateCommentRequest.ProtoReflect.Descriptor instead.
 func (*UpdateCommentRequest) Descriptor() ([]byte, []int) {
-	return file_proto_comment_proto_rawDescGZIP(), []int{2}
+	return file_proto_comment_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *UpdateCommentRequest) GetCommentId() string {
@@ -212,7 +300,7 @@ type DeleteCommentRequest struct {
 
 func (x *DeleteCommentRequest) Reset() {
 	*x = DeleteCommentRequest{}
-	mi := &file_proto_comment_proto_msgTypes[3]
+	mi := &file_proto_comment_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -224,7 +312,7 @@ func (x *DeleteCommentRequest) String() string {
 func (*DeleteCommentRequest) ProtoMessage() {}
 
 func (x *DeleteCommentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_comment_proto_msgTypes[3]
+	mi := &file_proto_comment_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -237,7 +325,7 @@ func (x *DeleteCommentRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteCommentRequest.ProtoReflect.Descriptor instead.
 func (*DeleteCommentRequest) Descriptor() ([]byte, []int) {
-	return file_proto_comment_proto_rawDescGZIP(), []int{3}
+	return file_proto_comment_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *DeleteCommentRequest) GetCommentId() string {
@@ -254,21 +342,297 @@ func (x *DeleteCommentRequest) GetUserId() string {
 	return ""
 }
 
-type Comment struct {
+type GetCommentsCountsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PostIds       []string               `protobuf:"bytes,1,rep,name=post_ids,json=postIds,proto3" json:"post_ids,omitempty"` // At most 1000
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCommentsCountsRequest) Reset() {
+	*x = GetCommentsCountsRequest{}
+	mi := &file_proto_comment_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCommentsCountsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCommentsCountsRequest) ProtoMessage() {}
+
+func (x *GetCommentsCountsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_comment_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCommentsCountsRequest.ProtoReflect.Descriptor instead.
+func (*GetCommentsCountsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_comment_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetCommentsCountsRequest) GetPostIds() []string {
+	if x != nil {
+		return x.PostIds
+	}
+	return nil
+}
+
+type PostCommentsCount struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PostId        string                 `protobuf:"bytes,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
+	Count         int32                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"` // Comments and replies, not deleted or shadow-hidden
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PostCommentsCount) Reset() {
+	*x = PostCommentsCount{}
+	mi := &file_proto_comment_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PostCommentsCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostCommentsCount) ProtoMessage() {}
+
+func (x *PostCommentsCount) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_comment_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostCommentsCount.ProtoReflect.Descriptor instead.
+func (*PostCommentsCount) Descriptor() ([]byte, []int) {
+	return file_proto_comment_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *PostCommentsCount) GetPostId() string {
+	if x != nil {
+		return x.PostId
+	}
+	return ""
+}
+
+func (x *PostCommentsCount) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type GetCommentsCountsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Counts        []*PostCommentsCount   `protobuf:"bytes,1,rep,name=counts,proto3" json:"counts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCommentsCountsResponse) Reset() {
+	*x = GetCommentsCountsResponse{}
+	mi := &file_proto_comment_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCommentsCountsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCommentsCountsResponse) ProtoMessage() {}
+
+func (x *GetCommentsCountsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_comment_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCommentsCountsResponse.ProtoReflect.Descriptor instead.
+func (*GetCommentsCountsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_comment_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetCommentsCountsResponse) GetCounts() []*PostCommentsCount {
+	if x != nil {
+		return x.Counts
+	}
+	return nil
+}
+
+type PurgeDeletedCommentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeletedBefore *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=deleted_before,json=deletedBefore,proto3" json:"deleted_before,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PurgeDeletedCommentsRequest) Reset() {
+	*x = PurgeDeletedCommentsRequest{}
+	mi := &file_proto_comment_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurgeDeletedCommentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgeDeletedCommentsRequest) ProtoMessage() {}
+
+func (x *PurgeDeletedCommentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_comment_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgeDeletedCommentsRequest.ProtoReflect.Descriptor instead.
+func (*PurgeDeletedCommentsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_comment_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *PurgeDeletedCommentsRequest) GetDeletedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeletedBefore
+	}
+	return nil
+}
+
+type PurgeDeletedCommentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Purged        int64                  `protobuf:"varint,1,opt,name=purged,proto3" json:"purged,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PurgeDeletedCommentsResponse) Reset() {
+	*x = PurgeDeletedCommentsResponse{}
+	mi := &file_proto_comment_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurgeDeletedCommentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgeDeletedCommentsResponse) ProtoMessage() {}
+
+func (x *PurgeDeletedCommentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_comment_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgeDeletedCommentsResponse.ProtoReflect.Descriptor instead.
+func (*PurgeDeletedCommentsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_comment_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *PurgeDeletedCommentsResponse) GetPurged() int64 {
+	if x != nil {
+		return x.Purged
+	}
+	return 0
+}
+
+type RemoveCommentRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	PostId        string                 `protobuf:"bytes,2,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
-	UserId        string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Content       string                 `protobuf:"bytes,4,opt,name=content,proto3" json:"content,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	CommentId     string                 `protobuf:"bytes,1,opt,name=comment_id,json=commentId,proto3" json:"comment_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
+func (x *RemoveCommentRequest) Reset() {
+	*x = RemoveCommentRequest{}
+	mi := &file_proto_comment_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveCommentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveCommentRequest) ProtoMessage() {}
+
+func (x *RemoveCommentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_comment_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveCommentRequest.ProtoReflect.Descriptor instead.
+func (*RemoveCommentRequest) Descriptor() ([]byte, []int) {
+	return file_proto_comment_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *RemoveCommentRequest) GetCommentId() string {
+	if x != nil {
+		return x.CommentId
+	}
+	return ""
+}
+
+type Comment struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	PostId          string                 `protobuf:"bytes,2,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
+	UserId          string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Content         string                 `protobuf:"bytes,4,opt,name=content,proto3" json:"content,omitempty"`
+	CreatedAt       *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt       *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Mentions        []*Mention             `protobuf:"bytes,7,rep,name=mentions,proto3" json:"mentions,omitempty"`
+	ParentCommentId *string                `protobuf:"bytes,8,opt,name=parent_comment_id,json=parentCommentId,proto3,oneof" json:"parent_comment_id,omitempty"`
+	RepliesCount    int32                  `protobuf:"varint,9,opt,name=replies_count,json=repliesCount,proto3" json:"replies_count,omitempty"`
+	DeletedAt       *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"` // Set only on "comment deleted" placeholders, which carry no content
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
 func (x *Comment) Reset() {
 	*x = Comment{}
-	mi := &file_proto_comment_proto_msgTypes[4]
+	mi := &file_proto_comment_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -280,7 +644,7 @@ func (x *Comment) String() string {
 func (*Comment) ProtoMessage() {}
 
 func (x *Comment) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_comment_proto_msgTypes[4]
+	mi := &file_proto_comment_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -293,7 +657,7 @@ func (x *Comment) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Comment.ProtoReflect.Descriptor instead.
 func (*Comment) Descriptor() ([]byte, []int) {
-	return file_proto_comment_proto_rawDescGZIP(), []int{4}
+	return file_proto_comment_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *Comment) GetId() string {
@@ -338,6 +702,86 @@ func (x *Comment) GetUpdatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *Comment) GetMentions() []*Mention {
+	if x != nil {
+		return x.Mentions
+	}
+	return nil
+}
+
+func (x *Comment) GetParentCommentId() string {
+	if x != nil && x.ParentCommentId != nil {
+		return *x.ParentCommentId
+	}
+	return ""
+}
+
+func (x *Comment) GetRepliesCount() int32 {
+	if x != nil {
+		return x.RepliesCount
+	}
+	return 0
+}
+
+func (x *Comment) GetDeletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return nil
+}
+
+type Mention struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"` // As written in the content
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Mention) Reset() {
+	*x = Mention{}
+	mi := &file_proto_comment_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Mention) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Mention) ProtoMessage() {}
+
+func (x *Mention) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_comment_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Mention.ProtoReflect.Descriptor instead.
+func (*Mention) Descriptor() ([]byte, []int) {
+	return file_proto_comment_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *Mention) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Mention) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
 type CommentEdge struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Cursor        string                 `protobuf:"bytes,1,opt,name=cursor,proto3" json:"cursor,omitempty"`
@@ -348,7 +792,7 @@ type CommentEdge struct {
 
 func (x *CommentEdge) Reset() {
 	*x = CommentEdge{}
-	mi := &file_proto_comment_proto_msgTypes[5]
+	mi := &file_proto_comment_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -360,7 +804,7 @@ func (x *CommentEdge) String() string {
 func (*CommentEdge) ProtoMessage() {}
 
 func (x *CommentEdge) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_comment_proto_msgTypes[5]
+	mi := &file_proto_comment_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -373,7 +817,7 @@ func (x *CommentEdge) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CommentEdge.ProtoReflect.Descriptor instead.
 func (*CommentEdge) Descriptor() ([]byte, []int) {
-	return file_proto_comment_proto_rawDescGZIP(), []int{5}
+	return file_proto_comment_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *CommentEdge) GetCursor() string {
@@ -402,7 +846,7 @@ type PageInfo struct {
 
 func (x *PageInfo) Reset() {
 	*x = PageInfo{}
-	mi := &file_proto_comment_proto_msgTypes[6]
+	mi := &file_proto_comment_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -414,7 +858,7 @@ func (x *PageInfo) String() string {
 func (*PageInfo) ProtoMessage() {}
 
 func (x *PageInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_comment_proto_msgTypes[6]
+	mi := &file_proto_comment_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -427,7 +871,7 @@ func (x *PageInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PageInfo.ProtoReflect.Descriptor instead.
 func (*PageInfo) Descriptor() ([]byte, []int) {
-	return file_proto_comment_proto_rawDescGZIP(), []int{6}
+	return file_proto_comment_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *PageInfo) GetEndCursor() string {
@@ -469,7 +913,7 @@ type CommentConnection struct {
 
 func (x *CommentConnection) Reset() {
 	*x = CommentConnection{}
-	mi := &file_proto_comment_proto_msgTypes[7]
+	mi := &file_proto_comment_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -481,7 +925,7 @@ func (x *CommentConnection) String() string {
 func (*CommentConnection) ProtoMessage() {}
 
 func (x *CommentConnection) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_comment_proto_msgTypes[7]
+	mi := &file_proto_comment_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -494,7 +938,7 @@ func (x *CommentConnection) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CommentConnection.ProtoReflect.Descriptor instead.
 func (*CommentConnection) Descriptor() ([]byte, []int) {
-	return file_proto_comment_proto_rawDescGZIP(), []int{7}
+	return file_proto_comment_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *CommentConnection) GetEdges() []*CommentEdge {
@@ -528,7 +972,7 @@ type Response struct {
 
 func (x *Response) Reset() {
 	*x = Response{}
-	mi := &file_proto_comment_proto_msgTypes[8]
+	mi := &file_proto_comment_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -540,7 +984,7 @@ func (x *Response) String() string {
 func (*Response) ProtoMessage() {}
 
 func (x *Response) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_comment_proto_msgTypes[8]
+	mi := &file_proto_comment_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -553,7 +997,7 @@ func (x *Response) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Response.ProtoReflect.Descriptor instead.
 func (*Response) Descriptor() ([]byte, []int) {
-	return file_proto_comment_proto_rawDescGZIP(), []int{8}
+	return file_proto_comment_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *Response) GetSuccess() bool {
@@ -570,20 +1014,113 @@ func (x *Response) GetMessage() string {
 	return ""
 }
 
+// ExportMyData exports the data the service holds on the caller
+type ExportMyDataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportMyDataRequest) Reset() {
+	*x = ExportMyDataRequest{}
+	mi := &file_proto_comment_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportMyDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportMyDataRequest) ProtoMessage() {}
+
+func (x *ExportMyDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_comment_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportMyDataRequest.ProtoReflect.Descriptor instead.
+func (*ExportMyDataRequest) Descriptor() ([]byte, []int) {
+	return file_proto_comment_proto_rawDescGZIP(), []int{17}
+}
+
+type DataExport struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"` // JSON document
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DataExport) Reset() {
+	*x = DataExport{}
+	mi := &file_proto_comment_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DataExport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DataExport) ProtoMessage() {}
+
+func (x *DataExport) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_comment_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DataExport.ProtoReflect.Descriptor instead.
+func (*DataExport) Descriptor() ([]byte, []int) {
+	return file_proto_comment_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *DataExport) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
 var File_proto_comment_proto protoreflect.FileDescriptor
 
 const file_proto_comment_proto_rawDesc = "" +
 	"\n" +
-	"\x13proto/comment.proto\x12\acomment\x1a\x1fgoogle/protobuf/timestamp.proto\"b\n" +
+	"\x13proto/comment.proto\x12\acomment\x1a\x1fgoogle/protobuf/timestamp.proto\"\xa9\x01\n" +
 	"\x14CreateCommentRequest\x12\x17\n" +
 	"\apost_id\x18\x01 \x01(\tR\x06postId\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x18\n" +
-	"\acontent\x18\x03 \x01(\tR\acontent\"l\n" +
+	"\acontent\x18\x03 \x01(\tR\acontent\x12/\n" +
+	"\x11parent_comment_id\x18\x04 \x01(\tH\x00R\x0fparentCommentId\x88\x01\x01B\x14\n" +
+	"\x12_parent_comment_id\"\xb6\x01\n" +
 	"\x16GetPostCommentsRequest\x12\x17\n" +
 	"\apost_id\x18\x01 \x01(\tR\x06postId\x12\x14\n" +
 	"\x05first\x18\x02 \x01(\x05R\x05first\x12\x19\n" +
-	"\x05after\x18\x03 \x01(\tH\x00R\x05after\x88\x01\x01B\b\n" +
-	"\x06_after\"h\n" +
+	"\x05after\x18\x03 \x01(\tH\x00R\x05after\x88\x01\x01\x121\n" +
+	"\x12requesting_user_id\x18\x04 \x01(\tH\x01R\x10requestingUserId\x88\x01\x01B\b\n" +
+	"\x06_afterB\x15\n" +
+	"\x13_requesting_user_id\"\xbe\x01\n" +
+	"\x18GetCommentRepliesRequest\x12\x1d\n" +
+	"\n" +
+	"comment_id\x18\x01 \x01(\tR\tcommentId\x12\x14\n" +
+	"\x05first\x18\x02 \x01(\x05R\x05first\x12\x19\n" +
+	"\x05after\x18\x03 \x01(\tH\x00R\x05after\x88\x01\x01\x121\n" +
+	"\x12requesting_user_id\x18\x04 \x01(\tH\x01R\x10requestingUserId\x88\x01\x01B\b\n" +
+	"\x06_afterB\x15\n" +
+	"\x13_requesting_user_id\"h\n" +
 	"\x14UpdateCommentRequest\x12\x1d\n" +
 	"\n" +
 	"comment_id\x18\x01 \x01(\tR\tcommentId\x12\x17\n" +
@@ -592,7 +1129,21 @@ const file_proto_comment_proto_rawDesc = "" +
 	"\x14DeleteCommentRequest\x12\x1d\n" +
 	"\n" +
 	"comment_id\x18\x01 \x01(\tR\tcommentId\x12\x17\n" +
-	"\auser_id\x18\x02 \x01(\tR\x06userId\"\xdb\x01\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"5\n" +
+	"\x18GetCommentsCountsRequest\x12\x19\n" +
+	"\bpost_ids\x18\x01 \x03(\tR\apostIds\"B\n" +
+	"\x11PostCommentsCount\x12\x17\n" +
+	"\apost_id\x18\x01 \x01(\tR\x06postId\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\"O\n" +
+	"\x19GetCommentsCountsResponse\x122\n" +
+	"\x06counts\x18\x01 \x03(\v2\x1a.comment.PostCommentsCountR\x06counts\"`\n" +
+	"\x1bPurgeDeletedCommentsRequest\x12A\n" +
+	"\x0edeleted_before\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\rdeletedBefore\"6\n" +
+	"\x1cPurgeDeletedCommentsResponse\x12\x16\n" +
+	"\x06purged\x18\x01 \x01(\x03R\x06purged\"5\n" +
+	"\x14RemoveCommentRequest\x12\x1d\n" +
+	"\n" +
+	"comment_id\x18\x01 \x01(\tR\tcommentId\"\xb0\x03\n" +
 	"\aComment\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
 	"\apost_id\x18\x02 \x01(\tR\x06postId\x12\x17\n" +
@@ -601,7 +1152,17 @@ const file_proto_comment_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
-	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"K\n" +
+	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12,\n" +
+	"\bmentions\x18\a \x03(\v2\x10.comment.MentionR\bmentions\x12/\n" +
+	"\x11parent_comment_id\x18\b \x01(\tH\x00R\x0fparentCommentId\x88\x01\x01\x12#\n" +
+	"\rreplies_count\x18\t \x01(\x05R\frepliesCount\x129\n" +
+	"\n" +
+	"deleted_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tdeletedAtB\x14\n" +
+	"\x12_parent_comment_id\">\n" +
+	"\aMention\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\"K\n" +
 	"\vCommentEdge\x12\x16\n" +
 	"\x06cursor\x18\x01 \x01(\tR\x06cursor\x12$\n" +
 	"\x04node\x18\x02 \x01(\v2\x10.comment.CommentR\x04node\"\xc6\x01\n" +
@@ -620,12 +1181,21 @@ const file_proto_comment_proto_rawDesc = "" +
 	"totalCount\">\n" +
 	"\bResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage2\xa7\x02\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\x15\n" +
+	"\x13ExportMyDataRequest\" \n" +
+	"\n" +
+	"DataExport\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data2\xc2\x05\n" +
 	"\x0eCommentService\x12@\n" +
 	"\rCreateComment\x12\x1d.comment.CreateCommentRequest\x1a\x10.comment.Comment\x12N\n" +
-	"\x0fGetPostComments\x12\x1f.comment.GetPostCommentsRequest\x1a\x1a.comment.CommentConnection\x12@\n" +
+	"\x0fGetPostComments\x12\x1f.comment.GetPostCommentsRequest\x1a\x1a.comment.CommentConnection\x12R\n" +
+	"\x11GetCommentReplies\x12!.comment.GetCommentRepliesRequest\x1a\x1a.comment.CommentConnection\x12@\n" +
 	"\rUpdateComment\x12\x1d.comment.UpdateCommentRequest\x1a\x10.comment.Comment\x12A\n" +
-	"\rDeleteComment\x12\x1d.comment.DeleteCommentRequest\x1a\x11.comment.ResponseB\x04Z\x02./b\x06proto3"
+	"\rDeleteComment\x12\x1d.comment.DeleteCommentRequest\x1a\x11.comment.Response\x12A\n" +
+	"\fExportMyData\x12\x1c.comment.ExportMyDataRequest\x1a\x13.comment.DataExport\x12Z\n" +
+	"\x11GetCommentsCounts\x12!.comment.GetCommentsCountsRequest\x1a\".comment.GetCommentsCountsResponse\x12c\n" +
+	"\x14PurgeDeletedComments\x12$.comment.PurgeDeletedCommentsRequest\x1a%.comment.PurgeDeletedCommentsResponse\x12A\n" +
+	"\rRemoveComment\x12\x1d.comment.RemoveCommentRequest\x1a\x11.comment.ResponseB\x04Z\x02./b\x06proto3"
 
 var (
 	file_proto_comment_proto_rawDescOnce sync.Once
@@ -639,38 +1209,62 @@ func file_proto_comment_proto_rawDescGZIP() []byte {
 	return file_proto_comment_proto_rawDescData
 }
 
-var file_proto_comment_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_proto_comment_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
 var file_proto_comment_proto_goTypes = []any{
-	(*CreateCommentRequest)(nil),   // 0: comment.CreateCommentRequest
-	(*GetPostCommentsRequest)(nil), // 1: comment.GetPostCommentsRequest
-	(*UpdateCommentRequest)(nil),   // 2: comment.UpdateCommentRequest
-	(*DeleteCommentRequest)(nil),   // 3: comment.DeleteCommentRequest
-	(*Comment)(nil),                // 4: comment.Comment
-	(*CommentEdge)(nil),            // 5: comment.CommentEdge
-	(*PageInfo)(nil),               // 6: comment.PageInfo
-	(*CommentConnection)(nil),      // 7: comment.CommentConnection
-	(*Response)(nil),               // 8: comment.Response
-	(*timestamppb.Timestamp)(nil),  // 9: google.protobuf.Timestamp
+	(*CreateCommentRequest)(nil),         // 0: comment.CreateCommentRequest
+	(*GetPostCommentsRequest)(nil),       // 1: comment.GetPostCommentsRequest
+	(*GetCommentRepliesRequest)(nil),     // 2: comment.GetCommentRepliesRequest
+	(*UpdateCommentRequest)(nil),         // 3: comment.UpdateCommentRequest
+	(*DeleteCommentRequest)(nil),         // 4: comment.DeleteCommentRequest
+	(*GetCommentsCountsRequest)(nil),     // 5: comment.GetCommentsCountsRequest
+	(*PostCommentsCount)(nil),            // 6: comment.PostCommentsCount
+	(*GetCommentsCountsResponse)(nil),    // 7: comment.GetCommentsCountsResponse
+	(*PurgeDeletedCommentsRequest)(nil),  // 8: comment.PurgeDeletedCommentsRequest
+	(*PurgeDeletedCommentsResponse)(nil), // 9: comment.PurgeDeletedCommentsResponse
+	(*RemoveCommentRequest)(nil),         // 10: comment.RemoveCommentRequest
+	(*Comment)(nil),                      // 11: comment.Comment
+	(*Mention)(nil),                      // 12: comment.Mention
+	(*CommentEdge)(nil),                  // 13: comment.CommentEdge
+	(*PageInfo)(nil),                     // 14: comment.PageInfo
+	(*CommentConnection)(nil),            // 15: comment.CommentConnection
+	(*Response)(nil),                     // 16: comment.Response
+	(*ExportMyDataRequest)(nil),          // 17: comment.ExportMyDataRequest
+	(*DataExport)(nil),                   // 18: comment.DataExport
+	(*timestamppb.Timestamp)(nil),        // 19: google.protobuf.Timestamp
 }
 var file_proto_comment_proto_depIdxs = []int32{
-	9, // 0: comment.Comment.created_at:type_name -> google.protobuf.Timestamp
-	9, // 1: comment.Comment.updated_at:type_name -> google.protobuf.Timestamp
-	4, // 2: comment.CommentEdge.node:type_name -> comment.Comment
-	5, // 3: comment.CommentConnection.edges:type_name -> comment.CommentEdge
-	6, // 4: comment.CommentConnection.page_info:type_name -> comment.PageInfo
-	0, // 5: comment.CommentService.CreateComment:input_type -> comment.CreateCommentRequest
-	1, // 6: comment.CommentService.GetPostComments:input_type -> comment.GetPostCommentsRequest
-	2, // 7: comment.CommentService.UpdateComment:input_type -> comment.UpdateCommentRequest
-	3, // 8: comment.CommentService.DeleteComment:input_type -> comment.DeleteCommentRequest
-	4, // 9: comment.CommentService.CreateComment:output_type -> comment.Comment
-	7, // 10: comment.CommentService.GetPostComments:output_type -> comment.CommentConnection
-	4, // 11: comment.CommentService.UpdateComment:output_type -> comment.Comment
-	8, // 12: comment.CommentService.DeleteComment:output_type -> comment.Response
-	9, // [9:13] is the sub-list for method output_type
-	5, // [5:9] is the sub-list for method input_type
-	5, // [5:5] is the sub-list for extension type_name
-	5, // [5:5] is the sub-list for extension extendee
-	0, // [0:5] is the sub-list for field type_name
+	6,  // 0: comment.GetCommentsCountsResponse.counts:type_name -> comment.PostCommentsCount
+	19, // 1: comment.PurgeDeletedCommentsRequest.deleted_before:type_name -> google.protobuf.Timestamp
+	19, // 2: comment.Comment.created_at:type_name -> google.protobuf.Timestamp
+	19, // 3: comment.Comment.updated_at:type_name -> google.protobuf.Timestamp
+	12, // 4: comment.Comment.mentions:type_name -> comment.Mention
+	19, // 5: comment.Comment.deleted_at:type_name -> google.protobuf.Timestamp
+	11, // 6: comment.CommentEdge.node:type_name -> comment.Comment
+	13, // 7: comment.CommentConnection.edges:type_name -> comment.CommentEdge
+	14, // 8: comment.CommentConnection.page_info:type_name -> comment.PageInfo
+	0,  // 9: comment.CommentService.CreateComment:input_type -> comment.CreateCommentRequest
+	1,  // 10: comment.CommentService.GetPostComments:input_type -> comment.GetPostCommentsRequest
+	2,  // 11: comment.CommentService.GetCommentReplies:input_type -> comment.GetCommentRepliesRequest
+	3,  // 12: comment.CommentService.UpdateComment:input_type -> comment.UpdateCommentRequest
+	4,  // 13: comment.CommentService.DeleteComment:input_type -> comment.DeleteCommentRequest
+	17, // 14: comment.CommentService.ExportMyData:input_type -> comment.ExportMyDataRequest
+	5,  // 15: comment.CommentService.GetCommentsCounts:input_type -> comment.GetCommentsCountsRequest
+	8,  // 16: comment.CommentService.PurgeDeletedComments:input_type -> comment.PurgeDeletedCommentsRequest
+	10, // 17: comment.CommentService.RemoveComment:input_type -> comment.RemoveCommentRequest
+	11, // 18: comment.CommentService.CreateComment:output_type -> comment.Comment
+	15, // 19: comment.CommentService.GetPostComments:output_type -> comment.CommentConnection
+	15, // 20: comment.CommentService.GetCommentReplies:output_type -> comment.CommentConnection
+	11, // 21: comment.CommentService.UpdateComment:output_type -> comment.Comment
+	16, // 22: comment.CommentService.DeleteComment:output_type -> comment.Response
+	18, // 23: comment.CommentService.ExportMyData:output_type -> comment.DataExport
+	7,  // 24: comment.CommentService.GetCommentsCounts:output_type -> comment.GetCommentsCountsResponse
+	9,  // 25: comment.CommentService.PurgeDeletedComments:output_type -> comment.PurgeDeletedCommentsResponse
+	16, // 26: comment.CommentService.RemoveComment:output_type -> comment.Response
+	18, // [18:27] is the sub-list for method output_type
+	9,  // [9:18] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
 }
 
 func init() { file_proto_comment_proto_init() }
@@ -678,15 +1272,18 @@ func file_proto_comment_proto_init() {
 	if File_proto_comment_proto != nil {
 		return
 	}
+	file_proto_comment_proto_msgTypes[0].OneofWrappers = []any{}
 	file_proto_comment_proto_msgTypes[1].OneofWrappers = []any{}
-	file_proto_comment_proto_msgTypes[6].OneofWrappers = []any{}
+	file_proto_comment_proto_msgTypes[2].OneofWrappers = []any{}
+	file_proto_comment_proto_msgTypes[11].OneofWrappers = []any{}
+	file_proto_comment_proto_msgTypes[14].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_comment_proto_rawDesc), len(file_proto_comment_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   9,
+			NumMessages:   19,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
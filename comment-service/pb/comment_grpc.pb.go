@@ -19,10 +19,15 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	CommentService_CreateComment_FullMethodName   = "/comment.CommentService/CreateComment"
-	CommentService_GetPostComments_FullMethodName = "/comment.CommentService/GetPostComments"
-	CommentService_UpdateComment_FullMethodName   = "/comment.CommentService/UpdateComment"
-	CommentService_DeleteComment_FullMethodName   = "/comment.CommentService/DeleteComment"
+	CommentService_CreateComment_FullMethodName        = "/comment.CommentService/CreateComment"
+	CommentService_GetPostComments_FullMethodName      = "/comment.CommentService/GetPostComments"
+	CommentService_GetCommentReplies_FullMethodName    = "/comment.CommentService/GetCommentReplies"
+	CommentService_UpdateComment_FullMethodName        = "/comment.CommentService/UpdateComment"
+	CommentService_DeleteComment_FullMethodName        = "/comment.CommentService/DeleteComment"
+	CommentService_ExportMyData_FullMethodName         = "/comment.CommentService/ExportMyData"
+	CommentService_GetCommentsCounts_FullMethodName    = "/comment.CommentService/GetCommentsCounts"
+	CommentService_PurgeDeletedComments_FullMethodName = "/comment.CommentService/PurgeDeletedComments"
+	CommentService_RemoveComment_FullMethodName        = "/comment.CommentService/RemoveComment"
 )
 
 // CommentServiceClient is the client API for CommentService service.
@@ -31,8 +36,17 @@ const (
 type CommentServiceClient interface {
 	CreateComment(ctx context.Context, in *CreateCommentRequest, opts ...grpc.CallOption) (*Comment, error)
 	GetPostComments(ctx context.Context, in *GetPostCommentsRequest, opts ...grpc.CallOption) (*CommentConnection, error)
+	GetCommentReplies(ctx context.Context, in *GetCommentRepliesRequest, opts ...grpc.CallOption) (*CommentConnection, error)
 	UpdateComment(ctx context.Context, in *UpdateCommentRequest, opts ...grpc.CallOption) (*Comment, error)
 	DeleteComment(ctx context.Context, in *DeleteCommentRequest, opts ...grpc.CallOption) (*Response, error)
+	ExportMyData(ctx context.Context, in *ExportMyDataRequest, opts ...grpc.CallOption) (*DataExport, error)
+	// Admin operations (require the ADMIN role)
+	// GetCommentsCounts counts comments as stored, which post-service
+	// reconciles its counters with
+	GetCommentsCounts(ctx context.Context, in *GetCommentsCountsRequest, opts ...grpc.CallOption) (*GetCommentsCountsResponse, error)
+	PurgeDeletedComments(ctx context.Context, in *PurgeDeletedCommentsRequest, opts ...grpc.CallOption) (*PurgeDeletedCommentsResponse, error)
+	// RemoveComment deletes any user's comment, for moderation
+	RemoveComment(ctx context.Context, in *RemoveCommentRequest, opts ...grpc.CallOption) (*Response, error)
 }
 
 type commentServiceClient struct {
@@ -63,6 +77,16 @@ func (c *commentServiceClient) GetPostComments(ctx context.Context, in *GetPostC
 	return out, nil
 }
 
+func (c *commentServiceClient) GetCommentReplies(ctx context.Context, in *GetCommentRepliesRequest, opts ...grpc.CallOption) (*CommentConnection, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CommentConnection)
+	err := c.cc.Invoke(ctx, CommentService_GetCommentReplies_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *commentServiceClient) UpdateComment(ctx context.Context, in *UpdateCommentRequest, opts ...grpc.CallOption) (*Comment, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(Comment)
@@ -83,14 +107,63 @@ func (c *commentServiceClient) DeleteComment(ctx context.Context, in *DeleteComm
 	return out, nil
 }
 
+func (c *commentServiceClient) ExportMyData(ctx context.Context, in *ExportMyDataRequest, opts ...grpc.CallOption) (*DataExport, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DataExport)
+	err := c.cc.Invoke(ctx, CommentService_ExportMyData_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *commentServiceClient) GetCommentsCounts(ctx context.Context, in *GetCommentsCountsRequest, opts ...grpc.CallOption) (*GetCommentsCountsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCommentsCountsResponse)
+	err := c.cc.Invoke(ctx, CommentService_GetCommentsCounts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *commentServiceClient) PurgeDeletedComments(ctx context.Context, in *PurgeDeletedCommentsRequest, opts ...grpc.CallOption) (*PurgeDeletedCommentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PurgeDeletedCommentsResponse)
+	err := c.cc.Invoke(ctx, CommentService_PurgeDeletedComments_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *commentServiceClient) RemoveComment(ctx context.Context, in *RemoveCommentRequest, opts ...grpc.CallOption) (*Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Response)
+	err := c.cc.Invoke(ctx, CommentService_RemoveComment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CommentServiceServer is the server API for CommentService service.
 // All implementations must embed UnimplementedCommentServiceServer
 // for forward compatibility.
 type CommentServiceServer interface {
 	CreateComment(context.Context, *CreateCommentRequest) (*Comment, error)
 	GetPostComments(context.Context, *GetPostCommentsRequest) (*CommentConnection, error)
+	GetCommentReplies(context.Context, *GetCommentRepliesRequest) (*CommentConnection, error)
 	UpdateComment(context.Context, *UpdateCommentRequest) (*Comment, error)
 	DeleteComment(context.Context, *DeleteCommentRequest) (*Response, error)
+	ExportMyData(context.Context, *ExportMyDataRequest) (*DataExport, error)
+	// Admin operations (require the ADMIN role)
+	// GetCommentsCounts counts comments as stored, which post-service
+	// reconciles its counters with
+	GetCommentsCounts(context.Context, *GetCommentsCountsRequest) (*GetCommentsCountsResponse, error)
+	PurgeDeletedComments(context.Context, *PurgeDeletedCommentsRequest) (*PurgeDeletedCommentsResponse, error)
+	// RemoveComment deletes any user's comment, for moderation
+	RemoveComment(context.Context, *RemoveCommentRequest) (*Response, error)
 	mustEmbedUnimplementedCommentServiceServer()
 }
 
@@ -107,12 +180,27 @@ func (UnimplementedCommentServiceServer) CreateComment(context.Context, *CreateC
 func (UnimplementedCommentServiceServer) GetPostComments(context.Context, *GetPostCommentsRequest) (*CommentConnection, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetPostComments not implemented")
 }
+func (UnimplementedCommentServiceServer) GetCommentReplies(context.Context, *GetCommentRepliesRequest) (*CommentConnection, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCommentReplies not implemented")
+}
 func (UnimplementedCommentServiceServer) UpdateComment(context.Context, *UpdateCommentRequest) (*Comment, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateComment not implemented")
 }
 func (UnimplementedCommentServiceServer) DeleteComment(context.Context, *DeleteCommentRequest) (*Response, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteComment not implemented")
 }
+func (UnimplementedCommentServiceServer) ExportMyData(context.Context, *ExportMyDataRequest) (*DataExport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportMyData not implemented")
+}
+func (UnimplementedCommentServiceServer) GetCommentsCounts(context.Context, *GetCommentsCountsRequest) (*GetCommentsCountsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCommentsCounts not implemented")
+}
+func (UnimplementedCommentServiceServer) PurgeDeletedComments(context.Context, *PurgeDeletedCommentsRequest) (*PurgeDeletedCommentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PurgeDeletedComments not implemented")
+}
+func (UnimplementedCommentServiceServer) RemoveComment(context.Context, *RemoveCommentRequest) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveComment not implemented")
+}
 func (UnimplementedCommentServiceServer) mustEmbedUnimplementedCommentServiceServer() {}
 func (UnimplementedCommentServiceServer) testEmbeddedByValue()                        {}
 
@@ -170,6 +258,24 @@ func _CommentService_GetPostComments_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CommentService_GetCommentReplies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCommentRepliesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommentServiceServer).GetCommentReplies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CommentService_GetCommentReplies_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommentServiceServer).GetCommentReplies(ctx, req.(*GetCommentRepliesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _CommentService_UpdateComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(UpdateCommentRequest)
 	if err := dec(in); err != nil {
@@ -206,6 +312,78 @@ func _CommentService_DeleteComment_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CommentService_ExportMyData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportMyDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommentServiceServer).ExportMyData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CommentService_ExportMyData_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommentServiceServer).ExportMyData(ctx, req.(*ExportMyDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CommentService_GetCommentsCounts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCommentsCountsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommentServiceServer).GetCommentsCounts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CommentService_GetCommentsCounts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommentServiceServer).GetCommentsCounts(ctx, req.(*GetCommentsCountsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CommentService_PurgeDeletedComments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PurgeDeletedCommentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommentServiceServer).PurgeDeletedComments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CommentService_PurgeDeletedComments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommentServiceServer).PurgeDeletedComments(ctx, req.(*PurgeDeletedCommentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CommentService_RemoveComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveCommentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommentServiceServer).RemoveComment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CommentService_RemoveComment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommentServiceServer).RemoveComment(ctx, req.(*RemoveCommentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // CommentService_ServiceDesc is the grpc.ServiceDesc for CommentService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -221,6 +399,10 @@ var CommentService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetPostComments",
 			Handler:    _CommentService_GetPostComments_Handler,
 		},
+		{
+			MethodName: "GetCommentReplies",
+			Handler:    _CommentService_GetCommentReplies_Handler,
+		},
 		{
 			MethodName: "UpdateComment",
 			Handler:    _CommentService_UpdateComment_Handler,
@@ -229,6 +411,22 @@ var CommentService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteComment",
 			Handler:    _CommentService_DeleteComment_Handler,
 		},
+		{
+			MethodName: "ExportMyData",
+			Handler:    _CommentService_ExportMyData_Handler,
+		},
+		{
+			MethodName: "GetCommentsCounts",
+			Handler:    _CommentService_GetCommentsCounts_Handler,
+		},
+		{
+			MethodName: "PurgeDeletedComments",
+			Handler:    _CommentService_PurgeDeletedComments_Handler,
+		},
+		{
+			MethodName: "RemoveComment",
+			Handler:    _CommentService_RemoveComment_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/comment.proto",
@@ -2,29 +2,84 @@ package publisher
 
 import (
 	"comment-service/events"
-	natsClient "comment-service/nats"
+	"comment-service/outbox"
+	"context"
 	"encoding/json"
-	"log"
+	eventspb "shared/eventschema/pb"
+	"shared/logging"
 )
 
+// EventPublisher stores events in the outbox, to be relayed to NATS once
+// committed. Publish within the transaction of the change an event describes.
 type EventPublisher struct {
-	nats *natsClient.Client
+	outbox *outbox.Outbox
 }
 
-func NewEventPublisher(nats *natsClient.Client) *EventPublisher {
-	return &EventPublisher{nats: nats}
+func NewEventPublisher(outbox *outbox.Outbox) *EventPublisher {
+	return &EventPublisher{outbox: outbox}
 }
 
-func (p *EventPublisher) PublishCommentAdded(event events.CommentAddedEvent) error {
+func (p *EventPublisher) PublishCommentAdded(ctx context.Context, event *eventspb.CommentAdded) error {
+	if err := p.outbox.AddEvent(ctx, events.CommentAdded, event); err != nil {
+		return err
+	}
+
+	logging.FromContext(ctx).Info().Str("subject", events.CommentAdded).Str("comment_id", event.CommentId).Msg("queued event")
+	return nil
+}
+
+func (p *EventPublisher) PublishCommentDeleted(ctx context.Context, event events.CommentDeletedEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	if err := p.outbox.Add(ctx, events.CommentDeleted, data); err != nil {
+		return err
+	}
+
+	logging.FromContext(ctx).Info().Str("subject", events.CommentDeleted).Stringer("comment_id", event.CommentID).Msg("queued event")
+	return nil
+}
+
+func (p *EventPublisher) PublishCommentReplied(ctx context.Context, event events.CommentRepliedEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	if err := p.outbox.Add(ctx, events.CommentReplied, data); err != nil {
+		return err
+	}
+
+	logging.FromContext(ctx).Info().Str("subject", events.CommentReplied).Stringer("reply_id", event.ReplyID).Stringer("comment_id", event.CommentID).Msg("queued event")
+	return nil
+}
+
+func (p *EventPublisher) PublishMentionCreated(ctx context.Context, event events.MentionCreatedEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	if err := p.outbox.Add(ctx, events.MentionCreated, data); err != nil {
+		return err
+	}
+
+	logging.FromContext(ctx).Info().Str("subject", events.MentionCreated).Stringer("mentioned_user_id", event.MentionedUserID).Stringer("post_id", event.PostID).Msg("queued event")
+	return nil
+}
+
+func (p *EventPublisher) PublishContentFlagged(ctx context.Context, event events.ContentFlaggedEvent) error {
 	data, err := json.Marshal(event)
 	if err != nil {
 		return err
 	}
 
-	if err := p.nats.Publish(events.CommentAdded, data); err != nil {
+	if err := p.outbox.Add(ctx, events.ContentFlagged, data); err != nil {
 		return err
 	}
 
-	log.Printf("Published event: %s for comment %s", events.CommentAdded, event.CommentID)
+	logging.FromContext(ctx).Info().Str("subject", events.ContentFlagged).Str("content_type", event.ContentType).Stringer("content_id", event.ContentID).Str("action", event.Action).Msg("queued event")
 	return nil
 }
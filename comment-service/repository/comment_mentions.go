@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"comment-service/model"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// SetMentions replaces the users a comment mentions and returns the mentions
+// that did not exist before, which are the ones to notify
+func (r *commentRepository) SetMentions(ctx context.Context, commentID uuid.UUID, mentions []models.Mention, createdAt time.Time) ([]models.Mention, error) {
+	userIDs := make([]string, len(mentions))
+	usernames := make([]string, len(mentions))
+	for i, m := range mentions {
+		userIDs[i] = m.UserID.String()
+		usernames[i] = m.Username
+	}
+
+	_, err := r.db.Conn(ctx).ExecContext(ctx, `
+		DELETE FROM comment_service_comment_mentions
+		WHERE comment_id = $1 AND NOT (user_id = ANY($2::uuid[]))
+	`, commentID, pq.Array(userIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to clear mentions: %w", err)
+	}
+
+	added := []models.Mention{}
+	if len(mentions) == 0 {
+		return added, nil
+	}
+
+	query := `
+		INSERT INTO comment_service_comment_mentions (id, comment_id, user_id, username, created_at)
+		SELECT uuid_generate_v4(), $1, m.user_id, m.username, $4
+		FROM unnest($2::uuid[], $3::text[]) AS m(user_id, username)
+		ON CONFLICT (comment_id, user_id) DO NOTHING
+		RETURNING id, comment_id, user_id, username
+	`
+	err = r.db.Conn(ctx).SelectContext(ctx, &added, query, commentID, pq.Array(userIDs), pq.Array(usernames), createdAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to store mentions: %w", err)
+	}
+	return added, nil
+}
+
+// attachMentions loads the mentions of every comment in one query
+func (r *commentRepository) attachMentions(ctx context.Context, comments []models.Comment) error {
+	if len(comments) == 0 {
+		return nil
+	}
+
+	commentIDs := make([]string, len(comments))
+	for i, comment := range comments {
+		commentIDs[i] = comment.ID.String()
+	}
+
+	query := `
+		SELECT id, comment_id, user_id, username
+		FROM comment_service_comment_mentions
+		WHERE comment_id = ANY($1::uuid[])
+		ORDER BY created_at, username
+	`
+	var mentions []models.Mention
+	if err := r.db.Conn(ctx).SelectContext(ctx, &mentions, query, pq.Array(commentIDs)); err != nil {
+		return fmt.Errorf("failed to load mentions: %w", err)
+	}
+
+	byComment := make(map[uuid.UUID][]models.Mention)
+	for _, m := range mentions {
+		byComment[m.CommentID] = append(byComment[m.CommentID], m)
+	}
+	for i := range comments {
+		comments[i].Mentions = byComment[comments[i].ID]
+	}
+	return nil
+}
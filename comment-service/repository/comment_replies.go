@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"comment-service/model"
+	"github.com/google/uuid"
+	"shared/cursor"
+)
+
+// GetReplies retrieves the direct replies to a comment, oldest first, so a
+// thread reads in the order it was written. A deleted reply is listed as a
+// placeholder while it still has replies of its own, and a shadow-hidden
+// reply only to its author, viewerID.
+func (r *commentRepository) GetReplies(ctx context.Context, commentID uuid.UUID, first int32, after *string, viewerID *uuid.UUID) (*models.CommentConnection, error) {
+	if first <= 0 || first > 100 {
+		first = 10
+	}
+
+	query, args, err := cursor.Keyset{TimeColumn: "created_at", IDColumn: "id", Ascending: true}.Page(`
+		SELECT id, post_id, user_id, parent_comment_id, content, created_at, updated_at, replies_count, deleted_at, shadow_hidden_at
+		FROM comment_service_comments
+		WHERE parent_comment_id = $1
+		  AND (deleted_at IS NULL OR replies_count > 0)
+		  AND (shadow_hidden_at IS NULL OR user_id = $2)
+	`, []interface{}{commentID, viewerID}, after, int(first))
+	if err != nil {
+		return nil, err
+	}
+
+	var replies []models.Comment
+	if err := r.db.ReadDB().SelectContext(ctx, &replies, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to get replies: %w", err)
+	}
+
+	replies, hasNextPage := cursor.Trim(replies, int(first))
+
+	if err := r.attachMentions(ctx, replies); err != nil {
+		return nil, err
+	}
+	blankDeleted(replies)
+
+	edges := make([]models.CommentEdge, len(replies))
+	for i, reply := range replies {
+		edges[i] = models.CommentEdge{
+			Cursor: cursor.EncodeKeyset(reply.CreatedAt, reply.ID),
+			Node:   reply,
+		}
+	}
+
+	var totalCount int32
+	err = r.db.ReadDB().GetContext(ctx, &totalCount, `SELECT replies_count FROM comment_service_comments WHERE id = $1`, commentID)
+	if err != nil {
+		totalCount = 0
+	}
+
+	pageInfo := models.PageInfo{
+		HasNextPage:     hasNextPage,
+		HasPreviousPage: after != nil && *after != "",
+	}
+
+	if len(edges) > 0 {
+		startCursor := edges[0].Cursor
+		endCursor := edges[len(edges)-1].Cursor
+		pageInfo.StartCursor = &startCursor
+		pageInfo.EndCursor = &endCursor
+	}
+
+	return &models.CommentConnection{
+		Edges:      edges,
+		PageInfo:   pageInfo,
+		TotalCount: totalCount,
+	}, nil
+}
+
+// IncrementRepliesCount bumps the denormalized reply counter of a comment
+func (r *commentRepository) IncrementRepliesCount(ctx context.Context, commentID uuid.UUID) error {
+	query := `UPDATE comment_service_comments SET replies_count = replies_count + 1 WHERE id = $1`
+	if _, err := r.db.Conn(ctx).ExecContext(ctx, query, commentID); err != nil {
+		return fmt.Errorf("failed to increment replies count: %w", err)
+	}
+	return nil
+}
+
+// DecrementRepliesCount lowers the reply counter of a comment, never below zero
+func (r *commentRepository) DecrementRepliesCount(ctx context.Context, commentID uuid.UUID) error {
+	query := `UPDATE comment_service_comments SET replies_count = GREATEST(replies_count - 1, 0) WHERE id = $1`
+	if _, err := r.db.Conn(ctx).ExecContext(ctx, query, commentID); err != nil {
+		return fmt.Errorf("failed to decrement replies count: %w", err)
+	}
+	return nil
+}
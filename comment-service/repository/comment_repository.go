@@ -3,50 +3,70 @@ package repository
 import (
 	"context"
 	"database/sql"
-	"encoding/base64"
 	"fmt"
 	"time"
 
+	"comment-service/db"
 	"comment-service/model"
 	"github.com/google/uuid"
-	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"shared/cursor"
 )
 
 type CommentRepository interface {
+	// WithTx runs fn in a single transaction; repository calls made with the
+	// context passed to fn join it
+	WithTx(ctx context.Context, fn func(ctx context.Context) error) error
 	Create(ctx context.Context, comment *models.Comment) error
 	GetByID(ctx context.Context, commentID uuid.UUID) (*models.Comment, error)
-	GetPostComments(ctx context.Context, postID uuid.UUID, first int32, after *string) (*models.CommentConnection, error)
+	GetPostComments(ctx context.Context, postID uuid.UUID, first int32, after *string, viewerID *uuid.UUID) (*models.CommentConnection, error)
 	Update(ctx context.Context, comment *models.Comment) error
 	Delete(ctx context.Context, commentID uuid.UUID) error
+	DeleteByPost(ctx context.Context, postID uuid.UUID) (int64, error)
+	GetByUser(ctx context.Context, userID uuid.UUID) ([]models.Comment, error)
+	DeleteByUser(ctx context.Context, userID uuid.UUID) (int64, error)
+	PurgeDeletedComments(ctx context.Context, deletedBefore time.Time, limit int32) (int64, error)
 	GetTotalCountByPost(ctx context.Context, postID uuid.UUID) (int32, error)
+	GetCountsByPosts(ctx context.Context, postIDs []uuid.UUID) (map[uuid.UUID]int32, error)
 	CheckOwnership(ctx context.Context, commentID, userID uuid.UUID) (bool, error)
+	SetMentions(ctx context.Context, commentID uuid.UUID, mentions []models.Mention, createdAt time.Time) ([]models.Mention, error)
+	GetReplies(ctx context.Context, commentID uuid.UUID, first int32, after *string, viewerID *uuid.UUID) (*models.CommentConnection, error)
+	IncrementRepliesCount(ctx context.Context, commentID uuid.UUID) error
+	DecrementRepliesCount(ctx context.Context, commentID uuid.UUID) error
 }
 
 type commentRepository struct {
-	db *sqlx.DB
+	db *database.DB
 }
 
-func NewCommentRepository(db *sqlx.DB) CommentRepository {
+func NewCommentRepository(db *database.DB) CommentRepository {
 	return &commentRepository{db: db}
 }
 
+// WithTx runs fn as a single unit of work
+func (r *commentRepository) WithTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return r.db.WithTx(ctx, fn)
+}
+
 // Create inserts a new comment into the database
 func (r *commentRepository) Create(ctx context.Context, comment *models.Comment) error {
 	query := `
-		INSERT INTO comment_service_comments (id, post_id, user_id, content, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6)
-		RETURNING id, post_id, user_id, content, created_at, updated_at
+		INSERT INTO comment_service_comments (id, post_id, user_id, parent_comment_id, content, created_at, updated_at, shadow_hidden_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id, post_id, user_id, parent_comment_id, content, created_at, updated_at, replies_count, shadow_hidden_at
 	`
 
-	err := r.db.QueryRowxContext(
+	err := r.db.Conn(ctx).QueryRowxContext(
 		ctx,
 		query,
 		comment.ID,
 		comment.PostID,
 		comment.UserID,
+		comment.ParentCommentID,
 		comment.Content,
 		comment.CreatedAt,
 		comment.UpdatedAt,
+		comment.ShadowHiddenAt,
 	).StructScan(comment)
 
 	if err != nil {
@@ -56,16 +76,16 @@ func (r *commentRepository) Create(ctx context.Context, comment *models.Comment)
 	return nil
 }
 
-// GetByID retrieves a comment by its ID
+// GetByID retrieves a comment by its ID; deleted comments are not found
 func (r *commentRepository) GetByID(ctx context.Context, commentID uuid.UUID) (*models.Comment, error) {
 	query := `
-		SELECT id, post_id, user_id, content, created_at, updated_at
+		SELECT id, post_id, user_id, parent_comment_id, content, created_at, updated_at, replies_count, shadow_hidden_at
 		FROM comment_service_comments
-		WHERE id = $1
+		WHERE id = $1 AND deleted_at IS NULL
 	`
 
 	var comment models.Comment
-	err := r.db.GetContext(ctx, &comment, query, commentID)
+	err := r.db.Conn(ctx).GetContext(ctx, &comment, query, commentID)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, fmt.Errorf("comment not found")
@@ -73,60 +93,51 @@ func (r *commentRepository) GetByID(ctx context.Context, commentID uuid.UUID) (*
 		return nil, fmt.Errorf("failed to get comment: %w", err)
 	}
 
-	return &comment, nil
+	comments := []models.Comment{comment}
+	if err := r.attachMentions(ctx, comments); err != nil {
+		return nil, err
+	}
+
+	return &comments[0], nil
 }
 
-// GetPostComments retrieves comments for a post with cursor-based pagination
-func (r *commentRepository) GetPostComments(ctx context.Context, postID uuid.UUID, first int32, after *string) (*models.CommentConnection, error) {
+// GetPostComments retrieves the top-level comments of a post with
+// cursor-based pagination; replies are paged through GetReplies. A deleted
+// comment is listed as a placeholder while it still has replies, and a
+// shadow-hidden comment only to its author, viewerID.
+func (r *commentRepository) GetPostComments(ctx context.Context, postID uuid.UUID, first int32, after *string, viewerID *uuid.UUID) (*models.CommentConnection, error) {
 	// Default pagination limit
 	if first <= 0 || first > 100 {
 		first = 10
 	}
 
-	var comments []models.Comment
-	var query string
-	var args []interface{}
-
-	if after != nil && *after != "" {
-		// Decode cursor
-		cursorTime, err := decodeCursor(*after)
-		if err != nil {
-			return nil, fmt.Errorf("invalid cursor: %w", err)
-		}
-
-		query = `
-			SELECT id, post_id, user_id, content, created_at, updated_at
-			FROM comment_service_comments
-			WHERE post_id = $1 AND created_at < $2
-			ORDER BY created_at DESC
-			LIMIT $3
-		`
-		args = []interface{}{postID, cursorTime, first + 1}
-	} else {
-		query = `
-			SELECT id, post_id, user_id, content, created_at, updated_at
-			FROM comment_service_comments
-			WHERE post_id = $1
-			ORDER BY created_at DESC
-			LIMIT $2
-		`
-		args = []interface{}{postID, first + 1}
+	query, args, err := cursor.Keyset{TimeColumn: "created_at", IDColumn: "id"}.Page(`
+		SELECT id, post_id, user_id, parent_comment_id, content, created_at, updated_at, replies_count, deleted_at, shadow_hidden_at
+		FROM comment_service_comments
+		WHERE post_id = $1 AND parent_comment_id IS NULL
+		  AND (deleted_at IS NULL OR replies_count > 0)
+		  AND (shadow_hidden_at IS NULL OR user_id = $2)
+	`, []interface{}{postID, viewerID}, after, int(first))
+	if err != nil {
+		return nil, err
 	}
 
-	err := r.db.SelectContext(ctx, &comments, query, args...)
-	if err != nil {
+	var comments []models.Comment
+	if err := r.db.ReadDB().SelectContext(ctx, &comments, query, args...); err != nil {
 		return nil, fmt.Errorf("failed to get comments: %w", err)
 	}
 
-	hasNextPage := len(comments) > int(first)
-	if hasNextPage {
-		comments = comments[:first]
+	comments, hasNextPage := cursor.Trim(comments, int(first))
+
+	if err := r.attachMentions(ctx, comments); err != nil {
+		return nil, err
 	}
+	blankDeleted(comments)
 
 	edges := make([]models.CommentEdge, len(comments))
 	for i, comment := range comments {
 		edges[i] = models.CommentEdge{
-			Cursor: encodeCursor(comment.CreatedAt),
+			Cursor: cursor.EncodeKeyset(comment.CreatedAt, comment.ID),
 			Node:   comment,
 		}
 	}
@@ -160,11 +171,11 @@ func (r *commentRepository) Update(ctx context.Context, comment *models.Comment)
 	query := `
 		UPDATE comment_service_comments
 		SET content = $1, updated_at = $2
-		WHERE id = $3
-		RETURNING id, post_id, user_id, content, created_at, updated_at
+		WHERE id = $3 AND deleted_at IS NULL
+		RETURNING id, post_id, user_id, parent_comment_id, content, created_at, updated_at, replies_count, shadow_hidden_at
 	`
 
-	err := r.db.QueryRowxContext(
+	err := r.db.Conn(ctx).QueryRowxContext(
 		ctx,
 		query,
 		comment.Content,
@@ -182,11 +193,13 @@ func (r *commentRepository) Update(ctx context.Context, comment *models.Comment)
 	return nil
 }
 
-// Delete removes a comment from the database
+// Delete soft-deletes a comment. Its replies stay, shown under a "comment
+// deleted" placeholder, and PurgeDeletedComments removes the row after the
+// retention window.
 func (r *commentRepository) Delete(ctx context.Context, commentID uuid.UUID) error {
-	query := `DELETE FROM comment_service_comments WHERE id = $1`
+	query := `UPDATE comment_service_comments SET deleted_at = NOW() WHERE id = $1 AND deleted_at IS NULL`
 
-	result, err := r.db.ExecContext(ctx, query, commentID)
+	result, err := r.db.Conn(ctx).ExecContext(ctx, query, commentID)
 	if err != nil {
 		return fmt.Errorf("failed to delete comment: %w", err)
 	}
@@ -203,12 +216,61 @@ func (r *commentRepository) Delete(ctx context.Context, commentID uuid.UUID) err
 	return nil
 }
 
-// GetTotalCountByPost returns the total number of comments for a post
+// DeleteByPost soft-deletes every comment on a post, replies included, and
+// returns how many were deleted. Deleting them again deletes nothing.
+func (r *commentRepository) DeleteByPost(ctx context.Context, postID uuid.UUID) (int64, error) {
+	query := `UPDATE comment_service_comments SET deleted_at = NOW() WHERE post_id = $1 AND deleted_at IS NULL`
+
+	result, err := r.db.Conn(ctx).ExecContext(ctx, query, postID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete comments of post: %w", err)
+	}
+	return result.RowsAffected()
+}
+
+// PurgeDeletedComments hard-deletes up to limit comments deleted before
+// deletedBefore, with their mentions, and returns how many were purged. A
+// comment is only purged once it has no replies left, so a thread is
+// removed from its leaves up and live replies are never lost.
+func (r *commentRepository) PurgeDeletedComments(ctx context.Context, deletedBefore time.Time, limit int32) (int64, error) {
+	query := `
+		DELETE FROM comment_service_comments
+		WHERE id IN (
+			SELECT c.id FROM comment_service_comments c
+			WHERE c.deleted_at < $1
+			  AND NOT EXISTS (SELECT 1 FROM comment_service_comments r WHERE r.parent_comment_id = c.id)
+			ORDER BY c.deleted_at
+			LIMIT $2
+		)
+	`
+
+	result, err := r.db.Conn(ctx).ExecContext(ctx, query, deletedBefore, limit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge deleted comments: %w", err)
+	}
+	return result.RowsAffected()
+}
+
+// blankDeleted strips the content of deleted comments read as placeholders
+func blankDeleted(comments []models.Comment) {
+	for i := range comments {
+		if comments[i].DeletedAt != nil {
+			comments[i].Content = ""
+			comments[i].Mentions = nil
+		}
+	}
+}
+
+// GetTotalCountByPost returns the number of top-level comments on a post,
+// not counting deleted or shadow-hidden ones
 func (r *commentRepository) GetTotalCountByPost(ctx context.Context, postID uuid.UUID) (int32, error) {
-	query := `SELECT COUNT(*) FROM comment_service_comments WHERE post_id = $1`
+	query := `
+		SELECT COUNT(*) FROM comment_service_comments
+		WHERE post_id = $1 AND parent_comment_id IS NULL AND deleted_at IS NULL AND shadow_hidden_at IS NULL
+	`
 
 	var count int32
-	err := r.db.GetContext(ctx, &count, query, postID)
+	err := r.db.ReadDB().GetContext(ctx, &count, query, postID)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get comment count: %w", err)
 	}
@@ -216,35 +278,43 @@ func (r *commentRepository) GetTotalCountByPost(ctx context.Context, postID uuid
 	return count, nil
 }
 
-// CheckOwnership verifies if a user owns a specific comment
-func (r *commentRepository) CheckOwnership(ctx context.Context, commentID, userID uuid.UUID) (bool, error) {
-	query := `SELECT EXISTS(SELECT 1 FROM comment_service_comments WHERE id = $1 AND user_id = $2)`
+// GetCountsByPosts returns the number of comments and replies on each post,
+// not counting deleted or shadow-hidden ones. Posts without comments are
+// included with a count of 0.
+func (r *commentRepository) GetCountsByPosts(ctx context.Context, postIDs []uuid.UUID) (map[uuid.UUID]int32, error) {
+	query := `
+		SELECT post_id, COUNT(*) AS count FROM comment_service_comments
+		WHERE post_id = ANY($1) AND deleted_at IS NULL AND shadow_hidden_at IS NULL
+		GROUP BY post_id
+	`
 
-	var exists bool
-	err := r.db.GetContext(ctx, &exists, query, commentID, userID)
-	if err != nil {
-		return false, fmt.Errorf("failed to check comment ownership: %w", err)
+	var rows []struct {
+		PostID uuid.UUID `db:"post_id"`
+		Count  int32     `db:"count"`
+	}
+	if err := r.db.ReadDB().SelectContext(ctx, &rows, query, pq.Array(postIDs)); err != nil {
+		return nil, fmt.Errorf("failed to get comment counts: %w", err)
 	}
 
-	return exists, nil
-}
-
-// encodeCursor encodes a timestamp into a base64 cursor
-func encodeCursor(t time.Time) string {
-	return base64.StdEncoding.EncodeToString([]byte(t.Format(time.RFC3339Nano)))
+	counts := make(map[uuid.UUID]int32, len(postIDs))
+	for _, postID := range postIDs {
+		counts[postID] = 0
+	}
+	for _, row := range rows {
+		counts[row.PostID] = row.Count
+	}
+	return counts, nil
 }
 
-// decodeCursor decodes a base64 cursor into a timestamp
-func decodeCursor(cursor string) (time.Time, error) {
-	decoded, err := base64.StdEncoding.DecodeString(cursor)
-	if err != nil {
-		return time.Time{}, err
-	}
+// CheckOwnership verifies if a user owns a specific comment
+func (r *commentRepository) CheckOwnership(ctx context.Context, commentID, userID uuid.UUID) (bool, error) {
+	query := `SELECT EXISTS(SELECT 1 FROM comment_service_comments WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL)`
 
-	t, err := time.Parse(time.RFC3339Nano, string(decoded))
+	var exists bool
+	err := r.db.Conn(ctx).GetContext(ctx, &exists, query, commentID, userID)
 	if err != nil {
-		return time.Time{}, err
+		return false, fmt.Errorf("failed to check comment ownership: %w", err)
 	}
 
-	return t, nil
+	return exists, nil
 }
@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"comment-service/model"
+	"github.com/google/uuid"
+)
+
+// GetByUser returns every comment and reply a user has not deleted, most
+// recent first
+func (r *commentRepository) GetByUser(ctx context.Context, userID uuid.UUID) ([]models.Comment, error) {
+	query := `
+		SELECT id, post_id, user_id, parent_comment_id, content, created_at, updated_at, replies_count, shadow_hidden_at
+		FROM comment_service_comments
+		WHERE user_id = $1 AND deleted_at IS NULL
+		ORDER BY created_at DESC, id DESC
+	`
+
+	comments := []models.Comment{}
+	if err := r.db.ReadDB().SelectContext(ctx, &comments, query, userID); err != nil {
+		return nil, fmt.Errorf("failed to get comments of user: %w", err)
+	}
+
+	if err := r.attachMentions(ctx, comments); err != nil {
+		return nil, err
+	}
+	return comments, nil
+}
+
+// DeleteByUser soft-deletes every comment of a user, keeping the reply counts
+// of the comments they replied to, and unlinks the user from the comments
+// mentioning them. It returns how many comments were deleted; deleting them
+// again deletes nothing. Call it within WithTx so the counts stay consistent.
+func (r *commentRepository) DeleteByUser(ctx context.Context, userID uuid.UUID) (int64, error) {
+	query := `
+		UPDATE comment_service_comments SET deleted_at = NOW()
+		WHERE user_id = $1 AND deleted_at IS NULL
+		RETURNING parent_comment_id
+	`
+
+	var parents []*uuid.UUID
+	if err := r.db.Conn(ctx).SelectContext(ctx, &parents, query, userID); err != nil {
+		return 0, fmt.Errorf("failed to delete comments of user: %w", err)
+	}
+
+	for _, parentID := range parents {
+		if parentID == nil {
+			continue
+		}
+		if err := r.DecrementRepliesCount(ctx, *parentID); err != nil {
+			return 0, err
+		}
+	}
+
+	mentionsQuery := `DELETE FROM comment_service_comment_mentions WHERE user_id = $1`
+	if _, err := r.db.Conn(ctx).ExecContext(ctx, mentionsQuery, userID); err != nil {
+		return 0, fmt.Errorf("failed to delete mentions of user: %w", err)
+	}
+
+	return int64(len(parents)), nil
+}
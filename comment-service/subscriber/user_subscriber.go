@@ -0,0 +1,82 @@
+package subscriber
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"comment-service/events"
+	natsClient "comment-service/nats"
+	"comment-service/repository"
+	"github.com/nats-io/nats.go"
+	"shared/logging"
+	"shared/tracing"
+)
+
+// UserSubscriber soft-deletes the comments of users who delete their account
+type UserSubscriber struct {
+	natsClient *natsClient.Client
+	repo       repository.CommentRepository
+	ctx        context.Context
+}
+
+func NewUserSubscriber(
+	natsClient *natsClient.Client,
+	repo repository.CommentRepository,
+	ctx context.Context,
+) *UserSubscriber {
+	return &UserSubscriber{
+		natsClient: natsClient,
+		repo:       repo,
+		ctx:        ctx,
+	}
+}
+
+// Start subscribes in the background, retrying until the stream exists like
+// PostSubscriber.Start
+func (s *UserSubscriber) Start() {
+	go func() {
+		for {
+			_, err := s.natsClient.SubscribeDurable(events.UserDeleted, "comment-service-user-deletions", "comment-workers", s.handle)
+			if err == nil {
+				log.Println("User subscriber started successfully")
+				return
+			}
+
+			log.Printf("Failed to start user subscriber, retrying: %v", err)
+			select {
+			case <-time.After(subscribeRetry):
+			case <-s.ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+func (s *UserSubscriber) handle(msg *nats.Msg) {
+	ctx, span := tracing.StartProcess(s.ctx, msg.Subject, msg.Header)
+	ctx = logging.Extract(ctx, msg.Subject, msg.Header)
+	defer span.End()
+
+	var event events.UserDeletedEvent
+	if err := natsClient.DecodeEvent(msg, &event); err != nil {
+		logging.FromContext(ctx).Error().Err(err).Msg("failed to decode user deleted event")
+		msg.Nak()
+		return
+	}
+
+	var deleted int64
+	err := s.repo.WithTx(ctx, func(ctx context.Context) error {
+		var err error
+		deleted, err = s.repo.DeleteByUser(ctx, event.UserID)
+		return err
+	})
+	if err != nil {
+		logging.FromContext(ctx).Error().Err(err).Stringer("user_id", event.UserID).Msg("failed to delete comments of deleted user")
+		msg.Nak()
+		return
+	}
+
+	logging.FromContext(ctx).Info().Stringer("user_id", event.UserID).Int64("deleted", deleted).Msg("deleted comments of deleted user")
+	msg.Ack()
+}
@@ -2,14 +2,14 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"log"
 	"net"
 	"os"
-	"os/signal"
-	"syscall"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/joho/godotenv"
 	"github.com/redis/go-redis/v9"
 	"google.golang.org/grpc"
@@ -17,18 +17,43 @@ import (
 
 	"feed-service/config"
 	"feed-service/db"
+	"feed-service/events"
 	"feed-service/handler"
 	"feed-service/interceptor"
+	"feed-service/migrations"
+	natsClient "feed-service/nats"
 	pb "feed-service/pb"
+	"feed-service/ranking"
 	"feed-service/repository"
+	"feed-service/rpcerror"
+	"feed-service/service"
+	"feed-service/subscriber"
+	"feed-service/warmup"
+	"shared/chaos"
+	"shared/cursor"
+	"shared/dedupe"
+	"shared/lifecycle"
+	"shared/logging"
+	"shared/migrate"
+	"shared/mtls"
+	"shared/tracing"
 )
 
 func main() {
+	logging.Init("feed-service")
+
+	migrateFlag := flag.Bool("migrate", false, "apply pending schema migrations before serving")
+	flag.Parse()
+
 	ctx := context.Background()
 	if err := godotenv.Load(); err != nil {
 		log.Println("failed to load Comment .env file")
 	}
 
+	// The supervisor health-checks the dependencies below and closes them
+	// in order on shutdown
+	supervisor := lifecycle.New(pb.FeedService_ServiceDesc.ServiceName)
+
 	// Load database configuration
 	dbCfg, err := config.LoadDatabaseConfig("")
 	if err != nil {
@@ -46,11 +71,18 @@ func main() {
 		MaxOpenConns: dbCfg.MaxOpenConns,
 		MaxIdleConns: dbCfg.MaxIdleConns,
 		MaxLifetime:  dbCfg.MaxLifetime,
+		ReplicaDSNs:  dbCfg.ReplicaDSNs,
 	})
 	if err != nil {
 		log.Fatalf("Failed to connect to Feed database: %v", err)
 	}
-	defer dbConn.Close()
+	supervisor.Add(lifecycle.Dependency{Name: "database", Check: dbConn.HealthCheck, Close: dbConn.Close})
+
+	// Apply pending schema migrations with -migrate or DB_MIGRATE=true;
+	// without either they are only reported
+	if err := migrate.OnStartup(context.Background(), dbConn.DB, "feed-service", migrations.Files, *migrateFlag || os.Getenv("DB_MIGRATE") == "true"); err != nil {
+		log.Fatalf("Failed to migrate Feed database: %v", err)
+	}
 	log.Println("Feed Database connected successfully")
 
 	// Load Redis configuration
@@ -64,7 +96,7 @@ func main() {
 		DB:       redisDB,
 		PoolSize: 10,
 	})
-	defer redisClient.Close()
+	supervisor.Add(lifecycle.Dependency{Name: "redis", Check: func(ctx context.Context) error { return redisClient.Ping(ctx).Err() }, Close: redisClient.Close})
 
 	// Test Redis connection
 	if err := redisClient.Ping(ctx).Err(); err != nil {
@@ -75,21 +107,141 @@ func main() {
 	// Load service port
 	grpcPort := getEnv("PORT", "50054")
 	jwtSecret := getEnv("JWT_SECRET", "your-secret-key")
+	// Tokens are verified with JWT_SECRET, or with auth-service's public keys
+	// when JWT_ALGORITHM is RS256
+	tokenKeys, err := interceptor.KeysFromEnv(jwtSecret)
+	if err != nil {
+		log.Fatalf("Invalid JWT configuration: %v", err)
+	}
+
+	// Fault injection for resilience testing; inert unless CHAOS_ENABLED=true
+	chaosInjector, err := chaos.FromEnv()
+	if err != nil {
+		log.Fatalf("Invalid chaos configuration: %v", err)
+	}
+
+	// Export traces when OTEL_EXPORTER_OTLP_ENDPOINT is set
+	shutdownTracing, err := tracing.Init(context.Background(), "feed-service")
+	if err != nil {
+		log.Fatalf("Failed to initialize tracing: %v", err)
+	}
+	supervisor.OnShutdown("tracing", shutdownTracing)
+
+	// Pagination cursors are signed so clients cannot forge positions
+	if err := cursor.SetSecret(os.Getenv("CURSOR_SECRET")); err != nil {
+		log.Fatalf("Invalid CURSOR_SECRET: %v", err)
+	}
+
+	// Feeds are ranked by FEED_RANKING_STRATEGY, except for users in an arm of
+	// FEED_RANKING_EXPERIMENT or with a strategy set in Redis
+	assigner, err := ranking.NewAssigner(redisClient, getEnv("FEED_RANKING_STRATEGY", ranking.Engagement), getEnv("FEED_RANKING_EXPERIMENT", ""))
+	if err != nil {
+		log.Fatalf("Failed to configure feed ranking: %v", err)
+	}
 
 	// Initialize repository and handler
-	feedRepo := repository.NewFeedRepository(dbConn.DB, redisClient)
+	feedRepo := repository.NewFeedRepository(dbConn, redisClient, getEnvAsFloat("FEED_EARLY_REFRESH_BETA", 0), assigner)
 	feedHandler := handler.NewFeedHandler(feedRepo)
 
+	// Initialize NATS client
+	nats, err := natsClient.NewClient(natsClient.Config{
+		URL:           getEnv("NATS_URL", "nats://nats:4222"),
+		MaxReconnects: 10,
+		ReconnectWait: 2 * time.Second,
+		ClientID:      getEnv("NATS_CLIENT_ID", "feed-service"),
+		Chaos:         chaosInjector,
+	})
+	if err != nil {
+		log.Fatalf("Failed to initialize NATS client: %v", err)
+	}
+	supervisor.Add(lifecycle.Dependency{Name: "nats", Check: nats.HealthCheck, Drain: nats.Drain})
+
+	// Fanned-out posts are pushed to followers' feedUpdated subscriptions
+	// through a stream keeping each user's latest updates for a while, so
+	// a reconnecting subscriber can catch up
+	if err := nats.EnsureStream(events.FeedUpdatesStream, []string{events.FeedUpdatesSubjects}, time.Hour, 100); err != nil {
+		log.Fatalf("Failed to create feed updates stream: %v", err)
+	}
+	// Acknowledged, retried and failed updates are logged every
+	// NATS_STATS_INTERVAL
+	go logPublishStats(supervisor.Context(), nats, getEnvAsDuration("NATS_STATS_INTERVAL", 5*time.Minute))
+
+	// Reposts reach followers' feeds as they happen. Posts by authors with at
+	// least FEED_FANOUT_THRESHOLD followers are merged into feeds at read time.
+	feedBuilder := service.NewFeedBuilder(feedRepo, feedRepo, nats, getEnvAsInt("FEED_FANOUT_THRESHOLD", 10000))
+	repostSub := subscriber.NewRepostSubscriber(nats, feedRepo, feedBuilder, ctx)
+	if err := repostSub.Start(); err != nil {
+		log.Fatalf("Failed to start repost subscriber: %v", err)
+	}
+
+	// Events the durable subscribers below already handled are recorded, so
+	// redeliveries are skipped
+	processedEvents := dedupe.New(dbConn.DB, "feed_service_processed_events")
+	go processedEvents.RunPruning(supervisor.Context())
+
+	// New posts are fanned out to followers' feeds and deleted ones removed
+	subscriber.NewPostSubscriber(nats, processedEvents, feedRepo, feedBuilder, ctx).Start()
+
+	// Following or unfollowing someone rebuilds the follower's feed
+	subscriber.NewFollowSubscriber(nats, processedEvents, feedRepo, feedBuilder, ctx).Start()
+
+	// Likes and comments feed author affinity and the counts posts rank by
+	subscriber.NewEngagementSubscriber(nats, processedEvents, feedRepo, ctx).Start()
+
+	// Deleted users' feeds, likes, reposts and follows are dropped
+	subscriber.NewUserSubscriber(nats, processedEvents, feedRepo, ctx).Start()
+
+	// Posts by private accounts are kept out of non-followers' feeds
+	privacySub := subscriber.NewPrivacySubscriber(nats, feedRepo, ctx)
+	if err := privacySub.Start(); err != nil {
+		log.Fatalf("Failed to start privacy subscriber: %v", err)
+	}
+
 	// Initialize auth interceptor (allowing public routes)
-	authInterceptor := interceptor.NewAuthInterceptor(jwtSecret, []string{})
+	authInterceptor := interceptor.NewAuthInterceptor(tokenKeys, []string{})
+	authInterceptor.AddAdminMethods([]string{
+		"/feed.FeedService/InspectFeedCache",
+		"/feed.FeedService/RebuildFeedCache",
+		"/feed.FeedService/CleanupFeedCache",
+	})
+	authInterceptor.AddPublicMethods(lifecycle.HealthMethods)
+
+	// Feeds of users active in the last FEED_WARMUP_ACTIVE_HOURS are rebuilt
+	// every FEED_WARMUP_INTERVAL_MINUTES before they expire; 0 turns it off
+	warmupCtx, stopWarmup := context.WithCancel(supervisor.Context())
+	defer stopWarmup()
+	if interval := getEnvAsInt("FEED_WARMUP_INTERVAL_MINUTES", 10); interval > 0 {
+		authInterceptor.AddObserver(func(ctx context.Context, userID string) {
+			id, err := uuid.Parse(userID)
+			if err != nil {
+				return
+			}
+			if err := feedRepo.RecordActivity(ctx, id, time.Now()); err != nil {
+				logging.FromContext(ctx).Warn().Err(err).Msg("failed to record activity")
+			}
+		})
+
+		activeWindow := time.Duration(getEnvAsInt("FEED_WARMUP_ACTIVE_HOURS", 24)) * time.Hour
+		go warmup.New(feedBuilder, redisClient, time.Duration(interval)*time.Minute, activeWindow).Run(warmupCtx)
+	}
+
+	// TLS, and mutual TLS with a CA bundle, from GRPC_TLS_*
+	tlsOption, err := mtls.ServerOption()
+	if err != nil {
+		log.Fatalf("Failed to load TLS config: %v", err)
+	}
 
 	// Create gRPC server
 	grpcServer := grpc.NewServer(
-		grpc.UnaryInterceptor(authInterceptor.Unary()),
+		tlsOption,
+		tracing.ServerOption(),
+		grpc.ChainUnaryInterceptor(logging.UnaryServerInterceptor(), rpcerror.UnaryServerInterceptor(), chaosInjector.Unary(), authInterceptor.Unary()),
+		grpc.ChainStreamInterceptor(logging.StreamServerInterceptor(), rpcerror.StreamServerInterceptor(), chaosInjector.Stream(), authInterceptor.Stream()),
 	)
 
 	// Register the FeedService
 	pb.RegisterFeedServiceServer(grpcServer, feedHandler)
+	supervisor.Register(grpcServer)
 
 	// Enable reflection (for grpcurl/testing)
 	reflection.Register(grpcServer)
@@ -100,69 +252,12 @@ func main() {
 		log.Fatalf("Failed to listen on port %s: %v", grpcPort, err)
 	}
 
-	// Background cleanup job
-	go startBackgroundJobs(feedRepo)
-
-	// Start gRPC server in a goroutine
-	go func() {
-		log.Printf("Feed Service gRPC server listening on port %s", grpcPort)
-		if err := grpcServer.Serve(listener); err != nil {
-			log.Fatalf("Failed to serve gRPC: %v", err)
-		}
-	}()
-
-	// Graceful shutdown
-	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
-	<-sigChan
-
-	log.Println("Shutting down Feed Service...")
-	grpcServer.GracefulStop()
-	redisClient.Close()
-	dbConn.Close()
-	log.Println("Feed Service stopped cleanly")
-}
-
-// --- Background Jobs ---
-
-func startBackgroundJobs(feedRepo repository.FeedRepository) {
-	cleanupTicker := time.NewTicker(24 * time.Hour)
-	defer cleanupTicker.Stop()
-
-	for range cleanupTicker.C {
-		ctx := context.Background()
-		olderThan := time.Now().AddDate(0, 0, -30) // keep last 30 days
-
-		log.Println("🧺 Starting feed cleanup job...")
-		if err := feedRepo.CleanupOldFeedItems(ctx, olderThan); err != nil {
-			log.Printf("Feed cleanup failed: %v", err)
-		} else {
-			log.Println("Feed cleanup completed successfully")
-		}
+	log.Printf("Feed Service gRPC server listening on port %s", grpcPort)
+	if err := supervisor.Serve(grpcServer, listener); err != nil {
+		log.Fatalf("Failed to serve gRPC: %v", err)
 	}
 }
 
-// --- Interceptors ---
-
-func loggingInterceptor(
-	ctx context.Context,
-	req interface{},
-	info *grpc.UnaryServerInfo,
-	handler grpc.UnaryHandler,
-) (interface{}, error) {
-	start := time.Now()
-	resp, err := handler(ctx, req)
-	duration := time.Since(start)
-
-	status := "✅"
-	if err != nil {
-		status = "❌"
-	}
-
-	log.Printf("[%s] %s - %v (took %v)", status, info.FullMethod, err, duration)
-	return resp, err
-}
-
 // --- Utility helpers ---
 
 func getEnv(key, defaultVal string) string {
@@ -180,3 +275,52 @@ func getEnvAsInt(key string, defaultVal int) int {
 	}
 	return defaultVal
 }
+
+func getEnvAsFloat(key string, defaultVal float64) float64 {
+	if val := os.Getenv(key); val != "" {
+		var floatVal float64
+		fmt.Sscanf(val, "%g", &floatVal)
+		return floatVal
+	}
+	return defaultVal
+}
+
+func getEnvAsDuration(key string, defaultVal time.Duration) time.Duration {
+	if val, err := time.ParseDuration(os.Getenv(key)); err == nil && val > 0 {
+		return val
+	}
+	return defaultVal
+}
+
+// logPublishStats logs the feed updates acknowledged, published again and
+// given up on in each interval until ctx is cancelled
+func logPublishStats(ctx context.Context, client *natsClient.Client, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	last := client.PublishStats()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		stats := client.PublishStats()
+		published, retried, failed := stats.Published-last.Published, stats.Retried-last.Retried, stats.Failed-last.Failed
+		last = stats
+		if published+retried+failed == 0 {
+			continue
+		}
+
+		event := logging.FromContext(ctx).Info()
+		if failed > 0 {
+			event = logging.FromContext(ctx).Warn()
+		}
+		event.
+			Int64("published", published).
+			Int64("retried", retried).
+			Int64("failed", failed).
+			Msg("feed update publish stats")
+	}
+}
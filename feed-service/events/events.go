@@ -0,0 +1,116 @@
+package events
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"shared/eventschema"
+)
+
+// Subjects the feed projection consumes. The events of those from
+// shared/eventschema are decoded with it.
+const (
+	PostCreated    = eventschema.PostCreated
+	PostUpdated    = eventschema.PostUpdated
+	PostReposted   = "post.reposted"
+	PostUnreposted = "post.unreposted"
+	PostDeleted    = eventschema.PostDeleted
+	PostLiked      = "post.liked"
+	PostUnliked    = "post.unliked"
+	CommentAdded   = eventschema.CommentAdded
+	CommentDeleted = "post.comment.deleted"
+	UserUpdated    = "user.updated"
+	UserFollowed   = "follow.created"
+	UserUnfollowed = "follow.deleted"
+	UserDeleted    = "user.deleted"
+)
+
+// FeedUpdatesStream retains the live updates of users' feeds, so a
+// subscriber that reconnects can resume where it left off
+const FeedUpdatesStream = "FEED_UPDATES"
+
+// FeedUpdatesSubjects are the subjects captured by FeedUpdatesStream
+const FeedUpdatesSubjects = "feed.updates.*"
+
+// FeedUpdatesSubject is the subject new posts in a user's feed are published
+// on for the gateway's feedUpdated subscription
+func FeedUpdatesSubject(userID uuid.UUID) string {
+	return "feed.updates." + userID.String()
+}
+
+// FeedUpdateEvent is published by feed-service on a follower's
+// FeedUpdatesSubject when a post is fanned out to their feed
+type FeedUpdateEvent struct {
+	PostID     uuid.UUID `json:"post_id"`
+	UserID     uuid.UUID `json:"user_id"`
+	Content    string    `json:"content"`
+	Visibility string    `json:"visibility"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// PostRepostedEvent and PostUnrepostedEvent are published by post-service
+type PostRepostedEvent struct {
+	RepostID      uuid.UUID `json:"repost_id"`
+	PostID        uuid.UUID `json:"post_id"`
+	PostAuthorID  uuid.UUID `json:"post_author_id"`
+	PostContent   string    `json:"post_content"`
+	PostCreatedAt time.Time `json:"post_created_at"`
+	UserID        uuid.UUID `json:"user_id"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type PostUnrepostedEvent struct {
+	RepostID  uuid.UUID `json:"repost_id"`
+	PostID    uuid.UUID `json:"post_id"`
+	UserID    uuid.UUID `json:"user_id"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+// PostLikedEvent and PostUnlikedEvent are published by like-service
+type PostLikedEvent struct {
+	LikeID    uuid.UUID `json:"like_id"`
+	PostID    uuid.UUID `json:"post_id"`
+	UserID    uuid.UUID `json:"user_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type PostUnlikedEvent struct {
+	LikeID    uuid.UUID `json:"like_id"`
+	PostID    uuid.UUID `json:"post_id"`
+	UserID    uuid.UUID `json:"user_id"`
+	UnlikedAt time.Time `json:"unliked_at"`
+}
+
+// CommentDeletedEvent is published by comment-service, for replies as well
+// as comments
+type CommentDeletedEvent struct {
+	CommentID uuid.UUID `json:"comment_id"`
+	PostID    uuid.UUID `json:"post_id"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+// UserUpdatedEvent is published by user-service when a profile changes
+type UserUpdatedEvent struct {
+	UserID    uuid.UUID `json:"user_id"`
+	IsPrivate bool      `json:"is_private"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// UserFollowedEvent and UserUnfollowedEvent are published by follow-service
+type UserFollowedEvent struct {
+	FollowerID  uuid.UUID `json:"follower_id"`
+	FollowingID uuid.UUID `json:"following_id"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type UserUnfollowedEvent struct {
+	FollowerID  uuid.UUID `json:"follower_id"`
+	FollowingID uuid.UUID `json:"following_id"`
+	DeletedAt   time.Time `json:"deleted_at"`
+}
+
+// UserDeletedEvent is published by auth-service when an account is deleted
+type UserDeletedEvent struct {
+	UserID    uuid.UUID `json:"user_id"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
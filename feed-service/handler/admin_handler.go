@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "feed-service/pb"
+	"feed-service/repository"
+	"feed-service/rpcerror"
+	"shared/logging"
+)
+
+// InspectFeedCache reports the cached feed state for a user
+func (h *FeedHandler) InspectFeedCache(ctx context.Context, req *pb.InspectFeedCacheRequest) (*pb.FeedCacheInfo, error) {
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid user_id: %v", err)
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 500 {
+		limit = 500
+	}
+
+	info, err := h.feedRepo.InspectFeedCache(ctx, userID, int(limit))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to inspect feed cache: %v", err)
+	}
+
+	entries := make([]*pb.CachedFeedEntry, len(info.Entries))
+	for i, entry := range info.Entries {
+		entries[i] = &pb.CachedFeedEntry{
+			PostId: entry.PostID.String(),
+			Score:  entry.Score,
+		}
+	}
+
+	// Redis reports a missing key as -2 and a key without expiry as -1
+	ttlSeconds := int64(info.TTL.Seconds())
+	if info.TTL < 0 {
+		ttlSeconds = int64(info.TTL)
+	}
+
+	return &pb.FeedCacheInfo{
+		UserId:         info.UserID.String(),
+		CachedCount:    info.CachedCount,
+		TtlSeconds:     ttlSeconds,
+		Entries:        entries,
+		StoredCount:    info.StoredCount,
+		FollowingCount: info.FollowingCount,
+	}, nil
+}
+
+// RebuildFeedCache drops a user's cached feed and rebuilds it from the stored posts and follows
+func (h *FeedHandler) RebuildFeedCache(ctx context.Context, req *pb.RefreshFeedRequest) (*pb.Response, error) {
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid user_id: %v", err)
+	}
+
+	if err := h.feedRepo.InvalidateUserFeed(ctx, userID); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to invalidate feed cache: %v", err)
+	}
+
+	page, err := h.feedRepo.BuildFeedForUser(ctx, userID, time.Now(), nil, repository.FeedCacheSize, false)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to build feed: %v", err)
+	}
+
+	if err := h.feedRepo.CacheFeedItems(ctx, userID, page); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to cache feed: %v", err)
+	}
+
+	return &pb.Response{
+		Success: true,
+		Message: fmt.Sprintf("Feed rebuilt with %d posts", len(page.Posts)),
+	}, nil
+}
+
+// CleanupFeedCache deletes stored feed entries created before older_than. It
+// is run on a schedule by scheduler-service.
+func (h *FeedHandler) CleanupFeedCache(ctx context.Context, req *pb.CleanupFeedCacheRequest) (*pb.CleanupFeedCacheResponse, error) {
+	if req.OlderThan == nil {
+		return nil, rpcerror.InvalidField("older_than", "older_than is required")
+	}
+
+	deleted, err := h.feedRepo.CleanupOldFeedItems(ctx, req.OlderThan.AsTime())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to clean up feed cache: %v", err)
+	}
+
+	logging.FromContext(ctx).Info().Int64("deleted", deleted).Time("older_than", req.OlderThan.AsTime()).Msg("cleaned up feed entries")
+	return &pb.CleanupFeedCacheResponse{Deleted: deleted}, nil
+}
@@ -2,6 +2,7 @@ package handler
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/google/uuid"
@@ -9,9 +10,13 @@ import (
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"feed-service/interceptor"
 	"feed-service/model"
 	pb "feed-service/pb"
 	"feed-service/repository"
+	"feed-service/rpcerror"
+	"shared/cursor"
+	"shared/logging"
 )
 
 type FeedHandler struct {
@@ -45,8 +50,11 @@ func (h *FeedHandler) GetFeed(ctx context.Context, req *pb.GetFeedRequest) (*pb.
 		after = req.After
 	}
 
-	feedConnection, err := h.feedRepo.GetFeed(ctx, userID, int(limit), after)
+	feedConnection, err := h.feedRepo.GetFeed(ctx, userID, int(limit), after, req.ExcludeSeen)
 	if err != nil {
+		if errors.Is(err, cursor.ErrInvalid) {
+			return nil, rpcerror.InvalidField("after", "invalid cursor")
+		}
 		return nil, status.Errorf(codes.Internal, "failed to get feed: %v", err)
 	}
 
@@ -64,43 +72,206 @@ func (h *FeedHandler) GetFeed(ctx context.Context, req *pb.GetFeedRequest) (*pb.
 	return h.toProtoPostConnection(feedConnection, likeStatus), nil
 }
 
+// streamFeedChunkSize is how many posts StreamFeed hydrates and sends at a
+// time
+const streamFeedChunkSize = 10
+
+// StreamFeed sends a page of the caller's feed in chunks. The page is ranked
+// as by GetFeed, then each chunk is hydrated with the caller's likes and sent
+// before the next one is, so the receiver can start on the first posts while
+// later ones are still being hydrated. The last chunk carries the page info.
+func (h *FeedHandler) StreamFeed(req *pb.StreamFeedRequest, stream pb.FeedService_StreamFeedServer) error {
+	ctx := stream.Context()
+	userID, err := callerID(ctx)
+	if err != nil {
+		return err
+	}
+
+	limit := req.First
+	if limit <= 0 {
+		limit = 10
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	feedConnection, err := h.feedRepo.GetFeed(ctx, userID, int(limit), req.After, req.ExcludeSeen)
+	if err != nil {
+		if errors.Is(err, cursor.ErrInvalid) {
+			return rpcerror.InvalidField("after", "invalid cursor")
+		}
+		return status.Errorf(codes.Internal, "failed to get feed: %v", err)
+	}
+
+	edges := feedConnection.Edges
+	for {
+		n := min(streamFeedChunkSize, len(edges))
+		chunk := edges[:n]
+		edges = edges[n:]
+
+		postIDs := make([]uuid.UUID, len(chunk))
+		for i, edge := range chunk {
+			postIDs[i] = edge.Node.ID
+		}
+		likeStatus, err := h.feedRepo.GetPostsWithLikeStatus(ctx, userID, postIDs)
+		if err != nil {
+			logging.FromContext(ctx).Warn().Err(err).Msg("failed to get like status")
+			likeStatus = make(map[uuid.UUID]bool)
+		}
+
+		msg := &pb.FeedChunk{Edges: make([]*pb.PostEdge, len(chunk))}
+		for i, edge := range chunk {
+			msg.Edges[i] = toProtoPostEdge(edge, likeStatus)
+		}
+		if len(edges) == 0 {
+			msg.PageInfo = toProtoPageInfo(feedConnection.PageInfo)
+			msg.TotalCount = feedConnection.TotalCount
+			msg.RankingStrategy = feedConnection.RankingStrategy
+		}
+
+		if err := stream.Send(msg); err != nil {
+			return err
+		}
+		if len(edges) == 0 {
+			return nil
+		}
+	}
+}
+
+// GetExploreFeed retrieves posts trending across the network for the caller
+// to discover, leaving out their own posts and those of users they follow
+func (h *FeedHandler) GetExploreFeed(ctx context.Context, req *pb.GetExploreFeedRequest) (*pb.PostConnection, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := req.First
+	if limit <= 0 {
+		limit = 10
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	exploreConnection, err := h.feedRepo.GetExploreFeed(ctx, userID, int(limit), req.After)
+	if err != nil {
+		if errors.Is(err, cursor.ErrInvalid) {
+			return nil, rpcerror.InvalidField("after", "invalid cursor")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get explore feed: %v", err)
+	}
+
+	postIDs := make([]uuid.UUID, len(exploreConnection.Edges))
+	for i, edge := range exploreConnection.Edges {
+		postIDs[i] = edge.Node.ID
+	}
+
+	likeStatus, err := h.feedRepo.GetPostsWithLikeStatus(ctx, userID, postIDs)
+	if err != nil {
+		logging.FromContext(ctx).Warn().Err(err).Msg("failed to get like status")
+		likeStatus = make(map[uuid.UUID]bool)
+	}
+
+	return h.toProtoPostConnection(exploreConnection, likeStatus), nil
+}
+
+// maxSeenPostIDs bounds the posts marked seen in one call
+const maxSeenPostIDs = 100
+
+// MarkFeedItemsSeen records posts of the caller's feed as seen. Clients call
+// it as posts scroll into view; marking a post again changes nothing.
+func (h *FeedHandler) MarkFeedItemsSeen(ctx context.Context, req *pb.MarkFeedItemsSeenRequest) (*pb.Response, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(req.PostIds) == 0 {
+		return nil, rpcerror.InvalidField("post_ids", "post_ids is required")
+	}
+	if len(req.PostIds) > maxSeenPostIDs {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d post_ids may be marked at once", maxSeenPostIDs)
+	}
+
+	postIDs := make([]uuid.UUID, len(req.PostIds))
+	for i, raw := range req.PostIds {
+		postIDs[i], err = uuid.Parse(raw)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid post_id: %v", err)
+		}
+	}
+
+	marked, err := h.feedRepo.MarkSeen(ctx, userID, postIDs)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to mark posts seen: %v", err)
+	}
+
+	return &pb.Response{
+		Success: true,
+		Message: fmt.Sprintf("Marked %d posts seen", marked),
+	}, nil
+}
+
+// callerID returns the authenticated user making the request
+func callerID(ctx context.Context) (uuid.UUID, error) {
+	raw, err := interceptor.GetUserIDFromContext(ctx)
+	if err != nil {
+		return uuid.Nil, status.Error(codes.Unauthenticated, "user not authenticated")
+	}
+	userID, err := uuid.Parse(raw)
+	if err != nil {
+		return uuid.Nil, status.Error(codes.Unauthenticated, "invalid user ID in token")
+	}
+	return userID, nil
+}
+
 // Helper function to convert models.PostConnection to protobuf PostConnection
 func (h *FeedHandler) toProtoPostConnection(conn *models.PostConnection, likeStatus map[uuid.UUID]bool) *pb.PostConnection {
 	edges := make([]*pb.PostEdge, len(conn.Edges))
-
 	for i, edge := range conn.Edges {
-		isLiked := likeStatus[edge.Node.ID]
-
-		edges[i] = &pb.PostEdge{
-			Cursor: edge.Cursor,
-			Node: &pb.Post{
-				Id:            edge.Node.ID.String(),
-				UserId:        edge.Node.UserID.String(),
-				Content:       edge.Node.Content,
-				CreatedAt:     timestamppb.New(edge.Node.CreatedAt),
-				UpdatedAt:     timestamppb.New(edge.Node.UpdatedAt),
-				LikesCount:    edge.Node.LikesCount,
-				CommentsCount: edge.Node.CommentsCount,
-				IsLiked:       &isLiked,
-			},
-		}
+		edges[i] = toProtoPostEdge(edge, likeStatus)
 	}
 
-	pageInfo := &pb.PageInfo{
-		HasNextPage:     conn.PageInfo.HasNextPage,
-		HasPreviousPage: conn.PageInfo.HasPreviousPage,
+	return &pb.PostConnection{
+		Edges:           edges,
+		PageInfo:        toProtoPageInfo(conn.PageInfo),
+		TotalCount:      conn.TotalCount,
+		RankingStrategy: conn.RankingStrategy,
 	}
+}
 
-	if conn.PageInfo.EndCursor != nil {
-		pageInfo.EndCursor = conn.PageInfo.EndCursor
+func toProtoPostEdge(edge models.PostEdge, likeStatus map[uuid.UUID]bool) *pb.PostEdge {
+	isLiked := likeStatus[edge.Node.ID]
+
+	return &pb.PostEdge{
+		Cursor: edge.Cursor,
+		Node: &pb.Post{
+			Id:            edge.Node.ID.String(),
+			UserId:        edge.Node.UserID.String(),
+			Content:       edge.Node.Content,
+			CreatedAt:     timestamppb.New(edge.Node.CreatedAt),
+			UpdatedAt:     timestamppb.New(edge.Node.UpdatedAt),
+			LikesCount:    edge.Node.LikesCount,
+			CommentsCount: edge.Node.CommentsCount,
+			IsLiked:       &isLiked,
+			Visibility:    edge.Node.Visibility,
+		},
 	}
-	if conn.PageInfo.StartCursor != nil {
-		pageInfo.StartCursor = conn.PageInfo.StartCursor
+}
+
+func toProtoPageInfo(info models.PageInfo) *pb.PageInfo {
+	pageInfo := &pb.PageInfo{
+		HasNextPage:     info.HasNextPage,
+		HasPreviousPage: info.HasPreviousPage,
 	}
 
-	return &pb.PostConnection{
-		Edges:      edges,
-		PageInfo:   pageInfo,
-		TotalCount: conn.TotalCount,
+	if info.EndCursor != nil {
+		pageInfo.EndCursor = info.EndCursor
 	}
+	if info.StartCursor != nil {
+		pageInfo.StartCursor = info.StartCursor
+	}
+
+	return pageInfo
 }
@@ -61,6 +61,55 @@ CREATE TABLE IF NOT EXISTS feed_service_follows (
     PRIMARY KEY (follower_id, followed_id)
 );
 
+-- ========================================
+-- Feed Reposts Table
+-- ========================================
+-- Posts reposted by users, fed from post.reposted and post.unreposted. A
+-- reposted post reaches the feeds of the reposter's followers.
+CREATE TABLE IF NOT EXISTS feed_service_reposts (
+    user_id UUID NOT NULL,
+    post_id UUID NOT NULL REFERENCES feed_service_posts(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, post_id)
+);
+
+-- ========================================
+-- Feed Private Users Table
+-- ========================================
+-- Privacy of accounts, fed from user.updated. Posts by private accounts only
+-- reach the feeds of their followers, even when reposted.
+CREATE TABLE IF NOT EXISTS feed_service_private_users (
+    user_id UUID PRIMARY KEY,
+    is_private BOOLEAN NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+-- ========================================
+-- Feed Author Strategies Table
+-- ========================================
+-- How posts by each author reach their followers' feeds, decided from the
+-- follower count whenever they post. Posts by 'push' authors are written to
+-- every follower's feed items; posts by 'pull' authors are merged into feeds
+-- when they are read.
+CREATE TABLE IF NOT EXISTS feed_service_author_strategies (
+    user_id UUID PRIMARY KEY,
+    strategy TEXT NOT NULL CHECK (strategy IN ('push', 'pull')),
+    follower_count INTEGER NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- ========================================
+-- Feed Seen Table
+-- ========================================
+-- Posts each user has seen in their feed, reported by clients as they
+-- scroll. Seen posts rank lower in later feeds, or are left out on request.
+CREATE TABLE IF NOT EXISTS feed_service_seen (
+    user_id UUID NOT NULL,
+    post_id UUID NOT NULL REFERENCES feed_service_posts(id) ON DELETE CASCADE,
+    seen_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, post_id)
+);
+
 -- ========================================
 -- Indexes for Performance
 -- ========================================
@@ -70,6 +119,9 @@ CREATE INDEX IF NOT EXISTS idx_feed_service_cache_user_id ON feed_service_cache(
 CREATE INDEX IF NOT EXISTS idx_feed_service_cache_post_id ON feed_service_cache(post_id);
 CREATE UNIQUE INDEX IF NOT EXISTS idx_feed_service_cache_user_post ON feed_service_cache(user_id, post_id);
 CREATE INDEX IF NOT EXISTS idx_feed_service_cache_created_at ON feed_service_cache(created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_feed_service_reposts_post_id ON feed_service_reposts(post_id);
+CREATE INDEX IF NOT EXISTS idx_feed_service_seen_seen_at ON feed_service_seen(seen_at);
+CREATE INDEX IF NOT EXISTS idx_feed_service_follows_followed_id ON feed_service_follows(followed_id) WHERE deleted_at IS NULL;
 
 -- ========================================
 -- Function: Update 'updated_at' Column
@@ -85,7 +137,7 @@ $$ LANGUAGE plpgsql;
 -- ========================================
 -- Trigger: Automatically Update 'updated_at'
 -- ========================================
-CREATE TRIGGER trigger_update_feed_posts_updated_at
+CREATE OR REPLACE TRIGGER trigger_update_feed_posts_updated_at
 BEFORE UPDATE ON feed_service_posts
 FOR EACH ROW
 EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,6 @@
+-- ========================================
+-- Post Visibility
+-- ========================================
+-- Visibility of projected posts, fed from post.created. Followers-only posts
+-- only reach the author's approved followers and private posts reach no one.
+ALTER TABLE feed_service_posts ADD COLUMN IF NOT EXISTS visibility VARCHAR(16) NOT NULL DEFAULT 'PUBLIC';
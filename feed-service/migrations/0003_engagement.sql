@@ -0,0 +1,21 @@
+-- ========================================
+-- Likes, Comments and Post Edits Projection
+-- ========================================
+-- feed_service_likes is fed from post.liked and post.unliked. Comments are
+-- fed from post.comment.added and post.comment.deleted. Each keeps a
+-- tombstone when removed, so a redelivered event never counts twice. The
+-- likes_count and comments_count of posts change only as rows become live
+-- or are removed.
+CREATE TABLE IF NOT EXISTS feed_service_comments (
+    comment_id UUID PRIMARY KEY,
+    post_id UUID NOT NULL REFERENCES feed_service_posts(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_feed_service_comments_post_id ON feed_service_comments(post_id);
+CREATE INDEX IF NOT EXISTS idx_feed_service_likes_post_id ON feed_service_likes(post_id);
+
+-- updated_at is when the post was last edited, as post.updated reports it,
+-- so counting likes and comments must not move it
+DROP TRIGGER IF EXISTS trigger_update_feed_posts_updated_at ON feed_service_posts;
@@ -0,0 +1,8 @@
+// Package migrations embeds the service's schema migrations, applied in name
+// order by database.Migrate
+package migrations
+
+import "embed"
+
+//go:embed *.sql
+var Files embed.FS
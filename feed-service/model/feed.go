@@ -23,6 +23,25 @@ type Post struct {
 	UpdatedAt     time.Time `json:"updated_at" db:"updated_at"`
 	LikesCount    int32     `json:"likes_count" db:"likes_count"`
 	CommentsCount int32     `json:"comments_count" db:"comments_count"`
+	// Visibility is PUBLIC or FOLLOWERS_ONLY; private posts are not projected
+	Visibility string `json:"visibility" db:"visibility"`
+}
+
+// FanOutStrategy is how posts by an author reach their followers' feeds
+type FanOutStrategy string
+
+const (
+	// FanOutPush writes a feed item for every follower when the author posts
+	FanOutPush FanOutStrategy = "push"
+	// FanOutPull merges the author's posts into feeds when they are read
+	FanOutPull FanOutStrategy = "pull"
+)
+
+// Repost records that a user shared a post with their followers
+type Repost struct {
+	UserID    uuid.UUID `json:"user_id" db:"user_id"`
+	PostID    uuid.UUID `json:"post_id" db:"post_id"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
 }
 
 // PostWithLikeStatus extends Post with user-specific like status
@@ -31,6 +50,41 @@ type PostWithLikeStatus struct {
 	IsLiked *bool `json:"is_liked,omitempty"`
 }
 
+// RankedPost is a post with the score it was ranked by in a user's feed
+type RankedPost struct {
+	Post
+	Score float64 `json:"score" db:"feed_score"`
+}
+
+// FeedCandidate is a post that may appear in a user's feed, with the signals
+// ranking strategies score it by
+type FeedCandidate struct {
+	Post
+	// RankedAt is when the post was made or, if later, last reposted by
+	// someone the user follows
+	RankedAt time.Time `json:"ranked_at" db:"ranked_at"`
+	// Seen is whether the user had seen the post when the feed was ranked
+	Seen bool `json:"seen" db:"seen"`
+	// AuthorAffinity is how many of the author's posts the user liked lately
+	AuthorAffinity int `json:"author_affinity" db:"author_affinity"`
+}
+
+// FeedPosition is the place of a post in a ranked feed. Feeds are ordered by
+// score and then by post ID, both descending.
+type FeedPosition struct {
+	Score  float64   `json:"score"`
+	PostID uuid.UUID `json:"post_id"`
+}
+
+// FeedPage is a page of a user's feed. Scores depend on the age of posts, so
+// every page of one traversal is ranked as of the same time.
+type FeedPage struct {
+	Posts       []RankedPost `json:"posts"`
+	HasNextPage bool         `json:"has_next_page"`
+	AsOf        time.Time    `json:"as_of"`
+	Strategy    string       `json:"strategy"`
+}
+
 // FeedItem represents a single item in the user's feed
 type FeedItem struct {
 	Post     Post      `json:"post"`
@@ -56,9 +110,10 @@ type PageInfo struct {
 
 // PostConnection represents a paginated list of posts
 type PostConnection struct {
-	Edges      []PostEdge `json:"edges"`
-	PageInfo   PageInfo   `json:"page_info"`
-	TotalCount int32      `json:"total_count"`
+	Edges           []PostEdge `json:"edges"`
+	PageInfo        PageInfo   `json:"page_info"`
+	TotalCount      int32      `json:"total_count"`
+	RankingStrategy string     `json:"ranking_strategy"`
 }
 
 // FeedStats contains statistics about a user's feed
@@ -69,3 +124,19 @@ type FeedStats struct {
 	LastRefreshedAt time.Time `json:"last_refreshed_at"`
 	FollowingCount  int32     `json:"following_count"`
 }
+
+// CachedFeedEntry is a single post ID held in a user's Redis feed cache
+type CachedFeedEntry struct {
+	PostID uuid.UUID `json:"post_id"`
+	Score  float64   `json:"score"`
+}
+
+// FeedCacheInfo describes the cached state of a user's feed for diagnostics
+type FeedCacheInfo struct {
+	UserID         uuid.UUID         `json:"user_id"`
+	CachedCount    int64             `json:"cached_count"`
+	TTL            time.Duration     `json:"ttl"`
+	Entries        []CachedFeedEntry `json:"entries"`
+	StoredCount    int32             `json:"stored_count"`
+	FollowingCount int32             `json:"following_count"`
+}
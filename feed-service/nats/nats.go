@@ -0,0 +1,324 @@
+package nats
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sync/atomic"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"go.opentelemetry.io/otel/trace"
+
+	"shared/chaos"
+	"shared/logging"
+	"shared/tracing"
+)
+
+// maxPendingPublishes bounds the asynchronous publishes awaiting their
+// acknowledgement. Publishing beyond it waits for earlier ones to complete.
+const maxPendingPublishes = 512
+
+const (
+	// maxPublishAttempts bounds how many times PublishBatch publishes a
+	// message that is not acknowledged
+	maxPublishAttempts = 3
+	// publishRetryDelay is the wait before the second attempt, and grows by as
+	// much before each further one
+	publishRetryDelay = 200 * time.Millisecond
+)
+
+type Config struct {
+	URL           string
+	MaxReconnects int
+	ReconnectWait time.Duration
+	ClientID      string
+	Chaos         *chaos.Injector
+}
+
+type Client struct {
+	conn  *nats.Conn
+	js    nats.JetStreamContext
+	chaos *chaos.Injector
+
+	published atomic.Int64
+	retried   atomic.Int64
+	failed    atomic.Int64
+}
+
+func NewClient(cfg Config) (*Client, error) {
+	opts := []nats.Option{
+		nats.Name(cfg.ClientID),
+		nats.MaxReconnects(cfg.MaxReconnects),
+		nats.ReconnectWait(cfg.ReconnectWait),
+		nats.DisconnectErrHandler(func(nc *nats.Conn, err error) {
+			if err != nil {
+				log.Printf("NATS disconnected: %v", err)
+			}
+		}),
+		nats.ReconnectHandler(func(nc *nats.Conn) {
+			log.Printf("NATS reconnected to %s", nc.ConnectedUrl())
+		}),
+	}
+
+	conn, err := nats.Connect(cfg.URL, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to NATS: %w", err)
+	}
+
+	js, err := conn.JetStream(nats.PublishAsyncMaxPending(maxPendingPublishes))
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to create JetStream context: %w", err)
+	}
+
+	log.Printf("Connected to NATS at %s", conn.ConnectedUrl())
+	return &Client{conn: conn, js: js, chaos: cfg.Chaos}, nil
+}
+
+// QueueSubscribe delivers each message on subject to one member of queue
+func (c *Client) QueueSubscribe(subject, queue string, handler nats.MsgHandler) (*nats.Subscription, error) {
+	sub, err := c.conn.QueueSubscribe(subject, queue, func(msg *nats.Msg) {
+		if c.chaos.Drop(msg.Subject) {
+			return
+		}
+		handler(msg)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to queue subscribe to %s: %w", subject, err)
+	}
+
+	log.Printf("Queue subscribed to subject: %s (queue: %s)", subject, queue)
+	return sub, nil
+}
+
+// SubscribeDurable consumes subject from its JetStream stream through the
+// durable consumer durableName, shared by the members of queueGroup. Messages
+// are redelivered until acknowledged, up to 3 times. A message dropped by
+// chaos injection is not acknowledged, so it is redelivered after AckWait.
+func (c *Client) SubscribeDurable(subject, durableName, queueGroup string, handler nats.MsgHandler) (*nats.Subscription, error) {
+	sub, err := c.js.QueueSubscribe(
+		subject,
+		queueGroup,
+		func(msg *nats.Msg) {
+			if c.chaos.Drop(msg.Subject) {
+				return
+			}
+			handler(msg)
+		},
+		nats.Durable(durableName),
+		nats.ManualAck(),
+		nats.AckExplicit(),
+		nats.MaxDeliver(3),
+		nats.AckWait(30*time.Second),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create durable subscription to %s: %w", subject, err)
+	}
+
+	log.Printf("Durable subscription created: %s (durable: %s, queue: %s)", subject, durableName, queueGroup)
+	return sub, nil
+}
+
+// EnsureStream creates a stream retaining messages on subjects for maxAge,
+// and at most maxPerSubject of them per subject, or updates an existing
+// stream of that name to match
+func (c *Client) EnsureStream(streamName string, subjects []string, maxAge time.Duration, maxPerSubject int64) error {
+	cfg := &nats.StreamConfig{
+		Name:              streamName,
+		Subjects:          subjects,
+		Storage:           nats.FileStorage,
+		MaxAge:            maxAge,
+		MaxMsgsPerSubject: maxPerSubject,
+		Retention:         nats.LimitsPolicy,
+	}
+
+	_, err := c.js.AddStream(cfg)
+	if errors.Is(err, nats.ErrStreamNameAlreadyInUse) {
+		_, err = c.js.UpdateStream(cfg)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to ensure stream %s: %w", streamName, err)
+	}
+
+	log.Printf("Stream ready: %s (retention %s)", streamName, maxAge)
+	return nil
+}
+
+// Message is an event published as JSON by PublishBatch
+type Message struct {
+	Subject string
+	// MsgID lets JetStream drop the message when it is published again
+	MsgID string
+	Data  interface{}
+}
+
+// PublishStats counts the messages PublishBatch had acknowledged, published
+// again after a failure, and gave up on
+type PublishStats struct {
+	Published int64
+	Retried   int64
+	Failed    int64
+}
+
+// PublishBatch sends msgs to JetStream without waiting on each in turn, then
+// waits for their acknowledgements. Messages that are not acknowledged are
+// published again, up to maxPublishAttempts times in all, and an error
+// reports those that never were. The message IDs keep a message that was
+// stored but whose acknowledgement was lost from being stored twice.
+func (c *Client) PublishBatch(ctx context.Context, msgs []Message) error {
+	pending := make([]*nats.Msg, 0, len(msgs))
+	spans := make([]trace.Span, 0, len(msgs))
+	defer func() {
+		for _, span := range spans {
+			span.End()
+		}
+	}()
+	for _, m := range msgs {
+		payload, err := json.Marshal(m.Data)
+		if err != nil {
+			return fmt.Errorf("failed to marshal event: %w", err)
+		}
+		if c.chaos.Drop(m.Subject) {
+			continue
+		}
+
+		msg := &nats.Msg{Subject: m.Subject, Data: payload, Header: nats.Header{}}
+		msg.Header.Set(nats.MsgIdHdr, m.MsgID)
+		logging.Inject(ctx, msg.Header)
+		spans = append(spans, tracing.StartPublish(ctx, m.Subject, msg.Header))
+		pending = append(pending, msg)
+	}
+
+	var lastErr error
+	for attempt := 1; len(pending) > 0; attempt++ {
+		if attempt > 1 {
+			c.retried.Add(int64(len(pending)))
+			select {
+			case <-ctx.Done():
+				c.failed.Add(int64(len(pending)))
+				return ctx.Err()
+			case <-time.After(time.Duration(attempt-1) * publishRetryDelay):
+			}
+		}
+
+		var failed []*nats.Msg
+		failed, lastErr = c.publishAndWait(ctx, pending)
+		c.published.Add(int64(len(pending) - len(failed)))
+		pending = failed
+
+		if attempt == maxPublishAttempts || ctx.Err() != nil {
+			break
+		}
+	}
+
+	if len(pending) > 0 {
+		c.failed.Add(int64(len(pending)))
+		return fmt.Errorf("%d of %d messages not acknowledged: %w", len(pending), len(msgs), lastErr)
+	}
+	return nil
+}
+
+// publishAndWait publishes msgs asynchronously and returns those that were
+// not acknowledged, with the last error seen
+func (c *Client) publishAndWait(ctx context.Context, msgs []*nats.Msg) ([]*nats.Msg, error) {
+	var failed []*nats.Msg
+	var lastErr error
+
+	futures := make([]nats.PubAckFuture, len(msgs))
+	for i, msg := range msgs {
+		var err error
+		for {
+			futures[i], err = c.js.PublishMsgAsync(msg)
+			if !errors.Is(err, nats.ErrTooManyStalledMsgs) {
+				break
+			}
+			if err = c.WaitPublished(ctx); err != nil {
+				break
+			}
+		}
+		if err != nil {
+			futures[i] = nil
+			failed = append(failed, msg)
+			lastErr = err
+		}
+	}
+
+	for i, future := range futures {
+		if future == nil {
+			continue
+		}
+		select {
+		case <-future.Ok():
+		case err := <-future.Err():
+			failed = append(failed, msgs[i])
+			lastErr = err
+		case <-ctx.Done():
+			failed = append(failed, msgs[i])
+			lastErr = ctx.Err()
+		}
+	}
+	return failed, lastErr
+}
+
+// PublishStats returns the counts of PublishBatch since start
+func (c *Client) PublishStats() PublishStats {
+	return PublishStats{
+		Published: c.published.Load(),
+		Retried:   c.retried.Load(),
+		Failed:    c.failed.Load(),
+	}
+}
+
+// WaitPublished waits until every asynchronous publish has been acknowledged
+// or has failed
+func (c *Client) WaitPublished(ctx context.Context) error {
+	select {
+	case <-c.js.PublishAsyncComplete():
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (c *Client) Close() {
+	if c.conn != nil {
+		c.conn.Close()
+	}
+}
+
+// Drain stops delivering messages to subscriptions, waits for the handlers
+// still running and for buffered publishes to be sent, and then closes the
+// connection. It closes the connection at once when ctx is done first.
+func (c *Client) Drain(ctx context.Context) error {
+	// Publishes awaiting their acknowledgement are given the chance to get it
+	// before the acknowledgements stop being received
+	if err := c.WaitPublished(ctx); err != nil {
+		c.conn.Close()
+		return err
+	}
+	if err := c.conn.Drain(); err != nil {
+		c.conn.Close()
+		return err
+	}
+	for !c.conn.IsClosed() {
+		select {
+		case <-ctx.Done():
+			c.conn.Close()
+			return ctx.Err()
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+	return nil
+}
+
+// HealthCheck makes a round trip to the NATS server
+func (c *Client) HealthCheck(ctx context.Context) error {
+	return c.conn.FlushWithContext(ctx)
+}
+
+func DecodeEvent(msg *nats.Msg, v interface{}) error {
+	return json.Unmarshal(msg.Data, v)
+}
@@ -23,10 +23,12 @@ const (
 )
 
 type GetFeedRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	First         int32                  `protobuf:"varint,2,opt,name=first,proto3" json:"first,omitempty"`
-	After         *string                `protobuf:"bytes,3,opt,name=after,proto3,oneof" json:"after,omitempty"`
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	First  int32                  `protobuf:"varint,2,opt,name=first,proto3" json:"first,omitempty"`
+	After  *string                `protobuf:"bytes,3,opt,name=after,proto3,oneof" json:"after,omitempty"`
+	// Leaves out posts seen before the feed was ranked instead of ranking them lower
+	ExcludeSeen   bool `protobuf:"varint,4,opt,name=exclude_seen,json=excludeSeen,proto3" json:"exclude_seen,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -82,6 +84,170 @@ func (x *GetFeedRequest) GetAfter() string {
 	return ""
 }
 
+func (x *GetFeedRequest) GetExcludeSeen() bool {
+	if x != nil {
+		return x.ExcludeSeen
+	}
+	return false
+}
+
+type StreamFeedRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	First int32                  `protobuf:"varint,1,opt,name=first,proto3" json:"first,omitempty"`
+	After *string                `protobuf:"bytes,2,opt,name=after,proto3,oneof" json:"after,omitempty"`
+	// Leaves out posts seen before the feed was ranked instead of ranking them lower
+	ExcludeSeen   bool `protobuf:"varint,3,opt,name=exclude_seen,json=excludeSeen,proto3" json:"exclude_seen,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamFeedRequest) Reset() {
+	*x = StreamFeedRequest{}
+	mi := &file_proto_feed_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamFeedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamFeedRequest) ProtoMessage() {}
+
+func (x *StreamFeedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_feed_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamFeedRequest.ProtoReflect.Descriptor instead.
+func (*StreamFeedRequest) Descriptor() ([]byte, []int) {
+	return file_proto_feed_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *StreamFeedRequest) GetFirst() int32 {
+	if x != nil {
+		return x.First
+	}
+	return 0
+}
+
+func (x *StreamFeedRequest) GetAfter() string {
+	if x != nil && x.After != nil {
+		return *x.After
+	}
+	return ""
+}
+
+func (x *StreamFeedRequest) GetExcludeSeen() bool {
+	if x != nil {
+		return x.ExcludeSeen
+	}
+	return false
+}
+
+type GetExploreFeedRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	First         int32                  `protobuf:"varint,1,opt,name=first,proto3" json:"first,omitempty"`
+	After         *string                `protobuf:"bytes,2,opt,name=after,proto3,oneof" json:"after,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetExploreFeedRequest) Reset() {
+	*x = GetExploreFeedRequest{}
+	mi := &file_proto_feed_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetExploreFeedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetExploreFeedRequest) ProtoMessage() {}
+
+func (x *GetExploreFeedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_feed_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetExploreFeedRequest.ProtoReflect.Descriptor instead.
+func (*GetExploreFeedRequest) Descriptor() ([]byte, []int) {
+	return file_proto_feed_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetExploreFeedRequest) GetFirst() int32 {
+	if x != nil {
+		return x.First
+	}
+	return 0
+}
+
+func (x *GetExploreFeedRequest) GetAfter() string {
+	if x != nil && x.After != nil {
+		return *x.After
+	}
+	return ""
+}
+
+type MarkFeedItemsSeenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PostIds       []string               `protobuf:"bytes,1,rep,name=post_ids,json=postIds,proto3" json:"post_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MarkFeedItemsSeenRequest) Reset() {
+	*x = MarkFeedItemsSeenRequest{}
+	mi := &file_proto_feed_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarkFeedItemsSeenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarkFeedItemsSeenRequest) ProtoMessage() {}
+
+func (x *MarkFeedItemsSeenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_feed_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarkFeedItemsSeenRequest.ProtoReflect.Descriptor instead.
+func (*MarkFeedItemsSeenRequest) Descriptor() ([]byte, []int) {
+	return file_proto_feed_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *MarkFeedItemsSeenRequest) GetPostIds() []string {
+	if x != nil {
+		return x.PostIds
+	}
+	return nil
+}
+
 type RefreshFeedRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
@@ -91,7 +257,7 @@ type RefreshFeedRequest struct {
 
 func (x *RefreshFeedRequest) Reset() {
 	*x = RefreshFeedRequest{}
-	mi := &file_proto_feed_proto_msgTypes[1]
+	mi := &file_proto_feed_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -103,7 +269,7 @@ func (x *RefreshFeedRequest) String() string {
 func (*RefreshFeedRequest) ProtoMessage() {}
 
 func (x *RefreshFeedRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_feed_proto_msgTypes[1]
+	mi := &file_proto_feed_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -116,7 +282,7 @@ func (x *RefreshFeedRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RefreshFeedRequest.ProtoReflect.Descriptor instead.
 func (*RefreshFeedRequest) Descriptor() ([]byte, []int) {
-	return file_proto_feed_proto_rawDescGZIP(), []int{1}
+	return file_proto_feed_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *RefreshFeedRequest) GetUserId() string {
@@ -126,6 +292,282 @@ func (x *RefreshFeedRequest) GetUserId() string {
 	return ""
 }
 
+type InspectFeedCacheRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InspectFeedCacheRequest) Reset() {
+	*x = InspectFeedCacheRequest{}
+	mi := &file_proto_feed_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InspectFeedCacheRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InspectFeedCacheRequest) ProtoMessage() {}
+
+func (x *InspectFeedCacheRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_feed_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InspectFeedCacheRequest.ProtoReflect.Descriptor instead.
+func (*InspectFeedCacheRequest) Descriptor() ([]byte, []int) {
+	return file_proto_feed_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *InspectFeedCacheRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *InspectFeedCacheRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type CleanupFeedCacheRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OlderThan     *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=older_than,json=olderThan,proto3" json:"older_than,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CleanupFeedCacheRequest) Reset() {
+	*x = CleanupFeedCacheRequest{}
+	mi := &file_proto_feed_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CleanupFeedCacheRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CleanupFeedCacheRequest) ProtoMessage() {}
+
+func (x *CleanupFeedCacheRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_feed_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CleanupFeedCacheRequest.ProtoReflect.Descriptor instead.
+func (*CleanupFeedCacheRequest) Descriptor() ([]byte, []int) {
+	return file_proto_feed_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CleanupFeedCacheRequest) GetOlderThan() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OlderThan
+	}
+	return nil
+}
+
+type CleanupFeedCacheResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Deleted       int64                  `protobuf:"varint,1,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CleanupFeedCacheResponse) Reset() {
+	*x = CleanupFeedCacheResponse{}
+	mi := &file_proto_feed_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CleanupFeedCacheResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CleanupFeedCacheResponse) ProtoMessage() {}
+
+func (x *CleanupFeedCacheResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_feed_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CleanupFeedCacheResponse.ProtoReflect.Descriptor instead.
+func (*CleanupFeedCacheResponse) Descriptor() ([]byte, []int) {
+	return file_proto_feed_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CleanupFeedCacheResponse) GetDeleted() int64 {
+	if x != nil {
+		return x.Deleted
+	}
+	return 0
+}
+
+type CachedFeedEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PostId        string                 `protobuf:"bytes,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
+	Score         float64                `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CachedFeedEntry) Reset() {
+	*x = CachedFeedEntry{}
+	mi := &file_proto_feed_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CachedFeedEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CachedFeedEntry) ProtoMessage() {}
+
+func (x *CachedFeedEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_feed_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CachedFeedEntry.ProtoReflect.Descriptor instead.
+func (*CachedFeedEntry) Descriptor() ([]byte, []int) {
+	return file_proto_feed_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CachedFeedEntry) GetPostId() string {
+	if x != nil {
+		return x.PostId
+	}
+	return ""
+}
+
+func (x *CachedFeedEntry) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+type FeedCacheInfo struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	UserId         string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	CachedCount    int64                  `protobuf:"varint,2,opt,name=cached_count,json=cachedCount,proto3" json:"cached_count,omitempty"`
+	TtlSeconds     int64                  `protobuf:"varint,3,opt,name=ttl_seconds,json=ttlSeconds,proto3" json:"ttl_seconds,omitempty"`
+	Entries        []*CachedFeedEntry     `protobuf:"bytes,4,rep,name=entries,proto3" json:"entries,omitempty"`
+	StoredCount    int32                  `protobuf:"varint,5,opt,name=stored_count,json=storedCount,proto3" json:"stored_count,omitempty"`
+	FollowingCount int32                  `protobuf:"varint,6,opt,name=following_count,json=followingCount,proto3" json:"following_count,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *FeedCacheInfo) Reset() {
+	*x = FeedCacheInfo{}
+	mi := &file_proto_feed_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FeedCacheInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FeedCacheInfo) ProtoMessage() {}
+
+func (x *FeedCacheInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_feed_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FeedCacheInfo.ProtoReflect.Descriptor instead.
+func (*FeedCacheInfo) Descriptor() ([]byte, []int) {
+	return file_proto_feed_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *FeedCacheInfo) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *FeedCacheInfo) GetCachedCount() int64 {
+	if x != nil {
+		return x.CachedCount
+	}
+	return 0
+}
+
+func (x *FeedCacheInfo) GetTtlSeconds() int64 {
+	if x != nil {
+		return x.TtlSeconds
+	}
+	return 0
+}
+
+func (x *FeedCacheInfo) GetEntries() []*CachedFeedEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *FeedCacheInfo) GetStoredCount() int32 {
+	if x != nil {
+		return x.StoredCount
+	}
+	return 0
+}
+
+func (x *FeedCacheInfo) GetFollowingCount() int32 {
+	if x != nil {
+		return x.FollowingCount
+	}
+	return 0
+}
+
 type Post struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -136,13 +578,15 @@ type Post struct {
 	LikesCount    int32                  `protobuf:"varint,6,opt,name=likes_count,json=likesCount,proto3" json:"likes_count,omitempty"`
 	CommentsCount int32                  `protobuf:"varint,7,opt,name=comments_count,json=commentsCount,proto3" json:"comments_count,omitempty"`
 	IsLiked       *bool                  `protobuf:"varint,8,opt,name=is_liked,json=isLiked,proto3,oneof" json:"is_liked,omitempty"`
+	// PUBLIC or FOLLOWERS_ONLY; private posts never reach feeds
+	Visibility    string `protobuf:"bytes,9,opt,name=visibility,proto3" json:"visibility,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Post) Reset() {
 	*x = Post{}
-	mi := &file_proto_feed_proto_msgTypes[2]
+	mi := &file_proto_feed_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -154,7 +598,7 @@ func (x *Post) String() string {
 func (*Post) ProtoMessage() {}
 
 func (x *Post) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_feed_proto_msgTypes[2]
+	mi := &file_proto_feed_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -167,7 +611,7 @@ func (x *Post) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Post.ProtoReflect.Descriptor instead.
 func (*Post) Descriptor() ([]byte, []int) {
-	return file_proto_feed_proto_rawDescGZIP(), []int{2}
+	return file_proto_feed_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *Post) GetId() string {
@@ -226,6 +670,13 @@ func (x *Post) GetIsLiked() bool {
 	return false
 }
 
+func (x *Post) GetVisibility() string {
+	if x != nil {
+		return x.Visibility
+	}
+	return ""
+}
+
 type PostEdge struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Cursor        string                 `protobuf:"bytes,1,opt,name=cursor,proto3" json:"cursor,omitempty"`
@@ -236,7 +687,7 @@ type PostEdge struct {
 
 func (x *PostEdge) Reset() {
 	*x = PostEdge{}
-	mi := &file_proto_feed_proto_msgTypes[3]
+	mi := &file_proto_feed_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -248,7 +699,7 @@ func (x *PostEdge) String() string {
 func (*PostEdge) ProtoMessage() {}
 
 func (x *PostEdge) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_feed_proto_msgTypes[3]
+	mi := &file_proto_feed_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -261,7 +712,7 @@ func (x *PostEdge) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PostEdge.ProtoReflect.Descriptor instead.
 func (*PostEdge) Descriptor() ([]byte, []int) {
-	return file_proto_feed_proto_rawDescGZIP(), []int{3}
+	return file_proto_feed_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *PostEdge) GetCursor() string {
@@ -290,7 +741,7 @@ type PageInfo struct {
 
 func (x *PageInfo) Reset() {
 	*x = PageInfo{}
-	mi := &file_proto_feed_proto_msgTypes[4]
+	mi := &file_proto_feed_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -302,7 +753,7 @@ func (x *PageInfo) String() string {
 func (*PageInfo) ProtoMessage() {}
 
 func (x *PageInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_feed_proto_msgTypes[4]
+	mi := &file_proto_feed_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -315,7 +766,7 @@ func (x *PageInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PageInfo.ProtoReflect.Descriptor instead.
 func (*PageInfo) Descriptor() ([]byte, []int) {
-	return file_proto_feed_proto_rawDescGZIP(), []int{4}
+	return file_proto_feed_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *PageInfo) GetEndCursor() string {
@@ -347,17 +798,19 @@ func (x *PageInfo) GetHasPreviousPage() bool {
 }
 
 type PostConnection struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Edges         []*PostEdge            `protobuf:"bytes,1,rep,name=edges,proto3" json:"edges,omitempty"`
-	PageInfo      *PageInfo              `protobuf:"bytes,2,opt,name=page_info,json=pageInfo,proto3" json:"page_info,omitempty"`
-	TotalCount    int32                  `protobuf:"varint,3,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Edges      []*PostEdge            `protobuf:"bytes,1,rep,name=edges,proto3" json:"edges,omitempty"`
+	PageInfo   *PageInfo              `protobuf:"bytes,2,opt,name=page_info,json=pageInfo,proto3" json:"page_info,omitempty"`
+	TotalCount int32                  `protobuf:"varint,3,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	// Strategy that ranked the page, for analysing ranking experiments
+	RankingStrategy string `protobuf:"bytes,4,opt,name=ranking_strategy,json=rankingStrategy,proto3" json:"ranking_strategy,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *PostConnection) Reset() {
 	*x = PostConnection{}
-	mi := &file_proto_feed_proto_msgTypes[5]
+	mi := &file_proto_feed_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -369,7 +822,7 @@ func (x *PostConnection) String() string {
 func (*PostConnection) ProtoMessage() {}
 
 func (x *PostConnection) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_feed_proto_msgTypes[5]
+	mi := &file_proto_feed_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -382,7 +835,7 @@ func (x *PostConnection) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PostConnection.ProtoReflect.Descriptor instead.
 func (*PostConnection) Descriptor() ([]byte, []int) {
-	return file_proto_feed_proto_rawDescGZIP(), []int{5}
+	return file_proto_feed_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *PostConnection) GetEdges() []*PostEdge {
@@ -406,6 +859,84 @@ func (x *PostConnection) GetTotalCount() int32 {
 	return 0
 }
 
+func (x *PostConnection) GetRankingStrategy() string {
+	if x != nil {
+		return x.RankingStrategy
+	}
+	return ""
+}
+
+// A part of a streamed feed page. Chunks carry the page's edges in feed
+// order; the last one also carries the page's info.
+type FeedChunk struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Edges []*PostEdge            `protobuf:"bytes,1,rep,name=edges,proto3" json:"edges,omitempty"`
+	// Set on the last chunk only
+	PageInfo        *PageInfo `protobuf:"bytes,2,opt,name=page_info,json=pageInfo,proto3" json:"page_info,omitempty"`
+	TotalCount      int32     `protobuf:"varint,3,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	RankingStrategy string    `protobuf:"bytes,4,opt,name=ranking_strategy,json=rankingStrategy,proto3" json:"ranking_strategy,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *FeedChunk) Reset() {
+	*x = FeedChunk{}
+	mi := &file_proto_feed_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FeedChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FeedChunk) ProtoMessage() {}
+
+func (x *FeedChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_feed_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FeedChunk.ProtoReflect.Descriptor instead.
+func (*FeedChunk) Descriptor() ([]byte, []int) {
+	return file_proto_feed_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *FeedChunk) GetEdges() []*PostEdge {
+	if x != nil {
+		return x.Edges
+	}
+	return nil
+}
+
+func (x *FeedChunk) GetPageInfo() *PageInfo {
+	if x != nil {
+		return x.PageInfo
+	}
+	return nil
+}
+
+func (x *FeedChunk) GetTotalCount() int32 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+func (x *FeedChunk) GetRankingStrategy() string {
+	if x != nil {
+		return x.RankingStrategy
+	}
+	return ""
+}
+
 type Response struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
@@ -416,7 +947,7 @@ type Response struct {
 
 func (x *Response) Reset() {
 	*x = Response{}
-	mi := &file_proto_feed_proto_msgTypes[6]
+	mi := &file_proto_feed_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -428,7 +959,7 @@ func (x *Response) String() string {
 func (*Response) ProtoMessage() {}
 
 func (x *Response) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_feed_proto_msgTypes[6]
+	mi := &file_proto_feed_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -441,7 +972,7 @@ func (x *Response) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Response.ProtoReflect.Descriptor instead.
 func (*Response) Descriptor() ([]byte, []int) {
-	return file_proto_feed_proto_rawDescGZIP(), []int{6}
+	return file_proto_feed_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *Response) GetSuccess() bool {
@@ -462,14 +993,45 @@ var File_proto_feed_proto protoreflect.FileDescriptor
 
 const file_proto_feed_proto_rawDesc = "" +
 	"\n" +
-	"\x10proto/feed.proto\x12\x04feed\x1a\x1fgoogle/protobuf/timestamp.proto\"d\n" +
+	"\x10proto/feed.proto\x12\x04feed\x1a\x1fgoogle/protobuf/timestamp.proto\"\x87\x01\n" +
 	"\x0eGetFeedRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x14\n" +
 	"\x05first\x18\x02 \x01(\x05R\x05first\x12\x19\n" +
-	"\x05after\x18\x03 \x01(\tH\x00R\x05after\x88\x01\x01B\b\n" +
-	"\x06_after\"-\n" +
+	"\x05after\x18\x03 \x01(\tH\x00R\x05after\x88\x01\x01\x12!\n" +
+	"\fexclude_seen\x18\x04 \x01(\bR\vexcludeSeenB\b\n" +
+	"\x06_after\"q\n" +
+	"\x11StreamFeedRequest\x12\x14\n" +
+	"\x05first\x18\x01 \x01(\x05R\x05first\x12\x19\n" +
+	"\x05after\x18\x02 \x01(\tH\x00R\x05after\x88\x01\x01\x12!\n" +
+	"\fexclude_seen\x18\x03 \x01(\bR\vexcludeSeenB\b\n" +
+	"\x06_after\"R\n" +
+	"\x15GetExploreFeedRequest\x12\x14\n" +
+	"\x05first\x18\x01 \x01(\x05R\x05first\x12\x19\n" +
+	"\x05after\x18\x02 \x01(\tH\x00R\x05after\x88\x01\x01B\b\n" +
+	"\x06_after\"5\n" +
+	"\x18MarkFeedItemsSeenRequest\x12\x19\n" +
+	"\bpost_ids\x18\x01 \x03(\tR\apostIds\"-\n" +
 	"\x12RefreshFeedRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\"\xb4\x02\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"H\n" +
+	"\x17InspectFeedCacheRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"T\n" +
+	"\x17CleanupFeedCacheRequest\x129\n" +
+	"\n" +
+	"older_than\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\tolderThan\"4\n" +
+	"\x18CleanupFeedCacheResponse\x12\x18\n" +
+	"\adeleted\x18\x01 \x01(\x03R\adeleted\"@\n" +
+	"\x0fCachedFeedEntry\x12\x17\n" +
+	"\apost_id\x18\x01 \x01(\tR\x06postId\x12\x14\n" +
+	"\x05score\x18\x02 \x01(\x01R\x05score\"\xe9\x01\n" +
+	"\rFeedCacheInfo\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12!\n" +
+	"\fcached_count\x18\x02 \x01(\x03R\vcachedCount\x12\x1f\n" +
+	"\vttl_seconds\x18\x03 \x01(\x03R\n" +
+	"ttlSeconds\x12/\n" +
+	"\aentries\x18\x04 \x03(\v2\x15.feed.CachedFeedEntryR\aentries\x12!\n" +
+	"\fstored_count\x18\x05 \x01(\x05R\vstoredCount\x12'\n" +
+	"\x0ffollowing_count\x18\x06 \x01(\x05R\x0efollowingCount\"\xd4\x02\n" +
 	"\x04Post\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x18\n" +
@@ -481,7 +1043,10 @@ const file_proto_feed_proto_rawDesc = "" +
 	"\vlikes_count\x18\x06 \x01(\x05R\n" +
 	"likesCount\x12%\n" +
 	"\x0ecomments_count\x18\a \x01(\x05R\rcommentsCount\x12\x1e\n" +
-	"\bis_liked\x18\b \x01(\bH\x00R\aisLiked\x88\x01\x01B\v\n" +
+	"\bis_liked\x18\b \x01(\bH\x00R\aisLiked\x88\x01\x01\x12\x1e\n" +
+	"\n" +
+	"visibility\x18\t \x01(\tR\n" +
+	"visibilityB\v\n" +
 	"\t_is_liked\"B\n" +
 	"\bPostEdge\x12\x16\n" +
 	"\x06cursor\x18\x01 \x01(\tR\x06cursor\x12\x1e\n" +
@@ -494,17 +1059,31 @@ const file_proto_feed_proto_rawDesc = "" +
 	"\fstart_cursor\x18\x03 \x01(\tH\x01R\vstartCursor\x88\x01\x01\x12*\n" +
 	"\x11has_previous_page\x18\x04 \x01(\bR\x0fhasPreviousPageB\r\n" +
 	"\v_end_cursorB\x0f\n" +
-	"\r_start_cursor\"\x84\x01\n" +
+	"\r_start_cursor\"\xaf\x01\n" +
 	"\x0ePostConnection\x12$\n" +
 	"\x05edges\x18\x01 \x03(\v2\x0e.feed.PostEdgeR\x05edges\x12+\n" +
 	"\tpage_info\x18\x02 \x01(\v2\x0e.feed.PageInfoR\bpageInfo\x12\x1f\n" +
 	"\vtotal_count\x18\x03 \x01(\x05R\n" +
-	"totalCount\">\n" +
+	"totalCount\x12)\n" +
+	"\x10ranking_strategy\x18\x04 \x01(\tR\x0frankingStrategy\"\xaa\x01\n" +
+	"\tFeedChunk\x12$\n" +
+	"\x05edges\x18\x01 \x03(\v2\x0e.feed.PostEdgeR\x05edges\x12+\n" +
+	"\tpage_info\x18\x02 \x01(\v2\x0e.feed.PageInfoR\bpageInfo\x12\x1f\n" +
+	"\vtotal_count\x18\x03 \x01(\x05R\n" +
+	"totalCount\x12)\n" +
+	"\x10ranking_strategy\x18\x04 \x01(\tR\x0frankingStrategy\">\n" +
 	"\bResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage2D\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage2\xe1\x03\n" +
 	"\vFeedService\x125\n" +
-	"\aGetFeed\x12\x14.feed.GetFeedRequest\x1a\x14.feed.PostConnectionB\x04Z\x02./b\x06proto3"
+	"\aGetFeed\x12\x14.feed.GetFeedRequest\x1a\x14.feed.PostConnection\x128\n" +
+	"\n" +
+	"StreamFeed\x12\x17.feed.StreamFeedRequest\x1a\x0f.feed.FeedChunk0\x01\x12C\n" +
+	"\x11MarkFeedItemsSeen\x12\x1e.feed.MarkFeedItemsSeenRequest\x1a\x0e.feed.Response\x12C\n" +
+	"\x0eGetExploreFeed\x12\x1b.feed.GetExploreFeedRequest\x1a\x14.feed.PostConnection\x12F\n" +
+	"\x10InspectFeedCache\x12\x1d.feed.InspectFeedCacheRequest\x1a\x13.feed.FeedCacheInfo\x12<\n" +
+	"\x10RebuildFeedCache\x12\x18.feed.RefreshFeedRequest\x1a\x0e.feed.Response\x12Q\n" +
+	"\x10CleanupFeedCache\x12\x1d.feed.CleanupFeedCacheRequest\x1a\x1e.feed.CleanupFeedCacheResponseB\x04Z\x02./b\x06proto3"
 
 var (
 	file_proto_feed_proto_rawDescOnce sync.Once
@@ -518,30 +1097,55 @@ func file_proto_feed_proto_rawDescGZIP() []byte {
 	return file_proto_feed_proto_rawDescData
 }
 
-var file_proto_feed_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_proto_feed_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
 var file_proto_feed_proto_goTypes = []any{
-	(*GetFeedRequest)(nil),        // 0: feed.GetFeedRequest
-	(*RefreshFeedRequest)(nil),    // 1: feed.RefreshFeedRequest
-	(*Post)(nil),                  // 2: feed.Post
-	(*PostEdge)(nil),              // 3: feed.PostEdge
-	(*PageInfo)(nil),              // 4: feed.PageInfo
-	(*PostConnection)(nil),        // 5: feed.PostConnection
-	(*Response)(nil),              // 6: feed.Response
-	(*timestamppb.Timestamp)(nil), // 7: google.protobuf.Timestamp
+	(*GetFeedRequest)(nil),           // 0: feed.GetFeedRequest
+	(*StreamFeedRequest)(nil),        // 1: feed.StreamFeedRequest
+	(*GetExploreFeedRequest)(nil),    // 2: feed.GetExploreFeedRequest
+	(*MarkFeedItemsSeenRequest)(nil), // 3: feed.MarkFeedItemsSeenRequest
+	(*RefreshFeedRequest)(nil),       // 4: feed.RefreshFeedRequest
+	(*InspectFeedCacheRequest)(nil),  // 5: feed.InspectFeedCacheRequest
+	(*CleanupFeedCacheRequest)(nil),  // 6: feed.CleanupFeedCacheRequest
+	(*CleanupFeedCacheResponse)(nil), // 7: feed.CleanupFeedCacheResponse
+	(*CachedFeedEntry)(nil),          // 8: feed.CachedFeedEntry
+	(*FeedCacheInfo)(nil),            // 9: feed.FeedCacheInfo
+	(*Post)(nil),                     // 10: feed.Post
+	(*PostEdge)(nil),                 // 11: feed.PostEdge
+	(*PageInfo)(nil),                 // 12: feed.PageInfo
+	(*PostConnection)(nil),           // 13: feed.PostConnection
+	(*FeedChunk)(nil),                // 14: feed.FeedChunk
+	(*Response)(nil),                 // 15: feed.Response
+	(*timestamppb.Timestamp)(nil),    // 16: google.protobuf.Timestamp
 }
 var file_proto_feed_proto_depIdxs = []int32{
-	7, // 0: feed.Post.created_at:type_name -> google.protobuf.Timestamp
-	7, // 1: feed.Post.updated_at:type_name -> google.protobuf.Timestamp
-	2, // 2: feed.PostEdge.node:type_name -> feed.Post
-	3, // 3: feed.PostConnection.edges:type_name -> feed.PostEdge
-	4, // 4: feed.PostConnection.page_info:type_name -> feed.PageInfo
-	0, // 5: feed.FeedService.GetFeed:input_type -> feed.GetFeedRequest
-	5, // 6: feed.FeedService.GetFeed:output_type -> feed.PostConnection
-	6, // [6:7] is the sub-list for method output_type
-	5, // [5:6] is the sub-list for method input_type
-	5, // [5:5] is the sub-list for extension type_name
-	5, // [5:5] is the sub-list for extension extendee
-	0, // [0:5] is the sub-list for field type_name
+	16, // 0: feed.CleanupFeedCacheRequest.older_than:type_name -> google.protobuf.Timestamp
+	8,  // 1: feed.FeedCacheInfo.entries:type_name -> feed.CachedFeedEntry
+	16, // 2: feed.Post.created_at:type_name -> google.protobuf.Timestamp
+	16, // 3: feed.Post.updated_at:type_name -> google.protobuf.Timestamp
+	10, // 4: feed.PostEdge.node:type_name -> feed.Post
+	11, // 5: feed.PostConnection.edges:type_name -> feed.PostEdge
+	12, // 6: feed.PostConnection.page_info:type_name -> feed.PageInfo
+	11, // 7: feed.FeedChunk.edges:type_name -> feed.PostEdge
+	12, // 8: feed.FeedChunk.page_info:type_name -> feed.PageInfo
+	0,  // 9: feed.FeedService.GetFeed:input_type -> feed.GetFeedRequest
+	1,  // 10: feed.FeedService.StreamFeed:input_type -> feed.StreamFeedRequest
+	3,  // 11: feed.FeedService.MarkFeedItemsSeen:input_type -> feed.MarkFeedItemsSeenRequest
+	2,  // 12: feed.FeedService.GetExploreFeed:input_type -> feed.GetExploreFeedRequest
+	5,  // 13: feed.FeedService.InspectFeedCache:input_type -> feed.InspectFeedCacheRequest
+	4,  // 14: feed.FeedService.RebuildFeedCache:input_type -> feed.RefreshFeedRequest
+	6,  // 15: feed.FeedService.CleanupFeedCache:input_type -> feed.CleanupFeedCacheRequest
+	13, // 16: feed.FeedService.GetFeed:output_type -> feed.PostConnection
+	14, // 17: feed.FeedService.StreamFeed:output_type -> feed.FeedChunk
+	15, // 18: feed.FeedService.MarkFeedItemsSeen:output_type -> feed.Response
+	13, // 19: feed.FeedService.GetExploreFeed:output_type -> feed.PostConnection
+	9,  // 20: feed.FeedService.InspectFeedCache:output_type -> feed.FeedCacheInfo
+	15, // 21: feed.FeedService.RebuildFeedCache:output_type -> feed.Response
+	7,  // 22: feed.FeedService.CleanupFeedCache:output_type -> feed.CleanupFeedCacheResponse
+	16, // [16:23] is the sub-list for method output_type
+	9,  // [9:16] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
 }
 
 func init() { file_proto_feed_proto_init() }
@@ -550,15 +1154,17 @@ func file_proto_feed_proto_init() {
 		return
 	}
 	file_proto_feed_proto_msgTypes[0].OneofWrappers = []any{}
+	file_proto_feed_proto_msgTypes[1].OneofWrappers = []any{}
 	file_proto_feed_proto_msgTypes[2].OneofWrappers = []any{}
-	file_proto_feed_proto_msgTypes[4].OneofWrappers = []any{}
+	file_proto_feed_proto_msgTypes[10].OneofWrappers = []any{}
+	file_proto_feed_proto_msgTypes[12].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_feed_proto_rawDesc), len(file_proto_feed_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   7,
+			NumMessages:   16,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
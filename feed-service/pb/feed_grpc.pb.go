@@ -19,7 +19,13 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	FeedService_GetFeed_FullMethodName = "/feed.FeedService/GetFeed"
+	FeedService_GetFeed_FullMethodName           = "/feed.FeedService/GetFeed"
+	FeedService_StreamFeed_FullMethodName        = "/feed.FeedService/StreamFeed"
+	FeedService_MarkFeedItemsSeen_FullMethodName = "/feed.FeedService/MarkFeedItemsSeen"
+	FeedService_GetExploreFeed_FullMethodName    = "/feed.FeedService/GetExploreFeed"
+	FeedService_InspectFeedCache_FullMethodName  = "/feed.FeedService/InspectFeedCache"
+	FeedService_RebuildFeedCache_FullMethodName  = "/feed.FeedService/RebuildFeedCache"
+	FeedService_CleanupFeedCache_FullMethodName  = "/feed.FeedService/CleanupFeedCache"
 )
 
 // FeedServiceClient is the client API for FeedService service.
@@ -27,6 +33,17 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type FeedServiceClient interface {
 	GetFeed(ctx context.Context, in *GetFeedRequest, opts ...grpc.CallOption) (*PostConnection, error)
+	// Streams a page of the caller's feed, sending its posts in order as they
+	// are hydrated instead of once the whole page is
+	StreamFeed(ctx context.Context, in *StreamFeedRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[FeedChunk], error)
+	// Records posts of the caller's feed as seen, e.g. as they scroll past
+	MarkFeedItemsSeen(ctx context.Context, in *MarkFeedItemsSeenRequest, opts ...grpc.CallOption) (*Response, error)
+	// Pages through posts trending across the network by users the caller does not follow
+	GetExploreFeed(ctx context.Context, in *GetExploreFeedRequest, opts ...grpc.CallOption) (*PostConnection, error)
+	// Admin operations (require the ADMIN role)
+	InspectFeedCache(ctx context.Context, in *InspectFeedCacheRequest, opts ...grpc.CallOption) (*FeedCacheInfo, error)
+	RebuildFeedCache(ctx context.Context, in *RefreshFeedRequest, opts ...grpc.CallOption) (*Response, error)
+	CleanupFeedCache(ctx context.Context, in *CleanupFeedCacheRequest, opts ...grpc.CallOption) (*CleanupFeedCacheResponse, error)
 }
 
 type feedServiceClient struct {
@@ -47,11 +64,91 @@ func (c *feedServiceClient) GetFeed(ctx context.Context, in *GetFeedRequest, opt
 	return out, nil
 }
 
+func (c *feedServiceClient) StreamFeed(ctx context.Context, in *StreamFeedRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[FeedChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &FeedService_ServiceDesc.Streams[0], FeedService_StreamFeed_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamFeedRequest, FeedChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type FeedService_StreamFeedClient = grpc.ServerStreamingClient[FeedChunk]
+
+func (c *feedServiceClient) MarkFeedItemsSeen(ctx context.Context, in *MarkFeedItemsSeenRequest, opts ...grpc.CallOption) (*Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Response)
+	err := c.cc.Invoke(ctx, FeedService_MarkFeedItemsSeen_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *feedServiceClient) GetExploreFeed(ctx context.Context, in *GetExploreFeedRequest, opts ...grpc.CallOption) (*PostConnection, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PostConnection)
+	err := c.cc.Invoke(ctx, FeedService_GetExploreFeed_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *feedServiceClient) InspectFeedCache(ctx context.Context, in *InspectFeedCacheRequest, opts ...grpc.CallOption) (*FeedCacheInfo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FeedCacheInfo)
+	err := c.cc.Invoke(ctx, FeedService_InspectFeedCache_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *feedServiceClient) RebuildFeedCache(ctx context.Context, in *RefreshFeedRequest, opts ...grpc.CallOption) (*Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Response)
+	err := c.cc.Invoke(ctx, FeedService_RebuildFeedCache_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *feedServiceClient) CleanupFeedCache(ctx context.Context, in *CleanupFeedCacheRequest, opts ...grpc.CallOption) (*CleanupFeedCacheResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CleanupFeedCacheResponse)
+	err := c.cc.Invoke(ctx, FeedService_CleanupFeedCache_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // FeedServiceServer is the server API for FeedService service.
 // All implementations must embed UnimplementedFeedServiceServer
 // for forward compatibility.
 type FeedServiceServer interface {
 	GetFeed(context.Context, *GetFeedRequest) (*PostConnection, error)
+	// Streams a page of the caller's feed, sending its posts in order as they
+	// are hydrated instead of once the whole page is
+	StreamFeed(*StreamFeedRequest, grpc.ServerStreamingServer[FeedChunk]) error
+	// Records posts of the caller's feed as seen, e.g. as they scroll past
+	MarkFeedItemsSeen(context.Context, *MarkFeedItemsSeenRequest) (*Response, error)
+	// Pages through posts trending across the network by users the caller does not follow
+	GetExploreFeed(context.Context, *GetExploreFeedRequest) (*PostConnection, error)
+	// Admin operations (require the ADMIN role)
+	InspectFeedCache(context.Context, *InspectFeedCacheRequest) (*FeedCacheInfo, error)
+	RebuildFeedCache(context.Context, *RefreshFeedRequest) (*Response, error)
+	CleanupFeedCache(context.Context, *CleanupFeedCacheRequest) (*CleanupFeedCacheResponse, error)
 	mustEmbedUnimplementedFeedServiceServer()
 }
 
@@ -65,6 +162,24 @@ type UnimplementedFeedServiceServer struct{}
 func (UnimplementedFeedServiceServer) GetFeed(context.Context, *GetFeedRequest) (*PostConnection, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetFeed not implemented")
 }
+func (UnimplementedFeedServiceServer) StreamFeed(*StreamFeedRequest, grpc.ServerStreamingServer[FeedChunk]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamFeed not implemented")
+}
+func (UnimplementedFeedServiceServer) MarkFeedItemsSeen(context.Context, *MarkFeedItemsSeenRequest) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MarkFeedItemsSeen not implemented")
+}
+func (UnimplementedFeedServiceServer) GetExploreFeed(context.Context, *GetExploreFeedRequest) (*PostConnection, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetExploreFeed not implemented")
+}
+func (UnimplementedFeedServiceServer) InspectFeedCache(context.Context, *InspectFeedCacheRequest) (*FeedCacheInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InspectFeedCache not implemented")
+}
+func (UnimplementedFeedServiceServer) RebuildFeedCache(context.Context, *RefreshFeedRequest) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RebuildFeedCache not implemented")
+}
+func (UnimplementedFeedServiceServer) CleanupFeedCache(context.Context, *CleanupFeedCacheRequest) (*CleanupFeedCacheResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CleanupFeedCache not implemented")
+}
 func (UnimplementedFeedServiceServer) mustEmbedUnimplementedFeedServiceServer() {}
 func (UnimplementedFeedServiceServer) testEmbeddedByValue()                     {}
 
@@ -104,6 +219,107 @@ func _FeedService_GetFeed_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _FeedService_StreamFeed_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamFeedRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(FeedServiceServer).StreamFeed(m, &grpc.GenericServerStream[StreamFeedRequest, FeedChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type FeedService_StreamFeedServer = grpc.ServerStreamingServer[FeedChunk]
+
+func _FeedService_MarkFeedItemsSeen_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MarkFeedItemsSeenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FeedServiceServer).MarkFeedItemsSeen(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FeedService_MarkFeedItemsSeen_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeedServiceServer).MarkFeedItemsSeen(ctx, req.(*MarkFeedItemsSeenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FeedService_GetExploreFeed_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetExploreFeedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FeedServiceServer).GetExploreFeed(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FeedService_GetExploreFeed_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeedServiceServer).GetExploreFeed(ctx, req.(*GetExploreFeedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FeedService_InspectFeedCache_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InspectFeedCacheRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FeedServiceServer).InspectFeedCache(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FeedService_InspectFeedCache_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeedServiceServer).InspectFeedCache(ctx, req.(*InspectFeedCacheRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FeedService_RebuildFeedCache_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefreshFeedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FeedServiceServer).RebuildFeedCache(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FeedService_RebuildFeedCache_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeedServiceServer).RebuildFeedCache(ctx, req.(*RefreshFeedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FeedService_CleanupFeedCache_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CleanupFeedCacheRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FeedServiceServer).CleanupFeedCache(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FeedService_CleanupFeedCache_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeedServiceServer).CleanupFeedCache(ctx, req.(*CleanupFeedCacheRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // FeedService_ServiceDesc is the grpc.ServiceDesc for FeedService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -115,7 +331,33 @@ var FeedService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetFeed",
 			Handler:    _FeedService_GetFeed_Handler,
 		},
+		{
+			MethodName: "MarkFeedItemsSeen",
+			Handler:    _FeedService_MarkFeedItemsSeen_Handler,
+		},
+		{
+			MethodName: "GetExploreFeed",
+			Handler:    _FeedService_GetExploreFeed_Handler,
+		},
+		{
+			MethodName: "InspectFeedCache",
+			Handler:    _FeedService_InspectFeedCache_Handler,
+		},
+		{
+			MethodName: "RebuildFeedCache",
+			Handler:    _FeedService_RebuildFeedCache_Handler,
+		},
+		{
+			MethodName: "CleanupFeedCache",
+			Handler:    _FeedService_CleanupFeedCache_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamFeed",
+			Handler:       _FeedService_StreamFeed_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/feed.proto",
 }
@@ -0,0 +1,93 @@
+package ranking
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// Assigner decides which strategy ranks each user's feed. A strategy set for
+// the user in Redis under feed:ranking:<user-id> wins; otherwise users are
+// split between experiment arms by a hash of their ID, so a user stays in the
+// same arm, and users outside every arm get the default strategy.
+type Assigner struct {
+	redis    *redis.Client
+	fallback Strategy
+	arms     []arm
+}
+
+type arm struct {
+	strategy Strategy
+	percent  int
+}
+
+// NewAssigner returns an assigner with the default strategy defaultName and
+// the arms in experiment, a comma-separated list of name=percent pairs such
+// as "chronological=10,affinity=10". Arms may take up to 100% of users.
+func NewAssigner(redis *redis.Client, defaultName, experiment string) (*Assigner, error) {
+	fallback, ok := Lookup(defaultName)
+	if !ok {
+		return nil, fmt.Errorf("unknown ranking strategy %q", defaultName)
+	}
+
+	a := &Assigner{redis: redis, fallback: fallback}
+
+	total := 0
+	for _, part := range strings.Split(experiment, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		name, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid experiment arm %q: expected name=percent", part)
+		}
+		strategy, ok := Lookup(strings.TrimSpace(name))
+		if !ok {
+			return nil, fmt.Errorf("unknown ranking strategy %q", name)
+		}
+		percent, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil || percent < 0 {
+			return nil, fmt.Errorf("invalid experiment arm %q: percent must be a non-negative integer", part)
+		}
+
+		total += percent
+		a.arms = append(a.arms, arm{strategy: strategy, percent: percent})
+	}
+	if total > 100 {
+		return nil, fmt.Errorf("experiment arms take %d%% of users, more than 100%%", total)
+	}
+
+	return a, nil
+}
+
+// Assign returns the strategy ranking userID's feed. A failed Redis lookup
+// falls back to the experiment arms.
+func (a *Assigner) Assign(ctx context.Context, userID uuid.UUID) Strategy {
+	if a.redis != nil {
+		name, err := a.redis.Get(ctx, fmt.Sprintf("feed:ranking:%s", userID.String())).Result()
+		if err == nil {
+			if strategy, ok := Lookup(name); ok {
+				return strategy
+			}
+		}
+	}
+
+	h := fnv.New32a()
+	h.Write(userID[:])
+	bucket := int(h.Sum32() % 100)
+
+	for _, arm := range a.arms {
+		if bucket < arm.percent {
+			return arm.strategy
+		}
+		bucket -= arm.percent
+	}
+	return a.fallback
+}
@@ -0,0 +1,105 @@
+// Package ranking orders the posts of a feed. A Strategy scores each
+// candidate post, and Rank sorts candidates by score and then by post ID,
+// both descending, which is the order feed cursors page through. Scores only
+// depend on the candidate and the time the feed is ranked as of, so every
+// page of one feed is ranked the same way.
+package ranking
+
+import (
+	"bytes"
+	"math"
+	"sort"
+	"time"
+
+	"feed-service/model"
+)
+
+// Strategy scores feed candidates
+type Strategy interface {
+	// Name identifies the strategy in configuration, caches and responses
+	Name() string
+	// Score returns how high a candidate ranks in a feed ranked as of asOf
+	Score(candidate models.FeedCandidate, asOf time.Time) float64
+}
+
+// Strategy names
+const (
+	Chronological = "chronological"
+	Engagement    = "engagement"
+	Affinity      = "affinity"
+)
+
+var strategies = map[string]Strategy{
+	Chronological: chronological{},
+	Engagement:    engagement{},
+	Affinity:      affinity{},
+}
+
+// Lookup returns the strategy registered under name
+func Lookup(name string) (Strategy, bool) {
+	strategy, ok := strategies[name]
+	return strategy, ok
+}
+
+// Rank scores candidates with strategy and sorts them into feed order
+func Rank(strategy Strategy, candidates []models.FeedCandidate, asOf time.Time) []models.RankedPost {
+	ranked := make([]models.RankedPost, len(candidates))
+	for i, candidate := range candidates {
+		ranked[i] = models.RankedPost{
+			Post:  candidate.Post,
+			Score: strategy.Score(candidate, asOf),
+		}
+	}
+
+	sort.Slice(ranked, func(i, j int) bool {
+		return Before(ranked[i].Score, ranked[i].ID[:], ranked[j].Score, ranked[j].ID[:])
+	})
+	return ranked
+}
+
+// Before reports whether the post with score a and ID idA comes before the
+// one with score b and ID idB in feed order
+func Before(a float64, idA []byte, b float64, idB []byte) bool {
+	if a != b {
+		return a > b
+	}
+	return bytes.Compare(idA, idB) > 0
+}
+
+// chronological ranks the newest posts, or latest reposts, first. Seen posts
+// keep their place.
+type chronological struct{}
+
+func (chronological) Name() string { return Chronological }
+
+func (chronological) Score(c models.FeedCandidate, _ time.Time) float64 {
+	return float64(c.RankedAt.UnixMicro()) / 1e6
+}
+
+// engagement mixes recency, decaying over a day, with likes and comments,
+// and halves the score of seen posts
+type engagement struct{}
+
+func (engagement) Name() string { return Engagement }
+
+func (engagement) Score(c models.FeedCandidate, asOf time.Time) float64 {
+	recency := math.Exp(-asOf.Sub(c.RankedAt).Seconds()/86400) * 0.5
+	score := recency +
+		math.Log10(float64(c.LikesCount)+1)*0.3 +
+		math.Log10(float64(c.CommentsCount)+1)*0.2
+
+	if c.Seen {
+		score *= 0.5
+	}
+	return score
+}
+
+// affinity weights the engagement score by how often the viewer has liked
+// the author's posts lately
+type affinity struct{}
+
+func (affinity) Name() string { return Affinity }
+
+func (affinity) Score(c models.FeedCandidate, asOf time.Time) float64 {
+	return engagement{}.Score(c, asOf) * (1 + 0.5*math.Log1p(float64(c.AuthorAffinity)))
+}
@@ -0,0 +1,227 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"feed-service/model"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/redis/go-redis/v9"
+	"shared/cursor"
+)
+
+const (
+	// exploreWindow is how far back explore looks for posts
+	exploreWindow = 72 * time.Hour
+	// exploreSize is the number of trending posts in an explore snapshot
+	exploreSize = 500
+	// exploreRefresh is how long a snapshot serves first pages
+	exploreRefresh = 5 * time.Minute
+	// exploreRetention is how long a snapshot serves later pages
+	exploreRetention = 30 * time.Minute
+	// exploreBatch is the number of snapshot entries read at a time
+	exploreBatch = 100
+)
+
+// GetExploreFeed pages through the posts trending across the network, leaving
+// out the user's own posts, posts by users they follow and posts by private
+// accounts. Trending posts are ranked for everyone at once into a snapshot in
+// Redis, refreshed every exploreRefresh. A cursor keeps reading the snapshot
+// its first page came from, so pages do not shift when a new one is taken.
+func (r *feedRepository) GetExploreFeed(ctx context.Context, userID uuid.UUID, limit int, after *string) (*models.PostConnection, error) {
+	var asOf time.Time
+	var position *models.FeedPosition
+	if after != nil && *after != "" {
+		score, postID, t, err := cursor.DecodeRanked(*after)
+		if err != nil {
+			return nil, err
+		}
+		asOf = t
+		position = &models.FeedPosition{Score: score, PostID: postID}
+	}
+
+	asOf, err := r.exploreSnapshot(ctx, asOf)
+	if err != nil {
+		return nil, err
+	}
+	key := exploreKey(asOf)
+
+	page := &models.FeedPage{AsOf: asOf}
+	from := position
+	for len(page.Posts) <= limit {
+		entries, err := r.cachedEntries(ctx, key, from, exploreBatch)
+		if err != nil {
+			return nil, err
+		}
+		if len(entries) == 0 {
+			break
+		}
+
+		posts, err := r.explorePosts(ctx, userID, entries)
+		if err != nil {
+			return nil, err
+		}
+		page.Posts = append(page.Posts, posts...)
+
+		if len(entries) < exploreBatch {
+			break
+		}
+		from = &entries[len(entries)-1]
+	}
+
+	if len(page.Posts) > limit {
+		page.Posts = page.Posts[:limit]
+		page.HasNextPage = true
+	}
+
+	return r.buildPostConnection(page, position != nil), nil
+}
+
+// exploreSnapshot returns the time of the snapshot to read: the current one
+// for a first page, or the one a cursor came from. A missing snapshot is
+// taken, as of the cursor's time for later pages.
+func (r *feedRepository) exploreSnapshot(ctx context.Context, asOf time.Time) (time.Time, error) {
+	if asOf.IsZero() {
+		micros, err := r.redis.Get(ctx, "explore:current").Int64()
+		if err == nil {
+			return time.UnixMicro(micros).UTC(), nil
+		}
+		if !errors.Is(err, redis.Nil) {
+			return time.Time{}, fmt.Errorf("failed to get explore snapshot: %w", err)
+		}
+
+		asOf = time.Now().UTC().Truncate(time.Microsecond)
+		if err := r.buildExplore(ctx, asOf); err != nil {
+			return time.Time{}, err
+		}
+		err = r.redis.Set(ctx, "explore:current", strconv.FormatInt(asOf.UnixMicro(), 10), exploreRefresh).Err()
+		if err != nil {
+			return time.Time{}, fmt.Errorf("failed to publish explore snapshot: %w", err)
+		}
+		return asOf, nil
+	}
+
+	exists, err := r.redis.Exists(ctx, exploreKey(asOf)).Result()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to get explore snapshot: %w", err)
+	}
+	if exists == 0 {
+		if err := r.buildExplore(ctx, asOf); err != nil {
+			return time.Time{}, err
+		}
+	}
+	return asOf, nil
+}
+
+// buildExplore ranks the trending posts as of asOf into a snapshot. Posts
+// score by likes, comments and reposts, decaying with age. Concurrent
+// builds of the same snapshot share one query.
+func (r *feedRepository) buildExplore(ctx context.Context, asOf time.Time) error {
+	key := exploreKey(asOf)
+
+	_, err, _ := r.builds.Do(key, func() (interface{}, error) {
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), feedBuildTimeout)
+		defer cancel()
+
+		query := `
+			WITH reposts AS (
+				SELECT post_id, COUNT(*) AS reposts
+				FROM feed_service_reposts
+				WHERE created_at <= $1::timestamptz
+				GROUP BY post_id
+			)
+			SELECT
+				p.id,
+				((p.likes_count + 2 * p.comments_count + 3 * COALESCE(rp.reposts, 0))
+					/ POWER(EXTRACT(EPOCH FROM ($1::timestamptz - p.created_at)) / 3600.0 + 2, 1.5))::float8 AS score
+			FROM feed_service_posts p
+			LEFT JOIN reposts rp ON rp.post_id = p.id
+			WHERE p.created_at > $1::timestamptz - make_interval(secs => $2)
+				AND p.created_at <= $1::timestamptz
+				AND p.visibility = 'PUBLIC'
+				AND NOT EXISTS (
+					SELECT 1 FROM feed_service_private_users pu
+					WHERE pu.user_id = p.user_id AND pu.is_private
+				)
+			ORDER BY score DESC, p.id DESC
+			LIMIT $3
+		`
+
+		var entries []struct {
+			ID    uuid.UUID `db:"id"`
+			Score float64   `db:"score"`
+		}
+		err := r.db.ReadDB().SelectContext(ctx, &entries, query, asOf, exploreWindow.Seconds(), exploreSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to rank explore posts: %w", err)
+		}
+
+		pipe := r.redis.TxPipeline()
+		pipe.Del(ctx, key)
+		for _, entry := range entries {
+			pipe.ZAdd(ctx, key, redis.Z{Score: entry.Score, Member: entry.ID.String()})
+		}
+		pipe.Expire(ctx, key, exploreRetention)
+
+		if _, err := pipe.Exec(ctx); err != nil {
+			return nil, fmt.Errorf("failed to cache explore posts: %w", err)
+		}
+		return nil, nil
+	})
+	return err
+}
+
+// explorePosts loads the posts of snapshot entries userID may discover, in
+// snapshot order
+func (r *feedRepository) explorePosts(ctx context.Context, userID uuid.UUID, entries []models.FeedPosition) ([]models.RankedPost, error) {
+	ids := make([]uuid.UUID, len(entries))
+	for i, entry := range entries {
+		ids[i] = entry.PostID
+	}
+
+	query, args, err := sqlx.In(`
+		SELECT p.id, p.user_id, p.content, p.created_at, p.updated_at, p.likes_count, p.comments_count, p.visibility
+		FROM feed_service_posts p
+		WHERE p.id IN (?)
+			AND p.user_id <> ?
+			AND p.visibility = 'PUBLIC'
+			AND NOT EXISTS (
+				SELECT 1 FROM feed_service_follows f
+				WHERE f.follower_id = ? AND f.followed_id = p.user_id AND f.deleted_at IS NULL
+			)
+			AND NOT EXISTS (
+				SELECT 1 FROM feed_service_private_users pu
+				WHERE pu.user_id = p.user_id AND pu.is_private
+			)
+	`, ids, userID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	var posts []models.Post
+	err = r.db.ReadDB().SelectContext(ctx, &posts, r.db.Rebind(query), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch explore posts: %w", err)
+	}
+
+	byID := make(map[uuid.UUID]models.Post, len(posts))
+	for _, post := range posts {
+		byID[post.ID] = post
+	}
+
+	ranked := make([]models.RankedPost, 0, len(posts))
+	for _, entry := range entries {
+		if post, ok := byID[entry.PostID]; ok {
+			ranked = append(ranked, models.RankedPost{Post: post, Score: entry.Score})
+		}
+	}
+	return ranked, nil
+}
+
+func exploreKey(asOf time.Time) string {
+	return fmt.Sprintf("explore:%d", asOf.UnixMicro())
+}
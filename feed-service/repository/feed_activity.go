@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// activeUsersKey is a sorted set of the users making feed requests, scored
+// by the unix time of their latest one
+const activeUsersKey = "feed:active"
+
+// RecordActivity notes that userID made a request at the given time
+func (r *feedRepository) RecordActivity(ctx context.Context, userID uuid.UUID, at time.Time) error {
+	err := r.redis.ZAdd(ctx, activeUsersKey, redis.Z{Score: float64(at.Unix()), Member: userID.String()}).Err()
+	if err != nil {
+		return fmt.Errorf("failed to record activity: %w", err)
+	}
+	return nil
+}
+
+// GetActiveUserIDs returns the users who made a request since the given
+// time, dropping those who have not from the set
+func (r *feedRepository) GetActiveUserIDs(ctx context.Context, since time.Time) ([]uuid.UUID, error) {
+	cutoff := strconv.FormatInt(since.Unix(), 10)
+
+	err := r.redis.ZRemRangeByScore(ctx, activeUsersKey, "-inf", "("+cutoff).Err()
+	if err != nil {
+		return nil, fmt.Errorf("failed to trim active users: %w", err)
+	}
+
+	members, err := r.redis.ZRangeByScore(ctx, activeUsersKey, &redis.ZRangeBy{Min: cutoff, Max: "+inf"}).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active users: %w", err)
+	}
+
+	userIDs := make([]uuid.UUID, 0, len(members))
+	for _, member := range members {
+		userID, err := uuid.Parse(member)
+		if err != nil {
+			continue
+		}
+		userIDs = append(userIDs, userID)
+	}
+	return userIDs, nil
+}
+
+// FeedCacheTTL returns how long a user's cached feed has left, or a negative
+// duration if it is not cached
+func (r *feedRepository) FeedCacheTTL(ctx context.Context, userID uuid.UUID) (time.Duration, error) {
+	ttl, err := r.redis.TTL(ctx, fmt.Sprintf("feed:%s", userID.String())).Result()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get feed cache TTL: %w", err)
+	}
+	return ttl, nil
+}
@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UpdatePost projects an edit of a post's content. An edit older than the
+// one projected is ignored, so a late event never restores old content.
+func (r *feedRepository) UpdatePost(ctx context.Context, postID uuid.UUID, content string, updatedAt time.Time) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE feed_service_posts
+		SET content = $2, updated_at = $3
+		WHERE id = $1 AND updated_at < $3
+	`, postID, content, updatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to update post: %w", err)
+	}
+	return nil
+}
+
+// AddLike records that userID likes postID and counts the like on the post.
+// Likes of posts outside the projection, such as private posts, are not
+// recorded. A like already live, or older than the unlike that ended it, is
+// ignored, so redelivered events are counted once.
+func (r *feedRepository) AddLike(ctx context.Context, userID, postID uuid.UUID, createdAt time.Time) error {
+	return r.db.WithTx(ctx, func(ctx context.Context) error {
+		result, err := r.db.Conn(ctx).ExecContext(ctx, `
+			INSERT INTO feed_service_likes (user_id, post_id, created_at)
+			SELECT $1, $2, $3
+			WHERE EXISTS (SELECT 1 FROM feed_service_posts WHERE id = $2)
+			ON CONFLICT (user_id, post_id) DO UPDATE
+			SET created_at = EXCLUDED.created_at, deleted_at = NULL
+			WHERE feed_service_likes.deleted_at IS NOT NULL
+				AND feed_service_likes.deleted_at < EXCLUDED.created_at
+		`, userID, postID, createdAt)
+		if err != nil {
+			return fmt.Errorf("failed to insert like: %w", err)
+		}
+		return r.countChange(ctx, result, "likes_count", postID, 1)
+	})
+}
+
+// RemoveLike marks a like made no later than deletedAt as ended and counts
+// it off the post, so a late unlike never ends a newer like
+func (r *feedRepository) RemoveLike(ctx context.Context, userID, postID uuid.UUID, deletedAt time.Time) error {
+	return r.db.WithTx(ctx, func(ctx context.Context) error {
+		result, err := r.db.Conn(ctx).ExecContext(ctx, `
+			UPDATE feed_service_likes
+			SET deleted_at = $3
+			WHERE user_id = $1 AND post_id = $2 AND deleted_at IS NULL AND created_at <= $3
+		`, userID, postID, deletedAt)
+		if err != nil {
+			return fmt.Errorf("failed to delete like: %w", err)
+		}
+		return r.countChange(ctx, result, "likes_count", postID, -1)
+	})
+}
+
+// AddComment records a comment or reply and counts it on its post. Comments
+// on posts outside the projection are not recorded, and a comment already
+// recorded, live or deleted, is not counted again.
+func (r *feedRepository) AddComment(ctx context.Context, commentID, postID uuid.UUID, createdAt time.Time) error {
+	return r.db.WithTx(ctx, func(ctx context.Context) error {
+		result, err := r.db.Conn(ctx).ExecContext(ctx, `
+			INSERT INTO feed_service_comments (comment_id, post_id, created_at)
+			SELECT $1, $2, $3
+			WHERE EXISTS (SELECT 1 FROM feed_service_posts WHERE id = $2)
+			ON CONFLICT (comment_id) DO NOTHING
+		`, commentID, postID, createdAt)
+		if err != nil {
+			return fmt.Errorf("failed to insert comment: %w", err)
+		}
+		return r.countChange(ctx, result, "comments_count", postID, 1)
+	})
+}
+
+// RemoveComment marks a comment as deleted and counts it off its post. A
+// comment deleted already, or never recorded, changes nothing.
+func (r *feedRepository) RemoveComment(ctx context.Context, commentID, postID uuid.UUID, deletedAt time.Time) error {
+	return r.db.WithTx(ctx, func(ctx context.Context) error {
+		result, err := r.db.Conn(ctx).ExecContext(ctx, `
+			UPDATE feed_service_comments
+			SET deleted_at = $3
+			WHERE comment_id = $1 AND post_id = $2 AND deleted_at IS NULL
+		`, commentID, postID, deletedAt)
+		if err != nil {
+			return fmt.Errorf("failed to delete comment: %w", err)
+		}
+		return r.countChange(ctx, result, "comments_count", postID, -1)
+	})
+}
+
+// countChange adds delta to a counter of the post when result changed a row
+func (r *feedRepository) countChange(ctx context.Context, result sql.Result, column string, postID uuid.UUID, delta int) error {
+	changed, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if changed == 0 {
+		return nil
+	}
+
+	_, err = r.db.Conn(ctx).ExecContext(ctx, `
+		UPDATE feed_service_posts
+		SET `+column+` = GREATEST(`+column+` + $2, 0)
+		WHERE id = $1
+	`, postID, delta)
+	if err != nil {
+		return fmt.Errorf("failed to update %s: %w", column, err)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AddFollow records that followerID follows followedID and backfills the
+// followed user's posts of the last 30 days into the follower's feed items,
+// as fan-out would have had the follow existed when they were posted. Posts
+// of authors whose posts are pulled are not backfilled. A follow older than
+// the latest recorded change of the pair is ignored, so a late event never
+// undoes a newer unfollow.
+func (r *feedRepository) AddFollow(ctx context.Context, followerID, followedID uuid.UUID, createdAt time.Time) error {
+	return r.db.WithTx(ctx, func(ctx context.Context) error {
+		result, err := r.db.Conn(ctx).ExecContext(ctx, `
+			INSERT INTO feed_service_follows (follower_id, followed_id, created_at)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (follower_id, followed_id) DO UPDATE
+			SET created_at = EXCLUDED.created_at, deleted_at = NULL
+			WHERE feed_service_follows.created_at < EXCLUDED.created_at
+				AND (feed_service_follows.deleted_at IS NULL OR feed_service_follows.deleted_at < EXCLUDED.created_at)
+		`, followerID, followedID, createdAt)
+		if err != nil {
+			return fmt.Errorf("failed to insert follow: %w", err)
+		}
+		if changed, err := result.RowsAffected(); err != nil || changed == 0 {
+			return err
+		}
+
+		_, err = r.db.Conn(ctx).ExecContext(ctx, `
+			INSERT INTO feed_service_cache (id, user_id, post_id, created_at)
+			SELECT uuid_generate_v4(), $1, p.id, p.created_at
+			FROM feed_service_posts p
+			WHERE p.user_id = $2
+				AND p.created_at > NOW() - INTERVAL '30 days'
+				AND NOT EXISTS (
+					SELECT 1 FROM feed_service_author_strategies s
+					WHERE s.user_id = $2 AND s.strategy = 'pull'
+				)
+			ON CONFLICT (user_id, post_id) DO NOTHING
+		`, followerID, followedID)
+		if err != nil {
+			return fmt.Errorf("failed to backfill feed items: %w", err)
+		}
+		return nil
+	})
+}
+
+// RemoveFollow marks a follow made no later than deletedAt as ended, so a
+// late unfollow never ends a newer follow of the same user, and removes the
+// followed user's posts from the follower's feed items
+func (r *feedRepository) RemoveFollow(ctx context.Context, followerID, followedID uuid.UUID, deletedAt time.Time) error {
+	return r.db.WithTx(ctx, func(ctx context.Context) error {
+		result, err := r.db.Conn(ctx).ExecContext(ctx, `
+			UPDATE feed_service_follows
+			SET deleted_at = $3
+			WHERE follower_id = $1 AND followed_id = $2 AND created_at <= $3
+		`, followerID, followedID, deletedAt)
+		if err != nil {
+			return fmt.Errorf("failed to delete follow: %w", err)
+		}
+		if changed, err := result.RowsAffected(); err != nil || changed == 0 {
+			return err
+		}
+
+		_, err = r.db.Conn(ctx).ExecContext(ctx, `
+			DELETE FROM feed_service_cache c
+			USING feed_service_posts p
+			WHERE c.user_id = $1 AND c.post_id = p.id AND p.user_id = $2
+		`, followerID, followedID)
+		if err != nil {
+			return fmt.Errorf("failed to remove feed items: %w", err)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SetUserPrivacy records whether an account is private. Updates older than
+// the one already stored are ignored, so out-of-order events cannot revert a
+// newer setting.
+func (r *feedRepository) SetUserPrivacy(ctx context.Context, userID uuid.UUID, isPrivate bool, updatedAt time.Time) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO feed_service_private_users (user_id, is_private, updated_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id) DO UPDATE
+		SET is_private = EXCLUDED.is_private, updated_at = EXCLUDED.updated_at
+		WHERE feed_service_private_users.updated_at < EXCLUDED.updated_at
+	`, userID, isPrivate, updatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to set user privacy: %w", err)
+	}
+	return nil
+}
@@ -2,129 +2,337 @@ package repository
 
 import (
 	"context"
-	"encoding/base64"
 	"fmt"
+	"sort"
+	"strconv"
+	"sync/atomic"
 	"time"
 
+	"feed-service/db"
 	"feed-service/model"
+	"feed-service/ranking"
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
 	"github.com/redis/go-redis/v9"
+	"golang.org/x/sync/singleflight"
+	"shared/cursor"
 )
 
+// feedBuildTimeout bounds a shared feed build, which no longer follows the
+// cancellation of the request that started it
+const feedBuildTimeout = 10 * time.Second
+
+// FeedCacheSize is the number of posts kept in a user's cached feed. It is
+// larger than the largest page, so a first page is always served whole from
+// a freshly built cache.
+const FeedCacheSize = 200
+
 type FeedRepository interface {
 	// Feed retrieval
-	GetFeed(ctx context.Context, userID uuid.UUID, limit int, after *string) (*models.PostConnection, error)
+	GetFeed(ctx context.Context, userID uuid.UUID, limit int, after *string, excludeSeen bool) (*models.PostConnection, error)
+	GetExploreFeed(ctx context.Context, userID uuid.UUID, limit int, after *string) (*models.PostConnection, error)
 
 	// Feed cache management
-	GetCachedFeed(ctx context.Context, userID uuid.UUID, limit int, offset int) ([]models.Post, error)
-	CacheFeedItems(ctx context.Context, userID uuid.UUID, posts []models.Post) error
+	GetCachedFeed(ctx context.Context, userID uuid.UUID, asOf time.Time, after *models.FeedPosition, limit int) (*models.FeedPage, error)
+	CacheFeedItems(ctx context.Context, userID uuid.UUID, page *models.FeedPage) error
 	InvalidateUserFeed(ctx context.Context, userID uuid.UUID) error
+	InspectFeedCache(ctx context.Context, userID uuid.UUID, limit int) (*models.FeedCacheInfo, error)
+	FeedCacheTTL(ctx context.Context, userID uuid.UUID) (time.Duration, error)
 
 	// Feed building
-	BuildFeedForUser(ctx context.Context, userID uuid.UUID, limit int) ([]models.Post, error)
+	BuildFeedForUser(ctx context.Context, userID uuid.UUID, asOf time.Time, after *models.FeedPosition, limit int, excludeSeen bool) (*models.FeedPage, error)
 	GetFollowingPosts(ctx context.Context, userID uuid.UUID, limit int, since time.Time) ([]models.Post, error)
 
 	// Like status checks
 	GetPostsWithLikeStatus(ctx context.Context, userID uuid.UUID, postIDs []uuid.UUID) (map[uuid.UUID]bool, error)
 
+	// Posts, projected from post.created and post.updated
+	AddPost(ctx context.Context, post models.Post) error
+	UpdatePost(ctx context.Context, postID uuid.UUID, content string, updatedAt time.Time) error
+
+	// Likes and comments, projected with the counts of posts
+	AddLike(ctx context.Context, userID, postID uuid.UUID, createdAt time.Time) error
+	RemoveLike(ctx context.Context, userID, postID uuid.UUID, deletedAt time.Time) error
+	AddComment(ctx context.Context, commentID, postID uuid.UUID, createdAt time.Time) error
+	RemoveComment(ctx context.Context, commentID, postID uuid.UUID, deletedAt time.Time) error
+
 	// Feed item insertion (for fan-out on write)
 	InsertFeedItem(ctx context.Context, userID, postID uuid.UUID) error
 	BulkInsertFeedItems(ctx context.Context, items []models.FeedCache) error
 
 	// Feed cleanup
-	CleanupOldFeedItems(ctx context.Context, olderThan time.Time) error
+	CleanupOldFeedItems(ctx context.Context, olderThan time.Time) (int64, error)
+	DeletePost(ctx context.Context, postID, authorID uuid.UUID) ([]uuid.UUID, error)
+	RemoveFromCachedFeeds(ctx context.Context, postID uuid.UUID, userIDs []uuid.UUID) error
+
+	// Reposts
+	AddRepost(ctx context.Context, repost models.Repost, post models.Post) error
+	RemoveRepost(ctx context.Context, userID, postID uuid.UUID, deletedAt time.Time) error
+
+	// Follow graph
+	GetFollowerIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error)
+	GetFollowingIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error)
+	AddFollow(ctx context.Context, followerID, followedID uuid.UUID, createdAt time.Time) error
+	RemoveFollow(ctx context.Context, followerID, followedID uuid.UUID, deletedAt time.Time) error
+
+	// Active users, whose feeds are kept warm
+	RecordActivity(ctx context.Context, userID uuid.UUID, at time.Time) error
+	GetActiveUserIDs(ctx context.Context, since time.Time) ([]uuid.UUID, error)
+
+	// Seen posts
+	MarkSeen(ctx context.Context, userID uuid.UUID, postIDs []uuid.UUID) (int64, error)
+
+	// Account privacy
+	SetUserPrivacy(ctx context.Context, userID uuid.UUID, isPrivate bool, updatedAt time.Time) error
+
+	// Deleted users
+	DeleteUserData(ctx context.Context, userID uuid.UUID) error
+
+	// Fan-out strategy
+	UpdateAuthorStrategy(ctx context.Context, authorID uuid.UUID, threshold int) (models.FanOutStrategy, error)
+	HasPulledPostsSince(ctx context.Context, userID uuid.UUID, since time.Time) (bool, error)
 }
 
 type feedRepository struct {
-	db    *sqlx.DB
+	db    *database.DB
 	redis *redis.Client
+
+	// builds collapses concurrent rebuilds of the same feed into one query
+	builds singleflight.Group
+	// earlyRefreshBeta enables probabilistic early refresh of cached feeds when > 0
+	earlyRefreshBeta float64
+	// lastBuild is the duration of the most recent feed build in nanoseconds
+	lastBuild atomic.Int64
+	// ranking picks the strategy that ranks each user's feed
+	ranking *ranking.Assigner
 }
 
-func NewFeedRepository(db *sqlx.DB, redis *redis.Client) FeedRepository {
+func NewFeedRepository(db *database.DB, redis *redis.Client, earlyRefreshBeta float64, assigner *ranking.Assigner) FeedRepository {
 	return &feedRepository{
-		db:    db,
-		redis: redis,
+		db:               db,
+		redis:            redis,
+		earlyRefreshBeta: earlyRefreshBeta,
+		ranking:          assigner,
 	}
 }
 
-// GetFeed retrieves paginated feed for a user
-func (r *feedRepository) GetFeed(ctx context.Context, userID uuid.UUID, limit int, after *string) (*models.PostConnection, error) {
-	var offset int
+// GetFeed retrieves paginated feed for a user. Pages are keyset positions in
+// the ranked feed as of the first page, so posts arriving or being ranked
+// differently between pages do not shift later pages. Posts the user had
+// seen by then rank lower, or are left out with excludeSeen; the cache holds
+// them all, so such pages are ranked from the stored posts. A cached feed
+// ranked by another strategy than the user's current one is not used.
+func (r *feedRepository) GetFeed(ctx context.Context, userID uuid.UUID, limit int, after *string, excludeSeen bool) (*models.PostConnection, error) {
+	var asOf time.Time
+	var position *models.FeedPosition
 	if after != nil && *after != "" {
-		decoded, err := decodeCursor(*after)
+		score, postID, t, err := cursor.DecodeRanked(*after)
 		if err != nil {
-			return nil, fmt.Errorf("invalid cursor: %w", err)
+			return nil, err
+		}
+		asOf = t
+		position = &models.FeedPosition{Score: score, PostID: postID}
+	}
+
+	strategy := r.ranking.Assign(ctx, userID)
+
+	if excludeSeen {
+		if asOf.IsZero() {
+			asOf = time.Now()
+		}
+		page, err := r.buildFeed(ctx, userID, strategy, asOf, position, limit, true)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build feed: %w", err)
+		}
+		return r.buildPostConnection(page, position != nil), nil
+	}
+
+	page, err := r.GetCachedFeed(ctx, userID, asOf, position, limit)
+	if err == nil && page.Strategy != strategy.Name() {
+		err = fmt.Errorf("cached feed was ranked by another strategy")
+	}
+	if err == nil && position == nil {
+		err = r.mergePulledPosts(ctx, userID, page.AsOf)
+	}
+	if err == nil {
+		if position == nil {
+			r.maybeRefreshFeed(ctx, userID, strategy)
 		}
-		offset = decoded
+		return r.buildPostConnection(page, position != nil), nil
 	}
 
-	cachedPosts, err := r.GetCachedFeed(ctx, userID, limit+1, offset)
-	if err == nil && len(cachedPosts) > 0 {
-		return r.buildPostConnection(cachedPosts, limit, offset), nil
+	if position == nil {
+		page, err = r.rebuildFeed(ctx, userID, strategy)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build feed: %w", err)
+		}
+		if len(page.Posts) > limit {
+			page = &models.FeedPage{Posts: page.Posts[:limit], HasNextPage: true, AsOf: page.AsOf, Strategy: page.Strategy}
+		}
+		return r.buildPostConnection(page, false), nil
 	}
 
-	posts, err := r.BuildFeedForUser(ctx, userID, limit+1)
+	// The cache was rebuilt since the first page or does not reach this far,
+	// so the page is ranked from the stored posts as of the first page
+	page, err = r.buildFeed(ctx, userID, strategy, asOf, position, limit, false)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build feed: %w", err)
 	}
 
-	go func() {
-		_ = r.CacheFeedItems(context.Background(), userID, posts)
-	}()
+	return r.buildPostConnection(page, true), nil
+}
+
+// mergePulledPosts fails when authors followed by the user whose posts are
+// not fanned out on write have posted since the cached feed was ranked. The
+// ranked query reads their posts like any other, so ranking the feed again
+// merges them in.
+func (r *feedRepository) mergePulledPosts(ctx context.Context, userID uuid.UUID, since time.Time) error {
+	pulled, err := r.HasPulledPostsSince(ctx, userID, since)
+	if err != nil {
+		return err
+	}
+	if pulled {
+		return fmt.Errorf("cached feed misses pulled posts")
+	}
+	return nil
+}
+
+// rebuildFeed builds and caches a user's feed. Concurrent callers for the same
+// user share a single build, so an expired hot key costs one query.
+func (r *feedRepository) rebuildFeed(ctx context.Context, userID uuid.UUID, strategy ranking.Strategy) (*models.FeedPage, error) {
+	v, err, _ := r.builds.Do(userID.String(), func() (interface{}, error) {
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), feedBuildTimeout)
+		defer cancel()
+
+		start := time.Now()
+		page, err := r.buildFeed(ctx, userID, strategy, start, nil, FeedCacheSize, false)
+		if err != nil {
+			return nil, err
+		}
+		r.lastBuild.Store(int64(time.Since(start)))
 
-	return r.buildPostConnection(posts, limit, offset), nil
+		_ = r.CacheFeedItems(ctx, userID, page)
+		return page, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return v.(*models.FeedPage), nil
 }
 
-// BuildFeedForUser creates a personalized feed using a hybrid approach
-func (r *feedRepository) BuildFeedForUser(ctx context.Context, userID uuid.UUID, limit int) ([]models.Post, error) {
+// maybeRefreshFeed rebuilds a cached feed in the background when early
+// refresh is enabled and the key is close enough to expiring
+func (r *feedRepository) maybeRefreshFeed(ctx context.Context, userID uuid.UUID, strategy ranking.Strategy) {
+	if r.earlyRefreshBeta <= 0 {
+		return
+	}
+
+	ttl, err := r.redis.TTL(ctx, fmt.Sprintf("feed:%s", userID.String())).Result()
+	if err != nil {
+		return
+	}
+
+	if shouldRefreshEarly(ttl, time.Duration(r.lastBuild.Load()), r.earlyRefreshBeta) {
+		go func() {
+			_, _ = r.rebuildFeed(context.Background(), userID, strategy)
+		}()
+	}
+}
+
+// BuildFeedForUser creates a personalized feed using a hybrid approach,
+// ranked by the strategy the user is assigned to
+func (r *feedRepository) BuildFeedForUser(ctx context.Context, userID uuid.UUID, asOf time.Time, after *models.FeedPosition, limit int, excludeSeen bool) (*models.FeedPage, error) {
+	return r.buildFeed(ctx, userID, r.ranking.Assign(ctx, userID), asOf, after, limit, excludeSeen)
+}
+
+// buildFeed ranks the posts of followed users and reposts by them with
+// strategy. Posts are ranked as of asOf, and only those posted or reposted by
+// then are included, so every page ranked as of the same time fits together.
+// With after set the page starts behind that position. Posts the user had
+// seen by asOf are marked for the strategy, or left out with excludeSeen.
+func (r *feedRepository) buildFeed(ctx context.Context, userID uuid.UUID, strategy ranking.Strategy, asOf time.Time, after *models.FeedPosition, limit int, excludeSeen bool) (*models.FeedPage, error) {
+	asOf = asOf.UTC().Truncate(time.Microsecond)
+
 	query := `
 		WITH following_users AS (
 			SELECT followed_id 
 			FROM feed_service_follows 
 			WHERE follower_id = $1 AND deleted_at IS NULL
 		),
-		ranked_posts AS (
-			SELECT 
-				p.id,
-				p.user_id,
-				p.content,
-				p.created_at,
-				p.updated_at,
-				p.likes_count,
-				p.comments_count,
-				-- Ranking algorithm: recency + engagement
-				(
-					-- Recency score (exponential decay)
-					EXP(-EXTRACT(EPOCH FROM (NOW() - p.created_at)) / 86400.0) * 0.5 +
-					-- Engagement score
-					(LOG(GREATEST(p.likes_count + 1, 1)) * 0.3) +
-					(LOG(GREATEST(p.comments_count + 1, 1)) * 0.2)
-				) AS feed_score
-			FROM feed_service_posts p
-			WHERE p.user_id IN (SELECT followed_id FROM following_users)
-				AND p.created_at > NOW() - INTERVAL '30 days'
+		-- Posts shared by followed users rank from their latest repost
+		reposted AS (
+			SELECT post_id, MAX(created_at) AS reposted_at
+			FROM feed_service_reposts
+			WHERE user_id IN (SELECT followed_id FROM following_users)
+				AND created_at > $2::timestamptz - INTERVAL '30 days'
+				AND created_at <= $2::timestamptz
+			GROUP BY post_id
+		),
+		-- How many posts of each author the user liked lately
+		affinity AS (
+			SELECT p.user_id, COUNT(*) AS likes
+			FROM feed_service_likes l
+			INNER JOIN feed_service_posts p ON p.id = l.post_id
+			WHERE l.user_id = $1
+				AND l.deleted_at IS NULL
+				AND l.created_at > $2::timestamptz - INTERVAL '30 days'
+				AND l.created_at <= $2::timestamptz
+			GROUP BY p.user_id
 		)
 		SELECT 
-			id,
-			user_id,
-			content,
-			created_at,
-			updated_at,
-			likes_count,
-			comments_count
-		FROM ranked_posts
-		ORDER BY feed_score DESC, created_at DESC
-		LIMIT $2
+			p.id,
+			p.user_id,
+			p.content,
+			p.created_at,
+			p.updated_at,
+			p.likes_count,
+			p.comments_count,
+			p.visibility,
+			GREATEST(p.created_at, r.reposted_at) AS ranked_at,
+			sn.post_id IS NOT NULL AS seen,
+			COALESCE(a.likes, 0) AS author_affinity
+		FROM feed_service_posts p
+		LEFT JOIN reposted r ON r.post_id = p.id
+		LEFT JOIN feed_service_seen sn
+			ON sn.user_id = $1 AND sn.post_id = p.id AND sn.seen_at <= $2::timestamptz
+		LEFT JOIN affinity a ON a.user_id = p.user_id
+		WHERE ((p.user_id IN (SELECT followed_id FROM following_users)
+				AND p.created_at > $2::timestamptz - INTERVAL '30 days'
+				AND p.created_at <= $2::timestamptz)
+			OR r.post_id IS NOT NULL)
+			-- Reposts never surface private accounts or followers-only posts
+			-- of users the user does not follow
+			AND (p.user_id = $1
+				OR p.user_id IN (SELECT followed_id FROM following_users)
+				OR (p.visibility = 'PUBLIC' AND NOT EXISTS (
+					SELECT 1 FROM feed_service_private_users pu
+					WHERE pu.user_id = p.user_id AND pu.is_private
+				)))
+			AND NOT ($3 AND sn.post_id IS NOT NULL)
 	`
 
-	var posts []models.Post
-	err := r.db.SelectContext(ctx, &posts, query, userID, limit)
+	var candidates []models.FeedCandidate
+	err := r.db.ReadDB().SelectContext(ctx, &candidates, query, userID, asOf, excludeSeen)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch ranked feed: %w", err)
+		return nil, fmt.Errorf("failed to fetch feed candidates: %w", err)
 	}
 
-	return posts, nil
+	ranked := ranking.Rank(strategy, candidates, asOf)
+	if after != nil {
+		start := sort.Search(len(ranked), func(i int) bool {
+			return ranking.Before(after.Score, after.PostID[:], ranked[i].Score, ranked[i].ID[:])
+		})
+		ranked = ranked[start:]
+	}
+
+	page := &models.FeedPage{Posts: ranked, AsOf: asOf, Strategy: strategy.Name()}
+	if len(ranked) > limit {
+		page.Posts = ranked[:limit]
+		page.HasNextPage = true
+	}
+
+	return page, nil
 }
 
 // GetFollowingPosts retrieves recent posts from users that the given user follows
@@ -137,7 +345,8 @@ func (r *feedRepository) GetFollowingPosts(ctx context.Context, userID uuid.UUID
 			p.created_at,
 			p.updated_at,
 			p.likes_count,
-			p.comments_count
+			p.comments_count,
+			p.visibility
 		FROM feed_service_posts p
 		INNER JOIN feed_service_follows f ON f.followed_id = p.user_id
 		WHERE f.follower_id = $1
@@ -148,7 +357,7 @@ func (r *feedRepository) GetFollowingPosts(ctx context.Context, userID uuid.UUID
 	`
 
 	var posts []models.Post
-	err := r.db.SelectContext(ctx, &posts, query, userID, since, limit)
+	err := r.db.ReadDB().SelectContext(ctx, &posts, query, userID, since, limit)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch following posts: %w", err)
 	}
@@ -156,69 +365,160 @@ func (r *feedRepository) GetFollowingPosts(ctx context.Context, userID uuid.UUID
 	return posts, nil
 }
 
-// GetCachedFeed retrieves feed from Redis cache
-func (r *feedRepository) GetCachedFeed(ctx context.Context, userID uuid.UUID, limit int, offset int) ([]models.Post, error) {
+// GetCachedFeed retrieves feed from Redis cache. Posts by private accounts
+// and followers-only posts of users the user does not follow are dropped, as
+// the account may have turned private or the user unfollowed after the feed
+// was cached. A non-zero asOf must match the time the
+// cache was ranked as of; otherwise, or when the cache ends before the page
+// while the feed goes on, the cache cannot serve the page.
+func (r *feedRepository) GetCachedFeed(ctx context.Context, userID uuid.UUID, asOf time.Time, after *models.FeedPosition, limit int) (*models.FeedPage, error) {
 	cacheKey := fmt.Sprintf("feed:%s", userID.String())
 
-	postIDs, err := r.redis.ZRevRange(ctx, cacheKey, int64(offset), int64(offset+limit-1)).Result()
+	meta, err := r.redis.HGetAll(ctx, cacheKey+":meta").Result()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get cached feed: %w", err)
 	}
+	if len(meta) == 0 {
+		return nil, fmt.Errorf("cache miss")
+	}
 
-	if len(postIDs) == 0 {
+	cachedAsOf, err := time.Parse(time.RFC3339Nano, meta["as_of"])
+	if err != nil {
+		return nil, fmt.Errorf("invalid cached feed time: %w", err)
+	}
+	if !asOf.IsZero() && !asOf.Equal(cachedAsOf) {
+		return nil, fmt.Errorf("cached feed was ranked at another time")
+	}
+
+	entries, err := r.cachedEntries(ctx, cacheKey, after, limit+1)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 && after == nil {
 		return nil, fmt.Errorf("cache miss")
 	}
+	if len(entries) <= limit && meta["complete"] != "1" {
+		return nil, fmt.Errorf("cached feed ends before the page")
+	}
 
-	uuids := make([]uuid.UUID, 0, len(postIDs))
-	for _, id := range postIDs {
-		parsedID, err := uuid.Parse(id)
-		if err != nil {
-			continue
-		}
-		uuids = append(uuids, parsedID)
+	page := &models.FeedPage{AsOf: cachedAsOf, Strategy: meta["strategy"]}
+	if len(entries) > limit {
+		entries = entries[:limit]
+		page.HasNextPage = true
 	}
 
+	uuids := make([]uuid.UUID, 0, len(entries))
+	for _, entry := range entries {
+		uuids = append(uuids, entry.PostID)
+	}
 	if len(uuids) == 0 {
-		return nil, fmt.Errorf("no valid post IDs in cache")
+		return page, nil
 	}
 
 	query, args, err := sqlx.In(`
-		SELECT id, user_id, content, created_at, updated_at, likes_count, comments_count
-		FROM feed_service_posts
-		WHERE id IN (?)
-		ORDER BY created_at DESC
-	`, uuids)
+		SELECT p.id, p.user_id, p.content, p.created_at, p.updated_at, p.likes_count, p.comments_count, p.visibility
+		FROM feed_service_posts p
+		LEFT JOIN feed_service_private_users pu ON pu.user_id = p.user_id AND pu.is_private
+		WHERE p.id IN (?)
+			AND ((pu.user_id IS NULL AND p.visibility = 'PUBLIC') OR p.user_id = ? OR EXISTS (
+				SELECT 1 FROM feed_service_follows f
+				WHERE f.follower_id = ? AND f.followed_id = p.user_id AND f.deleted_at IS NULL
+			))
+	`, uuids, userID, userID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build query: %w", err)
 	}
 
 	var posts []models.Post
-	err = r.db.SelectContext(ctx, &posts, r.db.Rebind(query), args...)
+	err = r.db.ReadDB().SelectContext(ctx, &posts, r.db.Rebind(query), args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch cached posts: %w", err)
 	}
 
-	return posts, nil
+	byID := make(map[uuid.UUID]models.Post, len(posts))
+	for _, post := range posts {
+		byID[post.ID] = post
+	}
+	for _, entry := range entries {
+		if post, ok := byID[entry.PostID]; ok {
+			page.Posts = append(page.Posts, models.RankedPost{Post: post, Score: entry.Score})
+		}
+	}
+
+	return page, nil
 }
 
-// CacheFeedItems stores feed items in Redis
-func (r *feedRepository) CacheFeedItems(ctx context.Context, userID uuid.UUID, posts []models.Post) error {
-	if len(posts) == 0 {
+// cachedEntries reads up to limit entries of a cached feed, starting behind
+// after when it is set. Redis orders equal scores by member, descending,
+// which for post IDs is the order the ranked query uses.
+func (r *feedRepository) cachedEntries(ctx context.Context, cacheKey string, after *models.FeedPosition, limit int) ([]models.FeedPosition, error) {
+	opt := &redis.ZRangeBy{Min: "-inf", Max: "+inf", Count: int64(limit)}
+	if after != nil {
+		opt.Max = strconv.FormatFloat(after.Score, 'g', -1, 64)
+	}
+
+	var entries []models.FeedPosition
+	for len(entries) < limit {
+		members, err := r.redis.ZRevRangeByScoreWithScores(ctx, cacheKey, opt).Result()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get cached feed: %w", err)
+		}
+
+		for _, member := range members {
+			postID, err := uuid.Parse(fmt.Sprint(member.Member))
+			if err != nil {
+				continue
+			}
+			// Posts tied with the position at or before it were on earlier pages
+			if after != nil && member.Score == after.Score && postID.String() >= after.PostID.String() {
+				continue
+			}
+			entries = append(entries, models.FeedPosition{Score: member.Score, PostID: postID})
+		}
+
+		if int64(len(members)) < opt.Count {
+			break
+		}
+		opt.Offset += int64(len(members))
+	}
+
+	if len(entries) > limit {
+		entries = entries[:limit]
+	}
+	return entries, nil
+}
+
+// CacheFeedItems replaces a user's cached feed with a ranked page, scored
+// as ranked, together with the time and strategy it was ranked by and
+// whether it holds the whole feed
+func (r *feedRepository) CacheFeedItems(ctx context.Context, userID uuid.UUID, page *models.FeedPage) error {
+	if len(page.Posts) == 0 {
 		return nil
 	}
 
 	cacheKey := fmt.Sprintf("feed:%s", userID.String())
-	pipe := r.redis.Pipeline()
+	complete := "0"
+	if !page.HasNextPage {
+		complete = "1"
+	}
 
-	for _, post := range posts {
-		score := float64(post.CreatedAt.Unix())
+	pipe := r.redis.TxPipeline()
+	pipe.Del(ctx, cacheKey, cacheKey+":meta")
+
+	for _, post := range page.Posts {
 		pipe.ZAdd(ctx, cacheKey, redis.Z{
-			Score:  score,
+			Score:  post.Score,
 			Member: post.ID.String(),
 		})
 	}
+	pipe.HSet(ctx, cacheKey+":meta",
+		"as_of", page.AsOf.Format(time.RFC3339Nano),
+		"strategy", page.Strategy,
+		"complete", complete,
+	)
 
 	pipe.Expire(ctx, cacheKey, time.Hour)
+	pipe.Expire(ctx, cacheKey+":meta", time.Hour)
 
 	_, err := pipe.Exec(ctx)
 	if err != nil {
@@ -231,13 +531,60 @@ func (r *feedRepository) CacheFeedItems(ctx context.Context, userID uuid.UUID, p
 // InvalidateUserFeed removes cached feed for a user
 func (r *feedRepository) InvalidateUserFeed(ctx context.Context, userID uuid.UUID) error {
 	cacheKey := fmt.Sprintf("feed:%s", userID.String())
-	err := r.redis.Del(ctx, cacheKey).Err()
+	err := r.redis.Del(ctx, cacheKey, cacheKey+":meta").Err()
 	if err != nil {
 		return fmt.Errorf("failed to invalidate feed cache: %w", err)
 	}
 	return nil
 }
 
+// InspectFeedCache reports what is currently cached for a user's feed in Redis
+// alongside the stored fan-out items and follow count in Postgres
+func (r *feedRepository) InspectFeedCache(ctx context.Context, userID uuid.UUID, limit int) (*models.FeedCacheInfo, error) {
+	cacheKey := fmt.Sprintf("feed:%s", userID.String())
+
+	info := &models.FeedCacheInfo{UserID: userID}
+
+	cachedCount, err := r.redis.ZCard(ctx, cacheKey).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to count cached feed: %w", err)
+	}
+	info.CachedCount = cachedCount
+
+	ttl, err := r.redis.TTL(ctx, cacheKey).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get feed cache ttl: %w", err)
+	}
+	info.TTL = ttl
+
+	members, err := r.redis.ZRevRangeWithScores(ctx, cacheKey, 0, int64(limit-1)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cached feed: %w", err)
+	}
+	for _, member := range members {
+		postID, err := uuid.Parse(fmt.Sprint(member.Member))
+		if err != nil {
+			continue
+		}
+		info.Entries = append(info.Entries, models.CachedFeedEntry{PostID: postID, Score: member.Score})
+	}
+
+	err = r.db.ReadDB().GetContext(ctx, &info.StoredCount, `SELECT COUNT(*) FROM feed_service_cache WHERE user_id = $1`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count stored feed items: %w", err)
+	}
+
+	err = r.db.ReadDB().GetContext(ctx, &info.FollowingCount, `
+		SELECT COUNT(*) FROM feed_service_follows
+		WHERE follower_id = $1 AND deleted_at IS NULL
+	`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count following: %w", err)
+	}
+
+	return info, nil
+}
+
 // GetPostsWithLikeStatus retrieves like status for posts
 func (r *feedRepository) GetPostsWithLikeStatus(ctx context.Context, userID uuid.UUID, postIDs []uuid.UUID) (map[uuid.UUID]bool, error) {
 	if len(postIDs) == 0 {
@@ -254,7 +601,7 @@ func (r *feedRepository) GetPostsWithLikeStatus(ctx context.Context, userID uuid
 	}
 
 	var likedPostIDs []uuid.UUID
-	err = r.db.SelectContext(ctx, &likedPostIDs, r.db.Rebind(query), args...)
+	err = r.db.ReadDB().SelectContext(ctx, &likedPostIDs, r.db.Rebind(query), args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch like status: %w", err)
 	}
@@ -270,6 +617,23 @@ func (r *feedRepository) GetPostsWithLikeStatus(ctx context.Context, userID uuid
 	return result, nil
 }
 
+// AddPost projects a new post. A post already projected, for instance from
+// a repost, is kept. Posts with no visibility are public.
+func (r *feedRepository) AddPost(ctx context.Context, post models.Post) error {
+	query := `
+		INSERT INTO feed_service_posts (id, user_id, content, created_at, updated_at, visibility)
+		VALUES ($1, $2, $3, $4, $4, COALESCE(NULLIF($5, ''), 'PUBLIC'))
+		ON CONFLICT (id) DO NOTHING
+	`
+
+	_, err := r.db.ExecContext(ctx, query, post.ID, post.UserID, post.Content, post.CreatedAt, post.Visibility)
+	if err != nil {
+		return fmt.Errorf("failed to insert post: %w", err)
+	}
+
+	return nil
+}
+
 // InsertFeedItem inserts a single feed item (for fan-out on write)
 func (r *feedRepository) InsertFeedItem(ctx context.Context, userID, postID uuid.UUID) error {
 	query := `
@@ -306,35 +670,91 @@ func (r *feedRepository) BulkInsertFeedItems(ctx context.Context, items []models
 	return nil
 }
 
-// CleanupOldFeedItems removes old feed cache entries
-func (r *feedRepository) CleanupOldFeedItems(ctx context.Context, olderThan time.Time) error {
+// CleanupOldFeedItems removes old feed cache entries and seen marks and
+// returns how many were deleted
+func (r *feedRepository) CleanupOldFeedItems(ctx context.Context, olderThan time.Time) (int64, error) {
 	query := `DELETE FROM feed_service_cache WHERE created_at < $1`
 
 	result, err := r.db.ExecContext(ctx, query, olderThan)
 	if err != nil {
-		return fmt.Errorf("failed to cleanup old feed items: %w", err)
+		return 0, fmt.Errorf("failed to cleanup old feed items: %w", err)
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
 	}
 
-	rowsAffected, _ := result.RowsAffected()
-	fmt.Printf("Cleaned up %d old feed items\n", rowsAffected)
+	result, err = r.db.ExecContext(ctx, `DELETE FROM feed_service_seen WHERE seen_at < $1`, olderThan)
+	if err != nil {
+		return 0, fmt.Errorf("failed to cleanup old seen marks: %w", err)
+	}
+	seen, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
 
-	return nil
+	return deleted + seen, nil
 }
 
-// Helper functions
+// DeletePost removes a post from the projection, with its fan-out items,
+// likes and reposts. It returns the users whose cached feeds may hold the
+// post: those it was fanned out to and the followers of its author and
+// reposters. Deleting a post again removes nothing.
+func (r *feedRepository) DeletePost(ctx context.Context, postID, authorID uuid.UUID) ([]uuid.UUID, error) {
+	var userIDs []uuid.UUID
+	err := r.db.WithTx(ctx, func(ctx context.Context) error {
+		err := r.db.Conn(ctx).SelectContext(ctx, &userIDs, `
+			SELECT user_id FROM feed_service_cache WHERE post_id = $1
+			UNION
+			SELECT follower_id FROM feed_service_follows
+			WHERE deleted_at IS NULL
+				AND (followed_id = $2 OR followed_id IN (
+					SELECT user_id FROM feed_service_reposts WHERE post_id = $1
+				))
+		`, postID, authorID)
+		if err != nil {
+			return fmt.Errorf("failed to find feeds of post: %w", err)
+		}
 
-func (r *feedRepository) buildPostConnection(posts []models.Post, limit int, offset int) *models.PostConnection {
-	hasNextPage := len(posts) > limit
-	if hasNextPage {
-		posts = posts[:limit]
+		// Fan-out items, likes and reposts go with the post
+		if _, err := r.db.Conn(ctx).ExecContext(ctx, `DELETE FROM feed_service_posts WHERE id = $1`, postID); err != nil {
+			return fmt.Errorf("failed to delete post: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	edges := make([]models.PostEdge, len(posts))
-	for i, post := range posts {
-		cursor := encodeCursor(offset + i)
+	return userIDs, nil
+}
+
+// RemoveFromCachedFeeds removes a post from the cached feeds of users
+func (r *feedRepository) RemoveFromCachedFeeds(ctx context.Context, postID uuid.UUID, userIDs []uuid.UUID) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	pipe := r.redis.Pipeline()
+	for _, userID := range userIDs {
+		pipe.ZRem(ctx, fmt.Sprintf("feed:%s", userID.String()), postID.String())
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to remove post from cached feeds: %w", err)
+	}
+
+	return nil
+}
+
+// Helper functions
+
+func (r *feedRepository) buildPostConnection(page *models.FeedPage, hasPreviousPage bool) *models.PostConnection {
+	edges := make([]models.PostEdge, len(page.Posts))
+	for i, post := range page.Posts {
 		edges[i] = models.PostEdge{
-			Cursor: cursor,
-			Node:   post,
+			Cursor: cursor.EncodeRanked(post.Score, post.ID, page.AsOf),
+			Node:   post.Post,
 		}
 	}
 
@@ -350,24 +770,11 @@ func (r *feedRepository) buildPostConnection(posts []models.Post, limit int, off
 		Edges: edges,
 		PageInfo: models.PageInfo{
 			EndCursor:       endCursor,
-			HasNextPage:     hasNextPage,
+			HasNextPage:     page.HasNextPage,
 			StartCursor:     startCursor,
-			HasPreviousPage: offset > 0,
+			HasPreviousPage: hasPreviousPage,
 		},
-		TotalCount: int32(len(posts)),
-	}
-}
-
-func encodeCursor(offset int) string {
-	return base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%d", offset)))
-}
-
-func decodeCursor(cursor string) (int, error) {
-	decoded, err := base64.StdEncoding.DecodeString(cursor)
-	if err != nil {
-		return 0, err
+		TotalCount:      int32(len(edges)),
+		RankingStrategy: page.Strategy,
 	}
-	var offset int
-	_, err = fmt.Sscanf(string(decoded), "%d", &offset)
-	return offset, err
 }
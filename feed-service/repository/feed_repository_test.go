@@ -0,0 +1,185 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/redis/go-redis/v9"
+
+	"feed-service/db"
+	"feed-service/migrations"
+	"feed-service/model"
+	"feed-service/ranking"
+	"shared/migrate"
+)
+
+// newTestRepository connects to the Postgres and Redis named by
+// FEED_TEST_DB_DSN and FEED_TEST_REDIS_ADDR, skipping the test when they are
+// not set. The schema is migrated on the way.
+func newTestRepository(t *testing.T) (*feedRepository, *redis.Client) {
+	t.Helper()
+
+	dsn, redisAddr := os.Getenv("FEED_TEST_DB_DSN"), os.Getenv("FEED_TEST_REDIS_ADDR")
+	if dsn == "" || redisAddr == "" {
+		t.Skip("FEED_TEST_DB_DSN and FEED_TEST_REDIS_ADDR are not set")
+	}
+
+	conn, err := sqlx.Connect("postgres", dsn)
+	if err != nil {
+		t.Fatalf("failed to connect to database: %v", err)
+	}
+	dbConn := &database.DB{DB: conn}
+	t.Cleanup(func() { dbConn.Close() })
+
+	if _, err := migrate.Apply(context.Background(), dbConn.DB, "feed-service", migrations.Files); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+
+	redisClient := redis.NewClient(&redis.Options{Addr: redisAddr})
+	t.Cleanup(func() { redisClient.Close() })
+
+	assigner, err := ranking.NewAssigner(nil, ranking.Engagement, "")
+	if err != nil {
+		t.Fatalf("failed to create ranking assigner: %v", err)
+	}
+
+	return NewFeedRepository(dbConn, redisClient, 0, assigner).(*feedRepository), redisClient
+}
+
+// seedFeed has a new reader follow a new author who wrote n posts, a minute
+// apart, and returns the reader and the posts
+func seedFeed(t *testing.T, repo *feedRepository, redisClient *redis.Client, n int) (uuid.UUID, []uuid.UUID) {
+	t.Helper()
+	ctx := context.Background()
+
+	reader, author := uuid.New(), uuid.New()
+	t.Cleanup(func() {
+		repo.db.Exec(`DELETE FROM feed_service_posts WHERE user_id = $1`, author)
+		repo.db.Exec(`DELETE FROM feed_service_follows WHERE follower_id = $1`, reader)
+		redisClient.Del(context.Background(), fmt.Sprintf("feed:%s", reader), fmt.Sprintf("feed:%s:meta", reader))
+	})
+
+	now := time.Now()
+	if err := repo.AddFollow(ctx, reader, author, now.Add(-time.Hour)); err != nil {
+		t.Fatalf("failed to follow: %v", err)
+	}
+
+	posts := make([]uuid.UUID, n)
+	for i := range posts {
+		posts[i] = addTestPost(t, repo, author, now.Add(-time.Duration(i+1)*time.Minute))
+	}
+	return reader, posts
+}
+
+func addTestPost(t *testing.T, repo *feedRepository, author uuid.UUID, createdAt time.Time) uuid.UUID {
+	t.Helper()
+
+	id := uuid.New()
+	err := repo.AddPost(context.Background(), models.Post{
+		ID:         id,
+		UserID:     author,
+		Content:    "post " + id.String(),
+		CreatedAt:  createdAt,
+		Visibility: "PUBLIC",
+	})
+	if err != nil {
+		t.Fatalf("failed to add post: %v", err)
+	}
+	return id
+}
+
+// authorOf returns the author of a post in the projection
+func authorOf(t *testing.T, repo *feedRepository, postID uuid.UUID) uuid.UUID {
+	t.Helper()
+
+	var author uuid.UUID
+	if err := repo.db.Get(&author, `SELECT user_id FROM feed_service_posts WHERE id = $1`, postID); err != nil {
+		t.Fatalf("failed to look up author: %v", err)
+	}
+	return author
+}
+
+// TestGetFeedPagesAreStable pages through a feed while posts are added and
+// liked between pages. Every page is ranked as of the first, so the pages
+// together hold each post that was in the feed then exactly once.
+func TestGetFeedPagesAreStable(t *testing.T) {
+	repo, redisClient := newTestRepository(t)
+
+	tests := []struct {
+		name string
+		// dropCache empties the cached feed after the first page, so later
+		// pages are ranked from the stored posts
+		dropCache bool
+		// like likes posts between pages, which reorders them by engagement
+		like bool
+	}{
+		{name: "cached", like: true},
+		{name: "ranked from posts", dropCache: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			reader, posts := seedFeed(t, repo, redisClient, 25)
+			author := authorOf(t, repo, posts[0])
+
+			seen := make(map[uuid.UUID]int)
+			var after *string
+			for page := 1; ; page++ {
+				conn, err := repo.GetFeed(ctx, reader, 10, after, false)
+				if err != nil {
+					t.Fatalf("page %d: %v", page, err)
+				}
+				for _, edge := range conn.Edges {
+					seen[edge.Node.ID]++
+				}
+				if !conn.PageInfo.HasNextPage {
+					break
+				}
+				after = conn.PageInfo.EndCursor
+
+				if page == 1 {
+					// New posts rank above everything, and likes lift the
+					// posts at the end of the feed
+					for i := 0; i < 3; i++ {
+						addTestPost(t, repo, author, time.Now())
+					}
+					if tt.like {
+						for _, postID := range posts[len(posts)-5:] {
+							if err := repo.AddLike(ctx, uuid.New(), postID, time.Now()); err != nil {
+								t.Fatalf("failed to like: %v", err)
+							}
+						}
+					}
+					if tt.dropCache {
+						if err := repo.InvalidateUserFeed(ctx, reader); err != nil {
+							t.Fatalf("failed to drop cached feed: %v", err)
+						}
+					}
+				}
+				if page > len(posts) {
+					t.Fatalf("feed does not end")
+				}
+			}
+
+			for _, postID := range posts {
+				switch seen[postID] {
+				case 1:
+				case 0:
+					t.Errorf("post %s was skipped", postID)
+				default:
+					t.Errorf("post %s was returned %d times", postID, seen[postID])
+				}
+				delete(seen, postID)
+			}
+			for postID := range seen {
+				t.Errorf("post %s made after the first page was returned", postID)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"feed-service/model"
+	"github.com/google/uuid"
+)
+
+// AddRepost records a repost. The reposted post may be by someone the
+// projection has not seen yet, so it is projected from the event first.
+func (r *feedRepository) AddRepost(ctx context.Context, repost models.Repost, post models.Post) error {
+	return r.db.WithTx(ctx, func(ctx context.Context) error {
+		_, err := r.db.Conn(ctx).ExecContext(ctx, `
+			INSERT INTO feed_service_posts (id, user_id, content, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $4)
+			ON CONFLICT (id) DO NOTHING
+		`, post.ID, post.UserID, post.Content, post.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to upsert reposted post: %w", err)
+		}
+
+		_, err = r.db.Conn(ctx).ExecContext(ctx, `
+			INSERT INTO feed_service_reposts (user_id, post_id, created_at)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (user_id, post_id) DO NOTHING
+		`, repost.UserID, repost.PostID, repost.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to insert repost: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// RemoveRepost deletes a repost made no later than deletedAt, so a late
+// undo never removes a newer repost of the same post
+func (r *feedRepository) RemoveRepost(ctx context.Context, userID, postID uuid.UUID, deletedAt time.Time) error {
+	_, err := r.db.ExecContext(ctx, `
+		DELETE FROM feed_service_reposts
+		WHERE user_id = $1 AND post_id = $2 AND created_at <= $3
+	`, userID, postID, deletedAt)
+	if err != nil {
+		return fmt.Errorf("failed to delete repost: %w", err)
+	}
+	return nil
+}
+
+// GetFollowerIDs lists the users following userID
+func (r *feedRepository) GetFollowerIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	err := r.db.ReadDB().SelectContext(ctx, &ids, `
+		SELECT follower_id FROM feed_service_follows
+		WHERE followed_id = $1 AND deleted_at IS NULL
+	`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch followers: %w", err)
+	}
+	return ids, nil
+}
+
+// GetFollowingIDs lists the users userID follows
+func (r *feedRepository) GetFollowingIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	err := r.db.ReadDB().SelectContext(ctx, &ids, `
+		SELECT followed_id FROM feed_service_follows
+		WHERE follower_id = $1 AND deleted_at IS NULL
+	`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch following: %w", err)
+	}
+	return ids, nil
+}
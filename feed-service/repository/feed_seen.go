@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// MarkSeen records posts as seen by userID and returns how many were newly
+// marked. Posts already marked keep the time they were first seen, and IDs
+// of posts not in the projection are skipped.
+func (r *feedRepository) MarkSeen(ctx context.Context, userID uuid.UUID, postIDs []uuid.UUID) (int64, error) {
+	if len(postIDs) == 0 {
+		return 0, nil
+	}
+
+	query, args, err := sqlx.In(`
+		INSERT INTO feed_service_seen (user_id, post_id, seen_at)
+		SELECT ?, p.id, NOW()
+		FROM feed_service_posts p
+		WHERE p.id IN (?)
+		ON CONFLICT (user_id, post_id) DO NOTHING
+	`, userID, postIDs)
+	if err != nil {
+		return 0, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	result, err := r.db.ExecContext(ctx, r.db.Rebind(query), args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to mark posts seen: %w", err)
+	}
+
+	return result.RowsAffected()
+}
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"feed-service/model"
+	"github.com/google/uuid"
+)
+
+// UpdateAuthorStrategy counts an author's followers and records whether
+// their posts are pushed to followers or pulled at read time. Authors with at
+// least threshold followers are pulled; a threshold of 0 pushes everyone.
+func (r *feedRepository) UpdateAuthorStrategy(ctx context.Context, authorID uuid.UUID, threshold int) (models.FanOutStrategy, error) {
+	var strategy models.FanOutStrategy
+	err := r.db.GetContext(ctx, &strategy, `
+		INSERT INTO feed_service_author_strategies (user_id, strategy, follower_count, updated_at)
+		SELECT $1, CASE WHEN $2 > 0 AND c.n >= $2 THEN 'pull' ELSE 'push' END, c.n, NOW()
+		FROM (
+			SELECT COUNT(*) AS n FROM feed_service_follows
+			WHERE followed_id = $1 AND deleted_at IS NULL
+		) c
+		ON CONFLICT (user_id) DO UPDATE
+		SET strategy = EXCLUDED.strategy,
+			follower_count = EXCLUDED.follower_count,
+			updated_at = EXCLUDED.updated_at
+		RETURNING strategy
+	`, authorID, threshold)
+	if err != nil {
+		return "", fmt.Errorf("failed to update author strategy: %w", err)
+	}
+	return strategy, nil
+}
+
+// HasPulledPostsSince reports whether an author userID follows whose posts
+// are pulled at read time has posted or reposted after since
+func (r *feedRepository) HasPulledPostsSince(ctx context.Context, userID uuid.UUID, since time.Time) (bool, error) {
+	var pulled bool
+	err := r.db.ReadDB().GetContext(ctx, &pulled, `
+		SELECT EXISTS (
+			SELECT 1
+			FROM feed_service_follows f
+			INNER JOIN feed_service_author_strategies s
+				ON s.user_id = f.followed_id AND s.strategy = 'pull'
+			WHERE f.follower_id = $1
+				AND f.deleted_at IS NULL
+				AND (EXISTS (
+					SELECT 1 FROM feed_service_posts p
+					WHERE p.user_id = f.followed_id AND p.created_at > $2
+				) OR EXISTS (
+					SELECT 1 FROM feed_service_reposts rp
+					WHERE rp.user_id = f.followed_id AND rp.created_at > $2
+				))
+		)
+	`, userID, since)
+	if err != nil {
+		return false, fmt.Errorf("failed to check pulled posts: %w", err)
+	}
+	return pulled, nil
+}
@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// DeleteUserData removes everything projected for a deleted user: their
+// feed, likes, reposts, follows, seen posts and settings. Their likes are
+// counted off the posts they liked. Their posts are
+// removed as post-service deletes them, and their followers' feeds are
+// rebuilt as follow-service deletes their follows.
+func (r *feedRepository) DeleteUserData(ctx context.Context, userID uuid.UUID) error {
+	queries := []string{
+		`DELETE FROM feed_service_cache WHERE user_id = $1`,
+		`DELETE FROM feed_service_stats WHERE user_id = $1`,
+		`UPDATE feed_service_posts p SET likes_count = GREATEST(p.likes_count - 1, 0)
+			FROM feed_service_likes l
+			WHERE l.post_id = p.id AND l.user_id = $1 AND l.deleted_at IS NULL`,
+		`DELETE FROM feed_service_likes WHERE user_id = $1`,
+		`DELETE FROM feed_service_reposts WHERE user_id = $1`,
+		`DELETE FROM feed_service_follows WHERE follower_id = $1 OR followed_id = $1`,
+		`DELETE FROM feed_service_seen WHERE user_id = $1`,
+		`DELETE FROM feed_service_private_users WHERE user_id = $1`,
+		`DELETE FROM feed_service_author_strategies WHERE user_id = $1`,
+	}
+
+	err := r.db.WithTx(ctx, func(ctx context.Context) error {
+		for _, query := range queries {
+			if _, err := r.db.Conn(ctx).ExecContext(ctx, query, userID); err != nil {
+				return fmt.Errorf("failed to delete user data: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := r.redis.ZRem(ctx, activeUsersKey, userID.String()).Err(); err != nil {
+		return fmt.Errorf("failed to remove active user: %w", err)
+	}
+	return r.InvalidateUserFeed(ctx, userID)
+}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+// shouldRefreshEarly implements probabilistic early expiration (XFetch). A
+// cache hit is recomputed ahead of expiry with a probability that grows as the
+// remaining TTL shrinks and as rebuilds get slower, so a hot key is usually
+// refreshed by one request before it expires rather than by all of them after.
+// A beta of 0 disables early refresh; larger values refresh earlier.
+func shouldRefreshEarly(ttl, rebuild time.Duration, beta float64) bool {
+	if beta <= 0 || ttl <= 0 || rebuild <= 0 {
+		return false
+	}
+	return -float64(rebuild)*beta*math.Log(rand.Float64()) >= float64(ttl)
+}
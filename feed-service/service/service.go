@@ -4,32 +4,47 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"feed-service/events"
 	"feed-service/model"
+	"feed-service/nats"
 	"feed-service/repository"
 	"github.com/google/uuid"
+	"shared/logging"
 )
 
 // FeedBuilder handles feed generation and refresh operations
 type FeedBuilder interface {
 	// Fan-out on write: When a user creates a post, add it to all followers' feeds
-	FanOutPost(ctx context.Context, postID, authorID uuid.UUID) error
+	FanOutPost(ctx context.Context, post models.Post) error
+
+	// Fan-out on write for reposts: add the reposted post to the reposter's followers' feeds
+	FanOutRepost(ctx context.Context, postID, reposterID uuid.UUID) error
+
+	// Drop the reposter's followers' cached feeds so an undone repost disappears
+	RetractRepost(ctx context.Context, reposterID uuid.UUID) error
 
 	// Refresh a user's feed (can be triggered periodically or on-demand)
 	RefreshUserFeed(ctx context.Context, userID uuid.UUID) error
 
-	// Background job to refresh feeds for active users
-	RefreshActiveUserFeeds(ctx context.Context) error
+	// Background job to rebuild the cached feeds of active users before they expire
+	RefreshActiveUserFeeds(ctx context.Context, activeSince time.Time, expiringWithin time.Duration) (int, error)
 
 	// Remove post from all feeds (when post is deleted)
-	RemovePostFromFeeds(ctx context.Context, postID uuid.UUID) error
+	RemovePostFromFeeds(ctx context.Context, postID, authorID uuid.UUID) error
 }
 
 type feedBuilder struct {
 	feedRepo   repository.FeedRepository
 	followRepo FollowRepository
+	updates    UpdatePublisher
 	mu         sync.Mutex
+
+	// fanOutThreshold is the follower count from which an author's posts are
+	// pulled at read time instead of fanned out; 0 fans out every post
+	fanOutThreshold int
 }
 
 // FollowRepository interface for getting followers
@@ -38,22 +53,63 @@ type FollowRepository interface {
 	GetFollowingIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error)
 }
 
-func NewFeedBuilder(feedRepo repository.FeedRepository, followRepo FollowRepository) FeedBuilder {
+// UpdatePublisher publishes the live updates of users' feeds and waits for
+// JetStream to acknowledge them
+type UpdatePublisher interface {
+	PublishBatch(ctx context.Context, msgs []nats.Message) error
+}
+
+func NewFeedBuilder(feedRepo repository.FeedRepository, followRepo FollowRepository, updates UpdatePublisher, fanOutThreshold int) FeedBuilder {
 	return &feedBuilder{
-		feedRepo:   feedRepo,
-		followRepo: followRepo,
+		feedRepo:        feedRepo,
+		followRepo:      followRepo,
+		updates:         updates,
+		fanOutThreshold: fanOutThreshold,
 	}
 }
 
 // When a user creates a post, immediately add it to all their followers' feeds
-func (fb *feedBuilder) FanOutPost(ctx context.Context, postID, authorID uuid.UUID) error {
+// and push it to their feedUpdated subscriptions.
+// Only approved follows are projected, so followers-only posts never reach
+// users whose follow request is pending.
+// Authors with at least fanOutThreshold followers are skipped; their posts
+// are merged into feeds when they are read.
+func (fb *feedBuilder) FanOutPost(ctx context.Context, post models.Post) error {
+	followerIDs, err := fb.fanOut(ctx, post.ID, post.UserID)
+	if err != nil {
+		return err
+	}
+
+	fb.publishUpdates(ctx, post, followerIDs)
+	return nil
+}
+
+// FanOutRepost reaches the reposter's followers exactly as the reposter's
+// own post would. Reposts are not pushed to subscriptions: the reposted
+// post's visibility is not known here.
+func (fb *feedBuilder) FanOutRepost(ctx context.Context, postID, reposterID uuid.UUID) error {
+	_, err := fb.fanOut(ctx, postID, reposterID)
+	return err
+}
+
+// fanOut adds a post to the feeds of the followers of authorID and returns
+// them, or none when the author's posts are pulled at read time
+func (fb *feedBuilder) fanOut(ctx context.Context, postID, authorID uuid.UUID) ([]uuid.UUID, error) {
+	strategy, err := fb.feedRepo.UpdateAuthorStrategy(ctx, authorID, fb.fanOutThreshold)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decide fan-out strategy: %w", err)
+	}
+	if strategy == models.FanOutPull {
+		return nil, nil
+	}
+
 	followerIDs, err := fb.followRepo.GetFollowerIDs(ctx, authorID)
 	if err != nil {
-		return fmt.Errorf("failed to get followers: %w", err)
+		return nil, fmt.Errorf("failed to get followers: %w", err)
 	}
 
 	if len(followerIDs) == 0 {
-		return nil
+		return nil, nil
 	}
 
 	feedItems := make([]models.FeedCache, len(followerIDs))
@@ -70,11 +126,60 @@ func (fb *feedBuilder) FanOutPost(ctx context.Context, postID, authorID uuid.UUI
 
 	err = fb.feedRepo.BulkInsertFeedItems(ctx, feedItems)
 	if err != nil {
-		return fmt.Errorf("failed to fan out post: %w", err)
+		return nil, fmt.Errorf("failed to fan out post: %w", err)
 	}
 
 	go fb.invalidateFollowersCaches(context.Background(), followerIDs)
 
+	return followerIDs, nil
+}
+
+// publishUpdates pushes a fanned-out post to each follower's subject and
+// waits until the server has acknowledged them all, so a large fan-out holds
+// up the next event instead of piling up in memory. Live updates are best
+// effort: updates still unacknowledged after the publisher's retries are
+// logged and dropped rather than dead-lettered, since a late update is of no
+// use and the post still reaches the feeds.
+func (fb *feedBuilder) publishUpdates(ctx context.Context, post models.Post, followerIDs []uuid.UUID) {
+	if fb.updates == nil || len(followerIDs) == 0 {
+		return
+	}
+
+	update := events.FeedUpdateEvent{
+		PostID:     post.ID,
+		UserID:     post.UserID,
+		Content:    post.Content,
+		Visibility: post.Visibility,
+		CreatedAt:  post.CreatedAt,
+	}
+	if update.Visibility == "" {
+		update.Visibility = "PUBLIC"
+	}
+
+	msgs := make([]nats.Message, len(followerIDs))
+	for i, followerID := range followerIDs {
+		msgs[i] = nats.Message{
+			Subject: events.FeedUpdatesSubject(followerID),
+			// Keyed by post and follower, so a redelivered post is pushed once
+			MsgID: post.ID.String() + ":" + followerID.String(),
+			Data:  update,
+		}
+	}
+
+	if err := fb.updates.PublishBatch(ctx, msgs); err != nil {
+		logging.FromContext(ctx).Warn().Err(err).Stringer("post_id", post.ID).Msg("failed to publish feed updates")
+	}
+}
+
+// RetractRepost invalidates the cached feeds of the reposter's followers;
+// their next read rebuilds without the repost
+func (fb *feedBuilder) RetractRepost(ctx context.Context, reposterID uuid.UUID) error {
+	followerIDs, err := fb.followRepo.GetFollowerIDs(ctx, reposterID)
+	if err != nil {
+		return fmt.Errorf("failed to get followers: %w", err)
+	}
+
+	fb.invalidateFollowersCaches(ctx, followerIDs)
 	return nil
 }
 
@@ -85,12 +190,12 @@ func (fb *feedBuilder) RefreshUserFeed(ctx context.Context, userID uuid.UUID) er
 		return fmt.Errorf("failed to invalidate feed cache: %w", err)
 	}
 
-	posts, err := fb.feedRepo.BuildFeedForUser(ctx, userID, 100)
+	page, err := fb.feedRepo.BuildFeedForUser(ctx, userID, time.Now(), nil, repository.FeedCacheSize, false)
 	if err != nil {
 		return fmt.Errorf("failed to build feed: %w", err)
 	}
 
-	err = fb.feedRepo.CacheFeedItems(ctx, userID, posts)
+	err = fb.feedRepo.CacheFeedItems(ctx, userID, page)
 	if err != nil {
 		return fmt.Errorf("failed to cache feed: %w", err)
 	}
@@ -98,91 +203,87 @@ func (fb *feedBuilder) RefreshUserFeed(ctx context.Context, userID uuid.UUID) er
 	return nil
 }
 
-// RefreshActiveUserFeeds refreshes feeds for recently active users
-func (fb *feedBuilder) RefreshActiveUserFeeds(ctx context.Context) error {
-	return nil
-}
-
-// RemovePostFromFeeds removes a deleted post from all feeds
-func (fb *feedBuilder) RemovePostFromFeeds(ctx context.Context, postID uuid.UUID) error {
-
-	return nil
-}
+// RefreshActiveUserFeeds rebuilds the cached feeds of users active since
+// activeSince that are missing or expire within expiringWithin, so their next
+// GetFeed is served from the cache. The new feed replaces the old one in
+// place, so readers never find it missing. It returns how many were rebuilt.
+func (fb *feedBuilder) RefreshActiveUserFeeds(ctx context.Context, activeSince time.Time, expiringWithin time.Duration) (int, error) {
+	userIDs, err := fb.feedRepo.GetActiveUserIDs(ctx, activeSince)
+	if err != nil {
+		return 0, err
+	}
 
-// invalidateFollowersCaches invalidates Redis cache for multiple users
-func (fb *feedBuilder) invalidateFollowersCaches(ctx context.Context, userIDs []uuid.UUID) {
 	var wg sync.WaitGroup
+	var rebuilt atomic.Int64
 	sem := make(chan struct{}, 10)
 
 	for _, userID := range userIDs {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			wg.Wait()
+			return int(rebuilt.Load()), ctx.Err()
+		}
+
 		wg.Add(1)
 		go func(uid uuid.UUID) {
 			defer wg.Done()
-			sem <- struct{}{}
 			defer func() { <-sem }()
 
-			err := fb.feedRepo.InvalidateUserFeed(ctx, uid)
+			ttl, err := fb.feedRepo.FeedCacheTTL(ctx, uid)
 			if err != nil {
-				fmt.Printf("Failed to invalidate cache for user %s: %v\n", uid, err)
+				logging.FromContext(ctx).Error().Err(err).Stringer("user_id", uid).Msg("failed to check cached feed")
+				return
+			}
+			if ttl > expiringWithin {
+				return
+			}
+
+			page, err := fb.feedRepo.BuildFeedForUser(ctx, uid, time.Now(), nil, repository.FeedCacheSize, false)
+			if err == nil {
+				err = fb.feedRepo.CacheFeedItems(ctx, uid, page)
 			}
+			if err != nil {
+				logging.FromContext(ctx).Error().Err(err).Stringer("user_id", uid).Msg("failed to rebuild feed")
+				return
+			}
+			rebuilt.Add(1)
 		}(userID)
 	}
 
 	wg.Wait()
+	return int(rebuilt.Load()), nil
 }
 
-// FeedRankingService handles feed ranking algorithms
-type FeedRankingService struct {
-	feedRepo repository.FeedRepository
-}
-
-func NewFeedRankingService(feedRepo repository.FeedRepository) *FeedRankingService {
-	return &FeedRankingService{
-		feedRepo: feedRepo,
+// RemovePostFromFeeds removes a deleted post from the projection and from
+// every cached feed that may hold it
+func (fb *feedBuilder) RemovePostFromFeeds(ctx context.Context, postID, authorID uuid.UUID) error {
+	userIDs, err := fb.feedRepo.DeletePost(ctx, postID, authorID)
+	if err != nil {
+		return err
 	}
-}
-
-// CalculateFeedScore calculates a ranking score for a post in the feed
-func (frs *FeedRankingService) CalculateFeedScore(post models.Post, viewerID uuid.UUID) float64 {
-	now := time.Now()
-	age := now.Sub(post.CreatedAt).Hours()
-
-	timeDecay := 1.0 / (1.0 + age*0.1)
-
-	engagementScore := float64(post.LikesCount)*0.6 + float64(post.CommentsCount)*0.4
-
-	totalScore := (timeDecay * 0.6) + (engagementScore * 0.4)
 
-	return totalScore
+	return fb.feedRepo.RemoveFromCachedFeeds(ctx, postID, userIDs)
 }
 
-// RankPosts sorts posts by their calculated scores
-func (frs *FeedRankingService) RankPosts(posts []models.Post, viewerID uuid.UUID) []models.Post {
-	type scoredPost struct {
-		post  models.Post
-		score float64
-	}
+// invalidateFollowersCaches invalidates Redis cache for multiple users
+func (fb *feedBuilder) invalidateFollowersCaches(ctx context.Context, userIDs []uuid.UUID) {
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, 10)
 
-	scored := make([]scoredPost, len(posts))
-	for i, post := range posts {
-		scored[i] = scoredPost{
-			post:  post,
-			score: frs.CalculateFeedScore(post, viewerID),
-		}
-	}
+	for _, userID := range userIDs {
+		wg.Add(1)
+		go func(uid uuid.UUID) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
 
-	for i := 0; i < len(scored); i++ {
-		for j := i + 1; j < len(scored); j++ {
-			if scored[j].score > scored[i].score {
-				scored[i], scored[j] = scored[j], scored[i]
+			err := fb.feedRepo.InvalidateUserFeed(ctx, uid)
+			if err != nil {
+				fmt.Printf("Failed to invalidate cache for user %s: %v\n", uid, err)
 			}
-		}
-	}
-
-	result := make([]models.Post, len(scored))
-	for i, sp := range scored {
-		result[i] = sp.post
+		}(userID)
 	}
 
-	return result
+	wg.Wait()
 }
@@ -0,0 +1,80 @@
+package subscriber
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	natsClient "feed-service/nats"
+	"github.com/nats-io/nats.go"
+	"shared/dedupe"
+	"shared/logging"
+	"shared/tracing"
+)
+
+// subscribeRetry is the wait between attempts to subscribe while the stream
+// does not exist yet
+const subscribeRetry = 5 * time.Second
+
+// subscribeDurable subscribes handle to subject through a durable consumer
+// in the background. The stream is created by notification-service, so
+// subscribing is retried until it exists or ctx is done. The subscription is
+// never unsubscribed, which would delete the consumer; closing the NATS
+// connection ends it.
+func subscribeDurable(ctx context.Context, client *natsClient.Client, processed *dedupe.Store, subject, durableName string, handle func(ctx context.Context, msg *nats.Msg) error) {
+	handler := acked(ctx, processed, durableName, handle)
+	go func() {
+		for {
+			_, err := client.SubscribeDurable(subject, durableName, "feed-builders", handler)
+			if err == nil {
+				return
+			}
+
+			log.Printf("Failed to subscribe to %s, retrying: %v", subject, err)
+			select {
+			case <-time.After(subscribeRetry):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// acked runs handle for each message, acknowledging it once handle succeeds
+// and asking for it to be redelivered otherwise. Events the consumer already
+// handled are acknowledged without running handle again.
+func acked(ctx context.Context, processed *dedupe.Store, consumer string, handle func(ctx context.Context, msg *nats.Msg) error) nats.MsgHandler {
+	return func(msg *nats.Msg) {
+		ctx, span := tracing.StartProcess(ctx, msg.Subject, msg.Header)
+		ctx = logging.Extract(ctx, msg.Subject, msg.Header)
+		defer span.End()
+
+		err := processed.Process(ctx, consumer, dedupeID(msg), func(ctx context.Context) error {
+			return handle(ctx, msg)
+		})
+		if errors.Is(err, dedupe.ErrInProgress) {
+			msg.NakWithDelay(dedupe.Lease)
+			return
+		}
+		if err != nil {
+			logging.FromContext(ctx).Error().Err(err).Msg("failed to handle event")
+			msg.Nak()
+			return
+		}
+		msg.Ack()
+	}
+}
+
+// dedupeID identifies an event for the processed events: by the ID it was
+// published with or, lacking one, by the message holding it
+func dedupeID(msg *nats.Msg) string {
+	if id := dedupe.EventID(msg.Header); id != "" {
+		return id
+	}
+	meta, err := msg.Metadata()
+	if err != nil {
+		return ""
+	}
+	return dedupe.MessageID(meta.Stream, meta.Sequence.Stream, meta.Timestamp)
+}
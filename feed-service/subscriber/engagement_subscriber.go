@@ -0,0 +1,83 @@
+package subscriber
+
+import (
+	"context"
+	"log"
+
+	"feed-service/events"
+	natsClient "feed-service/nats"
+	"feed-service/repository"
+	"github.com/nats-io/nats.go"
+	"shared/dedupe"
+	"shared/eventschema"
+	eventspb "shared/eventschema/pb"
+)
+
+// EngagementSubscriber projects likes and comments, which rank feeds by the
+// user's affinity for authors and by the counts of posts. Cached feeds are
+// not rebuilt for them; the next rebuild ranks with the new counts.
+type EngagementSubscriber struct {
+	natsClient *natsClient.Client
+	processed  *dedupe.Store
+	repo       repository.FeedRepository
+	ctx        context.Context
+}
+
+func NewEngagementSubscriber(
+	natsClient *natsClient.Client,
+	processed *dedupe.Store,
+	repo repository.FeedRepository,
+	ctx context.Context,
+) *EngagementSubscriber {
+	return &EngagementSubscriber{
+		natsClient: natsClient,
+		processed:  processed,
+		repo:       repo,
+		ctx:        ctx,
+	}
+}
+
+// Start subscribes in the background
+func (s *EngagementSubscriber) Start() {
+	subscribeDurable(s.ctx, s.natsClient, s.processed, events.PostLiked, "feed-service-likes", s.handlePostLiked)
+	subscribeDurable(s.ctx, s.natsClient, s.processed, events.PostUnliked, "feed-service-unlikes", s.handlePostUnliked)
+	subscribeDurable(s.ctx, s.natsClient, s.processed, events.CommentAdded, "feed-service-comments", s.handleCommentAdded)
+	subscribeDurable(s.ctx, s.natsClient, s.processed, events.CommentDeleted, "feed-service-comment-deletions", s.handleCommentDeleted)
+	log.Println("Engagement subscriber started successfully")
+}
+
+func (s *EngagementSubscriber) handlePostLiked(ctx context.Context, msg *nats.Msg) error {
+	var event events.PostLikedEvent
+	if err := natsClient.DecodeEvent(msg, &event); err != nil {
+		return err
+	}
+
+	return s.repo.AddLike(ctx, event.UserID, event.PostID, event.CreatedAt)
+}
+
+func (s *EngagementSubscriber) handlePostUnliked(ctx context.Context, msg *nats.Msg) error {
+	var event events.PostUnlikedEvent
+	if err := natsClient.DecodeEvent(msg, &event); err != nil {
+		return err
+	}
+
+	return s.repo.RemoveLike(ctx, event.UserID, event.PostID, event.UnlikedAt)
+}
+
+func (s *EngagementSubscriber) handleCommentAdded(ctx context.Context, msg *nats.Msg) error {
+	var event eventspb.CommentAdded
+	if _, err := eventschema.Decode(events.CommentAdded, msg.Header, msg.Data, &event); err != nil {
+		return err
+	}
+
+	return s.repo.AddComment(ctx, eventschema.UUID(event.CommentId), eventschema.UUID(event.PostId), eventschema.Time(event.CreatedAt))
+}
+
+func (s *EngagementSubscriber) handleCommentDeleted(ctx context.Context, msg *nats.Msg) error {
+	var event events.CommentDeletedEvent
+	if err := natsClient.DecodeEvent(msg, &event); err != nil {
+		return err
+	}
+
+	return s.repo.RemoveComment(ctx, event.CommentID, event.PostID, event.DeletedAt)
+}
@@ -0,0 +1,73 @@
+package subscriber
+
+import (
+	"context"
+	"log"
+
+	"feed-service/events"
+	natsClient "feed-service/nats"
+	"feed-service/repository"
+	"feed-service/service"
+	"github.com/nats-io/nats.go"
+	"shared/dedupe"
+)
+
+// FollowSubscriber projects the follow graph and rebuilds the feed of a user
+// who follows or unfollows someone, so it shows, or stops showing, their
+// posts right away
+type FollowSubscriber struct {
+	natsClient *natsClient.Client
+	processed  *dedupe.Store
+	repo       repository.FeedRepository
+	builder    service.FeedBuilder
+	ctx        context.Context
+}
+
+func NewFollowSubscriber(
+	natsClient *natsClient.Client,
+	processed *dedupe.Store,
+	repo repository.FeedRepository,
+	builder service.FeedBuilder,
+	ctx context.Context,
+) *FollowSubscriber {
+	return &FollowSubscriber{
+		natsClient: natsClient,
+		processed:  processed,
+		repo:       repo,
+		builder:    builder,
+		ctx:        ctx,
+	}
+}
+
+// Start subscribes in the background
+func (s *FollowSubscriber) Start() {
+	subscribeDurable(s.ctx, s.natsClient, s.processed, events.UserFollowed, "feed-service-follows", s.handleUserFollowed)
+	subscribeDurable(s.ctx, s.natsClient, s.processed, events.UserUnfollowed, "feed-service-unfollows", s.handleUserUnfollowed)
+	log.Println("Follow subscriber started successfully")
+}
+
+func (s *FollowSubscriber) handleUserFollowed(ctx context.Context, msg *nats.Msg) error {
+	var event events.UserFollowedEvent
+	if err := natsClient.DecodeEvent(msg, &event); err != nil {
+		return err
+	}
+
+	if err := s.repo.AddFollow(ctx, event.FollowerID, event.FollowingID, event.CreatedAt); err != nil {
+		return err
+	}
+
+	return s.builder.RefreshUserFeed(ctx, event.FollowerID)
+}
+
+func (s *FollowSubscriber) handleUserUnfollowed(ctx context.Context, msg *nats.Msg) error {
+	var event events.UserUnfollowedEvent
+	if err := natsClient.DecodeEvent(msg, &event); err != nil {
+		return err
+	}
+
+	if err := s.repo.RemoveFollow(ctx, event.FollowerID, event.FollowingID, event.DeletedAt); err != nil {
+		return err
+	}
+
+	return s.builder.RefreshUserFeed(ctx, event.FollowerID)
+}
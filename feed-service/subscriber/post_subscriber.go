@@ -0,0 +1,98 @@
+package subscriber
+
+import (
+	"context"
+	"log"
+
+	"feed-service/events"
+	"feed-service/model"
+	natsClient "feed-service/nats"
+	"feed-service/repository"
+	"feed-service/service"
+	"github.com/nats-io/nats.go"
+	"shared/dedupe"
+	"shared/eventschema"
+	eventspb "shared/eventschema/pb"
+)
+
+// PostSubscriber fans new posts out to their author's followers, projects
+// edits, and removes deleted posts from the projection and from cached feeds. It consumes the
+// JetStream stream through durable consumers, so posts made while the service
+// is down still reach feeds, and handling an event twice is harmless.
+type PostSubscriber struct {
+	natsClient *natsClient.Client
+	processed  *dedupe.Store
+	repo       repository.FeedRepository
+	builder    service.FeedBuilder
+	ctx        context.Context
+}
+
+func NewPostSubscriber(
+	natsClient *natsClient.Client,
+	processed *dedupe.Store,
+	repo repository.FeedRepository,
+	builder service.FeedBuilder,
+	ctx context.Context,
+) *PostSubscriber {
+	return &PostSubscriber{
+		natsClient: natsClient,
+		processed:  processed,
+		repo:       repo,
+		builder:    builder,
+		ctx:        ctx,
+	}
+}
+
+// Start subscribes in the background
+func (s *PostSubscriber) Start() {
+	subscribeDurable(s.ctx, s.natsClient, s.processed, events.PostCreated, "feed-service-post-creations", s.handlePostCreated)
+	subscribeDurable(s.ctx, s.natsClient, s.processed, events.PostUpdated, "feed-service-post-updates", s.handlePostUpdated)
+	subscribeDurable(s.ctx, s.natsClient, s.processed, events.PostDeleted, "feed-service-post-deletions", s.handlePostDeleted)
+	log.Println("Post subscriber started successfully")
+}
+
+// handlePostCreated projects a post and fans it out to the author's
+// followers. Private posts are only ever read by their author, so they stay
+// out of every feed.
+func (s *PostSubscriber) handlePostCreated(ctx context.Context, msg *nats.Msg) error {
+	var event eventspb.PostCreated
+	if _, err := eventschema.Decode(events.PostCreated, msg.Header, msg.Data, &event); err != nil {
+		return err
+	}
+	if event.Visibility == "PRIVATE" {
+		return nil
+	}
+
+	post := models.Post{
+		ID:         eventschema.UUID(event.PostId),
+		UserID:     eventschema.UUID(event.UserId),
+		Content:    event.Content,
+		CreatedAt:  eventschema.Time(event.CreatedAt),
+		Visibility: event.Visibility,
+	}
+	if err := s.repo.AddPost(ctx, post); err != nil {
+		return err
+	}
+
+	return s.builder.FanOutPost(ctx, post)
+}
+
+// handlePostUpdated projects an edit. Cached feeds hold post IDs only, so
+// they show the new content without being rebuilt.
+func (s *PostSubscriber) handlePostUpdated(ctx context.Context, msg *nats.Msg) error {
+	var event eventspb.PostUpdated
+	if _, err := eventschema.Decode(events.PostUpdated, msg.Header, msg.Data, &event); err != nil {
+		return err
+	}
+
+	return s.repo.UpdatePost(ctx, eventschema.UUID(event.PostId), event.Content, eventschema.Time(event.UpdatedAt))
+}
+
+func (s *PostSubscriber) handlePostDeleted(ctx context.Context, msg *nats.Msg) error {
+	var event eventspb.PostDeleted
+	if _, err := eventschema.Decode(events.PostDeleted, msg.Header, msg.Data, &event); err != nil {
+		return err
+	}
+
+	return s.builder.RemovePostFromFeeds(ctx, eventschema.UUID(event.PostId), eventschema.UUID(event.UserId))
+}
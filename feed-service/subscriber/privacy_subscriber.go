@@ -0,0 +1,70 @@
+package subscriber
+
+import (
+	"context"
+	"log"
+
+	"feed-service/events"
+	natsClient "feed-service/nats"
+	"feed-service/repository"
+	"github.com/nats-io/nats.go"
+	"shared/logging"
+	"shared/tracing"
+)
+
+// PrivacySubscriber projects whether accounts are private, so feeds can hide
+// their posts from users who do not follow them. Every replica joins the same
+// queue group, so each event is handled once.
+type PrivacySubscriber struct {
+	natsClient *natsClient.Client
+	repo       repository.FeedRepository
+	ctx        context.Context
+	sub        *nats.Subscription
+}
+
+func NewPrivacySubscriber(
+	natsClient *natsClient.Client,
+	repo repository.FeedRepository,
+	ctx context.Context,
+) *PrivacySubscriber {
+	return &PrivacySubscriber{
+		natsClient: natsClient,
+		repo:       repo,
+		ctx:        ctx,
+	}
+}
+
+func (s *PrivacySubscriber) Start() error {
+	sub, err := s.natsClient.QueueSubscribe(events.UserUpdated, "feed-builders", func(msg *nats.Msg) {
+		ctx, span := tracing.StartProcess(s.ctx, msg.Subject, msg.Header)
+		ctx = logging.Extract(ctx, msg.Subject, msg.Header)
+		defer span.End()
+
+		if err := s.handleUserUpdated(ctx, msg); err != nil {
+			logging.FromContext(ctx).Error().Err(err).Msg("failed to handle event")
+		}
+	})
+	if err != nil {
+		return err
+	}
+	s.sub = sub
+
+	log.Println("Privacy subscriber started successfully")
+	return nil
+}
+
+func (s *PrivacySubscriber) handleUserUpdated(ctx context.Context, msg *nats.Msg) error {
+	var event events.UserUpdatedEvent
+	if err := natsClient.DecodeEvent(msg, &event); err != nil {
+		return err
+	}
+
+	return s.repo.SetUserPrivacy(ctx, event.UserID, event.IsPrivate, event.UpdatedAt)
+}
+
+func (s *PrivacySubscriber) Stop() error {
+	if s.sub != nil {
+		s.sub.Unsubscribe()
+	}
+	return nil
+}
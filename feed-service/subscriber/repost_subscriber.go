@@ -0,0 +1,109 @@
+package subscriber
+
+import (
+	"context"
+	"log"
+
+	"feed-service/events"
+	"feed-service/model"
+	natsClient "feed-service/nats"
+	"feed-service/repository"
+	"feed-service/service"
+	"github.com/nats-io/nats.go"
+	"shared/logging"
+	"shared/tracing"
+)
+
+// RepostSubscriber projects reposts into feed-service and pushes them to the
+// reposter's followers. Every replica joins the same queue group, so each
+// event is handled once.
+type RepostSubscriber struct {
+	natsClient *natsClient.Client
+	repo       repository.FeedRepository
+	builder    service.FeedBuilder
+	ctx        context.Context
+	subs       []*nats.Subscription
+}
+
+func NewRepostSubscriber(
+	natsClient *natsClient.Client,
+	repo repository.FeedRepository,
+	builder service.FeedBuilder,
+	ctx context.Context,
+) *RepostSubscriber {
+	return &RepostSubscriber{
+		natsClient: natsClient,
+		repo:       repo,
+		builder:    builder,
+		ctx:        ctx,
+	}
+}
+
+func (s *RepostSubscriber) Start() error {
+	handlers := map[string]func(ctx context.Context, msg *nats.Msg) error{
+		events.PostReposted:   s.handlePostReposted,
+		events.PostUnreposted: s.handlePostUnreposted,
+	}
+
+	for subject, handle := range handlers {
+		sub, err := s.natsClient.QueueSubscribe(subject, "feed-builders", func(msg *nats.Msg) {
+			ctx, span := tracing.StartProcess(s.ctx, msg.Subject, msg.Header)
+			ctx = logging.Extract(ctx, msg.Subject, msg.Header)
+			defer span.End()
+
+			if err := handle(ctx, msg); err != nil {
+				logging.FromContext(ctx).Error().Err(err).Msg("failed to handle event")
+			}
+		})
+		if err != nil {
+			return err
+		}
+		s.subs = append(s.subs, sub)
+	}
+
+	log.Println("Repost subscriber started successfully")
+	return nil
+}
+
+func (s *RepostSubscriber) handlePostReposted(ctx context.Context, msg *nats.Msg) error {
+	var event events.PostRepostedEvent
+	if err := natsClient.DecodeEvent(msg, &event); err != nil {
+		return err
+	}
+
+	err := s.repo.AddRepost(ctx, models.Repost{
+		UserID:    event.UserID,
+		PostID:    event.PostID,
+		CreatedAt: event.CreatedAt,
+	}, models.Post{
+		ID:        event.PostID,
+		UserID:    event.PostAuthorID,
+		Content:   event.PostContent,
+		CreatedAt: event.PostCreatedAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.builder.FanOutRepost(ctx, event.PostID, event.UserID)
+}
+
+func (s *RepostSubscriber) handlePostUnreposted(ctx context.Context, msg *nats.Msg) error {
+	var event events.PostUnrepostedEvent
+	if err := natsClient.DecodeEvent(msg, &event); err != nil {
+		return err
+	}
+
+	if err := s.repo.RemoveRepost(ctx, event.UserID, event.PostID, event.DeletedAt); err != nil {
+		return err
+	}
+
+	return s.builder.RetractRepost(ctx, event.UserID)
+}
+
+func (s *RepostSubscriber) Stop() error {
+	for _, sub := range s.subs {
+		sub.Unsubscribe()
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package subscriber
+
+import (
+	"context"
+	"log"
+
+	"feed-service/events"
+	natsClient "feed-service/nats"
+	"feed-service/repository"
+	"github.com/nats-io/nats.go"
+	"shared/dedupe"
+)
+
+// UserSubscriber removes the feed state of users who delete their account
+type UserSubscriber struct {
+	natsClient *natsClient.Client
+	processed  *dedupe.Store
+	repo       repository.FeedRepository
+	ctx        context.Context
+}
+
+func NewUserSubscriber(natsClient *natsClient.Client, processed *dedupe.Store, repo repository.FeedRepository, ctx context.Context) *UserSubscriber {
+	return &UserSubscriber{
+		natsClient: natsClient,
+		processed:  processed,
+		repo:       repo,
+		ctx:        ctx,
+	}
+}
+
+// Start subscribes in the background
+func (s *UserSubscriber) Start() {
+	subscribeDurable(s.ctx, s.natsClient, s.processed, events.UserDeleted, "feed-service-user-deletions", s.handleUserDeleted)
+	log.Println("User subscriber started successfully")
+}
+
+func (s *UserSubscriber) handleUserDeleted(ctx context.Context, msg *nats.Msg) error {
+	var event events.UserDeletedEvent
+	if err := natsClient.DecodeEvent(msg, &event); err != nil {
+		return err
+	}
+
+	return s.repo.DeleteUserData(ctx, event.UserID)
+}
@@ -0,0 +1,75 @@
+// Package warmup rebuilds the cached feeds of active users before they
+// expire, so their next GetFeed is served from the cache instead of ranking
+// the feed from Postgres. Every replica runs a worker, and a lock in Redis
+// lets one of them run each round.
+package warmup
+
+import (
+	"context"
+	"log"
+	"os"
+	"time"
+
+	"feed-service/service"
+	"github.com/redis/go-redis/v9"
+)
+
+const lockKey = "feed:warmup:lock"
+
+type Worker struct {
+	builder      service.FeedBuilder
+	redis        *redis.Client
+	interval     time.Duration
+	activeWindow time.Duration
+}
+
+// New returns a worker that every interval rebuilds the feeds of users who
+// made a request within activeWindow
+func New(builder service.FeedBuilder, redis *redis.Client, interval, activeWindow time.Duration) *Worker {
+	return &Worker{
+		builder:      builder,
+		redis:        redis,
+		interval:     interval,
+		activeWindow: activeWindow,
+	}
+}
+
+// Run warms feeds until ctx is cancelled
+func (w *Worker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		w.round(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// round rebuilds the feeds that would expire before the round after next,
+// which leaves a round to spare if one is skipped. The lock expires shortly
+// before the next round is due, so rounds are not skipped on the replica
+// holding it.
+func (w *Worker) round(ctx context.Context) {
+	owner, _ := os.Hostname()
+	locked, err := w.redis.SetNX(ctx, lockKey, owner, w.interval*9/10).Result()
+	if err != nil {
+		log.Printf("Failed to take feed warm-up lock: %v", err)
+		return
+	}
+	if !locked {
+		return
+	}
+
+	start := time.Now()
+	rebuilt, err := w.builder.RefreshActiveUserFeeds(ctx, start.Add(-w.activeWindow), 2*w.interval)
+	if err != nil {
+		log.Printf("Feed warm-up failed after rebuilding %d feeds: %v", rebuilt, err)
+		return
+	}
+	log.Printf("Feed warm-up rebuilt %d feeds in %s", rebuilt, time.Since(start).Round(time.Millisecond))
+}
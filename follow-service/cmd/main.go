@@ -2,14 +2,15 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"log"
 	"net"
 	"os"
-	"os/signal"
-	"syscall"
+	"time"
 
 	"github.com/joho/godotenv"
+	"github.com/redis/go-redis/v9"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
 
@@ -17,22 +18,46 @@ import (
 	"follow-service/db"
 	"follow-service/handler"
 	"follow-service/interceptor"
+	"follow-service/migrations"
+	natsClient "follow-service/nats"
 	pb "follow-service/pb"
+	"follow-service/publisher"
 	"follow-service/repository"
+	"follow-service/rpcerror"
+	"follow-service/runner"
+	"follow-service/subscriber"
+	"shared/chaos"
+	"shared/cursor"
+	"shared/idempotency"
+	"shared/lifecycle"
+	"shared/logging"
+	"shared/mtls"
+	"shared/tracing"
+	userpb "user-service/pb"
 )
 
 func main() {
+	logging.Init("follow-service")
+
+	migrateFlag := flag.Bool("migrate", false, "apply pending schema migrations before serving")
+	flag.Parse()
+
 	if err := godotenv.Load(); err != nil {
 		log.Println("failed to load Follow .env")
 	}
+
+	// The supervisor health-checks the dependencies below and closes them
+	// in order on shutdown
+	supervisor := lifecycle.New(pb.FollowService_ServiceDesc.ServiceName)
+
 	// Load database configuration
 	dbCfg, err := config.LoadDatabaseConfig("")
 	if err != nil {
 		log.Fatalf("Failed to load Follow database config: %v", err)
 	}
 
-	// Connect to the database
-	dbConn, err := database.NewConnection(database.Config{
+	// Connect to every shard of the database
+	shards, err := database.NewShards(database.Config{
 		Host:         dbCfg.Host,
 		Port:         dbCfg.Port,
 		User:         dbCfg.User,
@@ -42,57 +67,168 @@ func main() {
 		MaxOpenConns: dbCfg.MaxOpenConns,
 		MaxIdleConns: dbCfg.MaxIdleConns,
 		MaxLifetime:  dbCfg.MaxLifetime,
+		ReplicaDSNs:  dbCfg.ReplicaDSNs,
+		ShardDSNs:    dbCfg.ShardDSNs,
 	})
 	if err != nil {
 		log.Fatalf("Failed to connect to Follow database: %v", err)
 	}
-	defer dbConn.Close()
+	supervisor.Add(lifecycle.Dependency{Name: "database", Check: shards.HealthCheck, Close: shards.Close})
 
-	log.Println("Successfully connected to database")
+	// Apply pending schema migrations with -migrate or DB_MIGRATE=true;
+	// without either they are only reported
+	if err := shards.MigrateOnStartup(context.Background(), "follow-service", migrations.Files, *migrateFlag || os.Getenv("DB_MIGRATE") == "true"); err != nil {
+		log.Fatalf("Failed to migrate Follow database: %v", err)
+	}
+
+	log.Printf("Successfully connected to Follow database (%d shards)", shards.Len())
 
 	// Load other service-level configs
 	grpcPort := getEnv("GRPC_PORT", "50055")
 	jwtSecret := getEnv("JWT_SECRET", "your-secret-key")
+	// Tokens are verified with JWT_SECRET, or with auth-service's public keys
+	// when JWT_ALGORITHM is RS256
+	tokenKeys, err := interceptor.KeysFromEnv(jwtSecret)
+	if err != nil {
+		log.Fatalf("Invalid JWT configuration: %v", err)
+	}
 
-	// Initialize repository and handler
-	followRepo := repository.NewFollowRepository(dbConn.DB)
-	followHandler := handler.NewFollowHandler(followRepo)
+	// Fault injection for resilience testing; inert unless CHAOS_ENABLED=true
+	chaosInjector, err := chaos.FromEnv()
+	if err != nil {
+		log.Fatalf("Invalid chaos configuration: %v", err)
+	}
 
-	// Initialize auth interceptor (allowing public routes)
-	authInterceptor := interceptor.NewAuthInterceptor(jwtSecret, []string{
-		"/follow.FollowService/GetFollowers",
-		"/follow.FollowService/GetFollowing",
-	})
+	// Export traces when OTEL_EXPORTER_OTLP_ENDPOINT is set
+	shutdownTracing, err := tracing.Init(context.Background(), "follow-service")
+	if err != nil {
+		log.Fatalf("Failed to initialize tracing: %v", err)
+	}
+	supervisor.OnShutdown("tracing", shutdownTracing)
 
-	// Create gRPC server with interceptors
-	grpcServer := grpc.NewServer(
-		grpc.UnaryInterceptor(authInterceptor.Unary()),
-		grpc.StreamInterceptor(authInterceptor.Stream()),
-	)
+	// Pagination cursors are signed so clients cannot forge positions
+	if err := cursor.SetSecret(os.Getenv("CURSOR_SECRET")); err != nil {
+		log.Fatalf("Invalid CURSOR_SECRET: %v", err)
+	}
+	natsURL := getEnv("NATS_URL", "nats://nats:4222")
+	natsClientID := getEnv("NATS_CLIENT_ID", "follow-service")
 
-	// Graceful shutdown handling
-	go func() {
-		sigChan := make(chan os.Signal, 1)
-		signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
-		<-sigChan
+	// Initialize NATS client
+	natsCfg := natsClient.Config{
+		URL:           natsURL,
+		MaxReconnects: 10,
+		ReconnectWait: 2 * time.Second,
+		ClientID:      natsClientID,
+		Chaos:         chaosInjector,
+	}
 
-		log.Println("Follow service Shutting down gracefully...")
-		grpcServer.GracefulStop()
+	nats, err := natsClient.NewClient(natsCfg)
+	if err != nil {
+		log.Fatalf("Failed to initialize NATS client: %v", err)
+	}
+	supervisor.Add(lifecycle.Dependency{Name: "nats", Check: nats.HealthCheck, Drain: nats.Drain})
+	log.Println("NATS client initialized successfully")
+
+	// Initialize event publisher
+	eventPublisher := publisher.NewEventPublisher(nats)
 
-		ctx, cancel := context.WithTimeout(context.Background(), dbCfg.MaxLifetime)
-		defer cancel()
+	// Other services are dialed over TLS when GRPC_TLS is set
+	clientTLS, err := mtls.DialOption()
+	if err != nil {
+		log.Fatalf("Failed to load TLS config: %v", err)
+	}
 
-		if err := dbConn.HealthCheck(ctx); err == nil {
-			_ = dbConn.Close()
-			log.Println("Follow Database connection closed")
+	// Private accounts are looked up in user-service
+	userConn, err := grpc.NewClient(getEnv("USER_SERVICE_ADDR", "user-service:50052"), clientTLS, tracing.DialOption(), grpc.WithChainUnaryInterceptor(logging.UnaryClientInterceptor()))
+	if err != nil {
+		log.Fatalf("Failed to connect to user service: %v", err)
+	}
+	defer userConn.Close()
+
+	// Connect to Redis when REDIS_URL is set, to cache mutual followers;
+	// without it they are computed on every call
+	var redisClient *redis.Client
+	if redisURL := os.Getenv("REDIS_URL"); redisURL != "" {
+		redisClient = redis.NewClient(&redis.Options{
+			Addr:     redisURL,
+			Password: getEnv("REDIS_PASSWORD", ""),
+			DB:       getEnvAsInt("REDIS_DB", 0),
+			PoolSize: 10,
+		})
+		// Redis only caches, so the service stays up without it
+		supervisor.Add(lifecycle.Dependency{Name: "redis", Check: func(ctx context.Context) error { return redisClient.Ping(ctx).Err() }, Close: redisClient.Close, Optional: true})
+
+		pingCtx, pingCancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer pingCancel()
+		if err := redisClient.Ping(pingCtx).Err(); err != nil {
+			log.Fatalf("Failed to connect to Follow Redis: %v", err)
 		}
+		log.Println("Follow Redis connected successfully")
+	}
+
+	// Initialize repositories, import runner and handler
+	followRepo := repository.NewFollowRepository(shards, redisClient)
+	importRepo := repository.NewImportJobRepository(shards)
+	userClient := userpb.NewUserServiceClient(userConn)
+
+	// Follow imports run in the background at FOLLOW_IMPORT_RATE follows per
+	// second
+	importRunner := runner.New(importRepo, followRepo, userClient, eventPublisher, getEnvAsInt("FOLLOW_IMPORT_RATE", 200), 30*time.Second)
+	importCtx, stopImports := context.WithCancel(supervisor.Context())
+	defer stopImports()
+	go importRunner.Run(importCtx)
+
+	followHandler := handler.NewFollowHandler(followRepo, eventPublisher, userClient, importRepo, importRunner)
 
-		log.Println("Server stopped")
-		os.Exit(0)
-	}()
+	// Follows are deleted along with their user
+	subscriber.NewUserSubscriber(nats, followRepo, eventPublisher, context.Background()).Start()
+
+	// Initialize auth interceptor (allowing public routes). Follow
+	// relationships are public, and post-service checks them to show private
+	// accounts' posts to approved followers.
+	authInterceptor := interceptor.NewAuthInterceptor(tokenKeys, []string{
+		"/follow.FollowService/GetFollowers",
+		"/follow.FollowService/GetFollowing",
+		"/follow.FollowService/IsFollowing",
+		"/follow.FollowService/GetFollowStatus",
+	})
+	authInterceptor.AddAdminMethods([]string{
+		"/follow.FollowService/ImportFollows",
+	})
+	authInterceptor.AddPublicMethods(lifecycle.HealthMethods)
+
+	// Retries of writes that carry an idempotency-key header get the first
+	// call's response instead of writing again. Keys are per caller.
+	// Idempotency keys live on shard 0
+	idempotencyStore, err := idempotency.NewStore(shards.All()[0].WriteDB(), "follow_service_idempotency_keys", rpcerror.Domain, []byte(os.Getenv("IDEMPOTENCY_SECRET")))
+	if err != nil {
+		log.Fatalf("Invalid IDEMPOTENCY_SECRET: %v", err)
+	}
+	idempotencyCtx, stopIdempotency := context.WithCancel(supervisor.Context())
+	defer stopIdempotency()
+	go idempotencyStore.Run(idempotencyCtx)
+	idempotent := idempotencyStore.UnaryServerInterceptor(func(ctx context.Context) string {
+		userID, _ := interceptor.GetUserIDFromContext(ctx)
+		return userID
+	}, "/follow.FollowService/FollowUser")
+
+	// TLS, and mutual TLS with a CA bundle, from GRPC_TLS_*
+	tlsOption, err := mtls.ServerOption()
+	if err != nil {
+		log.Fatalf("Failed to load TLS config: %v", err)
+	}
+
+	// Create gRPC server with interceptors
+	grpcServer := grpc.NewServer(
+		tlsOption,
+		tracing.ServerOption(),
+		grpc.ChainUnaryInterceptor(logging.UnaryServerInterceptor(), rpcerror.UnaryServerInterceptor(), chaosInjector.Unary(), authInterceptor.Unary(), idempotent),
+		grpc.ChainStreamInterceptor(logging.StreamServerInterceptor(), rpcerror.StreamServerInterceptor(), chaosInjector.Stream(), authInterceptor.Stream()),
+	)
 
 	// Register the gRPC service
 	pb.RegisterFollowServiceServer(grpcServer, followHandler)
+	supervisor.Register(grpcServer)
 
 	// Enable reflection for debugging tools like grpcurl
 	reflection.Register(grpcServer)
@@ -106,7 +242,7 @@ func main() {
 	log.Printf("Follow Service gRPC server listening on port %s", grpcPort)
 
 	// Serve requests
-	if err := grpcServer.Serve(listener); err != nil {
+	if err := supervisor.Serve(grpcServer, listener); err != nil {
 		log.Fatalf("Failed to serve: %v", err)
 	}
 }
@@ -118,3 +254,12 @@ func getEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+func getEnvAsInt(key string, defaultVal int) int {
+	if val := os.Getenv(key); val != "" {
+		var intVal int
+		fmt.Sscanf(val, "%d", &intVal)
+		return intVal
+	}
+	return defaultVal
+}
@@ -0,0 +1,45 @@
+package events
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	UserFollowed    = "follow.created"
+	UserUnfollowed  = "follow.deleted"
+	FollowRequested = "follow.requested"
+	// UserDeleted is published by auth-service; the follows of the user are
+	// deleted with them
+	UserDeleted = "user.deleted"
+)
+
+// Event payloads
+type UserFollowedEvent struct {
+	FollowerID  uuid.UUID `json:"follower_id"`
+	FollowingID uuid.UUID `json:"following_id"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type UserUnfollowedEvent struct {
+	FollowerID  uuid.UUID `json:"follower_id"`
+	FollowingID uuid.UUID `json:"following_id"`
+	DeletedAt   time.Time `json:"deleted_at"`
+}
+
+// FollowRequestedEvent is published when someone asks to follow a private
+// account
+type FollowRequestedEvent struct {
+	RequestID   uuid.UUID `json:"request_id"`
+	FollowerID  uuid.UUID `json:"follower_id"`
+	FollowingID uuid.UUID `json:"following_id"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// UserDeletedEvent is published by auth-service when a user deletes their
+// account
+type UserDeletedEvent struct {
+	UserID    uuid.UUID `json:"user_id"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
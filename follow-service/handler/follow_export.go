@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"follow-service/model"
+	pb "follow-service/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// followExport is the document ExportMyData returns. Each list holds the
+// relationships with the caller on one side.
+type followExport struct {
+	Followers        []models.Follow `json:"followers"`
+	Following        []models.Follow `json:"following"`
+	RequestsReceived []models.Follow `json:"follow_requests_received"`
+	RequestsSent     []models.Follow `json:"follow_requests_sent"`
+}
+
+// ExportMyData returns the caller's followers, follows and pending follow
+// requests as a JSON document
+func (h *FollowHandler) ExportMyData(ctx context.Context, req *pb.ExportMyDataRequest) (*pb.DataExport, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	follows, err := h.repo.GetUserFollows(ctx, userID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to export follows: %v", err))
+	}
+
+	export := followExport{
+		Followers:        []models.Follow{},
+		Following:        []models.Follow{},
+		RequestsReceived: []models.Follow{},
+		RequestsSent:     []models.Follow{},
+	}
+	for _, follow := range follows.Follows {
+		if follow.FollowerID == userID {
+			export.Following = append(export.Following, follow)
+		} else {
+			export.Followers = append(export.Followers, follow)
+		}
+	}
+	for _, request := range follows.Requests {
+		if request.FollowerID == userID {
+			export.RequestsSent = append(export.RequestsSent, request)
+		} else {
+			export.RequestsReceived = append(export.RequestsReceived, request)
+		}
+	}
+
+	data, err := json.Marshal(export)
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to encode export: %v", err))
+	}
+	return &pb.DataExport{Data: data}, nil
+}
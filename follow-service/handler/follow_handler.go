@@ -2,53 +2,102 @@ package handler
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
+	"follow-service/events"
+	"follow-service/model"
 	pb "follow-service/pb"
+	"follow-service/publisher"
 	"follow-service/repository"
+	"follow-service/rpcerror"
+	"follow-service/runner"
 	"github.com/google/uuid"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
+	"shared/cursor"
+	"shared/logging"
+	userpb "user-service/pb"
 )
 
 type FollowHandler struct {
 	pb.UnimplementedFollowServiceServer
-	repo repository.FollowRepository
+	repo      repository.FollowRepository
+	publisher *publisher.EventPublisher
+	users     userpb.UserServiceClient
+	imports   repository.ImportJobRepository
+	importer  *runner.Runner
 }
 
-func NewFollowHandler(repo repository.FollowRepository) *FollowHandler {
+func NewFollowHandler(repo repository.FollowRepository, pub *publisher.EventPublisher, users userpb.UserServiceClient, imports repository.ImportJobRepository, importer *runner.Runner) *FollowHandler {
 	return &FollowHandler{
-		repo: repo,
+		repo:      repo,
+		publisher: pub,
+		users:     users,
+		imports:   imports,
+		importer:  importer,
 	}
 }
 
-// FollowUser handles the FollowUser RPC
+// FollowUser handles the FollowUser RPC. Following a private account only
+// sends a follow request, which the account owner has to approve.
 func (h *FollowHandler) FollowUser(ctx context.Context, req *pb.FollowUserRequest) (*pb.Response, error) {
 	if req.FollowerId == "" {
-		return nil, status.Error(codes.InvalidArgument, "follower_id is required")
+		return nil, rpcerror.InvalidField("follower_id", "follower_id is required")
 	}
 	if req.FollowingId == "" {
-		return nil, status.Error(codes.InvalidArgument, "following_id is required")
+		return nil, rpcerror.InvalidField("following_id", "following_id is required")
 	}
 
 	followerID, err := uuid.Parse(req.FollowerId)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid follower_id format")
+		return nil, rpcerror.InvalidField("follower_id", "invalid follower_id format")
 	}
 
 	followingID, err := uuid.Parse(req.FollowingId)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid following_id format")
+		return nil, rpcerror.InvalidField("following_id", "invalid following_id format")
 	}
 
 	if followerID == followingID {
 		return nil, status.Error(codes.InvalidArgument, "users cannot follow themselves")
 	}
 
-	if err := h.repo.FollowUser(ctx, followerID, followingID); err != nil {
+	requested, err := h.requestFollowIfPrivate(ctx, followerID, followingID)
+	if err != nil {
+		return nil, err
+	}
+	if requested {
+		return &pb.Response{
+			Success: true,
+			Message: "Follow request sent",
+		}, nil
+	}
+
+	created, err := h.repo.FollowUser(ctx, followerID, followingID)
+	if err != nil {
 		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to follow user: %v", err))
 	}
+	// Following again changes nothing, so nothing is published and the
+	// followed user is not counted or notified twice
+	if !created {
+		return &pb.Response{
+			Success: true,
+			Message: "Already following user",
+		}, nil
+	}
+
+	event := events.UserFollowedEvent{
+		FollowerID:  followerID,
+		FollowingID: followingID,
+		CreatedAt:   time.Now(),
+	}
+
+	if err := h.publisher.PublishUserFollowed(ctx, event); err != nil {
+		logging.FromContext(ctx).Error().Err(err).Msg("failed to publish user followed event")
+	}
 
 	return &pb.Response{
 		Success: true,
@@ -59,26 +108,36 @@ func (h *FollowHandler) FollowUser(ctx context.Context, req *pb.FollowUserReques
 // UnfollowUser handles the UnfollowUser RPC
 func (h *FollowHandler) UnfollowUser(ctx context.Context, req *pb.UnfollowUserRequest) (*pb.Response, error) {
 	if req.FollowerId == "" {
-		return nil, status.Error(codes.InvalidArgument, "follower_id is required")
+		return nil, rpcerror.InvalidField("follower_id", "follower_id is required")
 	}
 	if req.FollowingId == "" {
-		return nil, status.Error(codes.InvalidArgument, "following_id is required")
+		return nil, rpcerror.InvalidField("following_id", "following_id is required")
 	}
 
 	followerID, err := uuid.Parse(req.FollowerId)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid follower_id format")
+		return nil, rpcerror.InvalidField("follower_id", "invalid follower_id format")
 	}
 
 	followingID, err := uuid.Parse(req.FollowingId)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid following_id format")
+		return nil, rpcerror.InvalidField("following_id", "invalid following_id format")
 	}
 
 	if err := h.repo.UnfollowUser(ctx, followerID, followingID); err != nil {
 		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to unfollow user: %v", err))
 	}
 
+	event := events.UserUnfollowedEvent{
+		FollowerID:  followerID,
+		FollowingID: followingID,
+		DeletedAt:   time.Now(),
+	}
+
+	if err := h.publisher.PublishUserUnfollowed(ctx, event); err != nil {
+		logging.FromContext(ctx).Error().Err(err).Msg("failed to publish user unfollowed event")
+	}
+
 	return &pb.Response{
 		Success: true,
 		Message: "Successfully unfollowed user",
@@ -88,12 +147,12 @@ func (h *FollowHandler) UnfollowUser(ctx context.Context, req *pb.UnfollowUserRe
 // GetFollowers handles the GetFollowers RPC
 func (h *FollowHandler) GetFollowers(ctx context.Context, req *pb.GetFollowersRequest) (*pb.FollowConnection, error) {
 	if req.UserId == "" {
-		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+		return nil, rpcerror.InvalidField("user_id", "user_id is required")
 	}
 
 	userID, err := uuid.Parse(req.UserId)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid user_id format")
+		return nil, rpcerror.InvalidField("user_id", "invalid user_id format")
 	}
 
 	first := req.First
@@ -111,45 +170,24 @@ func (h *FollowHandler) GetFollowers(ctx context.Context, req *pb.GetFollowersRe
 
 	connection, err := h.repo.GetFollowers(ctx, userID, first, after)
 	if err != nil {
-		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to get followers: %v", err))
-	}
-
-	pbEdges := make([]*pb.FollowEdge, len(connection.Edges))
-	for i, edge := range connection.Edges {
-		pbEdges[i] = &pb.FollowEdge{
-			Cursor:     edge.Cursor,
-			UserId:     edge.UserID.String(),
-			FollowedAt: timestamppb.New(edge.FollowedAt),
+		if errors.Is(err, cursor.ErrInvalid) {
+			return nil, rpcerror.InvalidField("after", "invalid cursor")
 		}
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to get followers: %v", err))
 	}
 
-	pbPageInfo := &pb.PageInfo{
-		HasNextPage:     connection.PageInfo.HasNextPage,
-		HasPreviousPage: connection.PageInfo.HasPreviousPage,
-	}
-	if connection.PageInfo.EndCursor != nil {
-		pbPageInfo.EndCursor = connection.PageInfo.EndCursor
-	}
-	if connection.PageInfo.StartCursor != nil {
-		pbPageInfo.StartCursor = connection.PageInfo.StartCursor
-	}
-
-	return &pb.FollowConnection{
-		Edges:      pbEdges,
-		PageInfo:   pbPageInfo,
-		TotalCount: connection.TotalCount,
-	}, nil
+	return connectionToProto(connection), nil
 }
 
 // GetFollowing handles the GetFollowing RPC
 func (h *FollowHandler) GetFollowing(ctx context.Context, req *pb.GetFollowingRequest) (*pb.FollowConnection, error) {
 	if req.UserId == "" {
-		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+		return nil, rpcerror.InvalidField("user_id", "user_id is required")
 	}
 
 	userID, err := uuid.Parse(req.UserId)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid user_id format")
+		return nil, rpcerror.InvalidField("user_id", "invalid user_id format")
 	}
 
 	first := req.First
@@ -167,9 +205,16 @@ func (h *FollowHandler) GetFollowing(ctx context.Context, req *pb.GetFollowingRe
 
 	connection, err := h.repo.GetFollowing(ctx, userID, first, after)
 	if err != nil {
+		if errors.Is(err, cursor.ErrInvalid) {
+			return nil, rpcerror.InvalidField("after", "invalid cursor")
+		}
 		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to get following: %v", err))
 	}
 
+	return connectionToProto(connection), nil
+}
+
+func connectionToProto(connection *models.FollowConnection) *pb.FollowConnection {
 	pbEdges := make([]*pb.FollowEdge, len(connection.Edges))
 	for i, edge := range connection.Edges {
 		pbEdges[i] = &pb.FollowEdge{
@@ -194,26 +239,26 @@ func (h *FollowHandler) GetFollowing(ctx context.Context, req *pb.GetFollowingRe
 		Edges:      pbEdges,
 		PageInfo:   pbPageInfo,
 		TotalCount: connection.TotalCount,
-	}, nil
+	}
 }
 
 // IsFollowing handles the IsFollowing RPC
 func (h *FollowHandler) IsFollowing(ctx context.Context, req *pb.IsFollowingRequest) (*pb.IsFollowingResponse, error) {
 	if req.FollowerId == "" {
-		return nil, status.Error(codes.InvalidArgument, "follower_id is required")
+		return nil, rpcerror.InvalidField("follower_id", "follower_id is required")
 	}
 	if req.FollowingId == "" {
-		return nil, status.Error(codes.InvalidArgument, "following_id is required")
+		return nil, rpcerror.InvalidField("following_id", "following_id is required")
 	}
 
 	followerID, err := uuid.Parse(req.FollowerId)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid follower_id format")
+		return nil, rpcerror.InvalidField("follower_id", "invalid follower_id format")
 	}
 
 	followingID, err := uuid.Parse(req.FollowingId)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid following_id format")
+		return nil, rpcerror.InvalidField("following_id", "invalid following_id format")
 	}
 
 	isFollowing, err := h.repo.IsFollowing(ctx, followerID, followingID)
@@ -229,7 +274,7 @@ func (h *FollowHandler) IsFollowing(ctx context.Context, req *pb.IsFollowingRequ
 // GetFollowStatus handles the GetFollowStatus RPC
 func (h *FollowHandler) GetFollowStatus(ctx context.Context, req *pb.GetFollowStatusRequest) (*pb.GetFollowStatusResponse, error) {
 	if req.UserId == "" {
-		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+		return nil, rpcerror.InvalidField("user_id", "user_id is required")
 	}
 	if len(req.TargetUserIds) == 0 {
 		return &pb.GetFollowStatusResponse{Statuses: []*pb.FollowStatus{}}, nil
@@ -237,14 +282,14 @@ func (h *FollowHandler) GetFollowStatus(ctx context.Context, req *pb.GetFollowSt
 
 	userID, err := uuid.Parse(req.UserId)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid user_id format")
+		return nil, rpcerror.InvalidField("user_id", "invalid user_id format")
 	}
 
 	targetUserIDs := make([]uuid.UUID, len(req.TargetUserIds))
 	for i, idStr := range req.TargetUserIds {
 		id, err := uuid.Parse(idStr)
 		if err != nil {
-			return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("invalid target_user_id at index %d", i))
+			return nil, rpcerror.InvalidField(fmt.Sprintf("target_user_ids[%d]", i), fmt.Sprintf("invalid target_user_id at index %d", i))
 		}
 		targetUserIDs[i] = id
 	}
@@ -277,7 +322,7 @@ func (h *FollowHandler) GetFollowersCounts(ctx context.Context, req *pb.GetFollo
 	for i, idStr := range req.UserIds {
 		id, err := uuid.Parse(idStr)
 		if err != nil {
-			return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("invalid user_id at index %d", i))
+			return nil, rpcerror.InvalidField(fmt.Sprintf("user_ids[%d]", i), fmt.Sprintf("invalid user_id at index %d", i))
 		}
 		userIDs[i] = id
 	}
@@ -300,3 +345,48 @@ func (h *FollowHandler) GetFollowersCounts(ctx context.Context, req *pb.GetFollo
 		Counts: pbCounts,
 	}, nil
 }
+
+const maxImportBatch = 1000
+
+// ImportFollows stores relationships migrated from another platform. No
+// follow.created events are published, so importing an archive does not
+// notify anyone; feeds are filled in by the feed backfill afterwards.
+func (h *FollowHandler) ImportFollows(ctx context.Context, req *pb.ImportFollowsRequest) (*pb.ImportFollowsResponse, error) {
+	if len(req.Follows) > maxImportBatch {
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("at most %d follows can be imported per request", maxImportBatch))
+	}
+
+	follows := make([]models.Follow, 0, len(req.Follows))
+	for i, f := range req.Follows {
+		followerID, err := uuid.Parse(f.FollowerId)
+		if err != nil {
+			return nil, rpcerror.InvalidField(fmt.Sprintf("follows[%d].follower_id", i), fmt.Sprintf("invalid follower_id at index %d", i))
+		}
+		followingID, err := uuid.Parse(f.FollowingId)
+		if err != nil {
+			return nil, rpcerror.InvalidField(fmt.Sprintf("follows[%d].following_id", i), fmt.Sprintf("invalid following_id at index %d", i))
+		}
+		if f.CreatedAt == nil {
+			return nil, rpcerror.InvalidField(fmt.Sprintf("follows[%d].created_at", i), fmt.Sprintf("created_at is required at index %d", i))
+		}
+		if followerID == followingID {
+			continue
+		}
+
+		follows = append(follows, models.Follow{
+			FollowerID:  followerID,
+			FollowingID: followingID,
+			CreatedAt:   f.CreatedAt.AsTime(),
+		})
+	}
+
+	created, err := h.repo.ImportFollows(ctx, follows)
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to import follows: %v", err))
+	}
+
+	return &pb.ImportFollowsResponse{
+		Created: created,
+		Skipped: int32(len(req.Follows)) - created,
+	}, nil
+}
@@ -0,0 +1,338 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"follow-service/interceptor"
+	"follow-service/model"
+	pb "follow-service/pb"
+	"follow-service/repository"
+	"follow-service/rpcerror"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// maxUserImport and maxAdminImport bound the rows of one import file.
+	// Users import their own follows, admins those of many users; files are
+	// also bound by gRPC's 4 MB message limit.
+	maxUserImport  = 5000
+	maxAdminImport = 30000
+)
+
+// followFileColumns are the columns of exported and imported files
+var followFileColumns = []string{"follower_id", "following_id", "created_at"}
+
+// followFileRow is a row of a JSON follow file
+type followFileRow struct {
+	FollowerID  string `json:"follower_id"`
+	FollowingID string `json:"following_id"`
+	CreatedAt   string `json:"created_at"`
+}
+
+// ExportFollows returns the follows a user made, received or both as a CSV
+// or JSON file, most recent first. Users export their own follows; admins
+// those of any user.
+func (h *FollowHandler) ExportFollows(ctx context.Context, req *pb.ExportFollowsRequest) (*pb.FollowGraphExport, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req.UserId != nil && *req.UserId != userID.String() {
+		if !interceptor.HasRole(ctx, "ADMIN") {
+			return nil, status.Error(codes.PermissionDenied, "only admins can export the follows of other users")
+		}
+		userID, err = uuid.Parse(*req.UserId)
+		if err != nil {
+			return nil, rpcerror.InvalidField("user_id", "invalid user_id format")
+		}
+	}
+
+	follows, err := h.repo.GetUserFollows(ctx, userID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to export follows: %v", err))
+	}
+
+	rows := make([]followFileRow, 0, len(follows.Follows))
+	for _, follow := range follows.Follows {
+		switch {
+		case req.Direction == pb.FollowDirection_FOLLOW_DIRECTION_FOLLOWERS && follow.FollowingID != userID:
+			continue
+		case req.Direction == pb.FollowDirection_FOLLOW_DIRECTION_FOLLOWING && follow.FollowerID != userID:
+			continue
+		}
+		rows = append(rows, followFileRow{
+			FollowerID:  follow.FollowerID.String(),
+			FollowingID: follow.FollowingID.String(),
+			CreatedAt:   follow.CreatedAt.UTC().Format(time.RFC3339),
+		})
+	}
+
+	export := &pb.FollowGraphExport{Count: int32(len(rows))}
+	switch req.Format {
+	case pb.FollowFileFormat_FOLLOW_FILE_FORMAT_JSON:
+		export.ContentType = "application/json"
+		export.Data, err = json.Marshal(rows)
+	default:
+		export.ContentType = "text/csv"
+		export.Data, err = encodeFollowsCSV(rows)
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to encode export: %v", err))
+	}
+	return export, nil
+}
+
+func encodeFollowsCSV(rows []followFileRow) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write(followFileColumns); err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		if err := w.Write([]string{row.FollowerID, row.FollowingID, row.CreatedAt}); err != nil {
+			return nil, err
+		}
+	}
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
+
+// StartFollowImport checks a follow file and queues it as an import job.
+// Users import follows they make, which become follow requests where the
+// account is private; admins import any follows as they are. Repeated rows
+// are dropped and self-follows ignored. A user can have one unfinished
+// import at a time.
+func (h *FollowHandler) StartFollowImport(ctx context.Context, req *pb.StartFollowImportRequest) (*pb.FollowImportJob, error) {
+	callerUserID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	isAdmin := interceptor.HasRole(ctx, "ADMIN")
+
+	if len(req.Data) == 0 {
+		return nil, rpcerror.InvalidField("data", "data is required")
+	}
+
+	var rows []followFileRow
+	switch req.Format {
+	case pb.FollowFileFormat_FOLLOW_FILE_FORMAT_JSON:
+		if err := json.Unmarshal(req.Data, &rows); err != nil {
+			return nil, rpcerror.InvalidField("data", fmt.Sprintf("invalid JSON follow file: %v", err))
+		}
+	default:
+		rows, err = decodeFollowsCSV(req.Data)
+		if err != nil {
+			return nil, rpcerror.InvalidField("data", fmt.Sprintf("invalid CSV follow file: %v", err))
+		}
+	}
+
+	maxRows := maxUserImport
+	if isAdmin {
+		maxRows = maxAdminImport
+	}
+	if len(rows) > maxRows {
+		return nil, rpcerror.InvalidField("data", fmt.Sprintf("at most %d follows can be imported at once", maxRows))
+	}
+
+	follows := make([]models.Follow, 0, len(rows))
+	seen := make(map[[2]uuid.UUID]bool, len(rows))
+	var duplicates int32
+	for i, row := range rows {
+		follow, err := parseFollowRow(row, callerUserID, isAdmin)
+		if err != nil {
+			return nil, rpcerror.InvalidField("data", fmt.Sprintf("row %d: %v", i+1, err))
+		}
+		if follow.FollowerID == follow.FollowingID {
+			continue
+		}
+		pair := [2]uuid.UUID{follow.FollowerID, follow.FollowingID}
+		if seen[pair] {
+			duplicates++
+			continue
+		}
+		seen[pair] = true
+		follows = append(follows, follow)
+	}
+
+	job := &models.ImportJob{
+		ID:          uuid.New(),
+		RequestedBy: callerUserID,
+		Status:      models.ImportPending,
+		Total:       int32(len(follows)),
+		Duplicates:  duplicates,
+		CreatedAt:   time.Now(),
+	}
+	// Admin imports are not limited to one at a time
+	if !isAdmin {
+		job.OwnerID = &callerUserID
+	}
+
+	if err := h.imports.CreateImportJob(ctx, job, follows); err != nil {
+		if errors.Is(err, repository.ErrImportInProgress) {
+			return nil, rpcerror.New(codes.ResourceExhausted, "IMPORT_IN_PROGRESS", "wait for your current import to finish before starting another")
+		}
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to start import: %v", err))
+	}
+	h.importer.Wake()
+
+	return importJobToProto(job), nil
+}
+
+// decodeFollowsCSV reads a CSV follow file. Its header names the columns,
+// which may come in any order; created_at may be left out.
+func decodeFollowsCSV(data []byte) ([]followFileRow, error) {
+	r := csv.NewReader(bytes.NewReader(data))
+	r.TrimLeadingSpace = true
+
+	header, err := r.Read()
+	if err == io.EOF {
+		return nil, errors.New("missing header row")
+	}
+	if err != nil {
+		return nil, err
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := columns["following_id"]; !ok {
+		return nil, errors.New("missing following_id column")
+	}
+
+	field := func(record []string, name string) string {
+		if i, ok := columns[name]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	var rows []followFileRow
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, followFileRow{
+			FollowerID:  field(record, "follower_id"),
+			FollowingID: field(record, "following_id"),
+			CreatedAt:   field(record, "created_at"),
+		})
+	}
+}
+
+// parseFollowRow checks a row of a follow file. Users may only import their
+// own follows and may leave follower_id empty for them.
+func parseFollowRow(row followFileRow, callerUserID uuid.UUID, isAdmin bool) (models.Follow, error) {
+	var follow models.Follow
+
+	switch {
+	case row.FollowerID == "" && !isAdmin:
+		follow.FollowerID = callerUserID
+	case row.FollowerID == "":
+		return follow, errors.New("follower_id is required")
+	default:
+		followerID, err := uuid.Parse(row.FollowerID)
+		if err != nil {
+			return follow, errors.New("invalid follower_id format")
+		}
+		if !isAdmin && followerID != callerUserID {
+			return follow, errors.New("only follows you make can be imported")
+		}
+		follow.FollowerID = followerID
+	}
+
+	if row.FollowingID == "" {
+		return follow, errors.New("following_id is required")
+	}
+	followingID, err := uuid.Parse(row.FollowingID)
+	if err != nil {
+		return follow, errors.New("invalid following_id format")
+	}
+	follow.FollowingID = followingID
+
+	// Follows without a date are dated when they are imported
+	if row.CreatedAt != "" {
+		createdAt, err := time.Parse(time.RFC3339, row.CreatedAt)
+		if err != nil {
+			return follow, errors.New("created_at must be an RFC 3339 timestamp")
+		}
+		if createdAt.After(time.Now()) {
+			return follow, errors.New("created_at is in the future")
+		}
+		follow.CreatedAt = createdAt
+	}
+	return follow, nil
+}
+
+// GetFollowImportJob returns the progress of an import job to the user who
+// started it or an admin
+func (h *FollowHandler) GetFollowImportJob(ctx context.Context, req *pb.GetFollowImportJobRequest) (*pb.FollowImportJob, error) {
+	callerUserID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	jobID, err := uuid.Parse(req.JobId)
+	if err != nil {
+		return nil, rpcerror.InvalidField("job_id", "invalid job_id format")
+	}
+
+	job, err := h.imports.GetImportJob(ctx, jobID)
+	if errors.Is(err, repository.ErrImportJobNotFound) {
+		return nil, status.Error(codes.NotFound, "import job not found")
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to get import job: %v", err))
+	}
+	// Other users' jobs are reported as missing rather than forbidden
+	if job.RequestedBy != callerUserID && !interceptor.HasRole(ctx, "ADMIN") {
+		return nil, status.Error(codes.NotFound, "import job not found")
+	}
+
+	return importJobToProto(job), nil
+}
+
+var importStatusToProto = map[models.ImportStatus]pb.FollowImportStatus{
+	models.ImportPending:   pb.FollowImportStatus_FOLLOW_IMPORT_STATUS_PENDING,
+	models.ImportRunning:   pb.FollowImportStatus_FOLLOW_IMPORT_STATUS_RUNNING,
+	models.ImportCompleted: pb.FollowImportStatus_FOLLOW_IMPORT_STATUS_COMPLETED,
+	models.ImportFailed:    pb.FollowImportStatus_FOLLOW_IMPORT_STATUS_FAILED,
+}
+
+func importJobToProto(job *models.ImportJob) *pb.FollowImportJob {
+	pbJob := &pb.FollowImportJob{
+		Id:          job.ID.String(),
+		RequestedBy: job.RequestedBy.String(),
+		Status:      importStatusToProto[job.Status],
+		Total:       job.Total,
+		Duplicates:  job.Duplicates,
+		Processed:   job.Processed,
+		Created:     job.Created,
+		Requested:   job.Requested,
+		Skipped:     job.Skipped,
+		Failed:      job.Failed,
+		Error:       job.Error,
+		CreatedAt:   timestamppb.New(job.CreatedAt),
+	}
+	if job.StartedAt != nil {
+		pbJob.StartedAt = timestamppb.New(*job.StartedAt)
+	}
+	if job.FinishedAt != nil {
+		pbJob.FinishedAt = timestamppb.New(*job.FinishedAt)
+	}
+	return pbJob
+}
@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+
+	pb "follow-service/pb"
+	"follow-service/rpcerror"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GetMutualFollowers returns the users the caller follows who also follow a
+// user, with how many there are in all. Callers asking about themselves get
+// none.
+func (h *FollowHandler) GetMutualFollowers(ctx context.Context, req *pb.GetMutualFollowersRequest) (*pb.MutualFollowers, error) {
+	viewerID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.UserId == "" {
+		return nil, rpcerror.InvalidField("user_id", "user_id is required")
+	}
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, rpcerror.InvalidField("user_id", "invalid user_id format")
+	}
+	if userID == viewerID {
+		return &pb.MutualFollowers{UserIds: []string{}}, nil
+	}
+
+	first := req.First
+	if first <= 0 {
+		first = 3
+	}
+	if first > 100 {
+		first = 100
+	}
+
+	mutuals, err := h.repo.GetMutualFollowers(ctx, viewerID, userID, first)
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to get mutual followers: %v", err))
+	}
+
+	userIDs := make([]string, len(mutuals.UserIDs))
+	for i, id := range mutuals.UserIDs {
+		userIDs[i] = id.String()
+	}
+
+	return &pb.MutualFollowers{
+		UserIds:    userIDs,
+		TotalCount: mutuals.TotalCount,
+	}, nil
+}
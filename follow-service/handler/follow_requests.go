@@ -0,0 +1,167 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"follow-service/events"
+	"follow-service/interceptor"
+	pb "follow-service/pb"
+	"follow-service/repository"
+	"follow-service/rpcerror"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"shared/cursor"
+	"shared/logging"
+	userpb "user-service/pb"
+)
+
+// requestFollowIfPrivate records a follow request instead of a follow when
+// followingID is a private account that followerID does not follow yet, and
+// reports whether it did. If user-service cannot say whether the account is
+// private the follow is refused rather than risk bypassing approval.
+func (h *FollowHandler) requestFollowIfPrivate(ctx context.Context, followerID, followingID uuid.UUID) (bool, error) {
+	profile, err := h.users.GetProfile(ctx, &userpb.GetProfileRequest{UserId: followingID.String()})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return false, status.Error(codes.NotFound, "user not found")
+		}
+		return false, status.Error(codes.Unavailable, fmt.Sprintf("failed to look up user: %v", err))
+	}
+	if !profile.IsPrivate {
+		return false, nil
+	}
+
+	following, err := h.repo.IsFollowing(ctx, followerID, followingID)
+	if err != nil {
+		return false, status.Error(codes.Internal, fmt.Sprintf("failed to check following status: %v", err))
+	}
+	if following {
+		return false, nil
+	}
+
+	requestID := uuid.New()
+	created, err := h.repo.CreateFollowRequest(ctx, requestID, followerID, followingID)
+	if err != nil {
+		return false, status.Error(codes.Internal, fmt.Sprintf("failed to request follow: %v", err))
+	}
+	if !created {
+		return true, nil
+	}
+
+	event := events.FollowRequestedEvent{
+		RequestID:   requestID,
+		FollowerID:  followerID,
+		FollowingID: followingID,
+		CreatedAt:   time.Now(),
+	}
+	if err := h.publisher.PublishFollowRequested(ctx, event); err != nil {
+		logging.FromContext(ctx).Error().Err(err).Msg("failed to publish follow requested event")
+	}
+
+	return true, nil
+}
+
+// ApproveFollowRequest lets the caller accept a pending request to follow
+// them
+func (h *FollowHandler) ApproveFollowRequest(ctx context.Context, req *pb.ApproveFollowRequestRequest) (*pb.Response, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	requesterID, err := uuid.Parse(req.RequesterId)
+	if err != nil {
+		return nil, rpcerror.InvalidField("requester_id", "invalid requester_id format")
+	}
+
+	if err := h.repo.ApproveFollowRequest(ctx, requesterID, userID); err != nil {
+		if errors.Is(err, repository.ErrFollowRequestNotFound) {
+			return nil, status.Error(codes.NotFound, "follow request not found")
+		}
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to approve follow request: %v", err))
+	}
+
+	event := events.UserFollowedEvent{
+		FollowerID:  requesterID,
+		FollowingID: userID,
+		CreatedAt:   time.Now(),
+	}
+	if err := h.publisher.PublishUserFollowed(ctx, event); err != nil {
+		logging.FromContext(ctx).Error().Err(err).Msg("failed to publish user followed event")
+	}
+
+	return &pb.Response{
+		Success: true,
+		Message: "Follow request approved",
+	}, nil
+}
+
+// RejectFollowRequest lets the caller decline a pending request to follow
+// them. The requester is not notified.
+func (h *FollowHandler) RejectFollowRequest(ctx context.Context, req *pb.RejectFollowRequestRequest) (*pb.Response, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	requesterID, err := uuid.Parse(req.RequesterId)
+	if err != nil {
+		return nil, rpcerror.InvalidField("requester_id", "invalid requester_id format")
+	}
+
+	if err := h.repo.RejectFollowRequest(ctx, requesterID, userID); err != nil {
+		if errors.Is(err, repository.ErrFollowRequestNotFound) {
+			return nil, status.Error(codes.NotFound, "follow request not found")
+		}
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to reject follow request: %v", err))
+	}
+
+	return &pb.Response{
+		Success: true,
+		Message: "Follow request rejected",
+	}, nil
+}
+
+// ListFollowRequests returns the requests waiting for the caller's approval,
+// newest first
+func (h *FollowHandler) ListFollowRequests(ctx context.Context, req *pb.ListFollowRequestsRequest) (*pb.FollowConnection, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	first := req.First
+	if first <= 0 {
+		first = 10
+	}
+	if first > 100 {
+		first = 100
+	}
+
+	connection, err := h.repo.ListFollowRequests(ctx, userID, first, req.After)
+	if err != nil {
+		if errors.Is(err, cursor.ErrInvalid) {
+			return nil, rpcerror.InvalidField("after", "invalid cursor")
+		}
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to list follow requests: %v", err))
+	}
+
+	return connectionToProto(connection), nil
+}
+
+// callerID returns the authenticated user making the request
+func callerID(ctx context.Context) (uuid.UUID, error) {
+	raw, err := interceptor.GetUserIDFromContext(ctx)
+	if err != nil {
+		return uuid.Nil, status.Error(codes.Unauthenticated, "user not authenticated")
+	}
+	userID, err := uuid.Parse(raw)
+	if err != nil {
+		return uuid.Nil, status.Error(codes.Unauthenticated, "invalid user ID in token")
+	}
+	return userID, nil
+}
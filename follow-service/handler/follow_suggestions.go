@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	pb "follow-service/pb"
+	"follow-service/rpcerror"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"shared/cursor"
+)
+
+// GetFollowSuggestions returns users the caller may want to follow, ranked by
+// mutual follows and shared interests
+func (h *FollowHandler) GetFollowSuggestions(ctx context.Context, req *pb.GetFollowSuggestionsRequest) (*pb.FollowSuggestionConnection, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	first := req.First
+	if first <= 0 {
+		first = 10
+	}
+	if first > 100 {
+		first = 100
+	}
+
+	connection, err := h.repo.GetFollowSuggestions(ctx, userID, first, req.After)
+	if err != nil {
+		if errors.Is(err, cursor.ErrInvalid) {
+			return nil, rpcerror.InvalidField("after", "invalid cursor")
+		}
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to get follow suggestions: %v", err))
+	}
+
+	edges := make([]*pb.FollowSuggestionEdge, len(connection.Edges))
+	for i, edge := range connection.Edges {
+		edges[i] = &pb.FollowSuggestionEdge{
+			Cursor:          edge.Cursor,
+			UserId:          edge.UserID.String(),
+			MutualFollows:   edge.MutualFollows,
+			SharedInterests: edge.SharedInterests,
+		}
+	}
+
+	return &pb.FollowSuggestionConnection{
+		Edges: edges,
+		PageInfo: &pb.PageInfo{
+			EndCursor:       connection.PageInfo.EndCursor,
+			HasNextPage:     connection.PageInfo.HasNextPage,
+			StartCursor:     connection.PageInfo.StartCursor,
+			HasPreviousPage: connection.PageInfo.HasPreviousPage,
+		},
+	}, nil
+}
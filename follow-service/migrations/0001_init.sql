@@ -32,6 +32,23 @@ ON follow_service_follows(follower_id, following_id);
 CREATE INDEX IF NOT EXISTS idx_follow_service_follows_created_at 
 ON follow_service_follows(created_at DESC, id);
 
+-- ========================================
+-- Follow Requests Table
+-- ========================================
+-- Pending requests to follow private accounts, sharded by follower_id like
+-- follows so approving one is a single-shard transaction
+CREATE TABLE IF NOT EXISTS follow_service_follow_requests (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    follower_id UUID NOT NULL,
+    following_id UUID NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT unique_follow_request UNIQUE (follower_id, following_id),
+    CONSTRAINT no_self_follow_request CHECK (follower_id != following_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_follow_service_follow_requests_following_id
+ON follow_service_follow_requests(following_id, created_at DESC, id DESC);
+
 -- ========================================
 -- Functions
 -- ========================================
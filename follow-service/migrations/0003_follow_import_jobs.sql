@@ -0,0 +1,36 @@
+-- ========================================
+-- Follow Import Jobs
+-- ========================================
+-- Bulk imports of follows, run in the background batch by batch. follows
+-- holds the validated rows as JSON until the job finishes; processed doubles
+-- as the offset a resumed job continues from. owner_id is set for users
+-- importing their own follows and NULL for admin imports. Jobs live on
+-- shard 0.
+CREATE TABLE IF NOT EXISTS follow_service_import_jobs (
+    id UUID PRIMARY KEY,
+    requested_by UUID NOT NULL,
+    owner_id UUID,
+    status VARCHAR(16) NOT NULL DEFAULT 'PENDING',
+    follows JSONB,
+    total INTEGER NOT NULL DEFAULT 0,
+    duplicates INTEGER NOT NULL DEFAULT 0,
+    processed INTEGER NOT NULL DEFAULT 0,
+    created INTEGER NOT NULL DEFAULT 0,
+    requested INTEGER NOT NULL DEFAULT 0,
+    skipped INTEGER NOT NULL DEFAULT 0,
+    failed INTEGER NOT NULL DEFAULT 0,
+    error TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    started_at TIMESTAMP WITH TIME ZONE,
+    finished_at TIMESTAMP WITH TIME ZONE,
+    locked_until TIMESTAMP WITH TIME ZONE,
+    CONSTRAINT follow_import_jobs_status_valid CHECK (
+        status IN ('PENDING', 'RUNNING', 'COMPLETED', 'FAILED')
+    )
+);
+
+-- Users run one import at a time
+CREATE UNIQUE INDEX IF NOT EXISTS idx_follow_import_jobs_owner_unfinished ON follow_service_import_jobs(owner_id)
+    WHERE status IN ('PENDING', 'RUNNING');
+CREATE INDEX IF NOT EXISTS idx_follow_import_jobs_unfinished ON follow_service_import_jobs(created_at)
+    WHERE status IN ('PENDING', 'RUNNING');
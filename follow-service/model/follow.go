@@ -42,3 +42,28 @@ type UserFollowCounts struct {
 	FollowersCount int32     `json:"followers_count"`
 	FollowingCount int32     `json:"following_count"`
 }
+
+// FollowSuggestion is a user suggested to follow, with the signals that
+// ranked them
+type FollowSuggestion struct {
+	UserID          uuid.UUID `json:"user_id"`
+	MutualFollows   int32     `json:"mutual_follows"`   // Users the viewer follows who follow them
+	SharedInterests int32     `json:"shared_interests"` // Accounts both the viewer and they follow
+	Score           float64   `json:"score"`
+}
+
+type FollowSuggestionEdge struct {
+	Cursor string `json:"cursor"`
+	FollowSuggestion
+}
+
+type FollowSuggestionConnection struct {
+	Edges    []FollowSuggestionEdge `json:"edges"`
+	PageInfo PageInfo               `json:"page_info"`
+}
+
+// MutualFollowers are the users a viewer follows who follow another user
+type MutualFollowers struct {
+	UserIDs    []uuid.UUID `json:"user_ids"`
+	TotalCount int32       `json:"total_count"`
+}
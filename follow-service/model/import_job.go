@@ -0,0 +1,36 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ImportStatus string
+
+const (
+	ImportPending   ImportStatus = "PENDING"
+	ImportRunning   ImportStatus = "RUNNING"
+	ImportCompleted ImportStatus = "COMPLETED"
+	ImportFailed    ImportStatus = "FAILED"
+)
+
+// ImportJob is a bulk import of follows and its progress. OwnerID is the
+// user importing their own follows, or nil for an admin import.
+type ImportJob struct {
+	ID          uuid.UUID    `json:"id" db:"id"`
+	RequestedBy uuid.UUID    `json:"requested_by" db:"requested_by"`
+	OwnerID     *uuid.UUID   `json:"owner_id,omitempty" db:"owner_id"`
+	Status      ImportStatus `json:"status" db:"status"`
+	Total       int32        `json:"total" db:"total"`           // Follows left after dropping duplicates
+	Duplicates  int32        `json:"duplicates" db:"duplicates"` // Rows repeating an earlier row of the file
+	Processed   int32        `json:"processed" db:"processed"`
+	Created     int32        `json:"created" db:"created"`
+	Requested   int32        `json:"requested" db:"requested"` // Follow requests made to private accounts
+	Skipped     int32        `json:"skipped" db:"skipped"`     // Already following or requested
+	Failed      int32        `json:"failed" db:"failed"`       // Users that do not exist
+	Error       *string      `json:"error,omitempty" db:"error"`
+	CreatedAt   time.Time    `json:"created_at" db:"created_at"`
+	StartedAt   *time.Time   `json:"started_at,omitempty" db:"started_at"`
+	FinishedAt  *time.Time   `json:"finished_at,omitempty" db:"finished_at"`
+}
@@ -22,6 +22,162 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// Files have the columns follower_id, following_id and created_at, the
+// last of which may be left empty on import. CSV files start with a header
+// row; JSON files are an array of objects with those keys.
+type FollowFileFormat int32
+
+const (
+	FollowFileFormat_FOLLOW_FILE_FORMAT_UNSPECIFIED FollowFileFormat = 0 // CSV
+	FollowFileFormat_FOLLOW_FILE_FORMAT_CSV         FollowFileFormat = 1
+	FollowFileFormat_FOLLOW_FILE_FORMAT_JSON        FollowFileFormat = 2
+)
+
+// Enum value maps for FollowFileFormat.
+var (
+	FollowFileFormat_name = map[int32]string{
+		0: "FOLLOW_FILE_FORMAT_UNSPECIFIED",
+		1: "FOLLOW_FILE_FORMAT_CSV",
+		2: "FOLLOW_FILE_FORMAT_JSON",
+	}
+	FollowFileFormat_value = map[string]int32{
+		"FOLLOW_FILE_FORMAT_UNSPECIFIED": 0,
+		"FOLLOW_FILE_FORMAT_CSV":         1,
+		"FOLLOW_FILE_FORMAT_JSON":        2,
+	}
+)
+
+func (x FollowFileFormat) Enum() *FollowFileFormat {
+	p := new(FollowFileFormat)
+	*p = x
+	return p
+}
+
+func (x FollowFileFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (FollowFileFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_follow_proto_enumTypes[0].Descriptor()
+}
+
+func (FollowFileFormat) Type() protoreflect.EnumType {
+	return &file_proto_follow_proto_enumTypes[0]
+}
+
+func (x FollowFileFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use FollowFileFormat.Descriptor instead.
+func (FollowFileFormat) EnumDescriptor() ([]byte, []int) {
+	return file_proto_follow_proto_rawDescGZIP(), []int{0}
+}
+
+type FollowDirection int32
+
+const (
+	FollowDirection_FOLLOW_DIRECTION_UNSPECIFIED FollowDirection = 0 // Both
+	FollowDirection_FOLLOW_DIRECTION_FOLLOWERS   FollowDirection = 1
+	FollowDirection_FOLLOW_DIRECTION_FOLLOWING   FollowDirection = 2
+)
+
+// Enum value maps for FollowDirection.
+var (
+	FollowDirection_name = map[int32]string{
+		0: "FOLLOW_DIRECTION_UNSPECIFIED",
+		1: "FOLLOW_DIRECTION_FOLLOWERS",
+		2: "FOLLOW_DIRECTION_FOLLOWING",
+	}
+	FollowDirection_value = map[string]int32{
+		"FOLLOW_DIRECTION_UNSPECIFIED": 0,
+		"FOLLOW_DIRECTION_FOLLOWERS":   1,
+		"FOLLOW_DIRECTION_FOLLOWING":   2,
+	}
+)
+
+func (x FollowDirection) Enum() *FollowDirection {
+	p := new(FollowDirection)
+	*p = x
+	return p
+}
+
+func (x FollowDirection) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (FollowDirection) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_follow_proto_enumTypes[1].Descriptor()
+}
+
+func (FollowDirection) Type() protoreflect.EnumType {
+	return &file_proto_follow_proto_enumTypes[1]
+}
+
+func (x FollowDirection) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use FollowDirection.Descriptor instead.
+func (FollowDirection) EnumDescriptor() ([]byte, []int) {
+	return file_proto_follow_proto_rawDescGZIP(), []int{1}
+}
+
+type FollowImportStatus int32
+
+const (
+	FollowImportStatus_FOLLOW_IMPORT_STATUS_UNSPECIFIED FollowImportStatus = 0
+	FollowImportStatus_FOLLOW_IMPORT_STATUS_PENDING     FollowImportStatus = 1
+	FollowImportStatus_FOLLOW_IMPORT_STATUS_RUNNING     FollowImportStatus = 2
+	FollowImportStatus_FOLLOW_IMPORT_STATUS_COMPLETED   FollowImportStatus = 3
+	FollowImportStatus_FOLLOW_IMPORT_STATUS_FAILED      FollowImportStatus = 4
+)
+
+// Enum value maps for FollowImportStatus.
+var (
+	FollowImportStatus_name = map[int32]string{
+		0: "FOLLOW_IMPORT_STATUS_UNSPECIFIED",
+		1: "FOLLOW_IMPORT_STATUS_PENDING",
+		2: "FOLLOW_IMPORT_STATUS_RUNNING",
+		3: "FOLLOW_IMPORT_STATUS_COMPLETED",
+		4: "FOLLOW_IMPORT_STATUS_FAILED",
+	}
+	FollowImportStatus_value = map[string]int32{
+		"FOLLOW_IMPORT_STATUS_UNSPECIFIED": 0,
+		"FOLLOW_IMPORT_STATUS_PENDING":     1,
+		"FOLLOW_IMPORT_STATUS_RUNNING":     2,
+		"FOLLOW_IMPORT_STATUS_COMPLETED":   3,
+		"FOLLOW_IMPORT_STATUS_FAILED":      4,
+	}
+)
+
+func (x FollowImportStatus) Enum() *FollowImportStatus {
+	p := new(FollowImportStatus)
+	*p = x
+	return p
+}
+
+func (x FollowImportStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (FollowImportStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_follow_proto_enumTypes[2].Descriptor()
+}
+
+func (FollowImportStatus) Type() protoreflect.EnumType {
+	return &file_proto_follow_proto_enumTypes[2]
+}
+
+func (x FollowImportStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use FollowImportStatus.Descriptor instead.
+func (FollowImportStatus) EnumDescriptor() ([]byte, []int) {
+	return file_proto_follow_proto_rawDescGZIP(), []int{2}
+}
+
 type FollowUserRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	FollowerId    string                 `protobuf:"bytes,1,opt,name=follower_id,json=followerId,proto3" json:"follower_id,omitempty"`    // User who is following
@@ -714,30 +870,27 @@ func (x *FollowEdge) GetFollowedAt() *timestamppb.Timestamp {
 	return nil
 }
 
-type PageInfo struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	EndCursor       *string                `protobuf:"bytes,1,opt,name=end_cursor,json=endCursor,proto3,oneof" json:"end_cursor,omitempty"`
-	HasNextPage     bool                   `protobuf:"varint,2,opt,name=has_next_page,json=hasNextPage,proto3" json:"has_next_page,omitempty"`
-	StartCursor     *string                `protobuf:"bytes,3,opt,name=start_cursor,json=startCursor,proto3,oneof" json:"start_cursor,omitempty"`
-	HasPreviousPage bool                   `protobuf:"varint,4,opt,name=has_previous_page,json=hasPreviousPage,proto3" json:"has_previous_page,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+type ApproveFollowRequestRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RequesterId   string                 `protobuf:"bytes,1,opt,name=requester_id,json=requesterId,proto3" json:"requester_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PageInfo) Reset() {
-	*x = PageInfo{}
+func (x *ApproveFollowRequestRequest) Reset() {
+	*x = ApproveFollowRequestRequest{}
 	mi := &file_proto_follow_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PageInfo) String() string {
+func (x *ApproveFollowRequestRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PageInfo) ProtoMessage() {}
+func (*ApproveFollowRequestRequest) ProtoMessage() {}
 
-func (x *PageInfo) ProtoReflect() protoreflect.Message {
+func (x *ApproveFollowRequestRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_follow_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -749,63 +902,137 @@ func (x *PageInfo) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PageInfo.ProtoReflect.Descriptor instead.
-func (*PageInfo) Descriptor() ([]byte, []int) {
+// Deprecated: Use ApproveFollowRequestRequest.ProtoReflect.Descriptor instead.
+func (*ApproveFollowRequestRequest) Descriptor() ([]byte, []int) {
 	return file_proto_follow_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *PageInfo) GetEndCursor() string {
-	if x != nil && x.EndCursor != nil {
-		return *x.EndCursor
+func (x *ApproveFollowRequestRequest) GetRequesterId() string {
+	if x != nil {
+		return x.RequesterId
 	}
 	return ""
 }
 
-func (x *PageInfo) GetHasNextPage() bool {
+type RejectFollowRequestRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RequesterId   string                 `protobuf:"bytes,1,opt,name=requester_id,json=requesterId,proto3" json:"requester_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RejectFollowRequestRequest) Reset() {
+	*x = RejectFollowRequestRequest{}
+	mi := &file_proto_follow_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RejectFollowRequestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RejectFollowRequestRequest) ProtoMessage() {}
+
+func (x *RejectFollowRequestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_follow_proto_msgTypes[14]
 	if x != nil {
-		return x.HasNextPage
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return false
+	return mi.MessageOf(x)
 }
 
-func (x *PageInfo) GetStartCursor() string {
-	if x != nil && x.StartCursor != nil {
-		return *x.StartCursor
+// Deprecated: Use RejectFollowRequestRequest.ProtoReflect.Descriptor instead.
+func (*RejectFollowRequestRequest) Descriptor() ([]byte, []int) {
+	return file_proto_follow_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *RejectFollowRequestRequest) GetRequesterId() string {
+	if x != nil {
+		return x.RequesterId
 	}
 	return ""
 }
 
-func (x *PageInfo) GetHasPreviousPage() bool {
+type ListFollowRequestsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	First         int32                  `protobuf:"varint,1,opt,name=first,proto3" json:"first,omitempty"`
+	After         *string                `protobuf:"bytes,2,opt,name=after,proto3,oneof" json:"after,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFollowRequestsRequest) Reset() {
+	*x = ListFollowRequestsRequest{}
+	mi := &file_proto_follow_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFollowRequestsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFollowRequestsRequest) ProtoMessage() {}
+
+func (x *ListFollowRequestsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_follow_proto_msgTypes[15]
 	if x != nil {
-		return x.HasPreviousPage
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return false
+	return mi.MessageOf(x)
 }
 
-type FollowConnection struct {
+// Deprecated: Use ListFollowRequestsRequest.ProtoReflect.Descriptor instead.
+func (*ListFollowRequestsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_follow_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListFollowRequestsRequest) GetFirst() int32 {
+	if x != nil {
+		return x.First
+	}
+	return 0
+}
+
+func (x *ListFollowRequestsRequest) GetAfter() string {
+	if x != nil && x.After != nil {
+		return *x.After
+	}
+	return ""
+}
+
+type GetFollowSuggestionsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Edges         []*FollowEdge          `protobuf:"bytes,1,rep,name=edges,proto3" json:"edges,omitempty"`
-	PageInfo      *PageInfo              `protobuf:"bytes,2,opt,name=page_info,json=pageInfo,proto3" json:"page_info,omitempty"`
-	TotalCount    int32                  `protobuf:"varint,3,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	First         int32                  `protobuf:"varint,1,opt,name=first,proto3" json:"first,omitempty"`
+	After         *string                `protobuf:"bytes,2,opt,name=after,proto3,oneof" json:"after,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *FollowConnection) Reset() {
-	*x = FollowConnection{}
-	mi := &file_proto_follow_proto_msgTypes[14]
+func (x *GetFollowSuggestionsRequest) Reset() {
+	*x = GetFollowSuggestionsRequest{}
+	mi := &file_proto_follow_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *FollowConnection) String() string {
+func (x *GetFollowSuggestionsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*FollowConnection) ProtoMessage() {}
+func (*GetFollowSuggestionsRequest) ProtoMessage() {}
 
-func (x *FollowConnection) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_follow_proto_msgTypes[14]
+func (x *GetFollowSuggestionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_follow_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -816,55 +1043,116 @@ func (x *FollowConnection) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use FollowConnection.ProtoReflect.Descriptor instead.
-func (*FollowConnection) Descriptor() ([]byte, []int) {
-	return file_proto_follow_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use GetFollowSuggestionsRequest.ProtoReflect.Descriptor instead.
+func (*GetFollowSuggestionsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_follow_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *FollowConnection) GetEdges() []*FollowEdge {
+func (x *GetFollowSuggestionsRequest) GetFirst() int32 {
 	if x != nil {
-		return x.Edges
+		return x.First
 	}
-	return nil
+	return 0
 }
 
-func (x *FollowConnection) GetPageInfo() *PageInfo {
+func (x *GetFollowSuggestionsRequest) GetAfter() string {
+	if x != nil && x.After != nil {
+		return *x.After
+	}
+	return ""
+}
+
+type FollowSuggestionEdge struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Cursor          string                 `protobuf:"bytes,1,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	UserId          string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	MutualFollows   int32                  `protobuf:"varint,3,opt,name=mutual_follows,json=mutualFollows,proto3" json:"mutual_follows,omitempty"`       // Users the caller follows who follow this user
+	SharedInterests int32                  `protobuf:"varint,4,opt,name=shared_interests,json=sharedInterests,proto3" json:"shared_interests,omitempty"` // Accounts both the caller and this user follow
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *FollowSuggestionEdge) Reset() {
+	*x = FollowSuggestionEdge{}
+	mi := &file_proto_follow_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FollowSuggestionEdge) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FollowSuggestionEdge) ProtoMessage() {}
+
+func (x *FollowSuggestionEdge) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_follow_proto_msgTypes[17]
 	if x != nil {
-		return x.PageInfo
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *FollowConnection) GetTotalCount() int32 {
+// Deprecated: Use FollowSuggestionEdge.ProtoReflect.Descriptor instead.
+func (*FollowSuggestionEdge) Descriptor() ([]byte, []int) {
+	return file_proto_follow_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *FollowSuggestionEdge) GetCursor() string {
 	if x != nil {
-		return x.TotalCount
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *FollowSuggestionEdge) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *FollowSuggestionEdge) GetMutualFollows() int32 {
+	if x != nil {
+		return x.MutualFollows
 	}
 	return 0
 }
 
-type Response struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+func (x *FollowSuggestionEdge) GetSharedInterests() int32 {
+	if x != nil {
+		return x.SharedInterests
+	}
+	return 0
+}
+
+type FollowSuggestionConnection struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Edges         []*FollowSuggestionEdge `protobuf:"bytes,1,rep,name=edges,proto3" json:"edges,omitempty"`
+	PageInfo      *PageInfo               `protobuf:"bytes,2,opt,name=page_info,json=pageInfo,proto3" json:"page_info,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Response) Reset() {
-	*x = Response{}
-	mi := &file_proto_follow_proto_msgTypes[15]
+func (x *FollowSuggestionConnection) Reset() {
+	*x = FollowSuggestionConnection{}
+	mi := &file_proto_follow_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Response) String() string {
+func (x *FollowSuggestionConnection) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Response) ProtoMessage() {}
+func (*FollowSuggestionConnection) ProtoMessage() {}
 
-func (x *Response) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_follow_proto_msgTypes[15]
+func (x *FollowSuggestionConnection) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_follow_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -875,26 +1163,912 @@ func (x *Response) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Response.ProtoReflect.Descriptor instead.
-func (*Response) Descriptor() ([]byte, []int) {
-	return file_proto_follow_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use FollowSuggestionConnection.ProtoReflect.Descriptor instead.
+func (*FollowSuggestionConnection) Descriptor() ([]byte, []int) {
+	return file_proto_follow_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *Response) GetSuccess() bool {
+func (x *FollowSuggestionConnection) GetEdges() []*FollowSuggestionEdge {
 	if x != nil {
-		return x.Success
+		return x.Edges
 	}
-	return false
+	return nil
 }
 
-func (x *Response) GetMessage() string {
+func (x *FollowSuggestionConnection) GetPageInfo() *PageInfo {
 	if x != nil {
-		return x.Message
+		return x.PageInfo
 	}
-	return ""
+	return nil
 }
 
-var File_proto_follow_proto protoreflect.FileDescriptor
+type GetMutualFollowersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	First         int32                  `protobuf:"varint,2,opt,name=first,proto3" json:"first,omitempty"` // Default 3, at most 100
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMutualFollowersRequest) Reset() {
+	*x = GetMutualFollowersRequest{}
+	mi := &file_proto_follow_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMutualFollowersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMutualFollowersRequest) ProtoMessage() {}
+
+func (x *GetMutualFollowersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_follow_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMutualFollowersRequest.ProtoReflect.Descriptor instead.
+func (*GetMutualFollowersRequest) Descriptor() ([]byte, []int) {
+	return file_proto_follow_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetMutualFollowersRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetMutualFollowersRequest) GetFirst() int32 {
+	if x != nil {
+		return x.First
+	}
+	return 0
+}
+
+type MutualFollowers struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserIds       []string               `protobuf:"bytes,1,rep,name=user_ids,json=userIds,proto3" json:"user_ids,omitempty"` // Most recently followed by the caller first
+	TotalCount    int32                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MutualFollowers) Reset() {
+	*x = MutualFollowers{}
+	mi := &file_proto_follow_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MutualFollowers) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MutualFollowers) ProtoMessage() {}
+
+func (x *MutualFollowers) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_follow_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MutualFollowers.ProtoReflect.Descriptor instead.
+func (*MutualFollowers) Descriptor() ([]byte, []int) {
+	return file_proto_follow_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *MutualFollowers) GetUserIds() []string {
+	if x != nil {
+		return x.UserIds
+	}
+	return nil
+}
+
+func (x *MutualFollowers) GetTotalCount() int32 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+type ImportedFollow struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FollowerId    string                 `protobuf:"bytes,1,opt,name=follower_id,json=followerId,proto3" json:"follower_id,omitempty"`
+	FollowingId   string                 `protobuf:"bytes,2,opt,name=following_id,json=followingId,proto3" json:"following_id,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportedFollow) Reset() {
+	*x = ImportedFollow{}
+	mi := &file_proto_follow_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportedFollow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportedFollow) ProtoMessage() {}
+
+func (x *ImportedFollow) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_follow_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportedFollow.ProtoReflect.Descriptor instead.
+func (*ImportedFollow) Descriptor() ([]byte, []int) {
+	return file_proto_follow_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ImportedFollow) GetFollowerId() string {
+	if x != nil {
+		return x.FollowerId
+	}
+	return ""
+}
+
+func (x *ImportedFollow) GetFollowingId() string {
+	if x != nil {
+		return x.FollowingId
+	}
+	return ""
+}
+
+func (x *ImportedFollow) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type ImportFollowsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Follows       []*ImportedFollow      `protobuf:"bytes,1,rep,name=follows,proto3" json:"follows,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportFollowsRequest) Reset() {
+	*x = ImportFollowsRequest{}
+	mi := &file_proto_follow_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportFollowsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportFollowsRequest) ProtoMessage() {}
+
+func (x *ImportFollowsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_follow_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportFollowsRequest.ProtoReflect.Descriptor instead.
+func (*ImportFollowsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_follow_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ImportFollowsRequest) GetFollows() []*ImportedFollow {
+	if x != nil {
+		return x.Follows
+	}
+	return nil
+}
+
+type ImportFollowsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Created       int32                  `protobuf:"varint,1,opt,name=created,proto3" json:"created,omitempty"`
+	Skipped       int32                  `protobuf:"varint,2,opt,name=skipped,proto3" json:"skipped,omitempty"` // Relationships that already exist or are self-follows
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportFollowsResponse) Reset() {
+	*x = ImportFollowsResponse{}
+	mi := &file_proto_follow_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportFollowsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportFollowsResponse) ProtoMessage() {}
+
+func (x *ImportFollowsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_follow_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportFollowsResponse.ProtoReflect.Descriptor instead.
+func (*ImportFollowsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_follow_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ImportFollowsResponse) GetCreated() int32 {
+	if x != nil {
+		return x.Created
+	}
+	return 0
+}
+
+func (x *ImportFollowsResponse) GetSkipped() int32 {
+	if x != nil {
+		return x.Skipped
+	}
+	return 0
+}
+
+type PageInfo struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	EndCursor       *string                `protobuf:"bytes,1,opt,name=end_cursor,json=endCursor,proto3,oneof" json:"end_cursor,omitempty"`
+	HasNextPage     bool                   `protobuf:"varint,2,opt,name=has_next_page,json=hasNextPage,proto3" json:"has_next_page,omitempty"`
+	StartCursor     *string                `protobuf:"bytes,3,opt,name=start_cursor,json=startCursor,proto3,oneof" json:"start_cursor,omitempty"`
+	HasPreviousPage bool                   `protobuf:"varint,4,opt,name=has_previous_page,json=hasPreviousPage,proto3" json:"has_previous_page,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *PageInfo) Reset() {
+	*x = PageInfo{}
+	mi := &file_proto_follow_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PageInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PageInfo) ProtoMessage() {}
+
+func (x *PageInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_follow_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PageInfo.ProtoReflect.Descriptor instead.
+func (*PageInfo) Descriptor() ([]byte, []int) {
+	return file_proto_follow_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *PageInfo) GetEndCursor() string {
+	if x != nil && x.EndCursor != nil {
+		return *x.EndCursor
+	}
+	return ""
+}
+
+func (x *PageInfo) GetHasNextPage() bool {
+	if x != nil {
+		return x.HasNextPage
+	}
+	return false
+}
+
+func (x *PageInfo) GetStartCursor() string {
+	if x != nil && x.StartCursor != nil {
+		return *x.StartCursor
+	}
+	return ""
+}
+
+func (x *PageInfo) GetHasPreviousPage() bool {
+	if x != nil {
+		return x.HasPreviousPage
+	}
+	return false
+}
+
+type FollowConnection struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Edges         []*FollowEdge          `protobuf:"bytes,1,rep,name=edges,proto3" json:"edges,omitempty"`
+	PageInfo      *PageInfo              `protobuf:"bytes,2,opt,name=page_info,json=pageInfo,proto3" json:"page_info,omitempty"`
+	TotalCount    int32                  `protobuf:"varint,3,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FollowConnection) Reset() {
+	*x = FollowConnection{}
+	mi := &file_proto_follow_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FollowConnection) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FollowConnection) ProtoMessage() {}
+
+func (x *FollowConnection) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_follow_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FollowConnection.ProtoReflect.Descriptor instead.
+func (*FollowConnection) Descriptor() ([]byte, []int) {
+	return file_proto_follow_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *FollowConnection) GetEdges() []*FollowEdge {
+	if x != nil {
+		return x.Edges
+	}
+	return nil
+}
+
+func (x *FollowConnection) GetPageInfo() *PageInfo {
+	if x != nil {
+		return x.PageInfo
+	}
+	return nil
+}
+
+func (x *FollowConnection) GetTotalCount() int32 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+type Response struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Response) Reset() {
+	*x = Response{}
+	mi := &file_proto_follow_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Response) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Response) ProtoMessage() {}
+
+func (x *Response) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_follow_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Response.ProtoReflect.Descriptor instead.
+func (*Response) Descriptor() ([]byte, []int) {
+	return file_proto_follow_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *Response) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *Response) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type ExportMyDataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportMyDataRequest) Reset() {
+	*x = ExportMyDataRequest{}
+	mi := &file_proto_follow_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportMyDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportMyDataRequest) ProtoMessage() {}
+
+func (x *ExportMyDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_follow_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportMyDataRequest.ProtoReflect.Descriptor instead.
+func (*ExportMyDataRequest) Descriptor() ([]byte, []int) {
+	return file_proto_follow_proto_rawDescGZIP(), []int{27}
+}
+
+type DataExport struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"` // JSON document
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DataExport) Reset() {
+	*x = DataExport{}
+	mi := &file_proto_follow_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DataExport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DataExport) ProtoMessage() {}
+
+func (x *DataExport) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_follow_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DataExport.ProtoReflect.Descriptor instead.
+func (*DataExport) Descriptor() ([]byte, []int) {
+	return file_proto_follow_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *DataExport) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type ExportFollowsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        *string                `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3,oneof" json:"user_id,omitempty"` // Defaults to the caller; others need the ADMIN role
+	Format        FollowFileFormat       `protobuf:"varint,2,opt,name=format,proto3,enum=follow.FollowFileFormat" json:"format,omitempty"`
+	Direction     FollowDirection        `protobuf:"varint,3,opt,name=direction,proto3,enum=follow.FollowDirection" json:"direction,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportFollowsRequest) Reset() {
+	*x = ExportFollowsRequest{}
+	mi := &file_proto_follow_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportFollowsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportFollowsRequest) ProtoMessage() {}
+
+func (x *ExportFollowsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_follow_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportFollowsRequest.ProtoReflect.Descriptor instead.
+func (*ExportFollowsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_follow_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ExportFollowsRequest) GetUserId() string {
+	if x != nil && x.UserId != nil {
+		return *x.UserId
+	}
+	return ""
+}
+
+func (x *ExportFollowsRequest) GetFormat() FollowFileFormat {
+	if x != nil {
+		return x.Format
+	}
+	return FollowFileFormat_FOLLOW_FILE_FORMAT_UNSPECIFIED
+}
+
+func (x *ExportFollowsRequest) GetDirection() FollowDirection {
+	if x != nil {
+		return x.Direction
+	}
+	return FollowDirection_FOLLOW_DIRECTION_UNSPECIFIED
+}
+
+type FollowGraphExport struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	ContentType   string                 `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"` // text/csv or application/json
+	Count         int32                  `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FollowGraphExport) Reset() {
+	*x = FollowGraphExport{}
+	mi := &file_proto_follow_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FollowGraphExport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FollowGraphExport) ProtoMessage() {}
+
+func (x *FollowGraphExport) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_follow_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FollowGraphExport.ProtoReflect.Descriptor instead.
+func (*FollowGraphExport) Descriptor() ([]byte, []int) {
+	return file_proto_follow_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *FollowGraphExport) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *FollowGraphExport) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *FollowGraphExport) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type StartFollowImportRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Format FollowFileFormat       `protobuf:"varint,1,opt,name=format,proto3,enum=follow.FollowFileFormat" json:"format,omitempty"`
+	// Users import follows they make and may leave follower_id empty; admins
+	// import any follows
+	Data          []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartFollowImportRequest) Reset() {
+	*x = StartFollowImportRequest{}
+	mi := &file_proto_follow_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartFollowImportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartFollowImportRequest) ProtoMessage() {}
+
+func (x *StartFollowImportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_follow_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartFollowImportRequest.ProtoReflect.Descriptor instead.
+func (*StartFollowImportRequest) Descriptor() ([]byte, []int) {
+	return file_proto_follow_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *StartFollowImportRequest) GetFormat() FollowFileFormat {
+	if x != nil {
+		return x.Format
+	}
+	return FollowFileFormat_FOLLOW_FILE_FORMAT_UNSPECIFIED
+}
+
+func (x *StartFollowImportRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type GetFollowImportJobRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFollowImportJobRequest) Reset() {
+	*x = GetFollowImportJobRequest{}
+	mi := &file_proto_follow_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFollowImportJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFollowImportJobRequest) ProtoMessage() {}
+
+func (x *GetFollowImportJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_follow_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFollowImportJobRequest.ProtoReflect.Descriptor instead.
+func (*GetFollowImportJobRequest) Descriptor() ([]byte, []int) {
+	return file_proto_follow_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *GetFollowImportJobRequest) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+type FollowImportJob struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	RequestedBy   string                 `protobuf:"bytes,2,opt,name=requested_by,json=requestedBy,proto3" json:"requested_by,omitempty"`
+	Status        FollowImportStatus     `protobuf:"varint,3,opt,name=status,proto3,enum=follow.FollowImportStatus" json:"status,omitempty"`
+	Total         int32                  `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"`           // Follows to import, after dropping duplicates
+	Duplicates    int32                  `protobuf:"varint,5,opt,name=duplicates,proto3" json:"duplicates,omitempty"` // Rows repeating an earlier row of the file
+	Processed     int32                  `protobuf:"varint,6,opt,name=processed,proto3" json:"processed,omitempty"`
+	Created       int32                  `protobuf:"varint,7,opt,name=created,proto3" json:"created,omitempty"`
+	Requested     int32                  `protobuf:"varint,8,opt,name=requested,proto3" json:"requested,omitempty"` // Follow requests made to private accounts
+	Skipped       int32                  `protobuf:"varint,9,opt,name=skipped,proto3" json:"skipped,omitempty"`     // Already following or requested
+	Failed        int32                  `protobuf:"varint,10,opt,name=failed,proto3" json:"failed,omitempty"`      // Users that do not exist
+	Error         *string                `protobuf:"bytes,11,opt,name=error,proto3,oneof" json:"error,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	StartedAt     *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=started_at,json=startedAt,proto3,oneof" json:"started_at,omitempty"`
+	FinishedAt    *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=finished_at,json=finishedAt,proto3,oneof" json:"finished_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FollowImportJob) Reset() {
+	*x = FollowImportJob{}
+	mi := &file_proto_follow_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FollowImportJob) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FollowImportJob) ProtoMessage() {}
+
+func (x *FollowImportJob) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_follow_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FollowImportJob.ProtoReflect.Descriptor instead.
+func (*FollowImportJob) Descriptor() ([]byte, []int) {
+	return file_proto_follow_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *FollowImportJob) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *FollowImportJob) GetRequestedBy() string {
+	if x != nil {
+		return x.RequestedBy
+	}
+	return ""
+}
+
+func (x *FollowImportJob) GetStatus() FollowImportStatus {
+	if x != nil {
+		return x.Status
+	}
+	return FollowImportStatus_FOLLOW_IMPORT_STATUS_UNSPECIFIED
+}
+
+func (x *FollowImportJob) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *FollowImportJob) GetDuplicates() int32 {
+	if x != nil {
+		return x.Duplicates
+	}
+	return 0
+}
+
+func (x *FollowImportJob) GetProcessed() int32 {
+	if x != nil {
+		return x.Processed
+	}
+	return 0
+}
+
+func (x *FollowImportJob) GetCreated() int32 {
+	if x != nil {
+		return x.Created
+	}
+	return 0
+}
+
+func (x *FollowImportJob) GetRequested() int32 {
+	if x != nil {
+		return x.Requested
+	}
+	return 0
+}
+
+func (x *FollowImportJob) GetSkipped() int32 {
+	if x != nil {
+		return x.Skipped
+	}
+	return 0
+}
+
+func (x *FollowImportJob) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *FollowImportJob) GetError() string {
+	if x != nil && x.Error != nil {
+		return *x.Error
+	}
+	return ""
+}
+
+func (x *FollowImportJob) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *FollowImportJob) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *FollowImportJob) GetFinishedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return nil
+}
+
+var File_proto_follow_proto protoreflect.FileDescriptor
 
 const file_proto_follow_proto_rawDesc = "" +
 	"\n" +
@@ -948,7 +2122,45 @@ const file_proto_follow_proto_rawDesc = "" +
 	"\x06cursor\x18\x01 \x01(\tR\x06cursor\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\tR\x06userId\x12;\n" +
 	"\vfollowed_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
-	"followedAt\"\xc6\x01\n" +
+	"followedAt\"@\n" +
+	"\x1bApproveFollowRequestRequest\x12!\n" +
+	"\frequester_id\x18\x01 \x01(\tR\vrequesterId\"?\n" +
+	"\x1aRejectFollowRequestRequest\x12!\n" +
+	"\frequester_id\x18\x01 \x01(\tR\vrequesterId\"V\n" +
+	"\x19ListFollowRequestsRequest\x12\x14\n" +
+	"\x05first\x18\x01 \x01(\x05R\x05first\x12\x19\n" +
+	"\x05after\x18\x02 \x01(\tH\x00R\x05after\x88\x01\x01B\b\n" +
+	"\x06_after\"X\n" +
+	"\x1bGetFollowSuggestionsRequest\x12\x14\n" +
+	"\x05first\x18\x01 \x01(\x05R\x05first\x12\x19\n" +
+	"\x05after\x18\x02 \x01(\tH\x00R\x05after\x88\x01\x01B\b\n" +
+	"\x06_after\"\x99\x01\n" +
+	"\x14FollowSuggestionEdge\x12\x16\n" +
+	"\x06cursor\x18\x01 \x01(\tR\x06cursor\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12%\n" +
+	"\x0emutual_follows\x18\x03 \x01(\x05R\rmutualFollows\x12)\n" +
+	"\x10shared_interests\x18\x04 \x01(\x05R\x0fsharedInterests\"\x7f\n" +
+	"\x1aFollowSuggestionConnection\x122\n" +
+	"\x05edges\x18\x01 \x03(\v2\x1c.follow.FollowSuggestionEdgeR\x05edges\x12-\n" +
+	"\tpage_info\x18\x02 \x01(\v2\x10.follow.PageInfoR\bpageInfo\"J\n" +
+	"\x19GetMutualFollowersRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05first\x18\x02 \x01(\x05R\x05first\"M\n" +
+	"\x0fMutualFollowers\x12\x19\n" +
+	"\buser_ids\x18\x01 \x03(\tR\auserIds\x12\x1f\n" +
+	"\vtotal_count\x18\x02 \x01(\x05R\n" +
+	"totalCount\"\x8f\x01\n" +
+	"\x0eImportedFollow\x12\x1f\n" +
+	"\vfollower_id\x18\x01 \x01(\tR\n" +
+	"followerId\x12!\n" +
+	"\ffollowing_id\x18\x02 \x01(\tR\vfollowingId\x129\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"H\n" +
+	"\x14ImportFollowsRequest\x120\n" +
+	"\afollows\x18\x01 \x03(\v2\x16.follow.ImportedFollowR\afollows\"K\n" +
+	"\x15ImportFollowsResponse\x12\x18\n" +
+	"\acreated\x18\x01 \x01(\x05R\acreated\x12\x18\n" +
+	"\askipped\x18\x02 \x01(\x05R\askipped\"\xc6\x01\n" +
 	"\bPageInfo\x12\"\n" +
 	"\n" +
 	"end_cursor\x18\x01 \x01(\tH\x00R\tendCursor\x88\x01\x01\x12\"\n" +
@@ -964,7 +2176,65 @@ const file_proto_follow_proto_rawDesc = "" +
 	"totalCount\">\n" +
 	"\bResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage2\x90\x04\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\x15\n" +
+	"\x13ExportMyDataRequest\" \n" +
+	"\n" +
+	"DataExport\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\"\xa9\x01\n" +
+	"\x14ExportFollowsRequest\x12\x1c\n" +
+	"\auser_id\x18\x01 \x01(\tH\x00R\x06userId\x88\x01\x01\x120\n" +
+	"\x06format\x18\x02 \x01(\x0e2\x18.follow.FollowFileFormatR\x06format\x125\n" +
+	"\tdirection\x18\x03 \x01(\x0e2\x17.follow.FollowDirectionR\tdirectionB\n" +
+	"\n" +
+	"\b_user_id\"`\n" +
+	"\x11FollowGraphExport\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\x12!\n" +
+	"\fcontent_type\x18\x02 \x01(\tR\vcontentType\x12\x14\n" +
+	"\x05count\x18\x03 \x01(\x05R\x05count\"`\n" +
+	"\x18StartFollowImportRequest\x120\n" +
+	"\x06format\x18\x01 \x01(\x0e2\x18.follow.FollowFileFormatR\x06format\x12\x12\n" +
+	"\x04data\x18\x02 \x01(\fR\x04data\"2\n" +
+	"\x19GetFollowImportJobRequest\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\"\xb7\x04\n" +
+	"\x0fFollowImportJob\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12!\n" +
+	"\frequested_by\x18\x02 \x01(\tR\vrequestedBy\x122\n" +
+	"\x06status\x18\x03 \x01(\x0e2\x1a.follow.FollowImportStatusR\x06status\x12\x14\n" +
+	"\x05total\x18\x04 \x01(\x05R\x05total\x12\x1e\n" +
+	"\n" +
+	"duplicates\x18\x05 \x01(\x05R\n" +
+	"duplicates\x12\x1c\n" +
+	"\tprocessed\x18\x06 \x01(\x05R\tprocessed\x12\x18\n" +
+	"\acreated\x18\a \x01(\x05R\acreated\x12\x1c\n" +
+	"\trequested\x18\b \x01(\x05R\trequested\x12\x18\n" +
+	"\askipped\x18\t \x01(\x05R\askipped\x12\x16\n" +
+	"\x06failed\x18\n" +
+	" \x01(\x05R\x06failed\x12\x19\n" +
+	"\x05error\x18\v \x01(\tH\x00R\x05error\x88\x01\x01\x129\n" +
+	"\n" +
+	"created_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12>\n" +
+	"\n" +
+	"started_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampH\x01R\tstartedAt\x88\x01\x01\x12@\n" +
+	"\vfinished_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampH\x02R\n" +
+	"finishedAt\x88\x01\x01B\b\n" +
+	"\x06_errorB\r\n" +
+	"\v_started_atB\x0e\n" +
+	"\f_finished_at*o\n" +
+	"\x10FollowFileFormat\x12\"\n" +
+	"\x1eFOLLOW_FILE_FORMAT_UNSPECIFIED\x10\x00\x12\x1a\n" +
+	"\x16FOLLOW_FILE_FORMAT_CSV\x10\x01\x12\x1b\n" +
+	"\x17FOLLOW_FILE_FORMAT_JSON\x10\x02*s\n" +
+	"\x0fFollowDirection\x12 \n" +
+	"\x1cFOLLOW_DIRECTION_UNSPECIFIED\x10\x00\x12\x1e\n" +
+	"\x1aFOLLOW_DIRECTION_FOLLOWERS\x10\x01\x12\x1e\n" +
+	"\x1aFOLLOW_DIRECTION_FOLLOWING\x10\x02*\xc3\x01\n" +
+	"\x12FollowImportStatus\x12$\n" +
+	" FOLLOW_IMPORT_STATUS_UNSPECIFIED\x10\x00\x12 \n" +
+	"\x1cFOLLOW_IMPORT_STATUS_PENDING\x10\x01\x12 \n" +
+	"\x1cFOLLOW_IMPORT_STATUS_RUNNING\x10\x02\x12\"\n" +
+	"\x1eFOLLOW_IMPORT_STATUS_COMPLETED\x10\x03\x12\x1f\n" +
+	"\x1bFOLLOW_IMPORT_STATUS_FAILED\x10\x042\xad\n" +
+	"\n" +
 	"\rFollowService\x129\n" +
 	"\n" +
 	"FollowUser\x12\x19.follow.FollowUserRequest\x1a\x10.follow.Response\x12=\n" +
@@ -973,7 +2243,17 @@ const file_proto_follow_proto_rawDesc = "" +
 	"\fGetFollowing\x12\x1b.follow.GetFollowingRequest\x1a\x18.follow.FollowConnection\x12F\n" +
 	"\vIsFollowing\x12\x1a.follow.IsFollowingRequest\x1a\x1b.follow.IsFollowingResponse\x12R\n" +
 	"\x0fGetFollowStatus\x12\x1e.follow.GetFollowStatusRequest\x1a\x1f.follow.GetFollowStatusResponse\x12[\n" +
-	"\x12GetFollowersCounts\x12!.follow.GetFollowersCountsRequest\x1a\".follow.GetFollowersCountsResponseB\x04Z\x02./b\x06proto3"
+	"\x12GetFollowersCounts\x12!.follow.GetFollowersCountsRequest\x1a\".follow.GetFollowersCountsResponse\x12M\n" +
+	"\x14ApproveFollowRequest\x12#.follow.ApproveFollowRequestRequest\x1a\x10.follow.Response\x12K\n" +
+	"\x13RejectFollowRequest\x12\".follow.RejectFollowRequestRequest\x1a\x10.follow.Response\x12Q\n" +
+	"\x12ListFollowRequests\x12!.follow.ListFollowRequestsRequest\x1a\x18.follow.FollowConnection\x12_\n" +
+	"\x14GetFollowSuggestions\x12#.follow.GetFollowSuggestionsRequest\x1a\".follow.FollowSuggestionConnection\x12P\n" +
+	"\x12GetMutualFollowers\x12!.follow.GetMutualFollowersRequest\x1a\x17.follow.MutualFollowers\x12?\n" +
+	"\fExportMyData\x12\x1b.follow.ExportMyDataRequest\x1a\x12.follow.DataExport\x12H\n" +
+	"\rExportFollows\x12\x1c.follow.ExportFollowsRequest\x1a\x19.follow.FollowGraphExport\x12N\n" +
+	"\x11StartFollowImport\x12 .follow.StartFollowImportRequest\x1a\x17.follow.FollowImportJob\x12P\n" +
+	"\x12GetFollowImportJob\x12!.follow.GetFollowImportJobRequest\x1a\x17.follow.FollowImportJob\x12L\n" +
+	"\rImportFollows\x12\x1c.follow.ImportFollowsRequest\x1a\x1d.follow.ImportFollowsResponseB\x04Z\x02./b\x06proto3"
 
 var (
 	file_proto_follow_proto_rawDescOnce sync.Once
@@ -987,51 +2267,104 @@ func file_proto_follow_proto_rawDescGZIP() []byte {
 	return file_proto_follow_proto_rawDescData
 }
 
-var file_proto_follow_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_proto_follow_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_proto_follow_proto_msgTypes = make([]protoimpl.MessageInfo, 34)
 var file_proto_follow_proto_goTypes = []any{
-	(*FollowUserRequest)(nil),          // 0: follow.FollowUserRequest
-	(*UnfollowUserRequest)(nil),        // 1: follow.UnfollowUserRequest
-	(*GetFollowersRequest)(nil),        // 2: follow.GetFollowersRequest
-	(*GetFollowingRequest)(nil),        // 3: follow.GetFollowingRequest
-	(*IsFollowingRequest)(nil),         // 4: follow.IsFollowingRequest
-	(*IsFollowingResponse)(nil),        // 5: follow.IsFollowingResponse
-	(*GetFollowStatusRequest)(nil),     // 6: follow.GetFollowStatusRequest
-	(*FollowStatus)(nil),               // 7: follow.FollowStatus
-	(*GetFollowStatusResponse)(nil),    // 8: follow.GetFollowStatusResponse
-	(*GetFollowersCountsRequest)(nil),  // 9: follow.GetFollowersCountsRequest
-	(*UserFollowCounts)(nil),           // 10: follow.UserFollowCounts
-	(*GetFollowersCountsResponse)(nil), // 11: follow.GetFollowersCountsResponse
-	(*FollowEdge)(nil),                 // 12: follow.FollowEdge
-	(*PageInfo)(nil),                   // 13: follow.PageInfo
-	(*FollowConnection)(nil),           // 14: follow.FollowConnection
-	(*Response)(nil),                   // 15: follow.Response
-	(*timestamppb.Timestamp)(nil),      // 16: google.protobuf.Timestamp
+	(FollowFileFormat)(0),               // 0: follow.FollowFileFormat
+	(FollowDirection)(0),                // 1: follow.FollowDirection
+	(FollowImportStatus)(0),             // 2: follow.FollowImportStatus
+	(*FollowUserRequest)(nil),           // 3: follow.FollowUserRequest
+	(*UnfollowUserRequest)(nil),         // 4: follow.UnfollowUserRequest
+	(*GetFollowersRequest)(nil),         // 5: follow.GetFollowersRequest
+	(*GetFollowingRequest)(nil),         // 6: follow.GetFollowingRequest
+	(*IsFollowingRequest)(nil),          // 7: follow.IsFollowingRequest
+	(*IsFollowingResponse)(nil),         // 8: follow.IsFollowingResponse
+	(*GetFollowStatusRequest)(nil),      // 9: follow.GetFollowStatusRequest
+	(*FollowStatus)(nil),                // 10: follow.FollowStatus
+	(*GetFollowStatusResponse)(nil),     // 11: follow.GetFollowStatusResponse
+	(*GetFollowersCountsRequest)(nil),   // 12: follow.GetFollowersCountsRequest
+	(*UserFollowCounts)(nil),            // 13: follow.UserFollowCounts
+	(*GetFollowersCountsResponse)(nil),  // 14: follow.GetFollowersCountsResponse
+	(*FollowEdge)(nil),                  // 15: follow.FollowEdge
+	(*ApproveFollowRequestRequest)(nil), // 16: follow.ApproveFollowRequestRequest
+	(*RejectFollowRequestRequest)(nil),  // 17: follow.RejectFollowRequestRequest
+	(*ListFollowRequestsRequest)(nil),   // 18: follow.ListFollowRequestsRequest
+	(*GetFollowSuggestionsRequest)(nil), // 19: follow.GetFollowSuggestionsRequest
+	(*FollowSuggestionEdge)(nil),        // 20: follow.FollowSuggestionEdge
+	(*FollowSuggestionConnection)(nil),  // 21: follow.FollowSuggestionConnection
+	(*GetMutualFollowersRequest)(nil),   // 22: follow.GetMutualFollowersRequest
+	(*MutualFollowers)(nil),             // 23: follow.MutualFollowers
+	(*ImportedFollow)(nil),              // 24: follow.ImportedFollow
+	(*ImportFollowsRequest)(nil),        // 25: follow.ImportFollowsRequest
+	(*ImportFollowsResponse)(nil),       // 26: follow.ImportFollowsResponse
+	(*PageInfo)(nil),                    // 27: follow.PageInfo
+	(*FollowConnection)(nil),            // 28: follow.FollowConnection
+	(*Response)(nil),                    // 29: follow.Response
+	(*ExportMyDataRequest)(nil),         // 30: follow.ExportMyDataRequest
+	(*DataExport)(nil),                  // 31: follow.DataExport
+	(*ExportFollowsRequest)(nil),        // 32: follow.ExportFollowsRequest
+	(*FollowGraphExport)(nil),           // 33: follow.FollowGraphExport
+	(*StartFollowImportRequest)(nil),    // 34: follow.StartFollowImportRequest
+	(*GetFollowImportJobRequest)(nil),   // 35: follow.GetFollowImportJobRequest
+	(*FollowImportJob)(nil),             // 36: follow.FollowImportJob
+	(*timestamppb.Timestamp)(nil),       // 37: google.protobuf.Timestamp
 }
 var file_proto_follow_proto_depIdxs = []int32{
-	7,  // 0: follow.GetFollowStatusResponse.statuses:type_name -> follow.FollowStatus
-	10, // 1: follow.GetFollowersCountsResponse.counts:type_name -> follow.UserFollowCounts
-	16, // 2: follow.FollowEdge.followed_at:type_name -> google.protobuf.Timestamp
-	12, // 3: follow.FollowConnection.edges:type_name -> follow.FollowEdge
-	13, // 4: follow.FollowConnection.page_info:type_name -> follow.PageInfo
-	0,  // 5: follow.FollowService.FollowUser:input_type -> follow.FollowUserRequest
-	1,  // 6: follow.FollowService.UnfollowUser:input_type -> follow.UnfollowUserRequest
-	2,  // 7: follow.FollowService.GetFollowers:input_type -> follow.GetFollowersRequest
-	3,  // 8: follow.FollowService.GetFollowing:input_type -> follow.GetFollowingRequest
-	4,  // 9: follow.FollowService.IsFollowing:input_type -> follow.IsFollowingRequest
-	6,  // 10: follow.FollowService.GetFollowStatus:input_type -> follow.GetFollowStatusRequest
-	9,  // 11: follow.FollowService.GetFollowersCounts:input_type -> follow.GetFollowersCountsRequest
-	15, // 12: follow.FollowService.FollowUser:output_type -> follow.Response
-	15, // 13: follow.FollowService.UnfollowUser:output_type -> follow.Response
-	14, // 14: follow.FollowService.GetFollowers:output_type -> follow.FollowConnection
-	14, // 15: follow.FollowService.GetFollowing:output_type -> follow.FollowConnection
-	5,  // 16: follow.FollowService.IsFollowing:output_type -> follow.IsFollowingResponse
-	8,  // 17: follow.FollowService.GetFollowStatus:output_type -> follow.GetFollowStatusResponse
-	11, // 18: follow.FollowService.GetFollowersCounts:output_type -> follow.GetFollowersCountsResponse
-	12, // [12:19] is the sub-list for method output_type
-	5,  // [5:12] is the sub-list for method input_type
-	5,  // [5:5] is the sub-list for extension type_name
-	5,  // [5:5] is the sub-list for extension extendee
-	0,  // [0:5] is the sub-list for field type_name
+	10, // 0: follow.GetFollowStatusResponse.statuses:type_name -> follow.FollowStatus
+	13, // 1: follow.GetFollowersCountsResponse.counts:type_name -> follow.UserFollowCounts
+	37, // 2: follow.FollowEdge.followed_at:type_name -> google.protobuf.Timestamp
+	20, // 3: follow.FollowSuggestionConnection.edges:type_name -> follow.FollowSuggestionEdge
+	27, // 4: follow.FollowSuggestionConnection.page_info:type_name -> follow.PageInfo
+	37, // 5: follow.ImportedFollow.created_at:type_name -> google.protobuf.Timestamp
+	24, // 6: follow.ImportFollowsRequest.follows:type_name -> follow.ImportedFollow
+	15, // 7: follow.FollowConnection.edges:type_name -> follow.FollowEdge
+	27, // 8: follow.FollowConnection.page_info:type_name -> follow.PageInfo
+	0,  // 9: follow.ExportFollowsRequest.format:type_name -> follow.FollowFileFormat
+	1,  // 10: follow.ExportFollowsRequest.direction:type_name -> follow.FollowDirection
+	0,  // 11: follow.StartFollowImportRequest.format:type_name -> follow.FollowFileFormat
+	2,  // 12: follow.FollowImportJob.status:type_name -> follow.FollowImportStatus
+	37, // 13: follow.FollowImportJob.created_at:type_name -> google.protobuf.Timestamp
+	37, // 14: follow.FollowImportJob.started_at:type_name -> google.protobuf.Timestamp
+	37, // 15: follow.FollowImportJob.finished_at:type_name -> google.protobuf.Timestamp
+	3,  // 16: follow.FollowService.FollowUser:input_type -> follow.FollowUserRequest
+	4,  // 17: follow.FollowService.UnfollowUser:input_type -> follow.UnfollowUserRequest
+	5,  // 18: follow.FollowService.GetFollowers:input_type -> follow.GetFollowersRequest
+	6,  // 19: follow.FollowService.GetFollowing:input_type -> follow.GetFollowingRequest
+	7,  // 20: follow.FollowService.IsFollowing:input_type -> follow.IsFollowingRequest
+	9,  // 21: follow.FollowService.GetFollowStatus:input_type -> follow.GetFollowStatusRequest
+	12, // 22: follow.FollowService.GetFollowersCounts:input_type -> follow.GetFollowersCountsRequest
+	16, // 23: follow.FollowService.ApproveFollowRequest:input_type -> follow.ApproveFollowRequestRequest
+	17, // 24: follow.FollowService.RejectFollowRequest:input_type -> follow.RejectFollowRequestRequest
+	18, // 25: follow.FollowService.ListFollowRequests:input_type -> follow.ListFollowRequestsRequest
+	19, // 26: follow.FollowService.GetFollowSuggestions:input_type -> follow.GetFollowSuggestionsRequest
+	22, // 27: follow.FollowService.GetMutualFollowers:input_type -> follow.GetMutualFollowersRequest
+	30, // 28: follow.FollowService.ExportMyData:input_type -> follow.ExportMyDataRequest
+	32, // 29: follow.FollowService.ExportFollows:input_type -> follow.ExportFollowsRequest
+	34, // 30: follow.FollowService.StartFollowImport:input_type -> follow.StartFollowImportRequest
+	35, // 31: follow.FollowService.GetFollowImportJob:input_type -> follow.GetFollowImportJobRequest
+	25, // 32: follow.FollowService.ImportFollows:input_type -> follow.ImportFollowsRequest
+	29, // 33: follow.FollowService.FollowUser:output_type -> follow.Response
+	29, // 34: follow.FollowService.UnfollowUser:output_type -> follow.Response
+	28, // 35: follow.FollowService.GetFollowers:output_type -> follow.FollowConnection
+	28, // 36: follow.FollowService.GetFollowing:output_type -> follow.FollowConnection
+	8,  // 37: follow.FollowService.IsFollowing:output_type -> follow.IsFollowingResponse
+	11, // 38: follow.FollowService.GetFollowStatus:output_type -> follow.GetFollowStatusResponse
+	14, // 39: follow.FollowService.GetFollowersCounts:output_type -> follow.GetFollowersCountsResponse
+	29, // 40: follow.FollowService.ApproveFollowRequest:output_type -> follow.Response
+	29, // 41: follow.FollowService.RejectFollowRequest:output_type -> follow.Response
+	28, // 42: follow.FollowService.ListFollowRequests:output_type -> follow.FollowConnection
+	21, // 43: follow.FollowService.GetFollowSuggestions:output_type -> follow.FollowSuggestionConnection
+	23, // 44: follow.FollowService.GetMutualFollowers:output_type -> follow.MutualFollowers
+	31, // 45: follow.FollowService.ExportMyData:output_type -> follow.DataExport
+	33, // 46: follow.FollowService.ExportFollows:output_type -> follow.FollowGraphExport
+	36, // 47: follow.FollowService.StartFollowImport:output_type -> follow.FollowImportJob
+	36, // 48: follow.FollowService.GetFollowImportJob:output_type -> follow.FollowImportJob
+	26, // 49: follow.FollowService.ImportFollows:output_type -> follow.ImportFollowsResponse
+	33, // [33:50] is the sub-list for method output_type
+	16, // [16:33] is the sub-list for method input_type
+	16, // [16:16] is the sub-list for extension type_name
+	16, // [16:16] is the sub-list for extension extendee
+	0,  // [0:16] is the sub-list for field type_name
 }
 
 func init() { file_proto_follow_proto_init() }
@@ -1041,19 +2374,24 @@ func file_proto_follow_proto_init() {
 	}
 	file_proto_follow_proto_msgTypes[2].OneofWrappers = []any{}
 	file_proto_follow_proto_msgTypes[3].OneofWrappers = []any{}
-	file_proto_follow_proto_msgTypes[13].OneofWrappers = []any{}
+	file_proto_follow_proto_msgTypes[15].OneofWrappers = []any{}
+	file_proto_follow_proto_msgTypes[16].OneofWrappers = []any{}
+	file_proto_follow_proto_msgTypes[24].OneofWrappers = []any{}
+	file_proto_follow_proto_msgTypes[29].OneofWrappers = []any{}
+	file_proto_follow_proto_msgTypes[33].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_follow_proto_rawDesc), len(file_proto_follow_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   16,
+			NumEnums:      3,
+			NumMessages:   34,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_proto_follow_proto_goTypes,
 		DependencyIndexes: file_proto_follow_proto_depIdxs,
+		EnumInfos:         file_proto_follow_proto_enumTypes,
 		MessageInfos:      file_proto_follow_proto_msgTypes,
 	}.Build()
 	File_proto_follow_proto = out.File
@@ -19,13 +19,23 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	FollowService_FollowUser_FullMethodName         = "/follow.FollowService/FollowUser"
-	FollowService_UnfollowUser_FullMethodName       = "/follow.FollowService/UnfollowUser"
-	FollowService_GetFollowers_FullMethodName       = "/follow.FollowService/GetFollowers"
-	FollowService_GetFollowing_FullMethodName       = "/follow.FollowService/GetFollowing"
-	FollowService_IsFollowing_FullMethodName        = "/follow.FollowService/IsFollowing"
-	FollowService_GetFollowStatus_FullMethodName    = "/follow.FollowService/GetFollowStatus"
-	FollowService_GetFollowersCounts_FullMethodName = "/follow.FollowService/GetFollowersCounts"
+	FollowService_FollowUser_FullMethodName           = "/follow.FollowService/FollowUser"
+	FollowService_UnfollowUser_FullMethodName         = "/follow.FollowService/UnfollowUser"
+	FollowService_GetFollowers_FullMethodName         = "/follow.FollowService/GetFollowers"
+	FollowService_GetFollowing_FullMethodName         = "/follow.FollowService/GetFollowing"
+	FollowService_IsFollowing_FullMethodName          = "/follow.FollowService/IsFollowing"
+	FollowService_GetFollowStatus_FullMethodName      = "/follow.FollowService/GetFollowStatus"
+	FollowService_GetFollowersCounts_FullMethodName   = "/follow.FollowService/GetFollowersCounts"
+	FollowService_ApproveFollowRequest_FullMethodName = "/follow.FollowService/ApproveFollowRequest"
+	FollowService_RejectFollowRequest_FullMethodName  = "/follow.FollowService/RejectFollowRequest"
+	FollowService_ListFollowRequests_FullMethodName   = "/follow.FollowService/ListFollowRequests"
+	FollowService_GetFollowSuggestions_FullMethodName = "/follow.FollowService/GetFollowSuggestions"
+	FollowService_GetMutualFollowers_FullMethodName   = "/follow.FollowService/GetMutualFollowers"
+	FollowService_ExportMyData_FullMethodName         = "/follow.FollowService/ExportMyData"
+	FollowService_ExportFollows_FullMethodName        = "/follow.FollowService/ExportFollows"
+	FollowService_StartFollowImport_FullMethodName    = "/follow.FollowService/StartFollowImport"
+	FollowService_GetFollowImportJob_FullMethodName   = "/follow.FollowService/GetFollowImportJob"
+	FollowService_ImportFollows_FullMethodName        = "/follow.FollowService/ImportFollows"
 )
 
 // FollowServiceClient is the client API for FollowService service.
@@ -39,6 +49,25 @@ type FollowServiceClient interface {
 	IsFollowing(ctx context.Context, in *IsFollowingRequest, opts ...grpc.CallOption) (*IsFollowingResponse, error)
 	GetFollowStatus(ctx context.Context, in *GetFollowStatusRequest, opts ...grpc.CallOption) (*GetFollowStatusResponse, error)
 	GetFollowersCounts(ctx context.Context, in *GetFollowersCountsRequest, opts ...grpc.CallOption) (*GetFollowersCountsResponse, error)
+	// Follow requests to private accounts; the caller is the account owner
+	ApproveFollowRequest(ctx context.Context, in *ApproveFollowRequestRequest, opts ...grpc.CallOption) (*Response, error)
+	RejectFollowRequest(ctx context.Context, in *RejectFollowRequestRequest, opts ...grpc.CallOption) (*Response, error)
+	ListFollowRequests(ctx context.Context, in *ListFollowRequestsRequest, opts ...grpc.CallOption) (*FollowConnection, error)
+	// Users the caller may want to follow, best first
+	GetFollowSuggestions(ctx context.Context, in *GetFollowSuggestionsRequest, opts ...grpc.CallOption) (*FollowSuggestionConnection, error)
+	// Users the caller follows who follow user_id, for "followed by X, Y and
+	// 12 others you follow"
+	GetMutualFollowers(ctx context.Context, in *GetMutualFollowersRequest, opts ...grpc.CallOption) (*MutualFollowers, error)
+	// The caller's follows and follow requests, for their data export
+	ExportMyData(ctx context.Context, in *ExportMyDataRequest, opts ...grpc.CallOption) (*DataExport, error)
+	// Follow graphs as CSV or JSON files, for moving to and from other
+	// platforms. Callers export and import their own follows; admins those of
+	// any user. Imports run in the background as jobs.
+	ExportFollows(ctx context.Context, in *ExportFollowsRequest, opts ...grpc.CallOption) (*FollowGraphExport, error)
+	StartFollowImport(ctx context.Context, in *StartFollowImportRequest, opts ...grpc.CallOption) (*FollowImportJob, error)
+	GetFollowImportJob(ctx context.Context, in *GetFollowImportJobRequest, opts ...grpc.CallOption) (*FollowImportJob, error)
+	// Admin operations (require the ADMIN role)
+	ImportFollows(ctx context.Context, in *ImportFollowsRequest, opts ...grpc.CallOption) (*ImportFollowsResponse, error)
 }
 
 type followServiceClient struct {
@@ -119,6 +148,106 @@ func (c *followServiceClient) GetFollowersCounts(ctx context.Context, in *GetFol
 	return out, nil
 }
 
+func (c *followServiceClient) ApproveFollowRequest(ctx context.Context, in *ApproveFollowRequestRequest, opts ...grpc.CallOption) (*Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Response)
+	err := c.cc.Invoke(ctx, FollowService_ApproveFollowRequest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *followServiceClient) RejectFollowRequest(ctx context.Context, in *RejectFollowRequestRequest, opts ...grpc.CallOption) (*Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Response)
+	err := c.cc.Invoke(ctx, FollowService_RejectFollowRequest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *followServiceClient) ListFollowRequests(ctx context.Context, in *ListFollowRequestsRequest, opts ...grpc.CallOption) (*FollowConnection, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FollowConnection)
+	err := c.cc.Invoke(ctx, FollowService_ListFollowRequests_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *followServiceClient) GetFollowSuggestions(ctx context.Context, in *GetFollowSuggestionsRequest, opts ...grpc.CallOption) (*FollowSuggestionConnection, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FollowSuggestionConnection)
+	err := c.cc.Invoke(ctx, FollowService_GetFollowSuggestions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *followServiceClient) GetMutualFollowers(ctx context.Context, in *GetMutualFollowersRequest, opts ...grpc.CallOption) (*MutualFollowers, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MutualFollowers)
+	err := c.cc.Invoke(ctx, FollowService_GetMutualFollowers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *followServiceClient) ExportMyData(ctx context.Context, in *ExportMyDataRequest, opts ...grpc.CallOption) (*DataExport, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DataExport)
+	err := c.cc.Invoke(ctx, FollowService_ExportMyData_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *followServiceClient) ExportFollows(ctx context.Context, in *ExportFollowsRequest, opts ...grpc.CallOption) (*FollowGraphExport, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FollowGraphExport)
+	err := c.cc.Invoke(ctx, FollowService_ExportFollows_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *followServiceClient) StartFollowImport(ctx context.Context, in *StartFollowImportRequest, opts ...grpc.CallOption) (*FollowImportJob, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FollowImportJob)
+	err := c.cc.Invoke(ctx, FollowService_StartFollowImport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *followServiceClient) GetFollowImportJob(ctx context.Context, in *GetFollowImportJobRequest, opts ...grpc.CallOption) (*FollowImportJob, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FollowImportJob)
+	err := c.cc.Invoke(ctx, FollowService_GetFollowImportJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *followServiceClient) ImportFollows(ctx context.Context, in *ImportFollowsRequest, opts ...grpc.CallOption) (*ImportFollowsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ImportFollowsResponse)
+	err := c.cc.Invoke(ctx, FollowService_ImportFollows_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // FollowServiceServer is the server API for FollowService service.
 // All implementations must embed UnimplementedFollowServiceServer
 // for forward compatibility.
@@ -130,6 +259,25 @@ type FollowServiceServer interface {
 	IsFollowing(context.Context, *IsFollowingRequest) (*IsFollowingResponse, error)
 	GetFollowStatus(context.Context, *GetFollowStatusRequest) (*GetFollowStatusResponse, error)
 	GetFollowersCounts(context.Context, *GetFollowersCountsRequest) (*GetFollowersCountsResponse, error)
+	// Follow requests to private accounts; the caller is the account owner
+	ApproveFollowRequest(context.Context, *ApproveFollowRequestRequest) (*Response, error)
+	RejectFollowRequest(context.Context, *RejectFollowRequestRequest) (*Response, error)
+	ListFollowRequests(context.Context, *ListFollowRequestsRequest) (*FollowConnection, error)
+	// Users the caller may want to follow, best first
+	GetFollowSuggestions(context.Context, *GetFollowSuggestionsRequest) (*FollowSuggestionConnection, error)
+	// Users the caller follows who follow user_id, for "followed by X, Y and
+	// 12 others you follow"
+	GetMutualFollowers(context.Context, *GetMutualFollowersRequest) (*MutualFollowers, error)
+	// The caller's follows and follow requests, for their data export
+	ExportMyData(context.Context, *ExportMyDataRequest) (*DataExport, error)
+	// Follow graphs as CSV or JSON files, for moving to and from other
+	// platforms. Callers export and import their own follows; admins those of
+	// any user. Imports run in the background as jobs.
+	ExportFollows(context.Context, *ExportFollowsRequest) (*FollowGraphExport, error)
+	StartFollowImport(context.Context, *StartFollowImportRequest) (*FollowImportJob, error)
+	GetFollowImportJob(context.Context, *GetFollowImportJobRequest) (*FollowImportJob, error)
+	// Admin operations (require the ADMIN role)
+	ImportFollows(context.Context, *ImportFollowsRequest) (*ImportFollowsResponse, error)
 	mustEmbedUnimplementedFollowServiceServer()
 }
 
@@ -161,6 +309,36 @@ func (UnimplementedFollowServiceServer) GetFollowStatus(context.Context, *GetFol
 func (UnimplementedFollowServiceServer) GetFollowersCounts(context.Context, *GetFollowersCountsRequest) (*GetFollowersCountsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetFollowersCounts not implemented")
 }
+func (UnimplementedFollowServiceServer) ApproveFollowRequest(context.Context, *ApproveFollowRequestRequest) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApproveFollowRequest not implemented")
+}
+func (UnimplementedFollowServiceServer) RejectFollowRequest(context.Context, *RejectFollowRequestRequest) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RejectFollowRequest not implemented")
+}
+func (UnimplementedFollowServiceServer) ListFollowRequests(context.Context, *ListFollowRequestsRequest) (*FollowConnection, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFollowRequests not implemented")
+}
+func (UnimplementedFollowServiceServer) GetFollowSuggestions(context.Context, *GetFollowSuggestionsRequest) (*FollowSuggestionConnection, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFollowSuggestions not implemented")
+}
+func (UnimplementedFollowServiceServer) GetMutualFollowers(context.Context, *GetMutualFollowersRequest) (*MutualFollowers, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMutualFollowers not implemented")
+}
+func (UnimplementedFollowServiceServer) ExportMyData(context.Context, *ExportMyDataRequest) (*DataExport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportMyData not implemented")
+}
+func (UnimplementedFollowServiceServer) ExportFollows(context.Context, *ExportFollowsRequest) (*FollowGraphExport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportFollows not implemented")
+}
+func (UnimplementedFollowServiceServer) StartFollowImport(context.Context, *StartFollowImportRequest) (*FollowImportJob, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartFollowImport not implemented")
+}
+func (UnimplementedFollowServiceServer) GetFollowImportJob(context.Context, *GetFollowImportJobRequest) (*FollowImportJob, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFollowImportJob not implemented")
+}
+func (UnimplementedFollowServiceServer) ImportFollows(context.Context, *ImportFollowsRequest) (*ImportFollowsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportFollows not implemented")
+}
 func (UnimplementedFollowServiceServer) mustEmbedUnimplementedFollowServiceServer() {}
 func (UnimplementedFollowServiceServer) testEmbeddedByValue()                       {}
 
@@ -308,6 +486,186 @@ func _FollowService_GetFollowersCounts_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _FollowService_ApproveFollowRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApproveFollowRequestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FollowServiceServer).ApproveFollowRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FollowService_ApproveFollowRequest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FollowServiceServer).ApproveFollowRequest(ctx, req.(*ApproveFollowRequestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FollowService_RejectFollowRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RejectFollowRequestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FollowServiceServer).RejectFollowRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FollowService_RejectFollowRequest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FollowServiceServer).RejectFollowRequest(ctx, req.(*RejectFollowRequestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FollowService_ListFollowRequests_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFollowRequestsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FollowServiceServer).ListFollowRequests(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FollowService_ListFollowRequests_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FollowServiceServer).ListFollowRequests(ctx, req.(*ListFollowRequestsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FollowService_GetFollowSuggestions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFollowSuggestionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FollowServiceServer).GetFollowSuggestions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FollowService_GetFollowSuggestions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FollowServiceServer).GetFollowSuggestions(ctx, req.(*GetFollowSuggestionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FollowService_GetMutualFollowers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMutualFollowersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FollowServiceServer).GetMutualFollowers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FollowService_GetMutualFollowers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FollowServiceServer).GetMutualFollowers(ctx, req.(*GetMutualFollowersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FollowService_ExportMyData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportMyDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FollowServiceServer).ExportMyData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FollowService_ExportMyData_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FollowServiceServer).ExportMyData(ctx, req.(*ExportMyDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FollowService_ExportFollows_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportFollowsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FollowServiceServer).ExportFollows(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FollowService_ExportFollows_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FollowServiceServer).ExportFollows(ctx, req.(*ExportFollowsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FollowService_StartFollowImport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartFollowImportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FollowServiceServer).StartFollowImport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FollowService_StartFollowImport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FollowServiceServer).StartFollowImport(ctx, req.(*StartFollowImportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FollowService_GetFollowImportJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFollowImportJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FollowServiceServer).GetFollowImportJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FollowService_GetFollowImportJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FollowServiceServer).GetFollowImportJob(ctx, req.(*GetFollowImportJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FollowService_ImportFollows_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportFollowsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FollowServiceServer).ImportFollows(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FollowService_ImportFollows_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FollowServiceServer).ImportFollows(ctx, req.(*ImportFollowsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // FollowService_ServiceDesc is the grpc.ServiceDesc for FollowService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -343,6 +701,46 @@ var FollowService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetFollowersCounts",
 			Handler:    _FollowService_GetFollowersCounts_Handler,
 		},
+		{
+			MethodName: "ApproveFollowRequest",
+			Handler:    _FollowService_ApproveFollowRequest_Handler,
+		},
+		{
+			MethodName: "RejectFollowRequest",
+			Handler:    _FollowService_RejectFollowRequest_Handler,
+		},
+		{
+			MethodName: "ListFollowRequests",
+			Handler:    _FollowService_ListFollowRequests_Handler,
+		},
+		{
+			MethodName: "GetFollowSuggestions",
+			Handler:    _FollowService_GetFollowSuggestions_Handler,
+		},
+		{
+			MethodName: "GetMutualFollowers",
+			Handler:    _FollowService_GetMutualFollowers_Handler,
+		},
+		{
+			MethodName: "ExportMyData",
+			Handler:    _FollowService_ExportMyData_Handler,
+		},
+		{
+			MethodName: "ExportFollows",
+			Handler:    _FollowService_ExportFollows_Handler,
+		},
+		{
+			MethodName: "StartFollowImport",
+			Handler:    _FollowService_StartFollowImport_Handler,
+		},
+		{
+			MethodName: "GetFollowImportJob",
+			Handler:    _FollowService_GetFollowImportJob_Handler,
+		},
+		{
+			MethodName: "ImportFollows",
+			Handler:    _FollowService_ImportFollows_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/follow.proto",
@@ -0,0 +1,59 @@
+package publisher
+
+import (
+	"context"
+	"encoding/json"
+	"follow-service/events"
+	natsClient "follow-service/nats"
+	"shared/logging"
+)
+
+type EventPublisher struct {
+	nats *natsClient.Client
+}
+
+func NewEventPublisher(nats *natsClient.Client) *EventPublisher {
+	return &EventPublisher{nats: nats}
+}
+
+func (p *EventPublisher) PublishUserFollowed(ctx context.Context, event events.UserFollowedEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	if err := p.nats.Publish(ctx, events.UserFollowed, data); err != nil {
+		return err
+	}
+
+	logging.FromContext(ctx).Info().Str("subject", events.UserFollowed).Stringer("follower_id", event.FollowerID).Msg("published event")
+	return nil
+}
+
+func (p *EventPublisher) PublishUserUnfollowed(ctx context.Context, event events.UserUnfollowedEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	if err := p.nats.Publish(ctx, events.UserUnfollowed, data); err != nil {
+		return err
+	}
+
+	logging.FromContext(ctx).Info().Str("subject", events.UserUnfollowed).Stringer("follower_id", event.FollowerID).Msg("published event")
+	return nil
+}
+
+func (p *EventPublisher) PublishFollowRequested(ctx context.Context, event events.FollowRequestedEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	if err := p.nats.Publish(ctx, events.FollowRequested, data); err != nil {
+		return err
+	}
+
+	logging.FromContext(ctx).Info().Str("subject", events.FollowRequested).Stringer("follower_id", event.FollowerID).Msg("published event")
+	return nil
+}
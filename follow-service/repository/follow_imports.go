@@ -0,0 +1,152 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"follow-service/db"
+	"follow-service/model"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+var (
+	// ErrImportInProgress is returned when a user starts an import while
+	// another of theirs has not finished
+	ErrImportInProgress = errors.New("an import is already in progress")
+	// ErrImportJobNotFound is returned for an import job that does not exist
+	ErrImportJobNotFound = errors.New("import job not found")
+)
+
+// ImportJobRepository stores bulk import jobs. Jobs live on shard 0, like
+// idempotency keys.
+type ImportJobRepository interface {
+	CreateImportJob(ctx context.Context, job *models.ImportJob, follows []models.Follow) error
+	GetImportJob(ctx context.Context, jobID uuid.UUID) (*models.ImportJob, error)
+	ClaimImportJob(ctx context.Context, lease time.Duration) (*models.ImportJob, error)
+	GetImportJobFollows(ctx context.Context, jobID uuid.UUID) ([]models.Follow, error)
+	SaveImportProgress(ctx context.Context, job *models.ImportJob, lease time.Duration) error
+	FinishImportJob(ctx context.Context, jobID uuid.UUID, status models.ImportStatus, errMsg *string) error
+}
+
+type importJobRepository struct {
+	db *database.DB
+}
+
+func NewImportJobRepository(shards *database.Shards) ImportJobRepository {
+	return &importJobRepository{db: shards.All()[0]}
+}
+
+const importJobColumns = `id, requested_by, owner_id, status, total, duplicates, processed, created,
+	requested, skipped, failed, error, created_at, started_at, finished_at`
+
+// CreateImportJob stores a pending job with the follows it imports. A user
+// with an unfinished import gets ErrImportInProgress.
+func (r *importJobRepository) CreateImportJob(ctx context.Context, job *models.ImportJob, follows []models.Follow) error {
+	data, err := json.Marshal(follows)
+	if err != nil {
+		return fmt.Errorf("failed to encode follows: %w", err)
+	}
+
+	_, err = r.db.ExecContext(ctx, `
+		INSERT INTO follow_service_import_jobs (id, requested_by, owner_id, status, follows, total, duplicates, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`, job.ID, job.RequestedBy, job.OwnerID, job.Status, data, job.Total, job.Duplicates, job.CreatedAt)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" && pqErr.Constraint == "idx_follow_import_jobs_owner_unfinished" {
+			return ErrImportInProgress
+		}
+		return fmt.Errorf("failed to create import job: %w", err)
+	}
+	return nil
+}
+
+func (r *importJobRepository) GetImportJob(ctx context.Context, jobID uuid.UUID) (*models.ImportJob, error) {
+	var job models.ImportJob
+	err := r.db.GetContext(ctx, &job, `SELECT `+importJobColumns+` FROM follow_service_import_jobs WHERE id = $1`, jobID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrImportJobNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get import job: %w", err)
+	}
+	return &job, nil
+}
+
+// ClaimImportJob marks the oldest pending job as running and holds it for
+// lease. A running job whose lease ran out, because the process running it
+// stopped, is claimed again so it resumes. It returns nil when no job is
+// waiting.
+func (r *importJobRepository) ClaimImportJob(ctx context.Context, lease time.Duration) (*models.ImportJob, error) {
+	var job models.ImportJob
+	err := r.db.GetContext(ctx, &job, `
+		UPDATE follow_service_import_jobs
+		SET status = 'RUNNING', started_at = COALESCE(started_at, NOW()), locked_until = NOW() + $1 * INTERVAL '1 second'
+		WHERE id = (
+			SELECT id FROM follow_service_import_jobs
+			WHERE status = 'PENDING' OR (status = 'RUNNING' AND locked_until < NOW())
+			ORDER BY created_at
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING `+importJobColumns, lease.Seconds())
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim import job: %w", err)
+	}
+	return &job, nil
+}
+
+// GetImportJobFollows returns the follows a job imports, in file order
+func (r *importJobRepository) GetImportJobFollows(ctx context.Context, jobID uuid.UUID) ([]models.Follow, error) {
+	var data []byte
+	err := r.db.GetContext(ctx, &data, `SELECT follows FROM follow_service_import_jobs WHERE id = $1`, jobID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrImportJobNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get follows of import job: %w", err)
+	}
+
+	var follows []models.Follow
+	if err := json.Unmarshal(data, &follows); err != nil {
+		return nil, fmt.Errorf("failed to decode follows of import job: %w", err)
+	}
+	return follows, nil
+}
+
+// SaveImportProgress records the counts of a running job and extends its
+// lease
+func (r *importJobRepository) SaveImportProgress(ctx context.Context, job *models.ImportJob, lease time.Duration) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE follow_service_import_jobs
+		SET processed = $2, created = $3, requested = $4, skipped = $5, failed = $6,
+			locked_until = NOW() + $7 * INTERVAL '1 second'
+		WHERE id = $1
+	`, job.ID, job.Processed, job.Created, job.Requested, job.Skipped, job.Failed, lease.Seconds())
+	if err != nil {
+		return fmt.Errorf("failed to save import progress: %w", err)
+	}
+	return nil
+}
+
+// FinishImportJob records the outcome of a job and drops its follows, which
+// are no longer needed
+func (r *importJobRepository) FinishImportJob(ctx context.Context, jobID uuid.UUID, status models.ImportStatus, errMsg *string) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE follow_service_import_jobs
+		SET status = $2, error = $3, finished_at = NOW(), locked_until = NULL, follows = NULL
+		WHERE id = $1
+	`, jobID, status, errMsg)
+	if err != nil {
+		return fmt.Errorf("failed to finish import job: %w", err)
+	}
+	return nil
+}
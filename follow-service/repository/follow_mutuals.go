@@ -0,0 +1,167 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"follow-service/model"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// mutualsCacheTTL bounds how long a computed list of mutual followers is
+	// used. The viewer's own follows drop it right away; follows made by the
+	// accounts they follow show once it expires.
+	mutualsCacheTTL = 5 * time.Minute
+	// maxMutuals bounds the mutual followers kept for a pair of users. Only
+	// the first of them are shown; the rest are only counted.
+	maxMutuals = 100
+)
+
+// GetMutualFollowers returns up to first of the users viewerID follows who
+// follow userID, most recently followed by viewerID first, and how many
+// there are in all.
+//
+// The viewer's following list lives on their shard, while each of those
+// accounts' follow of userID lives on the account's own shard. The list is
+// read first and each shard is asked which of the accounts it owns follow
+// userID, which each answers from its (follower_id, following_id) index.
+// Results are cached in Redis per viewer.
+func (r *followRepository) GetMutualFollowers(ctx context.Context, viewerID, userID uuid.UUID, first int32) (*models.MutualFollowers, error) {
+	mutuals, cached := r.getCachedMutuals(ctx, viewerID, userID)
+	if !cached {
+		var err error
+		mutuals, err = r.loadMutuals(ctx, viewerID, userID)
+		if err != nil {
+			return nil, err
+		}
+		r.cacheMutuals(ctx, viewerID, userID, mutuals)
+	}
+
+	if len(mutuals.UserIDs) > int(first) {
+		mutuals.UserIDs = mutuals.UserIDs[:first]
+	}
+	return mutuals, nil
+}
+
+func (r *followRepository) loadMutuals(ctx context.Context, viewerID, userID uuid.UUID) (*models.MutualFollowers, error) {
+	var followed []uuid.UUID
+	err := r.shards.For(viewerID).ReadDB().SelectContext(ctx, &followed, `
+		SELECT following_id
+		FROM follow_service_follows
+		WHERE follower_id = $1 AND following_id <> $2
+		ORDER BY created_at DESC
+	`, viewerID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query followed users: %w", err)
+	}
+
+	groups := r.shards.Group(followed)
+	found := make([][]uuid.UUID, r.shards.Len())
+
+	g, gctx := errgroup.WithContext(ctx)
+	for shard, ids := range groups {
+		g.Go(func() error {
+			db := r.shards.All()[shard]
+			err := db.ReadDB().SelectContext(gctx, &found[shard], `
+				SELECT follower_id
+				FROM follow_service_follows
+				WHERE following_id = $1 AND follower_id = ANY($2)
+			`, userID, pq.Array(ids))
+			if err != nil {
+				return fmt.Errorf("failed to query mutual followers on shard %d: %w", shard, err)
+			}
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	isMutual := make(map[uuid.UUID]bool)
+	for _, ids := range found {
+		for _, id := range ids {
+			isMutual[id] = true
+		}
+	}
+
+	// Keep the order of the viewer's following list
+	mutuals := &models.MutualFollowers{
+		UserIDs:    []uuid.UUID{},
+		TotalCount: int32(len(isMutual)),
+	}
+	for _, id := range followed {
+		if !isMutual[id] {
+			continue
+		}
+		if len(mutuals.UserIDs) == maxMutuals {
+			break
+		}
+		mutuals.UserIDs = append(mutuals.UserIDs, id)
+	}
+	return mutuals, nil
+}
+
+// mutualsCacheKey is a hash keyed by the other user, so every cached list of
+// a viewer is dropped with a single DEL when they follow or unfollow someone
+func mutualsCacheKey(viewerID uuid.UUID) string {
+	return fmt.Sprintf("mutuals:%s", viewerID.String())
+}
+
+// getCachedMutuals returns the cached mutual followers of a pair; any Redis
+// error counts as a miss, and without Redis nothing is cached
+func (r *followRepository) getCachedMutuals(ctx context.Context, viewerID, userID uuid.UUID) (*models.MutualFollowers, bool) {
+	if r.redis == nil {
+		return nil, false
+	}
+
+	data, err := r.redis.HGet(ctx, mutualsCacheKey(viewerID), userID.String()).Bytes()
+	if err != nil {
+		return nil, false
+	}
+
+	var mutuals models.MutualFollowers
+	if err := json.Unmarshal(data, &mutuals); err != nil {
+		return nil, false
+	}
+	return &mutuals, true
+}
+
+// cacheMutuals stores the mutual followers of a pair
+func (r *followRepository) cacheMutuals(ctx context.Context, viewerID, userID uuid.UUID, mutuals *models.MutualFollowers) {
+	if r.redis == nil {
+		return
+	}
+
+	data, err := json.Marshal(mutuals)
+	if err != nil {
+		return
+	}
+
+	key := mutualsCacheKey(viewerID)
+	pipe := r.redis.Pipeline()
+	pipe.HSet(ctx, key, userID.String(), data)
+	pipe.Expire(ctx, key, mutualsCacheTTL)
+	_, _ = pipe.Exec(ctx)
+}
+
+// invalidateMutuals drops the cached mutual followers of viewers whose
+// following lists changed
+func (r *followRepository) invalidateMutuals(ctx context.Context, viewerIDs ...uuid.UUID) {
+	if r.redis == nil || len(viewerIDs) == 0 {
+		return
+	}
+
+	keys := make([]string, len(viewerIDs))
+	for i, id := range viewerIDs {
+		keys[i] = mutualsCacheKey(id)
+	}
+	if err := r.redis.Del(ctx, keys...).Err(); err != nil {
+		log.Printf("Failed to invalidate mutual followers cache %v: %v", keys, err)
+	}
+}
@@ -1,38 +1,57 @@
 package repository
 
 import (
+	"bytes"
 	"context"
-	"encoding/base64"
 	"fmt"
+	"sort"
 	"time"
 
+	"follow-service/db"
 	"follow-service/model"
 	"github.com/google/uuid"
-	"github.com/jmoiron/sqlx"
+	"github.com/redis/go-redis/v9"
+	"golang.org/x/sync/errgroup"
+	"shared/cursor"
 )
 
 type FollowRepository interface {
-	FollowUser(ctx context.Context, followerID, followingID uuid.UUID) error
+	FollowUser(ctx context.Context, followerID, followingID uuid.UUID) (bool, error)
 	UnfollowUser(ctx context.Context, followerID, followingID uuid.UUID) error
 	GetFollowers(ctx context.Context, userID uuid.UUID, first int32, after *string) (*models.FollowConnection, error)
 	GetFollowing(ctx context.Context, userID uuid.UUID, first int32, after *string) (*models.FollowConnection, error)
 	IsFollowing(ctx context.Context, followerID, followingID uuid.UUID) (bool, error)
 	GetFollowStatus(ctx context.Context, userID uuid.UUID, targetUserIDs []uuid.UUID) ([]models.FollowStatus, error)
 	GetFollowersCounts(ctx context.Context, userIDs []uuid.UUID) ([]models.UserFollowCounts, error)
+	ImportFollows(ctx context.Context, follows []models.Follow) (int32, error)
+	CreateFollowRequest(ctx context.Context, requestID, followerID, followingID uuid.UUID) (bool, error)
+	ApproveFollowRequest(ctx context.Context, followerID, followingID uuid.UUID) error
+	RejectFollowRequest(ctx context.Context, followerID, followingID uuid.UUID) error
+	ListFollowRequests(ctx context.Context, userID uuid.UUID, first int32, after *string) (*models.FollowConnection, error)
+	GetFollowSuggestions(ctx context.Context, userID uuid.UUID, first int32, after *string) (*models.FollowSuggestionConnection, error)
+	GetMutualFollowers(ctx context.Context, viewerID, userID uuid.UUID, first int32) (*models.MutualFollowers, error)
+	GetUserFollows(ctx context.Context, userID uuid.UUID) (*UserFollows, error)
+	DeleteUserFollows(ctx context.Context, userID uuid.UUID) ([]models.Follow, error)
 }
 
+// followRepository shards follows by follower_id: a user's following list,
+// follow statuses and following count live on one shard, while their
+// followers are spread over all shards and gathered from each. redis caches
+// mutual followers and may be nil, which caches nothing.
 type followRepository struct {
-	db *sqlx.DB
+	shards *database.Shards
+	redis  *redis.Client
 }
 
-func NewFollowRepository(db *sqlx.DB) FollowRepository {
-	return &followRepository{db: db}
+func NewFollowRepository(shards *database.Shards, redis *redis.Client) FollowRepository {
+	return &followRepository{shards: shards, redis: redis}
 }
 
-// FollowUser creates a new follow relationship
-func (r *followRepository) FollowUser(ctx context.Context, followerID, followingID uuid.UUID) error {
+// FollowUser creates a new follow relationship. It reports false when the
+// follower already follows the user.
+func (r *followRepository) FollowUser(ctx context.Context, followerID, followingID uuid.UUID) (bool, error) {
 	if followerID == followingID {
-		return fmt.Errorf("users cannot follow themselves")
+		return false, fmt.Errorf("users cannot follow themselves")
 	}
 
 	query := `
@@ -41,12 +60,19 @@ func (r *followRepository) FollowUser(ctx context.Context, followerID, following
 		ON CONFLICT (follower_id, following_id) DO NOTHING
 	`
 
-	_, err := r.db.ExecContext(ctx, query, uuid.New(), followerID, followingID, time.Now())
+	result, err := r.shards.For(followerID).ExecContext(ctx, query, uuid.New(), followerID, followingID, time.Now())
 	if err != nil {
-		return fmt.Errorf("failed to follow user: %w", err)
+		return false, fmt.Errorf("failed to follow user: %w", err)
 	}
 
-	return nil
+	created, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if created > 0 {
+		r.invalidateMutuals(ctx, followerID)
+	}
+	return created > 0, nil
 }
 
 // UnfollowUser removes a follow relationship
@@ -56,7 +82,7 @@ func (r *followRepository) UnfollowUser(ctx context.Context, followerID, followi
 		WHERE follower_id = $1 AND following_id = $2
 	`
 
-	result, err := r.db.ExecContext(ctx, query, followerID, followingID)
+	result, err := r.shards.For(followerID).ExecContext(ctx, query, followerID, followingID)
 	if err != nil {
 		return fmt.Errorf("failed to unfollow user: %w", err)
 	}
@@ -70,163 +96,135 @@ func (r *followRepository) UnfollowUser(ctx context.Context, followerID, followi
 		return fmt.Errorf("follow relationship not found")
 	}
 
+	r.invalidateMutuals(ctx, followerID)
 	return nil
 }
 
-// GetFollowers returns paginated list of followers
+// GetFollowers returns paginated list of followers. Followers of a user are
+// spread over every shard, so each shard returns its first page and the pages
+// are merged.
 func (r *followRepository) GetFollowers(ctx context.Context, userID uuid.UUID, first int32, after *string) (*models.FollowConnection, error) {
-	var startTime time.Time
-	var startID uuid.UUID
-
-	if after != nil && *after != "" {
-		decoded, err := decodeCursor(*after)
-		if err != nil {
-			return nil, fmt.Errorf("invalid cursor: %w", err)
-		}
-		startTime = decoded.Timestamp
-		startID = decoded.ID
-	}
-
-	query := `
-		SELECT f.follower_id, f.created_at, f.id
-		FROM follow_service_follows f
-		WHERE f.following_id = $1
-	`
-
-	args := []interface{}{userID}
-	argCount := 1
-
-	if after != nil && *after != "" {
-		argCount++
-		query += fmt.Sprintf(" AND (f.created_at, f.id) < ($%d, $%d)", argCount, argCount+1)
-		args = append(args, startTime, startID)
-		argCount++
-	}
-
-	query += " ORDER BY f.created_at DESC, f.id DESC"
-	query += fmt.Sprintf(" LIMIT $%d", argCount+1)
-	args = append(args, first+1)
-
-	rows, err := r.db.QueryxContext(ctx, query, args...)
+	rows, err := r.gatherFollowRows(ctx, "follow_service_follows", "follower_id", "following_id", userID, first, after)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query followers: %w", err)
 	}
-	defer rows.Close()
-
-	var edges []models.FollowEdge
-	for rows.Next() {
-		var followerID uuid.UUID
-		var createdAt time.Time
-		var id uuid.UUID
-
-		if err := rows.Scan(&followerID, &createdAt, &id); err != nil {
-			return nil, fmt.Errorf("failed to scan follower: %w", err)
-		}
-
-		cursor := encodeCursor(createdAt, id)
-		edges = append(edges, models.FollowEdge{
-			Cursor:     cursor,
-			UserID:     followerID,
-			FollowedAt: createdAt,
-		})
-	}
-
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating followers: %w", err)
-	}
 
-	hasNextPage := len(edges) > int(first)
-	if hasNextPage {
-		edges = edges[:first]
+	totalCount, err := r.countAcrossShards(ctx, `SELECT COUNT(*) FROM follow_service_follows WHERE following_id = $1`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get total count: %w", err)
 	}
 
-	var pageInfo models.PageInfo
-	pageInfo.HasNextPage = hasNextPage
+	return buildConnection(rows, first, totalCount), nil
+}
 
-	if len(edges) > 0 {
-		startCursor := edges[0].Cursor
-		endCursor := edges[len(edges)-1].Cursor
-		pageInfo.StartCursor = &startCursor
-		pageInfo.EndCursor = &endCursor
+// GetFollowing returns paginated list of users being followed
+func (r *followRepository) GetFollowing(ctx context.Context, userID uuid.UUID, first int32, after *string) (*models.FollowConnection, error) {
+	rows, err := listFollowRows(ctx, r.shards.For(userID), "follow_service_follows", "following_id", "follower_id", userID, first, after)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query following: %w", err)
 	}
 
-	totalCount, err := r.getFollowersCount(ctx, userID)
+	totalCount, err := r.getFollowingCount(ctx, userID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get total count: %w", err)
 	}
 
-	return &models.FollowConnection{
-		Edges:      edges,
-		PageInfo:   pageInfo,
-		TotalCount: totalCount,
-	}, nil
+	return buildConnection(rows, first, totalCount), nil
 }
 
-// GetFollowing returns paginated list of users being followed
-func (r *followRepository) GetFollowing(ctx context.Context, userID uuid.UUID, first int32, after *string) (*models.FollowConnection, error) {
-	var startTime time.Time
-	var startID uuid.UUID
+// followRow is a follow relationship seen from one side, positioned by
+// (created_at, id)
+type followRow struct {
+	UserID    uuid.UUID
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
 
-	if after != nil && *after != "" {
-		decoded, err := decodeCursor(*after)
-		if err != nil {
-			return nil, fmt.Errorf("invalid cursor: %w", err)
-		}
-		startTime = decoded.Timestamp
-		startID = decoded.ID
+// gatherFollowRows returns up to first+1 rows of userID's relationships in
+// table when they are spread over every shard: each shard returns its first
+// page and the pages are merged
+func (r *followRepository) gatherFollowRows(ctx context.Context, table, userColumn, matchColumn string, userID uuid.UUID, first int32, after *string) ([]followRow, error) {
+	shards := r.shards.All()
+	pages := make([][]followRow, len(shards))
+
+	g, gctx := errgroup.WithContext(ctx)
+	for i, db := range shards {
+		g.Go(func() error {
+			rows, err := listFollowRows(gctx, db, table, userColumn, matchColumn, userID, first, after)
+			if err != nil {
+				return fmt.Errorf("shard %d: %w", i, err)
+			}
+			pages[i] = rows
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
 	}
 
-	query := `
-		SELECT f.following_id, f.created_at, f.id
-		FROM follow_service_follows f
-		WHERE f.follower_id = $1
-	`
+	var rows []followRow
+	for _, page := range pages {
+		rows = append(rows, page...)
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if !rows[i].CreatedAt.Equal(rows[j].CreatedAt) {
+			return rows[i].CreatedAt.After(rows[j].CreatedAt)
+		}
+		return bytes.Compare(rows[i].ID[:], rows[j].ID[:]) > 0
+	})
+	if len(rows) > int(first)+1 {
+		rows = rows[:first+1]
+	}
+	return rows, nil
+}
 
-	args := []interface{}{userID}
-	argCount := 1
+// listFollowRows returns up to first+1 rows of userID's relationships in table
+// on one shard, where matchColumn holds userID and userColumn the other side
+func listFollowRows(ctx context.Context, db *database.DB, table, userColumn, matchColumn string, userID uuid.UUID, first int32, after *string) ([]followRow, error) {
+	query := fmt.Sprintf(`
+		SELECT f.%s, f.created_at, f.id
+		FROM %s f
+		WHERE f.%s = $1
+	`, userColumn, table, matchColumn)
 
-	if after != nil && *after != "" {
-		argCount++
-		query += fmt.Sprintf(" AND (f.created_at, f.id) < ($%d, $%d)", argCount, argCount+1)
-		args = append(args, startTime, startID)
-		argCount++
+	query, args, err := cursor.Keyset{TimeColumn: "f.created_at", IDColumn: "f.id"}.Page(query, []interface{}{userID}, after, int(first))
+	if err != nil {
+		return nil, err
 	}
 
-	query += " ORDER BY f.created_at DESC, f.id DESC"
-	query += fmt.Sprintf(" LIMIT $%d", argCount+1)
-	args = append(args, first+1)
-
-	rows, err := r.db.QueryxContext(ctx, query, args...)
+	rows, err := db.ReadDB().QueryxContext(ctx, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query following: %w", err)
+		return nil, err
 	}
 	defer rows.Close()
 
-	var edges []models.FollowEdge
+	var result []followRow
 	for rows.Next() {
-		var followingID uuid.UUID
-		var createdAt time.Time
-		var id uuid.UUID
-
-		if err := rows.Scan(&followingID, &createdAt, &id); err != nil {
-			return nil, fmt.Errorf("failed to scan following: %w", err)
+		var row followRow
+		if err := rows.Scan(&row.UserID, &row.CreatedAt, &row.ID); err != nil {
+			return nil, fmt.Errorf("failed to scan follow: %w", err)
 		}
-
-		cursor := encodeCursor(createdAt, id)
-		edges = append(edges, models.FollowEdge{
-			Cursor:     cursor,
-			UserID:     followingID,
-			FollowedAt: createdAt,
-		})
+		result = append(result, row)
 	}
 
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating following: %w", err)
+		return nil, fmt.Errorf("error iterating follows: %w", err)
 	}
 
-	hasNextPage := len(edges) > int(first)
-	if hasNextPage {
-		edges = edges[:first]
+	return result, nil
+}
+
+// buildConnection turns up to first+1 ordered rows into a page
+func buildConnection(rows []followRow, first int32, totalCount int32) *models.FollowConnection {
+	rows, hasNextPage := cursor.Trim(rows, int(first))
+
+	edges := make([]models.FollowEdge, 0, len(rows))
+	for _, row := range rows {
+		edges = append(edges, models.FollowEdge{
+			Cursor:     cursor.EncodeKeyset(row.CreatedAt, row.ID),
+			UserID:     row.UserID,
+			FollowedAt: row.CreatedAt,
+		})
 	}
 
 	var pageInfo models.PageInfo
@@ -239,16 +237,11 @@ func (r *followRepository) GetFollowing(ctx context.Context, userID uuid.UUID, f
 		pageInfo.EndCursor = &endCursor
 	}
 
-	totalCount, err := r.getFollowingCount(ctx, userID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get total count: %w", err)
-	}
-
 	return &models.FollowConnection{
 		Edges:      edges,
 		PageInfo:   pageInfo,
 		TotalCount: totalCount,
-	}, nil
+	}
 }
 
 // IsFollowing checks if followerID follows followingID
@@ -261,7 +254,7 @@ func (r *followRepository) IsFollowing(ctx context.Context, followerID, followin
 	`
 
 	var exists bool
-	err := r.db.GetContext(ctx, &exists, query, followerID, followingID)
+	err := r.shards.For(followerID).GetContext(ctx, &exists, query, followerID, followingID)
 	if err != nil {
 		return false, fmt.Errorf("failed to check following status: %w", err)
 	}
@@ -281,7 +274,7 @@ func (r *followRepository) GetFollowStatus(ctx context.Context, userID uuid.UUID
 		WHERE follower_id = $1 AND following_id = ANY($2)
 	`
 
-	rows, err := r.db.QueryxContext(ctx, query, userID, targetUserIDs)
+	rows, err := r.shards.For(userID).ReadDB().QueryxContext(ctx, query, userID, targetUserIDs)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query follow status: %w", err)
 	}
@@ -340,9 +333,52 @@ func (r *followRepository) GetFollowersCounts(ctx context.Context, userIDs []uui
 		LEFT JOIN following ON u.user_id = following.user_id
 	`
 
-	rows, err := r.db.QueryxContext(ctx, query, userIDs)
+	// Each shard counts the relationships it stores; a user's following
+	// count comes from one shard and their follower count from all of them
+	shards := r.shards.All()
+	partial := make([][]models.UserFollowCounts, len(shards))
+
+	g, gctx := errgroup.WithContext(ctx)
+	for i, db := range shards {
+		g.Go(func() error {
+			counts, err := queryFollowCounts(gctx, db, query, userIDs)
+			if err != nil {
+				return fmt.Errorf("failed to query follow counts on shard %d: %w", i, err)
+			}
+			partial[i] = counts
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	totals := make(map[uuid.UUID]*models.UserFollowCounts, len(userIDs))
+	for _, counts := range partial {
+		for _, c := range counts {
+			total, ok := totals[c.UserID]
+			if !ok {
+				total = &models.UserFollowCounts{UserID: c.UserID}
+				totals[c.UserID] = total
+			}
+			total.FollowersCount += c.FollowersCount
+			total.FollowingCount += c.FollowingCount
+		}
+	}
+
+	counts := make([]models.UserFollowCounts, 0, len(userIDs))
+	for _, userID := range userIDs {
+		if total, ok := totals[userID]; ok {
+			counts = append(counts, *total)
+		}
+	}
+	return counts, nil
+}
+
+func queryFollowCounts(ctx context.Context, db *database.DB, query string, userIDs []uuid.UUID) ([]models.UserFollowCounts, error) {
+	rows, err := db.ReadDB().QueryxContext(ctx, query, userIDs)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query follow counts: %w", err)
+		return nil, err
 	}
 	defer rows.Close()
 
@@ -364,58 +400,86 @@ func (r *followRepository) GetFollowersCounts(ctx context.Context, userIDs []uui
 
 // Helper functions
 
-func (r *followRepository) getFollowersCount(ctx context.Context, userID uuid.UUID) (int32, error) {
-	query := `SELECT COUNT(*) FROM follow_service_follows WHERE following_id = $1`
-	var count int32
-	err := r.db.GetContext(ctx, &count, query, userID)
-	if err != nil {
-		return 0, err
-	}
-	return count, nil
-}
+// ImportFollows inserts migrated relationships with their original
+// timestamps, skipping pairs that already exist. It returns how many were
+// created. Each shard's share is written in its own transaction, so a failure
+// can leave other shards' shares in place; re-importing skips them.
+func (r *followRepository) ImportFollows(ctx context.Context, follows []models.Follow) (int32, error) {
+	query := `
+		INSERT INTO follow_service_follows (id, follower_id, following_id, created_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (follower_id, following_id) DO NOTHING
+	`
 
-func (r *followRepository) getFollowingCount(ctx context.Context, userID uuid.UUID) (int32, error) {
-	query := `SELECT COUNT(*) FROM follow_service_follows WHERE follower_id = $1`
-	var count int32
-	err := r.db.GetContext(ctx, &count, query, userID)
-	if err != nil {
-		return 0, err
+	byShard := make(map[int][]models.Follow)
+	for _, f := range follows {
+		i := r.shards.Index(f.FollowerID)
+		byShard[i] = append(byShard[i], f)
+	}
+
+	var created int32
+	for i, shardFollows := range byShard {
+		db := r.shards.All()[i]
+		var shardCreated int32
+		err := db.WithTx(ctx, func(ctx context.Context) error {
+			for _, f := range shardFollows {
+				result, err := db.Conn(ctx).ExecContext(ctx, query, uuid.New(), f.FollowerID, f.FollowingID, f.CreatedAt)
+				if err != nil {
+					return fmt.Errorf("failed to import follow %s -> %s: %w", f.FollowerID, f.FollowingID, err)
+				}
+				if n, _ := result.RowsAffected(); n > 0 {
+					shardCreated++
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return 0, err
+		}
+		created += shardCreated
 	}
-	return count, nil
-}
 
-// Cursor encoding/decoding
+	followers := make(map[uuid.UUID]bool)
+	for _, f := range follows {
+		followers[f.FollowerID] = true
+	}
+	viewerIDs := make([]uuid.UUID, 0, len(followers))
+	for id := range followers {
+		viewerIDs = append(viewerIDs, id)
+	}
+	r.invalidateMutuals(ctx, viewerIDs...)
 
-type CursorData struct {
-	Timestamp time.Time
-	ID        uuid.UUID
+	return created, nil
 }
 
-func encodeCursor(timestamp time.Time, id uuid.UUID) string {
-	cursor := fmt.Sprintf("%d:%s", timestamp.Unix(), id.String())
-	return base64.StdEncoding.EncodeToString([]byte(cursor))
-}
+// countAcrossShards sums a COUNT(*) query over every shard
+func (r *followRepository) countAcrossShards(ctx context.Context, query string, userID uuid.UUID) (int32, error) {
+	shards := r.shards.All()
+	counts := make([]int32, len(shards))
 
-func decodeCursor(cursor string) (*CursorData, error) {
-	decoded, err := base64.StdEncoding.DecodeString(cursor)
-	if err != nil {
-		return nil, fmt.Errorf("failed to decode cursor: %w", err)
+	g, gctx := errgroup.WithContext(ctx)
+	for i, db := range shards {
+		g.Go(func() error {
+			return db.ReadDB().GetContext(gctx, &counts[i], query, userID)
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return 0, err
 	}
 
-	var timestamp int64
-	var idStr string
-	_, err = fmt.Sscanf(string(decoded), "%d:%s", &timestamp, &idStr)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse cursor: %w", err)
+	var total int32
+	for _, count := range counts {
+		total += count
 	}
+	return total, nil
+}
 
-	id, err := uuid.Parse(idStr)
+func (r *followRepository) getFollowingCount(ctx context.Context, userID uuid.UUID) (int32, error) {
+	query := `SELECT COUNT(*) FROM follow_service_follows WHERE follower_id = $1`
+	var count int32
+	err := r.shards.For(userID).ReadDB().GetContext(ctx, &count, query, userID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse UUID from cursor: %w", err)
+		return 0, err
 	}
-
-	return &CursorData{
-		Timestamp: time.Unix(timestamp, 0),
-		ID:        id,
-	}, nil
+	return count, nil
 }
@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"follow-service/model"
+	"github.com/google/uuid"
+)
+
+// ErrFollowRequestNotFound is returned when approving or rejecting a request
+// that is not pending
+var ErrFollowRequestNotFound = errors.New("follow request not found")
+
+// Follow requests are sharded by follower_id like follows, so approving a
+// request moves it into follow_service_follows within one shard's
+// transaction. A private account's pending requests are gathered from every
+// shard.
+
+// CreateFollowRequest records that followerID asked to follow followingID.
+// It returns false if the request is already pending.
+func (r *followRepository) CreateFollowRequest(ctx context.Context, requestID, followerID, followingID uuid.UUID) (bool, error) {
+	query := `
+		INSERT INTO follow_service_follow_requests (id, follower_id, following_id, created_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (follower_id, following_id) DO NOTHING
+	`
+
+	result, err := r.shards.For(followerID).ExecContext(ctx, query, requestID, followerID, followingID, time.Now())
+	if err != nil {
+		return false, fmt.Errorf("failed to create follow request: %w", err)
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	return n > 0, nil
+}
+
+// ApproveFollowRequest turns a pending request into a follow relationship
+func (r *followRepository) ApproveFollowRequest(ctx context.Context, followerID, followingID uuid.UUID) error {
+	db := r.shards.For(followerID)
+	defer r.invalidateMutuals(ctx, followerID)
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		result, err := db.Conn(ctx).ExecContext(ctx, `
+			DELETE FROM follow_service_follow_requests
+			WHERE follower_id = $1 AND following_id = $2
+		`, followerID, followingID)
+		if err != nil {
+			return fmt.Errorf("failed to delete follow request: %w", err)
+		}
+		if n, _ := result.RowsAffected(); n == 0 {
+			return ErrFollowRequestNotFound
+		}
+
+		_, err = db.Conn(ctx).ExecContext(ctx, `
+			INSERT INTO follow_service_follows (id, follower_id, following_id, created_at)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (follower_id, following_id) DO NOTHING
+		`, uuid.New(), followerID, followingID, time.Now())
+		if err != nil {
+			return fmt.Errorf("failed to follow user: %w", err)
+		}
+		return nil
+	})
+}
+
+// RejectFollowRequest drops a pending request
+func (r *followRepository) RejectFollowRequest(ctx context.Context, followerID, followingID uuid.UUID) error {
+	query := `
+		DELETE FROM follow_service_follow_requests
+		WHERE follower_id = $1 AND following_id = $2
+	`
+
+	result, err := r.shards.For(followerID).ExecContext(ctx, query, followerID, followingID)
+	if err != nil {
+		return fmt.Errorf("failed to delete follow request: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return ErrFollowRequestNotFound
+	}
+
+	return nil
+}
+
+// ListFollowRequests returns the pending requests to follow userID, newest
+// first. Edges carry the requester and when they asked.
+func (r *followRepository) ListFollowRequests(ctx context.Context, userID uuid.UUID, first int32, after *string) (*models.FollowConnection, error) {
+	rows, err := r.gatherFollowRows(ctx, "follow_service_follow_requests", "follower_id", "following_id", userID, first, after)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query follow requests: %w", err)
+	}
+
+	totalCount, err := r.countAcrossShards(ctx, `SELECT COUNT(*) FROM follow_service_follow_requests WHERE following_id = $1`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get total count: %w", err)
+	}
+
+	return buildConnection(rows, first, totalCount), nil
+}
@@ -0,0 +1,259 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"follow-service/db"
+	"follow-service/model"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"golang.org/x/sync/errgroup"
+	"shared/cursor"
+)
+
+const (
+	// suggestionSeeds bounds the followed accounts suggestions start from;
+	// the most recently followed are used
+	suggestionSeeds = 500
+	// suggestionCandidates bounds the candidates each shard returns per signal
+	suggestionCandidates = 1000
+	// mutualFollowWeight is how much more a mutual follow counts than a
+	// shared interest
+	mutualFollowWeight = 2
+)
+
+// GetFollowSuggestions ranks users for userID to follow from two signals of
+// the follow graph:
+//
+//   - mutual follows: users followed by the accounts userID follows
+//     (friends of friends)
+//   - shared interests: users who follow the same accounts as userID
+//
+// Users userID already follows or asked to follow are left out. Candidates
+// are counted from follows made up to the time of the first page, which its
+// cursor carries, so scores do not move between pages. Each shard returns its
+// top candidates per signal, so counts of users spread over many shards can
+// come out low.
+func (r *followRepository) GetFollowSuggestions(ctx context.Context, userID uuid.UUID, first int32, after *string) (*models.FollowSuggestionConnection, error) {
+	asOf := time.Now().UTC()
+	var position *models.FollowSuggestion
+	if after != nil && *after != "" {
+		score, id, t, err := cursor.DecodeRanked(*after)
+		if err != nil {
+			return nil, err
+		}
+		asOf = t
+		position = &models.FollowSuggestion{UserID: id, Score: score}
+	}
+
+	var seeds []uuid.UUID
+	err := r.shards.For(userID).ReadDB().SelectContext(ctx, &seeds, `
+		SELECT following_id
+		FROM follow_service_follows
+		WHERE follower_id = $1 AND created_at <= $2
+		ORDER BY created_at DESC
+		LIMIT $3
+	`, userID, asOf, suggestionSeeds)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query followed users: %w", err)
+	}
+
+	suggestions, err := r.rankSuggestions(ctx, userID, seeds, asOf)
+	if err != nil {
+		return nil, err
+	}
+
+	if position != nil {
+		start := sort.Search(len(suggestions), func(i int) bool {
+			return suggestionBefore(*position, suggestions[i])
+		})
+		suggestions = suggestions[start:]
+	}
+
+	suggestions, hasNextPage := cursor.Trim(suggestions, int(first))
+
+	edges := make([]models.FollowSuggestionEdge, len(suggestions))
+	for i, s := range suggestions {
+		edges[i] = models.FollowSuggestionEdge{
+			Cursor:           cursor.EncodeRanked(s.Score, s.UserID, asOf),
+			FollowSuggestion: s,
+		}
+	}
+
+	pageInfo := models.PageInfo{
+		HasNextPage:     hasNextPage,
+		HasPreviousPage: position != nil,
+	}
+	if len(edges) > 0 {
+		startCursor := edges[0].Cursor
+		endCursor := edges[len(edges)-1].Cursor
+		pageInfo.StartCursor = &startCursor
+		pageInfo.EndCursor = &endCursor
+	}
+
+	return &models.FollowSuggestionConnection{
+		Edges:    edges,
+		PageInfo: pageInfo,
+	}, nil
+}
+
+// rankSuggestions counts both signals for the accounts userID follows and
+// returns the candidates userID may still follow, best first
+func (r *followRepository) rankSuggestions(ctx context.Context, userID uuid.UUID, seeds []uuid.UUID, asOf time.Time) ([]models.FollowSuggestion, error) {
+	if len(seeds) == 0 {
+		return nil, nil
+	}
+
+	// The accounts a seed follows live on the seed's shard
+	mutualQuery := `
+		SELECT following_id, COUNT(*)
+		FROM follow_service_follows
+		WHERE follower_id = ANY($1) AND following_id <> $2 AND created_at <= $3
+		GROUP BY following_id
+		ORDER BY COUNT(*) DESC, following_id DESC
+		LIMIT $4
+	`
+	// The followers of a seed are spread over every shard
+	interestQuery := `
+		SELECT follower_id, COUNT(*)
+		FROM follow_service_follows
+		WHERE following_id = ANY($1) AND follower_id <> $2 AND created_at <= $3
+		GROUP BY follower_id
+		ORDER BY COUNT(*) DESC, follower_id DESC
+		LIMIT $4
+	`
+
+	shards := r.shards.All()
+	groups := r.shards.Group(seeds)
+	mutual := make([]map[uuid.UUID]int32, len(shards))
+	interest := make([]map[uuid.UUID]int32, len(shards))
+
+	g, gctx := errgroup.WithContext(ctx)
+	for i, db := range shards {
+		if group := groups[i]; len(group) > 0 {
+			g.Go(func() error {
+				counts, err := countCandidates(gctx, db, mutualQuery, group, userID, asOf)
+				if err != nil {
+					return fmt.Errorf("failed to count mutual follows on shard %d: %w", i, err)
+				}
+				mutual[i] = counts
+				return nil
+			})
+		}
+		g.Go(func() error {
+			counts, err := countCandidates(gctx, db, interestQuery, seeds, userID, asOf)
+			if err != nil {
+				return fmt.Errorf("failed to count shared interests on shard %d: %w", i, err)
+			}
+			interest[i] = counts
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	byUser := make(map[uuid.UUID]*models.FollowSuggestion)
+	candidate := func(id uuid.UUID) *models.FollowSuggestion {
+		s, ok := byUser[id]
+		if !ok {
+			s = &models.FollowSuggestion{UserID: id}
+			byUser[id] = s
+		}
+		return s
+	}
+	for i := range shards {
+		for id, n := range mutual[i] {
+			candidate(id).MutualFollows += n
+		}
+		for id, n := range interest[i] {
+			candidate(id).SharedInterests += n
+		}
+	}
+
+	ids := make([]uuid.UUID, 0, len(byUser))
+	for id := range byUser {
+		ids = append(ids, id)
+	}
+	excluded, err := r.excludedSuggestions(ctx, userID, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	suggestions := make([]models.FollowSuggestion, 0, len(byUser))
+	for id, s := range byUser {
+		if excluded[id] {
+			continue
+		}
+		s.Score = float64(mutualFollowWeight*s.MutualFollows + s.SharedInterests)
+		suggestions = append(suggestions, *s)
+	}
+	sort.Slice(suggestions, func(i, j int) bool {
+		return suggestionBefore(suggestions[i], suggestions[j])
+	})
+	return suggestions, nil
+}
+
+// excludedSuggestions returns the candidates userID follows or has asked to
+// follow. Both live on userID's shard.
+func (r *followRepository) excludedSuggestions(ctx context.Context, userID uuid.UUID, candidates []uuid.UUID) (map[uuid.UUID]bool, error) {
+	excluded := make(map[uuid.UUID]bool)
+	if len(candidates) == 0 {
+		return excluded, nil
+	}
+
+	var ids []uuid.UUID
+	err := r.shards.For(userID).ReadDB().SelectContext(ctx, &ids, `
+		SELECT following_id FROM follow_service_follows
+		WHERE follower_id = $1 AND following_id = ANY($2)
+		UNION
+		SELECT following_id FROM follow_service_follow_requests
+		WHERE follower_id = $1 AND following_id = ANY($2)
+	`, userID, pq.Array(candidates))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query followed candidates: %w", err)
+	}
+
+	for _, id := range ids {
+		excluded[id] = true
+	}
+	return excluded, nil
+}
+
+// countCandidates runs a candidate query on one shard
+func countCandidates(ctx context.Context, db *database.DB, query string, seeds []uuid.UUID, userID uuid.UUID, asOf time.Time) (map[uuid.UUID]int32, error) {
+	rows, err := db.ReadDB().QueryxContext(ctx, query, pq.Array(seeds), userID, asOf, suggestionCandidates)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[uuid.UUID]int32)
+	for rows.Next() {
+		var id uuid.UUID
+		var count int32
+		if err := rows.Scan(&id, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan candidate: %w", err)
+		}
+		counts[id] = count
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating candidates: %w", err)
+	}
+
+	return counts, nil
+}
+
+// suggestionBefore reports whether a ranks before b: higher scores first,
+// ties by user ID descending
+func suggestionBefore(a, b models.FollowSuggestion) bool {
+	if a.Score != b.Score {
+		return a.Score > b.Score
+	}
+	return bytes.Compare(a.UserID[:], b.UserID[:]) > 0
+}
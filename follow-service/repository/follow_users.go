@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"follow-service/model"
+	"github.com/google/uuid"
+	"golang.org/x/sync/errgroup"
+)
+
+// UserFollows is everything follow-service holds on one user: the follows
+// and follow requests they made or received
+type UserFollows struct {
+	Follows  []models.Follow
+	Requests []models.Follow
+}
+
+// GetUserFollows returns the follows and pending follow requests a user made
+// or received, most recent first. Those they received are spread over every
+// shard, so each shard is queried.
+func (r *followRepository) GetUserFollows(ctx context.Context, userID uuid.UUID) (*UserFollows, error) {
+	follows := make([][]models.Follow, r.shards.Len())
+	requests := make([][]models.Follow, r.shards.Len())
+
+	g, gctx := errgroup.WithContext(ctx)
+	for shard, db := range r.shards.All() {
+		g.Go(func() error {
+			err := db.ReadDB().SelectContext(gctx, &follows[shard], `
+				SELECT id, follower_id, following_id, created_at
+				FROM follow_service_follows
+				WHERE follower_id = $1 OR following_id = $1
+			`, userID)
+			if err != nil {
+				return fmt.Errorf("failed to get follows of user on shard %d: %w", shard, err)
+			}
+
+			err = db.ReadDB().SelectContext(gctx, &requests[shard], `
+				SELECT id, follower_id, following_id, created_at
+				FROM follow_service_follow_requests
+				WHERE follower_id = $1 OR following_id = $1
+			`, userID)
+			if err != nil {
+				return fmt.Errorf("failed to get follow requests of user on shard %d: %w", shard, err)
+			}
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	return &UserFollows{
+		Follows:  mergeFollows(follows),
+		Requests: mergeFollows(requests),
+	}, nil
+}
+
+// DeleteUserFollows removes every follow and follow request a user made or
+// received from every shard, and returns the follows removed. Deleting them
+// again removes nothing.
+func (r *followRepository) DeleteUserFollows(ctx context.Context, userID uuid.UUID) ([]models.Follow, error) {
+	deleted := make([][]models.Follow, r.shards.Len())
+
+	g, gctx := errgroup.WithContext(ctx)
+	for shard, db := range r.shards.All() {
+		g.Go(func() error {
+			return db.WithTx(gctx, func(ctx context.Context) error {
+				err := db.Conn(ctx).SelectContext(ctx, &deleted[shard], `
+					DELETE FROM follow_service_follows
+					WHERE follower_id = $1 OR following_id = $1
+					RETURNING id, follower_id, following_id, created_at
+				`, userID)
+				if err != nil {
+					return fmt.Errorf("failed to delete follows of user on shard %d: %w", shard, err)
+				}
+
+				_, err = db.Conn(ctx).ExecContext(ctx, `
+					DELETE FROM follow_service_follow_requests
+					WHERE follower_id = $1 OR following_id = $1
+				`, userID)
+				if err != nil {
+					return fmt.Errorf("failed to delete follow requests of user on shard %d: %w", shard, err)
+				}
+				return nil
+			})
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	r.invalidateMutuals(ctx, userID)
+	return mergeFollows(deleted), nil
+}
+
+// mergeFollows joins the rows read from each shard, most recent first
+func mergeFollows(shards [][]models.Follow) []models.Follow {
+	follows := []models.Follow{}
+	for _, rows := range shards {
+		follows = append(follows, rows...)
+	}
+	sort.Slice(follows, func(i, j int) bool {
+		return follows[i].CreatedAt.After(follows[j].CreatedAt)
+	})
+	return follows
+}
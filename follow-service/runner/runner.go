@@ -0,0 +1,260 @@
+// Package runner executes follow import jobs in the background, a batch at a
+// time. Progress is saved after every batch; a job whose process stopped is
+// left running until its lease runs out, then claimed again and resumed from
+// the last saved batch. Follows and requests already made by a batch that
+// was not recorded are skipped when it runs again.
+package runner
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"follow-service/events"
+	"follow-service/model"
+	"follow-service/publisher"
+	"follow-service/repository"
+	"github.com/google/uuid"
+	userpb "user-service/pb"
+)
+
+const (
+	batchSize = 100
+	// lease is how long a claimed job is held without saving progress before
+	// another process may take it over
+	lease = 2 * time.Minute
+)
+
+type Runner struct {
+	jobs         repository.ImportJobRepository
+	follows      repository.FollowRepository
+	users        userpb.UserServiceClient
+	publisher    *publisher.EventPublisher
+	rate         int
+	pollInterval time.Duration
+	wake         chan struct{}
+}
+
+// New creates a runner importing at most rate follows per second across its
+// jobs, so a large import does not crowd out other writes
+func New(jobs repository.ImportJobRepository, follows repository.FollowRepository, users userpb.UserServiceClient, pub *publisher.EventPublisher, rate int, pollInterval time.Duration) *Runner {
+	return &Runner{
+		jobs:         jobs,
+		follows:      follows,
+		users:        users,
+		publisher:    pub,
+		rate:         max(rate, 1),
+		pollInterval: pollInterval,
+		wake:         make(chan struct{}, 1),
+	}
+}
+
+// Wake asks the runner to look for pending jobs without waiting for the next
+// poll
+func (r *Runner) Wake() {
+	select {
+	case r.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run processes jobs one at a time until ctx is cancelled
+func (r *Runner) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		r.drain(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-r.wake:
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *Runner) drain(ctx context.Context) {
+	for ctx.Err() == nil {
+		job, err := r.jobs.ClaimImportJob(ctx, lease)
+		if err != nil {
+			log.Printf("Failed to claim follow import job: %v", err)
+			return
+		}
+		if job == nil {
+			return
+		}
+		r.execute(ctx, job)
+	}
+}
+
+func (r *Runner) execute(ctx context.Context, job *models.ImportJob) {
+	log.Printf("Running follow import job %s (%d/%d processed)", job.ID, job.Processed, job.Total)
+
+	err := r.runJob(ctx, job)
+	if ctx.Err() != nil {
+		// Left RUNNING so it resumes once its lease runs out
+		log.Printf("Follow import job %s interrupted, it will resume", job.ID)
+		return
+	}
+
+	finalStatus := models.ImportCompleted
+	var errMsg *string
+	if err != nil {
+		finalStatus = models.ImportFailed
+		msg := err.Error()
+		errMsg = &msg
+		log.Printf("Follow import job %s failed: %v", job.ID, err)
+	} else {
+		log.Printf("Follow import job %s completed: %d created, %d requested, %d skipped, %d failed", job.ID, job.Created, job.Requested, job.Skipped, job.Failed)
+	}
+
+	if err := r.jobs.FinishImportJob(ctx, job.ID, finalStatus, errMsg); err != nil {
+		log.Printf("Failed to record outcome of follow import job %s: %v", job.ID, err)
+	}
+}
+
+func (r *Runner) runJob(ctx context.Context, job *models.ImportJob) error {
+	follows, err := r.jobs.GetImportJobFollows(ctx, job.ID)
+	if err != nil {
+		return err
+	}
+	if len(follows) != int(job.Total) {
+		return fmt.Errorf("job holds %d follows, expected %d", len(follows), job.Total)
+	}
+
+	for job.Processed < job.Total {
+		start := int(job.Processed)
+		end := min(start+batchSize, int(job.Total))
+		batchStart := time.Now()
+
+		if err := r.importBatch(ctx, job, follows[start:end]); err != nil {
+			return fmt.Errorf("follows %d-%d: %w", start, end, err)
+		}
+		job.Processed = int32(end)
+		if err := r.jobs.SaveImportProgress(ctx, job, lease); err != nil {
+			return err
+		}
+
+		// Throttle to the configured rate
+		wait := time.Duration(end-start)*time.Second/time.Duration(r.rate) - time.Since(batchStart)
+		if wait > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(wait):
+			}
+		}
+	}
+	return nil
+}
+
+// importBatch imports a batch of follows, counting them on job. Follows of
+// users who do not exist fail. In users' own imports, follows of private
+// accounts become follow requests as they would when following by hand;
+// admin imports store every follow as it is. No follow.created events are
+// published, so an import does not notify anyone.
+func (r *Runner) importBatch(ctx context.Context, job *models.ImportJob, batch []models.Follow) error {
+	existing, err := r.lookUpUsers(ctx, batch)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	follows := make([]models.Follow, 0, len(batch))
+	for _, f := range batch {
+		private, ok := existing[f.FollowingID]
+		if _, followerExists := existing[f.FollowerID]; !ok || !followerExists {
+			job.Failed++
+			continue
+		}
+		if f.CreatedAt.IsZero() {
+			f.CreatedAt = now
+		}
+
+		if job.OwnerID != nil && private {
+			requested, err := r.requestFollow(ctx, f)
+			if err != nil {
+				return err
+			}
+			if requested {
+				job.Requested++
+			} else {
+				job.Skipped++
+			}
+			continue
+		}
+		follows = append(follows, f)
+	}
+	if len(follows) == 0 {
+		return nil
+	}
+
+	created, err := r.follows.ImportFollows(ctx, follows)
+	if err != nil {
+		return err
+	}
+	job.Created += created
+	job.Skipped += int32(len(follows)) - created
+	return nil
+}
+
+// lookUpUsers returns which users of a batch exist, and whether each is a
+// private account
+func (r *Runner) lookUpUsers(ctx context.Context, batch []models.Follow) (map[uuid.UUID]bool, error) {
+	ids := make(map[uuid.UUID]bool)
+	for _, f := range batch {
+		ids[f.FollowerID] = true
+		ids[f.FollowingID] = true
+	}
+	userIDs := make([]string, 0, len(ids))
+	for id := range ids {
+		userIDs = append(userIDs, id.String())
+	}
+
+	resp, err := r.users.GetUsersByIds(ctx, &userpb.GetUsersByIdsRequest{UserIds: userIDs})
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up users: %w", err)
+	}
+
+	existing := make(map[uuid.UUID]bool, len(resp.Users))
+	for _, user := range resp.Users {
+		id, err := uuid.Parse(user.Id)
+		if err != nil {
+			continue
+		}
+		existing[id] = user.IsPrivate
+	}
+	return existing, nil
+}
+
+// requestFollow asks to follow a private account, and reports whether a new
+// request was made; pairs already following or requested are left alone
+func (r *Runner) requestFollow(ctx context.Context, f models.Follow) (bool, error) {
+	following, err := r.follows.IsFollowing(ctx, f.FollowerID, f.FollowingID)
+	if err != nil {
+		return false, err
+	}
+	if following {
+		return false, nil
+	}
+
+	requestID := uuid.New()
+	created, err := r.follows.CreateFollowRequest(ctx, requestID, f.FollowerID, f.FollowingID)
+	if err != nil || !created {
+		return false, err
+	}
+
+	event := events.FollowRequestedEvent{
+		RequestID:   requestID,
+		FollowerID:  f.FollowerID,
+		FollowingID: f.FollowingID,
+		CreatedAt:   time.Now(),
+	}
+	if err := r.publisher.PublishFollowRequested(ctx, event); err != nil {
+		log.Printf("Failed to publish follow requested event: %v", err)
+	}
+	return true, nil
+}
@@ -0,0 +1,104 @@
+package subscriber
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"follow-service/events"
+	natsClient "follow-service/nats"
+	"follow-service/publisher"
+	"follow-service/repository"
+	"github.com/nats-io/nats.go"
+	"shared/logging"
+	"shared/tracing"
+)
+
+// subscribeRetry is the wait between attempts to subscribe while the stream
+// does not exist yet
+const subscribeRetry = 5 * time.Second
+
+// UserSubscriber deletes the follows of users who delete their account. It
+// consumes the JetStream stream through a durable consumer, so deletions
+// made while the service is down are still handled, and handling one twice
+// is harmless.
+type UserSubscriber struct {
+	natsClient *natsClient.Client
+	repo       repository.FollowRepository
+	publisher  *publisher.EventPublisher
+	ctx        context.Context
+}
+
+func NewUserSubscriber(
+	natsClient *natsClient.Client,
+	repo repository.FollowRepository,
+	publisher *publisher.EventPublisher,
+	ctx context.Context,
+) *UserSubscriber {
+	return &UserSubscriber{
+		natsClient: natsClient,
+		repo:       repo,
+		publisher:  publisher,
+		ctx:        ctx,
+	}
+}
+
+// Start subscribes in the background. The stream is created by
+// notification-service, so subscribing is retried until it exists. The
+// subscription is never unsubscribed, which would delete the durable
+// consumer; closing the NATS connection ends it.
+func (s *UserSubscriber) Start() {
+	go func() {
+		for {
+			_, err := s.natsClient.SubscribeDurable(events.UserDeleted, "follow-service-user-deletions", "follow-workers", s.handle)
+			if err == nil {
+				log.Println("User subscriber started successfully")
+				return
+			}
+
+			log.Printf("Failed to start user subscriber, retrying: %v", err)
+			select {
+			case <-time.After(subscribeRetry):
+			case <-s.ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// handle deletes the follows of a deleted user and announces each as an
+// unfollow, so that feeds and counters drop the user as they would after
+// an unfollow
+func (s *UserSubscriber) handle(msg *nats.Msg) {
+	ctx, span := tracing.StartProcess(s.ctx, msg.Subject, msg.Header)
+	ctx = logging.Extract(ctx, msg.Subject, msg.Header)
+	defer span.End()
+
+	var event events.UserDeletedEvent
+	if err := natsClient.DecodeEvent(msg, &event); err != nil {
+		logging.FromContext(ctx).Error().Err(err).Msg("failed to decode user deleted event")
+		msg.Nak()
+		return
+	}
+
+	deleted, err := s.repo.DeleteUserFollows(ctx, event.UserID)
+	if err != nil {
+		logging.FromContext(ctx).Error().Err(err).Stringer("user_id", event.UserID).Msg("failed to delete follows of deleted user")
+		msg.Nak()
+		return
+	}
+
+	for _, follow := range deleted {
+		unfollowed := events.UserUnfollowedEvent{
+			FollowerID:  follow.FollowerID,
+			FollowingID: follow.FollowingID,
+			DeletedAt:   event.DeletedAt,
+		}
+		if err := s.publisher.PublishUserUnfollowed(ctx, unfollowed); err != nil {
+			logging.FromContext(ctx).Error().Err(err).Msg("failed to publish user unfollowed event")
+		}
+	}
+
+	logging.FromContext(ctx).Info().Stringer("user_id", event.UserID).Int("deleted", len(deleted)).Msg("deleted follows of deleted user")
+	msg.Ack()
+}
@@ -0,0 +1,101 @@
+// Package archive parses the JSON archives accepted by the import service and
+// derives stable local IDs for the records they contain.
+package archive
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// namespace scopes the name-based UUIDs derived for imported records
+var namespace = uuid.MustParse("6f1c2a52-3b0e-4d43-9a57-0c8a1f5e2b7d")
+
+type User struct {
+	ExternalID string    `json:"external_id"`
+	Username   string    `json:"username"`
+	Email      string    `json:"email"`
+	Bio        *string   `json:"bio,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type Post struct {
+	ExternalID       string    `json:"external_id"`
+	AuthorExternalID string    `json:"author_external_id"`
+	Content          string    `json:"content"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+type Follow struct {
+	FollowerExternalID  string    `json:"follower_external_id"`
+	FollowingExternalID string    `json:"following_external_id"`
+	CreatedAt           time.Time `json:"created_at"`
+}
+
+// Archive is an export of another platform's users, posts and follows
+type Archive struct {
+	Users   []User   `json:"users"`
+	Posts   []Post   `json:"posts"`
+	Follows []Follow `json:"follows"`
+}
+
+// Parse decodes and validates an archive. Records referring to users that are
+// not part of the archive are rejected so problems surface before approval.
+func Parse(data []byte) (*Archive, error) {
+	var a Archive
+	if err := json.Unmarshal(data, &a); err != nil {
+		return nil, fmt.Errorf("invalid archive: %w", err)
+	}
+
+	users := make(map[string]bool, len(a.Users))
+	for i, u := range a.Users {
+		if u.ExternalID == "" || u.Username == "" || u.Email == "" {
+			return nil, fmt.Errorf("users[%d]: external_id, username and email are required", i)
+		}
+		if u.CreatedAt.IsZero() {
+			return nil, fmt.Errorf("users[%d]: created_at is required", i)
+		}
+		if users[u.ExternalID] {
+			return nil, fmt.Errorf("users[%d]: duplicate external_id %q", i, u.ExternalID)
+		}
+		users[u.ExternalID] = true
+	}
+
+	posts := make(map[string]bool, len(a.Posts))
+	for i, p := range a.Posts {
+		if p.ExternalID == "" || p.Content == "" || p.CreatedAt.IsZero() {
+			return nil, fmt.Errorf("posts[%d]: external_id, content and created_at are required", i)
+		}
+		if !users[p.AuthorExternalID] {
+			return nil, fmt.Errorf("posts[%d]: unknown author %q", i, p.AuthorExternalID)
+		}
+		if posts[p.ExternalID] {
+			return nil, fmt.Errorf("posts[%d]: duplicate external_id %q", i, p.ExternalID)
+		}
+		posts[p.ExternalID] = true
+	}
+
+	for i, f := range a.Follows {
+		if !users[f.FollowerExternalID] || !users[f.FollowingExternalID] {
+			return nil, fmt.Errorf("follows[%d]: follower and following must be archive users", i)
+		}
+		if f.CreatedAt.IsZero() {
+			return nil, fmt.Errorf("follows[%d]: created_at is required", i)
+		}
+	}
+
+	return &a, nil
+}
+
+// UserID returns the ID a user from source is created under. The same archive
+// record always maps to the same ID, which makes re-imports idempotent.
+func UserID(source, externalID string) uuid.UUID {
+	return uuid.NewSHA1(namespace, []byte("user:"+source+":"+externalID))
+}
+
+// PostID returns the ID a post from source is created under
+func PostID(source, externalID string) uuid.UUID {
+	return uuid.NewSHA1(namespace, []byte("post:"+source+":"+externalID))
+}
@@ -0,0 +1,212 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/joho/godotenv"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+
+	authpb "auth-service/pb"
+	followpb "follow-service/pb"
+	postpb "post-service/pb"
+	userpb "user-service/pb"
+
+	"import-service/config"
+	"import-service/db"
+	"import-service/handler"
+	"import-service/interceptor"
+	"import-service/migrations"
+	pb "import-service/pb"
+	"import-service/repository"
+	"import-service/rpcerror"
+	"import-service/runner"
+	"shared/chaos"
+	"shared/lifecycle"
+	"shared/logging"
+	"shared/migrate"
+	"shared/mtls"
+	"shared/tracing"
+)
+
+func main() {
+	logging.Init("import-service")
+
+	migrateFlag := flag.Bool("migrate", false, "apply pending schema migrations before serving")
+	flag.Parse()
+
+	if err := godotenv.Load(); err != nil {
+		log.Println("failed to load Import .env")
+	}
+
+	// The supervisor health-checks the dependencies below and closes them
+	// in order on shutdown
+	supervisor := lifecycle.New(pb.ImportService_ServiceDesc.ServiceName)
+
+	// Load database configuration
+	dbCfg, err := config.LoadDatabaseConfig("IMPORT_")
+	if err != nil {
+		log.Fatalf("Failed to load Import database config: %v", err)
+	}
+
+	// Connect to the database
+	dbConn, err := database.NewConnection(database.Config{
+		Host:         dbCfg.Host,
+		Port:         dbCfg.Port,
+		User:         dbCfg.User,
+		Password:     dbCfg.Password,
+		DBName:       dbCfg.DBName,
+		SSLMode:      dbCfg.SSLMode,
+		MaxOpenConns: dbCfg.MaxOpenConns,
+		MaxIdleConns: dbCfg.MaxIdleConns,
+		MaxLifetime:  dbCfg.MaxLifetime,
+		ReplicaDSNs:  dbCfg.ReplicaDSNs,
+	})
+	if err != nil {
+		log.Fatalf("Failed to connect to Import database: %v", err)
+	}
+	supervisor.Add(lifecycle.Dependency{Name: "database", Check: dbConn.HealthCheck, Close: dbConn.Close})
+
+	// Apply pending schema migrations with -migrate or DB_MIGRATE=true;
+	// without either they are only reported
+	if err := migrate.OnStartup(context.Background(), dbConn.DB, "import-service", migrations.Files, *migrateFlag || os.Getenv("DB_MIGRATE") == "true"); err != nil {
+		log.Fatalf("Failed to migrate Import database: %v", err)
+	}
+
+	log.Println("Successfully connected to database")
+
+	// Load other service-level configs
+	grpcPort := getEnv("GRPC_PORT", "50059")
+	jwtSecret := getEnv("JWT_SECRET", "your-secret-key")
+	// Tokens are verified with JWT_SECRET, or with auth-service's public keys
+	// when JWT_ALGORITHM is RS256
+	tokenKeys, err := interceptor.KeysFromEnv(jwtSecret)
+	if err != nil {
+		log.Fatalf("Invalid JWT configuration: %v", err)
+	}
+	maxArchiveBytes := getEnvAsInt("IMPORT_MAX_ARCHIVE_BYTES", 64<<20)
+	batchSize := getEnvAsInt("IMPORT_BATCH_SIZE", 200)
+	pollInterval := getEnvAsDuration("IMPORT_POLL_INTERVAL", 30*time.Second)
+
+	// Fault injection for resilience testing; inert unless CHAOS_ENABLED=true
+	chaosInjector, err := chaos.FromEnv()
+	if err != nil {
+		log.Fatalf("Invalid chaos configuration: %v", err)
+	}
+
+	// Export traces when OTEL_EXPORTER_OTLP_ENDPOINT is set
+	shutdownTracing, err := tracing.Init(context.Background(), "import-service")
+	if err != nil {
+		log.Fatalf("Failed to initialize tracing: %v", err)
+	}
+	supervisor.OnShutdown("tracing", shutdownTracing)
+
+	// Other services are dialed over TLS when GRPC_TLS is set
+	clientTLS, err := mtls.DialOption()
+	if err != nil {
+		log.Fatalf("Failed to load TLS config: %v", err)
+	}
+
+	// Connect to the services records are imported into
+	dial := func(addr string) *grpc.ClientConn {
+		conn, err := grpc.NewClient(addr, clientTLS, tracing.DialOption(), grpc.WithChainUnaryInterceptor(logging.UnaryClientInterceptor()))
+		if err != nil {
+			log.Fatalf("Failed to connect to %s: %v", addr, err)
+		}
+		return conn
+	}
+	authConn := dial(getEnv("AUTH_SERVICE_ADDR", "auth-service:50051"))
+	defer authConn.Close()
+	userConn := dial(getEnv("USER_SERVICE_ADDR", "user-service:50052"))
+	defer userConn.Close()
+	postConn := dial(getEnv("POST_SERVICE_ADDR", "post-service:50053"))
+	defer postConn.Close()
+	followConn := dial(getEnv("FOLLOW_SERVICE_ADDR", "follow-service:50055"))
+	defer followConn.Close()
+
+	// Initialize repository, runner and handler
+	importRepo := repository.NewImportRepository(dbConn)
+	importRunner := runner.New(importRepo, runner.Clients{
+		Auth:   authpb.NewAuthServiceClient(authConn),
+		User:   userpb.NewUserServiceClient(userConn),
+		Post:   postpb.NewPostServiceClient(postConn),
+		Follow: followpb.NewFollowServiceClient(followConn),
+	}, tokenKeys, batchSize, pollInterval)
+	importHandler := handler.NewImportHandler(importRepo, importRunner)
+
+	runnerCtx, stopRunner := context.WithCancel(supervisor.Context())
+	defer stopRunner()
+	go importRunner.Run(runnerCtx)
+
+	// Every method requires authentication; reviewing jobs requires ADMIN
+	authInterceptor := interceptor.NewAuthInterceptor(tokenKeys, nil)
+	authInterceptor.AddAdminMethods([]string{
+		"/imports.ImportService/ApproveImport",
+		"/imports.ImportService/RejectImport",
+		"/imports.ImportService/ListImportJobs",
+	})
+	authInterceptor.AddPublicMethods(lifecycle.HealthMethods)
+
+	// TLS, and mutual TLS with a CA bundle, from GRPC_TLS_*
+	tlsOption, err := mtls.ServerOption()
+	if err != nil {
+		log.Fatalf("Failed to load TLS config: %v", err)
+	}
+
+	// Create gRPC server; archives are uploaded in a single message
+	grpcServer := grpc.NewServer(
+		tlsOption,
+		tracing.ServerOption(),
+		grpc.ChainUnaryInterceptor(logging.UnaryServerInterceptor(), rpcerror.UnaryServerInterceptor(), chaosInjector.Unary(), authInterceptor.Unary()),
+		grpc.MaxRecvMsgSize(maxArchiveBytes),
+	)
+
+	// Register the gRPC service
+	pb.RegisterImportServiceServer(grpcServer, importHandler)
+	supervisor.Register(grpcServer)
+
+	// Enable reflection for debugging tools like grpcurl
+	reflection.Register(grpcServer)
+
+	// Start listening for connections
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%s", grpcPort))
+	if err != nil {
+		log.Fatalf("Failed to listen on port %s: %v", grpcPort, err)
+	}
+
+	log.Printf("Import Service gRPC server listening on port %s", grpcPort)
+
+	// Serve requests
+	if err := supervisor.Serve(grpcServer, listener); err != nil {
+		log.Fatalf("Failed to serve: %v", err)
+	}
+}
+
+// small helpers for optional env vars
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+func getEnvAsInt(key string, defaultValue int) int {
+	if value, err := strconv.Atoi(os.Getenv(key)); err == nil && value > 0 {
+		return value
+	}
+	return defaultValue
+}
+
+func getEnvAsDuration(key string, defaultValue time.Duration) time.Duration {
+	if value, err := time.ParseDuration(os.Getenv(key)); err == nil && value > 0 {
+		return value
+	}
+	return defaultValue
+}
@@ -0,0 +1,276 @@
+package handler
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"import-service/archive"
+	"import-service/interceptor"
+	"import-service/model"
+	pb "import-service/pb"
+	"import-service/repository"
+	"import-service/rpcerror"
+	"import-service/runner"
+)
+
+const (
+	defaultListLimit = 20
+	maxListLimit     = 100
+	maxSourceLength  = 64
+)
+
+type ImportHandler struct {
+	pb.UnimplementedImportServiceServer
+	repo   repository.ImportRepository
+	runner *runner.Runner
+}
+
+func NewImportHandler(repo repository.ImportRepository, runner *runner.Runner) *ImportHandler {
+	return &ImportHandler{
+		repo:   repo,
+		runner: runner,
+	}
+}
+
+// SubmitImport validates an archive and stores it as a job awaiting admin
+// approval. Nothing is imported until an admin approves the job.
+func (h *ImportHandler) SubmitImport(ctx context.Context, req *pb.SubmitImportRequest) (*pb.ImportJob, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	source := strings.ToLower(strings.TrimSpace(req.Source))
+	if source == "" || len(source) > maxSourceLength {
+		return nil, rpcerror.InvalidField("source", fmt.Sprintf("source is required and must be at most %d characters", maxSourceLength))
+	}
+
+	a, err := archive.Parse(req.Archive)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	sum := sha256.Sum256(req.Archive)
+	digest := hex.EncodeToString(sum[:])
+
+	existing, err := h.repo.FindActiveJobByArchive(ctx, digest)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to check for duplicate imports")
+	}
+	if existing != nil {
+		return nil, status.Error(codes.AlreadyExists, fmt.Sprintf("archive was already submitted as job %s", existing.ID))
+	}
+
+	job := &models.Job{
+		ID:            uuid.New(),
+		Source:        source,
+		SubmittedBy:   userID,
+		Status:        models.StatusPendingApproval,
+		ArchiveSHA256: digest,
+		CreatedAt:     time.Now(),
+	}
+
+	totals := map[string]int{
+		models.PhaseUsers:    len(a.Users),
+		models.PhaseProfiles: len(a.Users),
+		models.PhasePosts:    len(a.Posts),
+		models.PhaseFollows:  len(a.Follows),
+	}
+	progress := make([]models.Progress, 0, len(models.Phases))
+	for _, phase := range models.Phases {
+		progress = append(progress, models.Progress{JobID: job.ID, Phase: phase, Total: int32(totals[phase])})
+	}
+
+	if err := h.repo.CreateJob(ctx, job, req.Archive, progress); err != nil {
+		return nil, status.Error(codes.Internal, "failed to create import job")
+	}
+
+	return convertJobToProto(job, progress), nil
+}
+
+// GetImportJob reports a job's status and per-phase progress to its
+// submitter or an admin
+func (h *ImportHandler) GetImportJob(ctx context.Context, req *pb.GetImportJobRequest) (*pb.ImportJob, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	jobID, err := uuid.Parse(req.JobId)
+	if err != nil {
+		return nil, rpcerror.InvalidField("job_id", "invalid job_id")
+	}
+
+	job, err := h.repo.GetJob(ctx, jobID)
+	if err != nil {
+		return nil, mapRepoError(err)
+	}
+
+	if job.SubmittedBy != userID && !interceptor.HasRole(ctx, interceptor.RoleAdmin) {
+		return nil, status.Error(codes.PermissionDenied, "you can only view your own imports")
+	}
+
+	return h.withProgress(ctx, job)
+}
+
+// ApproveImport queues a pending job for the runner. Failed jobs can be
+// approved again, in which case they resume from their saved progress.
+func (h *ImportHandler) ApproveImport(ctx context.Context, req *pb.ApproveImportRequest) (*pb.ImportJob, error) {
+	adminID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	jobID, err := uuid.Parse(req.JobId)
+	if err != nil {
+		return nil, rpcerror.InvalidField("job_id", "invalid job_id")
+	}
+
+	from := []models.JobStatus{models.StatusPendingApproval, models.StatusFailed}
+	job, err := h.repo.Review(ctx, jobID, from, models.StatusApproved, adminID, nil)
+	if err != nil {
+		return nil, mapRepoError(err)
+	}
+
+	h.runner.Wake()
+	return h.withProgress(ctx, job)
+}
+
+func (h *ImportHandler) RejectImport(ctx context.Context, req *pb.RejectImportRequest) (*pb.ImportJob, error) {
+	adminID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	jobID, err := uuid.Parse(req.JobId)
+	if err != nil {
+		return nil, rpcerror.InvalidField("job_id", "invalid job_id")
+	}
+	if strings.TrimSpace(req.Reason) == "" {
+		return nil, rpcerror.InvalidField("reason", "reason is required")
+	}
+
+	from := []models.JobStatus{models.StatusPendingApproval}
+	job, err := h.repo.Review(ctx, jobID, from, models.StatusRejected, adminID, &req.Reason)
+	if err != nil {
+		return nil, mapRepoError(err)
+	}
+
+	return h.withProgress(ctx, job)
+}
+
+func (h *ImportHandler) ListImportJobs(ctx context.Context, req *pb.ListImportJobsRequest) (*pb.ListImportJobsResponse, error) {
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultListLimit
+	}
+	if limit > maxListLimit {
+		limit = maxListLimit
+	}
+
+	var filter *models.JobStatus
+	if req.Status != nil {
+		s := models.JobStatus(strings.TrimPrefix(req.Status.String(), "IMPORT_STATUS_"))
+		filter = &s
+	}
+
+	jobs, err := h.repo.ListJobs(ctx, filter, limit)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to list import jobs")
+	}
+
+	resp := &pb.ListImportJobsResponse{Jobs: make([]*pb.ImportJob, 0, len(jobs))}
+	for i := range jobs {
+		job, err := h.withProgress(ctx, &jobs[i])
+		if err != nil {
+			return nil, err
+		}
+		resp.Jobs = append(resp.Jobs, job)
+	}
+	return resp, nil
+}
+
+func (h *ImportHandler) withProgress(ctx context.Context, job *models.Job) (*pb.ImportJob, error) {
+	progress, err := h.repo.GetProgress(ctx, job.ID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to load import progress")
+	}
+	return convertJobToProto(job, progress), nil
+}
+
+func callerID(ctx context.Context) (uuid.UUID, error) {
+	raw, err := interceptor.GetUserIDFromContext(ctx)
+	if err != nil {
+		return uuid.Nil, status.Error(codes.Unauthenticated, "user not authenticated")
+	}
+	userID, err := uuid.Parse(raw)
+	if err != nil {
+		return uuid.Nil, status.Error(codes.Unauthenticated, "invalid user ID in token")
+	}
+	return userID, nil
+}
+
+func mapRepoError(err error) error {
+	switch {
+	case errors.Is(err, repository.ErrJobNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, repository.ErrInvalidTransition):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+func convertJobToProto(job *models.Job, progress []models.Progress) *pb.ImportJob {
+	out := &pb.ImportJob{
+		Id:          job.ID.String(),
+		Source:      job.Source,
+		SubmittedBy: job.SubmittedBy.String(),
+		Status:      pb.ImportStatus(pb.ImportStatus_value["IMPORT_STATUS_"+string(job.Status)]),
+		ReviewNote:  job.ReviewNote,
+		Error:       job.Error,
+		CreatedAt:   timestamppb.New(job.CreatedAt),
+	}
+
+	if job.ReviewedBy != nil {
+		reviewedBy := job.ReviewedBy.String()
+		out.ReviewedBy = &reviewedBy
+	}
+	if job.ReviewedAt != nil {
+		out.ReviewedAt = timestamppb.New(*job.ReviewedAt)
+	}
+	if job.StartedAt != nil {
+		out.StartedAt = timestamppb.New(*job.StartedAt)
+	}
+	if job.FinishedAt != nil {
+		out.FinishedAt = timestamppb.New(*job.FinishedAt)
+	}
+
+	// Report phases in execution order
+	for _, phase := range models.Phases {
+		for _, p := range progress {
+			if p.Phase != phase {
+				continue
+			}
+			out.Progress = append(out.Progress, &pb.ImportProgress{
+				Phase:     p.Phase,
+				Total:     p.Total,
+				Processed: p.Processed,
+				Created:   p.Created,
+				Skipped:   p.Skipped,
+				Failed:    p.Failed,
+			})
+		}
+	}
+	return out
+}
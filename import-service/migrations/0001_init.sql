@@ -0,0 +1,54 @@
+-- ========================================
+-- Import Service Schema (Standalone)
+-- ========================================
+
+-- ========================================
+-- Import Jobs Table
+-- ========================================
+CREATE TABLE IF NOT EXISTS import_service_jobs (
+    id UUID PRIMARY KEY,
+    source VARCHAR(64) NOT NULL,
+    submitted_by UUID NOT NULL,
+    status VARCHAR(32) NOT NULL DEFAULT 'PENDING_APPROVAL',
+    archive BYTEA NOT NULL,
+    archive_sha256 VARCHAR(64) NOT NULL,
+    reviewed_by UUID,
+    review_note TEXT,
+    error TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    reviewed_at TIMESTAMP WITH TIME ZONE,
+    started_at TIMESTAMP WITH TIME ZONE,
+    finished_at TIMESTAMP WITH TIME ZONE,
+    CONSTRAINT import_jobs_status_valid CHECK (
+        status IN ('PENDING_APPROVAL', 'APPROVED', 'RUNNING', 'COMPLETED', 'FAILED', 'REJECTED')
+    )
+);
+
+CREATE INDEX IF NOT EXISTS idx_import_jobs_status ON import_service_jobs(status, reviewed_at);
+CREATE INDEX IF NOT EXISTS idx_import_jobs_archive ON import_service_jobs(archive_sha256);
+CREATE INDEX IF NOT EXISTS idx_import_jobs_created_at ON import_service_jobs(created_at DESC);
+
+-- ========================================
+-- Per-phase Progress (processed doubles as the resume offset)
+-- ========================================
+CREATE TABLE IF NOT EXISTS import_service_progress (
+    job_id UUID NOT NULL REFERENCES import_service_jobs(id) ON DELETE CASCADE,
+    phase VARCHAR(16) NOT NULL,
+    total INTEGER NOT NULL DEFAULT 0,
+    processed INTEGER NOT NULL DEFAULT 0,
+    created INTEGER NOT NULL DEFAULT 0,
+    skipped INTEGER NOT NULL DEFAULT 0,
+    failed INTEGER NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (job_id, phase)
+);
+
+-- ========================================
+-- Archive users to the local accounts they were imported as
+-- ========================================
+CREATE TABLE IF NOT EXISTS import_service_user_map (
+    job_id UUID NOT NULL REFERENCES import_service_jobs(id) ON DELETE CASCADE,
+    external_id VARCHAR(255) NOT NULL,
+    user_id UUID NOT NULL,
+    PRIMARY KEY (job_id, external_id)
+);
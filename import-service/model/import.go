@@ -0,0 +1,61 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type JobStatus string
+
+const (
+	StatusPendingApproval JobStatus = "PENDING_APPROVAL"
+	StatusApproved        JobStatus = "APPROVED"
+	StatusRunning         JobStatus = "RUNNING"
+	StatusCompleted       JobStatus = "COMPLETED"
+	StatusFailed          JobStatus = "FAILED"
+	StatusRejected        JobStatus = "REJECTED"
+)
+
+// Import phases, in the order the runner executes them
+const (
+	PhaseUsers    = "users"
+	PhaseProfiles = "profiles"
+	PhasePosts    = "posts"
+	PhaseFollows  = "follows"
+)
+
+var Phases = []string{PhaseUsers, PhaseProfiles, PhasePosts, PhaseFollows}
+
+type Job struct {
+	ID            uuid.UUID  `json:"id" db:"id"`
+	Source        string     `json:"source" db:"source"`
+	SubmittedBy   uuid.UUID  `json:"submitted_by" db:"submitted_by"`
+	Status        JobStatus  `json:"status" db:"status"`
+	ArchiveSHA256 string     `json:"archive_sha256" db:"archive_sha256"`
+	ReviewedBy    *uuid.UUID `json:"reviewed_by,omitempty" db:"reviewed_by"`
+	ReviewNote    *string    `json:"review_note,omitempty" db:"review_note"`
+	Error         *string    `json:"error,omitempty" db:"error"`
+	CreatedAt     time.Time  `json:"created_at" db:"created_at"`
+	ReviewedAt    *time.Time `json:"reviewed_at,omitempty" db:"reviewed_at"`
+	StartedAt     *time.Time `json:"started_at,omitempty" db:"started_at"`
+	FinishedAt    *time.Time `json:"finished_at,omitempty" db:"finished_at"`
+}
+
+// Progress holds the counters of one phase of a job. Processed is also the
+// offset the runner resumes from after a restart.
+type Progress struct {
+	JobID     uuid.UUID `json:"job_id" db:"job_id"`
+	Phase     string    `json:"phase" db:"phase"`
+	Total     int32     `json:"total" db:"total"`
+	Processed int32     `json:"processed" db:"processed"`
+	Created   int32     `json:"created" db:"created"`
+	Skipped   int32     `json:"skipped" db:"skipped"`
+	Failed    int32     `json:"failed" db:"failed"`
+}
+
+// UserMapping records which local account an archive user was imported as
+type UserMapping struct {
+	ExternalID string    `json:"external_id" db:"external_id"`
+	UserID     uuid.UUID `json:"user_id" db:"user_id"`
+}
@@ -0,0 +1,736 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v6.32.1
+// source: proto/import.proto
+
+package __
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ImportStatus int32
+
+const (
+	ImportStatus_IMPORT_STATUS_UNSPECIFIED      ImportStatus = 0
+	ImportStatus_IMPORT_STATUS_PENDING_APPROVAL ImportStatus = 1
+	ImportStatus_IMPORT_STATUS_APPROVED         ImportStatus = 2 // Queued for the runner
+	ImportStatus_IMPORT_STATUS_RUNNING          ImportStatus = 3
+	ImportStatus_IMPORT_STATUS_COMPLETED        ImportStatus = 4
+	ImportStatus_IMPORT_STATUS_FAILED           ImportStatus = 5
+	ImportStatus_IMPORT_STATUS_REJECTED         ImportStatus = 6
+)
+
+// Enum value maps for ImportStatus.
+var (
+	ImportStatus_name = map[int32]string{
+		0: "IMPORT_STATUS_UNSPECIFIED",
+		1: "IMPORT_STATUS_PENDING_APPROVAL",
+		2: "IMPORT_STATUS_APPROVED",
+		3: "IMPORT_STATUS_RUNNING",
+		4: "IMPORT_STATUS_COMPLETED",
+		5: "IMPORT_STATUS_FAILED",
+		6: "IMPORT_STATUS_REJECTED",
+	}
+	ImportStatus_value = map[string]int32{
+		"IMPORT_STATUS_UNSPECIFIED":      0,
+		"IMPORT_STATUS_PENDING_APPROVAL": 1,
+		"IMPORT_STATUS_APPROVED":         2,
+		"IMPORT_STATUS_RUNNING":          3,
+		"IMPORT_STATUS_COMPLETED":        4,
+		"IMPORT_STATUS_FAILED":           5,
+		"IMPORT_STATUS_REJECTED":         6,
+	}
+)
+
+func (x ImportStatus) Enum() *ImportStatus {
+	p := new(ImportStatus)
+	*p = x
+	return p
+}
+
+func (x ImportStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ImportStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_import_proto_enumTypes[0].Descriptor()
+}
+
+func (ImportStatus) Type() protoreflect.EnumType {
+	return &file_proto_import_proto_enumTypes[0]
+}
+
+func (x ImportStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ImportStatus.Descriptor instead.
+func (ImportStatus) EnumDescriptor() ([]byte, []int) {
+	return file_proto_import_proto_rawDescGZIP(), []int{0}
+}
+
+type SubmitImportRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Source        string                 `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`   // Platform the archive was exported from, e.g. "mastodon"
+	Archive       []byte                 `protobuf:"bytes,2,opt,name=archive,proto3" json:"archive,omitempty"` // JSON archive of users, posts and follows
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitImportRequest) Reset() {
+	*x = SubmitImportRequest{}
+	mi := &file_proto_import_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitImportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitImportRequest) ProtoMessage() {}
+
+func (x *SubmitImportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_import_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitImportRequest.ProtoReflect.Descriptor instead.
+func (*SubmitImportRequest) Descriptor() ([]byte, []int) {
+	return file_proto_import_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SubmitImportRequest) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *SubmitImportRequest) GetArchive() []byte {
+	if x != nil {
+		return x.Archive
+	}
+	return nil
+}
+
+type GetImportJobRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetImportJobRequest) Reset() {
+	*x = GetImportJobRequest{}
+	mi := &file_proto_import_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetImportJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetImportJobRequest) ProtoMessage() {}
+
+func (x *GetImportJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_import_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetImportJobRequest.ProtoReflect.Descriptor instead.
+func (*GetImportJobRequest) Descriptor() ([]byte, []int) {
+	return file_proto_import_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetImportJobRequest) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+type ApproveImportRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApproveImportRequest) Reset() {
+	*x = ApproveImportRequest{}
+	mi := &file_proto_import_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApproveImportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApproveImportRequest) ProtoMessage() {}
+
+func (x *ApproveImportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_import_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApproveImportRequest.ProtoReflect.Descriptor instead.
+func (*ApproveImportRequest) Descriptor() ([]byte, []int) {
+	return file_proto_import_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ApproveImportRequest) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+type RejectImportRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RejectImportRequest) Reset() {
+	*x = RejectImportRequest{}
+	mi := &file_proto_import_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RejectImportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RejectImportRequest) ProtoMessage() {}
+
+func (x *RejectImportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_import_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RejectImportRequest.ProtoReflect.Descriptor instead.
+func (*RejectImportRequest) Descriptor() ([]byte, []int) {
+	return file_proto_import_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RejectImportRequest) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *RejectImportRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type ListImportJobsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        *ImportStatus          `protobuf:"varint,1,opt,name=status,proto3,enum=imports.ImportStatus,oneof" json:"status,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListImportJobsRequest) Reset() {
+	*x = ListImportJobsRequest{}
+	mi := &file_proto_import_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListImportJobsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListImportJobsRequest) ProtoMessage() {}
+
+func (x *ListImportJobsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_import_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListImportJobsRequest.ProtoReflect.Descriptor instead.
+func (*ListImportJobsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_import_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListImportJobsRequest) GetStatus() ImportStatus {
+	if x != nil && x.Status != nil {
+		return *x.Status
+	}
+	return ImportStatus_IMPORT_STATUS_UNSPECIFIED
+}
+
+func (x *ListImportJobsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListImportJobsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Jobs          []*ImportJob           `protobuf:"bytes,1,rep,name=jobs,proto3" json:"jobs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListImportJobsResponse) Reset() {
+	*x = ListImportJobsResponse{}
+	mi := &file_proto_import_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListImportJobsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListImportJobsResponse) ProtoMessage() {}
+
+func (x *ListImportJobsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_import_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListImportJobsResponse.ProtoReflect.Descriptor instead.
+func (*ListImportJobsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_import_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListImportJobsResponse) GetJobs() []*ImportJob {
+	if x != nil {
+		return x.Jobs
+	}
+	return nil
+}
+
+type ImportProgress struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Phase         string                 `protobuf:"bytes,1,opt,name=phase,proto3" json:"phase,omitempty"` // users, profiles, posts or follows
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Processed     int32                  `protobuf:"varint,3,opt,name=processed,proto3" json:"processed,omitempty"`
+	Created       int32                  `protobuf:"varint,4,opt,name=created,proto3" json:"created,omitempty"`
+	Skipped       int32                  `protobuf:"varint,5,opt,name=skipped,proto3" json:"skipped,omitempty"` // Already present, e.g. from an earlier import
+	Failed        int32                  `protobuf:"varint,6,opt,name=failed,proto3" json:"failed,omitempty"`   // Rejected records, e.g. a username conflict
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportProgress) Reset() {
+	*x = ImportProgress{}
+	mi := &file_proto_import_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportProgress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportProgress) ProtoMessage() {}
+
+func (x *ImportProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_import_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportProgress.ProtoReflect.Descriptor instead.
+func (*ImportProgress) Descriptor() ([]byte, []int) {
+	return file_proto_import_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ImportProgress) GetPhase() string {
+	if x != nil {
+		return x.Phase
+	}
+	return ""
+}
+
+func (x *ImportProgress) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ImportProgress) GetProcessed() int32 {
+	if x != nil {
+		return x.Processed
+	}
+	return 0
+}
+
+func (x *ImportProgress) GetCreated() int32 {
+	if x != nil {
+		return x.Created
+	}
+	return 0
+}
+
+func (x *ImportProgress) GetSkipped() int32 {
+	if x != nil {
+		return x.Skipped
+	}
+	return 0
+}
+
+func (x *ImportProgress) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+type ImportJob struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Source        string                 `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`
+	SubmittedBy   string                 `protobuf:"bytes,3,opt,name=submitted_by,json=submittedBy,proto3" json:"submitted_by,omitempty"`
+	Status        ImportStatus           `protobuf:"varint,4,opt,name=status,proto3,enum=imports.ImportStatus" json:"status,omitempty"`
+	ReviewedBy    *string                `protobuf:"bytes,5,opt,name=reviewed_by,json=reviewedBy,proto3,oneof" json:"reviewed_by,omitempty"`
+	ReviewNote    *string                `protobuf:"bytes,6,opt,name=review_note,json=reviewNote,proto3,oneof" json:"review_note,omitempty"`
+	Error         *string                `protobuf:"bytes,7,opt,name=error,proto3,oneof" json:"error,omitempty"`
+	Progress      []*ImportProgress      `protobuf:"bytes,8,rep,name=progress,proto3" json:"progress,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ReviewedAt    *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=reviewed_at,json=reviewedAt,proto3,oneof" json:"reviewed_at,omitempty"`
+	StartedAt     *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=started_at,json=startedAt,proto3,oneof" json:"started_at,omitempty"`
+	FinishedAt    *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=finished_at,json=finishedAt,proto3,oneof" json:"finished_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportJob) Reset() {
+	*x = ImportJob{}
+	mi := &file_proto_import_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportJob) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportJob) ProtoMessage() {}
+
+func (x *ImportJob) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_import_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportJob.ProtoReflect.Descriptor instead.
+func (*ImportJob) Descriptor() ([]byte, []int) {
+	return file_proto_import_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ImportJob) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ImportJob) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *ImportJob) GetSubmittedBy() string {
+	if x != nil {
+		return x.SubmittedBy
+	}
+	return ""
+}
+
+func (x *ImportJob) GetStatus() ImportStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ImportStatus_IMPORT_STATUS_UNSPECIFIED
+}
+
+func (x *ImportJob) GetReviewedBy() string {
+	if x != nil && x.ReviewedBy != nil {
+		return *x.ReviewedBy
+	}
+	return ""
+}
+
+func (x *ImportJob) GetReviewNote() string {
+	if x != nil && x.ReviewNote != nil {
+		return *x.ReviewNote
+	}
+	return ""
+}
+
+func (x *ImportJob) GetError() string {
+	if x != nil && x.Error != nil {
+		return *x.Error
+	}
+	return ""
+}
+
+func (x *ImportJob) GetProgress() []*ImportProgress {
+	if x != nil {
+		return x.Progress
+	}
+	return nil
+}
+
+func (x *ImportJob) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *ImportJob) GetReviewedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReviewedAt
+	}
+	return nil
+}
+
+func (x *ImportJob) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *ImportJob) GetFinishedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return nil
+}
+
+var File_proto_import_proto protoreflect.FileDescriptor
+
+const file_proto_import_proto_rawDesc = "" +
+	"\n" +
+	"\x12proto/import.proto\x12\aimports\x1a\x1fgoogle/protobuf/timestamp.proto\"G\n" +
+	"\x13SubmitImportRequest\x12\x16\n" +
+	"\x06source\x18\x01 \x01(\tR\x06source\x12\x18\n" +
+	"\aarchive\x18\x02 \x01(\fR\aarchive\",\n" +
+	"\x13GetImportJobRequest\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\"-\n" +
+	"\x14ApproveImportRequest\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\"D\n" +
+	"\x13RejectImportRequest\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"l\n" +
+	"\x15ListImportJobsRequest\x122\n" +
+	"\x06status\x18\x01 \x01(\x0e2\x15.imports.ImportStatusH\x00R\x06status\x88\x01\x01\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limitB\t\n" +
+	"\a_status\"@\n" +
+	"\x16ListImportJobsResponse\x12&\n" +
+	"\x04jobs\x18\x01 \x03(\v2\x12.imports.ImportJobR\x04jobs\"\xa6\x01\n" +
+	"\x0eImportProgress\x12\x14\n" +
+	"\x05phase\x18\x01 \x01(\tR\x05phase\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x1c\n" +
+	"\tprocessed\x18\x03 \x01(\x05R\tprocessed\x12\x18\n" +
+	"\acreated\x18\x04 \x01(\x05R\acreated\x12\x18\n" +
+	"\askipped\x18\x05 \x01(\x05R\askipped\x12\x16\n" +
+	"\x06failed\x18\x06 \x01(\x05R\x06failed\"\xf9\x04\n" +
+	"\tImportJob\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06source\x18\x02 \x01(\tR\x06source\x12!\n" +
+	"\fsubmitted_by\x18\x03 \x01(\tR\vsubmittedBy\x12-\n" +
+	"\x06status\x18\x04 \x01(\x0e2\x15.imports.ImportStatusR\x06status\x12$\n" +
+	"\vreviewed_by\x18\x05 \x01(\tH\x00R\n" +
+	"reviewedBy\x88\x01\x01\x12$\n" +
+	"\vreview_note\x18\x06 \x01(\tH\x01R\n" +
+	"reviewNote\x88\x01\x01\x12\x19\n" +
+	"\x05error\x18\a \x01(\tH\x02R\x05error\x88\x01\x01\x123\n" +
+	"\bprogress\x18\b \x03(\v2\x17.imports.ImportProgressR\bprogress\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12@\n" +
+	"\vreviewed_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampH\x03R\n" +
+	"reviewedAt\x88\x01\x01\x12>\n" +
+	"\n" +
+	"started_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampH\x04R\tstartedAt\x88\x01\x01\x12@\n" +
+	"\vfinished_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampH\x05R\n" +
+	"finishedAt\x88\x01\x01B\x0e\n" +
+	"\f_reviewed_byB\x0e\n" +
+	"\f_review_noteB\b\n" +
+	"\x06_errorB\x0e\n" +
+	"\f_reviewed_atB\r\n" +
+	"\v_started_atB\x0e\n" +
+	"\f_finished_at*\xdb\x01\n" +
+	"\fImportStatus\x12\x1d\n" +
+	"\x19IMPORT_STATUS_UNSPECIFIED\x10\x00\x12\"\n" +
+	"\x1eIMPORT_STATUS_PENDING_APPROVAL\x10\x01\x12\x1a\n" +
+	"\x16IMPORT_STATUS_APPROVED\x10\x02\x12\x19\n" +
+	"\x15IMPORT_STATUS_RUNNING\x10\x03\x12\x1b\n" +
+	"\x17IMPORT_STATUS_COMPLETED\x10\x04\x12\x18\n" +
+	"\x14IMPORT_STATUS_FAILED\x10\x05\x12\x1a\n" +
+	"\x16IMPORT_STATUS_REJECTED\x10\x062\xec\x02\n" +
+	"\rImportService\x12@\n" +
+	"\fSubmitImport\x12\x1c.imports.SubmitImportRequest\x1a\x12.imports.ImportJob\x12@\n" +
+	"\fGetImportJob\x12\x1c.imports.GetImportJobRequest\x1a\x12.imports.ImportJob\x12B\n" +
+	"\rApproveImport\x12\x1d.imports.ApproveImportRequest\x1a\x12.imports.ImportJob\x12@\n" +
+	"\fRejectImport\x12\x1c.imports.RejectImportRequest\x1a\x12.imports.ImportJob\x12Q\n" +
+	"\x0eListImportJobs\x12\x1e.imports.ListImportJobsRequest\x1a\x1f.imports.ListImportJobsResponseB\x04Z\x02./b\x06proto3"
+
+var (
+	file_proto_import_proto_rawDescOnce sync.Once
+	file_proto_import_proto_rawDescData []byte
+)
+
+func file_proto_import_proto_rawDescGZIP() []byte {
+	file_proto_import_proto_rawDescOnce.Do(func() {
+		file_proto_import_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_import_proto_rawDesc), len(file_proto_import_proto_rawDesc)))
+	})
+	return file_proto_import_proto_rawDescData
+}
+
+var file_proto_import_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_proto_import_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_proto_import_proto_goTypes = []any{
+	(ImportStatus)(0),              // 0: imports.ImportStatus
+	(*SubmitImportRequest)(nil),    // 1: imports.SubmitImportRequest
+	(*GetImportJobRequest)(nil),    // 2: imports.GetImportJobRequest
+	(*ApproveImportRequest)(nil),   // 3: imports.ApproveImportRequest
+	(*RejectImportRequest)(nil),    // 4: imports.RejectImportRequest
+	(*ListImportJobsRequest)(nil),  // 5: imports.ListImportJobsRequest
+	(*ListImportJobsResponse)(nil), // 6: imports.ListImportJobsResponse
+	(*ImportProgress)(nil),         // 7: imports.ImportProgress
+	(*ImportJob)(nil),              // 8: imports.ImportJob
+	(*timestamppb.Timestamp)(nil),  // 9: google.protobuf.Timestamp
+}
+var file_proto_import_proto_depIdxs = []int32{
+	0,  // 0: imports.ListImportJobsRequest.status:type_name -> imports.ImportStatus
+	8,  // 1: imports.ListImportJobsResponse.jobs:type_name -> imports.ImportJob
+	0,  // 2: imports.ImportJob.status:type_name -> imports.ImportStatus
+	7,  // 3: imports.ImportJob.progress:type_name -> imports.ImportProgress
+	9,  // 4: imports.ImportJob.created_at:type_name -> google.protobuf.Timestamp
+	9,  // 5: imports.ImportJob.reviewed_at:type_name -> google.protobuf.Timestamp
+	9,  // 6: imports.ImportJob.started_at:type_name -> google.protobuf.Timestamp
+	9,  // 7: imports.ImportJob.finished_at:type_name -> google.protobuf.Timestamp
+	1,  // 8: imports.ImportService.SubmitImport:input_type -> imports.SubmitImportRequest
+	2,  // 9: imports.ImportService.GetImportJob:input_type -> imports.GetImportJobRequest
+	3,  // 10: imports.ImportService.ApproveImport:input_type -> imports.ApproveImportRequest
+	4,  // 11: imports.ImportService.RejectImport:input_type -> imports.RejectImportRequest
+	5,  // 12: imports.ImportService.ListImportJobs:input_type -> imports.ListImportJobsRequest
+	8,  // 13: imports.ImportService.SubmitImport:output_type -> imports.ImportJob
+	8,  // 14: imports.ImportService.GetImportJob:output_type -> imports.ImportJob
+	8,  // 15: imports.ImportService.ApproveImport:output_type -> imports.ImportJob
+	8,  // 16: imports.ImportService.RejectImport:output_type -> imports.ImportJob
+	6,  // 17: imports.ImportService.ListImportJobs:output_type -> imports.ListImportJobsResponse
+	13, // [13:18] is the sub-list for method output_type
+	8,  // [8:13] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
+}
+
+func init() { file_proto_import_proto_init() }
+func file_proto_import_proto_init() {
+	if File_proto_import_proto != nil {
+		return
+	}
+	file_proto_import_proto_msgTypes[4].OneofWrappers = []any{}
+	file_proto_import_proto_msgTypes[7].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_import_proto_rawDesc), len(file_proto_import_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   8,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_import_proto_goTypes,
+		DependencyIndexes: file_proto_import_proto_depIdxs,
+		EnumInfos:         file_proto_import_proto_enumTypes,
+		MessageInfos:      file_proto_import_proto_msgTypes,
+	}.Build()
+	File_proto_import_proto = out.File
+	file_proto_import_proto_goTypes = nil
+	file_proto_import_proto_depIdxs = nil
+}
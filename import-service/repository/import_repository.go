@@ -0,0 +1,261 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"import-service/db"
+	"import-service/model"
+)
+
+var (
+	ErrJobNotFound       = errors.New("import job not found")
+	ErrInvalidTransition = errors.New("import job is not in a state that allows this change")
+)
+
+type ImportRepository interface {
+	// WithTx runs fn in a single transaction; repository calls made with the
+	// context passed to fn join it
+	WithTx(ctx context.Context, fn func(ctx context.Context) error) error
+	CreateJob(ctx context.Context, job *models.Job, archive []byte, progress []models.Progress) error
+	GetJob(ctx context.Context, jobID uuid.UUID) (*models.Job, error)
+	FindActiveJobByArchive(ctx context.Context, archiveSHA256 string) (*models.Job, error)
+	ListJobs(ctx context.Context, status *models.JobStatus, limit int32) ([]models.Job, error)
+	GetArchive(ctx context.Context, jobID uuid.UUID) ([]byte, error)
+	Review(ctx context.Context, jobID uuid.UUID, from []models.JobStatus, to models.JobStatus, reviewerID uuid.UUID, note *string) (*models.Job, error)
+	ClaimNextJob(ctx context.Context) (*models.Job, error)
+	FinishJob(ctx context.Context, jobID uuid.UUID, status models.JobStatus, errMsg *string) error
+	GetProgress(ctx context.Context, jobID uuid.UUID) ([]models.Progress, error)
+	SaveProgress(ctx context.Context, progress *models.Progress) error
+	SaveUserMappings(ctx context.Context, jobID uuid.UUID, mappings []models.UserMapping) error
+	GetUserMappings(ctx context.Context, jobID uuid.UUID) (map[string]uuid.UUID, error)
+}
+
+type importRepository struct {
+	db *database.DB
+}
+
+func NewImportRepository(db *database.DB) ImportRepository {
+	return &importRepository{db: db}
+}
+
+const jobColumns = `id, source, submitted_by, status, archive_sha256, reviewed_by, review_note,
+	error, created_at, reviewed_at, started_at, finished_at`
+
+func (r *importRepository) WithTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return r.db.WithTx(ctx, fn)
+}
+
+// CreateJob stores a submitted job together with its archive and the
+// per-phase totals used for progress reporting
+func (r *importRepository) CreateJob(ctx context.Context, job *models.Job, archive []byte, progress []models.Progress) error {
+	return r.db.WithTx(ctx, func(ctx context.Context) error {
+		query := `
+			INSERT INTO import_service_jobs (id, source, submitted_by, status, archive, archive_sha256, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
+		`
+		_, err := r.db.Conn(ctx).ExecContext(ctx, query,
+			job.ID, job.Source, job.SubmittedBy, job.Status, archive, job.ArchiveSHA256, job.CreatedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create import job: %w", err)
+		}
+
+		for i := range progress {
+			if err := r.SaveProgress(ctx, &progress[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *importRepository) GetJob(ctx context.Context, jobID uuid.UUID) (*models.Job, error) {
+	var job models.Job
+	query := `SELECT ` + jobColumns + ` FROM import_service_jobs WHERE id = $1`
+
+	if err := r.db.Conn(ctx).GetContext(ctx, &job, query, jobID); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrJobNotFound
+		}
+		return nil, fmt.Errorf("failed to get import job: %w", err)
+	}
+	return &job, nil
+}
+
+// FindActiveJobByArchive returns a job for the same archive that has not
+// been rejected or failed, or nil when there is none
+func (r *importRepository) FindActiveJobByArchive(ctx context.Context, archiveSHA256 string) (*models.Job, error) {
+	var job models.Job
+	query := `
+		SELECT ` + jobColumns + `
+		FROM import_service_jobs
+		WHERE archive_sha256 = $1 AND status NOT IN ($2, $3)
+		ORDER BY created_at DESC
+		LIMIT 1
+	`
+
+	err := r.db.Conn(ctx).GetContext(ctx, &job, query, archiveSHA256, models.StatusRejected, models.StatusFailed)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to look up import job: %w", err)
+	}
+	return &job, nil
+}
+
+func (r *importRepository) ListJobs(ctx context.Context, status *models.JobStatus, limit int32) ([]models.Job, error) {
+	query := `SELECT ` + jobColumns + ` FROM import_service_jobs`
+	args := []interface{}{}
+	if status != nil {
+		query += ` WHERE status = $1`
+		args = append(args, *status)
+	}
+	query += fmt.Sprintf(" ORDER BY created_at DESC LIMIT $%d", len(args)+1)
+	args = append(args, limit)
+
+	jobs := []models.Job{}
+	if err := r.db.ReadDB().SelectContext(ctx, &jobs, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to list import jobs: %w", err)
+	}
+	return jobs, nil
+}
+
+func (r *importRepository) GetArchive(ctx context.Context, jobID uuid.UUID) ([]byte, error) {
+	var archive []byte
+	query := `SELECT archive FROM import_service_jobs WHERE id = $1`
+
+	if err := r.db.Conn(ctx).QueryRowContext(ctx, query, jobID).Scan(&archive); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrJobNotFound
+		}
+		return nil, fmt.Errorf("failed to load import archive: %w", err)
+	}
+	return archive, nil
+}
+
+// Review moves a job from one of the given states to the target state and
+// records who made the decision. It returns ErrInvalidTransition when the job
+// is not currently in one of the from states.
+func (r *importRepository) Review(ctx context.Context, jobID uuid.UUID, from []models.JobStatus, to models.JobStatus, reviewerID uuid.UUID, note *string) (*models.Job, error) {
+	states := make([]string, len(from))
+	for i, s := range from {
+		states[i] = string(s)
+	}
+
+	var job models.Job
+	query := `
+		UPDATE import_service_jobs
+		SET status = $2, reviewed_by = $3, review_note = $4, reviewed_at = NOW(), error = NULL, finished_at = NULL
+		WHERE id = $1 AND status = ANY($5)
+		RETURNING ` + jobColumns
+
+	err := r.db.Conn(ctx).GetContext(ctx, &job, query, jobID, to, reviewerID, note, pq.Array(states))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			if _, err := r.GetJob(ctx, jobID); err != nil {
+				return nil, err
+			}
+			return nil, ErrInvalidTransition
+		}
+		return nil, fmt.Errorf("failed to review import job: %w", err)
+	}
+	return &job, nil
+}
+
+// ClaimNextJob marks the oldest approved job as running and returns it. Jobs
+// left running by a previous process are returned first so they resume.
+func (r *importRepository) ClaimNextJob(ctx context.Context) (*models.Job, error) {
+	var job models.Job
+	query := `
+		UPDATE import_service_jobs
+		SET status = $1, started_at = COALESCE(started_at, NOW())
+		WHERE id = (
+			SELECT id FROM import_service_jobs
+			WHERE status IN ($1, $2)
+			ORDER BY status = $1 DESC, reviewed_at
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + jobColumns
+
+	err := r.db.Conn(ctx).GetContext(ctx, &job, query, models.StatusRunning, models.StatusApproved)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to claim import job: %w", err)
+	}
+	return &job, nil
+}
+
+func (r *importRepository) FinishJob(ctx context.Context, jobID uuid.UUID, status models.JobStatus, errMsg *string) error {
+	query := `UPDATE import_service_jobs SET status = $2, error = $3, finished_at = NOW() WHERE id = $1`
+	if _, err := r.db.Conn(ctx).ExecContext(ctx, query, jobID, status, errMsg); err != nil {
+		return fmt.Errorf("failed to finish import job: %w", err)
+	}
+	return nil
+}
+
+func (r *importRepository) GetProgress(ctx context.Context, jobID uuid.UUID) ([]models.Progress, error) {
+	query := `
+		SELECT job_id, phase, total, processed, created, skipped, failed
+		FROM import_service_progress
+		WHERE job_id = $1
+	`
+
+	progress := []models.Progress{}
+	if err := r.db.Conn(ctx).SelectContext(ctx, &progress, query, jobID); err != nil {
+		return nil, fmt.Errorf("failed to get import progress: %w", err)
+	}
+	return progress, nil
+}
+
+func (r *importRepository) SaveProgress(ctx context.Context, p *models.Progress) error {
+	query := `
+		INSERT INTO import_service_progress (job_id, phase, total, processed, created, skipped, failed, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NOW())
+		ON CONFLICT (job_id, phase) DO UPDATE
+		SET total = EXCLUDED.total, processed = EXCLUDED.processed, created = EXCLUDED.created,
+		    skipped = EXCLUDED.skipped, failed = EXCLUDED.failed, updated_at = NOW()
+	`
+	_, err := r.db.Conn(ctx).ExecContext(ctx, query, p.JobID, p.Phase, p.Total, p.Processed, p.Created, p.Skipped, p.Failed)
+	if err != nil {
+		return fmt.Errorf("failed to save import progress: %w", err)
+	}
+	return nil
+}
+
+func (r *importRepository) SaveUserMappings(ctx context.Context, jobID uuid.UUID, mappings []models.UserMapping) error {
+	query := `
+		INSERT INTO import_service_user_map (job_id, external_id, user_id)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (job_id, external_id) DO UPDATE SET user_id = EXCLUDED.user_id
+	`
+	for _, m := range mappings {
+		if _, err := r.db.Conn(ctx).ExecContext(ctx, query, jobID, m.ExternalID, m.UserID); err != nil {
+			return fmt.Errorf("failed to save user mapping: %w", err)
+		}
+	}
+	return nil
+}
+
+func (r *importRepository) GetUserMappings(ctx context.Context, jobID uuid.UUID) (map[string]uuid.UUID, error) {
+	query := `SELECT external_id, user_id FROM import_service_user_map WHERE job_id = $1`
+
+	var mappings []models.UserMapping
+	if err := r.db.Conn(ctx).SelectContext(ctx, &mappings, query, jobID); err != nil {
+		return nil, fmt.Errorf("failed to get user mappings: %w", err)
+	}
+
+	result := make(map[string]uuid.UUID, len(mappings))
+	for _, m := range mappings {
+		result[m.ExternalID] = m.UserID
+	}
+	return result, nil
+}
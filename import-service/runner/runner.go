@@ -0,0 +1,438 @@
+// Package runner executes approved import jobs by feeding archive records to
+// the owning services in batches. Progress is saved after every batch so a job
+// interrupted by a restart resumes where it stopped; a batch that was sent but
+// not recorded is simply sent again and deduplicated downstream.
+package runner
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	authpb "auth-service/pb"
+	followpb "follow-service/pb"
+	postpb "post-service/pb"
+	userpb "user-service/pb"
+
+	"import-service/archive"
+	"import-service/interceptor"
+	"import-service/model"
+	"import-service/repository"
+)
+
+const (
+	tokenExpiry  = 10 * time.Minute
+	callAttempts = 3
+)
+
+// Clients are the services records are imported into
+type Clients struct {
+	Auth   authpb.AuthServiceClient
+	User   userpb.UserServiceClient
+	Post   postpb.PostServiceClient
+	Follow followpb.FollowServiceClient
+}
+
+type Runner struct {
+	repo         repository.ImportRepository
+	clients      Clients
+	keys         interceptor.Keys
+	batchSize    int
+	pollInterval time.Duration
+	wake         chan struct{}
+}
+
+func New(repo repository.ImportRepository, clients Clients, keys interceptor.Keys, batchSize int, pollInterval time.Duration) *Runner {
+	return &Runner{
+		repo:         repo,
+		clients:      clients,
+		keys:         keys,
+		batchSize:    batchSize,
+		pollInterval: pollInterval,
+		wake:         make(chan struct{}, 1),
+	}
+}
+
+// Wake asks the runner to look for approved jobs without waiting for the
+// next poll
+func (r *Runner) Wake() {
+	select {
+	case r.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run processes approved jobs one at a time until ctx is cancelled
+func (r *Runner) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		r.drain(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-r.wake:
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *Runner) drain(ctx context.Context) {
+	for ctx.Err() == nil {
+		job, err := r.repo.ClaimNextJob(ctx)
+		if err != nil {
+			log.Printf("Failed to claim import job: %v", err)
+			return
+		}
+		if job == nil {
+			return
+		}
+		r.execute(ctx, job)
+	}
+}
+
+func (r *Runner) execute(ctx context.Context, job *models.Job) {
+	log.Printf("Running import job %s (source %s)", job.ID, job.Source)
+
+	err := r.runJob(ctx, job)
+	if ctx.Err() != nil {
+		// Left RUNNING so the next process resumes it
+		log.Printf("Import job %s interrupted, it will resume on restart", job.ID)
+		return
+	}
+
+	finalStatus := models.StatusCompleted
+	var errMsg *string
+	if err != nil {
+		finalStatus = models.StatusFailed
+		msg := err.Error()
+		errMsg = &msg
+		log.Printf("Import job %s failed: %v", job.ID, err)
+	} else {
+		log.Printf("Import job %s completed", job.ID)
+	}
+
+	if err := r.repo.FinishJob(ctx, job.ID, finalStatus, errMsg); err != nil {
+		log.Printf("Failed to record outcome of import job %s: %v", job.ID, err)
+	}
+}
+
+func (r *Runner) runJob(ctx context.Context, job *models.Job) error {
+	if job.ReviewedBy == nil {
+		return fmt.Errorf("job has no approving admin")
+	}
+
+	data, err := r.repo.GetArchive(ctx, job.ID)
+	if err != nil {
+		return err
+	}
+	a, err := archive.Parse(data)
+	if err != nil {
+		return err
+	}
+
+	progress, err := r.repo.GetProgress(ctx, job.ID)
+	if err != nil {
+		return err
+	}
+	byPhase := make(map[string]*models.Progress, len(progress))
+	for i := range progress {
+		byPhase[progress[i].Phase] = &progress[i]
+	}
+
+	mappings, err := r.repo.GetUserMappings(ctx, job.ID)
+	if err != nil {
+		return err
+	}
+
+	j := &jobRun{Runner: r, job: job, archive: a, adminID: *job.ReviewedBy, users: mappings}
+	phases := map[string]func(ctx context.Context, start, end int) (*batchResult, error){
+		models.PhaseUsers:    j.importUsers,
+		models.PhaseProfiles: j.importProfiles,
+		models.PhasePosts:    j.importPosts,
+		models.PhaseFollows:  j.importFollows,
+	}
+
+	for _, phase := range models.Phases {
+		p, ok := byPhase[phase]
+		if !ok {
+			return fmt.Errorf("missing progress for phase %s", phase)
+		}
+		if err := j.runPhase(ctx, p, phases[phase]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type batchResult struct {
+	created  int32
+	skipped  int32
+	failed   int32
+	mappings []models.UserMapping
+}
+
+// jobRun holds the state of one job execution
+type jobRun struct {
+	*Runner
+	job     *models.Job
+	archive *archive.Archive
+	adminID uuid.UUID
+
+	// users maps archive user IDs to the local accounts they were imported as
+	users map[string]uuid.UUID
+}
+
+// runPhase imports the remaining records of a phase batch by batch, saving
+// progress and user mappings after each batch
+func (j *jobRun) runPhase(ctx context.Context, p *models.Progress, importBatch func(ctx context.Context, start, end int) (*batchResult, error)) error {
+	for p.Processed < p.Total {
+		start := int(p.Processed)
+		end := min(start+j.batchSize, int(p.Total))
+
+		res, err := importBatch(ctx, start, end)
+		if err != nil {
+			return fmt.Errorf("%s %d-%d: %w", p.Phase, start, end, err)
+		}
+
+		p.Processed = int32(end)
+		p.Created += res.created
+		p.Skipped += res.skipped
+		p.Failed += res.failed
+
+		err = j.repo.WithTx(ctx, func(ctx context.Context) error {
+			if err := j.repo.SaveUserMappings(ctx, j.job.ID, res.mappings); err != nil {
+				return err
+			}
+			return j.repo.SaveProgress(ctx, p)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (j *jobRun) importUsers(ctx context.Context, start, end int) (*batchResult, error) {
+	req := &authpb.ImportUsersRequest{}
+	for _, u := range j.archive.Users[start:end] {
+		req.Users = append(req.Users, &authpb.ImportedUser{
+			Id:        archive.UserID(j.job.Source, u.ExternalID).String(),
+			Username:  u.Username,
+			Email:     u.Email,
+			Bio:       u.Bio,
+			CreatedAt: timestamppb.New(u.CreatedAt),
+		})
+	}
+
+	var resp *authpb.ImportUsersResponse
+	err := j.call(ctx, func(ctx context.Context) (err error) {
+		resp, err = j.clients.Auth.ImportUsers(ctx, req)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Results) != end-start {
+		return nil, fmt.Errorf("auth-service returned %d results for %d users", len(resp.Results), end-start)
+	}
+
+	res := &batchResult{}
+	for i, result := range resp.Results {
+		externalID := j.archive.Users[start+i].ExternalID
+		switch result.Outcome {
+		case authpb.ImportUserOutcome_IMPORT_USER_OUTCOME_CREATED:
+			res.created++
+		case authpb.ImportUserOutcome_IMPORT_USER_OUTCOME_EXISTING:
+			res.skipped++
+		default:
+			res.failed++
+			continue
+		}
+
+		userID, err := uuid.Parse(result.UserId)
+		if err != nil {
+			return nil, fmt.Errorf("auth-service returned invalid user id %q", result.UserId)
+		}
+		j.users[externalID] = userID
+		res.mappings = append(res.mappings, models.UserMapping{ExternalID: externalID, UserID: userID})
+	}
+	return res, nil
+}
+
+// importProfiles creates profiles only for accounts the import created; users
+// matched onto an existing account keep the profile they already have
+func (j *jobRun) importProfiles(ctx context.Context, start, end int) (*batchResult, error) {
+	res := &batchResult{}
+	req := &userpb.ImportProfilesRequest{}
+	for _, u := range j.archive.Users[start:end] {
+		userID, ok := j.users[u.ExternalID]
+		switch {
+		case !ok:
+			res.failed++
+			continue
+		case userID != archive.UserID(j.job.Source, u.ExternalID):
+			res.skipped++
+			continue
+		}
+
+		req.Profiles = append(req.Profiles, &userpb.ImportedProfile{
+			Id:        userID.String(),
+			Username:  u.Username,
+			Email:     u.Email,
+			Bio:       u.Bio,
+			CreatedAt: timestamppb.New(u.CreatedAt),
+		})
+	}
+	if len(req.Profiles) == 0 {
+		return res, nil
+	}
+
+	var resp *userpb.ImportProfilesResponse
+	err := j.call(ctx, func(ctx context.Context) (err error) {
+		resp, err = j.clients.User.ImportProfiles(ctx, req)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res.created += resp.Created
+	res.skipped += resp.Skipped
+	return res, nil
+}
+
+func (j *jobRun) importPosts(ctx context.Context, start, end int) (*batchResult, error) {
+	res := &batchResult{}
+	req := &postpb.ImportPostsRequest{}
+	for _, p := range j.archive.Posts[start:end] {
+		authorID, ok := j.users[p.AuthorExternalID]
+		if !ok {
+			res.failed++
+			continue
+		}
+
+		req.Posts = append(req.Posts, &postpb.ImportedPost{
+			Id:        archive.PostID(j.job.Source, p.ExternalID).String(),
+			UserId:    authorID.String(),
+			Content:   p.Content,
+			CreatedAt: timestamppb.New(p.CreatedAt),
+		})
+	}
+	if len(req.Posts) == 0 {
+		return res, nil
+	}
+
+	var resp *postpb.ImportPostsResponse
+	err := j.call(ctx, func(ctx context.Context) (err error) {
+		resp, err = j.clients.Post.ImportPosts(ctx, req)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res.created += resp.Created
+	res.skipped += resp.Skipped
+	return res, nil
+}
+
+func (j *jobRun) importFollows(ctx context.Context, start, end int) (*batchResult, error) {
+	res := &batchResult{}
+	req := &followpb.ImportFollowsRequest{}
+	for _, f := range j.archive.Follows[start:end] {
+		followerID, ok := j.users[f.FollowerExternalID]
+		if !ok {
+			res.failed++
+			continue
+		}
+		followingID, ok := j.users[f.FollowingExternalID]
+		if !ok {
+			res.failed++
+			continue
+		}
+
+		req.Follows = append(req.Follows, &followpb.ImportedFollow{
+			FollowerId:  followerID.String(),
+			FollowingId: followingID.String(),
+			CreatedAt:   timestamppb.New(f.CreatedAt),
+		})
+	}
+	if len(req.Follows) == 0 {
+		return res, nil
+	}
+
+	var resp *followpb.ImportFollowsResponse
+	err := j.call(ctx, func(ctx context.Context) (err error) {
+		resp, err = j.clients.Follow.ImportFollows(ctx, req)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res.created += resp.Created
+	res.skipped += resp.Skipped
+	return res, nil
+}
+
+// call invokes fn with an ADMIN token for the approving admin, retrying
+// errors that are likely to be transient
+func (j *jobRun) call(ctx context.Context, fn func(ctx context.Context) error) error {
+	var err error
+	for attempt := 1; attempt <= callAttempts; attempt++ {
+		var callCtx context.Context
+		callCtx, err = j.authorize(ctx)
+		if err != nil {
+			return err
+		}
+
+		err = fn(callCtx)
+		switch status.Code(err) {
+		case codes.OK:
+			return nil
+		case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted:
+		default:
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Duration(attempt) * time.Second):
+		}
+	}
+	return err
+}
+
+// authorize attaches a short-lived token acting as the approving admin, in the
+// same shape auth-service issues
+func (j *jobRun) authorize(ctx context.Context) (context.Context, error) {
+	now := time.Now()
+	claims := interceptor.Claims{
+		UserID: j.adminID.String(),
+		Roles:  []string{interceptor.RoleAdmin},
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    "import-service",
+			Subject:   j.adminID.String(),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(tokenExpiry)),
+		},
+	}
+
+	token, err := j.keys.Sign(claims)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign import token: %w", err)
+	}
+	return metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token), nil
+}
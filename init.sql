@@ -9,6 +9,11 @@ CREATE DATABASE like_service_db;
 CREATE DATABASE follow_service_db;
 CREATE DATABASE feed_service_db;
 CREATE DATABASE notification_service_db;
+CREATE DATABASE import_service_db;
+CREATE DATABASE scheduler_service_db;
+CREATE DATABASE search_service_db;
+CREATE DATABASE moderation_service_db;
+CREATE DATABASE audit_service_db;
 
 -- ========================================
 -- Connect to auth_service_db
@@ -47,9 +52,18 @@ CREATE TABLE IF NOT EXISTS auth_refresh_tokens (
     token VARCHAR(512) NOT NULL UNIQUE,
     expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
     created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
-    is_revoked BOOLEAN NOT NULL DEFAULT FALSE
+    is_revoked BOOLEAN NOT NULL DEFAULT FALSE,
+    session_id UUID NOT NULL,
+    signed_in_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    device_name VARCHAR(255) NOT NULL DEFAULT '',
+    ip_address VARCHAR(64) NOT NULL DEFAULT '',
+    user_agent TEXT NOT NULL DEFAULT '',
+    last_seen_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    rotated_at TIMESTAMP WITH TIME ZONE
 );
 
+CREATE INDEX IF NOT EXISTS idx_auth_refresh_tokens_session_id ON auth_refresh_tokens(session_id);
+
 CREATE TABLE IF NOT EXISTS auth_token_blacklist (
     id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
     token VARCHAR(512) NOT NULL UNIQUE,
@@ -65,6 +79,60 @@ CREATE TABLE IF NOT EXISTS auth_user_roles (
     UNIQUE(user_id, role)
 );
 
+CREATE TABLE IF NOT EXISTS auth_user_suspensions (
+    user_id UUID PRIMARY KEY REFERENCES auth_users(id) ON DELETE CASCADE,
+    reason TEXT NOT NULL DEFAULT '',
+    suspended_by UUID,
+    suspended_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE TABLE IF NOT EXISTS auth_oauth_identities (
+    provider VARCHAR(32) NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    user_id UUID NOT NULL REFERENCES auth_users(id) ON DELETE CASCADE,
+    email VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (provider, subject)
+);
+
+CREATE INDEX IF NOT EXISTS idx_auth_oauth_identities_user_id ON auth_oauth_identities(user_id);
+
+CREATE TABLE IF NOT EXISTS auth_signing_keys (
+    id VARCHAR(64) PRIMARY KEY,
+    private_key TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    activates_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_auth_signing_keys_activates_at ON auth_signing_keys(activates_at);
+
+-- Write calls carrying an idempotency-key header, with the response a retry
+-- gets back. response is NULL while the first call is running; expired keys
+-- are pruned.
+CREATE TABLE IF NOT EXISTS auth_idempotency_keys (
+    scope VARCHAR(64) NOT NULL,
+    method VARCHAR(255) NOT NULL,
+    key VARCHAR(255) NOT NULL,
+    request_hash BYTEA NOT NULL,
+    response BYTEA,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (scope, method, key)
+);
+
+CREATE INDEX IF NOT EXISTS idx_auth_idempotency_keys_expires_at ON auth_idempotency_keys(expires_at);
+
+-- Usernames given up in user-service, kept from new accounts until
+-- reserved_until; username is lowercased
+CREATE TABLE IF NOT EXISTS auth_reserved_usernames (
+    username VARCHAR(255) PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES auth_users(id) ON DELETE CASCADE,
+    reserved_until TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_auth_reserved_usernames_user_id ON auth_reserved_usernames(user_id);
+
 -- ========================================
 -- Connect to user_service_db
 -- ========================================
@@ -82,6 +150,7 @@ CREATE TABLE IF NOT EXISTS user_service_users (
     followers_count INTEGER NOT NULL DEFAULT 0,
     following_count INTEGER NOT NULL DEFAULT 0,
     posts_count INTEGER NOT NULL DEFAULT 0,
+    is_private BOOLEAN NOT NULL DEFAULT FALSE,
     CONSTRAINT username_not_empty CHECK (username <> ''),
     CONSTRAINT email_not_empty CHECK (email <> ''),
     CONSTRAINT followers_count_positive CHECK (followers_count >= 0),
@@ -89,6 +158,9 @@ CREATE TABLE IF NOT EXISTS user_service_users (
     CONSTRAINT posts_count_positive CHECK (posts_count >= 0)
 );
 
+-- Added after the table was first created
+ALTER TABLE user_service_users ADD COLUMN IF NOT EXISTS is_private BOOLEAN NOT NULL DEFAULT FALSE;
+
 CREATE TABLE IF NOT EXISTS user_service_follows (
     follower_id UUID NOT NULL REFERENCES user_service_users(id) ON DELETE CASCADE,
     following_id UUID NOT NULL REFERENCES user_service_users(id) ON DELETE CASCADE,
@@ -97,6 +169,86 @@ CREATE TABLE IF NOT EXISTS user_service_follows (
     CONSTRAINT no_self_follow CHECK (follower_id <> following_id)
 );
 
+-- Follow events already applied to the counters of users, so a redelivered
+-- event is counted once. Rows outlive the event stream's retention and are
+-- then pruned.
+CREATE TABLE IF NOT EXISTS user_service_counter_events (
+    event_id VARCHAR(255) PRIMARY KEY,
+    applied_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_counter_events_applied_at ON user_service_counter_events(applied_at);
+
+-- Posts counted into posts_count; deleted ones are kept as tombstones until
+-- their events can no longer be delivered
+CREATE TABLE IF NOT EXISTS user_service_counted_posts (
+    post_id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_counted_posts_deleted_at ON user_service_counted_posts(deleted_at) WHERE deleted_at IS NOT NULL;
+
+-- Avatars and cover images, PENDING until resized; the profile points at
+-- the ready ones through avatar_id and cover_id
+CREATE TABLE IF NOT EXISTS user_service_profile_images (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    kind VARCHAR(10) NOT NULL,
+    status VARCHAR(10) NOT NULL DEFAULT 'PENDING',
+    mime_type VARCHAR(50) NOT NULL,
+    size_bytes BIGINT NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    claimed_at TIMESTAMP WITH TIME ZONE,
+    error TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    processed_at TIMESTAMP WITH TIME ZONE,
+    CONSTRAINT user_service_profile_image_kind CHECK (kind IN ('AVATAR', 'COVER')),
+    CONSTRAINT user_service_profile_image_status CHECK (status IN ('PENDING', 'READY', 'FAILED'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_profile_images_pending ON user_service_profile_images(created_at) WHERE status = 'PENDING';
+CREATE INDEX IF NOT EXISTS idx_user_profile_images_created_at ON user_service_profile_images(created_at) WHERE status <> 'PENDING';
+
+ALTER TABLE user_service_users ADD COLUMN IF NOT EXISTS avatar_id UUID;
+ALTER TABLE user_service_users ADD COLUMN IF NOT EXISTS cover_id UUID;
+
+-- Optional profile details, and flags hiding the email and birth date from
+-- everyone but the user
+ALTER TABLE user_service_users ADD COLUMN IF NOT EXISTS display_name VARCHAR(50);
+ALTER TABLE user_service_users ADD COLUMN IF NOT EXISTS location VARCHAR(100);
+ALTER TABLE user_service_users ADD COLUMN IF NOT EXISTS website VARCHAR(200);
+ALTER TABLE user_service_users ADD COLUMN IF NOT EXISTS birth_date DATE;
+ALTER TABLE user_service_users ADD COLUMN IF NOT EXISTS pronouns VARCHAR(30);
+ALTER TABLE user_service_users ADD COLUMN IF NOT EXISTS hide_email BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE user_service_users ADD COLUMN IF NOT EXISTS hide_birth_date BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Former usernames, reserved for their user until reserved_until
+CREATE TABLE IF NOT EXISTS user_service_username_history (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES user_service_users(id) ON DELETE CASCADE,
+    username VARCHAR(255) NOT NULL,
+    changed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    reserved_until TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_username_history_username ON user_service_username_history(LOWER(username), reserved_until);
+CREATE INDEX IF NOT EXISTS idx_user_username_history_user_id ON user_service_username_history(user_id, changed_at DESC);
+
+-- Events this service's consumers handled, so an event delivered again is
+-- skipped (see shared/dedupe). A claim without processed_at is an event
+-- being handled, or one whose worker died and whose claim lapses. Rows
+-- outlive the event stream's retention and are then pruned.
+CREATE TABLE IF NOT EXISTS user_service_processed_events (
+    consumer VARCHAR(255) NOT NULL,
+    event_id VARCHAR(255) NOT NULL,
+    claimed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    processed_at TIMESTAMP WITH TIME ZONE,
+    PRIMARY KEY (consumer, event_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_processed_events_claimed_at ON user_service_processed_events(claimed_at);
+
 -- ========================================
 -- Connect to post_service_db
 -- ========================================
@@ -112,10 +264,35 @@ CREATE TABLE IF NOT EXISTS post_service_posts (
     updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
     likes_count INTEGER NOT NULL DEFAULT 0,
     comments_count INTEGER NOT NULL DEFAULT 0,
+    reposts_count INTEGER NOT NULL DEFAULT 0,
+    quoted_post_id UUID REFERENCES post_service_posts(id) ON DELETE SET NULL,
     CONSTRAINT posts_likes_count_non_negative CHECK (likes_count >= 0),
     CONSTRAINT posts_comments_count_non_negative CHECK (comments_count >= 0)
 );
 
+-- Added after the table was first created
+ALTER TABLE post_service_posts ADD COLUMN IF NOT EXISTS reposts_count INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE post_service_posts ADD COLUMN IF NOT EXISTS quoted_post_id UUID REFERENCES post_service_posts(id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS idx_posts_quoted_post_id ON post_service_posts(quoted_post_id) WHERE quoted_post_id IS NOT NULL;
+ALTER TABLE post_service_posts ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITH TIME ZONE;
+CREATE INDEX IF NOT EXISTS idx_posts_deleted_at ON post_service_posts(deleted_at) WHERE deleted_at IS NOT NULL;
+ALTER TABLE post_service_posts ADD COLUMN IF NOT EXISTS edited_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE post_service_posts ADD COLUMN IF NOT EXISTS status VARCHAR(16) NOT NULL DEFAULT 'PUBLISHED';
+ALTER TABLE post_service_posts ADD COLUMN IF NOT EXISTS publish_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE post_service_posts DROP CONSTRAINT IF EXISTS posts_status_valid;
+ALTER TABLE post_service_posts ADD CONSTRAINT posts_status_valid CHECK (
+    status IN ('DRAFT', 'SCHEDULED', 'PUBLISHED')
+    AND (status = 'SCHEDULED') = (publish_at IS NOT NULL)
+);
+CREATE INDEX IF NOT EXISTS idx_posts_publish_at ON post_service_posts(publish_at) WHERE status = 'SCHEDULED';
+CREATE INDEX IF NOT EXISTS idx_posts_unpublished ON post_service_posts(user_id, created_at DESC, id DESC) WHERE status <> 'PUBLISHED';
+ALTER TABLE post_service_posts ADD COLUMN IF NOT EXISTS visibility VARCHAR(16) NOT NULL DEFAULT 'PUBLIC';
+ALTER TABLE post_service_posts DROP CONSTRAINT IF EXISTS posts_visibility_valid;
+ALTER TABLE post_service_posts ADD CONSTRAINT posts_visibility_valid CHECK (
+    visibility IN ('PUBLIC', 'FOLLOWERS_ONLY', 'PRIVATE')
+);
+ALTER TABLE post_service_posts ADD COLUMN IF NOT EXISTS shadow_hidden_at TIMESTAMP WITH TIME ZONE;
+
 CREATE TABLE IF NOT EXISTS post_service_likes (
     id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
     post_id UUID NOT NULL REFERENCES post_service_posts(id) ON DELETE CASCADE,
@@ -133,6 +310,122 @@ CREATE TABLE IF NOT EXISTS post_service_comments (
     updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
 );
 
+CREATE TABLE IF NOT EXISTS post_service_post_hashtags (
+    post_id UUID NOT NULL REFERENCES post_service_posts(id) ON DELETE CASCADE,
+    tag VARCHAR(64) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (post_id, tag)
+);
+
+CREATE INDEX IF NOT EXISTS idx_post_hashtags_tag_created_at ON post_service_post_hashtags(tag, created_at DESC, post_id DESC);
+CREATE INDEX IF NOT EXISTS idx_post_hashtags_created_at ON post_service_post_hashtags(created_at);
+
+CREATE TABLE IF NOT EXISTS post_service_post_mentions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    post_id UUID NOT NULL REFERENCES post_service_posts(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL,
+    username VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT post_mentions_unique_post_user UNIQUE (post_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_post_mentions_user_id ON post_service_post_mentions(user_id);
+
+CREATE TABLE IF NOT EXISTS post_service_post_media (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    post_id UUID REFERENCES post_service_posts(id) ON DELETE CASCADE,
+    mime_type VARCHAR(100) NOT NULL,
+    size_bytes BIGINT NOT NULL CHECK (size_bytes > 0),
+    position INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_post_media_post_id ON post_service_post_media(post_id, position);
+
+CREATE TABLE IF NOT EXISTS post_service_reposts (
+    id UUID NOT NULL UNIQUE,
+    post_id UUID NOT NULL REFERENCES post_service_posts(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (post_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_post_reposts_user_id ON post_service_reposts(user_id, created_at DESC);
+
+CREATE TABLE IF NOT EXISTS post_service_post_revisions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    post_id UUID NOT NULL REFERENCES post_service_posts(id) ON DELETE CASCADE,
+    editor_id UUID NOT NULL,
+    content TEXT NOT NULL,
+    edited_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_post_revisions_post_edited_at ON post_service_post_revisions(post_id, edited_at DESC, id DESC);
+
+CREATE TABLE IF NOT EXISTS post_service_polls (
+    post_id UUID PRIMARY KEY REFERENCES post_service_posts(id) ON DELETE CASCADE,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS post_service_poll_options (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    post_id UUID NOT NULL REFERENCES post_service_polls(post_id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    text VARCHAR(80) NOT NULL,
+    votes_count INTEGER NOT NULL DEFAULT 0,
+    UNIQUE (post_id, position)
+);
+
+CREATE TABLE IF NOT EXISTS post_service_poll_votes (
+    post_id UUID NOT NULL REFERENCES post_service_polls(post_id) ON DELETE CASCADE,
+    user_id UUID NOT NULL,
+    option_id UUID NOT NULL REFERENCES post_service_poll_options(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (post_id, user_id)
+);
+
+-- Write calls carrying an idempotency-key header, with the response a retry
+-- gets back. response is NULL while the first call is running; expired keys
+-- are pruned.
+CREATE TABLE IF NOT EXISTS post_service_idempotency_keys (
+    scope VARCHAR(64) NOT NULL,
+    method VARCHAR(255) NOT NULL,
+    key VARCHAR(255) NOT NULL,
+    request_hash BYTEA NOT NULL,
+    response BYTEA,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (scope, method, key)
+);
+
+CREATE INDEX IF NOT EXISTS idx_post_idempotency_keys_expires_at ON post_service_idempotency_keys(expires_at);
+
+-- Like and comment events already applied to the counters of posts, so a
+-- redelivered event is counted once. Rows outlive the event stream's
+-- retention and are then pruned.
+CREATE TABLE IF NOT EXISTS post_service_counter_events (
+    event_id VARCHAR(255) PRIMARY KEY,
+    applied_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_post_counter_events_applied_at ON post_service_counter_events(applied_at);
+
+-- Events this service's consumers handled, so an event delivered again is
+-- skipped (see shared/dedupe). A claim without processed_at is an event
+-- being handled, or one whose worker died and whose claim lapses. Rows
+-- outlive the event stream's retention and are then pruned.
+CREATE TABLE IF NOT EXISTS post_service_processed_events (
+    consumer VARCHAR(255) NOT NULL,
+    event_id VARCHAR(255) NOT NULL,
+    claimed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    processed_at TIMESTAMP WITH TIME ZONE,
+    PRIMARY KEY (consumer, event_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_post_processed_events_claimed_at ON post_service_processed_events(claimed_at);
+
 -- ========================================
 -- Connect to comment_service_db
 -- ========================================
@@ -147,9 +440,46 @@ CREATE TABLE IF NOT EXISTS comment_service_comments (
     content TEXT NOT NULL,
     created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    parent_comment_id UUID REFERENCES comment_service_comments(id) ON DELETE CASCADE,
+    replies_count INTEGER NOT NULL DEFAULT 0,
     CONSTRAINT check_content_not_empty CHECK (length(trim(content)) > 0)
 );
 
+-- Added after the table was first created
+ALTER TABLE comment_service_comments ADD COLUMN IF NOT EXISTS parent_comment_id UUID REFERENCES comment_service_comments(id) ON DELETE CASCADE;
+ALTER TABLE comment_service_comments ADD COLUMN IF NOT EXISTS replies_count INTEGER NOT NULL DEFAULT 0;
+CREATE INDEX IF NOT EXISTS idx_comment_service_comments_parent_created ON comment_service_comments(parent_comment_id, created_at, id);
+ALTER TABLE comment_service_comments ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITH TIME ZONE;
+CREATE INDEX IF NOT EXISTS idx_comment_service_comments_deleted_at ON comment_service_comments(deleted_at) WHERE deleted_at IS NOT NULL;
+ALTER TABLE comment_service_comments ADD COLUMN IF NOT EXISTS shadow_hidden_at TIMESTAMP WITH TIME ZONE;
+
+CREATE TABLE IF NOT EXISTS comment_service_comment_mentions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    comment_id UUID NOT NULL REFERENCES comment_service_comments(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL,
+    username VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT comment_mentions_unique_comment_user UNIQUE (comment_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_comment_service_comment_mentions_user_id ON comment_service_comment_mentions(user_id);
+
+-- Write calls carrying an idempotency-key header, with the response a retry
+-- gets back. response is NULL while the first call is running; expired keys
+-- are pruned.
+CREATE TABLE IF NOT EXISTS comment_service_idempotency_keys (
+    scope VARCHAR(64) NOT NULL,
+    method VARCHAR(255) NOT NULL,
+    key VARCHAR(255) NOT NULL,
+    request_hash BYTEA NOT NULL,
+    response BYTEA,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (scope, method, key)
+);
+
+CREATE INDEX IF NOT EXISTS idx_comment_idempotency_keys_expires_at ON comment_service_idempotency_keys(expires_at);
+
 -- ========================================
 -- Connect to like_service_db
 -- ========================================
@@ -179,6 +509,28 @@ BEGIN
 END;
 $$ LANGUAGE plpgsql;
 
+-- Write calls carrying an idempotency-key header, with the response a retry
+-- gets back. response is NULL while the first call is running; expired keys
+-- are pruned.
+CREATE TABLE IF NOT EXISTS like_service_idempotency_keys (
+    scope VARCHAR(64) NOT NULL,
+    method VARCHAR(255) NOT NULL,
+    key VARCHAR(255) NOT NULL,
+    request_hash BYTEA NOT NULL,
+    response BYTEA,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (scope, method, key)
+);
+
+CREATE INDEX IF NOT EXISTS idx_like_idempotency_keys_expires_at ON like_service_idempotency_keys(expires_at);
+
+-- Pages through the likers of a post, most recent first
+CREATE INDEX IF NOT EXISTS idx_like_service_likes_post_created ON like_service_likes(post_id, created_at DESC, id DESC);
+
+-- Pages through the posts a user liked, most recent first
+CREATE INDEX IF NOT EXISTS idx_like_service_likes_user_created ON like_service_likes(user_id, created_at DESC, id DESC);
+
 -- ========================================
 -- Connect to follow_service_db
 -- ========================================
@@ -195,6 +547,18 @@ CREATE TABLE IF NOT EXISTS follow_service_follows (
     CONSTRAINT no_self_follow CHECK (follower_id != following_id)
 );
 
+CREATE TABLE IF NOT EXISTS follow_service_follow_requests (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    follower_id UUID NOT NULL,
+    following_id UUID NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT unique_follow_request UNIQUE (follower_id, following_id),
+    CONSTRAINT no_self_follow_request CHECK (follower_id != following_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_follow_service_follow_requests_following_id
+ON follow_service_follow_requests(following_id, created_at DESC, id DESC);
+
 CREATE OR REPLACE FUNCTION follow_service_get_followers_count(user_id UUID)
 RETURNS INTEGER AS $$
 BEGIN
@@ -220,6 +584,53 @@ BEGIN
 END;
 $$ LANGUAGE plpgsql STABLE;
 
+-- Write calls carrying an idempotency-key header, with the response a retry
+-- gets back. response is NULL while the first call is running; expired keys
+-- are pruned.
+CREATE TABLE IF NOT EXISTS follow_service_idempotency_keys (
+    scope VARCHAR(64) NOT NULL,
+    method VARCHAR(255) NOT NULL,
+    key VARCHAR(255) NOT NULL,
+    request_hash BYTEA NOT NULL,
+    response BYTEA,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (scope, method, key)
+);
+
+CREATE INDEX IF NOT EXISTS idx_follow_idempotency_keys_expires_at ON follow_service_idempotency_keys(expires_at);
+
+-- Bulk imports of follows, run in the background batch by batch. follows
+-- holds the validated rows until the job finishes; owner_id is NULL for
+-- admin imports.
+CREATE TABLE IF NOT EXISTS follow_service_import_jobs (
+    id UUID PRIMARY KEY,
+    requested_by UUID NOT NULL,
+    owner_id UUID,
+    status VARCHAR(16) NOT NULL DEFAULT 'PENDING',
+    follows JSONB,
+    total INTEGER NOT NULL DEFAULT 0,
+    duplicates INTEGER NOT NULL DEFAULT 0,
+    processed INTEGER NOT NULL DEFAULT 0,
+    created INTEGER NOT NULL DEFAULT 0,
+    requested INTEGER NOT NULL DEFAULT 0,
+    skipped INTEGER NOT NULL DEFAULT 0,
+    failed INTEGER NOT NULL DEFAULT 0,
+    error TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    started_at TIMESTAMP WITH TIME ZONE,
+    finished_at TIMESTAMP WITH TIME ZONE,
+    locked_until TIMESTAMP WITH TIME ZONE,
+    CONSTRAINT follow_import_jobs_status_valid CHECK (
+        status IN ('PENDING', 'RUNNING', 'COMPLETED', 'FAILED')
+    )
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_follow_import_jobs_owner_unfinished ON follow_service_import_jobs(owner_id)
+    WHERE status IN ('PENDING', 'RUNNING');
+CREATE INDEX IF NOT EXISTS idx_follow_import_jobs_unfinished ON follow_service_import_jobs(created_at)
+    WHERE status IN ('PENDING', 'RUNNING');
+
 -- ========================================
 -- Connect to feed_service_db
 -- ========================================
@@ -237,6 +648,9 @@ CREATE TABLE IF NOT EXISTS feed_service_posts (
     comments_count INTEGER NOT NULL DEFAULT 0
 );
 
+-- Added after the table was first created
+ALTER TABLE feed_service_posts ADD COLUMN IF NOT EXISTS visibility VARCHAR(16) NOT NULL DEFAULT 'PUBLIC';
+
 CREATE TABLE IF NOT EXISTS feed_service_cache (
     id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
     user_id UUID NOT NULL,
@@ -269,9 +683,76 @@ CREATE TABLE IF NOT EXISTS feed_service_follows (
     PRIMARY KEY (follower_id, followed_id)
 );
 
+CREATE TABLE IF NOT EXISTS feed_service_reposts (
+    user_id UUID NOT NULL,
+    post_id UUID NOT NULL REFERENCES feed_service_posts(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, post_id)
+);
+
+CREATE TABLE IF NOT EXISTS feed_service_private_users (
+    user_id UUID PRIMARY KEY,
+    is_private BOOLEAN NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS feed_service_author_strategies (
+    user_id UUID PRIMARY KEY,
+    strategy TEXT NOT NULL CHECK (strategy IN ('push', 'pull')),
+    follower_count INTEGER NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_feed_service_follows_followed_id
+ON feed_service_follows(followed_id) WHERE deleted_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS feed_service_seen (
+    user_id UUID NOT NULL,
+    post_id UUID NOT NULL REFERENCES feed_service_posts(id) ON DELETE CASCADE,
+    seen_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, post_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_feed_service_seen_seen_at ON feed_service_seen(seen_at);
+
 CREATE UNIQUE INDEX idx_feed_cache_user_post 
 ON feed_service_cache(user_id, post_id);
 
+CREATE INDEX IF NOT EXISTS idx_feed_service_reposts_post_id ON feed_service_reposts(post_id);
+
+-- feed_service_likes is fed from post.liked and post.unliked. Comments are
+-- fed from post.comment.added and post.comment.deleted. Each keeps a
+-- tombstone when removed, so a redelivered event never counts twice. The
+-- likes_count and comments_count of posts change only as rows become live
+-- or are removed.
+CREATE TABLE IF NOT EXISTS feed_service_comments (
+    comment_id UUID PRIMARY KEY,
+    post_id UUID NOT NULL REFERENCES feed_service_posts(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_feed_service_comments_post_id ON feed_service_comments(post_id);
+CREATE INDEX IF NOT EXISTS idx_feed_service_likes_post_id ON feed_service_likes(post_id);
+
+-- updated_at is when the post was last edited, as post.updated reports it,
+-- so counting likes and comments must not move it
+DROP TRIGGER IF EXISTS trigger_update_feed_posts_updated_at ON feed_service_posts;
+
+-- Events this service's consumers handled, so an event delivered again is
+-- skipped (see shared/dedupe). A claim without processed_at is an event
+-- being handled, or one whose worker died and whose claim lapses. Rows
+-- outlive the event stream's retention and are then pruned.
+CREATE TABLE IF NOT EXISTS feed_service_processed_events (
+    consumer VARCHAR(255) NOT NULL,
+    event_id VARCHAR(255) NOT NULL,
+    claimed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    processed_at TIMESTAMP WITH TIME ZONE,
+    PRIMARY KEY (consumer, event_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_feed_processed_events_claimed_at ON feed_service_processed_events(claimed_at);
+
 
 -- ========================================
 -- Connect to notification_service_db
@@ -281,10 +762,17 @@ ON feed_service_cache(user_id, post_id);
 DO $$
 BEGIN
     IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'notification_type') THEN
-        CREATE TYPE notification_type AS ENUM ('LIKE','COMMENT','FOLLOW');
+        CREATE TYPE notification_type AS ENUM ('LIKE','COMMENT','FOLLOW','MENTION','REPOST','REPLY','FOLLOW_REQUEST');
     END IF;
 END
 $$;
+
+-- Databases created before these types existed lack the values
+ALTER TYPE notification_type ADD VALUE IF NOT EXISTS 'MENTION';
+ALTER TYPE notification_type ADD VALUE IF NOT EXISTS 'REPOST';
+ALTER TYPE notification_type ADD VALUE IF NOT EXISTS 'REPLY';
+ALTER TYPE notification_type ADD VALUE IF NOT EXISTS 'FOLLOW_REQUEST';
+ALTER TYPE notification_type ADD VALUE IF NOT EXISTS 'SECURITY';
 CREATE TABLE IF NOT EXISTS notification_service_notifications (
     id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
     user_id UUID NOT NULL,
@@ -293,9 +781,31 @@ CREATE TABLE IF NOT EXISTS notification_service_notifications (
     actor_id UUID,
     related_id UUID,
     is_read BOOLEAN NOT NULL DEFAULT FALSE,
-    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    group_key TEXT,
+    actor_count INTEGER NOT NULL DEFAULT 1,
+    latest_actors JSONB NOT NULL DEFAULT '[]'
 );
 
+-- Added after the table was first created
+ALTER TABLE notification_service_notifications ADD COLUMN IF NOT EXISTS group_key TEXT;
+ALTER TABLE notification_service_notifications ADD COLUMN IF NOT EXISTS actor_count INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE notification_service_notifications ADD COLUMN IF NOT EXISTS latest_actors JSONB NOT NULL DEFAULT '[]';
+
+-- At most one unread notification per group; later events are folded into it
+CREATE UNIQUE INDEX IF NOT EXISTS idx_notification_service_notifications_unread_group
+ON notification_service_notifications(user_id, group_key) WHERE group_key IS NOT NULL AND NOT is_read;
+
+-- Events already folded into a grouped notification, so redeliveries are
+-- not counted twice
+CREATE TABLE IF NOT EXISTS notification_service_notification_group_events (
+    event_id UUID PRIMARY KEY,
+    notification_id UUID REFERENCES notification_service_notifications(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_notification_service_notification_group_events_notification_id
+ON notification_service_notification_group_events(notification_id);
+
 CREATE OR REPLACE FUNCTION notification_service_get_unread_notification_count(p_user_id UUID)
 RETURNS INTEGER AS $$
 BEGIN
@@ -319,6 +829,352 @@ BEGIN
 END;
 $$ LANGUAGE plpgsql;
 
+CREATE TABLE IF NOT EXISTS notification_service_webhooks (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL,
+    url TEXT NOT NULL,
+    secret TEXT NOT NULL,
+    event_types TEXT[] NOT NULL,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_notification_service_webhooks_user_id
+ON notification_service_webhooks(user_id, created_at DESC);
+
+CREATE TABLE IF NOT EXISTS notification_service_webhook_deliveries (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    webhook_id UUID NOT NULL REFERENCES notification_service_webhooks(id) ON DELETE CASCADE,
+    event_id UUID NOT NULL,
+    event_type TEXT NOT NULL,
+    attempt INTEGER NOT NULL,
+    status_code INTEGER,
+    success BOOLEAN NOT NULL,
+    error TEXT,
+    duration_ms BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_notification_service_webhook_deliveries_webhook_created_at
+ON notification_service_webhook_deliveries(webhook_id, created_at DESC);
+
+CREATE TABLE IF NOT EXISTS notification_service_devices (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL,
+    platform TEXT NOT NULL,
+    token TEXT NOT NULL,
+    p256dh TEXT,
+    auth TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_seen_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT notification_service_devices_platform_valid CHECK (platform IN ('FCM', 'APNS', 'WEBPUSH')),
+    CONSTRAINT notification_service_devices_token_unique UNIQUE (platform, token)
+);
+
+CREATE INDEX IF NOT EXISTS idx_notification_service_devices_user_id
+ON notification_service_devices(user_id, last_seen_at DESC);
+
+CREATE TABLE IF NOT EXISTS notification_service_push_preferences (
+    user_id UUID NOT NULL,
+    type notification_type NOT NULL,
+    enabled BOOLEAN NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, type)
+);
+
+CREATE TABLE IF NOT EXISTS notification_service_preferences (
+    user_id UUID PRIMARY KEY,
+    disabled_types notification_type[] NOT NULL DEFAULT '{}',
+    quiet_hours_start SMALLINT,
+    quiet_hours_end SMALLINT,
+    time_zone TEXT NOT NULL DEFAULT 'UTC',
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT notification_service_preferences_quiet_hours_valid CHECK (
+        (quiet_hours_start IS NULL) = (quiet_hours_end IS NULL)
+        AND quiet_hours_start BETWEEN 0 AND 1439
+        AND quiet_hours_end BETWEEN 0 AND 1439
+    )
+);
+
+-- ========================================
+-- Failed Events
+-- ========================================
+-- Events the subscriber gave up on, kept from the dead-letter stream so
+-- admins can inspect and replay them
+CREATE TABLE IF NOT EXISTS notification_service_failed_events (
+    id UUID PRIMARY KEY,
+    -- The consumer and stream sequence of the failed delivery, so a dead
+    -- letter delivered twice is stored once
+    delivery_id TEXT NOT NULL UNIQUE,
+    subject TEXT NOT NULL,
+    consumer TEXT NOT NULL,
+    payload BYTEA NOT NULL,
+    headers JSONB NOT NULL DEFAULT '{}',
+    error TEXT NOT NULL,
+    attempts INTEGER NOT NULL,
+    failed_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    replayed_at TIMESTAMP WITH TIME ZONE,
+    replay_error TEXT
+);
+
+CREATE INDEX IF NOT EXISTS idx_notification_service_failed_events_failed_at
+ON notification_service_failed_events(failed_at DESC);
+
+-- Events this service's consumers handled, so an event delivered again is
+-- skipped (see shared/dedupe). A claim without processed_at is an event
+-- being handled, or one whose worker died and whose claim lapses. Rows
+-- outlive the event stream's retention and are then pruned.
+CREATE TABLE IF NOT EXISTS notification_service_processed_events (
+    consumer VARCHAR(255) NOT NULL,
+    event_id VARCHAR(255) NOT NULL,
+    claimed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    processed_at TIMESTAMP WITH TIME ZONE,
+    PRIMARY KEY (consumer, event_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_notification_processed_events_claimed_at ON notification_service_processed_events(claimed_at);
+
+-- ========================================
+-- Private Users
+-- ========================================
+-- Privacy of accounts, fed from user.updated. Webhooks do not deliver the
+-- posts of private accounts.
+CREATE TABLE IF NOT EXISTS notification_service_private_users (
+    user_id UUID PRIMARY KEY,
+    is_private BOOLEAN NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+-- ========================================
+-- Connect to import_service_db
+-- ========================================
+\c import_service_db
+
+-- ========================================
+-- Import Jobs Table
+-- ========================================
+CREATE TABLE IF NOT EXISTS import_service_jobs (
+    id UUID PRIMARY KEY,
+    source VARCHAR(64) NOT NULL,
+    submitted_by UUID NOT NULL,
+    status VARCHAR(32) NOT NULL DEFAULT 'PENDING_APPROVAL',
+    archive BYTEA NOT NULL,
+    archive_sha256 VARCHAR(64) NOT NULL,
+    reviewed_by UUID,
+    review_note TEXT,
+    error TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    reviewed_at TIMESTAMP WITH TIME ZONE,
+    started_at TIMESTAMP WITH TIME ZONE,
+    finished_at TIMESTAMP WITH TIME ZONE,
+    CONSTRAINT import_jobs_status_valid CHECK (
+        status IN ('PENDING_APPROVAL', 'APPROVED', 'RUNNING', 'COMPLETED', 'FAILED', 'REJECTED')
+    )
+);
+
+CREATE INDEX IF NOT EXISTS idx_import_jobs_status ON import_service_jobs(status, reviewed_at);
+CREATE INDEX IF NOT EXISTS idx_import_jobs_archive ON import_service_jobs(archive_sha256);
+CREATE INDEX IF NOT EXISTS idx_import_jobs_created_at ON import_service_jobs(created_at DESC);
+
+-- ========================================
+-- Per-phase Progress (processed doubles as the resume offset)
+-- ========================================
+CREATE TABLE IF NOT EXISTS import_service_progress (
+    job_id UUID NOT NULL REFERENCES import_service_jobs(id) ON DELETE CASCADE,
+    phase VARCHAR(16) NOT NULL,
+    total INTEGER NOT NULL DEFAULT 0,
+    processed INTEGER NOT NULL DEFAULT 0,
+    created INTEGER NOT NULL DEFAULT 0,
+    skipped INTEGER NOT NULL DEFAULT 0,
+    failed INTEGER NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (job_id, phase)
+);
+
+-- ========================================
+-- Archive users to the local accounts they were imported as
+-- ========================================
+CREATE TABLE IF NOT EXISTS import_service_user_map (
+    job_id UUID NOT NULL REFERENCES import_service_jobs(id) ON DELETE CASCADE,
+    external_id VARCHAR(255) NOT NULL,
+    user_id UUID NOT NULL,
+    PRIMARY KEY (job_id, external_id)
+);
+
+-- ========================================
+-- Connect to scheduler_service_db
+-- ========================================
+\c scheduler_service_db
+
+-- ========================================
+-- Job Runs Table
+-- ========================================
+CREATE TABLE IF NOT EXISTS scheduler_service_runs (
+    id UUID PRIMARY KEY,
+    job_name VARCHAR(64) NOT NULL,
+    trigger VARCHAR(16) NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'RUNNING',
+    instance VARCHAR(255) NOT NULL,
+    message TEXT,
+    error TEXT,
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    finished_at TIMESTAMP WITH TIME ZONE,
+    CONSTRAINT scheduler_runs_trigger_valid CHECK (trigger IN ('SCHEDULED', 'MANUAL')),
+    CONSTRAINT scheduler_runs_status_valid CHECK (status IN ('RUNNING', 'SUCCEEDED', 'FAILED'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_scheduler_runs_job_started_at ON scheduler_service_runs(job_name, started_at DESC);
+CREATE INDEX IF NOT EXISTS idx_scheduler_runs_started_at ON scheduler_service_runs(started_at DESC);
+
+-- ========================================
+-- Job Locks (one lease per job across scheduler instances)
+-- ========================================
+CREATE TABLE IF NOT EXISTS scheduler_service_locks (
+    job_name VARCHAR(64) PRIMARY KEY,
+    locked_by VARCHAR(255) NOT NULL,
+    locked_until TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+-- ========================================
+-- Connect to search_service_db
+-- ========================================
+\c search_service_db
+
+-- ========================================
+-- Post Index
+-- Deleted posts keep a tombstone row so that a late post.updated event
+-- cannot bring them back
+-- ========================================
+CREATE TABLE IF NOT EXISTS search_service_posts (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    content TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    document TSVECTOR GENERATED ALWAYS AS (to_tsvector('english', content)) STORED
+);
+
+CREATE INDEX IF NOT EXISTS idx_search_posts_document ON search_service_posts USING GIN (document);
+
+-- ========================================
+-- User Index
+-- Usernames are not natural language, so they use the 'simple' configuration
+-- and outrank matches in the bio
+-- ========================================
+CREATE TABLE IF NOT EXISTS search_service_users (
+    id UUID PRIMARY KEY,
+    username VARCHAR(255) NOT NULL,
+    bio TEXT,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    document TSVECTOR GENERATED ALWAYS AS (
+        setweight(to_tsvector('simple', username), 'A') ||
+        setweight(to_tsvector('simple', coalesce(bio, '')), 'B')
+    ) STORED
+);
+
+CREATE INDEX IF NOT EXISTS idx_search_users_document ON search_service_users USING GIN (document);
+
+-- ========================================
+-- Private Accounts
+-- ========================================
+-- Privacy of indexed users, fed from user.updated. Posts by private accounts
+-- are not indexed and are left out of results if they were before.
+ALTER TABLE search_service_users ADD COLUMN IF NOT EXISTS is_private BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- ========================================
+-- Connect to moderation_service_db
+-- ========================================
+\c moderation_service_db
+
+-- ========================================
+-- Audit Log (one row per admin action, never updated or deleted)
+-- ========================================
+CREATE TABLE IF NOT EXISTS moderation_service_actions (
+    id UUID PRIMARY KEY,
+    admin_id UUID NOT NULL,
+    action VARCHAR(32) NOT NULL,
+    target_type VARCHAR(16) NOT NULL,
+    target_id UUID NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    suspended_until TIMESTAMP WITH TIME ZONE,
+    reports_resolved INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT moderation_actions_action_valid CHECK (
+        action IN ('REMOVE_POST', 'REMOVE_COMMENT', 'SUSPEND_USER', 'UNSUSPEND_USER', 'DISMISS_REPORTS', 'RESOLVE_REPORT')
+    ),
+    CONSTRAINT moderation_actions_target_type_valid CHECK (target_type IN ('POST', 'COMMENT', 'USER'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_moderation_actions_created_at ON moderation_service_actions(created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_moderation_actions_admin ON moderation_service_actions(admin_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_moderation_actions_target ON moderation_service_actions(target_type, target_id, created_at DESC);
+
+-- ========================================
+-- Reports (OPEN -> REVIEWING -> RESOLVED; a user may have one unresolved
+-- report per target)
+-- ========================================
+CREATE TABLE IF NOT EXISTS moderation_service_reports (
+    id UUID PRIMARY KEY,
+    reporter_id UUID NOT NULL,
+    target_type VARCHAR(16) NOT NULL,
+    target_id UUID NOT NULL,
+    reason VARCHAR(32) NOT NULL DEFAULT 'OTHER',
+    details TEXT NOT NULL DEFAULT '',
+    anonymous BOOLEAN NOT NULL DEFAULT TRUE,
+    status VARCHAR(16) NOT NULL DEFAULT 'OPEN',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    reviewer_id UUID,
+    review_started_at TIMESTAMP WITH TIME ZONE,
+    resolution VARCHAR(16),
+    resolution_note TEXT NOT NULL DEFAULT '',
+    resolved_by UUID,
+    resolved_at TIMESTAMP WITH TIME ZONE,
+    action_id UUID,
+    CONSTRAINT moderation_reports_target_type_valid CHECK (target_type IN ('POST', 'COMMENT', 'USER')),
+    CONSTRAINT moderation_reports_status_valid CHECK (status IN ('OPEN', 'REVIEWING', 'RESOLVED')),
+    CONSTRAINT moderation_reports_resolution_valid CHECK (
+        (status = 'RESOLVED') = (resolution IS NOT NULL)
+        AND (resolution IS NULL OR resolution IN ('ACTIONED', 'DISMISSED'))
+    ),
+    CONSTRAINT moderation_reports_reason_valid CHECK (
+        reason IN ('SPAM', 'HARASSMENT', 'HATE_SPEECH', 'VIOLENCE', 'NUDITY', 'MISINFORMATION', 'SELF_HARM', 'IMPERSONATION', 'OTHER')
+    )
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_moderation_reports_unresolved_unique
+    ON moderation_service_reports(reporter_id, target_type, target_id) WHERE status <> 'RESOLVED';
+CREATE INDEX IF NOT EXISTS idx_moderation_reports_target ON moderation_service_reports(target_type, target_id) WHERE status <> 'RESOLVED';
+CREATE INDEX IF NOT EXISTS idx_moderation_reports_status ON moderation_service_reports(status, created_at DESC);
+
+-- ========================================
+-- Connect to audit_service_db
+-- ========================================
+\c audit_service_db
+
+-- ========================================
+-- Audit Log (one row per sensitive operation, never updated or deleted)
+-- ========================================
+CREATE TABLE IF NOT EXISTS audit_service_entries (
+    id UUID PRIMARY KEY,
+    service VARCHAR(64) NOT NULL,
+    action VARCHAR(64) NOT NULL,
+    actor_id UUID,
+    target_type VARCHAR(32) NOT NULL,
+    target_id VARCHAR(255) NOT NULL DEFAULT '',
+    ip_address VARCHAR(64) NOT NULL DEFAULT '',
+    user_agent TEXT NOT NULL DEFAULT '',
+    changes JSONB NOT NULL DEFAULT '[]',
+    occurred_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    recorded_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_entries_occurred_at ON audit_service_entries(occurred_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_entries_actor ON audit_service_entries(actor_id, occurred_at DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_entries_target ON audit_service_entries(target_type, target_id, occurred_at DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_entries_action ON audit_service_entries(action, occurred_at DESC);
+
 -- ========================================
 -- Update Triggers (for all databases)
 -- ========================================
@@ -382,17 +1238,3 @@ CREATE TRIGGER trigger_update_comments_updated_at
 BEFORE UPDATE ON comment_service_comments
 FOR EACH ROW
 EXECUTE FUNCTION update_updated_at_column();
-
-\c feed_service_db
-CREATE OR REPLACE FUNCTION update_updated_at_column()
-RETURNS TRIGGER AS $$
-BEGIN
-    NEW.updated_at = NOW();
-    RETURN NEW;
-END;
-$$ LANGUAGE plpgsql;
-
-CREATE TRIGGER trigger_update_feed_posts_updated_at
-BEFORE UPDATE ON feed_service_posts
-FOR EACH ROW
-EXECUTE FUNCTION update_updated_at_column();
\ No newline at end of file
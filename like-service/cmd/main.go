@@ -2,12 +2,12 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"log"
 	"net"
 	"os"
-	"os/signal"
-	"syscall"
+	"time"
 
 	"github.com/joho/godotenv"
 	"google.golang.org/grpc"
@@ -17,22 +17,45 @@ import (
 	"like-service/db"
 	"like-service/handler"
 	"like-service/interceptor"
+	"like-service/migrations"
+	natsClient "like-service/nats"
 	pb "like-service/pb"
+	"like-service/publisher"
 	"like-service/repository"
+	"like-service/rpcerror"
+	"like-service/subscriber"
+	postpb "post-service/pb"
+	"shared/chaos"
+	"shared/cursor"
+	"shared/idempotency"
+	"shared/lifecycle"
+	"shared/logging"
+	"shared/mtls"
+	"shared/tracing"
 )
 
 func main() {
+	logging.Init("like-service")
+
+	migrateFlag := flag.Bool("migrate", false, "apply pending schema migrations before serving")
+	flag.Parse()
+
 	if err := godotenv.Load(); err != nil {
 		log.Println("failed to load Follow .env")
 	}
+
+	// The supervisor health-checks the dependencies below and closes them
+	// in order on shutdown
+	supervisor := lifecycle.New(pb.LikeService_ServiceDesc.ServiceName)
+
 	// Load database configuration
 	dbCfg, err := config.LoadDatabaseConfig("")
 	if err != nil {
 		log.Fatalf("Failed to load Like database config: %v", err)
 	}
 
-	// Connect to the database
-	dbConn, err := database.NewConnection(database.Config{
+	// Connect to every shard of the database
+	shards, err := database.NewShards(database.Config{
 		Host:         dbCfg.Host,
 		Port:         dbCfg.Port,
 		User:         dbCfg.User,
@@ -42,56 +65,136 @@ func main() {
 		MaxOpenConns: dbCfg.MaxOpenConns,
 		MaxIdleConns: dbCfg.MaxIdleConns,
 		MaxLifetime:  dbCfg.MaxLifetime,
+		ReplicaDSNs:  dbCfg.ReplicaDSNs,
+		ShardDSNs:    dbCfg.ShardDSNs,
 	})
 	if err != nil {
 		log.Fatalf("Failed to connect to Like database: %v", err)
 	}
-	defer dbConn.Close()
+	supervisor.Add(lifecycle.Dependency{Name: "database", Check: shards.HealthCheck, Close: shards.Close})
+
+	// Apply pending schema migrations with -migrate or DB_MIGRATE=true;
+	// without either they are only reported
+	if err := shards.MigrateOnStartup(context.Background(), "like-service", migrations.Files, *migrateFlag || os.Getenv("DB_MIGRATE") == "true"); err != nil {
+		log.Fatalf("Failed to migrate Like database: %v", err)
+	}
 
-	log.Println("Successfully connected to Like database")
+	log.Printf("Successfully connected to Like database (%d shards)", shards.Len())
 
 	// Load other service-level configs
 	grpcPort := getEnv("GRPC_PORT", "50057")
 	jwtSecret := getEnv("JWT_SECRET", "your-secret-key")
+	// Tokens are verified with JWT_SECRET, or with auth-service's public keys
+	// when JWT_ALGORITHM is RS256
+	tokenKeys, err := interceptor.KeysFromEnv(jwtSecret)
+	if err != nil {
+		log.Fatalf("Invalid JWT configuration: %v", err)
+	}
+
+	// Fault injection for resilience testing; inert unless CHAOS_ENABLED=true
+	chaosInjector, err := chaos.FromEnv()
+	if err != nil {
+		log.Fatalf("Invalid chaos configuration: %v", err)
+	}
+
+	// Export traces when OTEL_EXPORTER_OTLP_ENDPOINT is set
+	shutdownTracing, err := tracing.Init(context.Background(), "like-service")
+	if err != nil {
+		log.Fatalf("Failed to initialize tracing: %v", err)
+	}
+	supervisor.OnShutdown("tracing", shutdownTracing)
+
+	// Pagination cursors are signed so clients cannot forge positions
+	if err := cursor.SetSecret(os.Getenv("CURSOR_SECRET")); err != nil {
+		log.Fatalf("Invalid CURSOR_SECRET: %v", err)
+	}
+	natsURL := getEnv("NATS_URL", "nats://nats:4222")
+	natsClientID := getEnv("NATS_CLIENT_ID", "like-service")
+
+	// Initialize NATS client
+	natsCfg := natsClient.Config{
+		URL:           natsURL,
+		MaxReconnects: 10,
+		ReconnectWait: 2 * time.Second,
+		ClientID:      natsClientID,
+		Chaos:         chaosInjector,
+	}
+
+	nats, err := natsClient.NewClient(natsCfg)
+	if err != nil {
+		log.Fatalf("Failed to initialize NATS client: %v", err)
+	}
+	supervisor.Add(lifecycle.Dependency{Name: "nats", Check: nats.HealthCheck, Drain: nats.Drain})
+	log.Println("NATS client initialized successfully")
+
+	// Initialize event publisher
+	eventPublisher := publisher.NewEventPublisher(nats)
+
+	// Other services are dialed over TLS when GRPC_TLS is set
+	clientTLS, err := mtls.DialOption()
+	if err != nil {
+		log.Fatalf("Failed to load TLS config: %v", err)
+	}
+
+	// Likes are only shown and accepted on posts post-service shows the caller,
+	// which also has the liked posts' authors for notifications
+	postConn, err := grpc.NewClient(getEnv("POST_SERVICE_ADDR", "post-service:50053"), clientTLS, tracing.DialOption(), grpc.WithChainUnaryInterceptor(logging.UnaryClientInterceptor()))
+	if err != nil {
+		log.Fatalf("Failed to connect to post service: %v", err)
+	}
+	defer postConn.Close()
 
 	// Initialize repository and handler
-	likeRepo := repository.NewLikeRepository(dbConn.DB)
-	likeHandler := handler.NewLikeHandler(likeRepo)
+	likeRepo := repository.NewLikeRepository(shards)
+	likeHandler := handler.NewLikeHandler(likeRepo, eventPublisher, postpb.NewPostServiceClient(postConn))
+
+	// Likes are deleted along with their post or their user
+	subscriber.NewPostSubscriber(nats, likeRepo, context.Background()).Start()
+	subscriber.NewUserSubscriber(nats, likeRepo, context.Background()).Start()
 
 	// Initialize auth interceptor (allowing public routes)
-	authInterceptor := interceptor.NewAuthInterceptor(jwtSecret, []string{
+	authInterceptor := interceptor.NewAuthInterceptor(tokenKeys, []string{
 		"/like.LikeService/GetPostLikes",
+		"/like.LikeService/GetPostLikers",
+		"/like.LikeService/GetUserLikedPosts",
 	})
+	authInterceptor.AddAdminMethods([]string{
+		"/like.LikeService/GetLikesCounts",
+	})
+	authInterceptor.AddPublicMethods(lifecycle.HealthMethods)
+
+	// Retries of writes that carry an idempotency-key header get the first
+	// call's response instead of writing again. Keys are per caller.
+	// Idempotency keys live on shard 0
+	idempotencyStore, err := idempotency.NewStore(shards.All()[0].WriteDB(), "like_service_idempotency_keys", rpcerror.Domain, []byte(os.Getenv("IDEMPOTENCY_SECRET")))
+	if err != nil {
+		log.Fatalf("Invalid IDEMPOTENCY_SECRET: %v", err)
+	}
+	idempotencyCtx, stopIdempotency := context.WithCancel(supervisor.Context())
+	defer stopIdempotency()
+	go idempotencyStore.Run(idempotencyCtx)
+	idempotent := idempotencyStore.UnaryServerInterceptor(func(ctx context.Context) string {
+		userID, _ := interceptor.GetUserIDFromContext(ctx)
+		return userID
+	}, "/like.LikeService/LikePost")
+
+	// TLS, and mutual TLS with a CA bundle, from GRPC_TLS_*
+	tlsOption, err := mtls.ServerOption()
+	if err != nil {
+		log.Fatalf("Failed to load TLS config: %v", err)
+	}
 
 	// Create gRPC server with interceptors
 	grpcServer := grpc.NewServer(
-		grpc.UnaryInterceptor(authInterceptor.Unary()),
-		grpc.StreamInterceptor(authInterceptor.Stream()),
+		tlsOption,
+		tracing.ServerOption(),
+		grpc.ChainUnaryInterceptor(logging.UnaryServerInterceptor(), rpcerror.UnaryServerInterceptor(), chaosInjector.Unary(), authInterceptor.Unary(), idempotent),
+		grpc.ChainStreamInterceptor(logging.StreamServerInterceptor(), rpcerror.StreamServerInterceptor(), chaosInjector.Stream(), authInterceptor.Stream()),
 	)
 
-	// Graceful shutdown handling
-	go func() {
-		sigChan := make(chan os.Signal, 1)
-		signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
-		<-sigChan
-
-		log.Println("Like service Shutting down gracefully...")
-		grpcServer.GracefulStop()
-
-		ctx, cancel := context.WithTimeout(context.Background(), dbCfg.MaxLifetime)
-		defer cancel()
-
-		if err := dbConn.HealthCheck(ctx); err == nil {
-			_ = dbConn.Close()
-			log.Println("Like Database connection closed")
-		}
-
-		log.Println("Server stopped")
-		os.Exit(0)
-	}()
-
 	// Register the gRPC service
 	pb.RegisterLikeServiceServer(grpcServer, likeHandler)
+	supervisor.Register(grpcServer)
 
 	// Enable reflection for debugging tools like grpcurl
 	reflection.Register(grpcServer)
@@ -105,7 +208,7 @@ func main() {
 	log.Printf("Like Service gRPC server listening on port %s", grpcPort)
 
 	// Serve requests
-	if err := grpcServer.Serve(listener); err != nil {
+	if err := supervisor.Serve(grpcServer, listener); err != nil {
 		log.Fatalf("Failed to serve: %v", err)
 	}
 }
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -18,6 +19,8 @@ type DatabaseConfig struct {
 	MaxOpenConns int
 	MaxIdleConns int
 	MaxLifetime  time.Duration
+	ReplicaDSNs  []string
+	ShardDSNs    []string
 }
 
 // LoadDatabaseConfig loads database configuration from environment variables
@@ -31,6 +34,8 @@ func LoadDatabaseConfig(prefix string) (*DatabaseConfig, error) {
 		MaxOpenConns: getEnvAsInt(prefix+"DB_MAX_OPEN_CONNS", 25),
 		MaxIdleConns: getEnvAsInt(prefix+"DB_MAX_IDLE_CONNS", 5),
 		MaxLifetime:  getEnvAsDuration(prefix+"DB_MAX_LIFETIME", 5*time.Minute),
+		ReplicaDSNs:  getEnvAsList(prefix + "DB_REPLICA_DSNS"),
+		ShardDSNs:    getEnvAsList(prefix + "DB_SHARD_DSNS"),
 	}
 
 	var err error
@@ -80,3 +85,14 @@ func getEnvAsDuration(key string, defaultValue time.Duration) time.Duration {
 	}
 	return value
 }
+
+// getEnvAsList gets a comma-separated environment variable as a list, skipping empty entries
+func getEnvAsList(key string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
@@ -0,0 +1,116 @@
+package database
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io/fs"
+
+	"github.com/google/uuid"
+
+	"shared/migrate"
+)
+
+// Shards routes rows to one of several databases by hashing a key. Shard 0 is
+// the database described by Config (with its read replicas); ShardDSNs add
+// shards 1..N-1. With no ShardDSNs every key routes to shard 0.
+//
+// Keys are placed with jump consistent hashing, so appending a shard moves
+// only about 1/N of the keys, all of them onto the new shard. Shards must
+// only ever be appended, and the moved rows copied before traffic is
+// switched over; reordering or removing DSNs misroutes existing rows.
+type Shards struct {
+	dbs []*DB
+}
+
+// NewShards connects to every shard
+func NewShards(cfg Config) (*Shards, error) {
+	first, err := NewConnection(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Shards{dbs: []*DB{first}}
+	for i, dsn := range cfg.ShardDSNs {
+		conn, err := connect(dsn, cfg)
+		if err != nil {
+			s.Close()
+			return nil, fmt.Errorf("shard %d: %w", i+1, err)
+		}
+		s.dbs = append(s.dbs, &DB{DB: conn})
+	}
+
+	return s, nil
+}
+
+// Len returns the number of shards
+func (s *Shards) Len() int {
+	return len(s.dbs)
+}
+
+// Index returns the shard that owns key
+func (s *Shards) Index(key uuid.UUID) int {
+	return jumpHash(binary.BigEndian.Uint64(key[8:])^binary.BigEndian.Uint64(key[:8]), len(s.dbs))
+}
+
+// For returns the database that owns key
+func (s *Shards) For(key uuid.UUID) *DB {
+	return s.dbs[s.Index(key)]
+}
+
+// All returns every shard, in shard order
+func (s *Shards) All() []*DB {
+	return s.dbs
+}
+
+// Group splits keys by the shard that owns them, keeping their order
+func (s *Shards) Group(keys []uuid.UUID) map[int][]uuid.UUID {
+	groups := make(map[int][]uuid.UUID)
+	for _, key := range keys {
+		i := s.Index(key)
+		groups[i] = append(groups[i], key)
+	}
+	return groups
+}
+
+// Close closes every shard
+func (s *Shards) Close() error {
+	var firstErr error
+	for _, db := range s.dbs {
+		if err := db.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// HealthCheck checks that every shard is healthy
+func (s *Shards) HealthCheck(ctx context.Context) error {
+	for i, db := range s.dbs {
+		if err := db.HealthCheck(ctx); err != nil {
+			return fmt.Errorf("shard %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// jumpHash is Lamping and Veach's jump consistent hash
+func jumpHash(key uint64, buckets int) int {
+	var b, j int64 = -1, 0
+	for j < int64(buckets) {
+		b = j
+		key = key*2862933555777941757 + 1
+		j = int64(float64(b+1) * (float64(int64(1)<<31) / float64((key>>33)+1)))
+	}
+	return int(b)
+}
+
+// MigrateOnStartup migrates or checks every shard; see migrate.OnStartup
+func (s *Shards) MigrateOnStartup(ctx context.Context, service string, files fs.FS, apply bool) error {
+	for i, db := range s.dbs {
+		if err := migrate.OnStartup(ctx, db.DB, service, files, apply); err != nil {
+			return fmt.Errorf("shard %d: %w", i, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package events
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"shared/eventschema"
+)
+
+const (
+	PostLiked = "post.liked"
+	// PostUnliked is published when a like is removed, so post-service can
+	// count it down
+	PostUnliked = "post.unliked"
+	// PostDeleted is published by post-service; the likes of the post are
+	// deleted with it
+	PostDeleted = eventschema.PostDeleted
+	// UserDeleted is published by auth-service; the likes of the user are
+	// deleted with them
+	UserDeleted = "user.deleted"
+)
+
+// PostLikedEvent is published when a user likes a post. PostAuthorID lets
+// subscribers notify the author without looking the post up.
+type PostLikedEvent struct {
+	LikeID       uuid.UUID `json:"like_id"`
+	PostID       uuid.UUID `json:"post_id"`
+	PostAuthorID uuid.UUID `json:"post_author_id"`
+	UserID       uuid.UUID `json:"user_id"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// PostUnlikedEvent is published when a user removes their like of a post
+type PostUnlikedEvent struct {
+	LikeID    uuid.UUID `json:"like_id"`
+	PostID    uuid.UUID `json:"post_id"`
+	UserID    uuid.UUID `json:"user_id"`
+	UnlikedAt time.Time `json:"unliked_at"`
+}
+
+// UserDeletedEvent is published by auth-service when a user deletes their
+// account
+type UserDeletedEvent struct {
+	UserID    uuid.UUID `json:"user_id"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
@@ -2,47 +2,71 @@ package handler
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"like-service/events"
+	"like-service/interceptor"
 	pb "like-service/pb"
+	"like-service/publisher"
 	"like-service/repository"
+	"like-service/rpcerror"
+	postpb "post-service/pb"
+	"shared/cursor"
+	"shared/logging"
 )
 
+// maxCountBatch is the most posts GetLikesCounts counts at once
+const maxCountBatch = 1000
+
 type LikeHandler struct {
 	pb.UnimplementedLikeServiceServer
-	likeRepo repository.LikeRepository
+	likeRepo  repository.LikeRepository
+	publisher *publisher.EventPublisher
+	posts     postpb.PostServiceClient
 }
 
-func NewLikeHandler(likeRepo repository.LikeRepository) *LikeHandler {
+func NewLikeHandler(likeRepo repository.LikeRepository, publisher *publisher.EventPublisher, posts postpb.PostServiceClient) *LikeHandler {
 	return &LikeHandler{
-		likeRepo: likeRepo,
+		likeRepo:  likeRepo,
+		publisher: publisher,
+		posts:     posts,
 	}
 }
 
 // LikePost handles the request to like a post
 func (h *LikeHandler) LikePost(ctx context.Context, req *pb.LikePostRequest) (*pb.Response, error) {
 	if req.PostId == "" {
-		return nil, status.Error(codes.InvalidArgument, "post_id is required")
+		return nil, rpcerror.InvalidField("post_id", "post_id is required")
 	}
 	if req.UserId == "" {
-		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+		return nil, rpcerror.InvalidField("user_id", "user_id is required")
 	}
 
 	postID, err := uuid.Parse(req.PostId)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid post_id format")
+		return nil, rpcerror.InvalidField("post_id", "invalid post_id format")
 	}
 
 	userID, err := uuid.Parse(req.UserId)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid user_id format")
+		return nil, rpcerror.InvalidField("user_id", "invalid user_id format")
+	}
+
+	post, err := h.visiblePost(ctx, postID)
+	if err != nil {
+		return nil, err
 	}
 
-	err = h.likeRepo.CreateLike(ctx, postID, userID)
+	likeID := uuid.New()
+	err = h.likeRepo.CreateLike(ctx, likeID, postID, userID)
 	if err != nil {
 		if err.Error() == "like already exists" {
 			return &pb.Response{
@@ -53,6 +77,8 @@ func (h *LikeHandler) LikePost(ctx context.Context, req *pb.LikePostRequest) (*p
 		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to like post: %v", err))
 	}
 
+	h.publishPostLiked(ctx, likeID, postID, userID, post.UserId)
+
 	return &pb.Response{
 		Success: true,
 		Message: "Post liked successfully",
@@ -62,25 +88,25 @@ func (h *LikeHandler) LikePost(ctx context.Context, req *pb.LikePostRequest) (*p
 // UnlikePost handles the request to unlike a post
 func (h *LikeHandler) UnlikePost(ctx context.Context, req *pb.UnlikePostRequest) (*pb.Response, error) {
 	if req.PostId == "" {
-		return nil, status.Error(codes.InvalidArgument, "post_id is required")
+		return nil, rpcerror.InvalidField("post_id", "post_id is required")
 	}
 	if req.UserId == "" {
-		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+		return nil, rpcerror.InvalidField("user_id", "user_id is required")
 	}
 
 	postID, err := uuid.Parse(req.PostId)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid post_id format")
+		return nil, rpcerror.InvalidField("post_id", "invalid post_id format")
 	}
 
 	userID, err := uuid.Parse(req.UserId)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid user_id format")
+		return nil, rpcerror.InvalidField("user_id", "invalid user_id format")
 	}
 
-	err = h.likeRepo.DeleteLike(ctx, postID, userID)
+	likeID, err := h.likeRepo.DeleteLike(ctx, postID, userID)
 	if err != nil {
-		if errors.Is(err, errors.New("like not found")) {
+		if errors.Is(err, repository.ErrLikeNotFound) {
 			return &pb.Response{
 				Success: true,
 				Message: "Like already removed",
@@ -89,6 +115,16 @@ func (h *LikeHandler) UnlikePost(ctx context.Context, req *pb.UnlikePostRequest)
 		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to unlike post: %v", err))
 	}
 
+	event := events.PostUnlikedEvent{
+		LikeID:    likeID,
+		PostID:    postID,
+		UserID:    userID,
+		UnlikedAt: time.Now(),
+	}
+	if err := h.publisher.PublishPostUnliked(ctx, event); err != nil {
+		logging.FromContext(ctx).Error().Err(err).Msg("failed to publish post unliked event")
+	}
+
 	return &pb.Response{
 		Success: true,
 		Message: "Post unliked successfully",
@@ -98,12 +134,16 @@ func (h *LikeHandler) UnlikePost(ctx context.Context, req *pb.UnlikePostRequest)
 // GetPostLikes retrieves like information for a post
 func (h *LikeHandler) GetPostLikes(ctx context.Context, req *pb.GetPostLikesRequest) (*pb.LikeInfo, error) {
 	if req.PostId == "" {
-		return nil, status.Error(codes.InvalidArgument, "post_id is required")
+		return nil, rpcerror.InvalidField("post_id", "post_id is required")
 	}
 
 	postID, err := uuid.Parse(req.PostId)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid post_id format")
+		return nil, rpcerror.InvalidField("post_id", "invalid post_id format")
+	}
+
+	if _, err := h.visiblePost(ctx, postID); err != nil {
+		return nil, err
 	}
 
 	count, err := h.likeRepo.GetLikeCountByPost(ctx, postID)
@@ -131,36 +171,135 @@ func (h *LikeHandler) GetPostLikes(ctx context.Context, req *pb.GetPostLikesRequ
 		RecentLikerIds: recentLikerIDs,
 	}
 
-	if req.RequestingUserId != nil && *req.RequestingUserId != "" {
-		userID, err := uuid.Parse(*req.RequestingUserId)
+	if viewer := viewerID(ctx); viewer != nil {
+		isLiked, err := h.likeRepo.IsPostLikedByUser(ctx, postID, *viewer)
 		if err == nil {
-			isLiked, err := h.likeRepo.IsPostLikedByUser(ctx, postID, userID)
-			if err == nil {
-				response.IsLikedByCurrentUser = &isLiked
-			}
+			response.IsLikedByCurrentUser = &isLiked
 		}
 	}
 
 	return response, nil
 }
 
+// GetPostLikers pages through everyone who liked a post, most recent first
+func (h *LikeHandler) GetPostLikers(ctx context.Context, req *pb.GetPostLikersRequest) (*pb.LikerConnection, error) {
+	if req.PostId == "" {
+		return nil, rpcerror.InvalidField("post_id", "post_id is required")
+	}
+
+	postID, err := uuid.Parse(req.PostId)
+	if err != nil {
+		return nil, rpcerror.InvalidField("post_id", "invalid post_id format")
+	}
+
+	if _, err := h.visiblePost(ctx, postID); err != nil {
+		return nil, err
+	}
+
+	first := req.First
+	if first <= 0 {
+		first = 20
+	}
+	if first > 100 {
+		first = 100
+	}
+
+	connection, err := h.likeRepo.GetLikersByPost(ctx, postID, first, req.After)
+	if err != nil {
+		if errors.Is(err, cursor.ErrInvalid) {
+			return nil, rpcerror.InvalidField("after", "invalid cursor")
+		}
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to get likers: %v", err))
+	}
+
+	edges := make([]*pb.LikerEdge, len(connection.Edges))
+	for i, edge := range connection.Edges {
+		edges[i] = &pb.LikerEdge{
+			Cursor:  edge.Cursor,
+			UserId:  edge.UserID.String(),
+			LikedAt: timestamppb.New(edge.LikedAt),
+		}
+	}
+
+	return &pb.LikerConnection{
+		Edges: edges,
+		PageInfo: &pb.PageInfo{
+			EndCursor:       connection.PageInfo.EndCursor,
+			HasNextPage:     connection.PageInfo.HasNextPage,
+			StartCursor:     connection.PageInfo.StartCursor,
+			HasPreviousPage: connection.PageInfo.HasPreviousPage,
+		},
+		TotalCount: connection.TotalCount,
+	}, nil
+}
+
+// GetUserLikedPosts pages through the posts a user liked, most recently liked
+// first. Whether the caller may see them is left to the gateway, which
+// hydrates the posts.
+func (h *LikeHandler) GetUserLikedPosts(ctx context.Context, req *pb.GetUserLikedPostsRequest) (*pb.LikedPostConnection, error) {
+	if req.UserId == "" {
+		return nil, rpcerror.InvalidField("user_id", "user_id is required")
+	}
+
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, rpcerror.InvalidField("user_id", "invalid user_id format")
+	}
+
+	first := req.First
+	if first <= 0 {
+		first = 20
+	}
+	if first > 100 {
+		first = 100
+	}
+
+	connection, err := h.likeRepo.GetLikedPostsByUser(ctx, userID, first, req.After)
+	if err != nil {
+		if errors.Is(err, cursor.ErrInvalid) {
+			return nil, rpcerror.InvalidField("after", "invalid cursor")
+		}
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to get liked posts: %v", err))
+	}
+
+	edges := make([]*pb.LikedPostEdge, len(connection.Edges))
+	for i, edge := range connection.Edges {
+		edges[i] = &pb.LikedPostEdge{
+			Cursor:  edge.Cursor,
+			PostId:  edge.PostID.String(),
+			LikedAt: timestamppb.New(edge.LikedAt),
+		}
+	}
+
+	return &pb.LikedPostConnection{
+		Edges: edges,
+		PageInfo: &pb.PageInfo{
+			EndCursor:       connection.PageInfo.EndCursor,
+			HasNextPage:     connection.PageInfo.HasNextPage,
+			StartCursor:     connection.PageInfo.StartCursor,
+			HasPreviousPage: connection.PageInfo.HasPreviousPage,
+		},
+		TotalCount: connection.TotalCount,
+	}, nil
+}
+
 // IsPostLikedByUser checks if a user has liked a specific post
 func (h *LikeHandler) IsPostLikedByUser(ctx context.Context, req *pb.IsPostLikedByUserRequest) (*pb.IsPostLikedByUserResponse, error) {
 	if req.PostId == "" {
-		return nil, status.Error(codes.InvalidArgument, "post_id is required")
+		return nil, rpcerror.InvalidField("post_id", "post_id is required")
 	}
 	if req.UserId == "" {
-		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+		return nil, rpcerror.InvalidField("user_id", "user_id is required")
 	}
 
 	postID, err := uuid.Parse(req.PostId)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid post_id format")
+		return nil, rpcerror.InvalidField("post_id", "invalid post_id format")
 	}
 
 	userID, err := uuid.Parse(req.UserId)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid user_id format")
+		return nil, rpcerror.InvalidField("user_id", "invalid user_id format")
 	}
 
 	isLiked, err := h.likeRepo.IsPostLikedByUser(ctx, postID, userID)
@@ -181,19 +320,19 @@ func (h *LikeHandler) GetPostLikesByUsers(ctx context.Context, req *pb.GetPostLi
 		}, nil
 	}
 	if req.UserId == "" {
-		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+		return nil, rpcerror.InvalidField("user_id", "user_id is required")
 	}
 
 	userID, err := uuid.Parse(req.UserId)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid user_id format")
+		return nil, rpcerror.InvalidField("user_id", "invalid user_id format")
 	}
 
 	postIDs := make([]uuid.UUID, len(req.PostIds))
 	for i, postIDStr := range req.PostIds {
 		postID, err := uuid.Parse(postIDStr)
 		if err != nil {
-			return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("invalid post_id format at index %d", i))
+			return nil, rpcerror.InvalidField(fmt.Sprintf("post_ids[%d]", i), fmt.Sprintf("invalid post_id format at index %d", i))
 		}
 		postIDs[i] = postID
 	}
@@ -215,3 +354,120 @@ func (h *LikeHandler) GetPostLikesByUsers(ctx context.Context, req *pb.GetPostLi
 		Likes: pbLikeStatuses,
 	}, nil
 }
+
+// GetLikesCounts returns the number of likes of each post, for post-service
+// to reconcile its like counters with
+func (h *LikeHandler) GetLikesCounts(ctx context.Context, req *pb.GetLikesCountsRequest) (*pb.GetLikesCountsResponse, error) {
+	if len(req.PostIds) > maxCountBatch {
+		return nil, rpcerror.InvalidField("post_ids", fmt.Sprintf("at most %d post_ids can be counted per request", maxCountBatch))
+	}
+
+	postIDs := make([]uuid.UUID, len(req.PostIds))
+	for i, postIDStr := range req.PostIds {
+		postID, err := uuid.Parse(postIDStr)
+		if err != nil {
+			return nil, rpcerror.InvalidField(fmt.Sprintf("post_ids[%d]", i), fmt.Sprintf("invalid post_id format at index %d", i))
+		}
+		postIDs[i] = postID
+	}
+
+	counts, err := h.likeRepo.GetLikeCounts(ctx, postIDs)
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to get like counts: %v", err))
+	}
+
+	pbCounts := make([]*pb.PostLikesCount, len(postIDs))
+	for i, postID := range postIDs {
+		pbCounts[i] = &pb.PostLikesCount{
+			PostId: postID.String(),
+			Count:  counts[postID],
+		}
+	}
+
+	return &pb.GetLikesCountsResponse{Counts: pbCounts}, nil
+}
+
+// ExportMyData returns the caller's likes as a JSON document
+func (h *LikeHandler) ExportMyData(ctx context.Context, req *pb.ExportMyDataRequest) (*pb.DataExport, error) {
+	raw, err := interceptor.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "user not authenticated")
+	}
+	userID, err := uuid.Parse(raw)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "invalid user ID in token")
+	}
+
+	likes, err := h.likeRepo.GetLikesByUser(ctx, userID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to export likes: %v", err))
+	}
+
+	data, err := json.Marshal(map[string]interface{}{"likes": likes})
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to encode export: %v", err))
+	}
+	return &pb.DataExport{Data: data}, nil
+}
+
+// publishPostLiked announces a new like so its post's author can be notified.
+// The like itself is already stored, so a failure only costs the
+// notification.
+func (h *LikeHandler) publishPostLiked(ctx context.Context, likeID, postID, userID uuid.UUID, postAuthorID string) {
+	authorID, err := uuid.Parse(postAuthorID)
+	if err != nil {
+		logging.FromContext(ctx).Error().Err(err).Stringer("post_id", postID).Msg("invalid author ID on post")
+		return
+	}
+
+	event := events.PostLikedEvent{
+		LikeID:       likeID,
+		PostID:       postID,
+		PostAuthorID: authorID,
+		UserID:       userID,
+		CreatedAt:    time.Now(),
+	}
+	if err := h.publisher.PublishPostLiked(ctx, event); err != nil {
+		logging.FromContext(ctx).Error().Err(err).Msg("failed to publish post liked event")
+	}
+}
+
+// viewerID is the user a read is made for, from its verified token, or nil
+// for anonymous reads. The requesting_user_id of requests is ignored, as any
+// caller could set it.
+func viewerID(ctx context.Context) *uuid.UUID {
+	raw, err := interceptor.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil
+	}
+	id, err := uuid.Parse(raw)
+	if err != nil {
+		return nil
+	}
+	return &id
+}
+
+// visiblePost looks a post up in post-service as the caller. Posts the caller
+// may not see are reported as not found, so their likes are neither shown nor
+// accepted.
+func (h *LikeHandler) visiblePost(ctx context.Context, postID uuid.UUID) (*postpb.Post, error) {
+	post, err := h.posts.GetPost(forwardToken(ctx), &postpb.GetPostRequest{PostId: postID.String()})
+	switch status.Code(err) {
+	case codes.OK:
+		return post, nil
+	case codes.NotFound, codes.PermissionDenied:
+		return nil, status.Error(codes.NotFound, "post not found")
+	default:
+		return nil, status.Error(codes.Unavailable, fmt.Sprintf("failed to look up post: %v", err))
+	}
+}
+
+// forwardToken passes the caller's token on to post-service, which only
+// shows a post to the user the token is for
+func forwardToken(ctx context.Context) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get("authorization"); len(values) > 0 {
+		return metadata.AppendToOutgoingContext(ctx, "authorization", values[0])
+	}
+	return ctx
+}
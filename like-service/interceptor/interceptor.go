@@ -3,6 +3,7 @@ package interceptor
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -10,6 +11,8 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+
+	"shared/logging"
 )
 
 // ContextKey type for context keys
@@ -17,39 +20,73 @@ type ContextKey string
 
 const (
 	UserIDKey ContextKey = "user_id"
+	RolesKey  ContextKey = "roles"
+
+	// RoleUser is carried by every account, RoleAdmin by administrators
+	RoleUser  = "USER"
+	RoleAdmin = "ADMIN"
 )
 
+// Observer is called with the user of every authenticated request before it
+// is handled. It must be quick, as it delays the request.
+type Observer func(ctx context.Context, userID string)
+
 // AuthInterceptor provides gRPC interceptor for JWT authentication
 type AuthInterceptor struct {
-	jwtSecret     string
+	keys          Keys
 	publicMethods map[string]bool
+	// methodRoles holds the roles of which a caller needs one, by method
+	methodRoles map[string][]string
+	observers   []Observer
 }
 
-// NewAuthInterceptor creates a new auth interceptor with public methods
-func NewAuthInterceptor(jwtSecret string, publicMethods []string) *AuthInterceptor {
+// NewAuthInterceptor creates a new auth interceptor verifying tokens with keys,
+// with public methods
+func NewAuthInterceptor(keys Keys, publicMethods []string) *AuthInterceptor {
 	methodMap := make(map[string]bool)
 	for _, method := range publicMethods {
 		methodMap[method] = true
 	}
 
 	return &AuthInterceptor{
-		jwtSecret:     jwtSecret,
+		keys:          keys,
 		publicMethods: methodMap,
+		methodRoles:   make(map[string][]string),
 	}
 }
 
-// AddPublicMethod adds a method that doesn't require authentication
+// AddPublicMethod adds a method that doesn't require authentication. A
+// token sent to it is still verified, and a request with an invalid one is
+// refused, so handlers can tell who a request is made for.
 func (interceptor *AuthInterceptor) AddPublicMethod(method string) {
 	interceptor.publicMethods[method] = true
 }
 
-// AddPublicMethods adds multiple methods that don't require authentication
+// AddPublicMethods adds multiple methods that don't require authentication, as
+// AddPublicMethod
 func (interceptor *AuthInterceptor) AddPublicMethods(methods []string) {
 	for _, method := range methods {
 		interceptor.publicMethods[method] = true
 	}
 }
 
+// RequireRoles makes methods require a token carrying at least one of roles
+func (interceptor *AuthInterceptor) RequireRoles(methods []string, roles ...string) {
+	for _, method := range methods {
+		interceptor.methodRoles[method] = roles
+	}
+}
+
+// AddAdminMethods adds methods that require a token carrying the ADMIN role
+func (interceptor *AuthInterceptor) AddAdminMethods(methods []string) {
+	interceptor.RequireRoles(methods, RoleAdmin)
+}
+
+// AddObserver registers an observer of authenticated requests
+func (interceptor *AuthInterceptor) AddObserver(observer Observer) {
+	interceptor.observers = append(interceptor.observers, observer)
+}
+
 // Unary returns a server interceptor function to authenticate and authorize unary RPC
 func (interceptor *AuthInterceptor) Unary() grpc.UnaryServerInterceptor {
 	return func(
@@ -58,16 +95,19 @@ func (interceptor *AuthInterceptor) Unary() grpc.UnaryServerInterceptor {
 		info *grpc.UnaryServerInfo,
 		handler grpc.UnaryHandler,
 	) (interface{}, error) {
-		if interceptor.publicMethods[info.FullMethod] {
+		if interceptor.publicMethods[info.FullMethod] && !hasToken(ctx) {
 			return handler(ctx, req)
 		}
 
-		userID, err := interceptor.authorize(ctx)
+		claims, err := interceptor.authorize(ctx, info.FullMethod)
 		if err != nil {
 			return nil, err
 		}
 
-		ctx = context.WithValue(ctx, UserIDKey, userID)
+		ctx = context.WithValue(ctx, UserIDKey, claims.UserID)
+		ctx = context.WithValue(ctx, RolesKey, claims.Roles)
+		ctx = logging.WithUser(ctx, claims.UserID)
+		interceptor.observe(ctx, claims.UserID)
 
 		return handler(ctx, req)
 	}
@@ -81,16 +121,19 @@ func (interceptor *AuthInterceptor) Stream() grpc.StreamServerInterceptor {
 		info *grpc.StreamServerInfo,
 		handler grpc.StreamHandler,
 	) error {
-		if interceptor.publicMethods[info.FullMethod] {
+		if interceptor.publicMethods[info.FullMethod] && !hasToken(stream.Context()) {
 			return handler(srv, stream)
 		}
 
-		userID, err := interceptor.authorize(stream.Context())
+		claims, err := interceptor.authorize(stream.Context(), info.FullMethod)
 		if err != nil {
 			return err
 		}
 
-		ctx := context.WithValue(stream.Context(), UserIDKey, userID)
+		ctx := context.WithValue(stream.Context(), UserIDKey, claims.UserID)
+		ctx = context.WithValue(ctx, RolesKey, claims.Roles)
+		ctx = logging.WithUser(ctx, claims.UserID)
+		interceptor.observe(ctx, claims.UserID)
 		wrappedStream := &wrappedStream{
 			ServerStream: stream,
 			ctx:          ctx,
@@ -100,40 +143,52 @@ func (interceptor *AuthInterceptor) Stream() grpc.StreamServerInterceptor {
 	}
 }
 
-// authorize verifies the JWT token and returns the user ID
-func (interceptor *AuthInterceptor) authorize(ctx context.Context) (string, error) {
+func (interceptor *AuthInterceptor) observe(ctx context.Context, userID string) {
+	for _, observer := range interceptor.observers {
+		observer(ctx, userID)
+	}
+}
+
+// hasToken reports whether the request carries an authorization token
+func hasToken(ctx context.Context) bool {
+	md, _ := metadata.FromIncomingContext(ctx)
+	return len(md.Get("authorization")) > 0
+}
+
+// authorize verifies the JWT token, enforces the roles the method requires and
+// returns the token claims
+func (interceptor *AuthInterceptor) authorize(ctx context.Context, method string) (*Claims, error) {
 	md, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
-		return "", status.Error(codes.Unauthenticated, "metadata is not provided")
+		return nil, status.Error(codes.Unauthenticated, "metadata is not provided")
 	}
 
 	values := md["authorization"]
 	if len(values) == 0 {
-		return "", status.Error(codes.Unauthenticated, "authorization token is not provided")
+		return nil, status.Error(codes.Unauthenticated, "authorization token is not provided")
 	}
 
 	token := values[0]
 	if !strings.HasPrefix(token, "Bearer ") {
-		return "", status.Error(codes.Unauthenticated, "invalid authorization format")
+		return nil, status.Error(codes.Unauthenticated, "invalid authorization format")
 	}
 	token = strings.TrimPrefix(token, "Bearer ")
 
 	claims, err := interceptor.verifyToken(token)
 	if err != nil {
-		return "", status.Error(codes.Unauthenticated, fmt.Sprintf("invalid token: %v", err))
+		return nil, status.Error(codes.Unauthenticated, fmt.Sprintf("invalid token: %v", err))
 	}
 
-	return claims.UserID, nil
+	if roles, ok := interceptor.methodRoles[method]; ok && !hasAnyRole(claims.Roles, roles) {
+		return nil, status.Error(codes.PermissionDenied, strings.ToLower(strings.Join(roles, " or "))+" role required")
+	}
+
+	return claims, nil
 }
 
 // verifyToken verifies the JWT token and extracts claims
 func (interceptor *AuthInterceptor) verifyToken(tokenString string) (*Claims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return []byte(interceptor.jwtSecret), nil
-	})
+	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, interceptor.keys.keyFunc)
 
 	if err != nil {
 		return nil, err
@@ -147,9 +202,19 @@ func (interceptor *AuthInterceptor) verifyToken(tokenString string) (*Claims, er
 	return claims, nil
 }
 
+func hasAnyRole(have, want []string) bool {
+	for _, role := range want {
+		if slices.Contains(have, role) {
+			return true
+		}
+	}
+	return false
+}
+
 // Claims represents JWT claims
 type Claims struct {
-	UserID string `json:"user_id"`
+	UserID string   `json:"user_id"`
+	Roles  []string `json:"roles"`
 	jwt.RegisteredClaims
 }
 
@@ -171,3 +236,24 @@ func GetUserIDFromContext(ctx context.Context) (string, error) {
 	}
 	return userID, nil
 }
+
+// GetRolesFromContext extracts the caller's roles from context
+func GetRolesFromContext(ctx context.Context) []string {
+	roles, _ := ctx.Value(RolesKey).([]string)
+	return roles
+}
+
+// GetUserIDAndRoles extracts the caller's user ID and roles from context, for
+// handlers letting either the owner of a resource or an admin act on it
+func GetUserIDAndRoles(ctx context.Context) (string, []string, error) {
+	userID, err := GetUserIDFromContext(ctx)
+	if err != nil {
+		return "", nil, err
+	}
+	return userID, GetRolesFromContext(ctx), nil
+}
+
+// HasRole reports whether the caller's token carries role
+func HasRole(ctx context.Context, role string) bool {
+	return slices.Contains(GetRolesFromContext(ctx), role)
+}
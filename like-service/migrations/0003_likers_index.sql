@@ -0,0 +1,5 @@
+-- ========================================
+-- Likers Index
+-- ========================================
+-- Pages through the likers of a post, most recent first, for GetPostLikers
+CREATE INDEX IF NOT EXISTS idx_like_service_likes_post_created ON like_service_likes(post_id, created_at DESC, id DESC);
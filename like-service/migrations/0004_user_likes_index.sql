@@ -0,0 +1,6 @@
+-- ========================================
+-- Liked Posts Index
+-- ========================================
+-- Pages through the posts a user liked, most recent first, for
+-- GetUserLikedPosts
+CREATE INDEX IF NOT EXISTS idx_like_service_likes_user_created ON like_service_likes(user_id, created_at DESC, id DESC);
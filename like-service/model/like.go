@@ -23,3 +23,40 @@ type PostLikeStatus struct {
 	PostID  uuid.UUID `json:"post_id"`
 	IsLiked bool      `json:"is_liked"`
 }
+
+// LikerEdge is a user who liked a post, positioned by when they did
+type LikerEdge struct {
+	Cursor  string    `json:"cursor"`
+	UserID  uuid.UUID `json:"user_id"`
+	LikedAt time.Time `json:"liked_at"`
+}
+
+type PageInfo struct {
+	EndCursor       *string `json:"end_cursor,omitempty"`
+	HasNextPage     bool    `json:"has_next_page"`
+	StartCursor     *string `json:"start_cursor,omitempty"`
+	HasPreviousPage bool    `json:"has_previous_page"`
+}
+
+// LikerConnection is a page of the likers of a post, with the number of
+// likes the post has
+type LikerConnection struct {
+	Edges      []LikerEdge `json:"edges"`
+	PageInfo   PageInfo    `json:"page_info"`
+	TotalCount int32       `json:"total_count"`
+}
+
+// LikedPostEdge is a post a user liked, positioned by when they liked it
+type LikedPostEdge struct {
+	Cursor  string    `json:"cursor"`
+	PostID  uuid.UUID `json:"post_id"`
+	LikedAt time.Time `json:"liked_at"`
+}
+
+// LikedPostConnection is a page of the posts a user liked, with the number
+// of likes the user has made
+type LikedPostConnection struct {
+	Edges      []LikedPostEdge `json:"edges"`
+	PageInfo   PageInfo        `json:"page_info"`
+	TotalCount int32           `json:"total_count"`
+}
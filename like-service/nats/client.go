@@ -0,0 +1,155 @@
+package nats
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/nats-io/nats.go"
+
+	"shared/chaos"
+	"shared/logging"
+	"shared/tracing"
+)
+
+type Config struct {
+	URL           string
+	MaxReconnects int
+	ReconnectWait time.Duration
+	ClientID      string
+	Chaos         *chaos.Injector
+}
+
+type Client struct {
+	conn  *nats.Conn
+	js    nats.JetStreamContext
+	chaos *chaos.Injector
+}
+
+func NewClient(cfg Config) (*Client, error) {
+	opts := []nats.Option{
+		nats.MaxReconnects(cfg.MaxReconnects),
+		nats.ReconnectWait(cfg.ReconnectWait),
+		nats.DisconnectErrHandler(func(nc *nats.Conn, err error) {
+			if err != nil {
+				log.Printf("NATS disconnected: %v", err)
+			}
+		}),
+		nats.ReconnectHandler(func(nc *nats.Conn) {
+			log.Printf("NATS reconnected to %s", nc.ConnectedUrl())
+		}),
+	}
+
+	conn, err := nats.Connect(cfg.URL, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	js, err := conn.JetStream()
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to create JetStream context: %w", err)
+	}
+
+	return &Client{conn: conn, js: js, chaos: cfg.Chaos}, nil
+}
+
+// Publish sends data on subject, carrying the trace of ctx in its headers. A
+// message dropped by chaos injection is reported as sent, as a message lost
+// in transit would be.
+func (c *Client) Publish(ctx context.Context, subject string, data []byte) error {
+	if c.chaos.Drop(subject) {
+		return nil
+	}
+
+	msg := &nats.Msg{Subject: subject, Data: data, Header: nats.Header{}}
+	logging.Inject(ctx, msg.Header)
+	span := tracing.StartPublish(ctx, subject, msg.Header)
+	defer span.End()
+
+	return c.conn.PublishMsg(msg)
+}
+
+// PublishDurable sends data on subject to JetStream and waits until a stream
+// has stored it. msgID identifies the event: the stream discards a copy sent
+// again within its duplicate window, and consumers use it to apply the event
+// once. A message dropped by chaos injection is reported as not
+// acknowledged.
+func (c *Client) PublishDurable(ctx context.Context, subject string, data []byte, msgID string) error {
+	if c.chaos.Drop(subject) {
+		return errors.New("message dropped by chaos injection")
+	}
+
+	msg := &nats.Msg{Subject: subject, Data: data, Header: nats.Header{}}
+	logging.Inject(ctx, msg.Header)
+	span := tracing.StartPublish(ctx, subject, msg.Header)
+	defer span.End()
+
+	_, err := c.js.PublishMsg(msg, nats.MsgId(msgID), nats.Context(ctx))
+	return err
+}
+
+// SubscribeDurable consumes subject from its JetStream stream through the
+// durable consumer durableName, shared by the members of queueGroup. Messages
+// are redelivered until acknowledged, up to 3 times. A message dropped by
+// chaos injection is not acknowledged, so it is redelivered after AckWait.
+func (c *Client) SubscribeDurable(subject, durableName, queueGroup string, handler nats.MsgHandler) (*nats.Subscription, error) {
+	sub, err := c.js.QueueSubscribe(
+		subject,
+		queueGroup,
+		func(msg *nats.Msg) {
+			if c.chaos.Drop(msg.Subject) {
+				return
+			}
+			handler(msg)
+		},
+		nats.Durable(durableName),
+		nats.ManualAck(),
+		nats.AckExplicit(),
+		nats.MaxDeliver(3),
+		nats.AckWait(30*time.Second),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create durable subscription to %s: %w", subject, err)
+	}
+
+	log.Printf("Durable subscription created: %s (durable: %s, queue: %s)", subject, durableName, queueGroup)
+	return sub, nil
+}
+
+func (c *Client) Close() {
+	if c.conn != nil {
+		c.conn.Close()
+	}
+}
+
+// Drain stops delivering messages to subscriptions, waits for the handlers
+// still running and for buffered publishes to be sent, and then closes the
+// connection. It closes the connection at once when ctx is done first.
+func (c *Client) Drain(ctx context.Context) error {
+	if err := c.conn.Drain(); err != nil {
+		c.conn.Close()
+		return err
+	}
+	for !c.conn.IsClosed() {
+		select {
+		case <-ctx.Done():
+			c.conn.Close()
+			return ctx.Err()
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+	return nil
+}
+
+// HealthCheck makes a round trip to the NATS server
+func (c *Client) HealthCheck(ctx context.Context) error {
+	return c.conn.FlushWithContext(ctx)
+}
+
+func DecodeEvent(msg *nats.Msg, v interface{}) error {
+	return json.Unmarshal(msg.Data, v)
+}
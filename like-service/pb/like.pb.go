@@ -9,6 +9,7 @@ package __
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -126,10 +127,11 @@ func (x *UnlikePostRequest) GetUserId() string {
 }
 
 type GetPostLikesRequest struct {
-	state             protoimpl.MessageState `protogen:"open.v1"`
-	PostId            string                 `protobuf:"bytes,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
-	RequestingUserId  *string                `protobuf:"bytes,2,opt,name=requesting_user_id,json=requestingUserId,proto3,oneof" json:"requesting_user_id,omitempty"`
-	RecentLikersLimit int32                  `protobuf:"varint,3,opt,name=recent_likers_limit,json=recentLikersLimit,proto3" json:"recent_likers_limit,omitempty"` // Default 5
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	PostId string                 `protobuf:"bytes,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
+	// Ignored: the viewer is the user of the request's token, if any
+	RequestingUserId  *string `protobuf:"bytes,2,opt,name=requesting_user_id,json=requestingUserId,proto3,oneof" json:"requesting_user_id,omitempty"`
+	RecentLikersLimit int32   `protobuf:"varint,3,opt,name=recent_likers_limit,json=recentLikersLimit,proto3" json:"recent_likers_limit,omitempty"` // Default 5
 	unknownFields     protoimpl.UnknownFields
 	sizeCache         protoimpl.SizeCache
 }
@@ -185,6 +187,434 @@ func (x *GetPostLikesRequest) GetRecentLikersLimit() int32 {
 	return 0
 }
 
+type GetPostLikersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PostId        string                 `protobuf:"bytes,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
+	First         int32                  `protobuf:"varint,2,opt,name=first,proto3" json:"first,omitempty"` // Default 20, at most 100
+	After         *string                `protobuf:"bytes,3,opt,name=after,proto3,oneof" json:"after,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPostLikersRequest) Reset() {
+	*x = GetPostLikersRequest{}
+	mi := &file_proto_like_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPostLikersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPostLikersRequest) ProtoMessage() {}
+
+func (x *GetPostLikersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_like_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPostLikersRequest.ProtoReflect.Descriptor instead.
+func (*GetPostLikersRequest) Descriptor() ([]byte, []int) {
+	return file_proto_like_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetPostLikersRequest) GetPostId() string {
+	if x != nil {
+		return x.PostId
+	}
+	return ""
+}
+
+func (x *GetPostLikersRequest) GetFirst() int32 {
+	if x != nil {
+		return x.First
+	}
+	return 0
+}
+
+func (x *GetPostLikersRequest) GetAfter() string {
+	if x != nil && x.After != nil {
+		return *x.After
+	}
+	return ""
+}
+
+type LikerEdge struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cursor        string                 `protobuf:"bytes,1,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	LikedAt       *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=liked_at,json=likedAt,proto3" json:"liked_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LikerEdge) Reset() {
+	*x = LikerEdge{}
+	mi := &file_proto_like_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LikerEdge) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LikerEdge) ProtoMessage() {}
+
+func (x *LikerEdge) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_like_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LikerEdge.ProtoReflect.Descriptor instead.
+func (*LikerEdge) Descriptor() ([]byte, []int) {
+	return file_proto_like_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *LikerEdge) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *LikerEdge) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *LikerEdge) GetLikedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LikedAt
+	}
+	return nil
+}
+
+type PageInfo struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	EndCursor       *string                `protobuf:"bytes,1,opt,name=end_cursor,json=endCursor,proto3,oneof" json:"end_cursor,omitempty"`
+	HasNextPage     bool                   `protobuf:"varint,2,opt,name=has_next_page,json=hasNextPage,proto3" json:"has_next_page,omitempty"`
+	StartCursor     *string                `protobuf:"bytes,3,opt,name=start_cursor,json=startCursor,proto3,oneof" json:"start_cursor,omitempty"`
+	HasPreviousPage bool                   `protobuf:"varint,4,opt,name=has_previous_page,json=hasPreviousPage,proto3" json:"has_previous_page,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *PageInfo) Reset() {
+	*x = PageInfo{}
+	mi := &file_proto_like_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PageInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PageInfo) ProtoMessage() {}
+
+func (x *PageInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_like_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PageInfo.ProtoReflect.Descriptor instead.
+func (*PageInfo) Descriptor() ([]byte, []int) {
+	return file_proto_like_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *PageInfo) GetEndCursor() string {
+	if x != nil && x.EndCursor != nil {
+		return *x.EndCursor
+	}
+	return ""
+}
+
+func (x *PageInfo) GetHasNextPage() bool {
+	if x != nil {
+		return x.HasNextPage
+	}
+	return false
+}
+
+func (x *PageInfo) GetStartCursor() string {
+	if x != nil && x.StartCursor != nil {
+		return *x.StartCursor
+	}
+	return ""
+}
+
+func (x *PageInfo) GetHasPreviousPage() bool {
+	if x != nil {
+		return x.HasPreviousPage
+	}
+	return false
+}
+
+type LikerConnection struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Edges         []*LikerEdge           `protobuf:"bytes,1,rep,name=edges,proto3" json:"edges,omitempty"`
+	PageInfo      *PageInfo              `protobuf:"bytes,2,opt,name=page_info,json=pageInfo,proto3" json:"page_info,omitempty"`
+	TotalCount    int32                  `protobuf:"varint,3,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"` // Likes of the post, across every page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LikerConnection) Reset() {
+	*x = LikerConnection{}
+	mi := &file_proto_like_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LikerConnection) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LikerConnection) ProtoMessage() {}
+
+func (x *LikerConnection) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_like_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LikerConnection.ProtoReflect.Descriptor instead.
+func (*LikerConnection) Descriptor() ([]byte, []int) {
+	return file_proto_like_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *LikerConnection) GetEdges() []*LikerEdge {
+	if x != nil {
+		return x.Edges
+	}
+	return nil
+}
+
+func (x *LikerConnection) GetPageInfo() *PageInfo {
+	if x != nil {
+		return x.PageInfo
+	}
+	return nil
+}
+
+func (x *LikerConnection) GetTotalCount() int32 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+type GetUserLikedPostsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	First         int32                  `protobuf:"varint,2,opt,name=first,proto3" json:"first,omitempty"` // Default 20, at most 100
+	After         *string                `protobuf:"bytes,3,opt,name=after,proto3,oneof" json:"after,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserLikedPostsRequest) Reset() {
+	*x = GetUserLikedPostsRequest{}
+	mi := &file_proto_like_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserLikedPostsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserLikedPostsRequest) ProtoMessage() {}
+
+func (x *GetUserLikedPostsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_like_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserLikedPostsRequest.ProtoReflect.Descriptor instead.
+func (*GetUserLikedPostsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_like_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetUserLikedPostsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetUserLikedPostsRequest) GetFirst() int32 {
+	if x != nil {
+		return x.First
+	}
+	return 0
+}
+
+func (x *GetUserLikedPostsRequest) GetAfter() string {
+	if x != nil && x.After != nil {
+		return *x.After
+	}
+	return ""
+}
+
+type LikedPostEdge struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cursor        string                 `protobuf:"bytes,1,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	PostId        string                 `protobuf:"bytes,2,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
+	LikedAt       *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=liked_at,json=likedAt,proto3" json:"liked_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LikedPostEdge) Reset() {
+	*x = LikedPostEdge{}
+	mi := &file_proto_like_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LikedPostEdge) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LikedPostEdge) ProtoMessage() {}
+
+func (x *LikedPostEdge) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_like_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LikedPostEdge.ProtoReflect.Descriptor instead.
+func (*LikedPostEdge) Descriptor() ([]byte, []int) {
+	return file_proto_like_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *LikedPostEdge) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *LikedPostEdge) GetPostId() string {
+	if x != nil {
+		return x.PostId
+	}
+	return ""
+}
+
+func (x *LikedPostEdge) GetLikedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LikedAt
+	}
+	return nil
+}
+
+type LikedPostConnection struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Edges         []*LikedPostEdge       `protobuf:"bytes,1,rep,name=edges,proto3" json:"edges,omitempty"`
+	PageInfo      *PageInfo              `protobuf:"bytes,2,opt,name=page_info,json=pageInfo,proto3" json:"page_info,omitempty"`
+	TotalCount    int32                  `protobuf:"varint,3,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"` // Likes of the user, across every page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LikedPostConnection) Reset() {
+	*x = LikedPostConnection{}
+	mi := &file_proto_like_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LikedPostConnection) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LikedPostConnection) ProtoMessage() {}
+
+func (x *LikedPostConnection) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_like_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LikedPostConnection.ProtoReflect.Descriptor instead.
+func (*LikedPostConnection) Descriptor() ([]byte, []int) {
+	return file_proto_like_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *LikedPostConnection) GetEdges() []*LikedPostEdge {
+	if x != nil {
+		return x.Edges
+	}
+	return nil
+}
+
+func (x *LikedPostConnection) GetPageInfo() *PageInfo {
+	if x != nil {
+		return x.PageInfo
+	}
+	return nil
+}
+
+func (x *LikedPostConnection) GetTotalCount() int32 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
 type IsPostLikedByUserRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	PostId        string                 `protobuf:"bytes,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
@@ -195,7 +625,7 @@ type IsPostLikedByUserRequest struct {
 
 func (x *IsPostLikedByUserRequest) Reset() {
 	*x = IsPostLikedByUserRequest{}
-	mi := &file_proto_like_proto_msgTypes[3]
+	mi := &file_proto_like_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -207,7 +637,7 @@ func (x *IsPostLikedByUserRequest) String() string {
 func (*IsPostLikedByUserRequest) ProtoMessage() {}
 
 func (x *IsPostLikedByUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_like_proto_msgTypes[3]
+	mi := &file_proto_like_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -220,7 +650,7 @@ func (x *IsPostLikedByUserRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use IsPostLikedByUserRequest.ProtoReflect.Descriptor instead.
 func (*IsPostLikedByUserRequest) Descriptor() ([]byte, []int) {
-	return file_proto_like_proto_rawDescGZIP(), []int{3}
+	return file_proto_like_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *IsPostLikedByUserRequest) GetPostId() string {
@@ -246,7 +676,7 @@ type IsPostLikedByUserResponse struct {
 
 func (x *IsPostLikedByUserResponse) Reset() {
 	*x = IsPostLikedByUserResponse{}
-	mi := &file_proto_like_proto_msgTypes[4]
+	mi := &file_proto_like_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -258,7 +688,7 @@ func (x *IsPostLikedByUserResponse) String() string {
 func (*IsPostLikedByUserResponse) ProtoMessage() {}
 
 func (x *IsPostLikedByUserResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_like_proto_msgTypes[4]
+	mi := &file_proto_like_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -271,7 +701,7 @@ func (x *IsPostLikedByUserResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use IsPostLikedByUserResponse.ProtoReflect.Descriptor instead.
 func (*IsPostLikedByUserResponse) Descriptor() ([]byte, []int) {
-	return file_proto_like_proto_rawDescGZIP(), []int{4}
+	return file_proto_like_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *IsPostLikedByUserResponse) GetIsLiked() bool {
@@ -291,7 +721,7 @@ type GetPostLikesByUsersRequest struct {
 
 func (x *GetPostLikesByUsersRequest) Reset() {
 	*x = GetPostLikesByUsersRequest{}
-	mi := &file_proto_like_proto_msgTypes[5]
+	mi := &file_proto_like_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -303,7 +733,7 @@ func (x *GetPostLikesByUsersRequest) String() string {
 func (*GetPostLikesByUsersRequest) ProtoMessage() {}
 
 func (x *GetPostLikesByUsersRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_like_proto_msgTypes[5]
+	mi := &file_proto_like_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -316,7 +746,7 @@ func (x *GetPostLikesByUsersRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetPostLikesByUsersRequest.ProtoReflect.Descriptor instead.
 func (*GetPostLikesByUsersRequest) Descriptor() ([]byte, []int) {
-	return file_proto_like_proto_rawDescGZIP(), []int{5}
+	return file_proto_like_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *GetPostLikesByUsersRequest) GetPostIds() []string {
@@ -343,7 +773,7 @@ type PostLikeStatus struct {
 
 func (x *PostLikeStatus) Reset() {
 	*x = PostLikeStatus{}
-	mi := &file_proto_like_proto_msgTypes[6]
+	mi := &file_proto_like_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -355,7 +785,7 @@ func (x *PostLikeStatus) String() string {
 func (*PostLikeStatus) ProtoMessage() {}
 
 func (x *PostLikeStatus) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_like_proto_msgTypes[6]
+	mi := &file_proto_like_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -368,7 +798,7 @@ func (x *PostLikeStatus) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PostLikeStatus.ProtoReflect.Descriptor instead.
 func (*PostLikeStatus) Descriptor() ([]byte, []int) {
-	return file_proto_like_proto_rawDescGZIP(), []int{6}
+	return file_proto_like_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *PostLikeStatus) GetPostId() string {
@@ -394,7 +824,7 @@ type GetPostLikesByUsersResponse struct {
 
 func (x *GetPostLikesByUsersResponse) Reset() {
 	*x = GetPostLikesByUsersResponse{}
-	mi := &file_proto_like_proto_msgTypes[7]
+	mi := &file_proto_like_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -406,7 +836,7 @@ func (x *GetPostLikesByUsersResponse) String() string {
 func (*GetPostLikesByUsersResponse) ProtoMessage() {}
 
 func (x *GetPostLikesByUsersResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_like_proto_msgTypes[7]
+	mi := &file_proto_like_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -419,7 +849,7 @@ func (x *GetPostLikesByUsersResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetPostLikesByUsersResponse.ProtoReflect.Descriptor instead.
 func (*GetPostLikesByUsersResponse) Descriptor() ([]byte, []int) {
-	return file_proto_like_proto_rawDescGZIP(), []int{7}
+	return file_proto_like_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *GetPostLikesByUsersResponse) GetLikes() []*PostLikeStatus {
@@ -429,6 +859,146 @@ func (x *GetPostLikesByUsersResponse) GetLikes() []*PostLikeStatus {
 	return nil
 }
 
+type GetLikesCountsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PostIds       []string               `protobuf:"bytes,1,rep,name=post_ids,json=postIds,proto3" json:"post_ids,omitempty"` // At most 1000
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLikesCountsRequest) Reset() {
+	*x = GetLikesCountsRequest{}
+	mi := &file_proto_like_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLikesCountsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLikesCountsRequest) ProtoMessage() {}
+
+func (x *GetLikesCountsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_like_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLikesCountsRequest.ProtoReflect.Descriptor instead.
+func (*GetLikesCountsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_like_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetLikesCountsRequest) GetPostIds() []string {
+	if x != nil {
+		return x.PostIds
+	}
+	return nil
+}
+
+type PostLikesCount struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PostId        string                 `protobuf:"bytes,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
+	Count         int32                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PostLikesCount) Reset() {
+	*x = PostLikesCount{}
+	mi := &file_proto_like_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PostLikesCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostLikesCount) ProtoMessage() {}
+
+func (x *PostLikesCount) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_like_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostLikesCount.ProtoReflect.Descriptor instead.
+func (*PostLikesCount) Descriptor() ([]byte, []int) {
+	return file_proto_like_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *PostLikesCount) GetPostId() string {
+	if x != nil {
+		return x.PostId
+	}
+	return ""
+}
+
+func (x *PostLikesCount) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type GetLikesCountsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Counts        []*PostLikesCount      `protobuf:"bytes,1,rep,name=counts,proto3" json:"counts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLikesCountsResponse) Reset() {
+	*x = GetLikesCountsResponse{}
+	mi := &file_proto_like_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLikesCountsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLikesCountsResponse) ProtoMessage() {}
+
+func (x *GetLikesCountsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_like_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLikesCountsResponse.ProtoReflect.Descriptor instead.
+func (*GetLikesCountsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_like_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetLikesCountsResponse) GetCounts() []*PostLikesCount {
+	if x != nil {
+		return x.Counts
+	}
+	return nil
+}
+
 type LikeInfo struct {
 	state                protoimpl.MessageState `protogen:"open.v1"`
 	Count                int32                  `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
@@ -440,7 +1010,7 @@ type LikeInfo struct {
 
 func (x *LikeInfo) Reset() {
 	*x = LikeInfo{}
-	mi := &file_proto_like_proto_msgTypes[8]
+	mi := &file_proto_like_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -452,7 +1022,7 @@ func (x *LikeInfo) String() string {
 func (*LikeInfo) ProtoMessage() {}
 
 func (x *LikeInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_like_proto_msgTypes[8]
+	mi := &file_proto_like_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -465,7 +1035,7 @@ func (x *LikeInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LikeInfo.ProtoReflect.Descriptor instead.
 func (*LikeInfo) Descriptor() ([]byte, []int) {
-	return file_proto_like_proto_rawDescGZIP(), []int{8}
+	return file_proto_like_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *LikeInfo) GetCount() int32 {
@@ -499,7 +1069,7 @@ type Response struct {
 
 func (x *Response) Reset() {
 	*x = Response{}
-	mi := &file_proto_like_proto_msgTypes[9]
+	mi := &file_proto_like_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -511,7 +1081,7 @@ func (x *Response) String() string {
 func (*Response) ProtoMessage() {}
 
 func (x *Response) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_like_proto_msgTypes[9]
+	mi := &file_proto_like_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -524,7 +1094,7 @@ func (x *Response) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Response.ProtoReflect.Descriptor instead.
 func (*Response) Descriptor() ([]byte, []int) {
-	return file_proto_like_proto_rawDescGZIP(), []int{9}
+	return file_proto_like_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *Response) GetSuccess() bool {
@@ -541,11 +1111,92 @@ func (x *Response) GetMessage() string {
 	return ""
 }
 
+// ExportMyData exports the data the service holds on the caller
+type ExportMyDataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportMyDataRequest) Reset() {
+	*x = ExportMyDataRequest{}
+	mi := &file_proto_like_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportMyDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportMyDataRequest) ProtoMessage() {}
+
+func (x *ExportMyDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_like_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportMyDataRequest.ProtoReflect.Descriptor instead.
+func (*ExportMyDataRequest) Descriptor() ([]byte, []int) {
+	return file_proto_like_proto_rawDescGZIP(), []int{20}
+}
+
+type DataExport struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"` // JSON document
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DataExport) Reset() {
+	*x = DataExport{}
+	mi := &file_proto_like_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DataExport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DataExport) ProtoMessage() {}
+
+func (x *DataExport) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_like_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DataExport.ProtoReflect.Descriptor instead.
+func (*DataExport) Descriptor() ([]byte, []int) {
+	return file_proto_like_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *DataExport) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
 var File_proto_like_proto protoreflect.FileDescriptor
 
 const file_proto_like_proto_rawDesc = "" +
 	"\n" +
-	"\x10proto/like.proto\x12\x04like\"C\n" +
+	"\x10proto/like.proto\x12\x04like\x1a\x1fgoogle/protobuf/timestamp.proto\"C\n" +
 	"\x0fLikePostRequest\x12\x17\n" +
 	"\apost_id\x18\x01 \x01(\tR\x06postId\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\tR\x06userId\"E\n" +
@@ -556,7 +1207,43 @@ const file_proto_like_proto_rawDesc = "" +
 	"\apost_id\x18\x01 \x01(\tR\x06postId\x121\n" +
 	"\x12requesting_user_id\x18\x02 \x01(\tH\x00R\x10requestingUserId\x88\x01\x01\x12.\n" +
 	"\x13recent_likers_limit\x18\x03 \x01(\x05R\x11recentLikersLimitB\x15\n" +
-	"\x13_requesting_user_id\"L\n" +
+	"\x13_requesting_user_id\"j\n" +
+	"\x14GetPostLikersRequest\x12\x17\n" +
+	"\apost_id\x18\x01 \x01(\tR\x06postId\x12\x14\n" +
+	"\x05first\x18\x02 \x01(\x05R\x05first\x12\x19\n" +
+	"\x05after\x18\x03 \x01(\tH\x00R\x05after\x88\x01\x01B\b\n" +
+	"\x06_after\"s\n" +
+	"\tLikerEdge\x12\x16\n" +
+	"\x06cursor\x18\x01 \x01(\tR\x06cursor\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x125\n" +
+	"\bliked_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\alikedAt\"\xc6\x01\n" +
+	"\bPageInfo\x12\"\n" +
+	"\n" +
+	"end_cursor\x18\x01 \x01(\tH\x00R\tendCursor\x88\x01\x01\x12\"\n" +
+	"\rhas_next_page\x18\x02 \x01(\bR\vhasNextPage\x12&\n" +
+	"\fstart_cursor\x18\x03 \x01(\tH\x01R\vstartCursor\x88\x01\x01\x12*\n" +
+	"\x11has_previous_page\x18\x04 \x01(\bR\x0fhasPreviousPageB\r\n" +
+	"\v_end_cursorB\x0f\n" +
+	"\r_start_cursor\"\x86\x01\n" +
+	"\x0fLikerConnection\x12%\n" +
+	"\x05edges\x18\x01 \x03(\v2\x0f.like.LikerEdgeR\x05edges\x12+\n" +
+	"\tpage_info\x18\x02 \x01(\v2\x0e.like.PageInfoR\bpageInfo\x12\x1f\n" +
+	"\vtotal_count\x18\x03 \x01(\x05R\n" +
+	"totalCount\"n\n" +
+	"\x18GetUserLikedPostsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05first\x18\x02 \x01(\x05R\x05first\x12\x19\n" +
+	"\x05after\x18\x03 \x01(\tH\x00R\x05after\x88\x01\x01B\b\n" +
+	"\x06_after\"w\n" +
+	"\rLikedPostEdge\x12\x16\n" +
+	"\x06cursor\x18\x01 \x01(\tR\x06cursor\x12\x17\n" +
+	"\apost_id\x18\x02 \x01(\tR\x06postId\x125\n" +
+	"\bliked_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\alikedAt\"\x8e\x01\n" +
+	"\x13LikedPostConnection\x12)\n" +
+	"\x05edges\x18\x01 \x03(\v2\x13.like.LikedPostEdgeR\x05edges\x12+\n" +
+	"\tpage_info\x18\x02 \x01(\v2\x0e.like.PageInfoR\bpageInfo\x12\x1f\n" +
+	"\vtotal_count\x18\x03 \x01(\x05R\n" +
+	"totalCount\"L\n" +
 	"\x18IsPostLikedByUserRequest\x12\x17\n" +
 	"\apost_id\x18\x01 \x01(\tR\x06postId\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\tR\x06userId\"6\n" +
@@ -569,7 +1256,14 @@ const file_proto_like_proto_rawDesc = "" +
 	"\apost_id\x18\x01 \x01(\tR\x06postId\x12\x19\n" +
 	"\bis_liked\x18\x02 \x01(\bR\aisLiked\"I\n" +
 	"\x1bGetPostLikesByUsersResponse\x12*\n" +
-	"\x05likes\x18\x01 \x03(\v2\x14.like.PostLikeStatusR\x05likes\"\xa4\x01\n" +
+	"\x05likes\x18\x01 \x03(\v2\x14.like.PostLikeStatusR\x05likes\"2\n" +
+	"\x15GetLikesCountsRequest\x12\x19\n" +
+	"\bpost_ids\x18\x01 \x03(\tR\apostIds\"?\n" +
+	"\x0ePostLikesCount\x12\x17\n" +
+	"\apost_id\x18\x01 \x01(\tR\x06postId\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\"F\n" +
+	"\x16GetLikesCountsResponse\x12,\n" +
+	"\x06counts\x18\x01 \x03(\v2\x14.like.PostLikesCountR\x06counts\"\xa4\x01\n" +
 	"\bLikeInfo\x12\x14\n" +
 	"\x05count\x18\x01 \x01(\x05R\x05count\x12;\n" +
 	"\x18is_liked_by_current_user\x18\x02 \x01(\bH\x00R\x14isLikedByCurrentUser\x88\x01\x01\x12(\n" +
@@ -577,14 +1271,22 @@ const file_proto_like_proto_rawDesc = "" +
 	"\x19_is_liked_by_current_user\">\n" +
 	"\bResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage2\xe4\x02\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\x15\n" +
+	"\x13ExportMyDataRequest\" \n" +
+	"\n" +
+	"DataExport\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data2\x82\x05\n" +
 	"\vLikeService\x121\n" +
 	"\bLikePost\x12\x15.like.LikePostRequest\x1a\x0e.like.Response\x125\n" +
 	"\n" +
 	"UnlikePost\x12\x17.like.UnlikePostRequest\x1a\x0e.like.Response\x129\n" +
-	"\fGetPostLikes\x12\x19.like.GetPostLikesRequest\x1a\x0e.like.LikeInfo\x12T\n" +
+	"\fGetPostLikes\x12\x19.like.GetPostLikesRequest\x1a\x0e.like.LikeInfo\x12B\n" +
+	"\rGetPostLikers\x12\x1a.like.GetPostLikersRequest\x1a\x15.like.LikerConnection\x12N\n" +
+	"\x11GetUserLikedPosts\x12\x1e.like.GetUserLikedPostsRequest\x1a\x19.like.LikedPostConnection\x12T\n" +
 	"\x11IsPostLikedByUser\x12\x1e.like.IsPostLikedByUserRequest\x1a\x1f.like.IsPostLikedByUserResponse\x12Z\n" +
-	"\x13GetPostLikesByUsers\x12 .like.GetPostLikesByUsersRequest\x1a!.like.GetPostLikesByUsersResponseB\x04Z\x02./b\x06proto3"
+	"\x13GetPostLikesByUsers\x12 .like.GetPostLikesByUsersRequest\x1a!.like.GetPostLikesByUsersResponse\x12;\n" +
+	"\fExportMyData\x12\x19.like.ExportMyDataRequest\x1a\x10.like.DataExport\x12K\n" +
+	"\x0eGetLikesCounts\x12\x1b.like.GetLikesCountsRequest\x1a\x1c.like.GetLikesCountsResponseB\x04Z\x02./b\x06proto3"
 
 var (
 	file_proto_like_proto_rawDescOnce sync.Once
@@ -598,36 +1300,64 @@ func file_proto_like_proto_rawDescGZIP() []byte {
 	return file_proto_like_proto_rawDescData
 }
 
-var file_proto_like_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_proto_like_proto_msgTypes = make([]protoimpl.MessageInfo, 22)
 var file_proto_like_proto_goTypes = []any{
 	(*LikePostRequest)(nil),             // 0: like.LikePostRequest
 	(*UnlikePostRequest)(nil),           // 1: like.UnlikePostRequest
 	(*GetPostLikesRequest)(nil),         // 2: like.GetPostLikesRequest
-	(*IsPostLikedByUserRequest)(nil),    // 3: like.IsPostLikedByUserRequest
-	(*IsPostLikedByUserResponse)(nil),   // 4: like.IsPostLikedByUserResponse
-	(*GetPostLikesByUsersRequest)(nil),  // 5: like.GetPostLikesByUsersRequest
-	(*PostLikeStatus)(nil),              // 6: like.PostLikeStatus
-	(*GetPostLikesByUsersResponse)(nil), // 7: like.GetPostLikesByUsersResponse
-	(*LikeInfo)(nil),                    // 8: like.LikeInfo
-	(*Response)(nil),                    // 9: like.Response
+	(*GetPostLikersRequest)(nil),        // 3: like.GetPostLikersRequest
+	(*LikerEdge)(nil),                   // 4: like.LikerEdge
+	(*PageInfo)(nil),                    // 5: like.PageInfo
+	(*LikerConnection)(nil),             // 6: like.LikerConnection
+	(*GetUserLikedPostsRequest)(nil),    // 7: like.GetUserLikedPostsRequest
+	(*LikedPostEdge)(nil),               // 8: like.LikedPostEdge
+	(*LikedPostConnection)(nil),         // 9: like.LikedPostConnection
+	(*IsPostLikedByUserRequest)(nil),    // 10: like.IsPostLikedByUserRequest
+	(*IsPostLikedByUserResponse)(nil),   // 11: like.IsPostLikedByUserResponse
+	(*GetPostLikesByUsersRequest)(nil),  // 12: like.GetPostLikesByUsersRequest
+	(*PostLikeStatus)(nil),              // 13: like.PostLikeStatus
+	(*GetPostLikesByUsersResponse)(nil), // 14: like.GetPostLikesByUsersResponse
+	(*GetLikesCountsRequest)(nil),       // 15: like.GetLikesCountsRequest
+	(*PostLikesCount)(nil),              // 16: like.PostLikesCount
+	(*GetLikesCountsResponse)(nil),      // 17: like.GetLikesCountsResponse
+	(*LikeInfo)(nil),                    // 18: like.LikeInfo
+	(*Response)(nil),                    // 19: like.Response
+	(*ExportMyDataRequest)(nil),         // 20: like.ExportMyDataRequest
+	(*DataExport)(nil),                  // 21: like.DataExport
+	(*timestamppb.Timestamp)(nil),       // 22: google.protobuf.Timestamp
 }
 var file_proto_like_proto_depIdxs = []int32{
-	6, // 0: like.GetPostLikesByUsersResponse.likes:type_name -> like.PostLikeStatus
-	0, // 1: like.LikeService.LikePost:input_type -> like.LikePostRequest
-	1, // 2: like.LikeService.UnlikePost:input_type -> like.UnlikePostRequest
-	2, // 3: like.LikeService.GetPostLikes:input_type -> like.GetPostLikesRequest
-	3, // 4: like.LikeService.IsPostLikedByUser:input_type -> like.IsPostLikedByUserRequest
-	5, // 5: like.LikeService.GetPostLikesByUsers:input_type -> like.GetPostLikesByUsersRequest
-	9, // 6: like.LikeService.LikePost:output_type -> like.Response
-	9, // 7: like.LikeService.UnlikePost:output_type -> like.Response
-	8, // 8: like.LikeService.GetPostLikes:output_type -> like.LikeInfo
-	4, // 9: like.LikeService.IsPostLikedByUser:output_type -> like.IsPostLikedByUserResponse
-	7, // 10: like.LikeService.GetPostLikesByUsers:output_type -> like.GetPostLikesByUsersResponse
-	6, // [6:11] is the sub-list for method output_type
-	1, // [1:6] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	22, // 0: like.LikerEdge.liked_at:type_name -> google.protobuf.Timestamp
+	4,  // 1: like.LikerConnection.edges:type_name -> like.LikerEdge
+	5,  // 2: like.LikerConnection.page_info:type_name -> like.PageInfo
+	22, // 3: like.LikedPostEdge.liked_at:type_name -> google.protobuf.Timestamp
+	8,  // 4: like.LikedPostConnection.edges:type_name -> like.LikedPostEdge
+	5,  // 5: like.LikedPostConnection.page_info:type_name -> like.PageInfo
+	13, // 6: like.GetPostLikesByUsersResponse.likes:type_name -> like.PostLikeStatus
+	16, // 7: like.GetLikesCountsResponse.counts:type_name -> like.PostLikesCount
+	0,  // 8: like.LikeService.LikePost:input_type -> like.LikePostRequest
+	1,  // 9: like.LikeService.UnlikePost:input_type -> like.UnlikePostRequest
+	2,  // 10: like.LikeService.GetPostLikes:input_type -> like.GetPostLikesRequest
+	3,  // 11: like.LikeService.GetPostLikers:input_type -> like.GetPostLikersRequest
+	7,  // 12: like.LikeService.GetUserLikedPosts:input_type -> like.GetUserLikedPostsRequest
+	10, // 13: like.LikeService.IsPostLikedByUser:input_type -> like.IsPostLikedByUserRequest
+	12, // 14: like.LikeService.GetPostLikesByUsers:input_type -> like.GetPostLikesByUsersRequest
+	20, // 15: like.LikeService.ExportMyData:input_type -> like.ExportMyDataRequest
+	15, // 16: like.LikeService.GetLikesCounts:input_type -> like.GetLikesCountsRequest
+	19, // 17: like.LikeService.LikePost:output_type -> like.Response
+	19, // 18: like.LikeService.UnlikePost:output_type -> like.Response
+	18, // 19: like.LikeService.GetPostLikes:output_type -> like.LikeInfo
+	6,  // 20: like.LikeService.GetPostLikers:output_type -> like.LikerConnection
+	9,  // 21: like.LikeService.GetUserLikedPosts:output_type -> like.LikedPostConnection
+	11, // 22: like.LikeService.IsPostLikedByUser:output_type -> like.IsPostLikedByUserResponse
+	14, // 23: like.LikeService.GetPostLikesByUsers:output_type -> like.GetPostLikesByUsersResponse
+	21, // 24: like.LikeService.ExportMyData:output_type -> like.DataExport
+	17, // 25: like.LikeService.GetLikesCounts:output_type -> like.GetLikesCountsResponse
+	17, // [17:26] is the sub-list for method output_type
+	8,  // [8:17] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
 }
 
 func init() { file_proto_like_proto_init() }
@@ -636,14 +1366,17 @@ func file_proto_like_proto_init() {
 		return
 	}
 	file_proto_like_proto_msgTypes[2].OneofWrappers = []any{}
-	file_proto_like_proto_msgTypes[8].OneofWrappers = []any{}
+	file_proto_like_proto_msgTypes[3].OneofWrappers = []any{}
+	file_proto_like_proto_msgTypes[5].OneofWrappers = []any{}
+	file_proto_like_proto_msgTypes[7].OneofWrappers = []any{}
+	file_proto_like_proto_msgTypes[18].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_like_proto_rawDesc), len(file_proto_like_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   10,
+			NumMessages:   22,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
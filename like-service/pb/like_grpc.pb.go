@@ -22,8 +22,12 @@ const (
 	LikeService_LikePost_FullMethodName            = "/like.LikeService/LikePost"
 	LikeService_UnlikePost_FullMethodName          = "/like.LikeService/UnlikePost"
 	LikeService_GetPostLikes_FullMethodName        = "/like.LikeService/GetPostLikes"
+	LikeService_GetPostLikers_FullMethodName       = "/like.LikeService/GetPostLikers"
+	LikeService_GetUserLikedPosts_FullMethodName   = "/like.LikeService/GetUserLikedPosts"
 	LikeService_IsPostLikedByUser_FullMethodName   = "/like.LikeService/IsPostLikedByUser"
 	LikeService_GetPostLikesByUsers_FullMethodName = "/like.LikeService/GetPostLikesByUsers"
+	LikeService_ExportMyData_FullMethodName        = "/like.LikeService/ExportMyData"
+	LikeService_GetLikesCounts_FullMethodName      = "/like.LikeService/GetLikesCounts"
 )
 
 // LikeServiceClient is the client API for LikeService service.
@@ -33,8 +37,16 @@ type LikeServiceClient interface {
 	LikePost(ctx context.Context, in *LikePostRequest, opts ...grpc.CallOption) (*Response, error)
 	UnlikePost(ctx context.Context, in *UnlikePostRequest, opts ...grpc.CallOption) (*Response, error)
 	GetPostLikes(ctx context.Context, in *GetPostLikesRequest, opts ...grpc.CallOption) (*LikeInfo, error)
+	// Everyone who liked a post, most recent first, a page at a time
+	GetPostLikers(ctx context.Context, in *GetPostLikersRequest, opts ...grpc.CallOption) (*LikerConnection, error)
+	// The posts a user liked, most recently liked first, a page at a time
+	GetUserLikedPosts(ctx context.Context, in *GetUserLikedPostsRequest, opts ...grpc.CallOption) (*LikedPostConnection, error)
 	IsPostLikedByUser(ctx context.Context, in *IsPostLikedByUserRequest, opts ...grpc.CallOption) (*IsPostLikedByUserResponse, error)
 	GetPostLikesByUsers(ctx context.Context, in *GetPostLikesByUsersRequest, opts ...grpc.CallOption) (*GetPostLikesByUsersResponse, error)
+	ExportMyData(ctx context.Context, in *ExportMyDataRequest, opts ...grpc.CallOption) (*DataExport, error)
+	// Counts of likes as stored, which post-service reconciles its counters
+	// with (requires the ADMIN role)
+	GetLikesCounts(ctx context.Context, in *GetLikesCountsRequest, opts ...grpc.CallOption) (*GetLikesCountsResponse, error)
 }
 
 type likeServiceClient struct {
@@ -75,6 +87,26 @@ func (c *likeServiceClient) GetPostLikes(ctx context.Context, in *GetPostLikesRe
 	return out, nil
 }
 
+func (c *likeServiceClient) GetPostLikers(ctx context.Context, in *GetPostLikersRequest, opts ...grpc.CallOption) (*LikerConnection, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LikerConnection)
+	err := c.cc.Invoke(ctx, LikeService_GetPostLikers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *likeServiceClient) GetUserLikedPosts(ctx context.Context, in *GetUserLikedPostsRequest, opts ...grpc.CallOption) (*LikedPostConnection, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LikedPostConnection)
+	err := c.cc.Invoke(ctx, LikeService_GetUserLikedPosts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *likeServiceClient) IsPostLikedByUser(ctx context.Context, in *IsPostLikedByUserRequest, opts ...grpc.CallOption) (*IsPostLikedByUserResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(IsPostLikedByUserResponse)
@@ -95,6 +127,26 @@ func (c *likeServiceClient) GetPostLikesByUsers(ctx context.Context, in *GetPost
 	return out, nil
 }
 
+func (c *likeServiceClient) ExportMyData(ctx context.Context, in *ExportMyDataRequest, opts ...grpc.CallOption) (*DataExport, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DataExport)
+	err := c.cc.Invoke(ctx, LikeService_ExportMyData_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *likeServiceClient) GetLikesCounts(ctx context.Context, in *GetLikesCountsRequest, opts ...grpc.CallOption) (*GetLikesCountsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetLikesCountsResponse)
+	err := c.cc.Invoke(ctx, LikeService_GetLikesCounts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // LikeServiceServer is the server API for LikeService service.
 // All implementations must embed UnimplementedLikeServiceServer
 // for forward compatibility.
@@ -102,8 +154,16 @@ type LikeServiceServer interface {
 	LikePost(context.Context, *LikePostRequest) (*Response, error)
 	UnlikePost(context.Context, *UnlikePostRequest) (*Response, error)
 	GetPostLikes(context.Context, *GetPostLikesRequest) (*LikeInfo, error)
+	// Everyone who liked a post, most recent first, a page at a time
+	GetPostLikers(context.Context, *GetPostLikersRequest) (*LikerConnection, error)
+	// The posts a user liked, most recently liked first, a page at a time
+	GetUserLikedPosts(context.Context, *GetUserLikedPostsRequest) (*LikedPostConnection, error)
 	IsPostLikedByUser(context.Context, *IsPostLikedByUserRequest) (*IsPostLikedByUserResponse, error)
 	GetPostLikesByUsers(context.Context, *GetPostLikesByUsersRequest) (*GetPostLikesByUsersResponse, error)
+	ExportMyData(context.Context, *ExportMyDataRequest) (*DataExport, error)
+	// Counts of likes as stored, which post-service reconciles its counters
+	// with (requires the ADMIN role)
+	GetLikesCounts(context.Context, *GetLikesCountsRequest) (*GetLikesCountsResponse, error)
 	mustEmbedUnimplementedLikeServiceServer()
 }
 
@@ -123,12 +183,24 @@ func (UnimplementedLikeServiceServer) UnlikePost(context.Context, *UnlikePostReq
 func (UnimplementedLikeServiceServer) GetPostLikes(context.Context, *GetPostLikesRequest) (*LikeInfo, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetPostLikes not implemented")
 }
+func (UnimplementedLikeServiceServer) GetPostLikers(context.Context, *GetPostLikersRequest) (*LikerConnection, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPostLikers not implemented")
+}
+func (UnimplementedLikeServiceServer) GetUserLikedPosts(context.Context, *GetUserLikedPostsRequest) (*LikedPostConnection, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserLikedPosts not implemented")
+}
 func (UnimplementedLikeServiceServer) IsPostLikedByUser(context.Context, *IsPostLikedByUserRequest) (*IsPostLikedByUserResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method IsPostLikedByUser not implemented")
 }
 func (UnimplementedLikeServiceServer) GetPostLikesByUsers(context.Context, *GetPostLikesByUsersRequest) (*GetPostLikesByUsersResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetPostLikesByUsers not implemented")
 }
+func (UnimplementedLikeServiceServer) ExportMyData(context.Context, *ExportMyDataRequest) (*DataExport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportMyData not implemented")
+}
+func (UnimplementedLikeServiceServer) GetLikesCounts(context.Context, *GetLikesCountsRequest) (*GetLikesCountsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLikesCounts not implemented")
+}
 func (UnimplementedLikeServiceServer) mustEmbedUnimplementedLikeServiceServer() {}
 func (UnimplementedLikeServiceServer) testEmbeddedByValue()                     {}
 
@@ -204,6 +276,42 @@ func _LikeService_GetPostLikes_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _LikeService_GetPostLikers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPostLikersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LikeServiceServer).GetPostLikers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LikeService_GetPostLikers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LikeServiceServer).GetPostLikers(ctx, req.(*GetPostLikersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LikeService_GetUserLikedPosts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserLikedPostsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LikeServiceServer).GetUserLikedPosts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LikeService_GetUserLikedPosts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LikeServiceServer).GetUserLikedPosts(ctx, req.(*GetUserLikedPostsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _LikeService_IsPostLikedByUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(IsPostLikedByUserRequest)
 	if err := dec(in); err != nil {
@@ -240,6 +348,42 @@ func _LikeService_GetPostLikesByUsers_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _LikeService_ExportMyData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportMyDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LikeServiceServer).ExportMyData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LikeService_ExportMyData_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LikeServiceServer).ExportMyData(ctx, req.(*ExportMyDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LikeService_GetLikesCounts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLikesCountsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LikeServiceServer).GetLikesCounts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LikeService_GetLikesCounts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LikeServiceServer).GetLikesCounts(ctx, req.(*GetLikesCountsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // LikeService_ServiceDesc is the grpc.ServiceDesc for LikeService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -259,6 +403,14 @@ var LikeService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetPostLikes",
 			Handler:    _LikeService_GetPostLikes_Handler,
 		},
+		{
+			MethodName: "GetPostLikers",
+			Handler:    _LikeService_GetPostLikers_Handler,
+		},
+		{
+			MethodName: "GetUserLikedPosts",
+			Handler:    _LikeService_GetUserLikedPosts_Handler,
+		},
 		{
 			MethodName: "IsPostLikedByUser",
 			Handler:    _LikeService_IsPostLikedByUser_Handler,
@@ -267,6 +419,14 @@ var LikeService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetPostLikesByUsers",
 			Handler:    _LikeService_GetPostLikesByUsers_Handler,
 		},
+		{
+			MethodName: "ExportMyData",
+			Handler:    _LikeService_ExportMyData_Handler,
+		},
+		{
+			MethodName: "GetLikesCounts",
+			Handler:    _LikeService_GetLikesCounts_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/like.proto",
@@ -0,0 +1,44 @@
+package publisher
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/google/uuid"
+
+	"like-service/events"
+	natsClient "like-service/nats"
+	"shared/logging"
+)
+
+// EventPublisher sends like events to JetStream. Each event gets an ID of
+// its own, which post-service uses to count it exactly once.
+type EventPublisher struct {
+	nats *natsClient.Client
+}
+
+func NewEventPublisher(nats *natsClient.Client) *EventPublisher {
+	return &EventPublisher{nats: nats}
+}
+
+func (p *EventPublisher) PublishPostLiked(ctx context.Context, event events.PostLikedEvent) error {
+	return p.publish(ctx, events.PostLiked, event.PostID, event)
+}
+
+func (p *EventPublisher) PublishPostUnliked(ctx context.Context, event events.PostUnlikedEvent) error {
+	return p.publish(ctx, events.PostUnliked, event.PostID, event)
+}
+
+func (p *EventPublisher) publish(ctx context.Context, subject string, postID uuid.UUID, event any) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	if err := p.nats.PublishDurable(ctx, subject, data, uuid.NewString()); err != nil {
+		return err
+	}
+
+	logging.FromContext(ctx).Info().Str("subject", subject).Stringer("post_id", postID).Msg("published event")
+	return nil
+}
@@ -1,48 +1,63 @@
 package repository
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
 	"errors"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/google/uuid"
-	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"golang.org/x/sync/errgroup"
+	"like-service/db"
 	"like-service/model"
+	"shared/cursor"
 )
 
 type LikeRepository interface {
-	CreateLike(ctx context.Context, postID, userID uuid.UUID) error
-	DeleteLike(ctx context.Context, postID, userID uuid.UUID) error
+	CreateLike(ctx context.Context, likeID, postID, userID uuid.UUID) error
+	DeleteLike(ctx context.Context, postID, userID uuid.UUID) (uuid.UUID, error)
 	GetLikeByPostAndUser(ctx context.Context, postID, userID uuid.UUID) (*models.Like, error)
 	GetLikeCountByPost(ctx context.Context, postID uuid.UUID) (int32, error)
+	GetLikeCounts(ctx context.Context, postIDs []uuid.UUID) (map[uuid.UUID]int32, error)
 	GetRecentLikersByPost(ctx context.Context, postID uuid.UUID, limit int32) ([]uuid.UUID, error)
+	GetLikersByPost(ctx context.Context, postID uuid.UUID, first int32, after *string) (*models.LikerConnection, error)
+	GetLikedPostsByUser(ctx context.Context, userID uuid.UUID, first int32, after *string) (*models.LikedPostConnection, error)
 	IsPostLikedByUser(ctx context.Context, postID, userID uuid.UUID) (bool, error)
 	GetPostLikesByUsers(ctx context.Context, postIDs []uuid.UUID, userID uuid.UUID) ([]models.PostLikeStatus, error)
 	GetLikesByPost(ctx context.Context, postID uuid.UUID) ([]*models.Like, error)
+	DeleteLikesByPost(ctx context.Context, postID uuid.UUID) (int64, error)
+	GetLikesByUser(ctx context.Context, userID uuid.UUID) ([]models.Like, error)
+	DeleteLikesByUser(ctx context.Context, userID uuid.UUID) (int64, error)
 }
 
+// ErrLikeNotFound is returned when removing a like that does not exist
+var ErrLikeNotFound = errors.New("like not found")
+
+// likeRepository shards likes by post_id, so every query about one post
+// stays on a single shard
 type likeRepository struct {
-	db *sqlx.DB
+	shards *database.Shards
 }
 
-func NewLikeRepository(db *sqlx.DB) LikeRepository {
-	return &likeRepository{db: db}
+func NewLikeRepository(shards *database.Shards) LikeRepository {
+	return &likeRepository{shards: shards}
 }
 
 // CreateLike adds a new like for a post by a user
-func (r *likeRepository) CreateLike(ctx context.Context, postID, userID uuid.UUID) error {
+func (r *likeRepository) CreateLike(ctx context.Context, likeID, postID, userID uuid.UUID) error {
 	query := `
 		INSERT INTO like_service_likes (id, post_id, user_id, created_at)
 		VALUES ($1, $2, $3, $4)
 		ON CONFLICT (post_id, user_id) DO NOTHING
 	`
 
-	likeID := uuid.New()
 	now := time.Now()
 
-	result, err := r.db.ExecContext(ctx, query, likeID, postID, userID, now)
+	result, err := r.shards.For(postID).ExecContext(ctx, query, likeID, postID, userID, now)
 	if err != nil {
 		return fmt.Errorf("failed to create like: %w", err)
 	}
@@ -59,28 +74,24 @@ func (r *likeRepository) CreateLike(ctx context.Context, postID, userID uuid.UUI
 	return nil
 }
 
-// DeleteLike removes a like for a post by a user
-func (r *likeRepository) DeleteLike(ctx context.Context, postID, userID uuid.UUID) error {
+// DeleteLike removes a like for a post by a user and returns its ID
+func (r *likeRepository) DeleteLike(ctx context.Context, postID, userID uuid.UUID) (uuid.UUID, error) {
 	query := `
 		DELETE FROM like_service_likes
 		WHERE post_id = $1 AND user_id = $2
+		RETURNING id
 	`
 
-	result, err := r.db.ExecContext(ctx, query, postID, userID)
+	var likeID uuid.UUID
+	err := r.shards.For(postID).GetContext(ctx, &likeID, query, postID, userID)
 	if err != nil {
-		return fmt.Errorf("failed to delete like: %w", err)
-	}
-
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return fmt.Errorf("failed to get rows affected: %w", err)
-	}
-
-	if rowsAffected == 0 {
-		return errors.New("like not found")
+		if errors.Is(err, sql.ErrNoRows) {
+			return uuid.Nil, ErrLikeNotFound
+		}
+		return uuid.Nil, fmt.Errorf("failed to delete like: %w", err)
 	}
 
-	return nil
+	return likeID, nil
 }
 
 // GetLikeByPostAndUser retrieves a specific like
@@ -92,7 +103,7 @@ func (r *likeRepository) GetLikeByPostAndUser(ctx context.Context, postID, userI
 	`
 
 	var like models.Like
-	err := r.db.GetContext(ctx, &like, query, postID, userID)
+	err := r.shards.For(postID).GetContext(ctx, &like, query, postID, userID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, nil
@@ -112,7 +123,7 @@ func (r *likeRepository) GetLikeCountByPost(ctx context.Context, postID uuid.UUI
 	`
 
 	var count int32
-	err := r.db.GetContext(ctx, &count, query, postID)
+	err := r.shards.For(postID).ReadDB().GetContext(ctx, &count, query, postID)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get like count: %w", err)
 	}
@@ -120,6 +131,51 @@ func (r *likeRepository) GetLikeCountByPost(ctx context.Context, postID uuid.UUI
 	return count, nil
 }
 
+// GetLikeCounts returns the number of likes of each post. Posts without
+// likes are included with a count of 0.
+func (r *likeRepository) GetLikeCounts(ctx context.Context, postIDs []uuid.UUID) (map[uuid.UUID]int32, error) {
+	query := `
+		SELECT post_id, COUNT(*) AS count
+		FROM like_service_likes
+		WHERE post_id = ANY($1)
+		GROUP BY post_id
+	`
+
+	type postCount struct {
+		PostID uuid.UUID `db:"post_id"`
+		Count  int32     `db:"count"`
+	}
+
+	// Query the shards owning the posts in parallel
+	groups := r.shards.Group(postIDs)
+	results := make([][]postCount, r.shards.Len())
+
+	g, gctx := errgroup.WithContext(ctx)
+	for shard, ids := range groups {
+		g.Go(func() error {
+			db := r.shards.All()[shard]
+			if err := db.ReadDB().SelectContext(gctx, &results[shard], query, pq.Array(ids)); err != nil {
+				return fmt.Errorf("failed to get like counts on shard %d: %w", shard, err)
+			}
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	counts := make(map[uuid.UUID]int32, len(postIDs))
+	for _, postID := range postIDs {
+		counts[postID] = 0
+	}
+	for _, shardCounts := range results {
+		for _, c := range shardCounts {
+			counts[c.PostID] = c.Count
+		}
+	}
+	return counts, nil
+}
+
 // GetRecentLikersByPost returns the most recent users who liked a post
 func (r *likeRepository) GetRecentLikersByPost(ctx context.Context, postID uuid.UUID, limit int32) ([]uuid.UUID, error) {
 	if limit <= 0 {
@@ -135,7 +191,7 @@ func (r *likeRepository) GetRecentLikersByPost(ctx context.Context, postID uuid.
 	`
 
 	var userIDs []uuid.UUID
-	err := r.db.SelectContext(ctx, &userIDs, query, postID, limit)
+	err := r.shards.For(postID).ReadDB().SelectContext(ctx, &userIDs, query, postID, limit)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get recent likers: %w", err)
 	}
@@ -143,6 +199,132 @@ func (r *likeRepository) GetRecentLikersByPost(ctx context.Context, postID uuid.
 	return userIDs, nil
 }
 
+// GetLikersByPost returns a page of the users who liked a post, most recent
+// first, and the number of likes of the post. A post's likes are all on its
+// shard, so the page is read from that shard alone.
+func (r *likeRepository) GetLikersByPost(ctx context.Context, postID uuid.UUID, first int32, after *string) (*models.LikerConnection, error) {
+	query := `
+		SELECT user_id, created_at, id
+		FROM like_service_likes
+		WHERE post_id = $1
+	`
+
+	query, args, err := cursor.Keyset{TimeColumn: "created_at", IDColumn: "id"}.Page(query, []interface{}{postID}, after, int(first))
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []models.Like
+	if err := r.shards.For(postID).ReadDB().SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to get likers: %w", err)
+	}
+	rows, hasNextPage := cursor.Trim(rows, int(first))
+
+	totalCount, err := r.GetLikeCountByPost(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+
+	edges := make([]models.LikerEdge, len(rows))
+	for i, row := range rows {
+		edges[i] = models.LikerEdge{
+			Cursor:  cursor.EncodeKeyset(row.CreatedAt, row.ID),
+			UserID:  row.UserID,
+			LikedAt: row.CreatedAt,
+		}
+	}
+
+	pageInfo := models.PageInfo{HasNextPage: hasNextPage}
+	if len(edges) > 0 {
+		startCursor := edges[0].Cursor
+		endCursor := edges[len(edges)-1].Cursor
+		pageInfo.StartCursor = &startCursor
+		pageInfo.EndCursor = &endCursor
+	}
+
+	return &models.LikerConnection{
+		Edges:      edges,
+		PageInfo:   pageInfo,
+		TotalCount: totalCount,
+	}, nil
+}
+
+// GetLikedPostsByUser returns a page of the posts a user liked, most recently
+// liked first, and the number of likes the user has made. Likes are sharded
+// by post, so every shard returns its first page after the cursor and the
+// pages are merged.
+func (r *likeRepository) GetLikedPostsByUser(ctx context.Context, userID uuid.UUID, first int32, after *string) (*models.LikedPostConnection, error) {
+	query := `
+		SELECT post_id, created_at, id
+		FROM like_service_likes
+		WHERE user_id = $1
+	`
+
+	query, args, err := cursor.Keyset{TimeColumn: "created_at", IDColumn: "id"}.Page(query, []interface{}{userID}, after, int(first))
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([][]models.Like, r.shards.Len())
+	counts := make([]int32, r.shards.Len())
+
+	g, gctx := errgroup.WithContext(ctx)
+	for shard, db := range r.shards.All() {
+		g.Go(func() error {
+			if err := db.ReadDB().SelectContext(gctx, &results[shard], query, args...); err != nil {
+				return fmt.Errorf("failed to get liked posts on shard %d: %w", shard, err)
+			}
+			if err := db.ReadDB().GetContext(gctx, &counts[shard], `SELECT COUNT(*) FROM like_service_likes WHERE user_id = $1`, userID); err != nil {
+				return fmt.Errorf("failed to count likes of user on shard %d: %w", shard, err)
+			}
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	var rows []models.Like
+	for _, shardRows := range results {
+		rows = append(rows, shardRows...)
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if !rows[i].CreatedAt.Equal(rows[j].CreatedAt) {
+			return rows[i].CreatedAt.After(rows[j].CreatedAt)
+		}
+		return bytes.Compare(rows[i].ID[:], rows[j].ID[:]) > 0
+	})
+	rows, hasNextPage := cursor.Trim(rows, int(first))
+
+	var totalCount int32
+	for _, count := range counts {
+		totalCount += count
+	}
+
+	edges := make([]models.LikedPostEdge, len(rows))
+	for i, row := range rows {
+		edges[i] = models.LikedPostEdge{
+			Cursor:  cursor.EncodeKeyset(row.CreatedAt, row.ID),
+			PostID:  row.PostID,
+			LikedAt: row.CreatedAt,
+		}
+	}
+
+	pageInfo := models.PageInfo{HasNextPage: hasNextPage}
+	if len(edges) > 0 {
+		startCursor := edges[0].Cursor
+		endCursor := edges[len(edges)-1].Cursor
+		pageInfo.StartCursor = &startCursor
+		pageInfo.EndCursor = &endCursor
+	}
+
+	return &models.LikedPostConnection{
+		Edges:      edges,
+		PageInfo:   pageInfo,
+		TotalCount: totalCount,
+	}, nil
+}
+
 // IsPostLikedByUser checks if a user has liked a specific post
 func (r *likeRepository) IsPostLikedByUser(ctx context.Context, postID, userID uuid.UUID) (bool, error) {
 	query := `
@@ -154,7 +336,7 @@ func (r *likeRepository) IsPostLikedByUser(ctx context.Context, postID, userID u
 	`
 
 	var exists bool
-	err := r.db.GetContext(ctx, &exists, query, postID, userID)
+	err := r.shards.For(postID).GetContext(ctx, &exists, query, postID, userID)
 	if err != nil {
 		return false, fmt.Errorf("failed to check if post is liked: %w", err)
 	}
@@ -174,15 +356,29 @@ func (r *likeRepository) GetPostLikesByUsers(ctx context.Context, postIDs []uuid
 		WHERE post_id = ANY($1) AND user_id = $2
 	`
 
-	var likedPosts []models.PostLikeStatus
-	err := r.db.SelectContext(ctx, &likedPosts, query, postIDs, userID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get post likes by user: %w", err)
+	// Query the shards owning the posts in parallel
+	groups := r.shards.Group(postIDs)
+	results := make([][]models.PostLikeStatus, r.shards.Len())
+
+	g, gctx := errgroup.WithContext(ctx)
+	for shard, ids := range groups {
+		g.Go(func() error {
+			db := r.shards.All()[shard]
+			if err := db.ReadDB().SelectContext(gctx, &results[shard], query, ids, userID); err != nil {
+				return fmt.Errorf("failed to get post likes by user on shard %d: %w", shard, err)
+			}
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
 	}
 
 	likedMap := make(map[uuid.UUID]bool)
-	for _, liked := range likedPosts {
-		likedMap[liked.PostID] = true
+	for _, likedPosts := range results {
+		for _, liked := range likedPosts {
+			likedMap[liked.PostID] = true
+		}
 	}
 
 	result := make([]models.PostLikeStatus, 0, len(postIDs))
@@ -206,10 +402,93 @@ func (r *likeRepository) GetLikesByPost(ctx context.Context, postID uuid.UUID) (
 	`
 
 	var likes []*models.Like
-	err := r.db.SelectContext(ctx, &likes, query, postID)
+	err := r.shards.For(postID).ReadDB().SelectContext(ctx, &likes, query, postID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get likes by post: %w", err)
 	}
 
 	return likes, nil
 }
+
+// DeleteLikesByPost removes every like of a post and returns how many were
+// removed. Deleting them again removes nothing.
+func (r *likeRepository) DeleteLikesByPost(ctx context.Context, postID uuid.UUID) (int64, error) {
+	query := `
+		DELETE FROM like_service_likes
+		WHERE post_id = $1
+	`
+
+	result, err := r.shards.For(postID).ExecContext(ctx, query, postID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete likes of post: %w", err)
+	}
+
+	return result.RowsAffected()
+}
+
+// GetLikesByUser retrieves every like of a user, most recent first. Likes are
+// sharded by post, so every shard is queried.
+func (r *likeRepository) GetLikesByUser(ctx context.Context, userID uuid.UUID) ([]models.Like, error) {
+	query := `
+		SELECT id, post_id, user_id, created_at
+		FROM like_service_likes
+		WHERE user_id = $1
+	`
+
+	results := make([][]models.Like, r.shards.Len())
+
+	g, gctx := errgroup.WithContext(ctx)
+	for shard, db := range r.shards.All() {
+		g.Go(func() error {
+			if err := db.ReadDB().SelectContext(gctx, &results[shard], query, userID); err != nil {
+				return fmt.Errorf("failed to get likes by user on shard %d: %w", shard, err)
+			}
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	likes := []models.Like{}
+	for _, shardLikes := range results {
+		likes = append(likes, shardLikes...)
+	}
+	sort.Slice(likes, func(i, j int) bool {
+		return likes[i].CreatedAt.After(likes[j].CreatedAt)
+	})
+
+	return likes, nil
+}
+
+// DeleteLikesByUser removes every like of a user from every shard and returns
+// how many were removed. Deleting them again removes nothing.
+func (r *likeRepository) DeleteLikesByUser(ctx context.Context, userID uuid.UUID) (int64, error) {
+	query := `
+		DELETE FROM like_service_likes
+		WHERE user_id = $1
+	`
+
+	deleted := make([]int64, r.shards.Len())
+
+	g, gctx := errgroup.WithContext(ctx)
+	for shard, db := range r.shards.All() {
+		g.Go(func() error {
+			result, err := db.ExecContext(gctx, query, userID)
+			if err != nil {
+				return fmt.Errorf("failed to delete likes of user on shard %d: %w", shard, err)
+			}
+			deleted[shard], err = result.RowsAffected()
+			return err
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return 0, err
+	}
+
+	var total int64
+	for _, n := range deleted {
+		total += n
+	}
+	return total, nil
+}
@@ -0,0 +1,87 @@
+package subscriber
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"like-service/events"
+	natsClient "like-service/nats"
+	"like-service/repository"
+	"shared/eventschema"
+	eventspb "shared/eventschema/pb"
+	"shared/logging"
+	"shared/tracing"
+)
+
+// subscribeRetry is the wait between attempts to subscribe while the stream
+// does not exist yet
+const subscribeRetry = 5 * time.Second
+
+// PostSubscriber deletes the likes of deleted posts. It consumes the
+// JetStream stream through a durable consumer, so deletions made while the
+// service is down are still handled, and handling one twice is harmless.
+type PostSubscriber struct {
+	natsClient *natsClient.Client
+	repo       repository.LikeRepository
+	ctx        context.Context
+}
+
+func NewPostSubscriber(
+	natsClient *natsClient.Client,
+	repo repository.LikeRepository,
+	ctx context.Context,
+) *PostSubscriber {
+	return &PostSubscriber{
+		natsClient: natsClient,
+		repo:       repo,
+		ctx:        ctx,
+	}
+}
+
+// Start subscribes in the background. The stream is created by
+// notification-service, so subscribing is retried until it exists. The
+// subscription is never unsubscribed, which would delete the durable
+// consumer; closing the NATS connection ends it.
+func (s *PostSubscriber) Start() {
+	go func() {
+		for {
+			_, err := s.natsClient.SubscribeDurable(events.PostDeleted, "like-service-post-deletions", "like-workers", s.handle)
+			if err == nil {
+				log.Println("Post subscriber started successfully")
+				return
+			}
+
+			log.Printf("Failed to start post subscriber, retrying: %v", err)
+			select {
+			case <-time.After(subscribeRetry):
+			case <-s.ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+func (s *PostSubscriber) handle(msg *nats.Msg) {
+	ctx, span := tracing.StartProcess(s.ctx, msg.Subject, msg.Header)
+	ctx = logging.Extract(ctx, msg.Subject, msg.Header)
+	defer span.End()
+
+	var event eventspb.PostDeleted
+	if _, err := eventschema.Decode(events.PostDeleted, msg.Header, msg.Data, &event); err != nil {
+		logging.FromContext(ctx).Error().Err(err).Msg("failed to decode post deleted event")
+		msg.Nak()
+		return
+	}
+
+	deleted, err := s.repo.DeleteLikesByPost(ctx, eventschema.UUID(event.PostId))
+	if err != nil {
+		logging.FromContext(ctx).Error().Err(err).Str("post_id", event.PostId).Msg("failed to delete likes of deleted post")
+		msg.Nak()
+		return
+	}
+
+	logging.FromContext(ctx).Info().Str("post_id", event.PostId).Int64("deleted", deleted).Msg("deleted likes of deleted post")
+	msg.Ack()
+}
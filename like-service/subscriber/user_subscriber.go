@@ -0,0 +1,77 @@
+package subscriber
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"like-service/events"
+	natsClient "like-service/nats"
+	"like-service/repository"
+	"shared/logging"
+	"shared/tracing"
+)
+
+// UserSubscriber deletes the likes of users who delete their account
+type UserSubscriber struct {
+	natsClient *natsClient.Client
+	repo       repository.LikeRepository
+	ctx        context.Context
+}
+
+func NewUserSubscriber(
+	natsClient *natsClient.Client,
+	repo repository.LikeRepository,
+	ctx context.Context,
+) *UserSubscriber {
+	return &UserSubscriber{
+		natsClient: natsClient,
+		repo:       repo,
+		ctx:        ctx,
+	}
+}
+
+// Start subscribes in the background, retrying until the stream exists like
+// PostSubscriber.Start
+func (s *UserSubscriber) Start() {
+	go func() {
+		for {
+			_, err := s.natsClient.SubscribeDurable(events.UserDeleted, "like-service-user-deletions", "like-workers", s.handle)
+			if err == nil {
+				log.Println("User subscriber started successfully")
+				return
+			}
+
+			log.Printf("Failed to start user subscriber, retrying: %v", err)
+			select {
+			case <-time.After(subscribeRetry):
+			case <-s.ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+func (s *UserSubscriber) handle(msg *nats.Msg) {
+	ctx, span := tracing.StartProcess(s.ctx, msg.Subject, msg.Header)
+	ctx = logging.Extract(ctx, msg.Subject, msg.Header)
+	defer span.End()
+
+	var event events.UserDeletedEvent
+	if err := natsClient.DecodeEvent(msg, &event); err != nil {
+		logging.FromContext(ctx).Error().Err(err).Msg("failed to decode user deleted event")
+		msg.Nak()
+		return
+	}
+
+	deleted, err := s.repo.DeleteLikesByUser(ctx, event.UserID)
+	if err != nil {
+		logging.FromContext(ctx).Error().Err(err).Stringer("user_id", event.UserID).Msg("failed to delete likes of deleted user")
+		msg.Nak()
+		return
+	}
+
+	logging.FromContext(ctx).Info().Stringer("user_id", event.UserID).Int64("deleted", deleted).Msg("deleted likes of deleted user")
+	msg.Ack()
+}
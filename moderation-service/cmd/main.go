@@ -0,0 +1,189 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"net"
+	"os"
+
+	"github.com/joho/godotenv"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+
+	authpb "auth-service/pb"
+	commentpb "comment-service/pb"
+	postpb "post-service/pb"
+
+	"moderation-service/config"
+	"moderation-service/db"
+	"moderation-service/handler"
+	"moderation-service/interceptor"
+	"moderation-service/migrations"
+	pb "moderation-service/pb"
+	"moderation-service/repository"
+	"moderation-service/rpcerror"
+	"shared/chaos"
+	"shared/lifecycle"
+	"shared/logging"
+	"shared/migrate"
+	"shared/mtls"
+	"shared/tracing"
+)
+
+func main() {
+	logging.Init("moderation-service")
+
+	migrateFlag := flag.Bool("migrate", false, "apply pending schema migrations before serving")
+	flag.Parse()
+
+	if err := godotenv.Load(); err != nil {
+		log.Println("failed to load Moderation .env")
+	}
+
+	// The supervisor health-checks the dependencies below and closes them
+	// in order on shutdown
+	supervisor := lifecycle.New(pb.ModerationService_ServiceDesc.ServiceName)
+
+	// Load database configuration
+	dbCfg, err := config.LoadDatabaseConfig("MODERATION_")
+	if err != nil {
+		log.Fatalf("Failed to load Moderation database config: %v", err)
+	}
+
+	// Connect to the database
+	dbConn, err := database.NewConnection(database.Config{
+		Host:         dbCfg.Host,
+		Port:         dbCfg.Port,
+		User:         dbCfg.User,
+		Password:     dbCfg.Password,
+		DBName:       dbCfg.DBName,
+		SSLMode:      dbCfg.SSLMode,
+		MaxOpenConns: dbCfg.MaxOpenConns,
+		MaxIdleConns: dbCfg.MaxIdleConns,
+		MaxLifetime:  dbCfg.MaxLifetime,
+		ReplicaDSNs:  dbCfg.ReplicaDSNs,
+	})
+	if err != nil {
+		log.Fatalf("Failed to connect to Moderation database: %v", err)
+	}
+	supervisor.Add(lifecycle.Dependency{Name: "database", Check: dbConn.HealthCheck, Close: dbConn.Close})
+
+	// Apply pending schema migrations with -migrate or DB_MIGRATE=true;
+	// without either they are only reported
+	if err := migrate.OnStartup(context.Background(), dbConn.DB, "moderation-service", migrations.Files, *migrateFlag || os.Getenv("DB_MIGRATE") == "true"); err != nil {
+		log.Fatalf("Failed to migrate Moderation database: %v", err)
+	}
+
+	log.Println("Successfully connected to database")
+
+	// Load other service-level configs
+	grpcPort := getEnv("GRPC_PORT", "50063")
+	jwtSecret := getEnv("JWT_SECRET", "your-secret-key")
+	// Tokens are verified with JWT_SECRET, or with auth-service's public keys
+	// when JWT_ALGORITHM is RS256
+	tokenKeys, err := interceptor.KeysFromEnv(jwtSecret)
+	if err != nil {
+		log.Fatalf("Invalid JWT configuration: %v", err)
+	}
+
+	// Fault injection for resilience testing; inert unless CHAOS_ENABLED=true
+	chaosInjector, err := chaos.FromEnv()
+	if err != nil {
+		log.Fatalf("Invalid chaos configuration: %v", err)
+	}
+
+	// Export traces when OTEL_EXPORTER_OTLP_ENDPOINT is set
+	shutdownTracing, err := tracing.Init(context.Background(), "moderation-service")
+	if err != nil {
+		log.Fatalf("Failed to initialize tracing: %v", err)
+	}
+	supervisor.OnShutdown("tracing", shutdownTracing)
+
+	// Other services are dialed over TLS when GRPC_TLS is set
+	clientTLS, err := mtls.DialOption()
+	if err != nil {
+		log.Fatalf("Failed to load TLS config: %v", err)
+	}
+
+	// Connect to the services moderation actions are carried out by
+	dial := func(addr string) *grpc.ClientConn {
+		conn, err := grpc.NewClient(addr, clientTLS, tracing.DialOption(), grpc.WithChainUnaryInterceptor(logging.UnaryClientInterceptor()))
+		if err != nil {
+			log.Fatalf("Failed to connect to %s: %v", addr, err)
+		}
+		return conn
+	}
+	authConn := dial(getEnv("AUTH_SERVICE_ADDR", "auth-service:50051"))
+	defer authConn.Close()
+	postConn := dial(getEnv("POST_SERVICE_ADDR", "post-service:50053"))
+	defer postConn.Close()
+	commentConn := dial(getEnv("COMMENT_SERVICE_ADDR", "comment-service:50056"))
+	defer commentConn.Close()
+
+	// Initialize repository and handler
+	moderationRepo := repository.NewModerationRepository(dbConn)
+	moderationHandler := handler.NewModerationHandler(moderationRepo, handler.Clients{
+		Auth:    authpb.NewAuthServiceClient(authConn),
+		Post:    postpb.NewPostServiceClient(postConn),
+		Comment: commentpb.NewCommentServiceClient(commentConn),
+	})
+
+	// Every method requires authentication; everything but reporting
+	// requires ADMIN
+	authInterceptor := interceptor.NewAuthInterceptor(tokenKeys, nil)
+	authInterceptor.AddAdminMethods([]string{
+		"/moderation.ModerationService/ListReports",
+		"/moderation.ModerationService/ReviewReport",
+		"/moderation.ModerationService/ResolveReport",
+		"/moderation.ModerationService/DismissReports",
+		"/moderation.ModerationService/RemovePost",
+		"/moderation.ModerationService/RemoveComment",
+		"/moderation.ModerationService/SuspendUser",
+		"/moderation.ModerationService/UnsuspendUser",
+		"/moderation.ModerationService/ListAuditLog",
+	})
+	authInterceptor.AddPublicMethods(lifecycle.HealthMethods)
+
+	// TLS, and mutual TLS with a CA bundle, from GRPC_TLS_*
+	tlsOption, err := mtls.ServerOption()
+	if err != nil {
+		log.Fatalf("Failed to load TLS config: %v", err)
+	}
+
+	// Create gRPC server
+	grpcServer := grpc.NewServer(
+		tlsOption,
+		tracing.ServerOption(),
+		grpc.ChainUnaryInterceptor(logging.UnaryServerInterceptor(), rpcerror.UnaryServerInterceptor(), chaosInjector.Unary(), authInterceptor.Unary()),
+	)
+
+	// Register the gRPC service
+	pb.RegisterModerationServiceServer(grpcServer, moderationHandler)
+	supervisor.Register(grpcServer)
+
+	// Enable reflection for debugging tools like grpcurl
+	reflection.Register(grpcServer)
+
+	// Start listening for connections
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%s", grpcPort))
+	if err != nil {
+		log.Fatalf("Failed to listen on port %s: %v", grpcPort, err)
+	}
+
+	log.Printf("Moderation Service gRPC server listening on port %s", grpcPort)
+
+	// Serve requests
+	if err := supervisor.Serve(grpcServer, listener); err != nil {
+		log.Fatalf("Failed to serve: %v", err)
+	}
+}
+
+// small helpers for optional env vars
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
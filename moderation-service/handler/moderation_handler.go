@@ -0,0 +1,539 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	authpb "auth-service/pb"
+	commentpb "comment-service/pb"
+	postpb "post-service/pb"
+
+	"moderation-service/interceptor"
+	"moderation-service/model"
+	pb "moderation-service/pb"
+	"moderation-service/repository"
+	"moderation-service/rpcerror"
+	"shared/logging"
+)
+
+const (
+	defaultListLimit = 20
+	maxListLimit     = 100
+	maxReasonLength  = 1000
+)
+
+// Clients are the services moderation actions are carried out by
+type Clients struct {
+	Auth    authpb.AuthServiceClient
+	Post    postpb.PostServiceClient
+	Comment commentpb.CommentServiceClient
+}
+
+type ModerationHandler struct {
+	pb.UnimplementedModerationServiceServer
+	repo    repository.ModerationRepository
+	clients Clients
+}
+
+func NewModerationHandler(repo repository.ModerationRepository, clients Clients) *ModerationHandler {
+	return &ModerationHandler{
+		repo:    repo,
+		clients: clients,
+	}
+}
+
+func (h *ModerationHandler) ReportPost(ctx context.Context, req *pb.ReportPostRequest) (*pb.Report, error) {
+	postID, err := uuid.Parse(req.PostId)
+	if err != nil {
+		return nil, rpcerror.InvalidField("post_id", "invalid post_id")
+	}
+	return h.report(ctx, models.TargetPost, postID, req.Reason, req.Details, req.ShowReporter)
+}
+
+func (h *ModerationHandler) ReportComment(ctx context.Context, req *pb.ReportCommentRequest) (*pb.Report, error) {
+	commentID, err := uuid.Parse(req.CommentId)
+	if err != nil {
+		return nil, rpcerror.InvalidField("comment_id", "invalid comment_id")
+	}
+	return h.report(ctx, models.TargetComment, commentID, req.Reason, req.Details, req.ShowReporter)
+}
+
+func (h *ModerationHandler) ReportUser(ctx context.Context, req *pb.ReportUserRequest) (*pb.Report, error) {
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, rpcerror.InvalidField("user_id", "invalid user_id")
+	}
+	return h.report(ctx, models.TargetUser, userID, req.Reason, req.Details, req.ShowReporter)
+}
+
+// report files a report for moderators. A user can have one unresolved
+// report per target; reports are not checked against the target, which an
+// admin may find already gone.
+func (h *ModerationHandler) report(ctx context.Context, targetType models.TargetType, targetID uuid.UUID, reason pb.ReportReason, details string, showReporter bool) (*pb.Report, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if targetType == models.TargetUser && targetID == userID {
+		return nil, status.Error(codes.InvalidArgument, "you cannot report yourself")
+	}
+	if reason == pb.ReportReason_REPORT_REASON_UNSPECIFIED {
+		return nil, rpcerror.InvalidField("reason", "reason is required")
+	}
+	details = strings.TrimSpace(details)
+	if reason == pb.ReportReason_REPORT_REASON_OTHER && details == "" {
+		return nil, status.Error(codes.InvalidArgument, "details are required when the reason is OTHER")
+	}
+	if len(details) > maxReasonLength {
+		return nil, rpcerror.InvalidField("details", fmt.Sprintf("details must be at most %d characters", maxReasonLength))
+	}
+
+	report := &models.Report{
+		ID:         uuid.New(),
+		ReporterID: userID,
+		TargetType: targetType,
+		TargetID:   targetID,
+		Reason:     reasonFromProto(reason),
+		Details:    details,
+		Anonymous:  !showReporter,
+		Status:     models.ReportOpen,
+		CreatedAt:  time.Now(),
+	}
+	if err := h.repo.CreateReport(ctx, report); err != nil {
+		if errors.Is(err, repository.ErrDuplicateReport) {
+			return nil, status.Error(codes.AlreadyExists, err.Error())
+		}
+		return nil, status.Error(codes.Internal, "failed to create report")
+	}
+
+	return convertReportToProto(report), nil
+}
+
+func (h *ModerationHandler) ListReports(ctx context.Context, req *pb.ListReportsRequest) (*pb.ListReportsResponse, error) {
+	var filter models.ReportFilter
+	if req.Status != nil && *req.Status != pb.ReportStatus_REPORT_STATUS_UNSPECIFIED {
+		s := models.ReportStatus(strings.TrimPrefix(req.Status.String(), "REPORT_STATUS_"))
+		filter.Status = &s
+	}
+	if req.Reason != nil && *req.Reason != pb.ReportReason_REPORT_REASON_UNSPECIFIED {
+		r := reasonFromProto(*req.Reason)
+		filter.Reason = &r
+	}
+	if req.TargetType != nil && *req.TargetType != pb.TargetType_TARGET_TYPE_UNSPECIFIED {
+		t := targetTypeFromProto(*req.TargetType)
+		filter.TargetType = &t
+	}
+
+	reports, err := h.repo.ListReports(ctx, filter, listLimit(req.Limit))
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to list reports")
+	}
+
+	resp := &pb.ListReportsResponse{Reports: make([]*pb.Report, 0, len(reports))}
+	for i := range reports {
+		resp.Reports = append(resp.Reports, convertReportToProto(&reports[i]))
+	}
+	return resp, nil
+}
+
+// ReviewReport claims an open report for the caller, so other admins can see
+// it is being looked at
+func (h *ModerationHandler) ReviewReport(ctx context.Context, req *pb.ReviewReportRequest) (*pb.Report, error) {
+	adminID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	reportID, err := uuid.Parse(req.ReportId)
+	if err != nil {
+		return nil, rpcerror.InvalidField("report_id", "invalid report_id")
+	}
+
+	report, err := h.repo.StartReview(ctx, reportID, adminID)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrReportNotFound):
+			return nil, status.Error(codes.NotFound, err.Error())
+		case errors.Is(err, repository.ErrReportClaimed):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		return nil, status.Error(codes.Internal, "failed to start review")
+	}
+	return convertReportToProto(report), nil
+}
+
+// ResolveReport settles a single report without touching the other reports
+// on its target. It is recorded in the audit log like any other action.
+func (h *ModerationHandler) ResolveReport(ctx context.Context, req *pb.ResolveReportRequest) (*pb.Report, error) {
+	adminID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	reportID, err := uuid.Parse(req.ReportId)
+	if err != nil {
+		return nil, rpcerror.InvalidField("report_id", "invalid report_id")
+	}
+	if req.Resolution == pb.ReportResolution_REPORT_RESOLUTION_UNSPECIFIED {
+		return nil, rpcerror.InvalidField("resolution", "resolution is required")
+	}
+	resolution := models.ReportResolution(strings.TrimPrefix(req.Resolution.String(), "REPORT_RESOLUTION_"))
+	note := strings.TrimSpace(req.Note)
+	if err := checkReason(note); err != nil {
+		return nil, err
+	}
+
+	var resolved *models.Report
+	err = h.repo.WithTx(ctx, func(ctx context.Context) error {
+		report, err := h.repo.GetReport(ctx, reportID)
+		if err != nil {
+			return reportError(err)
+		}
+		if report.Status == models.ReportResolved {
+			return status.Error(codes.FailedPrecondition, repository.ErrReportResolved.Error())
+		}
+
+		action := &models.Action{
+			ID:              uuid.New(),
+			AdminID:         adminID,
+			Action:          models.ActionResolveReport,
+			TargetType:      report.TargetType,
+			TargetID:        report.TargetID,
+			Reason:          note,
+			ReportsResolved: 1,
+			CreatedAt:       time.Now(),
+		}
+		if err := h.repo.CreateAction(ctx, action); err != nil {
+			return status.Error(codes.Internal, "failed to record action")
+		}
+
+		resolved, err = h.repo.ResolveReport(ctx, reportID, resolution, note, adminID, action.ID)
+		if err != nil {
+			return reportError(err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logging.FromContext(ctx).Info().
+		Stringer("report_id", reportID).
+		Str("resolution", string(resolution)).
+		Msg("report resolved")
+
+	return convertReportToProto(resolved), nil
+}
+
+// DismissReports resolves the unresolved reports on a target without acting
+// on it
+func (h *ModerationHandler) DismissReports(ctx context.Context, req *pb.DismissReportsRequest) (*pb.ModerationAction, error) {
+	targetType, targetID, err := parseTarget(req.TargetType, req.TargetId)
+	if err != nil {
+		return nil, err
+	}
+
+	return h.act(ctx, models.ActionDismissReports, targetType, targetID, req.Reason, nil, nil)
+}
+
+func (h *ModerationHandler) RemovePost(ctx context.Context, req *pb.RemovePostRequest) (*pb.ModerationAction, error) {
+	postID, err := uuid.Parse(req.PostId)
+	if err != nil {
+		return nil, rpcerror.InvalidField("post_id", "invalid post_id")
+	}
+	if strings.TrimSpace(req.Reason) == "" {
+		return nil, rpcerror.InvalidField("reason", "reason is required")
+	}
+
+	return h.act(ctx, models.ActionRemovePost, models.TargetPost, postID, req.Reason, nil, func(ctx context.Context) error {
+		_, err := h.clients.Post.RemovePost(ctx, &postpb.RemovePostRequest{PostId: postID.String()})
+		return err
+	})
+}
+
+func (h *ModerationHandler) RemoveComment(ctx context.Context, req *pb.RemoveCommentRequest) (*pb.ModerationAction, error) {
+	commentID, err := uuid.Parse(req.CommentId)
+	if err != nil {
+		return nil, rpcerror.InvalidField("comment_id", "invalid comment_id")
+	}
+	if strings.TrimSpace(req.Reason) == "" {
+		return nil, rpcerror.InvalidField("reason", "reason is required")
+	}
+
+	return h.act(ctx, models.ActionRemoveComment, models.TargetComment, commentID, req.Reason, nil, func(ctx context.Context) error {
+		_, err := h.clients.Comment.RemoveComment(ctx, &commentpb.RemoveCommentRequest{CommentId: commentID.String()})
+		return err
+	})
+}
+
+// SuspendUser suspends a user until req.Until, or bans them when it is unset
+func (h *ModerationHandler) SuspendUser(ctx context.Context, req *pb.SuspendUserRequest) (*pb.ModerationAction, error) {
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, rpcerror.InvalidField("user_id", "invalid user_id")
+	}
+	if strings.TrimSpace(req.Reason) == "" {
+		return nil, rpcerror.InvalidField("reason", "reason is required")
+	}
+
+	var until *time.Time
+	if req.Until != nil {
+		if err := req.Until.CheckValid(); err != nil {
+			return nil, rpcerror.InvalidField("until", "invalid until")
+		}
+		t := req.Until.AsTime()
+		until = &t
+	}
+
+	return h.act(ctx, models.ActionSuspendUser, models.TargetUser, userID, req.Reason, until, func(ctx context.Context) error {
+		_, err := h.clients.Auth.SuspendUser(ctx, &authpb.SuspendUserRequest{
+			UserId: userID.String(),
+			Reason: req.Reason,
+			Until:  req.Until,
+		})
+		return err
+	})
+}
+
+func (h *ModerationHandler) UnsuspendUser(ctx context.Context, req *pb.UnsuspendUserRequest) (*pb.ModerationAction, error) {
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, rpcerror.InvalidField("user_id", "invalid user_id")
+	}
+
+	return h.act(ctx, models.ActionUnsuspendUser, models.TargetUser, userID, req.Reason, nil, func(ctx context.Context) error {
+		_, err := h.clients.Auth.UnsuspendUser(ctx, &authpb.UnsuspendUserRequest{UserId: userID.String()})
+		return err
+	})
+}
+
+func (h *ModerationHandler) ListAuditLog(ctx context.Context, req *pb.ListAuditLogRequest) (*pb.ListAuditLogResponse, error) {
+	var filter models.ActionFilter
+	if req.AdminId != nil && *req.AdminId != "" {
+		id, err := uuid.Parse(*req.AdminId)
+		if err != nil {
+			return nil, rpcerror.InvalidField("admin_id", "invalid admin_id")
+		}
+		filter.AdminID = &id
+	}
+	if req.TargetType != nil && *req.TargetType != pb.TargetType_TARGET_TYPE_UNSPECIFIED {
+		t := targetTypeFromProto(*req.TargetType)
+		filter.TargetType = &t
+	}
+	if req.TargetId != nil && *req.TargetId != "" {
+		id, err := uuid.Parse(*req.TargetId)
+		if err != nil {
+			return nil, rpcerror.InvalidField("target_id", "invalid target_id")
+		}
+		filter.TargetID = &id
+	}
+
+	actions, err := h.repo.ListActions(ctx, filter, listLimit(req.Limit))
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to list audit log")
+	}
+
+	resp := &pb.ListAuditLogResponse{Actions: make([]*pb.ModerationAction, 0, len(actions))}
+	for i := range actions {
+		resp.Actions = append(resp.Actions, convertActionToProto(&actions[i]))
+	}
+	return resp, nil
+}
+
+// act records an admin action in the audit log, resolves the reports on
+// its target and carries it out, all or nothing: when carryOut fails, its
+// error is returned and neither the entry nor the resolutions are kept.
+// carryOut is called with the admin's token, so the service doing the work
+// checks the ADMIN role itself and records the admin as the actor.
+func (h *ModerationHandler) act(ctx context.Context, actionType models.ActionType, targetType models.TargetType, targetID uuid.UUID, reason string, until *time.Time, carryOut func(ctx context.Context) error) (*pb.ModerationAction, error) {
+	adminID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	reason = strings.TrimSpace(reason)
+	if err := checkReason(reason); err != nil {
+		return nil, err
+	}
+
+	resolution := models.ResolutionActioned
+	if actionType == models.ActionDismissReports {
+		resolution = models.ResolutionDismissed
+	}
+
+	action := &models.Action{
+		ID:             uuid.New(),
+		AdminID:        adminID,
+		Action:         actionType,
+		TargetType:     targetType,
+		TargetID:       targetID,
+		Reason:         reason,
+		SuspendedUntil: until,
+		CreatedAt:      time.Now(),
+	}
+
+	err = h.repo.WithTx(ctx, func(ctx context.Context) error {
+		resolved, err := h.repo.ResolveReports(ctx, targetType, targetID, resolution, adminID, action.ID)
+		if err != nil {
+			return status.Error(codes.Internal, "failed to resolve reports")
+		}
+		action.ReportsResolved = resolved
+
+		if err := h.repo.CreateAction(ctx, action); err != nil {
+			return status.Error(codes.Internal, "failed to record action")
+		}
+
+		if carryOut != nil {
+			return carryOut(forwardToken(ctx))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logging.FromContext(ctx).Info().
+		Str("action", string(actionType)).
+		Str("target_type", string(targetType)).
+		Stringer("target_id", targetID).
+		Int32("reports_resolved", action.ReportsResolved).
+		Msg("moderation action taken")
+
+	return convertActionToProto(action), nil
+}
+
+// forwardToken passes the caller's token on to the services an action is
+// carried out by
+func forwardToken(ctx context.Context) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get("authorization"); len(values) > 0 {
+		return metadata.AppendToOutgoingContext(ctx, "authorization", values[0])
+	}
+	return ctx
+}
+
+func callerID(ctx context.Context) (uuid.UUID, error) {
+	raw, err := interceptor.GetUserIDFromContext(ctx)
+	if err != nil {
+		return uuid.Nil, status.Error(codes.Unauthenticated, "user not authenticated")
+	}
+	userID, err := uuid.Parse(raw)
+	if err != nil {
+		return uuid.Nil, status.Error(codes.Unauthenticated, "invalid user ID in token")
+	}
+	return userID, nil
+}
+
+func parseTarget(targetType pb.TargetType, rawID string) (models.TargetType, uuid.UUID, error) {
+	if targetType == pb.TargetType_TARGET_TYPE_UNSPECIFIED {
+		return "", uuid.Nil, rpcerror.InvalidField("target_type", "target_type is required")
+	}
+	targetID, err := uuid.Parse(rawID)
+	if err != nil {
+		return "", uuid.Nil, rpcerror.InvalidField("target_id", "invalid target_id")
+	}
+	return targetTypeFromProto(targetType), targetID, nil
+}
+
+// reportError maps the repository's report errors to gRPC statuses
+func reportError(err error) error {
+	switch {
+	case errors.Is(err, repository.ErrReportNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, repository.ErrReportResolved):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return status.Error(codes.Internal, "failed to resolve report")
+}
+
+func checkReason(reason string) error {
+	if len(reason) > maxReasonLength {
+		return rpcerror.InvalidField("reason", fmt.Sprintf("reason must be at most %d characters", maxReasonLength))
+	}
+	return nil
+}
+
+func listLimit(limit int32) int32 {
+	if limit <= 0 {
+		return defaultListLimit
+	}
+	if limit > maxListLimit {
+		return maxListLimit
+	}
+	return limit
+}
+
+func targetTypeFromProto(t pb.TargetType) models.TargetType {
+	return models.TargetType(strings.TrimPrefix(t.String(), "TARGET_TYPE_"))
+}
+
+func reasonFromProto(r pb.ReportReason) models.ReportReason {
+	return models.ReportReason(strings.TrimPrefix(r.String(), "REPORT_REASON_"))
+}
+
+func targetTypeToProto(t models.TargetType) pb.TargetType {
+	return pb.TargetType(pb.TargetType_value["TARGET_TYPE_"+string(t)])
+}
+
+// convertReportToProto leaves out the reporter of anonymous reports
+func convertReportToProto(report *models.Report) *pb.Report {
+	out := &pb.Report{
+		Id:             report.ID.String(),
+		TargetType:     targetTypeToProto(report.TargetType),
+		TargetId:       report.TargetID.String(),
+		Reason:         pb.ReportReason(pb.ReportReason_value["REPORT_REASON_"+string(report.Reason)]),
+		Details:        report.Details,
+		Status:         pb.ReportStatus(pb.ReportStatus_value["REPORT_STATUS_"+string(report.Status)]),
+		CreatedAt:      timestamppb.New(report.CreatedAt),
+		ResolutionNote: report.ResolutionNote,
+	}
+	if !report.Anonymous {
+		reporterID := report.ReporterID.String()
+		out.ReporterId = &reporterID
+	}
+	if report.ReviewerID != nil {
+		reviewerID := report.ReviewerID.String()
+		out.ReviewerId = &reviewerID
+	}
+	if report.ReviewStartedAt != nil {
+		out.ReviewStartedAt = timestamppb.New(*report.ReviewStartedAt)
+	}
+	if report.Resolution != nil {
+		out.Resolution = pb.ReportResolution(pb.ReportResolution_value["REPORT_RESOLUTION_"+string(*report.Resolution)])
+	}
+	if report.ResolvedBy != nil {
+		resolvedBy := report.ResolvedBy.String()
+		out.ResolvedBy = &resolvedBy
+	}
+	if report.ResolvedAt != nil {
+		out.ResolvedAt = timestamppb.New(*report.ResolvedAt)
+	}
+	if report.ActionID != nil {
+		actionID := report.ActionID.String()
+		out.ActionId = &actionID
+	}
+	return out
+}
+
+func convertActionToProto(action *models.Action) *pb.ModerationAction {
+	out := &pb.ModerationAction{
+		Id:              action.ID.String(),
+		AdminId:         action.AdminID.String(),
+		Action:          pb.ActionType(pb.ActionType_value["ACTION_TYPE_"+string(action.Action)]),
+		TargetType:      targetTypeToProto(action.TargetType),
+		TargetId:        action.TargetID.String(),
+		Reason:          action.Reason,
+		ReportsResolved: action.ReportsResolved,
+		CreatedAt:       timestamppb.New(action.CreatedAt),
+	}
+	if action.SuspendedUntil != nil {
+		out.SuspendedUntil = timestamppb.New(*action.SuspendedUntil)
+	}
+	return out
+}
@@ -0,0 +1,49 @@
+-- ========================================
+-- Moderation Service Schema (Standalone)
+-- ========================================
+
+-- ========================================
+-- Audit Log (one row per admin action, never updated or deleted)
+-- ========================================
+CREATE TABLE IF NOT EXISTS moderation_service_actions (
+    id UUID PRIMARY KEY,
+    admin_id UUID NOT NULL,
+    action VARCHAR(32) NOT NULL,
+    target_type VARCHAR(16) NOT NULL,
+    target_id UUID NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    suspended_until TIMESTAMP WITH TIME ZONE,
+    reports_resolved INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT moderation_actions_action_valid CHECK (
+        action IN ('REMOVE_POST', 'REMOVE_COMMENT', 'SUSPEND_USER', 'UNSUSPEND_USER', 'DISMISS_REPORTS')
+    ),
+    CONSTRAINT moderation_actions_target_type_valid CHECK (target_type IN ('POST', 'COMMENT', 'USER'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_moderation_actions_created_at ON moderation_service_actions(created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_moderation_actions_admin ON moderation_service_actions(admin_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_moderation_actions_target ON moderation_service_actions(target_type, target_id, created_at DESC);
+
+-- ========================================
+-- Reports (a user may have one open report per target)
+-- ========================================
+CREATE TABLE IF NOT EXISTS moderation_service_reports (
+    id UUID PRIMARY KEY,
+    reporter_id UUID NOT NULL,
+    target_type VARCHAR(16) NOT NULL,
+    target_id UUID NOT NULL,
+    reason TEXT NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'OPEN',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    resolved_by UUID,
+    resolved_at TIMESTAMP WITH TIME ZONE,
+    action_id UUID,
+    CONSTRAINT moderation_reports_target_type_valid CHECK (target_type IN ('POST', 'COMMENT', 'USER')),
+    CONSTRAINT moderation_reports_status_valid CHECK (status IN ('OPEN', 'ACTIONED', 'DISMISSED'))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_moderation_reports_open_unique
+    ON moderation_service_reports(reporter_id, target_type, target_id) WHERE status = 'OPEN';
+CREATE INDEX IF NOT EXISTS idx_moderation_reports_target ON moderation_service_reports(target_type, target_id) WHERE status = 'OPEN';
+CREATE INDEX IF NOT EXISTS idx_moderation_reports_status ON moderation_service_reports(status, created_at DESC);
@@ -0,0 +1,46 @@
+-- ========================================
+-- Reports carry a reason category with optional details, may hide their
+-- reporter from admins, and move OPEN -> REVIEWING -> RESOLVED. How a report
+-- was resolved moves from its status to its resolution.
+-- ========================================
+ALTER TABLE moderation_service_reports RENAME COLUMN reason TO details;
+ALTER TABLE moderation_service_reports ALTER COLUMN details SET DEFAULT '';
+
+ALTER TABLE moderation_service_reports
+    ADD COLUMN reason VARCHAR(32) NOT NULL DEFAULT 'OTHER',
+    ADD COLUMN anonymous BOOLEAN NOT NULL DEFAULT TRUE,
+    ADD COLUMN reviewer_id UUID,
+    ADD COLUMN review_started_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN resolution VARCHAR(16),
+    ADD COLUMN resolution_note TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE moderation_service_reports DROP CONSTRAINT moderation_reports_status_valid;
+
+UPDATE moderation_service_reports
+SET resolution = status, status = 'RESOLVED'
+WHERE status IN ('ACTIONED', 'DISMISSED');
+
+ALTER TABLE moderation_service_reports
+    ADD CONSTRAINT moderation_reports_status_valid CHECK (status IN ('OPEN', 'REVIEWING', 'RESOLVED')),
+    ADD CONSTRAINT moderation_reports_resolution_valid CHECK (
+        (status = 'RESOLVED') = (resolution IS NOT NULL)
+        AND (resolution IS NULL OR resolution IN ('ACTIONED', 'DISMISSED'))
+    ),
+    ADD CONSTRAINT moderation_reports_reason_valid CHECK (
+        reason IN ('SPAM', 'HARASSMENT', 'HATE_SPEECH', 'VIOLENCE', 'NUDITY', 'MISINFORMATION', 'SELF_HARM', 'IMPERSONATION', 'OTHER')
+    );
+
+-- Reports under review still count as unresolved
+DROP INDEX IF EXISTS idx_moderation_reports_open_unique;
+DROP INDEX IF EXISTS idx_moderation_reports_target;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_moderation_reports_unresolved_unique
+    ON moderation_service_reports(reporter_id, target_type, target_id) WHERE status <> 'RESOLVED';
+CREATE INDEX IF NOT EXISTS idx_moderation_reports_target ON moderation_service_reports(target_type, target_id) WHERE status <> 'RESOLVED';
+
+-- ========================================
+-- Resolving a single report is an audited action
+-- ========================================
+ALTER TABLE moderation_service_actions DROP CONSTRAINT moderation_actions_action_valid;
+ALTER TABLE moderation_service_actions ADD CONSTRAINT moderation_actions_action_valid CHECK (
+    action IN ('REMOVE_POST', 'REMOVE_COMMENT', 'SUSPEND_USER', 'UNSUSPEND_USER', 'DISMISS_REPORTS', 'RESOLVE_REPORT')
+);
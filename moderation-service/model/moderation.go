@@ -0,0 +1,114 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TargetType is the kind of thing a report or action is about
+type TargetType string
+
+const (
+	TargetPost    TargetType = "POST"
+	TargetComment TargetType = "COMMENT"
+	TargetUser    TargetType = "USER"
+)
+
+// ReportReason is the category a reporter picks for a report
+type ReportReason string
+
+const (
+	ReasonSpam           ReportReason = "SPAM"
+	ReasonHarassment     ReportReason = "HARASSMENT"
+	ReasonHateSpeech     ReportReason = "HATE_SPEECH"
+	ReasonViolence       ReportReason = "VIOLENCE"
+	ReasonNudity         ReportReason = "NUDITY"
+	ReasonMisinformation ReportReason = "MISINFORMATION"
+	ReasonSelfHarm       ReportReason = "SELF_HARM"
+	ReasonImpersonation  ReportReason = "IMPERSONATION"
+	ReasonOther          ReportReason = "OTHER"
+)
+
+// ReportStatus is where a report is in review. Reports go from OPEN to
+// REVIEWING when an admin picks them up, and to RESOLVED from either.
+type ReportStatus string
+
+const (
+	ReportOpen      ReportStatus = "OPEN"
+	ReportReviewing ReportStatus = "REVIEWING"
+	ReportResolved  ReportStatus = "RESOLVED"
+)
+
+// ReportResolution is how a resolved report was settled
+type ReportResolution string
+
+const (
+	// ResolutionActioned means an admin acted on the target
+	ResolutionActioned ReportResolution = "ACTIONED"
+	// ResolutionDismissed means an admin found nothing to act on
+	ResolutionDismissed ReportResolution = "DISMISSED"
+)
+
+type ActionType string
+
+const (
+	ActionRemovePost     ActionType = "REMOVE_POST"
+	ActionRemoveComment  ActionType = "REMOVE_COMMENT"
+	ActionSuspendUser    ActionType = "SUSPEND_USER"
+	ActionUnsuspendUser  ActionType = "UNSUSPEND_USER"
+	ActionDismissReports ActionType = "DISMISS_REPORTS"
+	ActionResolveReport  ActionType = "RESOLVE_REPORT"
+)
+
+// Report is a user's request for moderators to look at a post, comment or
+// user. It is unresolved until an admin resolves it, or acts on or dismisses
+// its target.
+type Report struct {
+	ID         uuid.UUID    `json:"id" db:"id"`
+	ReporterID uuid.UUID    `json:"reporter_id" db:"reporter_id"`
+	TargetType TargetType   `json:"target_type" db:"target_type"`
+	TargetID   uuid.UUID    `json:"target_id" db:"target_id"`
+	Reason     ReportReason `json:"reason" db:"reason"`
+	Details    string       `json:"details" db:"details"`
+	// Anonymous reports do not show their reporter to admins
+	Anonymous       bool              `json:"anonymous" db:"anonymous"`
+	Status          ReportStatus      `json:"status" db:"status"`
+	CreatedAt       time.Time         `json:"created_at" db:"created_at"`
+	ReviewerID      *uuid.UUID        `json:"reviewer_id,omitempty" db:"reviewer_id"`
+	ReviewStartedAt *time.Time        `json:"review_started_at,omitempty" db:"review_started_at"`
+	Resolution      *ReportResolution `json:"resolution,omitempty" db:"resolution"`
+	ResolutionNote  string            `json:"resolution_note" db:"resolution_note"`
+	ResolvedBy      *uuid.UUID        `json:"resolved_by,omitempty" db:"resolved_by"`
+	ResolvedAt      *time.Time        `json:"resolved_at,omitempty" db:"resolved_at"`
+	// ActionID is the audit log entry that resolved the report
+	ActionID *uuid.UUID `json:"action_id,omitempty" db:"action_id"`
+}
+
+// ReportFilter narrows a list of reports; nil fields match everything
+type ReportFilter struct {
+	Status     *ReportStatus
+	Reason     *ReportReason
+	TargetType *TargetType
+}
+
+// Action is an audit log entry for something an admin did
+type Action struct {
+	ID         uuid.UUID  `json:"id" db:"id"`
+	AdminID    uuid.UUID  `json:"admin_id" db:"admin_id"`
+	Action     ActionType `json:"action" db:"action"`
+	TargetType TargetType `json:"target_type" db:"target_type"`
+	TargetID   uuid.UUID  `json:"target_id" db:"target_id"`
+	Reason     string     `json:"reason" db:"reason"`
+	// SuspendedUntil is when a suspension ends, nil for bans and other actions
+	SuspendedUntil  *time.Time `json:"suspended_until,omitempty" db:"suspended_until"`
+	ReportsResolved int32      `json:"reports_resolved" db:"reports_resolved"`
+	CreatedAt       time.Time  `json:"created_at" db:"created_at"`
+}
+
+// ActionFilter narrows the audit log; nil fields match everything
+type ActionFilter struct {
+	AdminID    *uuid.UUID
+	TargetType *TargetType
+	TargetID   *uuid.UUID
+}
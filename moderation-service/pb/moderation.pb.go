@@ -0,0 +1,1677 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v6.32.1
+// source: proto/moderation.proto
+
+package __
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type TargetType int32
+
+const (
+	TargetType_TARGET_TYPE_UNSPECIFIED TargetType = 0
+	TargetType_TARGET_TYPE_POST        TargetType = 1
+	TargetType_TARGET_TYPE_COMMENT     TargetType = 2
+	TargetType_TARGET_TYPE_USER        TargetType = 3
+)
+
+// Enum value maps for TargetType.
+var (
+	TargetType_name = map[int32]string{
+		0: "TARGET_TYPE_UNSPECIFIED",
+		1: "TARGET_TYPE_POST",
+		2: "TARGET_TYPE_COMMENT",
+		3: "TARGET_TYPE_USER",
+	}
+	TargetType_value = map[string]int32{
+		"TARGET_TYPE_UNSPECIFIED": 0,
+		"TARGET_TYPE_POST":        1,
+		"TARGET_TYPE_COMMENT":     2,
+		"TARGET_TYPE_USER":        3,
+	}
+)
+
+func (x TargetType) Enum() *TargetType {
+	p := new(TargetType)
+	*p = x
+	return p
+}
+
+func (x TargetType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TargetType) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_moderation_proto_enumTypes[0].Descriptor()
+}
+
+func (TargetType) Type() protoreflect.EnumType {
+	return &file_proto_moderation_proto_enumTypes[0]
+}
+
+func (x TargetType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TargetType.Descriptor instead.
+func (TargetType) EnumDescriptor() ([]byte, []int) {
+	return file_proto_moderation_proto_rawDescGZIP(), []int{0}
+}
+
+type ReportReason int32
+
+const (
+	ReportReason_REPORT_REASON_UNSPECIFIED    ReportReason = 0
+	ReportReason_REPORT_REASON_SPAM           ReportReason = 1
+	ReportReason_REPORT_REASON_HARASSMENT     ReportReason = 2
+	ReportReason_REPORT_REASON_HATE_SPEECH    ReportReason = 3
+	ReportReason_REPORT_REASON_VIOLENCE       ReportReason = 4
+	ReportReason_REPORT_REASON_NUDITY         ReportReason = 5
+	ReportReason_REPORT_REASON_MISINFORMATION ReportReason = 6
+	ReportReason_REPORT_REASON_SELF_HARM      ReportReason = 7
+	ReportReason_REPORT_REASON_IMPERSONATION  ReportReason = 8
+	ReportReason_REPORT_REASON_OTHER          ReportReason = 9 // details is required
+)
+
+// Enum value maps for ReportReason.
+var (
+	ReportReason_name = map[int32]string{
+		0: "REPORT_REASON_UNSPECIFIED",
+		1: "REPORT_REASON_SPAM",
+		2: "REPORT_REASON_HARASSMENT",
+		3: "REPORT_REASON_HATE_SPEECH",
+		4: "REPORT_REASON_VIOLENCE",
+		5: "REPORT_REASON_NUDITY",
+		6: "REPORT_REASON_MISINFORMATION",
+		7: "REPORT_REASON_SELF_HARM",
+		8: "REPORT_REASON_IMPERSONATION",
+		9: "REPORT_REASON_OTHER",
+	}
+	ReportReason_value = map[string]int32{
+		"REPORT_REASON_UNSPECIFIED":    0,
+		"REPORT_REASON_SPAM":           1,
+		"REPORT_REASON_HARASSMENT":     2,
+		"REPORT_REASON_HATE_SPEECH":    3,
+		"REPORT_REASON_VIOLENCE":       4,
+		"REPORT_REASON_NUDITY":         5,
+		"REPORT_REASON_MISINFORMATION": 6,
+		"REPORT_REASON_SELF_HARM":      7,
+		"REPORT_REASON_IMPERSONATION":  8,
+		"REPORT_REASON_OTHER":          9,
+	}
+)
+
+func (x ReportReason) Enum() *ReportReason {
+	p := new(ReportReason)
+	*p = x
+	return p
+}
+
+func (x ReportReason) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ReportReason) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_moderation_proto_enumTypes[1].Descriptor()
+}
+
+func (ReportReason) Type() protoreflect.EnumType {
+	return &file_proto_moderation_proto_enumTypes[1]
+}
+
+func (x ReportReason) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ReportReason.Descriptor instead.
+func (ReportReason) EnumDescriptor() ([]byte, []int) {
+	return file_proto_moderation_proto_rawDescGZIP(), []int{1}
+}
+
+type ReportStatus int32
+
+const (
+	ReportStatus_REPORT_STATUS_UNSPECIFIED ReportStatus = 0
+	ReportStatus_REPORT_STATUS_OPEN        ReportStatus = 1
+	ReportStatus_REPORT_STATUS_REVIEWING   ReportStatus = 2 // An admin has picked the report up
+	ReportStatus_REPORT_STATUS_RESOLVED    ReportStatus = 3
+)
+
+// Enum value maps for ReportStatus.
+var (
+	ReportStatus_name = map[int32]string{
+		0: "REPORT_STATUS_UNSPECIFIED",
+		1: "REPORT_STATUS_OPEN",
+		2: "REPORT_STATUS_REVIEWING",
+		3: "REPORT_STATUS_RESOLVED",
+	}
+	ReportStatus_value = map[string]int32{
+		"REPORT_STATUS_UNSPECIFIED": 0,
+		"REPORT_STATUS_OPEN":        1,
+		"REPORT_STATUS_REVIEWING":   2,
+		"REPORT_STATUS_RESOLVED":    3,
+	}
+)
+
+func (x ReportStatus) Enum() *ReportStatus {
+	p := new(ReportStatus)
+	*p = x
+	return p
+}
+
+func (x ReportStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ReportStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_moderation_proto_enumTypes[2].Descriptor()
+}
+
+func (ReportStatus) Type() protoreflect.EnumType {
+	return &file_proto_moderation_proto_enumTypes[2]
+}
+
+func (x ReportStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ReportStatus.Descriptor instead.
+func (ReportStatus) EnumDescriptor() ([]byte, []int) {
+	return file_proto_moderation_proto_rawDescGZIP(), []int{2}
+}
+
+type ReportResolution int32
+
+const (
+	ReportResolution_REPORT_RESOLUTION_UNSPECIFIED ReportResolution = 0
+	ReportResolution_REPORT_RESOLUTION_ACTIONED    ReportResolution = 1 // An admin acted on the target
+	ReportResolution_REPORT_RESOLUTION_DISMISSED   ReportResolution = 2 // An admin found nothing to act on
+)
+
+// Enum value maps for ReportResolution.
+var (
+	ReportResolution_name = map[int32]string{
+		0: "REPORT_RESOLUTION_UNSPECIFIED",
+		1: "REPORT_RESOLUTION_ACTIONED",
+		2: "REPORT_RESOLUTION_DISMISSED",
+	}
+	ReportResolution_value = map[string]int32{
+		"REPORT_RESOLUTION_UNSPECIFIED": 0,
+		"REPORT_RESOLUTION_ACTIONED":    1,
+		"REPORT_RESOLUTION_DISMISSED":   2,
+	}
+)
+
+func (x ReportResolution) Enum() *ReportResolution {
+	p := new(ReportResolution)
+	*p = x
+	return p
+}
+
+func (x ReportResolution) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ReportResolution) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_moderation_proto_enumTypes[3].Descriptor()
+}
+
+func (ReportResolution) Type() protoreflect.EnumType {
+	return &file_proto_moderation_proto_enumTypes[3]
+}
+
+func (x ReportResolution) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ReportResolution.Descriptor instead.
+func (ReportResolution) EnumDescriptor() ([]byte, []int) {
+	return file_proto_moderation_proto_rawDescGZIP(), []int{3}
+}
+
+type ActionType int32
+
+const (
+	ActionType_ACTION_TYPE_UNSPECIFIED     ActionType = 0
+	ActionType_ACTION_TYPE_REMOVE_POST     ActionType = 1
+	ActionType_ACTION_TYPE_REMOVE_COMMENT  ActionType = 2
+	ActionType_ACTION_TYPE_SUSPEND_USER    ActionType = 3
+	ActionType_ACTION_TYPE_UNSUSPEND_USER  ActionType = 4
+	ActionType_ACTION_TYPE_DISMISS_REPORTS ActionType = 5
+	ActionType_ACTION_TYPE_RESOLVE_REPORT  ActionType = 6
+)
+
+// Enum value maps for ActionType.
+var (
+	ActionType_name = map[int32]string{
+		0: "ACTION_TYPE_UNSPECIFIED",
+		1: "ACTION_TYPE_REMOVE_POST",
+		2: "ACTION_TYPE_REMOVE_COMMENT",
+		3: "ACTION_TYPE_SUSPEND_USER",
+		4: "ACTION_TYPE_UNSUSPEND_USER",
+		5: "ACTION_TYPE_DISMISS_REPORTS",
+		6: "ACTION_TYPE_RESOLVE_REPORT",
+	}
+	ActionType_value = map[string]int32{
+		"ACTION_TYPE_UNSPECIFIED":     0,
+		"ACTION_TYPE_REMOVE_POST":     1,
+		"ACTION_TYPE_REMOVE_COMMENT":  2,
+		"ACTION_TYPE_SUSPEND_USER":    3,
+		"ACTION_TYPE_UNSUSPEND_USER":  4,
+		"ACTION_TYPE_DISMISS_REPORTS": 5,
+		"ACTION_TYPE_RESOLVE_REPORT":  6,
+	}
+)
+
+func (x ActionType) Enum() *ActionType {
+	p := new(ActionType)
+	*p = x
+	return p
+}
+
+func (x ActionType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ActionType) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_moderation_proto_enumTypes[4].Descriptor()
+}
+
+func (ActionType) Type() protoreflect.EnumType {
+	return &file_proto_moderation_proto_enumTypes[4]
+}
+
+func (x ActionType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ActionType.Descriptor instead.
+func (ActionType) EnumDescriptor() ([]byte, []int) {
+	return file_proto_moderation_proto_rawDescGZIP(), []int{4}
+}
+
+// Reports are anonymous unless the reporter lets admins see who they are.
+// Reported users are never told who reported them.
+type ReportPostRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PostId        string                 `protobuf:"bytes,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
+	Reason        ReportReason           `protobuf:"varint,2,opt,name=reason,proto3,enum=moderation.ReportReason" json:"reason,omitempty"`
+	Details       string                 `protobuf:"bytes,3,opt,name=details,proto3" json:"details,omitempty"`
+	ShowReporter  bool                   `protobuf:"varint,4,opt,name=show_reporter,json=showReporter,proto3" json:"show_reporter,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportPostRequest) Reset() {
+	*x = ReportPostRequest{}
+	mi := &file_proto_moderation_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportPostRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportPostRequest) ProtoMessage() {}
+
+func (x *ReportPostRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_moderation_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportPostRequest.ProtoReflect.Descriptor instead.
+func (*ReportPostRequest) Descriptor() ([]byte, []int) {
+	return file_proto_moderation_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ReportPostRequest) GetPostId() string {
+	if x != nil {
+		return x.PostId
+	}
+	return ""
+}
+
+func (x *ReportPostRequest) GetReason() ReportReason {
+	if x != nil {
+		return x.Reason
+	}
+	return ReportReason_REPORT_REASON_UNSPECIFIED
+}
+
+func (x *ReportPostRequest) GetDetails() string {
+	if x != nil {
+		return x.Details
+	}
+	return ""
+}
+
+func (x *ReportPostRequest) GetShowReporter() bool {
+	if x != nil {
+		return x.ShowReporter
+	}
+	return false
+}
+
+type ReportCommentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CommentId     string                 `protobuf:"bytes,1,opt,name=comment_id,json=commentId,proto3" json:"comment_id,omitempty"`
+	Reason        ReportReason           `protobuf:"varint,2,opt,name=reason,proto3,enum=moderation.ReportReason" json:"reason,omitempty"`
+	Details       string                 `protobuf:"bytes,3,opt,name=details,proto3" json:"details,omitempty"`
+	ShowReporter  bool                   `protobuf:"varint,4,opt,name=show_reporter,json=showReporter,proto3" json:"show_reporter,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportCommentRequest) Reset() {
+	*x = ReportCommentRequest{}
+	mi := &file_proto_moderation_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportCommentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportCommentRequest) ProtoMessage() {}
+
+func (x *ReportCommentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_moderation_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportCommentRequest.ProtoReflect.Descriptor instead.
+func (*ReportCommentRequest) Descriptor() ([]byte, []int) {
+	return file_proto_moderation_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ReportCommentRequest) GetCommentId() string {
+	if x != nil {
+		return x.CommentId
+	}
+	return ""
+}
+
+func (x *ReportCommentRequest) GetReason() ReportReason {
+	if x != nil {
+		return x.Reason
+	}
+	return ReportReason_REPORT_REASON_UNSPECIFIED
+}
+
+func (x *ReportCommentRequest) GetDetails() string {
+	if x != nil {
+		return x.Details
+	}
+	return ""
+}
+
+func (x *ReportCommentRequest) GetShowReporter() bool {
+	if x != nil {
+		return x.ShowReporter
+	}
+	return false
+}
+
+type ReportUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Reason        ReportReason           `protobuf:"varint,2,opt,name=reason,proto3,enum=moderation.ReportReason" json:"reason,omitempty"`
+	Details       string                 `protobuf:"bytes,3,opt,name=details,proto3" json:"details,omitempty"`
+	ShowReporter  bool                   `protobuf:"varint,4,opt,name=show_reporter,json=showReporter,proto3" json:"show_reporter,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportUserRequest) Reset() {
+	*x = ReportUserRequest{}
+	mi := &file_proto_moderation_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportUserRequest) ProtoMessage() {}
+
+func (x *ReportUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_moderation_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportUserRequest.ProtoReflect.Descriptor instead.
+func (*ReportUserRequest) Descriptor() ([]byte, []int) {
+	return file_proto_moderation_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ReportUserRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ReportUserRequest) GetReason() ReportReason {
+	if x != nil {
+		return x.Reason
+	}
+	return ReportReason_REPORT_REASON_UNSPECIFIED
+}
+
+func (x *ReportUserRequest) GetDetails() string {
+	if x != nil {
+		return x.Details
+	}
+	return ""
+}
+
+func (x *ReportUserRequest) GetShowReporter() bool {
+	if x != nil {
+		return x.ShowReporter
+	}
+	return false
+}
+
+type ListReportsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        *ReportStatus          `protobuf:"varint,1,opt,name=status,proto3,enum=moderation.ReportStatus,oneof" json:"status,omitempty"`
+	TargetType    *TargetType            `protobuf:"varint,2,opt,name=target_type,json=targetType,proto3,enum=moderation.TargetType,oneof" json:"target_type,omitempty"`
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	Reason        *ReportReason          `protobuf:"varint,4,opt,name=reason,proto3,enum=moderation.ReportReason,oneof" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListReportsRequest) Reset() {
+	*x = ListReportsRequest{}
+	mi := &file_proto_moderation_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListReportsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReportsRequest) ProtoMessage() {}
+
+func (x *ListReportsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_moderation_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReportsRequest.ProtoReflect.Descriptor instead.
+func (*ListReportsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_moderation_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListReportsRequest) GetStatus() ReportStatus {
+	if x != nil && x.Status != nil {
+		return *x.Status
+	}
+	return ReportStatus_REPORT_STATUS_UNSPECIFIED
+}
+
+func (x *ListReportsRequest) GetTargetType() TargetType {
+	if x != nil && x.TargetType != nil {
+		return *x.TargetType
+	}
+	return TargetType_TARGET_TYPE_UNSPECIFIED
+}
+
+func (x *ListReportsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListReportsRequest) GetReason() ReportReason {
+	if x != nil && x.Reason != nil {
+		return *x.Reason
+	}
+	return ReportReason_REPORT_REASON_UNSPECIFIED
+}
+
+type ReviewReportRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReportId      string                 `protobuf:"bytes,1,opt,name=report_id,json=reportId,proto3" json:"report_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReviewReportRequest) Reset() {
+	*x = ReviewReportRequest{}
+	mi := &file_proto_moderation_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReviewReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReviewReportRequest) ProtoMessage() {}
+
+func (x *ReviewReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_moderation_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReviewReportRequest.ProtoReflect.Descriptor instead.
+func (*ReviewReportRequest) Descriptor() ([]byte, []int) {
+	return file_proto_moderation_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ReviewReportRequest) GetReportId() string {
+	if x != nil {
+		return x.ReportId
+	}
+	return ""
+}
+
+type ResolveReportRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReportId      string                 `protobuf:"bytes,1,opt,name=report_id,json=reportId,proto3" json:"report_id,omitempty"`
+	Resolution    ReportResolution       `protobuf:"varint,2,opt,name=resolution,proto3,enum=moderation.ReportResolution" json:"resolution,omitempty"`
+	Note          string                 `protobuf:"bytes,3,opt,name=note,proto3" json:"note,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolveReportRequest) Reset() {
+	*x = ResolveReportRequest{}
+	mi := &file_proto_moderation_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolveReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveReportRequest) ProtoMessage() {}
+
+func (x *ResolveReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_moderation_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveReportRequest.ProtoReflect.Descriptor instead.
+func (*ResolveReportRequest) Descriptor() ([]byte, []int) {
+	return file_proto_moderation_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ResolveReportRequest) GetReportId() string {
+	if x != nil {
+		return x.ReportId
+	}
+	return ""
+}
+
+func (x *ResolveReportRequest) GetResolution() ReportResolution {
+	if x != nil {
+		return x.Resolution
+	}
+	return ReportResolution_REPORT_RESOLUTION_UNSPECIFIED
+}
+
+func (x *ResolveReportRequest) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+type ListReportsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reports       []*Report              `protobuf:"bytes,1,rep,name=reports,proto3" json:"reports,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListReportsResponse) Reset() {
+	*x = ListReportsResponse{}
+	mi := &file_proto_moderation_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListReportsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReportsResponse) ProtoMessage() {}
+
+func (x *ListReportsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_moderation_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReportsResponse.ProtoReflect.Descriptor instead.
+func (*ListReportsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_moderation_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListReportsResponse) GetReports() []*Report {
+	if x != nil {
+		return x.Reports
+	}
+	return nil
+}
+
+type DismissReportsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TargetType    TargetType             `protobuf:"varint,1,opt,name=target_type,json=targetType,proto3,enum=moderation.TargetType" json:"target_type,omitempty"`
+	TargetId      string                 `protobuf:"bytes,2,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DismissReportsRequest) Reset() {
+	*x = DismissReportsRequest{}
+	mi := &file_proto_moderation_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DismissReportsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DismissReportsRequest) ProtoMessage() {}
+
+func (x *DismissReportsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_moderation_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DismissReportsRequest.ProtoReflect.Descriptor instead.
+func (*DismissReportsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_moderation_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *DismissReportsRequest) GetTargetType() TargetType {
+	if x != nil {
+		return x.TargetType
+	}
+	return TargetType_TARGET_TYPE_UNSPECIFIED
+}
+
+func (x *DismissReportsRequest) GetTargetId() string {
+	if x != nil {
+		return x.TargetId
+	}
+	return ""
+}
+
+func (x *DismissReportsRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type RemovePostRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PostId        string                 `protobuf:"bytes,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemovePostRequest) Reset() {
+	*x = RemovePostRequest{}
+	mi := &file_proto_moderation_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemovePostRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemovePostRequest) ProtoMessage() {}
+
+func (x *RemovePostRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_moderation_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemovePostRequest.ProtoReflect.Descriptor instead.
+func (*RemovePostRequest) Descriptor() ([]byte, []int) {
+	return file_proto_moderation_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *RemovePostRequest) GetPostId() string {
+	if x != nil {
+		return x.PostId
+	}
+	return ""
+}
+
+func (x *RemovePostRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type RemoveCommentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CommentId     string                 `protobuf:"bytes,1,opt,name=comment_id,json=commentId,proto3" json:"comment_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveCommentRequest) Reset() {
+	*x = RemoveCommentRequest{}
+	mi := &file_proto_moderation_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveCommentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveCommentRequest) ProtoMessage() {}
+
+func (x *RemoveCommentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_moderation_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveCommentRequest.ProtoReflect.Descriptor instead.
+func (*RemoveCommentRequest) Descriptor() ([]byte, []int) {
+	return file_proto_moderation_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *RemoveCommentRequest) GetCommentId() string {
+	if x != nil {
+		return x.CommentId
+	}
+	return ""
+}
+
+func (x *RemoveCommentRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type SuspendUserRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Reason string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	// until lifts the suspension at that time; without it the user is banned
+	// until an admin unsuspends them
+	Until         *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=until,proto3,oneof" json:"until,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SuspendUserRequest) Reset() {
+	*x = SuspendUserRequest{}
+	mi := &file_proto_moderation_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SuspendUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuspendUserRequest) ProtoMessage() {}
+
+func (x *SuspendUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_moderation_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuspendUserRequest.ProtoReflect.Descriptor instead.
+func (*SuspendUserRequest) Descriptor() ([]byte, []int) {
+	return file_proto_moderation_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SuspendUserRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SuspendUserRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *SuspendUserRequest) GetUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Until
+	}
+	return nil
+}
+
+type UnsuspendUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnsuspendUserRequest) Reset() {
+	*x = UnsuspendUserRequest{}
+	mi := &file_proto_moderation_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnsuspendUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnsuspendUserRequest) ProtoMessage() {}
+
+func (x *UnsuspendUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_moderation_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnsuspendUserRequest.ProtoReflect.Descriptor instead.
+func (*UnsuspendUserRequest) Descriptor() ([]byte, []int) {
+	return file_proto_moderation_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *UnsuspendUserRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UnsuspendUserRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type ListAuditLogRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	AdminId *string                `protobuf:"bytes,1,opt,name=admin_id,json=adminId,proto3,oneof" json:"admin_id,omitempty"`
+	// target_type and target_id narrow the log to one post, comment or user
+	TargetType    *TargetType `protobuf:"varint,2,opt,name=target_type,json=targetType,proto3,enum=moderation.TargetType,oneof" json:"target_type,omitempty"`
+	TargetId      *string     `protobuf:"bytes,3,opt,name=target_id,json=targetId,proto3,oneof" json:"target_id,omitempty"`
+	Limit         int32       `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAuditLogRequest) Reset() {
+	*x = ListAuditLogRequest{}
+	mi := &file_proto_moderation_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAuditLogRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuditLogRequest) ProtoMessage() {}
+
+func (x *ListAuditLogRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_moderation_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAuditLogRequest.ProtoReflect.Descriptor instead.
+func (*ListAuditLogRequest) Descriptor() ([]byte, []int) {
+	return file_proto_moderation_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListAuditLogRequest) GetAdminId() string {
+	if x != nil && x.AdminId != nil {
+		return *x.AdminId
+	}
+	return ""
+}
+
+func (x *ListAuditLogRequest) GetTargetType() TargetType {
+	if x != nil && x.TargetType != nil {
+		return *x.TargetType
+	}
+	return TargetType_TARGET_TYPE_UNSPECIFIED
+}
+
+func (x *ListAuditLogRequest) GetTargetId() string {
+	if x != nil && x.TargetId != nil {
+		return *x.TargetId
+	}
+	return ""
+}
+
+func (x *ListAuditLogRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListAuditLogResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Actions       []*ModerationAction    `protobuf:"bytes,1,rep,name=actions,proto3" json:"actions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAuditLogResponse) Reset() {
+	*x = ListAuditLogResponse{}
+	mi := &file_proto_moderation_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAuditLogResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuditLogResponse) ProtoMessage() {}
+
+func (x *ListAuditLogResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_moderation_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAuditLogResponse.ProtoReflect.Descriptor instead.
+func (*ListAuditLogResponse) Descriptor() ([]byte, []int) {
+	return file_proto_moderation_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListAuditLogResponse) GetActions() []*ModerationAction {
+	if x != nil {
+		return x.Actions
+	}
+	return nil
+}
+
+type Report struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ReporterId      *string                `protobuf:"bytes,2,opt,name=reporter_id,json=reporterId,proto3,oneof" json:"reporter_id,omitempty"` // Unset for anonymous reports
+	TargetType      TargetType             `protobuf:"varint,3,opt,name=target_type,json=targetType,proto3,enum=moderation.TargetType" json:"target_type,omitempty"`
+	TargetId        string                 `protobuf:"bytes,4,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`
+	Reason          ReportReason           `protobuf:"varint,5,opt,name=reason,proto3,enum=moderation.ReportReason" json:"reason,omitempty"`
+	Status          ReportStatus           `protobuf:"varint,6,opt,name=status,proto3,enum=moderation.ReportStatus" json:"status,omitempty"`
+	CreatedAt       *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ResolvedBy      *string                `protobuf:"bytes,8,opt,name=resolved_by,json=resolvedBy,proto3,oneof" json:"resolved_by,omitempty"`
+	ResolvedAt      *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=resolved_at,json=resolvedAt,proto3,oneof" json:"resolved_at,omitempty"`
+	ActionId        *string                `protobuf:"bytes,10,opt,name=action_id,json=actionId,proto3,oneof" json:"action_id,omitempty"` // Audit log entry that resolved the report
+	Details         string                 `protobuf:"bytes,11,opt,name=details,proto3" json:"details,omitempty"`
+	ReviewerId      *string                `protobuf:"bytes,12,opt,name=reviewer_id,json=reviewerId,proto3,oneof" json:"reviewer_id,omitempty"`
+	ReviewStartedAt *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=review_started_at,json=reviewStartedAt,proto3,oneof" json:"review_started_at,omitempty"`
+	Resolution      ReportResolution       `protobuf:"varint,14,opt,name=resolution,proto3,enum=moderation.ReportResolution" json:"resolution,omitempty"`
+	ResolutionNote  string                 `protobuf:"bytes,15,opt,name=resolution_note,json=resolutionNote,proto3" json:"resolution_note,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Report) Reset() {
+	*x = Report{}
+	mi := &file_proto_moderation_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Report) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Report) ProtoMessage() {}
+
+func (x *Report) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_moderation_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Report.ProtoReflect.Descriptor instead.
+func (*Report) Descriptor() ([]byte, []int) {
+	return file_proto_moderation_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Report) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Report) GetReporterId() string {
+	if x != nil && x.ReporterId != nil {
+		return *x.ReporterId
+	}
+	return ""
+}
+
+func (x *Report) GetTargetType() TargetType {
+	if x != nil {
+		return x.TargetType
+	}
+	return TargetType_TARGET_TYPE_UNSPECIFIED
+}
+
+func (x *Report) GetTargetId() string {
+	if x != nil {
+		return x.TargetId
+	}
+	return ""
+}
+
+func (x *Report) GetReason() ReportReason {
+	if x != nil {
+		return x.Reason
+	}
+	return ReportReason_REPORT_REASON_UNSPECIFIED
+}
+
+func (x *Report) GetStatus() ReportStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ReportStatus_REPORT_STATUS_UNSPECIFIED
+}
+
+func (x *Report) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Report) GetResolvedBy() string {
+	if x != nil && x.ResolvedBy != nil {
+		return *x.ResolvedBy
+	}
+	return ""
+}
+
+func (x *Report) GetResolvedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ResolvedAt
+	}
+	return nil
+}
+
+func (x *Report) GetActionId() string {
+	if x != nil && x.ActionId != nil {
+		return *x.ActionId
+	}
+	return ""
+}
+
+func (x *Report) GetDetails() string {
+	if x != nil {
+		return x.Details
+	}
+	return ""
+}
+
+func (x *Report) GetReviewerId() string {
+	if x != nil && x.ReviewerId != nil {
+		return *x.ReviewerId
+	}
+	return ""
+}
+
+func (x *Report) GetReviewStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReviewStartedAt
+	}
+	return nil
+}
+
+func (x *Report) GetResolution() ReportResolution {
+	if x != nil {
+		return x.Resolution
+	}
+	return ReportResolution_REPORT_RESOLUTION_UNSPECIFIED
+}
+
+func (x *Report) GetResolutionNote() string {
+	if x != nil {
+		return x.ResolutionNote
+	}
+	return ""
+}
+
+type ModerationAction struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	AdminId         string                 `protobuf:"bytes,2,opt,name=admin_id,json=adminId,proto3" json:"admin_id,omitempty"`
+	Action          ActionType             `protobuf:"varint,3,opt,name=action,proto3,enum=moderation.ActionType" json:"action,omitempty"`
+	TargetType      TargetType             `protobuf:"varint,4,opt,name=target_type,json=targetType,proto3,enum=moderation.TargetType" json:"target_type,omitempty"`
+	TargetId        string                 `protobuf:"bytes,5,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`
+	Reason          string                 `protobuf:"bytes,6,opt,name=reason,proto3" json:"reason,omitempty"`
+	SuspendedUntil  *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=suspended_until,json=suspendedUntil,proto3,oneof" json:"suspended_until,omitempty"`
+	ReportsResolved int32                  `protobuf:"varint,8,opt,name=reports_resolved,json=reportsResolved,proto3" json:"reports_resolved,omitempty"`
+	CreatedAt       *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ModerationAction) Reset() {
+	*x = ModerationAction{}
+	mi := &file_proto_moderation_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModerationAction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModerationAction) ProtoMessage() {}
+
+func (x *ModerationAction) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_moderation_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModerationAction.ProtoReflect.Descriptor instead.
+func (*ModerationAction) Descriptor() ([]byte, []int) {
+	return file_proto_moderation_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ModerationAction) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ModerationAction) GetAdminId() string {
+	if x != nil {
+		return x.AdminId
+	}
+	return ""
+}
+
+func (x *ModerationAction) GetAction() ActionType {
+	if x != nil {
+		return x.Action
+	}
+	return ActionType_ACTION_TYPE_UNSPECIFIED
+}
+
+func (x *ModerationAction) GetTargetType() TargetType {
+	if x != nil {
+		return x.TargetType
+	}
+	return TargetType_TARGET_TYPE_UNSPECIFIED
+}
+
+func (x *ModerationAction) GetTargetId() string {
+	if x != nil {
+		return x.TargetId
+	}
+	return ""
+}
+
+func (x *ModerationAction) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *ModerationAction) GetSuspendedUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SuspendedUntil
+	}
+	return nil
+}
+
+func (x *ModerationAction) GetReportsResolved() int32 {
+	if x != nil {
+		return x.ReportsResolved
+	}
+	return 0
+}
+
+func (x *ModerationAction) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+var File_proto_moderation_proto protoreflect.FileDescriptor
+
+const file_proto_moderation_proto_rawDesc = "" +
+	"\n" +
+	"\x16proto/moderation.proto\x12\n" +
+	"moderation\x1a\x1fgoogle/protobuf/timestamp.proto\"\x9d\x01\n" +
+	"\x11ReportPostRequest\x12\x17\n" +
+	"\apost_id\x18\x01 \x01(\tR\x06postId\x120\n" +
+	"\x06reason\x18\x02 \x01(\x0e2\x18.moderation.ReportReasonR\x06reason\x12\x18\n" +
+	"\adetails\x18\x03 \x01(\tR\adetails\x12#\n" +
+	"\rshow_reporter\x18\x04 \x01(\bR\fshowReporter\"\xa6\x01\n" +
+	"\x14ReportCommentRequest\x12\x1d\n" +
+	"\n" +
+	"comment_id\x18\x01 \x01(\tR\tcommentId\x120\n" +
+	"\x06reason\x18\x02 \x01(\x0e2\x18.moderation.ReportReasonR\x06reason\x12\x18\n" +
+	"\adetails\x18\x03 \x01(\tR\adetails\x12#\n" +
+	"\rshow_reporter\x18\x04 \x01(\bR\fshowReporter\"\x9d\x01\n" +
+	"\x11ReportUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x120\n" +
+	"\x06reason\x18\x02 \x01(\x0e2\x18.moderation.ReportReasonR\x06reason\x12\x18\n" +
+	"\adetails\x18\x03 \x01(\tR\adetails\x12#\n" +
+	"\rshow_reporter\x18\x04 \x01(\bR\fshowReporter\"\xfc\x01\n" +
+	"\x12ListReportsRequest\x125\n" +
+	"\x06status\x18\x01 \x01(\x0e2\x18.moderation.ReportStatusH\x00R\x06status\x88\x01\x01\x12<\n" +
+	"\vtarget_type\x18\x02 \x01(\x0e2\x16.moderation.TargetTypeH\x01R\n" +
+	"targetType\x88\x01\x01\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x125\n" +
+	"\x06reason\x18\x04 \x01(\x0e2\x18.moderation.ReportReasonH\x02R\x06reason\x88\x01\x01B\t\n" +
+	"\a_statusB\x0e\n" +
+	"\f_target_typeB\t\n" +
+	"\a_reason\"2\n" +
+	"\x13ReviewReportRequest\x12\x1b\n" +
+	"\treport_id\x18\x01 \x01(\tR\breportId\"\x85\x01\n" +
+	"\x14ResolveReportRequest\x12\x1b\n" +
+	"\treport_id\x18\x01 \x01(\tR\breportId\x12<\n" +
+	"\n" +
+	"resolution\x18\x02 \x01(\x0e2\x1c.moderation.ReportResolutionR\n" +
+	"resolution\x12\x12\n" +
+	"\x04note\x18\x03 \x01(\tR\x04note\"C\n" +
+	"\x13ListReportsResponse\x12,\n" +
+	"\areports\x18\x01 \x03(\v2\x12.moderation.ReportR\areports\"\x85\x01\n" +
+	"\x15DismissReportsRequest\x127\n" +
+	"\vtarget_type\x18\x01 \x01(\x0e2\x16.moderation.TargetTypeR\n" +
+	"targetType\x12\x1b\n" +
+	"\ttarget_id\x18\x02 \x01(\tR\btargetId\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"D\n" +
+	"\x11RemovePostRequest\x12\x17\n" +
+	"\apost_id\x18\x01 \x01(\tR\x06postId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"M\n" +
+	"\x14RemoveCommentRequest\x12\x1d\n" +
+	"\n" +
+	"comment_id\x18\x01 \x01(\tR\tcommentId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"\x86\x01\n" +
+	"\x12SuspendUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\x125\n" +
+	"\x05until\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampH\x00R\x05until\x88\x01\x01B\b\n" +
+	"\x06_until\"G\n" +
+	"\x14UnsuspendUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"\xd6\x01\n" +
+	"\x13ListAuditLogRequest\x12\x1e\n" +
+	"\badmin_id\x18\x01 \x01(\tH\x00R\aadminId\x88\x01\x01\x12<\n" +
+	"\vtarget_type\x18\x02 \x01(\x0e2\x16.moderation.TargetTypeH\x01R\n" +
+	"targetType\x88\x01\x01\x12 \n" +
+	"\ttarget_id\x18\x03 \x01(\tH\x02R\btargetId\x88\x01\x01\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limitB\v\n" +
+	"\t_admin_idB\x0e\n" +
+	"\f_target_typeB\f\n" +
+	"\n" +
+	"_target_id\"N\n" +
+	"\x14ListAuditLogResponse\x126\n" +
+	"\aactions\x18\x01 \x03(\v2\x1c.moderation.ModerationActionR\aactions\"\x95\x06\n" +
+	"\x06Report\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12$\n" +
+	"\vreporter_id\x18\x02 \x01(\tH\x00R\n" +
+	"reporterId\x88\x01\x01\x127\n" +
+	"\vtarget_type\x18\x03 \x01(\x0e2\x16.moderation.TargetTypeR\n" +
+	"targetType\x12\x1b\n" +
+	"\ttarget_id\x18\x04 \x01(\tR\btargetId\x120\n" +
+	"\x06reason\x18\x05 \x01(\x0e2\x18.moderation.ReportReasonR\x06reason\x120\n" +
+	"\x06status\x18\x06 \x01(\x0e2\x18.moderation.ReportStatusR\x06status\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12$\n" +
+	"\vresolved_by\x18\b \x01(\tH\x01R\n" +
+	"resolvedBy\x88\x01\x01\x12@\n" +
+	"\vresolved_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampH\x02R\n" +
+	"resolvedAt\x88\x01\x01\x12 \n" +
+	"\taction_id\x18\n" +
+	" \x01(\tH\x03R\bactionId\x88\x01\x01\x12\x18\n" +
+	"\adetails\x18\v \x01(\tR\adetails\x12$\n" +
+	"\vreviewer_id\x18\f \x01(\tH\x04R\n" +
+	"reviewerId\x88\x01\x01\x12K\n" +
+	"\x11review_started_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampH\x05R\x0freviewStartedAt\x88\x01\x01\x12<\n" +
+	"\n" +
+	"resolution\x18\x0e \x01(\x0e2\x1c.moderation.ReportResolutionR\n" +
+	"resolution\x12'\n" +
+	"\x0fresolution_note\x18\x0f \x01(\tR\x0eresolutionNoteB\x0e\n" +
+	"\f_reporter_idB\x0e\n" +
+	"\f_resolved_byB\x0e\n" +
+	"\f_resolved_atB\f\n" +
+	"\n" +
+	"_action_idB\x0e\n" +
+	"\f_reviewer_idB\x14\n" +
+	"\x12_review_started_at\"\x9f\x03\n" +
+	"\x10ModerationAction\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
+	"\badmin_id\x18\x02 \x01(\tR\aadminId\x12.\n" +
+	"\x06action\x18\x03 \x01(\x0e2\x16.moderation.ActionTypeR\x06action\x127\n" +
+	"\vtarget_type\x18\x04 \x01(\x0e2\x16.moderation.TargetTypeR\n" +
+	"targetType\x12\x1b\n" +
+	"\ttarget_id\x18\x05 \x01(\tR\btargetId\x12\x16\n" +
+	"\x06reason\x18\x06 \x01(\tR\x06reason\x12H\n" +
+	"\x0fsuspended_until\x18\a \x01(\v2\x1a.google.protobuf.TimestampH\x00R\x0esuspendedUntil\x88\x01\x01\x12)\n" +
+	"\x10reports_resolved\x18\b \x01(\x05R\x0freportsResolved\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAtB\x12\n" +
+	"\x10_suspended_until*n\n" +
+	"\n" +
+	"TargetType\x12\x1b\n" +
+	"\x17TARGET_TYPE_UNSPECIFIED\x10\x00\x12\x14\n" +
+	"\x10TARGET_TYPE_POST\x10\x01\x12\x17\n" +
+	"\x13TARGET_TYPE_COMMENT\x10\x02\x12\x14\n" +
+	"\x10TARGET_TYPE_USER\x10\x03*\xb1\x02\n" +
+	"\fReportReason\x12\x1d\n" +
+	"\x19REPORT_REASON_UNSPECIFIED\x10\x00\x12\x16\n" +
+	"\x12REPORT_REASON_SPAM\x10\x01\x12\x1c\n" +
+	"\x18REPORT_REASON_HARASSMENT\x10\x02\x12\x1d\n" +
+	"\x19REPORT_REASON_HATE_SPEECH\x10\x03\x12\x1a\n" +
+	"\x16REPORT_REASON_VIOLENCE\x10\x04\x12\x18\n" +
+	"\x14REPORT_REASON_NUDITY\x10\x05\x12 \n" +
+	"\x1cREPORT_REASON_MISINFORMATION\x10\x06\x12\x1b\n" +
+	"\x17REPORT_REASON_SELF_HARM\x10\a\x12\x1f\n" +
+	"\x1bREPORT_REASON_IMPERSONATION\x10\b\x12\x17\n" +
+	"\x13REPORT_REASON_OTHER\x10\t*~\n" +
+	"\fReportStatus\x12\x1d\n" +
+	"\x19REPORT_STATUS_UNSPECIFIED\x10\x00\x12\x16\n" +
+	"\x12REPORT_STATUS_OPEN\x10\x01\x12\x1b\n" +
+	"\x17REPORT_STATUS_REVIEWING\x10\x02\x12\x1a\n" +
+	"\x16REPORT_STATUS_RESOLVED\x10\x03*v\n" +
+	"\x10ReportResolution\x12!\n" +
+	"\x1dREPORT_RESOLUTION_UNSPECIFIED\x10\x00\x12\x1e\n" +
+	"\x1aREPORT_RESOLUTION_ACTIONED\x10\x01\x12\x1f\n" +
+	"\x1bREPORT_RESOLUTION_DISMISSED\x10\x02*\xe5\x01\n" +
+	"\n" +
+	"ActionType\x12\x1b\n" +
+	"\x17ACTION_TYPE_UNSPECIFIED\x10\x00\x12\x1b\n" +
+	"\x17ACTION_TYPE_REMOVE_POST\x10\x01\x12\x1e\n" +
+	"\x1aACTION_TYPE_REMOVE_COMMENT\x10\x02\x12\x1c\n" +
+	"\x18ACTION_TYPE_SUSPEND_USER\x10\x03\x12\x1e\n" +
+	"\x1aACTION_TYPE_UNSUSPEND_USER\x10\x04\x12\x1f\n" +
+	"\x1bACTION_TYPE_DISMISS_REPORTS\x10\x05\x12\x1e\n" +
+	"\x1aACTION_TYPE_RESOLVE_REPORT\x10\x062\x98\a\n" +
+	"\x11ModerationService\x12?\n" +
+	"\n" +
+	"ReportPost\x12\x1d.moderation.ReportPostRequest\x1a\x12.moderation.Report\x12E\n" +
+	"\rReportComment\x12 .moderation.ReportCommentRequest\x1a\x12.moderation.Report\x12?\n" +
+	"\n" +
+	"ReportUser\x12\x1d.moderation.ReportUserRequest\x1a\x12.moderation.Report\x12N\n" +
+	"\vListReports\x12\x1e.moderation.ListReportsRequest\x1a\x1f.moderation.ListReportsResponse\x12C\n" +
+	"\fReviewReport\x12\x1f.moderation.ReviewReportRequest\x1a\x12.moderation.Report\x12E\n" +
+	"\rResolveReport\x12 .moderation.ResolveReportRequest\x1a\x12.moderation.Report\x12Q\n" +
+	"\x0eDismissReports\x12!.moderation.DismissReportsRequest\x1a\x1c.moderation.ModerationAction\x12I\n" +
+	"\n" +
+	"RemovePost\x12\x1d.moderation.RemovePostRequest\x1a\x1c.moderation.ModerationAction\x12O\n" +
+	"\rRemoveComment\x12 .moderation.RemoveCommentRequest\x1a\x1c.moderation.ModerationAction\x12K\n" +
+	"\vSuspendUser\x12\x1e.moderation.SuspendUserRequest\x1a\x1c.moderation.ModerationAction\x12O\n" +
+	"\rUnsuspendUser\x12 .moderation.UnsuspendUserRequest\x1a\x1c.moderation.ModerationAction\x12Q\n" +
+	"\fListAuditLog\x12\x1f.moderation.ListAuditLogRequest\x1a .moderation.ListAuditLogResponseB\x04Z\x02./b\x06proto3"
+
+var (
+	file_proto_moderation_proto_rawDescOnce sync.Once
+	file_proto_moderation_proto_rawDescData []byte
+)
+
+func file_proto_moderation_proto_rawDescGZIP() []byte {
+	file_proto_moderation_proto_rawDescOnce.Do(func() {
+		file_proto_moderation_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_moderation_proto_rawDesc), len(file_proto_moderation_proto_rawDesc)))
+	})
+	return file_proto_moderation_proto_rawDescData
+}
+
+var file_proto_moderation_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
+var file_proto_moderation_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_proto_moderation_proto_goTypes = []any{
+	(TargetType)(0),               // 0: moderation.TargetType
+	(ReportReason)(0),             // 1: moderation.ReportReason
+	(ReportStatus)(0),             // 2: moderation.ReportStatus
+	(ReportResolution)(0),         // 3: moderation.ReportResolution
+	(ActionType)(0),               // 4: moderation.ActionType
+	(*ReportPostRequest)(nil),     // 5: moderation.ReportPostRequest
+	(*ReportCommentRequest)(nil),  // 6: moderation.ReportCommentRequest
+	(*ReportUserRequest)(nil),     // 7: moderation.ReportUserRequest
+	(*ListReportsRequest)(nil),    // 8: moderation.ListReportsRequest
+	(*ReviewReportRequest)(nil),   // 9: moderation.ReviewReportRequest
+	(*ResolveReportRequest)(nil),  // 10: moderation.ResolveReportRequest
+	(*ListReportsResponse)(nil),   // 11: moderation.ListReportsResponse
+	(*DismissReportsRequest)(nil), // 12: moderation.DismissReportsRequest
+	(*RemovePostRequest)(nil),     // 13: moderation.RemovePostRequest
+	(*RemoveCommentRequest)(nil),  // 14: moderation.RemoveCommentRequest
+	(*SuspendUserRequest)(nil),    // 15: moderation.SuspendUserRequest
+	(*UnsuspendUserRequest)(nil),  // 16: moderation.UnsuspendUserRequest
+	(*ListAuditLogRequest)(nil),   // 17: moderation.ListAuditLogRequest
+	(*ListAuditLogResponse)(nil),  // 18: moderation.ListAuditLogResponse
+	(*Report)(nil),                // 19: moderation.Report
+	(*ModerationAction)(nil),      // 20: moderation.ModerationAction
+	(*timestamppb.Timestamp)(nil), // 21: google.protobuf.Timestamp
+}
+var file_proto_moderation_proto_depIdxs = []int32{
+	1,  // 0: moderation.ReportPostRequest.reason:type_name -> moderation.ReportReason
+	1,  // 1: moderation.ReportCommentRequest.reason:type_name -> moderation.ReportReason
+	1,  // 2: moderation.ReportUserRequest.reason:type_name -> moderation.ReportReason
+	2,  // 3: moderation.ListReportsRequest.status:type_name -> moderation.ReportStatus
+	0,  // 4: moderation.ListReportsRequest.target_type:type_name -> moderation.TargetType
+	1,  // 5: moderation.ListReportsRequest.reason:type_name -> moderation.ReportReason
+	3,  // 6: moderation.ResolveReportRequest.resolution:type_name -> moderation.ReportResolution
+	19, // 7: moderation.ListReportsResponse.reports:type_name -> moderation.Report
+	0,  // 8: moderation.DismissReportsRequest.target_type:type_name -> moderation.TargetType
+	21, // 9: moderation.SuspendUserRequest.until:type_name -> google.protobuf.Timestamp
+	0,  // 10: moderation.ListAuditLogRequest.target_type:type_name -> moderation.TargetType
+	20, // 11: moderation.ListAuditLogResponse.actions:type_name -> moderation.ModerationAction
+	0,  // 12: moderation.Report.target_type:type_name -> moderation.TargetType
+	1,  // 13: moderation.Report.reason:type_name -> moderation.ReportReason
+	2,  // 14: moderation.Report.status:type_name -> moderation.ReportStatus
+	21, // 15: moderation.Report.created_at:type_name -> google.protobuf.Timestamp
+	21, // 16: moderation.Report.resolved_at:type_name -> google.protobuf.Timestamp
+	21, // 17: moderation.Report.review_started_at:type_name -> google.protobuf.Timestamp
+	3,  // 18: moderation.Report.resolution:type_name -> moderation.ReportResolution
+	4,  // 19: moderation.ModerationAction.action:type_name -> moderation.ActionType
+	0,  // 20: moderation.ModerationAction.target_type:type_name -> moderation.TargetType
+	21, // 21: moderation.ModerationAction.suspended_until:type_name -> google.protobuf.Timestamp
+	21, // 22: moderation.ModerationAction.created_at:type_name -> google.protobuf.Timestamp
+	5,  // 23: moderation.ModerationService.ReportPost:input_type -> moderation.ReportPostRequest
+	6,  // 24: moderation.ModerationService.ReportComment:input_type -> moderation.ReportCommentRequest
+	7,  // 25: moderation.ModerationService.ReportUser:input_type -> moderation.ReportUserRequest
+	8,  // 26: moderation.ModerationService.ListReports:input_type -> moderation.ListReportsRequest
+	9,  // 27: moderation.ModerationService.ReviewReport:input_type -> moderation.ReviewReportRequest
+	10, // 28: moderation.ModerationService.ResolveReport:input_type -> moderation.ResolveReportRequest
+	12, // 29: moderation.ModerationService.DismissReports:input_type -> moderation.DismissReportsRequest
+	13, // 30: moderation.ModerationService.RemovePost:input_type -> moderation.RemovePostRequest
+	14, // 31: moderation.ModerationService.RemoveComment:input_type -> moderation.RemoveCommentRequest
+	15, // 32: moderation.ModerationService.SuspendUser:input_type -> moderation.SuspendUserRequest
+	16, // 33: moderation.ModerationService.UnsuspendUser:input_type -> moderation.UnsuspendUserRequest
+	17, // 34: moderation.ModerationService.ListAuditLog:input_type -> moderation.ListAuditLogRequest
+	19, // 35: moderation.ModerationService.ReportPost:output_type -> moderation.Report
+	19, // 36: moderation.ModerationService.ReportComment:output_type -> moderation.Report
+	19, // 37: moderation.ModerationService.ReportUser:output_type -> moderation.Report
+	11, // 38: moderation.ModerationService.ListReports:output_type -> moderation.ListReportsResponse
+	19, // 39: moderation.ModerationService.ReviewReport:output_type -> moderation.Report
+	19, // 40: moderation.ModerationService.ResolveReport:output_type -> moderation.Report
+	20, // 41: moderation.ModerationService.DismissReports:output_type -> moderation.ModerationAction
+	20, // 42: moderation.ModerationService.RemovePost:output_type -> moderation.ModerationAction
+	20, // 43: moderation.ModerationService.RemoveComment:output_type -> moderation.ModerationAction
+	20, // 44: moderation.ModerationService.SuspendUser:output_type -> moderation.ModerationAction
+	20, // 45: moderation.ModerationService.UnsuspendUser:output_type -> moderation.ModerationAction
+	18, // 46: moderation.ModerationService.ListAuditLog:output_type -> moderation.ListAuditLogResponse
+	35, // [35:47] is the sub-list for method output_type
+	23, // [23:35] is the sub-list for method input_type
+	23, // [23:23] is the sub-list for extension type_name
+	23, // [23:23] is the sub-list for extension extendee
+	0,  // [0:23] is the sub-list for field type_name
+}
+
+func init() { file_proto_moderation_proto_init() }
+func file_proto_moderation_proto_init() {
+	if File_proto_moderation_proto != nil {
+		return
+	}
+	file_proto_moderation_proto_msgTypes[3].OneofWrappers = []any{}
+	file_proto_moderation_proto_msgTypes[10].OneofWrappers = []any{}
+	file_proto_moderation_proto_msgTypes[12].OneofWrappers = []any{}
+	file_proto_moderation_proto_msgTypes[14].OneofWrappers = []any{}
+	file_proto_moderation_proto_msgTypes[15].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_moderation_proto_rawDesc), len(file_proto_moderation_proto_rawDesc)),
+			NumEnums:      5,
+			NumMessages:   16,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_moderation_proto_goTypes,
+		DependencyIndexes: file_proto_moderation_proto_depIdxs,
+		EnumInfos:         file_proto_moderation_proto_enumTypes,
+		MessageInfos:      file_proto_moderation_proto_msgTypes,
+	}.Build()
+	File_proto_moderation_proto = out.File
+	file_proto_moderation_proto_goTypes = nil
+	file_proto_moderation_proto_depIdxs = nil
+}
@@ -0,0 +1,222 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"moderation-service/db"
+	"moderation-service/model"
+)
+
+var (
+	// ErrDuplicateReport is returned when a user reports a target they
+	// already have an unresolved report on
+	ErrDuplicateReport = errors.New("you have already reported this")
+	ErrReportNotFound  = errors.New("report not found")
+	// ErrReportClaimed is returned when a report being reviewed by another
+	// admin, or already resolved, is picked up for review
+	ErrReportClaimed  = errors.New("report is resolved or being reviewed by another admin")
+	ErrReportResolved = errors.New("report is already resolved")
+)
+
+type ModerationRepository interface {
+	// WithTx runs fn in a single transaction; repository calls made with the
+	// context passed to fn join it
+	WithTx(ctx context.Context, fn func(ctx context.Context) error) error
+	CreateReport(ctx context.Context, report *models.Report) error
+	GetReport(ctx context.Context, reportID uuid.UUID) (*models.Report, error)
+	ListReports(ctx context.Context, filter models.ReportFilter, limit int32) ([]models.Report, error)
+	StartReview(ctx context.Context, reportID, reviewerID uuid.UUID) (*models.Report, error)
+	ResolveReport(ctx context.Context, reportID uuid.UUID, resolution models.ReportResolution, note string, adminID, actionID uuid.UUID) (*models.Report, error)
+	ResolveReports(ctx context.Context, targetType models.TargetType, targetID uuid.UUID, resolution models.ReportResolution, adminID, actionID uuid.UUID) (int32, error)
+	CreateAction(ctx context.Context, action *models.Action) error
+	ListActions(ctx context.Context, filter models.ActionFilter, limit int32) ([]models.Action, error)
+}
+
+type moderationRepository struct {
+	db *database.DB
+}
+
+func NewModerationRepository(db *database.DB) ModerationRepository {
+	return &moderationRepository{db: db}
+}
+
+const (
+	reportColumns = `id, reporter_id, target_type, target_id, reason, details, anonymous, status, created_at,
+		reviewer_id, review_started_at, resolution, resolution_note, resolved_by, resolved_at, action_id`
+	actionColumns = `id, admin_id, action, target_type, target_id, reason, suspended_until, reports_resolved, created_at`
+)
+
+func (r *moderationRepository) WithTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return r.db.WithTx(ctx, fn)
+}
+
+func (r *moderationRepository) CreateReport(ctx context.Context, report *models.Report) error {
+	query := `
+		INSERT INTO moderation_service_reports (id, reporter_id, target_type, target_id, reason, details, anonymous, status, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (reporter_id, target_type, target_id) WHERE status <> 'RESOLVED' DO NOTHING
+	`
+	result, err := r.db.Conn(ctx).ExecContext(ctx, query,
+		report.ID, report.ReporterID, report.TargetType, report.TargetID, report.Reason, report.Details,
+		report.Anonymous, report.Status, report.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create report: %w", err)
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	} else if n == 0 {
+		return ErrDuplicateReport
+	}
+	return nil
+}
+
+// GetReport reads a report from the primary, so a report just changed in
+// the caller's transaction is seen as it is now
+func (r *moderationRepository) GetReport(ctx context.Context, reportID uuid.UUID) (*models.Report, error) {
+	var report models.Report
+	query := `SELECT ` + reportColumns + ` FROM moderation_service_reports WHERE id = $1`
+	if err := r.db.Conn(ctx).GetContext(ctx, &report, query, reportID); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrReportNotFound
+		}
+		return nil, fmt.Errorf("failed to get report: %w", err)
+	}
+	return &report, nil
+}
+
+// ListReports returns reports newest first
+func (r *moderationRepository) ListReports(ctx context.Context, filter models.ReportFilter, limit int32) ([]models.Report, error) {
+	query := `SELECT ` + reportColumns + ` FROM moderation_service_reports WHERE TRUE`
+	args := []interface{}{}
+	if filter.Status != nil {
+		args = append(args, *filter.Status)
+		query += fmt.Sprintf(" AND status = $%d", len(args))
+	}
+	if filter.Reason != nil {
+		args = append(args, *filter.Reason)
+		query += fmt.Sprintf(" AND reason = $%d", len(args))
+	}
+	if filter.TargetType != nil {
+		args = append(args, *filter.TargetType)
+		query += fmt.Sprintf(" AND target_type = $%d", len(args))
+	}
+	query += fmt.Sprintf(" ORDER BY created_at DESC, id DESC LIMIT $%d", len(args)+1)
+	args = append(args, limit)
+
+	reports := []models.Report{}
+	if err := r.db.ReadDB().SelectContext(ctx, &reports, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to list reports: %w", err)
+	}
+	return reports, nil
+}
+
+// StartReview moves an open report to REVIEWING with reviewerID as its
+// reviewer. Picking up a report the reviewer already has is a no-op.
+func (r *moderationRepository) StartReview(ctx context.Context, reportID, reviewerID uuid.UUID) (*models.Report, error) {
+	query := `
+		UPDATE moderation_service_reports
+		SET status = 'REVIEWING', reviewer_id = $2, review_started_at = COALESCE(review_started_at, NOW())
+		WHERE id = $1 AND (status = 'OPEN' OR (status = 'REVIEWING' AND reviewer_id = $2))
+		RETURNING ` + reportColumns
+	var report models.Report
+	err := r.db.Conn(ctx).GetContext(ctx, &report, query, reportID, reviewerID)
+	if err == sql.ErrNoRows {
+		if _, err := r.GetReport(ctx, reportID); err != nil {
+			return nil, err
+		}
+		return nil, ErrReportClaimed
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to start review: %w", err)
+	}
+	return &report, nil
+}
+
+// ResolveReport settles one unresolved report, crediting the admin and the
+// audit log entry that resolved it
+func (r *moderationRepository) ResolveReport(ctx context.Context, reportID uuid.UUID, resolution models.ReportResolution, note string, adminID, actionID uuid.UUID) (*models.Report, error) {
+	query := `
+		UPDATE moderation_service_reports
+		SET status = 'RESOLVED', resolution = $2, resolution_note = $3, resolved_by = $4, resolved_at = NOW(), action_id = $5
+		WHERE id = $1 AND status <> 'RESOLVED'
+		RETURNING ` + reportColumns
+	var report models.Report
+	err := r.db.Conn(ctx).GetContext(ctx, &report, query, reportID, resolution, note, adminID, actionID)
+	if err == sql.ErrNoRows {
+		if _, err := r.GetReport(ctx, reportID); err != nil {
+			return nil, err
+		}
+		return nil, ErrReportResolved
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve report: %w", err)
+	}
+	return &report, nil
+}
+
+// ResolveReports resolves every unresolved report on a target, crediting the
+// admin and the audit log entry that resolved them, and returns how many
+// were resolved
+func (r *moderationRepository) ResolveReports(ctx context.Context, targetType models.TargetType, targetID uuid.UUID, resolution models.ReportResolution, adminID, actionID uuid.UUID) (int32, error) {
+	query := `
+		UPDATE moderation_service_reports
+		SET status = 'RESOLVED', resolution = $3, resolved_by = $4, resolved_at = NOW(), action_id = $5
+		WHERE target_type = $1 AND target_id = $2 AND status <> 'RESOLVED'
+	`
+	result, err := r.db.Conn(ctx).ExecContext(ctx, query, targetType, targetID, resolution, adminID, actionID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to resolve reports: %w", err)
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	return int32(n), nil
+}
+
+// CreateAction appends an entry to the audit log
+func (r *moderationRepository) CreateAction(ctx context.Context, action *models.Action) error {
+	query := `
+		INSERT INTO moderation_service_actions (` + actionColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+	_, err := r.db.Conn(ctx).ExecContext(ctx, query,
+		action.ID, action.AdminID, action.Action, action.TargetType, action.TargetID, action.Reason,
+		action.SuspendedUntil, action.ReportsResolved, action.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to record action: %w", err)
+	}
+	return nil
+}
+
+// ListActions returns audit log entries newest first
+func (r *moderationRepository) ListActions(ctx context.Context, filter models.ActionFilter, limit int32) ([]models.Action, error) {
+	query := `SELECT ` + actionColumns + ` FROM moderation_service_actions WHERE TRUE`
+	args := []interface{}{}
+	if filter.AdminID != nil {
+		args = append(args, *filter.AdminID)
+		query += fmt.Sprintf(" AND admin_id = $%d", len(args))
+	}
+	if filter.TargetType != nil {
+		args = append(args, *filter.TargetType)
+		query += fmt.Sprintf(" AND target_type = $%d", len(args))
+	}
+	if filter.TargetID != nil {
+		args = append(args, *filter.TargetID)
+		query += fmt.Sprintf(" AND target_id = $%d", len(args))
+	}
+	query += fmt.Sprintf(" ORDER BY created_at DESC, id DESC LIMIT $%d", len(args)+1)
+	args = append(args, limit)
+
+	actions := []models.Action{}
+	if err := r.db.ReadDB().SelectContext(ctx, &actions, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to list actions: %w", err)
+	}
+	return actions, nil
+}
@@ -0,0 +1,260 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	feedpb "feed-service/pb"
+	"muzeengctl/backfill"
+)
+
+// backfillJobs lists the derived stores in the order a full rebuild must run them
+var backfillJobs = []string{"feed-posts", "feed-follows", "feed-likes", "feed-comments", "feed-cache", "redis-feeds", "notification-counts", "post-hashtags", "search-users", "search-posts"}
+
+func runBackfill(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("backfill: expected one of %v or all", backfillJobs)
+	}
+	target, args := args[0], args[1:]
+
+	fs := flag.NewFlagSet("backfill", flag.ExitOnError)
+	batchSize := fs.Int("batch-size", 500, "items processed per batch")
+	rate := fs.Float64("rate", 2, "maximum batches per second (0 disables pacing)")
+	checkpointPath := fs.String("checkpoint", "muzeengctl-backfill.json", "checkpoint file used to resume interrupted runs")
+	reset := fs.Bool("reset", false, "ignore saved progress and start from the beginning")
+	postDSN := fs.String("post-dsn", os.Getenv("MUZEENG_POST_DATABASE_URL"), "post-service database")
+	followDSN := fs.String("follow-dsn", os.Getenv("MUZEENG_FOLLOW_DATABASE_URL"), "follow-service database")
+	likeDSN := fs.String("like-dsn", os.Getenv("MUZEENG_LIKE_DATABASE_URL"), "like-service database")
+	commentDSN := fs.String("comment-dsn", os.Getenv("MUZEENG_COMMENT_DATABASE_URL"), "comment-service database")
+	feedDSN := fs.String("feed-dsn", os.Getenv("MUZEENG_FEED_DATABASE_URL"), "feed-service database")
+	notificationDSN := fs.String("notification-dsn", os.Getenv("MUZEENG_NOTIFICATION_DATABASE_URL"), "notification-service database")
+	userDSN := fs.String("user-dsn", os.Getenv("MUZEENG_USER_DATABASE_URL"), "user-service database")
+	searchDSN := fs.String("search-dsn", os.Getenv("MUZEENG_SEARCH_DATABASE_URL"), "search-service database")
+	redisAddr := fs.String("notification-redis", getEnv("MUZEENG_NOTIFICATION_REDIS_ADDR", "localhost:6379"), "notification-service redis address")
+	fs.Parse(args)
+
+	if *batchSize <= 0 {
+		return fmt.Errorf("-batch-size must be positive")
+	}
+
+	names := []string{target}
+	if target == "all" {
+		names = backfillJobs
+	}
+
+	checkpoints, err := backfill.OpenCheckpointStore(*checkpointPath)
+	if err != nil {
+		return err
+	}
+
+	var interval time.Duration
+	if *rate > 0 {
+		interval = time.Duration(float64(time.Second) / *rate)
+	}
+	runner := &backfill.Runner{
+		BatchSize:   *batchSize,
+		Interval:    interval,
+		Checkpoints: checkpoints,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	sources := &backfillSources{
+		dsns: map[string]string{
+			"post":         *postDSN,
+			"follow":       *followDSN,
+			"like":         *likeDSN,
+			"comment":      *commentDSN,
+			"feed":         *feedDSN,
+			"notification": *notificationDSN,
+			"user":         *userDSN,
+			"search":       *searchDSN,
+		},
+		redisAddr: *redisAddr,
+		dbs:       make(map[string]*sql.DB),
+	}
+	defer sources.Close()
+
+	for _, name := range names {
+		job, cleanup, err := sources.job(name)
+		if err != nil {
+			return err
+		}
+
+		if *reset {
+			if err := checkpoints.Reset(name); err != nil {
+				cleanup()
+				return err
+			}
+		}
+
+		err = runner.Run(ctx, job)
+		cleanup()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// backfillSources lazily opens the databases a job needs
+type backfillSources struct {
+	dsns      map[string]string
+	redisAddr string
+	dbs       map[string]*sql.DB
+}
+
+func (s *backfillSources) db(name string) (*sql.DB, error) {
+	if db, ok := s.dbs[name]; ok {
+		return db, nil
+	}
+	if s.dsns[name] == "" {
+		return nil, fmt.Errorf("-%s-dsn is required", name)
+	}
+
+	db, err := sql.Open("postgres", s.dsns[name])
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s database: %w", name, err)
+	}
+	s.dbs[name] = db
+	return db, nil
+}
+
+func (s *backfillSources) Close() {
+	for _, db := range s.dbs {
+		db.Close()
+	}
+}
+
+// job builds the named backfill job and returns a cleanup for any extra clients it opened
+func (s *backfillSources) job(name string) (backfill.Job, func(), error) {
+	noop := func() {}
+
+	switch name {
+	case "feed-posts":
+		postDB, err := s.db("post")
+		if err != nil {
+			return nil, nil, err
+		}
+		feedDB, err := s.db("feed")
+		if err != nil {
+			return nil, nil, err
+		}
+		return &backfill.FeedPostsJob{PostDB: postDB, FeedDB: feedDB}, noop, nil
+
+	case "feed-follows":
+		followDB, err := s.db("follow")
+		if err != nil {
+			return nil, nil, err
+		}
+		feedDB, err := s.db("feed")
+		if err != nil {
+			return nil, nil, err
+		}
+		return &backfill.FeedFollowsJob{FollowDB: followDB, FeedDB: feedDB}, noop, nil
+
+	case "feed-likes":
+		likeDB, err := s.db("like")
+		if err != nil {
+			return nil, nil, err
+		}
+		feedDB, err := s.db("feed")
+		if err != nil {
+			return nil, nil, err
+		}
+		return &backfill.FeedLikesJob{LikeDB: likeDB, FeedDB: feedDB}, noop, nil
+
+	case "feed-comments":
+		commentDB, err := s.db("comment")
+		if err != nil {
+			return nil, nil, err
+		}
+		feedDB, err := s.db("feed")
+		if err != nil {
+			return nil, nil, err
+		}
+		return &backfill.FeedCommentsJob{CommentDB: commentDB, FeedDB: feedDB}, noop, nil
+
+	case "feed-cache":
+		feedDB, err := s.db("feed")
+		if err != nil {
+			return nil, nil, err
+		}
+		return &backfill.FeedCacheJob{FeedDB: feedDB}, noop, nil
+
+	case "redis-feeds":
+		feedDB, err := s.db("feed")
+		if err != nil {
+			return nil, nil, err
+		}
+		conn, err := dial("FEED")
+		if err != nil {
+			return nil, nil, err
+		}
+		client := feedpb.NewFeedServiceClient(conn)
+		rebuild := func(ctx context.Context, userID string) error {
+			ctx, err := withAdminToken(ctx)
+			if err != nil {
+				return err
+			}
+			_, err = client.RebuildFeedCache(ctx, &feedpb.RefreshFeedRequest{UserId: userID})
+			return err
+		}
+		return &backfill.RedisFeedsJob{FeedDB: feedDB, Rebuild: rebuild}, func() { conn.Close() }, nil
+
+	case "notification-counts":
+		notificationDB, err := s.db("notification")
+		if err != nil {
+			return nil, nil, err
+		}
+		rdb := redis.NewClient(&redis.Options{Addr: s.redisAddr})
+		job := &backfill.NotificationCountsJob{
+			NotificationDB: notificationDB,
+			Redis:          rdb,
+			TTL:            5 * time.Minute,
+		}
+		return job, func() { rdb.Close() }, nil
+
+	case "post-hashtags":
+		postDB, err := s.db("post")
+		if err != nil {
+			return nil, nil, err
+		}
+		return &backfill.PostHashtagsJob{PostDB: postDB}, noop, nil
+
+	case "search-posts":
+		postDB, err := s.db("post")
+		if err != nil {
+			return nil, nil, err
+		}
+		searchDB, err := s.db("search")
+		if err != nil {
+			return nil, nil, err
+		}
+		return &backfill.SearchPostsJob{PostDB: postDB, SearchDB: searchDB}, noop, nil
+
+	case "search-users":
+		userDB, err := s.db("user")
+		if err != nil {
+			return nil, nil, err
+		}
+		searchDB, err := s.db("search")
+		if err != nil {
+			return nil, nil, err
+		}
+		return &backfill.SearchUsersJob{UserDB: userDB, SearchDB: searchDB}, noop, nil
+
+	default:
+		return nil, nil, fmt.Errorf("backfill: unknown store %q (expected one of %v or all)", name, backfillJobs)
+	}
+}
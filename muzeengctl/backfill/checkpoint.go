@@ -0,0 +1,88 @@
+package backfill
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// Checkpoint records how far a job has progressed
+type Checkpoint struct {
+	Cursor    string    `json:"cursor"`
+	Processed int64     `json:"processed"`
+	Done      bool      `json:"done"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// CheckpointStore persists checkpoints for all jobs in a single JSON file
+type CheckpointStore struct {
+	path string
+
+	mu          sync.Mutex
+	checkpoints map[string]Checkpoint
+}
+
+// OpenCheckpointStore loads the checkpoint file at path, starting empty if it does not exist
+func OpenCheckpointStore(path string) (*CheckpointStore, error) {
+	store := &CheckpointStore{
+		path:        path,
+		checkpoints: make(map[string]Checkpoint),
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read checkpoints: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &store.checkpoints); err != nil {
+		return nil, fmt.Errorf("failed to parse checkpoints %s: %w", path, err)
+	}
+	return store, nil
+}
+
+// Get returns the checkpoint for a job, or the zero checkpoint if it never ran
+func (s *CheckpointStore) Get(job string) Checkpoint {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.checkpoints[job]
+}
+
+// Reset forgets a job's progress so the next run starts from the beginning
+func (s *CheckpointStore) Reset(job string) error {
+	s.mu.Lock()
+	delete(s.checkpoints, job)
+	s.mu.Unlock()
+	return s.flush()
+}
+
+// Save stores a job's checkpoint and writes the file atomically
+func (s *CheckpointStore) Save(job string, cp Checkpoint) error {
+	s.mu.Lock()
+	s.checkpoints[job] = cp
+	s.mu.Unlock()
+	return s.flush()
+}
+
+func (s *CheckpointStore) flush() error {
+	s.mu.Lock()
+	data, err := json.MarshalIndent(s.checkpoints, "", "  ")
+	s.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("failed to encode checkpoints: %w", err)
+	}
+
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write checkpoints: %w", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("failed to write checkpoints: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,595 @@
+package backfill
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/redis/go-redis/v9"
+
+	"post-service/hashtag"
+)
+
+// feedWindow matches the 30 day window feed-service ranks and retains posts in
+const feedWindow = "30 days"
+
+// FeedPostsJob copies the live posts of post_service_posts into the
+// feed-service projection feed_service_posts: private, deleted, unpublished
+// and shadow-hidden posts are not projected, and are removed from it along
+// with their fan-out, likes and comments if they were.
+type FeedPostsJob struct {
+	PostDB *sql.DB
+	FeedDB *sql.DB
+}
+
+func (j *FeedPostsJob) Name() string { return "feed-posts" }
+
+func (j *FeedPostsJob) Batch(ctx context.Context, cursor string, limit int) (string, int, error) {
+	rows, err := j.PostDB.QueryContext(ctx, `
+		SELECT id, user_id, content, created_at, updated_at, likes_count, comments_count, visibility,
+			visibility <> 'PRIVATE' AND deleted_at IS NULL AND status = 'PUBLISHED' AND shadow_hidden_at IS NULL
+		FROM post_service_posts
+		WHERE ($1 = '' OR id > NULLIF($1, '')::uuid)
+		ORDER BY id
+		LIMIT $2
+	`, cursor, limit)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to read posts: %w", err)
+	}
+	defer rows.Close()
+
+	tx, err := j.FeedDB.BeginTx(ctx, nil)
+	if err != nil {
+		return "", 0, err
+	}
+	defer tx.Rollback()
+
+	var last string
+	var n int
+	for rows.Next() {
+		var id, userID, content, visibility string
+		var createdAt, updatedAt time.Time
+		var likes, comments int32
+		var live bool
+		if err := rows.Scan(&id, &userID, &content, &createdAt, &updatedAt, &likes, &comments, &visibility, &live); err != nil {
+			return "", 0, err
+		}
+
+		if !live {
+			if _, err := tx.ExecContext(ctx, `DELETE FROM feed_service_posts WHERE id = $1`, id); err != nil {
+				return "", 0, fmt.Errorf("failed to remove post %s: %w", id, err)
+			}
+			last = id
+			n++
+			continue
+		}
+
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO feed_service_posts (id, user_id, content, created_at, updated_at, likes_count, comments_count, visibility)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			ON CONFLICT (id) DO UPDATE
+			SET content = EXCLUDED.content, updated_at = EXCLUDED.updated_at,
+			    likes_count = EXCLUDED.likes_count, comments_count = EXCLUDED.comments_count,
+			    visibility = EXCLUDED.visibility
+		`, id, userID, content, createdAt, updatedAt, likes, comments, visibility)
+		if err != nil {
+			return "", 0, fmt.Errorf("failed to upsert post %s: %w", id, err)
+		}
+		last = id
+		n++
+	}
+	if err := rows.Err(); err != nil {
+		return "", 0, err
+	}
+
+	return last, n, tx.Commit()
+}
+
+// FeedFollowsJob copies follow_service_follows into the feed-service projection feed_service_follows
+type FeedFollowsJob struct {
+	FollowDB *sql.DB
+	FeedDB   *sql.DB
+}
+
+func (j *FeedFollowsJob) Name() string { return "feed-follows" }
+
+func (j *FeedFollowsJob) Batch(ctx context.Context, cursor string, limit int) (string, int, error) {
+	rows, err := j.FollowDB.QueryContext(ctx, `
+		SELECT id, follower_id, following_id, created_at
+		FROM follow_service_follows
+		WHERE ($1 = '' OR id > NULLIF($1, '')::uuid)
+		ORDER BY id
+		LIMIT $2
+	`, cursor, limit)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to read follows: %w", err)
+	}
+	defer rows.Close()
+
+	tx, err := j.FeedDB.BeginTx(ctx, nil)
+	if err != nil {
+		return "", 0, err
+	}
+	defer tx.Rollback()
+
+	var last string
+	var n int
+	for rows.Next() {
+		var id, followerID, followingID string
+		var createdAt time.Time
+		if err := rows.Scan(&id, &followerID, &followingID, &createdAt); err != nil {
+			return "", 0, err
+		}
+
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO feed_service_follows (follower_id, followed_id, created_at)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (follower_id, followed_id) DO UPDATE
+			SET created_at = EXCLUDED.created_at, deleted_at = NULL
+		`, followerID, followingID, createdAt)
+		if err != nil {
+			return "", 0, fmt.Errorf("failed to upsert follow %s: %w", id, err)
+		}
+		last = id
+		n++
+	}
+	if err := rows.Err(); err != nil {
+		return "", 0, err
+	}
+
+	return last, n, tx.Commit()
+}
+
+// FeedLikesJob copies like_service_likes into the feed-service projection
+// feed_service_likes. Likes of posts outside the projection are skipped. The
+// counts of posts are copied by FeedPostsJob.
+type FeedLikesJob struct {
+	LikeDB *sql.DB
+	FeedDB *sql.DB
+}
+
+func (j *FeedLikesJob) Name() string { return "feed-likes" }
+
+func (j *FeedLikesJob) Batch(ctx context.Context, cursor string, limit int) (string, int, error) {
+	rows, err := j.LikeDB.QueryContext(ctx, `
+		SELECT id, user_id, post_id, created_at
+		FROM like_service_likes
+		WHERE ($1 = '' OR id > NULLIF($1, '')::uuid)
+		ORDER BY id
+		LIMIT $2
+	`, cursor, limit)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to read likes: %w", err)
+	}
+	defer rows.Close()
+
+	tx, err := j.FeedDB.BeginTx(ctx, nil)
+	if err != nil {
+		return "", 0, err
+	}
+	defer tx.Rollback()
+
+	var last string
+	var n int
+	for rows.Next() {
+		var id, userID, postID string
+		var createdAt time.Time
+		if err := rows.Scan(&id, &userID, &postID, &createdAt); err != nil {
+			return "", 0, err
+		}
+
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO feed_service_likes (user_id, post_id, created_at)
+			SELECT $1, $2, $3
+			WHERE EXISTS (SELECT 1 FROM feed_service_posts WHERE id = $2)
+			ON CONFLICT (user_id, post_id) DO UPDATE
+			SET created_at = EXCLUDED.created_at, deleted_at = NULL
+		`, userID, postID, createdAt)
+		if err != nil {
+			return "", 0, fmt.Errorf("failed to upsert like %s: %w", id, err)
+		}
+		last = id
+		n++
+	}
+	if err := rows.Err(); err != nil {
+		return "", 0, err
+	}
+
+	return last, n, tx.Commit()
+}
+
+// FeedCommentsJob copies the live comments and replies of
+// comment_service_comments into the feed-service projection
+// feed_service_comments. Comments on posts outside the projection are
+// skipped. The counts of posts are copied by FeedPostsJob.
+type FeedCommentsJob struct {
+	CommentDB *sql.DB
+	FeedDB    *sql.DB
+}
+
+func (j *FeedCommentsJob) Name() string { return "feed-comments" }
+
+func (j *FeedCommentsJob) Batch(ctx context.Context, cursor string, limit int) (string, int, error) {
+	rows, err := j.CommentDB.QueryContext(ctx, `
+		SELECT id, post_id, created_at
+		FROM comment_service_comments
+		WHERE ($1 = '' OR id > NULLIF($1, '')::uuid)
+			AND deleted_at IS NULL AND shadow_hidden_at IS NULL
+		ORDER BY id
+		LIMIT $2
+	`, cursor, limit)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to read comments: %w", err)
+	}
+	defer rows.Close()
+
+	tx, err := j.FeedDB.BeginTx(ctx, nil)
+	if err != nil {
+		return "", 0, err
+	}
+	defer tx.Rollback()
+
+	var last string
+	var n int
+	for rows.Next() {
+		var id, postID string
+		var createdAt time.Time
+		if err := rows.Scan(&id, &postID, &createdAt); err != nil {
+			return "", 0, err
+		}
+
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO feed_service_comments (comment_id, post_id, created_at)
+			SELECT $1, $2, $3
+			WHERE EXISTS (SELECT 1 FROM feed_service_posts WHERE id = $2)
+			ON CONFLICT (comment_id) DO NOTHING
+		`, id, postID, createdAt)
+		if err != nil {
+			return "", 0, fmt.Errorf("failed to insert comment %s: %w", id, err)
+		}
+		last = id
+		n++
+	}
+	if err := rows.Err(); err != nil {
+		return "", 0, err
+	}
+
+	return last, n, tx.Commit()
+}
+
+// FeedCacheJob fans recent posts of followed users out into feed_service_cache.
+// Authors whose posts feed-service pulls at read time are skipped.
+type FeedCacheJob struct {
+	FeedDB *sql.DB
+}
+
+func (j *FeedCacheJob) Name() string { return "feed-cache" }
+
+func (j *FeedCacheJob) Batch(ctx context.Context, cursor string, limit int) (string, int, error) {
+	followers, err := listFollowers(ctx, j.FeedDB, cursor, limit)
+	if err != nil {
+		return "", 0, err
+	}
+	if len(followers) == 0 {
+		return "", 0, nil
+	}
+
+	_, err = j.FeedDB.ExecContext(ctx, `
+		INSERT INTO feed_service_cache (id, user_id, post_id, created_at)
+		SELECT uuid_generate_v4(), f.follower_id, p.id, p.created_at
+		FROM feed_service_follows f
+		INNER JOIN feed_service_posts p ON p.user_id = f.followed_id
+		WHERE f.follower_id = ANY($1::uuid[])
+			AND f.deleted_at IS NULL
+			AND p.created_at > NOW() - INTERVAL '`+feedWindow+`'
+			AND NOT EXISTS (
+				SELECT 1 FROM feed_service_author_strategies s
+				WHERE s.user_id = f.followed_id AND s.strategy = 'pull'
+			)
+		ON CONFLICT (user_id, post_id) DO NOTHING
+	`, pq.Array(followers))
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to fan out feed items: %w", err)
+	}
+
+	return followers[len(followers)-1], len(followers), nil
+}
+
+// RedisFeedsJob rebuilds the Redis feed of every user that follows someone.
+// Rebuild is expected to call feed-service so the cache format stays owned by it.
+type RedisFeedsJob struct {
+	FeedDB  *sql.DB
+	Rebuild func(ctx context.Context, userID string) error
+}
+
+func (j *RedisFeedsJob) Name() string { return "redis-feeds" }
+
+func (j *RedisFeedsJob) Batch(ctx context.Context, cursor string, limit int) (string, int, error) {
+	followers, err := listFollowers(ctx, j.FeedDB, cursor, limit)
+	if err != nil {
+		return "", 0, err
+	}
+
+	// Rebuilds are idempotent, so a failed batch is simply retried on resume
+	for _, userID := range followers {
+		if err := j.Rebuild(ctx, userID); err != nil {
+			return "", 0, fmt.Errorf("failed to rebuild feed for %s: %w", userID, err)
+		}
+	}
+
+	if len(followers) == 0 {
+		return "", 0, nil
+	}
+	return followers[len(followers)-1], len(followers), nil
+}
+
+// NotificationCountsJob recomputes the cached unread notification counters
+type NotificationCountsJob struct {
+	NotificationDB *sql.DB
+	Redis          *redis.Client
+	TTL            time.Duration
+}
+
+func (j *NotificationCountsJob) Name() string { return "notification-counts" }
+
+func (j *NotificationCountsJob) Batch(ctx context.Context, cursor string, limit int) (string, int, error) {
+	rows, err := j.NotificationDB.QueryContext(ctx, `
+		SELECT user_id, COUNT(*) FILTER (WHERE is_read = FALSE)
+		FROM notification_service_notifications
+		WHERE ($1 = '' OR user_id > NULLIF($1, '')::uuid)
+		GROUP BY user_id
+		ORDER BY user_id
+		LIMIT $2
+	`, cursor, limit)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to count notifications: %w", err)
+	}
+	defer rows.Close()
+
+	pipe := j.Redis.Pipeline()
+	var last string
+	var n int
+	for rows.Next() {
+		var userID string
+		var unread int32
+		if err := rows.Scan(&userID, &unread); err != nil {
+			return "", 0, err
+		}
+		// Key format owned by notification-service's repository
+		pipe.Set(ctx, "notif:unread:"+userID, fmt.Sprintf("%d", unread), j.TTL)
+		last = userID
+		n++
+	}
+	if err := rows.Err(); err != nil {
+		return "", 0, err
+	}
+
+	if n > 0 {
+		if _, err := pipe.Exec(ctx); err != nil {
+			return "", 0, fmt.Errorf("failed to write unread counts: %w", err)
+		}
+	}
+	return last, n, nil
+}
+
+// PostHashtagsJob re-extracts the hashtags of every post into
+// post_service_post_hashtags, using the same rules as post-service: only
+// public, published posts that are neither deleted nor shadow-hidden are
+// listed under hashtags. The tags of other posts are cleared.
+type PostHashtagsJob struct {
+	PostDB *sql.DB
+}
+
+func (j *PostHashtagsJob) Name() string { return "post-hashtags" }
+
+func (j *PostHashtagsJob) Batch(ctx context.Context, cursor string, limit int) (string, int, error) {
+	rows, err := j.PostDB.QueryContext(ctx, `
+		SELECT id, content, created_at,
+			visibility = 'PUBLIC' AND status = 'PUBLISHED' AND deleted_at IS NULL AND shadow_hidden_at IS NULL
+		FROM post_service_posts
+		WHERE ($1 = '' OR id > NULLIF($1, '')::uuid)
+		ORDER BY id
+		LIMIT $2
+	`, cursor, limit)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to read posts: %w", err)
+	}
+
+	type post struct {
+		id        string
+		tags      []string
+		createdAt time.Time
+	}
+	var posts []post
+	for rows.Next() {
+		var p post
+		var content string
+		var listed bool
+		if err := rows.Scan(&p.id, &content, &p.createdAt, &listed); err != nil {
+			rows.Close()
+			return "", 0, err
+		}
+		if listed {
+			p.tags = hashtag.Extract(content)
+		}
+		posts = append(posts, p)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return "", 0, err
+	}
+	if len(posts) == 0 {
+		return "", 0, nil
+	}
+
+	tx, err := j.PostDB.BeginTx(ctx, nil)
+	if err != nil {
+		return "", 0, err
+	}
+	defer tx.Rollback()
+
+	for _, p := range posts {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM post_service_post_hashtags WHERE post_id = $1`, p.id); err != nil {
+			return "", 0, fmt.Errorf("failed to clear hashtags of %s: %w", p.id, err)
+		}
+		if len(p.tags) == 0 {
+			continue
+		}
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO post_service_post_hashtags (post_id, tag, created_at)
+			SELECT $1, tag, $3 FROM unnest($2::text[]) AS tag
+		`, p.id, pq.Array(p.tags), p.createdAt)
+		if err != nil {
+			return "", 0, fmt.Errorf("failed to store hashtags of %s: %w", p.id, err)
+		}
+	}
+
+	return posts[len(posts)-1].id, len(posts), tx.Commit()
+}
+
+// SearchPostsJob indexes the public, live posts of post_service_posts into
+// search-service. Upserts are guarded by updated_at, so newer content indexed
+// from events is kept. Posts by accounts search-service knows as private are
+// skipped, so run search-users first.
+type SearchPostsJob struct {
+	PostDB   *sql.DB
+	SearchDB *sql.DB
+}
+
+func (j *SearchPostsJob) Name() string { return "search-posts" }
+
+func (j *SearchPostsJob) Batch(ctx context.Context, cursor string, limit int) (string, int, error) {
+	rows, err := j.PostDB.QueryContext(ctx, `
+		SELECT id, user_id, content, created_at, updated_at
+		FROM post_service_posts
+		WHERE ($1 = '' OR id > NULLIF($1, '')::uuid) AND visibility = 'PUBLIC'
+			AND deleted_at IS NULL AND status = 'PUBLISHED' AND shadow_hidden_at IS NULL
+		ORDER BY id
+		LIMIT $2
+	`, cursor, limit)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to read posts: %w", err)
+	}
+	defer rows.Close()
+
+	tx, err := j.SearchDB.BeginTx(ctx, nil)
+	if err != nil {
+		return "", 0, err
+	}
+	defer tx.Rollback()
+
+	var last string
+	var n int
+	for rows.Next() {
+		var id, userID, content string
+		var createdAt, updatedAt time.Time
+		if err := rows.Scan(&id, &userID, &content, &createdAt, &updatedAt); err != nil {
+			return "", 0, err
+		}
+
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO search_service_posts (id, user_id, content, created_at, updated_at)
+			SELECT $1, $2, $3, $4, $5
+			WHERE NOT EXISTS (SELECT 1 FROM search_service_users WHERE id = $2 AND is_private)
+			ON CONFLICT (id) DO UPDATE
+			SET content = EXCLUDED.content, created_at = EXCLUDED.created_at, updated_at = EXCLUDED.updated_at
+			WHERE search_service_posts.deleted_at IS NULL
+				AND search_service_posts.updated_at <= EXCLUDED.updated_at
+		`, id, userID, content, createdAt, updatedAt)
+		if err != nil {
+			return "", 0, fmt.Errorf("failed to index post %s: %w", id, err)
+		}
+		last = id
+		n++
+	}
+	if err := rows.Err(); err != nil {
+		return "", 0, err
+	}
+
+	return last, n, tx.Commit()
+}
+
+// SearchUsersJob indexes user_service_users into search-service
+type SearchUsersJob struct {
+	UserDB   *sql.DB
+	SearchDB *sql.DB
+}
+
+func (j *SearchUsersJob) Name() string { return "search-users" }
+
+func (j *SearchUsersJob) Batch(ctx context.Context, cursor string, limit int) (string, int, error) {
+	rows, err := j.UserDB.QueryContext(ctx, `
+		SELECT id, username, bio, is_private, updated_at
+		FROM user_service_users
+		WHERE ($1 = '' OR id > NULLIF($1, '')::uuid)
+		ORDER BY id
+		LIMIT $2
+	`, cursor, limit)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to read users: %w", err)
+	}
+	defer rows.Close()
+
+	tx, err := j.SearchDB.BeginTx(ctx, nil)
+	if err != nil {
+		return "", 0, err
+	}
+	defer tx.Rollback()
+
+	var last string
+	var n int
+	for rows.Next() {
+		var id, username string
+		var bio sql.NullString
+		var isPrivate bool
+		var updatedAt time.Time
+		if err := rows.Scan(&id, &username, &bio, &isPrivate, &updatedAt); err != nil {
+			return "", 0, err
+		}
+
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO search_service_users (id, username, bio, is_private, updated_at)
+			VALUES ($1, $2, $3, $4, $5)
+			ON CONFLICT (id) DO UPDATE
+			SET username = EXCLUDED.username, bio = EXCLUDED.bio, is_private = EXCLUDED.is_private,
+				updated_at = EXCLUDED.updated_at
+			WHERE search_service_users.updated_at <= EXCLUDED.updated_at
+		`, id, username, bio, isPrivate, updatedAt)
+		if err != nil {
+			return "", 0, fmt.Errorf("failed to index user %s: %w", id, err)
+		}
+		last = id
+		n++
+	}
+	if err := rows.Err(); err != nil {
+		return "", 0, err
+	}
+
+	return last, n, tx.Commit()
+}
+
+func listFollowers(ctx context.Context, db *sql.DB, cursor string, limit int) ([]string, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT DISTINCT follower_id
+		FROM feed_service_follows
+		WHERE deleted_at IS NULL AND ($1 = '' OR follower_id > NULLIF($1, '')::uuid)
+		ORDER BY follower_id
+		LIMIT $2
+	`, cursor, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list followers: %w", err)
+	}
+	defer rows.Close()
+
+	var followers []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		followers = append(followers, id)
+	}
+	return followers, rows.Err()
+}
@@ -0,0 +1,77 @@
+// Package backfill rebuilds derived stores from their source-of-truth tables
+// in keyset-ordered batches, pacing the work and checkpointing progress so an
+// interrupted run resumes where it stopped.
+package backfill
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+)
+
+// Job rebuilds one derived store. Batch processes up to limit items ordered
+// after cursor and returns the cursor of the last processed item; a batch
+// shorter than limit means the job is complete.
+type Job interface {
+	Name() string
+	Batch(ctx context.Context, cursor string, limit int) (next string, processed int, err error)
+}
+
+// Runner drives a Job with batching, rate limiting and checkpoints
+type Runner struct {
+	BatchSize   int
+	Interval    time.Duration
+	Checkpoints *CheckpointStore
+}
+
+// Run executes the job from its last checkpoint until it completes or ctx is cancelled
+func (r *Runner) Run(ctx context.Context, job Job) error {
+	cp := r.Checkpoints.Get(job.Name())
+	if cp.Done {
+		log.Printf("%s: already complete (%d items), use -reset to run again", job.Name(), cp.Processed)
+		return nil
+	}
+	if cp.Cursor != "" {
+		log.Printf("%s: resuming after %s (%d items processed)", job.Name(), cp.Cursor, cp.Processed)
+	}
+
+	var ticker *time.Ticker
+	if r.Interval > 0 {
+		ticker = time.NewTicker(r.Interval)
+		defer ticker.Stop()
+	}
+
+	for {
+		next, processed, err := job.Batch(ctx, cp.Cursor, r.BatchSize)
+		if err != nil {
+			return fmt.Errorf("%s: batch after %q failed: %w", job.Name(), cp.Cursor, err)
+		}
+
+		if processed > 0 {
+			cp.Cursor = next
+		}
+		cp.Processed += int64(processed)
+		cp.Done = processed < r.BatchSize
+		cp.UpdatedAt = time.Now().UTC()
+
+		if err := r.Checkpoints.Save(job.Name(), cp); err != nil {
+			return err
+		}
+
+		log.Printf("%s: %d items processed", job.Name(), cp.Processed)
+		if cp.Done {
+			return nil
+		}
+
+		if ticker != nil {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-ticker.C:
+			}
+		} else if err := ctx.Err(); err != nil {
+			return err
+		}
+	}
+}
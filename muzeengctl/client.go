@@ -0,0 +1,135 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	authjwt "auth-service/pkg/jwt"
+	"shared/mtls"
+)
+
+const (
+	requestTimeout = 5 * time.Minute
+	tokenExpiry    = 10 * time.Minute
+)
+
+// defaultAddrs mirrors the addresses used by the api-gateway inside the compose network
+var defaultAddrs = map[string]string{
+	"AUTH":         "localhost:50051",
+	"USER":         "localhost:50052",
+	"POST":         "localhost:50053",
+	"FEED":         "localhost:50054",
+	"COMMENT":      "localhost:50055",
+	"LIKE":         "localhost:50057",
+	"NOTIFICATION": "localhost:50058",
+	"FOLLOW":       "localhost:50060",
+	"IMPORT":       "localhost:50059",
+	"SCHEDULER":    "localhost:50061",
+}
+
+// dial connects to the named service, honouring MUZEENG_<SVC>_ADDR overrides
+func dial(service string) (*grpc.ClientConn, error) {
+	addr := getEnv("MUZEENG_"+service+"_ADDR", defaultAddrs[service])
+	// Services requiring mutual TLS need GRPC_TLS_* set as for a service
+	creds, err := mtls.DialOption()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load TLS config: %w", err)
+	}
+	conn, err := grpc.NewClient(addr, creds)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", addr, err)
+	}
+	return conn, nil
+}
+
+// adminContext returns a request context carrying an ADMIN bearer token
+func adminContext() (context.Context, context.CancelFunc, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	ctx, err := withAdminToken(ctx)
+	if err != nil {
+		cancel()
+		return nil, nil, err
+	}
+	return ctx, cancel, nil
+}
+
+// withAdminToken attaches a freshly signed ADMIN bearer token to ctx
+func withAdminToken(ctx context.Context) (context.Context, error) {
+	token, err := adminToken()
+	if err != nil {
+		return nil, err
+	}
+	return metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token), nil
+}
+
+// adminToken uses MUZEENG_TOKEN when set, otherwise signs a short-lived token
+// in the same shape auth-service issues, with the RSA key in
+// MUZEENG_JWT_PRIVATE_KEY_FILE or else the shared JWT secret
+func adminToken() (string, error) {
+	if token := os.Getenv("MUZEENG_TOKEN"); token != "" {
+		return token, nil
+	}
+
+	keyFile := os.Getenv("MUZEENG_JWT_PRIVATE_KEY_FILE")
+	secret := getEnv("MUZEENG_JWT_SECRET", os.Getenv("JWT_SECRET"))
+	if keyFile == "" && secret == "" {
+		return "", fmt.Errorf("MUZEENG_TOKEN, MUZEENG_JWT_PRIVATE_KEY_FILE or MUZEENG_JWT_SECRET is required")
+	}
+
+	adminID := getEnv("MUZEENG_ADMIN_ID", uuid.Nil.String())
+	if _, err := uuid.Parse(adminID); err != nil {
+		return "", fmt.Errorf("invalid MUZEENG_ADMIN_ID: %w", err)
+	}
+
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"user_id": adminID,
+		"roles":   []string{"ADMIN"},
+		"iss":     "muzeengctl",
+		"sub":     adminID,
+		"iat":     now.Unix(),
+		"exp":     now.Add(tokenExpiry).Unix(),
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	var signingKey interface{} = []byte(secret)
+	if keyFile != "" {
+		key, err := authjwt.LoadPrivateKey(keyFile)
+		if err != nil {
+			return "", err
+		}
+		token = jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+		token.Header["kid"] = authjwt.Thumbprint(&key.PublicKey)
+		signingKey = key
+	}
+
+	signed, err := token.SignedString(signingKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign admin token: %w", err)
+	}
+	return signed, nil
+}
+
+func parseUUIDArg(args []string, name string) (string, []string, error) {
+	if len(args) == 0 {
+		return "", nil, fmt.Errorf("%s is required", name)
+	}
+	if _, err := uuid.Parse(args[0]); err != nil {
+		return "", nil, fmt.Errorf("invalid %s %q", name, args[0])
+	}
+	return args[0], args[1:], nil
+}
+
+func getEnv(key, defaultValue string) string {
+	if val := os.Getenv(key); val != "" {
+		return val
+	}
+	return defaultValue
+}
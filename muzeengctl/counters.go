@@ -0,0 +1,148 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc"
+
+	followpb "follow-service/pb"
+	postpb "post-service/pb"
+	userpb "user-service/pb"
+)
+
+func runCounters(args []string) error {
+	if len(args) < 2 || args[0] != "recompute" {
+		return fmt.Errorf("counters: expected recompute post|user <id>")
+	}
+
+	switch args[1] {
+	case "post":
+		return recomputePostCounters(args[2:])
+	case "user":
+		return recomputeUserCounters(args[2:])
+	default:
+		return fmt.Errorf("counters recompute: unknown target %q", args[1])
+	}
+}
+
+func recomputePostCounters(args []string) error {
+	postID, _, err := parseUUIDArg(args, "post-id")
+	if err != nil {
+		return err
+	}
+	return recomputeOne(func(ctx context.Context, c *counterClients) error {
+		return c.recomputePost(ctx, postID)
+	})
+}
+
+func recomputeUserCounters(args []string) error {
+	userID, _, err := parseUUIDArg(args, "user-id")
+	if err != nil {
+		return err
+	}
+	return recomputeOne(func(ctx context.Context, c *counterClients) error {
+		return c.recomputeUser(ctx, userID)
+	})
+}
+
+func recomputeOne(fn func(ctx context.Context, c *counterClients) error) error {
+	clients, err := dialCounterClients()
+	if err != nil {
+		return err
+	}
+	defer clients.Close()
+
+	ctx, cancel, err := adminContext()
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	return fn(ctx, clients)
+}
+
+// counterClients holds the connections needed to recompute counters, so that
+// recomputing many posts or users reuses them
+type counterClients struct {
+	conns  []*grpc.ClientConn
+	post   postpb.PostServiceClient
+	follow followpb.FollowServiceClient
+	user   userpb.UserServiceClient
+}
+
+func dialCounterClients() (*counterClients, error) {
+	c := &counterClients{}
+	for _, service := range []string{"POST", "FOLLOW", "USER"} {
+		conn, err := dial(service)
+		if err != nil {
+			c.Close()
+			return nil, err
+		}
+		c.conns = append(c.conns, conn)
+	}
+
+	c.post = postpb.NewPostServiceClient(c.conns[0])
+	c.follow = followpb.NewFollowServiceClient(c.conns[1])
+	c.user = userpb.NewUserServiceClient(c.conns[2])
+	return c, nil
+}
+
+func (c *counterClients) Close() {
+	for _, conn := range c.conns {
+		conn.Close()
+	}
+}
+
+// recomputePost has post-service recount the post's likes and comments from
+// like-service and comment-service
+func (c *counterClients) recomputePost(ctx context.Context, postID string) error {
+	counters, err := c.post.RecountPost(ctx, &postpb.RecountPostRequest{PostId: postID})
+	if err != nil {
+		return fmt.Errorf("failed to recount post: %w", err)
+	}
+
+	fmt.Printf("post %s: likes=%d comments=%d\n", postID, counters.LikesCount, counters.CommentsCount)
+	return nil
+}
+
+// recomputeUser reads follow counts from follow-service and the post count
+// from post-service and writes them onto the user profile
+func (c *counterClients) recomputeUser(ctx context.Context, userID string) error {
+	followCounts, err := c.follow.GetFollowersCounts(ctx, &followpb.GetFollowersCountsRequest{UserIds: []string{userID}})
+	if err != nil {
+		return fmt.Errorf("failed to get follow counts: %w", err)
+	}
+
+	var followers, following int32
+	for _, count := range followCounts.Counts {
+		if count.UserId == userID {
+			followers, following = count.FollowersCount, count.FollowingCount
+		}
+	}
+
+	postCounts, err := c.post.GetPostsCounts(ctx, &postpb.GetPostsCountsRequest{UserIds: []string{userID}})
+	if err != nil {
+		return fmt.Errorf("failed to get post count: %w", err)
+	}
+
+	var posts int32
+	for _, count := range postCounts.Counts {
+		if count.UserId == userID {
+			posts = count.Count
+		}
+	}
+
+	_, err = c.user.SetUserCounters(ctx, &userpb.SetUserCountersRequest{
+		UserId:         userID,
+		FollowersCount: followers,
+		FollowingCount: following,
+		PostsCount:     posts,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set user counters: %w", err)
+	}
+
+	fmt.Printf("user %s: followers=%d following=%d posts=%d\n", userID, followers, following, posts)
+	return nil
+}
@@ -0,0 +1,213 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/redis/go-redis/v9"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	feedpb "feed-service/pb"
+	"muzeengctl/replay"
+	postpb "post-service/pb"
+)
+
+// eventProjections lists the projections events rebuild can replay into
+var eventProjections = []string{"feed", "notifications", "counters"}
+
+func runEvents(args []string) error {
+	if len(args) >= 2 && args[0] == "rebuild" {
+		return rebuildProjection(args[1], args[2:])
+	}
+	if len(args) < 2 || args[0] != "replay" {
+		return fmt.Errorf("events: expected replay <stream> or rebuild <projection>")
+	}
+
+	switch args[1] {
+	case "posts":
+		return replayPosts(args[2:])
+	default:
+		return fmt.Errorf("events replay: unknown stream %q (supported: posts)", args[1])
+	}
+}
+
+func replayPosts(args []string) error {
+	fs := flag.NewFlagSet("events replay posts", flag.ExitOnError)
+	since := fs.String("since", "", "replay posts created at or after this RFC3339 time or duration ago (e.g. 24h)")
+	until := fs.String("until", "", "replay posts created before this RFC3339 time (default now)")
+	fs.Parse(args)
+
+	if *since == "" {
+		return fmt.Errorf("-since is required")
+	}
+
+	sinceTime, err := parseTimeArg(*since)
+	if err != nil {
+		return fmt.Errorf("invalid -since: %w", err)
+	}
+
+	req := &postpb.ReplayPostEventsRequest{Since: timestamppb.New(sinceTime)}
+	if *until != "" {
+		untilTime, err := parseTimeArg(*until)
+		if err != nil {
+			return fmt.Errorf("invalid -until: %w", err)
+		}
+		req.Until = timestamppb.New(untilTime)
+	}
+
+	conn, err := dial("POST")
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	ctx, cancel, err := adminContext()
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	resp, err := postpb.NewPostServiceClient(conn).ReplayPostEvents(ctx, req)
+	if err != nil {
+		return fmt.Errorf("failed to replay post events: %w", err)
+	}
+
+	fmt.Printf("replayed %d post.created events\n", resp.Replayed)
+	return nil
+}
+
+// rebuildProjection replays a range of the retained EVENTS stream into a
+// projection. Unlike replay, nothing is re-published, so other consumers of
+// the events are unaffected.
+func rebuildProjection(name string, args []string) error {
+	fs := flag.NewFlagSet("events rebuild", flag.ExitOnError)
+	natsURL := fs.String("nats", getEnv("MUZEENG_NATS_URL", "nats://localhost:4222"), "NATS server URL")
+	stream := fs.String("stream", "EVENTS", "JetStream stream to read")
+	subject := fs.String("subject", "", "only replay events on this subject (wildcards allowed)")
+	since := fs.String("since", "", "start at events stored at or after this RFC3339 time or duration ago (e.g. 24h)")
+	startSeq := fs.Uint64("start-seq", 0, "start at this stream sequence (overrides -since)")
+	endSeq := fs.Uint64("end-seq", 0, "stop after this stream sequence (default: the last event when the replay starts)")
+	feedDSN := fs.String("feed-dsn", os.Getenv("MUZEENG_FEED_DATABASE_URL"), "feed-service database")
+	notificationDSN := fs.String("notification-dsn", os.Getenv("MUZEENG_NOTIFICATION_DATABASE_URL"), "notification-service database")
+	redisAddr := fs.String("notification-redis", getEnv("MUZEENG_NOTIFICATION_REDIS_ADDR", "localhost:6379"), "notification-service redis address")
+	fs.Parse(args)
+
+	rng := replay.Range{Stream: *stream, Subject: *subject, StartSeq: *startSeq, EndSeq: *endSeq}
+	if *since != "" {
+		sinceTime, err := parseTimeArg(*since)
+		if err != nil {
+			return fmt.Errorf("invalid -since: %w", err)
+		}
+		rng.Since = sinceTime
+	}
+
+	sources := &backfillSources{
+		dsns: map[string]string{
+			"feed":         *feedDSN,
+			"notification": *notificationDSN,
+		},
+		dbs: make(map[string]*sql.DB),
+	}
+	defer sources.Close()
+
+	var projection replay.Projection
+	switch name {
+	case "feed":
+		feedDB, err := sources.db("feed")
+		if err != nil {
+			return err
+		}
+		conn, err := dial("FEED")
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+		client := feedpb.NewFeedServiceClient(conn)
+		projection = &replay.FeedProjection{
+			FeedDB: feedDB,
+			Rebuild: adminCall(func(ctx context.Context, userID string) error {
+				_, err := client.RebuildFeedCache(ctx, &feedpb.RefreshFeedRequest{UserId: userID})
+				return err
+			}),
+		}
+
+	case "notifications":
+		notificationDB, err := sources.db("notification")
+		if err != nil {
+			return err
+		}
+		rdb := redis.NewClient(&redis.Options{Addr: *redisAddr})
+		defer rdb.Close()
+		projection = &replay.NotificationsProjection{
+			NotificationDB: notificationDB,
+			Redis:          rdb,
+			TTL:            5 * time.Minute,
+		}
+
+	case "counters":
+		clients, err := dialCounterClients()
+		if err != nil {
+			return err
+		}
+		defer clients.Close()
+		projection = &replay.CountersProjection{
+			RecomputePost: adminCall(clients.recomputePost),
+			RecomputeUser: adminCall(clients.recomputeUser),
+		}
+
+	default:
+		return fmt.Errorf("events rebuild: unknown projection %q (expected one of %v)", name, eventProjections)
+	}
+
+	nc, err := nats.Connect(*natsURL, nats.Name("muzeengctl"))
+	if err != nil {
+		return fmt.Errorf("failed to connect to NATS: %w", err)
+	}
+	defer nc.Close()
+
+	js, err := nc.JetStream()
+	if err != nil {
+		return fmt.Errorf("failed to create JetStream context: %w", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	stats, err := replay.Run(ctx, js, rng, projection)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s: applied %d events (sequences %d-%d)\n", name, stats.Applied, stats.FirstSeq, stats.LastSeq)
+	return nil
+}
+
+// adminCall gives each call its own timeout and ADMIN token, as a long
+// rebuild would outlive a single adminContext
+func adminCall(fn func(ctx context.Context, id string) error) func(ctx context.Context, id string) error {
+	return func(ctx context.Context, id string) error {
+		ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+		defer cancel()
+
+		ctx, err := withAdminToken(ctx)
+		if err != nil {
+			return err
+		}
+		return fn(ctx, id)
+	}
+}
+
+// parseTimeArg accepts either an RFC3339 timestamp or a duration relative to now
+func parseTimeArg(value string) (time.Time, error) {
+	if d, err := time.ParseDuration(value); err == nil {
+		return time.Now().Add(-d), nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
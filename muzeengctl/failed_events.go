@@ -0,0 +1,127 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"time"
+
+	notificationpb "notification-service/pb"
+)
+
+func runFailedEvents(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("failed-events: expected list, show or replay")
+	}
+
+	switch args[0] {
+	case "list":
+		return failedEventsList(args[1:])
+	case "show":
+		return failedEventsShow(args[1:])
+	case "replay":
+		return failedEventsReplay(args[1:])
+	default:
+		return fmt.Errorf("failed-events: unknown subcommand %q", args[0])
+	}
+}
+
+func failedEventsList(args []string) error {
+	fs := flag.NewFlagSet("failed-events list", flag.ExitOnError)
+	all := fs.Bool("all", false, "also list events already replayed")
+	limit := fs.Int("limit", 50, "maximum number of events to list")
+	fs.Parse(args)
+
+	return callNotification(func(client notificationpb.NotificationServiceClient) error {
+		ctx, cancel, err := adminContext()
+		if err != nil {
+			return err
+		}
+		defer cancel()
+
+		resp, err := client.ListFailedEvents(ctx, &notificationpb.ListFailedEventsRequest{Limit: int32(*limit), IncludeReplayed: *all})
+		if err != nil {
+			return fmt.Errorf("failed to list failed events: %w", err)
+		}
+
+		for _, event := range resp.Events {
+			state := "pending"
+			if event.ReplayedAt != nil {
+				state = "replayed"
+			}
+			fmt.Printf("%s  %-8s  %-24s  failed %s after %d attempts\n",
+				event.Id, state, event.Subject, event.FailedAt.AsTime().Format(time.RFC3339), event.Attempts)
+			fmt.Printf("  %s\n", event.Error)
+		}
+		return nil
+	})
+}
+
+func failedEventsShow(args []string) error {
+	id, _, err := parseUUIDArg(args, "event-id")
+	if err != nil {
+		return err
+	}
+
+	return callNotification(func(client notificationpb.NotificationServiceClient) error {
+		ctx, cancel, err := adminContext()
+		if err != nil {
+			return err
+		}
+		defer cancel()
+
+		event, err := client.GetFailedEvent(ctx, &notificationpb.GetFailedEventRequest{Id: id})
+		if err != nil {
+			return fmt.Errorf("failed to get failed event: %w", err)
+		}
+		printFailedEvent(event)
+		return nil
+	})
+}
+
+func failedEventsReplay(args []string) error {
+	id, _, err := parseUUIDArg(args, "event-id")
+	if err != nil {
+		return err
+	}
+
+	return callNotification(func(client notificationpb.NotificationServiceClient) error {
+		ctx, cancel, err := adminContext()
+		if err != nil {
+			return err
+		}
+		defer cancel()
+
+		event, err := client.ReplayFailedEvent(ctx, &notificationpb.ReplayFailedEventRequest{Id: id})
+		if err != nil {
+			return fmt.Errorf("failed to replay event: %w", err)
+		}
+		printFailedEvent(event)
+		return nil
+	})
+}
+
+func printFailedEvent(event *notificationpb.FailedEvent) {
+	fmt.Printf("event %s on %s (consumer %s)\n", event.Id, event.Subject, event.Consumer)
+	fmt.Printf("  failed:   %s after %d attempts\n", event.FailedAt.AsTime().Format(time.RFC3339), event.Attempts)
+	fmt.Printf("  error:    %s\n", event.Error)
+	if event.ReplayedAt != nil {
+		fmt.Printf("  replayed: %s\n", event.ReplayedAt.AsTime().Format(time.RFC3339))
+	}
+	if event.ReplayError != nil {
+		fmt.Printf("  replay error: %s\n", *event.ReplayError)
+	}
+	for key, value := range event.Headers {
+		fmt.Printf("  %s: %s\n", key, value)
+	}
+	fmt.Printf("  payload:  %s\n", event.Payload)
+}
+
+func callNotification(fn func(client notificationpb.NotificationServiceClient) error) error {
+	conn, err := dial("NOTIFICATION")
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	return fn(notificationpb.NewNotificationServiceClient(conn))
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"time"
+
+	feedpb "feed-service/pb"
+)
+
+func runFeed(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("feed: expected inspect or rebuild")
+	}
+
+	switch args[0] {
+	case "inspect":
+		return feedInspect(args[1:])
+	case "rebuild":
+		return feedRebuild(args[1:])
+	default:
+		return fmt.Errorf("feed: unknown subcommand %q", args[0])
+	}
+}
+
+func feedInspect(args []string) error {
+	userID, rest, err := parseUUIDArg(args, "user-id")
+	if err != nil {
+		return err
+	}
+
+	fs := flag.NewFlagSet("feed inspect", flag.ExitOnError)
+	limit := fs.Int("limit", 20, "number of cached entries to show")
+	fs.Parse(rest)
+
+	conn, err := dial("FEED")
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	ctx, cancel, err := adminContext()
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	info, err := feedpb.NewFeedServiceClient(conn).InspectFeedCache(ctx, &feedpb.InspectFeedCacheRequest{
+		UserId: userID,
+		Limit:  int32(*limit),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to inspect feed cache: %w", err)
+	}
+
+	fmt.Printf("user:            %s\n", info.UserId)
+	fmt.Printf("cached entries:  %d\n", info.CachedCount)
+	fmt.Printf("cache ttl:       %s\n", formatTTL(info.TtlSeconds))
+	fmt.Printf("stored items:    %d\n", info.StoredCount)
+	fmt.Printf("following:       %d\n", info.FollowingCount)
+	for _, entry := range info.Entries {
+		fmt.Printf("  %s  %s\n", entry.PostId, time.Unix(int64(entry.Score), 0).UTC().Format(time.RFC3339))
+	}
+	return nil
+}
+
+func feedRebuild(args []string) error {
+	userID, _, err := parseUUIDArg(args, "user-id")
+	if err != nil {
+		return err
+	}
+
+	conn, err := dial("FEED")
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	ctx, cancel, err := adminContext()
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	resp, err := feedpb.NewFeedServiceClient(conn).RebuildFeedCache(ctx, &feedpb.RefreshFeedRequest{UserId: userID})
+	if err != nil {
+		return fmt.Errorf("failed to rebuild feed cache: %w", err)
+	}
+
+	fmt.Println(resp.Message)
+	return nil
+}
+
+// formatTTL renders Redis TTL semantics: -2 means missing, -1 means no expiry
+func formatTTL(seconds int64) string {
+	switch {
+	case seconds == -2:
+		return "not cached"
+	case seconds == -1:
+		return "no expiry"
+	default:
+		return (time.Duration(seconds) * time.Second).String()
+	}
+}
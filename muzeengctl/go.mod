@@ -0,0 +1,61 @@
+module muzeengctl
+
+go 1.25.1
+
+require (
+	auth-service v0.0.0-00010101000000-000000000000
+	comment-service v0.0.0-00010101000000-000000000000
+	feed-service v0.0.0-00010101000000-000000000000
+	follow-service v0.0.0-00010101000000-000000000000
+	github.com/golang-jwt/jwt/v5 v5.3.0
+	github.com/google/uuid v1.6.0
+	github.com/lib/pq v1.10.9
+	github.com/nats-io/nats.go v1.46.1
+	github.com/redis/go-redis/v9 v9.14.0
+	google.golang.org/grpc v1.75.1
+	google.golang.org/protobuf v1.36.8
+	import-service v0.0.0-00010101000000-000000000000
+	like-service v0.0.0-00010101000000-000000000000
+	notification-service v0.0.0-00010101000000-000000000000
+	post-service v0.0.0-00010101000000-000000000000
+	scheduler-service v0.0.0-00010101000000-000000000000
+	shared v0.0.0-00010101000000-000000000000
+	user-service v0.0.0-00010101000000-000000000000
+)
+
+require (
+	github.com/cespare/xxhash/v2 v2.3.0 // indirect
+	github.com/dgryski/go-rendezvous v0.0.0-20200823014737-9f7001d12a5f // indirect
+	github.com/jmoiron/sqlx v1.4.0 // indirect
+	github.com/klauspost/compress v1.18.0 // indirect
+	github.com/nats-io/nkeys v0.4.11 // indirect
+	github.com/nats-io/nuid v1.0.1 // indirect
+	golang.org/x/crypto v0.42.0 // indirect
+	golang.org/x/net v0.43.0 // indirect
+	golang.org/x/sync v0.17.0 // indirect
+	golang.org/x/sys v0.36.0 // indirect
+	golang.org/x/text v0.29.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20250825161204-c5933d9347a5 // indirect
+)
+
+replace auth-service => ../auth-service
+
+replace user-service => ../user-service
+
+replace post-service => ../post-service
+
+replace comment-service => ../comment-service
+
+replace like-service => ../like-service
+
+replace follow-service => ../follow-service
+
+replace feed-service => ../feed-service
+
+replace import-service => ../import-service
+
+replace notification-service => ../notification-service
+
+replace scheduler-service => ../scheduler-service
+
+replace shared => ../shared
@@ -0,0 +1,212 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	importpb "import-service/pb"
+)
+
+func runImport(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("import: expected submit, approve, reject, status or list")
+	}
+
+	switch args[0] {
+	case "submit":
+		return importSubmit(args[1:])
+	case "approve":
+		return importApprove(args[1:])
+	case "reject":
+		return importReject(args[1:])
+	case "status":
+		return importStatus(args[1:])
+	case "list":
+		return importList(args[1:])
+	default:
+		return fmt.Errorf("import: unknown subcommand %q", args[0])
+	}
+}
+
+func importSubmit(args []string) error {
+	fs := flag.NewFlagSet("import submit", flag.ExitOnError)
+	source := fs.String("source", "", "platform the archive was exported from")
+	fs.Parse(args)
+
+	if *source == "" || fs.NArg() != 1 {
+		return fmt.Errorf("import submit: expected -source S <archive.json>")
+	}
+
+	archive, err := os.ReadFile(fs.Arg(0))
+	if err != nil {
+		return fmt.Errorf("failed to read archive: %w", err)
+	}
+
+	return callImport(func(client importpb.ImportServiceClient) error {
+		ctx, cancel, err := adminContext()
+		if err != nil {
+			return err
+		}
+		defer cancel()
+
+		job, err := client.SubmitImport(ctx, &importpb.SubmitImportRequest{Source: *source, Archive: archive})
+		if err != nil {
+			return fmt.Errorf("failed to submit import: %w", err)
+		}
+		printImportJob(job)
+		return nil
+	})
+}
+
+func importApprove(args []string) error {
+	jobID, _, err := parseUUIDArg(args, "job-id")
+	if err != nil {
+		return err
+	}
+
+	return callImport(func(client importpb.ImportServiceClient) error {
+		ctx, cancel, err := adminContext()
+		if err != nil {
+			return err
+		}
+		defer cancel()
+
+		job, err := client.ApproveImport(ctx, &importpb.ApproveImportRequest{JobId: jobID})
+		if err != nil {
+			return fmt.Errorf("failed to approve import: %w", err)
+		}
+		printImportJob(job)
+		return nil
+	})
+}
+
+func importReject(args []string) error {
+	jobID, rest, err := parseUUIDArg(args, "job-id")
+	if err != nil {
+		return err
+	}
+
+	fs := flag.NewFlagSet("import reject", flag.ExitOnError)
+	reason := fs.String("reason", "", "reason shown to the submitter")
+	fs.Parse(rest)
+
+	return callImport(func(client importpb.ImportServiceClient) error {
+		ctx, cancel, err := adminContext()
+		if err != nil {
+			return err
+		}
+		defer cancel()
+
+		job, err := client.RejectImport(ctx, &importpb.RejectImportRequest{JobId: jobID, Reason: *reason})
+		if err != nil {
+			return fmt.Errorf("failed to reject import: %w", err)
+		}
+		printImportJob(job)
+		return nil
+	})
+}
+
+func importStatus(args []string) error {
+	jobID, rest, err := parseUUIDArg(args, "job-id")
+	if err != nil {
+		return err
+	}
+
+	fs := flag.NewFlagSet("import status", flag.ExitOnError)
+	watch := fs.Bool("watch", false, "poll until the job stops running")
+	interval := fs.Duration("interval", 2*time.Second, "poll interval with -watch")
+	fs.Parse(rest)
+
+	return callImport(func(client importpb.ImportServiceClient) error {
+		for {
+			ctx, cancel, err := adminContext()
+			if err != nil {
+				return err
+			}
+			job, err := client.GetImportJob(ctx, &importpb.GetImportJobRequest{JobId: jobID})
+			cancel()
+			if err != nil {
+				return fmt.Errorf("failed to get import job: %w", err)
+			}
+
+			printImportJob(job)
+			if !*watch || !importInProgress(job.Status) {
+				return nil
+			}
+			time.Sleep(*interval)
+			fmt.Println()
+		}
+	})
+}
+
+func importList(args []string) error {
+	fs := flag.NewFlagSet("import list", flag.ExitOnError)
+	statusName := fs.String("status", "", "only list jobs in this status, e.g. pending_approval")
+	limit := fs.Int("limit", 20, "maximum number of jobs to list")
+	fs.Parse(args)
+
+	req := &importpb.ListImportJobsRequest{Limit: int32(*limit)}
+	if *statusName != "" {
+		value, ok := importpb.ImportStatus_value["IMPORT_STATUS_"+strings.ToUpper(*statusName)]
+		if !ok {
+			return fmt.Errorf("unknown import status %q", *statusName)
+		}
+		s := importpb.ImportStatus(value)
+		req.Status = &s
+	}
+
+	return callImport(func(client importpb.ImportServiceClient) error {
+		ctx, cancel, err := adminContext()
+		if err != nil {
+			return err
+		}
+		defer cancel()
+
+		resp, err := client.ListImportJobs(ctx, req)
+		if err != nil {
+			return fmt.Errorf("failed to list import jobs: %w", err)
+		}
+
+		for _, job := range resp.Jobs {
+			fmt.Printf("%s  %-16s  %-12s  submitted %s by %s\n",
+				job.Id, importStatusName(job.Status), job.Source,
+				job.CreatedAt.AsTime().Format(time.RFC3339), job.SubmittedBy)
+		}
+		return nil
+	})
+}
+
+func printImportJob(job *importpb.ImportJob) {
+	fmt.Printf("job %s (%s) %s\n", job.Id, job.Source, importStatusName(job.Status))
+	if job.ReviewNote != nil {
+		fmt.Printf("  note: %s\n", *job.ReviewNote)
+	}
+	if job.Error != nil {
+		fmt.Printf("  error: %s\n", *job.Error)
+	}
+	for _, p := range job.Progress {
+		fmt.Printf("  %-8s %d/%d  created=%d skipped=%d failed=%d\n",
+			p.Phase, p.Processed, p.Total, p.Created, p.Skipped, p.Failed)
+	}
+}
+
+func importStatusName(s importpb.ImportStatus) string {
+	return strings.TrimPrefix(s.String(), "IMPORT_STATUS_")
+}
+
+func importInProgress(s importpb.ImportStatus) bool {
+	return s == importpb.ImportStatus_IMPORT_STATUS_APPROVED || s == importpb.ImportStatus_IMPORT_STATUS_RUNNING
+}
+
+func callImport(fn func(client importpb.ImportServiceClient) error) error {
+	conn, err := dial("IMPORT")
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	return fn(importpb.NewImportServiceClient(conn))
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	schedulerpb "scheduler-service/pb"
+)
+
+func runJobs(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("jobs: expected list, runs or run")
+	}
+
+	switch args[0] {
+	case "list":
+		return jobsList()
+	case "runs":
+		return jobsRuns(args[1:])
+	case "run":
+		return jobsRun(args[1:])
+	default:
+		return fmt.Errorf("jobs: unknown subcommand %q", args[0])
+	}
+}
+
+func jobsList() error {
+	return callScheduler(func(client schedulerpb.SchedulerServiceClient) error {
+		ctx, cancel, err := adminContext()
+		if err != nil {
+			return err
+		}
+		defer cancel()
+
+		resp, err := client.ListJobs(ctx, &schedulerpb.ListJobsRequest{})
+		if err != nil {
+			return fmt.Errorf("failed to list jobs: %w", err)
+		}
+
+		for _, job := range resp.Jobs {
+			schedule, next := "disabled", "-"
+			if job.Enabled {
+				schedule = job.Schedule
+			}
+			if job.NextRunAt != nil {
+				next = job.NextRunAt.AsTime().Format(time.RFC3339)
+			}
+			fmt.Printf("%-24s  %-14s  next %s\n", job.Name, schedule, next)
+			fmt.Printf("  %s\n", job.Description)
+			if job.LastRun != nil {
+				fmt.Print("  last: ")
+				printJobRun(job.LastRun)
+			}
+		}
+		return nil
+	})
+}
+
+func jobsRuns(args []string) error {
+	fs := flag.NewFlagSet("jobs runs", flag.ExitOnError)
+	limit := fs.Int("limit", 20, "maximum number of runs to list")
+	fs.Parse(args)
+
+	req := &schedulerpb.ListJobRunsRequest{Limit: int32(*limit)}
+	if fs.NArg() > 0 {
+		name := fs.Arg(0)
+		req.JobName = &name
+	}
+
+	return callScheduler(func(client schedulerpb.SchedulerServiceClient) error {
+		ctx, cancel, err := adminContext()
+		if err != nil {
+			return err
+		}
+		defer cancel()
+
+		resp, err := client.ListJobRuns(ctx, req)
+		if err != nil {
+			return fmt.Errorf("failed to list job runs: %w", err)
+		}
+		for _, run := range resp.Runs {
+			printJobRun(run)
+		}
+		return nil
+	})
+}
+
+func jobsRun(args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("jobs run: expected <job-name>")
+	}
+
+	return callScheduler(func(client schedulerpb.SchedulerServiceClient) error {
+		ctx, cancel, err := adminContext()
+		if err != nil {
+			return err
+		}
+		defer cancel()
+
+		run, err := client.TriggerJob(ctx, &schedulerpb.TriggerJobRequest{JobName: args[0]})
+		if err != nil {
+			return fmt.Errorf("failed to trigger job: %w", err)
+		}
+		printJobRun(run)
+		return nil
+	})
+}
+
+func printJobRun(run *schedulerpb.JobRun) {
+	fmt.Printf("%s  %-22s  %-9s  %-9s  started %s on %s\n",
+		run.Id, run.JobName, run.Trigger, strings.TrimPrefix(run.Status.String(), "JOB_RUN_STATUS_"),
+		run.StartedAt.AsTime().Format(time.RFC3339), run.Instance)
+	if run.Message != nil {
+		fmt.Printf("    %s\n", *run.Message)
+	}
+	if run.Error != nil {
+		fmt.Printf("    error: %s\n", *run.Error)
+	}
+}
+
+func callScheduler(fn func(client schedulerpb.SchedulerServiceClient) error) error {
+	conn, err := dial("SCHEDULER")
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	return fn(schedulerpb.NewSchedulerServiceClient(conn))
+}
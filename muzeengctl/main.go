@@ -0,0 +1,94 @@
+// muzeengctl is the operational CLI for the muzeeng services. It talks to the
+// services over gRPC using a short-lived ADMIN token so that routine repairs
+// no longer require direct psql or redis-cli access.
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+const usage = `usage: muzeengctl <command> [arguments]
+
+Commands:
+  feed inspect <user-id> [-limit N]      show a user's cached feed
+  feed rebuild <user-id>                 drop and rebuild a user's feed cache
+  events replay posts -since T [-until T] re-publish post.created events
+  events rebuild <projection> [flags]    replay retained events into a projection
+                                         (feed, notifications, counters)
+  counters recompute post <post-id>      recompute a post's like/comment counters
+  counters recompute user <user-id>      recompute a user's follow/post counters
+  tokens revoke <user-id>                revoke all refresh tokens of a user
+  keys rotate                            create a new token signing key
+  users suspend <user-id> [-reason R] [-for D]
+                                         suspend a user for D, or ban them, and
+                                         revoke their tokens
+  users unsuspend <user-id>              lift a suspension
+  migrate -dsn DSN <file.sql|dir>...     apply schema files not yet applied
+  backfill <store>|all [flags]           rebuild derived stores from source tables
+                                         (feed-posts, feed-follows, feed-likes,
+                                         feed-comments, feed-cache, redis-feeds,
+                                         notification-counts)
+  import submit -source S <archive.json> submit a migration archive for approval
+  import approve <job-id>                approve (or retry a failed) import job
+  import reject <job-id> -reason R       reject a pending import job
+  import status <job-id> [-watch]        show an import job's per-phase progress
+  import list [-status S] [-limit N]     list import jobs, newest first
+  jobs list                              list scheduled jobs and their last runs
+  jobs runs [<job-name>] [-limit N]      show recent job runs, newest first
+  jobs run <job-name>                    run a scheduled job now
+  failed-events list [-all] [-limit N]   list events notification-service gave up on
+  failed-events show <event-id>          show a failed event with its payload
+  failed-events replay <event-id>        handle a failed event again
+
+Environment:
+  MUZEENG_JWT_SECRET    secret used to sign the admin token (falls back to JWT_SECRET)
+  MUZEENG_JWT_PRIVATE_KEY_FILE
+                        RSA key used to sign the admin token with RS256 instead
+  MUZEENG_TOKEN         pre-issued ADMIN access token, used instead of signing one
+  MUZEENG_ADMIN_ID      user ID recorded as the acting admin
+  MUZEENG_<SVC>_ADDR    service address override, e.g. MUZEENG_FEED_ADDR=localhost:50054
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	var err error
+	switch cmd, args := os.Args[1], os.Args[2:]; cmd {
+	case "feed":
+		err = runFeed(args)
+	case "events":
+		err = runEvents(args)
+	case "counters":
+		err = runCounters(args)
+	case "tokens":
+		err = runTokens(args)
+	case "keys":
+		err = runKeys(args)
+	case "users":
+		err = runUsers(args)
+	case "migrate":
+		err = runMigrate(args)
+	case "backfill":
+		err = runBackfill(args)
+	case "import":
+		err = runImport(args)
+	case "jobs":
+		err = runJobs(args)
+	case "failed-events":
+		err = runFailedEvents(args)
+	case "help", "-h", "--help":
+		fmt.Print(usage)
+		return
+	default:
+		err = fmt.Errorf("unknown command %q", cmd)
+	}
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "muzeengctl: %v\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	_ "github.com/lib/pq"
+)
+
+// runMigrate applies SQL schema files to a service database, recording each
+// applied file in schema_migrations so re-runs only apply what is new. Files
+// that changed after being applied are rejected. A directory such as
+// <service>/migrations applies its files in name order, recorded under the
+// same names the service itself records when started with -migrate.
+func runMigrate(args []string) error {
+	fs := flag.NewFlagSet("migrate", flag.ExitOnError)
+	dsn := fs.String("dsn", os.Getenv("MUZEENG_DATABASE_URL"), "postgres connection string")
+	dryRun := fs.Bool("dry-run", false, "list pending files without applying them")
+	fs.Parse(args)
+
+	if *dsn == "" {
+		return fmt.Errorf("-dsn or MUZEENG_DATABASE_URL is required")
+	}
+	if fs.NArg() == 0 {
+		return fmt.Errorf("at least one SQL file is required")
+	}
+
+	db, err := sql.Open("postgres", *dsn)
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	defer db.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	_, err = db.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			name TEXT PRIMARY KEY,
+			checksum TEXT NOT NULL,
+			applied_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create schema_migrations: %w", err)
+	}
+
+	// Services migrating at startup take the same lock
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get connection: %w", err)
+	}
+	defer conn.Close()
+	if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock(hashtext('schema_migrations'))`); err != nil {
+		return fmt.Errorf("failed to lock schema_migrations: %w", err)
+	}
+
+	paths, err := migrationFiles(fs.Args())
+	if err != nil {
+		return err
+	}
+
+	for _, path := range paths {
+		body, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", path, err)
+		}
+
+		name := migrationName(path)
+		sum := sha256.Sum256(body)
+		checksum := hex.EncodeToString(sum[:])
+
+		var applied string
+		err = conn.QueryRowContext(ctx, `SELECT checksum FROM schema_migrations WHERE name = $1`, name).Scan(&applied)
+		switch {
+		case err == nil && applied == checksum:
+			fmt.Printf("skip    %s (already applied)\n", name)
+			continue
+		case err == nil:
+			return fmt.Errorf("%s changed since it was applied; write a new migration file instead", name)
+		case err != sql.ErrNoRows:
+			return fmt.Errorf("failed to check %s: %w", name, err)
+		}
+
+		if *dryRun {
+			fmt.Printf("pending %s\n", name)
+			continue
+		}
+
+		if err := applyMigration(ctx, conn, name, checksum, string(body)); err != nil {
+			return err
+		}
+		fmt.Printf("applied %s\n", name)
+	}
+
+	return nil
+}
+
+// migrationFiles expands directories into their .sql files in name order
+func migrationFiles(args []string) ([]string, error) {
+	var paths []string
+	for _, arg := range args {
+		info, err := os.Stat(arg)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			paths = append(paths, arg)
+			continue
+		}
+
+		files, err := filepath.Glob(filepath.Join(arg, "*.sql"))
+		if err != nil {
+			return nil, err
+		}
+		sort.Strings(files)
+		paths = append(paths, files...)
+	}
+	return paths, nil
+}
+
+// migrationName is the name a file is recorded under: <service>/<file> for
+// <service>/migrations/<file>, and <dir>/<file> for other files
+func migrationName(path string) string {
+	dir := filepath.Dir(path)
+	if filepath.Base(dir) == "migrations" {
+		dir = filepath.Dir(dir)
+	}
+	return filepath.Base(dir) + "/" + filepath.Base(path)
+}
+
+func applyMigration(ctx context.Context, conn *sql.Conn, name, checksum, body string) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, body); err != nil {
+		return fmt.Errorf("failed to apply %s: %w", name, err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `INSERT INTO schema_migrations (name, checksum) VALUES ($1, $2)`, name, checksum); err != nil {
+		return fmt.Errorf("failed to record %s: %w", name, err)
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,546 @@
+package replay
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/redis/go-redis/v9"
+
+	commentevents "comment-service/events"
+	followevents "follow-service/events"
+	likeevents "like-service/events"
+	notificationevents "notification-service/events"
+	models "notification-service/model"
+	notificationrepository "notification-service/repository"
+	postevents "post-service/events"
+	"shared/eventschema"
+	eventspb "shared/eventschema/pb"
+	userevents "user-service/events"
+)
+
+// FeedProjection rebuilds the feed-service projections feed_service_posts,
+// feed_service_follows, feed_service_likes, feed_service_comments,
+// feed_service_reposts and feed_service_private_users, then has feed-service
+// rebuild the cached feed of every user whose feed the replayed events
+// affect.
+//
+// Follow and like events are resolved by timestamp rather than arrival order,
+// so a replayed follow.created never resurrects a follow deleted after it.
+// Likes and comments move the counts of posts only as they become live or
+// end, as in feed-service, so events it already handled are not counted
+// twice.
+type FeedProjection struct {
+	FeedDB  *sql.DB
+	Rebuild func(ctx context.Context, userID string) error
+
+	followers map[string]bool
+	authors   map[string]bool
+}
+
+func (p *FeedProjection) Name() string { return "feed" }
+
+func (p *FeedProjection) Apply(ctx context.Context, event Event) error {
+	if p.followers == nil {
+		p.followers = make(map[string]bool)
+		p.authors = make(map[string]bool)
+	}
+
+	switch event.Subject {
+	case postevents.PostCreated:
+		var e eventspb.PostCreated
+		if err := event.decode(&e); err != nil {
+			return err
+		}
+		// Private posts stay out of feeds, as in feed-service
+		if e.Visibility == "PRIVATE" {
+			return nil
+		}
+		_, err := p.FeedDB.ExecContext(ctx, `
+			INSERT INTO feed_service_posts (id, user_id, content, created_at, updated_at, visibility)
+			VALUES ($1, $2, $3, $4, $4, COALESCE(NULLIF($5, ''), 'PUBLIC'))
+			ON CONFLICT (id) DO NOTHING
+		`, eventschema.UUID(e.PostId), eventschema.UUID(e.UserId), e.Content, eventschema.Time(e.CreatedAt), e.Visibility)
+		if err != nil {
+			return fmt.Errorf("failed to upsert post %s: %w", e.PostId, err)
+		}
+		p.authors[eventschema.UUID(e.UserId).String()] = true
+
+	case postevents.PostUpdated:
+		var e eventspb.PostUpdated
+		if err := event.decode(&e); err != nil {
+			return err
+		}
+		_, err := p.FeedDB.ExecContext(ctx, `
+			UPDATE feed_service_posts
+			SET content = $2, updated_at = $3
+			WHERE id = $1 AND updated_at < $3
+		`, eventschema.UUID(e.PostId), e.Content, eventschema.Time(e.UpdatedAt))
+		if err != nil {
+			return fmt.Errorf("failed to update post %s: %w", e.PostId, err)
+		}
+
+	case postevents.PostDeleted:
+		var e eventspb.PostDeleted
+		if err := event.decode(&e); err != nil {
+			return err
+		}
+		// Fan-out items, likes, comments and reposts go with the post
+		if _, err := p.FeedDB.ExecContext(ctx, `DELETE FROM feed_service_posts WHERE id = $1`, eventschema.UUID(e.PostId)); err != nil {
+			return fmt.Errorf("failed to delete post %s: %w", e.PostId, err)
+		}
+		p.authors[eventschema.UUID(e.UserId).String()] = true
+
+	case likeevents.PostLiked:
+		var e likeevents.PostLikedEvent
+		if err := json.Unmarshal(event.Data, &e); err != nil {
+			return err
+		}
+		err := p.counted(ctx, "likes_count", e.PostID, 1, `
+			INSERT INTO feed_service_likes (user_id, post_id, created_at)
+			SELECT $1, $2, $3
+			WHERE EXISTS (SELECT 1 FROM feed_service_posts WHERE id = $2)
+			ON CONFLICT (user_id, post_id) DO UPDATE
+			SET created_at = EXCLUDED.created_at, deleted_at = NULL
+			WHERE feed_service_likes.deleted_at IS NOT NULL
+				AND feed_service_likes.deleted_at < EXCLUDED.created_at
+		`, e.UserID, e.PostID, e.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to insert like %s: %w", e.LikeID, err)
+		}
+
+	case likeevents.PostUnliked:
+		var e likeevents.PostUnlikedEvent
+		if err := json.Unmarshal(event.Data, &e); err != nil {
+			return err
+		}
+		err := p.counted(ctx, "likes_count", e.PostID, -1, `
+			UPDATE feed_service_likes
+			SET deleted_at = $3
+			WHERE user_id = $1 AND post_id = $2 AND deleted_at IS NULL AND created_at <= $3
+		`, e.UserID, e.PostID, e.UnlikedAt)
+		if err != nil {
+			return fmt.Errorf("failed to delete like %s: %w", e.LikeID, err)
+		}
+
+	case commentevents.CommentAdded:
+		var e eventspb.CommentAdded
+		if err := event.decode(&e); err != nil {
+			return err
+		}
+		postID := eventschema.UUID(e.PostId)
+		err := p.counted(ctx, "comments_count", postID, 1, `
+			INSERT INTO feed_service_comments (comment_id, post_id, created_at)
+			SELECT $1, $2, $3
+			WHERE EXISTS (SELECT 1 FROM feed_service_posts WHERE id = $2)
+			ON CONFLICT (comment_id) DO NOTHING
+		`, eventschema.UUID(e.CommentId), postID, eventschema.Time(e.CreatedAt))
+		if err != nil {
+			return fmt.Errorf("failed to insert comment %s: %w", e.CommentId, err)
+		}
+
+	case commentevents.CommentDeleted:
+		var e commentevents.CommentDeletedEvent
+		if err := json.Unmarshal(event.Data, &e); err != nil {
+			return err
+		}
+		err := p.counted(ctx, "comments_count", e.PostID, -1, `
+			UPDATE feed_service_comments
+			SET deleted_at = $3
+			WHERE comment_id = $1 AND post_id = $2 AND deleted_at IS NULL
+		`, e.CommentID, e.PostID, e.DeletedAt)
+		if err != nil {
+			return fmt.Errorf("failed to delete comment %s: %w", e.CommentID, err)
+		}
+
+	case followevents.UserFollowed:
+		var e followevents.UserFollowedEvent
+		if err := json.Unmarshal(event.Data, &e); err != nil {
+			return err
+		}
+		_, err := p.FeedDB.ExecContext(ctx, `
+			INSERT INTO feed_service_follows (follower_id, followed_id, created_at)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (follower_id, followed_id) DO UPDATE
+			SET created_at = EXCLUDED.created_at, deleted_at = NULL
+			WHERE GREATEST(feed_service_follows.created_at, feed_service_follows.deleted_at) < EXCLUDED.created_at
+		`, e.FollowerID, e.FollowingID, e.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to upsert follow %s -> %s: %w", e.FollowerID, e.FollowingID, err)
+		}
+		p.followers[e.FollowerID.String()] = true
+
+	case followevents.UserUnfollowed:
+		var e followevents.UserUnfollowedEvent
+		if err := json.Unmarshal(event.Data, &e); err != nil {
+			return err
+		}
+		_, err := p.FeedDB.ExecContext(ctx, `
+			UPDATE feed_service_follows
+			SET deleted_at = $3
+			WHERE follower_id = $1 AND followed_id = $2
+				AND created_at <= $3 AND (deleted_at IS NULL OR deleted_at < $3)
+		`, e.FollowerID, e.FollowingID, e.DeletedAt)
+		if err != nil {
+			return fmt.Errorf("failed to delete follow %s -> %s: %w", e.FollowerID, e.FollowingID, err)
+		}
+		p.followers[e.FollowerID.String()] = true
+
+	case postevents.PostReposted:
+		var e postevents.PostRepostedEvent
+		if err := json.Unmarshal(event.Data, &e); err != nil {
+			return err
+		}
+		_, err := p.FeedDB.ExecContext(ctx, `
+			INSERT INTO feed_service_posts (id, user_id, content, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $4)
+			ON CONFLICT (id) DO NOTHING
+		`, e.PostID, e.PostAuthorID, e.PostContent, e.PostCreatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to upsert post %s: %w", e.PostID, err)
+		}
+		_, err = p.FeedDB.ExecContext(ctx, `
+			INSERT INTO feed_service_reposts (user_id, post_id, created_at)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (user_id, post_id) DO NOTHING
+		`, e.UserID, e.PostID, e.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to insert repost %s: %w", e.RepostID, err)
+		}
+		p.authors[e.UserID.String()] = true
+
+	case postevents.PostUnreposted:
+		var e postevents.PostUnrepostedEvent
+		if err := json.Unmarshal(event.Data, &e); err != nil {
+			return err
+		}
+		_, err := p.FeedDB.ExecContext(ctx, `
+			DELETE FROM feed_service_reposts
+			WHERE user_id = $1 AND post_id = $2 AND created_at <= $3
+		`, e.UserID, e.PostID, e.DeletedAt)
+		if err != nil {
+			return fmt.Errorf("failed to delete repost %s: %w", e.RepostID, err)
+		}
+		p.authors[e.UserID.String()] = true
+
+	case userevents.UserUpdated:
+		var e userevents.UserUpdatedEvent
+		if err := json.Unmarshal(event.Data, &e); err != nil {
+			return err
+		}
+		_, err := p.FeedDB.ExecContext(ctx, `
+			INSERT INTO feed_service_private_users (user_id, is_private, updated_at)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (user_id) DO UPDATE
+			SET is_private = EXCLUDED.is_private, updated_at = EXCLUDED.updated_at
+			WHERE feed_service_private_users.updated_at < EXCLUDED.updated_at
+		`, e.UserID, e.IsPrivate, e.UpdatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to set privacy of %s: %w", e.UserID, err)
+		}
+	}
+
+	return nil
+}
+
+// counted runs a statement that makes a like or comment live or ends it and,
+// when it changed a row, moves the post's counter by delta in the same
+// transaction
+func (p *FeedProjection) counted(ctx context.Context, column string, postID uuid.UUID, delta int, query string, args ...any) error {
+	tx, err := p.FeedDB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	changed, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if changed > 0 {
+		_, err := tx.ExecContext(ctx, `
+			UPDATE feed_service_posts
+			SET `+column+` = GREATEST(`+column+` + $2, 0)
+			WHERE id = $1
+		`, postID, delta)
+		if err != nil {
+			return fmt.Errorf("failed to update %s: %w", column, err)
+		}
+	}
+	return tx.Commit()
+}
+
+// Finish rebuilds the feeds of users who followed or unfollowed someone and
+// of the followers of users who posted, reposted or deleted a post
+func (p *FeedProjection) Finish(ctx context.Context) error {
+	if len(p.authors) > 0 {
+		rows, err := p.FeedDB.QueryContext(ctx, `
+			SELECT DISTINCT follower_id
+			FROM feed_service_follows
+			WHERE followed_id = ANY($1::uuid[]) AND deleted_at IS NULL
+		`, pq.Array(keys(p.authors)))
+		if err != nil {
+			return fmt.Errorf("failed to list followers of authors: %w", err)
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var id string
+			if err := rows.Scan(&id); err != nil {
+				return err
+			}
+			p.followers[id] = true
+		}
+		if err := rows.Err(); err != nil {
+			return err
+		}
+	}
+
+	for userID := range p.followers {
+		if err := p.Rebuild(ctx, userID); err != nil {
+			return fmt.Errorf("failed to rebuild feed for %s: %w", userID, err)
+		}
+	}
+	log.Printf("feed: rebuilt %d feeds", len(p.followers))
+	return nil
+}
+
+// NotificationsProjection re-records the notifications notification-service
+// creates from events, then recomputes the cached unread counts of the
+// recipients. Notifications get the same deterministic IDs as live delivery,
+// and are grouped the same way, so ones that already exist are skipped. Likes
+// removed later are retracted again by their unlike. Types a recipient has
+// turned off are not recorded.
+type NotificationsProjection struct {
+	NotificationDB *sql.DB
+	Redis          *redis.Client
+	TTL            time.Duration
+
+	users   map[string]bool
+	created int
+}
+
+func (p *NotificationsProjection) Name() string { return "notifications" }
+
+func (p *NotificationsProjection) Apply(ctx context.Context, event Event) error {
+	if p.users == nil {
+		p.users = make(map[string]bool)
+	}
+
+	var notification *models.Notification
+	switch event.Subject {
+	case notificationevents.SubjectPostCreated:
+		var e eventspb.PostCreated
+		if err := event.decode(&e); err != nil {
+			return err
+		}
+		notification = notificationevents.PostCreatedNotification(&e)
+
+	case notificationevents.SubjectPostCommented:
+		var e notificationevents.PostCommentedEvent
+		if err := json.Unmarshal(event.Data, &e); err != nil {
+			return err
+		}
+		notification = e.Notification()
+
+	case notificationevents.SubjectMentionCreated:
+		var e notificationevents.MentionCreatedEvent
+		if err := json.Unmarshal(event.Data, &e); err != nil {
+			return err
+		}
+		notification = e.Notification()
+
+	case notificationevents.SubjectPostReposted:
+		var e notificationevents.PostRepostedEvent
+		if err := json.Unmarshal(event.Data, &e); err != nil {
+			return err
+		}
+		notification = e.Notification()
+
+	case notificationevents.SubjectCommentReplied:
+		var e notificationevents.CommentRepliedEvent
+		if err := json.Unmarshal(event.Data, &e); err != nil {
+			return err
+		}
+		notification = e.Notification()
+
+	case notificationevents.SubjectFollowRequested:
+		var e notificationevents.FollowRequestedEvent
+		if err := json.Unmarshal(event.Data, &e); err != nil {
+			return err
+		}
+		notification = e.Notification()
+
+	case notificationevents.SubjectPostLiked:
+		var e notificationevents.PostLikedEvent
+		if err := json.Unmarshal(event.Data, &e); err != nil {
+			return err
+		}
+		notification = e.Notification()
+
+	case notificationevents.SubjectPostUnliked:
+		var e notificationevents.PostUnlikedEvent
+		if err := json.Unmarshal(event.Data, &e); err != nil {
+			return err
+		}
+		recipient, retracted, err := notificationrepository.RetractNotification(ctx, p.NotificationDB, e.NotificationID(), e.UserID)
+		if err != nil {
+			return fmt.Errorf("failed to retract like notification of post %s: %w", e.PostID, err)
+		}
+		if retracted {
+			p.users[recipient.String()] = true
+		}
+		return nil
+
+	case notificationevents.SubjectUserFollowed:
+		var e notificationevents.UserFollowedEvent
+		if err := json.Unmarshal(event.Data, &e); err != nil {
+			return err
+		}
+		notification = e.Notification()
+	}
+	if notification == nil {
+		return nil
+	}
+
+	preferences, err := notificationrepository.GetPreferences(ctx, p.NotificationDB, notification.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to get preferences of user %s: %w", notification.UserID, err)
+	}
+	if preferences.Disables(notification.Type) {
+		return nil
+	}
+
+	created, err := notificationrepository.InsertNotification(ctx, p.NotificationDB, notification)
+	if err != nil {
+		return fmt.Errorf("failed to insert notification %s: %w", notification.ID, err)
+	}
+
+	if created {
+		p.users[notification.UserID.String()] = true
+		p.created++
+	}
+	return nil
+}
+
+func (p *NotificationsProjection) Finish(ctx context.Context) error {
+	log.Printf("notifications: %d missing notifications recorded", p.created)
+	if len(p.users) == 0 {
+		return nil
+	}
+
+	rows, err := p.NotificationDB.QueryContext(ctx, `
+		SELECT user_id, COUNT(*) FILTER (WHERE is_read = FALSE)
+		FROM notification_service_notifications
+		WHERE user_id = ANY($1::uuid[])
+		GROUP BY user_id
+	`, pq.Array(keys(p.users)))
+	if err != nil {
+		return fmt.Errorf("failed to count notifications: %w", err)
+	}
+	defer rows.Close()
+
+	pipe := p.Redis.Pipeline()
+	for rows.Next() {
+		var userID string
+		var unread int32
+		if err := rows.Scan(&userID, &unread); err != nil {
+			return err
+		}
+		// Key formats owned by notification-service's repository; the cached
+		// notification pages of these users expire on their own
+		pipe.Set(ctx, "notif:unread:"+userID, fmt.Sprintf("%d", unread), p.TTL)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to write unread counts: %w", err)
+	}
+	return nil
+}
+
+// CountersProjection recomputes the denormalised counters of every post and
+// user the replayed events mention. Counters are recomputed from the owning
+// services rather than incremented, which makes the projection idempotent.
+type CountersProjection struct {
+	RecomputePost func(ctx context.Context, postID string) error
+	RecomputeUser func(ctx context.Context, userID string) error
+
+	posts map[string]bool
+	users map[string]bool
+}
+
+func (p *CountersProjection) Name() string { return "counters" }
+
+func (p *CountersProjection) Apply(ctx context.Context, event Event) error {
+	if p.posts == nil {
+		p.posts = make(map[string]bool)
+		p.users = make(map[string]bool)
+	}
+
+	switch event.Subject {
+	case postevents.PostCreated:
+		var e eventspb.PostCreated
+		if err := event.decode(&e); err != nil {
+			return err
+		}
+		p.users[eventschema.UUID(e.UserId).String()] = true
+
+	case commentevents.CommentAdded, commentevents.CommentDeleted,
+		likeevents.PostLiked, likeevents.PostUnliked:
+		var e struct {
+			PostID uuid.UUID `json:"post_id"`
+		}
+		if err := json.Unmarshal(event.Data, &e); err != nil {
+			return err
+		}
+		p.posts[e.PostID.String()] = true
+
+	case followevents.UserFollowed, followevents.UserUnfollowed:
+		var e struct {
+			FollowerID  uuid.UUID `json:"follower_id"`
+			FollowingID uuid.UUID `json:"following_id"`
+		}
+		if err := json.Unmarshal(event.Data, &e); err != nil {
+			return err
+		}
+		p.users[e.FollowerID.String()] = true
+		p.users[e.FollowingID.String()] = true
+	}
+
+	return nil
+}
+
+func (p *CountersProjection) Finish(ctx context.Context) error {
+	for postID := range p.posts {
+		if err := p.RecomputePost(ctx, postID); err != nil {
+			return fmt.Errorf("failed to recompute post %s: %w", postID, err)
+		}
+	}
+	for userID := range p.users {
+		if err := p.RecomputeUser(ctx, userID); err != nil {
+			return fmt.Errorf("failed to recompute user %s: %w", userID, err)
+		}
+	}
+	log.Printf("counters: recomputed %d posts and %d users", len(p.posts), len(p.users))
+	return nil
+}
+
+func keys(set map[string]bool) []string {
+	out := make([]string, 0, len(set))
+	for k := range set {
+		out = append(out, k)
+	}
+	return out
+}
@@ -0,0 +1,160 @@
+// Package replay re-consumes a range of the retained JetStream event stream
+// into a projection. Projections apply events idempotently, so a range can be
+// replayed any number of times, overlapping events the live consumers already
+// handled, without double-counting.
+package replay
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"google.golang.org/protobuf/proto"
+
+	"shared/eventschema"
+)
+
+// idleTimeout ends a replay when the filtered subject has no further messages
+// in the range, which JetStream does not signal otherwise
+const idleTimeout = 5 * time.Second
+
+// Event is one message read back from the stream
+type Event struct {
+	Subject  string
+	Sequence uint64
+	Time     time.Time
+	Header   nats.Header
+	Data     []byte
+}
+
+// decode reads an event that has a schema in shared/eventschema into payload
+func (e Event) decode(payload proto.Message) error {
+	_, err := eventschema.Decode(e.Subject, e.Header, e.Data, payload)
+	return err
+}
+
+// Projection is a derived store rebuilt from events. Apply must be idempotent;
+// Finish runs once after the last event, e.g. to refresh caches and
+// aggregates for everything Apply touched.
+type Projection interface {
+	Name() string
+	Apply(ctx context.Context, event Event) error
+	Finish(ctx context.Context) error
+}
+
+// Range selects the events to replay. Subject may contain wildcards and
+// defaults to the whole stream. The range starts at StartSeq, or at Since
+// when StartSeq is 0, or at the beginning of the stream; it ends at EndSeq,
+// or at the last message in the stream when the replay starts.
+type Range struct {
+	Stream   string
+	Subject  string
+	StartSeq uint64
+	Since    time.Time
+	EndSeq   uint64
+}
+
+// Stats summarises a replay
+type Stats struct {
+	Applied  int
+	FirstSeq uint64
+	LastSeq  uint64
+}
+
+// Run replays r into p through an ephemeral ordered consumer, which leaves the
+// durable consumers of the live services untouched
+func Run(ctx context.Context, js nats.JetStreamContext, r Range, p Projection) (Stats, error) {
+	var stats Stats
+
+	info, err := js.StreamInfo(r.Stream)
+	if err != nil {
+		return stats, fmt.Errorf("failed to read stream %s: %w", r.Stream, err)
+	}
+
+	end := info.State.LastSeq
+	if r.EndSeq > 0 && r.EndSeq < end {
+		end = r.EndSeq
+	}
+	if r.StartSeq > 0 && r.StartSeq < info.State.FirstSeq {
+		log.Printf("%s: sequence %d has expired, starting at %d", p.Name(), r.StartSeq, info.State.FirstSeq)
+	}
+
+	if end == 0 || r.StartSeq > end {
+		log.Printf("%s: nothing to replay", p.Name())
+		return stats, p.Finish(ctx)
+	}
+
+	subject := r.Subject
+	if subject == "" {
+		subject = ">"
+	}
+
+	opts := []nats.SubOpt{nats.BindStream(r.Stream), nats.OrderedConsumer()}
+	switch {
+	case r.StartSeq > 0:
+		opts = append(opts, nats.StartSequence(r.StartSeq))
+	case !r.Since.IsZero():
+		opts = append(opts, nats.StartTime(r.Since))
+	default:
+		opts = append(opts, nats.DeliverAll())
+	}
+
+	sub, err := js.SubscribeSync(subject, opts...)
+	if err != nil {
+		return stats, fmt.Errorf("failed to subscribe to %s: %w", subject, err)
+	}
+	defer sub.Unsubscribe()
+
+	for {
+		next, cancel := context.WithTimeout(ctx, idleTimeout)
+		msg, err := sub.NextMsgWithContext(next)
+		cancel()
+		if err != nil {
+			if errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil {
+				break
+			}
+			return stats, err
+		}
+
+		meta, err := msg.Metadata()
+		if err != nil {
+			return stats, fmt.Errorf("failed to read message metadata: %w", err)
+		}
+		if meta.Sequence.Stream > end {
+			break
+		}
+
+		event := Event{
+			Subject:  msg.Subject,
+			Sequence: meta.Sequence.Stream,
+			Time:     meta.Timestamp,
+			Header:   msg.Header,
+			Data:     msg.Data,
+		}
+		if err := p.Apply(ctx, event); err != nil {
+			return stats, fmt.Errorf("%s: event %d on %s: %w", p.Name(), event.Sequence, event.Subject, err)
+		}
+
+		if stats.FirstSeq == 0 {
+			stats.FirstSeq = event.Sequence
+		}
+		stats.LastSeq = event.Sequence
+		stats.Applied++
+		if stats.Applied%1000 == 0 {
+			log.Printf("%s: %d events applied (at sequence %d of %d)", p.Name(), stats.Applied, event.Sequence, end)
+		}
+
+		if event.Sequence == end || meta.NumPending == 0 {
+			break
+		}
+	}
+
+	log.Printf("%s: %d events applied, finishing", p.Name(), stats.Applied)
+	if err := p.Finish(ctx); err != nil {
+		return stats, fmt.Errorf("%s: finish: %w", p.Name(), err)
+	}
+	return stats, nil
+}
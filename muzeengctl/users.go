@@ -0,0 +1,124 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	authpb "auth-service/pb"
+)
+
+func runTokens(args []string) error {
+	if len(args) == 0 || args[0] != "revoke" {
+		return fmt.Errorf("tokens: expected revoke <user-id>")
+	}
+
+	userID, _, err := parseUUIDArg(args[1:], "user-id")
+	if err != nil {
+		return err
+	}
+
+	return callAuth(func(client authpb.AuthServiceClient) error {
+		ctx, cancel, err := adminContext()
+		if err != nil {
+			return err
+		}
+		defer cancel()
+
+		resp, err := client.RevokeUserTokens(ctx, &authpb.RevokeUserTokensRequest{UserId: userID})
+		if err != nil {
+			return fmt.Errorf("failed to revoke tokens: %w", err)
+		}
+		fmt.Println(resp.Message)
+		return nil
+	})
+}
+
+func runKeys(args []string) error {
+	if len(args) != 1 || args[0] != "rotate" {
+		return fmt.Errorf("keys: expected rotate")
+	}
+
+	return callAuth(func(client authpb.AuthServiceClient) error {
+		ctx, cancel, err := adminContext()
+		if err != nil {
+			return err
+		}
+		defer cancel()
+
+		resp, err := client.RotateSigningKey(ctx, &authpb.RotateSigningKeyRequest{})
+		if err != nil {
+			return fmt.Errorf("failed to rotate signing key: %w", err)
+		}
+		fmt.Printf("created signing key %s, signing from %s\n", resp.KeyId, resp.ActivatesAt.AsTime().Format(time.RFC3339))
+		return nil
+	})
+}
+
+func runUsers(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("users: expected suspend or unsuspend")
+	}
+
+	userID, rest, err := parseUUIDArg(args[1:], "user-id")
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "suspend":
+		fs := flag.NewFlagSet("users suspend", flag.ExitOnError)
+		reason := fs.String("reason", "", "reason recorded with the suspension")
+		duration := fs.Duration("for", 0, "lift the suspension after this long; without it the user is banned")
+		fs.Parse(rest)
+
+		req := &authpb.SuspendUserRequest{UserId: userID, Reason: *reason}
+		if *duration > 0 {
+			req.Until = timestamppb.New(time.Now().Add(*duration))
+		}
+
+		return callAuth(func(client authpb.AuthServiceClient) error {
+			ctx, cancel, err := adminContext()
+			if err != nil {
+				return err
+			}
+			defer cancel()
+
+			resp, err := client.SuspendUser(ctx, req)
+			if err != nil {
+				return fmt.Errorf("failed to suspend user: %w", err)
+			}
+			fmt.Println(resp.Message)
+			return nil
+		})
+	case "unsuspend":
+		return callAuth(func(client authpb.AuthServiceClient) error {
+			ctx, cancel, err := adminContext()
+			if err != nil {
+				return err
+			}
+			defer cancel()
+
+			resp, err := client.UnsuspendUser(ctx, &authpb.UnsuspendUserRequest{UserId: userID})
+			if err != nil {
+				return fmt.Errorf("failed to unsuspend user: %w", err)
+			}
+			fmt.Println(resp.Message)
+			return nil
+		})
+	default:
+		return fmt.Errorf("users: unknown subcommand %q", args[0])
+	}
+}
+
+func callAuth(fn func(client authpb.AuthServiceClient) error) error {
+	conn, err := dial("AUTH")
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	return fn(authpb.NewAuthServiceClient(conn))
+}
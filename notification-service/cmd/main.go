@@ -2,13 +2,15 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"log"
 	"net"
 	"os"
-	"os/signal"
-	"syscall"
 	"time"
+	// Quiet hours are kept in the user's time zone and the runtime image has
+	// no zoneinfo
+	_ "time/tzdata"
 
 	"github.com/joho/godotenv"
 	"github.com/redis/go-redis/v9"
@@ -17,19 +19,42 @@ import (
 	"notification-service/config"
 	"notification-service/db"
 	"notification-service/handler"
+	"notification-service/interceptor"
+	"notification-service/migrations"
+	models "notification-service/model"
 	natsClient "notification-service/nats"
 	pb "notification-service/pb"
+	"notification-service/push"
 	"notification-service/repository"
+	"notification-service/rpcerror"
 	"notification-service/subscriber"
+	"notification-service/webhook"
+	"shared/chaos"
+	"shared/cursor"
+	"shared/dedupe"
+	"shared/lifecycle"
+	"shared/logging"
+	"shared/migrate"
+	"shared/mtls"
+	"shared/tracing"
 )
 
 func main() {
+	logging.Init("notification-service")
+
+	migrateFlag := flag.Bool("migrate", false, "apply pending schema migrations before serving")
+	flag.Parse()
+
 	ctx := context.Background()
 
 	if err := godotenv.Load(); err != nil {
 		log.Println("failed to load Notification .env")
 	}
 
+	// The supervisor health-checks the dependencies below and closes them
+	// in order on shutdown
+	supervisor := lifecycle.New(pb.NotificationService_ServiceDesc.ServiceName)
+
 	// Load database configuration
 	dbCfg, err := config.LoadDatabaseConfig("NOTIFICATION_")
 	if err != nil {
@@ -47,15 +72,47 @@ func main() {
 		MaxOpenConns: dbCfg.MaxOpenConns,
 		MaxIdleConns: dbCfg.MaxIdleConns,
 		MaxLifetime:  dbCfg.MaxLifetime,
+		ReplicaDSNs:  dbCfg.ReplicaDSNs,
 	})
 	if err != nil {
 		log.Fatalf("Failed to connect to Notification database: %v", err)
 	}
-	defer dbConn.Close()
+	supervisor.Add(lifecycle.Dependency{Name: "database", Check: dbConn.HealthCheck, Close: dbConn.Close})
+
+	// Apply pending schema migrations with -migrate or DB_MIGRATE=true;
+	// without either they are only reported
+	if err := migrate.OnStartup(context.Background(), dbConn.DB, "notification-service", migrations.Files, *migrateFlag || os.Getenv("DB_MIGRATE") == "true"); err != nil {
+		log.Fatalf("Failed to migrate Notification database: %v", err)
+	}
 	log.Println("Notification Database connected successfully")
 
 	// Load other configurations
 	grpcPort := getEnv("GRPC_PORT", "50058")
+	jwtSecret := getEnv("JWT_SECRET", "your-secret-key")
+	// Tokens are verified with JWT_SECRET, or with auth-service's public keys
+	// when JWT_ALGORITHM is RS256
+	tokenKeys, err := interceptor.KeysFromEnv(jwtSecret)
+	if err != nil {
+		log.Fatalf("Invalid JWT configuration: %v", err)
+	}
+
+	// Fault injection for resilience testing; inert unless CHAOS_ENABLED=true
+	chaosInjector, err := chaos.FromEnv()
+	if err != nil {
+		log.Fatalf("Invalid chaos configuration: %v", err)
+	}
+
+	// Export traces when OTEL_EXPORTER_OTLP_ENDPOINT is set
+	shutdownTracing, err := tracing.Init(context.Background(), "notification-service")
+	if err != nil {
+		log.Fatalf("Failed to initialize tracing: %v", err)
+	}
+	supervisor.OnShutdown("tracing", shutdownTracing)
+
+	// Pagination cursors are signed so clients cannot forge positions
+	if err := cursor.SetSecret(os.Getenv("CURSOR_SECRET")); err != nil {
+		log.Fatalf("Invalid CURSOR_SECRET: %v", err)
+	}
 	natsURL := getEnv("NATS_URL", "nats://nats:4222")
 	natsClientID := getEnv("NATS_CLIENT_ID", "notification-service")
 
@@ -65,12 +122,13 @@ func main() {
 		MaxReconnects: 10,
 		ReconnectWait: 2 * time.Second,
 		ClientID:      natsClientID,
+		Chaos:         chaosInjector,
 	}
 	nats, err := natsClient.NewClient(natsCfg)
 	if err != nil {
 		log.Fatalf("Failed to initialize NATS client: %v", err)
 	}
-	defer nats.Close()
+	supervisor.Add(lifecycle.Dependency{Name: "nats", Check: nats.HealthCheck, Drain: nats.Drain})
 	log.Println("NATS client initialized successfully")
 
 	// Load Redis configuration
@@ -84,7 +142,7 @@ func main() {
 		DB:       redisDB,
 		PoolSize: 10,
 	})
-	defer redisClient.Close()
+	supervisor.Add(lifecycle.Dependency{Name: "redis", Check: func(ctx context.Context) error { return redisClient.Ping(ctx).Err() }, Close: redisClient.Close})
 
 	// Test Redis connection
 	if err := redisClient.Ping(ctx).Err(); err != nil {
@@ -92,57 +150,169 @@ func main() {
 	}
 	log.Println("Feed Redis connected successfully")
 
-	// Initialize repository
-	repo := repository.NewNotificationRepository(dbConn.DB, redisClient)
+	// Initialize repositories
+	repo := repository.NewNotificationRepository(dbConn, redisClient, getEnvAsFloat("NOTIFICATION_EARLY_REFRESH_BETA", 0))
+	webhookRepo := repository.NewWebhookRepository(dbConn.DB)
+	deviceRepo := repository.NewDeviceRepository(dbConn.DB)
+	prefRepo := repository.NewPreferenceRepository(dbConn.DB)
+	// Users are online while their gateway connections send heartbeats,
+	// and offline PRESENCE_TTL after the last one
+	presenceRepo := repository.NewPresenceRepository(redisClient, getEnvAsDuration("PRESENCE_TTL", time.Minute))
+	failedEventRepo := repository.NewFailedEventRepository(dbConn.DB)
 
-	// Initialize gRPC handler
-	grpcHandler := handler.NewNotificationHandler(repo)
+	// Initialize push delivery; platforms without credentials are skipped
+	senders, err := pushSenders()
+	if err != nil {
+		log.Fatalf("Invalid push configuration: %v", err)
+	}
+	pusher := push.NewDispatcher(deviceRepo, prefRepo, senders, push.Config{
+		MaxAttempts:    getEnvAsInt("PUSH_MAX_ATTEMPTS", 3),
+		InitialBackoff: time.Second,
+		MaxConcurrency: getEnvAsInt("PUSH_MAX_CONCURRENCY", 50),
+	})
+	// Sends in progress are finished on shutdown, once NATS has drained
+	supervisor.Add(lifecycle.Dependency{Name: "push deliveries", Drain: pusher.Wait})
 
-	// Initialize NATS subscriber
-	sub := subscriber.NewNotificationSubscriber(nats, repo, ctx)
+	// Initialize NATS subscriber; it also owns the retained event stream, whose
+	// retention bounds how far back projections can be replayed
+	eventRetention := getEnvAsDuration("EVENT_RETENTION", 7*24*time.Hour)
+	// Events it already handled are recorded, so redeliveries are skipped
+	processedEvents := dedupe.New(dbConn.DB, "notification_service_processed_events")
+	go processedEvents.RunPruning(supervisor.Context())
+	sub := subscriber.NewNotificationSubscriber(nats, repo, prefRepo, failedEventRepo, processedEvents, pusher, ctx, eventRetention)
 	if err := sub.Start(); err != nil {
 		log.Fatalf("Failed to start NATS subscriber: %v", err)
 	}
 
-	// Start gRPC server in a separate goroutine
-	go func() {
-		if err := startGRPCServer(grpcPort, grpcHandler); err != nil {
-			log.Fatalf("Failed to start gRPC server: %v", err)
-		}
-	}()
+	// Initialize gRPC handler; failed events are replayed by the subscriber
+	grpcHandler := handler.NewNotificationHandler(repo, webhookRepo, deviceRepo, prefRepo, presenceRepo, failedEventRepo, pusher, nats, sub)
+
+	// Data of deleted accounts is removed once the stream exists
+	userSub := subscriber.NewUserSubscriber(nats, repo, webhookRepo, deviceRepo, prefRepo, presenceRepo, ctx)
+	if err := userSub.Start(); err != nil {
+		log.Fatalf("Failed to start user subscriber: %v", err)
+	}
+
+	// Initialize webhook dispatcher and its subscriber
+	dispatcher := webhook.NewDispatcher(webhookRepo, webhook.Config{
+		MaxAttempts:    getEnvAsInt("WEBHOOK_MAX_ATTEMPTS", 5),
+		InitialBackoff: 2 * time.Second,
+		Timeout:        10 * time.Second,
+		MaxConcurrency: getEnvAsInt("WEBHOOK_MAX_CONCURRENCY", 20),
+	})
+	supervisor.Add(lifecycle.Dependency{Name: "webhook deliveries", Drain: dispatcher.Wait})
+	webhookSub := subscriber.NewWebhookSubscriber(nats, dispatcher, webhookRepo, ctx)
+	if err := webhookSub.Start(); err != nil {
+		log.Fatalf("Failed to start webhook subscriber: %v", err)
+	}
+
+	presenceSub := subscriber.NewPresenceSubscriber(nats, presenceRepo, 10*time.Second, ctx)
+	if err := presenceSub.Start(); err != nil {
+		log.Fatalf("Failed to start presence subscriber: %v", err)
+	}
 
 	log.Printf("Notification Service started")
-	log.Printf("gRPC listening on port %s", grpcPort)
 	log.Printf("NATS subscriber active")
 
-	// Graceful shutdown
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-	<-quit
-
-	log.Println("Notification Shutting down Notification Service...")
-	sub.Stop()
-	nats.Close()
-	dbConn.Close()
-	log.Println("Notification Service stopped cleanly")
+	// Serve until signalled, then shut down gracefully
+	if err := serveGRPC(grpcPort, tokenKeys, grpcHandler, supervisor, chaosInjector); err != nil {
+		log.Fatalf("Failed to serve gRPC: %v", err)
+	}
 }
 
-// startGRPCServer starts the notification gRPC server
-func startGRPCServer(port string, handler *handler.NotificationHandler) error {
+// serveGRPC serves the notification gRPC server until supervisor shuts it
+// down
+func serveGRPC(port string, tokenKeys interceptor.Keys, handler *handler.NotificationHandler, supervisor *lifecycle.Supervisor, chaosInjector *chaos.Injector) error {
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%s", port))
 	if err != nil {
 		return fmt.Errorf("failed to listen on port %s: %w", port, err)
 	}
 
+	// Webhook management is developer-facing and always requires a JWT;
+	// the notification RPCs keep their existing unauthenticated access
+	authInterceptor := interceptor.NewAuthInterceptor(tokenKeys, []string{
+		"/notification.NotificationService/GetNotifications",
+		"/notification.NotificationService/MarkRead",
+		"/notification.NotificationService/MarkAllRead",
+		"/notification.NotificationService/CreateNotification",
+		"/notification.NotificationService/DeleteNotification",
+	})
+	authInterceptor.AddAdminMethods([]string{
+		"/notification.NotificationService/PurgeNotifications",
+		"/notification.NotificationService/ListFailedEvents",
+		"/notification.NotificationService/GetFailedEvent",
+		"/notification.NotificationService/ReplayFailedEvent",
+	})
+	authInterceptor.AddPublicMethods(lifecycle.HealthMethods)
+
+	// TLS, and mutual TLS with a CA bundle, from GRPC_TLS_*
+	tlsOption, err := mtls.ServerOption()
+	if err != nil {
+		return fmt.Errorf("failed to load TLS config: %w", err)
+	}
+
 	grpcServer := grpc.NewServer(
+		tlsOption,
+		tracing.ServerOption(),
 		grpc.MaxRecvMsgSize(10*1024*1024), // 10MB
 		grpc.MaxSendMsgSize(10*1024*1024), // 10MB
+		grpc.ChainUnaryInterceptor(logging.UnaryServerInterceptor(), rpcerror.UnaryServerInterceptor(), chaosInjector.Unary(), authInterceptor.Unary()),
 	)
 
 	pb.RegisterNotificationServiceServer(grpcServer, handler)
+	supervisor.Register(grpcServer)
+
+	log.Printf("gRPC server listening on port %s", port)
+	return supervisor.Serve(grpcServer, lis)
+}
+
+// pushSenders builds a sender for every push platform whose credentials are
+// configured
+func pushSenders() (map[models.DevicePlatform]push.Sender, error) {
+	senders := make(map[models.DevicePlatform]push.Sender)
+	timeout := 10 * time.Second
+
+	if file := os.Getenv("FCM_CREDENTIALS_FILE"); file != "" {
+		sender, err := push.NewFCMSender(file, timeout)
+		if err != nil {
+			return nil, err
+		}
+		senders[models.DevicePlatformFCM] = sender
+	}
+
+	if file := os.Getenv("APNS_KEY_FILE"); file != "" {
+		sender, err := push.NewAPNsSender(push.APNsConfig{
+			KeyFile:    file,
+			KeyID:      os.Getenv("APNS_KEY_ID"),
+			TeamID:     os.Getenv("APNS_TEAM_ID"),
+			Topic:      os.Getenv("APNS_TOPIC"),
+			Production: os.Getenv("APNS_PRODUCTION") == "true",
+			Timeout:    timeout,
+		})
+		if err != nil {
+			return nil, err
+		}
+		senders[models.DevicePlatformAPNs] = sender
+	}
+
+	if key := os.Getenv("VAPID_PRIVATE_KEY"); key != "" {
+		sender, err := push.NewWebPushSender(push.WebPushConfig{
+			PublicKey:  os.Getenv("VAPID_PUBLIC_KEY"),
+			PrivateKey: key,
+			Subject:    os.Getenv("VAPID_SUBJECT"),
+			TTL:        getEnvAsDuration("WEBPUSH_TTL", 24*time.Hour),
+			Timeout:    timeout,
+		})
+		if err != nil {
+			return nil, err
+		}
+		senders[models.DevicePlatformWebPush] = sender
+	}
 
-	log.Printf("gRPC server starting on port %s", port)
-	return grpcServer.Serve(lis)
+	for platform := range senders {
+		log.Printf("Push delivery enabled for %s", platform)
+	}
+	return senders, nil
 }
 
 // helper for non-database environment variables
@@ -161,3 +331,19 @@ func getEnvAsInt(key string, defaultVal int) int {
 	}
 	return defaultVal
 }
+
+func getEnvAsFloat(key string, defaultVal float64) float64 {
+	if val := os.Getenv(key); val != "" {
+		var floatVal float64
+		fmt.Sscanf(val, "%g", &floatVal)
+		return floatVal
+	}
+	return defaultVal
+}
+
+func getEnvAsDuration(key string, defaultVal time.Duration) time.Duration {
+	if val, err := time.ParseDuration(os.Getenv(key)); err == nil && val > 0 {
+		return val
+	}
+	return defaultVal
+}
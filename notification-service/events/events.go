@@ -4,14 +4,131 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+
+	"notification-service/model"
+	"shared/eventschema"
+	eventspb "shared/eventschema/pb"
+)
+
+// StreamName is the JetStream stream retaining every domain event, so that
+// projections can be rebuilt by replaying it
+const StreamName = "EVENTS"
+
+// DeadLetterStream retains messages that could not be handled or published,
+// on their subject prefixed with "deadletter.", for DeadLetterRetention.
+// post-service and comment-service dead-letter outbox events in it too.
+const (
+	DeadLetterStream    = "DEAD_LETTERS"
+	DeadLetterSubjects  = "deadletter.>"
+	DeadLetterRetention = 30 * 24 * time.Hour
 )
 
+// DeadLetterPrefix prefixes the subject of the events this service's
+// subscriber gave up on, e.g. "deadletter.notification.post.created"
+const DeadLetterPrefix = "deadletter.notification."
+
 // Event subjects (topics)
 const (
-	SubjectPostCommented = "post.commented"
-	SubjectPostCreated   = "post.created"
+	SubjectPostCommented   = "post.commented"
+	SubjectPostCreated     = eventschema.PostCreated
+	SubjectPostUpdated     = eventschema.PostUpdated
+	SubjectPostDeleted     = eventschema.PostDeleted
+	SubjectCommentAdded    = eventschema.CommentAdded
+	SubjectUserFollowed    = "follow.created"
+	SubjectUserUnfollowed  = "follow.deleted"
+	SubjectUserUpdated     = "user.updated"
+	SubjectMentionCreated  = "mention.created"
+	SubjectPostReposted    = "post.reposted"
+	SubjectPostUnreposted  = "post.unreposted"
+	SubjectCommentReplied  = "comment.replied"
+	SubjectFollowRequested = "follow.requested"
+	SubjectPostLiked       = "post.liked"
+	SubjectUserDeleted     = "user.deleted"
+	// Published by auth-service
+	SubjectRefreshTokenReused = "security.refresh_token_reused"
+	// Published by post-service and comment-service when a content filter
+	// flags a post or comment. It is only retained, for moderation tools.
+	SubjectContentFlagged = "content.flagged"
+	// Published by auth-service and user-service for sensitive operations,
+	// and consumed by audit-service
+	SubjectAuditRecorded = "audit.recorded"
+	// Published by like-service and comment-service when a like or comment
+	// is removed, and consumed by post-service to keep its counters. Removed
+	// likes retract their notification.
+	SubjectPostUnliked    = "post.unliked"
+	SubjectCommentDeleted = "post.comment.deleted"
+	// Published by user-service when a username changes, and consumed by
+	// auth-service to keep its copy of the username
+	SubjectUsernameChanged = "user.username_changed"
 )
 
+// StreamSubjects are the subjects captured by StreamName
+var StreamSubjects = []string{
+	SubjectPostCreated,
+	SubjectPostUpdated,
+	SubjectPostDeleted,
+	SubjectPostCommented,
+	SubjectCommentAdded,
+	SubjectUserFollowed,
+	SubjectUserUnfollowed,
+	SubjectUserUpdated,
+	SubjectMentionCreated,
+	SubjectPostReposted,
+	SubjectPostUnreposted,
+	SubjectCommentReplied,
+	SubjectFollowRequested,
+	SubjectPostLiked,
+	SubjectUserDeleted,
+	SubjectRefreshTokenReused,
+	SubjectContentFlagged,
+	SubjectAuditRecorded,
+	SubjectPostUnliked,
+	SubjectCommentDeleted,
+	SubjectUsernameChanged,
+}
+
+// UserNotificationsSubject is the subject a user's new notifications are
+// published on for the gateway's notificationAdded subscription. It is not
+// captured by StreamName: live updates are not worth replaying.
+func UserNotificationsSubject(userID uuid.UUID) string {
+	return "notifications." + userID.String()
+}
+
+// NotificationReadStateSubject is the subject a user's notifications being
+// marked read are published on for the gateway's
+// notificationReadStateChanged subscription, so that every client the user
+// has open can update its unread count. Like new notifications, it is not
+// captured by StreamName.
+func NotificationReadStateSubject(userID uuid.UUID) string {
+	return "notifications.read." + userID.String()
+}
+
+// NotificationReadStateEvent is published on NotificationReadStateSubject.
+// NotificationID is nil when all of the user's notifications were marked
+// read.
+type NotificationReadStateEvent struct {
+	UserID         uuid.UUID  `json:"user_id"`
+	NotificationID *uuid.UUID `json:"notification_id,omitempty"`
+	UnreadCount    int32      `json:"unread_count"`
+	ReadAt         time.Time  `json:"read_at"`
+}
+
+// SubjectPresenceHeartbeat is published by the gateway for each open
+// WebSocket connection, to keep its user online. Like presence changes, it
+// is not captured by StreamName.
+const SubjectPresenceHeartbeat = "presence.heartbeat"
+
+// PresenceChangedSubject is the subject a user going online or offline is
+// published on for the gateway's presenceChanged subscription
+func PresenceChangedSubject(userID uuid.UUID) string {
+	return "presence.changed." + userID.String()
+}
+
+// PresenceHeartbeatEvent is published on SubjectPresenceHeartbeat
+type PresenceHeartbeatEvent struct {
+	UserID uuid.UUID `json:"user_id"`
+}
+
 // PostCommentedEvent is published when a user comments on a post
 type PostCommentedEvent struct {
 	PostID      uuid.UUID `json:"post_id"`
@@ -21,10 +138,286 @@ type PostCommentedEvent struct {
 	Timestamp   time.Time `json:"timestamp"`
 }
 
-// PostCreatedEvent is published when a user creates a post
-type PostCreatedEvent struct {
+// MentionCreatedEvent is published by post-service and comment-service when
+// a post or comment newly mentions a user. CommentID is set for comments.
+type MentionCreatedEvent struct {
+	MentionID       uuid.UUID  `json:"mention_id"`
+	PostID          uuid.UUID  `json:"post_id"`
+	CommentID       *uuid.UUID `json:"comment_id,omitempty"`
+	AuthorID        uuid.UUID  `json:"author_id"`
+	MentionedUserID uuid.UUID  `json:"mentioned_user_id"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+// PostRepostedEvent is published by post-service when a user reposts a post
+type PostRepostedEvent struct {
+	RepostID     uuid.UUID `json:"repost_id"`
+	PostID       uuid.UUID `json:"post_id"`
+	PostAuthorID uuid.UUID `json:"post_author_id"`
+	UserID       uuid.UUID `json:"user_id"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// CommentRepliedEvent is published by comment-service when a user replies to
+// a comment
+type CommentRepliedEvent struct {
+	ReplyID         uuid.UUID `json:"reply_id"`
+	CommentID       uuid.UUID `json:"comment_id"`
+	CommentAuthorID uuid.UUID `json:"comment_author_id"`
+	PostID          uuid.UUID `json:"post_id"`
+	UserID          uuid.UUID `json:"user_id"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+// FollowRequestedEvent is published by follow-service when a user asks to
+// follow a private account
+type FollowRequestedEvent struct {
+	RequestID   uuid.UUID `json:"request_id"`
+	FollowerID  uuid.UUID `json:"follower_id"`
+	FollowingID uuid.UUID `json:"following_id"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// PostLikedEvent is published by like-service when a user likes a post
+type PostLikedEvent struct {
+	LikeID       uuid.UUID `json:"like_id"`
+	PostID       uuid.UUID `json:"post_id"`
+	PostAuthorID uuid.UUID `json:"post_author_id"`
+	UserID       uuid.UUID `json:"user_id"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// PostUnlikedEvent is published by like-service when a user removes their
+// like of a post
+type PostUnlikedEvent struct {
+	LikeID    uuid.UUID `json:"like_id"`
 	PostID    uuid.UUID `json:"post_id"`
-	AuthorID  uuid.UUID `json:"author_id"`
-	Content   string    `json:"content"`
-	Timestamp time.Time `json:"timestamp"`
+	UserID    uuid.UUID `json:"user_id"`
+	UnlikedAt time.Time `json:"unliked_at"`
+}
+
+// NotificationID is the ID of the notification the like being removed
+// created, to be retracted
+func (e PostUnlikedEvent) NotificationID() uuid.UUID {
+	return likeNotificationID(e.PostID, e.UserID)
+}
+
+func likeNotificationID(postID, userID uuid.UUID) uuid.UUID {
+	return models.EventNotificationID(models.NotificationTypeLike, uuid.NewSHA1(postID, userID[:]))
+}
+
+// UserFollowedEvent is published by follow-service when a user follows
+// another, including when a follow request is approved
+type UserFollowedEvent struct {
+	FollowerID  uuid.UUID `json:"follower_id"`
+	FollowingID uuid.UUID `json:"following_id"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// UserUnfollowedEvent is published by follow-service when a user unfollows
+// another
+type UserUnfollowedEvent struct {
+	FollowerID  uuid.UUID `json:"follower_id"`
+	FollowingID uuid.UUID `json:"following_id"`
+	DeletedAt   time.Time `json:"deleted_at"`
+}
+
+// UserUpdatedEvent is published by user-service when a profile changes
+type UserUpdatedEvent struct {
+	UserID    uuid.UUID `json:"user_id"`
+	IsPrivate bool      `json:"is_private"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// UserDeletedEvent is published by auth-service when a user deletes their
+// account
+type UserDeletedEvent struct {
+	UserID    uuid.UUID `json:"user_id"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+// RefreshTokenReusedEvent is published by auth-service when a refresh token
+// that was already exchanged is presented again, and it has revoked the
+// session the token belonged to
+type RefreshTokenReusedEvent struct {
+	UserID       uuid.UUID `json:"user_id"`
+	SessionID    uuid.UUID `json:"session_id"`
+	DeviceName   string    `json:"device_name"`
+	IPAddress    string    `json:"ip_address"`
+	ReusedFromIP string    `json:"reused_from_ip"`
+	DetectedAt   time.Time `json:"detected_at"`
+}
+
+// PostCreatedNotification builds the notification recorded for a post.created
+// event
+func PostCreatedNotification(event *eventspb.PostCreated) *models.Notification {
+	postID := eventschema.UUID(event.PostId)
+	authorID := eventschema.UUID(event.UserId)
+	return &models.Notification{
+		ID:        models.EventNotificationID(models.NotificationTypePost, postID),
+		UserID:    authorID,
+		Type:      models.NotificationTypePost,
+		Message:   "created a new post",
+		ActorID:   &authorID,
+		RelatedID: &postID,
+		IsRead:    false,
+		CreatedAt: eventschema.Time(event.CreatedAt),
+	}
+}
+
+// Notification builds the notification recorded for the event, or nil when
+// users comment on their own post
+func (e PostCommentedEvent) Notification() *models.Notification {
+	if e.PostOwner == e.CommentedBy {
+		return nil
+	}
+
+	return &models.Notification{
+		ID:        models.EventNotificationID(models.NotificationTypeComment, e.CommentID),
+		UserID:    e.PostOwner,
+		Type:      models.NotificationTypeComment,
+		Message:   "commented on your post",
+		ActorID:   &e.CommentedBy,
+		RelatedID: &e.PostID,
+		IsRead:    false,
+		CreatedAt: e.Timestamp,
+	}
+}
+
+// Notification builds the notification recorded for the event. It links to
+// the post, which is where a mention in a comment is read too.
+func (e MentionCreatedEvent) Notification() *models.Notification {
+	message := "mentioned you in a post"
+	if e.CommentID != nil {
+		message = "mentioned you in a comment"
+	}
+
+	return &models.Notification{
+		ID:        models.EventNotificationID(models.NotificationTypeMention, e.MentionID),
+		UserID:    e.MentionedUserID,
+		Type:      models.NotificationTypeMention,
+		Message:   message,
+		ActorID:   &e.AuthorID,
+		RelatedID: &e.PostID,
+		IsRead:    false,
+		CreatedAt: e.CreatedAt,
+	}
+}
+
+// Notification builds the notification for the author of the reposted post,
+// or returns nil when authors repost their own post. Unread reposts of one
+// post are grouped into a single notification.
+func (e PostRepostedEvent) Notification() *models.Notification {
+	if e.UserID == e.PostAuthorID {
+		return nil
+	}
+
+	return &models.Notification{
+		ID:        models.EventNotificationID(models.NotificationTypeRepost, e.RepostID),
+		UserID:    e.PostAuthorID,
+		Type:      models.NotificationTypeRepost,
+		Message:   "reposted your post",
+		ActorID:   &e.UserID,
+		RelatedID: &e.PostID,
+		IsRead:    false,
+		CreatedAt: e.CreatedAt,
+		GroupKey:  models.GroupKey(models.NotificationTypeRepost, e.PostID),
+	}
+}
+
+// Notification builds the notification for the author of the comment replied
+// to, or returns nil when users reply to their own comment. Like mentions, it
+// links to the post the thread is on.
+func (e CommentRepliedEvent) Notification() *models.Notification {
+	if e.UserID == e.CommentAuthorID {
+		return nil
+	}
+
+	return &models.Notification{
+		ID:        models.EventNotificationID(models.NotificationTypeReply, e.ReplyID),
+		UserID:    e.CommentAuthorID,
+		Type:      models.NotificationTypeReply,
+		Message:   "replied to your comment",
+		ActorID:   &e.UserID,
+		RelatedID: &e.PostID,
+		IsRead:    false,
+		CreatedAt: e.CreatedAt,
+	}
+}
+
+// Notification builds the notification asking the owner of the private
+// account to approve the request. It links to the requester.
+func (e FollowRequestedEvent) Notification() *models.Notification {
+	return &models.Notification{
+		ID:        models.EventNotificationID(models.NotificationTypeFollowRequest, e.RequestID),
+		UserID:    e.FollowingID,
+		Type:      models.NotificationTypeFollowRequest,
+		Message:   "requested to follow you",
+		ActorID:   &e.FollowerID,
+		RelatedID: &e.FollowerID,
+		IsRead:    false,
+		CreatedAt: e.CreatedAt,
+	}
+}
+
+// Notification builds the notification for the author of the liked post, or
+// returns nil when authors like their own post. The ID is derived from the
+// post and the liker rather than the like, so a redelivered like does not
+// notify twice and the unlike can find it to retract. Unread likes on one
+// post are grouped into a single notification ("X and 3 others liked your
+// post").
+func (e PostLikedEvent) Notification() *models.Notification {
+	if e.UserID == e.PostAuthorID {
+		return nil
+	}
+
+	return &models.Notification{
+		ID:        likeNotificationID(e.PostID, e.UserID),
+		UserID:    e.PostAuthorID,
+		Type:      models.NotificationTypeLike,
+		Message:   "liked your post",
+		ActorID:   &e.UserID,
+		RelatedID: &e.PostID,
+		IsRead:    false,
+		CreatedAt: e.CreatedAt,
+		GroupKey:  models.GroupKey(models.NotificationTypeLike, e.PostID),
+	}
+}
+
+// Notification builds the notification for the followed user. Like
+// FollowRequestedEvent it links to the follower, and the ID is derived from
+// the pair so that unfollowing and following again does not notify twice.
+// Unread follows are grouped into a single notification.
+func (e UserFollowedEvent) Notification() *models.Notification {
+	return &models.Notification{
+		ID:        models.EventNotificationID(models.NotificationTypeFollow, uuid.NewSHA1(e.FollowerID, e.FollowingID[:])),
+		UserID:    e.FollowingID,
+		Type:      models.NotificationTypeFollow,
+		Message:   "started following you",
+		ActorID:   &e.FollowerID,
+		RelatedID: &e.FollowerID,
+		IsRead:    false,
+		CreatedAt: e.CreatedAt,
+		GroupKey:  models.GroupKey(models.NotificationTypeFollow, e.FollowingID),
+	}
+}
+
+// Notification builds the warning for the owner of the revoked session. It
+// links to the session, and is created once however often the token is
+// reused.
+func (e RefreshTokenReusedEvent) Notification() *models.Notification {
+	device := e.DeviceName
+	if device == "" {
+		device = "a device"
+	}
+
+	return &models.Notification{
+		ID:        models.EventNotificationID(models.NotificationTypeSecurity, e.SessionID),
+		UserID:    e.UserID,
+		Type:      models.NotificationTypeSecurity,
+		Message:   "Your session on " + device + " was signed out because its refresh token was used twice. If this wasn't you, change your password.",
+		RelatedID: &e.SessionID,
+		IsRead:    false,
+		CreatedAt: e.DetectedAt,
+	}
 }
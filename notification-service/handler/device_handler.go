@@ -0,0 +1,176 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+
+	models "notification-service/model"
+	pb "notification-service/pb"
+	"notification-service/rpcerror"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const maxDevicesPerUser = 20
+
+// pushNotificationTypes are the notification types push preferences cover
+var pushNotificationTypes = []models.NotificationType{
+	models.NotificationTypeLike,
+	models.NotificationTypeComment,
+	models.NotificationTypeFollow,
+	models.NotificationTypeFollowRequest,
+	models.NotificationTypeMention,
+	models.NotificationTypeRepost,
+	models.NotificationTypeReply,
+}
+
+func (h *NotificationHandler) RegisterDevice(ctx context.Context, req *pb.RegisterDeviceRequest) (*pb.Device, error) {
+	userID, err := ownerID(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Token == "" {
+		return nil, rpcerror.InvalidField("token", "token is required")
+	}
+
+	device := &models.Device{
+		ID:     uuid.New(),
+		UserID: userID,
+		Token:  req.Token,
+	}
+
+	switch req.Platform {
+	case pb.DevicePlatform_FCM:
+		device.Platform = models.DevicePlatformFCM
+	case pb.DevicePlatform_APNS:
+		device.Platform = models.DevicePlatformAPNs
+	case pb.DevicePlatform_WEBPUSH:
+		device.Platform = models.DevicePlatformWebPush
+		endpoint, err := url.Parse(req.Token)
+		if err != nil || endpoint.Scheme != "https" || endpoint.Host == "" {
+			return nil, status.Error(codes.InvalidArgument, "a WebPush token must be the subscription's https endpoint")
+		}
+		if req.GetP256Dh() == "" || req.GetAuth() == "" {
+			return nil, status.Error(codes.InvalidArgument, "p256dh and auth are required for WebPush")
+		}
+		device.P256dh = req.P256Dh
+		device.Auth = req.Auth
+	default:
+		return nil, rpcerror.InvalidField("platform", "platform must be specified")
+	}
+
+	existing, err := h.deviceRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to list devices: %v", err))
+	}
+	if len(existing) >= maxDevicesPerUser && !hasToken(existing, device.Platform, device.Token) {
+		return nil, status.Error(codes.ResourceExhausted, fmt.Sprintf("a user can register at most %d devices", maxDevicesPerUser))
+	}
+
+	if err := h.deviceRepo.Register(ctx, device); err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to register device: %v", err))
+	}
+
+	return &pb.Device{
+		Id:         device.ID.String(),
+		UserId:     device.UserID.String(),
+		Platform:   req.Platform,
+		Token:      device.Token,
+		CreatedAt:  timestamppb.New(device.CreatedAt),
+		LastSeenAt: timestamppb.New(device.LastSeenAt),
+	}, nil
+}
+
+func (h *NotificationHandler) UnregisterDevice(ctx context.Context, req *pb.UnregisterDeviceRequest) (*pb.Response, error) {
+	userID, err := ownerID(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Token == "" {
+		return nil, rpcerror.InvalidField("token", "token is required")
+	}
+
+	if err := h.deviceRepo.Unregister(ctx, userID, req.Token); err != nil {
+		return nil, status.Error(codes.NotFound, fmt.Sprintf("failed to unregister device: %v", err))
+	}
+
+	return &pb.Response{
+		Success: true,
+		Message: "Device unregistered successfully",
+	}, nil
+}
+
+func (h *NotificationHandler) GetPushPreferences(ctx context.Context, req *pb.GetPushPreferencesRequest) (*pb.PushPreferences, error) {
+	userID, err := ownerID(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	return h.pushPreferences(ctx, userID)
+}
+
+func (h *NotificationHandler) UpdatePushPreferences(ctx context.Context, req *pb.UpdatePushPreferencesRequest) (*pb.PushPreferences, error) {
+	userID, err := ownerID(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	preferences := make([]models.PushPreference, len(req.Preferences))
+	for i, p := range req.Preferences {
+		t := protoTypeToModel(p.Type)
+		if !slices.Contains(pushNotificationTypes, t) {
+			return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("push preferences cannot be set for %s", p.Type))
+		}
+		preferences[i] = models.PushPreference{
+			Type:    t,
+			Enabled: p.Enabled,
+		}
+	}
+
+	if err := h.deviceRepo.SetPushPreferences(ctx, userID, preferences); err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to update push preferences: %v", err))
+	}
+
+	return h.pushPreferences(ctx, userID)
+}
+
+// pushPreferences lists every notification type with whether it is pushed,
+// filling in the default for types the user has not set
+func (h *NotificationHandler) pushPreferences(ctx context.Context, userID uuid.UUID) (*pb.PushPreferences, error) {
+	stored, err := h.deviceRepo.GetPushPreferences(ctx, userID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to get push preferences: %v", err))
+	}
+
+	enabled := make(map[models.NotificationType]bool, len(stored))
+	for _, p := range stored {
+		enabled[p.Type] = p.Enabled
+	}
+
+	preferences := make([]*pb.PushPreference, len(pushNotificationTypes))
+	for i, t := range pushNotificationTypes {
+		on, ok := enabled[t]
+		preferences[i] = &pb.PushPreference{
+			Type:    modelTypeToProto(t),
+			Enabled: on || !ok,
+		}
+	}
+
+	return &pb.PushPreferences{Preferences: preferences}, nil
+}
+
+func hasToken(devices []models.Device, platform models.DevicePlatform, token string) bool {
+	for _, d := range devices {
+		if d.Platform == platform && d.Token == token {
+			return true
+		}
+	}
+	return false
+}
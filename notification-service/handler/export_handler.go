@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"notification-service/interceptor"
+	models "notification-service/model"
+	pb "notification-service/pb"
+)
+
+// exportPageSize is how many notifications are read per query when exporting
+const exportPageSize = 100
+
+// dataExport is the document ExportMyData returns
+type dataExport struct {
+	Notifications   []models.Notification           `json:"notifications"`
+	Preferences     *models.NotificationPreferences `json:"preferences"`
+	PushPreferences []models.PushPreference         `json:"push_preferences"`
+	Devices         []models.Device                 `json:"devices"`
+	Webhooks        []models.Webhook                `json:"webhooks"`
+}
+
+// ExportMyData returns the caller's notifications, settings, devices and
+// webhooks as a JSON document
+func (h *NotificationHandler) ExportMyData(ctx context.Context, req *pb.ExportMyDataRequest) (*pb.DataExport, error) {
+	raw, err := interceptor.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "user not authenticated")
+	}
+	userID, err := uuid.Parse(raw)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "invalid user ID in token")
+	}
+
+	export := dataExport{Notifications: []models.Notification{}}
+	var after *string
+	for {
+		page, err := h.repo.GetByUserID(ctx, userID, exportPageSize, after)
+		if err != nil {
+			return nil, status.Error(codes.Internal, fmt.Sprintf("failed to export notifications: %v", err))
+		}
+		for _, edge := range page.Edges {
+			export.Notifications = append(export.Notifications, edge.Node)
+		}
+		if !page.PageInfo.HasNextPage || page.PageInfo.EndCursor == nil {
+			break
+		}
+		after = page.PageInfo.EndCursor
+	}
+
+	if export.Preferences, err = h.prefRepo.Get(ctx, userID); err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to export preferences: %v", err))
+	}
+	if export.PushPreferences, err = h.deviceRepo.GetPushPreferences(ctx, userID); err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to export push preferences: %v", err))
+	}
+	if export.Devices, err = h.deviceRepo.ListByUserID(ctx, userID); err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to export devices: %v", err))
+	}
+	if export.Webhooks, err = h.webhookRepo.ListByUserID(ctx, userID); err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to export webhooks: %v", err))
+	}
+
+	data, err := json.Marshal(export)
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to encode export: %v", err))
+	}
+	return &pb.DataExport{Data: data}, nil
+}
@@ -0,0 +1,125 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	models "notification-service/model"
+	pb "notification-service/pb"
+	"notification-service/repository"
+	"notification-service/rpcerror"
+)
+
+const (
+	defaultFailedEventsLimit = 50
+	maxFailedEventsLimit     = 500
+)
+
+// EventReplayer handles failed events again
+type EventReplayer interface {
+	Replay(ctx context.Context, event *models.FailedEvent) error
+}
+
+// ListFailedEvents returns the events the subscriber gave up on, most recent
+// first. Events replayed successfully are left out unless asked for.
+func (h *NotificationHandler) ListFailedEvents(ctx context.Context, req *pb.ListFailedEventsRequest) (*pb.ListFailedEventsResponse, error) {
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = defaultFailedEventsLimit
+	}
+	limit = min(limit, maxFailedEventsLimit)
+
+	failed, err := h.failedEvents.List(ctx, req.IncludeReplayed, limit)
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to list failed events: %v", err))
+	}
+
+	resp := &pb.ListFailedEventsResponse{Events: make([]*pb.FailedEvent, len(failed))}
+	for i := range failed {
+		resp.Events[i] = failedEventToProto(&failed[i])
+	}
+	return resp, nil
+}
+
+// GetFailedEvent returns a failed event with its payload and headers
+func (h *NotificationHandler) GetFailedEvent(ctx context.Context, req *pb.GetFailedEventRequest) (*pb.FailedEvent, error) {
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, rpcerror.InvalidField("id", "invalid id format")
+	}
+
+	event, err := h.getFailedEvent(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return failedEventToProto(event), nil
+}
+
+// ReplayFailedEvent handles a failed event again. Its outcome is recorded on
+// the event: a successful replay marks it replayed, and a failing one keeps
+// it pending with the new error, which is also returned.
+func (h *NotificationHandler) ReplayFailedEvent(ctx context.Context, req *pb.ReplayFailedEventRequest) (*pb.FailedEvent, error) {
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, rpcerror.InvalidField("id", "invalid id format")
+	}
+
+	event, err := h.getFailedEvent(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	replayErr := h.replayer.Replay(ctx, event)
+	if err := h.failedEvents.MarkReplayed(ctx, id, replayErr); err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to record replay: %v", err))
+	}
+	if replayErr != nil {
+		return nil, status.Error(codes.FailedPrecondition, fmt.Sprintf("replay failed: %v", replayErr))
+	}
+
+	event, err = h.getFailedEvent(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return failedEventToProto(event), nil
+}
+
+func (h *NotificationHandler) getFailedEvent(ctx context.Context, id uuid.UUID) (*models.FailedEvent, error) {
+	event, err := h.failedEvents.Get(ctx, id)
+	if errors.Is(err, repository.ErrFailedEventNotFound) {
+		return nil, status.Error(codes.NotFound, "failed event not found")
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to get failed event: %v", err))
+	}
+	return event, nil
+}
+
+func failedEventToProto(event *models.FailedEvent) *pb.FailedEvent {
+	pbEvent := &pb.FailedEvent{
+		Id:          event.ID.String(),
+		Subject:     event.Subject,
+		Consumer:    event.Consumer,
+		Payload:     event.Payload,
+		Headers:     make(map[string]string, len(event.Headers)),
+		Error:       event.Error,
+		Attempts:    event.Attempts,
+		FailedAt:    timestamppb.New(event.FailedAt),
+		ReplayError: event.ReplayError,
+	}
+	for key, values := range event.Headers {
+		if len(values) > 0 {
+			pbEvent.Headers[key] = values[0]
+		}
+	}
+	if event.ReplayedAt != nil {
+		pbEvent.ReplayedAt = timestamppb.New(*event.ReplayedAt)
+	}
+	return pbEvent
+}
@@ -2,34 +2,67 @@ package handler
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
+	"notification-service/events"
 	models "notification-service/model"
+	natsClient "notification-service/nats"
 	pb "notification-service/pb"
+	"notification-service/push"
 	"notification-service/repository"
+	"shared/cursor"
 
 	"github.com/google/uuid"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
+	"notification-service/rpcerror"
+	"shared/logging"
 )
 
 type NotificationHandler struct {
 	pb.UnimplementedNotificationServiceServer
-	repo repository.NotificationRepository
+	repo         repository.NotificationRepository
+	webhookRepo  repository.WebhookRepository
+	deviceRepo   repository.DeviceRepository
+	prefRepo     repository.PreferenceRepository
+	presenceRepo repository.PresenceRepository
+	failedEvents repository.FailedEventRepository
+	pusher       *push.Dispatcher
+	natsClient   *natsClient.Client
+	replayer     EventReplayer
 }
 
-func NewNotificationHandler(repo repository.NotificationRepository) *NotificationHandler {
+func NewNotificationHandler(
+	repo repository.NotificationRepository,
+	webhookRepo repository.WebhookRepository,
+	deviceRepo repository.DeviceRepository,
+	prefRepo repository.PreferenceRepository,
+	presenceRepo repository.PresenceRepository,
+	failedEvents repository.FailedEventRepository,
+	pusher *push.Dispatcher,
+	natsClient *natsClient.Client,
+	replayer EventReplayer,
+) *NotificationHandler {
 	return &NotificationHandler{
-		repo: repo,
+		repo:         repo,
+		webhookRepo:  webhookRepo,
+		deviceRepo:   deviceRepo,
+		prefRepo:     prefRepo,
+		presenceRepo: presenceRepo,
+		failedEvents: failedEvents,
+		pusher:       pusher,
+		natsClient:   natsClient,
+		replayer:     replayer,
 	}
 }
 
 func (h *NotificationHandler) GetNotifications(ctx context.Context, req *pb.GetNotificationsRequest) (*pb.NotificationConnection, error) {
 	userID, err := uuid.Parse(req.UserId)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+		return nil, rpcerror.InvalidField("user_id", "invalid user_id")
 	}
 
 	first := req.First
@@ -42,27 +75,47 @@ func (h *NotificationHandler) GetNotifications(ctx context.Context, req *pb.GetN
 
 	connection, err := h.repo.GetByUserID(ctx, userID, int(first), req.After)
 	if err != nil {
+		if errors.Is(err, cursor.ErrInvalid) {
+			return nil, rpcerror.InvalidField("after", "invalid cursor")
+		}
 		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to get notifications: %v", err))
 	}
 
 	return modelConnectionToProto(connection), nil
 }
 
+// GetUnreadCount counts the caller's unread notifications. The count is
+// cached, and the cache is dropped whenever the user's notifications change.
+func (h *NotificationHandler) GetUnreadCount(ctx context.Context, req *pb.GetUnreadCountRequest) (*pb.UnreadCount, error) {
+	userID, err := ownerID(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := h.repo.GetUnreadCount(ctx, userID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to count unread notifications: %v", err))
+	}
+
+	return &pb.UnreadCount{UserId: userID.String(), Count: count}, nil
+}
+
 func (h *NotificationHandler) MarkRead(ctx context.Context, req *pb.MarkReadRequest) (*pb.Response, error) {
 	notificationID, err := uuid.Parse(req.NotificationId)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid notification_id")
+		return nil, rpcerror.InvalidField("notification_id", "invalid notification_id")
 	}
 
 	userID, err := uuid.Parse(req.UserId)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+		return nil, rpcerror.InvalidField("user_id", "invalid user_id")
 	}
 
 	err = h.repo.MarkAsRead(ctx, notificationID, userID)
 	if err != nil {
 		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to mark notification as read: %v", err))
 	}
+	h.publishReadState(ctx, userID, &notificationID)
 
 	return &pb.Response{
 		Success: true,
@@ -73,13 +126,14 @@ func (h *NotificationHandler) MarkRead(ctx context.Context, req *pb.MarkReadRequ
 func (h *NotificationHandler) MarkAllRead(ctx context.Context, req *pb.MarkAllReadRequest) (*pb.Response, error) {
 	userID, err := uuid.Parse(req.UserId)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+		return nil, rpcerror.InvalidField("user_id", "invalid user_id")
 	}
 
 	err = h.repo.MarkAllAsRead(ctx, userID)
 	if err != nil {
 		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to mark all notifications as read: %v", err))
 	}
+	h.publishReadState(ctx, userID, nil)
 
 	return &pb.Response{
 		Success: true,
@@ -87,10 +141,32 @@ func (h *NotificationHandler) MarkAllRead(ctx context.Context, req *pb.MarkAllRe
 	}, nil
 }
 
+// publishReadState tells the user's open clients that notificationID, or all
+// of their notifications when it is nil, were marked read, along with the
+// unread count that leaves. Failures are logged: the notifications are read
+// either way.
+func (h *NotificationHandler) publishReadState(ctx context.Context, userID uuid.UUID, notificationID *uuid.UUID) {
+	unreadCount, err := h.repo.GetUnreadCount(ctx, userID)
+	if err != nil {
+		logging.FromContext(ctx).Error().Err(err).Stringer("user_id", userID).Msg("failed to count unread notifications")
+		return
+	}
+
+	event := events.NotificationReadStateEvent{
+		UserID:         userID,
+		NotificationID: notificationID,
+		UnreadCount:    unreadCount,
+		ReadAt:         time.Now().UTC(),
+	}
+	if err := h.natsClient.Publish(ctx, events.NotificationReadStateSubject(userID), event); err != nil {
+		logging.FromContext(ctx).Error().Err(err).Stringer("user_id", userID).Msg("failed to publish notification read state")
+	}
+}
+
 func (h *NotificationHandler) CreateNotification(ctx context.Context, req *pb.CreateNotificationRequest) (*pb.Notification, error) {
 	userID, err := uuid.Parse(req.UserId)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+		return nil, rpcerror.InvalidField("user_id", "invalid user_id")
 	}
 
 	if req.Type == pb.NotificationType_NOTIFICATION_TYPE_UNSPECIFIED {
@@ -101,7 +177,7 @@ func (h *NotificationHandler) CreateNotification(ctx context.Context, req *pb.Cr
 	if req.ActorId != nil && *req.ActorId != "" {
 		parsed, err := uuid.Parse(*req.ActorId)
 		if err != nil {
-			return nil, status.Error(codes.InvalidArgument, "invalid actor_id")
+			return nil, rpcerror.InvalidField("actor_id", "invalid actor_id")
 		}
 		actorID = &parsed
 	}
@@ -110,7 +186,7 @@ func (h *NotificationHandler) CreateNotification(ctx context.Context, req *pb.Cr
 	if req.RelatedId != nil && *req.RelatedId != "" {
 		parsed, err := uuid.Parse(*req.RelatedId)
 		if err != nil {
-			return nil, status.Error(codes.InvalidArgument, "invalid related_id")
+			return nil, rpcerror.InvalidField("related_id", "invalid related_id")
 		}
 		relatedID = &parsed
 	}
@@ -126,23 +202,36 @@ func (h *NotificationHandler) CreateNotification(ctx context.Context, req *pb.Cr
 		CreatedAt: time.Now().UTC(),
 	}
 
-	err = h.repo.Create(ctx, notification)
-	if err != nil {
+	if _, err := h.repo.Create(ctx, notification); err != nil {
 		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to create notification: %v", err))
 	}
 
+	if err := h.natsClient.Publish(ctx, events.UserNotificationsSubject(notification.UserID), notification); err != nil {
+		logging.FromContext(ctx).Error().Err(err).Stringer("notification_id", notification.ID).Msg("failed to publish notification")
+	}
+
+	// Deliveries outlive the request, so they must not be cancelled with it
+	if err := h.pusher.Push(context.WithoutCancel(ctx), notification); err != nil {
+		logging.FromContext(ctx).Error().Err(err).Stringer("notification_id", notification.ID).Msg("failed to push notification")
+	}
+
 	return modelNotificationToProto(notification), nil
 }
 
+// DeleteNotification deletes one of the caller's notifications
 func (h *NotificationHandler) DeleteNotification(ctx context.Context, req *pb.DeleteNotificationRequest) (*pb.Response, error) {
 	notificationID, err := uuid.Parse(req.NotificationId)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid notification_id")
+		return nil, rpcerror.InvalidField("notification_id", "invalid notification_id")
 	}
 
-	err = h.repo.Delete(ctx, notificationID)
+	userID, err := ownerID(ctx, req.UserId)
 	if err != nil {
-		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to delete notification: %v", err))
+		return nil, err
+	}
+
+	if err := h.repo.Delete(ctx, notificationID, userID); err != nil {
+		return nil, status.Error(codes.NotFound, fmt.Sprintf("failed to delete notification: %v", err))
 	}
 
 	return &pb.Response{
@@ -172,6 +261,18 @@ func modelNotificationToProto(n *models.Notification) *pb.Notification {
 		notification.RelatedId = &relatedID
 	}
 
+	if n.GroupKey != nil {
+		latestActorIDs := make([]string, len(n.LatestActors))
+		for i, id := range n.LatestActors {
+			latestActorIDs[i] = id.String()
+		}
+		notification.Group = &pb.GroupedNotification{
+			GroupKey:       *n.GroupKey,
+			ActorCount:     n.ActorCount,
+			LatestActorIds: latestActorIDs,
+		}
+	}
+
 	return notification
 }
 
@@ -211,6 +312,20 @@ func modelTypeToProto(t models.NotificationType) pb.NotificationType {
 		return pb.NotificationType_POST
 	case models.NotificationTypeComment:
 		return pb.NotificationType_COMMENT
+	case models.NotificationTypeMention:
+		return pb.NotificationType_MENTION
+	case models.NotificationTypeRepost:
+		return pb.NotificationType_REPOST
+	case models.NotificationTypeReply:
+		return pb.NotificationType_REPLY
+	case models.NotificationTypeFollowRequest:
+		return pb.NotificationType_FOLLOW_REQUEST
+	case models.NotificationTypeLike:
+		return pb.NotificationType_LIKE
+	case models.NotificationTypeFollow:
+		return pb.NotificationType_FOLLOW
+	case models.NotificationTypeSecurity:
+		return pb.NotificationType_SECURITY
 	default:
 		return pb.NotificationType_NOTIFICATION_TYPE_UNSPECIFIED
 	}
@@ -222,7 +337,49 @@ func protoTypeToModel(t pb.NotificationType) models.NotificationType {
 		return models.NotificationTypePost
 	case pb.NotificationType_COMMENT:
 		return models.NotificationTypeComment
+	case pb.NotificationType_MENTION:
+		return models.NotificationTypeMention
+	case pb.NotificationType_REPOST:
+		return models.NotificationTypeRepost
+	case pb.NotificationType_REPLY:
+		return models.NotificationTypeReply
+	case pb.NotificationType_FOLLOW_REQUEST:
+		return models.NotificationTypeFollowRequest
+	case pb.NotificationType_LIKE:
+		return models.NotificationTypeLike
+	case pb.NotificationType_FOLLOW:
+		return models.NotificationTypeFollow
+	case pb.NotificationType_SECURITY:
+		return models.NotificationTypeSecurity
 	default:
 		return ""
 	}
 }
+
+// PurgeNotifications applies the retention policy: old read notifications and
+// old webhook delivery logs are deleted. It is run on a schedule by
+// scheduler-service; either cutoff may be omitted to skip that table.
+func (h *NotificationHandler) PurgeNotifications(ctx context.Context, req *pb.PurgeNotificationsRequest) (*pb.PurgeNotificationsResponse, error) {
+	if req.ReadBefore == nil && req.DeliveriesBefore == nil {
+		return nil, status.Error(codes.InvalidArgument, "read_before or deliveries_before is required")
+	}
+
+	resp := &pb.PurgeNotificationsResponse{}
+	if req.ReadBefore != nil {
+		deleted, err := h.repo.DeleteReadBefore(ctx, req.ReadBefore.AsTime())
+		if err != nil {
+			return nil, status.Error(codes.Internal, fmt.Sprintf("failed to purge notifications: %v", err))
+		}
+		resp.NotificationsDeleted = deleted
+	}
+
+	if req.DeliveriesBefore != nil {
+		deleted, err := h.webhookRepo.DeleteDeliveriesBefore(ctx, req.DeliveriesBefore.AsTime())
+		if err != nil {
+			return nil, status.Error(codes.Internal, fmt.Sprintf("failed to purge webhook deliveries: %v", err))
+		}
+		resp.DeliveriesDeleted = deleted
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	models "notification-service/model"
+	pb "notification-service/pb"
+	"notification-service/rpcerror"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (h *NotificationHandler) GetPreferences(ctx context.Context, req *pb.GetPreferencesRequest) (*pb.NotificationPreferences, error) {
+	userID, err := ownerID(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	preferences, err := h.prefRepo.Get(ctx, userID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to get preferences: %v", err))
+	}
+
+	return modelPreferencesToProto(preferences), nil
+}
+
+func (h *NotificationHandler) UpdatePreferences(ctx context.Context, req *pb.UpdatePreferencesRequest) (*pb.NotificationPreferences, error) {
+	userID, err := ownerID(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	preferences := models.DefaultNotificationPreferences(userID)
+
+	for _, t := range req.DisabledTypes {
+		if t == pb.NotificationType_NOTIFICATION_TYPE_UNSPECIFIED || t == pb.NotificationType_POST || t == pb.NotificationType_SECURITY {
+			return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("%s notifications cannot be disabled", t))
+		}
+		if !preferences.Disables(protoTypeToModel(t)) {
+			preferences.DisabledTypes = append(preferences.DisabledTypes, string(protoTypeToModel(t)))
+		}
+	}
+
+	if q := req.QuietHours; q != nil {
+		start, err := parseClock(q.Start)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "quiet hours start must be HH:MM")
+		}
+		end, err := parseClock(q.End)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "quiet hours end must be HH:MM")
+		}
+		if start == end {
+			return nil, status.Error(codes.InvalidArgument, "quiet hours must not start and end at the same time")
+		}
+
+		if q.TimeZone != "" {
+			if _, err := time.LoadLocation(q.TimeZone); err != nil {
+				return nil, rpcerror.InvalidField("time_zone", "invalid time_zone")
+			}
+			preferences.TimeZone = q.TimeZone
+		}
+		preferences.QuietHoursStart = &start
+		preferences.QuietHoursEnd = &end
+	}
+
+	if err := h.prefRepo.Update(ctx, preferences); err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to update preferences: %v", err))
+	}
+
+	return modelPreferencesToProto(preferences), nil
+}
+
+// parseClock turns an HH:MM time of day into minutes after midnight
+func parseClock(s string) (int32, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, err
+	}
+	return int32(t.Hour()*60 + t.Minute()), nil
+}
+
+func formatClock(minutes int32) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
+
+func modelPreferencesToProto(p *models.NotificationPreferences) *pb.NotificationPreferences {
+	preferences := &pb.NotificationPreferences{
+		UserId:        p.UserID.String(),
+		DisabledTypes: make([]pb.NotificationType, len(p.DisabledTypes)),
+	}
+
+	for i, t := range p.DisabledTypes {
+		preferences.DisabledTypes[i] = modelTypeToProto(models.NotificationType(t))
+	}
+
+	if p.QuietHoursStart != nil && p.QuietHoursEnd != nil {
+		preferences.QuietHours = &pb.QuietHours{
+			Start:    formatClock(*p.QuietHoursStart),
+			End:      formatClock(*p.QuietHoursEnd),
+			TimeZone: p.TimeZone,
+		}
+	}
+
+	if !p.UpdatedAt.IsZero() {
+		preferences.UpdatedAt = timestamppb.New(p.UpdatedAt)
+	}
+
+	return preferences
+}
@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "notification-service/pb"
+	"notification-service/rpcerror"
+)
+
+// maxPresenceUserIDs bounds the users looked up in one GetPresence call
+const maxPresenceUserIDs = 100
+
+// GetPresence reports whether each user is connected to the gateway and when
+// they were last seen
+func (h *NotificationHandler) GetPresence(ctx context.Context, req *pb.GetPresenceRequest) (*pb.GetPresenceResponse, error) {
+	if len(req.UserIds) > maxPresenceUserIDs {
+		return nil, rpcerror.InvalidField("user_ids", fmt.Sprintf("at most %d user_ids may be looked up at once", maxPresenceUserIDs))
+	}
+
+	userIDs := make([]uuid.UUID, len(req.UserIds))
+	for i, raw := range req.UserIds {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			return nil, rpcerror.InvalidField("user_ids", "invalid user_id")
+		}
+		userIDs[i] = id
+	}
+
+	presences, err := h.presenceRepo.Get(ctx, userIDs)
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to get presence: %v", err))
+	}
+
+	resp := &pb.GetPresenceResponse{Presences: make([]*pb.Presence, len(presences))}
+	for i, p := range presences {
+		resp.Presences[i] = &pb.Presence{
+			UserId: p.UserID.String(),
+			Online: p.Online,
+		}
+		if p.LastSeenAt != nil {
+			resp.Presences[i].LastSeenAt = timestamppb.New(*p.LastSeenAt)
+		}
+	}
+	return resp, nil
+}
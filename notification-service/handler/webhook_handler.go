@@ -0,0 +1,194 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"net/url"
+	"slices"
+	"time"
+
+	"notification-service/interceptor"
+	models "notification-service/model"
+	pb "notification-service/pb"
+	"notification-service/rpcerror"
+	"notification-service/webhook"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const maxWebhooksPerUser = 10
+
+func (h *NotificationHandler) RegisterWebhook(ctx context.Context, req *pb.RegisterWebhookRequest) (*pb.Webhook, error) {
+	userID, err := ownerID(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint, err := url.Parse(req.Url)
+	if err != nil || endpoint.Scheme != "https" || endpoint.Hostname() == "" {
+		return nil, rpcerror.InvalidField("url", "url must be an absolute https URL")
+	}
+	// Names are checked when delivering, once resolved
+	if addr, err := netip.ParseAddr(endpoint.Hostname()); err == nil && !webhook.PublicAddr(addr) {
+		return nil, rpcerror.InvalidField("url", "url must not point at a private or local address")
+	}
+
+	if len(req.EventTypes) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "at least one event type is required")
+	}
+	for _, eventType := range req.EventTypes {
+		if !slices.Contains(models.WebhookEventTypes, eventType) {
+			return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("unsupported event type: %s", eventType))
+		}
+	}
+
+	existing, err := h.webhookRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to list webhooks: %v", err))
+	}
+	if len(existing) >= maxWebhooksPerUser {
+		return nil, status.Error(codes.ResourceExhausted, fmt.Sprintf("a user can register at most %d webhooks", maxWebhooksPerUser))
+	}
+
+	secret, err := webhook.GenerateSecret()
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to generate webhook secret")
+	}
+
+	now := time.Now().UTC()
+	wh := &models.Webhook{
+		ID:         uuid.New(),
+		UserID:     userID,
+		URL:        endpoint.String(),
+		Secret:     secret,
+		EventTypes: slices.Compact(slices.Sorted(slices.Values(req.EventTypes))),
+		IsActive:   true,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+
+	if err := h.webhookRepo.Create(ctx, wh); err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to register webhook: %v", err))
+	}
+
+	pbWebhook := modelWebhookToProto(wh)
+	pbWebhook.Secret = &secret
+	return pbWebhook, nil
+}
+
+func (h *NotificationHandler) ListWebhooks(ctx context.Context, req *pb.ListWebhooksRequest) (*pb.ListWebhooksResponse, error) {
+	userID, err := ownerID(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	webhooks, err := h.webhookRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to list webhooks: %v", err))
+	}
+
+	pbWebhooks := make([]*pb.Webhook, len(webhooks))
+	for i := range webhooks {
+		pbWebhooks[i] = modelWebhookToProto(&webhooks[i])
+	}
+
+	return &pb.ListWebhooksResponse{Webhooks: pbWebhooks}, nil
+}
+
+func (h *NotificationHandler) DeleteWebhook(ctx context.Context, req *pb.DeleteWebhookRequest) (*pb.Response, error) {
+	webhookID, err := uuid.Parse(req.WebhookId)
+	if err != nil {
+		return nil, rpcerror.InvalidField("webhook_id", "invalid webhook_id")
+	}
+
+	userID, err := ownerID(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.webhookRepo.Delete(ctx, webhookID, userID); err != nil {
+		return nil, status.Error(codes.NotFound, fmt.Sprintf("failed to delete webhook: %v", err))
+	}
+
+	return &pb.Response{
+		Success: true,
+		Message: "Webhook deleted successfully",
+	}, nil
+}
+
+func (h *NotificationHandler) GetWebhookDeliveries(ctx context.Context, req *pb.GetWebhookDeliveriesRequest) (*pb.GetWebhookDeliveriesResponse, error) {
+	webhookID, err := uuid.Parse(req.WebhookId)
+	if err != nil {
+		return nil, rpcerror.InvalidField("webhook_id", "invalid webhook_id")
+	}
+
+	userID, err := ownerID(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	wh, err := h.webhookRepo.GetByID(ctx, webhookID)
+	if err != nil || wh.UserID != userID {
+		return nil, status.Error(codes.NotFound, "webhook not found")
+	}
+
+	first := req.First
+	if first <= 0 {
+		first = 20
+	}
+	if first > 100 {
+		first = 100
+	}
+
+	deliveries, err := h.webhookRepo.ListDeliveries(ctx, webhookID, int(first))
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to get webhook deliveries: %v", err))
+	}
+
+	pbDeliveries := make([]*pb.WebhookDelivery, len(deliveries))
+	for i, d := range deliveries {
+		pbDeliveries[i] = &pb.WebhookDelivery{
+			Id:         d.ID.String(),
+			WebhookId:  d.WebhookID.String(),
+			EventId:    d.EventID.String(),
+			EventType:  d.EventType,
+			Attempt:    d.Attempt,
+			StatusCode: d.StatusCode,
+			Success:    d.Success,
+			Error:      d.Error,
+			DurationMs: d.DurationMs,
+			CreatedAt:  timestamppb.New(d.CreatedAt),
+		}
+	}
+
+	return &pb.GetWebhookDeliveriesResponse{Deliveries: pbDeliveries}, nil
+}
+
+func modelWebhookToProto(w *models.Webhook) *pb.Webhook {
+	return &pb.Webhook{
+		Id:         w.ID.String(),
+		UserId:     w.UserID.String(),
+		Url:        w.URL,
+		EventTypes: w.EventTypes,
+		IsActive:   w.IsActive,
+		CreatedAt:  timestamppb.New(w.CreatedAt),
+	}
+}
+
+// ownerID prefers the authenticated caller from the JWT and falls back
+// to the user_id in the request for internal service-to-service calls
+func ownerID(ctx context.Context, reqUserID string) (uuid.UUID, error) {
+	if id, err := interceptor.GetUserIDFromContext(ctx); err == nil {
+		reqUserID = id
+	}
+
+	userID, err := uuid.Parse(reqUserID)
+	if err != nil {
+		return uuid.Nil, rpcerror.InvalidField("user_id", "invalid user_id")
+	}
+	return userID, nil
+}
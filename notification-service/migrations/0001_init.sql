@@ -0,0 +1,226 @@
+-- ========================================
+-- Notification Service Schema (Standalone)
+-- ========================================
+
+-- Enable UUID extension
+CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
+
+-- ========================================
+-- Notification Type ENUM
+-- ========================================
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'notification_type') THEN
+        CREATE TYPE notification_type AS ENUM ('LIKE', 'COMMENT', 'FOLLOW', 'MENTION', 'REPOST', 'REPLY', 'FOLLOW_REQUEST');
+    END IF;
+END
+$$;
+
+-- Databases created before these types existed lack the values
+ALTER TYPE notification_type ADD VALUE IF NOT EXISTS 'MENTION';
+ALTER TYPE notification_type ADD VALUE IF NOT EXISTS 'REPOST';
+ALTER TYPE notification_type ADD VALUE IF NOT EXISTS 'REPLY';
+ALTER TYPE notification_type ADD VALUE IF NOT EXISTS 'FOLLOW_REQUEST';
+
+-- ========================================
+-- Notifications Table
+-- ========================================
+CREATE TABLE IF NOT EXISTS notification_service_notifications (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL,
+    type notification_type NOT NULL,
+    message TEXT NOT NULL,
+    actor_id UUID,
+    related_id UUID,
+    is_read BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    group_key TEXT,
+    actor_count INTEGER NOT NULL DEFAULT 1,
+    latest_actors JSONB NOT NULL DEFAULT '[]'
+);
+
+-- Added after the table was first created
+ALTER TABLE notification_service_notifications ADD COLUMN IF NOT EXISTS group_key TEXT;
+ALTER TABLE notification_service_notifications ADD COLUMN IF NOT EXISTS actor_count INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE notification_service_notifications ADD COLUMN IF NOT EXISTS latest_actors JSONB NOT NULL DEFAULT '[]';
+
+-- At most one unread notification per group; later events are folded into it
+CREATE UNIQUE INDEX IF NOT EXISTS idx_notification_service_notifications_unread_group
+ON notification_service_notifications(user_id, group_key) WHERE group_key IS NOT NULL AND NOT is_read;
+
+-- Events already folded into a grouped notification, so redeliveries are
+-- not counted twice
+CREATE TABLE IF NOT EXISTS notification_service_notification_group_events (
+    event_id UUID PRIMARY KEY,
+    notification_id UUID REFERENCES notification_service_notifications(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_notification_service_notification_group_events_notification_id
+ON notification_service_notification_group_events(notification_id);
+
+-- ========================================
+-- Indexes for Performance
+-- ========================================
+CREATE INDEX IF NOT EXISTS idx_notification_service_notifications_user_id 
+ON notification_service_notifications(user_id);
+
+CREATE INDEX IF NOT EXISTS idx_notification_service_notifications_user_created_at 
+ON notification_service_notifications(user_id, created_at DESC);
+
+CREATE INDEX IF NOT EXISTS idx_notification_service_notifications_user_is_read 
+ON notification_service_notifications(user_id, is_read);
+
+CREATE INDEX IF NOT EXISTS idx_notification_service_notifications_actor_id 
+ON notification_service_notifications(actor_id);
+
+CREATE INDEX IF NOT EXISTS idx_notification_service_notifications_related_id 
+ON notification_service_notifications(related_id);
+
+CREATE INDEX IF NOT EXISTS idx_notification_service_notifications_created_at 
+ON notification_service_notifications(created_at DESC);
+
+CREATE INDEX IF NOT EXISTS idx_notification_service_notifications_user_pagination 
+ON notification_service_notifications(user_id, is_read, created_at DESC, id);
+
+-- Groups a user's notifications about the same post or user, e.g. every like
+-- on one post
+CREATE INDEX IF NOT EXISTS idx_notification_service_notifications_user_type_related
+ON notification_service_notifications(user_id, type, related_id);
+
+-- ========================================
+-- Comments for Documentation
+-- ========================================
+COMMENT ON TABLE notification_service_notifications IS 'Stores user notifications for likes, comments, and follows';
+COMMENT ON COLUMN notification_service_notifications.user_id IS 'The user who receives the notification';
+COMMENT ON COLUMN notification_service_notifications.actor_id IS 'The user who triggered the notification (optional)';
+COMMENT ON COLUMN notification_service_notifications.related_id IS 'Reference to related entity (post, comment, etc.)';
+COMMENT ON COLUMN notification_service_notifications.is_read IS 'Whether the notification has been read by the user';
+
+-- ========================================
+-- Functions
+-- ========================================
+
+-- Get unread notification count for a user
+CREATE OR REPLACE FUNCTION notification_service_get_unread_notification_count(p_user_id UUID)
+RETURNS INTEGER AS $$
+BEGIN
+    RETURN (SELECT COUNT(*)::INTEGER FROM notification_service_notifications WHERE user_id = p_user_id AND is_read = FALSE);
+END;
+$$ LANGUAGE plpgsql STABLE;
+
+-- Mark notifications as read for a user
+CREATE OR REPLACE FUNCTION notification_service_mark_notifications_as_read(p_user_id UUID, p_notification_ids UUID[])
+RETURNS INTEGER AS $$
+DECLARE
+    updated_count INTEGER;
+BEGIN
+    UPDATE notification_service_notifications
+    SET is_read = TRUE
+    WHERE user_id = p_user_id
+    AND id = ANY(p_notification_ids)
+    AND is_read = FALSE;
+
+    GET DIAGNOSTICS updated_count = ROW_COUNT;
+    RETURN updated_count;
+END;
+$$ LANGUAGE plpgsql;
+
+-- ========================================
+-- Webhooks Table
+-- ========================================
+CREATE TABLE IF NOT EXISTS notification_service_webhooks (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL,
+    url TEXT NOT NULL,
+    secret TEXT NOT NULL,
+    event_types TEXT[] NOT NULL,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT notification_service_webhooks_url_not_empty CHECK (url <> ''),
+    CONSTRAINT notification_service_webhooks_event_types_not_empty CHECK (cardinality(event_types) > 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_notification_service_webhooks_user_id
+ON notification_service_webhooks(user_id, created_at DESC);
+
+CREATE INDEX IF NOT EXISTS idx_notification_service_webhooks_event_types
+ON notification_service_webhooks USING GIN (event_types) WHERE is_active;
+
+-- ========================================
+-- Webhook Deliveries Table
+-- ========================================
+CREATE TABLE IF NOT EXISTS notification_service_webhook_deliveries (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    webhook_id UUID NOT NULL REFERENCES notification_service_webhooks(id) ON DELETE CASCADE,
+    event_id UUID NOT NULL,
+    event_type TEXT NOT NULL,
+    attempt INTEGER NOT NULL,
+    status_code INTEGER,
+    success BOOLEAN NOT NULL,
+    error TEXT,
+    duration_ms BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_notification_service_webhook_deliveries_webhook_created_at
+ON notification_service_webhook_deliveries(webhook_id, created_at DESC);
+
+COMMENT ON TABLE notification_service_webhooks IS 'Developer endpoints that receive signed event payloads';
+COMMENT ON COLUMN notification_service_webhooks.secret IS 'HMAC-SHA256 key used to sign delivered payloads';
+COMMENT ON TABLE notification_service_webhook_deliveries IS 'One row per webhook delivery attempt';
+
+-- ========================================
+-- Push Devices Table
+-- ========================================
+CREATE TABLE IF NOT EXISTS notification_service_devices (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL,
+    platform TEXT NOT NULL,
+    token TEXT NOT NULL,
+    p256dh TEXT,
+    auth TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_seen_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT notification_service_devices_platform_valid CHECK (platform IN ('FCM', 'APNS', 'WEBPUSH')),
+    CONSTRAINT notification_service_devices_token_unique UNIQUE (platform, token)
+);
+
+CREATE INDEX IF NOT EXISTS idx_notification_service_devices_user_id
+ON notification_service_devices(user_id, last_seen_at DESC);
+
+-- ========================================
+-- Push Preferences Table
+-- ========================================
+CREATE TABLE IF NOT EXISTS notification_service_push_preferences (
+    user_id UUID NOT NULL,
+    type notification_type NOT NULL,
+    enabled BOOLEAN NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, type)
+);
+
+COMMENT ON TABLE notification_service_devices IS 'Push tokens of the devices notifications are delivered to';
+COMMENT ON COLUMN notification_service_devices.token IS 'FCM registration token, APNs device token or WebPush endpoint URL';
+COMMENT ON TABLE notification_service_push_preferences IS 'Per-type push opt-outs; types without a row are pushed';
+
+-- ========================================
+-- Notification Preferences Table
+-- ========================================
+CREATE TABLE IF NOT EXISTS notification_service_preferences (
+    user_id UUID PRIMARY KEY,
+    disabled_types notification_type[] NOT NULL DEFAULT '{}',
+    quiet_hours_start SMALLINT,
+    quiet_hours_end SMALLINT,
+    time_zone TEXT NOT NULL DEFAULT 'UTC',
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT notification_service_preferences_quiet_hours_valid CHECK (
+        (quiet_hours_start IS NULL) = (quiet_hours_end IS NULL)
+        AND quiet_hours_start BETWEEN 0 AND 1439
+        AND quiet_hours_end BETWEEN 0 AND 1439
+    )
+);
+
+COMMENT ON TABLE notification_service_preferences IS 'Per-user notification settings; users without a row get every type and no quiet hours';
+COMMENT ON COLUMN notification_service_preferences.disabled_types IS 'Notification types that are not recorded for the user';
+COMMENT ON COLUMN notification_service_preferences.quiet_hours_start IS 'Minutes after midnight in time_zone; notifications are not pushed until quiet_hours_end';
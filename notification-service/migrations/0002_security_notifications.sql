@@ -0,0 +1,4 @@
+-- ========================================
+-- Security Notifications
+-- ========================================
+ALTER TYPE notification_type ADD VALUE IF NOT EXISTS 'SECURITY';
@@ -0,0 +1,23 @@
+-- ========================================
+-- Failed Events
+-- ========================================
+-- Events the subscriber gave up on, kept from the dead-letter stream so
+-- admins can inspect and replay them
+CREATE TABLE IF NOT EXISTS notification_service_failed_events (
+    id UUID PRIMARY KEY,
+    -- The consumer and stream sequence of the failed delivery, so a dead
+    -- letter delivered twice is stored once
+    delivery_id TEXT NOT NULL UNIQUE,
+    subject TEXT NOT NULL,
+    consumer TEXT NOT NULL,
+    payload BYTEA NOT NULL,
+    headers JSONB NOT NULL DEFAULT '{}',
+    error TEXT NOT NULL,
+    attempts INTEGER NOT NULL,
+    failed_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    replayed_at TIMESTAMP WITH TIME ZONE,
+    replay_error TEXT
+);
+
+CREATE INDEX IF NOT EXISTS idx_notification_service_failed_events_failed_at
+ON notification_service_failed_events(failed_at DESC);
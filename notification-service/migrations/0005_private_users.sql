@@ -0,0 +1,10 @@
+-- ========================================
+-- Private Users
+-- ========================================
+-- Privacy of accounts, fed from user.updated. Webhooks do not deliver the
+-- posts of private accounts.
+CREATE TABLE IF NOT EXISTS notification_service_private_users (
+    user_id UUID PRIMARY KEY,
+    is_private BOOLEAN NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
@@ -0,0 +1,36 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DevicePlatform is the push service a device token belongs to
+type DevicePlatform string
+
+const (
+	DevicePlatformFCM     DevicePlatform = "FCM"
+	DevicePlatformAPNs    DevicePlatform = "APNS"
+	DevicePlatformWebPush DevicePlatform = "WEBPUSH"
+)
+
+// Device is a registered push target. For WebPush the token is the
+// subscription endpoint and P256dh and Auth hold the subscription's keys.
+type Device struct {
+	ID         uuid.UUID      `json:"id" db:"id"`
+	UserID     uuid.UUID      `json:"user_id" db:"user_id"`
+	Platform   DevicePlatform `json:"platform" db:"platform"`
+	Token      string         `json:"token" db:"token"`
+	P256dh     *string        `json:"p256dh,omitempty" db:"p256dh"`
+	Auth       *string        `json:"auth,omitempty" db:"auth"`
+	CreatedAt  time.Time      `json:"created_at" db:"created_at"`
+	LastSeenAt time.Time      `json:"last_seen_at" db:"last_seen_at"`
+}
+
+// PushPreference turns push delivery of one notification type on or off.
+// Types without a stored preference are pushed.
+type PushPreference struct {
+	Type    NotificationType `json:"type" db:"type"`
+	Enabled bool             `json:"enabled" db:"enabled"`
+}
@@ -0,0 +1,59 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FailedEvent is an event the subscriber gave up on: its last delivery
+// failed, or it could not be decoded at all. It is kept with the error, so it
+// can be inspected and replayed once the cause is fixed.
+type FailedEvent struct {
+	ID uuid.UUID `json:"id" db:"id"`
+	// DeliveryID names the failed delivery by consumer and stream sequence
+	DeliveryID  string       `json:"delivery_id" db:"delivery_id"`
+	Subject     string       `json:"subject" db:"subject"`
+	Consumer    string       `json:"consumer" db:"consumer"`
+	Payload     []byte       `json:"payload" db:"payload"`
+	Headers     EventHeaders `json:"headers" db:"headers"`
+	Error       string       `json:"error" db:"error"`
+	Attempts    int32        `json:"attempts" db:"attempts"`
+	FailedAt    time.Time    `json:"failed_at" db:"failed_at"`
+	ReplayedAt  *time.Time   `json:"replayed_at,omitempty" db:"replayed_at"`
+	ReplayError *string      `json:"replay_error,omitempty" db:"replay_error"`
+}
+
+// EventHeaders are the NATS headers of an event, stored as a JSON object
+type EventHeaders map[string][]string
+
+// Value implements driver.Valuer. The JSON is passed as text so that
+// Postgres parses it rather than receiving it as bytea.
+func (h EventHeaders) Value() (driver.Value, error) {
+	if h == nil {
+		return "{}", nil
+	}
+	data, err := json.Marshal(map[string][]string(h))
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// Scan implements sql.Scanner
+func (h *EventHeaders) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*h = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(v, (*map[string][]string)(h))
+	case string:
+		return json.Unmarshal([]byte(v), (*map[string][]string)(h))
+	default:
+		return errors.New("unsupported type for EventHeaders")
+	}
+}
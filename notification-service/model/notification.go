@@ -1,6 +1,9 @@
 package models
 
 import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
@@ -9,8 +12,15 @@ import (
 type NotificationType string
 
 const (
-	NotificationTypeComment NotificationType = "COMMENT"
-	NotificationTypePost    NotificationType = "POST"
+	NotificationTypeComment       NotificationType = "COMMENT"
+	NotificationTypePost          NotificationType = "POST"
+	NotificationTypeMention       NotificationType = "MENTION"
+	NotificationTypeRepost        NotificationType = "REPOST"
+	NotificationTypeReply         NotificationType = "REPLY"
+	NotificationTypeFollowRequest NotificationType = "FOLLOW_REQUEST"
+	NotificationTypeLike          NotificationType = "LIKE"
+	NotificationTypeFollow        NotificationType = "FOLLOW"
+	NotificationTypeSecurity      NotificationType = "SECURITY"
 )
 
 type Notification struct {
@@ -22,6 +32,54 @@ type Notification struct {
 	RelatedID *uuid.UUID       `json:"related_id,omitempty" db:"related_id"`
 	IsRead    bool             `json:"is_read" db:"is_read"`
 	CreatedAt time.Time        `json:"created_at" db:"created_at"`
+
+	// GroupKey is set on notifications that are folded into one per
+	// recipient while unread, e.g. every like on a post. ActorCount and
+	// LatestActors then describe the whole group.
+	GroupKey     *string  `json:"group_key,omitempty" db:"group_key"`
+	ActorCount   int32    `json:"actor_count" db:"actor_count"`
+	LatestActors ActorIDs `json:"latest_actors" db:"latest_actors"`
+}
+
+// MaxLatestActors is how many of a group's most recent actors are kept
+const MaxLatestActors = 3
+
+// ActorIDs is a list of users stored as a JSON array
+type ActorIDs []uuid.UUID
+
+// Value implements driver.Valuer. The JSON is passed as text so that
+// Postgres parses it rather than receiving it as bytea.
+func (a ActorIDs) Value() (driver.Value, error) {
+	if a == nil {
+		return "[]", nil
+	}
+	data, err := json.Marshal([]uuid.UUID(a))
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// Scan implements sql.Scanner
+func (a *ActorIDs) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*a = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(v, (*[]uuid.UUID)(a))
+	case string:
+		return json.Unmarshal([]byte(v), (*[]uuid.UUID)(a))
+	default:
+		return errors.New("unsupported type for ActorIDs")
+	}
+}
+
+// GroupKey returns the key grouping notifications of kind about subject, the
+// post or user they concern
+func GroupKey(kind NotificationType, subject uuid.UUID) *string {
+	key := string(kind) + ":" + subject.String()
+	return &key
 }
 
 type NotificationEdge struct {
@@ -42,3 +100,12 @@ type NotificationConnection struct {
 	TotalCount  int32              `json:"total_count"`
 	UnreadCount int32              `json:"unread_count"`
 }
+
+// notificationNamespace seeds the name-based IDs of event-driven notifications
+var notificationNamespace = uuid.MustParse("4f1c2a7e-9b3d-4c8e-a5f6-0d2e7b9c1a34")
+
+// EventNotificationID derives the ID of the notification created for an
+// event, so that redelivered or replayed events map onto the same row
+func EventNotificationID(kind NotificationType, eventKey uuid.UUID) uuid.UUID {
+	return uuid.NewSHA1(notificationNamespace, []byte(string(kind)+":"+eventKey.String()))
+}
@@ -0,0 +1,57 @@
+package models
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// NotificationPreferences are a user's settings for which notifications they
+// receive. Quiet hours are minutes after midnight in TimeZone; while they
+// last, notifications are still recorded but not pushed. A window whose end
+// is before its start runs over midnight.
+type NotificationPreferences struct {
+	UserID          uuid.UUID      `json:"user_id" db:"user_id"`
+	DisabledTypes   pq.StringArray `json:"disabled_types" db:"disabled_types"`
+	QuietHoursStart *int32         `json:"quiet_hours_start,omitempty" db:"quiet_hours_start"`
+	QuietHoursEnd   *int32         `json:"quiet_hours_end,omitempty" db:"quiet_hours_end"`
+	TimeZone        string         `json:"time_zone" db:"time_zone"`
+	UpdatedAt       time.Time      `json:"updated_at" db:"updated_at"`
+}
+
+// DefaultNotificationPreferences are the settings of a user who has not
+// changed any: every type is enabled and there are no quiet hours
+func DefaultNotificationPreferences(userID uuid.UUID) *NotificationPreferences {
+	return &NotificationPreferences{
+		UserID:        userID,
+		DisabledTypes: pq.StringArray{},
+		TimeZone:      "UTC",
+	}
+}
+
+// Disables reports whether the user turned notifications of type t off
+func (p *NotificationPreferences) Disables(t NotificationType) bool {
+	return slices.Contains(p.DisabledTypes, string(t))
+}
+
+// InQuietHours reports whether t falls in the user's quiet hours
+func (p *NotificationPreferences) InQuietHours(t time.Time) bool {
+	if p.QuietHoursStart == nil || p.QuietHoursEnd == nil {
+		return false
+	}
+
+	loc, err := time.LoadLocation(p.TimeZone)
+	if err != nil {
+		loc = time.UTC
+	}
+	local := t.In(loc)
+	minute := int32(local.Hour()*60 + local.Minute())
+
+	start, end := *p.QuietHoursStart, *p.QuietHoursEnd
+	if start <= end {
+		return minute >= start && minute < end
+	}
+	return minute >= start || minute < end
+}
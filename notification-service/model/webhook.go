@@ -0,0 +1,84 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// Webhook event types a developer endpoint can subscribe to
+const (
+	WebhookEventPostCreated    = "post.created"
+	WebhookEventCommentAdded   = "comment.added"
+	WebhookEventUserFollowed   = "user.followed"
+	WebhookEventUserUnfollowed = "user.unfollowed"
+)
+
+var WebhookEventTypes = []string{
+	WebhookEventPostCreated,
+	WebhookEventCommentAdded,
+	WebhookEventUserFollowed,
+	WebhookEventUserUnfollowed,
+}
+
+type Webhook struct {
+	ID         uuid.UUID      `json:"id" db:"id"`
+	UserID     uuid.UUID      `json:"user_id" db:"user_id"`
+	URL        string         `json:"url" db:"url"`
+	Secret     string         `json:"-" db:"secret"`
+	EventTypes pq.StringArray `json:"event_types" db:"event_types"`
+	IsActive   bool           `json:"is_active" db:"is_active"`
+	CreatedAt  time.Time      `json:"created_at" db:"created_at"`
+	UpdatedAt  time.Time      `json:"updated_at" db:"updated_at"`
+}
+
+type WebhookDelivery struct {
+	ID         uuid.UUID `json:"id" db:"id"`
+	WebhookID  uuid.UUID `json:"webhook_id" db:"webhook_id"`
+	EventID    uuid.UUID `json:"event_id" db:"event_id"`
+	EventType  string    `json:"event_type" db:"event_type"`
+	Attempt    int32     `json:"attempt" db:"attempt"`
+	StatusCode *int32    `json:"status_code,omitempty" db:"status_code"`
+	Success    bool      `json:"success" db:"success"`
+	Error      *string   `json:"error,omitempty" db:"error"`
+	DurationMs int64     `json:"duration_ms" db:"duration_ms"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+}
+
+// WebhookPayload is the signed JSON body POSTed to subscriber endpoints.
+// Data is one of the Webhook*Data types below, depending on Type.
+type WebhookPayload struct {
+	ID        uuid.UUID   `json:"id"`
+	Type      string      `json:"type"`
+	CreatedAt time.Time   `json:"created_at"`
+	Data      interface{} `json:"data"`
+}
+
+// WebhookPostCreatedData is the data of post.created, sent to the webhooks
+// of the post's author
+type WebhookPostCreatedData struct {
+	PostID    uuid.UUID `json:"post_id"`
+	UserID    uuid.UUID `json:"user_id"`
+	Content   string    `json:"content"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// WebhookCommentAddedData is the data of comment.added, sent to the webhooks
+// of the comment's author
+type WebhookCommentAddedData struct {
+	CommentID uuid.UUID `json:"comment_id"`
+	PostID    uuid.UUID `json:"post_id"`
+	UserID    uuid.UUID `json:"user_id"`
+	Content   string    `json:"content"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// WebhookFollowData is the data of user.followed and user.unfollowed, sent
+// to the webhooks of both users. OccurredAt is when the follow was created
+// or deleted.
+type WebhookFollowData struct {
+	FollowerID  uuid.UUID `json:"follower_id"`
+	FollowingID uuid.UUID `json:"following_id"`
+	OccurredAt  time.Time `json:"occurred_at"`
+}
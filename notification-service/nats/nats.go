@@ -1,17 +1,28 @@
 package nats
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"time"
 
 	"github.com/nats-io/nats.go"
+
+	"shared/chaos"
+	"shared/logging"
+	"shared/tracing"
 )
 
+// MaxDeliver is how many times a durable subscription delivers a message
+// that is not acknowledged
+const MaxDeliver = 3
+
 type Client struct {
-	conn *nats.Conn
-	js   nats.JetStreamContext
+	conn  *nats.Conn
+	js    nats.JetStreamContext
+	chaos *chaos.Injector
 }
 
 type Config struct {
@@ -20,6 +31,7 @@ type Config struct {
 	ReconnectWait time.Duration
 	ClusterID     string
 	ClientID      string
+	Chaos         *chaos.Injector
 }
 
 func NewClient(config Config) (*Client, error) {
@@ -55,8 +67,9 @@ func NewClient(config Config) (*Client, error) {
 	log.Printf("Connected to NATS at %s", nc.ConnectedUrl())
 
 	return &Client{
-		conn: nc,
-		js:   js,
+		conn:  nc,
+		js:    js,
+		chaos: config.Chaos,
 	}, nil
 }
 
@@ -66,13 +79,56 @@ func (c *Client) Close() {
 	}
 }
 
-func (c *Client) Publish(subject string, data interface{}) error {
+// Drain stops delivering messages to subscriptions, waits for the handlers
+// still running and for buffered publishes to be sent, and then closes the
+// connection. It closes the connection at once when ctx is done first.
+func (c *Client) Drain(ctx context.Context) error {
+	// Publishes awaiting their acknowledgement are given the chance to get it
+	// before the acknowledgements stop being received
+	select {
+	case <-c.js.PublishAsyncComplete():
+	case <-ctx.Done():
+		c.conn.Close()
+		return ctx.Err()
+	}
+	if err := c.conn.Drain(); err != nil {
+		c.conn.Close()
+		return err
+	}
+	for !c.conn.IsClosed() {
+		select {
+		case <-ctx.Done():
+			c.conn.Close()
+			return ctx.Err()
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+	return nil
+}
+
+// HealthCheck makes a round trip to the NATS server
+func (c *Client) HealthCheck(ctx context.Context) error {
+	return c.conn.FlushWithContext(ctx)
+}
+
+// Publish sends data as JSON on subject, carrying the trace of ctx in its
+// headers
+func (c *Client) Publish(ctx context.Context, subject string, data interface{}) error {
 	payload, err := json.Marshal(data)
 	if err != nil {
 		return fmt.Errorf("failed to marshal event: %w", err)
 	}
 
-	err = c.conn.Publish(subject, payload)
+	if c.chaos.Drop(subject) {
+		return nil
+	}
+
+	msg := &nats.Msg{Subject: subject, Data: payload, Header: nats.Header{}}
+	logging.Inject(ctx, msg.Header)
+	span := tracing.StartPublish(ctx, subject, msg.Header)
+	defer span.End()
+
+	err = c.conn.PublishMsg(msg)
 	if err != nil {
 		return fmt.Errorf("failed to publish event: %w", err)
 	}
@@ -80,13 +136,22 @@ func (c *Client) Publish(subject string, data interface{}) error {
 	return nil
 }
 
-func (c *Client) PublishAsync(subject string, data interface{}) error {
+func (c *Client) PublishAsync(ctx context.Context, subject string, data interface{}) error {
 	payload, err := json.Marshal(data)
 	if err != nil {
 		return fmt.Errorf("failed to marshal event: %w", err)
 	}
 
-	_, err = c.js.PublishAsync(subject, payload)
+	if c.chaos.Drop(subject) {
+		return nil
+	}
+
+	msg := &nats.Msg{Subject: subject, Data: payload, Header: nats.Header{}}
+	logging.Inject(ctx, msg.Header)
+	span := tracing.StartPublish(ctx, subject, msg.Header)
+	defer span.End()
+
+	_, err = c.js.PublishMsgAsync(msg)
 	if err != nil {
 		return fmt.Errorf("failed to publish async event: %w", err)
 	}
@@ -94,8 +159,16 @@ func (c *Client) PublishAsync(subject string, data interface{}) error {
 	return nil
 }
 
+// PublishDurable sends msg to JetStream and waits until a stream has stored
+// it. msgID lets the stream discard a copy sent again after a lost
+// acknowledgement.
+func (c *Client) PublishDurable(ctx context.Context, msg *nats.Msg, msgID string) error {
+	_, err := c.js.PublishMsg(msg, nats.MsgId(msgID), nats.Context(ctx))
+	return err
+}
+
 func (c *Client) Subscribe(subject string, handler nats.MsgHandler) (*nats.Subscription, error) {
-	sub, err := c.conn.Subscribe(subject, handler)
+	sub, err := c.conn.Subscribe(subject, c.withChaos(handler))
 	if err != nil {
 		return nil, fmt.Errorf("failed to subscribe to %s: %w", subject, err)
 	}
@@ -105,7 +178,7 @@ func (c *Client) Subscribe(subject string, handler nats.MsgHandler) (*nats.Subsc
 }
 
 func (c *Client) QueueSubscribe(subject, queue string, handler nats.MsgHandler) (*nats.Subscription, error) {
-	sub, err := c.conn.QueueSubscribe(subject, queue, handler)
+	sub, err := c.conn.QueueSubscribe(subject, queue, c.withChaos(handler))
 	if err != nil {
 		return nil, fmt.Errorf("failed to queue subscribe to %s: %w", subject, err)
 	}
@@ -119,11 +192,11 @@ func (c *Client) SubscribeDurable(subject, durableName, queueGroup string, handl
 	sub, err := c.js.QueueSubscribe(
 		subject,
 		queueGroup,
-		handler,
+		c.withChaos(handler),
 		nats.Durable(durableName),
 		nats.ManualAck(),
 		nats.AckExplicit(),
-		nats.MaxDeliver(3),
+		nats.MaxDeliver(MaxDeliver),
 		nats.AckWait(30*time.Second),
 	)
 	if err != nil {
@@ -134,23 +207,58 @@ func (c *Client) SubscribeDurable(subject, durableName, queueGroup string, handl
 	return sub, nil
 }
 
-func (c *Client) CreateStream(streamName string, subjects []string) error {
-	_, err := c.js.AddStream(&nats.StreamConfig{
+// EnsureStream creates a stream retaining messages on subjects for maxAge,
+// or updates an existing stream of that name to match
+func (c *Client) EnsureStream(streamName string, subjects []string, maxAge time.Duration) error {
+	cfg := &nats.StreamConfig{
 		Name:      streamName,
 		Subjects:  subjects,
 		Storage:   nats.FileStorage,
-		MaxAge:    24 * time.Hour * 7,
-		Retention: nats.WorkQueuePolicy,
-	})
+		MaxAge:    maxAge,
+		Retention: nats.LimitsPolicy,
+	}
+
+	_, err := c.js.AddStream(cfg)
+	if errors.Is(err, nats.ErrStreamNameAlreadyInUse) {
+		_, err = c.js.UpdateStream(cfg)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to ensure stream %s: %w", streamName, err)
+	}
 
+	log.Printf("Stream ready: %s (retention %s)", streamName, maxAge)
+	return nil
+}
+
+// DeleteStream removes a stream and its consumers if it exists
+func (c *Client) DeleteStream(streamName string) error {
+	err := c.js.DeleteStream(streamName)
+	if errors.Is(err, nats.ErrStreamNotFound) {
+		return nil
+	}
 	if err != nil {
-		return fmt.Errorf("failed to create stream %s: %w", streamName, err)
+		return fmt.Errorf("failed to delete stream %s: %w", streamName, err)
 	}
 
-	log.Printf("Stream created: %s", streamName)
+	log.Printf("Stream deleted: %s", streamName)
 	return nil
 }
 
+// withChaos discards messages selected by chaos injection before they reach
+// handler. Durable subscriptions never ack a dropped message, so JetStream
+// redelivers it after AckWait like any other lost delivery.
+func (c *Client) withChaos(handler nats.MsgHandler) nats.MsgHandler {
+	if c.chaos == nil {
+		return handler
+	}
+	return func(msg *nats.Msg) {
+		if c.chaos.Drop(msg.Subject) {
+			return
+		}
+		handler(msg)
+	}
+}
+
 func DecodeEvent(msg *nats.Msg, v interface{}) error {
 	return json.Unmarshal(msg.Data, v)
 }
@@ -28,6 +28,15 @@ const (
 	NotificationType_NOTIFICATION_TYPE_UNSPECIFIED NotificationType = 0
 	NotificationType_POST                          NotificationType = 1
 	NotificationType_COMMENT                       NotificationType = 2
+	NotificationType_MENTION                       NotificationType = 3
+	NotificationType_REPOST                        NotificationType = 4
+	NotificationType_REPLY                         NotificationType = 5
+	NotificationType_FOLLOW_REQUEST                NotificationType = 6
+	NotificationType_LIKE                          NotificationType = 7
+	NotificationType_FOLLOW                        NotificationType = 8
+	// SECURITY warns about activity on the account, such as a stolen session.
+	// It cannot be disabled.
+	NotificationType_SECURITY NotificationType = 9
 )
 
 // Enum value maps for NotificationType.
@@ -36,11 +45,25 @@ var (
 		0: "NOTIFICATION_TYPE_UNSPECIFIED",
 		1: "POST",
 		2: "COMMENT",
+		3: "MENTION",
+		4: "REPOST",
+		5: "REPLY",
+		6: "FOLLOW_REQUEST",
+		7: "LIKE",
+		8: "FOLLOW",
+		9: "SECURITY",
 	}
 	NotificationType_value = map[string]int32{
 		"NOTIFICATION_TYPE_UNSPECIFIED": 0,
 		"POST":                          1,
 		"COMMENT":                       2,
+		"MENTION":                       3,
+		"REPOST":                        4,
+		"REPLY":                         5,
+		"FOLLOW_REQUEST":                6,
+		"LIKE":                          7,
+		"FOLLOW":                        8,
+		"SECURITY":                      9,
 	}
 )
 
@@ -71,6 +94,58 @@ func (NotificationType) EnumDescriptor() ([]byte, []int) {
 	return file_proto_notification_proto_rawDescGZIP(), []int{0}
 }
 
+type DevicePlatform int32
+
+const (
+	DevicePlatform_DEVICE_PLATFORM_UNSPECIFIED DevicePlatform = 0
+	DevicePlatform_FCM                         DevicePlatform = 1
+	DevicePlatform_APNS                        DevicePlatform = 2
+	DevicePlatform_WEBPUSH                     DevicePlatform = 3
+)
+
+// Enum value maps for DevicePlatform.
+var (
+	DevicePlatform_name = map[int32]string{
+		0: "DEVICE_PLATFORM_UNSPECIFIED",
+		1: "FCM",
+		2: "APNS",
+		3: "WEBPUSH",
+	}
+	DevicePlatform_value = map[string]int32{
+		"DEVICE_PLATFORM_UNSPECIFIED": 0,
+		"FCM":                         1,
+		"APNS":                        2,
+		"WEBPUSH":                     3,
+	}
+)
+
+func (x DevicePlatform) Enum() *DevicePlatform {
+	p := new(DevicePlatform)
+	*p = x
+	return p
+}
+
+func (x DevicePlatform) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DevicePlatform) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_notification_proto_enumTypes[1].Descriptor()
+}
+
+func (DevicePlatform) Type() protoreflect.EnumType {
+	return &file_proto_notification_proto_enumTypes[1]
+}
+
+func (x DevicePlatform) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DevicePlatform.Descriptor instead.
+func (DevicePlatform) EnumDescriptor() ([]byte, []int) {
+	return file_proto_notification_proto_rawDescGZIP(), []int{1}
+}
+
 type GetNotificationsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
@@ -131,6 +206,102 @@ func (x *GetNotificationsRequest) GetAfter() string {
 	return ""
 }
 
+type GetUnreadCountRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUnreadCountRequest) Reset() {
+	*x = GetUnreadCountRequest{}
+	mi := &file_proto_notification_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUnreadCountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUnreadCountRequest) ProtoMessage() {}
+
+func (x *GetUnreadCountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_notification_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUnreadCountRequest.ProtoReflect.Descriptor instead.
+func (*GetUnreadCountRequest) Descriptor() ([]byte, []int) {
+	return file_proto_notification_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetUnreadCountRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type UnreadCount struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Count         int32                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnreadCount) Reset() {
+	*x = UnreadCount{}
+	mi := &file_proto_notification_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnreadCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnreadCount) ProtoMessage() {}
+
+func (x *UnreadCount) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_notification_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnreadCount.ProtoReflect.Descriptor instead.
+func (*UnreadCount) Descriptor() ([]byte, []int) {
+	return file_proto_notification_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *UnreadCount) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UnreadCount) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
 type MarkReadRequest struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
 	NotificationId string                 `protobuf:"bytes,1,opt,name=notification_id,json=notificationId,proto3" json:"notification_id,omitempty"`
@@ -141,7 +312,7 @@ type MarkReadRequest struct {
 
 func (x *MarkReadRequest) Reset() {
 	*x = MarkReadRequest{}
-	mi := &file_proto_notification_proto_msgTypes[1]
+	mi := &file_proto_notification_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -153,7 +324,7 @@ func (x *MarkReadRequest) String() string {
 func (*MarkReadRequest) ProtoMessage() {}
 
 func (x *MarkReadRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_notification_proto_msgTypes[1]
+	mi := &file_proto_notification_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -166,7 +337,7 @@ func (x *MarkReadRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MarkReadRequest.ProtoReflect.Descriptor instead.
 func (*MarkReadRequest) Descriptor() ([]byte, []int) {
-	return file_proto_notification_proto_rawDescGZIP(), []int{1}
+	return file_proto_notification_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *MarkReadRequest) GetNotificationId() string {
@@ -192,7 +363,7 @@ type MarkAllReadRequest struct {
 
 func (x *MarkAllReadRequest) Reset() {
 	*x = MarkAllReadRequest{}
-	mi := &file_proto_notification_proto_msgTypes[2]
+	mi := &file_proto_notification_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -204,7 +375,7 @@ func (x *MarkAllReadRequest) String() string {
 func (*MarkAllReadRequest) ProtoMessage() {}
 
 func (x *MarkAllReadRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_notification_proto_msgTypes[2]
+	mi := &file_proto_notification_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -217,7 +388,7 @@ func (x *MarkAllReadRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MarkAllReadRequest.ProtoReflect.Descriptor instead.
 func (*MarkAllReadRequest) Descriptor() ([]byte, []int) {
-	return file_proto_notification_proto_rawDescGZIP(), []int{2}
+	return file_proto_notification_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *MarkAllReadRequest) GetUserId() string {
@@ -240,7 +411,7 @@ type CreateNotificationRequest struct {
 
 func (x *CreateNotificationRequest) Reset() {
 	*x = CreateNotificationRequest{}
-	mi := &file_proto_notification_proto_msgTypes[3]
+	mi := &file_proto_notification_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -252,7 +423,7 @@ func (x *CreateNotificationRequest) String() string {
 func (*CreateNotificationRequest) ProtoMessage() {}
 
 func (x *CreateNotificationRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_notification_proto_msgTypes[3]
+	mi := &file_proto_notification_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -265,7 +436,7 @@ func (x *CreateNotificationRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateNotificationRequest.ProtoReflect.Descriptor instead.
 func (*CreateNotificationRequest) Descriptor() ([]byte, []int) {
-	return file_proto_notification_proto_rawDescGZIP(), []int{3}
+	return file_proto_notification_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *CreateNotificationRequest) GetUserId() string {
@@ -306,13 +477,14 @@ func (x *CreateNotificationRequest) GetRelatedId() string {
 type DeleteNotificationRequest struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
 	NotificationId string                 `protobuf:"bytes,1,opt,name=notification_id,json=notificationId,proto3" json:"notification_id,omitempty"`
+	UserId         string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // The caller's, when called with a token
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
 }
 
 func (x *DeleteNotificationRequest) Reset() {
 	*x = DeleteNotificationRequest{}
-	mi := &file_proto_notification_proto_msgTypes[4]
+	mi := &file_proto_notification_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -324,7 +496,7 @@ func (x *DeleteNotificationRequest) String() string {
 func (*DeleteNotificationRequest) ProtoMessage() {}
 
 func (x *DeleteNotificationRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_notification_proto_msgTypes[4]
+	mi := &file_proto_notification_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -337,7 +509,7 @@ func (x *DeleteNotificationRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteNotificationRequest.ProtoReflect.Descriptor instead.
 func (*DeleteNotificationRequest) Descriptor() ([]byte, []int) {
-	return file_proto_notification_proto_rawDescGZIP(), []int{4}
+	return file_proto_notification_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *DeleteNotificationRequest) GetNotificationId() string {
@@ -347,6 +519,13 @@ func (x *DeleteNotificationRequest) GetNotificationId() string {
 	return ""
 }
 
+func (x *DeleteNotificationRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
 type Notification struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -357,13 +536,14 @@ type Notification struct {
 	RelatedId     *string                `protobuf:"bytes,6,opt,name=related_id,json=relatedId,proto3,oneof" json:"related_id,omitempty"`
 	IsRead        bool                   `protobuf:"varint,7,opt,name=is_read,json=isRead,proto3" json:"is_read,omitempty"`
 	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Group         *GroupedNotification   `protobuf:"bytes,9,opt,name=group,proto3,oneof" json:"group,omitempty"` // Set when events are folded into this notification
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Notification) Reset() {
 	*x = Notification{}
-	mi := &file_proto_notification_proto_msgTypes[5]
+	mi := &file_proto_notification_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -375,7 +555,7 @@ func (x *Notification) String() string {
 func (*Notification) ProtoMessage() {}
 
 func (x *Notification) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_notification_proto_msgTypes[5]
+	mi := &file_proto_notification_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -388,7 +568,7 @@ func (x *Notification) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Notification.ProtoReflect.Descriptor instead.
 func (*Notification) Descriptor() ([]byte, []int) {
-	return file_proto_notification_proto_rawDescGZIP(), []int{5}
+	return file_proto_notification_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *Notification) GetId() string {
@@ -447,6 +627,75 @@ func (x *Notification) GetCreatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *Notification) GetGroup() *GroupedNotification {
+	if x != nil {
+		return x.Group
+	}
+	return nil
+}
+
+// GroupedNotification describes the events folded into one notification,
+// e.g. "X and 12 others liked your post". actor_id is the most recent actor.
+type GroupedNotification struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	GroupKey       string                 `protobuf:"bytes,1,opt,name=group_key,json=groupKey,proto3" json:"group_key,omitempty"`
+	ActorCount     int32                  `protobuf:"varint,2,opt,name=actor_count,json=actorCount,proto3" json:"actor_count,omitempty"`
+	LatestActorIds []string               `protobuf:"bytes,3,rep,name=latest_actor_ids,json=latestActorIds,proto3" json:"latest_actor_ids,omitempty"` // Most recent first
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GroupedNotification) Reset() {
+	*x = GroupedNotification{}
+	mi := &file_proto_notification_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GroupedNotification) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupedNotification) ProtoMessage() {}
+
+func (x *GroupedNotification) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_notification_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupedNotification.ProtoReflect.Descriptor instead.
+func (*GroupedNotification) Descriptor() ([]byte, []int) {
+	return file_proto_notification_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GroupedNotification) GetGroupKey() string {
+	if x != nil {
+		return x.GroupKey
+	}
+	return ""
+}
+
+func (x *GroupedNotification) GetActorCount() int32 {
+	if x != nil {
+		return x.ActorCount
+	}
+	return 0
+}
+
+func (x *GroupedNotification) GetLatestActorIds() []string {
+	if x != nil {
+		return x.LatestActorIds
+	}
+	return nil
+}
+
 type NotificationEdge struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Cursor        string                 `protobuf:"bytes,1,opt,name=cursor,proto3" json:"cursor,omitempty"`
@@ -457,7 +706,7 @@ type NotificationEdge struct {
 
 func (x *NotificationEdge) Reset() {
 	*x = NotificationEdge{}
-	mi := &file_proto_notification_proto_msgTypes[6]
+	mi := &file_proto_notification_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -469,7 +718,7 @@ func (x *NotificationEdge) String() string {
 func (*NotificationEdge) ProtoMessage() {}
 
 func (x *NotificationEdge) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_notification_proto_msgTypes[6]
+	mi := &file_proto_notification_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -482,7 +731,7 @@ func (x *NotificationEdge) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NotificationEdge.ProtoReflect.Descriptor instead.
 func (*NotificationEdge) Descriptor() ([]byte, []int) {
-	return file_proto_notification_proto_rawDescGZIP(), []int{6}
+	return file_proto_notification_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *NotificationEdge) GetCursor() string {
@@ -511,7 +760,7 @@ type PageInfo struct {
 
 func (x *PageInfo) Reset() {
 	*x = PageInfo{}
-	mi := &file_proto_notification_proto_msgTypes[7]
+	mi := &file_proto_notification_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -523,7 +772,7 @@ func (x *PageInfo) String() string {
 func (*PageInfo) ProtoMessage() {}
 
 func (x *PageInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_notification_proto_msgTypes[7]
+	mi := &file_proto_notification_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -536,7 +785,7 @@ func (x *PageInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PageInfo.ProtoReflect.Descriptor instead.
 func (*PageInfo) Descriptor() ([]byte, []int) {
-	return file_proto_notification_proto_rawDescGZIP(), []int{7}
+	return file_proto_notification_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *PageInfo) GetEndCursor() string {
@@ -579,7 +828,7 @@ type NotificationConnection struct {
 
 func (x *NotificationConnection) Reset() {
 	*x = NotificationConnection{}
-	mi := &file_proto_notification_proto_msgTypes[8]
+	mi := &file_proto_notification_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -591,7 +840,7 @@ func (x *NotificationConnection) String() string {
 func (*NotificationConnection) ProtoMessage() {}
 
 func (x *NotificationConnection) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_notification_proto_msgTypes[8]
+	mi := &file_proto_notification_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -604,7 +853,7 @@ func (x *NotificationConnection) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NotificationConnection.ProtoReflect.Descriptor instead.
 func (*NotificationConnection) Descriptor() ([]byte, []int) {
-	return file_proto_notification_proto_rawDescGZIP(), []int{8}
+	return file_proto_notification_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *NotificationConnection) GetEdges() []*NotificationEdge {
@@ -635,29 +884,30 @@ func (x *NotificationConnection) GetUnreadCount() int32 {
 	return 0
 }
 
-type Response struct {
+type RegisterWebhookRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Url           string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	EventTypes    []string               `protobuf:"bytes,3,rep,name=event_types,json=eventTypes,proto3" json:"event_types,omitempty"` // post.created, comment.added, user.followed, user.unfollowed
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Response) Reset() {
-	*x = Response{}
-	mi := &file_proto_notification_proto_msgTypes[9]
+func (x *RegisterWebhookRequest) Reset() {
+	*x = RegisterWebhookRequest{}
+	mi := &file_proto_notification_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Response) String() string {
+func (x *RegisterWebhookRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Response) ProtoMessage() {}
+func (*RegisterWebhookRequest) ProtoMessage() {}
 
-func (x *Response) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_notification_proto_msgTypes[9]
+func (x *RegisterWebhookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_notification_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -668,65 +918,1868 @@ func (x *Response) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Response.ProtoReflect.Descriptor instead.
-func (*Response) Descriptor() ([]byte, []int) {
-	return file_proto_notification_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use RegisterWebhookRequest.ProtoReflect.Descriptor instead.
+func (*RegisterWebhookRequest) Descriptor() ([]byte, []int) {
+	return file_proto_notification_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *Response) GetSuccess() bool {
+func (x *RegisterWebhookRequest) GetUserId() string {
 	if x != nil {
-		return x.Success
+		return x.UserId
 	}
-	return false
+	return ""
 }
 
-func (x *Response) GetMessage() string {
+func (x *RegisterWebhookRequest) GetUrl() string {
 	if x != nil {
-		return x.Message
+		return x.Url
 	}
 	return ""
 }
 
-var File_proto_notification_proto protoreflect.FileDescriptor
+func (x *RegisterWebhookRequest) GetEventTypes() []string {
+	if x != nil {
+		return x.EventTypes
+	}
+	return nil
+}
 
-const file_proto_notification_proto_rawDesc = "" +
-	"\n" +
-	"\x18proto/notification.proto\x12\fnotification\x1a\x1fgoogle/protobuf/timestamp.proto\"m\n" +
-	"\x17GetNotificationsRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x14\n" +
-	"\x05first\x18\x02 \x01(\x05R\x05first\x12\x19\n" +
-	"\x05after\x18\x03 \x01(\tH\x00R\x05after\x88\x01\x01B\b\n" +
-	"\x06_after\"S\n" +
-	"\x0fMarkReadRequest\x12'\n" +
-	"\x0fnotification_id\x18\x01 \x01(\tR\x0enotificationId\x12\x17\n" +
-	"\auser_id\x18\x02 \x01(\tR\x06userId\"-\n" +
-	"\x12MarkAllReadRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\"\xe2\x01\n" +
-	"\x19CreateNotificationRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\x122\n" +
-	"\x04type\x18\x02 \x01(\x0e2\x1e.notification.NotificationTypeR\x04type\x12\x18\n" +
-	"\amessage\x18\x03 \x01(\tR\amessage\x12\x1e\n" +
-	"\bactor_id\x18\x04 \x01(\tH\x00R\aactorId\x88\x01\x01\x12\"\n" +
-	"\n" +
-	"related_id\x18\x05 \x01(\tH\x01R\trelatedId\x88\x01\x01B\v\n" +
-	"\t_actor_idB\r\n" +
-	"\v_related_id\"D\n" +
-	"\x19DeleteNotificationRequest\x12'\n" +
-	"\x0fnotification_id\x18\x01 \x01(\tR\x0enotificationId\"\xb9\x02\n" +
-	"\fNotification\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
-	"\auser_id\x18\x02 \x01(\tR\x06userId\x122\n" +
-	"\x04type\x18\x03 \x01(\x0e2\x1e.notification.NotificationTypeR\x04type\x12\x18\n" +
-	"\amessage\x18\x04 \x01(\tR\amessage\x12\x1e\n" +
-	"\bactor_id\x18\x05 \x01(\tH\x00R\aactorId\x88\x01\x01\x12\"\n" +
-	"\n" +
-	"related_id\x18\x06 \x01(\tH\x01R\trelatedId\x88\x01\x01\x12\x17\n" +
-	"\ais_read\x18\a \x01(\bR\x06isRead\x129\n" +
-	"\n" +
-	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAtB\v\n" +
-	"\t_actor_idB\r\n" +
-	"\v_related_id\"Z\n" +
-	"\x10NotificationEdge\x12\x16\n" +
+type ListWebhooksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWebhooksRequest) Reset() {
+	*x = ListWebhooksRequest{}
+	mi := &file_proto_notification_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWebhooksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWebhooksRequest) ProtoMessage() {}
+
+func (x *ListWebhooksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_notification_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWebhooksRequest.ProtoReflect.Descriptor instead.
+func (*ListWebhooksRequest) Descriptor() ([]byte, []int) {
+	return file_proto_notification_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListWebhooksRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type ListWebhooksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Webhooks      []*Webhook             `protobuf:"bytes,1,rep,name=webhooks,proto3" json:"webhooks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWebhooksResponse) Reset() {
+	*x = ListWebhooksResponse{}
+	mi := &file_proto_notification_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWebhooksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWebhooksResponse) ProtoMessage() {}
+
+func (x *ListWebhooksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_notification_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWebhooksResponse.ProtoReflect.Descriptor instead.
+func (*ListWebhooksResponse) Descriptor() ([]byte, []int) {
+	return file_proto_notification_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListWebhooksResponse) GetWebhooks() []*Webhook {
+	if x != nil {
+		return x.Webhooks
+	}
+	return nil
+}
+
+type DeleteWebhookRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WebhookId     string                 `protobuf:"bytes,1,opt,name=webhook_id,json=webhookId,proto3" json:"webhook_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteWebhookRequest) Reset() {
+	*x = DeleteWebhookRequest{}
+	mi := &file_proto_notification_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteWebhookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteWebhookRequest) ProtoMessage() {}
+
+func (x *DeleteWebhookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_notification_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteWebhookRequest.ProtoReflect.Descriptor instead.
+func (*DeleteWebhookRequest) Descriptor() ([]byte, []int) {
+	return file_proto_notification_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *DeleteWebhookRequest) GetWebhookId() string {
+	if x != nil {
+		return x.WebhookId
+	}
+	return ""
+}
+
+func (x *DeleteWebhookRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type GetWebhookDeliveriesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WebhookId     string                 `protobuf:"bytes,1,opt,name=webhook_id,json=webhookId,proto3" json:"webhook_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	First         int32                  `protobuf:"varint,3,opt,name=first,proto3" json:"first,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWebhookDeliveriesRequest) Reset() {
+	*x = GetWebhookDeliveriesRequest{}
+	mi := &file_proto_notification_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWebhookDeliveriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWebhookDeliveriesRequest) ProtoMessage() {}
+
+func (x *GetWebhookDeliveriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_notification_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWebhookDeliveriesRequest.ProtoReflect.Descriptor instead.
+func (*GetWebhookDeliveriesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_notification_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetWebhookDeliveriesRequest) GetWebhookId() string {
+	if x != nil {
+		return x.WebhookId
+	}
+	return ""
+}
+
+func (x *GetWebhookDeliveriesRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetWebhookDeliveriesRequest) GetFirst() int32 {
+	if x != nil {
+		return x.First
+	}
+	return 0
+}
+
+type GetWebhookDeliveriesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Deliveries    []*WebhookDelivery     `protobuf:"bytes,1,rep,name=deliveries,proto3" json:"deliveries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWebhookDeliveriesResponse) Reset() {
+	*x = GetWebhookDeliveriesResponse{}
+	mi := &file_proto_notification_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWebhookDeliveriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWebhookDeliveriesResponse) ProtoMessage() {}
+
+func (x *GetWebhookDeliveriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_notification_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWebhookDeliveriesResponse.ProtoReflect.Descriptor instead.
+func (*GetWebhookDeliveriesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_notification_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetWebhookDeliveriesResponse) GetDeliveries() []*WebhookDelivery {
+	if x != nil {
+		return x.Deliveries
+	}
+	return nil
+}
+
+type Webhook struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Url           string                 `protobuf:"bytes,3,opt,name=url,proto3" json:"url,omitempty"`
+	EventTypes    []string               `protobuf:"bytes,4,rep,name=event_types,json=eventTypes,proto3" json:"event_types,omitempty"`
+	IsActive      bool                   `protobuf:"varint,5,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	Secret        *string                `protobuf:"bytes,6,opt,name=secret,proto3,oneof" json:"secret,omitempty"` // Only returned once, from RegisterWebhook
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Webhook) Reset() {
+	*x = Webhook{}
+	mi := &file_proto_notification_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Webhook) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Webhook) ProtoMessage() {}
+
+func (x *Webhook) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_notification_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Webhook.ProtoReflect.Descriptor instead.
+func (*Webhook) Descriptor() ([]byte, []int) {
+	return file_proto_notification_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *Webhook) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Webhook) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Webhook) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *Webhook) GetEventTypes() []string {
+	if x != nil {
+		return x.EventTypes
+	}
+	return nil
+}
+
+func (x *Webhook) GetIsActive() bool {
+	if x != nil {
+		return x.IsActive
+	}
+	return false
+}
+
+func (x *Webhook) GetSecret() string {
+	if x != nil && x.Secret != nil {
+		return *x.Secret
+	}
+	return ""
+}
+
+func (x *Webhook) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type WebhookDelivery struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	WebhookId     string                 `protobuf:"bytes,2,opt,name=webhook_id,json=webhookId,proto3" json:"webhook_id,omitempty"`
+	EventId       string                 `protobuf:"bytes,3,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	EventType     string                 `protobuf:"bytes,4,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`
+	Attempt       int32                  `protobuf:"varint,5,opt,name=attempt,proto3" json:"attempt,omitempty"`
+	StatusCode    *int32                 `protobuf:"varint,6,opt,name=status_code,json=statusCode,proto3,oneof" json:"status_code,omitempty"`
+	Success       bool                   `protobuf:"varint,7,opt,name=success,proto3" json:"success,omitempty"`
+	Error         *string                `protobuf:"bytes,8,opt,name=error,proto3,oneof" json:"error,omitempty"`
+	DurationMs    int64                  `protobuf:"varint,9,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WebhookDelivery) Reset() {
+	*x = WebhookDelivery{}
+	mi := &file_proto_notification_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebhookDelivery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebhookDelivery) ProtoMessage() {}
+
+func (x *WebhookDelivery) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_notification_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebhookDelivery.ProtoReflect.Descriptor instead.
+func (*WebhookDelivery) Descriptor() ([]byte, []int) {
+	return file_proto_notification_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *WebhookDelivery) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetWebhookId() string {
+	if x != nil {
+		return x.WebhookId
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetEventType() string {
+	if x != nil {
+		return x.EventType
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetAttempt() int32 {
+	if x != nil {
+		return x.Attempt
+	}
+	return 0
+}
+
+func (x *WebhookDelivery) GetStatusCode() int32 {
+	if x != nil && x.StatusCode != nil {
+		return *x.StatusCode
+	}
+	return 0
+}
+
+func (x *WebhookDelivery) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *WebhookDelivery) GetError() string {
+	if x != nil && x.Error != nil {
+		return *x.Error
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+func (x *WebhookDelivery) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type Response struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Response) Reset() {
+	*x = Response{}
+	mi := &file_proto_notification_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Response) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Response) ProtoMessage() {}
+
+func (x *Response) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_notification_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Response.ProtoReflect.Descriptor instead.
+func (*Response) Descriptor() ([]byte, []int) {
+	return file_proto_notification_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *Response) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *Response) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// ExportMyData exports the data the service holds on the caller
+type ExportMyDataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportMyDataRequest) Reset() {
+	*x = ExportMyDataRequest{}
+	mi := &file_proto_notification_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportMyDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportMyDataRequest) ProtoMessage() {}
+
+func (x *ExportMyDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_notification_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportMyDataRequest.ProtoReflect.Descriptor instead.
+func (*ExportMyDataRequest) Descriptor() ([]byte, []int) {
+	return file_proto_notification_proto_rawDescGZIP(), []int{21}
+}
+
+type DataExport struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"` // JSON document
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DataExport) Reset() {
+	*x = DataExport{}
+	mi := &file_proto_notification_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DataExport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DataExport) ProtoMessage() {}
+
+func (x *DataExport) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_notification_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DataExport.ProtoReflect.Descriptor instead.
+func (*DataExport) Descriptor() ([]byte, []int) {
+	return file_proto_notification_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *DataExport) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type PurgeNotificationsRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	ReadBefore       *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=read_before,json=readBefore,proto3,oneof" json:"read_before,omitempty"`                   // Delete read notifications created before this
+	DeliveriesBefore *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=deliveries_before,json=deliveriesBefore,proto3,oneof" json:"deliveries_before,omitempty"` // Delete webhook deliveries created before this
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *PurgeNotificationsRequest) Reset() {
+	*x = PurgeNotificationsRequest{}
+	mi := &file_proto_notification_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurgeNotificationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgeNotificationsRequest) ProtoMessage() {}
+
+func (x *PurgeNotificationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_notification_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgeNotificationsRequest.ProtoReflect.Descriptor instead.
+func (*PurgeNotificationsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_notification_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *PurgeNotificationsRequest) GetReadBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReadBefore
+	}
+	return nil
+}
+
+func (x *PurgeNotificationsRequest) GetDeliveriesBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeliveriesBefore
+	}
+	return nil
+}
+
+type PurgeNotificationsResponse struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	NotificationsDeleted int64                  `protobuf:"varint,1,opt,name=notifications_deleted,json=notificationsDeleted,proto3" json:"notifications_deleted,omitempty"`
+	DeliveriesDeleted    int64                  `protobuf:"varint,2,opt,name=deliveries_deleted,json=deliveriesDeleted,proto3" json:"deliveries_deleted,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *PurgeNotificationsResponse) Reset() {
+	*x = PurgeNotificationsResponse{}
+	mi := &file_proto_notification_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurgeNotificationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgeNotificationsResponse) ProtoMessage() {}
+
+func (x *PurgeNotificationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_notification_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgeNotificationsResponse.ProtoReflect.Descriptor instead.
+func (*PurgeNotificationsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_notification_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *PurgeNotificationsResponse) GetNotificationsDeleted() int64 {
+	if x != nil {
+		return x.NotificationsDeleted
+	}
+	return 0
+}
+
+func (x *PurgeNotificationsResponse) GetDeliveriesDeleted() int64 {
+	if x != nil {
+		return x.DeliveriesDeleted
+	}
+	return 0
+}
+
+// FailedEvent is an event the subscriber dead-lettered after its last
+// delivery failed, or at once when it could not be decoded
+type FailedEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Subject       string                 `protobuf:"bytes,2,opt,name=subject,proto3" json:"subject,omitempty"`
+	Consumer      string                 `protobuf:"bytes,3,opt,name=consumer,proto3" json:"consumer,omitempty"` // Durable consumer the event failed on
+	Payload       []byte                 `protobuf:"bytes,4,opt,name=payload,proto3" json:"payload,omitempty"`
+	Headers       map[string]string      `protobuf:"bytes,5,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // First value of each header
+	Error         string                 `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+	Attempts      int32                  `protobuf:"varint,7,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	FailedAt      *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=failed_at,json=failedAt,proto3" json:"failed_at,omitempty"`
+	ReplayedAt    *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=replayed_at,json=replayedAt,proto3,oneof" json:"replayed_at,omitempty"`     // Set once a replay succeeded
+	ReplayError   *string                `protobuf:"bytes,10,opt,name=replay_error,json=replayError,proto3,oneof" json:"replay_error,omitempty"` // Error of the last replay, while it keeps failing
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FailedEvent) Reset() {
+	*x = FailedEvent{}
+	mi := &file_proto_notification_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FailedEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FailedEvent) ProtoMessage() {}
+
+func (x *FailedEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_notification_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FailedEvent.ProtoReflect.Descriptor instead.
+func (*FailedEvent) Descriptor() ([]byte, []int) {
+	return file_proto_notification_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *FailedEvent) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *FailedEvent) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *FailedEvent) GetConsumer() string {
+	if x != nil {
+		return x.Consumer
+	}
+	return ""
+}
+
+func (x *FailedEvent) GetPayload() []byte {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *FailedEvent) GetHeaders() map[string]string {
+	if x != nil {
+		return x.Headers
+	}
+	return nil
+}
+
+func (x *FailedEvent) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *FailedEvent) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *FailedEvent) GetFailedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FailedAt
+	}
+	return nil
+}
+
+func (x *FailedEvent) GetReplayedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReplayedAt
+	}
+	return nil
+}
+
+func (x *FailedEvent) GetReplayError() string {
+	if x != nil && x.ReplayError != nil {
+		return *x.ReplayError
+	}
+	return ""
+}
+
+type ListFailedEventsRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Limit           int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`                                            // Defaults to 50, at most 500
+	IncludeReplayed bool                   `protobuf:"varint,2,opt,name=include_replayed,json=includeReplayed,proto3" json:"include_replayed,omitempty"` // Also list events already replayed
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ListFailedEventsRequest) Reset() {
+	*x = ListFailedEventsRequest{}
+	mi := &file_proto_notification_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFailedEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFailedEventsRequest) ProtoMessage() {}
+
+func (x *ListFailedEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_notification_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFailedEventsRequest.ProtoReflect.Descriptor instead.
+func (*ListFailedEventsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_notification_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ListFailedEventsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListFailedEventsRequest) GetIncludeReplayed() bool {
+	if x != nil {
+		return x.IncludeReplayed
+	}
+	return false
+}
+
+type ListFailedEventsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Events        []*FailedEvent         `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFailedEventsResponse) Reset() {
+	*x = ListFailedEventsResponse{}
+	mi := &file_proto_notification_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFailedEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFailedEventsResponse) ProtoMessage() {}
+
+func (x *ListFailedEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_notification_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFailedEventsResponse.ProtoReflect.Descriptor instead.
+func (*ListFailedEventsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_notification_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ListFailedEventsResponse) GetEvents() []*FailedEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+type GetFailedEventRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFailedEventRequest) Reset() {
+	*x = GetFailedEventRequest{}
+	mi := &file_proto_notification_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFailedEventRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFailedEventRequest) ProtoMessage() {}
+
+func (x *GetFailedEventRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_notification_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFailedEventRequest.ProtoReflect.Descriptor instead.
+func (*GetFailedEventRequest) Descriptor() ([]byte, []int) {
+	return file_proto_notification_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *GetFailedEventRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ReplayFailedEventRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplayFailedEventRequest) Reset() {
+	*x = ReplayFailedEventRequest{}
+	mi := &file_proto_notification_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplayFailedEventRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplayFailedEventRequest) ProtoMessage() {}
+
+func (x *ReplayFailedEventRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_notification_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplayFailedEventRequest.ProtoReflect.Descriptor instead.
+func (*ReplayFailedEventRequest) Descriptor() ([]byte, []int) {
+	return file_proto_notification_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ReplayFailedEventRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type RegisterDeviceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Platform      DevicePlatform         `protobuf:"varint,2,opt,name=platform,proto3,enum=notification.DevicePlatform" json:"platform,omitempty"`
+	Token         string                 `protobuf:"bytes,3,opt,name=token,proto3" json:"token,omitempty"`         // FCM registration token, APNs device token or WebPush endpoint
+	P256Dh        *string                `protobuf:"bytes,4,opt,name=p256dh,proto3,oneof" json:"p256dh,omitempty"` // WebPush subscription keys
+	Auth          *string                `protobuf:"bytes,5,opt,name=auth,proto3,oneof" json:"auth,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegisterDeviceRequest) Reset() {
+	*x = RegisterDeviceRequest{}
+	mi := &file_proto_notification_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterDeviceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterDeviceRequest) ProtoMessage() {}
+
+func (x *RegisterDeviceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_notification_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterDeviceRequest.ProtoReflect.Descriptor instead.
+func (*RegisterDeviceRequest) Descriptor() ([]byte, []int) {
+	return file_proto_notification_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *RegisterDeviceRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RegisterDeviceRequest) GetPlatform() DevicePlatform {
+	if x != nil {
+		return x.Platform
+	}
+	return DevicePlatform_DEVICE_PLATFORM_UNSPECIFIED
+}
+
+func (x *RegisterDeviceRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *RegisterDeviceRequest) GetP256Dh() string {
+	if x != nil && x.P256Dh != nil {
+		return *x.P256Dh
+	}
+	return ""
+}
+
+func (x *RegisterDeviceRequest) GetAuth() string {
+	if x != nil && x.Auth != nil {
+		return *x.Auth
+	}
+	return ""
+}
+
+type UnregisterDeviceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Token         string                 `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnregisterDeviceRequest) Reset() {
+	*x = UnregisterDeviceRequest{}
+	mi := &file_proto_notification_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnregisterDeviceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnregisterDeviceRequest) ProtoMessage() {}
+
+func (x *UnregisterDeviceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_notification_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnregisterDeviceRequest.ProtoReflect.Descriptor instead.
+func (*UnregisterDeviceRequest) Descriptor() ([]byte, []int) {
+	return file_proto_notification_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *UnregisterDeviceRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UnregisterDeviceRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type Device struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Platform      DevicePlatform         `protobuf:"varint,3,opt,name=platform,proto3,enum=notification.DevicePlatform" json:"platform,omitempty"`
+	Token         string                 `protobuf:"bytes,4,opt,name=token,proto3" json:"token,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	LastSeenAt    *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=last_seen_at,json=lastSeenAt,proto3" json:"last_seen_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Device) Reset() {
+	*x = Device{}
+	mi := &file_proto_notification_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Device) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Device) ProtoMessage() {}
+
+func (x *Device) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_notification_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Device.ProtoReflect.Descriptor instead.
+func (*Device) Descriptor() ([]byte, []int) {
+	return file_proto_notification_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *Device) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Device) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Device) GetPlatform() DevicePlatform {
+	if x != nil {
+		return x.Platform
+	}
+	return DevicePlatform_DEVICE_PLATFORM_UNSPECIFIED
+}
+
+func (x *Device) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *Device) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Device) GetLastSeenAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSeenAt
+	}
+	return nil
+}
+
+type GetPushPreferencesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPushPreferencesRequest) Reset() {
+	*x = GetPushPreferencesRequest{}
+	mi := &file_proto_notification_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPushPreferencesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPushPreferencesRequest) ProtoMessage() {}
+
+func (x *GetPushPreferencesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_notification_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPushPreferencesRequest.ProtoReflect.Descriptor instead.
+func (*GetPushPreferencesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_notification_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *GetPushPreferencesRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type UpdatePushPreferencesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Preferences   []*PushPreference      `protobuf:"bytes,2,rep,name=preferences,proto3" json:"preferences,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdatePushPreferencesRequest) Reset() {
+	*x = UpdatePushPreferencesRequest{}
+	mi := &file_proto_notification_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdatePushPreferencesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdatePushPreferencesRequest) ProtoMessage() {}
+
+func (x *UpdatePushPreferencesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_notification_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdatePushPreferencesRequest.ProtoReflect.Descriptor instead.
+func (*UpdatePushPreferencesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_notification_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *UpdatePushPreferencesRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UpdatePushPreferencesRequest) GetPreferences() []*PushPreference {
+	if x != nil {
+		return x.Preferences
+	}
+	return nil
+}
+
+type PushPreference struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          NotificationType       `protobuf:"varint,1,opt,name=type,proto3,enum=notification.NotificationType" json:"type,omitempty"`
+	Enabled       bool                   `protobuf:"varint,2,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PushPreference) Reset() {
+	*x = PushPreference{}
+	mi := &file_proto_notification_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PushPreference) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PushPreference) ProtoMessage() {}
+
+func (x *PushPreference) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_notification_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PushPreference.ProtoReflect.Descriptor instead.
+func (*PushPreference) Descriptor() ([]byte, []int) {
+	return file_proto_notification_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *PushPreference) GetType() NotificationType {
+	if x != nil {
+		return x.Type
+	}
+	return NotificationType_NOTIFICATION_TYPE_UNSPECIFIED
+}
+
+func (x *PushPreference) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+// PushPreferences lists every notification type and whether it is pushed
+type PushPreferences struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Preferences   []*PushPreference      `protobuf:"bytes,1,rep,name=preferences,proto3" json:"preferences,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PushPreferences) Reset() {
+	*x = PushPreferences{}
+	mi := &file_proto_notification_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PushPreferences) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PushPreferences) ProtoMessage() {}
+
+func (x *PushPreferences) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_notification_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PushPreferences.ProtoReflect.Descriptor instead.
+func (*PushPreferences) Descriptor() ([]byte, []int) {
+	return file_proto_notification_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *PushPreferences) GetPreferences() []*PushPreference {
+	if x != nil {
+		return x.Preferences
+	}
+	return nil
+}
+
+type GetPreferencesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPreferencesRequest) Reset() {
+	*x = GetPreferencesRequest{}
+	mi := &file_proto_notification_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPreferencesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPreferencesRequest) ProtoMessage() {}
+
+func (x *GetPreferencesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_notification_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPreferencesRequest.ProtoReflect.Descriptor instead.
+func (*GetPreferencesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_notification_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *GetPreferencesRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+// UpdatePreferencesRequest replaces the user's preferences; leaving
+// quiet_hours out turns quiet hours off
+type UpdatePreferencesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	DisabledTypes []NotificationType     `protobuf:"varint,2,rep,packed,name=disabled_types,json=disabledTypes,proto3,enum=notification.NotificationType" json:"disabled_types,omitempty"`
+	QuietHours    *QuietHours            `protobuf:"bytes,3,opt,name=quiet_hours,json=quietHours,proto3,oneof" json:"quiet_hours,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdatePreferencesRequest) Reset() {
+	*x = UpdatePreferencesRequest{}
+	mi := &file_proto_notification_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdatePreferencesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdatePreferencesRequest) ProtoMessage() {}
+
+func (x *UpdatePreferencesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_notification_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdatePreferencesRequest.ProtoReflect.Descriptor instead.
+func (*UpdatePreferencesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_notification_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *UpdatePreferencesRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UpdatePreferencesRequest) GetDisabledTypes() []NotificationType {
+	if x != nil {
+		return x.DisabledTypes
+	}
+	return nil
+}
+
+func (x *UpdatePreferencesRequest) GetQuietHours() *QuietHours {
+	if x != nil {
+		return x.QuietHours
+	}
+	return nil
+}
+
+// QuietHours is a daily window, in the user's time zone, during which
+// notifications are recorded but not pushed. An end before the start runs
+// over midnight.
+type QuietHours struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Start         string                 `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`                       // HH:MM
+	End           string                 `protobuf:"bytes,2,opt,name=end,proto3" json:"end,omitempty"`                           // HH:MM
+	TimeZone      string                 `protobuf:"bytes,3,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"` // IANA name, defaults to UTC
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QuietHours) Reset() {
+	*x = QuietHours{}
+	mi := &file_proto_notification_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuietHours) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuietHours) ProtoMessage() {}
+
+func (x *QuietHours) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_notification_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuietHours.ProtoReflect.Descriptor instead.
+func (*QuietHours) Descriptor() ([]byte, []int) {
+	return file_proto_notification_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *QuietHours) GetStart() string {
+	if x != nil {
+		return x.Start
+	}
+	return ""
+}
+
+func (x *QuietHours) GetEnd() string {
+	if x != nil {
+		return x.End
+	}
+	return ""
+}
+
+func (x *QuietHours) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+type NotificationPreferences struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	DisabledTypes []NotificationType     `protobuf:"varint,2,rep,packed,name=disabled_types,json=disabledTypes,proto3,enum=notification.NotificationType" json:"disabled_types,omitempty"` // types that are not recorded at all
+	QuietHours    *QuietHours            `protobuf:"bytes,3,opt,name=quiet_hours,json=quietHours,proto3,oneof" json:"quiet_hours,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NotificationPreferences) Reset() {
+	*x = NotificationPreferences{}
+	mi := &file_proto_notification_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NotificationPreferences) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NotificationPreferences) ProtoMessage() {}
+
+func (x *NotificationPreferences) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_notification_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NotificationPreferences.ProtoReflect.Descriptor instead.
+func (*NotificationPreferences) Descriptor() ([]byte, []int) {
+	return file_proto_notification_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *NotificationPreferences) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *NotificationPreferences) GetDisabledTypes() []NotificationType {
+	if x != nil {
+		return x.DisabledTypes
+	}
+	return nil
+}
+
+func (x *NotificationPreferences) GetQuietHours() *QuietHours {
+	if x != nil {
+		return x.QuietHours
+	}
+	return nil
+}
+
+func (x *NotificationPreferences) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type GetPresenceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserIds       []string               `protobuf:"bytes,1,rep,name=user_ids,json=userIds,proto3" json:"user_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPresenceRequest) Reset() {
+	*x = GetPresenceRequest{}
+	mi := &file_proto_notification_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPresenceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPresenceRequest) ProtoMessage() {}
+
+func (x *GetPresenceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_notification_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPresenceRequest.ProtoReflect.Descriptor instead.
+func (*GetPresenceRequest) Descriptor() ([]byte, []int) {
+	return file_proto_notification_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *GetPresenceRequest) GetUserIds() []string {
+	if x != nil {
+		return x.UserIds
+	}
+	return nil
+}
+
+type Presence struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Online bool                   `protobuf:"varint,2,opt,name=online,proto3" json:"online,omitempty"`
+	// Time of the user's latest heartbeat; unset if they never connected
+	LastSeenAt    *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=last_seen_at,json=lastSeenAt,proto3,oneof" json:"last_seen_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Presence) Reset() {
+	*x = Presence{}
+	mi := &file_proto_notification_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Presence) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Presence) ProtoMessage() {}
+
+func (x *Presence) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_notification_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Presence.ProtoReflect.Descriptor instead.
+func (*Presence) Descriptor() ([]byte, []int) {
+	return file_proto_notification_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *Presence) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Presence) GetOnline() bool {
+	if x != nil {
+		return x.Online
+	}
+	return false
+}
+
+func (x *Presence) GetLastSeenAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSeenAt
+	}
+	return nil
+}
+
+// GetPresenceResponse has a presence for each requested user, in order
+type GetPresenceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Presences     []*Presence            `protobuf:"bytes,1,rep,name=presences,proto3" json:"presences,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPresenceResponse) Reset() {
+	*x = GetPresenceResponse{}
+	mi := &file_proto_notification_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPresenceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPresenceResponse) ProtoMessage() {}
+
+func (x *GetPresenceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_notification_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPresenceResponse.ProtoReflect.Descriptor instead.
+func (*GetPresenceResponse) Descriptor() ([]byte, []int) {
+	return file_proto_notification_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *GetPresenceResponse) GetPresences() []*Presence {
+	if x != nil {
+		return x.Presences
+	}
+	return nil
+}
+
+var File_proto_notification_proto protoreflect.FileDescriptor
+
+const file_proto_notification_proto_rawDesc = "" +
+	"\n" +
+	"\x18proto/notification.proto\x12\fnotification\x1a\x1fgoogle/protobuf/timestamp.proto\"m\n" +
+	"\x17GetNotificationsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05first\x18\x02 \x01(\x05R\x05first\x12\x19\n" +
+	"\x05after\x18\x03 \x01(\tH\x00R\x05after\x88\x01\x01B\b\n" +
+	"\x06_after\"0\n" +
+	"\x15GetUnreadCountRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"<\n" +
+	"\vUnreadCount\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\"S\n" +
+	"\x0fMarkReadRequest\x12'\n" +
+	"\x0fnotification_id\x18\x01 \x01(\tR\x0enotificationId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"-\n" +
+	"\x12MarkAllReadRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"\xe2\x01\n" +
+	"\x19CreateNotificationRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x122\n" +
+	"\x04type\x18\x02 \x01(\x0e2\x1e.notification.NotificationTypeR\x04type\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\x12\x1e\n" +
+	"\bactor_id\x18\x04 \x01(\tH\x00R\aactorId\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"related_id\x18\x05 \x01(\tH\x01R\trelatedId\x88\x01\x01B\v\n" +
+	"\t_actor_idB\r\n" +
+	"\v_related_id\"]\n" +
+	"\x19DeleteNotificationRequest\x12'\n" +
+	"\x0fnotification_id\x18\x01 \x01(\tR\x0enotificationId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"\x81\x03\n" +
+	"\fNotification\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x122\n" +
+	"\x04type\x18\x03 \x01(\x0e2\x1e.notification.NotificationTypeR\x04type\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\x12\x1e\n" +
+	"\bactor_id\x18\x05 \x01(\tH\x00R\aactorId\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"related_id\x18\x06 \x01(\tH\x01R\trelatedId\x88\x01\x01\x12\x17\n" +
+	"\ais_read\x18\a \x01(\bR\x06isRead\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12<\n" +
+	"\x05group\x18\t \x01(\v2!.notification.GroupedNotificationH\x02R\x05group\x88\x01\x01B\v\n" +
+	"\t_actor_idB\r\n" +
+	"\v_related_idB\b\n" +
+	"\x06_group\"}\n" +
+	"\x13GroupedNotification\x12\x1b\n" +
+	"\tgroup_key\x18\x01 \x01(\tR\bgroupKey\x12\x1f\n" +
+	"\vactor_count\x18\x02 \x01(\x05R\n" +
+	"actorCount\x12(\n" +
+	"\x10latest_actor_ids\x18\x03 \x03(\tR\x0elatestActorIds\"Z\n" +
+	"\x10NotificationEdge\x12\x16\n" +
 	"\x06cursor\x18\x01 \x01(\tR\x06cursor\x12.\n" +
 	"\x04node\x18\x02 \x01(\v2\x1a.notification.NotificationR\x04node\"\xc6\x01\n" +
 	"\bPageInfo\x12\"\n" +
@@ -742,20 +2795,204 @@ const file_proto_notification_proto_rawDesc = "" +
 	"\tpage_info\x18\x02 \x01(\v2\x16.notification.PageInfoR\bpageInfo\x12\x1f\n" +
 	"\vtotal_count\x18\x03 \x01(\x05R\n" +
 	"totalCount\x12!\n" +
-	"\funread_count\x18\x04 \x01(\x05R\vunreadCount\">\n" +
+	"\funread_count\x18\x04 \x01(\x05R\vunreadCount\"d\n" +
+	"\x16RegisterWebhookRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x12\x1f\n" +
+	"\vevent_types\x18\x03 \x03(\tR\n" +
+	"eventTypes\".\n" +
+	"\x13ListWebhooksRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"I\n" +
+	"\x14ListWebhooksResponse\x121\n" +
+	"\bwebhooks\x18\x01 \x03(\v2\x15.notification.WebhookR\bwebhooks\"N\n" +
+	"\x14DeleteWebhookRequest\x12\x1d\n" +
+	"\n" +
+	"webhook_id\x18\x01 \x01(\tR\twebhookId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"k\n" +
+	"\x1bGetWebhookDeliveriesRequest\x12\x1d\n" +
+	"\n" +
+	"webhook_id\x18\x01 \x01(\tR\twebhookId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05first\x18\x03 \x01(\x05R\x05first\"]\n" +
+	"\x1cGetWebhookDeliveriesResponse\x12=\n" +
+	"\n" +
+	"deliveries\x18\x01 \x03(\v2\x1d.notification.WebhookDeliveryR\n" +
+	"deliveries\"\xe5\x01\n" +
+	"\aWebhook\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x10\n" +
+	"\x03url\x18\x03 \x01(\tR\x03url\x12\x1f\n" +
+	"\vevent_types\x18\x04 \x03(\tR\n" +
+	"eventTypes\x12\x1b\n" +
+	"\tis_active\x18\x05 \x01(\bR\bisActive\x12\x1b\n" +
+	"\x06secret\x18\x06 \x01(\tH\x00R\x06secret\x88\x01\x01\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAtB\t\n" +
+	"\a_secret\"\xe5\x02\n" +
+	"\x0fWebhookDelivery\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"webhook_id\x18\x02 \x01(\tR\twebhookId\x12\x19\n" +
+	"\bevent_id\x18\x03 \x01(\tR\aeventId\x12\x1d\n" +
+	"\n" +
+	"event_type\x18\x04 \x01(\tR\teventType\x12\x18\n" +
+	"\aattempt\x18\x05 \x01(\x05R\aattempt\x12$\n" +
+	"\vstatus_code\x18\x06 \x01(\x05H\x00R\n" +
+	"statusCode\x88\x01\x01\x12\x18\n" +
+	"\asuccess\x18\a \x01(\bR\asuccess\x12\x19\n" +
+	"\x05error\x18\b \x01(\tH\x01R\x05error\x88\x01\x01\x12\x1f\n" +
+	"\vduration_ms\x18\t \x01(\x03R\n" +
+	"durationMs\x129\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAtB\x0e\n" +
+	"\f_status_codeB\b\n" +
+	"\x06_error\">\n" +
 	"\bResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage*L\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\x15\n" +
+	"\x13ExportMyDataRequest\" \n" +
+	"\n" +
+	"DataExport\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\"\xd1\x01\n" +
+	"\x19PurgeNotificationsRequest\x12@\n" +
+	"\vread_before\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampH\x00R\n" +
+	"readBefore\x88\x01\x01\x12L\n" +
+	"\x11deliveries_before\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampH\x01R\x10deliveriesBefore\x88\x01\x01B\x0e\n" +
+	"\f_read_beforeB\x14\n" +
+	"\x12_deliveries_before\"\x80\x01\n" +
+	"\x1aPurgeNotificationsResponse\x123\n" +
+	"\x15notifications_deleted\x18\x01 \x01(\x03R\x14notificationsDeleted\x12-\n" +
+	"\x12deliveries_deleted\x18\x02 \x01(\x03R\x11deliveriesDeleted\"\xe1\x03\n" +
+	"\vFailedEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\asubject\x18\x02 \x01(\tR\asubject\x12\x1a\n" +
+	"\bconsumer\x18\x03 \x01(\tR\bconsumer\x12\x18\n" +
+	"\apayload\x18\x04 \x01(\fR\apayload\x12@\n" +
+	"\aheaders\x18\x05 \x03(\v2&.notification.FailedEvent.HeadersEntryR\aheaders\x12\x14\n" +
+	"\x05error\x18\x06 \x01(\tR\x05error\x12\x1a\n" +
+	"\battempts\x18\a \x01(\x05R\battempts\x127\n" +
+	"\tfailed_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\bfailedAt\x12@\n" +
+	"\vreplayed_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampH\x00R\n" +
+	"replayedAt\x88\x01\x01\x12&\n" +
+	"\freplay_error\x18\n" +
+	" \x01(\tH\x01R\vreplayError\x88\x01\x01\x1a:\n" +
+	"\fHeadersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\x0e\n" +
+	"\f_replayed_atB\x0f\n" +
+	"\r_replay_error\"Z\n" +
+	"\x17ListFailedEventsRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12)\n" +
+	"\x10include_replayed\x18\x02 \x01(\bR\x0fincludeReplayed\"M\n" +
+	"\x18ListFailedEventsResponse\x121\n" +
+	"\x06events\x18\x01 \x03(\v2\x19.notification.FailedEventR\x06events\"'\n" +
+	"\x15GetFailedEventRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"*\n" +
+	"\x18ReplayFailedEventRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xca\x01\n" +
+	"\x15RegisterDeviceRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x128\n" +
+	"\bplatform\x18\x02 \x01(\x0e2\x1c.notification.DevicePlatformR\bplatform\x12\x14\n" +
+	"\x05token\x18\x03 \x01(\tR\x05token\x12\x1b\n" +
+	"\x06p256dh\x18\x04 \x01(\tH\x00R\x06p256dh\x88\x01\x01\x12\x17\n" +
+	"\x04auth\x18\x05 \x01(\tH\x01R\x04auth\x88\x01\x01B\t\n" +
+	"\a_p256dhB\a\n" +
+	"\x05_auth\"H\n" +
+	"\x17UnregisterDeviceRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05token\x18\x02 \x01(\tR\x05token\"\xfa\x01\n" +
+	"\x06Device\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x128\n" +
+	"\bplatform\x18\x03 \x01(\x0e2\x1c.notification.DevicePlatformR\bplatform\x12\x14\n" +
+	"\x05token\x18\x04 \x01(\tR\x05token\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12<\n" +
+	"\flast_seen_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"lastSeenAt\"4\n" +
+	"\x19GetPushPreferencesRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"w\n" +
+	"\x1cUpdatePushPreferencesRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12>\n" +
+	"\vpreferences\x18\x02 \x03(\v2\x1c.notification.PushPreferenceR\vpreferences\"^\n" +
+	"\x0ePushPreference\x122\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x1e.notification.NotificationTypeR\x04type\x12\x18\n" +
+	"\aenabled\x18\x02 \x01(\bR\aenabled\"Q\n" +
+	"\x0fPushPreferences\x12>\n" +
+	"\vpreferences\x18\x01 \x03(\v2\x1c.notification.PushPreferenceR\vpreferences\"0\n" +
+	"\x15GetPreferencesRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"\xca\x01\n" +
+	"\x18UpdatePreferencesRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12E\n" +
+	"\x0edisabled_types\x18\x02 \x03(\x0e2\x1e.notification.NotificationTypeR\rdisabledTypes\x12>\n" +
+	"\vquiet_hours\x18\x03 \x01(\v2\x18.notification.QuietHoursH\x00R\n" +
+	"quietHours\x88\x01\x01B\x0e\n" +
+	"\f_quiet_hours\"Q\n" +
+	"\n" +
+	"QuietHours\x12\x14\n" +
+	"\x05start\x18\x01 \x01(\tR\x05start\x12\x10\n" +
+	"\x03end\x18\x02 \x01(\tR\x03end\x12\x1b\n" +
+	"\ttime_zone\x18\x03 \x01(\tR\btimeZone\"\x84\x02\n" +
+	"\x17NotificationPreferences\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12E\n" +
+	"\x0edisabled_types\x18\x02 \x03(\x0e2\x1e.notification.NotificationTypeR\rdisabledTypes\x12>\n" +
+	"\vquiet_hours\x18\x03 \x01(\v2\x18.notification.QuietHoursH\x00R\n" +
+	"quietHours\x88\x01\x01\x129\n" +
+	"\n" +
+	"updated_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAtB\x0e\n" +
+	"\f_quiet_hours\"/\n" +
+	"\x12GetPresenceRequest\x12\x19\n" +
+	"\buser_ids\x18\x01 \x03(\tR\auserIds\"\x8f\x01\n" +
+	"\bPresence\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06online\x18\x02 \x01(\bR\x06online\x12A\n" +
+	"\flast_seen_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampH\x00R\n" +
+	"lastSeenAt\x88\x01\x01B\x0f\n" +
+	"\r_last_seen_at\"K\n" +
+	"\x13GetPresenceResponse\x124\n" +
+	"\tpresences\x18\x01 \x03(\v2\x16.notification.PresenceR\tpresences*\xa8\x01\n" +
 	"\x10NotificationType\x12!\n" +
 	"\x1dNOTIFICATION_TYPE_UNSPECIFIED\x10\x00\x12\b\n" +
 	"\x04POST\x10\x01\x12\v\n" +
-	"\aCOMMENT\x10\x022\xb4\x03\n" +
+	"\aCOMMENT\x10\x02\x12\v\n" +
+	"\aMENTION\x10\x03\x12\n" +
+	"\n" +
+	"\x06REPOST\x10\x04\x12\t\n" +
+	"\x05REPLY\x10\x05\x12\x12\n" +
+	"\x0eFOLLOW_REQUEST\x10\x06\x12\b\n" +
+	"\x04LIKE\x10\a\x12\n" +
+	"\n" +
+	"\x06FOLLOW\x10\b\x12\f\n" +
+	"\bSECURITY\x10\t*Q\n" +
+	"\x0eDevicePlatform\x12\x1f\n" +
+	"\x1bDEVICE_PLATFORM_UNSPECIFIED\x10\x00\x12\a\n" +
+	"\x03FCM\x10\x01\x12\b\n" +
+	"\x04APNS\x10\x02\x12\v\n" +
+	"\aWEBPUSH\x10\x032\xa4\x0f\n" +
 	"\x13NotificationService\x12_\n" +
-	"\x10GetNotifications\x12%.notification.GetNotificationsRequest\x1a$.notification.NotificationConnection\x12A\n" +
+	"\x10GetNotifications\x12%.notification.GetNotificationsRequest\x1a$.notification.NotificationConnection\x12P\n" +
+	"\x0eGetUnreadCount\x12#.notification.GetUnreadCountRequest\x1a\x19.notification.UnreadCount\x12A\n" +
 	"\bMarkRead\x12\x1d.notification.MarkReadRequest\x1a\x16.notification.Response\x12G\n" +
 	"\vMarkAllRead\x12 .notification.MarkAllReadRequest\x1a\x16.notification.Response\x12Y\n" +
 	"\x12CreateNotification\x12'.notification.CreateNotificationRequest\x1a\x1a.notification.Notification\x12U\n" +
-	"\x12DeleteNotification\x12'.notification.DeleteNotificationRequest\x1a\x16.notification.ResponseB\x04Z\x02./b\x06proto3"
+	"\x12DeleteNotification\x12'.notification.DeleteNotificationRequest\x1a\x16.notification.Response\x12N\n" +
+	"\x0fRegisterWebhook\x12$.notification.RegisterWebhookRequest\x1a\x15.notification.Webhook\x12U\n" +
+	"\fListWebhooks\x12!.notification.ListWebhooksRequest\x1a\".notification.ListWebhooksResponse\x12K\n" +
+	"\rDeleteWebhook\x12\".notification.DeleteWebhookRequest\x1a\x16.notification.Response\x12m\n" +
+	"\x14GetWebhookDeliveries\x12).notification.GetWebhookDeliveriesRequest\x1a*.notification.GetWebhookDeliveriesResponse\x12K\n" +
+	"\x0eRegisterDevice\x12#.notification.RegisterDeviceRequest\x1a\x14.notification.Device\x12Q\n" +
+	"\x10UnregisterDevice\x12%.notification.UnregisterDeviceRequest\x1a\x16.notification.Response\x12\\\n" +
+	"\x12GetPushPreferences\x12'.notification.GetPushPreferencesRequest\x1a\x1d.notification.PushPreferences\x12b\n" +
+	"\x15UpdatePushPreferences\x12*.notification.UpdatePushPreferencesRequest\x1a\x1d.notification.PushPreferences\x12\\\n" +
+	"\x0eGetPreferences\x12#.notification.GetPreferencesRequest\x1a%.notification.NotificationPreferences\x12b\n" +
+	"\x11UpdatePreferences\x12&.notification.UpdatePreferencesRequest\x1a%.notification.NotificationPreferences\x12K\n" +
+	"\fExportMyData\x12!.notification.ExportMyDataRequest\x1a\x18.notification.DataExport\x12R\n" +
+	"\vGetPresence\x12 .notification.GetPresenceRequest\x1a!.notification.GetPresenceResponse\x12g\n" +
+	"\x12PurgeNotifications\x12'.notification.PurgeNotificationsRequest\x1a(.notification.PurgeNotificationsResponse\x12a\n" +
+	"\x10ListFailedEvents\x12%.notification.ListFailedEventsRequest\x1a&.notification.ListFailedEventsResponse\x12P\n" +
+	"\x0eGetFailedEvent\x12#.notification.GetFailedEventRequest\x1a\x19.notification.FailedEvent\x12V\n" +
+	"\x11ReplayFailedEvent\x12&.notification.ReplayFailedEventRequest\x1a\x19.notification.FailedEventB\x04Z\x02./b\x06proto3"
 
 var (
 	file_proto_notification_proto_rawDescOnce sync.Once
@@ -769,44 +3006,139 @@ func file_proto_notification_proto_rawDescGZIP() []byte {
 	return file_proto_notification_proto_rawDescData
 }
 
-var file_proto_notification_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_proto_notification_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_proto_notification_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_proto_notification_proto_msgTypes = make([]protoimpl.MessageInfo, 45)
 var file_proto_notification_proto_goTypes = []any{
-	(NotificationType)(0),             // 0: notification.NotificationType
-	(*GetNotificationsRequest)(nil),   // 1: notification.GetNotificationsRequest
-	(*MarkReadRequest)(nil),           // 2: notification.MarkReadRequest
-	(*MarkAllReadRequest)(nil),        // 3: notification.MarkAllReadRequest
-	(*CreateNotificationRequest)(nil), // 4: notification.CreateNotificationRequest
-	(*DeleteNotificationRequest)(nil), // 5: notification.DeleteNotificationRequest
-	(*Notification)(nil),              // 6: notification.Notification
-	(*NotificationEdge)(nil),          // 7: notification.NotificationEdge
-	(*PageInfo)(nil),                  // 8: notification.PageInfo
-	(*NotificationConnection)(nil),    // 9: notification.NotificationConnection
-	(*Response)(nil),                  // 10: notification.Response
-	(*timestamppb.Timestamp)(nil),     // 11: google.protobuf.Timestamp
+	(NotificationType)(0),                // 0: notification.NotificationType
+	(DevicePlatform)(0),                  // 1: notification.DevicePlatform
+	(*GetNotificationsRequest)(nil),      // 2: notification.GetNotificationsRequest
+	(*GetUnreadCountRequest)(nil),        // 3: notification.GetUnreadCountRequest
+	(*UnreadCount)(nil),                  // 4: notification.UnreadCount
+	(*MarkReadRequest)(nil),              // 5: notification.MarkReadRequest
+	(*MarkAllReadRequest)(nil),           // 6: notification.MarkAllReadRequest
+	(*CreateNotificationRequest)(nil),    // 7: notification.CreateNotificationRequest
+	(*DeleteNotificationRequest)(nil),    // 8: notification.DeleteNotificationRequest
+	(*Notification)(nil),                 // 9: notification.Notification
+	(*GroupedNotification)(nil),          // 10: notification.GroupedNotification
+	(*NotificationEdge)(nil),             // 11: notification.NotificationEdge
+	(*PageInfo)(nil),                     // 12: notification.PageInfo
+	(*NotificationConnection)(nil),       // 13: notification.NotificationConnection
+	(*RegisterWebhookRequest)(nil),       // 14: notification.RegisterWebhookRequest
+	(*ListWebhooksRequest)(nil),          // 15: notification.ListWebhooksRequest
+	(*ListWebhooksResponse)(nil),         // 16: notification.ListWebhooksResponse
+	(*DeleteWebhookRequest)(nil),         // 17: notification.DeleteWebhookRequest
+	(*GetWebhookDeliveriesRequest)(nil),  // 18: notification.GetWebhookDeliveriesRequest
+	(*GetWebhookDeliveriesResponse)(nil), // 19: notification.GetWebhookDeliveriesResponse
+	(*Webhook)(nil),                      // 20: notification.Webhook
+	(*WebhookDelivery)(nil),              // 21: notification.WebhookDelivery
+	(*Response)(nil),                     // 22: notification.Response
+	(*ExportMyDataRequest)(nil),          // 23: notification.ExportMyDataRequest
+	(*DataExport)(nil),                   // 24: notification.DataExport
+	(*PurgeNotificationsRequest)(nil),    // 25: notification.PurgeNotificationsRequest
+	(*PurgeNotificationsResponse)(nil),   // 26: notification.PurgeNotificationsResponse
+	(*FailedEvent)(nil),                  // 27: notification.FailedEvent
+	(*ListFailedEventsRequest)(nil),      // 28: notification.ListFailedEventsRequest
+	(*ListFailedEventsResponse)(nil),     // 29: notification.ListFailedEventsResponse
+	(*GetFailedEventRequest)(nil),        // 30: notification.GetFailedEventRequest
+	(*ReplayFailedEventRequest)(nil),     // 31: notification.ReplayFailedEventRequest
+	(*RegisterDeviceRequest)(nil),        // 32: notification.RegisterDeviceRequest
+	(*UnregisterDeviceRequest)(nil),      // 33: notification.UnregisterDeviceRequest
+	(*Device)(nil),                       // 34: notification.Device
+	(*GetPushPreferencesRequest)(nil),    // 35: notification.GetPushPreferencesRequest
+	(*UpdatePushPreferencesRequest)(nil), // 36: notification.UpdatePushPreferencesRequest
+	(*PushPreference)(nil),               // 37: notification.PushPreference
+	(*PushPreferences)(nil),              // 38: notification.PushPreferences
+	(*GetPreferencesRequest)(nil),        // 39: notification.GetPreferencesRequest
+	(*UpdatePreferencesRequest)(nil),     // 40: notification.UpdatePreferencesRequest
+	(*QuietHours)(nil),                   // 41: notification.QuietHours
+	(*NotificationPreferences)(nil),      // 42: notification.NotificationPreferences
+	(*GetPresenceRequest)(nil),           // 43: notification.GetPresenceRequest
+	(*Presence)(nil),                     // 44: notification.Presence
+	(*GetPresenceResponse)(nil),          // 45: notification.GetPresenceResponse
+	nil,                                  // 46: notification.FailedEvent.HeadersEntry
+	(*timestamppb.Timestamp)(nil),        // 47: google.protobuf.Timestamp
 }
 var file_proto_notification_proto_depIdxs = []int32{
 	0,  // 0: notification.CreateNotificationRequest.type:type_name -> notification.NotificationType
 	0,  // 1: notification.Notification.type:type_name -> notification.NotificationType
-	11, // 2: notification.Notification.created_at:type_name -> google.protobuf.Timestamp
-	6,  // 3: notification.NotificationEdge.node:type_name -> notification.Notification
-	7,  // 4: notification.NotificationConnection.edges:type_name -> notification.NotificationEdge
-	8,  // 5: notification.NotificationConnection.page_info:type_name -> notification.PageInfo
-	1,  // 6: notification.NotificationService.GetNotifications:input_type -> notification.GetNotificationsRequest
-	2,  // 7: notification.NotificationService.MarkRead:input_type -> notification.MarkReadRequest
-	3,  // 8: notification.NotificationService.MarkAllRead:input_type -> notification.MarkAllReadRequest
-	4,  // 9: notification.NotificationService.CreateNotification:input_type -> notification.CreateNotificationRequest
-	5,  // 10: notification.NotificationService.DeleteNotification:input_type -> notification.DeleteNotificationRequest
-	9,  // 11: notification.NotificationService.GetNotifications:output_type -> notification.NotificationConnection
-	10, // 12: notification.NotificationService.MarkRead:output_type -> notification.Response
-	10, // 13: notification.NotificationService.MarkAllRead:output_type -> notification.Response
-	6,  // 14: notification.NotificationService.CreateNotification:output_type -> notification.Notification
-	10, // 15: notification.NotificationService.DeleteNotification:output_type -> notification.Response
-	11, // [11:16] is the sub-list for method output_type
-	6,  // [6:11] is the sub-list for method input_type
-	6,  // [6:6] is the sub-list for extension type_name
-	6,  // [6:6] is the sub-list for extension extendee
-	0,  // [0:6] is the sub-list for field type_name
+	47, // 2: notification.Notification.created_at:type_name -> google.protobuf.Timestamp
+	10, // 3: notification.Notification.group:type_name -> notification.GroupedNotification
+	9,  // 4: notification.NotificationEdge.node:type_name -> notification.Notification
+	11, // 5: notification.NotificationConnection.edges:type_name -> notification.NotificationEdge
+	12, // 6: notification.NotificationConnection.page_info:type_name -> notification.PageInfo
+	20, // 7: notification.ListWebhooksResponse.webhooks:type_name -> notification.Webhook
+	21, // 8: notification.GetWebhookDeliveriesResponse.deliveries:type_name -> notification.WebhookDelivery
+	47, // 9: notification.Webhook.created_at:type_name -> google.protobuf.Timestamp
+	47, // 10: notification.WebhookDelivery.created_at:type_name -> google.protobuf.Timestamp
+	47, // 11: notification.PurgeNotificationsRequest.read_before:type_name -> google.protobuf.Timestamp
+	47, // 12: notification.PurgeNotificationsRequest.deliveries_before:type_name -> google.protobuf.Timestamp
+	46, // 13: notification.FailedEvent.headers:type_name -> notification.FailedEvent.HeadersEntry
+	47, // 14: notification.FailedEvent.failed_at:type_name -> google.protobuf.Timestamp
+	47, // 15: notification.FailedEvent.replayed_at:type_name -> google.protobuf.Timestamp
+	27, // 16: notification.ListFailedEventsResponse.events:type_name -> notification.FailedEvent
+	1,  // 17: notification.RegisterDeviceRequest.platform:type_name -> notification.DevicePlatform
+	1,  // 18: notification.Device.platform:type_name -> notification.DevicePlatform
+	47, // 19: notification.Device.created_at:type_name -> google.protobuf.Timestamp
+	47, // 20: notification.Device.last_seen_at:type_name -> google.protobuf.Timestamp
+	37, // 21: notification.UpdatePushPreferencesRequest.preferences:type_name -> notification.PushPreference
+	0,  // 22: notification.PushPreference.type:type_name -> notification.NotificationType
+	37, // 23: notification.PushPreferences.preferences:type_name -> notification.PushPreference
+	0,  // 24: notification.UpdatePreferencesRequest.disabled_types:type_name -> notification.NotificationType
+	41, // 25: notification.UpdatePreferencesRequest.quiet_hours:type_name -> notification.QuietHours
+	0,  // 26: notification.NotificationPreferences.disabled_types:type_name -> notification.NotificationType
+	41, // 27: notification.NotificationPreferences.quiet_hours:type_name -> notification.QuietHours
+	47, // 28: notification.NotificationPreferences.updated_at:type_name -> google.protobuf.Timestamp
+	47, // 29: notification.Presence.last_seen_at:type_name -> google.protobuf.Timestamp
+	44, // 30: notification.GetPresenceResponse.presences:type_name -> notification.Presence
+	2,  // 31: notification.NotificationService.GetNotifications:input_type -> notification.GetNotificationsRequest
+	3,  // 32: notification.NotificationService.GetUnreadCount:input_type -> notification.GetUnreadCountRequest
+	5,  // 33: notification.NotificationService.MarkRead:input_type -> notification.MarkReadRequest
+	6,  // 34: notification.NotificationService.MarkAllRead:input_type -> notification.MarkAllReadRequest
+	7,  // 35: notification.NotificationService.CreateNotification:input_type -> notification.CreateNotificationRequest
+	8,  // 36: notification.NotificationService.DeleteNotification:input_type -> notification.DeleteNotificationRequest
+	14, // 37: notification.NotificationService.RegisterWebhook:input_type -> notification.RegisterWebhookRequest
+	15, // 38: notification.NotificationService.ListWebhooks:input_type -> notification.ListWebhooksRequest
+	17, // 39: notification.NotificationService.DeleteWebhook:input_type -> notification.DeleteWebhookRequest
+	18, // 40: notification.NotificationService.GetWebhookDeliveries:input_type -> notification.GetWebhookDeliveriesRequest
+	32, // 41: notification.NotificationService.RegisterDevice:input_type -> notification.RegisterDeviceRequest
+	33, // 42: notification.NotificationService.UnregisterDevice:input_type -> notification.UnregisterDeviceRequest
+	35, // 43: notification.NotificationService.GetPushPreferences:input_type -> notification.GetPushPreferencesRequest
+	36, // 44: notification.NotificationService.UpdatePushPreferences:input_type -> notification.UpdatePushPreferencesRequest
+	39, // 45: notification.NotificationService.GetPreferences:input_type -> notification.GetPreferencesRequest
+	40, // 46: notification.NotificationService.UpdatePreferences:input_type -> notification.UpdatePreferencesRequest
+	23, // 47: notification.NotificationService.ExportMyData:input_type -> notification.ExportMyDataRequest
+	43, // 48: notification.NotificationService.GetPresence:input_type -> notification.GetPresenceRequest
+	25, // 49: notification.NotificationService.PurgeNotifications:input_type -> notification.PurgeNotificationsRequest
+	28, // 50: notification.NotificationService.ListFailedEvents:input_type -> notification.ListFailedEventsRequest
+	30, // 51: notification.NotificationService.GetFailedEvent:input_type -> notification.GetFailedEventRequest
+	31, // 52: notification.NotificationService.ReplayFailedEvent:input_type -> notification.ReplayFailedEventRequest
+	13, // 53: notification.NotificationService.GetNotifications:output_type -> notification.NotificationConnection
+	4,  // 54: notification.NotificationService.GetUnreadCount:output_type -> notification.UnreadCount
+	22, // 55: notification.NotificationService.MarkRead:output_type -> notification.Response
+	22, // 56: notification.NotificationService.MarkAllRead:output_type -> notification.Response
+	9,  // 57: notification.NotificationService.CreateNotification:output_type -> notification.Notification
+	22, // 58: notification.NotificationService.DeleteNotification:output_type -> notification.Response
+	20, // 59: notification.NotificationService.RegisterWebhook:output_type -> notification.Webhook
+	16, // 60: notification.NotificationService.ListWebhooks:output_type -> notification.ListWebhooksResponse
+	22, // 61: notification.NotificationService.DeleteWebhook:output_type -> notification.Response
+	19, // 62: notification.NotificationService.GetWebhookDeliveries:output_type -> notification.GetWebhookDeliveriesResponse
+	34, // 63: notification.NotificationService.RegisterDevice:output_type -> notification.Device
+	22, // 64: notification.NotificationService.UnregisterDevice:output_type -> notification.Response
+	38, // 65: notification.NotificationService.GetPushPreferences:output_type -> notification.PushPreferences
+	38, // 66: notification.NotificationService.UpdatePushPreferences:output_type -> notification.PushPreferences
+	42, // 67: notification.NotificationService.GetPreferences:output_type -> notification.NotificationPreferences
+	42, // 68: notification.NotificationService.UpdatePreferences:output_type -> notification.NotificationPreferences
+	24, // 69: notification.NotificationService.ExportMyData:output_type -> notification.DataExport
+	45, // 70: notification.NotificationService.GetPresence:output_type -> notification.GetPresenceResponse
+	26, // 71: notification.NotificationService.PurgeNotifications:output_type -> notification.PurgeNotificationsResponse
+	29, // 72: notification.NotificationService.ListFailedEvents:output_type -> notification.ListFailedEventsResponse
+	27, // 73: notification.NotificationService.GetFailedEvent:output_type -> notification.FailedEvent
+	27, // 74: notification.NotificationService.ReplayFailedEvent:output_type -> notification.FailedEvent
+	53, // [53:75] is the sub-list for method output_type
+	31, // [31:53] is the sub-list for method input_type
+	31, // [31:31] is the sub-list for extension type_name
+	31, // [31:31] is the sub-list for extension extendee
+	0,  // [0:31] is the sub-list for field type_name
 }
 
 func init() { file_proto_notification_proto_init() }
@@ -815,16 +3147,24 @@ func file_proto_notification_proto_init() {
 		return
 	}
 	file_proto_notification_proto_msgTypes[0].OneofWrappers = []any{}
-	file_proto_notification_proto_msgTypes[3].OneofWrappers = []any{}
 	file_proto_notification_proto_msgTypes[5].OneofWrappers = []any{}
 	file_proto_notification_proto_msgTypes[7].OneofWrappers = []any{}
+	file_proto_notification_proto_msgTypes[10].OneofWrappers = []any{}
+	file_proto_notification_proto_msgTypes[18].OneofWrappers = []any{}
+	file_proto_notification_proto_msgTypes[19].OneofWrappers = []any{}
+	file_proto_notification_proto_msgTypes[23].OneofWrappers = []any{}
+	file_proto_notification_proto_msgTypes[25].OneofWrappers = []any{}
+	file_proto_notification_proto_msgTypes[30].OneofWrappers = []any{}
+	file_proto_notification_proto_msgTypes[38].OneofWrappers = []any{}
+	file_proto_notification_proto_msgTypes[40].OneofWrappers = []any{}
+	file_proto_notification_proto_msgTypes[42].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_notification_proto_rawDesc), len(file_proto_notification_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   10,
+			NumEnums:      2,
+			NumMessages:   45,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -0,0 +1,164 @@
+package push
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"notification-service/model"
+)
+
+const (
+	apnsProductionHost = "https://api.push.apple.com"
+	apnsSandboxHost    = "https://api.sandbox.push.apple.com"
+
+	// Apple rejects provider tokens older than an hour and throttles ones
+	// refreshed more often than every 20 minutes
+	apnsTokenLifetime = 50 * time.Minute
+)
+
+// APNsConfig identifies the app and the token signing key used with APNs
+type APNsConfig struct {
+	KeyFile    string // .p8 signing key downloaded from the Apple developer account
+	KeyID      string
+	TeamID     string
+	Topic      string // the app's bundle ID
+	Production bool
+	Timeout    time.Duration
+}
+
+// APNsSender delivers to iOS devices through the APNs HTTP/2 API with
+// token-based authentication
+type APNsSender struct {
+	cfg    APNsConfig
+	key    *ecdsa.PrivateKey
+	host   string
+	client *http.Client
+
+	mu       sync.Mutex
+	token    string
+	issuedAt time.Time
+}
+
+func NewAPNsSender(cfg APNsConfig) (*APNsSender, error) {
+	if cfg.KeyID == "" || cfg.TeamID == "" || cfg.Topic == "" {
+		return nil, fmt.Errorf("APNs needs a key ID, team ID and topic")
+	}
+
+	data, err := os.ReadFile(cfg.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read APNs key: %w", err)
+	}
+	key, err := jwt.ParseECPrivateKeyFromPEM(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid APNs key: %w", err)
+	}
+
+	host := apnsSandboxHost
+	if cfg.Production {
+		host = apnsProductionHost
+	}
+
+	return &APNsSender{
+		cfg:    cfg,
+		key:    key,
+		host:   host,
+		client: &http.Client{Timeout: cfg.Timeout},
+	}, nil
+}
+
+func (s *APNsSender) Send(ctx context.Context, device models.Device, msg Message) error {
+	token, err := s.providerToken()
+	if err != nil {
+		return err
+	}
+
+	payload := map[string]interface{}{
+		"aps": map[string]interface{}{
+			"alert": map[string]string{"title": msg.Title, "body": msg.Body},
+			"sound": "default",
+		},
+	}
+	for k, v := range msg.Data {
+		payload[k] = v
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.host+"/3/device/"+device.Token, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "bearer "+token)
+	req.Header.Set("apns-topic", s.cfg.Topic)
+	req.Header.Set("apns-push-type", "alert")
+	req.Header.Set("apns-priority", "10")
+	if msg.CollapseKey != "" && len(msg.CollapseKey) <= 64 {
+		req.Header.Set("apns-collapse-id", msg.CollapseKey)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusOK {
+		io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+
+	var apnsErr struct {
+		Reason string `json:"reason"`
+	}
+	json.NewDecoder(io.LimitReader(resp.Body, 64*1024)).Decode(&apnsErr)
+
+	switch {
+	case resp.StatusCode == http.StatusGone, apnsErr.Reason == "BadDeviceToken", apnsErr.Reason == "Unregistered":
+		return ErrUnregistered
+	case apnsErr.Reason == "ExpiredProviderToken":
+		s.mu.Lock()
+		s.token = ""
+		s.mu.Unlock()
+		return &statusError{code: http.StatusServiceUnavailable, reason: apnsErr.Reason}
+	}
+	return &statusError{code: resp.StatusCode, reason: apnsErr.Reason}
+}
+
+// providerToken returns the signed provider token, re-signing it once it is
+// close to expiring
+func (s *APNsSender) providerToken() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != "" && time.Since(s.issuedAt) < apnsTokenLifetime {
+		return s.token, nil
+	}
+
+	now := time.Now()
+	token := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.MapClaims{
+		"iss": s.cfg.TeamID,
+		"iat": now.Unix(),
+	})
+	token.Header["kid"] = s.cfg.KeyID
+
+	signed, err := token.SignedString(s.key)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign APNs provider token: %w", err)
+	}
+
+	s.token = signed
+	s.issuedAt = now
+	return s.token, nil
+}
@@ -0,0 +1,171 @@
+package push
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"notification-service/model"
+	"notification-service/repository"
+	"shared/logging"
+)
+
+type Config struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxConcurrency int
+}
+
+// Dispatcher pushes newly created notifications to the devices of their
+// recipient, honouring the recipient's push preferences and quiet hours and
+// retrying failed sends with exponential backoff. Devices whose token the
+// push service rejects are unregistered.
+type Dispatcher struct {
+	repo    repository.DeviceRepository
+	prefs   repository.PreferenceRepository
+	senders map[models.DevicePlatform]Sender
+	cfg     Config
+	sem     chan struct{}
+	wg      sync.WaitGroup
+}
+
+// NewDispatcher creates a dispatcher delivering through senders. Devices on a
+// platform without a sender are skipped.
+func NewDispatcher(repo repository.DeviceRepository, prefs repository.PreferenceRepository, senders map[models.DevicePlatform]Sender, cfg Config) *Dispatcher {
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = 3
+	}
+	if cfg.InitialBackoff <= 0 {
+		cfg.InitialBackoff = time.Second
+	}
+	if cfg.MaxConcurrency <= 0 {
+		cfg.MaxConcurrency = 50
+	}
+
+	return &Dispatcher{
+		repo:    repo,
+		prefs:   prefs,
+		senders: senders,
+		cfg:     cfg,
+		sem:     make(chan struct{}, cfg.MaxConcurrency),
+	}
+}
+
+// Push sends notification to every device of its recipient unless they have
+// turned push off for its type or are in their quiet hours. Sends run in the background; only lookup
+// errors are returned.
+func (d *Dispatcher) Push(ctx context.Context, notification *models.Notification) error {
+	if len(d.senders) == 0 {
+		return nil
+	}
+
+	preferences, err := d.prefs.Get(ctx, notification.UserID)
+	if err != nil {
+		return err
+	}
+	if preferences.InQuietHours(time.Now()) {
+		return nil
+	}
+
+	enabled, err := d.repo.IsPushEnabled(ctx, notification.UserID, notification.Type)
+	if err != nil || !enabled {
+		return err
+	}
+
+	devices, err := d.repo.ListByUserID(ctx, notification.UserID)
+	if err != nil {
+		return err
+	}
+
+	msg := messageFor(notification)
+	for _, device := range devices {
+		sender, ok := d.senders[device.Platform]
+		if !ok {
+			continue
+		}
+		d.wg.Add(1)
+		go d.deliver(ctx, sender, device, notification.ID, msg)
+	}
+
+	return nil
+}
+
+// Wait waits for the deliveries in progress to finish, or for ctx to be done
+func (d *Dispatcher) Wait(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		d.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (d *Dispatcher) deliver(ctx context.Context, sender Sender, device models.Device, notificationID uuid.UUID, msg Message) {
+	defer d.wg.Done()
+	d.sem <- struct{}{}
+	defer func() { <-d.sem }()
+
+	backoff := d.cfg.InitialBackoff
+	for attempt := 1; attempt <= d.cfg.MaxAttempts; attempt++ {
+		err := sender.Send(ctx, device, msg)
+		if err == nil {
+			return
+		}
+
+		if errors.Is(err, ErrUnregistered) {
+			logging.FromContext(ctx).Info().Err(err).Str("platform", string(device.Platform)).Stringer("device_id", device.ID).Msg("unregistering device")
+			if err := d.repo.DeleteByToken(ctx, device.Platform, device.Token); err != nil {
+				logging.FromContext(ctx).Error().Err(err).Stringer("device_id", device.ID).Msg("failed to unregister device")
+			}
+			return
+		}
+
+		if !retryable(err) || attempt == d.cfg.MaxAttempts {
+			logging.FromContext(ctx).Warn().Err(err).Stringer("notification_id", notificationID).Stringer("device_id", device.ID).Int("attempts", attempt).Msg("push failed")
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// messageFor builds the push message for a notification. Actor names are
+// resolved by the app from the data, so the body is the notification's own
+// message.
+func messageFor(n *models.Notification) Message {
+	data := map[string]string{
+		"notification_id": n.ID.String(),
+		"type":            string(n.Type),
+	}
+	if n.ActorID != nil {
+		data["actor_id"] = n.ActorID.String()
+	}
+	if n.RelatedID != nil {
+		data["related_id"] = n.RelatedID.String()
+	}
+
+	collapseKey := n.ID.String()
+	if n.GroupKey != nil {
+		collapseKey = *n.GroupKey
+	}
+
+	return Message{
+		Title:       "Muzeeng",
+		Body:        n.Message,
+		Data:        data,
+		CollapseKey: collapseKey,
+	}
+}
@@ -0,0 +1,56 @@
+package push
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"notification-service/model"
+)
+
+// ErrUnregistered is returned by a Sender when the push service reports that
+// the device token is no longer valid, so the device should be forgotten
+var ErrUnregistered = errors.New("device token is no longer registered")
+
+// Message is the platform-neutral content of a push notification
+type Message struct {
+	Title string
+	Body  string
+	// Data is delivered to the app alongside the alert
+	Data map[string]string
+	// CollapseKey lets the push service replace an earlier, undelivered
+	// message with the same key, e.g. a group's previous count
+	CollapseKey string
+}
+
+// Sender delivers a message to one device on a single push platform
+type Sender interface {
+	Send(ctx context.Context, device models.Device, msg Message) error
+}
+
+// statusError is a failed delivery the push service answered with an HTTP
+// status, which decides whether it is worth retrying
+type statusError struct {
+	code   int
+	reason string
+}
+
+func (e *statusError) Error() string {
+	if e.reason != "" {
+		return http.StatusText(e.code) + ": " + e.reason
+	}
+	return http.StatusText(e.code)
+}
+
+// retryable reports whether a failed send may succeed when tried again.
+// Rejected requests other than throttling are not retried.
+func retryable(err error) bool {
+	if errors.Is(err, ErrUnregistered) {
+		return false
+	}
+	var se *statusError
+	if errors.As(err, &se) {
+		return se.code == http.StatusTooManyRequests || se.code >= 500
+	}
+	return true
+}
@@ -0,0 +1,258 @@
+package push
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"notification-service/model"
+)
+
+// webPushRecordSize is the record size advertised in the aes128gcm header.
+// Payloads are sent as a single record, so it only has to exceed them.
+const webPushRecordSize = 4096
+
+// WebPushConfig holds the VAPID key pair identifying this server to browser
+// push services
+type WebPushConfig struct {
+	PublicKey  string // base64url uncompressed P-256 point, as given to browsers
+	PrivateKey string // base64url P-256 private scalar
+	Subject    string // mailto: or https: contact for the push service operator
+	TTL        time.Duration
+	Timeout    time.Duration
+}
+
+// WebPushSender delivers to browser push subscriptions, encrypting payloads
+// as specified by RFC 8291 and authenticating with VAPID (RFC 8292)
+type WebPushSender struct {
+	cfg       WebPushConfig
+	key       *ecdsa.PrivateKey
+	publicKey string
+	client    *http.Client
+}
+
+func NewWebPushSender(cfg WebPushConfig) (*WebPushSender, error) {
+	if cfg.Subject == "" {
+		return nil, fmt.Errorf("WebPush needs a VAPID subject")
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(cfg.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid VAPID private key: %w", err)
+	}
+	key, err := ecdsa.ParseRawPrivateKey(elliptic.P256(), raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid VAPID private key: %w", err)
+	}
+
+	public, err := key.PublicKey.Bytes()
+	if err != nil {
+		return nil, err
+	}
+	publicKey := base64.RawURLEncoding.EncodeToString(public)
+	if cfg.PublicKey != "" && cfg.PublicKey != publicKey {
+		return nil, fmt.Errorf("VAPID public key does not match the private key")
+	}
+
+	if cfg.TTL <= 0 {
+		cfg.TTL = 24 * time.Hour
+	}
+
+	return &WebPushSender{
+		cfg:       cfg,
+		key:       key,
+		publicKey: publicKey,
+		client:    &http.Client{Timeout: cfg.Timeout},
+	}, nil
+}
+
+func (s *WebPushSender) Send(ctx context.Context, device models.Device, msg Message) error {
+	if device.P256dh == nil || device.Auth == nil {
+		return ErrUnregistered
+	}
+
+	endpoint, err := url.Parse(device.Token)
+	if err != nil || endpoint.Scheme != "https" {
+		return ErrUnregistered
+	}
+
+	payload, err := json.Marshal(map[string]interface{}{
+		"title": msg.Title,
+		"body":  msg.Body,
+		"tag":   msg.CollapseKey,
+		"data":  msg.Data,
+	})
+	if err != nil {
+		return err
+	}
+
+	body, err := encryptWebPush(payload, *device.P256dh, *device.Auth)
+	if err != nil {
+		// The subscription's keys are unusable
+		return fmt.Errorf("%w: %v", ErrUnregistered, err)
+	}
+
+	authorization, err := s.vapidAuthorization(endpoint.Scheme + "://" + endpoint.Host)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, device.Token, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("Content-Encoding", "aes128gcm")
+	req.Header.Set("TTL", fmt.Sprintf("%d", int(s.cfg.TTL.Seconds())))
+	req.Header.Set("Urgency", "normal")
+	req.Header.Set("Authorization", authorization)
+	if topic := webPushTopic(msg.CollapseKey); topic != "" {
+		req.Header.Set("Topic", topic)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return nil
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone:
+		return ErrUnregistered
+	}
+	return &statusError{code: resp.StatusCode}
+}
+
+// vapidAuthorization returns the Authorization header value for a push
+// service at audience
+func (s *WebPushSender) vapidAuthorization(audience string) (string, error) {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.MapClaims{
+		"aud": audience,
+		"exp": time.Now().Add(12 * time.Hour).Unix(),
+		"sub": s.cfg.Subject,
+	}).SignedString(s.key)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign VAPID token: %w", err)
+	}
+	return fmt.Sprintf("vapid t=%s, k=%s", token, s.publicKey), nil
+}
+
+// webPushTopic turns a collapse key into a Topic header value, which push
+// services limit to 32 URL-safe base64 characters
+func webPushTopic(collapseKey string) string {
+	if collapseKey == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(collapseKey))
+	return base64.RawURLEncoding.EncodeToString(sum[:])[:32]
+}
+
+// encryptWebPush encrypts payload for the subscription with the given
+// p256dh public key and auth secret, returning the aes128gcm request body
+func encryptWebPush(payload []byte, p256dh, authSecret string) ([]byte, error) {
+	uaPublicBytes, err := decodeBase64URL(p256dh)
+	if err != nil {
+		return nil, fmt.Errorf("invalid p256dh key: %w", err)
+	}
+	auth, err := decodeBase64URL(authSecret)
+	if err != nil {
+		return nil, fmt.Errorf("invalid auth secret: %w", err)
+	}
+
+	uaPublic, err := ecdh.P256().NewPublicKey(uaPublicBytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid p256dh key: %w", err)
+	}
+
+	// A fresh key pair and salt per message
+	asPrivate, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	asPublicBytes := asPrivate.PublicKey().Bytes()
+
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+
+	sharedSecret, err := asPrivate.ECDH(uaPublic)
+	if err != nil {
+		return nil, err
+	}
+
+	keyInfo := append([]byte("WebPush: info\x00"), uaPublicBytes...)
+	keyInfo = append(keyInfo, asPublicBytes...)
+	prkKey, err := hkdf.Extract(sha256.New, sharedSecret, auth)
+	if err != nil {
+		return nil, err
+	}
+	ikm, err := hkdf.Expand(sha256.New, prkKey, string(keyInfo), 32)
+	if err != nil {
+		return nil, err
+	}
+
+	prk, err := hkdf.Extract(sha256.New, ikm, salt)
+	if err != nil {
+		return nil, err
+	}
+	cek, err := hkdf.Expand(sha256.New, prk, "Content-Encoding: aes128gcm\x00", 16)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := hkdf.Expand(sha256.New, prk, "Content-Encoding: nonce\x00", 12)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(cek)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	// A single, final record: the payload followed by the 0x02 delimiter
+	plaintext := append(append([]byte{}, payload...), 0x02)
+	if len(plaintext)+gcm.Overhead() > webPushRecordSize {
+		return nil, fmt.Errorf("payload too large")
+	}
+
+	header := make([]byte, 0, 16+4+1+len(asPublicBytes))
+	header = append(header, salt...)
+	header = binary.BigEndian.AppendUint32(header, webPushRecordSize)
+	header = append(header, byte(len(asPublicBytes)))
+	header = append(header, asPublicBytes...)
+
+	return gcm.Seal(header, nonce, plaintext, nil), nil
+}
+
+// decodeBase64URL accepts the padded and unpadded base64url encodings
+// browsers hand out subscription keys in
+func decodeBase64URL(s string) ([]byte, error) {
+	if b, err := base64.RawURLEncoding.DecodeString(s); err == nil {
+		return b, nil
+	}
+	return base64.URLEncoding.DecodeString(s)
+}
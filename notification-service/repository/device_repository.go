@@ -0,0 +1,170 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"notification-service/model"
+)
+
+type DeviceRepository interface {
+	Register(ctx context.Context, device *models.Device) error
+	Unregister(ctx context.Context, userID uuid.UUID, token string) error
+	DeleteByToken(ctx context.Context, platform models.DevicePlatform, token string) error
+	ListByUserID(ctx context.Context, userID uuid.UUID) ([]models.Device, error)
+	GetPushPreferences(ctx context.Context, userID uuid.UUID) ([]models.PushPreference, error)
+	SetPushPreferences(ctx context.Context, userID uuid.UUID, preferences []models.PushPreference) error
+	IsPushEnabled(ctx context.Context, userID uuid.UUID, notificationType models.NotificationType) (bool, error)
+	DeleteByUser(ctx context.Context, userID uuid.UUID) error
+}
+
+type deviceRepository struct {
+	db *sqlx.DB
+}
+
+func NewDeviceRepository(db *sqlx.DB) DeviceRepository {
+	return &deviceRepository{db: db}
+}
+
+// Register stores a device token for a user. A token is unique per platform,
+// so registering one that belongs to another user moves it to this user, as
+// happens when someone else signs in on the same device.
+func (r *deviceRepository) Register(ctx context.Context, device *models.Device) error {
+	query := `
+		INSERT INTO notification_service_devices (id, user_id, platform, token, p256dh, auth, created_at, last_seen_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $7)
+		ON CONFLICT (platform, token) DO UPDATE SET
+			user_id = EXCLUDED.user_id,
+			p256dh = EXCLUDED.p256dh,
+			auth = EXCLUDED.auth,
+			last_seen_at = EXCLUDED.last_seen_at
+		RETURNING id, created_at, last_seen_at
+	`
+
+	return r.db.QueryRowxContext(
+		ctx,
+		query,
+		device.ID,
+		device.UserID,
+		device.Platform,
+		device.Token,
+		device.P256dh,
+		device.Auth,
+		time.Now().UTC(),
+	).Scan(&device.ID, &device.CreatedAt, &device.LastSeenAt)
+}
+
+func (r *deviceRepository) Unregister(ctx context.Context, userID uuid.UUID, token string) error {
+	query := `DELETE FROM notification_service_devices WHERE user_id = $1 AND token = $2`
+
+	result, err := r.db.ExecContext(ctx, query, userID, token)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("device not found or unauthorized")
+	}
+
+	return nil
+}
+
+// DeleteByToken drops a token the push service reported as no longer valid
+func (r *deviceRepository) DeleteByToken(ctx context.Context, platform models.DevicePlatform, token string) error {
+	query := `DELETE FROM notification_service_devices WHERE platform = $1 AND token = $2`
+
+	_, err := r.db.ExecContext(ctx, query, platform, token)
+	return err
+}
+
+// DeleteByUser removes all of a user's devices and push preferences
+func (r *deviceRepository) DeleteByUser(ctx context.Context, userID uuid.UUID) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM notification_service_devices WHERE user_id = $1`, userID); err != nil {
+		return err
+	}
+
+	_, err := r.db.ExecContext(ctx, `DELETE FROM notification_service_push_preferences WHERE user_id = $1`, userID)
+	return err
+}
+
+func (r *deviceRepository) ListByUserID(ctx context.Context, userID uuid.UUID) ([]models.Device, error) {
+	query := `
+		SELECT id, user_id, platform, token, p256dh, auth, created_at, last_seen_at
+		FROM notification_service_devices
+		WHERE user_id = $1
+		ORDER BY last_seen_at DESC
+	`
+
+	var devices []models.Device
+	err := r.db.SelectContext(ctx, &devices, query, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return devices, nil
+}
+
+// GetPushPreferences returns the preferences the user has stored; types
+// missing from the result are pushed
+func (r *deviceRepository) GetPushPreferences(ctx context.Context, userID uuid.UUID) ([]models.PushPreference, error) {
+	query := `
+		SELECT type, enabled
+		FROM notification_service_push_preferences
+		WHERE user_id = $1
+		ORDER BY type
+	`
+
+	var preferences []models.PushPreference
+	err := r.db.SelectContext(ctx, &preferences, query, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return preferences, nil
+}
+
+func (r *deviceRepository) SetPushPreferences(ctx context.Context, userID uuid.UUID, preferences []models.PushPreference) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO notification_service_push_preferences (user_id, type, enabled, updated_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_id, type) DO UPDATE SET
+			enabled = EXCLUDED.enabled,
+			updated_at = EXCLUDED.updated_at
+	`
+
+	now := time.Now().UTC()
+	for _, preference := range preferences {
+		if _, err := tx.ExecContext(ctx, query, userID, preference.Type, preference.Enabled, now); err != nil {
+			return fmt.Errorf("failed to set push preference: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (r *deviceRepository) IsPushEnabled(ctx context.Context, userID uuid.UUID, notificationType models.NotificationType) (bool, error) {
+	query := `
+		SELECT COALESCE(
+			(SELECT enabled FROM notification_service_push_preferences WHERE user_id = $1 AND type = $2),
+			TRUE
+		)
+	`
+
+	var enabled bool
+	err := r.db.GetContext(ctx, &enabled, query, userID, notificationType)
+	return enabled, err
+}
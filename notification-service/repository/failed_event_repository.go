@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"notification-service/model"
+)
+
+// ErrFailedEventNotFound is returned for a failed event that does not exist
+var ErrFailedEventNotFound = errors.New("failed event not found")
+
+// FailedEventRepository stores the events the subscriber gave up on
+type FailedEventRepository interface {
+	Create(ctx context.Context, event *models.FailedEvent) (bool, error)
+	Get(ctx context.Context, id uuid.UUID) (*models.FailedEvent, error)
+	List(ctx context.Context, includeReplayed bool, limit int) ([]models.FailedEvent, error)
+	MarkReplayed(ctx context.Context, id uuid.UUID, replayErr error) error
+}
+
+type failedEventRepository struct {
+	db *sqlx.DB
+}
+
+func NewFailedEventRepository(db *sqlx.DB) FailedEventRepository {
+	return &failedEventRepository{db: db}
+}
+
+const failedEventColumns = `id, delivery_id, subject, consumer, payload, headers, error, attempts,
+	failed_at, replayed_at, replay_error`
+
+// Create stores a failed event, and reports whether it is new; a dead letter
+// delivered again stores nothing
+func (r *failedEventRepository) Create(ctx context.Context, event *models.FailedEvent) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `
+		INSERT INTO notification_service_failed_events
+			(id, delivery_id, subject, consumer, payload, headers, error, attempts, failed_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (delivery_id) DO NOTHING
+	`, event.ID, event.DeliveryID, event.Subject, event.Consumer, event.Payload, event.Headers,
+		event.Error, event.Attempts, event.FailedAt)
+	if err != nil {
+		return false, fmt.Errorf("failed to store failed event: %w", err)
+	}
+	created, err := result.RowsAffected()
+	return created > 0, err
+}
+
+func (r *failedEventRepository) Get(ctx context.Context, id uuid.UUID) (*models.FailedEvent, error) {
+	var event models.FailedEvent
+	err := r.db.GetContext(ctx, &event, `SELECT `+failedEventColumns+` FROM notification_service_failed_events WHERE id = $1`, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrFailedEventNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get failed event: %w", err)
+	}
+	return &event, nil
+}
+
+// List returns the most recent failed events, leaving out those replayed
+// successfully unless includeReplayed is set
+func (r *failedEventRepository) List(ctx context.Context, includeReplayed bool, limit int) ([]models.FailedEvent, error) {
+	var events []models.FailedEvent
+	err := r.db.SelectContext(ctx, &events, `
+		SELECT `+failedEventColumns+`
+		FROM notification_service_failed_events
+		WHERE $1 OR replayed_at IS NULL
+		ORDER BY failed_at DESC
+		LIMIT $2
+	`, includeReplayed, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list failed events: %w", err)
+	}
+	return events, nil
+}
+
+// MarkReplayed records the outcome of replaying an event. A failed replay
+// keeps the event pending with the error it failed with.
+func (r *failedEventRepository) MarkReplayed(ctx context.Context, id uuid.UUID, replayErr error) error {
+	var err error
+	if replayErr == nil {
+		_, err = r.db.ExecContext(ctx, `
+			UPDATE notification_service_failed_events SET replayed_at = NOW(), replay_error = NULL WHERE id = $1
+		`, id)
+	} else {
+		_, err = r.db.ExecContext(ctx, `
+			UPDATE notification_service_failed_events SET replay_error = $2 WHERE id = $1
+		`, id, replayErr.Error())
+	}
+	if err != nil {
+		return fmt.Errorf("failed to record replay: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,188 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"notification-service/model"
+)
+
+// Notifications with a group key are folded into a single row per recipient
+// while it is unread: a new event bumps actor_count, puts its actor at the
+// front of latest_actors and moves the row to the top of the list. Once the
+// row is read the next event starts a new group. Grouped events are recorded
+// in notification_service_notification_group_events, keyed by the event's
+// deterministic notification ID, so a redelivered event is not counted twice
+// and a retracted event can be taken back out of its group.
+
+// insertNotification stores notification, folding it into the recipient's
+// unread group when it has a group key. It returns the ID of the row that now
+// holds the notification and whether anything changed; a redelivered event
+// changes nothing.
+func insertNotification(ctx context.Context, db *sqlx.DB, notification *models.Notification) (uuid.UUID, bool, error) {
+	if notification.GroupKey == nil {
+		result, err := db.ExecContext(ctx, `
+			INSERT INTO notification_service_notifications (id, user_id, type, message, actor_id, related_id, is_read, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			ON CONFLICT (id) DO NOTHING
+		`, notification.ID, notification.UserID, notification.Type, notification.Message,
+			notification.ActorID, notification.RelatedID, notification.IsRead, notification.CreatedAt)
+		if err != nil {
+			return uuid.Nil, false, err
+		}
+		created, err := result.RowsAffected()
+		return notification.ID, created > 0, err
+	}
+
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return uuid.Nil, false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Notifications recorded one row per event before grouping existed are
+	// left alone too
+	result, err := tx.ExecContext(ctx, `
+		INSERT INTO notification_service_notification_group_events (event_id)
+		SELECT $1
+		WHERE NOT EXISTS (SELECT 1 FROM notification_service_notifications WHERE id = $1 AND group_key IS NULL)
+		ON CONFLICT (event_id) DO NOTHING
+	`, notification.ID)
+	if err != nil {
+		return uuid.Nil, false, fmt.Errorf("failed to record grouped event: %w", err)
+	}
+	if n, err := result.RowsAffected(); err != nil || n == 0 {
+		return uuid.Nil, false, err
+	}
+
+	latestActors := models.ActorIDs{}
+	if notification.ActorID != nil {
+		latestActors = models.ActorIDs{*notification.ActorID}
+	}
+
+	var groupID uuid.UUID
+	err = tx.GetContext(ctx, &groupID, fmt.Sprintf(`
+		INSERT INTO notification_service_notifications AS n
+			(id, user_id, type, message, actor_id, related_id, is_read, created_at, group_key, actor_count, latest_actors)
+		VALUES (
+			-- A group is named after its first event, unless that event was
+			-- retracted and its ID still names a group
+			CASE WHEN EXISTS (SELECT 1 FROM notification_service_notifications WHERE id = $1)
+				THEN uuid_generate_v4() ELSE $1 END,
+			$2, $3, $4, $5, $6, $7, $8, $9, 1, $10)
+		ON CONFLICT (user_id, group_key) WHERE group_key IS NOT NULL AND NOT is_read DO UPDATE SET
+			actor_id = EXCLUDED.actor_id,
+			actor_count = n.actor_count + 1,
+			latest_actors = jsonb_path_query_array(
+				EXCLUDED.latest_actors || (n.latest_actors - COALESCE(EXCLUDED.actor_id::text, '')),
+				'$[0 to %d]'
+			),
+			created_at = GREATEST(n.created_at, EXCLUDED.created_at)
+		RETURNING id
+	`, models.MaxLatestActors-1), notification.ID, notification.UserID, notification.Type, notification.Message,
+		notification.ActorID, notification.RelatedID, notification.IsRead, notification.CreatedAt,
+		notification.GroupKey, latestActors)
+	if err != nil {
+		return uuid.Nil, false, fmt.Errorf("failed to group notification: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE notification_service_notification_group_events SET notification_id = $2 WHERE event_id = $1
+	`, notification.ID, groupID)
+	if err != nil {
+		return uuid.Nil, false, fmt.Errorf("failed to record grouped event: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return uuid.Nil, false, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return groupID, true, nil
+}
+
+// retractNotification takes the event with the given notification ID back
+// out of the notification it was recorded in: a grouped notification loses
+// the actor and is deleted with its last one, and a notification of its own
+// is deleted. It returns the notification the event was retracted from, not
+// found when there was nothing to retract.
+func retractNotification(ctx context.Context, db *sqlx.DB, eventID, actorID uuid.UUID) (retracted, error) {
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return retracted{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var groupID uuid.NullUUID
+	err = tx.GetContext(ctx, &groupID, `
+		DELETE FROM notification_service_notification_group_events
+		WHERE event_id = $1
+		RETURNING notification_id
+	`, eventID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return retracted{}, fmt.Errorf("failed to retract grouped event: %w", err)
+	}
+
+	var r retracted
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		// Recorded before grouping existed, or not grouped at all
+		err = tx.GetContext(ctx, &r, `
+			DELETE FROM notification_service_notifications
+			WHERE id = $1 AND group_key IS NULL
+			RETURNING id, user_id
+		`, eventID)
+	case groupID.Valid:
+		err = tx.GetContext(ctx, &r, `
+			UPDATE notification_service_notifications
+			SET actor_count = actor_count - 1,
+				latest_actors = latest_actors - $2::text,
+				actor_id = CASE WHEN actor_id = $2::uuid THEN ((latest_actors - $2::text) ->> 0)::uuid ELSE actor_id END
+			WHERE id = $1
+			RETURNING id, user_id, actor_count
+		`, groupID.UUID, actorID)
+		if err == nil && r.ActorCount <= 0 {
+			_, err = tx.ExecContext(ctx, `DELETE FROM notification_service_notifications WHERE id = $1`, r.ID)
+		}
+	default:
+		err = sql.ErrNoRows
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		return retracted{}, tx.Commit()
+	}
+	if err != nil {
+		return retracted{}, fmt.Errorf("failed to retract notification: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return retracted{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	r.Found = true
+	return r, nil
+}
+
+// retracted is the notification an event was retracted from
+type retracted struct {
+	ID         uuid.UUID `db:"id"`
+	UserID     uuid.UUID `db:"user_id"`
+	ActorCount int       `db:"actor_count"`
+	Found      bool      `db:"-"`
+}
+
+// RetractNotification retracts an event the way Retract does, without
+// touching any cache, and returns the recipient of the notification it was
+// retracted from. It is used to rebuild notifications from replayed events.
+func RetractNotification(ctx context.Context, db *sql.DB, eventID, actorID uuid.UUID) (uuid.UUID, bool, error) {
+	r, err := retractNotification(ctx, sqlx.NewDb(db, "postgres"), eventID, actorID)
+	return r.UserID, r.Found, err
+}
+
+// InsertNotification stores notification the way Create does, without
+// touching any cache. It is used to rebuild notifications from replayed
+// events.
+func InsertNotification(ctx context.Context, db *sql.DB, notification *models.Notification) (bool, error) {
+	_, created, err := insertNotification(ctx, sqlx.NewDb(db, "postgres"), notification)
+	return created, err
+}
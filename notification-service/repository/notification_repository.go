@@ -3,15 +3,17 @@ package repository
 import (
 	"context"
 	"database/sql"
-	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
-	"github.com/jmoiron/sqlx"
 	"github.com/redis/go-redis/v9"
+	"golang.org/x/sync/singleflight"
+	"notification-service/db"
 	"notification-service/model"
+	"shared/cursor"
 )
 
 const (
@@ -24,58 +26,86 @@ const (
 	unreadCountPrefix  = "notif:unread:"
 	notificationPrefix = "notif:id:"
 	userNotifsPrefix   = "notif:user:"
+
+	// loadTimeout bounds a shared cache load, which no longer follows the
+	// cancellation of the request that started it
+	loadTimeout = 10 * time.Second
 )
 
 type NotificationRepository interface {
-	Create(ctx context.Context, notification *models.Notification) error
+	Create(ctx context.Context, notification *models.Notification) (bool, error)
+	Retract(ctx context.Context, eventID, actorID uuid.UUID) (bool, error)
 	GetByID(ctx context.Context, id uuid.UUID) (*models.Notification, error)
 	GetByUserID(ctx context.Context, userID uuid.UUID, first int, after *string) (*models.NotificationConnection, error)
 	MarkAsRead(ctx context.Context, notificationID, userID uuid.UUID) error
 	MarkAllAsRead(ctx context.Context, userID uuid.UUID) error
-	Delete(ctx context.Context, id uuid.UUID) error
+	Delete(ctx context.Context, id, userID uuid.UUID) error
 	GetUnreadCount(ctx context.Context, userID uuid.UUID) (int32, error)
+	DeleteReadBefore(ctx context.Context, before time.Time) (int64, error)
+	DeleteByPost(ctx context.Context, postID uuid.UUID) (int64, error)
+	DeleteByUser(ctx context.Context, userID uuid.UUID) (int64, error)
 }
 
 type notificationRepository struct {
-	db    *sqlx.DB
+	db    *database.DB
 	redis *redis.Client
+
+	// loads collapses concurrent cache misses for the same key into one query
+	loads singleflight.Group
+	// earlyRefreshBeta enables probabilistic early refresh of cached first pages when > 0
+	earlyRefreshBeta float64
+	// lastLoad is the duration of the most recent first page load in nanoseconds
+	lastLoad atomic.Int64
 }
 
-func NewNotificationRepository(db *sqlx.DB, redisClient *redis.Client) NotificationRepository {
+func NewNotificationRepository(db *database.DB, redisClient *redis.Client, earlyRefreshBeta float64) NotificationRepository {
 	return &notificationRepository{
-		db:    db,
-		redis: redisClient,
+		db:               db,
+		redis:            redisClient,
+		earlyRefreshBeta: earlyRefreshBeta,
 	}
 }
 
-func (r *notificationRepository) Create(ctx context.Context, notification *models.Notification) error {
-	query := `
-		INSERT INTO notification_service_notifications (id, user_id, type, message, actor_id, related_id, is_read, created_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
-	`
-
-	_, err := r.db.ExecContext(
-		ctx,
-		query,
-		notification.ID,
-		notification.UserID,
-		notification.Type,
-		notification.Message,
-		notification.ActorID,
-		notification.RelatedID,
-		notification.IsRead,
-		notification.CreatedAt,
-	)
-
+// Create stores a notification and reports whether it is new. A grouped
+// notification folded into an existing row takes that row's ID.
+func (r *notificationRepository) Create(ctx context.Context, notification *models.Notification) (bool, error) {
+	id, created, err := insertNotification(ctx, r.db.WriteDB(), notification)
 	if err != nil {
-		return err
+		return false, err
+	}
+
+	// Event-driven notifications have deterministic IDs, so a redelivered
+	// event lands here and must leave the caches alone
+	if !created {
+		return false, nil
 	}
 
 	r.invalidateUserCaches(ctx, notification.UserID)
 
+	// A grouped notification may have been folded into an existing row
+	if notification.GroupKey != nil {
+		notification.ID = id
+		r.redis.Del(ctx, notificationPrefix+id.String())
+		return true, nil
+	}
 	r.cacheNotification(ctx, notification)
 
-	return nil
+	return true, nil
+}
+
+// Retract takes the event whose notification had eventID, e.g. a like since
+// removed, back out of the notification holding it. It reports false when
+// there was nothing to retract, such as for a redelivered event.
+func (r *notificationRepository) Retract(ctx context.Context, eventID, actorID uuid.UUID) (bool, error) {
+	retracted, err := retractNotification(ctx, r.db.WriteDB(), eventID, actorID)
+	if err != nil || !retracted.Found {
+		return false, err
+	}
+
+	r.invalidateUserCaches(ctx, retracted.UserID)
+	r.redis.Del(ctx, notificationPrefix+retracted.ID.String())
+
+	return true, nil
 }
 
 func (r *notificationRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Notification, error) {
@@ -89,7 +119,8 @@ func (r *notificationRepository) GetByID(ctx context.Context, id uuid.UUID) (*mo
 	}
 
 	query := `
-		SELECT id, user_id, type, message, actor_id, related_id, is_read, created_at
+		SELECT id, user_id, type, message, actor_id, related_id, is_read, created_at,
+			group_key, actor_count, latest_actors
 		FROM notification_service_notifications
 		WHERE id = $1
 	`
@@ -109,53 +140,91 @@ func (r *notificationRepository) GetByID(ctx context.Context, id uuid.UUID) (*mo
 }
 
 func (r *notificationRepository) GetByUserID(ctx context.Context, userID uuid.UUID, first int, after *string) (*models.NotificationConnection, error) {
-	cacheKey := fmt.Sprintf("%s%s:first:%d", userNotifsPrefix, userID.String(), first)
+	// Only the first page is cached
 	if after != nil && *after != "" {
-		cacheKey += ":after:" + *after
+		return r.loadUserNotifications(ctx, userID, first, after)
 	}
 
-	if after == nil || *after == "" {
-		cached, err := r.redis.Get(ctx, cacheKey).Result()
-		if err == nil {
-			var connection models.NotificationConnection
-			if err := json.Unmarshal([]byte(cached), &connection); err == nil {
-				return &connection, nil
-			}
+	cacheKey := fmt.Sprintf("%s%s:first:%d", userNotifsPrefix, userID.String(), first)
+	cached, err := r.redis.Get(ctx, cacheKey).Result()
+	if err == nil {
+		var connection models.NotificationConnection
+		if err := json.Unmarshal([]byte(cached), &connection); err == nil {
+			r.maybeRefreshFirstPage(ctx, cacheKey, userID, first)
+			return &connection, nil
 		}
 	}
 
+	return r.refreshFirstPage(ctx, cacheKey, userID, first)
+}
+
+// refreshFirstPage loads and caches the first page of a user's notifications.
+// Concurrent callers for the same key share a single load.
+func (r *notificationRepository) refreshFirstPage(ctx context.Context, cacheKey string, userID uuid.UUID, first int) (*models.NotificationConnection, error) {
+	v, err, _ := r.loads.Do(cacheKey, func() (interface{}, error) {
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), loadTimeout)
+		defer cancel()
+
+		start := time.Now()
+		connection, err := r.loadUserNotifications(ctx, userID, first, nil)
+		if err != nil {
+			return nil, err
+		}
+		r.lastLoad.Store(int64(time.Since(start)))
+
+		if data, err := json.Marshal(connection); err == nil {
+			r.redis.Set(ctx, cacheKey, data, userNotificationsTTL)
+		}
+		return connection, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return v.(*models.NotificationConnection), nil
+}
+
+// maybeRefreshFirstPage reloads a cached first page in the background when
+// early refresh is enabled and the key is close enough to expiring
+func (r *notificationRepository) maybeRefreshFirstPage(ctx context.Context, cacheKey string, userID uuid.UUID, first int) {
+	if r.earlyRefreshBeta <= 0 {
+		return
+	}
+
+	ttl, err := r.redis.TTL(ctx, cacheKey).Result()
+	if err != nil {
+		return
+	}
+
+	if shouldRefreshEarly(ttl, time.Duration(r.lastLoad.Load()), r.earlyRefreshBeta) {
+		go func() {
+			_, _ = r.refreshFirstPage(context.Background(), cacheKey, userID, first)
+		}()
+	}
+}
+
+// loadUserNotifications reads one page of a user's notifications from the database
+func (r *notificationRepository) loadUserNotifications(ctx context.Context, userID uuid.UUID, first int, after *string) (*models.NotificationConnection, error) {
 	var notifications []models.Notification
 	var totalCount int32
-	var args []interface{}
-	argIndex := 1
 
 	countQuery := `SELECT COUNT(*) FROM notification_service_notifications WHERE user_id = $1`
-	err := r.db.GetContext(ctx, &totalCount, countQuery, userID)
+	err := r.db.ReadDB().GetContext(ctx, &totalCount, countQuery, userID)
 	if err != nil {
 		return nil, err
 	}
 
-	query := `
-		SELECT id, user_id, type, message, actor_id, related_id, is_read, created_at
+	query, args, err := cursor.Keyset{TimeColumn: "created_at", IDColumn: "id"}.Page(`
+		SELECT id, user_id, type, message, actor_id, related_id, is_read, created_at,
+			group_key, actor_count, latest_actors
 		FROM notification_service_notifications
-		WHERE user_id = $` + fmt.Sprintf("%d", argIndex)
-	args = append(args, userID)
-	argIndex++
-
-	if after != nil && *after != "" {
-		cursorTime, err := decodeCursor(*after)
-		if err != nil {
-			return nil, fmt.Errorf("invalid cursor: %w", err)
-		}
-		query += fmt.Sprintf(" AND created_at < $%d", argIndex)
-		args = append(args, cursorTime)
-		argIndex++
+		WHERE user_id = $1
+	`, []interface{}{userID}, after, first)
+	if err != nil {
+		return nil, err
 	}
 
-	query += fmt.Sprintf(" ORDER BY created_at DESC LIMIT $%d", argIndex)
-	args = append(args, first+1)
-
-	err = r.db.SelectContext(ctx, &notifications, query, args...)
+	err = r.db.ReadDB().SelectContext(ctx, &notifications, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -165,16 +234,12 @@ func (r *notificationRepository) GetByUserID(ctx context.Context, userID uuid.UU
 		return nil, err
 	}
 
-	hasNextPage := len(notifications) > first
-	if hasNextPage {
-		notifications = notifications[:first]
-	}
+	notifications, hasNextPage := cursor.Trim(notifications, first)
 
 	edges := make([]models.NotificationEdge, len(notifications))
 	for i, notification := range notifications {
-		cursor := encodeCursor(notification.CreatedAt)
 		edges[i] = models.NotificationEdge{
-			Cursor: cursor,
+			Cursor: cursor.EncodeKeyset(notification.CreatedAt, notification.ID),
 			Node:   notification,
 		}
 	}
@@ -199,12 +264,6 @@ func (r *notificationRepository) GetByUserID(ctx context.Context, userID uuid.UU
 		UnreadCount: unreadCount,
 	}
 
-	if after == nil || *after == "" {
-		if data, err := json.Marshal(connection); err == nil {
-			r.redis.Set(ctx, cacheKey, data, userNotificationsTTL)
-		}
-	}
-
 	return connection, nil
 }
 
@@ -252,15 +311,24 @@ func (r *notificationRepository) MarkAllAsRead(ctx context.Context, userID uuid.
 	return nil
 }
 
-func (r *notificationRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	notification, err := r.GetByID(ctx, id)
+// DeleteReadBefore removes read notifications created before the given time.
+// Unread counts are unaffected, and cached first pages only hold recent
+// notifications, so no cache entries need to be evicted.
+func (r *notificationRepository) DeleteReadBefore(ctx context.Context, before time.Time) (int64, error) {
+	query := `DELETE FROM notification_service_notifications WHERE is_read = true AND created_at < $1`
+
+	result, err := r.db.ExecContext(ctx, query, before)
 	if err != nil {
-		return err
+		return 0, fmt.Errorf("failed to delete read notifications: %w", err)
 	}
+	return result.RowsAffected()
+}
 
-	query := `DELETE FROM notification_service_notifications WHERE id = $1`
+// Delete removes a notification of userID
+func (r *notificationRepository) Delete(ctx context.Context, id, userID uuid.UUID) error {
+	query := `DELETE FROM notification_service_notifications WHERE id = $1 AND user_id = $2`
 
-	result, err := r.db.ExecContext(ctx, query, id)
+	result, err := r.db.ExecContext(ctx, query, id, userID)
 	if err != nil {
 		return err
 	}
@@ -271,15 +339,75 @@ func (r *notificationRepository) Delete(ctx context.Context, id uuid.UUID) error
 	}
 
 	if rows == 0 {
-		return fmt.Errorf("notification not found")
+		return fmt.Errorf("notification not found or unauthorized")
 	}
 
-	r.invalidateUserCaches(ctx, notification.UserID)
+	r.invalidateUserCaches(ctx, userID)
 	r.redis.Del(ctx, notificationPrefix+id.String())
 
 	return nil
 }
 
+// DeleteByPost removes the notifications about a post: the post itself and
+// the likes, comments, replies, mentions and reposts it received. Deleting
+// them again removes nothing.
+func (r *notificationRepository) DeleteByPost(ctx context.Context, postID uuid.UUID) (int64, error) {
+	query := `
+		DELETE FROM notification_service_notifications
+		WHERE related_id = $1 AND type IN ('POST', 'COMMENT', 'REPLY', 'MENTION', 'REPOST', 'LIKE')
+		RETURNING id, user_id
+	`
+
+	var deleted []struct {
+		ID     uuid.UUID `db:"id"`
+		UserID uuid.UUID `db:"user_id"`
+	}
+	if err := r.db.SelectContext(ctx, &deleted, query, postID); err != nil {
+		return 0, fmt.Errorf("failed to delete notifications of post: %w", err)
+	}
+
+	users := make(map[uuid.UUID]bool)
+	for _, n := range deleted {
+		r.redis.Del(ctx, notificationPrefix+n.ID.String())
+		if !users[n.UserID] {
+			users[n.UserID] = true
+			r.invalidateUserCaches(ctx, n.UserID)
+		}
+	}
+
+	return int64(len(deleted)), nil
+}
+
+// DeleteByUser removes the notifications a user received and those about
+// something only they did. Deleting them again removes nothing.
+func (r *notificationRepository) DeleteByUser(ctx context.Context, userID uuid.UUID) (int64, error) {
+	query := `
+		DELETE FROM notification_service_notifications
+		WHERE user_id = $1 OR (actor_id = $1 AND actor_count = 1)
+		RETURNING id, user_id
+	`
+
+	var deleted []struct {
+		ID     uuid.UUID `db:"id"`
+		UserID uuid.UUID `db:"user_id"`
+	}
+	if err := r.db.SelectContext(ctx, &deleted, query, userID); err != nil {
+		return 0, fmt.Errorf("failed to delete notifications of user: %w", err)
+	}
+
+	users := map[uuid.UUID]bool{userID: true}
+	r.invalidateUserCaches(ctx, userID)
+	for _, n := range deleted {
+		r.redis.Del(ctx, notificationPrefix+n.ID.String())
+		if !users[n.UserID] {
+			users[n.UserID] = true
+			r.invalidateUserCaches(ctx, n.UserID)
+		}
+	}
+
+	return int64(len(deleted)), nil
+}
+
 func (r *notificationRepository) GetUnreadCount(ctx context.Context, userID uuid.UUID) (int32, error) {
 	cacheKey := unreadCountPrefix + userID.String()
 	cached, err := r.redis.Get(ctx, cacheKey).Result()
@@ -290,17 +418,25 @@ func (r *notificationRepository) GetUnreadCount(ctx context.Context, userID uuid
 		}
 	}
 
-	query := `SELECT COUNT(*) FROM notification_service_notifications WHERE user_id = $1 AND is_read = false`
+	v, err, _ := r.loads.Do(cacheKey, func() (interface{}, error) {
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), loadTimeout)
+		defer cancel()
+
+		query := `SELECT COUNT(*) FROM notification_service_notifications WHERE user_id = $1 AND is_read = false`
+
+		var count int32
+		if err := r.db.GetContext(ctx, &count, query, userID); err != nil {
+			return nil, err
+		}
 
-	var count int32
-	err = r.db.GetContext(ctx, &count, query, userID)
+		r.redis.Set(ctx, cacheKey, fmt.Sprintf("%d", count), unreadCountTTL)
+		return count, nil
+	})
 	if err != nil {
 		return 0, err
 	}
 
-	r.redis.Set(ctx, cacheKey, fmt.Sprintf("%d", count), unreadCountTTL)
-
-	return count, nil
+	return v.(int32), nil
 }
 
 // Helper functions for caching
@@ -321,16 +457,3 @@ func (r *notificationRepository) invalidateUserCaches(ctx context.Context, userI
 		r.redis.Del(ctx, iter.Val())
 	}
 }
-
-// Helper functions for cursor encoding/decoding
-func encodeCursor(t time.Time) string {
-	return base64.StdEncoding.EncodeToString([]byte(t.Format(time.RFC3339Nano)))
-}
-
-func decodeCursor(cursor string) (time.Time, error) {
-	decoded, err := base64.StdEncoding.DecodeString(cursor)
-	if err != nil {
-		return time.Time{}, err
-	}
-	return time.Parse(time.RFC3339Nano, string(decoded))
-}
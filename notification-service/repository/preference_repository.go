@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"notification-service/model"
+)
+
+type PreferenceRepository interface {
+	Get(ctx context.Context, userID uuid.UUID) (*models.NotificationPreferences, error)
+	Update(ctx context.Context, preferences *models.NotificationPreferences) error
+	Delete(ctx context.Context, userID uuid.UUID) error
+}
+
+type preferenceRepository struct {
+	db *sqlx.DB
+}
+
+func NewPreferenceRepository(db *sqlx.DB) PreferenceRepository {
+	return &preferenceRepository{db: db}
+}
+
+// Get returns the user's preferences, or the defaults if they never set any
+func (r *preferenceRepository) Get(ctx context.Context, userID uuid.UUID) (*models.NotificationPreferences, error) {
+	return getPreferences(ctx, r.db, userID)
+}
+
+// Update replaces the user's preferences
+func (r *preferenceRepository) Update(ctx context.Context, preferences *models.NotificationPreferences) error {
+	query := `
+		INSERT INTO notification_service_preferences (user_id, disabled_types, quiet_hours_start, quiet_hours_end, time_zone, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (user_id) DO UPDATE SET
+			disabled_types = EXCLUDED.disabled_types,
+			quiet_hours_start = EXCLUDED.quiet_hours_start,
+			quiet_hours_end = EXCLUDED.quiet_hours_end,
+			time_zone = EXCLUDED.time_zone,
+			updated_at = EXCLUDED.updated_at
+	`
+
+	preferences.UpdatedAt = time.Now().UTC()
+	_, err := r.db.ExecContext(
+		ctx,
+		query,
+		preferences.UserID,
+		preferences.DisabledTypes,
+		preferences.QuietHoursStart,
+		preferences.QuietHoursEnd,
+		preferences.TimeZone,
+		preferences.UpdatedAt,
+	)
+	return err
+}
+
+// Delete removes the user's preferences, leaving them with the defaults
+func (r *preferenceRepository) Delete(ctx context.Context, userID uuid.UUID) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM notification_service_preferences WHERE user_id = $1`, userID)
+	return err
+}
+
+func getPreferences(ctx context.Context, db *sqlx.DB, userID uuid.UUID) (*models.NotificationPreferences, error) {
+	query := `
+		SELECT user_id, disabled_types::text[] AS disabled_types, quiet_hours_start, quiet_hours_end, time_zone, updated_at
+		FROM notification_service_preferences
+		WHERE user_id = $1
+	`
+
+	var preferences models.NotificationPreferences
+	err := db.GetContext(ctx, &preferences, query, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.DefaultNotificationPreferences(userID), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &preferences, nil
+}
+
+// GetPreferences looks up a user's preferences the way PreferenceRepository
+// does. It is used to honour them when rebuilding notifications from
+// replayed events.
+func GetPreferences(ctx context.Context, db *sql.DB, userID uuid.UUID) (*models.NotificationPreferences, error) {
+	return getPreferences(ctx, sqlx.NewDb(db, "postgres"), userID)
+}
@@ -0,0 +1,165 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"notification-service/model"
+)
+
+const (
+	// presenceOnlineKey is a sorted set of the users announced online,
+	// scored by their latest heartbeat in Unix milliseconds
+	presenceOnlineKey = "presence:online"
+	// presenceLastSeenKey is a hash of every user's latest heartbeat, in Unix
+	// milliseconds, kept after they go offline
+	presenceLastSeenKey = "presence:last_seen"
+)
+
+// PresenceRepository tracks which users are online from the heartbeats of
+// their gateway connections. A user is online while their key,
+// presence:<user-id>, has not expired.
+type PresenceRepository interface {
+	// Heartbeat keeps a user online for the TTL and reports whether they
+	// were offline before
+	Heartbeat(ctx context.Context, userID uuid.UUID, at time.Time) (bool, error)
+	Get(ctx context.Context, userIDs []uuid.UUID) ([]models.Presence, error)
+	// ExpireOffline returns the users announced online whose key has
+	// expired, each to one caller only, so that they are announced offline
+	// once
+	ExpireOffline(ctx context.Context, now time.Time) ([]models.Presence, error)
+	Delete(ctx context.Context, userID uuid.UUID) error
+}
+
+type presenceRepository struct {
+	redis *redis.Client
+	ttl   time.Duration
+}
+
+func NewPresenceRepository(redis *redis.Client, ttl time.Duration) PresenceRepository {
+	return &presenceRepository{redis: redis, ttl: ttl}
+}
+
+// expireOffline removes ARGV[1] from KEYS[1], the online set, unless KEYS[2],
+// the user's presence key, exists again. It returns 1 if it removed it.
+var expireOffline = redis.NewScript(`
+if redis.call('EXISTS', KEYS[2]) == 1 then
+  return 0
+end
+return redis.call('ZREM', KEYS[1], ARGV[1])
+`)
+
+func (r *presenceRepository) Heartbeat(ctx context.Context, userID uuid.UUID, at time.Time) (bool, error) {
+	key := presenceKey(userID)
+	millis := at.UnixMilli()
+
+	cameOnline, err := r.redis.SetNX(ctx, key, millis, r.ttl).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to record heartbeat: %w", err)
+	}
+
+	pipe := r.redis.Pipeline()
+	if !cameOnline {
+		pipe.Set(ctx, key, millis, r.ttl)
+	}
+	pipe.ZAdd(ctx, presenceOnlineKey, redis.Z{Score: float64(millis), Member: userID.String()})
+	pipe.HSet(ctx, presenceLastSeenKey, userID.String(), millis)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return false, fmt.Errorf("failed to record heartbeat: %w", err)
+	}
+
+	return cameOnline, nil
+}
+
+func (r *presenceRepository) Get(ctx context.Context, userIDs []uuid.UUID) ([]models.Presence, error) {
+	if len(userIDs) == 0 {
+		return []models.Presence{}, nil
+	}
+
+	keys := make([]string, len(userIDs))
+	fields := make([]string, len(userIDs))
+	for i, id := range userIDs {
+		keys[i] = presenceKey(id)
+		fields[i] = id.String()
+	}
+
+	pipe := r.redis.Pipeline()
+	online := pipe.MGet(ctx, keys...)
+	lastSeen := pipe.HMGet(ctx, presenceLastSeenKey, fields...)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, fmt.Errorf("failed to get presence: %w", err)
+	}
+
+	presences := make([]models.Presence, len(userIDs))
+	for i, id := range userIDs {
+		presences[i] = models.Presence{
+			UserID:     id,
+			Online:     online.Val()[i] != nil,
+			LastSeenAt: parseMillis(lastSeen.Val()[i]),
+		}
+	}
+	return presences, nil
+}
+
+func (r *presenceRepository) ExpireOffline(ctx context.Context, now time.Time) ([]models.Presence, error) {
+	stale, err := r.redis.ZRangeByScoreWithScores(ctx, presenceOnlineKey, &redis.ZRangeBy{
+		Min: "-inf",
+		Max: strconv.FormatInt(now.Add(-r.ttl).UnixMilli(), 10),
+	}).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stale presence: %w", err)
+	}
+
+	var offline []models.Presence
+	for _, z := range stale {
+		member := fmt.Sprint(z.Member)
+		userID, err := uuid.Parse(member)
+		if err != nil {
+			r.redis.ZRem(ctx, presenceOnlineKey, member)
+			continue
+		}
+
+		removed, err := expireOffline.Run(ctx, r.redis, []string{presenceOnlineKey, presenceKey(userID)}, member).Int()
+		if err != nil {
+			return offline, fmt.Errorf("failed to expire presence: %w", err)
+		}
+		if removed == 1 {
+			lastSeen := time.UnixMilli(int64(z.Score))
+			offline = append(offline, models.Presence{UserID: userID, LastSeenAt: &lastSeen})
+		}
+	}
+	return offline, nil
+}
+
+func (r *presenceRepository) Delete(ctx context.Context, userID uuid.UUID) error {
+	pipe := r.redis.TxPipeline()
+	pipe.Del(ctx, presenceKey(userID))
+	pipe.ZRem(ctx, presenceOnlineKey, userID.String())
+	pipe.HDel(ctx, presenceLastSeenKey, userID.String())
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to delete presence: %w", err)
+	}
+	return nil
+}
+
+func presenceKey(userID uuid.UUID) string {
+	return "presence:" + userID.String()
+}
+
+// parseMillis reads a time stored as Unix milliseconds, nil if it is missing
+func parseMillis(v interface{}) *time.Time {
+	s, ok := v.(string)
+	if !ok {
+		return nil
+	}
+	millis, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return nil
+	}
+	t := time.UnixMilli(millis)
+	return &t
+}
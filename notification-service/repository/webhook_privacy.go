@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SetUserPrivacy records whether an account is private. Updates older than
+// the one already stored are ignored, so out-of-order events cannot revert a
+// newer setting.
+func (r *webhookRepository) SetUserPrivacy(ctx context.Context, userID uuid.UUID, isPrivate bool, updatedAt time.Time) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO notification_service_private_users (user_id, is_private, updated_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id) DO UPDATE
+		SET is_private = EXCLUDED.is_private, updated_at = EXCLUDED.updated_at
+		WHERE notification_service_private_users.updated_at < EXCLUDED.updated_at
+	`, userID, isPrivate, updatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to set user privacy: %w", err)
+	}
+	return nil
+}
+
+// IsPrivateUser reports whether an account is private. Accounts never
+// updated count as public.
+func (r *webhookRepository) IsPrivateUser(ctx context.Context, userID uuid.UUID) (bool, error) {
+	var isPrivate bool
+	err := r.db.GetContext(ctx, &isPrivate, `
+		SELECT EXISTS (SELECT 1 FROM notification_service_private_users WHERE user_id = $1 AND is_private)
+	`, userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to check user privacy: %w", err)
+	}
+	return isPrivate, nil
+}
@@ -0,0 +1,194 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"notification-service/model"
+)
+
+type WebhookRepository interface {
+	Create(ctx context.Context, webhook *models.Webhook) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Webhook, error)
+	ListByUserID(ctx context.Context, userID uuid.UUID) ([]models.Webhook, error)
+	// ListActiveByEventType lists the active webhooks subscribed to
+	// eventType that belong to one of userIDs
+	ListActiveByEventType(ctx context.Context, eventType string, userIDs []uuid.UUID) ([]models.Webhook, error)
+	Delete(ctx context.Context, id, userID uuid.UUID) error
+	DeleteByUser(ctx context.Context, userID uuid.UUID) error
+	CreateDelivery(ctx context.Context, delivery *models.WebhookDelivery) error
+	ListDeliveries(ctx context.Context, webhookID uuid.UUID, limit int) ([]models.WebhookDelivery, error)
+	DeleteDeliveriesBefore(ctx context.Context, before time.Time) (int64, error)
+	SetUserPrivacy(ctx context.Context, userID uuid.UUID, isPrivate bool, updatedAt time.Time) error
+	IsPrivateUser(ctx context.Context, userID uuid.UUID) (bool, error)
+}
+
+type webhookRepository struct {
+	db *sqlx.DB
+}
+
+func NewWebhookRepository(db *sqlx.DB) WebhookRepository {
+	return &webhookRepository{db: db}
+}
+
+func (r *webhookRepository) Create(ctx context.Context, webhook *models.Webhook) error {
+	query := `
+		INSERT INTO notification_service_webhooks (id, user_id, url, secret, event_types, is_active, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	_, err := r.db.ExecContext(
+		ctx,
+		query,
+		webhook.ID,
+		webhook.UserID,
+		webhook.URL,
+		webhook.Secret,
+		webhook.EventTypes,
+		webhook.IsActive,
+		webhook.CreatedAt,
+		webhook.UpdatedAt,
+	)
+	return err
+}
+
+func (r *webhookRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Webhook, error) {
+	query := `
+		SELECT id, user_id, url, secret, event_types, is_active, created_at, updated_at
+		FROM notification_service_webhooks
+		WHERE id = $1
+	`
+
+	var webhook models.Webhook
+	err := r.db.GetContext(ctx, &webhook, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("webhook not found")
+		}
+		return nil, err
+	}
+
+	return &webhook, nil
+}
+
+func (r *webhookRepository) ListByUserID(ctx context.Context, userID uuid.UUID) ([]models.Webhook, error) {
+	query := `
+		SELECT id, user_id, url, secret, event_types, is_active, created_at, updated_at
+		FROM notification_service_webhooks
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+	`
+
+	var webhooks []models.Webhook
+	err := r.db.SelectContext(ctx, &webhooks, query, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return webhooks, nil
+}
+
+func (r *webhookRepository) ListActiveByEventType(ctx context.Context, eventType string, userIDs []uuid.UUID) ([]models.Webhook, error) {
+	owners := make([]string, len(userIDs))
+	for i, id := range userIDs {
+		owners[i] = id.String()
+	}
+
+	query := `
+		SELECT id, user_id, url, secret, event_types, is_active, created_at, updated_at
+		FROM notification_service_webhooks
+		WHERE is_active AND $1 = ANY(event_types) AND user_id = ANY($2::uuid[])
+	`
+
+	var webhooks []models.Webhook
+	err := r.db.SelectContext(ctx, &webhooks, query, eventType, pq.Array(owners))
+	if err != nil {
+		return nil, err
+	}
+
+	return webhooks, nil
+}
+
+func (r *webhookRepository) Delete(ctx context.Context, id, userID uuid.UUID) error {
+	query := `DELETE FROM notification_service_webhooks WHERE id = $1 AND user_id = $2`
+
+	result, err := r.db.ExecContext(ctx, query, id, userID)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("webhook not found or unauthorized")
+	}
+
+	return nil
+}
+
+// DeleteByUser removes all of a user's webhooks along with their deliveries
+func (r *webhookRepository) DeleteByUser(ctx context.Context, userID uuid.UUID) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM notification_service_webhooks WHERE user_id = $1`, userID)
+	return err
+}
+
+func (r *webhookRepository) CreateDelivery(ctx context.Context, delivery *models.WebhookDelivery) error {
+	query := `
+		INSERT INTO notification_service_webhook_deliveries
+			(id, webhook_id, event_id, event_type, attempt, status_code, success, error, duration_ms, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`
+
+	_, err := r.db.ExecContext(
+		ctx,
+		query,
+		delivery.ID,
+		delivery.WebhookID,
+		delivery.EventID,
+		delivery.EventType,
+		delivery.Attempt,
+		delivery.StatusCode,
+		delivery.Success,
+		delivery.Error,
+		delivery.DurationMs,
+		delivery.CreatedAt,
+	)
+	return err
+}
+
+func (r *webhookRepository) ListDeliveries(ctx context.Context, webhookID uuid.UUID, limit int) ([]models.WebhookDelivery, error) {
+	query := `
+		SELECT id, webhook_id, event_id, event_type, attempt, status_code, success, error, duration_ms, created_at
+		FROM notification_service_webhook_deliveries
+		WHERE webhook_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2
+	`
+
+	var deliveries []models.WebhookDelivery
+	err := r.db.SelectContext(ctx, &deliveries, query, webhookID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+// DeleteDeliveriesBefore removes delivery attempts recorded before the given time
+func (r *webhookRepository) DeleteDeliveriesBefore(ctx context.Context, before time.Time) (int64, error) {
+	query := `DELETE FROM notification_service_webhook_deliveries WHERE created_at < $1`
+
+	result, err := r.db.ExecContext(ctx, query, before)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,223 @@
+package subscriber
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nats-io/nats.go"
+	"notification-service/events"
+	"notification-service/model"
+	natsClient "notification-service/nats"
+	"shared/dedupe"
+	"shared/logging"
+	"shared/tracing"
+)
+
+const (
+	// deadLetterTimeout bounds publishing a dead letter
+	deadLetterTimeout = 5 * time.Second
+
+	// Headers of a dead letter, besides those of the event. They share the
+	// names post-service and comment-service use for theirs.
+	deadLetterHeaderPrefix   = "x-dead-letter-"
+	deadLetterSubjectHeader  = "x-dead-letter-subject"
+	deadLetterConsumerHeader = "x-dead-letter-consumer"
+	deadLetterDeliveryHeader = "x-dead-letter-delivery"
+	deadLetterErrorHeader    = "x-dead-letter-error"
+	deadLetterAttemptsHeader = "x-dead-letter-attempts"
+)
+
+// handler handles an event, returning why it could not
+type handler func(ctx context.Context, msg *nats.Msg) error
+
+// undecodableError marks an event that cannot be decoded, which no
+// redelivery would fix
+type undecodableError struct {
+	err error
+}
+
+func (e undecodableError) Error() string { return e.err.Error() }
+func (e undecodableError) Unwrap() error { return e.err }
+
+func undecodable(err error) error {
+	return undecodableError{err: err}
+}
+
+// subscribe consumes subject through the durable consumer durableName. An
+// event the consumer already handled is acknowledged without handling it
+// again. An event whose handler fails is delivered again, up to
+// natsClient.MaxDeliver times; on its last delivery, or at once when it
+// cannot be decoded, it is dead-lettered instead of dropped.
+func (s *NotificationSubscriber) subscribe(subject, durableName string, handle handler) error {
+	s.handlers[subject] = handle
+
+	_, err := s.natsClient.SubscribeDurable(subject, durableName, "notification-workers", func(msg *nats.Msg) {
+		ctx, span := tracing.StartProcess(s.ctx, msg.Subject, msg.Header)
+		ctx = logging.Extract(ctx, msg.Subject, msg.Header)
+		defer span.End()
+
+		err := s.processed.Process(ctx, durableName, dedupeID(msg), func(ctx context.Context) error {
+			return handle(ctx, msg)
+		})
+		if err == nil {
+			msg.Ack()
+			return
+		}
+		if errors.Is(err, dedupe.ErrInProgress) {
+			msg.NakWithDelay(dedupe.Lease)
+			return
+		}
+		logging.FromContext(ctx).Error().Err(err).Msg("failed to handle event")
+		s.fail(ctx, msg, durableName, err)
+	})
+	return err
+}
+
+// dedupeID identifies an event for the processed events: by the ID it was
+// published with or, lacking one, by the message holding it
+func dedupeID(msg *nats.Msg) string {
+	if id := dedupe.EventID(msg.Header); id != "" {
+		return id
+	}
+	meta, err := msg.Metadata()
+	if err != nil {
+		return ""
+	}
+	return dedupe.MessageID(meta.Stream, meta.Sequence.Stream, meta.Timestamp)
+}
+
+// fail hands an event that could not be handled back for redelivery, or
+// dead-letters it when it will not be delivered again
+func (s *NotificationSubscriber) fail(ctx context.Context, msg *nats.Msg, consumer string, cause error) {
+	meta, err := msg.Metadata()
+	if err != nil {
+		msg.Nak()
+		return
+	}
+
+	var undecodableErr undecodableError
+	if meta.NumDelivered < natsClient.MaxDeliver && !errors.As(cause, &undecodableErr) {
+		msg.Nak()
+		return
+	}
+
+	if err := s.deadLetter(ctx, msg, meta, consumer, cause); err != nil {
+		// On its last delivery the event is lost; otherwise it is tried again
+		logging.FromContext(ctx).Error().Err(err).Uint64("attempts", meta.NumDelivered).Msg("failed to dead-letter event")
+		msg.Nak()
+		return
+	}
+
+	logging.FromContext(ctx).Warn().Str("consumer", consumer).Uint64("attempts", meta.NumDelivered).Msg("dead-lettered event")
+	msg.Term()
+}
+
+// deadLetter publishes an event to the dead-letter stream, with the subject
+// and consumer it failed on and why. The delivery, named by consumer and
+// stream sequence, keys it, so dead-lettering it twice stores it once.
+func (s *NotificationSubscriber) deadLetter(ctx context.Context, msg *nats.Msg, meta *nats.MsgMetadata, consumer string, cause error) error {
+	deliveryID := fmt.Sprintf("%s:%d", consumer, meta.Sequence.Stream)
+
+	deadLetter := &nats.Msg{Subject: events.DeadLetterPrefix + msg.Subject, Data: msg.Data, Header: nats.Header{}}
+	for key, values := range msg.Header {
+		deadLetter.Header[key] = values
+	}
+	deadLetter.Header.Del(nats.MsgIdHdr)
+	deadLetter.Header.Set(deadLetterSubjectHeader, msg.Subject)
+	deadLetter.Header.Set(deadLetterConsumerHeader, consumer)
+	deadLetter.Header.Set(deadLetterDeliveryHeader, deliveryID)
+	deadLetter.Header.Set(deadLetterErrorHeader, cause.Error())
+	deadLetter.Header.Set(deadLetterAttemptsHeader, strconv.FormatUint(meta.NumDelivered, 10))
+
+	ctx, cancel := context.WithTimeout(ctx, deadLetterTimeout)
+	defer cancel()
+	return s.natsClient.PublishDurable(ctx, deadLetter, deliveryID)
+}
+
+// subscribeToDeadLetters stores the events the subscriber dead-lettered as
+// failed events, where admins can inspect and replay them
+func (s *NotificationSubscriber) subscribeToDeadLetters() error {
+	handler := func(msg *nats.Msg) {
+		ctx := logging.Extract(s.ctx, msg.Subject, msg.Header)
+
+		event := failedEventFromDeadLetter(msg)
+		created, err := s.failedEvents.Create(ctx, event)
+		if err != nil {
+			logging.FromContext(ctx).Error().Err(err).Msg("failed to store failed event")
+			msg.Nak()
+			return
+		}
+
+		if created {
+			logging.FromContext(ctx).Warn().Stringer("failed_event_id", event.ID).Str("event_subject", event.Subject).Msg("stored failed event")
+		}
+		msg.Ack()
+	}
+
+	_, err := s.natsClient.SubscribeDurable(
+		events.DeadLetterPrefix+">",
+		"notification-service-dead-letters",
+		"notification-workers",
+		handler,
+	)
+
+	return err
+}
+
+// failedEventFromDeadLetter reads a dead letter back into the event it holds
+// and the failure recorded with it
+func failedEventFromDeadLetter(msg *nats.Msg) *models.FailedEvent {
+	event := &models.FailedEvent{
+		ID:         uuid.New(),
+		DeliveryID: msg.Header.Get(deadLetterDeliveryHeader),
+		Subject:    msg.Header.Get(deadLetterSubjectHeader),
+		Consumer:   msg.Header.Get(deadLetterConsumerHeader),
+		Payload:    msg.Data,
+		Headers:    models.EventHeaders{},
+		Error:      msg.Header.Get(deadLetterErrorHeader),
+		FailedAt:   time.Now().UTC(),
+	}
+	if event.Subject == "" {
+		event.Subject = strings.TrimPrefix(msg.Subject, events.DeadLetterPrefix)
+	}
+	if attempts, err := strconv.Atoi(msg.Header.Get(deadLetterAttemptsHeader)); err == nil {
+		event.Attempts = int32(attempts)
+	}
+	if meta, err := msg.Metadata(); err == nil {
+		event.FailedAt = meta.Timestamp.UTC()
+		if event.DeliveryID == "" {
+			event.DeliveryID = fmt.Sprintf("dead-letter:%d", meta.Sequence.Stream)
+		}
+	}
+
+	// The event keeps the headers it was published with, for replaying
+	for key, values := range msg.Header {
+		if !strings.HasPrefix(strings.ToLower(key), deadLetterHeaderPrefix) {
+			event.Headers[key] = values
+		}
+	}
+	return event
+}
+
+// Replay handles a failed event again, as the subscription it failed on
+// would. It runs under the subscriber's context like a delivered event, so
+// pushes it starts are not cut short when the caller returns.
+func (s *NotificationSubscriber) Replay(ctx context.Context, event *models.FailedEvent) error {
+	handle, ok := s.handlers[event.Subject]
+	if !ok {
+		return fmt.Errorf("no subscription handles %s", event.Subject)
+	}
+
+	msg := &nats.Msg{Subject: event.Subject, Data: event.Payload, Header: nats.Header(event.Headers)}
+	handlerCtx := logging.Extract(s.ctx, msg.Subject, msg.Header)
+	if err := handle(handlerCtx, msg); err != nil {
+		logging.FromContext(ctx).Error().Err(err).Stringer("failed_event_id", event.ID).Msg("failed to replay event")
+		return err
+	}
+	return nil
+}
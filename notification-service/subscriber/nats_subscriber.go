@@ -2,43 +2,81 @@ package subscriber
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"time"
 
-	"github.com/google/uuid"
 	"github.com/nats-io/nats.go"
 	"notification-service/events"
 	"notification-service/model"
 	natsClient "notification-service/nats"
+	"notification-service/push"
 	"notification-service/repository"
+	"shared/dedupe"
+	"shared/eventschema"
+	eventspb "shared/eventschema/pb"
+	"shared/logging"
 )
 
+// legacyStreamName is the work-queue stream that held notification events
+// before they were retained in events.StreamName. It captured the same
+// subjects, so it has to go before the new stream can be created.
+const legacyStreamName = "NOTIFICATIONS"
+
 type NotificationSubscriber struct {
-	natsClient *natsClient.Client
-	repo       repository.NotificationRepository
-	ctx        context.Context
+	natsClient   *natsClient.Client
+	repo         repository.NotificationRepository
+	prefRepo     repository.PreferenceRepository
+	failedEvents repository.FailedEventRepository
+	processed    *dedupe.Store
+	pusher       *push.Dispatcher
+	ctx          context.Context
+	retention    time.Duration
+
+	// handlers are keyed by subject, for replaying failed events
+	handlers map[string]handler
 }
 
 func NewNotificationSubscriber(
 	natsClient *natsClient.Client,
 	repo repository.NotificationRepository,
+	prefRepo repository.PreferenceRepository,
+	failedEvents repository.FailedEventRepository,
+	processed *dedupe.Store,
+	pusher *push.Dispatcher,
 	ctx context.Context,
+	retention time.Duration,
 ) *NotificationSubscriber {
 	return &NotificationSubscriber{
-		natsClient: natsClient,
-		repo:       repo,
-		ctx:        ctx,
+		natsClient:   natsClient,
+		repo:         repo,
+		prefRepo:     prefRepo,
+		failedEvents: failedEvents,
+		processed:    processed,
+		pusher:       pusher,
+		ctx:          ctx,
+		retention:    retention,
+		handlers:     make(map[string]handler),
 	}
 }
 
 func (s *NotificationSubscriber) Start() error {
-	subjects := []string{
-		events.SubjectPostCreated,
-		events.SubjectPostCommented,
+	if err := s.natsClient.DeleteStream(legacyStreamName); err != nil {
+		return err
 	}
 
-	err := s.natsClient.CreateStream("NOTIFICATIONS", subjects)
-	if err != nil {
-		log.Printf("Stream might already exist or error creating: %v", err)
+	if err := s.natsClient.EnsureStream(events.StreamName, events.StreamSubjects, s.retention); err != nil {
+		return err
+	}
+
+	// Events the handlers below give up on are kept in the dead-letter
+	// stream, and from there stored as failed events
+	if err := s.natsClient.EnsureStream(events.DeadLetterStream, []string{events.DeadLetterSubjects}, events.DeadLetterRetention); err != nil {
+		return err
+	}
+
+	if err := s.subscribeToDeadLetters(); err != nil {
+		return err
 	}
 
 	if err := s.subscribeToPostCreated(); err != nil {
@@ -49,93 +87,294 @@ func (s *NotificationSubscriber) Start() error {
 		return err
 	}
 
+	if err := s.subscribeToMentionCreated(); err != nil {
+		return err
+	}
+
+	if err := s.subscribeToPostReposted(); err != nil {
+		return err
+	}
+
+	if err := s.subscribeToCommentReplied(); err != nil {
+		return err
+	}
+
+	if err := s.subscribeToFollowRequested(); err != nil {
+		return err
+	}
+
+	if err := s.subscribeToPostLiked(); err != nil {
+		return err
+	}
+
+	if err := s.subscribeToPostUnliked(); err != nil {
+		return err
+	}
+
+	if err := s.subscribeToUserFollowed(); err != nil {
+		return err
+	}
+
+	if err := s.subscribeToPostDeleted(); err != nil {
+		return err
+	}
+
+	if err := s.subscribeToRefreshTokenReused(); err != nil {
+		return err
+	}
+
 	log.Println("Notification subscriber started successfully")
 	return nil
 }
 
 func (s *NotificationSubscriber) subscribeToPostCreated() error {
-	handler := func(msg *nats.Msg) {
-		var event events.PostCreatedEvent
+	handler := func(ctx context.Context, msg *nats.Msg) error {
+		var event eventspb.PostCreated
+		if _, err := eventschema.Decode(events.SubjectPostCreated, msg.Header, msg.Data, &event); err != nil {
+			return undecodable(fmt.Errorf("failed to decode post created event: %w", err))
+		}
+
+		if err := s.create(ctx, events.PostCreatedNotification(&event)); err != nil {
+			return fmt.Errorf("failed to create post notification: %w", err)
+		}
+
+		logging.FromContext(ctx).Info().Str("user_id", event.UserId).Msg("created post notification")
+		return nil
+	}
+
+	return s.subscribe(events.SubjectPostCreated, "notification-service-posts", handler)
+}
+
+func (s *NotificationSubscriber) subscribeToPostCommented() error {
+	handler := func(ctx context.Context, msg *nats.Msg) error {
+		var event events.PostCommentedEvent
 		if err := natsClient.DecodeEvent(msg, &event); err != nil {
-			log.Printf("Error decoding post created event: %v", err)
-			msg.Nak()
-			return
+			return undecodable(fmt.Errorf("failed to decode post commented event: %w", err))
 		}
 
-		notification := &models.Notification{
-			ID:        uuid.New(),
-			UserID:    event.AuthorID,
-			Type:      models.NotificationTypePost,
-			Message:   "created a new post",
-			ActorID:   &event.AuthorID,
-			RelatedID: &event.PostID,
-			IsRead:    false,
-			CreatedAt: event.Timestamp,
+		notification := event.Notification()
+		if notification == nil {
+			return nil
 		}
 
-		if err := s.repo.Create(s.ctx, notification); err != nil {
-			log.Printf("Error creating post notification: %v", err)
-			msg.Nak()
-			return
+		if err := s.create(ctx, notification); err != nil {
+			return fmt.Errorf("failed to create comment notification: %w", err)
 		}
 
-		log.Printf("Created post notification for user %s", event.AuthorID)
-		msg.Ack()
+		logging.FromContext(ctx).Info().Stringer("user_id", event.PostOwner).Msg("created comment notification")
+		return nil
 	}
 
-	_, err := s.natsClient.SubscribeDurable(
-		events.SubjectPostCreated,
-		"notification-service-posts",
-		"notification-workers",
-		handler,
-	)
+	return s.subscribe(events.SubjectPostCommented, "notification-service-comments", handler)
+}
+
+func (s *NotificationSubscriber) subscribeToMentionCreated() error {
+	handler := func(ctx context.Context, msg *nats.Msg) error {
+		var event events.MentionCreatedEvent
+		if err := natsClient.DecodeEvent(msg, &event); err != nil {
+			return undecodable(fmt.Errorf("failed to decode mention created event: %w", err))
+		}
+
+		if err := s.create(ctx, event.Notification()); err != nil {
+			return fmt.Errorf("failed to create mention notification: %w", err)
+		}
+
+		logging.FromContext(ctx).Info().Stringer("user_id", event.MentionedUserID).Msg("created mention notification")
+		return nil
+	}
 
-	return err
+	return s.subscribe(events.SubjectMentionCreated, "notification-service-mentions", handler)
 }
 
-func (s *NotificationSubscriber) subscribeToPostCommented() error {
-	handler := func(msg *nats.Msg) {
-		var event events.PostCommentedEvent
+func (s *NotificationSubscriber) subscribeToPostReposted() error {
+	handler := func(ctx context.Context, msg *nats.Msg) error {
+		var event events.PostRepostedEvent
 		if err := natsClient.DecodeEvent(msg, &event); err != nil {
-			log.Printf("Error decoding post commented event: %v", err)
-			msg.Nak()
-			return
+			return undecodable(fmt.Errorf("failed to decode post reposted event: %w", err))
 		}
 
-		if event.PostOwner == event.CommentedBy {
-			msg.Ack()
-			return
+		notification := event.Notification()
+		if notification == nil {
+			return nil
 		}
 
-		notification := &models.Notification{
-			ID:        uuid.New(),
-			UserID:    event.PostOwner,
-			Type:      models.NotificationTypeComment,
-			Message:   "commented on your post",
-			ActorID:   &event.CommentedBy,
-			RelatedID: &event.PostID,
-			IsRead:    false,
-			CreatedAt: event.Timestamp,
+		if err := s.create(ctx, notification); err != nil {
+			return fmt.Errorf("failed to create repost notification: %w", err)
 		}
 
-		if err := s.repo.Create(s.ctx, notification); err != nil {
-			log.Printf("Error creating comment notification: %v", err)
-			msg.Nak()
-			return
+		logging.FromContext(ctx).Info().Stringer("user_id", event.PostAuthorID).Msg("created repost notification")
+		return nil
+	}
+
+	return s.subscribe(events.SubjectPostReposted, "notification-service-reposts", handler)
+}
+
+func (s *NotificationSubscriber) subscribeToCommentReplied() error {
+	handler := func(ctx context.Context, msg *nats.Msg) error {
+		var event events.CommentRepliedEvent
+		if err := natsClient.DecodeEvent(msg, &event); err != nil {
+			return undecodable(fmt.Errorf("failed to decode comment replied event: %w", err))
+		}
+
+		notification := event.Notification()
+		if notification == nil {
+			return nil
+		}
+
+		if err := s.create(ctx, notification); err != nil {
+			return fmt.Errorf("failed to create reply notification: %w", err)
 		}
 
-		log.Printf("Created comment notification for user %s", event.PostOwner)
-		msg.Ack()
+		logging.FromContext(ctx).Info().Stringer("user_id", event.CommentAuthorID).Msg("created reply notification")
+		return nil
 	}
 
-	_, err := s.natsClient.SubscribeDurable(
-		events.SubjectPostCommented,
-		"notification-service-comments",
-		"notification-workers",
-		handler,
-	)
+	return s.subscribe(events.SubjectCommentReplied, "notification-service-replies", handler)
+}
 
-	return err
+func (s *NotificationSubscriber) subscribeToFollowRequested() error {
+	handler := func(ctx context.Context, msg *nats.Msg) error {
+		var event events.FollowRequestedEvent
+		if err := natsClient.DecodeEvent(msg, &event); err != nil {
+			return undecodable(fmt.Errorf("failed to decode follow requested event: %w", err))
+		}
+
+		if err := s.create(ctx, event.Notification()); err != nil {
+			return fmt.Errorf("failed to create follow request notification: %w", err)
+		}
+
+		logging.FromContext(ctx).Info().Stringer("user_id", event.FollowingID).Msg("created follow request notification")
+		return nil
+	}
+
+	return s.subscribe(events.SubjectFollowRequested, "notification-service-follow-requests", handler)
+}
+
+func (s *NotificationSubscriber) subscribeToRefreshTokenReused() error {
+	handler := func(ctx context.Context, msg *nats.Msg) error {
+		var event events.RefreshTokenReusedEvent
+		if err := natsClient.DecodeEvent(msg, &event); err != nil {
+			return undecodable(fmt.Errorf("failed to decode refresh token reused event: %w", err))
+		}
+
+		if err := s.create(ctx, event.Notification()); err != nil {
+			return fmt.Errorf("failed to create security notification: %w", err)
+		}
+
+		logging.FromContext(ctx).Info().Stringer("user_id", event.UserID).Msg("created security notification")
+		return nil
+	}
+
+	return s.subscribe(events.SubjectRefreshTokenReused, "notification-service-security", handler)
+}
+
+func (s *NotificationSubscriber) subscribeToPostLiked() error {
+	handler := func(ctx context.Context, msg *nats.Msg) error {
+		var event events.PostLikedEvent
+		if err := natsClient.DecodeEvent(msg, &event); err != nil {
+			return undecodable(fmt.Errorf("failed to decode post liked event: %w", err))
+		}
+
+		notification := event.Notification()
+		if notification == nil {
+			return nil
+		}
+
+		if err := s.create(ctx, notification); err != nil {
+			return fmt.Errorf("failed to create like notification: %w", err)
+		}
+
+		logging.FromContext(ctx).Info().Stringer("user_id", event.PostAuthorID).Msg("created like notification")
+		return nil
+	}
+
+	return s.subscribe(events.SubjectPostLiked, "notification-service-likes", handler)
+}
+
+// subscribeToPostUnliked retracts the like notifications of removed likes
+func (s *NotificationSubscriber) subscribeToPostUnliked() error {
+	handler := func(ctx context.Context, msg *nats.Msg) error {
+		var event events.PostUnlikedEvent
+		if err := natsClient.DecodeEvent(msg, &event); err != nil {
+			return undecodable(fmt.Errorf("failed to decode post unliked event: %w", err))
+		}
+
+		retracted, err := s.repo.Retract(ctx, event.NotificationID(), event.UserID)
+		if err != nil {
+			return fmt.Errorf("failed to retract like notification: %w", err)
+		}
+
+		if retracted {
+			logging.FromContext(ctx).Info().Stringer("post_id", event.PostID).Msg("retracted like notification")
+		}
+		return nil
+	}
+
+	return s.subscribe(events.SubjectPostUnliked, "notification-service-unlikes", handler)
+}
+
+func (s *NotificationSubscriber) subscribeToUserFollowed() error {
+	handler := func(ctx context.Context, msg *nats.Msg) error {
+		var event events.UserFollowedEvent
+		if err := natsClient.DecodeEvent(msg, &event); err != nil {
+			return undecodable(fmt.Errorf("failed to decode user followed event: %w", err))
+		}
+
+		if err := s.create(ctx, event.Notification()); err != nil {
+			return fmt.Errorf("failed to create follow notification: %w", err)
+		}
+
+		logging.FromContext(ctx).Info().Stringer("user_id", event.FollowingID).Msg("created follow notification")
+		return nil
+	}
+
+	return s.subscribe(events.SubjectUserFollowed, "notification-service-follows", handler)
+}
+
+// subscribeToPostDeleted removes the notifications about deleted posts
+func (s *NotificationSubscriber) subscribeToPostDeleted() error {
+	handler := func(ctx context.Context, msg *nats.Msg) error {
+		var event eventspb.PostDeleted
+		if _, err := eventschema.Decode(events.SubjectPostDeleted, msg.Header, msg.Data, &event); err != nil {
+			return undecodable(fmt.Errorf("failed to decode post deleted event: %w", err))
+		}
+
+		deleted, err := s.repo.DeleteByPost(ctx, eventschema.UUID(event.PostId))
+		if err != nil {
+			return fmt.Errorf("failed to delete notifications of deleted post: %w", err)
+		}
+
+		logging.FromContext(ctx).Info().Str("post_id", event.PostId).Int64("deleted", deleted).Msg("deleted notifications of deleted post")
+		return nil
+	}
+
+	return s.subscribe(events.SubjectPostDeleted, "notification-service-post-deletions", handler)
+}
+
+// create stores notification unless its recipient has turned its type off,
+// and publishes it to live subscribers and pushes it if it is new.
+// Redelivered events create nothing and are not pushed again.
+func (s *NotificationSubscriber) create(ctx context.Context, notification *models.Notification) error {
+	preferences, err := s.prefRepo.Get(ctx, notification.UserID)
+	if err != nil {
+		return err
+	}
+	if preferences.Disables(notification.Type) {
+		return nil
+	}
+
+	created, err := s.repo.Create(ctx, notification)
+	if err != nil || !created {
+		return err
+	}
+
+	if err := s.natsClient.Publish(ctx, events.UserNotificationsSubject(notification.UserID), notification); err != nil {
+		logging.FromContext(ctx).Error().Err(err).Stringer("notification_id", notification.ID).Msg("failed to publish notification")
+	}
+	if err := s.pusher.Push(ctx, notification); err != nil {
+		logging.FromContext(ctx).Error().Err(err).Stringer("notification_id", notification.ID).Msg("failed to push notification")
+	}
+	return nil
 }
 
 func (s *NotificationSubscriber) Stop() error {
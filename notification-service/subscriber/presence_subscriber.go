@@ -0,0 +1,116 @@
+package subscriber
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"notification-service/events"
+	"notification-service/model"
+	natsClient "notification-service/nats"
+	"notification-service/repository"
+	"shared/logging"
+	"shared/tracing"
+)
+
+// PresenceSubscriber records the heartbeats of gateway connections and
+// publishes users going online and offline. Heartbeats are shared by the
+// replicas in the presence-workers queue group; each replica also sweeps for
+// users whose presence expired, and the repository announces each to one
+// replica only.
+type PresenceSubscriber struct {
+	natsClient *natsClient.Client
+	repo       repository.PresenceRepository
+	sweepEvery time.Duration
+	ctx        context.Context
+	sub        *nats.Subscription
+}
+
+func NewPresenceSubscriber(
+	natsClient *natsClient.Client,
+	repo repository.PresenceRepository,
+	sweepEvery time.Duration,
+	ctx context.Context,
+) *PresenceSubscriber {
+	return &PresenceSubscriber{
+		natsClient: natsClient,
+		repo:       repo,
+		sweepEvery: sweepEvery,
+		ctx:        ctx,
+	}
+}
+
+func (s *PresenceSubscriber) Start() error {
+	sub, err := s.natsClient.QueueSubscribe(events.SubjectPresenceHeartbeat, "presence-workers", s.handleHeartbeat)
+	if err != nil {
+		return err
+	}
+	s.sub = sub
+
+	go s.sweep()
+
+	log.Println("Presence subscriber started successfully")
+	return nil
+}
+
+// handleHeartbeat keeps the user online and announces them when they were
+// offline. A missed heartbeat only matters once the presence expires, so
+// failures are logged and not retried.
+func (s *PresenceSubscriber) handleHeartbeat(msg *nats.Msg) {
+	ctx, span := tracing.StartProcess(s.ctx, msg.Subject, msg.Header)
+	ctx = logging.Extract(ctx, msg.Subject, msg.Header)
+	defer span.End()
+
+	var event events.PresenceHeartbeatEvent
+	if err := natsClient.DecodeEvent(msg, &event); err != nil {
+		logging.FromContext(ctx).Warn().Err(err).Msg("dropping invalid presence heartbeat")
+		return
+	}
+
+	cameOnline, err := s.repo.Heartbeat(ctx, event.UserID, time.Now())
+	if err != nil {
+		logging.FromContext(ctx).Error().Err(err).Stringer("user_id", event.UserID).Msg("failed to record presence heartbeat")
+		return
+	}
+	if cameOnline {
+		now := time.Now()
+		s.publish(ctx, models.Presence{UserID: event.UserID, Online: true, LastSeenAt: &now})
+	}
+}
+
+// sweep announces users whose presence expired as offline until the
+// subscriber's context is cancelled
+func (s *PresenceSubscriber) sweep() {
+	ticker := time.NewTicker(s.sweepEvery)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		offline, err := s.repo.ExpireOffline(s.ctx, time.Now())
+		if err != nil {
+			log.Printf("Failed to expire presence: %v", err)
+		}
+		for _, presence := range offline {
+			s.publish(s.ctx, presence)
+		}
+	}
+}
+
+func (s *PresenceSubscriber) publish(ctx context.Context, presence models.Presence) {
+	if err := s.natsClient.Publish(ctx, events.PresenceChangedSubject(presence.UserID), presence); err != nil {
+		logging.FromContext(ctx).Error().Err(err).Stringer("user_id", presence.UserID).Msg("failed to publish presence change")
+	}
+}
+
+func (s *PresenceSubscriber) Stop() error {
+	if s.sub != nil {
+		return s.sub.Unsubscribe()
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package subscriber
+
+import (
+	"context"
+	"log"
+
+	"github.com/nats-io/nats.go"
+	"notification-service/events"
+	natsClient "notification-service/nats"
+	"notification-service/repository"
+	"shared/logging"
+	"shared/tracing"
+)
+
+// UserSubscriber removes the data of users who delete their account
+type UserSubscriber struct {
+	natsClient   *natsClient.Client
+	repo         repository.NotificationRepository
+	webhookRepo  repository.WebhookRepository
+	deviceRepo   repository.DeviceRepository
+	prefRepo     repository.PreferenceRepository
+	presenceRepo repository.PresenceRepository
+	ctx          context.Context
+}
+
+func NewUserSubscriber(
+	natsClient *natsClient.Client,
+	repo repository.NotificationRepository,
+	webhookRepo repository.WebhookRepository,
+	deviceRepo repository.DeviceRepository,
+	prefRepo repository.PreferenceRepository,
+	presenceRepo repository.PresenceRepository,
+	ctx context.Context,
+) *UserSubscriber {
+	return &UserSubscriber{
+		natsClient:   natsClient,
+		repo:         repo,
+		webhookRepo:  webhookRepo,
+		deviceRepo:   deviceRepo,
+		prefRepo:     prefRepo,
+		presenceRepo: presenceRepo,
+		ctx:          ctx,
+	}
+}
+
+// Start subscribes to user.deleted. The stream capturing it is created by
+// NotificationSubscriber, which has to be started first.
+func (s *UserSubscriber) Start() error {
+	if err := s.subscribeToUserDeleted(); err != nil {
+		return err
+	}
+
+	log.Println("User subscriber started successfully")
+	return nil
+}
+
+// subscribeToUserDeleted removes a deleted user's notifications, webhooks,
+// devices and preferences
+func (s *UserSubscriber) subscribeToUserDeleted() error {
+	handler := func(msg *nats.Msg) {
+		ctx, span := tracing.StartProcess(s.ctx, msg.Subject, msg.Header)
+		ctx = logging.Extract(ctx, msg.Subject, msg.Header)
+		defer span.End()
+
+		var event events.UserDeletedEvent
+		if err := natsClient.DecodeEvent(msg, &event); err != nil {
+			logging.FromContext(ctx).Error().Err(err).Msg("failed to decode user deleted event")
+			msg.Nak()
+			return
+		}
+
+		deleted, err := s.repo.DeleteByUser(ctx, event.UserID)
+		if err != nil {
+			logging.FromContext(ctx).Error().Err(err).Msg("failed to delete notifications of deleted user")
+			msg.Nak()
+			return
+		}
+		if err := s.webhookRepo.DeleteByUser(ctx, event.UserID); err != nil {
+			logging.FromContext(ctx).Error().Err(err).Msg("failed to delete webhooks of deleted user")
+			msg.Nak()
+			return
+		}
+		if err := s.deviceRepo.DeleteByUser(ctx, event.UserID); err != nil {
+			logging.FromContext(ctx).Error().Err(err).Msg("failed to delete devices of deleted user")
+			msg.Nak()
+			return
+		}
+		if err := s.prefRepo.Delete(ctx, event.UserID); err != nil {
+			logging.FromContext(ctx).Error().Err(err).Msg("failed to delete preferences of deleted user")
+			msg.Nak()
+			return
+		}
+		if err := s.presenceRepo.Delete(ctx, event.UserID); err != nil {
+			logging.FromContext(ctx).Error().Err(err).Msg("failed to delete presence of deleted user")
+			msg.Nak()
+			return
+		}
+
+		logging.FromContext(ctx).Info().Stringer("user_id", event.UserID).Int64("notifications", deleted).Msg("deleted data of deleted user")
+		msg.Ack()
+	}
+
+	_, err := s.natsClient.SubscribeDurable(
+		events.SubjectUserDeleted,
+		"notification-service-user-deletions",
+		"notification-workers",
+		handler,
+	)
+
+	return err
+}
@@ -0,0 +1,238 @@
+package subscriber
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nats-io/nats.go"
+	"notification-service/events"
+	"notification-service/model"
+	natsClient "notification-service/nats"
+	"notification-service/repository"
+	"notification-service/webhook"
+	"shared/eventschema"
+	eventspb "shared/eventschema/pb"
+	"shared/logging"
+	"shared/tracing"
+)
+
+// webhookEvent is a NATS event turned into a webhook delivery: its public
+// data and the users whose webhooks receive it. An event with an author is
+// not delivered while the author's account is private.
+type webhookEvent struct {
+	data   interface{}
+	owners []uuid.UUID
+	author uuid.UUID
+}
+
+// webhookSource is a NATS subject published by another service, the public
+// webhook event type developers subscribe to it as, and how its messages are
+// turned into deliveries. A nil event drops the message.
+type webhookSource struct {
+	eventType string
+	decode    func(msg *nats.Msg) (*webhookEvent, error)
+}
+
+var webhookSources = map[string]webhookSource{
+	events.SubjectPostCreated:    {models.WebhookEventPostCreated, decodePostCreatedWebhook},
+	events.SubjectCommentAdded:   {models.WebhookEventCommentAdded, decodeCommentAddedWebhook},
+	events.SubjectUserFollowed:   {models.WebhookEventUserFollowed, decodeUserFollowedWebhook},
+	events.SubjectUserUnfollowed: {models.WebhookEventUserUnfollowed, decodeUserUnfollowedWebhook},
+}
+
+// WebhookSubscriber delivers events to webhooks, and projects which accounts
+// are private from user.updated
+type WebhookSubscriber struct {
+	natsClient *natsClient.Client
+	dispatcher *webhook.Dispatcher
+	repo       repository.WebhookRepository
+	ctx        context.Context
+	subs       []*nats.Subscription
+}
+
+func NewWebhookSubscriber(
+	natsClient *natsClient.Client,
+	dispatcher *webhook.Dispatcher,
+	repo repository.WebhookRepository,
+	ctx context.Context,
+) *WebhookSubscriber {
+	return &WebhookSubscriber{
+		natsClient: natsClient,
+		dispatcher: dispatcher,
+		repo:       repo,
+		ctx:        ctx,
+	}
+}
+
+func (s *WebhookSubscriber) Start() error {
+	for subject, source := range webhookSources {
+		sub, err := s.natsClient.QueueSubscribe(subject, "webhook-workers", s.handle(source))
+		if err != nil {
+			return err
+		}
+		s.subs = append(s.subs, sub)
+	}
+
+	sub, err := s.natsClient.QueueSubscribe(events.SubjectUserUpdated, "webhook-workers", s.handleUserUpdated)
+	if err != nil {
+		return err
+	}
+	s.subs = append(s.subs, sub)
+
+	log.Println("Webhook subscriber started successfully")
+	return nil
+}
+
+func (s *WebhookSubscriber) handle(source webhookSource) nats.MsgHandler {
+	return func(msg *nats.Msg) {
+		ctx, span := tracing.StartProcess(s.ctx, msg.Subject, msg.Header)
+		ctx = logging.Extract(ctx, msg.Subject, msg.Header)
+		defer span.End()
+
+		event, err := source.decode(msg)
+		if err != nil {
+			logging.FromContext(ctx).Warn().Err(err).Msg("dropping invalid payload for webhooks")
+			return
+		}
+		if event == nil {
+			return
+		}
+		if event.author != uuid.Nil {
+			isPrivate, err := s.repo.IsPrivateUser(ctx, event.author)
+			if err != nil {
+				logging.FromContext(ctx).Error().Err(err).Msg("failed to check author privacy for webhooks")
+				return
+			}
+			if isPrivate {
+				return
+			}
+		}
+
+		if err := s.dispatcher.Dispatch(ctx, source.eventType, event.owners, event.data); err != nil {
+			logging.FromContext(ctx).Error().Err(err).Str("event_type", source.eventType).Msg("failed to dispatch webhooks")
+		}
+	}
+}
+
+func decodePostCreatedWebhook(msg *nats.Msg) (*webhookEvent, error) {
+	var event eventspb.PostCreated
+	if _, err := eventschema.Decode(events.SubjectPostCreated, msg.Header, msg.Data, &event); err != nil {
+		return nil, fmt.Errorf("failed to decode post created event: %w", err)
+	}
+	// Only posts anyone may see are delivered; older events carry no
+	// visibility and are public
+	if event.Visibility != "" && event.Visibility != "PUBLIC" {
+		return nil, nil
+	}
+
+	ids, err := parseEventIDs(event.PostId, event.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &webhookEvent{
+		data: models.WebhookPostCreatedData{
+			PostID:    ids[0],
+			UserID:    ids[1],
+			Content:   event.Content,
+			CreatedAt: event.CreatedAt.AsTime(),
+		},
+		owners: ids[1:],
+		author: ids[1],
+	}, nil
+}
+
+func (s *WebhookSubscriber) handleUserUpdated(msg *nats.Msg) {
+	ctx, span := tracing.StartProcess(s.ctx, msg.Subject, msg.Header)
+	ctx = logging.Extract(ctx, msg.Subject, msg.Header)
+	defer span.End()
+
+	var event events.UserUpdatedEvent
+	if err := natsClient.DecodeEvent(msg, &event); err != nil {
+		logging.FromContext(ctx).Warn().Err(err).Msg("dropping invalid user updated event")
+		return
+	}
+
+	if err := s.repo.SetUserPrivacy(ctx, event.UserID, event.IsPrivate, event.UpdatedAt); err != nil {
+		logging.FromContext(ctx).Error().Err(err).Msg("failed to set user privacy")
+	}
+}
+
+func decodeCommentAddedWebhook(msg *nats.Msg) (*webhookEvent, error) {
+	var event eventspb.CommentAdded
+	if _, err := eventschema.Decode(events.SubjectCommentAdded, msg.Header, msg.Data, &event); err != nil {
+		return nil, fmt.Errorf("failed to decode comment added event: %w", err)
+	}
+
+	// Version 1 events carried the author of the comment in post_user_id
+	author := event.UserId
+	if author == "" {
+		author = event.PostUserId
+	}
+	ids, err := parseEventIDs(event.CommentId, event.PostId, author)
+	if err != nil {
+		return nil, err
+	}
+
+	return &webhookEvent{
+		data: models.WebhookCommentAddedData{
+			CommentID: ids[0],
+			PostID:    ids[1],
+			UserID:    ids[2],
+			Content:   event.Content,
+			CreatedAt: event.CreatedAt.AsTime(),
+		},
+		owners: ids[2:],
+	}, nil
+}
+
+func decodeUserFollowedWebhook(msg *nats.Msg) (*webhookEvent, error) {
+	var event events.UserFollowedEvent
+	if err := natsClient.DecodeEvent(msg, &event); err != nil {
+		return nil, fmt.Errorf("failed to decode user followed event: %w", err)
+	}
+
+	return followWebhook(event.FollowerID, event.FollowingID, event.CreatedAt), nil
+}
+
+func decodeUserUnfollowedWebhook(msg *nats.Msg) (*webhookEvent, error) {
+	var event events.UserUnfollowedEvent
+	if err := natsClient.DecodeEvent(msg, &event); err != nil {
+		return nil, fmt.Errorf("failed to decode user unfollowed event: %w", err)
+	}
+
+	return followWebhook(event.FollowerID, event.FollowingID, event.DeletedAt), nil
+}
+
+func followWebhook(followerID, followingID uuid.UUID, occurredAt time.Time) *webhookEvent {
+	return &webhookEvent{
+		data: models.WebhookFollowData{
+			FollowerID:  followerID,
+			FollowingID: followingID,
+			OccurredAt:  occurredAt,
+		},
+		owners: []uuid.UUID{followerID, followingID},
+	}
+}
+
+// parseEventIDs parses the IDs of an event, all of which must be set
+func parseEventIDs(ids ...string) ([]uuid.UUID, error) {
+	parsed := make([]uuid.UUID, len(ids))
+	for i, id := range ids {
+		var err error
+		if parsed[i], err = uuid.Parse(id); err != nil {
+			return nil, fmt.Errorf("invalid id %q: %w", id, err)
+		}
+	}
+	return parsed, nil
+}
+
+func (s *WebhookSubscriber) Stop() error {
+	for _, sub := range s.subs {
+		sub.Unsubscribe()
+	}
+	return nil
+}
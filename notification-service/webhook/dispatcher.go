@@ -0,0 +1,219 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"notification-service/model"
+	"notification-service/repository"
+	"shared/logging"
+)
+
+// Headers sent with every delivery so receivers can verify and deduplicate
+const (
+	HeaderEvent     = "X-Muzeeng-Event"
+	HeaderDelivery  = "X-Muzeeng-Delivery"
+	HeaderTimestamp = "X-Muzeeng-Timestamp"
+	HeaderSignature = "X-Muzeeng-Signature"
+)
+
+type Config struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	Timeout        time.Duration
+	MaxConcurrency int
+}
+
+// Dispatcher delivers signed event payloads to registered webhook endpoints,
+// retrying with exponential backoff and recording every attempt
+type Dispatcher struct {
+	repo   repository.WebhookRepository
+	client *http.Client
+	cfg    Config
+	sem    chan struct{}
+	wg     sync.WaitGroup
+}
+
+func NewDispatcher(repo repository.WebhookRepository, cfg Config) *Dispatcher {
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = 5
+	}
+	if cfg.InitialBackoff <= 0 {
+		cfg.InitialBackoff = time.Second
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 10 * time.Second
+	}
+	if cfg.MaxConcurrency <= 0 {
+		cfg.MaxConcurrency = 20
+	}
+
+	return &Dispatcher{
+		repo:   repo,
+		client: newClient(cfg.Timeout),
+		cfg:    cfg,
+		sem:    make(chan struct{}, cfg.MaxConcurrency),
+	}
+}
+
+// Dispatch fans an event out to the active webhooks of owners subscribed to
+// eventType, with data as the payload's data. Deliveries run in the
+// background; only the lookup error is returned
+func (d *Dispatcher) Dispatch(ctx context.Context, eventType string, owners []uuid.UUID, data interface{}) error {
+	webhooks, err := d.repo.ListActiveByEventType(ctx, eventType, owners)
+	if err != nil {
+		return fmt.Errorf("failed to list webhooks for %s: %w", eventType, err)
+	}
+	if len(webhooks) == 0 {
+		return nil
+	}
+
+	payload := models.WebhookPayload{
+		ID:        uuid.New(),
+		Type:      eventType,
+		CreatedAt: time.Now().UTC(),
+		Data:      data,
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook payload: %w", err)
+	}
+
+	for _, webhook := range webhooks {
+		d.wg.Add(1)
+		go d.deliver(ctx, webhook, payload.ID, eventType, body)
+	}
+
+	return nil
+}
+
+// Wait waits for the deliveries in progress to finish, or for ctx to be done
+func (d *Dispatcher) Wait(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		d.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (d *Dispatcher) deliver(ctx context.Context, webhook models.Webhook, eventID uuid.UUID, eventType string, body []byte) {
+	defer d.wg.Done()
+	d.sem <- struct{}{}
+	defer func() { <-d.sem }()
+
+	backoff := d.cfg.InitialBackoff
+	for attempt := 1; attempt <= d.cfg.MaxAttempts; attempt++ {
+		delivery := d.send(ctx, webhook, eventID, eventType, body, attempt)
+
+		if err := d.repo.CreateDelivery(ctx, delivery); err != nil {
+			logging.FromContext(ctx).Error().Err(err).Stringer("webhook_id", webhook.ID).Msg("failed to record webhook delivery")
+		}
+
+		if delivery.Success {
+			return
+		}
+
+		if attempt == d.cfg.MaxAttempts {
+			logging.FromContext(ctx).Warn().Stringer("webhook_id", webhook.ID).Stringer("event_id", eventID).Int("attempts", attempt).Msg("webhook gave up on event")
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+func (d *Dispatcher) send(ctx context.Context, webhook models.Webhook, eventID uuid.UUID, eventType string, body []byte, attempt int) *models.WebhookDelivery {
+	delivery := &models.WebhookDelivery{
+		ID:        uuid.New(),
+		WebhookID: webhook.ID,
+		EventID:   eventID,
+		EventType: eventType,
+		Attempt:   int32(attempt),
+		CreatedAt: time.Now(),
+	}
+
+	fail := func(err error) *models.WebhookDelivery {
+		msg := err.Error()
+		delivery.Error = &msg
+		delivery.DurationMs = time.Since(delivery.CreatedAt).Milliseconds()
+		return delivery
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return fail(err)
+	}
+	// Webhooks registered before https was required are not delivered to
+	if req.URL.Scheme != "https" {
+		return fail(fmt.Errorf("endpoint is not https"))
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "muzeeng-webhooks/1.0")
+	req.Header.Set(HeaderEvent, eventType)
+	req.Header.Set(HeaderDelivery, eventID.String())
+	req.Header.Set(HeaderTimestamp, timestamp)
+	req.Header.Set(HeaderSignature, "sha256="+Sign(webhook.Secret, timestamp, body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return fail(err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	statusCode := int32(resp.StatusCode)
+	delivery.StatusCode = &statusCode
+	delivery.DurationMs = time.Since(delivery.CreatedAt).Milliseconds()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fail(fmt.Errorf("endpoint responded with status %d", resp.StatusCode))
+	}
+
+	delivery.Success = true
+	return delivery
+}
+
+// Sign returns the hex HMAC-SHA256 of "<timestamp>.<body>" keyed by the webhook secret
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// GenerateSecret returns a random signing secret for a new webhook
+func GenerateSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(buf), nil
+}
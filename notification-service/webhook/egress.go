@@ -0,0 +1,63 @@
+package webhook
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+)
+
+// ErrNonPublicAddress is returned for connections to an address that is not
+// on the public internet
+var ErrNonPublicAddress = errors.New("webhook endpoint resolves to a non-public address")
+
+// PublicAddr reports whether deliveries may connect to addr: loopback,
+// private, link-local, unspecified and multicast addresses reach the cluster
+// or its host rather than a developer's endpoint
+func PublicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return addr.IsGlobalUnicast() && !addr.IsPrivate()
+}
+
+// publicOnly is the Control hook of the deliveries' dialer. It sees the
+// resolved address of every connection, so names that resolve, or are
+// rebound, to an internal address are refused too.
+func publicOnly(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return err
+	}
+	if !PublicAddr(addr) {
+		return fmt.Errorf("%w: %s", ErrNonPublicAddress, addr)
+	}
+	return nil
+}
+
+// newClient returns the client deliveries are sent with. It only connects
+// to public addresses, never goes through a proxy, which would connect for
+// it, and does not follow redirects: a 3xx is a failed delivery.
+func newClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: publicOnly,
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
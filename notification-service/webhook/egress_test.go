@@ -0,0 +1,53 @@
+package webhook
+
+import (
+	"errors"
+	"net/netip"
+	"testing"
+)
+
+func TestPublicAddr(t *testing.T) {
+	tests := []struct {
+		addr   string
+		public bool
+	}{
+		{addr: "93.184.216.34", public: true},
+		{addr: "2606:2800:220:1:248:1893:25c8:1946", public: true},
+		{addr: "127.0.0.1"},
+		{addr: "127.255.0.1"},
+		{addr: "::1"},
+		{addr: "10.0.0.1"},
+		{addr: "172.16.5.4"},
+		{addr: "192.168.1.1"},
+		{addr: "fd00::1"},
+		{addr: "169.254.169.254"},
+		{addr: "fe80::1"},
+		{addr: "0.0.0.0"},
+		{addr: "::"},
+		{addr: "224.0.0.1"},
+		{addr: "ff02::1"},
+		{addr: "::ffff:127.0.0.1"},
+		{addr: "::ffff:10.0.0.1"},
+		{addr: "::ffff:93.184.216.34", public: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.addr, func(t *testing.T) {
+			if got := PublicAddr(netip.MustParseAddr(tt.addr)); got != tt.public {
+				t.Errorf("PublicAddr(%s) = %v; want %v", tt.addr, got, tt.public)
+			}
+		})
+	}
+}
+
+func TestPublicOnly(t *testing.T) {
+	if err := publicOnly("tcp4", "93.184.216.34:443", nil); err != nil {
+		t.Errorf("publicOnly() error = %v; want nil", err)
+	}
+	if err := publicOnly("tcp4", "127.0.0.1:8080", nil); !errors.Is(err, ErrNonPublicAddress) {
+		t.Errorf("publicOnly() error = %v; want ErrNonPublicAddress", err)
+	}
+	if err := publicOnly("tcp6", "[fe80::1]:443", nil); !errors.Is(err, ErrNonPublicAddress) {
+		t.Errorf("publicOnly() error = %v; want ErrNonPublicAddress", err)
+	}
+}
@@ -2,33 +2,62 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"log"
 	"net"
 	"os"
-	"os/signal"
-	"syscall"
 	"time"
 
 	"github.com/joho/godotenv"
+	"github.com/redis/go-redis/v9"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
 
+	commentpb "comment-service/pb"
+	followpb "follow-service/pb"
+	likepb "like-service/pb"
 	"post-service/config"
+	"post-service/contentfilter"
 	"post-service/db"
 	"post-service/handler"
 	"post-service/interceptor"
+	"post-service/media"
+	"post-service/migrations"
 	natsClient "post-service/nats"
+	"post-service/outbox"
 	pb "post-service/pb"
 	"post-service/publisher"
-
 	"post-service/repository"
+	"post-service/rpcerror"
+	"post-service/scheduling"
+	"post-service/subscriber"
+	"shared/chaos"
+	"shared/cursor"
+	"shared/dedupe"
+	"shared/idempotency"
+	"shared/lifecycle"
+	"shared/logging"
+	"shared/migrate"
+	"shared/mtls"
+	"shared/tracing"
+	userpb "user-service/pb"
 )
 
 func main() {
+	logging.Init("post-service")
+
+	migrateFlag := flag.Bool("migrate", false, "apply pending schema migrations before serving")
+	flag.Parse()
+
 	if err := godotenv.Load(); err != nil {
 		log.Println("failed to load Post .env")
 	}
+
+	// The supervisor health-checks the dependencies below and closes them
+	// in order on shutdown
+	supervisor := lifecycle.New(pb.PostService_ServiceDesc.ServiceName)
+
 	// Load database configuration
 	dbCfg, err := config.LoadDatabaseConfig("")
 	if err != nil {
@@ -46,17 +75,72 @@ func main() {
 		MaxOpenConns: dbCfg.MaxOpenConns,
 		MaxIdleConns: dbCfg.MaxIdleConns,
 		MaxLifetime:  dbCfg.MaxLifetime,
+		ReplicaDSNs:  dbCfg.ReplicaDSNs,
 	})
 	if err != nil {
 		log.Fatalf("Failed to connect to Post database: %v", err)
 	}
-	defer dbConn.Close()
+	supervisor.Add(lifecycle.Dependency{Name: "database", Check: dbConn.HealthCheck, Close: dbConn.Close})
+
+	// Apply pending schema migrations with -migrate or DB_MIGRATE=true;
+	// without either they are only reported
+	if err := migrate.OnStartup(context.Background(), dbConn.DB, "post-service", migrations.Files, *migrateFlag || os.Getenv("DB_MIGRATE") == "true"); err != nil {
+		log.Fatalf("Failed to migrate Post database: %v", err)
+	}
 
 	log.Println("Successfully connected to Post database")
 
+	// Connect to Redis, which caches post bodies and first pages of user posts
+	redisClient := redis.NewClient(&redis.Options{
+		Addr:     getEnv("REDIS_URL", "redis:6379"),
+		Password: getEnv("REDIS_PASSWORD", ""),
+		DB:       getEnvAsInt("REDIS_DB", 0),
+		PoolSize: 10,
+	})
+	// Redis only caches, so the service stays up without it
+	supervisor.Add(lifecycle.Dependency{Name: "redis", Check: func(ctx context.Context) error { return redisClient.Ping(ctx).Err() }, Close: redisClient.Close, Optional: true})
+
+	pingCtx, pingCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer pingCancel()
+	if err := redisClient.Ping(pingCtx).Err(); err != nil {
+		log.Fatalf("Failed to connect to Post Redis: %v", err)
+	}
+	log.Println("Post Redis connected successfully")
+
 	// Load other service-level configs
 	grpcPort := getEnv("GRPC_PORT", "50053")
 	jwtSecret := getEnv("JWT_SECRET", "your-secret-key")
+	// Tokens are verified with JWT_SECRET, or with auth-service's public keys
+	// when JWT_ALGORITHM is RS256
+	tokenKeys, err := interceptor.KeysFromEnv(jwtSecret)
+	if err != nil {
+		log.Fatalf("Invalid JWT configuration: %v", err)
+	}
+
+	// Fault injection for resilience testing; inert unless CHAOS_ENABLED=true
+	chaosInjector, err := chaos.FromEnv()
+	if err != nil {
+		log.Fatalf("Invalid chaos configuration: %v", err)
+	}
+
+	// Screen post content before it is stored; off unless a CONTENT_FILTER_*
+	// variable is set
+	contentFilter, err := contentfilter.FromEnv()
+	if err != nil {
+		log.Fatalf("Invalid content filter configuration: %v", err)
+	}
+
+	// Export traces when OTEL_EXPORTER_OTLP_ENDPOINT is set
+	shutdownTracing, err := tracing.Init(context.Background(), "post-service")
+	if err != nil {
+		log.Fatalf("Failed to initialize tracing: %v", err)
+	}
+	supervisor.OnShutdown("tracing", shutdownTracing)
+
+	// Pagination cursors are signed so clients cannot forge positions
+	if err := cursor.SetSecret(os.Getenv("CURSOR_SECRET")); err != nil {
+		log.Fatalf("Invalid CURSOR_SECRET: %v", err)
+	}
 	natsURL := getEnv("NATS_URL", "nats://nats:4222")
 	natsClientID := getEnv("NATS_CLIENT_ID", "post-service")
 
@@ -66,57 +150,152 @@ func main() {
 		MaxReconnects: 10,
 		ReconnectWait: 2 * time.Second,
 		ClientID:      natsClientID,
+		Chaos:         chaosInjector,
 	}
 
 	nats, err := natsClient.NewClient(natsCfg)
 	if err != nil {
 		log.Fatalf("Failed to initialize NATS client: %v", err)
 	}
-	defer nats.Close()
+	supervisor.Add(lifecycle.Dependency{Name: "nats", Check: nats.HealthCheck, Drain: nats.Drain})
 	log.Println("NATS client initialized successfully")
 
-	// Initialize event publisher
-	eventPublisher := publisher.NewEventPublisher(nats)
+	// Events are stored in the outbox with the changes they describe and
+	// relayed to JetStream once committed
+	eventPublisher := publisher.NewEventPublisher(outbox.New(dbConn))
+	relayCtx, stopRelay := context.WithCancel(supervisor.Context())
+	defer stopRelay()
+	// Events that keep failing to publish are set aside in the dead-letter
+	// stream, so they stop holding back the rest
+	if err := nats.EnsureStream(outbox.DeadLetterStream, []string{outbox.DeadLetterSubjects}, outbox.DeadLetterRetention); err != nil {
+		log.Fatalf("Failed to create dead-letter stream: %v", err)
+	}
+	relay := outbox.NewRelay(dbConn, nats)
+	go relay.Run(relayCtx)
+	// Published, failed and dead-lettered events are logged every
+	// OUTBOX_STATS_INTERVAL
+	go logRelayStats(relayCtx, relay, getEnvAsDuration("OUTBOX_STATS_INTERVAL", 5*time.Minute))
+
+	// Other services are dialed over TLS when GRPC_TLS is set
+	clientTLS, err := mtls.DialOption()
+	if err != nil {
+		log.Fatalf("Failed to load TLS config: %v", err)
+	}
+
+	// Mentions are resolved to users through user-service
+	userConn, err := grpc.NewClient(getEnv("USER_SERVICE_ADDR", "user-service:50052"), clientTLS, tracing.DialOption(), grpc.WithChainUnaryInterceptor(logging.UnaryClientInterceptor()))
+	if err != nil {
+		log.Fatalf("Failed to connect to user service: %v", err)
+	}
+	defer userConn.Close()
+
+	// Posts by private accounts are only shown to followers, checked through
+	// follow-service
+	followConn, err := grpc.NewClient(getEnv("FOLLOW_SERVICE_ADDR", "follow-service:50055"), clientTLS, tracing.DialOption(), grpc.WithChainUnaryInterceptor(logging.UnaryClientInterceptor()))
+	if err != nil {
+		log.Fatalf("Failed to connect to follow service: %v", err)
+	}
+	defer followConn.Close()
+
+	// Counters are recounted from like-service and comment-service, which
+	// hold the likes and comments themselves
+	likeConn, err := grpc.NewClient(getEnv("LIKE_SERVICE_ADDR", "like-service:50057"), clientTLS, tracing.DialOption(), grpc.WithChainUnaryInterceptor(logging.UnaryClientInterceptor()))
+	if err != nil {
+		log.Fatalf("Failed to connect to like service: %v", err)
+	}
+	defer likeConn.Close()
+
+	commentConn, err := grpc.NewClient(getEnv("COMMENT_SERVICE_ADDR", "comment-service:50056"), clientTLS, tracing.DialOption(), grpc.WithChainUnaryInterceptor(logging.UnaryClientInterceptor()))
+	if err != nil {
+		log.Fatalf("Failed to connect to comment service: %v", err)
+	}
+	defer commentConn.Close()
+
+	// Uploaded media is kept on disk; replicas must share MEDIA_DIR
+	mediaStore, err := media.NewFileStore(getEnv("MEDIA_DIR", "/var/lib/muzeeng/media"))
+	if err != nil {
+		log.Fatalf("Failed to initialize media store: %v", err)
+	}
 
 	// Initialize repository and handler
-	postRepo := repository.NewPostRepository(dbConn.DB)
-	postHandler := handler.NewPostHandler(postRepo, eventPublisher)
+	postRepo := repository.NewPostRepository(dbConn, redisClient)
+	postHandler := handler.NewPostHandler(postRepo, eventPublisher, userpb.NewUserServiceClient(userConn), followpb.NewFollowServiceClient(followConn), likepb.NewLikeServiceClient(likeConn), commentpb.NewCommentServiceClient(commentConn), mediaStore, contentFilter)
 
 	// Initialize auth interceptor (allowing public routes)
-	authInterceptor := interceptor.NewAuthInterceptor(jwtSecret, []string{
+	authInterceptor := interceptor.NewAuthInterceptor(tokenKeys, []string{
 		"/post.PostService/GetPost",
+		"/post.PostService/GetPostsByIds",
 		"/post.PostService/GetUserPosts",
+		"/post.PostService/ListPublicPosts",
+		"/post.PostService/GetPostsByHashtag",
+		"/post.PostService/GetTrendingHashtags",
+		"/post.PostService/GetPostRevisions",
+		"/post.PostService/GetPollResults",
+		"/post.PostService/GetMediaContent",
 	})
+	authInterceptor.AddAdminMethods([]string{
+		"/post.PostService/ReplayPostEvents",
+		"/post.PostService/SetPostCounters",
+		"/post.PostService/RecountPost",
+		"/post.PostService/ReconcilePostCounters",
+		"/post.PostService/GetPostsCounts",
+		"/post.PostService/ImportPosts",
+		"/post.PostService/PurgeDeletedPosts",
+		"/post.PostService/RemovePost",
+	})
+	authInterceptor.AddPublicMethods(lifecycle.HealthMethods)
 
-	// Create gRPC server with interceptors
-	grpcServer := grpc.NewServer(
-		grpc.UnaryInterceptor(authInterceptor.Unary()),
-		grpc.StreamInterceptor(authInterceptor.Stream()),
-	)
+	// Scheduled posts are published within POST_SCHEDULER_INTERVAL_SECONDS
+	// of their time; 0 turns publishing off on this replica
+	schedulerCtx, stopScheduler := context.WithCancel(supervisor.Context())
+	defer stopScheduler()
+	if interval := getEnvAsInt("POST_SCHEDULER_INTERVAL_SECONDS", 15); interval > 0 {
+		go scheduling.New(postHandler, time.Duration(interval)*time.Second).Run(schedulerCtx)
+	}
 
-	// Graceful shutdown handling
-	go func() {
-		sigChan := make(chan os.Signal, 1)
-		signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
-		<-sigChan
+	// User deletions already handled are recorded, so redeliveries are
+	// skipped
+	processedEvents := dedupe.New(dbConn.DB, "post_service_processed_events")
+	go processedEvents.RunPruning(supervisor.Context())
 
-		log.Println("Post service Shutting down gracefully...")
-		grpcServer.GracefulStop()
+	// Posts and reposts are deleted along with their user, and likes and
+	// comments are counted from the events of their services
+	subscriberCtx, stopSubscribers := context.WithCancel(context.Background())
+	defer stopSubscribers()
+	subscriber.NewUserSubscriber(nats, processedEvents, postHandler, subscriberCtx).Start()
+	subscriber.NewCounterSubscriber(nats, postHandler, subscriberCtx).Start()
 
-		ctx, cancel := context.WithTimeout(context.Background(), dbCfg.MaxLifetime)
-		defer cancel()
+	// Retries of writes that carry an idempotency-key header get the first
+	// call's response instead of writing again. Keys are per caller.
+	idempotencyStore, err := idempotency.NewStore(dbConn.WriteDB(), "post_service_idempotency_keys", rpcerror.Domain, []byte(os.Getenv("IDEMPOTENCY_SECRET")))
+	if err != nil {
+		log.Fatalf("Invalid IDEMPOTENCY_SECRET: %v", err)
+	}
+	idempotencyCtx, stopIdempotency := context.WithCancel(supervisor.Context())
+	defer stopIdempotency()
+	go idempotencyStore.Run(idempotencyCtx)
+	idempotent := idempotencyStore.UnaryServerInterceptor(func(ctx context.Context) string {
+		userID, _ := interceptor.GetUserIDFromContext(ctx)
+		return userID
+	}, "/post.PostService/CreatePost")
 
-		if err := dbConn.HealthCheck(ctx); err == nil {
-			_ = dbConn.Close()
-			log.Println("Post Database connection closed")
-		}
+	// TLS, and mutual TLS with a CA bundle, from GRPC_TLS_*
+	tlsOption, err := mtls.ServerOption()
+	if err != nil {
+		log.Fatalf("Failed to load TLS config: %v", err)
+	}
 
-		log.Println("Server stopped")
-		os.Exit(0)
-	}()
+	// Create gRPC server with interceptors
+	grpcServer := grpc.NewServer(
+		tlsOption,
+		tracing.ServerOption(),
+		grpc.ChainUnaryInterceptor(logging.UnaryServerInterceptor(), rpcerror.UnaryServerInterceptor(), chaosInjector.Unary(), authInterceptor.Unary(), idempotent),
+		grpc.ChainStreamInterceptor(logging.StreamServerInterceptor(), rpcerror.StreamServerInterceptor(), chaosInjector.Stream(), authInterceptor.Stream()),
+	)
 
 	// Register the gRPC service
 	pb.RegisterPostServiceServer(grpcServer, postHandler)
+	supervisor.Register(grpcServer)
 
 	// Enable reflection for debugging tools like grpcurl
 	reflection.Register(grpcServer)
@@ -130,7 +309,7 @@ func main() {
 	log.Printf("Post Service gRPC server listening on port %s", grpcPort)
 
 	// Serve requests
-	if err := grpcServer.Serve(listener); err != nil {
+	if err := supervisor.Serve(grpcServer, listener); err != nil {
 		log.Fatalf("Failed to serve: %v", err)
 	}
 }
@@ -142,3 +321,52 @@ func getEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+func getEnvAsInt(key string, defaultVal int) int {
+	if val := os.Getenv(key); val != "" {
+		var intVal int
+		fmt.Sscanf(val, "%d", &intVal)
+		return intVal
+	}
+	return defaultVal
+}
+
+func getEnvAsDuration(key string, defaultVal time.Duration) time.Duration {
+	if val, err := time.ParseDuration(os.Getenv(key)); err == nil && val > 0 {
+		return val
+	}
+	return defaultVal
+}
+
+// logRelayStats logs the events the outbox relay published, failed to publish
+// and dead-lettered in each interval until ctx is cancelled
+func logRelayStats(ctx context.Context, relay *outbox.Relay, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	last := relay.Stats()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		stats := relay.Stats()
+		published, failed, deadLettered := stats.Published-last.Published, stats.Failed-last.Failed, stats.DeadLettered-last.DeadLettered
+		last = stats
+		if published+failed+deadLettered == 0 {
+			continue
+		}
+
+		event := logging.FromContext(ctx).Info()
+		if failed > 0 {
+			event = logging.FromContext(ctx).Warn()
+		}
+		event.
+			Int64("published", published).
+			Int64("failed", failed).
+			Int64("dead_lettered", deadLettered).
+			Msg("outbox relay stats")
+	}
+}
@@ -0,0 +1,155 @@
+package contentfilter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// WordFilter matches whole words from a list, ignoring case, so "class" does
+// not match "ass"
+type WordFilter struct {
+	words map[string]bool
+}
+
+func NewWordFilter(words []string) *WordFilter {
+	f := &WordFilter{words: make(map[string]bool, len(words))}
+	for _, w := range words {
+		f.words[strings.ToLower(w)] = true
+	}
+	return f
+}
+
+func (f *WordFilter) Name() string { return "words" }
+
+func (f *WordFilter) Check(_ context.Context, text string) ([]string, error) {
+	seen := make(map[string]bool)
+	var matched []string
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, w := range words {
+		if f.words[w] && !seen[w] {
+			seen[w] = true
+			matched = append(matched, w)
+		}
+	}
+	return matched, nil
+}
+
+// linkPattern finds links with or without a scheme, e.g. https://a.example/x
+// and a.example/x
+var linkPattern = regexp.MustCompile(`(?i)\b(?:https?://)?((?:[a-z0-9](?:[a-z0-9-]*[a-z0-9])?\.)+[a-z]{2,})\b`)
+
+// DomainFilter matches links to blocked domains and their subdomains
+type DomainFilter struct {
+	domains []string
+}
+
+func NewDomainFilter(domains []string) *DomainFilter {
+	f := &DomainFilter{}
+	for _, d := range domains {
+		f.domains = append(f.domains, strings.TrimPrefix(strings.ToLower(d), "."))
+	}
+	return f
+}
+
+func (f *DomainFilter) Name() string { return "domains" }
+
+func (f *DomainFilter) Check(_ context.Context, text string) ([]string, error) {
+	seen := make(map[string]bool)
+	var matched []string
+	for _, m := range linkPattern.FindAllStringSubmatch(text, -1) {
+		host := strings.ToLower(m[1])
+		if seen[host] {
+			continue
+		}
+		seen[host] = true
+		for _, d := range f.domains {
+			if host == d || strings.HasSuffix(host, "."+d) {
+				matched = append(matched, host)
+				break
+			}
+		}
+	}
+	return matched, nil
+}
+
+// APIFilter asks an external moderation service about the text. It POSTs
+// {"text": "..."} and expects {"flagged": true, "categories": ["..."]} back.
+// With failOpen, text is allowed when the service cannot be reached or
+// answers with an error; otherwise the check fails.
+type APIFilter struct {
+	url      string
+	apiKey   string
+	failOpen bool
+	client   *http.Client
+}
+
+func NewAPIFilter(url, apiKey string, timeout time.Duration, failOpen bool) *APIFilter {
+	return &APIFilter{
+		url:      url,
+		apiKey:   apiKey,
+		failOpen: failOpen,
+		client:   &http.Client{Timeout: timeout},
+	}
+}
+
+func (f *APIFilter) Name() string { return "api" }
+
+func (f *APIFilter) Check(ctx context.Context, text string) ([]string, error) {
+	reasons, err := f.call(ctx, text)
+	if err != nil && f.failOpen {
+		log.Printf("Content filter API failed, allowing content: %v", err)
+		return nil, nil
+	}
+	return reasons, err
+}
+
+func (f *APIFilter) call(ctx context.Context, text string) ([]string, error) {
+	body, err := json.Marshal(map[string]string{"text": text})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, f.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if f.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+f.apiKey)
+	}
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		io.Copy(io.Discard, resp.Body)
+		return nil, fmt.Errorf("moderation API answered %s", resp.Status)
+	}
+
+	var result struct {
+		Flagged    bool     `json:"flagged"`
+		Categories []string `json:"categories"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode moderation API response: %w", err)
+	}
+	if !result.Flagged {
+		return nil, nil
+	}
+	if len(result.Categories) == 0 {
+		return []string{"flagged"}, nil
+	}
+	return result.Categories, nil
+}
@@ -2,7 +2,9 @@ package database
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
+	"sync/atomic"
 	"time"
 
 	"github.com/jmoiron/sqlx"
@@ -19,19 +21,45 @@ type Config struct {
 	MaxOpenConns int
 	MaxIdleConns int
 	MaxLifetime  time.Duration
+	// ReplicaDSNs are optional read replicas; reads fall back to the primary when empty
+	ReplicaDSNs []string
 }
 
+// DB wraps the primary connection and any read replicas. The embedded
+// *sqlx.DB is the primary, so existing callers keep writing to it.
 type DB struct {
 	*sqlx.DB
+	replicas []*sqlx.DB
+	next     atomic.Uint32
 }
 
-// NewConnection creates a new PostgreSQL database connection
+// NewConnection creates a new PostgreSQL database connection to the primary
+// and to every configured read replica
 func NewConnection(cfg Config) (*DB, error) {
 	dsn := fmt.Sprintf(
 		"host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
 		cfg.Host, cfg.Port, cfg.User, cfg.Password, cfg.DBName, cfg.SSLMode,
 	)
 
+	primary, err := connect(dsn, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	db := &DB{DB: primary}
+	for i, replicaDSN := range cfg.ReplicaDSNs {
+		replica, err := connect(replicaDSN, cfg)
+		if err != nil {
+			db.Close()
+			return nil, fmt.Errorf("replica %d: %w", i, err)
+		}
+		db.replicas = append(db.replicas, replica)
+	}
+
+	return db, nil
+}
+
+func connect(dsn string, cfg Config) (*sqlx.DB, error) {
 	db, err := sqlx.Connect("postgres", dsn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
@@ -45,20 +73,47 @@ func NewConnection(cfg Config) (*DB, error) {
 	defer cancel()
 
 	if err := db.PingContext(ctx); err != nil {
+		db.Close()
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
-	return &DB{db}, nil
+	return db, nil
 }
 
-// Close closes the database connection
+// WriteDB returns the primary connection
+func (db *DB) WriteDB() *sqlx.DB {
+	return db.DB
+}
+
+// ReadDB returns a read replica chosen round-robin, or the primary when no
+// replicas are configured. Only use it for queries that tolerate replication lag.
+func (db *DB) ReadDB() *sqlx.DB {
+	if len(db.replicas) == 0 {
+		return db.DB
+	}
+	n := db.next.Add(1)
+	return db.replicas[int(n)%len(db.replicas)]
+}
+
+// Close closes the primary and replica connections
 func (db *DB) Close() error {
+	for _, replica := range db.replicas {
+		replica.Close()
+	}
 	return db.DB.Close()
 }
 
-// HealthCheck checks if the database is healthy
+// HealthCheck checks if the primary and all replicas are healthy
 func (db *DB) HealthCheck(ctx context.Context) error {
-	return db.PingContext(ctx)
+	if err := db.PingContext(ctx); err != nil {
+		return err
+	}
+	for i, replica := range db.replicas {
+		if err := replica.PingContext(ctx); err != nil {
+			return fmt.Errorf("replica %d: %w", i, err)
+		}
+	}
+	return nil
 }
 
 // WithTransaction executes a function within a database transaction
@@ -88,3 +143,37 @@ func (db *DB) WithTransaction(ctx context.Context, fn func(*sqlx.Tx) error) erro
 
 	return nil
 }
+
+// DBTX is the query surface shared by *sqlx.DB and *sqlx.Tx, so repository
+// methods can run against either
+type DBTX interface {
+	sqlx.ExtContext
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+	GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+	SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+	NamedExecContext(ctx context.Context, query string, arg interface{}) (sql.Result, error)
+}
+
+type txKey struct{}
+
+// WithTx runs fn as a single unit of work. The transaction travels on the
+// context passed to fn, and repositories pick it up through Conn, so every
+// repository call made with that context commits or rolls back together.
+// Nested calls join the outer transaction.
+func (db *DB) WithTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*sqlx.Tx); ok {
+		return fn(ctx)
+	}
+	return db.WithTransaction(ctx, func(tx *sqlx.Tx) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+}
+
+// Conn returns the transaction started by WithTx when ctx carries one, and
+// the primary connection otherwise
+func (db *DB) Conn(ctx context.Context) DBTX {
+	if tx, ok := ctx.Value(txKey{}).(*sqlx.Tx); ok {
+		return tx
+	}
+	return db.DB
+}
@@ -4,16 +4,86 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"shared/eventschema"
 )
 
 const (
-	PostCreated = "post.created"
+	// PostCreated, PostUpdated and PostDeleted carry the payloads of
+	// shared/eventschema
+	PostCreated    = eventschema.PostCreated
+	PostUpdated    = eventschema.PostUpdated
+	PostDeleted    = eventschema.PostDeleted
+	PostReposted   = "post.reposted"
+	PostUnreposted = "post.unreposted"
+	// MentionCreated is shared with comment-service, which publishes it for
+	// mentions in comments
+	MentionCreated = "mention.created"
+	// ContentFlagged is shared with comment-service, which publishes it for
+	// flagged comments
+	ContentFlagged = "content.flagged"
+	// UserDeleted is published by auth-service when an account is deleted
+	UserDeleted = "user.deleted"
+	// PostLiked and PostUnliked are published by like-service, CommentAdded
+	// and CommentDeleted by comment-service; they are counted into the
+	// counters of posts
+	PostLiked      = "post.liked"
+	PostUnliked    = "post.unliked"
+	CommentAdded   = "post.comment.added"
+	CommentDeleted = "post.comment.deleted"
 )
 
-// Event payloads
-type PostCreatedEvent struct {
+// MentionCreatedEvent is published once per user newly mentioned in a post
+type MentionCreatedEvent struct {
+	MentionID       uuid.UUID  `json:"mention_id"`
+	PostID          uuid.UUID  `json:"post_id"`
+	CommentID       *uuid.UUID `json:"comment_id,omitempty"`
+	AuthorID        uuid.UUID  `json:"author_id"`
+	MentionedUserID uuid.UUID  `json:"mentioned_user_id"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+// PostRepostedEvent carries the reposted post as well, so feed-service can
+// place it in the feeds of the reposter's followers
+type PostRepostedEvent struct {
+	RepostID      uuid.UUID `json:"repost_id"`
+	PostID        uuid.UUID `json:"post_id"`
+	PostAuthorID  uuid.UUID `json:"post_author_id"`
+	PostContent   string    `json:"post_content"`
+	PostCreatedAt time.Time `json:"post_created_at"`
+	UserID        uuid.UUID `json:"user_id"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type PostUnrepostedEvent struct {
+	RepostID  uuid.UUID `json:"repost_id"`
 	PostID    uuid.UUID `json:"post_id"`
 	UserID    uuid.UUID `json:"user_id"`
-	Content   string    `json:"content"`
-	CreatedAt time.Time `json:"created_at"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+type UserDeletedEvent struct {
+	UserID    uuid.UUID `json:"user_id"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+// CounterEvent holds the fields of like and comment events that counting
+// them needs. LikeID is set on like events, CommentID on comment events.
+type CounterEvent struct {
+	PostID    uuid.UUID `json:"post_id"`
+	LikeID    uuid.UUID `json:"like_id"`
+	CommentID uuid.UUID `json:"comment_id"`
+}
+
+// ContentFlaggedEvent is published when a content filter flags or
+// shadow-hides a post or comment, for moderators to review. ContentType is
+// POST or COMMENT, Action is FLAG or SHADOW_HIDE, and PostID is the post a
+// comment is on.
+type ContentFlaggedEvent struct {
+	ContentType string    `json:"content_type"`
+	ContentID   uuid.UUID `json:"content_id"`
+	PostID      uuid.UUID `json:"post_id"`
+	UserID      uuid.UUID `json:"user_id"`
+	Action      string    `json:"action"`
+	Reasons     []string  `json:"reasons"`
+	FlaggedAt   time.Time `json:"flagged_at"`
 }
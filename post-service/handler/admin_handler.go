@@ -0,0 +1,184 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"post-service/model"
+	pb "post-service/pb"
+	"post-service/repository"
+	"post-service/rpcerror"
+	eventspb "shared/eventschema/pb"
+	"shared/logging"
+)
+
+const replayBatchSize = 500
+
+// ReplayPostEvents re-publishes post.created for every post created in the
+// requested window so downstream consumers can rebuild their projections
+func (h *PostHandler) ReplayPostEvents(ctx context.Context, req *pb.ReplayPostEventsRequest) (*pb.ReplayPostEventsResponse, error) {
+	if req.Since == nil {
+		return nil, rpcerror.InvalidField("since", "since is required")
+	}
+
+	since := req.Since.AsTime()
+	until := time.Now()
+	if req.Until != nil {
+		until = req.Until.AsTime()
+	}
+	if !since.Before(until) {
+		return nil, rpcerror.InvalidField("since", "since must be before until")
+	}
+
+	var replayed int32
+	var after *repository.Cursor
+	for {
+		posts, err := h.repo.ListPostsCreatedBetween(ctx, since, until, after, replayBatchSize)
+		if err != nil {
+			return nil, status.Error(codes.Internal, fmt.Sprintf("failed to list posts: %v", err))
+		}
+
+		for _, post := range posts {
+			event := &eventspb.PostCreated{
+				PostId:     post.ID.String(),
+				UserId:     post.UserID.String(),
+				Content:    post.Content,
+				Visibility: string(post.Visibility),
+				CreatedAt:  timestamppb.New(post.CreatedAt),
+			}
+			if err := h.publisher.PublishPostCreated(ctx, event); err != nil {
+				return nil, status.Error(codes.Internal, fmt.Sprintf("failed to queue event after %d replayed: %v", replayed, err))
+			}
+			replayed++
+		}
+
+		if len(posts) < replayBatchSize {
+			break
+		}
+		last := posts[len(posts)-1]
+		after = &repository.Cursor{Timestamp: last.CreatedAt, ID: last.ID}
+	}
+
+	logging.FromContext(ctx).Info().Int32("replayed", replayed).Time("since", since).Time("until", until).Msg("replayed post.created events")
+	return &pb.ReplayPostEventsResponse{Replayed: replayed}, nil
+}
+
+// SetPostCounters overwrites a post's like and comment counters with values
+// recomputed from the like and comment services
+func (h *PostHandler) SetPostCounters(ctx context.Context, req *pb.SetPostCountersRequest) (*pb.Response, error) {
+	postID, err := uuid.Parse(req.PostId)
+	if err != nil {
+		return nil, rpcerror.InvalidField("post_id", "invalid post_id format")
+	}
+
+	if req.LikesCount < 0 || req.CommentsCount < 0 {
+		return nil, status.Error(codes.InvalidArgument, "counters must not be negative")
+	}
+
+	if err := h.repo.SetCounters(ctx, postID, req.LikesCount, req.CommentsCount); err != nil {
+		return nil, status.Error(codes.NotFound, fmt.Sprintf("failed to set post counters: %v", err))
+	}
+
+	return &pb.Response{
+		Success: true,
+		Message: "Post counters updated successfully",
+	}, nil
+}
+
+const maxImportBatch = 1000
+
+// ImportPosts stores posts migrated from another platform under their
+// original timestamps. No post.created events are published; feeds pick the
+// posts up through the feed backfill once the import has finished.
+func (h *PostHandler) ImportPosts(ctx context.Context, req *pb.ImportPostsRequest) (*pb.ImportPostsResponse, error) {
+	if len(req.Posts) > maxImportBatch {
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("at most %d posts can be imported per request", maxImportBatch))
+	}
+
+	posts := make([]models.Post, 0, len(req.Posts))
+	for _, p := range req.Posts {
+		postID, err := uuid.Parse(p.Id)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("invalid post id %q", p.Id))
+		}
+		userID, err := uuid.Parse(p.UserId)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("invalid user_id for post %s", p.Id))
+		}
+		if p.Content == "" || p.CreatedAt == nil {
+			return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("content and created_at are required for post %s", p.Id))
+		}
+
+		posts = append(posts, models.Post{
+			ID:        postID,
+			UserID:    userID,
+			Content:   p.Content,
+			CreatedAt: p.CreatedAt.AsTime(),
+		})
+	}
+
+	created, err := h.repo.ImportPosts(ctx, posts)
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to import posts: %v", err))
+	}
+
+	return &pb.ImportPostsResponse{
+		Created: created,
+		Skipped: int32(len(posts)) - created,
+	}, nil
+}
+
+const purgeBatchSize = 500
+
+// RemovePost deletes a post whoever wrote it. It is how moderators take
+// posts down, and the author's followers see it go as for any deletion.
+func (h *PostHandler) RemovePost(ctx context.Context, req *pb.RemovePostRequest) (*pb.Response, error) {
+	postID, err := uuid.Parse(req.PostId)
+	if err != nil {
+		return nil, rpcerror.InvalidField("post_id", "invalid post_id format")
+	}
+
+	if err := h.deletePost(ctx, postID, nil); err != nil {
+		return nil, err
+	}
+
+	return &pb.Response{
+		Success: true,
+		Message: "Post removed successfully",
+	}, nil
+}
+
+// PurgeDeletedPosts hard-deletes posts soft-deleted before deleted_before,
+// along with their attachment files. It is run on a schedule by
+// scheduler-service.
+func (h *PostHandler) PurgeDeletedPosts(ctx context.Context, req *pb.PurgeDeletedPostsRequest) (*pb.PurgeDeletedPostsResponse, error) {
+	if req.DeletedBefore == nil {
+		return nil, rpcerror.InvalidField("deleted_before", "deleted_before is required")
+	}
+	deletedBefore := req.DeletedBefore.AsTime()
+
+	var purged int64
+	for {
+		n, mediaIDs, err := h.repo.PurgeDeletedPosts(ctx, deletedBefore, purgeBatchSize)
+		if err != nil {
+			return nil, status.Error(codes.Internal, fmt.Sprintf("failed to purge posts after %d purged: %v", purged, err))
+		}
+		for _, id := range mediaIDs {
+			h.deleteMediaFile(id)
+		}
+		purged += n
+
+		if n < purgeBatchSize {
+			break
+		}
+	}
+
+	logging.FromContext(ctx).Info().Int64("purged", purged).Time("deleted_before", deletedBefore).Msg("purged deleted posts")
+	return &pb.PurgeDeletedPostsResponse{Purged: purged}, nil
+}
@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"post-service/contentfilter"
+	"post-service/events"
+	"post-service/model"
+)
+
+// screen runs the content filters over content. Content they reject is
+// refused with InvalidArgument, and when they cannot run the request fails
+// with Unavailable, so nothing is stored unchecked.
+func (h *PostHandler) screen(ctx context.Context, content string) (contentfilter.Verdict, error) {
+	verdict, err := h.filter.Check(ctx, content)
+	if err != nil {
+		return verdict, status.Error(codes.Unavailable, err.Error())
+	}
+	if verdict.Action == contentfilter.ActionReject {
+		return verdict, rejected(verdict)
+	}
+	return verdict, nil
+}
+
+func rejected(verdict contentfilter.Verdict) error {
+	return status.Error(codes.InvalidArgument, "content rejected by the content filter: "+strings.Join(verdict.Reasons(), ", "))
+}
+
+// reportFlagged emits a ContentFlagged event when the filters flagged or
+// shadow-hid post
+func (h *PostHandler) reportFlagged(ctx context.Context, post *models.Post, verdict contentfilter.Verdict) error {
+	if verdict.Action != contentfilter.ActionFlag && verdict.Action != contentfilter.ActionShadowHide {
+		return nil
+	}
+	return h.publisher.PublishContentFlagged(ctx, events.ContentFlaggedEvent{
+		ContentType: "POST",
+		ContentID:   post.ID,
+		PostID:      post.ID,
+		UserID:      post.UserID,
+		Action:      verdict.Action.String(),
+		Reasons:     verdict.Reasons(),
+		FlaggedAt:   time.Now(),
+	})
+}
@@ -0,0 +1,208 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	commentpb "comment-service/pb"
+	likepb "like-service/pb"
+	"post-service/model"
+	pb "post-service/pb"
+	"post-service/rpcerror"
+	"shared/logging"
+)
+
+// reconcileBatchSize is how many posts ReconcilePostCounters recounts per
+// call to like-service and comment-service
+const reconcileBatchSize = 500
+
+// maxCountBatch is the most users GetPostsCounts counts at once
+const maxCountBatch = 1000
+
+// ApplyCounterEvent adds the deltas of a like or comment event to a post's
+// counters. An event already applied, e.g. one redelivered after a lost
+// acknowledgement, is skipped.
+func (h *PostHandler) ApplyCounterEvent(ctx context.Context, eventID string, postID uuid.UUID, likesDelta, commentsDelta int32) error {
+	applied, err := h.repo.ApplyCounterEvent(ctx, eventID, postID, likesDelta, commentsDelta)
+	if err != nil {
+		return err
+	}
+	if !applied {
+		logging.FromContext(ctx).Debug().Str("event_id", eventID).Stringer("post_id", postID).Msg("skipped counter event already applied")
+	}
+	return nil
+}
+
+// PruneCounterEvents forgets counter events applied before appliedBefore
+func (h *PostHandler) PruneCounterEvents(ctx context.Context, appliedBefore time.Time) (int64, error) {
+	return h.repo.PruneCounterEvents(ctx, appliedBefore)
+}
+
+// RecountPost overwrites a post's like and comment counters with the counts
+// like-service and comment-service hold
+func (h *PostHandler) RecountPost(ctx context.Context, req *pb.RecountPostRequest) (*pb.PostCounters, error) {
+	postID, err := uuid.Parse(req.PostId)
+	if err != nil {
+		return nil, rpcerror.InvalidField("post_id", "invalid post_id format")
+	}
+
+	counts, err := h.countSources(forwardToken(ctx), []uuid.UUID{postID})
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, fmt.Sprintf("failed to recount post: %v", err))
+	}
+	counters := counts[0]
+
+	if err := h.repo.SetCounters(ctx, postID, counters.LikesCount, counters.CommentsCount); err != nil {
+		return nil, status.Error(codes.NotFound, fmt.Sprintf("failed to set post counters: %v", err))
+	}
+
+	return &pb.PostCounters{
+		PostId:        postID.String(),
+		LikesCount:    counters.LikesCount,
+		CommentsCount: counters.CommentsCount,
+	}, nil
+}
+
+// ReconcilePostCounters recounts every post in batches and corrects the
+// counters that differ from the counts like-service and comment-service
+// hold. It is run on a schedule by scheduler-service, to catch events that
+// were lost or never published, such as for likes and comments deleted along
+// with their user.
+func (h *PostHandler) ReconcilePostCounters(ctx context.Context, req *pb.ReconcilePostCountersRequest) (*pb.ReconcilePostCountersResponse, error) {
+	sourceCtx := forwardToken(ctx)
+
+	var checked, corrected int32
+	after := uuid.Nil
+	for {
+		batch, err := h.repo.ListCounters(ctx, after, reconcileBatchSize)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to reconcile counters after %d checked: %v", checked, err)
+		}
+		if len(batch) == 0 {
+			break
+		}
+
+		postIDs := make([]uuid.UUID, len(batch))
+		for i, seen := range batch {
+			postIDs[i] = seen.PostID
+		}
+		counts, err := h.countSources(sourceCtx, postIDs)
+		if err != nil {
+			return nil, status.Errorf(codes.Unavailable, "failed to reconcile counters after %d checked: %v", checked, err)
+		}
+
+		for i, seen := range batch {
+			checked++
+			if seen == counts[i] {
+				continue
+			}
+			changed, err := h.repo.CorrectCounters(ctx, seen, counts[i])
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "failed to reconcile counters after %d checked: %v", checked, err)
+			}
+			if changed {
+				corrected++
+				logging.FromContext(ctx).Info().
+					Stringer("post_id", seen.PostID).
+					Int32("likes_count", seen.LikesCount).
+					Int32("likes_counted", counts[i].LikesCount).
+					Int32("comments_count", seen.CommentsCount).
+					Int32("comments_counted", counts[i].CommentsCount).
+					Msg("corrected drifted post counters")
+			}
+		}
+
+		if len(batch) < reconcileBatchSize {
+			break
+		}
+		after = batch[len(batch)-1].PostID
+	}
+
+	logging.FromContext(ctx).Info().Int32("checked", checked).Int32("corrected", corrected).Msg("reconciled post counters")
+	return &pb.ReconcilePostCountersResponse{Checked: checked, Corrected: corrected}, nil
+}
+
+// countSources returns the counters of posts as counted by like-service and
+// comment-service, in the order of postIDs
+func (h *PostHandler) countSources(ctx context.Context, postIDs []uuid.UUID) ([]models.PostCounters, error) {
+	ids := make([]string, len(postIDs))
+	for i, id := range postIDs {
+		ids[i] = id.String()
+	}
+
+	likes, err := h.likes.GetLikesCounts(ctx, &likepb.GetLikesCountsRequest{PostIds: ids})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count likes: %w", err)
+	}
+	comments, err := h.comments.GetCommentsCounts(ctx, &commentpb.GetCommentsCountsRequest{PostIds: ids})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count comments: %w", err)
+	}
+
+	likeCounts := make(map[string]int32, len(likes.Counts))
+	for _, c := range likes.Counts {
+		likeCounts[c.PostId] = c.Count
+	}
+	commentCounts := make(map[string]int32, len(comments.Counts))
+	for _, c := range comments.Counts {
+		commentCounts[c.PostId] = c.Count
+	}
+
+	counters := make([]models.PostCounters, len(postIDs))
+	for i, id := range postIDs {
+		counters[i] = models.PostCounters{
+			PostID:        id,
+			LikesCount:    likeCounts[ids[i]],
+			CommentsCount: commentCounts[ids[i]],
+		}
+	}
+	return counters, nil
+}
+
+// GetPostsCounts returns the number of published posts of each user, for
+// user-service to reconcile its post counters with
+func (h *PostHandler) GetPostsCounts(ctx context.Context, req *pb.GetPostsCountsRequest) (*pb.GetPostsCountsResponse, error) {
+	if len(req.UserIds) > maxCountBatch {
+		return nil, rpcerror.InvalidField("user_ids", fmt.Sprintf("at most %d user_ids can be counted per request", maxCountBatch))
+	}
+
+	userIDs := make([]uuid.UUID, len(req.UserIds))
+	for i, userIDStr := range req.UserIds {
+		userID, err := uuid.Parse(userIDStr)
+		if err != nil {
+			return nil, rpcerror.InvalidField(fmt.Sprintf("user_ids[%d]", i), fmt.Sprintf("invalid user_id format at index %d", i))
+		}
+		userIDs[i] = userID
+	}
+
+	counts, err := h.repo.CountUserPosts(ctx, userIDs)
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to count posts: %v", err))
+	}
+
+	pbCounts := make([]*pb.UserPostsCount, len(userIDs))
+	for i, userID := range userIDs {
+		pbCounts[i] = &pb.UserPostsCount{
+			UserId: userID.String(),
+			Count:  counts[userID],
+		}
+	}
+
+	return &pb.GetPostsCountsResponse{Counts: pbCounts}, nil
+}
+
+// forwardToken passes the caller's token on to like-service and
+// comment-service, whose counts are only given to admins
+func forwardToken(ctx context.Context) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get("authorization"); len(values) > 0 {
+		return metadata.AppendToOutgoingContext(ctx, "authorization", values[0])
+	}
+	return ctx
+}
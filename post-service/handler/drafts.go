@@ -0,0 +1,258 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"post-service/contentfilter"
+	"post-service/model"
+	pb "post-service/pb"
+	"post-service/repository"
+	"post-service/rpcerror"
+	"shared/cursor"
+	"shared/logging"
+)
+
+// SaveDraft creates a draft for the caller, or replaces the content of one of
+// their drafts or scheduled posts. Drafts get no hashtags, mentions or events
+// until they are published.
+func (h *PostHandler) SaveDraft(ctx context.Context, req *pb.SaveDraftRequest) (*pb.Post, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.PostId != nil && *req.PostId != "" {
+		return h.updateDraft(ctx, userID, req)
+	}
+
+	if req.Content == "" && len(req.MediaIds) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "content or media_ids is required")
+	}
+
+	mediaIDs, err := parseMediaIDs(req.MediaIds)
+	if err != nil {
+		return nil, err
+	}
+
+	var quotedPostID *uuid.UUID
+	if req.QuotedPostId != nil && *req.QuotedPostId != "" {
+		id, err := uuid.Parse(*req.QuotedPostId)
+		if err != nil {
+			return nil, rpcerror.InvalidField("quoted_post_id", "invalid quoted_post_id format")
+		}
+		quotedPostID = &id
+	}
+
+	now := time.Now()
+	post := &models.Post{
+		ID:           uuid.New(),
+		UserID:       userID,
+		Content:      req.Content,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+		QuotedPostID: quotedPostID,
+		Status:       models.PostStatusDraft,
+		Visibility:   visibilityFromProto(req.Visibility),
+	}
+
+	err = h.repo.WithTx(ctx, func(ctx context.Context) error {
+		if err := h.repo.Create(ctx, post); err != nil {
+			if errors.Is(err, repository.ErrQuotedPostNotFound) {
+				return status.Error(codes.FailedPrecondition, "quoted post not found or deleted")
+			}
+			return status.Error(codes.Internal, fmt.Sprintf("failed to save draft: %v", err))
+		}
+		post.Media, err = h.repo.AttachMedia(ctx, post.ID, post.UserID, mediaIDs)
+		if err != nil {
+			if errors.Is(err, repository.ErrMediaUnavailable) {
+				return status.Error(codes.InvalidArgument, err.Error())
+			}
+			return status.Error(codes.Internal, fmt.Sprintf("failed to save draft: %v", err))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if quotedPostID != nil {
+		if quoted, err := h.repo.GetByID(ctx, *quotedPostID, nil); err == nil {
+			post.QuotedPost = &quoted.Post
+		}
+	}
+
+	return postToProto(post, nil), nil
+}
+
+// updateDraft replaces the content of an existing draft or scheduled post
+func (h *PostHandler) updateDraft(ctx context.Context, userID uuid.UUID, req *pb.SaveDraftRequest) (*pb.Post, error) {
+	postID, err := uuid.Parse(*req.PostId)
+	if err != nil {
+		return nil, rpcerror.InvalidField("post_id", "invalid post_id format")
+	}
+	if req.Content == "" {
+		return nil, rpcerror.InvalidField("content", "content is required")
+	}
+	if len(req.MediaIds) > 0 || (req.QuotedPostId != nil && *req.QuotedPostId != "") || req.Visibility != pb.PostVisibility_POST_VISIBILITY_UNSPECIFIED {
+		return nil, status.Error(codes.InvalidArgument, "media, quotes and visibility can only be set when a draft is created")
+	}
+
+	post := &models.Post{
+		ID:        postID,
+		UserID:    userID,
+		Content:   req.Content,
+		UpdatedAt: time.Now(),
+	}
+	if err := h.repo.UpdateDraft(ctx, post); err != nil {
+		if errors.Is(err, repository.ErrDraftNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to save draft: %v", err))
+	}
+
+	saved, err := h.repo.GetByID(ctx, postID, nil)
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to load draft: %v", err))
+	}
+	return postWithLikeStatusToProto(saved), nil
+}
+
+// ListDrafts pages through the caller's drafts and scheduled posts
+func (h *PostHandler) ListDrafts(ctx context.Context, req *pb.ListDraftsRequest) (*pb.PostConnection, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	first := req.First
+	if first <= 0 {
+		first = 10
+	}
+	if first > 100 {
+		first = 100
+	}
+
+	connection, err := h.repo.GetDrafts(ctx, userID, first, req.After)
+	if err != nil {
+		if errors.Is(err, cursor.ErrInvalid) {
+			return nil, rpcerror.InvalidField("after", "invalid cursor")
+		}
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to list drafts: %v", err))
+	}
+
+	return connectionToProto(connection, &userID), nil
+}
+
+// SchedulePost schedules one of the caller's drafts, or moves a scheduled
+// post to a new time. A post with no time, or a time already past, is
+// published right away.
+func (h *PostHandler) SchedulePost(ctx context.Context, req *pb.SchedulePostRequest) (*pb.Post, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	postID, err := uuid.Parse(req.PostId)
+	if err != nil {
+		return nil, rpcerror.InvalidField("post_id", "invalid post_id format")
+	}
+	if req.PublishAt != nil {
+		if err := req.PublishAt.CheckValid(); err != nil {
+			return nil, rpcerror.InvalidField("publish_at", "invalid publish_at")
+		}
+	}
+
+	existing, err := h.repo.GetByID(ctx, postID, nil)
+	if err != nil || existing.Post.UserID != userID || existing.Post.Status == models.PostStatusPublished {
+		return nil, status.Error(codes.NotFound, repository.ErrDraftNotFound.Error())
+	}
+
+	if req.PublishAt != nil && req.PublishAt.AsTime().After(time.Now()) {
+		err = h.repo.SchedulePost(ctx, postID, userID, req.PublishAt.AsTime())
+	} else {
+		verdict, screenErr := h.screen(ctx, existing.Post.Content)
+		if screenErr != nil {
+			return nil, screenErr
+		}
+		err = h.publishDraft(ctx, &existing.Post, verdict, false)
+	}
+	if err != nil {
+		if errors.Is(err, repository.ErrDraftNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to schedule post: %v", err))
+	}
+
+	post, err := h.repo.GetByID(ctx, postID, &userID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to load post: %v", err))
+	}
+	return postWithLikeStatusToProto(post), nil
+}
+
+// PublishDuePosts publishes up to limit scheduled posts whose time has come
+// and returns how many were published. A post another replica publishes
+// first is skipped; a post that fails to publish is retried next time.
+func (h *PostHandler) PublishDuePosts(ctx context.Context, limit int32) (int, error) {
+	due, err := h.repo.ListDuePosts(ctx, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	published := 0
+	for i := range due {
+		verdict, err := h.filter.Check(ctx, due[i].Content)
+		if err != nil {
+			logging.FromContext(ctx).Error().Err(err).Str("post_id", due[i].ID.String()).Msg("failed to screen scheduled post")
+			continue
+		}
+		err = h.publishDraft(ctx, &due[i], verdict, true)
+		if errors.Is(err, repository.ErrDraftNotFound) {
+			continue
+		}
+		if err != nil {
+			logging.FromContext(ctx).Error().Err(err).Str("post_id", due[i].ID.String()).Msg("failed to publish scheduled post")
+			continue
+		}
+		published++
+	}
+	return published, nil
+}
+
+// publishDraft publishes an unpublished post and announces it as created
+// now, so it fans out into followers' feeds as a new post. With onlyIfDue it
+// is only published if it is scheduled and its time has come. verdict is the
+// content filters' verdict on the draft; a scheduled post has nobody to
+// refuse, so one they reject is shadow-hidden instead.
+func (h *PostHandler) publishDraft(ctx context.Context, draft *models.Post, verdict contentfilter.Verdict, onlyIfDue bool) error {
+	mentions := h.resolveMentions(ctx, draft.Content)
+	if verdict.Action == contentfilter.ActionReject {
+		verdict.Action = contentfilter.ActionShadowHide
+	}
+
+	var post *models.Post
+	err := h.repo.WithTx(ctx, func(ctx context.Context) error {
+		var err error
+		post, err = h.repo.PublishPost(ctx, draft.ID, onlyIfDue, verdict.Action == contentfilter.ActionShadowHide)
+		if err != nil {
+			return err
+		}
+		if err := h.announcePost(ctx, post, mentions); err != nil {
+			return err
+		}
+		return h.reportFlagged(ctx, post, verdict)
+	})
+	if err != nil {
+		return err
+	}
+
+	logging.FromContext(ctx).Info().Str("post_id", post.ID.String()).Msg("published post")
+	return nil
+}
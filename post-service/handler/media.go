@@ -0,0 +1,185 @@
+package handler
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"post-service/media"
+	"post-service/model"
+	pb "post-service/pb"
+	"post-service/repository"
+	"post-service/rpcerror"
+)
+
+// mediaChunkSize is the size of the chunks GetMediaContent streams
+const mediaChunkSize = 64 << 10
+
+// UploadMedia stores a file streamed by the caller. The upload stays
+// unattached until a post is created with its ID.
+func (h *PostHandler) UploadMedia(stream pb.PostService_UploadMediaServer) error {
+	ctx := stream.Context()
+	userID, err := callerID(ctx)
+	if err != nil {
+		return err
+	}
+
+	r := bufio.NewReaderSize(&uploadReader{stream: stream}, media.SniffLen)
+	head, err := r.Peek(media.SniffLen)
+	if err != nil && err != io.EOF {
+		return status.Error(codes.Internal, "failed to receive upload")
+	}
+	if len(head) == 0 {
+		return status.Error(codes.InvalidArgument, "upload is empty")
+	}
+
+	mimeType, limit, err := media.DetectType(head)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	m := &models.Media{
+		ID:        uuid.New(),
+		UserID:    userID,
+		MimeType:  mimeType,
+		CreatedAt: time.Now(),
+	}
+
+	// Read one byte past the limit to tell an exact fit from an oversize file
+	m.SizeBytes, err = h.media.Save(m.ID, io.LimitReader(r, limit+1))
+	if err != nil {
+		return status.Error(codes.Internal, "failed to store upload")
+	}
+	if m.SizeBytes > limit {
+		h.deleteMediaFile(m.ID)
+		return status.Errorf(codes.InvalidArgument, "%s uploads are limited to %d bytes", mimeType, limit)
+	}
+
+	if err := h.repo.CreateMedia(ctx, m); err != nil {
+		h.deleteMediaFile(m.ID)
+		return status.Error(codes.Internal, "failed to record upload")
+	}
+
+	return stream.SendAndClose(mediaToProto(m))
+}
+
+// GetMediaContent streams the bytes of an upload
+func (h *PostHandler) GetMediaContent(req *pb.GetMediaContentRequest, stream pb.PostService_GetMediaContentServer) error {
+	mediaID, err := uuid.Parse(req.MediaId)
+	if err != nil {
+		return rpcerror.InvalidField("media_id", "invalid media_id format")
+	}
+
+	m, err := h.repo.GetMedia(stream.Context(), mediaID)
+	if err != nil {
+		if errors.Is(err, repository.ErrMediaNotFound) {
+			return status.Error(codes.NotFound, "media not found")
+		}
+		return status.Error(codes.Internal, "failed to load media")
+	}
+
+	f, err := h.media.Open(m.ID)
+	if err != nil {
+		if errors.Is(err, media.ErrNotFound) {
+			return status.Error(codes.NotFound, "media not found")
+		}
+		return status.Error(codes.Internal, "failed to open media")
+	}
+	defer f.Close()
+
+	first := true
+	buf := make([]byte, mediaChunkSize)
+	for {
+		n, err := f.Read(buf)
+		if n > 0 {
+			chunk := &pb.MediaChunk{Chunk: buf[:n]}
+			if first {
+				chunk.MimeType = m.MimeType
+				chunk.SizeBytes = m.SizeBytes
+				first = false
+			}
+			if err := stream.Send(chunk); err != nil {
+				return err
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return status.Error(codes.Internal, "failed to read media")
+		}
+	}
+}
+
+// deleteMediaFile removes a stored file. Failures only leak disk space, so
+// they are logged rather than returned.
+func (h *PostHandler) deleteMediaFile(id uuid.UUID) {
+	if err := h.media.Delete(id); err != nil {
+		log.Printf("Failed to delete media %s: %v", id, err)
+	}
+}
+
+// parseMediaIDs validates the uploads a new post attaches
+func parseMediaIDs(raw []string) ([]uuid.UUID, error) {
+	if len(raw) > media.MaxPerPost {
+		return nil, status.Errorf(codes.InvalidArgument, "a post can have at most %d media attachments", media.MaxPerPost)
+	}
+
+	ids := make([]uuid.UUID, 0, len(raw))
+	seen := make(map[uuid.UUID]bool, len(raw))
+	for _, s := range raw {
+		id, err := uuid.Parse(s)
+		if err != nil {
+			return nil, rpcerror.InvalidField("media_ids", "invalid media_ids format")
+		}
+		if seen[id] {
+			return nil, status.Error(codes.InvalidArgument, "media_ids contains duplicates")
+		}
+		seen[id] = true
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func mediaToProto(m *models.Media) *pb.Media {
+	return &pb.Media{
+		Id:        m.ID.String(),
+		MimeType:  m.MimeType,
+		SizeBytes: m.SizeBytes,
+		CreatedAt: timestamppb.New(m.CreatedAt),
+	}
+}
+
+func mediaListToProto(list []models.Media) []*pb.Media {
+	out := make([]*pb.Media, len(list))
+	for i := range list {
+		out[i] = mediaToProto(&list[i])
+	}
+	return out
+}
+
+// uploadReader reads the chunks of an UploadMedia stream as one byte stream
+type uploadReader struct {
+	stream pb.PostService_UploadMediaServer
+	buf    []byte
+}
+
+func (u *uploadReader) Read(p []byte) (int, error) {
+	for len(u.buf) == 0 {
+		req, err := u.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+		u.buf = req.Chunk
+	}
+	n := copy(p, u.buf)
+	u.buf = u.buf[n:]
+	return n, nil
+}
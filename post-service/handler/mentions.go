@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"post-service/events"
+	"post-service/mention"
+	"post-service/model"
+	"shared/logging"
+	userpb "user-service/pb"
+)
+
+// resolveMentions turns the @usernames in content into users. Mentions are
+// best effort: when user-service is unavailable the post is saved without
+// them rather than rejected.
+func (h *PostHandler) resolveMentions(ctx context.Context, content string) []models.Mention {
+	usernames := mention.Extract(content)
+	if len(usernames) == 0 {
+		return nil
+	}
+
+	resp, err := h.users.GetUsersByUsernames(ctx, &userpb.GetUsersByUsernamesRequest{Usernames: usernames})
+	if err != nil {
+		logging.FromContext(ctx).Error().Err(err).Msg("failed to resolve mentions")
+		return nil
+	}
+
+	byName := make(map[string]*userpb.User, len(resp.Users)+len(resp.FormerUsernames))
+	for _, u := range resp.Users {
+		byName[strings.ToLower(u.Username)] = u
+	}
+	// A user mentioned by a username they have changed is mentioned under
+	// their current one
+	for _, former := range resp.FormerUsernames {
+		byName[strings.ToLower(former.Username)] = former.User
+	}
+
+	// Keep the order in which users were mentioned, and mention each once
+	var mentions []models.Mention
+	seen := make(map[uuid.UUID]bool)
+	for _, username := range usernames {
+		u, ok := byName[strings.ToLower(username)]
+		if !ok {
+			continue
+		}
+		userID, err := uuid.Parse(u.Id)
+		if err != nil || seen[userID] {
+			continue
+		}
+		seen[userID] = true
+		mentions = append(mentions, models.Mention{UserID: userID, Username: u.Username})
+	}
+	return mentions
+}
+
+// publishMentions notifies users newly mentioned by the author. Authors
+// mentioning themselves are not notified, nor are users the post's
+// visibility hides it from.
+func (h *PostHandler) publishMentions(ctx context.Context, post *models.Post, added []models.Mention) error {
+	for _, m := range added {
+		if m.UserID == post.UserID {
+			continue
+		}
+		if post.Visibility != models.VisibilityPublic {
+			visible, err := h.visibleTo(ctx, post.UserID, &m.UserID)
+			if err != nil {
+				logging.FromContext(ctx).Error().Err(err).Str("user_id", m.UserID.String()).Msg("failed to check mention audience")
+				continue
+			}
+			if !slices.Contains(visible, post.Visibility) {
+				continue
+			}
+		}
+
+		event := events.MentionCreatedEvent{
+			MentionID:       m.ID,
+			PostID:          post.ID,
+			AuthorID:        post.UserID,
+			MentionedUserID: m.UserID,
+			CreatedAt:       time.Now(),
+		}
+		if err := h.publisher.PublishMentionCreated(ctx, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"post-service/model"
+	pb "post-service/pb"
+	"post-service/repository"
+	"post-service/rpcerror"
+)
+
+const (
+	minPollOptions      = 2
+	maxPollOptions      = 4
+	maxPollOptionLength = 80
+	minPollDuration     = 5 * time.Minute
+	maxPollDuration     = 7 * 24 * time.Hour
+)
+
+// newPoll validates the poll requested with a new post
+func newPoll(input *pb.PollInput, postID uuid.UUID, now time.Time) (*models.Poll, error) {
+	if len(input.Options) < minPollOptions || len(input.Options) > maxPollOptions {
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("a poll needs %d to %d options", minPollOptions, maxPollOptions))
+	}
+	if input.ExpiresAt == nil || input.ExpiresAt.CheckValid() != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid poll expires_at")
+	}
+	expiresAt := input.ExpiresAt.AsTime()
+	if expiresAt.Before(now.Add(minPollDuration)) || expiresAt.After(now.Add(maxPollDuration)) {
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("a poll must close between %v and %v from now", minPollDuration, maxPollDuration))
+	}
+
+	poll := &models.Poll{PostID: postID, ExpiresAt: expiresAt, CreatedAt: now}
+	seen := make(map[string]bool, len(input.Options))
+	for i, text := range input.Options {
+		text = strings.TrimSpace(text)
+		if text == "" || len([]rune(text)) > maxPollOptionLength {
+			return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("poll options must be 1 to %d characters", maxPollOptionLength))
+		}
+		if seen[strings.ToLower(text)] {
+			return nil, status.Error(codes.InvalidArgument, "poll options must be distinct")
+		}
+		seen[strings.ToLower(text)] = true
+
+		poll.Options = append(poll.Options, models.PollOption{
+			ID:       uuid.New(),
+			PostID:   postID,
+			Position: int32(i),
+			Text:     text,
+		})
+	}
+	return poll, nil
+}
+
+// VotePoll casts the caller's vote in the poll of a post they may see and
+// returns the updated results. Votes cannot be changed.
+func (h *PostHandler) VotePoll(ctx context.Context, req *pb.VotePollRequest) (*pb.Poll, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	postID, err := uuid.Parse(req.PostId)
+	if err != nil {
+		return nil, rpcerror.InvalidField("post_id", "invalid post_id format")
+	}
+	optionID, err := uuid.Parse(req.OptionId)
+	if err != nil {
+		return nil, rpcerror.InvalidField("option_id", "invalid option_id format")
+	}
+
+	post, err := h.repo.GetByID(ctx, postID, nil)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, fmt.Sprintf("post not found: %v", err))
+	}
+	if err := h.checkCanSeePost(ctx, &post.Post, &userID); err != nil {
+		return nil, err
+	}
+
+	err = h.repo.WithTx(ctx, func(ctx context.Context) error {
+		return h.repo.VotePoll(ctx, postID, optionID, userID)
+	})
+	switch {
+	case errors.Is(err, repository.ErrPollNotFound), errors.Is(err, repository.ErrPollOptionNotFound):
+		return nil, status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, repository.ErrPollExpired):
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, repository.ErrAlreadyVoted):
+		return nil, status.Error(codes.AlreadyExists, err.Error())
+	case err != nil:
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to vote: %v", err))
+	}
+
+	poll, err := h.repo.GetPoll(ctx, postID, &userID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to load poll: %v", err))
+	}
+	return pollToProto(poll), nil
+}
+
+// GetPollResults returns the poll of a post with its current counts. They
+// are visible to whoever may see the post.
+func (h *PostHandler) GetPollResults(ctx context.Context, req *pb.GetPollResultsRequest) (*pb.Poll, error) {
+	postID, err := uuid.Parse(req.PostId)
+	if err != nil {
+		return nil, rpcerror.InvalidField("post_id", "invalid post_id format")
+	}
+
+	requestingUserID := viewerID(ctx)
+
+	post, err := h.repo.GetByID(ctx, postID, nil)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, fmt.Sprintf("post not found: %v", err))
+	}
+	if err := h.checkCanSeePost(ctx, &post.Post, requestingUserID); err != nil {
+		return nil, err
+	}
+
+	poll, err := h.repo.GetPoll(ctx, postID, requestingUserID)
+	if err != nil {
+		if errors.Is(err, repository.ErrPollNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to load poll: %v", err))
+	}
+	return pollToProto(poll), nil
+}
+
+func pollToProto(poll *models.Poll) *pb.Poll {
+	result := &pb.Poll{
+		PostId:    poll.PostID.String(),
+		Options:   make([]*pb.PollOption, len(poll.Options)),
+		ExpiresAt: timestamppb.New(poll.ExpiresAt),
+		IsExpired: !time.Now().Before(poll.ExpiresAt),
+	}
+	for i, option := range poll.Options {
+		result.Options[i] = &pb.PollOption{
+			Id:         option.ID.String(),
+			Text:       option.Text,
+			VotesCount: option.VotesCount,
+		}
+		result.TotalVotes += option.VotesCount
+	}
+	if poll.VotedOptionID != nil {
+		id := poll.VotedOptionID.String()
+		result.VotedOptionId = &id
+	}
+	return result
+}
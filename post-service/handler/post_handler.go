@@ -2,8 +2,10 @@ package handler
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"log"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -11,37 +13,79 @@ import (
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
-	"post-service/events"
+	commentpb "comment-service/pb"
+	followpb "follow-service/pb"
+	likepb "like-service/pb"
+	"post-service/contentfilter"
+	"post-service/hashtag"
+	"post-service/interceptor"
+	"post-service/media"
 	"post-service/model"
 	pb "post-service/pb"
 	"post-service/publisher"
 	"post-service/repository"
+	"post-service/rpcerror"
+	"shared/cursor"
+	eventspb "shared/eventschema/pb"
+	userpb "user-service/pb"
 )
 
 type PostHandler struct {
 	pb.UnimplementedPostServiceServer
 	repo      repository.PostRepository
 	publisher *publisher.EventPublisher
+	users     userpb.UserServiceClient
+	follows   followpb.FollowServiceClient
+	likes     likepb.LikeServiceClient
+	comments  commentpb.CommentServiceClient
+	media     media.Store
+	// filter screens content before it is stored; nil allows everything
+	filter *contentfilter.Pipeline
 }
 
-func NewPostHandler(repo repository.PostRepository, pub *publisher.EventPublisher) *PostHandler {
+func NewPostHandler(repo repository.PostRepository, pub *publisher.EventPublisher, users userpb.UserServiceClient, follows followpb.FollowServiceClient, likes likepb.LikeServiceClient, comments commentpb.CommentServiceClient, store media.Store, filter *contentfilter.Pipeline) *PostHandler {
 	return &PostHandler{
 		repo:      repo,
 		publisher: pub,
+		users:     users,
+		follows:   follows,
+		likes:     likes,
+		comments:  comments,
+		media:     store,
+		filter:    filter,
 	}
 }
 
 func (h *PostHandler) CreatePost(ctx context.Context, req *pb.CreatePostRequest) (*pb.Post, error) {
 	if req.UserId == "" {
-		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+		return nil, rpcerror.InvalidField("user_id", "user_id is required")
 	}
-	if req.Content == "" {
-		return nil, status.Error(codes.InvalidArgument, "content is required")
+	if req.Content == "" && len(req.MediaIds) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "content or media_ids is required")
 	}
 
 	userID, err := uuid.Parse(req.UserId)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid user_id format")
+		return nil, rpcerror.InvalidField("user_id", "invalid user_id format")
+	}
+
+	mediaIDs, err := parseMediaIDs(req.MediaIds)
+	if err != nil {
+		return nil, err
+	}
+
+	var quotedPostID *uuid.UUID
+	if req.QuotedPostId != nil && *req.QuotedPostId != "" {
+		id, err := uuid.Parse(*req.QuotedPostId)
+		if err != nil {
+			return nil, rpcerror.InvalidField("quoted_post_id", "invalid quoted_post_id format")
+		}
+		quotedPostID = &id
+	}
+
+	verdict, err := h.screen(ctx, req.Content)
+	if err != nil {
+		return nil, err
 	}
 
 	now := time.Now()
@@ -53,21 +97,60 @@ func (h *PostHandler) CreatePost(ctx context.Context, req *pb.CreatePostRequest)
 		UpdatedAt:     now,
 		LikesCount:    0,
 		CommentsCount: 0,
+		QuotedPostID:  quotedPostID,
+		Status:        models.PostStatusPublished,
+		Visibility:    visibilityFromProto(req.Visibility),
+	}
+	if verdict.Action == contentfilter.ActionShadowHide {
+		post.ShadowHiddenAt = &now
 	}
 
-	event := events.PostCreatedEvent{
-		PostID:    post.ID,
-		UserID:    post.UserID,
-		Content:   post.Content,
-		CreatedAt: time.Now(),
+	var poll *models.Poll
+	if req.Poll != nil {
+		poll, err = newPoll(req.Poll, post.ID, now)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	if err := h.publisher.PublishPostCreated(event); err != nil {
-		log.Printf("Failed to publish post created event: %v", err)
+	mentions := h.resolveMentions(ctx, post.Content)
+
+	err = h.repo.WithTx(ctx, func(ctx context.Context) error {
+		if err := h.repo.Create(ctx, post); err != nil {
+			if errors.Is(err, repository.ErrQuotedPostNotFound) {
+				return status.Error(codes.FailedPrecondition, "quoted post not found or deleted")
+			}
+			return status.Error(codes.Internal, fmt.Sprintf("failed to create post: %v", err))
+		}
+		post.Media, err = h.repo.AttachMedia(ctx, post.ID, post.UserID, mediaIDs)
+		if err != nil {
+			if errors.Is(err, repository.ErrMediaUnavailable) {
+				return status.Error(codes.InvalidArgument, err.Error())
+			}
+			return status.Error(codes.Internal, fmt.Sprintf("failed to create post: %v", err))
+		}
+		if poll != nil {
+			if err := h.repo.CreatePoll(ctx, poll); err != nil {
+				return status.Error(codes.Internal, fmt.Sprintf("failed to create post: %v", err))
+			}
+		}
+		if err := h.announcePost(ctx, post, mentions); err != nil {
+			return status.Error(codes.Internal, fmt.Sprintf("failed to create post: %v", err))
+		}
+		if err := h.reportFlagged(ctx, post, verdict); err != nil {
+			return status.Error(codes.Internal, fmt.Sprintf("failed to create post: %v", err))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
+	post.Mentions = mentions
 
-	if err := h.repo.Create(ctx, post); err != nil {
-		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to create post: %v", err))
+	if quotedPostID != nil {
+		if quoted, err := h.repo.GetByID(ctx, *quotedPostID, nil); err == nil {
+			post.QuotedPost = &quoted.Post
+		}
 	}
 
 	return postToProto(post, nil), nil
@@ -75,107 +158,217 @@ func (h *PostHandler) CreatePost(ctx context.Context, req *pb.CreatePostRequest)
 
 func (h *PostHandler) GetPost(ctx context.Context, req *pb.GetPostRequest) (*pb.Post, error) {
 	if req.PostId == "" {
-		return nil, status.Error(codes.InvalidArgument, "post_id is required")
+		return nil, rpcerror.InvalidField("post_id", "post_id is required")
 	}
 
 	postID, err := uuid.Parse(req.PostId)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid post_id format")
+		return nil, rpcerror.InvalidField("post_id", "invalid post_id format")
 	}
 
-	var requestingUserID *uuid.UUID
-	if req.RequestingUserId != nil && *req.RequestingUserId != "" {
-		id, err := uuid.Parse(*req.RequestingUserId)
-		if err != nil {
-			return nil, status.Error(codes.InvalidArgument, "invalid requesting_user_id format")
-		}
-		requestingUserID = &id
-	}
+	requestingUserID := viewerID(ctx)
 
 	post, err := h.repo.GetByID(ctx, postID, requestingUserID)
 	if err != nil {
 		return nil, status.Error(codes.NotFound, fmt.Sprintf("post not found: %v", err))
 	}
 
+	if err := h.checkCanSeePost(ctx, &post.Post, requestingUserID); err != nil {
+		return nil, err
+	}
+
 	return postWithLikeStatusToProto(post), nil
 }
 
+// maxPostsBatch is the most posts GetPostsByIds returns at once
+const maxPostsBatch = 100
+
+// GetPostsByIds returns the posts of post_ids that the requesting user may
+// see, in the order asked for. Posts that do not exist, are deleted or are
+// hidden from the requesting user are left out rather than failing the call.
+func (h *PostHandler) GetPostsByIds(ctx context.Context, req *pb.GetPostsByIdsRequest) (*pb.GetPostsByIdsResponse, error) {
+	if len(req.PostIds) > maxPostsBatch {
+		return nil, rpcerror.InvalidField("post_ids", fmt.Sprintf("at most %d post_ids can be fetched per request", maxPostsBatch))
+	}
+
+	postIDs := make([]uuid.UUID, len(req.PostIds))
+	for i, postIDStr := range req.PostIds {
+		postID, err := uuid.Parse(postIDStr)
+		if err != nil {
+			return nil, rpcerror.InvalidField(fmt.Sprintf("post_ids[%d]", i), fmt.Sprintf("invalid post_id format at index %d", i))
+		}
+		postIDs[i] = postID
+	}
+
+	requestingUserID := viewerID(ctx)
+
+	posts, err := h.repo.GetByIDs(ctx, postIDs, requestingUserID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to get posts: %v", err))
+	}
+
+	authorIDs := make([]uuid.UUID, len(posts))
+	for i, post := range posts {
+		authorIDs[i] = post.Post.UserID
+	}
+	hidden, err := h.hiddenAuthors(ctx, authorIDs, requestingUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Followers-only posts need the follow status of their author, which is
+	// checked once per author
+	visibleByAuthor := make(map[uuid.UUID][]models.PostVisibility)
+	pbPosts := make([]*pb.Post, 0, len(posts))
+	for i := range posts {
+		post := &posts[i]
+		if hidden[post.Post.UserID] || hiddenDraft(&post.Post, requestingUserID) || shadowHidden(&post.Post, requestingUserID) {
+			continue
+		}
+		if post.Post.Visibility != models.VisibilityPublic {
+			visible, ok := visibleByAuthor[post.Post.UserID]
+			if !ok {
+				visible, err = h.visibleTo(ctx, post.Post.UserID, requestingUserID)
+				if err != nil {
+					return nil, err
+				}
+				visibleByAuthor[post.Post.UserID] = visible
+			}
+			if !slices.Contains(visible, post.Post.Visibility) {
+				continue
+			}
+		}
+		pbPosts = append(pbPosts, postWithLikeStatusToProto(post))
+	}
+
+	return &pb.GetPostsByIdsResponse{Posts: pbPosts}, nil
+}
+
 func (h *PostHandler) UpdatePost(ctx context.Context, req *pb.UpdatePostRequest) (*pb.Post, error) {
 	if req.PostId == "" {
-		return nil, status.Error(codes.InvalidArgument, "post_id is required")
+		return nil, rpcerror.InvalidField("post_id", "post_id is required")
 	}
 	if req.UserId == "" {
-		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+		return nil, rpcerror.InvalidField("user_id", "user_id is required")
 	}
 	if req.Content == "" {
-		return nil, status.Error(codes.InvalidArgument, "content is required")
+		return nil, rpcerror.InvalidField("content", "content is required")
 	}
 
 	postID, err := uuid.Parse(req.PostId)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid post_id format")
+		return nil, rpcerror.InvalidField("post_id", "invalid post_id format")
 	}
 
 	userID, err := uuid.Parse(req.UserId)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid user_id format")
+		return nil, rpcerror.InvalidField("user_id", "invalid user_id format")
 	}
 
-	existingPost, err := h.repo.GetByID(ctx, postID, nil)
+	verdict, err := h.screen(ctx, req.Content)
 	if err != nil {
-		return nil, status.Error(codes.NotFound, "post not found")
+		return nil, err
 	}
 
-	if existingPost.Post.UserID != userID {
-		return nil, status.Error(codes.PermissionDenied, "you can only update your own posts")
-	}
+	mentions := h.resolveMentions(ctx, req.Content)
 
-	post := &models.Post{
-		ID:            postID,
-		UserID:        userID,
-		Content:       req.Content,
-		UpdatedAt:     time.Now(),
-		CreatedAt:     existingPost.Post.CreatedAt,
-		LikesCount:    existingPost.Post.LikesCount,
-		CommentsCount: existingPost.Post.CommentsCount,
-	}
+	var post *models.Post
+	var added []models.Mention
+	err = h.repo.WithTx(ctx, func(ctx context.Context) error {
+		existingPost, err := h.repo.GetByID(ctx, postID, nil)
+		if err != nil {
+			return status.Error(codes.NotFound, "post not found")
+		}
+
+		if existingPost.Post.UserID != userID {
+			return status.Error(codes.PermissionDenied, "you can only update your own posts")
+		}
+		if existingPost.Post.Status != models.PostStatusPublished {
+			return status.Error(codes.FailedPrecondition, "unpublished posts are edited with SaveDraft")
+		}
+		// Others may already have seen the post, so an edit that would hide
+		// it is refused instead
+		if verdict.Action == contentfilter.ActionShadowHide && existingPost.Post.ShadowHiddenAt == nil {
+			return rejected(verdict)
+		}
 
-	if err := h.repo.Update(ctx, post); err != nil {
-		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to update post: %v", err))
+		post = &models.Post{
+			ID:             postID,
+			UserID:         userID,
+			Content:        req.Content,
+			UpdatedAt:      time.Now(),
+			CreatedAt:      existingPost.Post.CreatedAt,
+			LikesCount:     existingPost.Post.LikesCount,
+			CommentsCount:  existingPost.Post.CommentsCount,
+			RepostsCount:   existingPost.Post.RepostsCount,
+			QuotedPostID:   existingPost.Post.QuotedPostID,
+			QuotedPost:     existingPost.Post.QuotedPost,
+			Media:          existingPost.Post.Media,
+			Status:         existingPost.Post.Status,
+			Visibility:     existingPost.Post.Visibility,
+			ShadowHiddenAt: existingPost.Post.ShadowHiddenAt,
+		}
+
+		if err := h.repo.Update(ctx, post); err != nil {
+			return status.Error(codes.Internal, fmt.Sprintf("failed to update post: %v", err))
+		}
+		if err := h.repo.SetHashtags(ctx, post.ID, hashtagsOf(post), post.CreatedAt); err != nil {
+			return status.Error(codes.Internal, fmt.Sprintf("failed to update post: %v", err))
+		}
+		added, err = h.repo.SetMentions(ctx, post.ID, mentions, post.UpdatedAt)
+		if err != nil {
+			return status.Error(codes.Internal, fmt.Sprintf("failed to update post: %v", err))
+		}
+		if err := h.reportFlagged(ctx, post, verdict); err != nil {
+			return status.Error(codes.Internal, fmt.Sprintf("failed to update post: %v", err))
+		}
+		if post.ShadowHiddenAt != nil {
+			return nil
+		}
+
+		event := &eventspb.PostUpdated{
+			PostId:     post.ID.String(),
+			UserId:     post.UserID.String(),
+			Content:    post.Content,
+			Visibility: string(post.Visibility),
+			UpdatedAt:  timestamppb.New(post.UpdatedAt),
+		}
+		if err := h.publisher.PublishPostUpdated(ctx, event); err != nil {
+			return status.Error(codes.Internal, fmt.Sprintf("failed to update post: %v", err))
+		}
+		if err := h.publishMentions(ctx, post, added); err != nil {
+			return status.Error(codes.Internal, fmt.Sprintf("failed to update post: %v", err))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
+	post.Mentions = mentions
 
 	return postToProto(post, nil), nil
 }
 
 func (h *PostHandler) DeletePost(ctx context.Context, req *pb.DeletePostRequest) (*pb.Response, error) {
 	if req.PostId == "" {
-		return nil, status.Error(codes.InvalidArgument, "post_id is required")
+		return nil, rpcerror.InvalidField("post_id", "post_id is required")
 	}
 	if req.UserId == "" {
-		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+		return nil, rpcerror.InvalidField("user_id", "user_id is required")
 	}
 
 	postID, err := uuid.Parse(req.PostId)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid post_id format")
+		return nil, rpcerror.InvalidField("post_id", "invalid post_id format")
 	}
 
 	userID, err := uuid.Parse(req.UserId)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid user_id format")
+		return nil, rpcerror.InvalidField("user_id", "invalid user_id format")
 	}
 
-	existingPost, err := h.repo.GetByID(ctx, postID, nil)
-	if err != nil {
-		return nil, status.Error(codes.NotFound, "post not found")
-	}
-
-	if existingPost.Post.UserID != userID {
-		return nil, status.Error(codes.PermissionDenied, "you can only delete your own posts")
-	}
-
-	if err := h.repo.Delete(ctx, postID); err != nil {
-		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to delete post: %v", err))
+	if err := h.deletePost(ctx, postID, &userID); err != nil {
+		return nil, err
 	}
 
 	return &pb.Response{
@@ -184,23 +377,55 @@ func (h *PostHandler) DeletePost(ctx context.Context, req *pb.DeletePostRequest)
 	}, nil
 }
 
+// deletePost deletes a post and announces it. With ownerID set, only a post
+// of that user is deleted.
+func (h *PostHandler) deletePost(ctx context.Context, postID uuid.UUID, ownerID *uuid.UUID) error {
+	return h.repo.WithTx(ctx, func(ctx context.Context) error {
+		existingPost, err := h.repo.GetByID(ctx, postID, nil)
+		if err != nil {
+			return status.Error(codes.NotFound, "post not found")
+		}
+
+		if ownerID != nil && existingPost.Post.UserID != *ownerID {
+			return status.Error(codes.PermissionDenied, "you can only delete your own posts")
+		}
+
+		// The post is kept as a tombstone, with its attachments, until the
+		// purge job removes it
+		if err := h.repo.Delete(ctx, postID); err != nil {
+			return status.Error(codes.Internal, fmt.Sprintf("failed to delete post: %v", err))
+		}
+
+		event := &eventspb.PostDeleted{
+			PostId:    postID.String(),
+			UserId:    existingPost.Post.UserID.String(),
+			DeletedAt: timestamppb.Now(),
+		}
+		if err := h.publisher.PublishPostDeleted(ctx, event); err != nil {
+			return status.Error(codes.Internal, fmt.Sprintf("failed to delete post: %v", err))
+		}
+		return nil
+	})
+}
+
 func (h *PostHandler) GetUserPosts(ctx context.Context, req *pb.GetUserPostsRequest) (*pb.PostConnection, error) {
 	if req.UserId == "" {
-		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+		return nil, rpcerror.InvalidField("user_id", "user_id is required")
 	}
 
 	userID, err := uuid.Parse(req.UserId)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid user_id format")
+		return nil, rpcerror.InvalidField("user_id", "invalid user_id format")
 	}
 
-	var requestingUserID *uuid.UUID
-	if req.RequestingUserId != nil && *req.RequestingUserId != "" {
-		id, err := uuid.Parse(*req.RequestingUserId)
-		if err != nil {
-			return nil, status.Error(codes.InvalidArgument, "invalid requesting_user_id format")
-		}
-		requestingUserID = &id
+	requestingUserID := viewerID(ctx)
+
+	if err := h.checkCanView(ctx, userID, requestingUserID); err != nil {
+		return nil, err
+	}
+	visible, err := h.visibleTo(ctx, userID, requestingUserID)
+	if err != nil {
+		return nil, err
 	}
 
 	first := req.First
@@ -211,97 +436,204 @@ func (h *PostHandler) GetUserPosts(ctx context.Context, req *pb.GetUserPostsRequ
 		first = 100
 	}
 
-	connection, err := h.repo.GetUserPosts(ctx, userID, first, req.After, requestingUserID)
+	connection, err := h.repo.GetUserPosts(ctx, userID, visible, first, req.After, requestingUserID)
 	if err != nil {
+		if errors.Is(err, cursor.ErrInvalid) {
+			return nil, rpcerror.InvalidField("after", "invalid cursor")
+		}
 		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to get user posts: %v", err))
 	}
+	dropShadowHidden(connection, requestingUserID)
 
 	return connectionToProto(connection, requestingUserID), nil
 }
 
-func (h *PostHandler) IncrementCommentsCount(ctx context.Context, req *pb.IncrementCommentsCountRequest) (*pb.Response, error) {
-	if req.PostId == "" {
-		return nil, status.Error(codes.InvalidArgument, "post_id is required")
+func (h *PostHandler) ListPublicPosts(ctx context.Context, req *pb.ListPublicPostsRequest) (*pb.ListPublicPostsResponse, error) {
+	limit := req.Limit
+	if limit <= 0 {
+		limit = 1000
+	}
+	if limit > 10000 {
+		limit = 10000
 	}
 
-	postID, err := uuid.Parse(req.PostId)
+	var afterID *uuid.UUID
+	if req.AfterId != nil && *req.AfterId != "" {
+		id, err := uuid.Parse(*req.AfterId)
+		if err != nil {
+			return nil, rpcerror.InvalidField("after_id", "invalid after_id format")
+		}
+		afterID = &id
+	}
+
+	posts, err := h.repo.ListPublicPosts(ctx, afterID, limit)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid post_id format")
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to list public posts: %v", err))
 	}
 
-	if err := h.repo.IncrementCommentsCount(ctx, postID); err != nil {
-		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to increment comments count: %v", err))
+	// Posts by private accounts are never indexed
+	authorIDs := make([]uuid.UUID, len(posts))
+	for i, post := range posts {
+		authorIDs[i] = post.UserID
+	}
+	hidden, err := h.hiddenAuthors(ctx, authorIDs, nil)
+	if err != nil {
+		return nil, err
 	}
 
-	return &pb.Response{
-		Success: true,
-		Message: "Comments count incremented successfully",
-	}, nil
+	pbPosts := make([]*pb.PublicPost, 0, len(posts))
+	for _, post := range posts {
+		if hidden[post.UserID] {
+			continue
+		}
+		pbPosts = append(pbPosts, &pb.PublicPost{
+			Id:        post.ID.String(),
+			UserId:    post.UserID.String(),
+			UpdatedAt: timestamppb.New(post.UpdatedAt),
+		})
+	}
+
+	resp := &pb.ListPublicPostsResponse{Posts: pbPosts}
+	if int32(len(posts)) == limit {
+		next := posts[len(posts)-1].ID.String()
+		resp.NextAfterId = &next
+	}
+
+	return resp, nil
 }
 
-func (h *PostHandler) DecrementCommentsCount(ctx context.Context, req *pb.DecrementCommentsCountRequest) (*pb.Response, error) {
-	if req.PostId == "" {
-		return nil, status.Error(codes.InvalidArgument, "post_id is required")
+func (h *PostHandler) GetPostsByHashtag(ctx context.Context, req *pb.GetPostsByHashtagRequest) (*pb.PostConnection, error) {
+	tag, ok := hashtag.Normalize(req.Tag)
+	if !ok {
+		return nil, status.Error(codes.InvalidArgument, "invalid hashtag")
 	}
 
-	postID, err := uuid.Parse(req.PostId)
+	first := req.First
+	if first <= 0 {
+		first = 10
+	}
+	if first > 100 {
+		first = 100
+	}
+
+	connection, err := h.repo.GetPostsByHashtag(ctx, tag, first, req.After)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid post_id format")
+		if errors.Is(err, cursor.ErrInvalid) {
+			return nil, rpcerror.InvalidField("after", "invalid cursor")
+		}
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to get posts by hashtag: %v", err))
 	}
 
-	if err := h.repo.DecrementCommentsCount(ctx, postID); err != nil {
-		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to decrement comments count: %v", err))
+	// Hashtag pages are public, so they never show posts by private accounts
+	if err := h.filterVisible(ctx, connection, nil); err != nil {
+		return nil, err
 	}
 
-	return &pb.Response{
-		Success: true,
-		Message: "Comments count decremented successfully",
-	}, nil
+	return connectionToProto(connection, nil), nil
 }
 
-func (h *PostHandler) IncrementLikesCount(ctx context.Context, req *pb.IncrementLikesCountRequest) (*pb.Response, error) {
-	if req.PostId == "" {
-		return nil, status.Error(codes.InvalidArgument, "post_id is required")
+func (h *PostHandler) GetTrendingHashtags(ctx context.Context, req *pb.GetTrendingHashtagsRequest) (*pb.GetTrendingHashtagsResponse, error) {
+	limit := req.Limit
+	if limit <= 0 {
+		limit = 10
+	}
+	if limit > 50 {
+		limit = 50
 	}
 
-	postID, err := uuid.Parse(req.PostId)
+	windowHours := req.WindowHours
+	if windowHours <= 0 {
+		windowHours = 24
+	}
+	// A week is the longest window worth aggregating on request
+	if windowHours > 168 {
+		return nil, rpcerror.InvalidField("window_hours", "window_hours must be at most 168")
+	}
+
+	trending, err := h.repo.GetTrendingHashtags(ctx, time.Duration(windowHours)*time.Hour, limit)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid post_id format")
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to get trending hashtags: %v", err))
 	}
 
-	if err := h.repo.IncrementLikesCount(ctx, postID); err != nil {
-		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to increment likes count: %v", err))
+	hashtags := make([]*pb.TrendingHashtag, len(trending))
+	for i, t := range trending {
+		hashtags[i] = &pb.TrendingHashtag{
+			Tag:        t.Tag,
+			PostsCount: t.PostsCount,
+		}
 	}
 
-	return &pb.Response{
-		Success: true,
-		Message: "Likes count incremented successfully",
-	}, nil
+	return &pb.GetTrendingHashtagsResponse{Hashtags: hashtags}, nil
 }
 
-func (h *PostHandler) DecrementLikesCount(ctx context.Context, req *pb.DecrementLikesCountRequest) (*pb.Response, error) {
-	if req.PostId == "" {
-		return nil, status.Error(codes.InvalidArgument, "post_id is required")
+// announcePost indexes the hashtags and mentions of a post being published
+// and emits its PostCreated and mention events. Events are stored with the
+// post and relayed once the transaction commits, so consumers never see a
+// post that does not exist. A shadow-hidden post only keeps its mentions.
+func (h *PostHandler) announcePost(ctx context.Context, post *models.Post, mentions []models.Mention) error {
+	if err := h.repo.SetHashtags(ctx, post.ID, hashtagsOf(post), post.CreatedAt); err != nil {
+		return err
 	}
-
-	postID, err := uuid.Parse(req.PostId)
+	added, err := h.repo.SetMentions(ctx, post.ID, mentions, post.CreatedAt)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid post_id format")
+		return err
+	}
+	if post.ShadowHiddenAt != nil {
+		return nil
 	}
 
-	if err := h.repo.DecrementLikesCount(ctx, postID); err != nil {
-		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to decrement likes count: %v", err))
+	event := &eventspb.PostCreated{
+		PostId:     post.ID.String(),
+		UserId:     post.UserID.String(),
+		Content:    post.Content,
+		Visibility: string(post.Visibility),
+		CreatedAt:  timestamppb.New(post.CreatedAt),
 	}
+	if err := h.publisher.PublishPostCreated(ctx, event); err != nil {
+		return err
+	}
+	return h.publishMentions(ctx, post, added)
+}
 
-	return &pb.Response{
-		Success: true,
-		Message: "Likes count decremented successfully",
-	}, nil
+// hashtagsOf returns the hashtags a post is listed under. Hashtag pages are
+// public, so only public posts that are not shadow-hidden are listed.
+func hashtagsOf(post *models.Post) []string {
+	if post.Visibility != models.VisibilityPublic || post.ShadowHiddenAt != nil {
+		return nil
+	}
+	return hashtag.Extract(post.Content)
 }
 
 // Helper functions to convert between models and proto
 
+// viewerID returns the user the request is made for, from its verified token,
+// or nil for anonymous requests. The requesting_user_id of public requests is
+// ignored, as any caller could set it.
+func viewerID(ctx context.Context) *uuid.UUID {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil
+	}
+	return &userID
+}
+
+// callerID returns the authenticated user making the request
+func callerID(ctx context.Context) (uuid.UUID, error) {
+	raw, err := interceptor.GetUserIDFromContext(ctx)
+	if err != nil {
+		return uuid.Nil, status.Error(codes.Unauthenticated, "user not authenticated")
+	}
+	userID, err := uuid.Parse(raw)
+	if err != nil {
+		return uuid.Nil, status.Error(codes.Unauthenticated, "invalid user ID in token")
+	}
+	return userID, nil
+}
+
 func postToProto(post *models.Post, isLiked *bool) *pb.Post {
+	if post == nil {
+		return nil
+	}
 	return &pb.Post{
 		Id:            post.ID.String(),
 		UserId:        post.UserID.String(),
@@ -310,7 +642,17 @@ func postToProto(post *models.Post, isLiked *bool) *pb.Post {
 		UpdatedAt:     timestamppb.New(post.UpdatedAt),
 		LikesCount:    post.LikesCount,
 		CommentsCount: post.CommentsCount,
+		RepostsCount:  post.RepostsCount,
 		IsLiked:       isLiked,
+		Mentions:      mentionsToProto(post.Mentions),
+		Media:         mediaListToProto(post.Media),
+		QuotedPostId:  uuidToProto(post.QuotedPostID),
+		QuotedPost:    postToProto(post.QuotedPost, nil),
+		DeletedAt:     timeToProto(post.DeletedAt),
+		EditedAt:      timeToProto(post.EditedAt),
+		Status:        statusToProto(post.Status),
+		PublishAt:     timeToProto(post.PublishAt),
+		Visibility:    visibilityToProto(post.Visibility),
 	}
 }
 
@@ -323,8 +665,62 @@ func postWithLikeStatusToProto(post *models.PostWithLikeStatus) *pb.Post {
 		UpdatedAt:     timestamppb.New(post.Post.UpdatedAt),
 		LikesCount:    post.Post.LikesCount,
 		CommentsCount: post.Post.CommentsCount,
+		RepostsCount:  post.Post.RepostsCount,
 		IsLiked:       post.IsLiked,
+		IsReposted:    post.IsReposted,
+		Mentions:      mentionsToProto(post.Post.Mentions),
+		Media:         mediaListToProto(post.Post.Media),
+		QuotedPostId:  uuidToProto(post.Post.QuotedPostID),
+		QuotedPost:    postToProto(post.Post.QuotedPost, nil),
+		EditedAt:      timeToProto(post.Post.EditedAt),
+		Status:        statusToProto(post.Post.Status),
+		PublishAt:     timeToProto(post.Post.PublishAt),
+		Visibility:    visibilityToProto(post.Post.Visibility),
+	}
+}
+
+func statusToProto(s models.PostStatus) pb.PostStatus {
+	return pb.PostStatus(pb.PostStatus_value["POST_STATUS_"+string(s)])
+}
+
+func visibilityToProto(v models.PostVisibility) pb.PostVisibility {
+	return pb.PostVisibility(pb.PostVisibility_value["POST_VISIBILITY_"+string(v)])
+}
+
+// visibilityFromProto converts a requested visibility, defaulting to public
+func visibilityFromProto(v pb.PostVisibility) models.PostVisibility {
+	if v == pb.PostVisibility_POST_VISIBILITY_UNSPECIFIED {
+		return models.VisibilityPublic
+	}
+	return models.PostVisibility(strings.TrimPrefix(v.String(), "POST_VISIBILITY_"))
+}
+
+// timeToProto converts an optional time to an optional proto timestamp
+func timeToProto(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+// uuidToProto converts an optional ID to an optional proto string
+func uuidToProto(id *uuid.UUID) *string {
+	if id == nil {
+		return nil
+	}
+	s := id.String()
+	return &s
+}
+
+func mentionsToProto(mentions []models.Mention) []*pb.Mention {
+	out := make([]*pb.Mention, len(mentions))
+	for i, m := range mentions {
+		out[i] = &pb.Mention{
+			UserId:   m.UserID.String(),
+			Username: m.Username,
+		}
 	}
+	return out
 }
 
 func connectionToProto(conn *models.PostConnection, requestingUserID *uuid.UUID) *pb.PostConnection {
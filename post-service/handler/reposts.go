@@ -0,0 +1,137 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"post-service/events"
+	"post-service/model"
+	pb "post-service/pb"
+	"post-service/rpcerror"
+)
+
+// Repost shares a post with the caller's followers. Only public posts can be
+// reposted, and reposting a post twice is a no-op.
+func (h *PostHandler) Repost(ctx context.Context, req *pb.RepostRequest) (*pb.Response, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	postID, err := uuid.Parse(req.PostId)
+	if err != nil {
+		return nil, rpcerror.InvalidField("post_id", "invalid post_id format")
+	}
+
+	repost := &models.Repost{
+		ID:        uuid.New(),
+		PostID:    postID,
+		UserID:    userID,
+		CreatedAt: time.Now(),
+	}
+
+	var post *models.Post
+	var created bool
+	err = h.repo.WithTx(ctx, func(ctx context.Context) error {
+		existing, err := h.repo.GetByID(ctx, postID, nil)
+		if err != nil || existing.Post.Status != models.PostStatusPublished || shadowHidden(&existing.Post, &userID) {
+			return status.Error(codes.NotFound, "post not found")
+		}
+		if existing.Post.Visibility != models.VisibilityPublic {
+			return status.Error(codes.FailedPrecondition, "only public posts can be reposted")
+		}
+		post = &existing.Post
+
+		created, err = h.repo.CreateRepost(ctx, repost)
+		if err != nil {
+			return status.Error(codes.Internal, fmt.Sprintf("failed to repost: %v", err))
+		}
+		if !created {
+			return nil
+		}
+
+		event := events.PostRepostedEvent{
+			RepostID:      repost.ID,
+			PostID:        post.ID,
+			PostAuthorID:  post.UserID,
+			PostContent:   post.Content,
+			PostCreatedAt: post.CreatedAt,
+			UserID:        userID,
+			CreatedAt:     repost.CreatedAt,
+		}
+		if err := h.publisher.PublishPostReposted(ctx, event); err != nil {
+			return status.Error(codes.Internal, fmt.Sprintf("failed to repost: %v", err))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !created {
+		return &pb.Response{
+			Success: true,
+			Message: "Post already reposted",
+		}, nil
+	}
+
+	return &pb.Response{
+		Success: true,
+		Message: "Post reposted successfully",
+	}, nil
+}
+
+// UndoRepost removes the caller's repost of a post
+func (h *PostHandler) UndoRepost(ctx context.Context, req *pb.UndoRepostRequest) (*pb.Response, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	postID, err := uuid.Parse(req.PostId)
+	if err != nil {
+		return nil, rpcerror.InvalidField("post_id", "invalid post_id format")
+	}
+
+	var repost *models.Repost
+	err = h.repo.WithTx(ctx, func(ctx context.Context) error {
+		repost, err = h.repo.DeleteRepost(ctx, postID, userID)
+		if err != nil {
+			return status.Error(codes.Internal, fmt.Sprintf("failed to undo repost: %v", err))
+		}
+		if repost == nil {
+			return nil
+		}
+
+		event := events.PostUnrepostedEvent{
+			RepostID:  repost.ID,
+			PostID:    postID,
+			UserID:    userID,
+			DeletedAt: time.Now(),
+		}
+		if err := h.publisher.PublishPostUnreposted(ctx, event); err != nil {
+			return status.Error(codes.Internal, fmt.Sprintf("failed to undo repost: %v", err))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if repost == nil {
+		return &pb.Response{
+			Success: true,
+			Message: "Post was not reposted",
+		}, nil
+	}
+
+	return &pb.Response{
+		Success: true,
+		Message: "Repost removed successfully",
+	}, nil
+}
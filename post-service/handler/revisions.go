@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"post-service/model"
+	pb "post-service/pb"
+	"post-service/rpcerror"
+	"shared/cursor"
+)
+
+// GetPostRevisions returns the earlier versions of a post, most recent edit
+// first. They are visible to whoever may see the post.
+func (h *PostHandler) GetPostRevisions(ctx context.Context, req *pb.GetPostRevisionsRequest) (*pb.PostRevisionConnection, error) {
+	postID, err := uuid.Parse(req.PostId)
+	if err != nil {
+		return nil, rpcerror.InvalidField("post_id", "invalid post_id format")
+	}
+
+	requestingUserID := viewerID(ctx)
+
+	first := req.First
+	if first <= 0 {
+		first = 10
+	}
+	if first > 100 {
+		first = 100
+	}
+
+	post, err := h.repo.GetByID(ctx, postID, nil)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, fmt.Sprintf("post not found: %v", err))
+	}
+	if err := h.checkCanSeePost(ctx, &post.Post, requestingUserID); err != nil {
+		return nil, err
+	}
+
+	connection, err := h.repo.GetPostRevisions(ctx, postID, first, req.After)
+	if err != nil {
+		if errors.Is(err, cursor.ErrInvalid) {
+			return nil, rpcerror.InvalidField("after", "invalid cursor")
+		}
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to get post revisions: %v", err))
+	}
+
+	return revisionConnectionToProto(connection), nil
+}
+
+func revisionConnectionToProto(conn *models.PostRevisionConnection) *pb.PostRevisionConnection {
+	edges := make([]*pb.PostRevisionEdge, len(conn.Edges))
+	for i, edge := range conn.Edges {
+		edges[i] = &pb.PostRevisionEdge{
+			Cursor: edge.Cursor,
+			Node: &pb.PostRevision{
+				Id:       edge.Node.ID.String(),
+				PostId:   edge.Node.PostID.String(),
+				EditorId: edge.Node.EditorID.String(),
+				Content:  edge.Node.Content,
+				EditedAt: timestamppb.New(edge.Node.EditedAt),
+			},
+		}
+	}
+
+	return &pb.PostRevisionConnection{
+		Edges: edges,
+		PageInfo: &pb.PageInfo{
+			EndCursor:       conn.PageInfo.EndCursor,
+			HasNextPage:     conn.PageInfo.HasNextPage,
+			StartCursor:     conn.PageInfo.StartCursor,
+			HasPreviousPage: conn.PageInfo.HasPreviousPage,
+		},
+		TotalCount: conn.TotalCount,
+	}
+}
@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"post-service/events"
+	"post-service/model"
+	pb "post-service/pb"
+	eventspb "shared/eventschema/pb"
+	"shared/logging"
+)
+
+// dataExport is the document ExportMyData returns
+type dataExport struct {
+	Posts     []models.Post     `json:"posts"`
+	Reposts   []models.Repost   `json:"reposts"`
+	PollVotes []models.PollVote `json:"poll_votes"`
+}
+
+// ExportMyData returns the caller's posts, drafts and scheduled posts
+// included, their reposts and their poll votes as a JSON document
+func (h *PostHandler) ExportMyData(ctx context.Context, req *pb.ExportMyDataRequest) (*pb.DataExport, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var export dataExport
+	if export.Posts, err = h.repo.GetAllUserPosts(ctx, userID); err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to export posts: %v", err))
+	}
+	if export.Reposts, err = h.repo.GetUserReposts(ctx, userID); err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to export reposts: %v", err))
+	}
+	if export.PollVotes, err = h.repo.GetUserPollVotes(ctx, userID); err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to export poll votes: %v", err))
+	}
+
+	data, err := json.Marshal(export)
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to encode export: %v", err))
+	}
+	return &pb.DataExport{Data: data}, nil
+}
+
+// DeleteUserContent removes what a deleted user left behind. Their posts are
+// deleted and their reposts undone one by one, with the same events as when
+// they do it themselves, so feeds, search and counters follow. Their poll
+// votes and the mentions of them are removed too.
+func (h *PostHandler) DeleteUserContent(ctx context.Context, userID uuid.UUID) error {
+	posts, err := h.repo.GetAllUserPosts(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, post := range posts {
+		err := h.repo.WithTx(ctx, func(ctx context.Context) error {
+			if err := h.repo.Delete(ctx, post.ID); err != nil {
+				return err
+			}
+			return h.publisher.PublishPostDeleted(ctx, &eventspb.PostDeleted{
+				PostId:    post.ID.String(),
+				UserId:    userID.String(),
+				DeletedAt: timestamppb.Now(),
+			})
+		})
+		if err != nil {
+			return fmt.Errorf("failed to delete post %s: %w", post.ID, err)
+		}
+	}
+
+	reposts, err := h.repo.GetUserReposts(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, repost := range reposts {
+		err := h.repo.WithTx(ctx, func(ctx context.Context) error {
+			deleted, err := h.repo.DeleteRepost(ctx, repost.PostID, userID)
+			if err != nil || deleted == nil {
+				return err
+			}
+			return h.publisher.PublishPostUnreposted(ctx, events.PostUnrepostedEvent{
+				RepostID:  deleted.ID,
+				PostID:    deleted.PostID,
+				UserID:    userID,
+				DeletedAt: time.Now(),
+			})
+		})
+		if err != nil {
+			return fmt.Errorf("failed to undo repost of %s: %w", repost.PostID, err)
+		}
+	}
+
+	votes, err := h.repo.DeleteUserPollVotes(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if err := h.repo.DeleteUserMentions(ctx, userID); err != nil {
+		return err
+	}
+
+	logging.FromContext(ctx).Info().Stringer("user_id", userID).Int("posts", len(posts)).Int("reposts", len(reposts)).Int64("poll_votes", votes).Msg("deleted content of deleted user")
+	return nil
+}
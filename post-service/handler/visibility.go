@@ -0,0 +1,192 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	followpb "follow-service/pb"
+	"post-service/model"
+	userpb "user-service/pb"
+)
+
+// hiddenAuthors returns which of authorIDs have private accounts that
+// viewerID (nil for anonymous callers) is not allowed to see: posts of a
+// private account are only shown to its owner and approved followers. When
+// user-service or follow-service cannot be reached the posts are hidden
+// rather than risk showing them.
+func (h *PostHandler) hiddenAuthors(ctx context.Context, authorIDs []uuid.UUID, viewerID *uuid.UUID) (map[uuid.UUID]bool, error) {
+	seen := make(map[uuid.UUID]bool, len(authorIDs))
+	ids := make([]string, 0, len(authorIDs))
+	for _, id := range authorIDs {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id.String())
+		}
+	}
+	if len(ids) == 0 {
+		return map[uuid.UUID]bool{}, nil
+	}
+
+	resp, err := h.users.GetUsersByIds(ctx, &userpb.GetUsersByIdsRequest{UserIds: ids})
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, fmt.Sprintf("failed to look up authors: %v", err))
+	}
+
+	hidden := make(map[uuid.UUID]bool)
+	var targets []string
+	for _, user := range resp.Users {
+		if !user.IsPrivate {
+			continue
+		}
+		id, err := uuid.Parse(user.Id)
+		if err != nil {
+			continue
+		}
+		if viewerID != nil && *viewerID == id {
+			continue
+		}
+		hidden[id] = true
+		targets = append(targets, user.Id)
+	}
+	if len(hidden) == 0 || viewerID == nil {
+		return hidden, nil
+	}
+
+	statuses, err := h.follows.GetFollowStatus(ctx, &followpb.GetFollowStatusRequest{
+		UserId:        viewerID.String(),
+		TargetUserIds: targets,
+	})
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, fmt.Sprintf("failed to check follow status: %v", err))
+	}
+	for _, s := range statuses.Statuses {
+		if s.IsFollowing {
+			id, _ := uuid.Parse(s.UserId)
+			delete(hidden, id)
+		}
+	}
+
+	return hidden, nil
+}
+
+// checkCanView returns a PermissionDenied error when viewerID may not see
+// posts by authorID
+func (h *PostHandler) checkCanView(ctx context.Context, authorID uuid.UUID, viewerID *uuid.UUID) error {
+	hidden, err := h.hiddenAuthors(ctx, []uuid.UUID{authorID}, viewerID)
+	if err != nil {
+		return err
+	}
+	if hidden[authorID] {
+		return status.Error(codes.PermissionDenied, "this account is private")
+	}
+	return nil
+}
+
+// filterVisible drops the posts of a page that viewerID may not see. The
+// page's cursors and total count are left as they are.
+func (h *PostHandler) filterVisible(ctx context.Context, conn *models.PostConnection, viewerID *uuid.UUID) error {
+	authorIDs := make([]uuid.UUID, len(conn.Edges))
+	for i, edge := range conn.Edges {
+		authorIDs[i] = edge.Node.UserID
+	}
+
+	hidden, err := h.hiddenAuthors(ctx, authorIDs, viewerID)
+	if err != nil {
+		return err
+	}
+	if len(hidden) == 0 {
+		return nil
+	}
+
+	visible := conn.Edges[:0]
+	for _, edge := range conn.Edges {
+		if !hidden[edge.Node.UserID] {
+			visible = append(visible, edge)
+		}
+	}
+	conn.Edges = visible
+	return nil
+}
+
+// hiddenDraft reports whether post is a draft or scheduled post that viewerID
+// (nil for anonymous callers) may not see. Only authors see their unpublished
+// posts.
+func hiddenDraft(post *models.Post, viewerID *uuid.UUID) bool {
+	if post.Status == models.PostStatusPublished {
+		return false
+	}
+	return viewerID == nil || *viewerID != post.UserID
+}
+
+// shadowHidden reports whether post was shadow-hidden by a content filter
+// from viewerID (nil for anonymous callers). Only authors see their
+// shadow-hidden posts, with nothing to tell them apart.
+func shadowHidden(post *models.Post, viewerID *uuid.UUID) bool {
+	if post.ShadowHiddenAt == nil {
+		return false
+	}
+	return viewerID == nil || *viewerID != post.UserID
+}
+
+// dropShadowHidden drops the shadow-hidden posts of a page that viewerID may
+// not see. Like filterVisible, it leaves cursors and the total count alone.
+func dropShadowHidden(conn *models.PostConnection, viewerID *uuid.UUID) {
+	visible := conn.Edges[:0]
+	for _, edge := range conn.Edges {
+		if !shadowHidden(&edge.Node, viewerID) {
+			visible = append(visible, edge)
+		}
+	}
+	conn.Edges = visible
+}
+
+// visibleTo returns the visibilities of posts by authorID that viewerID may
+// see: every post for the author, followers-only posts as well for approved
+// followers, and public posts for everyone else
+func (h *PostHandler) visibleTo(ctx context.Context, authorID uuid.UUID, viewerID *uuid.UUID) ([]models.PostVisibility, error) {
+	if viewerID == nil {
+		return []models.PostVisibility{models.VisibilityPublic}, nil
+	}
+	if *viewerID == authorID {
+		return []models.PostVisibility{models.VisibilityPublic, models.VisibilityFollowersOnly, models.VisibilityPrivate}, nil
+	}
+
+	resp, err := h.follows.IsFollowing(ctx, &followpb.IsFollowingRequest{
+		FollowerId:  viewerID.String(),
+		FollowingId: authorID.String(),
+	})
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, fmt.Sprintf("failed to check follow status: %v", err))
+	}
+	if resp.IsFollowing {
+		return []models.PostVisibility{models.VisibilityPublic, models.VisibilityFollowersOnly}, nil
+	}
+	return []models.PostVisibility{models.VisibilityPublic}, nil
+}
+
+// checkCanSeePost returns a NotFound error when post is unpublished,
+// shadow-hidden or its visibility keeps it from viewerID, so its existence is
+// not revealed, and a PermissionDenied error when its author's account is
+// private to viewerID
+func (h *PostHandler) checkCanSeePost(ctx context.Context, post *models.Post, viewerID *uuid.UUID) error {
+	if hiddenDraft(post, viewerID) || shadowHidden(post, viewerID) {
+		return status.Error(codes.NotFound, "post not found")
+	}
+
+	if post.Visibility != models.VisibilityPublic {
+		visible, err := h.visibleTo(ctx, post.UserID, viewerID)
+		if err != nil {
+			return err
+		}
+		if !slices.Contains(visible, post.Visibility) {
+			return status.Error(codes.NotFound, "post not found")
+		}
+	}
+
+	return h.checkCanView(ctx, post.UserID, viewerID)
+}
@@ -0,0 +1,83 @@
+// Package hashtag finds the hashtags used in post content
+package hashtag
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// MaxLength matches the tag column of post_service_post_hashtags
+	MaxLength = 64
+	// MaxPerPost caps how many tags a single post is indexed under
+	MaxPerPost = 30
+)
+
+// Extract returns the distinct normalized hashtags in content, in order of
+// first use. A hashtag is '#' followed by letters, digits or underscores,
+// containing at least one letter, and not preceded by a word character (so
+// "a#b" and "#123" are not tags). Over-long tags are ignored.
+func Extract(content string) []string {
+	var tags []string
+	seen := make(map[string]bool)
+
+	prev := ' '
+	for i := 0; i < len(content); {
+		r, size := utf8.DecodeRuneInString(content[i:])
+		if r != '#' || isWordRune(prev) {
+			prev = r
+			i += size
+			continue
+		}
+
+		end := i + size
+		for end < len(content) {
+			next, n := utf8.DecodeRuneInString(content[end:])
+			if !isWordRune(next) {
+				break
+			}
+			end += n
+		}
+
+		if tag, ok := Normalize(content[i+size : end]); ok && !seen[tag] {
+			seen[tag] = true
+			tags = append(tags, tag)
+			if len(tags) == MaxPerPost {
+				break
+			}
+		}
+
+		prev = '#'
+		if end > i+size {
+			prev, _ = utf8.DecodeLastRuneInString(content[:end])
+		}
+		i = end
+	}
+
+	return tags
+}
+
+// Normalize lowercases a tag, with or without its leading '#', and reports
+// whether it is a valid hashtag
+func Normalize(tag string) (string, bool) {
+	tag = strings.ToLower(strings.TrimPrefix(tag, "#"))
+	if tag == "" || utf8.RuneCountInString(tag) > MaxLength {
+		return "", false
+	}
+
+	hasLetter := false
+	for _, r := range tag {
+		if !isWordRune(r) {
+			return "", false
+		}
+		if unicode.IsLetter(r) {
+			hasLetter = true
+		}
+	}
+	return tag, hasLetter
+}
+
+func isWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
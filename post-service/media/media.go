@@ -0,0 +1,112 @@
+// Package media validates uploaded files and keeps them in a store
+package media
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/google/uuid"
+)
+
+// MaxPerPost caps how many uploads a single post can attach
+const MaxPerPost = 4
+
+// SniffLen is how many leading bytes DetectType needs
+const SniffLen = 512
+
+const (
+	maxImageBytes = 10 << 20
+	maxVideoBytes = 50 << 20
+)
+
+// allowedTypes maps the accepted content types to their size limit
+var allowedTypes = map[string]int64{
+	"image/jpeg": maxImageBytes,
+	"image/png":  maxImageBytes,
+	"image/gif":  maxImageBytes,
+	"image/webp": maxImageBytes,
+	"video/mp4":  maxVideoBytes,
+}
+
+// ErrNotFound is returned by Store.Open for an unknown file
+var ErrNotFound = errors.New("media not found")
+
+// DetectType returns the content type of a file from its first bytes and
+// the largest size accepted for it. The client's claimed type is never
+// trusted, so a script renamed to .png is rejected.
+func DetectType(head []byte) (string, int64, error) {
+	mimeType := http.DetectContentType(head)
+	limit, ok := allowedTypes[mimeType]
+	if !ok {
+		return "", 0, fmt.Errorf("unsupported media type %s", mimeType)
+	}
+	return mimeType, limit, nil
+}
+
+// Store keeps the bytes of uploaded files, keyed by media ID
+type Store interface {
+	// Save writes r under id and returns the number of bytes written
+	Save(id uuid.UUID, r io.Reader) (int64, error)
+	Open(id uuid.UUID) (io.ReadCloser, error)
+	Delete(id uuid.UUID) error
+}
+
+type fileStore struct {
+	dir string
+}
+
+// NewFileStore stores files in dir, which is created if missing. Replicas
+// must share the directory, e.g. through a common volume.
+func NewFileStore(dir string) (Store, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create media directory: %w", err)
+	}
+	return &fileStore{dir: dir}, nil
+}
+
+func (s *fileStore) path(id uuid.UUID) string {
+	return filepath.Join(s.dir, id.String())
+}
+
+// Save writes to a temporary file first so a failed upload never leaves a
+// partial file under the final name
+func (s *fileStore) Save(id uuid.UUID, r io.Reader) (int64, error) {
+	tmp, err := os.CreateTemp(s.dir, ".upload-*")
+	if err != nil {
+		return 0, err
+	}
+	defer os.Remove(tmp.Name())
+
+	n, err := io.Copy(tmp, r)
+	if err != nil {
+		tmp.Close()
+		return 0, err
+	}
+	if err := tmp.Close(); err != nil {
+		return 0, err
+	}
+	if err := os.Rename(tmp.Name(), s.path(id)); err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+
+func (s *fileStore) Open(id uuid.UUID) (io.ReadCloser, error) {
+	f, err := os.Open(s.path(id))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	return f, err
+}
+
+func (s *fileStore) Delete(id uuid.UUID) error {
+	err := os.Remove(s.path(id))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
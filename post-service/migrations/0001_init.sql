@@ -0,0 +1,243 @@
+-- ========================================
+-- Post Service Database Initialization
+-- ========================================
+
+-- Enable UUID extension
+CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
+
+-- ========================================
+-- Posts Table
+-- ========================================
+CREATE TABLE IF NOT EXISTS post_service_posts (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL,
+    content TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    likes_count INTEGER NOT NULL DEFAULT 0,
+    comments_count INTEGER NOT NULL DEFAULT 0,
+    reposts_count INTEGER NOT NULL DEFAULT 0,
+    -- Set to NULL when the quoted post is deleted
+    quoted_post_id UUID REFERENCES post_service_posts(id) ON DELETE SET NULL,
+    
+    -- Constraints
+    CONSTRAINT posts_likes_count_non_negative CHECK (likes_count >= 0),
+    CONSTRAINT posts_comments_count_non_negative CHECK (comments_count >= 0),
+    CONSTRAINT posts_reposts_count_non_negative CHECK (reposts_count >= 0)
+);
+
+-- ========================================
+-- Likes Table
+-- ========================================
+CREATE TABLE IF NOT EXISTS post_service_likes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    post_id UUID NOT NULL REFERENCES post_service_posts(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    
+    -- Ensure a user can only like a post once
+    CONSTRAINT post_likes_unique_user_post UNIQUE (post_id, user_id)
+);
+
+-- ========================================
+-- Comments Table
+-- ========================================
+CREATE TABLE IF NOT EXISTS post_service_comments (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    post_id UUID NOT NULL REFERENCES post_service_posts(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL,
+    content TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- ========================================
+-- Hashtags Table
+-- ========================================
+-- One row per hashtag used in a post. created_at copies the post's creation
+-- time so tag pages and trending counts never join back to the posts table.
+CREATE TABLE IF NOT EXISTS post_service_post_hashtags (
+    post_id UUID NOT NULL REFERENCES post_service_posts(id) ON DELETE CASCADE,
+    tag VARCHAR(64) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+
+    PRIMARY KEY (post_id, tag)
+);
+
+-- ========================================
+-- Mentions Table
+-- ========================================
+-- Users mentioned in a post, resolved through user-service when the post is
+-- written. username keeps the name the content refers to.
+CREATE TABLE IF NOT EXISTS post_service_post_mentions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    post_id UUID NOT NULL REFERENCES post_service_posts(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL,
+    username VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT post_mentions_unique_post_user UNIQUE (post_id, user_id)
+);
+
+-- Reposts of a post; a user can repost a post once. id identifies the repost
+-- in events, so undoing and reposting again notifies the author again.
+CREATE TABLE IF NOT EXISTS post_service_reposts (
+    id UUID NOT NULL UNIQUE,
+    post_id UUID NOT NULL REFERENCES post_service_posts(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (post_id, user_id)
+);
+
+-- Uploaded media. Files live in the media store under their id; post_id is
+-- set when a post is created with the upload and position orders a post's
+-- attachments.
+CREATE TABLE IF NOT EXISTS post_service_post_media (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    post_id UUID REFERENCES post_service_posts(id) ON DELETE CASCADE,
+    mime_type VARCHAR(100) NOT NULL,
+    size_bytes BIGINT NOT NULL CHECK (size_bytes > 0),
+    position INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Events waiting to be relayed to JetStream. They are written in the
+-- transaction of the change they describe and deleted once the stream
+-- acknowledges them; event_id lets the stream discard resent copies.
+CREATE TABLE IF NOT EXISTS post_service_outbox (
+    id BIGSERIAL PRIMARY KEY,
+    event_id UUID NOT NULL UNIQUE,
+    subject VARCHAR(255) NOT NULL,
+    payload BYTEA NOT NULL,
+    headers JSONB NOT NULL DEFAULT '{}',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- ========================================
+-- Indexes for Performance
+-- ========================================
+CREATE INDEX IF NOT EXISTS idx_posts_user_id ON post_service_posts(user_id);
+CREATE INDEX IF NOT EXISTS idx_posts_created_at ON post_service_posts(created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_posts_updated_at ON post_service_posts(updated_at DESC);
+CREATE INDEX IF NOT EXISTS idx_post_likes_post_id ON post_service_likes(post_id);
+CREATE INDEX IF NOT EXISTS idx_post_likes_user_id ON post_service_likes(user_id);
+CREATE INDEX IF NOT EXISTS idx_post_comments_post_id ON post_service_comments(post_id);
+CREATE INDEX IF NOT EXISTS idx_post_comments_user_id ON post_service_comments(user_id);
+
+-- Composite index for cursor-based pagination
+CREATE INDEX IF NOT EXISTS idx_posts_created_at_id ON post_service_posts(created_at DESC, id);
+
+-- Deleting a post clears quoted_post_id on its quotes
+CREATE INDEX IF NOT EXISTS idx_posts_quoted_post_id ON post_service_posts(quoted_post_id) WHERE quoted_post_id IS NOT NULL;
+
+-- Tag pages page by (created_at, post_id); trending scans a recent window
+CREATE INDEX IF NOT EXISTS idx_post_hashtags_tag_created_at ON post_service_post_hashtags(tag, created_at DESC, post_id DESC);
+CREATE INDEX IF NOT EXISTS idx_post_hashtags_created_at ON post_service_post_hashtags(created_at);
+CREATE INDEX IF NOT EXISTS idx_post_mentions_user_id ON post_service_post_mentions(user_id);
+CREATE INDEX IF NOT EXISTS idx_post_reposts_user_id ON post_service_reposts(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_post_media_post_id ON post_service_post_media(post_id, position);
+
+-- ========================================
+-- Triggers and Functions
+-- ========================================
+
+-- Function to automatically update updated_at timestamp
+CREATE OR REPLACE FUNCTION update_updated_at_column()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.updated_at = NOW();
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Trigger to auto-update updated_at on posts
+CREATE OR REPLACE TRIGGER trigger_update_posts_updated_at
+    BEFORE UPDATE ON post_service_posts
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- Trigger to auto-update updated_at on comments
+CREATE OR REPLACE TRIGGER trigger_update_post_comments_updated_at
+    BEFORE UPDATE ON post_service_comments
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- Function to increment likes_count
+CREATE OR REPLACE FUNCTION increment_post_likes_count()
+RETURNS TRIGGER AS $$
+BEGIN
+    UPDATE post_service_posts SET likes_count = likes_count + 1 WHERE id = NEW.post_id;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Function to decrement likes_count
+CREATE OR REPLACE FUNCTION decrement_post_likes_count()
+RETURNS TRIGGER AS $$
+BEGIN
+    UPDATE post_service_posts SET likes_count = likes_count - 1 WHERE id = OLD.post_id;
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Triggers for automatic likes_count management
+CREATE OR REPLACE TRIGGER trigger_increment_likes_count
+    AFTER INSERT ON post_service_likes
+    FOR EACH ROW
+    EXECUTE FUNCTION increment_post_likes_count();
+
+CREATE OR REPLACE TRIGGER trigger_decrement_likes_count
+    AFTER DELETE ON post_service_likes
+    FOR EACH ROW
+    EXECUTE FUNCTION decrement_post_likes_count();
+
+-- Function to increment comments_count
+CREATE OR REPLACE FUNCTION increment_post_comments_count()
+RETURNS TRIGGER AS $$
+BEGIN
+    UPDATE post_service_posts SET comments_count = comments_count + 1 WHERE id = NEW.post_id;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Function to decrement comments_count
+CREATE OR REPLACE FUNCTION decrement_post_comments_count()
+RETURNS TRIGGER AS $$
+BEGIN
+    UPDATE post_service_posts SET comments_count = comments_count - 1 WHERE id = OLD.post_id;
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Triggers for automatic comments_count management
+CREATE OR REPLACE TRIGGER trigger_increment_comments_count
+    AFTER INSERT ON post_service_comments
+    FOR EACH ROW
+    EXECUTE FUNCTION increment_post_comments_count();
+
+CREATE OR REPLACE TRIGGER trigger_decrement_comments_count
+    AFTER DELETE ON post_service_comments
+    FOR EACH ROW
+    EXECUTE FUNCTION decrement_post_comments_count();
+
+-- ========================================
+-- Example Queries (commented out)
+-- ========================================
+
+-- Get posts with like status for a specific user
+-- SELECT 
+--     p.*,
+--     CASE WHEN pl.user_id IS NOT NULL THEN true ELSE false END as is_liked
+-- FROM post_service_posts p
+-- LEFT JOIN post_service_likes pl ON p.id = pl.post_id AND pl.user_id = $1
+-- ORDER BY p.created_at DESC;
+
+-- Get posts with pagination
+-- SELECT * FROM post_service_posts
+-- WHERE created_at < $1  -- cursor timestamp
+-- ORDER BY created_at DESC, id DESC
+-- LIMIT $2;  -- page size
\ No newline at end of file
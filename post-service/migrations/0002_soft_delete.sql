@@ -0,0 +1,10 @@
+-- ========================================
+-- Soft Deletes
+-- ========================================
+-- Deleted posts keep their row as a tombstone until the purge job removes
+-- it, so quotes of them can show a "post deleted" placeholder. Reads skip
+-- rows with deleted_at set.
+ALTER TABLE post_service_posts ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITH TIME ZONE;
+
+-- The purge job scans tombstones by deletion time
+CREATE INDEX IF NOT EXISTS idx_posts_deleted_at ON post_service_posts(deleted_at) WHERE deleted_at IS NOT NULL;
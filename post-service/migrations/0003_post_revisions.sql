@@ -0,0 +1,18 @@
+-- ========================================
+-- Post Revisions
+-- ========================================
+-- The content a post had before each edit. editor_id is the user who made
+-- the edit and edited_at when it was made; the post's own edited_at is the
+-- time of its latest edit and stays NULL for posts never edited.
+ALTER TABLE post_service_posts ADD COLUMN IF NOT EXISTS edited_at TIMESTAMP WITH TIME ZONE;
+
+CREATE TABLE IF NOT EXISTS post_service_post_revisions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    post_id UUID NOT NULL REFERENCES post_service_posts(id) ON DELETE CASCADE,
+    editor_id UUID NOT NULL,
+    content TEXT NOT NULL,
+    edited_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Edit history pages newest first
+CREATE INDEX IF NOT EXISTS idx_post_revisions_post_edited_at ON post_service_post_revisions(post_id, edited_at DESC, id DESC);
@@ -0,0 +1,19 @@
+-- ========================================
+-- Drafts and Scheduled Posts
+-- ========================================
+-- Only PUBLISHED posts are read by anyone but their author. A SCHEDULED post
+-- is published by post-service once publish_at has passed; created_at is
+-- set to the time it is published.
+ALTER TABLE post_service_posts ADD COLUMN IF NOT EXISTS status VARCHAR(16) NOT NULL DEFAULT 'PUBLISHED';
+ALTER TABLE post_service_posts ADD COLUMN IF NOT EXISTS publish_at TIMESTAMP WITH TIME ZONE;
+
+ALTER TABLE post_service_posts DROP CONSTRAINT IF EXISTS posts_status_valid;
+ALTER TABLE post_service_posts ADD CONSTRAINT posts_status_valid CHECK (
+    status IN ('DRAFT', 'SCHEDULED', 'PUBLISHED')
+    AND (status = 'SCHEDULED') = (publish_at IS NOT NULL)
+);
+
+-- The publisher polls for scheduled posts that are due; authors list their
+-- unpublished posts
+CREATE INDEX IF NOT EXISTS idx_posts_publish_at ON post_service_posts(publish_at) WHERE status = 'SCHEDULED';
+CREATE INDEX IF NOT EXISTS idx_posts_unpublished ON post_service_posts(user_id, created_at DESC, id DESC) WHERE status <> 'PUBLISHED';
@@ -0,0 +1,11 @@
+-- ========================================
+-- Post Visibility
+-- ========================================
+-- Who may see a post: anyone, the author's approved followers, or only the
+-- author. Existing posts stay public.
+ALTER TABLE post_service_posts ADD COLUMN IF NOT EXISTS visibility VARCHAR(16) NOT NULL DEFAULT 'PUBLIC';
+
+ALTER TABLE post_service_posts DROP CONSTRAINT IF EXISTS posts_visibility_valid;
+ALTER TABLE post_service_posts ADD CONSTRAINT posts_visibility_valid CHECK (
+    visibility IN ('PUBLIC', 'FOLLOWERS_ONLY', 'PRIVATE')
+);
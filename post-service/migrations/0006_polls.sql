@@ -0,0 +1,27 @@
+-- ========================================
+-- Polls
+-- ========================================
+-- A post has at most one poll, made with the post. Each user votes once per
+-- poll, until it expires; votes_count is kept in step with the votes.
+CREATE TABLE IF NOT EXISTS post_service_polls (
+    post_id UUID PRIMARY KEY REFERENCES post_service_posts(id) ON DELETE CASCADE,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS post_service_poll_options (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    post_id UUID NOT NULL REFERENCES post_service_polls(post_id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    text VARCHAR(80) NOT NULL,
+    votes_count INTEGER NOT NULL DEFAULT 0,
+    UNIQUE (post_id, position)
+);
+
+CREATE TABLE IF NOT EXISTS post_service_poll_votes (
+    post_id UUID NOT NULL REFERENCES post_service_polls(post_id) ON DELETE CASCADE,
+    user_id UUID NOT NULL,
+    option_id UUID NOT NULL REFERENCES post_service_poll_options(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (post_id, user_id)
+);
@@ -0,0 +1,5 @@
+-- ========================================
+-- Shadow-hidden Posts
+-- ========================================
+-- Posts a content filter shadow-hides are shown only to their author
+ALTER TABLE post_service_posts ADD COLUMN IF NOT EXISTS shadow_hidden_at TIMESTAMP WITH TIME ZONE;
@@ -0,0 +1,12 @@
+-- ========================================
+-- Counter Events
+-- ========================================
+-- Like and comment events already applied to the counters of posts, so a
+-- redelivered event is counted once. Rows outlive the event stream's
+-- retention and are then pruned.
+CREATE TABLE IF NOT EXISTS post_service_counter_events (
+    event_id VARCHAR(255) PRIMARY KEY,
+    applied_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_post_counter_events_applied_at ON post_service_counter_events(applied_at);
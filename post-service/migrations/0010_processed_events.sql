@@ -0,0 +1,16 @@
+-- ========================================
+-- Processed Events
+-- ========================================
+-- Events this service's consumers handled, so an event delivered again is
+-- skipped (see shared/dedupe). A claim without processed_at is an event
+-- being handled, or one whose worker died and whose claim lapses. Rows
+-- outlive the event stream's retention and are then pruned.
+CREATE TABLE IF NOT EXISTS post_service_processed_events (
+    consumer VARCHAR(255) NOT NULL,
+    event_id VARCHAR(255) NOT NULL,
+    claimed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    processed_at TIMESTAMP WITH TIME ZONE,
+    PRIMARY KEY (consumer, event_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_post_processed_events_claimed_at ON post_service_processed_events(claimed_at);
@@ -6,19 +6,93 @@ import (
 	"github.com/google/uuid"
 )
 
+// PostStatus is the publication state of a post
+type PostStatus string
+
+const (
+	PostStatusDraft     PostStatus = "DRAFT"
+	PostStatusScheduled PostStatus = "SCHEDULED"
+	PostStatusPublished PostStatus = "PUBLISHED"
+)
+
+// PostVisibility is who may see a post besides its author
+type PostVisibility string
+
+const (
+	VisibilityPublic        PostVisibility = "PUBLIC"
+	VisibilityFollowersOnly PostVisibility = "FOLLOWERS_ONLY"
+	VisibilityPrivate       PostVisibility = "PRIVATE"
+)
+
 type Post struct {
-	ID            uuid.UUID `json:"id" db:"id"`
-	UserID        uuid.UUID `json:"user_id" db:"user_id"`
-	Content       string    `json:"content" db:"content"`
-	CreatedAt     time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt     time.Time `json:"updated_at" db:"updated_at"`
-	LikesCount    int32     `json:"likes_count" db:"likes_count"`
-	CommentsCount int32     `json:"comments_count" db:"comments_count"`
+	ID            uuid.UUID  `json:"id" db:"id"`
+	UserID        uuid.UUID  `json:"user_id" db:"user_id"`
+	Content       string     `json:"content" db:"content"`
+	CreatedAt     time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at" db:"updated_at"`
+	LikesCount    int32      `json:"likes_count" db:"likes_count"`
+	CommentsCount int32      `json:"comments_count" db:"comments_count"`
+	RepostsCount  int32      `json:"reposts_count" db:"reposts_count"`
+	QuotedPostID  *uuid.UUID `json:"quoted_post_id,omitempty" db:"quoted_post_id"`
+	// Status is PUBLISHED for every post anyone but the author can read
+	Status PostStatus `json:"status" db:"status"`
+	// PublishAt is when a SCHEDULED post will be published, nil otherwise
+	PublishAt *time.Time `json:"publish_at,omitempty" db:"publish_at"`
+	// Visibility is set when the post is created and never changes
+	Visibility PostVisibility `json:"visibility" db:"visibility"`
+	// EditedAt is the time of the latest edit, nil for posts never edited
+	EditedAt *time.Time `json:"edited_at,omitempty" db:"edited_at"`
+	// DeletedAt is set on tombstones of deleted posts, which are only read as
+	// "post deleted" placeholders and carry no content
+	DeletedAt *time.Time `json:"deleted_at,omitempty" db:"deleted_at"`
+	// ShadowHiddenAt is set on posts a content filter shadow-hid, which only
+	// their author sees and which are never announced
+	ShadowHiddenAt *time.Time `json:"shadow_hidden_at,omitempty" db:"shadow_hidden_at"`
+	Mentions       []Mention  `json:"mentions,omitempty" db:"-"`
+	Media          []Media    `json:"media,omitempty" db:"-"`
+	// QuotedPost is loaded on read and never cached with the quoting post, so
+	// edits and deletion of the quoted post show up right away
+	QuotedPost *Post `json:"-" db:"-"`
+}
+
+// PostCounters are the like and comment counters of a post
+type PostCounters struct {
+	PostID        uuid.UUID `db:"id"`
+	LikesCount    int32     `db:"likes_count"`
+	CommentsCount int32     `db:"comments_count"`
+}
+
+// Mention is a user mentioned in a post. Username is the user's name when the
+// post was written, which is what the content refers to.
+type Mention struct {
+	ID       uuid.UUID `json:"id" db:"id"`
+	PostID   uuid.UUID `json:"post_id" db:"post_id"`
+	UserID   uuid.UUID `json:"user_id" db:"user_id"`
+	Username string    `json:"username" db:"username"`
+}
+
+// Repost records that a user shared a post with their followers
+type Repost struct {
+	ID        uuid.UUID `json:"id" db:"id"`
+	PostID    uuid.UUID `json:"post_id" db:"post_id"`
+	UserID    uuid.UUID `json:"user_id" db:"user_id"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// Media is an uploaded file. PostID is nil until a post is created with it.
+type Media struct {
+	ID        uuid.UUID  `json:"id" db:"id"`
+	UserID    uuid.UUID  `json:"user_id" db:"user_id"`
+	PostID    *uuid.UUID `json:"post_id,omitempty" db:"post_id"`
+	MimeType  string     `json:"mime_type" db:"mime_type"`
+	SizeBytes int64      `json:"size_bytes" db:"size_bytes"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
 }
 
 type PostWithLikeStatus struct {
 	Post
-	IsLiked *bool `json:"is_liked,omitempty"`
+	IsLiked    *bool `json:"is_liked,omitempty"`
+	IsReposted *bool `json:"is_reposted,omitempty"`
 }
 
 type PostEdge struct {
@@ -38,3 +112,54 @@ type PostConnection struct {
 	PageInfo   PageInfo   `json:"page_info"`
 	TotalCount int32      `json:"total_count"`
 }
+
+// PostRevision is the content a post had before an edit
+type PostRevision struct {
+	ID       uuid.UUID `json:"id" db:"id"`
+	PostID   uuid.UUID `json:"post_id" db:"post_id"`
+	EditorID uuid.UUID `json:"editor_id" db:"editor_id"` // User who made the edit
+	Content  string    `json:"content" db:"content"`
+	EditedAt time.Time `json:"edited_at" db:"edited_at"`
+}
+
+type PostRevisionEdge struct {
+	Cursor string       `json:"cursor"`
+	Node   PostRevision `json:"node"`
+}
+
+type PostRevisionConnection struct {
+	Edges      []PostRevisionEdge `json:"edges"`
+	PageInfo   PageInfo           `json:"page_info"`
+	TotalCount int32              `json:"total_count"`
+}
+
+// Poll is the poll of a post. VotedOptionID is the option the requesting
+// user voted for, nil when they have not voted or are anonymous.
+type Poll struct {
+	PostID        uuid.UUID    `json:"post_id" db:"post_id"`
+	ExpiresAt     time.Time    `json:"expires_at" db:"expires_at"`
+	CreatedAt     time.Time    `json:"created_at" db:"created_at"`
+	Options       []PollOption `json:"options" db:"-"`
+	VotedOptionID *uuid.UUID   `json:"voted_option_id,omitempty" db:"-"`
+}
+
+type PollOption struct {
+	ID         uuid.UUID `json:"id" db:"id"`
+	PostID     uuid.UUID `json:"post_id" db:"post_id"`
+	Position   int32     `json:"position" db:"position"`
+	Text       string    `json:"text" db:"text"`
+	VotesCount int32     `json:"votes_count" db:"votes_count"`
+}
+
+// TrendingHashtag is a hashtag with the number of posts using it in a window
+type TrendingHashtag struct {
+	Tag        string `json:"tag" db:"tag"`
+	PostsCount int32  `json:"posts_count" db:"posts_count"`
+}
+
+// PollVote is a user's vote in a poll
+type PollVote struct {
+	PostID    uuid.UUID `json:"post_id" db:"post_id"`
+	OptionID  uuid.UUID `json:"option_id" db:"option_id"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
@@ -0,0 +1,68 @@
+// Package outbox publishes events exactly when the change they describe is
+// committed. Events are written to the outbox table in the transaction of
+// the change, and a Relay sends them to JetStream after the commit, trying
+// again until the stream acknowledges them. An event is never lost to a
+// failed publish, and never published for a change that was rolled back.
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/proto"
+
+	database "post-service/db"
+	"shared/eventschema"
+	"shared/logging"
+	"shared/tracing"
+)
+
+type Outbox struct {
+	db *database.DB
+}
+
+func New(db *database.DB) *Outbox {
+	return &Outbox{db: db}
+}
+
+// Add stores an event to be published on subject. Within DB.WithTx it is
+// part of the transaction; otherwise it is stored on its own. The request ID
+// and trace of ctx travel with the event.
+func (o *Outbox) Add(ctx context.Context, subject string, data []byte) error {
+	return o.add(ctx, uuid.NewString(), subject, data, map[string][]string{})
+}
+
+// AddEvent stores an event with a schema, like Add. The event ID of its
+// envelope is the one it is published under.
+func (o *Outbox) AddEvent(ctx context.Context, eventType string, payload proto.Message) error {
+	envelope, data, err := eventschema.Encode(eventType, payload)
+	if err != nil {
+		return err
+	}
+
+	header := map[string][]string{}
+	eventschema.SetHeaders(header, envelope)
+	return o.add(ctx, envelope.Id, eventType, data, header)
+}
+
+func (o *Outbox) add(ctx context.Context, eventID, subject string, data []byte, header map[string][]string) error {
+	logging.Inject(ctx, header)
+	span := tracing.StartPublish(ctx, subject, header)
+	defer span.End()
+
+	encoded, err := json.Marshal(header)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO post_service_outbox (event_id, subject, payload, headers)
+		VALUES ($1, $2, $3, $4)
+	`
+	if _, err := o.db.Conn(ctx).ExecContext(ctx, query, eventID, subject, data, string(encoded)); err != nil {
+		return fmt.Errorf("failed to store event in outbox: %w", err)
+	}
+	return nil
+}
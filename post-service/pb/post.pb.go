@@ -22,10 +22,122 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// Who may see a post besides its author. Followers-only posts are seen by
+// approved followers; private posts only by the author. Only public posts
+// appear on hashtag pages and in search, and only they can be reposted or
+// quoted.
+type PostVisibility int32
+
+const (
+	PostVisibility_POST_VISIBILITY_UNSPECIFIED    PostVisibility = 0
+	PostVisibility_POST_VISIBILITY_PUBLIC         PostVisibility = 1
+	PostVisibility_POST_VISIBILITY_FOLLOWERS_ONLY PostVisibility = 2
+	PostVisibility_POST_VISIBILITY_PRIVATE        PostVisibility = 3
+)
+
+// Enum value maps for PostVisibility.
+var (
+	PostVisibility_name = map[int32]string{
+		0: "POST_VISIBILITY_UNSPECIFIED",
+		1: "POST_VISIBILITY_PUBLIC",
+		2: "POST_VISIBILITY_FOLLOWERS_ONLY",
+		3: "POST_VISIBILITY_PRIVATE",
+	}
+	PostVisibility_value = map[string]int32{
+		"POST_VISIBILITY_UNSPECIFIED":    0,
+		"POST_VISIBILITY_PUBLIC":         1,
+		"POST_VISIBILITY_FOLLOWERS_ONLY": 2,
+		"POST_VISIBILITY_PRIVATE":        3,
+	}
+)
+
+func (x PostVisibility) Enum() *PostVisibility {
+	p := new(PostVisibility)
+	*p = x
+	return p
+}
+
+func (x PostVisibility) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PostVisibility) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_post_proto_enumTypes[0].Descriptor()
+}
+
+func (PostVisibility) Type() protoreflect.EnumType {
+	return &file_proto_post_proto_enumTypes[0]
+}
+
+func (x PostVisibility) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PostVisibility.Descriptor instead.
+func (PostVisibility) EnumDescriptor() ([]byte, []int) {
+	return file_proto_post_proto_rawDescGZIP(), []int{0}
+}
+
+type PostStatus int32
+
+const (
+	PostStatus_POST_STATUS_UNSPECIFIED PostStatus = 0
+	PostStatus_POST_STATUS_DRAFT       PostStatus = 1
+	PostStatus_POST_STATUS_SCHEDULED   PostStatus = 2
+	PostStatus_POST_STATUS_PUBLISHED   PostStatus = 3
+)
+
+// Enum value maps for PostStatus.
+var (
+	PostStatus_name = map[int32]string{
+		0: "POST_STATUS_UNSPECIFIED",
+		1: "POST_STATUS_DRAFT",
+		2: "POST_STATUS_SCHEDULED",
+		3: "POST_STATUS_PUBLISHED",
+	}
+	PostStatus_value = map[string]int32{
+		"POST_STATUS_UNSPECIFIED": 0,
+		"POST_STATUS_DRAFT":       1,
+		"POST_STATUS_SCHEDULED":   2,
+		"POST_STATUS_PUBLISHED":   3,
+	}
+)
+
+func (x PostStatus) Enum() *PostStatus {
+	p := new(PostStatus)
+	*p = x
+	return p
+}
+
+func (x PostStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PostStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_post_proto_enumTypes[1].Descriptor()
+}
+
+func (PostStatus) Type() protoreflect.EnumType {
+	return &file_proto_post_proto_enumTypes[1]
+}
+
+func (x PostStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PostStatus.Descriptor instead.
+func (PostStatus) EnumDescriptor() ([]byte, []int) {
+	return file_proto_post_proto_rawDescGZIP(), []int{1}
+}
+
 type CreatePostRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Content       string                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	Content       string                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"` // Optional when media_ids is set
+	MediaIds      []string               `protobuf:"bytes,3,rep,name=media_ids,json=mediaIds,proto3" json:"media_ids,omitempty"`
+	QuotedPostId  *string                `protobuf:"bytes,4,opt,name=quoted_post_id,json=quotedPostId,proto3,oneof" json:"quoted_post_id,omitempty"`
+	Visibility    PostVisibility         `protobuf:"varint,5,opt,name=visibility,proto3,enum=post.PostVisibility" json:"visibility,omitempty"` // PUBLIC when unspecified
+	Poll          *PollInput             `protobuf:"bytes,6,opt,name=poll,proto3" json:"poll,omitempty"`                                       // Optional
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -74,10 +186,39 @@ func (x *CreatePostRequest) GetContent() string {
 	return ""
 }
 
+func (x *CreatePostRequest) GetMediaIds() []string {
+	if x != nil {
+		return x.MediaIds
+	}
+	return nil
+}
+
+func (x *CreatePostRequest) GetQuotedPostId() string {
+	if x != nil && x.QuotedPostId != nil {
+		return *x.QuotedPostId
+	}
+	return ""
+}
+
+func (x *CreatePostRequest) GetVisibility() PostVisibility {
+	if x != nil {
+		return x.Visibility
+	}
+	return PostVisibility_POST_VISIBILITY_UNSPECIFIED
+}
+
+func (x *CreatePostRequest) GetPoll() *PollInput {
+	if x != nil {
+		return x.Poll
+	}
+	return nil
+}
+
 type GetPostRequest struct {
-	state            protoimpl.MessageState `protogen:"open.v1"`
-	PostId           string                 `protobuf:"bytes,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
-	RequestingUserId *string                `protobuf:"bytes,2,opt,name=requesting_user_id,json=requestingUserId,proto3,oneof" json:"requesting_user_id,omitempty"` // For isLiked field
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	PostId string                 `protobuf:"bytes,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
+	// Ignored: the viewer is the user of the request's token, if any
+	RequestingUserId *string `protobuf:"bytes,2,opt,name=requesting_user_id,json=requestingUserId,proto3,oneof" json:"requesting_user_id,omitempty"`
 	unknownFields    protoimpl.UnknownFields
 	sizeCache        protoimpl.SizeCache
 }
@@ -126,6 +267,103 @@ func (x *GetPostRequest) GetRequestingUserId() string {
 	return ""
 }
 
+type GetPostsByIdsRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	PostIds []string               `protobuf:"bytes,1,rep,name=post_ids,json=postIds,proto3" json:"post_ids,omitempty"`
+	// Ignored: the viewer is the user of the request's token, if any
+	RequestingUserId *string `protobuf:"bytes,2,opt,name=requesting_user_id,json=requestingUserId,proto3,oneof" json:"requesting_user_id,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *GetPostsByIdsRequest) Reset() {
+	*x = GetPostsByIdsRequest{}
+	mi := &file_proto_post_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPostsByIdsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPostsByIdsRequest) ProtoMessage() {}
+
+func (x *GetPostsByIdsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_post_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPostsByIdsRequest.ProtoReflect.Descriptor instead.
+func (*GetPostsByIdsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_post_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetPostsByIdsRequest) GetPostIds() []string {
+	if x != nil {
+		return x.PostIds
+	}
+	return nil
+}
+
+func (x *GetPostsByIdsRequest) GetRequestingUserId() string {
+	if x != nil && x.RequestingUserId != nil {
+		return *x.RequestingUserId
+	}
+	return ""
+}
+
+type GetPostsByIdsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Posts         []*Post                `protobuf:"bytes,1,rep,name=posts,proto3" json:"posts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPostsByIdsResponse) Reset() {
+	*x = GetPostsByIdsResponse{}
+	mi := &file_proto_post_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPostsByIdsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPostsByIdsResponse) ProtoMessage() {}
+
+func (x *GetPostsByIdsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_post_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPostsByIdsResponse.ProtoReflect.Descriptor instead.
+func (*GetPostsByIdsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_post_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetPostsByIdsResponse) GetPosts() []*Post {
+	if x != nil {
+		return x.Posts
+	}
+	return nil
+}
+
 type UpdatePostRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	PostId        string                 `protobuf:"bytes,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
@@ -137,7 +375,7 @@ type UpdatePostRequest struct {
 
 func (x *UpdatePostRequest) Reset() {
 	*x = UpdatePostRequest{}
-	mi := &file_proto_post_proto_msgTypes[2]
+	mi := &file_proto_post_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -149,7 +387,7 @@ func (x *UpdatePostRequest) String() string {
 func (*UpdatePostRequest) ProtoMessage() {}
 
 func (x *UpdatePostRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_post_proto_msgTypes[2]
+	mi := &file_proto_post_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -162,7 +400,7 @@ func (x *UpdatePostRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdatePostRequest.ProtoReflect.Descriptor instead.
 func (*UpdatePostRequest) Descriptor() ([]byte, []int) {
-	return file_proto_post_proto_rawDescGZIP(), []int{2}
+	return file_proto_post_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *UpdatePostRequest) GetPostId() string {
@@ -196,7 +434,7 @@ type DeletePostRequest struct {
 
 func (x *DeletePostRequest) Reset() {
 	*x = DeletePostRequest{}
-	mi := &file_proto_post_proto_msgTypes[3]
+	mi := &file_proto_post_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -208,7 +446,7 @@ func (x *DeletePostRequest) String() string {
 func (*DeletePostRequest) ProtoMessage() {}
 
 func (x *DeletePostRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_post_proto_msgTypes[3]
+	mi := &file_proto_post_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -221,7 +459,7 @@ func (x *DeletePostRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeletePostRequest.ProtoReflect.Descriptor instead.
 func (*DeletePostRequest) Descriptor() ([]byte, []int) {
-	return file_proto_post_proto_rawDescGZIP(), []int{3}
+	return file_proto_post_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *DeletePostRequest) GetPostId() string {
@@ -239,18 +477,19 @@ func (x *DeletePostRequest) GetUserId() string {
 }
 
 type GetUserPostsRequest struct {
-	state            protoimpl.MessageState `protogen:"open.v1"`
-	UserId           string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	First            int32                  `protobuf:"varint,2,opt,name=first,proto3" json:"first,omitempty"`
-	After            *string                `protobuf:"bytes,3,opt,name=after,proto3,oneof" json:"after,omitempty"`
-	RequestingUserId *string                `protobuf:"bytes,4,opt,name=requesting_user_id,json=requestingUserId,proto3,oneof" json:"requesting_user_id,omitempty"`
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	First  int32                  `protobuf:"varint,2,opt,name=first,proto3" json:"first,omitempty"`
+	After  *string                `protobuf:"bytes,3,opt,name=after,proto3,oneof" json:"after,omitempty"`
+	// Ignored: the viewer is the user of the request's token, if any
+	RequestingUserId *string `protobuf:"bytes,4,opt,name=requesting_user_id,json=requestingUserId,proto3,oneof" json:"requesting_user_id,omitempty"`
 	unknownFields    protoimpl.UnknownFields
 	sizeCache        protoimpl.SizeCache
 }
 
 func (x *GetUserPostsRequest) Reset() {
 	*x = GetUserPostsRequest{}
-	mi := &file_proto_post_proto_msgTypes[4]
+	mi := &file_proto_post_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -262,7 +501,7 @@ func (x *GetUserPostsRequest) String() string {
 func (*GetUserPostsRequest) ProtoMessage() {}
 
 func (x *GetUserPostsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_post_proto_msgTypes[4]
+	mi := &file_proto_post_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -275,7 +514,7 @@ func (x *GetUserPostsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetUserPostsRequest.ProtoReflect.Descriptor instead.
 func (*GetUserPostsRequest) Descriptor() ([]byte, []int) {
-	return file_proto_post_proto_rawDescGZIP(), []int{4}
+	return file_proto_post_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *GetUserPostsRequest) GetUserId() string {
@@ -306,28 +545,29 @@ func (x *GetUserPostsRequest) GetRequestingUserId() string {
 	return ""
 }
 
-type IncrementCommentsCountRequest struct {
+type ReplayPostEventsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	PostId        string                 `protobuf:"bytes,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
+	Since         *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=since,proto3" json:"since,omitempty"`
+	Until         *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=until,proto3,oneof" json:"until,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *IncrementCommentsCountRequest) Reset() {
-	*x = IncrementCommentsCountRequest{}
-	mi := &file_proto_post_proto_msgTypes[5]
+func (x *ReplayPostEventsRequest) Reset() {
+	*x = ReplayPostEventsRequest{}
+	mi := &file_proto_post_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *IncrementCommentsCountRequest) String() string {
+func (x *ReplayPostEventsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*IncrementCommentsCountRequest) ProtoMessage() {}
+func (*ReplayPostEventsRequest) ProtoMessage() {}
 
-func (x *IncrementCommentsCountRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_post_proto_msgTypes[5]
+func (x *ReplayPostEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_post_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -338,40 +578,47 @@ func (x *IncrementCommentsCountRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use IncrementCommentsCountRequest.ProtoReflect.Descriptor instead.
-func (*IncrementCommentsCountRequest) Descriptor() ([]byte, []int) {
-	return file_proto_post_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use ReplayPostEventsRequest.ProtoReflect.Descriptor instead.
+func (*ReplayPostEventsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_post_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *IncrementCommentsCountRequest) GetPostId() string {
+func (x *ReplayPostEventsRequest) GetSince() *timestamppb.Timestamp {
 	if x != nil {
-		return x.PostId
+		return x.Since
 	}
-	return ""
+	return nil
+}
+
+func (x *ReplayPostEventsRequest) GetUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Until
+	}
+	return nil
 }
 
-type DecrementCommentsCountRequest struct {
+type ReplayPostEventsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	PostId        string                 `protobuf:"bytes,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
+	Replayed      int32                  `protobuf:"varint,1,opt,name=replayed,proto3" json:"replayed,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DecrementCommentsCountRequest) Reset() {
-	*x = DecrementCommentsCountRequest{}
-	mi := &file_proto_post_proto_msgTypes[6]
+func (x *ReplayPostEventsResponse) Reset() {
+	*x = ReplayPostEventsResponse{}
+	mi := &file_proto_post_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DecrementCommentsCountRequest) String() string {
+func (x *ReplayPostEventsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DecrementCommentsCountRequest) ProtoMessage() {}
+func (*ReplayPostEventsResponse) ProtoMessage() {}
 
-func (x *DecrementCommentsCountRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_post_proto_msgTypes[6]
+func (x *ReplayPostEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_post_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -382,40 +629,42 @@ func (x *DecrementCommentsCountRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DecrementCommentsCountRequest.ProtoReflect.Descriptor instead.
-func (*DecrementCommentsCountRequest) Descriptor() ([]byte, []int) {
-	return file_proto_post_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use ReplayPostEventsResponse.ProtoReflect.Descriptor instead.
+func (*ReplayPostEventsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_post_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *DecrementCommentsCountRequest) GetPostId() string {
+func (x *ReplayPostEventsResponse) GetReplayed() int32 {
 	if x != nil {
-		return x.PostId
+		return x.Replayed
 	}
-	return ""
+	return 0
 }
 
-type IncrementLikesCountRequest struct {
+type SetPostCountersRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	PostId        string                 `protobuf:"bytes,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
+	LikesCount    int32                  `protobuf:"varint,2,opt,name=likes_count,json=likesCount,proto3" json:"likes_count,omitempty"`
+	CommentsCount int32                  `protobuf:"varint,3,opt,name=comments_count,json=commentsCount,proto3" json:"comments_count,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *IncrementLikesCountRequest) Reset() {
-	*x = IncrementLikesCountRequest{}
-	mi := &file_proto_post_proto_msgTypes[7]
+func (x *SetPostCountersRequest) Reset() {
+	*x = SetPostCountersRequest{}
+	mi := &file_proto_post_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *IncrementLikesCountRequest) String() string {
+func (x *SetPostCountersRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*IncrementLikesCountRequest) ProtoMessage() {}
+func (*SetPostCountersRequest) ProtoMessage() {}
 
-func (x *IncrementLikesCountRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_post_proto_msgTypes[7]
+func (x *SetPostCountersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_post_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -426,40 +675,54 @@ func (x *IncrementLikesCountRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use IncrementLikesCountRequest.ProtoReflect.Descriptor instead.
-func (*IncrementLikesCountRequest) Descriptor() ([]byte, []int) {
-	return file_proto_post_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use SetPostCountersRequest.ProtoReflect.Descriptor instead.
+func (*SetPostCountersRequest) Descriptor() ([]byte, []int) {
+	return file_proto_post_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *IncrementLikesCountRequest) GetPostId() string {
+func (x *SetPostCountersRequest) GetPostId() string {
 	if x != nil {
 		return x.PostId
 	}
 	return ""
 }
 
-type DecrementLikesCountRequest struct {
+func (x *SetPostCountersRequest) GetLikesCount() int32 {
+	if x != nil {
+		return x.LikesCount
+	}
+	return 0
+}
+
+func (x *SetPostCountersRequest) GetCommentsCount() int32 {
+	if x != nil {
+		return x.CommentsCount
+	}
+	return 0
+}
+
+type RecountPostRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	PostId        string                 `protobuf:"bytes,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DecrementLikesCountRequest) Reset() {
-	*x = DecrementLikesCountRequest{}
-	mi := &file_proto_post_proto_msgTypes[8]
+func (x *RecountPostRequest) Reset() {
+	*x = RecountPostRequest{}
+	mi := &file_proto_post_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DecrementLikesCountRequest) String() string {
+func (x *RecountPostRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DecrementLikesCountRequest) ProtoMessage() {}
+func (*RecountPostRequest) ProtoMessage() {}
 
-func (x *DecrementLikesCountRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_post_proto_msgTypes[8]
+func (x *RecountPostRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_post_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -470,47 +733,42 @@ func (x *DecrementLikesCountRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DecrementLikesCountRequest.ProtoReflect.Descriptor instead.
-func (*DecrementLikesCountRequest) Descriptor() ([]byte, []int) {
-	return file_proto_post_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use RecountPostRequest.ProtoReflect.Descriptor instead.
+func (*RecountPostRequest) Descriptor() ([]byte, []int) {
+	return file_proto_post_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *DecrementLikesCountRequest) GetPostId() string {
+func (x *RecountPostRequest) GetPostId() string {
 	if x != nil {
 		return x.PostId
 	}
 	return ""
 }
 
-type Post struct {
+type PostCounters struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Content       string                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	LikesCount    int32                  `protobuf:"varint,6,opt,name=likes_count,json=likesCount,proto3" json:"likes_count,omitempty"`
-	CommentsCount int32                  `protobuf:"varint,7,opt,name=comments_count,json=commentsCount,proto3" json:"comments_count,omitempty"`
-	IsLiked       *bool                  `protobuf:"varint,8,opt,name=is_liked,json=isLiked,proto3,oneof" json:"is_liked,omitempty"` // Only set if requesting_user_id provided
+	PostId        string                 `protobuf:"bytes,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
+	LikesCount    int32                  `protobuf:"varint,2,opt,name=likes_count,json=likesCount,proto3" json:"likes_count,omitempty"`
+	CommentsCount int32                  `protobuf:"varint,3,opt,name=comments_count,json=commentsCount,proto3" json:"comments_count,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Post) Reset() {
-	*x = Post{}
-	mi := &file_proto_post_proto_msgTypes[9]
+func (x *PostCounters) Reset() {
+	*x = PostCounters{}
+	mi := &file_proto_post_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Post) String() string {
+func (x *PostCounters) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Post) ProtoMessage() {}
+func (*PostCounters) ProtoMessage() {}
 
-func (x *Post) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_post_proto_msgTypes[9]
+func (x *PostCounters) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_post_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -521,54 +779,955 @@ func (x *Post) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Post.ProtoReflect.Descriptor instead.
-func (*Post) Descriptor() ([]byte, []int) {
-	return file_proto_post_proto_rawDescGZIP(), []int{9}
-}
-
-func (x *Post) GetId() string {
-	if x != nil {
-		return x.Id
-	}
-	return ""
-}
-
-func (x *Post) GetUserId() string {
-	if x != nil {
-		return x.UserId
-	}
-	return ""
+// Deprecated: Use PostCounters.ProtoReflect.Descriptor instead.
+func (*PostCounters) Descriptor() ([]byte, []int) {
+	return file_proto_post_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *Post) GetContent() string {
+func (x *PostCounters) GetPostId() string {
 	if x != nil {
-		return x.Content
+		return x.PostId
 	}
 	return ""
 }
 
-func (x *Post) GetCreatedAt() *timestamppb.Timestamp {
-	if x != nil {
-		return x.CreatedAt
-	}
-	return nil
-}
-
-func (x *Post) GetUpdatedAt() *timestamppb.Timestamp {
-	if x != nil {
-		return x.UpdatedAt
-	}
-	return nil
-}
-
-func (x *Post) GetLikesCount() int32 {
+func (x *PostCounters) GetLikesCount() int32 {
 	if x != nil {
 		return x.LikesCount
 	}
 	return 0
 }
 
-func (x *Post) GetCommentsCount() int32 {
+func (x *PostCounters) GetCommentsCount() int32 {
+	if x != nil {
+		return x.CommentsCount
+	}
+	return 0
+}
+
+type ReconcilePostCountersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReconcilePostCountersRequest) Reset() {
+	*x = ReconcilePostCountersRequest{}
+	mi := &file_proto_post_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReconcilePostCountersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReconcilePostCountersRequest) ProtoMessage() {}
+
+func (x *ReconcilePostCountersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_post_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReconcilePostCountersRequest.ProtoReflect.Descriptor instead.
+func (*ReconcilePostCountersRequest) Descriptor() ([]byte, []int) {
+	return file_proto_post_proto_rawDescGZIP(), []int{12}
+}
+
+type ReconcilePostCountersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Checked       int32                  `protobuf:"varint,1,opt,name=checked,proto3" json:"checked,omitempty"`
+	Corrected     int32                  `protobuf:"varint,2,opt,name=corrected,proto3" json:"corrected,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReconcilePostCountersResponse) Reset() {
+	*x = ReconcilePostCountersResponse{}
+	mi := &file_proto_post_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReconcilePostCountersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReconcilePostCountersResponse) ProtoMessage() {}
+
+func (x *ReconcilePostCountersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_post_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReconcilePostCountersResponse.ProtoReflect.Descriptor instead.
+func (*ReconcilePostCountersResponse) Descriptor() ([]byte, []int) {
+	return file_proto_post_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ReconcilePostCountersResponse) GetChecked() int32 {
+	if x != nil {
+		return x.Checked
+	}
+	return 0
+}
+
+func (x *ReconcilePostCountersResponse) GetCorrected() int32 {
+	if x != nil {
+		return x.Corrected
+	}
+	return 0
+}
+
+type ImportedPost struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Content       string                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportedPost) Reset() {
+	*x = ImportedPost{}
+	mi := &file_proto_post_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportedPost) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportedPost) ProtoMessage() {}
+
+func (x *ImportedPost) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_post_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportedPost.ProtoReflect.Descriptor instead.
+func (*ImportedPost) Descriptor() ([]byte, []int) {
+	return file_proto_post_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ImportedPost) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ImportedPost) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ImportedPost) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *ImportedPost) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type ImportPostsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Posts         []*ImportedPost        `protobuf:"bytes,1,rep,name=posts,proto3" json:"posts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportPostsRequest) Reset() {
+	*x = ImportPostsRequest{}
+	mi := &file_proto_post_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportPostsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportPostsRequest) ProtoMessage() {}
+
+func (x *ImportPostsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_post_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportPostsRequest.ProtoReflect.Descriptor instead.
+func (*ImportPostsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_post_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ImportPostsRequest) GetPosts() []*ImportedPost {
+	if x != nil {
+		return x.Posts
+	}
+	return nil
+}
+
+type ImportPostsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Created       int32                  `protobuf:"varint,1,opt,name=created,proto3" json:"created,omitempty"`
+	Skipped       int32                  `protobuf:"varint,2,opt,name=skipped,proto3" json:"skipped,omitempty"` // Posts whose id already exists
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportPostsResponse) Reset() {
+	*x = ImportPostsResponse{}
+	mi := &file_proto_post_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportPostsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportPostsResponse) ProtoMessage() {}
+
+func (x *ImportPostsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_post_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportPostsResponse.ProtoReflect.Descriptor instead.
+func (*ImportPostsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_post_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ImportPostsResponse) GetCreated() int32 {
+	if x != nil {
+		return x.Created
+	}
+	return 0
+}
+
+func (x *ImportPostsResponse) GetSkipped() int32 {
+	if x != nil {
+		return x.Skipped
+	}
+	return 0
+}
+
+type PurgeDeletedPostsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeletedBefore *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=deleted_before,json=deletedBefore,proto3" json:"deleted_before,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PurgeDeletedPostsRequest) Reset() {
+	*x = PurgeDeletedPostsRequest{}
+	mi := &file_proto_post_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurgeDeletedPostsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgeDeletedPostsRequest) ProtoMessage() {}
+
+func (x *PurgeDeletedPostsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_post_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgeDeletedPostsRequest.ProtoReflect.Descriptor instead.
+func (*PurgeDeletedPostsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_post_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *PurgeDeletedPostsRequest) GetDeletedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeletedBefore
+	}
+	return nil
+}
+
+type PurgeDeletedPostsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Purged        int64                  `protobuf:"varint,1,opt,name=purged,proto3" json:"purged,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PurgeDeletedPostsResponse) Reset() {
+	*x = PurgeDeletedPostsResponse{}
+	mi := &file_proto_post_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurgeDeletedPostsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgeDeletedPostsResponse) ProtoMessage() {}
+
+func (x *PurgeDeletedPostsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_post_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgeDeletedPostsResponse.ProtoReflect.Descriptor instead.
+func (*PurgeDeletedPostsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_post_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *PurgeDeletedPostsResponse) GetPurged() int64 {
+	if x != nil {
+		return x.Purged
+	}
+	return 0
+}
+
+type RemovePostRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PostId        string                 `protobuf:"bytes,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemovePostRequest) Reset() {
+	*x = RemovePostRequest{}
+	mi := &file_proto_post_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemovePostRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemovePostRequest) ProtoMessage() {}
+
+func (x *RemovePostRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_post_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemovePostRequest.ProtoReflect.Descriptor instead.
+func (*RemovePostRequest) Descriptor() ([]byte, []int) {
+	return file_proto_post_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *RemovePostRequest) GetPostId() string {
+	if x != nil {
+		return x.PostId
+	}
+	return ""
+}
+
+type ListPublicPostsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	AfterId       *string                `protobuf:"bytes,2,opt,name=after_id,json=afterId,proto3,oneof" json:"after_id,omitempty"` // Keyset cursor: last post id of the previous page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPublicPostsRequest) Reset() {
+	*x = ListPublicPostsRequest{}
+	mi := &file_proto_post_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPublicPostsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPublicPostsRequest) ProtoMessage() {}
+
+func (x *ListPublicPostsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_post_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPublicPostsRequest.ProtoReflect.Descriptor instead.
+func (*ListPublicPostsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_post_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ListPublicPostsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListPublicPostsRequest) GetAfterId() string {
+	if x != nil && x.AfterId != nil {
+		return *x.AfterId
+	}
+	return ""
+}
+
+type PublicPost struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PublicPost) Reset() {
+	*x = PublicPost{}
+	mi := &file_proto_post_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PublicPost) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublicPost) ProtoMessage() {}
+
+func (x *PublicPost) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_post_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublicPost.ProtoReflect.Descriptor instead.
+func (*PublicPost) Descriptor() ([]byte, []int) {
+	return file_proto_post_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *PublicPost) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PublicPost) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *PublicPost) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type ListPublicPostsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Posts         []*PublicPost          `protobuf:"bytes,1,rep,name=posts,proto3" json:"posts,omitempty"`
+	NextAfterId   *string                `protobuf:"bytes,2,opt,name=next_after_id,json=nextAfterId,proto3,oneof" json:"next_after_id,omitempty"` // Unset when there are no more posts
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPublicPostsResponse) Reset() {
+	*x = ListPublicPostsResponse{}
+	mi := &file_proto_post_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPublicPostsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPublicPostsResponse) ProtoMessage() {}
+
+func (x *ListPublicPostsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_post_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPublicPostsResponse.ProtoReflect.Descriptor instead.
+func (*ListPublicPostsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_post_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ListPublicPostsResponse) GetPosts() []*PublicPost {
+	if x != nil {
+		return x.Posts
+	}
+	return nil
+}
+
+func (x *ListPublicPostsResponse) GetNextAfterId() string {
+	if x != nil && x.NextAfterId != nil {
+		return *x.NextAfterId
+	}
+	return ""
+}
+
+type GetPostsByHashtagRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tag           string                 `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"` // With or without the leading '#'; matched case-insensitively
+	First         int32                  `protobuf:"varint,2,opt,name=first,proto3" json:"first,omitempty"`
+	After         *string                `protobuf:"bytes,3,opt,name=after,proto3,oneof" json:"after,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPostsByHashtagRequest) Reset() {
+	*x = GetPostsByHashtagRequest{}
+	mi := &file_proto_post_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPostsByHashtagRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPostsByHashtagRequest) ProtoMessage() {}
+
+func (x *GetPostsByHashtagRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_post_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPostsByHashtagRequest.ProtoReflect.Descriptor instead.
+func (*GetPostsByHashtagRequest) Descriptor() ([]byte, []int) {
+	return file_proto_post_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *GetPostsByHashtagRequest) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *GetPostsByHashtagRequest) GetFirst() int32 {
+	if x != nil {
+		return x.First
+	}
+	return 0
+}
+
+func (x *GetPostsByHashtagRequest) GetAfter() string {
+	if x != nil && x.After != nil {
+		return *x.After
+	}
+	return ""
+}
+
+type GetPostRevisionsRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	PostId string                 `protobuf:"bytes,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
+	First  int32                  `protobuf:"varint,2,opt,name=first,proto3" json:"first,omitempty"`
+	After  *string                `protobuf:"bytes,3,opt,name=after,proto3,oneof" json:"after,omitempty"`
+	// Ignored: the viewer is the user of the request's token, if any
+	RequestingUserId *string `protobuf:"bytes,4,opt,name=requesting_user_id,json=requestingUserId,proto3,oneof" json:"requesting_user_id,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *GetPostRevisionsRequest) Reset() {
+	*x = GetPostRevisionsRequest{}
+	mi := &file_proto_post_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPostRevisionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPostRevisionsRequest) ProtoMessage() {}
+
+func (x *GetPostRevisionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_post_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPostRevisionsRequest.ProtoReflect.Descriptor instead.
+func (*GetPostRevisionsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_post_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GetPostRevisionsRequest) GetPostId() string {
+	if x != nil {
+		return x.PostId
+	}
+	return ""
+}
+
+func (x *GetPostRevisionsRequest) GetFirst() int32 {
+	if x != nil {
+		return x.First
+	}
+	return 0
+}
+
+func (x *GetPostRevisionsRequest) GetAfter() string {
+	if x != nil && x.After != nil {
+		return *x.After
+	}
+	return ""
+}
+
+func (x *GetPostRevisionsRequest) GetRequestingUserId() string {
+	if x != nil && x.RequestingUserId != nil {
+		return *x.RequestingUserId
+	}
+	return ""
+}
+
+type GetTrendingHashtagsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	WindowHours   int32                  `protobuf:"varint,2,opt,name=window_hours,json=windowHours,proto3" json:"window_hours,omitempty"` // Defaults to 24
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTrendingHashtagsRequest) Reset() {
+	*x = GetTrendingHashtagsRequest{}
+	mi := &file_proto_post_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTrendingHashtagsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTrendingHashtagsRequest) ProtoMessage() {}
+
+func (x *GetTrendingHashtagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_post_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTrendingHashtagsRequest.ProtoReflect.Descriptor instead.
+func (*GetTrendingHashtagsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_post_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *GetTrendingHashtagsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetTrendingHashtagsRequest) GetWindowHours() int32 {
+	if x != nil {
+		return x.WindowHours
+	}
+	return 0
+}
+
+type TrendingHashtag struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tag           string                 `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"`
+	PostsCount    int32                  `protobuf:"varint,2,opt,name=posts_count,json=postsCount,proto3" json:"posts_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TrendingHashtag) Reset() {
+	*x = TrendingHashtag{}
+	mi := &file_proto_post_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TrendingHashtag) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrendingHashtag) ProtoMessage() {}
+
+func (x *TrendingHashtag) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_post_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrendingHashtag.ProtoReflect.Descriptor instead.
+func (*TrendingHashtag) Descriptor() ([]byte, []int) {
+	return file_proto_post_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *TrendingHashtag) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *TrendingHashtag) GetPostsCount() int32 {
+	if x != nil {
+		return x.PostsCount
+	}
+	return 0
+}
+
+type GetTrendingHashtagsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hashtags      []*TrendingHashtag     `protobuf:"bytes,1,rep,name=hashtags,proto3" json:"hashtags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTrendingHashtagsResponse) Reset() {
+	*x = GetTrendingHashtagsResponse{}
+	mi := &file_proto_post_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTrendingHashtagsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTrendingHashtagsResponse) ProtoMessage() {}
+
+func (x *GetTrendingHashtagsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_post_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTrendingHashtagsResponse.ProtoReflect.Descriptor instead.
+func (*GetTrendingHashtagsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_post_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GetTrendingHashtagsResponse) GetHashtags() []*TrendingHashtag {
+	if x != nil {
+		return x.Hashtags
+	}
+	return nil
+}
+
+type Post struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Content       string                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	LikesCount    int32                  `protobuf:"varint,6,opt,name=likes_count,json=likesCount,proto3" json:"likes_count,omitempty"`
+	CommentsCount int32                  `protobuf:"varint,7,opt,name=comments_count,json=commentsCount,proto3" json:"comments_count,omitempty"`
+	IsLiked       *bool                  `protobuf:"varint,8,opt,name=is_liked,json=isLiked,proto3,oneof" json:"is_liked,omitempty"`
+	Mentions      []*Mention             `protobuf:"bytes,9,rep,name=mentions,proto3" json:"mentions,omitempty"`
+	Media         []*Media               `protobuf:"bytes,10,rep,name=media,proto3" json:"media,omitempty"`
+	RepostsCount  int32                  `protobuf:"varint,11,opt,name=reposts_count,json=repostsCount,proto3" json:"reposts_count,omitempty"`
+	IsReposted    *bool                  `protobuf:"varint,12,opt,name=is_reposted,json=isReposted,proto3,oneof" json:"is_reposted,omitempty"` // Set for requests made with a user's token
+	QuotedPostId  *string                `protobuf:"bytes,13,opt,name=quoted_post_id,json=quotedPostId,proto3,oneof" json:"quoted_post_id,omitempty"`
+	QuotedPost    *Post                  `protobuf:"bytes,14,opt,name=quoted_post,json=quotedPost,proto3" json:"quoted_post,omitempty"` // A "post deleted" placeholder when the quoted post has been deleted; unset once it is purged
+	DeletedAt     *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`    // Set only on "post deleted" placeholders, which carry no content
+	EditedAt      *timestamppb.Timestamp `protobuf:"bytes,16,opt,name=edited_at,json=editedAt,proto3" json:"edited_at,omitempty"`       // Time of the latest edit; unset for posts never edited
+	Status        PostStatus             `protobuf:"varint,17,opt,name=status,proto3,enum=post.PostStatus" json:"status,omitempty"`
+	PublishAt     *timestamppb.Timestamp `protobuf:"bytes,18,opt,name=publish_at,json=publishAt,proto3" json:"publish_at,omitempty"` // Set only on scheduled posts
+	Visibility    PostVisibility         `protobuf:"varint,19,opt,name=visibility,proto3,enum=post.PostVisibility" json:"visibility,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Post) Reset() {
+	*x = Post{}
+	mi := &file_proto_post_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Post) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Post) ProtoMessage() {}
+
+func (x *Post) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_post_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Post.ProtoReflect.Descriptor instead.
+func (*Post) Descriptor() ([]byte, []int) {
+	return file_proto_post_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *Post) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Post) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Post) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *Post) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Post) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Post) GetLikesCount() int32 {
+	if x != nil {
+		return x.LikesCount
+	}
+	return 0
+}
+
+func (x *Post) GetCommentsCount() int32 {
 	if x != nil {
 		return x.CommentsCount
 	}
@@ -579,7 +1738,1119 @@ func (x *Post) GetIsLiked() bool {
 	if x != nil && x.IsLiked != nil {
 		return *x.IsLiked
 	}
-	return false
+	return false
+}
+
+func (x *Post) GetMentions() []*Mention {
+	if x != nil {
+		return x.Mentions
+	}
+	return nil
+}
+
+func (x *Post) GetMedia() []*Media {
+	if x != nil {
+		return x.Media
+	}
+	return nil
+}
+
+func (x *Post) GetRepostsCount() int32 {
+	if x != nil {
+		return x.RepostsCount
+	}
+	return 0
+}
+
+func (x *Post) GetIsReposted() bool {
+	if x != nil && x.IsReposted != nil {
+		return *x.IsReposted
+	}
+	return false
+}
+
+func (x *Post) GetQuotedPostId() string {
+	if x != nil && x.QuotedPostId != nil {
+		return *x.QuotedPostId
+	}
+	return ""
+}
+
+func (x *Post) GetQuotedPost() *Post {
+	if x != nil {
+		return x.QuotedPost
+	}
+	return nil
+}
+
+func (x *Post) GetDeletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return nil
+}
+
+func (x *Post) GetEditedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EditedAt
+	}
+	return nil
+}
+
+func (x *Post) GetStatus() PostStatus {
+	if x != nil {
+		return x.Status
+	}
+	return PostStatus_POST_STATUS_UNSPECIFIED
+}
+
+func (x *Post) GetPublishAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PublishAt
+	}
+	return nil
+}
+
+func (x *Post) GetVisibility() PostVisibility {
+	if x != nil {
+		return x.Visibility
+	}
+	return PostVisibility_POST_VISIBILITY_UNSPECIFIED
+}
+
+// Creates a draft, or replaces the content of the caller's draft or
+// scheduled post given by post_id. Media and quotes can only be set when the
+// draft is created.
+type SaveDraftRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PostId        *string                `protobuf:"bytes,1,opt,name=post_id,json=postId,proto3,oneof" json:"post_id,omitempty"`
+	Content       string                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	MediaIds      []string               `protobuf:"bytes,3,rep,name=media_ids,json=mediaIds,proto3" json:"media_ids,omitempty"`
+	QuotedPostId  *string                `protobuf:"bytes,4,opt,name=quoted_post_id,json=quotedPostId,proto3,oneof" json:"quoted_post_id,omitempty"`
+	Visibility    PostVisibility         `protobuf:"varint,5,opt,name=visibility,proto3,enum=post.PostVisibility" json:"visibility,omitempty"` // Set when the draft is created; PUBLIC when unspecified
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SaveDraftRequest) Reset() {
+	*x = SaveDraftRequest{}
+	mi := &file_proto_post_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SaveDraftRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SaveDraftRequest) ProtoMessage() {}
+
+func (x *SaveDraftRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_post_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SaveDraftRequest.ProtoReflect.Descriptor instead.
+func (*SaveDraftRequest) Descriptor() ([]byte, []int) {
+	return file_proto_post_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *SaveDraftRequest) GetPostId() string {
+	if x != nil && x.PostId != nil {
+		return *x.PostId
+	}
+	return ""
+}
+
+func (x *SaveDraftRequest) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *SaveDraftRequest) GetMediaIds() []string {
+	if x != nil {
+		return x.MediaIds
+	}
+	return nil
+}
+
+func (x *SaveDraftRequest) GetQuotedPostId() string {
+	if x != nil && x.QuotedPostId != nil {
+		return *x.QuotedPostId
+	}
+	return ""
+}
+
+func (x *SaveDraftRequest) GetVisibility() PostVisibility {
+	if x != nil {
+		return x.Visibility
+	}
+	return PostVisibility_POST_VISIBILITY_UNSPECIFIED
+}
+
+// Lists the caller's drafts and scheduled posts, newest first
+type ListDraftsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	First         int32                  `protobuf:"varint,1,opt,name=first,proto3" json:"first,omitempty"`
+	After         *string                `protobuf:"bytes,2,opt,name=after,proto3,oneof" json:"after,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDraftsRequest) Reset() {
+	*x = ListDraftsRequest{}
+	mi := &file_proto_post_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDraftsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDraftsRequest) ProtoMessage() {}
+
+func (x *ListDraftsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_post_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDraftsRequest.ProtoReflect.Descriptor instead.
+func (*ListDraftsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_post_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ListDraftsRequest) GetFirst() int32 {
+	if x != nil {
+		return x.First
+	}
+	return 0
+}
+
+func (x *ListDraftsRequest) GetAfter() string {
+	if x != nil && x.After != nil {
+		return *x.After
+	}
+	return ""
+}
+
+// Schedules a draft to be published at publish_at. Without publish_at, or
+// with a time already past, it is published right away.
+type SchedulePostRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PostId        string                 `protobuf:"bytes,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
+	PublishAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=publish_at,json=publishAt,proto3" json:"publish_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SchedulePostRequest) Reset() {
+	*x = SchedulePostRequest{}
+	mi := &file_proto_post_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SchedulePostRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SchedulePostRequest) ProtoMessage() {}
+
+func (x *SchedulePostRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_post_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SchedulePostRequest.ProtoReflect.Descriptor instead.
+func (*SchedulePostRequest) Descriptor() ([]byte, []int) {
+	return file_proto_post_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *SchedulePostRequest) GetPostId() string {
+	if x != nil {
+		return x.PostId
+	}
+	return ""
+}
+
+func (x *SchedulePostRequest) GetPublishAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PublishAt
+	}
+	return nil
+}
+
+// A poll to create with a post: 2 to 4 options, closing between 5 minutes
+// and 7 days from now
+type PollInput struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Options       []string               `protobuf:"bytes,1,rep,name=options,proto3" json:"options,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PollInput) Reset() {
+	*x = PollInput{}
+	mi := &file_proto_post_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PollInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PollInput) ProtoMessage() {}
+
+func (x *PollInput) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_post_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PollInput.ProtoReflect.Descriptor instead.
+func (*PollInput) Descriptor() ([]byte, []int) {
+	return file_proto_post_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *PollInput) GetOptions() []string {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *PollInput) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+type Poll struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PostId        string                 `protobuf:"bytes,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
+	Options       []*PollOption          `protobuf:"bytes,2,rep,name=options,proto3" json:"options,omitempty"` // In the order they were given
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	IsExpired     bool                   `protobuf:"varint,4,opt,name=is_expired,json=isExpired,proto3" json:"is_expired,omitempty"`
+	TotalVotes    int32                  `protobuf:"varint,5,opt,name=total_votes,json=totalVotes,proto3" json:"total_votes,omitempty"`
+	VotedOptionId *string                `protobuf:"bytes,6,opt,name=voted_option_id,json=votedOptionId,proto3,oneof" json:"voted_option_id,omitempty"` // The requesting user's vote, if any
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Poll) Reset() {
+	*x = Poll{}
+	mi := &file_proto_post_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Poll) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Poll) ProtoMessage() {}
+
+func (x *Poll) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_post_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Poll.ProtoReflect.Descriptor instead.
+func (*Poll) Descriptor() ([]byte, []int) {
+	return file_proto_post_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *Poll) GetPostId() string {
+	if x != nil {
+		return x.PostId
+	}
+	return ""
+}
+
+func (x *Poll) GetOptions() []*PollOption {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *Poll) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *Poll) GetIsExpired() bool {
+	if x != nil {
+		return x.IsExpired
+	}
+	return false
+}
+
+func (x *Poll) GetTotalVotes() int32 {
+	if x != nil {
+		return x.TotalVotes
+	}
+	return 0
+}
+
+func (x *Poll) GetVotedOptionId() string {
+	if x != nil && x.VotedOptionId != nil {
+		return *x.VotedOptionId
+	}
+	return ""
+}
+
+type PollOption struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Text          string                 `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	VotesCount    int32                  `protobuf:"varint,3,opt,name=votes_count,json=votesCount,proto3" json:"votes_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PollOption) Reset() {
+	*x = PollOption{}
+	mi := &file_proto_post_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PollOption) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PollOption) ProtoMessage() {}
+
+func (x *PollOption) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_post_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PollOption.ProtoReflect.Descriptor instead.
+func (*PollOption) Descriptor() ([]byte, []int) {
+	return file_proto_post_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *PollOption) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PollOption) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *PollOption) GetVotesCount() int32 {
+	if x != nil {
+		return x.VotesCount
+	}
+	return 0
+}
+
+type VotePollRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PostId        string                 `protobuf:"bytes,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
+	OptionId      string                 `protobuf:"bytes,2,opt,name=option_id,json=optionId,proto3" json:"option_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VotePollRequest) Reset() {
+	*x = VotePollRequest{}
+	mi := &file_proto_post_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VotePollRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VotePollRequest) ProtoMessage() {}
+
+func (x *VotePollRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_post_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VotePollRequest.ProtoReflect.Descriptor instead.
+func (*VotePollRequest) Descriptor() ([]byte, []int) {
+	return file_proto_post_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *VotePollRequest) GetPostId() string {
+	if x != nil {
+		return x.PostId
+	}
+	return ""
+}
+
+func (x *VotePollRequest) GetOptionId() string {
+	if x != nil {
+		return x.OptionId
+	}
+	return ""
+}
+
+type GetPollResultsRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	PostId string                 `protobuf:"bytes,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
+	// Ignored: the viewer is the user of the request's token, if any
+	RequestingUserId *string `protobuf:"bytes,2,opt,name=requesting_user_id,json=requestingUserId,proto3,oneof" json:"requesting_user_id,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *GetPollResultsRequest) Reset() {
+	*x = GetPollResultsRequest{}
+	mi := &file_proto_post_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPollResultsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPollResultsRequest) ProtoMessage() {}
+
+func (x *GetPollResultsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_post_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPollResultsRequest.ProtoReflect.Descriptor instead.
+func (*GetPollResultsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_post_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *GetPollResultsRequest) GetPostId() string {
+	if x != nil {
+		return x.PostId
+	}
+	return ""
+}
+
+func (x *GetPollResultsRequest) GetRequestingUserId() string {
+	if x != nil && x.RequestingUserId != nil {
+		return *x.RequestingUserId
+	}
+	return ""
+}
+
+// The content a post had before an edit
+type PostRevision struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	PostId        string                 `protobuf:"bytes,2,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
+	EditorId      string                 `protobuf:"bytes,3,opt,name=editor_id,json=editorId,proto3" json:"editor_id,omitempty"` // User who made the edit
+	Content       string                 `protobuf:"bytes,4,opt,name=content,proto3" json:"content,omitempty"`
+	EditedAt      *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=edited_at,json=editedAt,proto3" json:"edited_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PostRevision) Reset() {
+	*x = PostRevision{}
+	mi := &file_proto_post_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PostRevision) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostRevision) ProtoMessage() {}
+
+func (x *PostRevision) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_post_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostRevision.ProtoReflect.Descriptor instead.
+func (*PostRevision) Descriptor() ([]byte, []int) {
+	return file_proto_post_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *PostRevision) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PostRevision) GetPostId() string {
+	if x != nil {
+		return x.PostId
+	}
+	return ""
+}
+
+func (x *PostRevision) GetEditorId() string {
+	if x != nil {
+		return x.EditorId
+	}
+	return ""
+}
+
+func (x *PostRevision) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *PostRevision) GetEditedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EditedAt
+	}
+	return nil
+}
+
+type PostRevisionEdge struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cursor        string                 `protobuf:"bytes,1,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	Node          *PostRevision          `protobuf:"bytes,2,opt,name=node,proto3" json:"node,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PostRevisionEdge) Reset() {
+	*x = PostRevisionEdge{}
+	mi := &file_proto_post_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PostRevisionEdge) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostRevisionEdge) ProtoMessage() {}
+
+func (x *PostRevisionEdge) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_post_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostRevisionEdge.ProtoReflect.Descriptor instead.
+func (*PostRevisionEdge) Descriptor() ([]byte, []int) {
+	return file_proto_post_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *PostRevisionEdge) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *PostRevisionEdge) GetNode() *PostRevision {
+	if x != nil {
+		return x.Node
+	}
+	return nil
+}
+
+type PostRevisionConnection struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Edges         []*PostRevisionEdge    `protobuf:"bytes,1,rep,name=edges,proto3" json:"edges,omitempty"`
+	PageInfo      *PageInfo              `protobuf:"bytes,2,opt,name=page_info,json=pageInfo,proto3" json:"page_info,omitempty"`
+	TotalCount    int32                  `protobuf:"varint,3,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PostRevisionConnection) Reset() {
+	*x = PostRevisionConnection{}
+	mi := &file_proto_post_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PostRevisionConnection) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostRevisionConnection) ProtoMessage() {}
+
+func (x *PostRevisionConnection) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_post_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostRevisionConnection.ProtoReflect.Descriptor instead.
+func (*PostRevisionConnection) Descriptor() ([]byte, []int) {
+	return file_proto_post_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *PostRevisionConnection) GetEdges() []*PostRevisionEdge {
+	if x != nil {
+		return x.Edges
+	}
+	return nil
+}
+
+func (x *PostRevisionConnection) GetPageInfo() *PageInfo {
+	if x != nil {
+		return x.PageInfo
+	}
+	return nil
+}
+
+func (x *PostRevisionConnection) GetTotalCount() int32 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+type RepostRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PostId        string                 `protobuf:"bytes,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RepostRequest) Reset() {
+	*x = RepostRequest{}
+	mi := &file_proto_post_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RepostRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RepostRequest) ProtoMessage() {}
+
+func (x *RepostRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_post_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RepostRequest.ProtoReflect.Descriptor instead.
+func (*RepostRequest) Descriptor() ([]byte, []int) {
+	return file_proto_post_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *RepostRequest) GetPostId() string {
+	if x != nil {
+		return x.PostId
+	}
+	return ""
+}
+
+type UndoRepostRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PostId        string                 `protobuf:"bytes,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UndoRepostRequest) Reset() {
+	*x = UndoRepostRequest{}
+	mi := &file_proto_post_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UndoRepostRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UndoRepostRequest) ProtoMessage() {}
+
+func (x *UndoRepostRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_post_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UndoRepostRequest.ProtoReflect.Descriptor instead.
+func (*UndoRepostRequest) Descriptor() ([]byte, []int) {
+	return file_proto_post_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *UndoRepostRequest) GetPostId() string {
+	if x != nil {
+		return x.PostId
+	}
+	return ""
+}
+
+type Mention struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"` // As written in the content
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Mention) Reset() {
+	*x = Mention{}
+	mi := &file_proto_post_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Mention) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Mention) ProtoMessage() {}
+
+func (x *Mention) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_post_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Mention.ProtoReflect.Descriptor instead.
+func (*Mention) Descriptor() ([]byte, []int) {
+	return file_proto_post_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *Mention) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Mention) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+type Media struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	MimeType      string                 `protobuf:"bytes,2,opt,name=mime_type,json=mimeType,proto3" json:"mime_type,omitempty"` // Detected from the file content
+	SizeBytes     int64                  `protobuf:"varint,3,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Media) Reset() {
+	*x = Media{}
+	mi := &file_proto_post_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Media) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Media) ProtoMessage() {}
+
+func (x *Media) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_post_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Media.ProtoReflect.Descriptor instead.
+func (*Media) Descriptor() ([]byte, []int) {
+	return file_proto_post_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *Media) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Media) GetMimeType() string {
+	if x != nil {
+		return x.MimeType
+	}
+	return ""
+}
+
+func (x *Media) GetSizeBytes() int64 {
+	if x != nil {
+		return x.SizeBytes
+	}
+	return 0
+}
+
+func (x *Media) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type UploadMediaRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Chunk         []byte                 `protobuf:"bytes,1,opt,name=chunk,proto3" json:"chunk,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadMediaRequest) Reset() {
+	*x = UploadMediaRequest{}
+	mi := &file_proto_post_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadMediaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadMediaRequest) ProtoMessage() {}
+
+func (x *UploadMediaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_post_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadMediaRequest.ProtoReflect.Descriptor instead.
+func (*UploadMediaRequest) Descriptor() ([]byte, []int) {
+	return file_proto_post_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *UploadMediaRequest) GetChunk() []byte {
+	if x != nil {
+		return x.Chunk
+	}
+	return nil
+}
+
+type GetMediaContentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MediaId       string                 `protobuf:"bytes,1,opt,name=media_id,json=mediaId,proto3" json:"media_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMediaContentRequest) Reset() {
+	*x = GetMediaContentRequest{}
+	mi := &file_proto_post_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMediaContentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMediaContentRequest) ProtoMessage() {}
+
+func (x *GetMediaContentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_post_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMediaContentRequest.ProtoReflect.Descriptor instead.
+func (*GetMediaContentRequest) Descriptor() ([]byte, []int) {
+	return file_proto_post_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *GetMediaContentRequest) GetMediaId() string {
+	if x != nil {
+		return x.MediaId
+	}
+	return ""
+}
+
+// The first chunk carries mime_type and size_bytes
+type MediaChunk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MimeType      string                 `protobuf:"bytes,1,opt,name=mime_type,json=mimeType,proto3" json:"mime_type,omitempty"`
+	SizeBytes     int64                  `protobuf:"varint,2,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
+	Chunk         []byte                 `protobuf:"bytes,3,opt,name=chunk,proto3" json:"chunk,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MediaChunk) Reset() {
+	*x = MediaChunk{}
+	mi := &file_proto_post_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MediaChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MediaChunk) ProtoMessage() {}
+
+func (x *MediaChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_post_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MediaChunk.ProtoReflect.Descriptor instead.
+func (*MediaChunk) Descriptor() ([]byte, []int) {
+	return file_proto_post_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *MediaChunk) GetMimeType() string {
+	if x != nil {
+		return x.MimeType
+	}
+	return ""
+}
+
+func (x *MediaChunk) GetSizeBytes() int64 {
+	if x != nil {
+		return x.SizeBytes
+	}
+	return 0
+}
+
+func (x *MediaChunk) GetChunk() []byte {
+	if x != nil {
+		return x.Chunk
+	}
+	return nil
 }
 
 type PostEdge struct {
@@ -592,7 +2863,7 @@ type PostEdge struct {
 
 func (x *PostEdge) Reset() {
 	*x = PostEdge{}
-	mi := &file_proto_post_proto_msgTypes[10]
+	mi := &file_proto_post_proto_msgTypes[47]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -604,7 +2875,7 @@ func (x *PostEdge) String() string {
 func (*PostEdge) ProtoMessage() {}
 
 func (x *PostEdge) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_post_proto_msgTypes[10]
+	mi := &file_proto_post_proto_msgTypes[47]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -617,7 +2888,7 @@ func (x *PostEdge) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PostEdge.ProtoReflect.Descriptor instead.
 func (*PostEdge) Descriptor() ([]byte, []int) {
-	return file_proto_post_proto_rawDescGZIP(), []int{10}
+	return file_proto_post_proto_rawDescGZIP(), []int{47}
 }
 
 func (x *PostEdge) GetCursor() string {
@@ -646,7 +2917,7 @@ type PageInfo struct {
 
 func (x *PageInfo) Reset() {
 	*x = PageInfo{}
-	mi := &file_proto_post_proto_msgTypes[11]
+	mi := &file_proto_post_proto_msgTypes[48]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -658,7 +2929,7 @@ func (x *PageInfo) String() string {
 func (*PageInfo) ProtoMessage() {}
 
 func (x *PageInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_post_proto_msgTypes[11]
+	mi := &file_proto_post_proto_msgTypes[48]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -671,7 +2942,7 @@ func (x *PageInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PageInfo.ProtoReflect.Descriptor instead.
 func (*PageInfo) Descriptor() ([]byte, []int) {
-	return file_proto_post_proto_rawDescGZIP(), []int{11}
+	return file_proto_post_proto_rawDescGZIP(), []int{48}
 }
 
 func (x *PageInfo) GetEndCursor() string {
@@ -713,7 +2984,7 @@ type PostConnection struct {
 
 func (x *PostConnection) Reset() {
 	*x = PostConnection{}
-	mi := &file_proto_post_proto_msgTypes[12]
+	mi := &file_proto_post_proto_msgTypes[49]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -725,7 +2996,7 @@ func (x *PostConnection) String() string {
 func (*PostConnection) ProtoMessage() {}
 
 func (x *PostConnection) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_post_proto_msgTypes[12]
+	mi := &file_proto_post_proto_msgTypes[49]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -738,7 +3009,7 @@ func (x *PostConnection) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PostConnection.ProtoReflect.Descriptor instead.
 func (*PostConnection) Descriptor() ([]byte, []int) {
-	return file_proto_post_proto_rawDescGZIP(), []int{12}
+	return file_proto_post_proto_rawDescGZIP(), []int{49}
 }
 
 func (x *PostConnection) GetEdges() []*PostEdge {
@@ -772,7 +3043,7 @@ type Response struct {
 
 func (x *Response) Reset() {
 	*x = Response{}
-	mi := &file_proto_post_proto_msgTypes[13]
+	mi := &file_proto_post_proto_msgTypes[50]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -784,7 +3055,7 @@ func (x *Response) String() string {
 func (*Response) ProtoMessage() {}
 
 func (x *Response) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_post_proto_msgTypes[13]
+	mi := &file_proto_post_proto_msgTypes[50]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -797,7 +3068,7 @@ func (x *Response) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Response.ProtoReflect.Descriptor instead.
 func (*Response) Descriptor() ([]byte, []int) {
-	return file_proto_post_proto_rawDescGZIP(), []int{13}
+	return file_proto_post_proto_rawDescGZIP(), []int{50}
 }
 
 func (x *Response) GetSuccess() bool {
@@ -814,18 +3085,252 @@ func (x *Response) GetMessage() string {
 	return ""
 }
 
+type ExportMyDataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportMyDataRequest) Reset() {
+	*x = ExportMyDataRequest{}
+	mi := &file_proto_post_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportMyDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportMyDataRequest) ProtoMessage() {}
+
+func (x *ExportMyDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_post_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportMyDataRequest.ProtoReflect.Descriptor instead.
+func (*ExportMyDataRequest) Descriptor() ([]byte, []int) {
+	return file_proto_post_proto_rawDescGZIP(), []int{51}
+}
+
+type DataExport struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"` // JSON document
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DataExport) Reset() {
+	*x = DataExport{}
+	mi := &file_proto_post_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DataExport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DataExport) ProtoMessage() {}
+
+func (x *DataExport) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_post_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DataExport.ProtoReflect.Descriptor instead.
+func (*DataExport) Descriptor() ([]byte, []int) {
+	return file_proto_post_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *DataExport) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type GetPostsCountsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserIds       []string               `protobuf:"bytes,1,rep,name=user_ids,json=userIds,proto3" json:"user_ids,omitempty"` // At most 1000
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPostsCountsRequest) Reset() {
+	*x = GetPostsCountsRequest{}
+	mi := &file_proto_post_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPostsCountsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPostsCountsRequest) ProtoMessage() {}
+
+func (x *GetPostsCountsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_post_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPostsCountsRequest.ProtoReflect.Descriptor instead.
+func (*GetPostsCountsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_post_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *GetPostsCountsRequest) GetUserIds() []string {
+	if x != nil {
+		return x.UserIds
+	}
+	return nil
+}
+
+type UserPostsCount struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Count         int32                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserPostsCount) Reset() {
+	*x = UserPostsCount{}
+	mi := &file_proto_post_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserPostsCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserPostsCount) ProtoMessage() {}
+
+func (x *UserPostsCount) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_post_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserPostsCount.ProtoReflect.Descriptor instead.
+func (*UserPostsCount) Descriptor() ([]byte, []int) {
+	return file_proto_post_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *UserPostsCount) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UserPostsCount) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type GetPostsCountsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Counts        []*UserPostsCount      `protobuf:"bytes,1,rep,name=counts,proto3" json:"counts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPostsCountsResponse) Reset() {
+	*x = GetPostsCountsResponse{}
+	mi := &file_proto_post_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPostsCountsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPostsCountsResponse) ProtoMessage() {}
+
+func (x *GetPostsCountsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_post_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPostsCountsResponse.ProtoReflect.Descriptor instead.
+func (*GetPostsCountsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_post_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *GetPostsCountsResponse) GetCounts() []*UserPostsCount {
+	if x != nil {
+		return x.Counts
+	}
+	return nil
+}
+
 var File_proto_post_proto protoreflect.FileDescriptor
 
 const file_proto_post_proto_rawDesc = "" +
 	"\n" +
-	"\x10proto/post.proto\x12\x04post\x1a\x1fgoogle/protobuf/timestamp.proto\"F\n" +
+	"\x10proto/post.proto\x12\x04post\x1a\x1fgoogle/protobuf/timestamp.proto\"\xfc\x01\n" +
 	"\x11CreatePostRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x18\n" +
-	"\acontent\x18\x02 \x01(\tR\acontent\"s\n" +
+	"\acontent\x18\x02 \x01(\tR\acontent\x12\x1b\n" +
+	"\tmedia_ids\x18\x03 \x03(\tR\bmediaIds\x12)\n" +
+	"\x0equoted_post_id\x18\x04 \x01(\tH\x00R\fquotedPostId\x88\x01\x01\x124\n" +
+	"\n" +
+	"visibility\x18\x05 \x01(\x0e2\x14.post.PostVisibilityR\n" +
+	"visibility\x12#\n" +
+	"\x04poll\x18\x06 \x01(\v2\x0f.post.PollInputR\x04pollB\x11\n" +
+	"\x0f_quoted_post_id\"s\n" +
 	"\x0eGetPostRequest\x12\x17\n" +
 	"\apost_id\x18\x01 \x01(\tR\x06postId\x121\n" +
 	"\x12requesting_user_id\x18\x02 \x01(\tH\x00R\x10requestingUserId\x88\x01\x01B\x15\n" +
-	"\x13_requesting_user_id\"_\n" +
+	"\x13_requesting_user_id\"{\n" +
+	"\x14GetPostsByIdsRequest\x12\x19\n" +
+	"\bpost_ids\x18\x01 \x03(\tR\apostIds\x121\n" +
+	"\x12requesting_user_id\x18\x02 \x01(\tH\x00R\x10requestingUserId\x88\x01\x01B\x15\n" +
+	"\x13_requesting_user_id\"9\n" +
+	"\x15GetPostsByIdsResponse\x12 \n" +
+	"\x05posts\x18\x01 \x03(\v2\n" +
+	".post.PostR\x05posts\"_\n" +
 	"\x11UpdatePostRequest\x12\x17\n" +
 	"\apost_id\x18\x01 \x01(\tR\x06postId\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x18\n" +
@@ -839,15 +3344,81 @@ const file_proto_post_proto_rawDesc = "" +
 	"\x05after\x18\x03 \x01(\tH\x00R\x05after\x88\x01\x01\x121\n" +
 	"\x12requesting_user_id\x18\x04 \x01(\tH\x01R\x10requestingUserId\x88\x01\x01B\b\n" +
 	"\x06_afterB\x15\n" +
-	"\x13_requesting_user_id\"8\n" +
-	"\x1dIncrementCommentsCountRequest\x12\x17\n" +
-	"\apost_id\x18\x01 \x01(\tR\x06postId\"8\n" +
-	"\x1dDecrementCommentsCountRequest\x12\x17\n" +
-	"\apost_id\x18\x01 \x01(\tR\x06postId\"5\n" +
-	"\x1aIncrementLikesCountRequest\x12\x17\n" +
-	"\apost_id\x18\x01 \x01(\tR\x06postId\"5\n" +
-	"\x1aDecrementLikesCountRequest\x12\x17\n" +
-	"\apost_id\x18\x01 \x01(\tR\x06postId\"\xb4\x02\n" +
+	"\x13_requesting_user_id\"\x8c\x01\n" +
+	"\x17ReplayPostEventsRequest\x120\n" +
+	"\x05since\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x05since\x125\n" +
+	"\x05until\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampH\x00R\x05until\x88\x01\x01B\b\n" +
+	"\x06_until\"6\n" +
+	"\x18ReplayPostEventsResponse\x12\x1a\n" +
+	"\breplayed\x18\x01 \x01(\x05R\breplayed\"y\n" +
+	"\x16SetPostCountersRequest\x12\x17\n" +
+	"\apost_id\x18\x01 \x01(\tR\x06postId\x12\x1f\n" +
+	"\vlikes_count\x18\x02 \x01(\x05R\n" +
+	"likesCount\x12%\n" +
+	"\x0ecomments_count\x18\x03 \x01(\x05R\rcommentsCount\"-\n" +
+	"\x12RecountPostRequest\x12\x17\n" +
+	"\apost_id\x18\x01 \x01(\tR\x06postId\"o\n" +
+	"\fPostCounters\x12\x17\n" +
+	"\apost_id\x18\x01 \x01(\tR\x06postId\x12\x1f\n" +
+	"\vlikes_count\x18\x02 \x01(\x05R\n" +
+	"likesCount\x12%\n" +
+	"\x0ecomments_count\x18\x03 \x01(\x05R\rcommentsCount\"\x1e\n" +
+	"\x1cReconcilePostCountersRequest\"W\n" +
+	"\x1dReconcilePostCountersResponse\x12\x18\n" +
+	"\achecked\x18\x01 \x01(\x05R\achecked\x12\x1c\n" +
+	"\tcorrected\x18\x02 \x01(\x05R\tcorrected\"\x8c\x01\n" +
+	"\fImportedPost\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x18\n" +
+	"\acontent\x18\x03 \x01(\tR\acontent\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\">\n" +
+	"\x12ImportPostsRequest\x12(\n" +
+	"\x05posts\x18\x01 \x03(\v2\x12.post.ImportedPostR\x05posts\"I\n" +
+	"\x13ImportPostsResponse\x12\x18\n" +
+	"\acreated\x18\x01 \x01(\x05R\acreated\x12\x18\n" +
+	"\askipped\x18\x02 \x01(\x05R\askipped\"]\n" +
+	"\x18PurgeDeletedPostsRequest\x12A\n" +
+	"\x0edeleted_before\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\rdeletedBefore\"3\n" +
+	"\x19PurgeDeletedPostsResponse\x12\x16\n" +
+	"\x06purged\x18\x01 \x01(\x03R\x06purged\",\n" +
+	"\x11RemovePostRequest\x12\x17\n" +
+	"\apost_id\x18\x01 \x01(\tR\x06postId\"[\n" +
+	"\x16ListPublicPostsRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12\x1e\n" +
+	"\bafter_id\x18\x02 \x01(\tH\x00R\aafterId\x88\x01\x01B\v\n" +
+	"\t_after_id\"p\n" +
+	"\n" +
+	"PublicPost\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x129\n" +
+	"\n" +
+	"updated_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"|\n" +
+	"\x17ListPublicPostsResponse\x12&\n" +
+	"\x05posts\x18\x01 \x03(\v2\x10.post.PublicPostR\x05posts\x12'\n" +
+	"\rnext_after_id\x18\x02 \x01(\tH\x00R\vnextAfterId\x88\x01\x01B\x10\n" +
+	"\x0e_next_after_id\"g\n" +
+	"\x18GetPostsByHashtagRequest\x12\x10\n" +
+	"\x03tag\x18\x01 \x01(\tR\x03tag\x12\x14\n" +
+	"\x05first\x18\x02 \x01(\x05R\x05first\x12\x19\n" +
+	"\x05after\x18\x03 \x01(\tH\x00R\x05after\x88\x01\x01B\b\n" +
+	"\x06_after\"\xb7\x01\n" +
+	"\x17GetPostRevisionsRequest\x12\x17\n" +
+	"\apost_id\x18\x01 \x01(\tR\x06postId\x12\x14\n" +
+	"\x05first\x18\x02 \x01(\x05R\x05first\x12\x19\n" +
+	"\x05after\x18\x03 \x01(\tH\x00R\x05after\x88\x01\x01\x121\n" +
+	"\x12requesting_user_id\x18\x04 \x01(\tH\x01R\x10requestingUserId\x88\x01\x01B\b\n" +
+	"\x06_afterB\x15\n" +
+	"\x13_requesting_user_id\"U\n" +
+	"\x1aGetTrendingHashtagsRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12!\n" +
+	"\fwindow_hours\x18\x02 \x01(\x05R\vwindowHours\"D\n" +
+	"\x0fTrendingHashtag\x12\x10\n" +
+	"\x03tag\x18\x01 \x01(\tR\x03tag\x12\x1f\n" +
+	"\vposts_count\x18\x02 \x01(\x05R\n" +
+	"postsCount\"P\n" +
+	"\x1bGetTrendingHashtagsResponse\x121\n" +
+	"\bhashtags\x18\x01 \x03(\v2\x15.post.TrendingHashtagR\bhashtags\"\xd7\x06\n" +
 	"\x04Post\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x18\n" +
@@ -859,8 +3430,114 @@ const file_proto_post_proto_rawDesc = "" +
 	"\vlikes_count\x18\x06 \x01(\x05R\n" +
 	"likesCount\x12%\n" +
 	"\x0ecomments_count\x18\a \x01(\x05R\rcommentsCount\x12\x1e\n" +
-	"\bis_liked\x18\b \x01(\bH\x00R\aisLiked\x88\x01\x01B\v\n" +
-	"\t_is_liked\"B\n" +
+	"\bis_liked\x18\b \x01(\bH\x00R\aisLiked\x88\x01\x01\x12)\n" +
+	"\bmentions\x18\t \x03(\v2\r.post.MentionR\bmentions\x12!\n" +
+	"\x05media\x18\n" +
+	" \x03(\v2\v.post.MediaR\x05media\x12#\n" +
+	"\rreposts_count\x18\v \x01(\x05R\frepostsCount\x12$\n" +
+	"\vis_reposted\x18\f \x01(\bH\x01R\n" +
+	"isReposted\x88\x01\x01\x12)\n" +
+	"\x0equoted_post_id\x18\r \x01(\tH\x02R\fquotedPostId\x88\x01\x01\x12+\n" +
+	"\vquoted_post\x18\x0e \x01(\v2\n" +
+	".post.PostR\n" +
+	"quotedPost\x129\n" +
+	"\n" +
+	"deleted_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\tdeletedAt\x127\n" +
+	"\tedited_at\x18\x10 \x01(\v2\x1a.google.protobuf.TimestampR\beditedAt\x12(\n" +
+	"\x06status\x18\x11 \x01(\x0e2\x10.post.PostStatusR\x06status\x129\n" +
+	"\n" +
+	"publish_at\x18\x12 \x01(\v2\x1a.google.protobuf.TimestampR\tpublishAt\x124\n" +
+	"\n" +
+	"visibility\x18\x13 \x01(\x0e2\x14.post.PostVisibilityR\n" +
+	"visibilityB\v\n" +
+	"\t_is_likedB\x0e\n" +
+	"\f_is_repostedB\x11\n" +
+	"\x0f_quoted_post_id\"\xe7\x01\n" +
+	"\x10SaveDraftRequest\x12\x1c\n" +
+	"\apost_id\x18\x01 \x01(\tH\x00R\x06postId\x88\x01\x01\x12\x18\n" +
+	"\acontent\x18\x02 \x01(\tR\acontent\x12\x1b\n" +
+	"\tmedia_ids\x18\x03 \x03(\tR\bmediaIds\x12)\n" +
+	"\x0equoted_post_id\x18\x04 \x01(\tH\x01R\fquotedPostId\x88\x01\x01\x124\n" +
+	"\n" +
+	"visibility\x18\x05 \x01(\x0e2\x14.post.PostVisibilityR\n" +
+	"visibilityB\n" +
+	"\n" +
+	"\b_post_idB\x11\n" +
+	"\x0f_quoted_post_id\"N\n" +
+	"\x11ListDraftsRequest\x12\x14\n" +
+	"\x05first\x18\x01 \x01(\x05R\x05first\x12\x19\n" +
+	"\x05after\x18\x02 \x01(\tH\x00R\x05after\x88\x01\x01B\b\n" +
+	"\x06_after\"i\n" +
+	"\x13SchedulePostRequest\x12\x17\n" +
+	"\apost_id\x18\x01 \x01(\tR\x06postId\x129\n" +
+	"\n" +
+	"publish_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tpublishAt\"`\n" +
+	"\tPollInput\x12\x18\n" +
+	"\aoptions\x18\x01 \x03(\tR\aoptions\x129\n" +
+	"\n" +
+	"expires_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"\x87\x02\n" +
+	"\x04Poll\x12\x17\n" +
+	"\apost_id\x18\x01 \x01(\tR\x06postId\x12*\n" +
+	"\aoptions\x18\x02 \x03(\v2\x10.post.PollOptionR\aoptions\x129\n" +
+	"\n" +
+	"expires_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12\x1d\n" +
+	"\n" +
+	"is_expired\x18\x04 \x01(\bR\tisExpired\x12\x1f\n" +
+	"\vtotal_votes\x18\x05 \x01(\x05R\n" +
+	"totalVotes\x12+\n" +
+	"\x0fvoted_option_id\x18\x06 \x01(\tH\x00R\rvotedOptionId\x88\x01\x01B\x12\n" +
+	"\x10_voted_option_id\"Q\n" +
+	"\n" +
+	"PollOption\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\x12\x1f\n" +
+	"\vvotes_count\x18\x03 \x01(\x05R\n" +
+	"votesCount\"G\n" +
+	"\x0fVotePollRequest\x12\x17\n" +
+	"\apost_id\x18\x01 \x01(\tR\x06postId\x12\x1b\n" +
+	"\toption_id\x18\x02 \x01(\tR\boptionId\"z\n" +
+	"\x15GetPollResultsRequest\x12\x17\n" +
+	"\apost_id\x18\x01 \x01(\tR\x06postId\x121\n" +
+	"\x12requesting_user_id\x18\x02 \x01(\tH\x00R\x10requestingUserId\x88\x01\x01B\x15\n" +
+	"\x13_requesting_user_id\"\xa7\x01\n" +
+	"\fPostRevision\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\apost_id\x18\x02 \x01(\tR\x06postId\x12\x1b\n" +
+	"\teditor_id\x18\x03 \x01(\tR\beditorId\x12\x18\n" +
+	"\acontent\x18\x04 \x01(\tR\acontent\x127\n" +
+	"\tedited_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\beditedAt\"R\n" +
+	"\x10PostRevisionEdge\x12\x16\n" +
+	"\x06cursor\x18\x01 \x01(\tR\x06cursor\x12&\n" +
+	"\x04node\x18\x02 \x01(\v2\x12.post.PostRevisionR\x04node\"\x94\x01\n" +
+	"\x16PostRevisionConnection\x12,\n" +
+	"\x05edges\x18\x01 \x03(\v2\x16.post.PostRevisionEdgeR\x05edges\x12+\n" +
+	"\tpage_info\x18\x02 \x01(\v2\x0e.post.PageInfoR\bpageInfo\x12\x1f\n" +
+	"\vtotal_count\x18\x03 \x01(\x05R\n" +
+	"totalCount\"(\n" +
+	"\rRepostRequest\x12\x17\n" +
+	"\apost_id\x18\x01 \x01(\tR\x06postId\",\n" +
+	"\x11UndoRepostRequest\x12\x17\n" +
+	"\apost_id\x18\x01 \x01(\tR\x06postId\">\n" +
+	"\aMention\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\"\x8e\x01\n" +
+	"\x05Media\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\tmime_type\x18\x02 \x01(\tR\bmimeType\x12\x1d\n" +
+	"\n" +
+	"size_bytes\x18\x03 \x01(\x03R\tsizeBytes\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"*\n" +
+	"\x12UploadMediaRequest\x12\x14\n" +
+	"\x05chunk\x18\x01 \x01(\fR\x05chunk\"3\n" +
+	"\x16GetMediaContentRequest\x12\x19\n" +
+	"\bmedia_id\x18\x01 \x01(\tR\amediaId\"^\n" +
+	"\n" +
+	"MediaChunk\x12\x1b\n" +
+	"\tmime_type\x18\x01 \x01(\tR\bmimeType\x12\x1d\n" +
+	"\n" +
+	"size_bytes\x18\x02 \x01(\x03R\tsizeBytes\x12\x14\n" +
+	"\x05chunk\x18\x03 \x01(\fR\x05chunk\"B\n" +
 	"\bPostEdge\x12\x16\n" +
 	"\x06cursor\x18\x01 \x01(\tR\x06cursor\x12\x1e\n" +
 	"\x04node\x18\x02 \x01(\v2\n" +
@@ -880,23 +3557,71 @@ const file_proto_post_proto_rawDesc = "" +
 	"totalCount\">\n" +
 	"\bResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage2\xc8\x04\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\x15\n" +
+	"\x13ExportMyDataRequest\" \n" +
+	"\n" +
+	"DataExport\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\"2\n" +
+	"\x15GetPostsCountsRequest\x12\x19\n" +
+	"\buser_ids\x18\x01 \x03(\tR\auserIds\"?\n" +
+	"\x0eUserPostsCount\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\"F\n" +
+	"\x16GetPostsCountsResponse\x12,\n" +
+	"\x06counts\x18\x01 \x03(\v2\x14.post.UserPostsCountR\x06counts*\x8e\x01\n" +
+	"\x0ePostVisibility\x12\x1f\n" +
+	"\x1bPOST_VISIBILITY_UNSPECIFIED\x10\x00\x12\x1a\n" +
+	"\x16POST_VISIBILITY_PUBLIC\x10\x01\x12\"\n" +
+	"\x1ePOST_VISIBILITY_FOLLOWERS_ONLY\x10\x02\x12\x1b\n" +
+	"\x17POST_VISIBILITY_PRIVATE\x10\x03*v\n" +
+	"\n" +
+	"PostStatus\x12\x1b\n" +
+	"\x17POST_STATUS_UNSPECIFIED\x10\x00\x12\x15\n" +
+	"\x11POST_STATUS_DRAFT\x10\x01\x12\x19\n" +
+	"\x15POST_STATUS_SCHEDULED\x10\x02\x12\x19\n" +
+	"\x15POST_STATUS_PUBLISHED\x10\x032\xaa\x0e\n" +
 	"\vPostService\x121\n" +
 	"\n" +
 	"CreatePost\x12\x17.post.CreatePostRequest\x1a\n" +
 	".post.Post\x12+\n" +
 	"\aGetPost\x12\x14.post.GetPostRequest\x1a\n" +
-	".post.Post\x121\n" +
+	".post.Post\x12H\n" +
+	"\rGetPostsByIds\x12\x1a.post.GetPostsByIdsRequest\x1a\x1b.post.GetPostsByIdsResponse\x121\n" +
 	"\n" +
 	"UpdatePost\x12\x17.post.UpdatePostRequest\x1a\n" +
 	".post.Post\x125\n" +
 	"\n" +
 	"DeletePost\x12\x17.post.DeletePostRequest\x1a\x0e.post.Response\x12?\n" +
-	"\fGetUserPosts\x12\x19.post.GetUserPostsRequest\x1a\x14.post.PostConnection\x12M\n" +
-	"\x16IncrementCommentsCount\x12#.post.IncrementCommentsCountRequest\x1a\x0e.post.Response\x12M\n" +
-	"\x16DecrementCommentsCount\x12#.post.DecrementCommentsCountRequest\x1a\x0e.post.Response\x12G\n" +
-	"\x13IncrementLikesCount\x12 .post.IncrementLikesCountRequest\x1a\x0e.post.Response\x12G\n" +
-	"\x13DecrementLikesCount\x12 .post.DecrementLikesCountRequest\x1a\x0e.post.ResponseB\x04Z\x02./b\x06proto3"
+	"\fGetUserPosts\x12\x19.post.GetUserPostsRequest\x1a\x14.post.PostConnection\x12N\n" +
+	"\x0fListPublicPosts\x12\x1c.post.ListPublicPostsRequest\x1a\x1d.post.ListPublicPostsResponse\x12I\n" +
+	"\x11GetPostsByHashtag\x12\x1e.post.GetPostsByHashtagRequest\x1a\x14.post.PostConnection\x12Z\n" +
+	"\x13GetTrendingHashtags\x12 .post.GetTrendingHashtagsRequest\x1a!.post.GetTrendingHashtagsResponse\x12O\n" +
+	"\x10GetPostRevisions\x12\x1d.post.GetPostRevisionsRequest\x1a\x1c.post.PostRevisionConnection\x12-\n" +
+	"\x06Repost\x12\x13.post.RepostRequest\x1a\x0e.post.Response\x125\n" +
+	"\n" +
+	"UndoRepost\x12\x17.post.UndoRepostRequest\x1a\x0e.post.Response\x12/\n" +
+	"\tSaveDraft\x12\x16.post.SaveDraftRequest\x1a\n" +
+	".post.Post\x12;\n" +
+	"\n" +
+	"ListDrafts\x12\x17.post.ListDraftsRequest\x1a\x14.post.PostConnection\x125\n" +
+	"\fSchedulePost\x12\x19.post.SchedulePostRequest\x1a\n" +
+	".post.Post\x12-\n" +
+	"\bVotePoll\x12\x15.post.VotePollRequest\x1a\n" +
+	".post.Poll\x129\n" +
+	"\x0eGetPollResults\x12\x1b.post.GetPollResultsRequest\x1a\n" +
+	".post.Poll\x126\n" +
+	"\vUploadMedia\x12\x18.post.UploadMediaRequest\x1a\v.post.Media(\x01\x12C\n" +
+	"\x0fGetMediaContent\x12\x1c.post.GetMediaContentRequest\x1a\x10.post.MediaChunk0\x01\x12;\n" +
+	"\fExportMyData\x12\x19.post.ExportMyDataRequest\x1a\x10.post.DataExport\x12K\n" +
+	"\x0eGetPostsCounts\x12\x1b.post.GetPostsCountsRequest\x1a\x1c.post.GetPostsCountsResponse\x12Q\n" +
+	"\x10ReplayPostEvents\x12\x1d.post.ReplayPostEventsRequest\x1a\x1e.post.ReplayPostEventsResponse\x12?\n" +
+	"\x0fSetPostCounters\x12\x1c.post.SetPostCountersRequest\x1a\x0e.post.Response\x12;\n" +
+	"\vRecountPost\x12\x18.post.RecountPostRequest\x1a\x12.post.PostCounters\x12`\n" +
+	"\x15ReconcilePostCounters\x12\".post.ReconcilePostCountersRequest\x1a#.post.ReconcilePostCountersResponse\x12B\n" +
+	"\vImportPosts\x12\x18.post.ImportPostsRequest\x1a\x19.post.ImportPostsResponse\x12T\n" +
+	"\x11PurgeDeletedPosts\x12\x1e.post.PurgeDeletedPostsRequest\x1a\x1f.post.PurgeDeletedPostsResponse\x125\n" +
+	"\n" +
+	"RemovePost\x12\x17.post.RemovePostRequest\x1a\x0e.post.ResponseB\x04Z\x02./b\x06proto3"
 
 var (
 	file_proto_post_proto_rawDescOnce sync.Once
@@ -910,53 +3635,166 @@ func file_proto_post_proto_rawDescGZIP() []byte {
 	return file_proto_post_proto_rawDescData
 }
 
-var file_proto_post_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_proto_post_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_proto_post_proto_msgTypes = make([]protoimpl.MessageInfo, 56)
 var file_proto_post_proto_goTypes = []any{
-	(*CreatePostRequest)(nil),             // 0: post.CreatePostRequest
-	(*GetPostRequest)(nil),                // 1: post.GetPostRequest
-	(*UpdatePostRequest)(nil),             // 2: post.UpdatePostRequest
-	(*DeletePostRequest)(nil),             // 3: post.DeletePostRequest
-	(*GetUserPostsRequest)(nil),           // 4: post.GetUserPostsRequest
-	(*IncrementCommentsCountRequest)(nil), // 5: post.IncrementCommentsCountRequest
-	(*DecrementCommentsCountRequest)(nil), // 6: post.DecrementCommentsCountRequest
-	(*IncrementLikesCountRequest)(nil),    // 7: post.IncrementLikesCountRequest
-	(*DecrementLikesCountRequest)(nil),    // 8: post.DecrementLikesCountRequest
-	(*Post)(nil),                          // 9: post.Post
-	(*PostEdge)(nil),                      // 10: post.PostEdge
-	(*PageInfo)(nil),                      // 11: post.PageInfo
-	(*PostConnection)(nil),                // 12: post.PostConnection
-	(*Response)(nil),                      // 13: post.Response
-	(*timestamppb.Timestamp)(nil),         // 14: google.protobuf.Timestamp
+	(PostVisibility)(0),                   // 0: post.PostVisibility
+	(PostStatus)(0),                       // 1: post.PostStatus
+	(*CreatePostRequest)(nil),             // 2: post.CreatePostRequest
+	(*GetPostRequest)(nil),                // 3: post.GetPostRequest
+	(*GetPostsByIdsRequest)(nil),          // 4: post.GetPostsByIdsRequest
+	(*GetPostsByIdsResponse)(nil),         // 5: post.GetPostsByIdsResponse
+	(*UpdatePostRequest)(nil),             // 6: post.UpdatePostRequest
+	(*DeletePostRequest)(nil),             // 7: post.DeletePostRequest
+	(*GetUserPostsRequest)(nil),           // 8: post.GetUserPostsRequest
+	(*ReplayPostEventsRequest)(nil),       // 9: post.ReplayPostEventsRequest
+	(*ReplayPostEventsResponse)(nil),      // 10: post.ReplayPostEventsResponse
+	(*SetPostCountersRequest)(nil),        // 11: post.SetPostCountersRequest
+	(*RecountPostRequest)(nil),            // 12: post.RecountPostRequest
+	(*PostCounters)(nil),                  // 13: post.PostCounters
+	(*ReconcilePostCountersRequest)(nil),  // 14: post.ReconcilePostCountersRequest
+	(*ReconcilePostCountersResponse)(nil), // 15: post.ReconcilePostCountersResponse
+	(*ImportedPost)(nil),                  // 16: post.ImportedPost
+	(*ImportPostsRequest)(nil),            // 17: post.ImportPostsRequest
+	(*ImportPostsResponse)(nil),           // 18: post.ImportPostsResponse
+	(*PurgeDeletedPostsRequest)(nil),      // 19: post.PurgeDeletedPostsRequest
+	(*PurgeDeletedPostsResponse)(nil),     // 20: post.PurgeDeletedPostsResponse
+	(*RemovePostRequest)(nil),             // 21: post.RemovePostRequest
+	(*ListPublicPostsRequest)(nil),        // 22: post.ListPublicPostsRequest
+	(*PublicPost)(nil),                    // 23: post.PublicPost
+	(*ListPublicPostsResponse)(nil),       // 24: post.ListPublicPostsResponse
+	(*GetPostsByHashtagRequest)(nil),      // 25: post.GetPostsByHashtagRequest
+	(*GetPostRevisionsRequest)(nil),       // 26: post.GetPostRevisionsRequest
+	(*GetTrendingHashtagsRequest)(nil),    // 27: post.GetTrendingHashtagsRequest
+	(*TrendingHashtag)(nil),               // 28: post.TrendingHashtag
+	(*GetTrendingHashtagsResponse)(nil),   // 29: post.GetTrendingHashtagsResponse
+	(*Post)(nil),                          // 30: post.Post
+	(*SaveDraftRequest)(nil),              // 31: post.SaveDraftRequest
+	(*ListDraftsRequest)(nil),             // 32: post.ListDraftsRequest
+	(*SchedulePostRequest)(nil),           // 33: post.SchedulePostRequest
+	(*PollInput)(nil),                     // 34: post.PollInput
+	(*Poll)(nil),                          // 35: post.Poll
+	(*PollOption)(nil),                    // 36: post.PollOption
+	(*VotePollRequest)(nil),               // 37: post.VotePollRequest
+	(*GetPollResultsRequest)(nil),         // 38: post.GetPollResultsRequest
+	(*PostRevision)(nil),                  // 39: post.PostRevision
+	(*PostRevisionEdge)(nil),              // 40: post.PostRevisionEdge
+	(*PostRevisionConnection)(nil),        // 41: post.PostRevisionConnection
+	(*RepostRequest)(nil),                 // 42: post.RepostRequest
+	(*UndoRepostRequest)(nil),             // 43: post.UndoRepostRequest
+	(*Mention)(nil),                       // 44: post.Mention
+	(*Media)(nil),                         // 45: post.Media
+	(*UploadMediaRequest)(nil),            // 46: post.UploadMediaRequest
+	(*GetMediaContentRequest)(nil),        // 47: post.GetMediaContentRequest
+	(*MediaChunk)(nil),                    // 48: post.MediaChunk
+	(*PostEdge)(nil),                      // 49: post.PostEdge
+	(*PageInfo)(nil),                      // 50: post.PageInfo
+	(*PostConnection)(nil),                // 51: post.PostConnection
+	(*Response)(nil),                      // 52: post.Response
+	(*ExportMyDataRequest)(nil),           // 53: post.ExportMyDataRequest
+	(*DataExport)(nil),                    // 54: post.DataExport
+	(*GetPostsCountsRequest)(nil),         // 55: post.GetPostsCountsRequest
+	(*UserPostsCount)(nil),                // 56: post.UserPostsCount
+	(*GetPostsCountsResponse)(nil),        // 57: post.GetPostsCountsResponse
+	(*timestamppb.Timestamp)(nil),         // 58: google.protobuf.Timestamp
 }
 var file_proto_post_proto_depIdxs = []int32{
-	14, // 0: post.Post.created_at:type_name -> google.protobuf.Timestamp
-	14, // 1: post.Post.updated_at:type_name -> google.protobuf.Timestamp
-	9,  // 2: post.PostEdge.node:type_name -> post.Post
-	10, // 3: post.PostConnection.edges:type_name -> post.PostEdge
-	11, // 4: post.PostConnection.page_info:type_name -> post.PageInfo
-	0,  // 5: post.PostService.CreatePost:input_type -> post.CreatePostRequest
-	1,  // 6: post.PostService.GetPost:input_type -> post.GetPostRequest
-	2,  // 7: post.PostService.UpdatePost:input_type -> post.UpdatePostRequest
-	3,  // 8: post.PostService.DeletePost:input_type -> post.DeletePostRequest
-	4,  // 9: post.PostService.GetUserPosts:input_type -> post.GetUserPostsRequest
-	5,  // 10: post.PostService.IncrementCommentsCount:input_type -> post.IncrementCommentsCountRequest
-	6,  // 11: post.PostService.DecrementCommentsCount:input_type -> post.DecrementCommentsCountRequest
-	7,  // 12: post.PostService.IncrementLikesCount:input_type -> post.IncrementLikesCountRequest
-	8,  // 13: post.PostService.DecrementLikesCount:input_type -> post.DecrementLikesCountRequest
-	9,  // 14: post.PostService.CreatePost:output_type -> post.Post
-	9,  // 15: post.PostService.GetPost:output_type -> post.Post
-	9,  // 16: post.PostService.UpdatePost:output_type -> post.Post
-	13, // 17: post.PostService.DeletePost:output_type -> post.Response
-	12, // 18: post.PostService.GetUserPosts:output_type -> post.PostConnection
-	13, // 19: post.PostService.IncrementCommentsCount:output_type -> post.Response
-	13, // 20: post.PostService.DecrementCommentsCount:output_type -> post.Response
-	13, // 21: post.PostService.IncrementLikesCount:output_type -> post.Response
-	13, // 22: post.PostService.DecrementLikesCount:output_type -> post.Response
-	14, // [14:23] is the sub-list for method output_type
-	5,  // [5:14] is the sub-list for method input_type
-	5,  // [5:5] is the sub-list for extension type_name
-	5,  // [5:5] is the sub-list for extension extendee
-	0,  // [0:5] is the sub-list for field type_name
+	0,  // 0: post.CreatePostRequest.visibility:type_name -> post.PostVisibility
+	34, // 1: post.CreatePostRequest.poll:type_name -> post.PollInput
+	30, // 2: post.GetPostsByIdsResponse.posts:type_name -> post.Post
+	58, // 3: post.ReplayPostEventsRequest.since:type_name -> google.protobuf.Timestamp
+	58, // 4: post.ReplayPostEventsRequest.until:type_name -> google.protobuf.Timestamp
+	58, // 5: post.ImportedPost.created_at:type_name -> google.protobuf.Timestamp
+	16, // 6: post.ImportPostsRequest.posts:type_name -> post.ImportedPost
+	58, // 7: post.PurgeDeletedPostsRequest.deleted_before:type_name -> google.protobuf.Timestamp
+	58, // 8: post.PublicPost.updated_at:type_name -> google.protobuf.Timestamp
+	23, // 9: post.ListPublicPostsResponse.posts:type_name -> post.PublicPost
+	28, // 10: post.GetTrendingHashtagsResponse.hashtags:type_name -> post.TrendingHashtag
+	58, // 11: post.Post.created_at:type_name -> google.protobuf.Timestamp
+	58, // 12: post.Post.updated_at:type_name -> google.protobuf.Timestamp
+	44, // 13: post.Post.mentions:type_name -> post.Mention
+	45, // 14: post.Post.media:type_name -> post.Media
+	30, // 15: post.Post.quoted_post:type_name -> post.Post
+	58, // 16: post.Post.deleted_at:type_name -> google.protobuf.Timestamp
+	58, // 17: post.Post.edited_at:type_name -> google.protobuf.Timestamp
+	1,  // 18: post.Post.status:type_name -> post.PostStatus
+	58, // 19: post.Post.publish_at:type_name -> google.protobuf.Timestamp
+	0,  // 20: post.Post.visibility:type_name -> post.PostVisibility
+	0,  // 21: post.SaveDraftRequest.visibility:type_name -> post.PostVisibility
+	58, // 22: post.SchedulePostRequest.publish_at:type_name -> google.protobuf.Timestamp
+	58, // 23: post.PollInput.expires_at:type_name -> google.protobuf.Timestamp
+	36, // 24: post.Poll.options:type_name -> post.PollOption
+	58, // 25: post.Poll.expires_at:type_name -> google.protobuf.Timestamp
+	58, // 26: post.PostRevision.edited_at:type_name -> google.protobuf.Timestamp
+	39, // 27: post.PostRevisionEdge.node:type_name -> post.PostRevision
+	40, // 28: post.PostRevisionConnection.edges:type_name -> post.PostRevisionEdge
+	50, // 29: post.PostRevisionConnection.page_info:type_name -> post.PageInfo
+	58, // 30: post.Media.created_at:type_name -> google.protobuf.Timestamp
+	30, // 31: post.PostEdge.node:type_name -> post.Post
+	49, // 32: post.PostConnection.edges:type_name -> post.PostEdge
+	50, // 33: post.PostConnection.page_info:type_name -> post.PageInfo
+	56, // 34: post.GetPostsCountsResponse.counts:type_name -> post.UserPostsCount
+	2,  // 35: post.PostService.CreatePost:input_type -> post.CreatePostRequest
+	3,  // 36: post.PostService.GetPost:input_type -> post.GetPostRequest
+	4,  // 37: post.PostService.GetPostsByIds:input_type -> post.GetPostsByIdsRequest
+	6,  // 38: post.PostService.UpdatePost:input_type -> post.UpdatePostRequest
+	7,  // 39: post.PostService.DeletePost:input_type -> post.DeletePostRequest
+	8,  // 40: post.PostService.GetUserPosts:input_type -> post.GetUserPostsRequest
+	22, // 41: post.PostService.ListPublicPosts:input_type -> post.ListPublicPostsRequest
+	25, // 42: post.PostService.GetPostsByHashtag:input_type -> post.GetPostsByHashtagRequest
+	27, // 43: post.PostService.GetTrendingHashtags:input_type -> post.GetTrendingHashtagsRequest
+	26, // 44: post.PostService.GetPostRevisions:input_type -> post.GetPostRevisionsRequest
+	42, // 45: post.PostService.Repost:input_type -> post.RepostRequest
+	43, // 46: post.PostService.UndoRepost:input_type -> post.UndoRepostRequest
+	31, // 47: post.PostService.SaveDraft:input_type -> post.SaveDraftRequest
+	32, // 48: post.PostService.ListDrafts:input_type -> post.ListDraftsRequest
+	33, // 49: post.PostService.SchedulePost:input_type -> post.SchedulePostRequest
+	37, // 50: post.PostService.VotePoll:input_type -> post.VotePollRequest
+	38, // 51: post.PostService.GetPollResults:input_type -> post.GetPollResultsRequest
+	46, // 52: post.PostService.UploadMedia:input_type -> post.UploadMediaRequest
+	47, // 53: post.PostService.GetMediaContent:input_type -> post.GetMediaContentRequest
+	53, // 54: post.PostService.ExportMyData:input_type -> post.ExportMyDataRequest
+	55, // 55: post.PostService.GetPostsCounts:input_type -> post.GetPostsCountsRequest
+	9,  // 56: post.PostService.ReplayPostEvents:input_type -> post.ReplayPostEventsRequest
+	11, // 57: post.PostService.SetPostCounters:input_type -> post.SetPostCountersRequest
+	12, // 58: post.PostService.RecountPost:input_type -> post.RecountPostRequest
+	14, // 59: post.PostService.ReconcilePostCounters:input_type -> post.ReconcilePostCountersRequest
+	17, // 60: post.PostService.ImportPosts:input_type -> post.ImportPostsRequest
+	19, // 61: post.PostService.PurgeDeletedPosts:input_type -> post.PurgeDeletedPostsRequest
+	21, // 62: post.PostService.RemovePost:input_type -> post.RemovePostRequest
+	30, // 63: post.PostService.CreatePost:output_type -> post.Post
+	30, // 64: post.PostService.GetPost:output_type -> post.Post
+	5,  // 65: post.PostService.GetPostsByIds:output_type -> post.GetPostsByIdsResponse
+	30, // 66: post.PostService.UpdatePost:output_type -> post.Post
+	52, // 67: post.PostService.DeletePost:output_type -> post.Response
+	51, // 68: post.PostService.GetUserPosts:output_type -> post.PostConnection
+	24, // 69: post.PostService.ListPublicPosts:output_type -> post.ListPublicPostsResponse
+	51, // 70: post.PostService.GetPostsByHashtag:output_type -> post.PostConnection
+	29, // 71: post.PostService.GetTrendingHashtags:output_type -> post.GetTrendingHashtagsResponse
+	41, // 72: post.PostService.GetPostRevisions:output_type -> post.PostRevisionConnection
+	52, // 73: post.PostService.Repost:output_type -> post.Response
+	52, // 74: post.PostService.UndoRepost:output_type -> post.Response
+	30, // 75: post.PostService.SaveDraft:output_type -> post.Post
+	51, // 76: post.PostService.ListDrafts:output_type -> post.PostConnection
+	30, // 77: post.PostService.SchedulePost:output_type -> post.Post
+	35, // 78: post.PostService.VotePoll:output_type -> post.Poll
+	35, // 79: post.PostService.GetPollResults:output_type -> post.Poll
+	45, // 80: post.PostService.UploadMedia:output_type -> post.Media
+	48, // 81: post.PostService.GetMediaContent:output_type -> post.MediaChunk
+	54, // 82: post.PostService.ExportMyData:output_type -> post.DataExport
+	57, // 83: post.PostService.GetPostsCounts:output_type -> post.GetPostsCountsResponse
+	10, // 84: post.PostService.ReplayPostEvents:output_type -> post.ReplayPostEventsResponse
+	52, // 85: post.PostService.SetPostCounters:output_type -> post.Response
+	13, // 86: post.PostService.RecountPost:output_type -> post.PostCounters
+	15, // 87: post.PostService.ReconcilePostCounters:output_type -> post.ReconcilePostCountersResponse
+	18, // 88: post.PostService.ImportPosts:output_type -> post.ImportPostsResponse
+	20, // 89: post.PostService.PurgeDeletedPosts:output_type -> post.PurgeDeletedPostsResponse
+	52, // 90: post.PostService.RemovePost:output_type -> post.Response
+	63, // [63:91] is the sub-list for method output_type
+	35, // [35:63] is the sub-list for method input_type
+	35, // [35:35] is the sub-list for extension type_name
+	35, // [35:35] is the sub-list for extension extendee
+	0,  // [0:35] is the sub-list for field type_name
 }
 
 func init() { file_proto_post_proto_init() }
@@ -964,22 +3802,34 @@ func file_proto_post_proto_init() {
 	if File_proto_post_proto != nil {
 		return
 	}
+	file_proto_post_proto_msgTypes[0].OneofWrappers = []any{}
 	file_proto_post_proto_msgTypes[1].OneofWrappers = []any{}
-	file_proto_post_proto_msgTypes[4].OneofWrappers = []any{}
-	file_proto_post_proto_msgTypes[9].OneofWrappers = []any{}
-	file_proto_post_proto_msgTypes[11].OneofWrappers = []any{}
+	file_proto_post_proto_msgTypes[2].OneofWrappers = []any{}
+	file_proto_post_proto_msgTypes[6].OneofWrappers = []any{}
+	file_proto_post_proto_msgTypes[7].OneofWrappers = []any{}
+	file_proto_post_proto_msgTypes[20].OneofWrappers = []any{}
+	file_proto_post_proto_msgTypes[22].OneofWrappers = []any{}
+	file_proto_post_proto_msgTypes[23].OneofWrappers = []any{}
+	file_proto_post_proto_msgTypes[24].OneofWrappers = []any{}
+	file_proto_post_proto_msgTypes[28].OneofWrappers = []any{}
+	file_proto_post_proto_msgTypes[29].OneofWrappers = []any{}
+	file_proto_post_proto_msgTypes[30].OneofWrappers = []any{}
+	file_proto_post_proto_msgTypes[33].OneofWrappers = []any{}
+	file_proto_post_proto_msgTypes[36].OneofWrappers = []any{}
+	file_proto_post_proto_msgTypes[48].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_post_proto_rawDesc), len(file_proto_post_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   14,
+			NumEnums:      2,
+			NumMessages:   56,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_proto_post_proto_goTypes,
 		DependencyIndexes: file_proto_post_proto_depIdxs,
+		EnumInfos:         file_proto_post_proto_enumTypes,
 		MessageInfos:      file_proto_post_proto_msgTypes,
 	}.Build()
 	File_proto_post_proto = out.File
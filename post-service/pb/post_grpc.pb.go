@@ -19,15 +19,34 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	PostService_CreatePost_FullMethodName             = "/post.PostService/CreatePost"
-	PostService_GetPost_FullMethodName                = "/post.PostService/GetPost"
-	PostService_UpdatePost_FullMethodName             = "/post.PostService/UpdatePost"
-	PostService_DeletePost_FullMethodName             = "/post.PostService/DeletePost"
-	PostService_GetUserPosts_FullMethodName           = "/post.PostService/GetUserPosts"
-	PostService_IncrementCommentsCount_FullMethodName = "/post.PostService/IncrementCommentsCount"
-	PostService_DecrementCommentsCount_FullMethodName = "/post.PostService/DecrementCommentsCount"
-	PostService_IncrementLikesCount_FullMethodName    = "/post.PostService/IncrementLikesCount"
-	PostService_DecrementLikesCount_FullMethodName    = "/post.PostService/DecrementLikesCount"
+	PostService_CreatePost_FullMethodName            = "/post.PostService/CreatePost"
+	PostService_GetPost_FullMethodName               = "/post.PostService/GetPost"
+	PostService_GetPostsByIds_FullMethodName         = "/post.PostService/GetPostsByIds"
+	PostService_UpdatePost_FullMethodName            = "/post.PostService/UpdatePost"
+	PostService_DeletePost_FullMethodName            = "/post.PostService/DeletePost"
+	PostService_GetUserPosts_FullMethodName          = "/post.PostService/GetUserPosts"
+	PostService_ListPublicPosts_FullMethodName       = "/post.PostService/ListPublicPosts"
+	PostService_GetPostsByHashtag_FullMethodName     = "/post.PostService/GetPostsByHashtag"
+	PostService_GetTrendingHashtags_FullMethodName   = "/post.PostService/GetTrendingHashtags"
+	PostService_GetPostRevisions_FullMethodName      = "/post.PostService/GetPostRevisions"
+	PostService_Repost_FullMethodName                = "/post.PostService/Repost"
+	PostService_UndoRepost_FullMethodName            = "/post.PostService/UndoRepost"
+	PostService_SaveDraft_FullMethodName             = "/post.PostService/SaveDraft"
+	PostService_ListDrafts_FullMethodName            = "/post.PostService/ListDrafts"
+	PostService_SchedulePost_FullMethodName          = "/post.PostService/SchedulePost"
+	PostService_VotePoll_FullMethodName              = "/post.PostService/VotePoll"
+	PostService_GetPollResults_FullMethodName        = "/post.PostService/GetPollResults"
+	PostService_UploadMedia_FullMethodName           = "/post.PostService/UploadMedia"
+	PostService_GetMediaContent_FullMethodName       = "/post.PostService/GetMediaContent"
+	PostService_ExportMyData_FullMethodName          = "/post.PostService/ExportMyData"
+	PostService_GetPostsCounts_FullMethodName        = "/post.PostService/GetPostsCounts"
+	PostService_ReplayPostEvents_FullMethodName      = "/post.PostService/ReplayPostEvents"
+	PostService_SetPostCounters_FullMethodName       = "/post.PostService/SetPostCounters"
+	PostService_RecountPost_FullMethodName           = "/post.PostService/RecountPost"
+	PostService_ReconcilePostCounters_FullMethodName = "/post.PostService/ReconcilePostCounters"
+	PostService_ImportPosts_FullMethodName           = "/post.PostService/ImportPosts"
+	PostService_PurgeDeletedPosts_FullMethodName     = "/post.PostService/PurgeDeletedPosts"
+	PostService_RemovePost_FullMethodName            = "/post.PostService/RemovePost"
 )
 
 // PostServiceClient is the client API for PostService service.
@@ -36,13 +55,50 @@ const (
 type PostServiceClient interface {
 	CreatePost(ctx context.Context, in *CreatePostRequest, opts ...grpc.CallOption) (*Post, error)
 	GetPost(ctx context.Context, in *GetPostRequest, opts ...grpc.CallOption) (*Post, error)
+	// The posts of up to 100 IDs that the requesting user may see, in the
+	// order asked for; the others are left out
+	GetPostsByIds(ctx context.Context, in *GetPostsByIdsRequest, opts ...grpc.CallOption) (*GetPostsByIdsResponse, error)
 	UpdatePost(ctx context.Context, in *UpdatePostRequest, opts ...grpc.CallOption) (*Post, error)
 	DeletePost(ctx context.Context, in *DeletePostRequest, opts ...grpc.CallOption) (*Response, error)
 	GetUserPosts(ctx context.Context, in *GetUserPostsRequest, opts ...grpc.CallOption) (*PostConnection, error)
-	IncrementCommentsCount(ctx context.Context, in *IncrementCommentsCountRequest, opts ...grpc.CallOption) (*Response, error)
-	DecrementCommentsCount(ctx context.Context, in *DecrementCommentsCountRequest, opts ...grpc.CallOption) (*Response, error)
-	IncrementLikesCount(ctx context.Context, in *IncrementLikesCountRequest, opts ...grpc.CallOption) (*Response, error)
-	DecrementLikesCount(ctx context.Context, in *DecrementLikesCountRequest, opts ...grpc.CallOption) (*Response, error)
+	ListPublicPosts(ctx context.Context, in *ListPublicPostsRequest, opts ...grpc.CallOption) (*ListPublicPostsResponse, error)
+	GetPostsByHashtag(ctx context.Context, in *GetPostsByHashtagRequest, opts ...grpc.CallOption) (*PostConnection, error)
+	GetTrendingHashtags(ctx context.Context, in *GetTrendingHashtagsRequest, opts ...grpc.CallOption) (*GetTrendingHashtagsResponse, error)
+	GetPostRevisions(ctx context.Context, in *GetPostRevisionsRequest, opts ...grpc.CallOption) (*PostRevisionConnection, error)
+	// Reposts are made as the authenticated user
+	Repost(ctx context.Context, in *RepostRequest, opts ...grpc.CallOption) (*Response, error)
+	UndoRepost(ctx context.Context, in *UndoRepostRequest, opts ...grpc.CallOption) (*Response, error)
+	// Drafts and scheduled posts belong to the authenticated user and are only
+	// visible to them until published
+	SaveDraft(ctx context.Context, in *SaveDraftRequest, opts ...grpc.CallOption) (*Post, error)
+	ListDrafts(ctx context.Context, in *ListDraftsRequest, opts ...grpc.CallOption) (*PostConnection, error)
+	SchedulePost(ctx context.Context, in *SchedulePostRequest, opts ...grpc.CallOption) (*Post, error)
+	// Polls are made with CreatePost. Votes are cast as the authenticated
+	// user, once per poll, until the poll expires.
+	VotePoll(ctx context.Context, in *VotePollRequest, opts ...grpc.CallOption) (*Poll, error)
+	GetPollResults(ctx context.Context, in *GetPollResultsRequest, opts ...grpc.CallOption) (*Poll, error)
+	// Media uploads. Uploads are streamed in chunks and attached to a post by
+	// passing their IDs to CreatePost.
+	UploadMedia(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[UploadMediaRequest, Media], error)
+	GetMediaContent(ctx context.Context, in *GetMediaContentRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[MediaChunk], error)
+	// The caller's posts, drafts, reposts and poll votes, for their data export
+	ExportMyData(ctx context.Context, in *ExportMyDataRequest, opts ...grpc.CallOption) (*DataExport, error)
+	// Counts of published posts as stored, which user-service reconciles its
+	// counters with (requires the ADMIN role)
+	GetPostsCounts(ctx context.Context, in *GetPostsCountsRequest, opts ...grpc.CallOption) (*GetPostsCountsResponse, error)
+	// Admin operations (require the ADMIN role)
+	ReplayPostEvents(ctx context.Context, in *ReplayPostEventsRequest, opts ...grpc.CallOption) (*ReplayPostEventsResponse, error)
+	SetPostCounters(ctx context.Context, in *SetPostCountersRequest, opts ...grpc.CallOption) (*Response, error)
+	// RecountPost recomputes a post's like and comment counters from
+	// like-service and comment-service
+	RecountPost(ctx context.Context, in *RecountPostRequest, opts ...grpc.CallOption) (*PostCounters, error)
+	// ReconcilePostCounters recounts every post and corrects counters that
+	// have drifted. It is run on a schedule by scheduler-service.
+	ReconcilePostCounters(ctx context.Context, in *ReconcilePostCountersRequest, opts ...grpc.CallOption) (*ReconcilePostCountersResponse, error)
+	ImportPosts(ctx context.Context, in *ImportPostsRequest, opts ...grpc.CallOption) (*ImportPostsResponse, error)
+	PurgeDeletedPosts(ctx context.Context, in *PurgeDeletedPostsRequest, opts ...grpc.CallOption) (*PurgeDeletedPostsResponse, error)
+	// RemovePost deletes any user's post, for moderation
+	RemovePost(ctx context.Context, in *RemovePostRequest, opts ...grpc.CallOption) (*Response, error)
 }
 
 type postServiceClient struct {
@@ -73,6 +129,16 @@ func (c *postServiceClient) GetPost(ctx context.Context, in *GetPostRequest, opt
 	return out, nil
 }
 
+func (c *postServiceClient) GetPostsByIds(ctx context.Context, in *GetPostsByIdsRequest, opts ...grpc.CallOption) (*GetPostsByIdsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPostsByIdsResponse)
+	err := c.cc.Invoke(ctx, PostService_GetPostsByIds_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *postServiceClient) UpdatePost(ctx context.Context, in *UpdatePostRequest, opts ...grpc.CallOption) (*Post, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(Post)
@@ -103,40 +169,232 @@ func (c *postServiceClient) GetUserPosts(ctx context.Context, in *GetUserPostsRe
 	return out, nil
 }
 
-func (c *postServiceClient) IncrementCommentsCount(ctx context.Context, in *IncrementCommentsCountRequest, opts ...grpc.CallOption) (*Response, error) {
+func (c *postServiceClient) ListPublicPosts(ctx context.Context, in *ListPublicPostsRequest, opts ...grpc.CallOption) (*ListPublicPostsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPublicPostsResponse)
+	err := c.cc.Invoke(ctx, PostService_ListPublicPosts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *postServiceClient) GetPostsByHashtag(ctx context.Context, in *GetPostsByHashtagRequest, opts ...grpc.CallOption) (*PostConnection, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PostConnection)
+	err := c.cc.Invoke(ctx, PostService_GetPostsByHashtag_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *postServiceClient) GetTrendingHashtags(ctx context.Context, in *GetTrendingHashtagsRequest, opts ...grpc.CallOption) (*GetTrendingHashtagsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTrendingHashtagsResponse)
+	err := c.cc.Invoke(ctx, PostService_GetTrendingHashtags_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *postServiceClient) GetPostRevisions(ctx context.Context, in *GetPostRevisionsRequest, opts ...grpc.CallOption) (*PostRevisionConnection, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PostRevisionConnection)
+	err := c.cc.Invoke(ctx, PostService_GetPostRevisions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *postServiceClient) Repost(ctx context.Context, in *RepostRequest, opts ...grpc.CallOption) (*Response, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(Response)
-	err := c.cc.Invoke(ctx, PostService_IncrementCommentsCount_FullMethodName, in, out, cOpts...)
+	err := c.cc.Invoke(ctx, PostService_Repost_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *postServiceClient) DecrementCommentsCount(ctx context.Context, in *DecrementCommentsCountRequest, opts ...grpc.CallOption) (*Response, error) {
+func (c *postServiceClient) UndoRepost(ctx context.Context, in *UndoRepostRequest, opts ...grpc.CallOption) (*Response, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(Response)
-	err := c.cc.Invoke(ctx, PostService_DecrementCommentsCount_FullMethodName, in, out, cOpts...)
+	err := c.cc.Invoke(ctx, PostService_UndoRepost_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *postServiceClient) SaveDraft(ctx context.Context, in *SaveDraftRequest, opts ...grpc.CallOption) (*Post, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Post)
+	err := c.cc.Invoke(ctx, PostService_SaveDraft_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *postServiceClient) ListDrafts(ctx context.Context, in *ListDraftsRequest, opts ...grpc.CallOption) (*PostConnection, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PostConnection)
+	err := c.cc.Invoke(ctx, PostService_ListDrafts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *postServiceClient) SchedulePost(ctx context.Context, in *SchedulePostRequest, opts ...grpc.CallOption) (*Post, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Post)
+	err := c.cc.Invoke(ctx, PostService_SchedulePost_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *postServiceClient) VotePoll(ctx context.Context, in *VotePollRequest, opts ...grpc.CallOption) (*Poll, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Poll)
+	err := c.cc.Invoke(ctx, PostService_VotePoll_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *postServiceClient) GetPollResults(ctx context.Context, in *GetPollResultsRequest, opts ...grpc.CallOption) (*Poll, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Poll)
+	err := c.cc.Invoke(ctx, PostService_GetPollResults_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *postServiceClient) UploadMedia(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[UploadMediaRequest, Media], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &PostService_ServiceDesc.Streams[0], PostService_UploadMedia_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[UploadMediaRequest, Media]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PostService_UploadMediaClient = grpc.ClientStreamingClient[UploadMediaRequest, Media]
+
+func (c *postServiceClient) GetMediaContent(ctx context.Context, in *GetMediaContentRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[MediaChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &PostService_ServiceDesc.Streams[1], PostService_GetMediaContent_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[GetMediaContentRequest, MediaChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PostService_GetMediaContentClient = grpc.ServerStreamingClient[MediaChunk]
+
+func (c *postServiceClient) ExportMyData(ctx context.Context, in *ExportMyDataRequest, opts ...grpc.CallOption) (*DataExport, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DataExport)
+	err := c.cc.Invoke(ctx, PostService_ExportMyData_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *postServiceClient) GetPostsCounts(ctx context.Context, in *GetPostsCountsRequest, opts ...grpc.CallOption) (*GetPostsCountsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPostsCountsResponse)
+	err := c.cc.Invoke(ctx, PostService_GetPostsCounts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *postServiceClient) ReplayPostEvents(ctx context.Context, in *ReplayPostEventsRequest, opts ...grpc.CallOption) (*ReplayPostEventsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReplayPostEventsResponse)
+	err := c.cc.Invoke(ctx, PostService_ReplayPostEvents_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *postServiceClient) IncrementLikesCount(ctx context.Context, in *IncrementLikesCountRequest, opts ...grpc.CallOption) (*Response, error) {
+func (c *postServiceClient) SetPostCounters(ctx context.Context, in *SetPostCountersRequest, opts ...grpc.CallOption) (*Response, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(Response)
-	err := c.cc.Invoke(ctx, PostService_IncrementLikesCount_FullMethodName, in, out, cOpts...)
+	err := c.cc.Invoke(ctx, PostService_SetPostCounters_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *postServiceClient) RecountPost(ctx context.Context, in *RecountPostRequest, opts ...grpc.CallOption) (*PostCounters, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PostCounters)
+	err := c.cc.Invoke(ctx, PostService_RecountPost_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *postServiceClient) ReconcilePostCounters(ctx context.Context, in *ReconcilePostCountersRequest, opts ...grpc.CallOption) (*ReconcilePostCountersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReconcilePostCountersResponse)
+	err := c.cc.Invoke(ctx, PostService_ReconcilePostCounters_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *postServiceClient) ImportPosts(ctx context.Context, in *ImportPostsRequest, opts ...grpc.CallOption) (*ImportPostsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ImportPostsResponse)
+	err := c.cc.Invoke(ctx, PostService_ImportPosts_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *postServiceClient) DecrementLikesCount(ctx context.Context, in *DecrementLikesCountRequest, opts ...grpc.CallOption) (*Response, error) {
+func (c *postServiceClient) PurgeDeletedPosts(ctx context.Context, in *PurgeDeletedPostsRequest, opts ...grpc.CallOption) (*PurgeDeletedPostsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PurgeDeletedPostsResponse)
+	err := c.cc.Invoke(ctx, PostService_PurgeDeletedPosts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *postServiceClient) RemovePost(ctx context.Context, in *RemovePostRequest, opts ...grpc.CallOption) (*Response, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(Response)
-	err := c.cc.Invoke(ctx, PostService_DecrementLikesCount_FullMethodName, in, out, cOpts...)
+	err := c.cc.Invoke(ctx, PostService_RemovePost_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -149,13 +407,50 @@ func (c *postServiceClient) DecrementLikesCount(ctx context.Context, in *Decreme
 type PostServiceServer interface {
 	CreatePost(context.Context, *CreatePostRequest) (*Post, error)
 	GetPost(context.Context, *GetPostRequest) (*Post, error)
+	// The posts of up to 100 IDs that the requesting user may see, in the
+	// order asked for; the others are left out
+	GetPostsByIds(context.Context, *GetPostsByIdsRequest) (*GetPostsByIdsResponse, error)
 	UpdatePost(context.Context, *UpdatePostRequest) (*Post, error)
 	DeletePost(context.Context, *DeletePostRequest) (*Response, error)
 	GetUserPosts(context.Context, *GetUserPostsRequest) (*PostConnection, error)
-	IncrementCommentsCount(context.Context, *IncrementCommentsCountRequest) (*Response, error)
-	DecrementCommentsCount(context.Context, *DecrementCommentsCountRequest) (*Response, error)
-	IncrementLikesCount(context.Context, *IncrementLikesCountRequest) (*Response, error)
-	DecrementLikesCount(context.Context, *DecrementLikesCountRequest) (*Response, error)
+	ListPublicPosts(context.Context, *ListPublicPostsRequest) (*ListPublicPostsResponse, error)
+	GetPostsByHashtag(context.Context, *GetPostsByHashtagRequest) (*PostConnection, error)
+	GetTrendingHashtags(context.Context, *GetTrendingHashtagsRequest) (*GetTrendingHashtagsResponse, error)
+	GetPostRevisions(context.Context, *GetPostRevisionsRequest) (*PostRevisionConnection, error)
+	// Reposts are made as the authenticated user
+	Repost(context.Context, *RepostRequest) (*Response, error)
+	UndoRepost(context.Context, *UndoRepostRequest) (*Response, error)
+	// Drafts and scheduled posts belong to the authenticated user and are only
+	// visible to them until published
+	SaveDraft(context.Context, *SaveDraftRequest) (*Post, error)
+	ListDrafts(context.Context, *ListDraftsRequest) (*PostConnection, error)
+	SchedulePost(context.Context, *SchedulePostRequest) (*Post, error)
+	// Polls are made with CreatePost. Votes are cast as the authenticated
+	// user, once per poll, until the poll expires.
+	VotePoll(context.Context, *VotePollRequest) (*Poll, error)
+	GetPollResults(context.Context, *GetPollResultsRequest) (*Poll, error)
+	// Media uploads. Uploads are streamed in chunks and attached to a post by
+	// passing their IDs to CreatePost.
+	UploadMedia(grpc.ClientStreamingServer[UploadMediaRequest, Media]) error
+	GetMediaContent(*GetMediaContentRequest, grpc.ServerStreamingServer[MediaChunk]) error
+	// The caller's posts, drafts, reposts and poll votes, for their data export
+	ExportMyData(context.Context, *ExportMyDataRequest) (*DataExport, error)
+	// Counts of published posts as stored, which user-service reconciles its
+	// counters with (requires the ADMIN role)
+	GetPostsCounts(context.Context, *GetPostsCountsRequest) (*GetPostsCountsResponse, error)
+	// Admin operations (require the ADMIN role)
+	ReplayPostEvents(context.Context, *ReplayPostEventsRequest) (*ReplayPostEventsResponse, error)
+	SetPostCounters(context.Context, *SetPostCountersRequest) (*Response, error)
+	// RecountPost recomputes a post's like and comment counters from
+	// like-service and comment-service
+	RecountPost(context.Context, *RecountPostRequest) (*PostCounters, error)
+	// ReconcilePostCounters recounts every post and corrects counters that
+	// have drifted. It is run on a schedule by scheduler-service.
+	ReconcilePostCounters(context.Context, *ReconcilePostCountersRequest) (*ReconcilePostCountersResponse, error)
+	ImportPosts(context.Context, *ImportPostsRequest) (*ImportPostsResponse, error)
+	PurgeDeletedPosts(context.Context, *PurgeDeletedPostsRequest) (*PurgeDeletedPostsResponse, error)
+	// RemovePost deletes any user's post, for moderation
+	RemovePost(context.Context, *RemovePostRequest) (*Response, error)
 	mustEmbedUnimplementedPostServiceServer()
 }
 
@@ -172,6 +467,9 @@ func (UnimplementedPostServiceServer) CreatePost(context.Context, *CreatePostReq
 func (UnimplementedPostServiceServer) GetPost(context.Context, *GetPostRequest) (*Post, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetPost not implemented")
 }
+func (UnimplementedPostServiceServer) GetPostsByIds(context.Context, *GetPostsByIdsRequest) (*GetPostsByIdsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPostsByIds not implemented")
+}
 func (UnimplementedPostServiceServer) UpdatePost(context.Context, *UpdatePostRequest) (*Post, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdatePost not implemented")
 }
@@ -181,17 +479,71 @@ func (UnimplementedPostServiceServer) DeletePost(context.Context, *DeletePostReq
 func (UnimplementedPostServiceServer) GetUserPosts(context.Context, *GetUserPostsRequest) (*PostConnection, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetUserPosts not implemented")
 }
-func (UnimplementedPostServiceServer) IncrementCommentsCount(context.Context, *IncrementCommentsCountRequest) (*Response, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method IncrementCommentsCount not implemented")
+func (UnimplementedPostServiceServer) ListPublicPosts(context.Context, *ListPublicPostsRequest) (*ListPublicPostsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPublicPosts not implemented")
+}
+func (UnimplementedPostServiceServer) GetPostsByHashtag(context.Context, *GetPostsByHashtagRequest) (*PostConnection, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPostsByHashtag not implemented")
+}
+func (UnimplementedPostServiceServer) GetTrendingHashtags(context.Context, *GetTrendingHashtagsRequest) (*GetTrendingHashtagsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTrendingHashtags not implemented")
+}
+func (UnimplementedPostServiceServer) GetPostRevisions(context.Context, *GetPostRevisionsRequest) (*PostRevisionConnection, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPostRevisions not implemented")
+}
+func (UnimplementedPostServiceServer) Repost(context.Context, *RepostRequest) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Repost not implemented")
+}
+func (UnimplementedPostServiceServer) UndoRepost(context.Context, *UndoRepostRequest) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UndoRepost not implemented")
+}
+func (UnimplementedPostServiceServer) SaveDraft(context.Context, *SaveDraftRequest) (*Post, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SaveDraft not implemented")
 }
-func (UnimplementedPostServiceServer) DecrementCommentsCount(context.Context, *DecrementCommentsCountRequest) (*Response, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DecrementCommentsCount not implemented")
+func (UnimplementedPostServiceServer) ListDrafts(context.Context, *ListDraftsRequest) (*PostConnection, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDrafts not implemented")
 }
-func (UnimplementedPostServiceServer) IncrementLikesCount(context.Context, *IncrementLikesCountRequest) (*Response, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method IncrementLikesCount not implemented")
+func (UnimplementedPostServiceServer) SchedulePost(context.Context, *SchedulePostRequest) (*Post, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SchedulePost not implemented")
 }
-func (UnimplementedPostServiceServer) DecrementLikesCount(context.Context, *DecrementLikesCountRequest) (*Response, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DecrementLikesCount not implemented")
+func (UnimplementedPostServiceServer) VotePoll(context.Context, *VotePollRequest) (*Poll, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VotePoll not implemented")
+}
+func (UnimplementedPostServiceServer) GetPollResults(context.Context, *GetPollResultsRequest) (*Poll, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPollResults not implemented")
+}
+func (UnimplementedPostServiceServer) UploadMedia(grpc.ClientStreamingServer[UploadMediaRequest, Media]) error {
+	return status.Errorf(codes.Unimplemented, "method UploadMedia not implemented")
+}
+func (UnimplementedPostServiceServer) GetMediaContent(*GetMediaContentRequest, grpc.ServerStreamingServer[MediaChunk]) error {
+	return status.Errorf(codes.Unimplemented, "method GetMediaContent not implemented")
+}
+func (UnimplementedPostServiceServer) ExportMyData(context.Context, *ExportMyDataRequest) (*DataExport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportMyData not implemented")
+}
+func (UnimplementedPostServiceServer) GetPostsCounts(context.Context, *GetPostsCountsRequest) (*GetPostsCountsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPostsCounts not implemented")
+}
+func (UnimplementedPostServiceServer) ReplayPostEvents(context.Context, *ReplayPostEventsRequest) (*ReplayPostEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReplayPostEvents not implemented")
+}
+func (UnimplementedPostServiceServer) SetPostCounters(context.Context, *SetPostCountersRequest) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetPostCounters not implemented")
+}
+func (UnimplementedPostServiceServer) RecountPost(context.Context, *RecountPostRequest) (*PostCounters, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecountPost not implemented")
+}
+func (UnimplementedPostServiceServer) ReconcilePostCounters(context.Context, *ReconcilePostCountersRequest) (*ReconcilePostCountersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReconcilePostCounters not implemented")
+}
+func (UnimplementedPostServiceServer) ImportPosts(context.Context, *ImportPostsRequest) (*ImportPostsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportPosts not implemented")
+}
+func (UnimplementedPostServiceServer) PurgeDeletedPosts(context.Context, *PurgeDeletedPostsRequest) (*PurgeDeletedPostsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PurgeDeletedPosts not implemented")
+}
+func (UnimplementedPostServiceServer) RemovePost(context.Context, *RemovePostRequest) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemovePost not implemented")
 }
 func (UnimplementedPostServiceServer) mustEmbedUnimplementedPostServiceServer() {}
 func (UnimplementedPostServiceServer) testEmbeddedByValue()                     {}
@@ -250,6 +602,24 @@ func _PostService_GetPost_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PostService_GetPostsByIds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPostsByIdsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostServiceServer).GetPostsByIds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PostService_GetPostsByIds_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostServiceServer).GetPostsByIds(ctx, req.(*GetPostsByIdsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _PostService_UpdatePost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(UpdatePostRequest)
 	if err := dec(in); err != nil {
@@ -304,74 +674,380 @@ func _PostService_GetUserPosts_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
-func _PostService_IncrementCommentsCount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(IncrementCommentsCountRequest)
+func _PostService_ListPublicPosts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPublicPostsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(PostServiceServer).IncrementCommentsCount(ctx, in)
+		return srv.(PostServiceServer).ListPublicPosts(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: PostService_IncrementCommentsCount_FullMethodName,
+		FullMethod: PostService_ListPublicPosts_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PostServiceServer).IncrementCommentsCount(ctx, req.(*IncrementCommentsCountRequest))
+		return srv.(PostServiceServer).ListPublicPosts(ctx, req.(*ListPublicPostsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _PostService_DecrementCommentsCount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DecrementCommentsCountRequest)
+func _PostService_GetPostsByHashtag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPostsByHashtagRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(PostServiceServer).DecrementCommentsCount(ctx, in)
+		return srv.(PostServiceServer).GetPostsByHashtag(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: PostService_DecrementCommentsCount_FullMethodName,
+		FullMethod: PostService_GetPostsByHashtag_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PostServiceServer).DecrementCommentsCount(ctx, req.(*DecrementCommentsCountRequest))
+		return srv.(PostServiceServer).GetPostsByHashtag(ctx, req.(*GetPostsByHashtagRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _PostService_IncrementLikesCount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(IncrementLikesCountRequest)
+func _PostService_GetTrendingHashtags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTrendingHashtagsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(PostServiceServer).IncrementLikesCount(ctx, in)
+		return srv.(PostServiceServer).GetTrendingHashtags(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: PostService_IncrementLikesCount_FullMethodName,
+		FullMethod: PostService_GetTrendingHashtags_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PostServiceServer).IncrementLikesCount(ctx, req.(*IncrementLikesCountRequest))
+		return srv.(PostServiceServer).GetTrendingHashtags(ctx, req.(*GetTrendingHashtagsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _PostService_DecrementLikesCount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DecrementLikesCountRequest)
+func _PostService_GetPostRevisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPostRevisionsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(PostServiceServer).DecrementLikesCount(ctx, in)
+		return srv.(PostServiceServer).GetPostRevisions(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: PostService_DecrementLikesCount_FullMethodName,
+		FullMethod: PostService_GetPostRevisions_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PostServiceServer).DecrementLikesCount(ctx, req.(*DecrementLikesCountRequest))
+		return srv.(PostServiceServer).GetPostRevisions(ctx, req.(*GetPostRevisionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PostService_Repost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RepostRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostServiceServer).Repost(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PostService_Repost_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostServiceServer).Repost(ctx, req.(*RepostRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PostService_UndoRepost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UndoRepostRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostServiceServer).UndoRepost(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PostService_UndoRepost_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostServiceServer).UndoRepost(ctx, req.(*UndoRepostRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PostService_SaveDraft_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SaveDraftRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostServiceServer).SaveDraft(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PostService_SaveDraft_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostServiceServer).SaveDraft(ctx, req.(*SaveDraftRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PostService_ListDrafts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDraftsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostServiceServer).ListDrafts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PostService_ListDrafts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostServiceServer).ListDrafts(ctx, req.(*ListDraftsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PostService_SchedulePost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SchedulePostRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostServiceServer).SchedulePost(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PostService_SchedulePost_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostServiceServer).SchedulePost(ctx, req.(*SchedulePostRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PostService_VotePoll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VotePollRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostServiceServer).VotePoll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PostService_VotePoll_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostServiceServer).VotePoll(ctx, req.(*VotePollRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PostService_GetPollResults_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPollResultsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostServiceServer).GetPollResults(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PostService_GetPollResults_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostServiceServer).GetPollResults(ctx, req.(*GetPollResultsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PostService_UploadMedia_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(PostServiceServer).UploadMedia(&grpc.GenericServerStream[UploadMediaRequest, Media]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PostService_UploadMediaServer = grpc.ClientStreamingServer[UploadMediaRequest, Media]
+
+func _PostService_GetMediaContent_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetMediaContentRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PostServiceServer).GetMediaContent(m, &grpc.GenericServerStream[GetMediaContentRequest, MediaChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PostService_GetMediaContentServer = grpc.ServerStreamingServer[MediaChunk]
+
+func _PostService_ExportMyData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportMyDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostServiceServer).ExportMyData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PostService_ExportMyData_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostServiceServer).ExportMyData(ctx, req.(*ExportMyDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PostService_GetPostsCounts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPostsCountsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostServiceServer).GetPostsCounts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PostService_GetPostsCounts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostServiceServer).GetPostsCounts(ctx, req.(*GetPostsCountsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PostService_ReplayPostEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReplayPostEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostServiceServer).ReplayPostEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PostService_ReplayPostEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostServiceServer).ReplayPostEvents(ctx, req.(*ReplayPostEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PostService_SetPostCounters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetPostCountersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostServiceServer).SetPostCounters(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PostService_SetPostCounters_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostServiceServer).SetPostCounters(ctx, req.(*SetPostCountersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PostService_RecountPost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecountPostRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostServiceServer).RecountPost(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PostService_RecountPost_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostServiceServer).RecountPost(ctx, req.(*RecountPostRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PostService_ReconcilePostCounters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReconcilePostCountersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostServiceServer).ReconcilePostCounters(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PostService_ReconcilePostCounters_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostServiceServer).ReconcilePostCounters(ctx, req.(*ReconcilePostCountersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PostService_ImportPosts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportPostsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostServiceServer).ImportPosts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PostService_ImportPosts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostServiceServer).ImportPosts(ctx, req.(*ImportPostsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PostService_PurgeDeletedPosts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PurgeDeletedPostsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostServiceServer).PurgeDeletedPosts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PostService_PurgeDeletedPosts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostServiceServer).PurgeDeletedPosts(ctx, req.(*PurgeDeletedPostsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PostService_RemovePost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemovePostRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostServiceServer).RemovePost(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PostService_RemovePost_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostServiceServer).RemovePost(ctx, req.(*RemovePostRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -391,6 +1067,10 @@ var PostService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetPost",
 			Handler:    _PostService_GetPost_Handler,
 		},
+		{
+			MethodName: "GetPostsByIds",
+			Handler:    _PostService_GetPostsByIds_Handler,
+		},
 		{
 			MethodName: "UpdatePost",
 			Handler:    _PostService_UpdatePost_Handler,
@@ -404,22 +1084,97 @@ var PostService_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _PostService_GetUserPosts_Handler,
 		},
 		{
-			MethodName: "IncrementCommentsCount",
-			Handler:    _PostService_IncrementCommentsCount_Handler,
+			MethodName: "ListPublicPosts",
+			Handler:    _PostService_ListPublicPosts_Handler,
+		},
+		{
+			MethodName: "GetPostsByHashtag",
+			Handler:    _PostService_GetPostsByHashtag_Handler,
 		},
 		{
-			MethodName: "DecrementCommentsCount",
-			Handler:    _PostService_DecrementCommentsCount_Handler,
+			MethodName: "GetTrendingHashtags",
+			Handler:    _PostService_GetTrendingHashtags_Handler,
 		},
 		{
-			MethodName: "IncrementLikesCount",
-			Handler:    _PostService_IncrementLikesCount_Handler,
+			MethodName: "GetPostRevisions",
+			Handler:    _PostService_GetPostRevisions_Handler,
+		},
+		{
+			MethodName: "Repost",
+			Handler:    _PostService_Repost_Handler,
+		},
+		{
+			MethodName: "UndoRepost",
+			Handler:    _PostService_UndoRepost_Handler,
+		},
+		{
+			MethodName: "SaveDraft",
+			Handler:    _PostService_SaveDraft_Handler,
+		},
+		{
+			MethodName: "ListDrafts",
+			Handler:    _PostService_ListDrafts_Handler,
+		},
+		{
+			MethodName: "SchedulePost",
+			Handler:    _PostService_SchedulePost_Handler,
+		},
+		{
+			MethodName: "VotePoll",
+			Handler:    _PostService_VotePoll_Handler,
+		},
+		{
+			MethodName: "GetPollResults",
+			Handler:    _PostService_GetPollResults_Handler,
+		},
+		{
+			MethodName: "ExportMyData",
+			Handler:    _PostService_ExportMyData_Handler,
+		},
+		{
+			MethodName: "GetPostsCounts",
+			Handler:    _PostService_GetPostsCounts_Handler,
+		},
+		{
+			MethodName: "ReplayPostEvents",
+			Handler:    _PostService_ReplayPostEvents_Handler,
+		},
+		{
+			MethodName: "SetPostCounters",
+			Handler:    _PostService_SetPostCounters_Handler,
+		},
+		{
+			MethodName: "RecountPost",
+			Handler:    _PostService_RecountPost_Handler,
+		},
+		{
+			MethodName: "ReconcilePostCounters",
+			Handler:    _PostService_ReconcilePostCounters_Handler,
+		},
+		{
+			MethodName: "ImportPosts",
+			Handler:    _PostService_ImportPosts_Handler,
+		},
+		{
+			MethodName: "PurgeDeletedPosts",
+			Handler:    _PostService_PurgeDeletedPosts_Handler,
+		},
+		{
+			MethodName: "RemovePost",
+			Handler:    _PostService_RemovePost_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "UploadMedia",
+			Handler:       _PostService_UploadMedia_Handler,
+			ClientStreams: true,
 		},
 		{
-			MethodName: "DecrementLikesCount",
-			Handler:    _PostService_DecrementLikesCount_Handler,
+			StreamName:    "GetMediaContent",
+			Handler:       _PostService_GetMediaContent_Handler,
+			ServerStreams: true,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/post.proto",
 }
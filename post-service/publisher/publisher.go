@@ -1,30 +1,103 @@
 package publisher
 
 import (
+	"context"
 	"encoding/json"
-	"log"
 	"post-service/events"
-	natsClient "post-service/nats"
+	"post-service/outbox"
+	eventspb "shared/eventschema/pb"
+	"shared/logging"
 )
 
+// EventPublisher stores events in the outbox, to be relayed to NATS once
+// committed. Publish within the transaction of the change an event describes.
 type EventPublisher struct {
-	nats *natsClient.Client
+	outbox *outbox.Outbox
 }
 
-func NewEventPublisher(nats *natsClient.Client) *EventPublisher {
-	return &EventPublisher{nats: nats}
+func NewEventPublisher(outbox *outbox.Outbox) *EventPublisher {
+	return &EventPublisher{outbox: outbox}
 }
 
-func (p *EventPublisher) PublishPostCreated(event events.PostCreatedEvent) error {
+func (p *EventPublisher) PublishPostCreated(ctx context.Context, event *eventspb.PostCreated) error {
+	if err := p.outbox.AddEvent(ctx, events.PostCreated, event); err != nil {
+		return err
+	}
+
+	logging.FromContext(ctx).Info().Str("subject", events.PostCreated).Str("post_id", event.PostId).Msg("queued event")
+	return nil
+}
+
+func (p *EventPublisher) PublishPostUpdated(ctx context.Context, event *eventspb.PostUpdated) error {
+	if err := p.outbox.AddEvent(ctx, events.PostUpdated, event); err != nil {
+		return err
+	}
+
+	logging.FromContext(ctx).Info().Str("subject", events.PostUpdated).Str("post_id", event.PostId).Msg("queued event")
+	return nil
+}
+
+func (p *EventPublisher) PublishPostDeleted(ctx context.Context, event *eventspb.PostDeleted) error {
+	if err := p.outbox.AddEvent(ctx, events.PostDeleted, event); err != nil {
+		return err
+	}
+
+	logging.FromContext(ctx).Info().Str("subject", events.PostDeleted).Str("post_id", event.PostId).Msg("queued event")
+	return nil
+}
+
+func (p *EventPublisher) PublishMentionCreated(ctx context.Context, event events.MentionCreatedEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	if err := p.outbox.Add(ctx, events.MentionCreated, data); err != nil {
+		return err
+	}
+
+	logging.FromContext(ctx).Info().Str("subject", events.MentionCreated).Stringer("mentioned_user_id", event.MentionedUserID).Stringer("post_id", event.PostID).Msg("queued event")
+	return nil
+}
+
+func (p *EventPublisher) PublishPostReposted(ctx context.Context, event events.PostRepostedEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	if err := p.outbox.Add(ctx, events.PostReposted, data); err != nil {
+		return err
+	}
+
+	logging.FromContext(ctx).Info().Str("subject", events.PostReposted).Stringer("post_id", event.PostID).Msg("queued event")
+	return nil
+}
+
+func (p *EventPublisher) PublishPostUnreposted(ctx context.Context, event events.PostUnrepostedEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	if err := p.outbox.Add(ctx, events.PostUnreposted, data); err != nil {
+		return err
+	}
+
+	logging.FromContext(ctx).Info().Str("subject", events.PostUnreposted).Stringer("post_id", event.PostID).Msg("queued event")
+	return nil
+}
+
+func (p *EventPublisher) PublishContentFlagged(ctx context.Context, event events.ContentFlaggedEvent) error {
 	data, err := json.Marshal(event)
 	if err != nil {
 		return err
 	}
 
-	if err := p.nats.Publish(events.PostCreated, data); err != nil {
+	if err := p.outbox.Add(ctx, events.ContentFlagged, data); err != nil {
 		return err
 	}
 
-	log.Printf("Published event: %s for post %s", events.PostCreated, event.PostID)
+	logging.FromContext(ctx).Info().Str("subject", events.ContentFlagged).Str("content_type", event.ContentType).Stringer("content_id", event.ContentID).Str("action", event.Action).Msg("queued event")
 	return nil
 }
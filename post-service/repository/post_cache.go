@@ -0,0 +1,254 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"post-service/model"
+)
+
+const (
+	postCacheTTL = 10 * time.Minute
+	// Counters are written through on every change rather than evicted. Two
+	// changes racing can reach Redis out of order, which leaves a counter off
+	// until the next change or until it expires.
+	postCountersTTL = time.Minute
+	// First pages are kept for less time in case an invalidation is lost
+	userPostsCacheTTL = 2 * time.Minute
+)
+
+// postCountersFields are the fields of a post's cached counters hash
+var postCountersFields = []string{"likes", "comments", "reposts"}
+
+// postCounters are the counters of a post as they are cached apart from its
+// body. Likes, comments and reposts change far more often than anything
+// else, so a hot post's body stays cached while they change.
+type postCounters struct {
+	PostID        uuid.UUID `db:"id"`
+	LikesCount    int32     `db:"likes_count"`
+	CommentsCount int32     `db:"comments_count"`
+	RepostsCount  int32     `db:"reposts_count"`
+}
+
+func (c postCounters) apply(post *models.Post) {
+	post.LikesCount = c.LikesCount
+	post.CommentsCount = c.CommentsCount
+	post.RepostsCount = c.RepostsCount
+}
+
+// userPostsPage is the cached form of the first page of a user's posts. Like
+// status is per viewer and is resolved after the page is read.
+type userPostsPage struct {
+	Posts       []models.Post `json:"posts"`
+	TotalCount  int32         `json:"total_count"`
+	HasNextPage bool          `json:"has_next_page"`
+}
+
+func postCacheKey(postID uuid.UUID) string {
+	return fmt.Sprintf("post:%s", postID.String())
+}
+
+func postCountersKey(postID uuid.UUID) string {
+	return fmt.Sprintf("post:%s:counters", postID.String())
+}
+
+// userPostsCacheKey is a hash keyed by userPostsCacheField, so every cached
+// first page of a user is dropped with a single DEL
+func userPostsCacheKey(userID uuid.UUID) string {
+	return fmt.Sprintf("posts:user:%s", userID.String())
+}
+
+// getCachedPosts returns the cached bodies of postIDs, keyed by id, the ids
+// whose bodies are not cached, and the ids of cached bodies whose counters
+// are not. Any Redis error counts as a miss.
+func (r *postRepository) getCachedPosts(ctx context.Context, postIDs []uuid.UUID) (map[uuid.UUID]*models.Post, []uuid.UUID, []uuid.UUID) {
+	posts := make(map[uuid.UUID]*models.Post, len(postIDs))
+
+	pipe := r.redis.Pipeline()
+	bodies := make([]*redis.StringCmd, len(postIDs))
+	counters := make([]*redis.SliceCmd, len(postIDs))
+	for i, postID := range postIDs {
+		bodies[i] = pipe.Get(ctx, postCacheKey(postID))
+		counters[i] = pipe.HMGet(ctx, postCountersKey(postID), postCountersFields...)
+	}
+	_, _ = pipe.Exec(ctx)
+
+	var missing, uncounted []uuid.UUID
+	for i, postID := range postIDs {
+		post, ok := decodeCachedPost(bodies[i])
+		if !ok {
+			missing = append(missing, postID)
+			continue
+		}
+		posts[postID] = post
+
+		// Tombstones have no counters
+		if post.DeletedAt != nil {
+			continue
+		}
+		if c, ok := decodeCachedCounters(postID, counters[i]); ok {
+			c.apply(post)
+		} else {
+			uncounted = append(uncounted, postID)
+		}
+	}
+	return posts, missing, uncounted
+}
+
+func decodeCachedPost(cmd *redis.StringCmd) (*models.Post, bool) {
+	data, err := cmd.Bytes()
+	if err != nil {
+		return nil, false
+	}
+
+	var post models.Post
+	if err := json.Unmarshal(data, &post); err != nil {
+		return nil, false
+	}
+	// Posts cached before they had a status and visibility are read again
+	if post.Visibility == "" {
+		return nil, false
+	}
+	return &post, true
+}
+
+func decodeCachedCounters(postID uuid.UUID, cmd *redis.SliceCmd) (postCounters, bool) {
+	values, err := cmd.Result()
+	if err != nil || len(values) != len(postCountersFields) {
+		return postCounters{}, false
+	}
+
+	counts := make([]int32, len(values))
+	for i, value := range values {
+		str, ok := value.(string)
+		if !ok {
+			return postCounters{}, false
+		}
+		n, err := strconv.ParseInt(str, 10, 32)
+		if err != nil {
+			return postCounters{}, false
+		}
+		counts[i] = int32(n)
+	}
+	return postCounters{PostID: postID, LikesCount: counts[0], CommentsCount: counts[1], RepostsCount: counts[2]}, true
+}
+
+// cachePosts stores post bodies in Redis, along with the counters of those
+// not deleted
+func (r *postRepository) cachePosts(ctx context.Context, posts []*models.Post) {
+	if len(posts) == 0 {
+		return
+	}
+
+	pipe := r.redis.Pipeline()
+	for _, post := range posts {
+		data, err := json.Marshal(post)
+		if err != nil {
+			continue
+		}
+		pipe.Set(ctx, postCacheKey(post.ID), data, postCacheTTL)
+		if post.DeletedAt == nil {
+			setCounters(ctx, pipe, postCounters{
+				PostID:        post.ID,
+				LikesCount:    post.LikesCount,
+				CommentsCount: post.CommentsCount,
+				RepostsCount:  post.RepostsCount,
+			})
+		}
+	}
+	_, _ = pipe.Exec(ctx)
+}
+
+// cacheCounters stores the counters of posts in Redis. It is called with the
+// counters a write returned, so a hot post's counters are never read from the
+// database while they keep changing.
+func (r *postRepository) cacheCounters(ctx context.Context, counters ...postCounters) {
+	if len(counters) == 0 {
+		return
+	}
+
+	pipe := r.redis.Pipeline()
+	for _, c := range counters {
+		setCounters(ctx, pipe, c)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		// Dropped instead, so the next read loads them again
+		keys := make([]string, len(counters))
+		for i, c := range counters {
+			keys[i] = postCountersKey(c.PostID)
+		}
+		if err := r.redis.Del(ctx, keys...).Err(); err != nil {
+			log.Printf("Failed to invalidate post counters %v: %v", keys, err)
+		}
+	}
+}
+
+func setCounters(ctx context.Context, pipe redis.Pipeliner, c postCounters) {
+	key := postCountersKey(c.PostID)
+	pipe.HSet(ctx, key, "likes", c.LikesCount, "comments", c.CommentsCount, "reposts", c.RepostsCount)
+	pipe.Expire(ctx, key, postCountersTTL)
+}
+
+// userPostsCacheField identifies a cached first page within the user's hash
+// by the visibilities it includes and its size
+func userPostsCacheField(visible []models.PostVisibility, first int32) string {
+	field := strconv.Itoa(int(first))
+	for _, v := range visible {
+		field += ":" + string(v)
+	}
+	return field
+}
+
+// getCachedUserPosts returns the cached first page of a user's posts
+func (r *postRepository) getCachedUserPosts(ctx context.Context, userID uuid.UUID, visible []models.PostVisibility, first int32) (*userPostsPage, bool) {
+	data, err := r.redis.HGet(ctx, userPostsCacheKey(userID), userPostsCacheField(visible, first)).Bytes()
+	if err != nil {
+		return nil, false
+	}
+
+	var page userPostsPage
+	if err := json.Unmarshal(data, &page); err != nil {
+		return nil, false
+	}
+	return &page, true
+}
+
+// cacheUserPosts stores the first page of a user's posts for the given
+// visibilities and page size
+func (r *postRepository) cacheUserPosts(ctx context.Context, userID uuid.UUID, visible []models.PostVisibility, first int32, page *userPostsPage) {
+	data, err := json.Marshal(page)
+	if err != nil {
+		return
+	}
+
+	key := userPostsCacheKey(userID)
+	pipe := r.redis.Pipeline()
+	pipe.HSet(ctx, key, userPostsCacheField(visible, first), data)
+	pipe.Expire(ctx, key, userPostsCacheTTL)
+	_, _ = pipe.Exec(ctx)
+}
+
+// invalidatePost drops the cached body and counters of a post and the cached
+// first pages of its author. Pass uuid.Nil for either to skip it.
+func (r *postRepository) invalidatePost(ctx context.Context, postID, userID uuid.UUID) {
+	var keys []string
+	if postID != uuid.Nil {
+		keys = append(keys, postCacheKey(postID), postCountersKey(postID))
+	}
+	if userID != uuid.Nil {
+		keys = append(keys, userPostsCacheKey(userID))
+	}
+	if len(keys) == 0 {
+		return
+	}
+
+	if err := r.redis.Del(ctx, keys...).Err(); err != nil {
+		log.Printf("Failed to invalidate post cache %v: %v", keys, err)
+	}
+}
@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"post-service/model"
+)
+
+// ApplyCounterEvent adds the deltas of a like or comment event to a post's
+// counters, unless the event was applied before. It reports whether the
+// event was applied now. The event is recorded even if the post is gone, so
+// that it is not retried.
+func (r *postRepository) ApplyCounterEvent(ctx context.Context, eventID string, postID uuid.UUID, likesDelta, commentsDelta int32) (bool, error) {
+	var applied bool
+	var counters *postCounters
+	err := r.db.WithTx(ctx, func(ctx context.Context) error {
+		result, err := r.db.Conn(ctx).ExecContext(ctx, `
+			INSERT INTO post_service_counter_events (event_id) VALUES ($1)
+			ON CONFLICT (event_id) DO NOTHING
+		`, eventID)
+		if err != nil {
+			return fmt.Errorf("failed to record counter event: %w", err)
+		}
+		if n, err := result.RowsAffected(); err != nil || n == 0 {
+			return err
+		}
+		applied = true
+
+		query := `
+			UPDATE post_service_posts
+			SET likes_count = GREATEST(likes_count + $2, 0), comments_count = GREATEST(comments_count + $3, 0)
+			WHERE id = $1
+		` + countersReturning
+		var updated postCounters
+		err = r.db.Conn(ctx).GetContext(ctx, &updated, query, postID, likesDelta, commentsDelta)
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to update counters: %w", err)
+		}
+		counters = &updated
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	// Cached once committed, so a read cannot see counters that are rolled
+	// back
+	if counters != nil {
+		r.cacheCounters(ctx, *counters)
+	}
+	return applied, nil
+}
+
+// PruneCounterEvents forgets events applied before appliedBefore and returns
+// how many were forgotten
+func (r *postRepository) PruneCounterEvents(ctx context.Context, appliedBefore time.Time) (int64, error) {
+	result, err := r.db.Conn(ctx).ExecContext(ctx, `DELETE FROM post_service_counter_events WHERE applied_at < $1`, appliedBefore)
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune counter events: %w", err)
+	}
+	return result.RowsAffected()
+}
+
+// ListCounters returns the counters of up to limit posts with IDs after
+// after, in ID order. Deleted posts are skipped.
+func (r *postRepository) ListCounters(ctx context.Context, after uuid.UUID, limit int32) ([]models.PostCounters, error) {
+	query := `
+		SELECT id, likes_count, comments_count
+		FROM post_service_posts
+		WHERE id > $1 AND deleted_at IS NULL
+		ORDER BY id
+		LIMIT $2
+	`
+	var counters []models.PostCounters
+	// Read from the primary, as CorrectCounters compares against what is read
+	if err := r.db.Conn(ctx).SelectContext(ctx, &counters, query, after, limit); err != nil {
+		return nil, fmt.Errorf("failed to list counters: %w", err)
+	}
+	return counters, nil
+}
+
+// CorrectCounters overwrites a post's counters with correct if they still
+// hold seen. Counters an event changed in the meantime are left for the next
+// reconciliation, since correct may not include that event. It reports
+// whether the counters were changed.
+func (r *postRepository) CorrectCounters(ctx context.Context, seen, correct models.PostCounters) (bool, error) {
+	query := `
+		UPDATE post_service_posts
+		SET likes_count = $4, comments_count = $5
+		WHERE id = $1 AND likes_count = $2 AND comments_count = $3
+	` + countersReturning
+	var counters postCounters
+	err := r.db.Conn(ctx).GetContext(ctx, &counters, query, seen.PostID, seen.LikesCount, seen.CommentsCount, correct.LikesCount, correct.CommentsCount)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to correct counters: %w", err)
+	}
+
+	r.cacheCounters(ctx, counters)
+	return true, nil
+}
+
+// CountUserPosts returns the number of published posts of each user, as
+// user-service counts them: posts that are deleted or shadow-hidden are not
+// counted. Users without posts are missing from the map.
+func (r *postRepository) CountUserPosts(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID]int32, error) {
+	query := `
+		SELECT user_id, COUNT(*) AS count
+		FROM post_service_posts
+		WHERE user_id = ANY($1) AND deleted_at IS NULL AND status = 'PUBLISHED' AND shadow_hidden_at IS NULL
+		GROUP BY user_id
+	`
+
+	var rows []struct {
+		UserID uuid.UUID `db:"user_id"`
+		Count  int32     `db:"count"`
+	}
+	if err := r.db.ReadDB().SelectContext(ctx, &rows, query, pq.Array(userIDs)); err != nil {
+		return nil, fmt.Errorf("failed to count posts: %w", err)
+	}
+
+	counts := make(map[uuid.UUID]int32, len(rows))
+	for _, row := range rows {
+		counts[row.UserID] = row.Count
+	}
+	return counts, nil
+}
@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"post-service/model"
+	"shared/cursor"
+)
+
+// ErrDraftNotFound is returned when a post is missing, deleted, not the
+// user's, or already published
+var ErrDraftNotFound = errors.New("draft not found or already published")
+
+// UpdateDraft replaces the content of a draft or scheduled post. Unpublished
+// posts have no readers, so no revision is kept.
+func (r *postRepository) UpdateDraft(ctx context.Context, post *models.Post) error {
+	query := `
+		UPDATE post_service_posts SET content = $1, updated_at = $2
+		WHERE id = $3 AND user_id = $4 AND deleted_at IS NULL AND status <> 'PUBLISHED'
+		RETURNING created_at, quoted_post_id, status, publish_at
+	`
+	err := r.db.Conn(ctx).QueryRowContext(ctx, query, post.Content, post.UpdatedAt, post.ID, post.UserID).
+		Scan(&post.CreatedAt, &post.QuotedPostID, &post.Status, &post.PublishAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrDraftNotFound
+		}
+		return err
+	}
+
+	r.invalidatePost(ctx, post.ID, post.UserID)
+	return nil
+}
+
+// SchedulePost sets a draft or scheduled post to be published at publishAt
+func (r *postRepository) SchedulePost(ctx context.Context, postID, userID uuid.UUID, publishAt time.Time) error {
+	query := `
+		UPDATE post_service_posts SET status = 'SCHEDULED', publish_at = $3, updated_at = NOW()
+		WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL AND status <> 'PUBLISHED'
+	`
+	result, err := r.db.Conn(ctx).ExecContext(ctx, query, postID, userID, publishAt)
+	if err != nil {
+		return err
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return ErrDraftNotFound
+	}
+
+	r.invalidatePost(ctx, postID, userID)
+	return nil
+}
+
+// PublishPost publishes a draft or scheduled post, dating it from now so it
+// lands at the top of feeds and timelines. With onlyIfDue only a scheduled
+// post whose time has come is published. The row lock taken by the update
+// makes a post be published once however many callers race for it. With
+// shadowHide the post is published shadow-hidden.
+func (r *postRepository) PublishPost(ctx context.Context, postID uuid.UUID, onlyIfDue, shadowHide bool) (*models.Post, error) {
+	query := `
+		UPDATE post_service_posts
+		SET status = 'PUBLISHED', publish_at = NULL, created_at = NOW(), updated_at = NOW(),
+		    shadow_hidden_at = CASE WHEN $3 THEN NOW() END
+		WHERE id = $1 AND deleted_at IS NULL AND status <> 'PUBLISHED'
+		  AND (NOT $2 OR (status = 'SCHEDULED' AND publish_at <= NOW()))
+		RETURNING id, user_id, content, created_at, updated_at, likes_count, comments_count, reposts_count, quoted_post_id, status, visibility, shadow_hidden_at
+	`
+	var post models.Post
+	err := r.db.Conn(ctx).GetContext(ctx, &post, query, postID, onlyIfDue, shadowHide)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrDraftNotFound
+		}
+		return nil, err
+	}
+
+	r.invalidatePost(ctx, post.ID, post.UserID)
+	return &post, nil
+}
+
+// ListDuePosts returns up to limit scheduled posts whose publish time has
+// passed, longest overdue first. It reads the primary so posts just
+// published by another replica are not returned again.
+func (r *postRepository) ListDuePosts(ctx context.Context, limit int32) ([]models.Post, error) {
+	query := `
+		SELECT id, user_id, content, created_at, updated_at, likes_count, comments_count, reposts_count, quoted_post_id, status, publish_at, visibility
+		FROM post_service_posts
+		WHERE status = 'SCHEDULED' AND publish_at <= NOW() AND deleted_at IS NULL
+		ORDER BY publish_at
+		LIMIT $1
+	`
+	var posts []models.Post
+	if err := r.db.Conn(ctx).SelectContext(ctx, &posts, query, limit); err != nil {
+		return nil, fmt.Errorf("failed to list due posts: %w", err)
+	}
+	return posts, nil
+}
+
+// GetDrafts pages through a user's drafts and scheduled posts, most recently
+// created first
+func (r *postRepository) GetDrafts(ctx context.Context, userID uuid.UUID, first int32, after *string) (*models.PostConnection, error) {
+	var totalCount int32
+	countQuery := `SELECT COUNT(*) FROM post_service_posts WHERE user_id = $1 AND deleted_at IS NULL AND status <> 'PUBLISHED'`
+	if err := r.db.ReadDB().GetContext(ctx, &totalCount, countQuery, userID); err != nil {
+		return nil, fmt.Errorf("failed to count drafts: %w", err)
+	}
+
+	query := `
+		SELECT id, user_id, content, created_at, updated_at, likes_count, comments_count, reposts_count, quoted_post_id, status, publish_at, visibility
+		FROM post_service_posts
+		WHERE user_id = $1 AND deleted_at IS NULL AND status <> 'PUBLISHED'
+	`
+	query, args, err := cursor.Keyset{TimeColumn: "created_at", IDColumn: "id"}.Page(query, []interface{}{userID}, after, int(first))
+	if err != nil {
+		return nil, err
+	}
+
+	var posts []models.Post
+	if err := r.db.ReadDB().SelectContext(ctx, &posts, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to get drafts: %w", err)
+	}
+
+	posts, hasNextPage := cursor.Trim(posts, int(first))
+
+	if err := r.attachPostMedia(ctx, posts); err != nil {
+		return nil, err
+	}
+	if err := r.attachQuotedPosts(ctx, posts); err != nil {
+		return nil, err
+	}
+
+	edges := make([]models.PostEdge, len(posts))
+	for i, post := range posts {
+		edges[i] = models.PostEdge{
+			Cursor: cursor.EncodeKeyset(post.CreatedAt, post.ID),
+			Node:   post,
+		}
+	}
+
+	pageInfo := models.PageInfo{
+		HasNextPage:     hasNextPage,
+		HasPreviousPage: after != nil && *after != "",
+	}
+	if len(edges) > 0 {
+		pageInfo.StartCursor = &edges[0].Cursor
+		pageInfo.EndCursor = &edges[len(edges)-1].Cursor
+	}
+
+	return &models.PostConnection{
+		Edges:      edges,
+		PageInfo:   pageInfo,
+		TotalCount: totalCount,
+	}, nil
+}
@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"post-service/model"
+	"shared/cursor"
+)
+
+// Trending counts are an aggregate over every recent post, so they are served
+// from Redis and allowed to lag by up to this long
+const trendingCacheTTL = time.Minute
+
+func trendingCacheKey(window time.Duration, limit int32) string {
+	return fmt.Sprintf("hashtags:trending:%d:%d", int64(window.Seconds()), limit)
+}
+
+// SetHashtags replaces the hashtags a post is indexed under. createdAt is the
+// post's creation time, which orders tag pages and trending windows.
+func (r *postRepository) SetHashtags(ctx context.Context, postID uuid.UUID, tags []string, createdAt time.Time) error {
+	_, err := r.db.Conn(ctx).ExecContext(ctx, `DELETE FROM post_service_post_hashtags WHERE post_id = $1`, postID)
+	if err != nil {
+		return fmt.Errorf("failed to clear hashtags: %w", err)
+	}
+	if len(tags) == 0 {
+		return nil
+	}
+
+	query := `
+		INSERT INTO post_service_post_hashtags (post_id, tag, created_at)
+		SELECT $1, tag, $3 FROM unnest($2::text[]) AS tag
+		ON CONFLICT (post_id, tag) DO NOTHING
+	`
+	if _, err := r.db.Conn(ctx).ExecContext(ctx, query, postID, pq.Array(tags), createdAt); err != nil {
+		return fmt.Errorf("failed to store hashtags: %w", err)
+	}
+	return nil
+}
+
+// GetPostsByHashtag pages through the posts using a tag, newest first
+func (r *postRepository) GetPostsByHashtag(ctx context.Context, tag string, first int32, after *string) (*models.PostConnection, error) {
+	var totalCount int32
+	countQuery := `SELECT COUNT(*) FROM post_service_post_hashtags WHERE tag = $1`
+	if err := r.db.ReadDB().GetContext(ctx, &totalCount, countQuery, tag); err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT p.id, p.user_id, p.content, p.created_at, p.updated_at, p.likes_count, p.comments_count, p.reposts_count, p.quoted_post_id, p.edited_at, p.status, p.visibility
+		FROM post_service_post_hashtags h
+		INNER JOIN post_service_posts p ON p.id = h.post_id
+		WHERE h.tag = $1
+	`
+	query, args, err := cursor.Keyset{TimeColumn: "h.created_at", IDColumn: "h.post_id"}.Page(query, []interface{}{tag}, after, int(first))
+	if err != nil {
+		return nil, err
+	}
+
+	var posts []models.Post
+	if err := r.db.ReadDB().SelectContext(ctx, &posts, query, args...); err != nil {
+		return nil, err
+	}
+
+	posts, hasNextPage := cursor.Trim(posts, int(first))
+
+	if err := r.attachMentions(ctx, posts); err != nil {
+		return nil, err
+	}
+	if err := r.attachPostMedia(ctx, posts); err != nil {
+		return nil, err
+	}
+
+	edges := make([]models.PostEdge, len(posts))
+	for i, post := range posts {
+		edges[i] = models.PostEdge{
+			Cursor: cursor.EncodeKeyset(post.CreatedAt, post.ID),
+			Node:   post,
+		}
+	}
+
+	pageInfo := models.PageInfo{
+		HasNextPage:     hasNextPage,
+		HasPreviousPage: after != nil && *after != "",
+	}
+	if len(edges) > 0 {
+		pageInfo.StartCursor = &edges[0].Cursor
+		pageInfo.EndCursor = &edges[len(edges)-1].Cursor
+	}
+
+	return &models.PostConnection{
+		Edges:      edges,
+		PageInfo:   pageInfo,
+		TotalCount: totalCount,
+	}, nil
+}
+
+// GetTrendingHashtags returns the tags used by the most posts created within
+// the window, most used first
+func (r *postRepository) GetTrendingHashtags(ctx context.Context, window time.Duration, limit int32) ([]models.TrendingHashtag, error) {
+	key := trendingCacheKey(window, limit)
+	if data, err := r.redis.Get(ctx, key).Bytes(); err == nil {
+		var trending []models.TrendingHashtag
+		if err := json.Unmarshal(data, &trending); err == nil {
+			return trending, nil
+		}
+	}
+
+	query := `
+		SELECT tag, COUNT(*) AS posts_count
+		FROM post_service_post_hashtags
+		WHERE created_at > $1
+		GROUP BY tag
+		ORDER BY posts_count DESC, tag
+		LIMIT $2
+	`
+	trending := []models.TrendingHashtag{}
+	err := r.db.ReadDB().SelectContext(ctx, &trending, query, time.Now().Add(-window), limit)
+	if err != nil {
+		return nil, err
+	}
+
+	if data, err := json.Marshal(trending); err == nil {
+		_ = r.redis.Set(ctx, key, data, trendingCacheTTL).Err()
+	}
+	return trending, nil
+}
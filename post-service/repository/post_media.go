@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"post-service/model"
+)
+
+// ErrMediaUnavailable is returned by AttachMedia when an upload does not
+// exist, belongs to another user or is already attached to a post
+var ErrMediaUnavailable = errors.New("media not found or already attached")
+
+// ErrMediaNotFound is returned by GetMedia for an unknown upload or one
+// attached to a deleted post
+var ErrMediaNotFound = errors.New("media not found")
+
+// CreateMedia records an upload that is not yet attached to a post
+func (r *postRepository) CreateMedia(ctx context.Context, m *models.Media) error {
+	query := `
+		INSERT INTO post_service_post_media (id, user_id, mime_type, size_bytes, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+	_, err := r.db.Conn(ctx).ExecContext(ctx, query, m.ID, m.UserID, m.MimeType, m.SizeBytes, m.CreatedAt)
+	return err
+}
+
+// GetMedia returns an upload. Uploads attached to a deleted post are not
+// found, though their files stay until the post is purged.
+func (r *postRepository) GetMedia(ctx context.Context, mediaID uuid.UUID) (*models.Media, error) {
+	query := `
+		SELECT m.id, m.user_id, m.post_id, m.mime_type, m.size_bytes, m.created_at
+		FROM post_service_post_media m
+		LEFT JOIN post_service_posts p ON p.id = m.post_id
+		WHERE m.id = $1 AND p.deleted_at IS NULL
+	`
+	var m models.Media
+	if err := r.db.Conn(ctx).GetContext(ctx, &m, query, mediaID); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrMediaNotFound
+		}
+		return nil, err
+	}
+	return &m, nil
+}
+
+// AttachMedia attaches the user's uploads to a post, in the order given,
+// and returns them. Either every upload is attached or none is.
+func (r *postRepository) AttachMedia(ctx context.Context, postID, userID uuid.UUID, mediaIDs []uuid.UUID) ([]models.Media, error) {
+	if len(mediaIDs) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]string, len(mediaIDs))
+	for i, id := range mediaIDs {
+		ids[i] = id.String()
+	}
+
+	query := `
+		UPDATE post_service_post_media
+		SET post_id = $1, position = array_position($3::uuid[], id)
+		WHERE id = ANY($3::uuid[]) AND user_id = $2 AND post_id IS NULL
+		RETURNING id, user_id, post_id, mime_type, size_bytes, created_at
+	`
+	var attached []models.Media
+	if err := r.db.Conn(ctx).SelectContext(ctx, &attached, query, postID, userID, pq.Array(ids)); err != nil {
+		return nil, fmt.Errorf("failed to attach media: %w", err)
+	}
+	if len(attached) != len(mediaIDs) {
+		return nil, ErrMediaUnavailable
+	}
+
+	byID := make(map[uuid.UUID]models.Media, len(attached))
+	for _, m := range attached {
+		byID[m.ID] = m
+	}
+	for i, id := range mediaIDs {
+		attached[i] = byID[id]
+	}
+	return attached, nil
+}
+
+// attachPostMedia loads the attachments of every post in one query
+func (r *postRepository) attachPostMedia(ctx context.Context, posts []models.Post) error {
+	if len(posts) == 0 {
+		return nil
+	}
+
+	postIDs := make([]string, len(posts))
+	for i, post := range posts {
+		postIDs[i] = post.ID.String()
+	}
+
+	query := `
+		SELECT id, user_id, post_id, mime_type, size_bytes, created_at
+		FROM post_service_post_media
+		WHERE post_id = ANY($1::uuid[])
+		ORDER BY position
+	`
+	var media []models.Media
+	if err := r.db.Conn(ctx).SelectContext(ctx, &media, query, pq.Array(postIDs)); err != nil {
+		return fmt.Errorf("failed to load media: %w", err)
+	}
+
+	byPost := make(map[uuid.UUID][]models.Media)
+	for _, m := range media {
+		byPost[*m.PostID] = append(byPost[*m.PostID], m)
+	}
+	for i := range posts {
+		posts[i].Media = byPost[posts[i].ID]
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"post-service/model"
+)
+
+// SetMentions replaces the users a post mentions and returns the mentions
+// that did not exist before, which are the ones to notify
+func (r *postRepository) SetMentions(ctx context.Context, postID uuid.UUID, mentions []models.Mention, createdAt time.Time) ([]models.Mention, error) {
+	userIDs := make([]string, len(mentions))
+	usernames := make([]string, len(mentions))
+	for i, m := range mentions {
+		userIDs[i] = m.UserID.String()
+		usernames[i] = m.Username
+	}
+
+	_, err := r.db.Conn(ctx).ExecContext(ctx, `
+		DELETE FROM post_service_post_mentions
+		WHERE post_id = $1 AND NOT (user_id = ANY($2::uuid[]))
+	`, postID, pq.Array(userIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to clear mentions: %w", err)
+	}
+
+	added := []models.Mention{}
+	if len(mentions) == 0 {
+		return added, nil
+	}
+
+	query := `
+		INSERT INTO post_service_post_mentions (id, post_id, user_id, username, created_at)
+		SELECT uuid_generate_v4(), $1, m.user_id, m.username, $4
+		FROM unnest($2::uuid[], $3::text[]) AS m(user_id, username)
+		ON CONFLICT (post_id, user_id) DO NOTHING
+		RETURNING id, post_id, user_id, username
+	`
+	err = r.db.Conn(ctx).SelectContext(ctx, &added, query, postID, pq.Array(userIDs), pq.Array(usernames), createdAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to store mentions: %w", err)
+	}
+	return added, nil
+}
+
+// attachMentions loads the mentions of every post in one query. It reads the
+// primary, as post bodies carrying mentions are cached right after.
+func (r *postRepository) attachMentions(ctx context.Context, posts []models.Post) error {
+	if len(posts) == 0 {
+		return nil
+	}
+
+	postIDs := make([]string, len(posts))
+	for i, post := range posts {
+		postIDs[i] = post.ID.String()
+	}
+
+	query := `
+		SELECT id, post_id, user_id, username
+		FROM post_service_post_mentions
+		WHERE post_id = ANY($1::uuid[])
+		ORDER BY created_at, username
+	`
+	var mentions []models.Mention
+	if err := r.db.Conn(ctx).SelectContext(ctx, &mentions, query, pq.Array(postIDs)); err != nil {
+		return fmt.Errorf("failed to load mentions: %w", err)
+	}
+
+	byPost := make(map[uuid.UUID][]models.Mention)
+	for _, m := range mentions {
+		byPost[m.PostID] = append(byPost[m.PostID], m)
+	}
+	for i := range posts {
+		posts[i].Mentions = byPost[posts[i].ID]
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"post-service/model"
+)
+
+var (
+	// ErrPollNotFound is returned when a post has no poll
+	ErrPollNotFound = errors.New("poll not found")
+	// ErrPollOptionNotFound is returned when a vote is for an option the
+	// poll does not have
+	ErrPollOptionNotFound = errors.New("poll option not found")
+	// ErrPollExpired is returned when a vote is cast after the poll closed
+	ErrPollExpired = errors.New("poll has expired")
+	// ErrAlreadyVoted is returned when a user votes in a poll a second time
+	ErrAlreadyVoted = errors.New("already voted in this poll")
+)
+
+// CreatePoll stores the poll of a post along with its options, in the order
+// given
+func (r *postRepository) CreatePoll(ctx context.Context, poll *models.Poll) error {
+	query := `INSERT INTO post_service_polls (post_id, expires_at, created_at) VALUES ($1, $2, $3)`
+	if _, err := r.db.Conn(ctx).ExecContext(ctx, query, poll.PostID, poll.ExpiresAt, poll.CreatedAt); err != nil {
+		return fmt.Errorf("failed to create poll: %w", err)
+	}
+
+	optionQuery := `
+		INSERT INTO post_service_poll_options (id, post_id, position, text)
+		VALUES ($1, $2, $3, $4)
+	`
+	for _, option := range poll.Options {
+		if _, err := r.db.Conn(ctx).ExecContext(ctx, optionQuery, option.ID, poll.PostID, option.Position, option.Text); err != nil {
+			return fmt.Errorf("failed to create poll option: %w", err)
+		}
+	}
+	return nil
+}
+
+// GetPoll returns the poll of a post with its current counts and, when
+// viewerID is set, the option they voted for. It reads the primary so a vote
+// shows up in the results returned for it.
+func (r *postRepository) GetPoll(ctx context.Context, postID uuid.UUID, viewerID *uuid.UUID) (*models.Poll, error) {
+	var poll models.Poll
+	query := `SELECT post_id, expires_at, created_at FROM post_service_polls WHERE post_id = $1`
+	if err := r.db.Conn(ctx).GetContext(ctx, &poll, query, postID); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrPollNotFound
+		}
+		return nil, err
+	}
+
+	optionsQuery := `
+		SELECT id, post_id, position, text, votes_count
+		FROM post_service_poll_options
+		WHERE post_id = $1
+		ORDER BY position
+	`
+	if err := r.db.Conn(ctx).SelectContext(ctx, &poll.Options, optionsQuery, postID); err != nil {
+		return nil, fmt.Errorf("failed to load poll options: %w", err)
+	}
+
+	if viewerID != nil {
+		var optionID uuid.UUID
+		voteQuery := `SELECT option_id FROM post_service_poll_votes WHERE post_id = $1 AND user_id = $2`
+		err := r.db.Conn(ctx).QueryRowContext(ctx, voteQuery, postID, viewerID).Scan(&optionID)
+		if err == nil {
+			poll.VotedOptionID = &optionID
+		} else if err != sql.ErrNoRows {
+			return nil, err
+		}
+	}
+
+	return &poll, nil
+}
+
+// VotePoll records a user's vote for an option of a post's poll and counts
+// it. Run it in a transaction so the vote and its count are kept together.
+func (r *postRepository) VotePoll(ctx context.Context, postID, optionID, userID uuid.UUID) error {
+	var expired, hasOption bool
+	checkQuery := `
+		SELECT p.expires_at <= NOW(),
+			EXISTS(SELECT 1 FROM post_service_poll_options WHERE id = $2 AND post_id = p.post_id)
+		FROM post_service_polls p
+		WHERE p.post_id = $1
+	`
+	if err := r.db.Conn(ctx).QueryRowContext(ctx, checkQuery, postID, optionID).Scan(&expired, &hasOption); err != nil {
+		if err == sql.ErrNoRows {
+			return ErrPollNotFound
+		}
+		return err
+	}
+	if expired {
+		return ErrPollExpired
+	}
+	if !hasOption {
+		return ErrPollOptionNotFound
+	}
+
+	// The primary key on (post_id, user_id) settles concurrent votes by the
+	// same user
+	voteQuery := `
+		INSERT INTO post_service_poll_votes (post_id, user_id, option_id)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (post_id, user_id) DO NOTHING
+	`
+	result, err := r.db.Conn(ctx).ExecContext(ctx, voteQuery, postID, userID, optionID)
+	if err != nil {
+		return err
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return ErrAlreadyVoted
+	}
+
+	countQuery := `UPDATE post_service_poll_options SET votes_count = votes_count + 1 WHERE id = $1`
+	_, err = r.db.Conn(ctx).ExecContext(ctx, countQuery, optionID)
+	return err
+}
@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"post-service/model"
+)
+
+// ErrQuotedPostNotFound is returned by Create when the post being quoted
+// does not exist or has been deleted
+var ErrQuotedPostNotFound = errors.New("quoted post not found")
+
+// isQuotedPostViolation reports whether err is the foreign key on
+// quoted_post_id rejecting a post that is gone, including one deleted while
+// the quote was being written
+func isQuotedPostViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) &&
+		pqErr.Code == "23503" &&
+		pqErr.Constraint == "post_service_posts_quoted_post_id_fkey"
+}
+
+// attachQuotedPosts embeds the post each post quotes. Only one level is
+// embedded: a quoted post's own quote is left as an ID. A deleted quoted post
+// is embedded as its tombstone, which clients show as "post deleted"; once
+// it is purged the quote is left without an embedded post.
+func (r *postRepository) attachQuotedPosts(ctx context.Context, posts []models.Post) error {
+	var ids []uuid.UUID
+	for _, post := range posts {
+		if post.QuotedPostID != nil {
+			ids = append(ids, *post.QuotedPostID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	quoted, err := r.getPosts(ctx, ids)
+	if err != nil {
+		return err
+	}
+	for i := range posts {
+		if id := posts[i].QuotedPostID; id != nil {
+			posts[i].QuotedPost = quoted[*id]
+		}
+	}
+	return nil
+}
@@ -3,41 +3,95 @@ package repository
 import (
 	"context"
 	"database/sql"
-	"encoding/base64"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/google/uuid"
-	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/redis/go-redis/v9"
+	"post-service/db"
+	"post-service/hashtag"
 	"post-service/model"
+	"shared/cursor"
 )
 
+var errPostNotFound = errors.New("post not found")
+
 type PostRepository interface {
+	// WithTx runs fn in a single transaction; repository calls made with the
+	// context passed to fn join it
+	WithTx(ctx context.Context, fn func(ctx context.Context) error) error
 	Create(ctx context.Context, post *models.Post) error
 	GetByID(ctx context.Context, postID uuid.UUID, requestingUserID *uuid.UUID) (*models.PostWithLikeStatus, error)
+	GetByIDs(ctx context.Context, postIDs []uuid.UUID, requestingUserID *uuid.UUID) ([]models.PostWithLikeStatus, error)
 	Update(ctx context.Context, post *models.Post) error
+	GetPostRevisions(ctx context.Context, postID uuid.UUID, first int32, after *string) (*models.PostRevisionConnection, error)
+	UpdateDraft(ctx context.Context, post *models.Post) error
+	SchedulePost(ctx context.Context, postID, userID uuid.UUID, publishAt time.Time) error
+	PublishPost(ctx context.Context, postID uuid.UUID, onlyIfDue, shadowHide bool) (*models.Post, error)
+	ListDuePosts(ctx context.Context, limit int32) ([]models.Post, error)
+	GetDrafts(ctx context.Context, userID uuid.UUID, first int32, after *string) (*models.PostConnection, error)
 	Delete(ctx context.Context, postID uuid.UUID) error
-	GetUserPosts(ctx context.Context, userID uuid.UUID, first int32, after *string, requestingUserID *uuid.UUID) (*models.PostConnection, error)
-	IncrementCommentsCount(ctx context.Context, postID uuid.UUID) error
-	DecrementCommentsCount(ctx context.Context, postID uuid.UUID) error
-	IncrementLikesCount(ctx context.Context, postID uuid.UUID) error
-	DecrementLikesCount(ctx context.Context, postID uuid.UUID) error
+	PurgeDeletedPosts(ctx context.Context, deletedBefore time.Time, limit int32) (int64, []uuid.UUID, error)
+	GetUserPosts(ctx context.Context, userID uuid.UUID, visible []models.PostVisibility, first int32, after *string, requestingUserID *uuid.UUID) (*models.PostConnection, error)
+	ListPublicPosts(ctx context.Context, afterID *uuid.UUID, limit int32) ([]models.Post, error)
+	ListPostsCreatedBetween(ctx context.Context, since, until time.Time, after *Cursor, limit int32) ([]models.Post, error)
+	SetCounters(ctx context.Context, postID uuid.UUID, likesCount, commentsCount int32) error
+	ApplyCounterEvent(ctx context.Context, eventID string, postID uuid.UUID, likesDelta, commentsDelta int32) (bool, error)
+	PruneCounterEvents(ctx context.Context, appliedBefore time.Time) (int64, error)
+	ListCounters(ctx context.Context, after uuid.UUID, limit int32) ([]models.PostCounters, error)
+	CorrectCounters(ctx context.Context, seen, correct models.PostCounters) (bool, error)
+	CountUserPosts(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID]int32, error)
+	ImportPosts(ctx context.Context, posts []models.Post) (int32, error)
+	SetHashtags(ctx context.Context, postID uuid.UUID, tags []string, createdAt time.Time) error
+	GetPostsByHashtag(ctx context.Context, tag string, first int32, after *string) (*models.PostConnection, error)
+	GetTrendingHashtags(ctx context.Context, window time.Duration, limit int32) ([]models.TrendingHashtag, error)
+	SetMentions(ctx context.Context, postID uuid.UUID, mentions []models.Mention, createdAt time.Time) ([]models.Mention, error)
+	CreateRepost(ctx context.Context, repost *models.Repost) (bool, error)
+	DeleteRepost(ctx context.Context, postID, userID uuid.UUID) (*models.Repost, error)
+	CreateMedia(ctx context.Context, m *models.Media) error
+	GetMedia(ctx context.Context, mediaID uuid.UUID) (*models.Media, error)
+	AttachMedia(ctx context.Context, postID, userID uuid.UUID, mediaIDs []uuid.UUID) ([]models.Media, error)
+	CreatePoll(ctx context.Context, poll *models.Poll) error
+	GetPoll(ctx context.Context, postID uuid.UUID, viewerID *uuid.UUID) (*models.Poll, error)
+	VotePoll(ctx context.Context, postID, optionID, userID uuid.UUID) error
+	GetAllUserPosts(ctx context.Context, userID uuid.UUID) ([]models.Post, error)
+	GetUserReposts(ctx context.Context, userID uuid.UUID) ([]models.Repost, error)
+	GetUserPollVotes(ctx context.Context, userID uuid.UUID) ([]models.PollVote, error)
+	DeleteUserPollVotes(ctx context.Context, userID uuid.UUID) (int64, error)
+	DeleteUserMentions(ctx context.Context, userID uuid.UUID) error
 }
 
 type postRepository struct {
-	db *sqlx.DB
+	db    *database.DB
+	redis *redis.Client
+}
+
+func NewPostRepository(db *database.DB, redis *redis.Client) PostRepository {
+	return &postRepository{
+		db:    db,
+		redis: redis,
+	}
 }
 
-func NewPostRepository(db *sqlx.DB) PostRepository {
-	return &postRepository{db: db}
+func (r *postRepository) WithTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return r.db.WithTx(ctx, fn)
 }
 
 func (r *postRepository) Create(ctx context.Context, post *models.Post) error {
+	// A deleted post keeps its row as a tombstone, so the foreign key alone
+	// does not stop it from being quoted. Only published public posts that
+	// are not shadow-hidden can be.
 	query := `
-		INSERT INTO post_service_posts (id, user_id, content, created_at, updated_at, likes_count, comments_count)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		INSERT INTO post_service_posts (id, user_id, content, created_at, updated_at, likes_count, comments_count, quoted_post_id, status, visibility, shadow_hidden_at)
+		SELECT $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
+		WHERE $8::uuid IS NULL OR EXISTS (
+			SELECT 1 FROM post_service_posts
+			WHERE id = $8 AND deleted_at IS NULL AND status = 'PUBLISHED' AND visibility = 'PUBLIC' AND shadow_hidden_at IS NULL
+		)
 	`
-	_, err := r.db.ExecContext(ctx, query,
+	result, err := r.db.Conn(ctx).ExecContext(ctx, query,
 		post.ID,
 		post.UserID,
 		post.Content,
@@ -45,84 +99,286 @@ func (r *postRepository) Create(ctx context.Context, post *models.Post) error {
 		post.UpdatedAt,
 		post.LikesCount,
 		post.CommentsCount,
+		post.QuotedPostID,
+		post.Status,
+		post.Visibility,
+		post.ShadowHiddenAt,
 	)
-	return err
+	if err != nil {
+		if isQuotedPostViolation(err) {
+			return ErrQuotedPostNotFound
+		}
+		return err
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return ErrQuotedPostNotFound
+	}
+
+	r.invalidatePost(ctx, uuid.Nil, post.UserID)
+	return nil
 }
 
 func (r *postRepository) GetByID(ctx context.Context, postID uuid.UUID, requestingUserID *uuid.UUID) (*models.PostWithLikeStatus, error) {
-	var post models.Post
-	var isLiked *bool
+	post, err := r.getPost(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+	if post.DeletedAt != nil {
+		return nil, errPostNotFound
+	}
 
+	posts := []models.Post{*post}
+	if err := r.attachQuotedPosts(ctx, posts); err != nil {
+		return nil, err
+	}
+	post = &posts[0]
+
+	var isLiked, isReposted *bool
 	if requestingUserID != nil {
 		query := `
-			SELECT p.id, p.user_id, p.content, p.created_at, p.updated_at, 
-			       p.likes_count, p.comments_count,
-			       EXISTS(SELECT 1 FROM likes WHERE post_id = p.id AND user_id = $2) as is_liked
-			FROM post_service_posts p
-			WHERE p.id = $1
+			SELECT
+				EXISTS(SELECT 1 FROM post_service_likes WHERE post_id = $1 AND user_id = $2),
+				EXISTS(SELECT 1 FROM post_service_reposts WHERE post_id = $1 AND user_id = $2)
 		`
-		var liked bool
-		err := r.db.GetContext(ctx, &struct {
-			models.Post
-			IsLikedVal bool `db:"is_liked"`
-		}{
-			Post: post,
-		}, query, postID, requestingUserID)
+		var liked, reposted bool
+		if err := r.db.Conn(ctx).QueryRowContext(ctx, query, postID, requestingUserID).Scan(&liked, &reposted); err != nil {
+			return nil, err
+		}
+		isLiked = &liked
+		isReposted = &reposted
+	}
 
+	return &models.PostWithLikeStatus{
+		Post:       *post,
+		IsLiked:    isLiked,
+		IsReposted: isReposted,
+	}, nil
+}
+
+// GetByIDs returns the posts of postIDs in their order, read through the
+// Redis cache like GetByID. Posts that do not exist or are deleted are left
+// out.
+func (r *postRepository) GetByIDs(ctx context.Context, postIDs []uuid.UUID, requestingUserID *uuid.UUID) ([]models.PostWithLikeStatus, error) {
+	found, err := r.getPosts(ctx, postIDs)
+	if err != nil {
+		return nil, err
+	}
+	posts := make([]models.Post, 0, len(postIDs))
+	for _, postID := range postIDs {
+		if post, ok := found[postID]; ok && post.DeletedAt == nil {
+			posts = append(posts, *post)
+		}
+	}
+	if err := r.attachQuotedPosts(ctx, posts); err != nil {
+		return nil, err
+	}
+
+	var liked, reposted map[uuid.UUID]bool
+	if requestingUserID != nil && len(posts) > 0 {
+		ids := make([]uuid.UUID, len(posts))
+		for i, post := range posts {
+			ids[i] = post.ID
+		}
+
+		var err error
+		liked, err = r.postsOfUser(ctx, `SELECT post_id FROM post_service_likes WHERE user_id = $1 AND post_id = ANY($2)`, *requestingUserID, ids)
+		if err != nil {
+			return nil, err
+		}
+		reposted, err = r.postsOfUser(ctx, `SELECT post_id FROM post_service_reposts WHERE user_id = $1 AND post_id = ANY($2)`, *requestingUserID, ids)
 		if err != nil {
-			if err == sql.ErrNoRows {
-				return nil, fmt.Errorf("post not found")
-			}
 			return nil, err
 		}
+	}
 
-		// Query again with proper scanning
-		row := r.db.QueryRowContext(ctx, query, postID, requestingUserID)
-		err = row.Scan(
-			&post.ID,
-			&post.UserID,
-			&post.Content,
-			&post.CreatedAt,
-			&post.UpdatedAt,
-			&post.LikesCount,
-			&post.CommentsCount,
-			&liked,
-		)
+	result := make([]models.PostWithLikeStatus, len(posts))
+	for i, post := range posts {
+		result[i] = models.PostWithLikeStatus{Post: post}
+		if requestingUserID != nil {
+			isLiked, isReposted := liked[post.ID], reposted[post.ID]
+			result[i].IsLiked = &isLiked
+			result[i].IsReposted = &isReposted
+		}
+	}
+	return result, nil
+}
+
+// postsOfUser runs a query selecting which of postIDs userID has a row for
+func (r *postRepository) postsOfUser(ctx context.Context, query string, userID uuid.UUID, postIDs []uuid.UUID) (map[uuid.UUID]bool, error) {
+	var ids []uuid.UUID
+	if err := r.db.ReadDB().SelectContext(ctx, &ids, query, userID, pq.Array(postIDs)); err != nil {
+		return nil, err
+	}
+
+	found := make(map[uuid.UUID]bool, len(ids))
+	for _, id := range ids {
+		found[id] = true
+	}
+	return found, nil
+}
+
+// getPost reads a post body through the Redis cache. A deleted post is
+// returned as its tombstone: DeletedAt set and the content left out.
+func (r *postRepository) getPost(ctx context.Context, postID uuid.UUID) (*models.Post, error) {
+	posts, err := r.getPosts(ctx, []uuid.UUID{postID})
+	if err != nil {
+		return nil, err
+	}
+	post, ok := posts[postID]
+	if !ok {
+		return nil, errPostNotFound
+	}
+	return post, nil
+}
+
+// getPosts reads post bodies through the Redis cache, keyed by id, with one
+// round trip to Redis and at most two queries for whatever it misses. Bodies
+// and counters are cached apart; see postCounters. Deleted posts are returned
+// as tombstones, and posts that do not exist are missing from the map.
+func (r *postRepository) getPosts(ctx context.Context, postIDs []uuid.UUID) (map[uuid.UUID]*models.Post, error) {
+	posts, missing, uncounted := r.getCachedPosts(ctx, postIDs)
+
+	if len(missing) > 0 {
+		loaded, err := r.loadPosts(ctx, missing)
 		if err != nil {
-			if err == sql.ErrNoRows {
-				return nil, fmt.Errorf("post not found")
-			}
 			return nil, err
 		}
-		isLiked = &liked
-	} else {
-		query := `
-			SELECT id, user_id, content, created_at, updated_at, likes_count, comments_count
+		for _, post := range loaded {
+			posts[post.ID] = post
+		}
+		r.cachePosts(ctx, loaded)
+	}
+
+	if len(uncounted) > 0 {
+		var counters []postCounters
+		err := r.db.Conn(ctx).SelectContext(ctx, &counters, `
+			SELECT id, likes_count, comments_count, reposts_count
 			FROM post_service_posts
-			WHERE id = $1
-		`
-		err := r.db.GetContext(ctx, &post, query, postID)
+			WHERE id = ANY($1)
+		`, pq.Array(uncounted))
 		if err != nil {
-			if err == sql.ErrNoRows {
-				return nil, fmt.Errorf("post not found")
-			}
 			return nil, err
 		}
+		for _, c := range counters {
+			c.apply(posts[c.PostID])
+		}
+		r.cacheCounters(ctx, counters...)
 	}
 
-	return &models.PostWithLikeStatus{
-		Post:    post,
-		IsLiked: isLiked,
-	}, nil
+	return posts, nil
 }
 
+// loadPosts reads post bodies from the database, with their mentions and
+// media. Deleted posts are returned as tombstones.
+func (r *postRepository) loadPosts(ctx context.Context, postIDs []uuid.UUID) ([]*models.Post, error) {
+	query := `
+		SELECT id, user_id, content, created_at, updated_at, likes_count, comments_count, reposts_count, quoted_post_id, edited_at, deleted_at, status, publish_at, visibility, shadow_hidden_at
+		FROM post_service_posts
+		WHERE id = ANY($1)
+	`
+	var rows []models.Post
+	if err := r.db.Conn(ctx).SelectContext(ctx, &rows, query, pq.Array(postIDs)); err != nil {
+		return nil, err
+	}
+
+	posts := make([]*models.Post, 0, len(rows))
+	var live []models.Post
+	for _, post := range rows {
+		if post.DeletedAt == nil {
+			live = append(live, post)
+			continue
+		}
+		posts = append(posts, &models.Post{
+			ID:         post.ID,
+			UserID:     post.UserID,
+			CreatedAt:  post.CreatedAt,
+			UpdatedAt:  post.UpdatedAt,
+			DeletedAt:  post.DeletedAt,
+			Status:     post.Status,
+			Visibility: post.Visibility,
+		})
+	}
+
+	if err := r.attachMentions(ctx, live); err != nil {
+		return nil, err
+	}
+	if err := r.attachPostMedia(ctx, live); err != nil {
+		return nil, err
+	}
+	for i := range live {
+		posts = append(posts, &live[i])
+	}
+	return posts, nil
+}
+
+// applyCachedCounters overwrites the counters of posts with the cached ones,
+// reading those not cached from the database. Cached first pages go through
+// it, so they need not be dropped whenever a counter changes.
+func (r *postRepository) applyCachedCounters(ctx context.Context, posts []models.Post) error {
+	if len(posts) == 0 {
+		return nil
+	}
+
+	pipe := r.redis.Pipeline()
+	cmds := make([]*redis.SliceCmd, len(posts))
+	for i, post := range posts {
+		cmds[i] = pipe.HMGet(ctx, postCountersKey(post.ID), postCountersFields...)
+	}
+	_, _ = pipe.Exec(ctx)
+
+	uncounted := make(map[uuid.UUID]*models.Post)
+	for i := range posts {
+		if c, ok := decodeCachedCounters(posts[i].ID, cmds[i]); ok {
+			c.apply(&posts[i])
+		} else {
+			uncounted[posts[i].ID] = &posts[i]
+		}
+	}
+	if len(uncounted) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, 0, len(uncounted))
+	for id := range uncounted {
+		ids = append(ids, id)
+	}
+	var counters []postCounters
+	err := r.db.ReadDB().SelectContext(ctx, &counters, `
+		SELECT id, likes_count, comments_count, reposts_count
+		FROM post_service_posts
+		WHERE id = ANY($1)
+	`, pq.Array(ids))
+	if err != nil {
+		return err
+	}
+	for _, c := range counters {
+		c.apply(uncounted[c.PostID])
+	}
+	r.cacheCounters(ctx, counters...)
+	return nil
+}
+
+// Update replaces a published post's content, keeping the content it
+// replaces as a revision edited by the author. The old content is read under
+// a row lock so concurrent edits each record the version they replaced.
 func (r *postRepository) Update(ctx context.Context, post *models.Post) error {
 	query := `
-		UPDATE post_service_posts 
-		SET content = $1, updated_at = $2
-		WHERE id = $3 AND user_id = $4
+		WITH previous AS (
+			SELECT id, content FROM post_service_posts
+			WHERE id = $3 AND user_id = $4 AND deleted_at IS NULL AND status = 'PUBLISHED'
+			FOR UPDATE
+		), revision AS (
+			INSERT INTO post_service_post_revisions (post_id, editor_id, content, edited_at)
+			SELECT id, $4, content, $2 FROM previous
+		)
+		UPDATE post_service_posts p
+		SET content = $1, updated_at = $2, edited_at = $2
+		FROM previous
+		WHERE p.id = previous.id
 	`
-	result, err := r.db.ExecContext(ctx, query, post.Content, post.UpdatedAt, post.ID, post.UserID)
+	result, err := r.db.Conn(ctx).ExecContext(ctx, query, post.Content, post.UpdatedAt, post.ID, post.UserID)
 	if err != nil {
 		return err
 	}
@@ -135,110 +391,116 @@ func (r *postRepository) Update(ctx context.Context, post *models.Post) error {
 	if rowsAffected == 0 {
 		return fmt.Errorf("post not found or unauthorized")
 	}
+	editedAt := post.UpdatedAt
+	post.EditedAt = &editedAt
 
+	r.invalidatePost(ctx, post.ID, post.UserID)
 	return nil
 }
 
+// Delete soft-deletes a post, leaving a tombstone that PurgeDeletedPosts
+// removes after the retention window. Its hashtags are dropped right away so
+// tag pages and trending counts stop including it.
 func (r *postRepository) Delete(ctx context.Context, postID uuid.UUID) error {
-	query := `DELETE FROM post_service_posts WHERE id = $1`
-	result, err := r.db.ExecContext(ctx, query, postID)
-	if err != nil {
-		return err
-	}
-
-	rowsAffected, err := result.RowsAffected()
+	query := `
+		UPDATE post_service_posts SET deleted_at = NOW()
+		WHERE id = $1 AND deleted_at IS NULL
+		RETURNING user_id
+	`
+	var userID uuid.UUID
+	err := r.db.Conn(ctx).QueryRowContext(ctx, query, postID).Scan(&userID)
 	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("post not found")
+		}
 		return err
 	}
 
-	if rowsAffected == 0 {
-		return fmt.Errorf("post not found")
+	if _, err := r.db.Conn(ctx).ExecContext(ctx, `DELETE FROM post_service_post_hashtags WHERE post_id = $1`, postID); err != nil {
+		return fmt.Errorf("failed to clear hashtags: %w", err)
 	}
 
+	r.invalidatePost(ctx, postID, userID)
 	return nil
 }
 
-func (r *postRepository) GetUserPosts(ctx context.Context, userID uuid.UUID, first int32, after *string, requestingUserID *uuid.UUID) (*models.PostConnection, error) {
-	var afterTime time.Time
-	var afterID uuid.UUID
+// PurgeDeletedPosts hard-deletes up to limit posts deleted before
+// deletedBefore, with their likes, reposts, mentions and media rows. It
+// returns how many were purged and the IDs of the media whose files can now
+// be removed. Quotes of a purged post lose their quoted_post_id.
+func (r *postRepository) PurgeDeletedPosts(ctx context.Context, deletedBefore time.Time, limit int32) (int64, []uuid.UUID, error) {
+	var purged int64
+	var mediaIDs []uuid.UUID
+	err := r.db.WithTx(ctx, func(ctx context.Context) error {
+		var postIDs []uuid.UUID
+		err := r.db.Conn(ctx).SelectContext(ctx, &postIDs, `
+			SELECT id FROM post_service_posts
+			WHERE deleted_at < $1
+			ORDER BY deleted_at
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		`, deletedBefore, limit)
+		if err != nil {
+			return fmt.Errorf("failed to find deleted posts: %w", err)
+		}
+		if len(postIDs) == 0 {
+			return nil
+		}
 
-	if after != nil && *after != "" {
-		decoded, err := decodeCursor(*after)
+		err = r.db.Conn(ctx).SelectContext(ctx, &mediaIDs,
+			`DELETE FROM post_service_post_media WHERE post_id = ANY($1) RETURNING id`, pq.Array(postIDs))
 		if err != nil {
-			return nil, fmt.Errorf("invalid cursor: %w", err)
+			return fmt.Errorf("failed to purge media: %w", err)
 		}
-		afterTime = decoded.Timestamp
-		afterID = decoded.ID
-	}
 
-	// Get total count
-	var totalCount int32
-	countQuery := `SELECT COUNT(*) FROM post_service_posts WHERE user_id = $1`
-	err := r.db.GetContext(ctx, &totalCount, countQuery, userID)
+		result, err := r.db.Conn(ctx).ExecContext(ctx, `DELETE FROM post_service_posts WHERE id = ANY($1)`, pq.Array(postIDs))
+		if err != nil {
+			return fmt.Errorf("failed to purge posts: %w", err)
+		}
+		purged, err = result.RowsAffected()
+		return err
+	})
 	if err != nil {
-		return nil, err
+		return 0, nil, err
 	}
 
-	// Build main query
-	var posts []models.Post
-	var query string
-	var args []interface{}
+	return purged, mediaIDs, nil
+}
 
-	if requestingUserID != nil {
-		if after != nil && *after != "" {
-			query = `
-				SELECT p.id, p.user_id, p.content, p.created_at, p.updated_at, 
-				       p.likes_count, p.comments_count
-				FROM post_service_posts p
-				WHERE p.user_id = $1 
-				  AND (p.created_at, p.id) < ($2, $3)
-				ORDER BY p.created_at DESC, p.id DESC
-				LIMIT $4
-			`
-			args = []interface{}{userID, afterTime, afterID, first + 1}
-		} else {
-			query = `
-				SELECT p.id, p.user_id, p.content, p.created_at, p.updated_at, 
-				       p.likes_count, p.comments_count
-				FROM post_service_posts p
-				WHERE p.user_id = $1
-				ORDER BY p.created_at DESC, p.id DESC
-				LIMIT $2
-			`
-			args = []interface{}{userID, first + 1}
+// GetUserPosts pages through a user's published posts with one of the
+// visible visibilities
+func (r *postRepository) GetUserPosts(ctx context.Context, userID uuid.UUID, visible []models.PostVisibility, first int32, after *string, requestingUserID *uuid.UUID) (*models.PostConnection, error) {
+	// Only the first page is cached; later pages are rarely requested twice
+	firstPage := after == nil || *after == ""
+
+	var page *userPostsPage
+	var cached bool
+	if firstPage {
+		page, cached = r.getCachedUserPosts(ctx, userID, visible, first)
+	}
+
+	if !cached {
+		var err error
+		page, err = r.loadUserPosts(ctx, userID, visible, first, after)
+		if err != nil {
+			return nil, err
 		}
-	} else {
-		if after != nil && *after != "" {
-			query = `
-				SELECT id, user_id, content, created_at, updated_at, likes_count, comments_count
-				FROM post_service_posts
-				WHERE user_id = $1 
-				  AND (created_at, id) < ($2, $3)
-				ORDER BY created_at DESC, id DESC
-				LIMIT $4
-			`
-			args = []interface{}{userID, afterTime, afterID, first + 1}
-		} else {
-			query = `
-				SELECT id, user_id, content, created_at, updated_at, likes_count, comments_count
-				FROM post_service_posts
-				WHERE user_id = $1
-				ORDER BY created_at DESC, id DESC
-				LIMIT $2
-			`
-			args = []interface{}{userID, first + 1}
+		if firstPage {
+			r.cacheUserPosts(ctx, userID, visible, first, page)
 		}
 	}
 
-	err = r.db.SelectContext(ctx, &posts, query, args...)
-	if err != nil {
-		return nil, err
+	posts := page.Posts
+	if cached {
+		if err := r.applyCachedCounters(ctx, posts); err != nil {
+			return nil, err
+		}
 	}
-
-	hasNextPage := len(posts) > int(first)
-	if hasNextPage {
-		posts = posts[:first]
+	if err := r.attachQuotedPosts(ctx, posts); err != nil {
+		return nil, err
 	}
+	hasNextPage := page.HasNextPage
+	totalCount := page.TotalCount
 
 	likeStatusMap := make(map[uuid.UUID]bool)
 	if requestingUserID != nil && len(posts) > 0 {
@@ -253,7 +515,7 @@ func (r *postRepository) GetUserPosts(ctx context.Context, userID uuid.UUID, fir
 			WHERE user_id = $1 AND post_id = ANY($2)
 		`
 		var likedPostIDs []uuid.UUID
-		err = r.db.SelectContext(ctx, &likedPostIDs, likeQuery, requestingUserID, postIDs)
+		err := r.db.ReadDB().SelectContext(ctx, &likedPostIDs, likeQuery, requestingUserID, postIDs)
 		if err != nil {
 			return nil, err
 		}
@@ -265,9 +527,8 @@ func (r *postRepository) GetUserPosts(ctx context.Context, userID uuid.UUID, fir
 
 	edges := make([]models.PostEdge, len(posts))
 	for i, post := range posts {
-		cursor := encodeCursor(post.CreatedAt, post.ID)
 		edges[i] = models.PostEdge{
-			Cursor: cursor,
+			Cursor: cursor.EncodeKeyset(post.CreatedAt, post.ID),
 			Node:   post,
 		}
 	}
@@ -293,61 +554,183 @@ func (r *postRepository) GetUserPosts(ctx context.Context, userID uuid.UUID, fir
 	}, nil
 }
 
-func (r *postRepository) IncrementCommentsCount(ctx context.Context, postID uuid.UUID) error {
-	query := `UPDATE post_service_posts SET comments_count = comments_count + 1 WHERE id = $1`
-	_, err := r.db.ExecContext(ctx, query, postID)
-	return err
-}
+// loadUserPosts reads one page of a user's posts and their total count from
+// the database
+func (r *postRepository) loadUserPosts(ctx context.Context, userID uuid.UUID, visible []models.PostVisibility, first int32, after *string) (*userPostsPage, error) {
+	visibilities := visibilityArray(visible)
 
-func (r *postRepository) DecrementCommentsCount(ctx context.Context, postID uuid.UUID) error {
-	query := `UPDATE post_service_posts SET comments_count = GREATEST(comments_count - 1, 0) WHERE id = $1`
-	_, err := r.db.ExecContext(ctx, query, postID)
-	return err
-}
+	var totalCount int32
+	countQuery := `
+		SELECT COUNT(*) FROM post_service_posts
+		WHERE user_id = $1 AND deleted_at IS NULL AND status = 'PUBLISHED' AND visibility = ANY($2)
+	`
+	err := r.db.ReadDB().GetContext(ctx, &totalCount, countQuery, userID, visibilities)
+	if err != nil {
+		return nil, err
+	}
 
-func (r *postRepository) IncrementLikesCount(ctx context.Context, postID uuid.UUID) error {
-	query := `UPDATE post_service_posts SET likes_count = likes_count + 1 WHERE id = $1`
-	_, err := r.db.ExecContext(ctx, query, postID)
-	return err
-}
+	query, args, err := cursor.Keyset{TimeColumn: "created_at", IDColumn: "id"}.Page(`
+		SELECT id, user_id, content, created_at, updated_at, likes_count, comments_count, reposts_count, quoted_post_id, edited_at, status, visibility, shadow_hidden_at
+		FROM post_service_posts
+		WHERE user_id = $1 AND deleted_at IS NULL AND status = 'PUBLISHED' AND visibility = ANY($2)
+	`, []interface{}{userID, visibilities}, after, int(first))
+	if err != nil {
+		return nil, err
+	}
+
+	var posts []models.Post
+	err = r.db.ReadDB().SelectContext(ctx, &posts, query, args...)
+	if err != nil {
+		return nil, err
+	}
 
-func (r *postRepository) DecrementLikesCount(ctx context.Context, postID uuid.UUID) error {
-	query := `UPDATE post_service_posts SET likes_count = GREATEST(likes_count - 1, 0) WHERE id = $1`
-	_, err := r.db.ExecContext(ctx, query, postID)
-	return err
+	posts, hasNextPage := cursor.Trim(posts, int(first))
+
+	if err := r.attachMentions(ctx, posts); err != nil {
+		return nil, err
+	}
+	if err := r.attachPostMedia(ctx, posts); err != nil {
+		return nil, err
+	}
+
+	return &userPostsPage{
+		Posts:       posts,
+		TotalCount:  totalCount,
+		HasNextPage: hasNextPage,
+	}, nil
 }
 
-// Cursor encoding/decoding helpers
-type Cursor struct {
-	Timestamp time.Time
-	ID        uuid.UUID
+// countersReturning is the RETURNING clause of counter updates, whose result
+// is written through to the cache
+const countersReturning = `RETURNING id, likes_count, comments_count, reposts_count`
+
+// updateCounter runs a counter UPDATE returning countersReturning and caches
+// the counters it returns. A missing post is not an error.
+func (r *postRepository) updateCounter(ctx context.Context, query string, args ...interface{}) error {
+	var counters postCounters
+	err := r.db.Conn(ctx).GetContext(ctx, &counters, query, args...)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	r.cacheCounters(ctx, counters)
+	return nil
 }
 
-func encodeCursor(timestamp time.Time, id uuid.UUID) string {
-	cursorStr := fmt.Sprintf("%d:%s", timestamp.Unix(), id.String())
-	return base64.StdEncoding.EncodeToString([]byte(cursorStr))
+// SetCounters overwrites the denormalized like and comment counters of a post
+func (r *postRepository) SetCounters(ctx context.Context, postID uuid.UUID, likesCount, commentsCount int32) error {
+	query := `UPDATE post_service_posts SET likes_count = $2, comments_count = $3 WHERE id = $1 ` + countersReturning
+	var counters postCounters
+	err := r.db.Conn(ctx).GetContext(ctx, &counters, query, postID, likesCount, commentsCount)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("post not found")
+		}
+		return err
+	}
+
+	r.cacheCounters(ctx, counters)
+	return nil
 }
 
-func decodeCursor(cursor string) (*Cursor, error) {
-	decoded, err := base64.StdEncoding.DecodeString(cursor)
+// ImportPosts inserts migrated posts with their original ids and timestamps,
+// along with their hashtags, leaving posts that already exist untouched. It
+// returns how many were created.
+func (r *postRepository) ImportPosts(ctx context.Context, posts []models.Post) (int32, error) {
+	query := `
+		INSERT INTO post_service_posts (id, user_id, content, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $4)
+		ON CONFLICT (id) DO NOTHING
+	`
+
+	var created int32
+	authors := make(map[uuid.UUID]bool)
+	err := r.db.WithTx(ctx, func(ctx context.Context) error {
+		for _, post := range posts {
+			result, err := r.db.Conn(ctx).ExecContext(ctx, query, post.ID, post.UserID, post.Content, post.CreatedAt)
+			if err != nil {
+				return fmt.Errorf("failed to import post %s: %w", post.ID, err)
+			}
+			if n, _ := result.RowsAffected(); n > 0 {
+				created++
+				authors[post.UserID] = true
+				if err := r.SetHashtags(ctx, post.ID, hashtag.Extract(post.Content), post.CreatedAt); err != nil {
+					return fmt.Errorf("failed to import post %s: %w", post.ID, err)
+				}
+			}
+		}
+		return nil
+	})
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
 
-	var timestamp int64
-	var idStr string
-	_, err = fmt.Sscanf(string(decoded), "%d:%s", &timestamp, &idStr)
+	for userID := range authors {
+		r.invalidatePost(ctx, uuid.Nil, userID)
+	}
+	return created, nil
+}
+
+// ListPostsCreatedBetween returns posts created in [since, until) in creation
+// order, continuing after the given cursor position when one is provided
+func (r *postRepository) ListPostsCreatedBetween(ctx context.Context, since, until time.Time, after *Cursor, limit int32) ([]models.Post, error) {
+	query := `
+		SELECT id, user_id, content, created_at, updated_at, likes_count, comments_count, reposts_count, quoted_post_id, visibility
+		FROM post_service_posts
+		WHERE created_at >= $1 AND created_at < $2 AND deleted_at IS NULL AND status = 'PUBLISHED' AND shadow_hidden_at IS NULL
+	`
+	args := []interface{}{since, until}
+	if after != nil {
+		query += ` AND (created_at, id) > ($3, $4)`
+		args = append(args, after.Timestamp, after.ID)
+	}
+	query += fmt.Sprintf(" ORDER BY created_at, id LIMIT $%d", len(args)+1)
+	args = append(args, limit)
+
+	var posts []models.Post
+	err := r.db.ReadDB().SelectContext(ctx, &posts, query, args...)
 	if err != nil {
 		return nil, err
 	}
 
-	id, err := uuid.Parse(idStr)
+	return posts, nil
+}
+
+// ListPublicPosts pages through every public post that may be indexed,
+// ordered by id so callers can walk the whole table with a keyset cursor.
+func (r *postRepository) ListPublicPosts(ctx context.Context, afterID *uuid.UUID, limit int32) ([]models.Post, error) {
+	query := `
+		SELECT id, user_id, content, created_at, updated_at, likes_count, comments_count, reposts_count, quoted_post_id
+		FROM post_service_posts
+		WHERE ($1::uuid IS NULL OR id > $1) AND deleted_at IS NULL AND status = 'PUBLISHED' AND visibility = 'PUBLIC'
+		  AND shadow_hidden_at IS NULL
+		ORDER BY id
+		LIMIT $2
+	`
+
+	var posts []models.Post
+	err := r.db.ReadDB().SelectContext(ctx, &posts, query, afterID, limit)
 	if err != nil {
 		return nil, err
 	}
 
-	return &Cursor{
-		Timestamp: time.Unix(timestamp, 0),
-		ID:        id,
-	}, nil
+	return posts, nil
+}
+
+// visibilityArray converts visibilities to a Postgres text array
+func visibilityArray(visible []models.PostVisibility) interface{} {
+	values := make([]string, len(visible))
+	for i, v := range visible {
+		values[i] = string(v)
+	}
+	return pq.Array(values)
+}
+
+// Cursor is a (created_at, id) position used by internal batch scans
+type Cursor struct {
+	Timestamp time.Time
+	ID        uuid.UUID
 }
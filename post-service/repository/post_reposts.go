@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"post-service/model"
+)
+
+// CreateRepost records a repost and bumps the post's reposts_count. It
+// reports false when the user had already reposted the post.
+func (r *postRepository) CreateRepost(ctx context.Context, repost *models.Repost) (bool, error) {
+	query := `
+		INSERT INTO post_service_reposts (id, post_id, user_id, created_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (post_id, user_id) DO NOTHING
+	`
+	result, err := r.db.Conn(ctx).ExecContext(ctx, query, repost.ID, repost.PostID, repost.UserID, repost.CreatedAt)
+	if err != nil {
+		return false, fmt.Errorf("failed to create repost: %w", err)
+	}
+	if n, err := result.RowsAffected(); err != nil || n == 0 {
+		return false, err
+	}
+
+	query = `UPDATE post_service_posts SET reposts_count = reposts_count + 1 WHERE id = $1 ` + countersReturning
+	if err := r.updateCounter(ctx, query, repost.PostID); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// DeleteRepost removes a repost and returns it, or nil when the user had not
+// reposted the post
+func (r *postRepository) DeleteRepost(ctx context.Context, postID, userID uuid.UUID) (*models.Repost, error) {
+	query := `
+		DELETE FROM post_service_reposts
+		WHERE post_id = $1 AND user_id = $2
+		RETURNING id, post_id, user_id, created_at
+	`
+	var repost models.Repost
+	if err := r.db.Conn(ctx).GetContext(ctx, &repost, query, postID, userID); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to delete repost: %w", err)
+	}
+
+	query = `UPDATE post_service_posts SET reposts_count = GREATEST(reposts_count - 1, 0) WHERE id = $1 ` + countersReturning
+	if err := r.updateCounter(ctx, query, postID); err != nil {
+		return nil, err
+	}
+	return &repost, nil
+}
@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"post-service/model"
+	"shared/cursor"
+)
+
+// GetPostRevisions pages through the earlier versions of a post, most recent
+// edit first
+func (r *postRepository) GetPostRevisions(ctx context.Context, postID uuid.UUID, first int32, after *string) (*models.PostRevisionConnection, error) {
+	var totalCount int32
+	countQuery := `SELECT COUNT(*) FROM post_service_post_revisions WHERE post_id = $1`
+	if err := r.db.ReadDB().GetContext(ctx, &totalCount, countQuery, postID); err != nil {
+		return nil, fmt.Errorf("failed to count revisions: %w", err)
+	}
+
+	query := `
+		SELECT id, post_id, editor_id, content, edited_at
+		FROM post_service_post_revisions
+		WHERE post_id = $1
+	`
+	query, args, err := cursor.Keyset{TimeColumn: "edited_at", IDColumn: "id"}.Page(query, []interface{}{postID}, after, int(first))
+	if err != nil {
+		return nil, err
+	}
+
+	var revisions []models.PostRevision
+	if err := r.db.ReadDB().SelectContext(ctx, &revisions, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to get revisions: %w", err)
+	}
+
+	revisions, hasNextPage := cursor.Trim(revisions, int(first))
+
+	edges := make([]models.PostRevisionEdge, len(revisions))
+	for i, revision := range revisions {
+		edges[i] = models.PostRevisionEdge{
+			Cursor: cursor.EncodeKeyset(revision.EditedAt, revision.ID),
+			Node:   revision,
+		}
+	}
+
+	pageInfo := models.PageInfo{
+		HasNextPage:     hasNextPage,
+		HasPreviousPage: after != nil && *after != "",
+	}
+	if len(edges) > 0 {
+		pageInfo.StartCursor = &edges[0].Cursor
+		pageInfo.EndCursor = &edges[len(edges)-1].Cursor
+	}
+
+	return &models.PostRevisionConnection{
+		Edges:      edges,
+		PageInfo:   pageInfo,
+		TotalCount: totalCount,
+	}, nil
+}
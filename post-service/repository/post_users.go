@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"post-service/model"
+)
+
+// GetAllUserPosts returns every post of a user that is not deleted, drafts
+// and scheduled posts included, newest first
+func (r *postRepository) GetAllUserPosts(ctx context.Context, userID uuid.UUID) ([]models.Post, error) {
+	query := `
+		SELECT id, user_id, content, created_at, updated_at, likes_count, comments_count, reposts_count, quoted_post_id, status, publish_at, visibility, edited_at
+		FROM post_service_posts
+		WHERE user_id = $1 AND deleted_at IS NULL
+		ORDER BY created_at DESC, id DESC
+	`
+	posts := []models.Post{}
+	if err := r.db.Conn(ctx).SelectContext(ctx, &posts, query, userID); err != nil {
+		return nil, fmt.Errorf("failed to get user posts: %w", err)
+	}
+
+	if err := r.attachMentions(ctx, posts); err != nil {
+		return nil, err
+	}
+	if err := r.attachPostMedia(ctx, posts); err != nil {
+		return nil, err
+	}
+	return posts, nil
+}
+
+// GetUserReposts returns every repost a user made, newest first
+func (r *postRepository) GetUserReposts(ctx context.Context, userID uuid.UUID) ([]models.Repost, error) {
+	query := `
+		SELECT id, post_id, user_id, created_at
+		FROM post_service_reposts
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+	`
+	reposts := []models.Repost{}
+	if err := r.db.Conn(ctx).SelectContext(ctx, &reposts, query, userID); err != nil {
+		return nil, fmt.Errorf("failed to get user reposts: %w", err)
+	}
+	return reposts, nil
+}
+
+// GetUserPollVotes returns every poll vote a user cast, newest first
+func (r *postRepository) GetUserPollVotes(ctx context.Context, userID uuid.UUID) ([]models.PollVote, error) {
+	query := `
+		SELECT post_id, option_id, created_at
+		FROM post_service_poll_votes
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+	`
+	votes := []models.PollVote{}
+	if err := r.db.Conn(ctx).SelectContext(ctx, &votes, query, userID); err != nil {
+		return nil, fmt.Errorf("failed to get user poll votes: %w", err)
+	}
+	return votes, nil
+}
+
+// DeleteUserPollVotes removes a user's poll votes, taking them off the vote
+// counts, and returns how many were removed
+func (r *postRepository) DeleteUserPollVotes(ctx context.Context, userID uuid.UUID) (int64, error) {
+	query := `
+		WITH deleted AS (
+			DELETE FROM post_service_poll_votes WHERE user_id = $1
+			RETURNING option_id
+		)
+		UPDATE post_service_poll_options o
+		SET votes_count = GREATEST(o.votes_count - d.votes, 0)
+		FROM (SELECT option_id, COUNT(*) AS votes FROM deleted GROUP BY option_id) d
+		WHERE o.id = d.option_id
+		RETURNING d.votes
+	`
+	var counts []int64
+	if err := r.db.Conn(ctx).SelectContext(ctx, &counts, query, userID); err != nil {
+		return 0, fmt.Errorf("failed to delete poll votes: %w", err)
+	}
+
+	var deleted int64
+	for _, n := range counts {
+		deleted += n
+	}
+	return deleted, nil
+}
+
+// DeleteUserMentions removes the mentions of a user from other users' posts.
+// The content keeps the name they had; it just no longer links to them.
+func (r *postRepository) DeleteUserMentions(ctx context.Context, userID uuid.UUID) error {
+	query := `
+		DELETE FROM post_service_post_mentions m
+		USING post_service_posts p
+		WHERE m.user_id = $1 AND p.id = m.post_id
+		RETURNING p.id, p.user_id
+	`
+	var posts []models.Post
+	if err := r.db.Conn(ctx).SelectContext(ctx, &posts, query, userID); err != nil {
+		return fmt.Errorf("failed to delete mentions: %w", err)
+	}
+
+	for _, post := range posts {
+		r.invalidatePost(ctx, post.ID, post.UserID)
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+// Package scheduling publishes scheduled posts once their time comes. Every
+// replica runs a worker; publishing a post takes its row lock, so a post due
+// on several replicas at once is still published once.
+package scheduling
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// batchSize is how many due posts are published per query
+const batchSize = 100
+
+// Publisher publishes scheduled posts that are due
+type Publisher interface {
+	PublishDuePosts(ctx context.Context, limit int32) (int, error)
+}
+
+type Worker struct {
+	publisher Publisher
+	interval  time.Duration
+}
+
+// New returns a worker that every interval publishes the scheduled posts
+// that have come due
+func New(publisher Publisher, interval time.Duration) *Worker {
+	return &Worker{
+		publisher: publisher,
+		interval:  interval,
+	}
+}
+
+// Run publishes due posts until ctx is cancelled
+func (w *Worker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		w.round(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// round publishes due posts batch by batch until a batch comes up short
+func (w *Worker) round(ctx context.Context) {
+	total := 0
+	for ctx.Err() == nil {
+		published, err := w.publisher.PublishDuePosts(ctx, batchSize)
+		total += published
+		if err != nil {
+			log.Printf("Failed to publish scheduled posts: %v", err)
+			break
+		}
+		if published < batchSize {
+			break
+		}
+	}
+	if total > 0 {
+		log.Printf("Published %d scheduled posts", total)
+	}
+}
@@ -0,0 +1,145 @@
+package subscriber
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nats-io/nats.go"
+	"post-service/events"
+	natsClient "post-service/nats"
+	"shared/logging"
+	"shared/tracing"
+)
+
+const (
+	// counterEventRetention is how long applied events are remembered. It
+	// outlasts the stream's retention, so no copy of a forgotten event can
+	// be delivered again.
+	counterEventRetention = 8 * 24 * time.Hour
+	// counterPruneInterval is how often applied events are forgotten
+	counterPruneInterval = time.Hour
+)
+
+// CounterApplier keeps the like and comment counters of posts
+type CounterApplier interface {
+	ApplyCounterEvent(ctx context.Context, eventID string, postID uuid.UUID, likesDelta, commentsDelta int32) error
+	PruneCounterEvents(ctx context.Context, appliedBefore time.Time) (int64, error)
+}
+
+// counterDeltas are the changes each counted subject makes to a post's
+// likes and comments
+var counterDeltas = map[string][2]int32{
+	events.PostLiked:      {1, 0},
+	events.PostUnliked:    {-1, 0},
+	events.CommentAdded:   {0, 1},
+	events.CommentDeleted: {0, -1},
+}
+
+// CounterSubscriber counts likes and comments into the counters of posts
+// from the events of like-service and comment-service. Each event is
+// applied once, by its ID, however often it is delivered.
+type CounterSubscriber struct {
+	natsClient *natsClient.Client
+	applier    CounterApplier
+	ctx        context.Context
+}
+
+func NewCounterSubscriber(natsClient *natsClient.Client, applier CounterApplier, ctx context.Context) *CounterSubscriber {
+	return &CounterSubscriber{
+		natsClient: natsClient,
+		applier:    applier,
+		ctx:        ctx,
+	}
+}
+
+// Start subscribes in the background, retrying until the stream, created by
+// notification-service, exists, and prunes applied events until the
+// subscriber's context is cancelled
+func (s *CounterSubscriber) Start() {
+	durables := map[string]string{
+		events.PostLiked:      "post-service-counters-liked",
+		events.PostUnliked:    "post-service-counters-unliked",
+		events.CommentAdded:   "post-service-counters-comment-added",
+		events.CommentDeleted: "post-service-counters-comment-deleted",
+	}
+	for subject, durable := range durables {
+		go s.subscribe(subject, durable)
+	}
+	go s.prune()
+}
+
+func (s *CounterSubscriber) subscribe(subject, durable string) {
+	for {
+		_, err := s.natsClient.SubscribeDurable(subject, durable, "post-workers", s.handle)
+		if err == nil {
+			log.Printf("Counter subscriber for %s started successfully", subject)
+			return
+		}
+
+		log.Printf("Failed to start counter subscriber for %s, retrying: %v", subject, err)
+		select {
+		case <-time.After(subscribeRetry):
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
+
+func (s *CounterSubscriber) handle(msg *nats.Msg) {
+	ctx, span := tracing.StartProcess(s.ctx, msg.Subject, msg.Header)
+	ctx = logging.Extract(ctx, msg.Subject, msg.Header)
+	defer span.End()
+
+	var event events.CounterEvent
+	if err := natsClient.DecodeEvent(msg, &event); err != nil {
+		logging.FromContext(ctx).Error().Err(err).Msg("failed to decode counter event")
+		msg.Nak()
+		return
+	}
+
+	deltas := counterDeltas[msg.Subject]
+	if err := s.applier.ApplyCounterEvent(ctx, eventID(msg, event), event.PostID, deltas[0], deltas[1]); err != nil {
+		logging.FromContext(ctx).Error().Err(err).Stringer("post_id", event.PostID).Msg("failed to apply counter event")
+		msg.Nak()
+		return
+	}
+
+	msg.Ack()
+}
+
+// eventID identifies an event by the message ID it was published with. An
+// event published without one is identified by its like or comment, each of
+// which is added and removed once.
+func eventID(msg *nats.Msg, event events.CounterEvent) string {
+	if id := msg.Header.Get(nats.MsgIdHdr); id != "" {
+		return id
+	}
+	if event.LikeID != uuid.Nil {
+		return msg.Subject + ":" + event.LikeID.String()
+	}
+	return msg.Subject + ":" + event.CommentID.String()
+}
+
+func (s *CounterSubscriber) prune() {
+	ticker := time.NewTicker(counterPruneInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		n, err := s.applier.PruneCounterEvents(s.ctx, time.Now().Add(-counterEventRetention))
+		if err != nil {
+			log.Printf("Failed to prune counter events: %v", err)
+			continue
+		}
+		if n > 0 {
+			log.Printf("Pruned %d applied counter events", n)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+// Package subscriber consumes the events of other services that post-service
+// acts on
+package subscriber
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nats-io/nats.go"
+	"post-service/events"
+	natsClient "post-service/nats"
+	"shared/dedupe"
+	"shared/logging"
+	"shared/tracing"
+)
+
+// userDeletionsConsumer is the durable consumer of user deletions, which
+// also names it in the processed events
+const userDeletionsConsumer = "post-service-user-deletions"
+
+// subscribeRetry is how long to wait before subscribing again when the
+// stream does not exist yet
+const subscribeRetry = 5 * time.Second
+
+// ContentDeleter removes the content of a deleted user
+type ContentDeleter interface {
+	DeleteUserContent(ctx context.Context, userID uuid.UUID) error
+}
+
+// UserSubscriber deletes the posts, reposts and poll votes of users who
+// delete their account
+type UserSubscriber struct {
+	natsClient *natsClient.Client
+	processed  *dedupe.Store
+	deleter    ContentDeleter
+	ctx        context.Context
+}
+
+func NewUserSubscriber(natsClient *natsClient.Client, processed *dedupe.Store, deleter ContentDeleter, ctx context.Context) *UserSubscriber {
+	return &UserSubscriber{
+		natsClient: natsClient,
+		processed:  processed,
+		deleter:    deleter,
+		ctx:        ctx,
+	}
+}
+
+// Start subscribes in the background, retrying until the stream, created by
+// notification-service, exists
+func (s *UserSubscriber) Start() {
+	go func() {
+		for {
+			_, err := s.natsClient.SubscribeDurable(events.UserDeleted, userDeletionsConsumer, "post-workers", s.handle)
+			if err == nil {
+				log.Println("User subscriber started successfully")
+				return
+			}
+
+			log.Printf("Failed to start user subscriber, retrying: %v", err)
+			select {
+			case <-time.After(subscribeRetry):
+			case <-s.ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+func (s *UserSubscriber) handle(msg *nats.Msg) {
+	ctx, span := tracing.StartProcess(s.ctx, msg.Subject, msg.Header)
+	ctx = logging.Extract(ctx, msg.Subject, msg.Header)
+	defer span.End()
+
+	err := s.processed.Process(ctx, userDeletionsConsumer, dedupeID(msg), func(ctx context.Context) error {
+		var event events.UserDeletedEvent
+		if err := natsClient.DecodeEvent(msg, &event); err != nil {
+			logging.FromContext(ctx).Error().Err(err).Msg("failed to decode user deleted event")
+			return err
+		}
+
+		if err := s.deleter.DeleteUserContent(ctx, event.UserID); err != nil {
+			logging.FromContext(ctx).Error().Err(err).Stringer("user_id", event.UserID).Msg("failed to delete content of deleted user")
+			return err
+		}
+		return nil
+	})
+	if errors.Is(err, dedupe.ErrInProgress) {
+		msg.NakWithDelay(dedupe.Lease)
+		return
+	}
+	if err != nil {
+		msg.Nak()
+		return
+	}
+
+	msg.Ack()
+}
+
+// dedupeID identifies an event for the processed events: by the ID it was
+// published with or, lacking one, by the message holding it
+func dedupeID(msg *nats.Msg) string {
+	if id := dedupe.EventID(msg.Header); id != "" {
+		return id
+	}
+	meta, err := msg.Metadata()
+	if err != nil {
+		return ""
+	}
+	return dedupe.MessageID(meta.Stream, meta.Sequence.Stream, meta.Timestamp)
+}
@@ -0,0 +1,252 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+
+	authpb "auth-service/pb"
+	commentpb "comment-service/pb"
+	feedpb "feed-service/pb"
+	notificationpb "notification-service/pb"
+	postpb "post-service/pb"
+	userpb "user-service/pb"
+
+	"scheduler-service/config"
+	"scheduler-service/db"
+	"scheduler-service/handler"
+	"scheduler-service/interceptor"
+	"scheduler-service/jobs"
+	"scheduler-service/migrations"
+	pb "scheduler-service/pb"
+	"scheduler-service/repository"
+	"scheduler-service/rpcerror"
+	"scheduler-service/scheduler"
+	"shared/chaos"
+	"shared/lifecycle"
+	"shared/logging"
+	"shared/migrate"
+	"shared/mtls"
+	"shared/tracing"
+)
+
+func main() {
+	logging.Init("scheduler-service")
+
+	migrateFlag := flag.Bool("migrate", false, "apply pending schema migrations before serving")
+	flag.Parse()
+
+	if err := godotenv.Load(); err != nil {
+		log.Println("failed to load Scheduler .env")
+	}
+
+	// The supervisor health-checks the dependencies below and closes them
+	// in order on shutdown
+	supervisor := lifecycle.New(pb.SchedulerService_ServiceDesc.ServiceName)
+
+	// Load database configuration
+	dbCfg, err := config.LoadDatabaseConfig("SCHEDULER_")
+	if err != nil {
+		log.Fatalf("Failed to load Scheduler database config: %v", err)
+	}
+
+	// Connect to the database
+	dbConn, err := database.NewConnection(database.Config{
+		Host:         dbCfg.Host,
+		Port:         dbCfg.Port,
+		User:         dbCfg.User,
+		Password:     dbCfg.Password,
+		DBName:       dbCfg.DBName,
+		SSLMode:      dbCfg.SSLMode,
+		MaxOpenConns: dbCfg.MaxOpenConns,
+		MaxIdleConns: dbCfg.MaxIdleConns,
+		MaxLifetime:  dbCfg.MaxLifetime,
+		ReplicaDSNs:  dbCfg.ReplicaDSNs,
+	})
+	if err != nil {
+		log.Fatalf("Failed to connect to Scheduler database: %v", err)
+	}
+	supervisor.Add(lifecycle.Dependency{Name: "database", Check: dbConn.HealthCheck, Close: dbConn.Close})
+
+	// Apply pending schema migrations with -migrate or DB_MIGRATE=true;
+	// without either they are only reported
+	if err := migrate.OnStartup(context.Background(), dbConn.DB, "scheduler-service", migrations.Files, *migrateFlag || os.Getenv("DB_MIGRATE") == "true"); err != nil {
+		log.Fatalf("Failed to migrate Scheduler database: %v", err)
+	}
+
+	log.Println("Successfully connected to database")
+
+	// Load other service-level configs
+	grpcPort := getEnv("GRPC_PORT", "50061")
+	jwtSecret := getEnv("JWT_SECRET", "your-secret-key")
+	// Tokens are verified with JWT_SECRET, or with auth-service's public keys
+	// when JWT_ALGORITHM is RS256
+	tokenKeys, err := interceptor.KeysFromEnv(jwtSecret)
+	if err != nil {
+		log.Fatalf("Invalid JWT configuration: %v", err)
+	}
+	location, err := time.LoadLocation(getEnv("SCHEDULER_TIMEZONE", "UTC"))
+	if err != nil {
+		log.Fatalf("Invalid SCHEDULER_TIMEZONE: %v", err)
+	}
+	hostname, _ := os.Hostname()
+	instance := getEnv("SCHEDULER_INSTANCE", hostname)
+
+	// Fault injection for resilience testing; inert unless CHAOS_ENABLED=true
+	chaosInjector, err := chaos.FromEnv()
+	if err != nil {
+		log.Fatalf("Invalid chaos configuration: %v", err)
+	}
+
+	// Export traces when OTEL_EXPORTER_OTLP_ENDPOINT is set
+	shutdownTracing, err := tracing.Init(context.Background(), "scheduler-service")
+	if err != nil {
+		log.Fatalf("Failed to initialize tracing: %v", err)
+	}
+	supervisor.OnShutdown("tracing", shutdownTracing)
+
+	// Other services are dialed over TLS when GRPC_TLS is set
+	clientTLS, err := mtls.DialOption()
+	if err != nil {
+		log.Fatalf("Failed to load TLS config: %v", err)
+	}
+
+	// Connect to the services jobs call into
+	dial := func(addr string) *grpc.ClientConn {
+		conn, err := grpc.NewClient(addr, clientTLS, tracing.DialOption(), grpc.WithChainUnaryInterceptor(logging.UnaryClientInterceptor()))
+		if err != nil {
+			log.Fatalf("Failed to connect to %s: %v", addr, err)
+		}
+		return conn
+	}
+	authConn := dial(getEnv("AUTH_SERVICE_ADDR", "auth-service:50051"))
+	defer authConn.Close()
+	feedConn := dial(getEnv("FEED_SERVICE_ADDR", "feed-service:50054"))
+	defer feedConn.Close()
+	notificationConn := dial(getEnv("NOTIFICATION_SERVICE_ADDR", "notification-service:50058"))
+	defer notificationConn.Close()
+	postConn := dial(getEnv("POST_SERVICE_ADDR", "post-service:50053"))
+	defer postConn.Close()
+	commentConn := dial(getEnv("COMMENT_SERVICE_ADDR", "comment-service:50056"))
+	defer commentConn.Close()
+	userConn := dial(getEnv("USER_SERVICE_ADDR", "user-service:50052"))
+	defer userConn.Close()
+
+	// Initialize repository, scheduler and handler
+	schedulerRepo := repository.NewSchedulerRepository(dbConn)
+	sched := scheduler.New(schedulerRepo, instance, location)
+
+	jobCfg := jobs.Config{
+		Schedules:                make(map[string]string),
+		FeedRetention:            days(getEnvAsInt("FEED_RETENTION_DAYS", 30)),
+		NotificationRetention:    days(getEnvAsInt("NOTIFICATION_RETENTION_DAYS", 90)),
+		WebhookDeliveryRetention: days(getEnvAsInt("WEBHOOK_DELIVERY_RETENTION_DAYS", 30)),
+		DeletedContentRetention:  days(getEnvAsInt("DELETED_CONTENT_RETENTION_DAYS", 30)),
+	}
+	// SCHEDULE_<JOB_NAME> overrides a job's schedule; "off" disables it
+	for name := range jobs.Defaults {
+		key := "SCHEDULE_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_"))
+		if value, ok := os.LookupEnv(key); ok {
+			if strings.EqualFold(value, "off") {
+				value = ""
+			}
+			jobCfg.Schedules[name] = value
+		}
+	}
+
+	clients := jobs.Clients{
+		Auth:         authpb.NewAuthServiceClient(authConn),
+		Comment:      commentpb.NewCommentServiceClient(commentConn),
+		Feed:         feedpb.NewFeedServiceClient(feedConn),
+		Notification: notificationpb.NewNotificationServiceClient(notificationConn),
+		Post:         postpb.NewPostServiceClient(postConn),
+		User:         userpb.NewUserServiceClient(userConn),
+	}
+	for _, job := range jobs.All(clients, tokenKeys, jobCfg) {
+		if err := sched.Register(job); err != nil {
+			log.Fatalf("Failed to register job: %v", err)
+		}
+	}
+
+	schedulerHandler := handler.NewSchedulerHandler(schedulerRepo, sched)
+
+	schedulerCtx, stopScheduler := context.WithCancel(supervisor.Context())
+	defer stopScheduler()
+	go sched.Start(schedulerCtx)
+	// Jobs already running are given the step timeout to finish on shutdown
+	supervisor.OnShutdown("scheduler", func(context.Context) error {
+		sched.Wait()
+		return nil
+	})
+
+	// Every method requires ADMIN
+	authInterceptor := interceptor.NewAuthInterceptor(tokenKeys, nil)
+	authInterceptor.AddAdminMethods([]string{
+		"/scheduler.SchedulerService/ListJobs",
+		"/scheduler.SchedulerService/ListJobRuns",
+		"/scheduler.SchedulerService/TriggerJob",
+	})
+	authInterceptor.AddPublicMethods(lifecycle.HealthMethods)
+
+	// TLS, and mutual TLS with a CA bundle, from GRPC_TLS_*
+	tlsOption, err := mtls.ServerOption()
+	if err != nil {
+		log.Fatalf("Failed to load TLS config: %v", err)
+	}
+
+	// Create gRPC server
+	grpcServer := grpc.NewServer(
+		tlsOption,
+		tracing.ServerOption(),
+		grpc.ChainUnaryInterceptor(logging.UnaryServerInterceptor(), rpcerror.UnaryServerInterceptor(), chaosInjector.Unary(), authInterceptor.Unary()),
+	)
+
+	// Register the gRPC service
+	pb.RegisterSchedulerServiceServer(grpcServer, schedulerHandler)
+	supervisor.Register(grpcServer)
+
+	// Enable reflection for debugging tools like grpcurl
+	reflection.Register(grpcServer)
+
+	// Start listening for connections
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%s", grpcPort))
+	if err != nil {
+		log.Fatalf("Failed to listen on port %s: %v", grpcPort, err)
+	}
+
+	log.Printf("Scheduler Service gRPC server listening on port %s", grpcPort)
+
+	// Serve requests
+	if err := supervisor.Serve(grpcServer, listener); err != nil {
+		log.Fatalf("Failed to serve: %v", err)
+	}
+}
+
+// small helpers for optional env vars
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+func getEnvAsInt(key string, defaultValue int) int {
+	if value, err := strconv.Atoi(os.Getenv(key)); err == nil && value > 0 {
+		return value
+	}
+	return defaultValue
+}
+
+func days(n int) time.Duration {
+	return time.Duration(n) * 24 * time.Hour
+}
@@ -0,0 +1,120 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"scheduler-service/model"
+	pb "scheduler-service/pb"
+	"scheduler-service/repository"
+	"scheduler-service/scheduler"
+)
+
+const (
+	defaultListLimit = 20
+	maxListLimit     = 100
+)
+
+type SchedulerHandler struct {
+	pb.UnimplementedSchedulerServiceServer
+	repo      repository.SchedulerRepository
+	scheduler *scheduler.Scheduler
+}
+
+func NewSchedulerHandler(repo repository.SchedulerRepository, scheduler *scheduler.Scheduler) *SchedulerHandler {
+	return &SchedulerHandler{
+		repo:      repo,
+		scheduler: scheduler,
+	}
+}
+
+// ListJobs returns every registered job with its next activation and most
+// recent run
+func (h *SchedulerHandler) ListJobs(ctx context.Context, req *pb.ListJobsRequest) (*pb.ListJobsResponse, error) {
+	lastRuns, err := h.repo.LastRuns(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to load last runs")
+	}
+
+	jobs := h.scheduler.Jobs()
+	resp := &pb.ListJobsResponse{Jobs: make([]*pb.Job, 0, len(jobs))}
+	for _, job := range jobs {
+		out := &pb.Job{
+			Name:        job.Name,
+			Description: job.Description,
+			Schedule:    job.Schedule,
+			Enabled:     job.Schedule != "",
+		}
+		if next, ok := h.scheduler.NextRun(job.Name); ok {
+			out.NextRunAt = timestamppb.New(next)
+		}
+		if run, ok := lastRuns[job.Name]; ok {
+			out.LastRun = convertRunToProto(&run)
+		}
+		resp.Jobs = append(resp.Jobs, out)
+	}
+	return resp, nil
+}
+
+func (h *SchedulerHandler) ListJobRuns(ctx context.Context, req *pb.ListJobRunsRequest) (*pb.ListJobRunsResponse, error) {
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultListLimit
+	}
+	if limit > maxListLimit {
+		limit = maxListLimit
+	}
+
+	runs, err := h.repo.ListRuns(ctx, req.JobName, limit)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to list job runs")
+	}
+
+	resp := &pb.ListJobRunsResponse{Runs: make([]*pb.JobRun, 0, len(runs))}
+	for i := range runs {
+		resp.Runs = append(resp.Runs, convertRunToProto(&runs[i]))
+	}
+	return resp, nil
+}
+
+// TriggerJob starts a job immediately. The returned run is still in progress;
+// its outcome is reported by ListJobRuns.
+func (h *SchedulerHandler) TriggerJob(ctx context.Context, req *pb.TriggerJobRequest) (*pb.JobRun, error) {
+	name := strings.TrimSpace(req.JobName)
+	if name == "" {
+		return nil, status.Error(codes.InvalidArgument, "job name is required")
+	}
+
+	run, err := h.scheduler.Trigger(ctx, name)
+	switch {
+	case errors.Is(err, scheduler.ErrUnknownJob):
+		return nil, status.Errorf(codes.NotFound, "job %s not found", name)
+	case errors.Is(err, scheduler.ErrJobRunning):
+		return nil, status.Errorf(codes.FailedPrecondition, "job %s is already running", name)
+	case err != nil:
+		return nil, status.Error(codes.Internal, "failed to start job")
+	}
+	return convertRunToProto(run), nil
+}
+
+func convertRunToProto(run *models.Run) *pb.JobRun {
+	out := &pb.JobRun{
+		Id:        run.ID.String(),
+		JobName:   run.JobName,
+		Trigger:   string(run.Trigger),
+		Status:    pb.JobRunStatus(pb.JobRunStatus_value["JOB_RUN_STATUS_"+string(run.Status)]),
+		Instance:  run.Instance,
+		Message:   run.Message,
+		Error:     run.Error,
+		StartedAt: timestamppb.New(run.StartedAt),
+	}
+	if run.FinishedAt != nil {
+		out.FinishedAt = timestamppb.New(*run.FinishedAt)
+	}
+	return out
+}
@@ -0,0 +1,248 @@
+// Package jobs defines the maintenance work run by the scheduler. Each job
+// calls an admin RPC on the service owning the data, so the work itself stays
+// next to its tables and the scheduler only decides when it happens.
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	authpb "auth-service/pb"
+	commentpb "comment-service/pb"
+	feedpb "feed-service/pb"
+	notificationpb "notification-service/pb"
+	postpb "post-service/pb"
+	userpb "user-service/pb"
+
+	"scheduler-service/interceptor"
+	"scheduler-service/scheduler"
+)
+
+const tokenExpiry = 5 * time.Minute
+
+// Clients are the services jobs call into
+type Clients struct {
+	Auth         authpb.AuthServiceClient
+	Comment      commentpb.CommentServiceClient
+	Feed         feedpb.FeedServiceClient
+	Notification notificationpb.NotificationServiceClient
+	Post         postpb.PostServiceClient
+	User         userpb.UserServiceClient
+}
+
+// Config holds the schedules and retention windows of the built-in jobs
+type Config struct {
+	// Schedules maps a job name to its cron expression; jobs missing from the
+	// map use their default schedule, and an empty expression disables the job
+	Schedules map[string]string
+
+	FeedRetention            time.Duration
+	NotificationRetention    time.Duration
+	WebhookDeliveryRetention time.Duration
+	// DeletedContentRetention is how long deleted posts and comments are
+	// kept as tombstones before they are purged
+	DeletedContentRetention time.Duration
+}
+
+// Defaults are the schedules jobs run on unless configured otherwise
+var Defaults = map[string]string{
+	"feed-cleanup":             "0 3 * * *",
+	"token-purge":              "0 * * * *",
+	"notification-retention":   "30 4 * * *",
+	"post-purge":               "0 5 * * *",
+	"comment-purge":            "15 5 * * *",
+	"counter-reconcile":        "30 5 * * *",
+	"follow-counter-reconcile": "45 5 * * *",
+	"posts-count-reconcile":    "0 6 * * *",
+}
+
+// All returns the built-in jobs
+func All(clients Clients, keys interceptor.Keys, cfg Config) []scheduler.Job {
+	schedule := func(name string) string {
+		if expr, ok := cfg.Schedules[name]; ok {
+			return expr
+		}
+		return Defaults[name]
+	}
+	auth := authorizer(keys)
+
+	return []scheduler.Job{
+		{
+			Name:        "feed-cleanup",
+			Description: fmt.Sprintf("Delete feed items older than %s", cfg.FeedRetention),
+			Schedule:    schedule("feed-cleanup"),
+			Run: func(ctx context.Context) (string, error) {
+				ctx, err := auth(ctx)
+				if err != nil {
+					return "", err
+				}
+				resp, err := clients.Feed.CleanupFeedCache(ctx, &feedpb.CleanupFeedCacheRequest{
+					OlderThan: timestamppb.New(time.Now().Add(-cfg.FeedRetention)),
+				})
+				if err != nil {
+					return "", err
+				}
+				return fmt.Sprintf("deleted %d feed items", resp.Deleted), nil
+			},
+		},
+		{
+			Name:        "token-purge",
+			Description: "Delete expired refresh tokens and blacklist entries",
+			Schedule:    schedule("token-purge"),
+			Run: func(ctx context.Context) (string, error) {
+				ctx, err := auth(ctx)
+				if err != nil {
+					return "", err
+				}
+				resp, err := clients.Auth.PurgeExpiredTokens(ctx, &authpb.PurgeExpiredTokensRequest{})
+				if err != nil {
+					return "", err
+				}
+				return fmt.Sprintf("deleted %d refresh tokens and %d blacklist entries",
+					resp.RefreshTokensDeleted, resp.BlacklistedTokensDeleted), nil
+			},
+		},
+		{
+			Name: "notification-retention",
+			Description: fmt.Sprintf("Delete read notifications older than %s and webhook deliveries older than %s",
+				cfg.NotificationRetention, cfg.WebhookDeliveryRetention),
+			Schedule: schedule("notification-retention"),
+			Run: func(ctx context.Context) (string, error) {
+				ctx, err := auth(ctx)
+				if err != nil {
+					return "", err
+				}
+				now := time.Now()
+				resp, err := clients.Notification.PurgeNotifications(ctx, &notificationpb.PurgeNotificationsRequest{
+					ReadBefore:       timestamppb.New(now.Add(-cfg.NotificationRetention)),
+					DeliveriesBefore: timestamppb.New(now.Add(-cfg.WebhookDeliveryRetention)),
+				})
+				if err != nil {
+					return "", err
+				}
+				return fmt.Sprintf("deleted %d notifications and %d webhook deliveries",
+					resp.NotificationsDeleted, resp.DeliveriesDeleted), nil
+			},
+		},
+		{
+			Name:        "post-purge",
+			Description: fmt.Sprintf("Hard-delete posts deleted more than %s ago", cfg.DeletedContentRetention),
+			Schedule:    schedule("post-purge"),
+			Run: func(ctx context.Context) (string, error) {
+				ctx, err := auth(ctx)
+				if err != nil {
+					return "", err
+				}
+				resp, err := clients.Post.PurgeDeletedPosts(ctx, &postpb.PurgeDeletedPostsRequest{
+					DeletedBefore: timestamppb.New(time.Now().Add(-cfg.DeletedContentRetention)),
+				})
+				if err != nil {
+					return "", err
+				}
+				return fmt.Sprintf("purged %d posts", resp.Purged), nil
+			},
+		},
+		{
+			Name:        "comment-purge",
+			Description: fmt.Sprintf("Hard-delete comments deleted more than %s ago", cfg.DeletedContentRetention),
+			Schedule:    schedule("comment-purge"),
+			Run: func(ctx context.Context) (string, error) {
+				ctx, err := auth(ctx)
+				if err != nil {
+					return "", err
+				}
+				resp, err := clients.Comment.PurgeDeletedComments(ctx, &commentpb.PurgeDeletedCommentsRequest{
+					DeletedBefore: timestamppb.New(time.Now().Add(-cfg.DeletedContentRetention)),
+				})
+				if err != nil {
+					return "", err
+				}
+				return fmt.Sprintf("purged %d comments", resp.Purged), nil
+			},
+		},
+		{
+			Name:        "counter-reconcile",
+			Description: "Recount the likes and comments of every post and correct drifted counters",
+			Schedule:    schedule("counter-reconcile"),
+			// Every post is recounted, in batches
+			Timeout: time.Hour,
+			Run: func(ctx context.Context) (string, error) {
+				ctx, err := auth(ctx)
+				if err != nil {
+					return "", err
+				}
+				resp, err := clients.Post.ReconcilePostCounters(ctx, &postpb.ReconcilePostCountersRequest{})
+				if err != nil {
+					return "", err
+				}
+				return fmt.Sprintf("checked %d posts and corrected %d", resp.Checked, resp.Corrected), nil
+			},
+		},
+		{
+			Name:        "follow-counter-reconcile",
+			Description: "Recount the followers and followings of every user and correct drifted counters",
+			Schedule:    schedule("follow-counter-reconcile"),
+			// Every user is recounted, in batches
+			Timeout: time.Hour,
+			Run: func(ctx context.Context) (string, error) {
+				ctx, err := auth(ctx)
+				if err != nil {
+					return "", err
+				}
+				resp, err := clients.User.ReconcileFollowCounters(ctx, &userpb.ReconcileFollowCountersRequest{})
+				if err != nil {
+					return "", err
+				}
+				return fmt.Sprintf("checked %d users and corrected %d", resp.Checked, resp.Corrected), nil
+			},
+		},
+		{
+			Name:        "posts-count-reconcile",
+			Description: "Recount the posts of every user and correct drifted counters",
+			Schedule:    schedule("posts-count-reconcile"),
+			// Every user is recounted, in batches
+			Timeout: time.Hour,
+			Run: func(ctx context.Context) (string, error) {
+				ctx, err := auth(ctx)
+				if err != nil {
+					return "", err
+				}
+				resp, err := clients.User.ReconcilePostCounters(ctx, &userpb.ReconcilePostCountersRequest{})
+				if err != nil {
+					return "", err
+				}
+				return fmt.Sprintf("checked %d users and corrected %d", resp.Checked, resp.Corrected), nil
+			},
+		},
+	}
+}
+
+// authorizer returns a function attaching a short-lived ADMIN token, in the
+// same shape auth-service issues, to outgoing calls
+func authorizer(keys interceptor.Keys) func(ctx context.Context) (context.Context, error) {
+	return func(ctx context.Context) (context.Context, error) {
+		now := time.Now()
+		claims := interceptor.Claims{
+			UserID: uuid.Nil.String(),
+			Roles:  []string{interceptor.RoleAdmin},
+			RegisteredClaims: jwt.RegisteredClaims{
+				Issuer:    "scheduler-service",
+				Subject:   uuid.Nil.String(),
+				IssuedAt:  jwt.NewNumericDate(now),
+				ExpiresAt: jwt.NewNumericDate(now.Add(tokenExpiry)),
+			},
+		}
+
+		token, err := keys.Sign(claims)
+		if err != nil {
+			return nil, fmt.Errorf("failed to sign scheduler token: %w", err)
+		}
+		return metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token), nil
+	}
+}
@@ -0,0 +1,32 @@
+-- ========================================
+-- Scheduler Service Schema (Standalone)
+-- ========================================
+
+-- ========================================
+-- Job Runs Table
+-- ========================================
+CREATE TABLE IF NOT EXISTS scheduler_service_runs (
+    id UUID PRIMARY KEY,
+    job_name VARCHAR(64) NOT NULL,
+    trigger VARCHAR(16) NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'RUNNING',
+    instance VARCHAR(255) NOT NULL,
+    message TEXT,
+    error TEXT,
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    finished_at TIMESTAMP WITH TIME ZONE,
+    CONSTRAINT scheduler_runs_trigger_valid CHECK (trigger IN ('SCHEDULED', 'MANUAL')),
+    CONSTRAINT scheduler_runs_status_valid CHECK (status IN ('RUNNING', 'SUCCEEDED', 'FAILED'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_scheduler_runs_job_started_at ON scheduler_service_runs(job_name, started_at DESC);
+CREATE INDEX IF NOT EXISTS idx_scheduler_runs_started_at ON scheduler_service_runs(started_at DESC);
+
+-- ========================================
+-- Job Locks (one lease per job across scheduler instances)
+-- ========================================
+CREATE TABLE IF NOT EXISTS scheduler_service_locks (
+    job_name VARCHAR(64) PRIMARY KEY,
+    locked_by VARCHAR(255) NOT NULL,
+    locked_until TIMESTAMP WITH TIME ZONE NOT NULL
+);
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type RunStatus string
+
+const (
+	RunRunning   RunStatus = "RUNNING"
+	RunSucceeded RunStatus = "SUCCEEDED"
+	RunFailed    RunStatus = "FAILED"
+)
+
+type Trigger string
+
+const (
+	TriggerScheduled Trigger = "SCHEDULED"
+	TriggerManual    Trigger = "MANUAL"
+)
+
+type Run struct {
+	ID         uuid.UUID  `json:"id" db:"id"`
+	JobName    string     `json:"job_name" db:"job_name"`
+	Trigger    Trigger    `json:"trigger" db:"trigger"`
+	Status     RunStatus  `json:"status" db:"status"`
+	Instance   string     `json:"instance" db:"instance"`
+	Message    *string    `json:"message,omitempty" db:"message"`
+	Error      *string    `json:"error,omitempty" db:"error"`
+	StartedAt  time.Time  `json:"started_at" db:"started_at"`
+	FinishedAt *time.Time `json:"finished_at,omitempty" db:"finished_at"`
+}
@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"scheduler-service/db"
+	"scheduler-service/model"
+)
+
+type SchedulerRepository interface {
+	// WithTx runs fn in a single transaction; repository calls made with the
+	// context passed to fn join it
+	WithTx(ctx context.Context, fn func(ctx context.Context) error) error
+	AcquireLock(ctx context.Context, jobName, owner string, lease time.Duration) (bool, error)
+	ReleaseLock(ctx context.Context, jobName, owner string) error
+	CreateRun(ctx context.Context, run *models.Run) error
+	FinishRun(ctx context.Context, run *models.Run) error
+	FailInterruptedRuns(ctx context.Context, jobName string) error
+	ListRuns(ctx context.Context, jobName *string, limit int32) ([]models.Run, error)
+	LastRuns(ctx context.Context) (map[string]models.Run, error)
+}
+
+type schedulerRepository struct {
+	db *database.DB
+}
+
+func NewSchedulerRepository(db *database.DB) SchedulerRepository {
+	return &schedulerRepository{db: db}
+}
+
+const runColumns = `id, job_name, trigger, status, instance, message, error, started_at, finished_at`
+
+func (r *schedulerRepository) WithTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return r.db.WithTx(ctx, fn)
+}
+
+// AcquireLock takes the job's lock for the lease duration. It succeeds when
+// the lock is free or its previous lease has expired, so a crashed instance
+// blocks a job for at most one lease.
+func (r *schedulerRepository) AcquireLock(ctx context.Context, jobName, owner string, lease time.Duration) (bool, error) {
+	query := `
+		INSERT INTO scheduler_service_locks (job_name, locked_by, locked_until)
+		VALUES ($1, $2, NOW() + $3 * INTERVAL '1 second')
+		ON CONFLICT (job_name) DO UPDATE
+		SET locked_by = EXCLUDED.locked_by, locked_until = EXCLUDED.locked_until
+		WHERE scheduler_service_locks.locked_until < NOW()
+	`
+
+	result, err := r.db.Conn(ctx).ExecContext(ctx, query, jobName, owner, lease.Seconds())
+	if err != nil {
+		return false, fmt.Errorf("failed to acquire lock for %s: %w", jobName, err)
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+func (r *schedulerRepository) ReleaseLock(ctx context.Context, jobName, owner string) error {
+	query := `DELETE FROM scheduler_service_locks WHERE job_name = $1 AND locked_by = $2`
+	if _, err := r.db.Conn(ctx).ExecContext(ctx, query, jobName, owner); err != nil {
+		return fmt.Errorf("failed to release lock for %s: %w", jobName, err)
+	}
+	return nil
+}
+
+func (r *schedulerRepository) CreateRun(ctx context.Context, run *models.Run) error {
+	query := `
+		INSERT INTO scheduler_service_runs (id, job_name, trigger, status, instance, started_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+	_, err := r.db.Conn(ctx).ExecContext(ctx, query, run.ID, run.JobName, run.Trigger, run.Status, run.Instance, run.StartedAt)
+	if err != nil {
+		return fmt.Errorf("failed to record run: %w", err)
+	}
+	return nil
+}
+
+func (r *schedulerRepository) FinishRun(ctx context.Context, run *models.Run) error {
+	query := `
+		UPDATE scheduler_service_runs
+		SET status = $2, message = $3, error = $4, finished_at = $5
+		WHERE id = $1
+	`
+	_, err := r.db.Conn(ctx).ExecContext(ctx, query, run.ID, run.Status, run.Message, run.Error, run.FinishedAt)
+	if err != nil {
+		return fmt.Errorf("failed to finish run: %w", err)
+	}
+	return nil
+}
+
+// FailInterruptedRuns marks runs of a job still recorded as running as failed.
+// It is called while holding the job's lock, when no run can be in progress.
+func (r *schedulerRepository) FailInterruptedRuns(ctx context.Context, jobName string) error {
+	query := `
+		UPDATE scheduler_service_runs
+		SET status = $2, error = 'interrupted before completion', finished_at = NOW()
+		WHERE job_name = $1 AND status = $3
+	`
+	_, err := r.db.Conn(ctx).ExecContext(ctx, query, jobName, models.RunFailed, models.RunRunning)
+	if err != nil {
+		return fmt.Errorf("failed to close interrupted runs: %w", err)
+	}
+	return nil
+}
+
+func (r *schedulerRepository) ListRuns(ctx context.Context, jobName *string, limit int32) ([]models.Run, error) {
+	query := `SELECT ` + runColumns + ` FROM scheduler_service_runs`
+	args := []interface{}{}
+	if jobName != nil {
+		query += ` WHERE job_name = $1`
+		args = append(args, *jobName)
+	}
+	query += fmt.Sprintf(" ORDER BY started_at DESC LIMIT $%d", len(args)+1)
+	args = append(args, limit)
+
+	runs := []models.Run{}
+	if err := r.db.ReadDB().SelectContext(ctx, &runs, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to list runs: %w", err)
+	}
+	return runs, nil
+}
+
+// LastRuns returns the most recent run of every job that has run
+func (r *schedulerRepository) LastRuns(ctx context.Context) (map[string]models.Run, error) {
+	query := `
+		SELECT DISTINCT ON (job_name) ` + runColumns + `
+		FROM scheduler_service_runs
+		ORDER BY job_name, started_at DESC
+	`
+
+	var runs []models.Run
+	if err := r.db.ReadDB().SelectContext(ctx, &runs, query); err != nil {
+		return nil, fmt.Errorf("failed to get last runs: %w", err)
+	}
+
+	result := make(map[string]models.Run, len(runs))
+	for _, run := range runs {
+		result[run.JobName] = run
+	}
+	return result, nil
+}
@@ -0,0 +1,298 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"scheduler-service/cron"
+	"scheduler-service/model"
+	"scheduler-service/repository"
+)
+
+var (
+	ErrUnknownJob = errors.New("unknown job")
+	ErrJobRunning = errors.New("job is already running")
+)
+
+const (
+	defaultTimeout = 10 * time.Minute
+
+	// leaseMargin keeps a job's lock held a little past its timeout, so a slow
+	// run finishing up cannot overlap with the next one
+	leaseMargin = time.Minute
+)
+
+// Job is a unit of scheduled work. Run returns a short summary of what it did,
+// which is stored with the run.
+type Job struct {
+	Name        string
+	Description string
+	// Schedule is a cron expression evaluated in the scheduler's location; an
+	// empty schedule disables the job, though it can still be triggered manually
+	Schedule string
+	Timeout  time.Duration
+	Run      func(ctx context.Context) (string, error)
+}
+
+type entry struct {
+	job      Job
+	schedule *cron.Schedule
+	next     time.Time
+}
+
+// Scheduler runs registered jobs on their schedules. Every run takes the job's
+// lock first, so when several instances are deployed each activation executes
+// once.
+type Scheduler struct {
+	repo     repository.SchedulerRepository
+	instance string
+	location *time.Location
+
+	mu      sync.Mutex
+	entries []*entry
+	byName  map[string]*entry
+
+	runCtx context.Context
+	wg     sync.WaitGroup
+}
+
+func New(repo repository.SchedulerRepository, instance string, location *time.Location) *Scheduler {
+	return &Scheduler{
+		repo:     repo,
+		instance: instance,
+		location: location,
+		byName:   make(map[string]*entry),
+		runCtx:   context.Background(),
+	}
+}
+
+// Register adds a job; it must be called before Start
+func (s *Scheduler) Register(job Job) error {
+	if job.Name == "" || job.Run == nil {
+		return fmt.Errorf("job requires a name and a run function")
+	}
+	if _, exists := s.byName[job.Name]; exists {
+		return fmt.Errorf("job %s is already registered", job.Name)
+	}
+	if job.Timeout <= 0 {
+		job.Timeout = defaultTimeout
+	}
+
+	e := &entry{job: job}
+	if job.Schedule != "" {
+		schedule, err := cron.Parse(job.Schedule)
+		if err != nil {
+			return fmt.Errorf("job %s: %w", job.Name, err)
+		}
+		e.schedule = schedule
+	}
+
+	s.entries = append(s.entries, e)
+	s.byName[job.Name] = e
+	return nil
+}
+
+// Jobs returns the registered jobs in registration order
+func (s *Scheduler) Jobs() []Job {
+	jobs := make([]Job, len(s.entries))
+	for i, e := range s.entries {
+		jobs[i] = e.job
+	}
+	return jobs
+}
+
+// NextRun returns when a job is next due, or false when it has no schedule
+func (s *Scheduler) NextRun(name string) (time.Time, bool) {
+	e, ok := s.byName[name]
+	if !ok || e.schedule == nil {
+		return time.Time{}, false
+	}
+
+	s.mu.Lock()
+	next := e.next
+	s.mu.Unlock()
+	if next.IsZero() {
+		next = e.schedule.Next(time.Now().In(s.location))
+	}
+	return next, !next.IsZero()
+}
+
+// Start runs jobs as they come due until ctx is cancelled. Runs started before
+// then are cancelled with it; Wait blocks until they have been recorded.
+func (s *Scheduler) Start(ctx context.Context) {
+	now := time.Now().In(s.location)
+	s.mu.Lock()
+	s.runCtx = ctx
+	for _, e := range s.entries {
+		if e.schedule != nil {
+			e.next = e.schedule.Next(now)
+		}
+	}
+	s.mu.Unlock()
+
+	for {
+		wake := s.earliest()
+		if wake.IsZero() {
+			<-ctx.Done()
+			return
+		}
+
+		timer := time.NewTimer(time.Until(wake))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		s.runDue(ctx)
+	}
+}
+
+// Wait blocks until all runs in progress have finished
+func (s *Scheduler) Wait() {
+	s.wg.Wait()
+}
+
+func (s *Scheduler) earliest() time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var earliest time.Time
+	for _, e := range s.entries {
+		if e.next.IsZero() {
+			continue
+		}
+		if earliest.IsZero() || e.next.Before(earliest) {
+			earliest = e.next
+		}
+	}
+	return earliest
+}
+
+func (s *Scheduler) runDue(ctx context.Context) {
+	now := time.Now().In(s.location)
+
+	var due []*entry
+	s.mu.Lock()
+	for _, e := range s.entries {
+		if !e.next.IsZero() && !e.next.After(now) {
+			due = append(due, e)
+			e.next = e.schedule.Next(now)
+		}
+	}
+	s.mu.Unlock()
+
+	for _, e := range due {
+		if _, err := s.start(ctx, e, models.TriggerScheduled); err != nil {
+			if errors.Is(err, ErrJobRunning) {
+				log.Printf("Skipping %s: %v", e.job.Name, err)
+				continue
+			}
+			log.Printf("Failed to start %s: %v", e.job.Name, err)
+		}
+	}
+}
+
+// Trigger starts a job immediately, outside its schedule
+func (s *Scheduler) Trigger(ctx context.Context, name string) (*models.Run, error) {
+	e, ok := s.byName[name]
+	if !ok {
+		return nil, ErrUnknownJob
+	}
+	return s.start(ctx, e, models.TriggerManual)
+}
+
+// start takes the job's lock and records the run, then executes it in the
+// background
+func (s *Scheduler) start(ctx context.Context, e *entry, trigger models.Trigger) (*models.Run, error) {
+	run := &models.Run{
+		ID:        uuid.New(),
+		JobName:   e.job.Name,
+		Trigger:   trigger,
+		Status:    models.RunRunning,
+		Instance:  s.instance,
+		StartedAt: time.Now(),
+	}
+	owner := fmt.Sprintf("%s/%s", s.instance, run.ID)
+
+	err := s.repo.WithTx(ctx, func(ctx context.Context) error {
+		acquired, err := s.repo.AcquireLock(ctx, e.job.Name, owner, e.job.Timeout+leaseMargin)
+		if err != nil {
+			return err
+		}
+		if !acquired {
+			return ErrJobRunning
+		}
+		// Holding the lock means no other run is in progress, so any run
+		// still marked running was cut short by a crash
+		if err := s.repo.FailInterruptedRuns(ctx, e.job.Name); err != nil {
+			return err
+		}
+		return s.repo.CreateRun(ctx, run)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	started := *run
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.execute(e.job, run, owner)
+	}()
+	return &started, nil
+}
+
+func (s *Scheduler) execute(job Job, run *models.Run, owner string) {
+	log.Printf("Running %s (%s)", job.Name, run.Trigger)
+
+	s.mu.Lock()
+	parent := s.runCtx
+	s.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(parent, job.Timeout)
+	message, err := runJob(ctx, job)
+	cancel()
+
+	finished := time.Now()
+	run.FinishedAt = &finished
+	run.Status = models.RunSucceeded
+	if message != "" {
+		run.Message = &message
+	}
+	if err != nil {
+		errMsg := err.Error()
+		run.Status = models.RunFailed
+		run.Error = &errMsg
+		log.Printf("Job %s failed after %s: %v", job.Name, finished.Sub(run.StartedAt), err)
+	} else {
+		log.Printf("Job %s succeeded after %s: %s", job.Name, finished.Sub(run.StartedAt), message)
+	}
+
+	// Record the outcome even when the scheduler is shutting down
+	recordCtx, cancelRecord := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancelRecord()
+
+	if err := s.repo.FinishRun(recordCtx, run); err != nil {
+		log.Printf("Failed to record run %s of %s: %v", run.ID, job.Name, err)
+	}
+	if err := s.repo.ReleaseLock(recordCtx, job.Name, owner); err != nil {
+		log.Printf("Failed to release lock of %s: %v", job.Name, err)
+	}
+}
+
+// runJob calls the job's run function, turning a panic into a failed run
+func runJob(ctx context.Context, job Job) (message string, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return job.Run(ctx)
+}
@@ -0,0 +1,183 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"time"
+
+	"github.com/joho/godotenv"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+
+	"search-service/config"
+	"search-service/db"
+	"search-service/handler"
+	"search-service/interceptor"
+	"search-service/migrations"
+	natsClient "search-service/nats"
+	pb "search-service/pb"
+	"search-service/repository"
+	"search-service/rpcerror"
+	"search-service/subscriber"
+	"shared/chaos"
+	"shared/cursor"
+	"shared/lifecycle"
+	"shared/logging"
+	"shared/migrate"
+	"shared/mtls"
+	"shared/tracing"
+)
+
+func main() {
+	logging.Init("search-service")
+
+	migrateFlag := flag.Bool("migrate", false, "apply pending schema migrations before serving")
+	flag.Parse()
+
+	if err := godotenv.Load(); err != nil {
+		log.Println("failed to load Search .env")
+	}
+
+	// The supervisor health-checks the dependencies below and closes them
+	// in order on shutdown
+	supervisor := lifecycle.New(pb.SearchService_ServiceDesc.ServiceName)
+
+	// Load database configuration
+	dbCfg, err := config.LoadDatabaseConfig("SEARCH_")
+	if err != nil {
+		log.Fatalf("Failed to load Search database config: %v", err)
+	}
+
+	// Connect to the database
+	dbConn, err := database.NewConnection(database.Config{
+		Host:         dbCfg.Host,
+		Port:         dbCfg.Port,
+		User:         dbCfg.User,
+		Password:     dbCfg.Password,
+		DBName:       dbCfg.DBName,
+		SSLMode:      dbCfg.SSLMode,
+		MaxOpenConns: dbCfg.MaxOpenConns,
+		MaxIdleConns: dbCfg.MaxIdleConns,
+		MaxLifetime:  dbCfg.MaxLifetime,
+		ReplicaDSNs:  dbCfg.ReplicaDSNs,
+	})
+	if err != nil {
+		log.Fatalf("Failed to connect to Search database: %v", err)
+	}
+	supervisor.Add(lifecycle.Dependency{Name: "database", Check: dbConn.HealthCheck, Close: dbConn.Close})
+
+	// Apply pending schema migrations with -migrate or DB_MIGRATE=true;
+	// without either they are only reported
+	if err := migrate.OnStartup(context.Background(), dbConn.DB, "search-service", migrations.Files, *migrateFlag || os.Getenv("DB_MIGRATE") == "true"); err != nil {
+		log.Fatalf("Failed to migrate Search database: %v", err)
+	}
+
+	log.Println("Successfully connected to database")
+
+	// Load other service-level configs
+	grpcPort := getEnv("GRPC_PORT", "50062")
+	jwtSecret := getEnv("JWT_SECRET", "your-secret-key")
+	// Tokens are verified with JWT_SECRET, or with auth-service's public keys
+	// when JWT_ALGORITHM is RS256
+	tokenKeys, err := interceptor.KeysFromEnv(jwtSecret)
+	if err != nil {
+		log.Fatalf("Invalid JWT configuration: %v", err)
+	}
+
+	// Fault injection for resilience testing; inert unless CHAOS_ENABLED=true
+	chaosInjector, err := chaos.FromEnv()
+	if err != nil {
+		log.Fatalf("Invalid chaos configuration: %v", err)
+	}
+
+	// Export traces when OTEL_EXPORTER_OTLP_ENDPOINT is set
+	shutdownTracing, err := tracing.Init(context.Background(), "search-service")
+	if err != nil {
+		log.Fatalf("Failed to initialize tracing: %v", err)
+	}
+	supervisor.OnShutdown("tracing", shutdownTracing)
+
+	// Pagination cursors are signed so clients cannot forge positions
+	if err := cursor.SetSecret(os.Getenv("CURSOR_SECRET")); err != nil {
+		log.Fatalf("Invalid CURSOR_SECRET: %v", err)
+	}
+
+	// Initialize NATS client
+	nats, err := natsClient.NewClient(natsClient.Config{
+		URL:           getEnv("NATS_URL", "nats://nats:4222"),
+		MaxReconnects: 10,
+		ReconnectWait: 2 * time.Second,
+		ClientID:      getEnv("NATS_CLIENT_ID", "search-service"),
+		Chaos:         chaosInjector,
+	})
+	if err != nil {
+		log.Fatalf("Failed to initialize NATS client: %v", err)
+	}
+	supervisor.Add(lifecycle.Dependency{Name: "nats", Check: nats.HealthCheck, Drain: nats.Drain})
+
+	// Initialize repository, index subscriber and handler
+	searchRepo := repository.NewSearchRepository(dbConn)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	indexSub := subscriber.NewIndexSubscriber(nats, searchRepo, ctx)
+	if err := indexSub.Start(); err != nil {
+		log.Fatalf("Failed to start index subscriber: %v", err)
+	}
+
+	searchHandler := handler.NewSearchHandler(searchRepo)
+
+	// Search is public, like browsing profiles and posts
+	authInterceptor := interceptor.NewAuthInterceptor(tokenKeys, []string{
+		"/search.SearchService/SearchPosts",
+		"/search.SearchService/SearchUsers",
+		"/search.SearchService/SearchAll",
+	})
+	authInterceptor.AddPublicMethods(lifecycle.HealthMethods)
+
+	// TLS, and mutual TLS with a CA bundle, from GRPC_TLS_*
+	tlsOption, err := mtls.ServerOption()
+	if err != nil {
+		log.Fatalf("Failed to load TLS config: %v", err)
+	}
+
+	// Create gRPC server
+	grpcServer := grpc.NewServer(
+		tlsOption,
+		tracing.ServerOption(),
+		grpc.ChainUnaryInterceptor(logging.UnaryServerInterceptor(), rpcerror.UnaryServerInterceptor(), chaosInjector.Unary(), authInterceptor.Unary()),
+	)
+
+	// Register the gRPC service
+	pb.RegisterSearchServiceServer(grpcServer, searchHandler)
+	supervisor.Register(grpcServer)
+
+	// Enable reflection for debugging tools like grpcurl
+	reflection.Register(grpcServer)
+
+	// Start listening for connections
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%s", grpcPort))
+	if err != nil {
+		log.Fatalf("Failed to listen on port %s: %v", grpcPort, err)
+	}
+
+	log.Printf("Search Service gRPC server listening on port %s", grpcPort)
+
+	// Serve requests
+	if err := supervisor.Serve(grpcServer, listener); err != nil {
+		log.Fatalf("Failed to serve: %v", err)
+	}
+}
+
+// small helper for optional env vars
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
@@ -0,0 +1,32 @@
+package events
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"shared/eventschema"
+)
+
+// Subjects the index is kept fresh from
+const (
+	PostCreated = eventschema.PostCreated
+	PostUpdated = eventschema.PostUpdated
+	PostDeleted = eventschema.PostDeleted
+	UserUpdated = "user.updated"
+	UserDeleted = "user.deleted"
+)
+
+// Event payloads, as published by user-service. Post events are decoded with
+// shared/eventschema.
+type UserUpdatedEvent struct {
+	UserID    uuid.UUID `json:"user_id"`
+	Username  string    `json:"username"`
+	Bio       *string   `json:"bio,omitempty"`
+	IsPrivate bool      `json:"is_private"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type UserDeletedEvent struct {
+	UserID    uuid.UUID `json:"user_id"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
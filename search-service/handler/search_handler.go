@@ -0,0 +1,161 @@
+package handler
+
+import (
+	"context"
+	"strings"
+	"unicode/utf8"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"search-service/model"
+	pb "search-service/pb"
+	"search-service/repository"
+	"search-service/rpcerror"
+	"shared/cursor"
+)
+
+const (
+	defaultPageSize = 10
+	maxPageSize     = 50
+	// SearchAll returns a short preview of each kind of result
+	defaultPreviewSize = 5
+	maxQueryLength     = 256
+)
+
+type SearchHandler struct {
+	pb.UnimplementedSearchServiceServer
+	repo repository.SearchRepository
+}
+
+func NewSearchHandler(repo repository.SearchRepository) *SearchHandler {
+	return &SearchHandler{repo: repo}
+}
+
+// SearchPosts returns posts matching the query, most relevant first
+func (h *SearchHandler) SearchPosts(ctx context.Context, req *pb.SearchRequest) (*pb.SearchPostsResponse, error) {
+	query, offset, limit, err := parseRequest(req, defaultPageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	hits, err := h.repo.SearchPosts(ctx, query, offset, limit+1)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to search posts")
+	}
+
+	resp := &pb.SearchPostsResponse{HasNextPage: len(hits) > limit}
+	if resp.HasNextPage {
+		hits = hits[:limit]
+	}
+	resp.Hits = convertPostHitsToProto(hits)
+	if len(hits) > 0 {
+		endCursor := cursor.EncodeOffset(offset + len(hits))
+		resp.EndCursor = &endCursor
+	}
+	return resp, nil
+}
+
+// SearchUsers returns users whose username or bio match the query
+func (h *SearchHandler) SearchUsers(ctx context.Context, req *pb.SearchRequest) (*pb.SearchUsersResponse, error) {
+	query, offset, limit, err := parseRequest(req, defaultPageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	hits, err := h.repo.SearchUsers(ctx, query, offset, limit+1)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to search users")
+	}
+
+	resp := &pb.SearchUsersResponse{HasNextPage: len(hits) > limit}
+	if resp.HasNextPage {
+		hits = hits[:limit]
+	}
+	resp.Hits = convertUserHitsToProto(hits)
+	if len(hits) > 0 {
+		endCursor := cursor.EncodeOffset(offset + len(hits))
+		resp.EndCursor = &endCursor
+	}
+	return resp, nil
+}
+
+// SearchAll returns the first page of both posts and users
+func (h *SearchHandler) SearchAll(ctx context.Context, req *pb.SearchRequest) (*pb.SearchAllResponse, error) {
+	query, _, limit, err := parseRequest(&pb.SearchRequest{Query: req.Query, First: req.First}, defaultPreviewSize)
+	if err != nil {
+		return nil, err
+	}
+
+	posts, err := h.repo.SearchPosts(ctx, query, 0, limit)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to search posts")
+	}
+
+	users, err := h.repo.SearchUsers(ctx, query, 0, limit)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to search users")
+	}
+
+	return &pb.SearchAllResponse{
+		Posts: convertPostHitsToProto(posts),
+		Users: convertUserHitsToProto(users),
+	}, nil
+}
+
+func parseRequest(req *pb.SearchRequest, defaultLimit int) (string, int, int, error) {
+	query := strings.TrimSpace(req.Query)
+	if query == "" {
+		return "", 0, 0, rpcerror.InvalidField("query", "query is required")
+	}
+	if utf8.RuneCountInString(query) > maxQueryLength {
+		return "", 0, 0, status.Errorf(codes.InvalidArgument, "query must be at most %d characters", maxQueryLength)
+	}
+
+	limit := int(req.First)
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+	if limit > maxPageSize {
+		limit = maxPageSize
+	}
+
+	var offset int
+	if req.After != nil && *req.After != "" {
+		decoded, err := cursor.DecodeOffset(*req.After)
+		if err != nil {
+			return "", 0, 0, status.Error(codes.InvalidArgument, "invalid cursor")
+		}
+		offset = decoded
+	}
+
+	return query, offset, limit, nil
+}
+
+func convertPostHitsToProto(hits []models.PostHit) []*pb.PostHit {
+	out := make([]*pb.PostHit, len(hits))
+	for i, hit := range hits {
+		out[i] = &pb.PostHit{
+			PostId:    hit.ID.String(),
+			UserId:    hit.UserID.String(),
+			Snippet:   hit.Snippet,
+			Rank:      hit.Rank,
+			CreatedAt: timestamppb.New(hit.CreatedAt),
+		}
+	}
+	return out
+}
+
+func convertUserHitsToProto(hits []models.UserHit) []*pb.UserHit {
+	out := make([]*pb.UserHit, len(hits))
+	for i, hit := range hits {
+		out[i] = &pb.UserHit{
+			UserId:   hit.ID.String(),
+			Username: hit.Username,
+			Bio:      hit.Bio,
+			Rank:     hit.Rank,
+		}
+	}
+	return out
+}
@@ -0,0 +1,38 @@
+-- ========================================
+-- Search Service Schema (Standalone)
+-- ========================================
+
+-- ========================================
+-- Post Index
+-- Deleted posts keep a tombstone row so that a late post.updated event
+-- cannot bring them back
+-- ========================================
+CREATE TABLE IF NOT EXISTS search_service_posts (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    content TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    document TSVECTOR GENERATED ALWAYS AS (to_tsvector('english', content)) STORED
+);
+
+CREATE INDEX IF NOT EXISTS idx_search_posts_document ON search_service_posts USING GIN (document);
+
+-- ========================================
+-- User Index
+-- Usernames are not natural language, so they use the 'simple' configuration
+-- and outrank matches in the bio
+-- ========================================
+CREATE TABLE IF NOT EXISTS search_service_users (
+    id UUID PRIMARY KEY,
+    username VARCHAR(255) NOT NULL,
+    bio TEXT,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    document TSVECTOR GENERATED ALWAYS AS (
+        setweight(to_tsvector('simple', username), 'A') ||
+        setweight(to_tsvector('simple', coalesce(bio, '')), 'B')
+    ) STORED
+);
+
+CREATE INDEX IF NOT EXISTS idx_search_users_document ON search_service_users USING GIN (document);
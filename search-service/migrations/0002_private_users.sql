@@ -0,0 +1,6 @@
+-- ========================================
+-- Private Accounts
+-- ========================================
+-- Privacy of indexed users, fed from user.updated. Posts by private accounts
+-- are not indexed and are left out of results if they were before.
+ALTER TABLE search_service_users ADD COLUMN IF NOT EXISTS is_private BOOLEAN NOT NULL DEFAULT FALSE;
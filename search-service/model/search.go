@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PostDocument is the indexed copy of a post
+type PostDocument struct {
+	ID        uuid.UUID `db:"id"`
+	UserID    uuid.UUID `db:"user_id"`
+	Content   string    `db:"content"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+// UserDocument is the indexed copy of a user profile
+type UserDocument struct {
+	ID        uuid.UUID `db:"id"`
+	Username  string    `db:"username"`
+	Bio       *string   `db:"bio"`
+	IsPrivate bool      `db:"is_private"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+type PostHit struct {
+	ID        uuid.UUID `db:"id"`
+	UserID    uuid.UUID `db:"user_id"`
+	Snippet   string    `db:"snippet"`
+	Rank      float32   `db:"rank"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+type UserHit struct {
+	ID       uuid.UUID `db:"id"`
+	Username string    `db:"username"`
+	Bio      *string   `db:"bio"`
+	Rank     float32   `db:"rank"`
+}
@@ -0,0 +1,513 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v6.32.1
+// source: proto/search.proto
+
+package __
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type SearchRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Query string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	First int32                  `protobuf:"varint,2,opt,name=first,proto3" json:"first,omitempty"`
+	// Cursor returned by a previous page; ignored by SearchAll
+	After         *string `protobuf:"bytes,3,opt,name=after,proto3,oneof" json:"after,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchRequest) Reset() {
+	*x = SearchRequest{}
+	mi := &file_proto_search_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchRequest) ProtoMessage() {}
+
+func (x *SearchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_search_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchRequest.ProtoReflect.Descriptor instead.
+func (*SearchRequest) Descriptor() ([]byte, []int) {
+	return file_proto_search_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SearchRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchRequest) GetFirst() int32 {
+	if x != nil {
+		return x.First
+	}
+	return 0
+}
+
+func (x *SearchRequest) GetAfter() string {
+	if x != nil && x.After != nil {
+		return *x.After
+	}
+	return ""
+}
+
+type PostHit struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	PostId string                 `protobuf:"bytes,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
+	UserId string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Plain-text excerpt of the content around the best match
+	Snippet       string                 `protobuf:"bytes,3,opt,name=snippet,proto3" json:"snippet,omitempty"`
+	Rank          float32                `protobuf:"fixed32,4,opt,name=rank,proto3" json:"rank,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PostHit) Reset() {
+	*x = PostHit{}
+	mi := &file_proto_search_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PostHit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostHit) ProtoMessage() {}
+
+func (x *PostHit) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_search_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostHit.ProtoReflect.Descriptor instead.
+func (*PostHit) Descriptor() ([]byte, []int) {
+	return file_proto_search_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PostHit) GetPostId() string {
+	if x != nil {
+		return x.PostId
+	}
+	return ""
+}
+
+func (x *PostHit) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *PostHit) GetSnippet() string {
+	if x != nil {
+		return x.Snippet
+	}
+	return ""
+}
+
+func (x *PostHit) GetRank() float32 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+func (x *PostHit) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type UserHit struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Bio           *string                `protobuf:"bytes,3,opt,name=bio,proto3,oneof" json:"bio,omitempty"`
+	Rank          float32                `protobuf:"fixed32,4,opt,name=rank,proto3" json:"rank,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserHit) Reset() {
+	*x = UserHit{}
+	mi := &file_proto_search_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserHit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserHit) ProtoMessage() {}
+
+func (x *UserHit) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_search_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserHit.ProtoReflect.Descriptor instead.
+func (*UserHit) Descriptor() ([]byte, []int) {
+	return file_proto_search_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *UserHit) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UserHit) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *UserHit) GetBio() string {
+	if x != nil && x.Bio != nil {
+		return *x.Bio
+	}
+	return ""
+}
+
+func (x *UserHit) GetRank() float32 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+type SearchPostsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hits          []*PostHit             `protobuf:"bytes,1,rep,name=hits,proto3" json:"hits,omitempty"`
+	EndCursor     *string                `protobuf:"bytes,2,opt,name=end_cursor,json=endCursor,proto3,oneof" json:"end_cursor,omitempty"`
+	HasNextPage   bool                   `protobuf:"varint,3,opt,name=has_next_page,json=hasNextPage,proto3" json:"has_next_page,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchPostsResponse) Reset() {
+	*x = SearchPostsResponse{}
+	mi := &file_proto_search_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchPostsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchPostsResponse) ProtoMessage() {}
+
+func (x *SearchPostsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_search_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchPostsResponse.ProtoReflect.Descriptor instead.
+func (*SearchPostsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_search_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *SearchPostsResponse) GetHits() []*PostHit {
+	if x != nil {
+		return x.Hits
+	}
+	return nil
+}
+
+func (x *SearchPostsResponse) GetEndCursor() string {
+	if x != nil && x.EndCursor != nil {
+		return *x.EndCursor
+	}
+	return ""
+}
+
+func (x *SearchPostsResponse) GetHasNextPage() bool {
+	if x != nil {
+		return x.HasNextPage
+	}
+	return false
+}
+
+type SearchUsersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hits          []*UserHit             `protobuf:"bytes,1,rep,name=hits,proto3" json:"hits,omitempty"`
+	EndCursor     *string                `protobuf:"bytes,2,opt,name=end_cursor,json=endCursor,proto3,oneof" json:"end_cursor,omitempty"`
+	HasNextPage   bool                   `protobuf:"varint,3,opt,name=has_next_page,json=hasNextPage,proto3" json:"has_next_page,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchUsersResponse) Reset() {
+	*x = SearchUsersResponse{}
+	mi := &file_proto_search_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchUsersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchUsersResponse) ProtoMessage() {}
+
+func (x *SearchUsersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_search_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchUsersResponse.ProtoReflect.Descriptor instead.
+func (*SearchUsersResponse) Descriptor() ([]byte, []int) {
+	return file_proto_search_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SearchUsersResponse) GetHits() []*UserHit {
+	if x != nil {
+		return x.Hits
+	}
+	return nil
+}
+
+func (x *SearchUsersResponse) GetEndCursor() string {
+	if x != nil && x.EndCursor != nil {
+		return *x.EndCursor
+	}
+	return ""
+}
+
+func (x *SearchUsersResponse) GetHasNextPage() bool {
+	if x != nil {
+		return x.HasNextPage
+	}
+	return false
+}
+
+type SearchAllResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Posts         []*PostHit             `protobuf:"bytes,1,rep,name=posts,proto3" json:"posts,omitempty"`
+	Users         []*UserHit             `protobuf:"bytes,2,rep,name=users,proto3" json:"users,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchAllResponse) Reset() {
+	*x = SearchAllResponse{}
+	mi := &file_proto_search_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchAllResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchAllResponse) ProtoMessage() {}
+
+func (x *SearchAllResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_search_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchAllResponse.ProtoReflect.Descriptor instead.
+func (*SearchAllResponse) Descriptor() ([]byte, []int) {
+	return file_proto_search_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *SearchAllResponse) GetPosts() []*PostHit {
+	if x != nil {
+		return x.Posts
+	}
+	return nil
+}
+
+func (x *SearchAllResponse) GetUsers() []*UserHit {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+var File_proto_search_proto protoreflect.FileDescriptor
+
+const file_proto_search_proto_rawDesc = "" +
+	"\n" +
+	"\x12proto/search.proto\x12\x06search\x1a\x1fgoogle/protobuf/timestamp.proto\"`\n" +
+	"\rSearchRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x14\n" +
+	"\x05first\x18\x02 \x01(\x05R\x05first\x12\x19\n" +
+	"\x05after\x18\x03 \x01(\tH\x00R\x05after\x88\x01\x01B\b\n" +
+	"\x06_after\"\xa4\x01\n" +
+	"\aPostHit\x12\x17\n" +
+	"\apost_id\x18\x01 \x01(\tR\x06postId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x18\n" +
+	"\asnippet\x18\x03 \x01(\tR\asnippet\x12\x12\n" +
+	"\x04rank\x18\x04 \x01(\x02R\x04rank\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"q\n" +
+	"\aUserHit\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x15\n" +
+	"\x03bio\x18\x03 \x01(\tH\x00R\x03bio\x88\x01\x01\x12\x12\n" +
+	"\x04rank\x18\x04 \x01(\x02R\x04rankB\x06\n" +
+	"\x04_bio\"\x91\x01\n" +
+	"\x13SearchPostsResponse\x12#\n" +
+	"\x04hits\x18\x01 \x03(\v2\x0f.search.PostHitR\x04hits\x12\"\n" +
+	"\n" +
+	"end_cursor\x18\x02 \x01(\tH\x00R\tendCursor\x88\x01\x01\x12\"\n" +
+	"\rhas_next_page\x18\x03 \x01(\bR\vhasNextPageB\r\n" +
+	"\v_end_cursor\"\x91\x01\n" +
+	"\x13SearchUsersResponse\x12#\n" +
+	"\x04hits\x18\x01 \x03(\v2\x0f.search.UserHitR\x04hits\x12\"\n" +
+	"\n" +
+	"end_cursor\x18\x02 \x01(\tH\x00R\tendCursor\x88\x01\x01\x12\"\n" +
+	"\rhas_next_page\x18\x03 \x01(\bR\vhasNextPageB\r\n" +
+	"\v_end_cursor\"a\n" +
+	"\x11SearchAllResponse\x12%\n" +
+	"\x05posts\x18\x01 \x03(\v2\x0f.search.PostHitR\x05posts\x12%\n" +
+	"\x05users\x18\x02 \x03(\v2\x0f.search.UserHitR\x05users2\xd4\x01\n" +
+	"\rSearchService\x12A\n" +
+	"\vSearchPosts\x12\x15.search.SearchRequest\x1a\x1b.search.SearchPostsResponse\x12A\n" +
+	"\vSearchUsers\x12\x15.search.SearchRequest\x1a\x1b.search.SearchUsersResponse\x12=\n" +
+	"\tSearchAll\x12\x15.search.SearchRequest\x1a\x19.search.SearchAllResponseB\x04Z\x02./b\x06proto3"
+
+var (
+	file_proto_search_proto_rawDescOnce sync.Once
+	file_proto_search_proto_rawDescData []byte
+)
+
+func file_proto_search_proto_rawDescGZIP() []byte {
+	file_proto_search_proto_rawDescOnce.Do(func() {
+		file_proto_search_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_search_proto_rawDesc), len(file_proto_search_proto_rawDesc)))
+	})
+	return file_proto_search_proto_rawDescData
+}
+
+var file_proto_search_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_proto_search_proto_goTypes = []any{
+	(*SearchRequest)(nil),         // 0: search.SearchRequest
+	(*PostHit)(nil),               // 1: search.PostHit
+	(*UserHit)(nil),               // 2: search.UserHit
+	(*SearchPostsResponse)(nil),   // 3: search.SearchPostsResponse
+	(*SearchUsersResponse)(nil),   // 4: search.SearchUsersResponse
+	(*SearchAllResponse)(nil),     // 5: search.SearchAllResponse
+	(*timestamppb.Timestamp)(nil), // 6: google.protobuf.Timestamp
+}
+var file_proto_search_proto_depIdxs = []int32{
+	6, // 0: search.PostHit.created_at:type_name -> google.protobuf.Timestamp
+	1, // 1: search.SearchPostsResponse.hits:type_name -> search.PostHit
+	2, // 2: search.SearchUsersResponse.hits:type_name -> search.UserHit
+	1, // 3: search.SearchAllResponse.posts:type_name -> search.PostHit
+	2, // 4: search.SearchAllResponse.users:type_name -> search.UserHit
+	0, // 5: search.SearchService.SearchPosts:input_type -> search.SearchRequest
+	0, // 6: search.SearchService.SearchUsers:input_type -> search.SearchRequest
+	0, // 7: search.SearchService.SearchAll:input_type -> search.SearchRequest
+	3, // 8: search.SearchService.SearchPosts:output_type -> search.SearchPostsResponse
+	4, // 9: search.SearchService.SearchUsers:output_type -> search.SearchUsersResponse
+	5, // 10: search.SearchService.SearchAll:output_type -> search.SearchAllResponse
+	8, // [8:11] is the sub-list for method output_type
+	5, // [5:8] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_proto_search_proto_init() }
+func file_proto_search_proto_init() {
+	if File_proto_search_proto != nil {
+		return
+	}
+	file_proto_search_proto_msgTypes[0].OneofWrappers = []any{}
+	file_proto_search_proto_msgTypes[2].OneofWrappers = []any{}
+	file_proto_search_proto_msgTypes[3].OneofWrappers = []any{}
+	file_proto_search_proto_msgTypes[4].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_search_proto_rawDesc), len(file_proto_search_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_search_proto_goTypes,
+		DependencyIndexes: file_proto_search_proto_depIdxs,
+		MessageInfos:      file_proto_search_proto_msgTypes,
+	}.Build()
+	File_proto_search_proto = out.File
+	file_proto_search_proto_goTypes = nil
+	file_proto_search_proto_depIdxs = nil
+}
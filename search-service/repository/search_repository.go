@@ -0,0 +1,173 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+
+	"search-service/db"
+	"search-service/model"
+)
+
+type SearchRepository interface {
+	UpsertPost(ctx context.Context, post models.PostDocument) error
+	DeletePost(ctx context.Context, postID, userID uuid.UUID, deletedAt time.Time) error
+	UpsertUser(ctx context.Context, user models.UserDocument) error
+	IsPrivateUser(ctx context.Context, userID uuid.UUID) (bool, error)
+	DeleteUser(ctx context.Context, userID uuid.UUID) error
+	SearchPosts(ctx context.Context, query string, offset, limit int) ([]models.PostHit, error)
+	SearchUsers(ctx context.Context, query string, offset, limit int) ([]models.UserHit, error)
+}
+
+type searchRepository struct {
+	db *database.DB
+}
+
+func NewSearchRepository(db *database.DB) SearchRepository {
+	return &searchRepository{db: db}
+}
+
+// UpsertPost indexes a post. Events can arrive out of order, so an older
+// version never overwrites a newer one, and a deleted post stays deleted.
+func (r *searchRepository) UpsertPost(ctx context.Context, post models.PostDocument) error {
+	query := `
+		INSERT INTO search_service_posts (id, user_id, content, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (id) DO UPDATE
+		SET content = EXCLUDED.content, updated_at = EXCLUDED.updated_at
+		WHERE search_service_posts.deleted_at IS NULL
+			AND search_service_posts.updated_at <= EXCLUDED.updated_at
+	`
+
+	_, err := r.db.ExecContext(ctx, query, post.ID, post.UserID, post.Content, post.CreatedAt, post.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to index post %s: %w", post.ID, err)
+	}
+	return nil
+}
+
+// DeletePost drops a post's content from the index and leaves a tombstone
+func (r *searchRepository) DeletePost(ctx context.Context, postID, userID uuid.UUID, deletedAt time.Time) error {
+	query := `
+		INSERT INTO search_service_posts (id, user_id, content, created_at, updated_at, deleted_at)
+		VALUES ($1, $2, '', $3, $3, $3)
+		ON CONFLICT (id) DO UPDATE
+		SET content = '', deleted_at = EXCLUDED.deleted_at
+		WHERE search_service_posts.deleted_at IS NULL
+	`
+
+	_, err := r.db.ExecContext(ctx, query, postID, userID, deletedAt)
+	if err != nil {
+		return fmt.Errorf("failed to remove post %s from index: %w", postID, err)
+	}
+	return nil
+}
+
+// UpsertUser indexes a user profile unless a newer version is already indexed
+func (r *searchRepository) UpsertUser(ctx context.Context, user models.UserDocument) error {
+	query := `
+		INSERT INTO search_service_users (id, username, bio, is_private, updated_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (id) DO UPDATE
+		SET username = EXCLUDED.username, bio = EXCLUDED.bio, is_private = EXCLUDED.is_private,
+			updated_at = EXCLUDED.updated_at
+		WHERE search_service_users.updated_at <= EXCLUDED.updated_at
+	`
+
+	_, err := r.db.ExecContext(ctx, query, user.ID, user.Username, user.Bio, user.IsPrivate, user.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to index user %s: %w", user.ID, err)
+	}
+	return nil
+}
+
+// IsPrivateUser reports whether a user's account is private. Users not
+// indexed yet count as public.
+func (r *searchRepository) IsPrivateUser(ctx context.Context, userID uuid.UUID) (bool, error) {
+	var isPrivate bool
+	err := r.db.GetContext(ctx, &isPrivate, `
+		SELECT EXISTS (SELECT 1 FROM search_service_users WHERE id = $1 AND is_private)
+	`, userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to check privacy of user %s: %w", userID, err)
+	}
+	return isPrivate, nil
+}
+
+// DeleteUser removes a deleted user's profile from the index. Their posts are
+// removed as post-service deletes them.
+func (r *searchRepository) DeleteUser(ctx context.Context, userID uuid.UUID) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM search_service_users WHERE id = $1`, userID)
+	if err != nil {
+		return fmt.Errorf("failed to remove user %s from index: %w", userID, err)
+	}
+	return nil
+}
+
+// SearchPosts matches posts against a web-search style query ("quoted
+// phrases", or, -excluded) and returns them by relevance, newest first on ties.
+// Posts indexed before their author went private are left out.
+func (r *searchRepository) SearchPosts(ctx context.Context, query string, offset, limit int) ([]models.PostHit, error) {
+	sqlQuery := `
+		SELECT p.id, p.user_id, p.created_at,
+			ts_headline('english', p.content, q, 'StartSel="", StopSel="", MaxWords=30, MinWords=10') AS snippet,
+			ts_rank(p.document, q) AS rank
+		FROM search_service_posts p, websearch_to_tsquery('english', $1) q
+		WHERE p.deleted_at IS NULL AND p.document @@ q
+			AND NOT EXISTS (SELECT 1 FROM search_service_users u WHERE u.id = p.user_id AND u.is_private)
+		ORDER BY rank DESC, p.created_at DESC, p.id
+		OFFSET $2
+		LIMIT $3
+	`
+
+	var hits []models.PostHit
+	err := r.db.ReadDB().SelectContext(ctx, &hits, sqlQuery, query, offset, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search posts: %w", err)
+	}
+	return hits, nil
+}
+
+// SearchUsers matches every word of the query as a prefix of a word in the
+// username or bio, so partially typed usernames are found
+func (r *searchRepository) SearchUsers(ctx context.Context, query string, offset, limit int) ([]models.UserHit, error) {
+	tsQuery := prefixQuery(query)
+	if tsQuery == "" {
+		return []models.UserHit{}, nil
+	}
+
+	sqlQuery := `
+		SELECT u.id, u.username, u.bio, ts_rank(u.document, q) AS rank
+		FROM search_service_users u, to_tsquery('simple', $1) q
+		WHERE u.document @@ q
+		ORDER BY rank DESC, u.username, u.id
+		OFFSET $2
+		LIMIT $3
+	`
+
+	var hits []models.UserHit
+	err := r.db.ReadDB().SelectContext(ctx, &hits, sqlQuery, tsQuery, offset, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search users: %w", err)
+	}
+	return hits, nil
+}
+
+// prefixQuery turns free text into a tsquery matching all of its words as
+// prefixes. Only letters and digits survive, so the result is always valid
+// tsquery syntax.
+func prefixQuery(query string) string {
+	words := strings.FieldsFunc(strings.ToLower(query), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	terms := make([]string, len(words))
+	for i, word := range words {
+		terms[i] = word + ":*"
+	}
+	return strings.Join(terms, " & ")
+}
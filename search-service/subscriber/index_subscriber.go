@@ -0,0 +1,167 @@
+package subscriber
+
+import (
+	"context"
+	"log"
+
+	"github.com/nats-io/nats.go"
+	"search-service/events"
+	"search-service/model"
+	natsClient "search-service/nats"
+	"search-service/repository"
+	"shared/eventschema"
+	eventspb "shared/eventschema/pb"
+	"shared/logging"
+	"shared/tracing"
+)
+
+// IndexSubscriber keeps the search index in step with post and profile
+// changes. Every replica joins the same queue group, so each event is indexed
+// once.
+type IndexSubscriber struct {
+	natsClient *natsClient.Client
+	repo       repository.SearchRepository
+	ctx        context.Context
+	subs       []*nats.Subscription
+}
+
+func NewIndexSubscriber(
+	natsClient *natsClient.Client,
+	repo repository.SearchRepository,
+	ctx context.Context,
+) *IndexSubscriber {
+	return &IndexSubscriber{
+		natsClient: natsClient,
+		repo:       repo,
+		ctx:        ctx,
+	}
+}
+
+func (s *IndexSubscriber) Start() error {
+	handlers := map[string]func(ctx context.Context, msg *nats.Msg) error{
+		events.PostCreated: s.handlePostCreated,
+		events.PostUpdated: s.handlePostUpdated,
+		events.PostDeleted: s.handlePostDeleted,
+		events.UserUpdated: s.handleUserUpdated,
+		events.UserDeleted: s.handleUserDeleted,
+	}
+
+	for subject, handle := range handlers {
+		sub, err := s.natsClient.QueueSubscribe(subject, "search-indexers", func(msg *nats.Msg) {
+			ctx, span := tracing.StartProcess(s.ctx, msg.Subject, msg.Header)
+			ctx = logging.Extract(ctx, msg.Subject, msg.Header)
+			defer span.End()
+
+			if err := handle(ctx, msg); err != nil {
+				logging.FromContext(ctx).Error().Err(err).Msg("failed to index event")
+			}
+		})
+		if err != nil {
+			return err
+		}
+		s.subs = append(s.subs, sub)
+	}
+
+	log.Println("Index subscriber started successfully")
+	return nil
+}
+
+// handlePostCreated indexes a post. Only public posts of public accounts are
+// searchable.
+func (s *IndexSubscriber) handlePostCreated(ctx context.Context, msg *nats.Msg) error {
+	var event eventspb.PostCreated
+	if _, err := eventschema.Decode(events.PostCreated, msg.Header, msg.Data, &event); err != nil {
+		return err
+	}
+	if searchable, err := s.searchable(ctx, event.Visibility, event.UserId); err != nil || !searchable {
+		return err
+	}
+
+	createdAt := eventschema.Time(event.CreatedAt)
+	return s.repo.UpsertPost(ctx, models.PostDocument{
+		ID:        eventschema.UUID(event.PostId),
+		UserID:    eventschema.UUID(event.UserId),
+		Content:   event.Content,
+		CreatedAt: createdAt,
+		UpdatedAt: createdAt,
+	})
+}
+
+func (s *IndexSubscriber) handlePostUpdated(ctx context.Context, msg *nats.Msg) error {
+	var event eventspb.PostUpdated
+	if _, err := eventschema.Decode(events.PostUpdated, msg.Header, msg.Data, &event); err != nil {
+		return err
+	}
+	if searchable, err := s.searchable(ctx, event.Visibility, event.UserId); err != nil || !searchable {
+		return err
+	}
+
+	// A post updated before it was indexed is stored with its update time as
+	// created_at until the next backfill corrects it
+	updatedAt := eventschema.Time(event.UpdatedAt)
+	return s.repo.UpsertPost(ctx, models.PostDocument{
+		ID:        eventschema.UUID(event.PostId),
+		UserID:    eventschema.UUID(event.UserId),
+		Content:   event.Content,
+		CreatedAt: updatedAt,
+		UpdatedAt: updatedAt,
+	})
+}
+
+func (s *IndexSubscriber) handlePostDeleted(ctx context.Context, msg *nats.Msg) error {
+	var event eventspb.PostDeleted
+	if _, err := eventschema.Decode(events.PostDeleted, msg.Header, msg.Data, &event); err != nil {
+		return err
+	}
+
+	return s.repo.DeletePost(ctx, eventschema.UUID(event.PostId), eventschema.UUID(event.UserId), eventschema.Time(event.DeletedAt))
+}
+
+func (s *IndexSubscriber) handleUserUpdated(ctx context.Context, msg *nats.Msg) error {
+	var event events.UserUpdatedEvent
+	if err := natsClient.DecodeEvent(msg, &event); err != nil {
+		return err
+	}
+
+	return s.repo.UpsertUser(ctx, models.UserDocument{
+		ID:        event.UserID,
+		Username:  event.Username,
+		Bio:       event.Bio,
+		IsPrivate: event.IsPrivate,
+		UpdatedAt: event.UpdatedAt,
+	})
+}
+
+func (s *IndexSubscriber) handleUserDeleted(ctx context.Context, msg *nats.Msg) error {
+	var event events.UserDeletedEvent
+	if err := natsClient.DecodeEvent(msg, &event); err != nil {
+		return err
+	}
+
+	return s.repo.DeleteUser(ctx, event.UserID)
+}
+
+// isPublic reports whether a post's visibility lets anyone find it
+func isPublic(visibility string) bool {
+	return visibility == "" || visibility == "PUBLIC"
+}
+
+// searchable reports whether a post with visibility by userID may be indexed:
+// it must be public and its author's account must not be private
+func (s *IndexSubscriber) searchable(ctx context.Context, visibility, userID string) (bool, error) {
+	if !isPublic(visibility) {
+		return false, nil
+	}
+	isPrivate, err := s.repo.IsPrivateUser(ctx, eventschema.UUID(userID))
+	if err != nil {
+		return false, err
+	}
+	return !isPrivate, nil
+}
+
+func (s *IndexSubscriber) Stop() error {
+	for _, sub := range s.subs {
+		sub.Unsubscribe()
+	}
+	return nil
+}
@@ -0,0 +1,256 @@
+// Package chaos injects faults into gRPC calls and NATS messages so that
+// client timeouts, retries and event consumers can be exercised in staging.
+// It does nothing unless CHAOS_ENABLED=true.
+//
+// CHAOS_RULES is a semicolon-separated list of rules, each a target followed
+// by comma-separated faults:
+//
+//	/post.PostService/CreatePost=delay=200ms-2s@0.5,error=unavailable@0.1
+//	/post.PostService/*=error=deadline_exceeded@0.05
+//	post.created=drop@0.2
+//
+// Targets starting with "/" match gRPC methods and anything else matches NATS
+// subjects; a trailing "*" matches by prefix and "*" alone matches everything.
+// Only the first matching rule applies. Faults:
+//
+//	delay=D or delay=MIN-MAX   sleep before handling the call
+//	error=CODE                 fail the call with the gRPC status code
+//	drop                       discard the NATS message
+//
+// Each fault takes an optional "@p" probability between 0 and 1 (default 1).
+package chaos
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/rand"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type faultKind int
+
+const (
+	faultDelay faultKind = iota
+	faultError
+	faultDrop
+)
+
+type fault struct {
+	kind        faultKind
+	minDelay    time.Duration
+	maxDelay    time.Duration
+	code        codes.Code
+	probability float64
+}
+
+type rule struct {
+	target string
+	prefix bool
+	faults []fault
+}
+
+func (r rule) matches(name string) bool {
+	if r.prefix {
+		return strings.HasPrefix(name, r.target)
+	}
+	return name == r.target
+}
+
+// Injector applies the configured faults. A nil Injector injects nothing, so
+// callers can use it without checking whether chaos is enabled.
+type Injector struct {
+	rules []rule
+}
+
+// FromEnv builds an Injector from CHAOS_ENABLED and CHAOS_RULES. It returns
+// nil when chaos is disabled.
+func FromEnv() (*Injector, error) {
+	if enabled, _ := strconv.ParseBool(os.Getenv("CHAOS_ENABLED")); !enabled {
+		return nil, nil
+	}
+
+	spec := os.Getenv("CHAOS_RULES")
+	injector, err := Parse(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("WARNING: chaos fault injection is enabled with rules %q", spec)
+	return injector, nil
+}
+
+// Parse parses a CHAOS_RULES specification
+func Parse(spec string) (*Injector, error) {
+	injector := &Injector{}
+	for _, raw := range strings.Split(spec, ";") {
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			continue
+		}
+
+		target, faults, ok := strings.Cut(raw, "=")
+		if !ok || strings.TrimSpace(target) == "" {
+			return nil, fmt.Errorf("chaos rule %q must be target=faults", raw)
+		}
+
+		r := rule{target: strings.TrimSpace(target)}
+		if strings.HasSuffix(r.target, "*") {
+			r.target = strings.TrimSuffix(r.target, "*")
+			r.prefix = true
+		}
+
+		for _, f := range strings.Split(faults, ",") {
+			parsed, err := parseFault(strings.TrimSpace(f))
+			if err != nil {
+				return nil, fmt.Errorf("chaos rule %q: %w", raw, err)
+			}
+			r.faults = append(r.faults, parsed)
+		}
+		injector.rules = append(injector.rules, r)
+	}
+	return injector, nil
+}
+
+func parseFault(spec string) (fault, error) {
+	f := fault{probability: 1}
+	if body, p, ok := strings.Cut(spec, "@"); ok {
+		probability, err := strconv.ParseFloat(p, 64)
+		if err != nil || probability < 0 || probability > 1 {
+			return f, fmt.Errorf("invalid probability in %q", spec)
+		}
+		spec, f.probability = body, probability
+	}
+
+	name, value, _ := strings.Cut(spec, "=")
+	switch name {
+	case "delay":
+		f.kind = faultDelay
+		minDelay, maxDelay, isRange := strings.Cut(value, "-")
+		var err error
+		if f.minDelay, err = time.ParseDuration(minDelay); err != nil {
+			return f, fmt.Errorf("invalid delay in %q", spec)
+		}
+		f.maxDelay = f.minDelay
+		if isRange {
+			if f.maxDelay, err = time.ParseDuration(maxDelay); err != nil || f.maxDelay < f.minDelay {
+				return f, fmt.Errorf("invalid delay range in %q", spec)
+			}
+		}
+	case "error":
+		f.kind = faultError
+		code, ok := parseCode(value)
+		if !ok || code == codes.OK {
+			return f, fmt.Errorf("unknown status code in %q", spec)
+		}
+		f.code = code
+	case "drop":
+		f.kind = faultDrop
+	default:
+		return f, fmt.Errorf("unknown fault %q", spec)
+	}
+	return f, nil
+}
+
+// parseCode accepts status code names in any case, with or without
+// underscores, e.g. "UNAVAILABLE" or "deadline_exceeded"
+func parseCode(name string) (codes.Code, bool) {
+	normalized := strings.ToLower(strings.ReplaceAll(name, "_", ""))
+	for c := codes.OK; c <= codes.Unauthenticated; c++ {
+		if strings.ToLower(c.String()) == normalized {
+			return c, true
+		}
+	}
+	return codes.OK, false
+}
+
+func (i *Injector) faultsFor(name string) []fault {
+	if i == nil {
+		return nil
+	}
+	for _, r := range i.rules {
+		if r.matches(name) {
+			return r.faults
+		}
+	}
+	return nil
+}
+
+func fires(f fault) bool {
+	return f.probability >= 1 || rand.Float64() < f.probability
+}
+
+// inject applies the delay and error faults configured for a gRPC method
+func (i *Injector) inject(ctx context.Context, method string) error {
+	for _, f := range i.faultsFor(method) {
+		if !fires(f) {
+			continue
+		}
+
+		switch f.kind {
+		case faultDelay:
+			delay := f.minDelay
+			if f.maxDelay > f.minDelay {
+				delay += time.Duration(rand.Int63n(int64(f.maxDelay - f.minDelay)))
+			}
+			select {
+			case <-ctx.Done():
+				return status.FromContextError(ctx.Err()).Err()
+			case <-time.After(delay):
+			}
+		case faultError:
+			log.Printf("chaos: injecting %s into %s", f.code, method)
+			return status.Error(f.code, "chaos: injected fault")
+		}
+	}
+	return nil
+}
+
+// Unary returns a server interceptor injecting faults into unary RPCs. It
+// should run before authentication so that every call is affected.
+func (i *Injector) Unary() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		if err := i.inject(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// Stream returns a server interceptor injecting faults when a stream opens
+func (i *Injector) Stream() grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		stream grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		if err := i.inject(stream.Context(), info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, stream)
+	}
+}
+
+// Drop reports whether a NATS message on subject should be discarded
+func (i *Injector) Drop(subject string) bool {
+	for _, f := range i.faultsFor(subject) {
+		if f.kind == faultDrop && fires(f) {
+			log.Printf("chaos: dropping message on %s", subject)
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,125 @@
+package cursor
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestMain(m *testing.M) {
+	if err := SetSecret("test-secret"); err != nil {
+		panic(err)
+	}
+	m.Run()
+}
+
+func TestKeysetRoundTrip(t *testing.T) {
+	at := time.Date(2025, 3, 1, 12, 30, 0, 123456789, time.UTC)
+	id := uuid.New()
+
+	gotTime, gotID, err := DecodeKeyset(EncodeKeyset(at, id))
+	if err != nil {
+		t.Fatalf("DecodeKeyset() error = %v", err)
+	}
+	if !gotTime.Equal(at) || gotID != id {
+		t.Errorf("DecodeKeyset() = %v, %v; want %v, %v", gotTime, gotID, at, id)
+	}
+}
+
+func TestOffsetRoundTrip(t *testing.T) {
+	got, err := DecodeOffset(EncodeOffset(40))
+	if err != nil || got != 40 {
+		t.Errorf("DecodeOffset() = %d, %v; want 40, nil", got, err)
+	}
+}
+
+func TestRankedRoundTrip(t *testing.T) {
+	at := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	id := uuid.New()
+
+	score, gotID, gotTime, err := DecodeRanked(EncodeRanked(0.75, id, at))
+	if err != nil {
+		t.Fatalf("DecodeRanked() error = %v", err)
+	}
+	if score != 0.75 || gotID != id || !gotTime.Equal(at) {
+		t.Errorf("DecodeRanked() = %v, %v, %v; want 0.75, %v, %v", score, gotID, gotTime, id, at)
+	}
+}
+
+func TestDecodeKeysetRejects(t *testing.T) {
+	valid := EncodeKeyset(time.Now(), uuid.New())
+	body, sig, _ := strings.Cut(valid, ".")
+
+	tests := []struct {
+		name   string
+		cursor string
+	}{
+		{name: "empty", cursor: ""},
+		{name: "no signature", cursor: body},
+		{name: "signature not base64", cursor: body + ".!!!"},
+		{name: "tampered body", cursor: tamper(body) + "." + sig},
+		{name: "tampered signature", cursor: body + "." + tamper(sig)},
+		{name: "forged position", cursor: signed(payload{Version: version, Kind: KindKeyset, Time: time.Now(), ID: uuid.New()}, "other-secret")},
+		{name: "other kind", cursor: EncodeOffset(10)},
+		{name: "other version", cursor: signed(payload{Version: version + 1, Kind: KindKeyset, Time: time.Now(), ID: uuid.New()}, "test-secret")},
+		{name: "signed garbage", cursor: signedBody("not json")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := DecodeKeyset(tt.cursor); !errors.Is(err, ErrInvalid) {
+				t.Errorf("DecodeKeyset() error = %v; want ErrInvalid", err)
+			}
+		})
+	}
+}
+
+func TestDecodeOffsetRejectsNegative(t *testing.T) {
+	if _, err := DecodeOffset(EncodeOffset(-1)); !errors.Is(err, ErrInvalid) {
+		t.Errorf("DecodeOffset() error = %v; want ErrInvalid", err)
+	}
+}
+
+func TestSetSecretRejectsEmpty(t *testing.T) {
+	if err := SetSecret(""); err == nil {
+		t.Error("SetSecret(\"\") error = nil; want an error")
+	}
+}
+
+// tamper flips the first character of s
+func tamper(s string) string {
+	if s[0] == 'A' {
+		return "B" + s[1:]
+	}
+	return "A" + s[1:]
+}
+
+// signed encodes p as encode does, but signs it with key and keeps its
+// version
+func signed(p payload, key string) string {
+	data, _ := json.Marshal(p)
+	body := base64.RawURLEncoding.EncodeToString(data)
+
+	mu.Lock()
+	saved := secret
+	secret = []byte(key)
+	mu.Unlock()
+	defer func() {
+		mu.Lock()
+		secret = saved
+		mu.Unlock()
+	}()
+
+	return body + "." + base64.RawURLEncoding.EncodeToString(sign(body))
+}
+
+// signedBody returns a correctly signed cursor whose body is raw
+func signedBody(raw string) string {
+	body := base64.RawURLEncoding.EncodeToString([]byte(raw))
+	return body + "." + base64.RawURLEncoding.EncodeToString(sign(body))
+}
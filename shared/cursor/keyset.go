@@ -0,0 +1,44 @@
+package cursor
+
+import "fmt"
+
+// Keyset pages a list ordered by a time column and then an ID column, newest
+// first unless Ascending. Its cursors are those of EncodeKeyset.
+type Keyset struct {
+	TimeColumn string
+	IDColumn   string
+	Ascending  bool
+}
+
+// Page appends to query, which must end in a WHERE clause, the condition
+// selecting the rows after the cursor after, when there is one, then the
+// order and a limit of first+1 rows. The extra row tells Trim whether another
+// page follows. args are the arguments query already uses.
+func (k Keyset) Page(query string, args []interface{}, after *string, first int) (string, []interface{}, error) {
+	direction, cmp := "DESC", "<"
+	if k.Ascending {
+		direction, cmp = "ASC", ">"
+	}
+
+	if after != nil && *after != "" {
+		afterTime, afterID, err := DecodeKeyset(*after)
+		if err != nil {
+			return "", nil, err
+		}
+		args = append(args, afterTime, afterID)
+		query += fmt.Sprintf(" AND (%s, %s) %s ($%d, $%d)", k.TimeColumn, k.IDColumn, cmp, len(args)-1, len(args))
+	}
+
+	args = append(args, first+1)
+	query += fmt.Sprintf(" ORDER BY %s %s, %s %s LIMIT $%d", k.TimeColumn, direction, k.IDColumn, direction, len(args))
+	return query, args, nil
+}
+
+// Trim drops the extra row a page was read with and reports whether another
+// page follows
+func Trim[T any](rows []T, first int) ([]T, bool) {
+	if len(rows) > first {
+		return rows[:first], true
+	}
+	return rows, false
+}
@@ -0,0 +1,214 @@
+// Package dedupe keeps JetStream consumers from handling an event twice.
+// JetStream delivers at least once: an event whose acknowledgement is lost,
+// or whose handler outlives AckWait, is delivered again, and an event
+// published twice is stored twice. Each service records the events its
+// consumers handled in a processed-events table, keyed by consumer and event
+// ID, and skips those it finds there.
+//
+// An event is claimed before it is handled and marked processed once its
+// handler succeeds. A failing handler releases the claim, so the event is
+// handled again on its next delivery. A claim left by a worker that died is
+// taken over once it is older than the lease, which matches the AckWait of
+// the consumers. Only an event handled successfully whose completion could
+// not be recorded is handled twice, hence "exactly-once-ish".
+//
+// The table each service migrates:
+//
+//	CREATE TABLE <service>_processed_events (
+//	    consumer VARCHAR(255) NOT NULL,
+//	    event_id VARCHAR(255) NOT NULL,
+//	    claimed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+//	    processed_at TIMESTAMP WITH TIME ZONE,
+//	    PRIMARY KEY (consumer, event_id)
+//	);
+package dedupe
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"shared/eventschema"
+)
+
+const (
+	// Lease is how long a claim holds off other deliveries of its event. It
+	// matches the AckWait of the services' durable consumers, after which
+	// the event is delivered again anyway.
+	Lease = 30 * time.Second
+
+	// Retention is how long handled events are remembered. It outlasts the
+	// EVENTS stream's default retention of 7 days, so no copy of a forgotten
+	// event can be delivered again.
+	Retention = 8 * 24 * time.Hour
+
+	// pruneInterval is how often events older than Retention are forgotten
+	pruneInterval = time.Hour
+)
+
+// msgIDHeader is the JetStream deduplication ID
+const msgIDHeader = "Nats-Msg-Id"
+
+// ErrInProgress is returned for an event another delivery is handling. Ask
+// for it to be delivered again later, when it will be found processed or
+// its claim will have lapsed.
+var ErrInProgress = errors.New("event is being handled by another delivery")
+
+// DB is the part of *sql.DB and *sqlx.DB the store uses
+type DB interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// Store records the events a service's consumers handled
+type Store struct {
+	db    DB
+	table string
+}
+
+// New returns a store over table, e.g. "feed_service_processed_events"
+func New(db DB, table string) *Store {
+	return &Store{db: db, table: table}
+}
+
+// EventID identifies an event by its envelope ID or, for events without
+// one, the message ID it was published with. It is empty for events
+// published with neither, which callers identify by MessageID instead.
+func EventID(header map[string][]string) string {
+	for _, key := range []string{eventschema.HeaderID, msgIDHeader} {
+		if values := header[key]; len(values) > 0 && values[0] != "" {
+			return values[0]
+		}
+	}
+	return ""
+}
+
+// MessageID identifies a message by the stream holding it, its sequence in
+// the stream and when it was stored, which all its redeliveries share. The
+// time tells apart messages of a stream that was deleted and created again.
+func MessageID(stream string, sequence uint64, stored time.Time) string {
+	return fmt.Sprintf("%s:%d:%d", stream, sequence, stored.UnixNano())
+}
+
+// Process runs handle for an event unless consumer handled it before, in
+// which case it returns nil without running it. It returns ErrInProgress
+// while another delivery of the event is being handled, and the error of
+// handle otherwise. An event without an ID is always handled.
+func (s *Store) Process(ctx context.Context, consumer, eventID string, handle func(ctx context.Context) error) error {
+	if eventID == "" {
+		return handle(ctx)
+	}
+
+	claimed, err := s.claim(ctx, consumer, eventID)
+	if err != nil {
+		return err
+	}
+	if !claimed {
+		processed, err := s.processed(ctx, consumer, eventID)
+		if err != nil {
+			return err
+		}
+		if processed {
+			return nil
+		}
+		return ErrInProgress
+	}
+
+	if err := handle(ctx); err != nil {
+		if releaseErr := s.release(context.WithoutCancel(ctx), consumer, eventID); releaseErr != nil {
+			log.Printf("Failed to release event %s of %s: %v", eventID, consumer, releaseErr)
+		}
+		return err
+	}
+
+	// The event was handled; failing to record it only risks handling it
+	// again, which is no reason to have it redelivered now
+	if err := s.complete(context.WithoutCancel(ctx), consumer, eventID); err != nil {
+		log.Printf("Failed to record event %s of %s as processed: %v", eventID, consumer, err)
+	}
+	return nil
+}
+
+// claim takes the event for this delivery. An event is claimed when it was
+// not seen before, or when its last claim lapsed without being completed.
+func (s *Store) claim(ctx context.Context, consumer, eventID string) (bool, error) {
+	result, err := s.db.ExecContext(ctx, fmt.Sprintf(`
+		INSERT INTO %[1]s (consumer, event_id, claimed_at)
+		VALUES ($1, $2, NOW())
+		ON CONFLICT (consumer, event_id) DO UPDATE SET claimed_at = NOW()
+		WHERE %[1]s.processed_at IS NULL AND %[1]s.claimed_at < NOW() - $3 * INTERVAL '1 millisecond'
+	`, s.table), consumer, eventID, Lease.Milliseconds())
+	if err != nil {
+		return false, fmt.Errorf("failed to claim event: %w", err)
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to claim event: %w", err)
+	}
+	return n > 0, nil
+}
+
+func (s *Store) processed(ctx context.Context, consumer, eventID string) (bool, error) {
+	var processed bool
+	err := s.db.QueryRowContext(ctx, fmt.Sprintf(`
+		SELECT processed_at IS NOT NULL FROM %s WHERE consumer = $1 AND event_id = $2
+	`, s.table), consumer, eventID).Scan(&processed)
+	if errors.Is(err, sql.ErrNoRows) {
+		// Released between the claim and now; the next delivery claims it
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to look up event: %w", err)
+	}
+	return processed, nil
+}
+
+func (s *Store) complete(ctx context.Context, consumer, eventID string) error {
+	_, err := s.db.ExecContext(ctx, fmt.Sprintf(`
+		UPDATE %s SET processed_at = NOW() WHERE consumer = $1 AND event_id = $2
+	`, s.table), consumer, eventID)
+	return err
+}
+
+func (s *Store) release(ctx context.Context, consumer, eventID string) error {
+	_, err := s.db.ExecContext(ctx, fmt.Sprintf(`
+		DELETE FROM %s WHERE consumer = $1 AND event_id = $2 AND processed_at IS NULL
+	`, s.table), consumer, eventID)
+	return err
+}
+
+// Prune forgets the events claimed before claimedBefore, handled or not
+func (s *Store) Prune(ctx context.Context, claimedBefore time.Time) (int64, error) {
+	result, err := s.db.ExecContext(ctx, fmt.Sprintf(`DELETE FROM %s WHERE claimed_at < $1`, s.table), claimedBefore)
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune processed events: %w", err)
+	}
+	return result.RowsAffected()
+}
+
+// RunPruning forgets events older than Retention every hour until ctx is
+// done
+func (s *Store) RunPruning(ctx context.Context) {
+	ticker := time.NewTicker(pruneInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		n, err := s.Prune(ctx, time.Now().Add(-Retention))
+		if err != nil {
+			log.Printf("Failed to prune processed events: %v", err)
+			continue
+		}
+		if n > 0 {
+			log.Printf("Pruned %d processed events", n)
+		}
+	}
+}
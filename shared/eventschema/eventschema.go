@@ -0,0 +1,220 @@
+// Package eventschema defines the events services publish to one another as
+// versioned protobuf messages, generated into package eventspb from
+// proto/events.proto.
+//
+// An event is sent as its payload in JSON, with the proto field names, and an
+// envelope (ID, type, version and when it occurred) in x-event-* headers. The
+// body is what the ad-hoc JSON structs these schemas replace used to send, so
+// consumers not yet migrated keep reading it, and events published before the
+// envelope existed decode as version 1.
+//
+// A schema only ever gains fields. A field that was added bumps the version
+// of its event, and consumers that need it check the version they received.
+package eventschema
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	eventspb "shared/eventschema/pb"
+)
+
+// Event types, which are also the subjects the events are published on
+const (
+	PostCreated  = "post.created"
+	PostUpdated  = "post.updated"
+	PostDeleted  = "post.deleted"
+	CommentAdded = "post.comment.added"
+)
+
+// Headers carrying the envelope
+const (
+	HeaderID         = "x-event-id"
+	HeaderType       = "x-event-type"
+	HeaderVersion    = "x-event-version"
+	HeaderOccurredAt = "x-event-occurred-at"
+)
+
+// legacyVersion is the version of events published without an envelope
+const legacyVersion = 1
+
+// msgIDHeader is the JetStream deduplication ID, which outbox relays set to
+// the event ID
+const msgIDHeader = "Nats-Msg-Id"
+
+var (
+	// ErrUnknownType is returned for an event type without a schema
+	ErrUnknownType = errors.New("unknown event type")
+	// ErrInvalid is returned for an event that does not match its schema
+	ErrInvalid = errors.New("invalid event")
+)
+
+type schema struct {
+	version int32
+	message proto.Message
+}
+
+// schemas holds the current version of each event type
+var schemas = map[string]schema{
+	PostCreated: {version: 1, message: &eventspb.PostCreated{}},
+	PostUpdated: {version: 1, message: &eventspb.PostUpdated{}},
+	PostDeleted: {version: 1, message: &eventspb.PostDeleted{}},
+	// Version 2 added the commenter's user_id
+	CommentAdded: {version: 2, message: &eventspb.CommentAdded{}},
+}
+
+var (
+	marshalOptions   = protojson.MarshalOptions{UseProtoNames: true}
+	unmarshalOptions = protojson.UnmarshalOptions{DiscardUnknown: true}
+)
+
+// Version returns the version events of eventType are published at
+func Version(eventType string) (int32, bool) {
+	s, ok := schemas[eventType]
+	return s.version, ok
+}
+
+// Encode stamps payload with a new envelope for eventType and returns the
+// envelope with the body to publish. Pass the envelope to SetHeaders for the
+// headers of the message.
+func Encode(eventType string, payload proto.Message) (*eventspb.Envelope, []byte, error) {
+	if err := checkType(eventType, payload); err != nil {
+		return nil, nil, err
+	}
+
+	data, err := marshalOptions.Marshal(payload)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode %s: %w", eventType, err)
+	}
+
+	envelope := &eventspb.Envelope{
+		Id:         uuid.NewString(),
+		Type:       eventType,
+		Version:    schemas[eventType].version,
+		OccurredAt: timestamppb.Now(),
+	}
+	return envelope, data, nil
+}
+
+// SetHeaders writes envelope into the headers of a message
+func SetHeaders(header map[string][]string, envelope *eventspb.Envelope) {
+	header[HeaderID] = []string{envelope.Id}
+	header[HeaderType] = []string{envelope.Type}
+	header[HeaderVersion] = []string{strconv.Itoa(int(envelope.Version))}
+	header[HeaderOccurredAt] = []string{envelope.OccurredAt.AsTime().Format(time.RFC3339Nano)}
+}
+
+// Decode reads an event of eventType into payload and returns its envelope.
+// An event without one is version 1, identified by its JetStream message ID
+// if it has one. Fields the schema does not know, e.g. from a newer version,
+// are ignored; IDs that are set must be UUIDs.
+func Decode(eventType string, header map[string][]string, data []byte, payload proto.Message) (*eventspb.Envelope, error) {
+	if err := checkType(eventType, payload); err != nil {
+		return nil, err
+	}
+
+	envelope, err := readHeaders(eventType, header)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := unmarshalOptions.Unmarshal(data, payload); err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", ErrInvalid, eventType, err)
+	}
+	if err := checkIDs(payload.ProtoReflect()); err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", ErrInvalid, eventType, err)
+	}
+	return envelope, nil
+}
+
+// UUID parses an ID of a decoded event. Decode has checked that IDs which
+// are set are UUIDs, so an unset one is the only one that yields uuid.Nil.
+func UUID(id string) uuid.UUID {
+	parsed, err := uuid.Parse(id)
+	if err != nil {
+		return uuid.Nil
+	}
+	return parsed
+}
+
+// Time returns the time of a decoded event, or the zero time if it is unset
+func Time(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
+
+func checkType(eventType string, payload proto.Message) error {
+	s, ok := schemas[eventType]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownType, eventType)
+	}
+	if payload.ProtoReflect().Descriptor() != s.message.ProtoReflect().Descriptor() {
+		return fmt.Errorf("%s carries %s, not %s", eventType,
+			s.message.ProtoReflect().Descriptor().FullName(), payload.ProtoReflect().Descriptor().FullName())
+	}
+	return nil
+}
+
+func readHeaders(eventType string, header map[string][]string) (*eventspb.Envelope, error) {
+	get := func(key string) string {
+		if values := header[key]; len(values) > 0 {
+			return values[0]
+		}
+		return ""
+	}
+
+	envelope := &eventspb.Envelope{
+		Id:      get(HeaderID),
+		Type:    eventType,
+		Version: legacyVersion,
+	}
+	if envelope.Id == "" {
+		envelope.Id = get(msgIDHeader)
+	}
+	if t := get(HeaderType); t != "" && t != eventType {
+		return nil, fmt.Errorf("%w: %s event received as %s", ErrInvalid, t, eventType)
+	}
+	if v := get(HeaderVersion); v != "" {
+		version, err := strconv.ParseInt(v, 10, 32)
+		if err != nil || version < 1 {
+			return nil, fmt.Errorf("%w: %s: version %q", ErrInvalid, eventType, v)
+		}
+		envelope.Version = int32(version)
+	}
+	if at := get(HeaderOccurredAt); at != "" {
+		occurredAt, err := time.Parse(time.RFC3339Nano, at)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: occurred at %q", ErrInvalid, eventType, at)
+		}
+		envelope.OccurredAt = timestamppb.New(occurredAt)
+	}
+	return envelope, nil
+}
+
+// checkIDs checks that the *_id fields of an event which are set are UUIDs
+func checkIDs(m protoreflect.Message) error {
+	var err error
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		name := string(fd.Name())
+		if fd.Kind() != protoreflect.StringKind || (name != "id" && !strings.HasSuffix(name, "_id")) {
+			return true
+		}
+		if _, parseErr := uuid.Parse(v.String()); parseErr != nil {
+			err = fmt.Errorf("%s %q is not a UUID", name, v.String())
+			return false
+		}
+		return true
+	})
+	return err
+}
@@ -0,0 +1,289 @@
+// Package idempotency lets clients retry write calls without repeating them.
+// A call to a guarded method that carries an idempotency-key header runs
+// once per caller and key; a retry with the same key gets the first call's
+// response back. Keys are kept in Postgres for TTL after the call that used
+// them, in a table of each service's own:
+//
+//	CREATE TABLE <service>_idempotency_keys (
+//	    scope VARCHAR(64) NOT NULL,
+//	    method VARCHAR(255) NOT NULL,
+//	    key VARCHAR(255) NOT NULL,
+//	    request_hash BYTEA NOT NULL,
+//	    response BYTEA,
+//	    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+//	    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+//	    PRIMARY KEY (scope, method, key)
+//	);
+//
+// Only successful calls are remembered. A call that fails gives up its key,
+// so the client can retry it. A retry that arrives while the first call is
+// still running is refused with Aborted, and a key reused for a different
+// request with InvalidArgument.
+package idempotency
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"shared/logging"
+)
+
+const (
+	// Header is the metadata key clients send their idempotency key in
+	Header = "idempotency-key"
+	// TTL is how long a key is remembered after its call
+	TTL = 24 * time.Hour
+
+	maxKeyLength = 255
+	// claimTimeout is how long a call may hold a key before a retry may take
+	// it over, so a key is not stuck when a replica dies mid-call
+	claimTimeout = time.Minute
+	// pruneInterval is how often expired keys are deleted
+	pruneInterval = time.Hour
+)
+
+// Reasons of the ErrorInfo of refused retries
+const (
+	ReasonKeyInUse  = "IDEMPOTENCY_KEY_IN_USE"
+	ReasonKeyReused = "IDEMPOTENCY_KEY_REUSED"
+)
+
+// DB is the part of *sql.DB and *sqlx.DB the store uses
+type DB interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// Store remembers idempotency keys and the responses of their calls
+type Store struct {
+	db     DB
+	table  string
+	domain string
+	secret []byte
+}
+
+// NewStore returns a store keeping keys in table, e.g.
+// "post_service_idempotency_keys". Refused calls get errors whose ErrorInfo
+// is in domain, the service's own. Requests are fingerprinted with an HMAC
+// keyed by secret, so the fingerprint of a request holding a password cannot
+// be used to guess it. The secret is required.
+func NewStore(db DB, table, domain string, secret []byte) (*Store, error) {
+	if len(secret) == 0 {
+		return nil, errors.New("secret is required")
+	}
+	return &Store{db: db, table: table, domain: domain, secret: secret}, nil
+}
+
+// UnaryServerInterceptor runs calls to methods at most once per idempotency
+// key. scope returns who a call is made for, so that callers cannot see
+// each other's responses; it may return "" for calls made for nobody, such
+// as a registration.
+func (s *Store) UnaryServerInterceptor(scope func(ctx context.Context) string, methods ...string) grpc.UnaryServerInterceptor {
+	guarded := make(map[string]bool, len(methods))
+	for _, method := range methods {
+		guarded[method] = true
+	}
+
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		key := keyOf(ctx)
+		if key == "" || !guarded[info.FullMethod] {
+			return handler(ctx, req)
+		}
+		if len(key) > maxKeyLength {
+			return nil, s.invalidKey(fmt.Sprintf("%s must be at most %d characters", Header, maxKeyLength))
+		}
+
+		msg, ok := req.(proto.Message)
+		if !ok {
+			return handler(ctx, req)
+		}
+		hash, err := s.requestHash(msg)
+		if err != nil {
+			return nil, err
+		}
+
+		id := callID{scope: scope(ctx), method: info.FullMethod, key: key}
+		if resp, err := s.claim(ctx, id, hash); resp != nil || err != nil {
+			return resp, err
+		}
+
+		resp, err := handler(ctx, req)
+		if err != nil {
+			s.release(ctx, id)
+			return nil, err
+		}
+		s.complete(ctx, id, resp)
+		return resp, nil
+	}
+}
+
+// Run deletes expired keys every hour until ctx is cancelled
+func (s *Store) Run(ctx context.Context) {
+	ticker := time.NewTicker(pruneInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		result, err := s.db.ExecContext(ctx, `DELETE FROM `+s.table+` WHERE expires_at < NOW()`)
+		if err != nil {
+			log.Printf("Failed to prune idempotency keys: %v", err)
+			continue
+		}
+		if n, _ := result.RowsAffected(); n > 0 {
+			log.Printf("Pruned %d expired idempotency keys", n)
+		}
+	}
+}
+
+type callID struct {
+	scope  string
+	method string
+	key    string
+}
+
+// claim takes the key for a call. It returns the response of the call that
+// already used the key, or an error if that call is still running or was a
+// different request, and nothing if the key is now this call's.
+func (s *Store) claim(ctx context.Context, id callID, hash []byte) (any, error) {
+	query := fmt.Sprintf(`
+		INSERT INTO %[1]s (scope, method, key, request_hash, expires_at)
+		VALUES ($1, $2, $3, $4, NOW() + $5 * INTERVAL '1 second')
+		ON CONFLICT (scope, method, key) DO UPDATE
+		SET request_hash = EXCLUDED.request_hash, response = NULL, created_at = NOW(), expires_at = EXCLUDED.expires_at
+		WHERE %[1]s.expires_at < NOW()
+		   OR (%[1]s.response IS NULL AND %[1]s.created_at < NOW() - $6 * INTERVAL '1 second')
+		RETURNING key
+	`, s.table)
+	var claimed string
+	err := s.db.QueryRowContext(ctx, query, id.scope, id.method, id.key, hash, TTL.Seconds(), claimTimeout.Seconds()).Scan(&claimed)
+	if err == nil {
+		return nil, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("failed to claim idempotency key: %w", err)
+	}
+
+	var requestHash, response []byte
+	err = s.db.QueryRowContext(ctx, `
+		SELECT request_hash, response FROM `+s.table+` WHERE scope = $1 AND method = $2 AND key = $3
+	`, id.scope, id.method, id.key).Scan(&requestHash, &response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read idempotency key: %w", err)
+	}
+
+	if !hmac.Equal(requestHash, hash) {
+		return nil, s.refuse(codes.InvalidArgument, ReasonKeyReused, "idempotency key was already used for a different request")
+	}
+	if response == nil {
+		return nil, s.refuse(codes.Aborted, ReasonKeyInUse, "a request with this idempotency key is still in progress")
+	}
+
+	var stored anypb.Any
+	if err := proto.Unmarshal(response, &stored); err != nil {
+		return nil, fmt.Errorf("failed to decode stored response: %w", err)
+	}
+	resp, err := stored.UnmarshalNew()
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode stored response: %w", err)
+	}
+	logging.FromContext(ctx).Info().Str("method", id.method).Msg("replayed idempotent call")
+	return resp, nil
+}
+
+// complete stores the response of the call holding the key, even if the
+// caller has gone away, since that is when it retries. If the response
+// cannot be stored the key is released, so a retry runs the call again
+// rather than waiting for a response that will never come.
+func (s *Store) complete(ctx context.Context, id callID, resp any) {
+	err := func() error {
+		msg, ok := resp.(proto.Message)
+		if !ok {
+			return fmt.Errorf("response is not a protobuf message")
+		}
+		stored, err := anypb.New(msg)
+		if err != nil {
+			return err
+		}
+		data, err := proto.Marshal(stored)
+		if err != nil {
+			return err
+		}
+		_, err = s.db.ExecContext(context.WithoutCancel(ctx), `
+			UPDATE `+s.table+` SET response = $4 WHERE scope = $1 AND method = $2 AND key = $3
+		`, id.scope, id.method, id.key, data)
+		return err
+	}()
+	if err != nil {
+		logging.FromContext(ctx).Error().Err(err).Msg("failed to store idempotent response")
+		s.release(ctx, id)
+	}
+}
+
+// release gives up the key of a call that failed
+func (s *Store) release(ctx context.Context, id callID) {
+	_, err := s.db.ExecContext(context.WithoutCancel(ctx), `
+		DELETE FROM `+s.table+` WHERE scope = $1 AND method = $2 AND key = $3 AND response IS NULL
+	`, id.scope, id.method, id.key)
+	if err != nil {
+		logging.FromContext(ctx).Error().Err(err).Msg("failed to release idempotency key")
+	}
+}
+
+func keyOf(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get(Header); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// requestHash fingerprints a request, so a key reused for another request
+// is caught
+func (s *Store) requestHash(req proto.Message) ([]byte, error) {
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash request: %w", err)
+	}
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write(data)
+	return mac.Sum(nil), nil
+}
+
+// refuse returns an error with code and msg whose ErrorInfo has reason, as the
+// service's rpcerror.New does
+func (s *Store) refuse(code codes.Code, reason, msg string, details ...protoadapt.MessageV1) error {
+	details = append([]protoadapt.MessageV1{&errdetails.ErrorInfo{Reason: reason, Domain: s.domain}}, details...)
+	st, err := status.New(code, msg).WithDetails(details...)
+	if err != nil {
+		return status.Error(code, msg)
+	}
+	return st.Err()
+}
+
+// invalidKey returns an InvalidArgument error for the idempotency-key header,
+// as the service's rpcerror.InvalidField does
+func (s *Store) invalidKey(description string) error {
+	return s.refuse(codes.InvalidArgument, "INVALID_ARGUMENT", description, &errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{{Field: Header, Description: description}},
+	})
+}
@@ -0,0 +1,328 @@
+// Package lifecycle runs a service's gRPC server together with the
+// dependencies it was started with: databases, Redis and NATS connections,
+// background workers and tracing.
+//
+// Each dependency is checked every HEALTH_CHECK_INTERVAL (default 10s), with
+// HEALTH_CHECK_TIMEOUT (default 2s) per check, and the outcome is served over
+// the standard grpc.health.v1.Health service. A failing dependency the
+// service cannot work without turns the service NOT_SERVING; a failing
+// optional one, such as a cache, only marks it degraded. Every dependency's
+// own status is published as "<service>/<dependency>".
+//
+// On SIGINT or SIGTERM the service reports NOT_SERVING, cancels its
+// background work and stops accepting RPCs. It then waits for the RPCs in
+// flight, and after them for the dependencies to drain, e.g. for NATS
+// messages being handled and publishes still buffered; RPCs and drains share
+// SHUTDOWN_TIMEOUT (default 30s), after which whatever is left is cut off.
+// Dependencies are drained in the order they were added. Shutdown hooks and
+// dependency closers then run in the reverse order they were added, each bounded by SHUTDOWN_STEP_TIMEOUT (default 5s), so a hung
+// connection cannot stall the exit.
+package lifecycle
+
+import (
+	"context"
+	"log"
+	"net"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// HealthMethods are the RPCs of the health service. They are public, so
+// probes and the gateway can call them without a token.
+var HealthMethods = []string{
+	healthpb.Health_Check_FullMethodName,
+	healthpb.Health_List_FullMethodName,
+	healthpb.Health_Watch_FullMethodName,
+}
+
+// Check reports whether a dependency is usable
+type Check func(ctx context.Context) error
+
+// Dependency is a connection the service holds for its whole life
+type Dependency struct {
+	Name string
+	// Check reports whether the dependency is usable; nil skips checking it
+	Check Check
+	// Drain lets the dependency finish its work once RPCs have stopped, and
+	// gives up when ctx is done; nil skips draining it
+	Drain func(ctx context.Context) error
+	// Close releases the dependency on shutdown; nil leaves it open
+	Close func() error
+	// Optional dependencies only degrade the service when they fail, which
+	// keeps it SERVING
+	Optional bool
+}
+
+// step is a shutdown hook or a dependency closer
+type step struct {
+	name string
+	run  func(ctx context.Context) error
+}
+
+// Supervisor owns the dependencies, health status and shutdown of one
+// service
+type Supervisor struct {
+	service      string
+	health       *health.Server
+	interval     time.Duration
+	timeout      time.Duration
+	drainTimeout time.Duration
+	stepTimeout  time.Duration
+
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu      sync.Mutex
+	deps    []Dependency
+	drains  []step
+	steps   []step
+	failing map[string]bool
+}
+
+// New creates a supervisor for the fully qualified gRPC service name, e.g.
+// "like.LikeService". It reports NOT_SERVING until the first checks pass.
+func New(service string) *Supervisor {
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &Supervisor{
+		service:      service,
+		health:       health.NewServer(),
+		interval:     durationEnv("HEALTH_CHECK_INTERVAL", 10*time.Second),
+		timeout:      durationEnv("HEALTH_CHECK_TIMEOUT", 2*time.Second),
+		drainTimeout: durationEnv("SHUTDOWN_TIMEOUT", 30*time.Second),
+		stepTimeout:  durationEnv("SHUTDOWN_STEP_TIMEOUT", 5*time.Second),
+		ctx:          ctx,
+		cancel:       cancel,
+		failing:      make(map[string]bool),
+	}
+	s.setStatus(healthpb.HealthCheckResponse_NOT_SERVING)
+	return s
+}
+
+// Context is cancelled as soon as shutdown begins. Background workers and
+// subscribers run under it, so they stop before the connections they use are
+// closed.
+func (s *Supervisor) Context() context.Context {
+	return s.ctx
+}
+
+// Add registers a dependency. Once the server has stopped, dependencies are
+// drained in the order they were added, so a source such as NATS stops before
+// the work it feeds is waited for, and then closed in the reverse order.
+func (s *Supervisor) Add(dep Dependency) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if dep.Check != nil {
+		s.deps = append(s.deps, dep)
+		s.health.SetServingStatus(s.dependencyService(dep.Name), healthpb.HealthCheckResponse_NOT_SERVING)
+	}
+	if dep.Drain != nil {
+		s.drains = append(s.drains, step{name: dep.Name, run: dep.Drain})
+	}
+	if dep.Close != nil {
+		closeDep := dep.Close
+		s.steps = append(s.steps, step{name: dep.Name, run: func(context.Context) error {
+			return closeDep()
+		}})
+	}
+}
+
+// OnShutdown registers a hook run on shutdown, e.g. flushing traces. Hooks
+// and closers run in the reverse order they were registered.
+func (s *Supervisor) OnShutdown(name string, hook func(ctx context.Context) error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.steps = append(s.steps, step{name: name, run: hook})
+}
+
+// Register serves the health service on server
+func (s *Supervisor) Register(server *grpc.Server) {
+	healthpb.RegisterHealthServer(server, s.health)
+}
+
+// Serve checks the dependencies, serves server on lis until the process is
+// signalled to stop or serving fails, and then shuts everything down. It
+// returns the error serving failed with, or nil after a signalled shutdown.
+func (s *Supervisor) Serve(server *grpc.Server, lis net.Listener) error {
+	s.start()
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signals)
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- server.Serve(lis)
+	}()
+
+	var err error
+	select {
+	case sig := <-signals:
+		log.Printf("Received %s, shutting down %s", sig, s.service)
+	case err = <-serveErr:
+		log.Printf("Serving %s failed, shutting down: %v", s.service, err)
+	}
+
+	s.shutdown(server)
+	return err
+}
+
+// start runs the checks once and then every interval until shutdown
+func (s *Supervisor) start() {
+	s.runChecks()
+
+	go func() {
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				s.runChecks()
+			case <-s.ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// runChecks checks every dependency concurrently and publishes the outcome
+func (s *Supervisor) runChecks() {
+	s.mu.Lock()
+	deps := s.deps
+	s.mu.Unlock()
+
+	errs := make([]error, len(deps))
+	var wg sync.WaitGroup
+	for i, dep := range deps {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(s.ctx, s.timeout)
+			defer cancel()
+			errs[i] = dep.Check(ctx)
+		}()
+	}
+	wg.Wait()
+
+	// Checks cut short by shutdown say nothing about the dependencies
+	if s.ctx.Err() != nil {
+		return
+	}
+
+	serving := true
+	for i, dep := range deps {
+		failing := errs[i] != nil
+		s.mu.Lock()
+		wasFailing := s.failing[dep.Name]
+		s.failing[dep.Name] = failing
+		s.mu.Unlock()
+
+		switch {
+		case failing && !wasFailing && dep.Optional:
+			log.Printf("Health check %s failed, %s is degraded: %v", dep.Name, s.service, errs[i])
+		case failing && !wasFailing:
+			log.Printf("Health check %s failed: %v", dep.Name, errs[i])
+		case !failing && wasFailing:
+			log.Printf("Health check %s passing again", dep.Name)
+		}
+
+		status := healthpb.HealthCheckResponse_SERVING
+		if failing {
+			status = healthpb.HealthCheckResponse_NOT_SERVING
+			serving = serving && dep.Optional
+		}
+		s.health.SetServingStatus(s.dependencyService(dep.Name), status)
+	}
+
+	if !serving {
+		s.setStatus(healthpb.HealthCheckResponse_NOT_SERVING)
+		return
+	}
+	s.setStatus(healthpb.HealthCheckResponse_SERVING)
+}
+
+// shutdown stops the server, drains the dependencies and runs the shutdown
+// steps
+func (s *Supervisor) shutdown(server *grpc.Server) {
+	// Report NOT_SERVING from now on, so clients stop sending requests while
+	// the server drains
+	s.health.Shutdown()
+	s.cancel()
+
+	drainCtx, cancelDrain := context.WithTimeout(context.Background(), s.drainTimeout)
+	defer cancelDrain()
+
+	stopped := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-drainCtx.Done():
+		log.Printf("RPCs still running after %s, stopping %s now", s.drainTimeout, s.service)
+		server.Stop()
+		<-stopped
+	}
+
+	s.mu.Lock()
+	drains, steps := s.drains, s.steps
+	s.mu.Unlock()
+
+	// Drains run one at a time within what is left of the drain timeout
+	for _, drain := range drains {
+		s.runStep(drainCtx, drain)
+	}
+	for i := len(steps) - 1; i >= 0; i-- {
+		ctx, cancel := context.WithTimeout(context.Background(), s.stepTimeout)
+		s.runStep(ctx, steps[i])
+		cancel()
+	}
+	log.Printf("%s stopped", s.service)
+}
+
+// runStep runs a shutdown step, giving up on it once ctx is done
+func (s *Supervisor) runStep(ctx context.Context, st step) {
+	done := make(chan error, 1)
+	go func() {
+		done <- st.run(ctx)
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			log.Printf("Failed to shut down %s: %v", st.name, err)
+		}
+	case <-ctx.Done():
+		log.Printf("Gave up shutting down %s: %v", st.name, ctx.Err())
+	}
+}
+
+func (s *Supervisor) dependencyService(name string) string {
+	return s.service + "/" + name
+}
+
+func (s *Supervisor) setStatus(status healthpb.HealthCheckResponse_ServingStatus) {
+	s.health.SetServingStatus("", status)
+	s.health.SetServingStatus(s.service, status)
+}
+
+func durationEnv(key string, defaultValue time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		log.Printf("Invalid %s %q, using %s", key, value, defaultValue)
+		return defaultValue
+	}
+	return d
+}
@@ -0,0 +1,91 @@
+package logging
+
+import (
+	"bufio"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// requestIDHeader is RequestIDKey as HTTP clients send it
+const requestIDHeader = "X-Request-ID"
+
+// Handler starts the request ID of every HTTP request, continuing the one a
+// client sent in X-Request-ID, returns it in the response headers and logs
+// the request once it completes
+func Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(requestIDHeader)
+		if len(id) > 128 {
+			// Not ours to trust with arbitrary sizes in every log line
+			id = ""
+		}
+		ctx := WithRequestID(r.Context(), id)
+		w.Header().Set(requestIDHeader, RequestID(ctx))
+
+		start := time.Now()
+		rw := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rw, r.WithContext(ctx))
+
+		logger := FromContext(ctx)
+		var event *zerolog.Event
+		switch {
+		case rw.status >= http.StatusInternalServerError:
+			event = logger.Error()
+		case rw.status >= http.StatusBadRequest:
+			event = logger.Warn()
+		default:
+			event = logger.Info()
+		}
+
+		event.
+			Str("http_method", r.Method).
+			Str("path", r.URL.Path).
+			Int("status", rw.status).
+			Dur("duration", time.Since(start)).
+			Msg("handled request")
+	})
+}
+
+// statusWriter records the status code of a response. It passes Hijack
+// through so websocket upgrades keep working.
+type statusWriter struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+}
+
+func (w *statusWriter) WriteHeader(status int) {
+	if !w.wroteHeader {
+		w.status = status
+		w.wroteHeader = true
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *statusWriter) Write(b []byte) (int, error) {
+	w.wroteHeader = true
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *statusWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (w *statusWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("response does not support hijacking")
+	}
+	w.status = http.StatusSwitchingProtocols
+	return h.Hijack()
+}
+
+func (w *statusWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
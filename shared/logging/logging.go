@@ -0,0 +1,235 @@
+// Package logging writes structured JSON logs and carries a request ID from
+// the gateway through gRPC metadata and NATS headers, so every line logged
+// for a request, in any service, can be found by that ID.
+//
+// LOG_LEVEL sets the minimum level (default info) and LOG_FORMAT=console
+// switches to human-readable output for local development. The standard
+// library logger is routed through the same output, so lines logged with
+// log.Printf at startup and shutdown are structured too.
+package logging
+
+import (
+	"context"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// RequestIDKey is the gRPC metadata key and NATS header carrying the request
+// ID. HTTP clients may send it as X-Request-ID.
+const RequestIDKey = "x-request-id"
+
+var base = zerolog.New(os.Stderr).With().Timestamp().Logger()
+
+// Init configures the logger of service and routes the standard library
+// logger through it
+func Init(service string) {
+	zerolog.TimeFieldFormat = time.RFC3339Nano
+	zerolog.DurationFieldUnit = time.Millisecond
+
+	level, err := zerolog.ParseLevel(strings.ToLower(os.Getenv("LOG_LEVEL")))
+	if err != nil || level == zerolog.NoLevel {
+		level = zerolog.InfoLevel
+	}
+	zerolog.SetGlobalLevel(level)
+
+	out := zerolog.New(os.Stderr)
+	if os.Getenv("LOG_FORMAT") == "console" {
+		out = zerolog.New(zerolog.ConsoleWriter{Out: os.Stderr, TimeFormat: time.TimeOnly})
+	}
+	base = out.With().Timestamp().Str("service", service).Logger()
+
+	log.SetFlags(0)
+	log.SetOutput(stdlibWriter{})
+}
+
+// stdlibWriter turns each line of the standard library logger into an info
+// entry
+type stdlibWriter struct{}
+
+func (stdlibWriter) Write(p []byte) (int, error) {
+	base.Info().Msg(strings.TrimRight(string(p), "\n"))
+	return len(p), nil
+}
+
+type contextKey struct{}
+
+// fields are shared by the contexts of one request, so the caller the auth
+// interceptor identifies also shows up in the line the logging interceptor,
+// which runs before it, writes when the call completes
+type fields struct {
+	requestID string
+	logger    zerolog.Logger
+}
+
+// FromContext returns the logger of the request ctx belongs to, or the
+// service logger outside a request
+func FromContext(ctx context.Context) *zerolog.Logger {
+	if f, ok := ctx.Value(contextKey{}).(*fields); ok {
+		return &f.logger
+	}
+	return &base
+}
+
+// RequestID returns the ID of the request ctx belongs to, if any
+func RequestID(ctx context.Context) string {
+	if f, ok := ctx.Value(contextKey{}).(*fields); ok {
+		return f.requestID
+	}
+	return ""
+}
+
+// WithRequestID returns a copy of ctx whose logger tags lines with id,
+// generating an ID when it is empty
+func WithRequestID(ctx context.Context, id string) context.Context {
+	if id == "" {
+		id = uuid.NewString()
+	}
+	return context.WithValue(ctx, contextKey{}, &fields{
+		requestID: id,
+		logger:    base.With().Str("request_id", id).Logger(),
+	})
+}
+
+// WithUser tags the lines logged for the request with the caller, including
+// the line the server interceptor logs when the call completes
+func WithUser(ctx context.Context, userID string) context.Context {
+	f, ok := ctx.Value(contextKey{}).(*fields)
+	if !ok {
+		ctx = WithRequestID(ctx, "")
+		f = ctx.Value(contextKey{}).(*fields)
+	}
+	f.logger = f.logger.With().Str("user_id", userID).Logger()
+	return ctx
+}
+
+// UnaryServerInterceptor continues the request ID of the caller, or starts
+// one, and logs every call once it completes. It must run before the auth
+// interceptor so that the caller it identifies is logged too.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx = incoming(ctx)
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		logCall(ctx, info.FullMethod, start, err)
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor is UnaryServerInterceptor for streaming calls
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx := incoming(stream.Context())
+		start := time.Now()
+		err := handler(srv, &contextStream{ServerStream: stream, ctx: ctx})
+		logCall(ctx, info.FullMethod, start, err)
+		return err
+	}
+}
+
+// UnaryClientInterceptor passes the request ID on to the called service
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(outgoing(ctx), method, req, reply, cc, opts...)
+	}
+}
+
+// StreamClientInterceptor passes the request ID on to the called service
+func StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(outgoing(ctx), desc, cc, method, opts...)
+	}
+}
+
+// Inject writes the request ID of ctx into the headers of a NATS message
+func Inject(ctx context.Context, header map[string][]string) {
+	if id := RequestID(ctx); id != "" {
+		header[RequestIDKey] = []string{id}
+	}
+}
+
+// Extract returns a copy of ctx carrying the request ID of a NATS message,
+// so the lines logged while handling it join the request that published it
+func Extract(ctx context.Context, subject string, header map[string][]string) context.Context {
+	var id string
+	if values := header[RequestIDKey]; len(values) > 0 {
+		id = values[0]
+	}
+	ctx = WithRequestID(ctx, id)
+	f := ctx.Value(contextKey{}).(*fields)
+	f.logger = f.logger.With().Str("subject", subject).Logger()
+	return ctx
+}
+
+func incoming(ctx context.Context) context.Context {
+	var id string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(RequestIDKey); len(values) > 0 {
+			id = values[0]
+		}
+	}
+	return WithRequestID(ctx, id)
+}
+
+func outgoing(ctx context.Context) context.Context {
+	id := RequestID(ctx)
+	if id == "" {
+		return ctx
+	}
+	if md, ok := metadata.FromOutgoingContext(ctx); ok && len(md.Get(RequestIDKey)) > 0 {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, RequestIDKey, id)
+}
+
+// logCall logs a completed call: client errors as warnings, server errors as
+// errors and health probes only at debug level
+func logCall(ctx context.Context, method string, start time.Time, err error) {
+	code := status.Code(err)
+
+	logger := FromContext(ctx)
+	var event *zerolog.Event
+	switch {
+	case strings.HasPrefix(method, "/"+healthpb.Health_ServiceDesc.ServiceName+"/"):
+		event = logger.Debug()
+	case code == codes.OK:
+		event = logger.Info()
+	case isServerError(code):
+		event = logger.Error().Err(err)
+	default:
+		event = logger.Warn().Err(err)
+	}
+
+	event.
+		Str("method", method).
+		Str("code", code.String()).
+		Dur("duration", time.Since(start)).
+		Msg("handled call")
+}
+
+func isServerError(code codes.Code) bool {
+	switch code {
+	case codes.Unknown, codes.Internal, codes.Unavailable, codes.DataLoss, codes.DeadlineExceeded, codes.Unimplemented:
+		return true
+	}
+	return false
+}
+
+// contextStream wraps a grpc.ServerStream with the request's context
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,194 @@
+// Package migrate applies the schema changes a service ships as numbered SQL
+// files (0001_init.sql, 0002_add_x.sql, ...), in name order. Each applied
+// file is recorded in schema_migrations as <service>/<file> with a checksum,
+// so services sharing a database keep separate histories, and a file edited
+// after it was applied is refused. Migrations only ever move forward; undo a
+// change by adding a file.
+package migrate
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"log"
+	"path"
+	"sort"
+	"strings"
+)
+
+// migrationLock serialises replicas migrating the same database
+const migrationLock = "schema_migrations"
+
+// DB is the part of *sql.DB and *sqlx.DB migrations use
+type DB interface {
+	Conn(ctx context.Context) (*sql.Conn, error)
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+// Migration is a schema migration file of a service
+type Migration struct {
+	Name     string
+	Body     string
+	Checksum string
+}
+
+// Load reads the .sql files of files in name order
+func Load(files fs.FS) ([]Migration, error) {
+	names, err := fs.Glob(files, "*.sql")
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(names)
+
+	migrations := make([]Migration, 0, len(names))
+	for _, name := range names {
+		body, err := fs.ReadFile(files, name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %s: %w", name, err)
+		}
+		sum := sha256.Sum256(body)
+		migrations = append(migrations, Migration{
+			Name:     path.Base(name),
+			Body:     string(body),
+			Checksum: hex.EncodeToString(sum[:]),
+		})
+	}
+	return migrations, nil
+}
+
+// Pending returns the migrations of service not applied to db yet. It fails
+// if an applied migration has changed since.
+func Pending(ctx context.Context, db DB, service string, files fs.FS) ([]Migration, error) {
+	migrations, err := Load(files)
+	if err != nil {
+		return nil, err
+	}
+
+	applied, err := appliedMigrations(ctx, db, service)
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []Migration
+	for _, m := range migrations {
+		checksum, ok := applied[m.Name]
+		if !ok {
+			pending = append(pending, m)
+			continue
+		}
+		if checksum != m.Checksum {
+			return nil, fmt.Errorf("migration %s/%s changed since it was applied; add a new migration instead", service, m.Name)
+		}
+	}
+	return pending, nil
+}
+
+// Apply applies the pending migrations of service to db, each in its own
+// transaction, and returns their names. Replicas starting together wait for
+// each other on an advisory lock, so every migration runs once.
+func Apply(ctx context.Context, db DB, service string, files fs.FS) ([]string, error) {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get connection: %w", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock(hashtext($1))`, migrationLock); err != nil {
+		return nil, fmt.Errorf("failed to lock schema_migrations: %w", err)
+	}
+	defer conn.ExecContext(context.WithoutCancel(ctx), `SELECT pg_advisory_unlock(hashtext($1))`, migrationLock)
+
+	pending, err := Pending(ctx, db, service, files)
+	if err != nil {
+		return nil, err
+	}
+
+	var applied []string
+	for _, m := range pending {
+		if err := applyMigration(ctx, conn, service, m); err != nil {
+			return applied, err
+		}
+		applied = append(applied, m.Name)
+	}
+	return applied, nil
+}
+
+// OnStartup applies the pending migrations of service to db when apply is
+// set, and otherwise logs them so a deploy that forgot to migrate is noticed
+func OnStartup(ctx context.Context, db DB, service string, files fs.FS, apply bool) error {
+	if !apply {
+		pending, err := Pending(ctx, db, service, files)
+		if err != nil {
+			return err
+		}
+		if len(pending) > 0 {
+			names := make([]string, len(pending))
+			for i, m := range pending {
+				names[i] = m.Name
+			}
+			log.Printf("%d schema migrations pending (%s); start with -migrate or DB_MIGRATE=true to apply them", len(pending), strings.Join(names, ", "))
+		}
+		return nil
+	}
+
+	applied, err := Apply(ctx, db, service, files)
+	for _, name := range applied {
+		log.Printf("Applied migration %s/%s", service, name)
+	}
+	return err
+}
+
+func appliedMigrations(ctx context.Context, db DB, service string) (map[string]string, error) {
+	_, err := db.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			name TEXT PRIMARY KEY,
+			checksum TEXT NOT NULL,
+			applied_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+		)
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create schema_migrations: %w", err)
+	}
+
+	rows, err := db.QueryContext(ctx, `SELECT name, checksum FROM schema_migrations WHERE name LIKE $1 || '/%'`, service)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query schema_migrations: %w", err)
+	}
+	defer rows.Close()
+
+	applied := make(map[string]string)
+	for rows.Next() {
+		var name, checksum string
+		if err := rows.Scan(&name, &checksum); err != nil {
+			return nil, fmt.Errorf("failed to scan migration: %w", err)
+		}
+		applied[strings.TrimPrefix(name, service+"/")] = checksum
+	}
+	return applied, rows.Err()
+}
+
+func applyMigration(ctx context.Context, conn *sql.Conn, service string, m Migration) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, m.Body); err != nil {
+		return fmt.Errorf("failed to apply migration %s/%s: %w", service, m.Name, err)
+	}
+
+	_, err = tx.ExecContext(ctx, `INSERT INTO schema_migrations (name, checksum) VALUES ($1, $2)`, service+"/"+m.Name, m.Checksum)
+	if err != nil {
+		return fmt.Errorf("failed to record migration %s/%s: %w", service, m.Name, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit migration %s/%s: %w", service, m.Name, err)
+	}
+	return nil
+}
@@ -0,0 +1,277 @@
+// Package mtls secures the gRPC connections between services with TLS and,
+// when a CA bundle is given, mutual TLS: servers then only accept clients
+// presenting a certificate the bundle vouches for.
+//
+// It is configured from the environment:
+//
+//	GRPC_TLS=true         enable TLS; plaintext otherwise
+//	GRPC_TLS_CERT_FILE    certificate of this process, served to clients and
+//	GRPC_TLS_KEY_FILE     presented to servers, with its key
+//	GRPC_TLS_CA_FILE      bundle peers are verified with
+//	GRPC_TLS_SPIFFE_IDS   comma-separated SPIFFE IDs, or trust domains such as
+//	                      spiffe://muzeeng.local, peers must present
+//
+// Certificates are read again when their files change, so short-lived ones,
+// such as SPIFFE SVIDs, can be rotated in place. The CA bundle is read once.
+package mtls
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+type Config struct {
+	Enabled  bool
+	CertFile string
+	KeyFile  string
+	CAFile   string
+	// ServerName overrides the name a server's certificate is verified
+	// against, which is the host dialed by default
+	ServerName string
+	// SPIFFEIDs are the IDs or trust domains a peer's certificate must name.
+	// When set, a server's certificate is verified against them instead of
+	// its host name, since SVIDs carry no DNS names.
+	SPIFFEIDs          []string
+	InsecureSkipVerify bool
+}
+
+// FromEnv reads the configuration from the environment
+func FromEnv() Config {
+	cfg := Config{
+		Enabled:  os.Getenv("GRPC_TLS") == "true",
+		CertFile: os.Getenv("GRPC_TLS_CERT_FILE"),
+		KeyFile:  os.Getenv("GRPC_TLS_KEY_FILE"),
+		CAFile:   os.Getenv("GRPC_TLS_CA_FILE"),
+	}
+	for _, id := range strings.Split(os.Getenv("GRPC_TLS_SPIFFE_IDS"), ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			cfg.SPIFFEIDs = append(cfg.SPIFFEIDs, id)
+		}
+	}
+	return cfg
+}
+
+// ServerOption returns the credentials of a gRPC server configured from the
+// environment
+func ServerOption() (grpc.ServerOption, error) {
+	creds, err := FromEnv().ServerCredentials()
+	if err != nil {
+		return nil, err
+	}
+	return grpc.Creds(creds), nil
+}
+
+// DialOption returns the credentials of a gRPC client configured from the
+// environment
+func DialOption() (grpc.DialOption, error) {
+	creds, err := FromEnv().ClientCredentials()
+	if err != nil {
+		return nil, err
+	}
+	return grpc.WithTransportCredentials(creds), nil
+}
+
+// ServerCredentials serves the configured certificate. With a CA bundle,
+// clients must present a certificate it vouches for.
+func (c Config) ServerCredentials() (credentials.TransportCredentials, error) {
+	if !c.Enabled {
+		return insecure.NewCredentials(), nil
+	}
+	if c.CertFile == "" || c.KeyFile == "" {
+		return nil, errors.New("TLS is enabled but GRPC_TLS_CERT_FILE or GRPC_TLS_KEY_FILE is not set")
+	}
+
+	pair, err := newKeyPair(c.CertFile, c.KeyFile)
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			return pair.get()
+		},
+	}
+
+	if c.CAFile != "" {
+		roots, err := loadPool(c.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.ClientCAs = roots
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+		if len(c.SPIFFEIDs) > 0 {
+			tlsConfig.VerifyConnection = func(cs tls.ConnectionState) error {
+				return verifySPIFFEID(cs.PeerCertificates[0], c.SPIFFEIDs)
+			}
+		}
+	}
+
+	return credentials.NewTLS(tlsConfig), nil
+}
+
+// ClientCredentials verifies servers with the CA bundle, or the system roots
+// without one, and presents the configured certificate if there is one
+func (c Config) ClientCredentials() (credentials.TransportCredentials, error) {
+	if !c.Enabled {
+		return insecure.NewCredentials(), nil
+	}
+	if (c.CertFile == "") != (c.KeyFile == "") {
+		return nil, errors.New("the TLS certificate and key must be set together")
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         c.ServerName,
+		InsecureSkipVerify: c.InsecureSkipVerify,
+	}
+
+	if c.CertFile != "" {
+		pair, err := newKeyPair(c.CertFile, c.KeyFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			return pair.get()
+		}
+	}
+
+	var roots *x509.CertPool
+	if c.CAFile != "" {
+		var err error
+		if roots, err = loadPool(c.CAFile); err != nil {
+			return nil, err
+		}
+		tlsConfig.RootCAs = roots
+	}
+
+	if len(c.SPIFFEIDs) > 0 && !c.InsecureSkipVerify {
+		// The chain is verified here, without the host name check
+		tlsConfig.InsecureSkipVerify = true
+		tlsConfig.VerifyConnection = func(cs tls.ConnectionState) error {
+			if err := verifyChain(cs.PeerCertificates, roots); err != nil {
+				return err
+			}
+			return verifySPIFFEID(cs.PeerCertificates[0], c.SPIFFEIDs)
+		}
+	}
+
+	return credentials.NewTLS(tlsConfig), nil
+}
+
+func verifyChain(certs []*x509.Certificate, roots *x509.CertPool) error {
+	if len(certs) == 0 {
+		return errors.New("server presented no certificate")
+	}
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+	_, err := certs[0].Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	})
+	return err
+}
+
+// verifySPIFFEID checks that cert names one of allowed, each either a full
+// SPIFFE ID or a trust domain
+func verifySPIFFEID(cert *x509.Certificate, allowed []string) error {
+	for _, uri := range cert.URIs {
+		if uri.Scheme != "spiffe" {
+			continue
+		}
+		for _, id := range allowed {
+			want, err := url.Parse(id)
+			if err != nil {
+				continue
+			}
+			if want.Host == uri.Host && (want.Path == "" || want.Path == "/" || want.Path == uri.Path) {
+				return nil
+			}
+		}
+		return fmt.Errorf("peer SPIFFE ID %s is not allowed", uri)
+	}
+	return errors.New("peer certificate has no SPIFFE ID")
+}
+
+func loadPool(path string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA bundle: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificates in CA bundle %s", path)
+	}
+	return pool, nil
+}
+
+// keyPair is a certificate that is read again once its files change
+type keyPair struct {
+	certFile, keyFile string
+
+	mu      sync.Mutex
+	cert    *tls.Certificate
+	modTime time.Time
+}
+
+func newKeyPair(certFile, keyFile string) (*keyPair, error) {
+	pair := &keyPair{certFile: certFile, keyFile: keyFile}
+	if _, err := pair.get(); err != nil {
+		return nil, err
+	}
+	return pair, nil
+}
+
+func (p *keyPair) get() (*tls.Certificate, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	modTime, err := latestModTime(p.certFile, p.keyFile)
+	if err != nil {
+		if p.cert != nil {
+			// Keep serving the last certificate while files are replaced
+			return p.cert, nil
+		}
+		return nil, err
+	}
+	if p.cert != nil && !modTime.After(p.modTime) {
+		return p.cert, nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(p.certFile, p.keyFile)
+	if err != nil {
+		if p.cert != nil {
+			return p.cert, nil
+		}
+		return nil, fmt.Errorf("failed to load TLS certificate: %w", err)
+	}
+	p.cert, p.modTime = &cert, modTime
+	return p.cert, nil
+}
+
+func latestModTime(paths ...string) (time.Time, error) {
+	var latest time.Time
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+	return latest, nil
+}
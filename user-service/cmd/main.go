@@ -2,30 +2,56 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"log"
 	"net"
 	"os"
-	"os/signal"
-	"syscall"
+	"time"
 
 	"github.com/joho/godotenv"
+	"github.com/redis/go-redis/v9"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
 
+	followpb "follow-service/pb"
+	postpb "post-service/pb"
+	"shared/chaos"
+	"shared/dedupe"
+	"shared/lifecycle"
+	"shared/logging"
+	"shared/migrate"
+	"shared/mtls"
+	"shared/tracing"
 	"user-service/config"
 	"user-service/db"
 	"user-service/handler"
+	"user-service/imaging"
 	"user-service/interceptor"
+	"user-service/media"
+	"user-service/migrations"
+	natsClient "user-service/nats"
 	pb "user-service/pb"
+	"user-service/publisher"
 	"user-service/repository"
+	"user-service/rpcerror"
+	"user-service/subscriber"
 )
 
 func main() {
+	logging.Init("user-service")
+
+	migrateFlag := flag.Bool("migrate", false, "apply pending schema migrations before serving")
+	flag.Parse()
+
 	if err := godotenv.Load(); err != nil {
 		log.Println("Failed to load User .env")
 	}
 
+	// The supervisor health-checks the dependencies below and closes them
+	// in order on shutdown
+	supervisor := lifecycle.New(pb.UserService_ServiceDesc.ServiceName)
+
 	// Load database configuration using config package
 	dbCfg, err := config.LoadDatabaseConfig("")
 	if err != nil {
@@ -43,35 +69,171 @@ func main() {
 		MaxOpenConns: dbCfg.MaxOpenConns,
 		MaxIdleConns: dbCfg.MaxIdleConns,
 		MaxLifetime:  dbCfg.MaxLifetime,
+		ReplicaDSNs:  dbCfg.ReplicaDSNs,
 	})
 	if err != nil {
 		log.Fatalf("Failed to connect to User database: %v", err)
 	}
-	defer dbConn.Close()
+	supervisor.Add(lifecycle.Dependency{Name: "database", Check: dbConn.HealthCheck, Close: dbConn.Close})
+
+	// Apply pending schema migrations with -migrate or DB_MIGRATE=true;
+	// without either they are only reported
+	if err := migrate.OnStartup(context.Background(), dbConn.DB, "user-service", migrations.Files, *migrateFlag || os.Getenv("DB_MIGRATE") == "true"); err != nil {
+		log.Fatalf("Failed to migrate User database: %v", err)
+	}
 
 	log.Println("Successfully connected to User database")
 
 	// Load other configuration (non-database)
 	grpcPort := getEnv("GRPC_PORT", "50052")
 	jwtSecret := getEnv("JWT_SECRET", "your-secret-key")
+	// Tokens are verified with JWT_SECRET, or with auth-service's public keys
+	// when JWT_ALGORITHM is RS256
+	tokenKeys, err := interceptor.KeysFromEnv(jwtSecret)
+	if err != nil {
+		log.Fatalf("Invalid JWT configuration: %v", err)
+	}
+
+	// Fault injection for resilience testing; inert unless CHAOS_ENABLED=true
+	chaosInjector, err := chaos.FromEnv()
+	if err != nil {
+		log.Fatalf("Invalid chaos configuration: %v", err)
+	}
+
+	// Export traces when OTEL_EXPORTER_OTLP_ENDPOINT is set
+	shutdownTracing, err := tracing.Init(context.Background(), "user-service")
+	if err != nil {
+		log.Fatalf("Failed to initialize tracing: %v", err)
+	}
+	supervisor.OnShutdown("tracing", shutdownTracing)
+
+	// Initialize NATS client
+	natsCfg := natsClient.Config{
+		URL:           getEnv("NATS_URL", "nats://nats:4222"),
+		MaxReconnects: 10,
+		ReconnectWait: 2 * time.Second,
+		ClientID:      getEnv("NATS_CLIENT_ID", "user-service"),
+		Chaos:         chaosInjector,
+	}
+
+	nats, err := natsClient.NewClient(natsCfg)
+	if err != nil {
+		log.Fatalf("Failed to initialize NATS client: %v", err)
+	}
+	supervisor.Add(lifecycle.Dependency{Name: "nats", Check: nats.HealthCheck, Drain: nats.Drain})
+	log.Println("NATS client initialized successfully")
+
+	// Initialize event publisher
+	eventPublisher := publisher.NewEventPublisher(nats)
+
+	// Other services are dialed over TLS when GRPC_TLS is set
+	clientTLS, err := mtls.DialOption()
+	if err != nil {
+		log.Fatalf("Failed to load TLS config: %v", err)
+	}
+
+	// Follow counters are recounted from follow-service, which holds the
+	// follows themselves
+	followConn, err := grpc.NewClient(getEnv("FOLLOW_SERVICE_ADDR", "follow-service:50055"), clientTLS, tracing.DialOption(), grpc.WithChainUnaryInterceptor(logging.UnaryClientInterceptor()))
+	if err != nil {
+		log.Fatalf("Failed to connect to follow service: %v", err)
+	}
+	defer followConn.Close()
+
+	// and post counters from post-service
+	postConn, err := grpc.NewClient(getEnv("POST_SERVICE_ADDR", "post-service:50053"), clientTLS, tracing.DialOption(), grpc.WithChainUnaryInterceptor(logging.UnaryClientInterceptor()))
+	if err != nil {
+		log.Fatalf("Failed to connect to post service: %v", err)
+	}
+	defer postConn.Close()
+
+	// Avatars and cover images are kept on disk; replicas must share
+	// PROFILE_IMAGE_DIR
+	imageStore, err := media.NewFileStore(getEnv("PROFILE_IMAGE_DIR", "/var/lib/muzeeng/profile-images"))
+	if err != nil {
+		log.Fatalf("Failed to initialize profile image store: %v", err)
+	}
+
+	// Connect to Redis when REDIS_URL is set, to cache profiles for
+	// PROFILE_CACHE_TTL; without it every lookup reads the database
+	var redisClient *redis.Client
+	if redisURL := os.Getenv("REDIS_URL"); redisURL != "" {
+		redisClient = redis.NewClient(&redis.Options{
+			Addr:     redisURL,
+			Password: getEnv("REDIS_PASSWORD", ""),
+			DB:       getEnvAsInt("REDIS_DB", 0),
+			PoolSize: 10,
+		})
+		// Redis only caches, so the service stays up without it
+		supervisor.Add(lifecycle.Dependency{Name: "redis", Check: func(ctx context.Context) error { return redisClient.Ping(ctx).Err() }, Close: redisClient.Close, Optional: true})
+
+		pingCtx, pingCancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer pingCancel()
+		if err := redisClient.Ping(pingCtx).Err(); err != nil {
+			log.Fatalf("Failed to connect to User Redis: %v", err)
+		}
+		log.Println("User Redis connected successfully")
+	}
 
 	// Initialize repository and handler
-	userRepo := repository.NewUserRepository(dbConn.DB)
-	userHandler := handler.NewUserHandler(userRepo)
+	userRepo := repository.NewUserRepository(dbConn, redisClient, getEnvAsDuration("PROFILE_CACHE_TTL", 5*time.Minute))
+	userHandler := handler.NewUserHandler(userRepo, eventPublisher, followpb.NewFollowServiceClient(followConn), postpb.NewPostServiceClient(postConn), imageStore)
+
+	// Uploaded images are resized, and unused ones swept, in the background
+	imagingCtx, stopImaging := context.WithCancel(supervisor.Context())
+	defer stopImaging()
+	go imaging.New(userHandler).Run(imagingCtx)
+
+	// User deletions already handled are recorded, so redeliveries are
+	// skipped
+	processedEvents := dedupe.New(dbConn.DB, "user_service_processed_events")
+	go processedEvents.RunPruning(supervisor.Context())
+
+	// Profiles are deleted along with their account, and follows and posts
+	// are counted from the events of follow-service and post-service
+	subscriberCtx, stopSubscribers := context.WithCancel(context.Background())
+	defer stopSubscribers()
+	subscriber.NewUserSubscriber(nats, processedEvents, userRepo, subscriberCtx).Start()
+	subscriber.NewCounterSubscriber(nats, userHandler, subscriberCtx).Start()
+
+	// Profile cache hits and misses are logged every PROFILE_CACHE_STATS_INTERVAL
+	if redisClient != nil {
+		go logCacheStats(subscriberCtx, userRepo, getEnvAsDuration("PROFILE_CACHE_STATS_INTERVAL", 5*time.Minute))
+	}
 
 	// Setup auth interceptor
-	authInterceptor := interceptor.NewAuthInterceptor(jwtSecret, []string{
+	authInterceptor := interceptor.NewAuthInterceptor(tokenKeys, []string{
 		"/user.UserService/GetProfile",
 		"/user.UserService/GetUsersByIds",
+		"/user.UserService/GetUsersByUsernames",
+		"/user.UserService/ListPublicProfiles",
+		"/user.UserService/GetProfileImageContent",
 	})
+	authInterceptor.AddAdminMethods([]string{
+		"/user.UserService/SetUserCounters",
+		"/user.UserService/ReconcileFollowCounters",
+		"/user.UserService/ReconcilePostCounters",
+		"/user.UserService/ImportProfiles",
+	})
+	authInterceptor.AddPublicMethods(lifecycle.HealthMethods)
+
+	// TLS, and mutual TLS with a CA bundle, from GRPC_TLS_*
+	tlsOption, err := mtls.ServerOption()
+	if err != nil {
+		log.Fatalf("Failed to load TLS config: %v", err)
+	}
 
 	// Create gRPC server
 	grpcServer := grpc.NewServer(
-		grpc.UnaryInterceptor(authInterceptor.Unary()),
+		tlsOption,
+		tracing.ServerOption(),
+		grpc.ChainUnaryInterceptor(logging.UnaryServerInterceptor(), rpcerror.UnaryServerInterceptor(), chaosInjector.Unary(), authInterceptor.Unary()),
+		grpc.ChainStreamInterceptor(logging.StreamServerInterceptor(), rpcerror.StreamServerInterceptor(), chaosInjector.Stream(), authInterceptor.Stream()),
 	)
 
 	// Register service
 	pb.RegisterUserServiceServer(grpcServer, userHandler)
+	supervisor.Register(grpcServer)
 
 	// Enable reflection for debugging tools
 	reflection.Register(grpcServer)
@@ -84,29 +246,8 @@ func main() {
 
 	log.Printf("User Service gRPC server listening on port %s", grpcPort)
 
-	// Graceful shutdown handling
-	go func() {
-		sigChan := make(chan os.Signal, 1)
-		signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
-		<-sigChan
-
-		log.Println("User service Shutting down gracefully...")
-		grpcServer.GracefulStop()
-
-		ctx, cancel := context.WithTimeout(context.Background(), dbCfg.MaxLifetime)
-		defer cancel()
-
-		if err := dbConn.HealthCheck(ctx); err == nil {
-			_ = dbConn.Close()
-			log.Println("User Database connection closed")
-		}
-
-		log.Println("Server stopped")
-		os.Exit(0)
-	}()
-
 	// Serve gRPC
-	if err := grpcServer.Serve(listener); err != nil {
+	if err := supervisor.Serve(grpcServer, listener); err != nil {
 		log.Fatalf("Failed to serve: %v", err)
 	}
 }
@@ -118,3 +259,47 @@ func getEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+func getEnvAsInt(key string, defaultVal int) int {
+	if val := os.Getenv(key); val != "" {
+		var intVal int
+		fmt.Sscanf(val, "%d", &intVal)
+		return intVal
+	}
+	return defaultVal
+}
+
+func getEnvAsDuration(key string, defaultVal time.Duration) time.Duration {
+	if val, err := time.ParseDuration(os.Getenv(key)); err == nil && val > 0 {
+		return val
+	}
+	return defaultVal
+}
+
+// logCacheStats logs the profile cache's hits and misses of each interval
+// until ctx is cancelled
+func logCacheStats(ctx context.Context, repo repository.UserRepository, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	last := repo.CacheStats()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		stats := repo.CacheStats()
+		hits, misses := stats.Hits-last.Hits, stats.Misses-last.Misses
+		last = stats
+		if hits+misses == 0 {
+			continue
+		}
+		logging.FromContext(ctx).Info().
+			Int64("hits", hits).
+			Int64("misses", misses).
+			Float64("hit_ratio", float64(hits)/float64(hits+misses)).
+			Msg("profile cache stats")
+	}
+}
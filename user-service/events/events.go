@@ -0,0 +1,90 @@
+package events
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	UserUpdated = "user.updated"
+	// UsernameChanged is published along with UserUpdated when a username
+	// changes, for auth-service to keep its copy of the username
+	UsernameChanged = "user.username_changed"
+	// UserDeleted is published by auth-service when an account is deleted
+	UserDeleted = "user.deleted"
+	// AuditRecorded is shared with auth-service and recorded by audit-service
+	AuditRecorded = "audit.recorded"
+	// UserFollowed and UserUnfollowed are published by follow-service and
+	// counted into the follower and following counters
+	UserFollowed   = "follow.created"
+	UserUnfollowed = "follow.deleted"
+	// PostCreated and PostDeleted are published by post-service and counted
+	// into the post counters
+	PostCreated = "post.created"
+	PostDeleted = "post.deleted"
+)
+
+// Event payloads
+type UserUpdatedEvent struct {
+	UserID      uuid.UUID `json:"user_id"`
+	Username    string    `json:"username"`
+	DisplayName *string   `json:"display_name,omitempty"`
+	Bio         *string   `json:"bio,omitempty"`
+	IsPrivate   bool      `json:"is_private"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// UsernameChangedEvent names a user's former username, reserved for them
+// until ReservedUntil. A change of case only reserves nothing.
+type UsernameChangedEvent struct {
+	UserID        uuid.UUID  `json:"user_id"`
+	OldUsername   string     `json:"old_username"`
+	NewUsername   string     `json:"new_username"`
+	ChangedAt     time.Time  `json:"changed_at"`
+	ReservedUntil *time.Time `json:"reserved_until,omitempty"`
+}
+
+type UserDeletedEvent struct {
+	UserID    uuid.UUID `json:"user_id"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+// FollowEvent holds the fields user-service uses of follow-service's
+// follow.created and follow.deleted events. CreatedAt is set on the first,
+// DeletedAt on the second.
+type FollowEvent struct {
+	FollowerID  uuid.UUID `json:"follower_id"`
+	FollowingID uuid.UUID `json:"following_id"`
+	CreatedAt   time.Time `json:"created_at"`
+	DeletedAt   time.Time `json:"deleted_at"`
+}
+
+// PostEvent holds the fields user-service uses of post-service's
+// post.created and post.deleted events
+type PostEvent struct {
+	PostID uuid.UUID `json:"post_id"`
+	UserID uuid.UUID `json:"user_id"`
+}
+
+// AuditRecordedEvent describes a sensitive operation for audit-service to
+// record
+type AuditRecordedEvent struct {
+	ID         uuid.UUID     `json:"id"`
+	Service    string        `json:"service"`
+	Action     string        `json:"action"`
+	ActorID    *uuid.UUID    `json:"actor_id,omitempty"`
+	TargetType string        `json:"target_type"`
+	TargetID   string        `json:"target_id"`
+	IPAddress  string        `json:"ip_address"`
+	UserAgent  string        `json:"user_agent"`
+	Changes    []FieldChange `json:"changes,omitempty"`
+	OccurredAt time.Time     `json:"occurred_at"`
+}
+
+// FieldChange is one field an audited operation changed
+type FieldChange struct {
+	Field string  `json:"field"`
+	Old   *string `json:"old,omitempty"`
+	New   *string `json:"new,omitempty"`
+}
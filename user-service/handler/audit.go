@@ -0,0 +1,127 @@
+package handler
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+
+	"shared/logging"
+	"user-service/events"
+	"user-service/interceptor"
+	models "user-service/model"
+)
+
+// AuditProfileUpdated is the audit log action of a profile edit
+const AuditProfileUpdated = "PROFILE_UPDATED"
+
+// auditProfileUpdate publishes a profile edit for audit-service to record,
+// with the fields it changed. Auditing is best effort: a failure to publish
+// is logged and the edit stands.
+func (h *UserHandler) auditProfileUpdate(ctx context.Context, before, after *models.User) {
+	var changes []events.FieldChange
+	if before.Username != after.Username {
+		changes = append(changes, changed("username", before.Username, after.Username))
+	}
+	if before.Email != after.Email {
+		changes = append(changes, changed("email", before.Email, after.Email))
+	}
+	if oldBio, newBio := valueOf(before.Bio), valueOf(after.Bio); oldBio != newBio {
+		changes = append(changes, changed("bio", oldBio, newBio))
+	}
+	if before.IsPrivate != after.IsPrivate {
+		changes = append(changes, changed("is_private", strconv.FormatBool(before.IsPrivate), strconv.FormatBool(after.IsPrivate)))
+	}
+	for _, field := range []struct {
+		name          string
+		before, after *string
+	}{
+		{"display_name", before.DisplayName, after.DisplayName},
+		{"location", before.Location, after.Location},
+		{"website", before.Website, after.Website},
+		{"birth_date", dateOf(before.BirthDate), dateOf(after.BirthDate)},
+		{"pronouns", before.Pronouns, after.Pronouns},
+	} {
+		if old, new := valueOf(field.before), valueOf(field.after); old != new {
+			changes = append(changes, changed(field.name, old, new))
+		}
+	}
+	if before.HideEmail != after.HideEmail {
+		changes = append(changes, changed("hide_email", strconv.FormatBool(before.HideEmail), strconv.FormatBool(after.HideEmail)))
+	}
+	if before.HideBirthDate != after.HideBirthDate {
+		changes = append(changes, changed("hide_birth_date", strconv.FormatBool(before.HideBirthDate), strconv.FormatBool(after.HideBirthDate)))
+	}
+	if len(changes) == 0 {
+		return
+	}
+
+	event := events.AuditRecordedEvent{
+		ID:         uuid.New(),
+		Service:    "user-service",
+		Action:     AuditProfileUpdated,
+		TargetType: "USER",
+		TargetID:   after.ID.String(),
+		IPAddress:  clientIP(ctx),
+		UserAgent:  incomingMetadata(ctx, "x-client-user-agent"),
+		Changes:    changes,
+		OccurredAt: after.UpdatedAt,
+	}
+	if raw, err := interceptor.GetUserIDFromContext(ctx); err == nil {
+		if actorID, err := uuid.Parse(raw); err == nil {
+			event.ActorID = &actorID
+		}
+	}
+	if err := h.publisher.PublishAuditRecorded(ctx, event); err != nil {
+		logging.FromContext(ctx).Error().Err(err).Msg("failed to publish audit event")
+	}
+}
+
+func changed(field, old, new string) events.FieldChange {
+	return events.FieldChange{Field: field, Old: &old, New: &new}
+}
+
+// dateOf formats an optional date as YYYY-MM-DD
+func dateOf(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	s := t.Format(birthDateLayout)
+	return &s
+}
+
+func valueOf(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// clientIP returns the IP address of the end user a call is made for. Calls
+// through the gateway carry it in x-client-ip; calls made directly come
+// from the peer.
+func clientIP(ctx context.Context) string {
+	if ip := incomingMetadata(ctx, "x-client-ip"); ip != "" {
+		return ip
+	}
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	if host, _, err := net.SplitHostPort(p.Addr.String()); err == nil {
+		return host
+	}
+	return p.Addr.String()
+}
+
+func incomingMetadata(ctx context.Context, key string) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
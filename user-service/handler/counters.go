@@ -0,0 +1,225 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	followpb "follow-service/pb"
+	postpb "post-service/pb"
+	"shared/logging"
+	models "user-service/model"
+	pb "user-service/pb"
+)
+
+// reconcileBatchSize is how many users ReconcileFollowCounters and
+// ReconcilePostCounters recount per call to follow-service or post-service
+const reconcileBatchSize = 500
+
+// ApplyFollowEvent adds delta to the follow counters of a follow event's
+// users. An event already applied, e.g. one redelivered after a lost
+// acknowledgement, is skipped.
+func (h *UserHandler) ApplyFollowEvent(ctx context.Context, eventID string, followerID, followingID uuid.UUID, delta int32) error {
+	applied, err := h.repo.ApplyFollowEvent(ctx, eventID, followerID, followingID, delta)
+	if err != nil {
+		return err
+	}
+	if !applied {
+		logging.FromContext(ctx).Debug().Str("event_id", eventID).Stringer("follower_id", followerID).Msg("skipped follow event already applied")
+	}
+	return nil
+}
+
+// ApplyPostEvent counts a post on or off its author's posts_count. A post
+// already counted, e.g. by a redelivered or replayed event, is skipped.
+func (h *UserHandler) ApplyPostEvent(ctx context.Context, postID, userID uuid.UUID, delta int32) error {
+	applied, err := h.repo.ApplyPostEvent(ctx, postID, userID, delta)
+	if err != nil {
+		return err
+	}
+	if !applied {
+		logging.FromContext(ctx).Debug().Stringer("post_id", postID).Int32("delta", delta).Msg("skipped post already counted")
+	}
+	return nil
+}
+
+// PruneCounterEvents forgets follow events applied before appliedBefore
+func (h *UserHandler) PruneCounterEvents(ctx context.Context, appliedBefore time.Time) (int64, error) {
+	return h.repo.PruneCounterEvents(ctx, appliedBefore)
+}
+
+// ReconcileFollowCounters recounts the follows of every user in batches and
+// corrects the counters that differ from the counts follow-service holds. It
+// is run on a schedule by scheduler-service, to catch events that were lost
+// or never published, such as for follows deleted along with their user.
+func (h *UserHandler) ReconcileFollowCounters(ctx context.Context, req *pb.ReconcileFollowCountersRequest) (*pb.ReconcileFollowCountersResponse, error) {
+	sourceCtx := forwardToken(ctx)
+
+	var checked, corrected int32
+	after := uuid.Nil
+	for {
+		batch, err := h.repo.ListFollowCounters(ctx, after, reconcileBatchSize)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to reconcile counters after %d checked: %v", checked, err)
+		}
+		if len(batch) == 0 {
+			break
+		}
+
+		counts, err := h.countFollows(sourceCtx, batch)
+		if err != nil {
+			return nil, status.Errorf(codes.Unavailable, "failed to reconcile counters after %d checked: %v", checked, err)
+		}
+
+		for i, seen := range batch {
+			checked++
+			if seen == counts[i] {
+				continue
+			}
+			changed, err := h.repo.CorrectFollowCounters(ctx, seen, counts[i])
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "failed to reconcile counters after %d checked: %v", checked, err)
+			}
+			if changed {
+				corrected++
+				logging.FromContext(ctx).Info().
+					Stringer("user_id", seen.UserID).
+					Int32("followers_count", seen.FollowersCount).
+					Int32("followers_counted", counts[i].FollowersCount).
+					Int32("following_count", seen.FollowingCount).
+					Int32("following_counted", counts[i].FollowingCount).
+					Msg("corrected drifted follow counters")
+			}
+		}
+
+		if len(batch) < reconcileBatchSize {
+			break
+		}
+		after = batch[len(batch)-1].UserID
+	}
+
+	logging.FromContext(ctx).Info().Int32("checked", checked).Int32("corrected", corrected).Msg("reconciled follow counters")
+	return &pb.ReconcileFollowCountersResponse{Checked: checked, Corrected: corrected}, nil
+}
+
+// ReconcilePostCounters recounts the posts of every user in batches and
+// corrects the counters that differ from the counts post-service holds. It is
+// run on a schedule by scheduler-service, to catch posts no event was
+// published for, such as imported ones, and fills in the counters of posts
+// made before user-service counted them.
+func (h *UserHandler) ReconcilePostCounters(ctx context.Context, req *pb.ReconcilePostCountersRequest) (*pb.ReconcilePostCountersResponse, error) {
+	sourceCtx := forwardToken(ctx)
+
+	var checked, corrected int32
+	after := uuid.Nil
+	for {
+		batch, err := h.repo.ListPostsCounters(ctx, after, reconcileBatchSize)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to reconcile counters after %d checked: %v", checked, err)
+		}
+		if len(batch) == 0 {
+			break
+		}
+
+		counts, err := h.countPosts(sourceCtx, batch)
+		if err != nil {
+			return nil, status.Errorf(codes.Unavailable, "failed to reconcile counters after %d checked: %v", checked, err)
+		}
+
+		for i, seen := range batch {
+			checked++
+			if seen == counts[i] {
+				continue
+			}
+			changed, err := h.repo.CorrectPostsCounter(ctx, seen, counts[i])
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "failed to reconcile counters after %d checked: %v", checked, err)
+			}
+			if changed {
+				corrected++
+				logging.FromContext(ctx).Info().
+					Stringer("user_id", seen.UserID).
+					Int32("posts_count", seen.PostsCount).
+					Int32("posts_counted", counts[i].PostsCount).
+					Msg("corrected drifted post counter")
+			}
+		}
+
+		if len(batch) < reconcileBatchSize {
+			break
+		}
+		after = batch[len(batch)-1].UserID
+	}
+
+	logging.FromContext(ctx).Info().Int32("checked", checked).Int32("corrected", corrected).Msg("reconciled post counters")
+	return &pb.ReconcilePostCountersResponse{Checked: checked, Corrected: corrected}, nil
+}
+
+// countFollows returns the follow counters of users as counted by
+// follow-service, in the order of users
+func (h *UserHandler) countFollows(ctx context.Context, users []models.FollowCounters) ([]models.FollowCounters, error) {
+	ids := make([]string, len(users))
+	for i, u := range users {
+		ids[i] = u.UserID.String()
+	}
+
+	resp, err := h.follows.GetFollowersCounts(ctx, &followpb.GetFollowersCountsRequest{UserIds: ids})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count follows: %w", err)
+	}
+
+	byUser := make(map[string]*followpb.UserFollowCounts, len(resp.Counts))
+	for _, c := range resp.Counts {
+		byUser[c.UserId] = c
+	}
+
+	counters := make([]models.FollowCounters, len(users))
+	for i, u := range users {
+		counters[i] = models.FollowCounters{UserID: u.UserID}
+		if c, ok := byUser[ids[i]]; ok {
+			counters[i].FollowersCount = c.FollowersCount
+			counters[i].FollowingCount = c.FollowingCount
+		}
+	}
+	return counters, nil
+}
+
+// countPosts returns the post counters of users as counted by post-service,
+// in the order of users
+func (h *UserHandler) countPosts(ctx context.Context, users []models.PostsCounter) ([]models.PostsCounter, error) {
+	ids := make([]string, len(users))
+	for i, u := range users {
+		ids[i] = u.UserID.String()
+	}
+
+	resp, err := h.posts.GetPostsCounts(ctx, &postpb.GetPostsCountsRequest{UserIds: ids})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count posts: %w", err)
+	}
+
+	byUser := make(map[string]int32, len(resp.Counts))
+	for _, c := range resp.Counts {
+		byUser[c.UserId] = c.Count
+	}
+
+	counters := make([]models.PostsCounter, len(users))
+	for i, u := range users {
+		counters[i] = models.PostsCounter{UserID: u.UserID, PostsCount: byUser[ids[i]]}
+	}
+	return counters, nil
+}
+
+// forwardToken passes the caller's token on to follow-service and
+// post-service, whose counts are only given to admins
+func forwardToken(ctx context.Context) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get("authorization"); len(values) > 0 {
+		return metadata.AppendToOutgoingContext(ctx, "authorization", values[0])
+	}
+	return ctx
+}
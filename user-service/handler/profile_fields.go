@@ -0,0 +1,177 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"user-service/interceptor"
+	models "user-service/model"
+	pb "user-service/pb"
+	"user-service/rpcerror"
+)
+
+const (
+	maxDisplayNameLength = 50
+	maxLocationLength    = 100
+	maxWebsiteLength     = 200
+	maxPronounsLength    = 30
+	// minAge is the youngest age a birth date may give
+	minAge = 13
+	// birthDateLayout is the format of birth dates, e.g. 1990-04-21
+	birthDateLayout = "2006-01-02"
+)
+
+// profileFields validates the optional profile fields of an update and
+// copies them into input, trimmed of surrounding spaces. Every invalid field
+// is reported, so a form can flag them all at once.
+func profileFields(req *pb.UpdateProfileRequest, input *models.UpdateUserInput, now time.Time) []rpcerror.FieldViolation {
+	var violations []rpcerror.FieldViolation
+	check := func(field string, value *string, validate func(string) string) *string {
+		if value == nil {
+			return nil
+		}
+		trimmed := strings.TrimSpace(*value)
+		if trimmed != "" {
+			if description := validate(trimmed); description != "" {
+				violations = append(violations, rpcerror.FieldViolation{Field: field, Description: description})
+			}
+		}
+		return &trimmed
+	}
+
+	input.DisplayName = check("display_name", req.DisplayName, textRule("display_name", maxDisplayNameLength))
+	input.Location = check("location", req.Location, textRule("location", maxLocationLength))
+	input.Website = check("website", req.Website, validateWebsite)
+	input.BirthDate = check("birth_date", req.BirthDate, func(s string) string { return validateBirthDate(s, now) })
+	input.Pronouns = check("pronouns", req.Pronouns, textRule("pronouns", maxPronounsLength))
+	input.HideEmail = req.HideEmail
+	input.HideBirthDate = req.HideBirthDate
+	return violations
+}
+
+// textRule limits a free-text field to max characters on a single line
+func textRule(field string, max int) func(string) string {
+	return func(s string) string {
+		if utf8.RuneCountInString(s) > max {
+			return fmt.Sprintf("%s must be at most %d characters", field, max)
+		}
+		if strings.IndexFunc(s, unicode.IsControl) >= 0 {
+			return fmt.Sprintf("%s must not contain line breaks or control characters", field)
+		}
+		return ""
+	}
+}
+
+func validateWebsite(s string) string {
+	if len(s) > maxWebsiteLength {
+		return fmt.Sprintf("website must be at most %d characters", maxWebsiteLength)
+	}
+	u, err := url.Parse(s)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "website must be an http or https URL"
+	}
+	return ""
+}
+
+func validateBirthDate(s string, now time.Time) string {
+	date, err := time.Parse(birthDateLayout, s)
+	if err != nil {
+		return "birth_date must be a date in YYYY-MM-DD format"
+	}
+	if date.Year() < 1900 {
+		return "birth_date must be no earlier than 1900"
+	}
+	if date.After(now.AddDate(-minAge, 0, 0)) {
+		return fmt.Sprintf("users must be at least %d years old", minAge)
+	}
+	return ""
+}
+
+// ownerID is the user a call acts for: the authenticated caller, or for
+// internal calls without a token, the user_id in the request
+func ownerID(ctx context.Context, reqUserID string) (uuid.UUID, error) {
+	if id, err := interceptor.GetUserIDFromContext(ctx); err == nil {
+		reqUserID = id
+	}
+	if reqUserID == "" {
+		return uuid.Nil, rpcerror.InvalidField("user_id", "user_id is required")
+	}
+
+	userID, err := uuid.Parse(reqUserID)
+	if err != nil {
+		return uuid.Nil, rpcerror.InvalidField("user_id", "invalid user_id format")
+	}
+	return userID, nil
+}
+
+// viewerID is the user a read is made for, from its verified token, or nil
+// for anonymous reads. The requesting_user_id of requests is ignored, as any
+// caller could set it.
+func viewerID(ctx context.Context) *uuid.UUID {
+	raw, err := interceptor.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil
+	}
+	id, err := uuid.Parse(raw)
+	if err != nil {
+		return nil
+	}
+	return &id
+}
+
+// seesHiddenFields reports whether the caller may see the email and birth
+// date userID hides: only the user themself and admins may
+func seesHiddenFields(ctx context.Context, userID uuid.UUID) bool {
+	callerID, err := interceptor.GetUserIDFromContext(ctx)
+	if err != nil {
+		return false
+	}
+	return callerID == userID.String() || interceptor.HasRole(ctx, interceptor.RoleAdmin)
+}
+
+// userToProto converts a user for the caller, leaving out the email and birth
+// date when the user hides them from the caller
+func userToProto(ctx context.Context, user *models.User) *pb.User {
+	pbUser := &pb.User{
+		Id:             user.ID.String(),
+		Username:       user.Username,
+		Email:          user.Email,
+		Bio:            user.Bio,
+		CreatedAt:      timestamppb.New(user.CreatedAt),
+		UpdatedAt:      timestamppb.New(user.UpdatedAt),
+		FollowersCount: user.FollowersCount,
+		FollowingCount: user.FollowingCount,
+		PostsCount:     user.PostsCount,
+		IsPrivate:      user.IsPrivate,
+		AvatarId:       imageIDString(user.AvatarID),
+		CoverId:        imageIDString(user.CoverID),
+		DisplayName:    user.DisplayName,
+		Location:       user.Location,
+		Website:        user.Website,
+		Pronouns:       user.Pronouns,
+		HideEmail:      user.HideEmail,
+		HideBirthDate:  user.HideBirthDate,
+	}
+	if user.BirthDate != nil {
+		birthDate := user.BirthDate.Format(birthDateLayout)
+		pbUser.BirthDate = &birthDate
+	}
+
+	if (user.HideEmail || user.HideBirthDate) && !seesHiddenFields(ctx, user.ID) {
+		if user.HideEmail {
+			pbUser.Email = ""
+		}
+		if user.HideBirthDate {
+			pbUser.BirthDate = nil
+		}
+	}
+	return pbUser
+}
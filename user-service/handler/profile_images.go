@@ -0,0 +1,347 @@
+package handler
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"shared/logging"
+	"user-service/events"
+	"user-service/interceptor"
+	"user-service/media"
+	models "user-service/model"
+	pb "user-service/pb"
+	"user-service/repository"
+	"user-service/rpcerror"
+)
+
+const (
+	// imageChunkSize is the size of the chunks GetProfileImageContent streams
+	imageChunkSize = 64 << 10
+	// maxImageAttempts is how often resizing an image is tried before it is
+	// marked failed
+	maxImageAttempts = 3
+	// imageClaimTimeout is how long a worker has to resize an image it
+	// claimed before another worker may take it
+	imageClaimTimeout = 5 * time.Minute
+	// unusedImageGrace is how old an image no profile shows must be before
+	// it is swept
+	unusedImageGrace = time.Hour
+)
+
+var profileImageKinds = map[pb.ProfileImageKind]string{
+	pb.ProfileImageKind_PROFILE_IMAGE_KIND_AVATAR: models.ProfileImageAvatar,
+	pb.ProfileImageKind_PROFILE_IMAGE_KIND_COVER:  models.ProfileImageCover,
+}
+
+var profileImageShapes = map[string]media.Shape{
+	models.ProfileImageAvatar: media.AvatarShape,
+	models.ProfileImageCover:  media.CoverShape,
+}
+
+var profileImageStatuses = map[string]pb.ProfileImageStatus{
+	models.ProfileImagePending: pb.ProfileImageStatus_PROFILE_IMAGE_STATUS_PENDING,
+	models.ProfileImageReady:   pb.ProfileImageStatus_PROFILE_IMAGE_STATUS_READY,
+	models.ProfileImageFailed:  pb.ProfileImageStatus_PROFILE_IMAGE_STATUS_FAILED,
+}
+
+// UploadProfileImage stores an avatar or cover image streamed by the caller.
+// Files that are not images, or too small or too large, are rejected here;
+// the image is then cropped and resized in the background and shown on the
+// profile once ready.
+func (h *UserHandler) UploadProfileImage(stream pb.UserService_UploadProfileImageServer) error {
+	ctx := stream.Context()
+	raw, err := interceptor.GetUserIDFromContext(ctx)
+	if err != nil {
+		return status.Error(codes.Unauthenticated, "user not authenticated")
+	}
+	userID, err := uuid.Parse(raw)
+	if err != nil {
+		return status.Error(codes.Unauthenticated, "invalid user ID in token")
+	}
+
+	first, err := stream.Recv()
+	if err == io.EOF {
+		return status.Error(codes.InvalidArgument, "upload is empty")
+	}
+	if err != nil {
+		return status.Error(codes.Internal, "failed to receive upload")
+	}
+	kind, ok := profileImageKinds[first.Kind]
+	if !ok {
+		return rpcerror.InvalidField("kind", "kind must be AVATAR or COVER")
+	}
+
+	r := bufio.NewReaderSize(&uploadReader{stream: stream, buf: first.Chunk}, media.SniffLen)
+	head, err := r.Peek(media.SniffLen)
+	if err != nil && err != io.EOF {
+		return status.Error(codes.Internal, "failed to receive upload")
+	}
+	if len(head) == 0 {
+		return status.Error(codes.InvalidArgument, "upload is empty")
+	}
+
+	mimeType, err := media.DetectType(head)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	img := &models.ProfileImage{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Kind:      kind,
+		Status:    models.ProfileImagePending,
+		MimeType:  mimeType,
+		CreatedAt: time.Now(),
+	}
+
+	// Read one byte past the limit to tell an exact fit from an oversize file
+	img.SizeBytes, err = h.images.Save(img.ID, io.LimitReader(r, media.MaxUploadBytes+1))
+	if err != nil {
+		return status.Error(codes.Internal, "failed to store upload")
+	}
+	if img.SizeBytes > media.MaxUploadBytes {
+		h.deleteImageFile(img.ID)
+		return status.Errorf(codes.InvalidArgument, "profile images are limited to %d bytes", media.MaxUploadBytes)
+	}
+
+	// Catch what could never be resized while the caller is still waiting
+	if err := h.checkImageFile(img.ID, profileImageShapes[kind]); err != nil {
+		h.deleteImageFile(img.ID)
+		if errors.Is(err, media.ErrInvalidImage) {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+		return status.Error(codes.Internal, "failed to read upload")
+	}
+
+	if err := h.repo.CreateProfileImage(ctx, img); err != nil {
+		h.deleteImageFile(img.ID)
+		return status.Error(codes.Internal, "failed to record upload")
+	}
+
+	return stream.SendAndClose(profileImageToProto(img))
+}
+
+// GetProfileImageContent streams the bytes of a ready profile image
+func (h *UserHandler) GetProfileImageContent(req *pb.GetProfileImageContentRequest, stream pb.UserService_GetProfileImageContentServer) error {
+	imageID, err := uuid.Parse(req.ImageId)
+	if err != nil {
+		return rpcerror.InvalidField("image_id", "invalid image_id format")
+	}
+
+	img, err := h.repo.GetProfileImage(stream.Context(), imageID)
+	if err != nil {
+		if errors.Is(err, repository.ErrProfileImageNotFound) {
+			return status.Error(codes.NotFound, "profile image not found")
+		}
+		return status.Error(codes.Internal, "failed to load profile image")
+	}
+	// Pending images are still the file as uploaded
+	if img.Status != models.ProfileImageReady {
+		return status.Error(codes.NotFound, "profile image not found")
+	}
+
+	f, err := h.images.Open(img.ID)
+	if err != nil {
+		if errors.Is(err, media.ErrNotFound) {
+			return status.Error(codes.NotFound, "profile image not found")
+		}
+		return status.Error(codes.Internal, "failed to open profile image")
+	}
+	defer f.Close()
+
+	first := true
+	buf := make([]byte, imageChunkSize)
+	for {
+		n, err := f.Read(buf)
+		if n > 0 {
+			chunk := &pb.ProfileImageChunk{Chunk: buf[:n]}
+			if first {
+				chunk.MimeType = img.MimeType
+				chunk.SizeBytes = img.SizeBytes
+				first = false
+			}
+			if err := stream.Send(chunk); err != nil {
+				return err
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return status.Error(codes.Internal, "failed to read profile image")
+		}
+	}
+}
+
+// ProcessProfileImage crops and resizes the oldest pending image and shows
+// it on its user's profile. Images that cannot be resized, or still fail
+// after maxImageAttempts, are marked failed; other failures leave the image
+// to be claimed again once the claim times out.
+func (h *UserHandler) ProcessProfileImage(ctx context.Context) (bool, error) {
+	img, err := h.repo.ClaimProfileImage(ctx, time.Now().Add(-imageClaimTimeout))
+	if err != nil {
+		return false, err
+	}
+	if img == nil {
+		return false, nil
+	}
+
+	err = h.resizeProfileImage(ctx, img)
+	if err == nil {
+		return true, nil
+	}
+	if !errors.Is(err, media.ErrInvalidImage) && !errors.Is(err, media.ErrNotFound) && img.Attempts < maxImageAttempts {
+		return true, fmt.Errorf("failed to resize profile image %s: %w", img.ID, err)
+	}
+
+	logging.FromContext(ctx).Warn().Err(err).Stringer("image_id", img.ID).Stringer("user_id", img.UserID).Msg("profile image failed")
+	if err := h.repo.FailProfileImage(ctx, img.ID, err.Error()); err != nil {
+		return true, err
+	}
+	return true, nil
+}
+
+// resizeProfileImage replaces the uploaded file with the resized one, then
+// shows the image
+func (h *UserHandler) resizeProfileImage(ctx context.Context, img *models.ProfileImage) error {
+	f, err := h.images.Open(img.ID)
+	if err != nil {
+		return err
+	}
+	data, err := io.ReadAll(io.LimitReader(f, media.MaxUploadBytes+1))
+	f.Close()
+	if err != nil {
+		return err
+	}
+
+	var resized bytes.Buffer
+	if err := media.Resize(&resized, data, profileImageShapes[img.Kind]); err != nil {
+		return err
+	}
+	size := int64(resized.Len())
+	if _, err := h.images.Save(img.ID, &resized); err != nil {
+		return err
+	}
+
+	shown, err := h.repo.ShowProfileImage(ctx, img, media.OutputType, size)
+	if err != nil {
+		return err
+	}
+	if shown {
+		h.publishProfileImageChange(ctx, img.UserID)
+	}
+	return nil
+}
+
+// publishProfileImageChange announces a new avatar or cover image, so caches
+// of the profile are dropped
+func (h *UserHandler) publishProfileImageChange(ctx context.Context, userID uuid.UUID) {
+	user, err := h.repo.GetByID(ctx, userID)
+	if err != nil {
+		logging.FromContext(ctx).Error().Err(err).Stringer("user_id", userID).Msg("failed to load user for user updated event")
+		return
+	}
+
+	event := events.UserUpdatedEvent{
+		UserID:      user.ID,
+		Username:    user.Username,
+		DisplayName: user.DisplayName,
+		Bio:         user.Bio,
+		IsPrivate:   user.IsPrivate,
+		UpdatedAt:   user.UpdatedAt,
+	}
+	if err := h.publisher.PublishUserUpdated(ctx, event); err != nil {
+		logging.FromContext(ctx).Error().Err(err).Msg("failed to publish user updated event")
+	}
+}
+
+// SweepProfileImages deletes up to limit images, with their files, that no
+// profile has shown for unusedImageGrace: replaced and failed images, and
+// those of deleted users. It returns how many were deleted.
+func (h *UserHandler) SweepProfileImages(ctx context.Context, limit int32) (int, error) {
+	ids, err := h.repo.ListUnusedProfileImages(ctx, time.Now().Add(-unusedImageGrace), limit)
+	if err != nil {
+		return 0, err
+	}
+
+	for i, id := range ids {
+		if err := h.images.Delete(id); err != nil {
+			return i, fmt.Errorf("failed to delete profile image file %s: %w", id, err)
+		}
+		if err := h.repo.DeleteProfileImage(ctx, id); err != nil {
+			return i, err
+		}
+	}
+	return len(ids), nil
+}
+
+// checkImageFile checks the header of a stored upload against shape
+func (h *UserHandler) checkImageFile(id uuid.UUID, shape media.Shape) error {
+	f, err := h.images.Open(id)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return media.Check(f, shape)
+}
+
+// deleteImageFile removes a stored file. Failures only leak disk space, so
+// they are logged rather than returned.
+func (h *UserHandler) deleteImageFile(id uuid.UUID) {
+	if err := h.images.Delete(id); err != nil {
+		log.Printf("Failed to delete profile image %s: %v", id, err)
+	}
+}
+
+// imageIDString formats the ID of a profile image shown, nil when none is
+func imageIDString(id *uuid.UUID) *string {
+	if id == nil {
+		return nil
+	}
+	s := id.String()
+	return &s
+}
+
+func profileImageToProto(img *models.ProfileImage) *pb.ProfileImage {
+	kind := pb.ProfileImageKind_PROFILE_IMAGE_KIND_AVATAR
+	if img.Kind == models.ProfileImageCover {
+		kind = pb.ProfileImageKind_PROFILE_IMAGE_KIND_COVER
+	}
+	return &pb.ProfileImage{
+		Id:        img.ID.String(),
+		Kind:      kind,
+		Status:    profileImageStatuses[img.Status],
+		CreatedAt: timestamppb.New(img.CreatedAt),
+	}
+}
+
+// uploadReader reads the chunks of an UploadProfileImage stream as one byte
+// stream, starting with the chunk of the first message
+type uploadReader struct {
+	stream pb.UserService_UploadProfileImageServer
+	buf    []byte
+}
+
+func (u *uploadReader) Read(p []byte) (int, error) {
+	for len(u.buf) == 0 {
+		req, err := u.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+		u.buf = req.Chunk
+	}
+	n := copy(p, u.buf)
+	u.buf = u.buf[n:]
+	return n, nil
+}
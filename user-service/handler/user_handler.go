@@ -3,36 +3,51 @@ package handler
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	followpb "follow-service/pb"
+	postpb "post-service/pb"
+	"shared/logging"
+	"user-service/events"
+	"user-service/media"
 	models "user-service/model"
 	pb "user-service/pb"
+	"user-service/publisher"
 	"user-service/repository"
+	"user-service/rpcerror"
 )
 
+const maxImportBatch = 1000
+
 type UserHandler struct {
 	pb.UnimplementedUserServiceServer
-	repo repository.UserRepository
+	repo      repository.UserRepository
+	publisher *publisher.EventPublisher
+	follows   followpb.FollowServiceClient
+	posts     postpb.PostServiceClient
+	images    media.Store
 }
 
-func NewUserHandler(repo repository.UserRepository) *UserHandler {
+func NewUserHandler(repo repository.UserRepository, pub *publisher.EventPublisher, follows followpb.FollowServiceClient, posts postpb.PostServiceClient, images media.Store) *UserHandler {
 	return &UserHandler{
-		repo: repo,
+		repo:      repo,
+		publisher: pub,
+		follows:   follows,
+		posts:     posts,
+		images:    images,
 	}
 }
 
 func (h *UserHandler) GetMe(ctx context.Context, req *pb.GetMeRequest) (*pb.User, error) {
-	if req.UserId == "" {
-		return nil, status.Error(codes.InvalidArgument, "user_id is required")
-	}
-
-	userID, err := uuid.Parse(req.UserId)
+	userID, err := ownerID(ctx, req.UserId)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid user_id format")
+		return nil, err
 	}
 
 	user, err := h.repo.GetByID(ctx, userID)
@@ -40,28 +55,12 @@ func (h *UserHandler) GetMe(ctx context.Context, req *pb.GetMeRequest) (*pb.User
 		return nil, status.Error(codes.NotFound, "user not found")
 	}
 
-	pbUser := &pb.User{
-		Id:             user.ID.String(),
-		Username:       user.Username,
-		Email:          user.Email,
-		CreatedAt:      timestamppb.New(user.CreatedAt),
-		UpdatedAt:      timestamppb.New(user.UpdatedAt),
-		FollowersCount: user.FollowersCount,
-		FollowingCount: user.FollowingCount,
-		PostsCount:     user.PostsCount,
-	}
+	pbUser := userToProto(ctx, user)
 
-	if user.Bio != nil {
-		pbUser.Bio = user.Bio
-	}
-
-	if req.RequestingUserId != nil && *req.RequestingUserId != "" && *req.RequestingUserId != req.UserId {
-		requestingUserID, err := uuid.Parse(*req.RequestingUserId)
+	if viewer := viewerID(ctx); viewer != nil && *viewer != userID {
+		isFollowing, err := h.repo.CheckFollowStatus(ctx, userID, *viewer)
 		if err == nil {
-			isFollowing, err := h.repo.CheckFollowStatus(ctx, userID, requestingUserID)
-			if err == nil {
-				pbUser.IsFollowing = &isFollowing
-			}
+			pbUser.IsFollowing = &isFollowing
 		}
 	}
 
@@ -70,12 +69,12 @@ func (h *UserHandler) GetMe(ctx context.Context, req *pb.GetMeRequest) (*pb.User
 
 func (h *UserHandler) GetProfile(ctx context.Context, req *pb.GetProfileRequest) (*pb.User, error) {
 	if req.UserId == "" {
-		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+		return nil, rpcerror.InvalidField("user_id", "user_id is required")
 	}
 
 	userID, err := uuid.Parse(req.UserId)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid user_id format")
+		return nil, rpcerror.InvalidField("user_id", "invalid user_id format")
 	}
 
 	user, err := h.repo.GetByID(ctx, userID)
@@ -83,28 +82,12 @@ func (h *UserHandler) GetProfile(ctx context.Context, req *pb.GetProfileRequest)
 		return nil, status.Error(codes.NotFound, "user not found")
 	}
 
-	pbUser := &pb.User{
-		Id:             user.ID.String(),
-		Username:       user.Username,
-		Email:          user.Email,
-		CreatedAt:      timestamppb.New(user.CreatedAt),
-		UpdatedAt:      timestamppb.New(user.UpdatedAt),
-		FollowersCount: user.FollowersCount,
-		FollowingCount: user.FollowingCount,
-		PostsCount:     user.PostsCount,
-	}
-
-	if user.Bio != nil {
-		pbUser.Bio = user.Bio
-	}
+	pbUser := userToProto(ctx, user)
 
-	if req.RequestingUserId != nil && *req.RequestingUserId != "" && *req.RequestingUserId != req.UserId {
-		requestingUserID, err := uuid.Parse(*req.RequestingUserId)
+	if viewer := viewerID(ctx); viewer != nil && *viewer != userID {
+		isFollowing, err := h.repo.CheckFollowStatus(ctx, userID, *viewer)
 		if err == nil {
-			isFollowing, err := h.repo.CheckFollowStatus(ctx, userID, requestingUserID)
-			if err == nil {
-				pbUser.IsFollowing = &isFollowing
-			}
+			pbUser.IsFollowing = &isFollowing
 		}
 	}
 
@@ -112,27 +95,20 @@ func (h *UserHandler) GetProfile(ctx context.Context, req *pb.GetProfileRequest)
 }
 
 func (h *UserHandler) UpdateProfile(ctx context.Context, req *pb.UpdateProfileRequest) (*pb.User, error) {
-	if req.UserId == "" {
-		return nil, status.Error(codes.InvalidArgument, "user_id is required")
-	}
-
-	userID, err := uuid.Parse(req.UserId)
+	userID, err := ownerID(ctx, req.UserId)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid user_id format")
+		return nil, err
 	}
 
-	if req.Username == nil && req.Email == nil && req.Bio == nil {
+	if req.Username == nil && req.Email == nil && req.Bio == nil && req.IsPrivate == nil &&
+		req.DisplayName == nil && req.Location == nil && req.Website == nil && req.BirthDate == nil &&
+		req.Pronouns == nil && req.HideEmail == nil && req.HideBirthDate == nil {
 		return nil, status.Error(codes.InvalidArgument, "at least one field must be provided")
 	}
 
 	if req.Username != nil {
 		if len(*req.Username) < 3 || len(*req.Username) > 30 {
-			return nil, status.Error(codes.InvalidArgument, "username must be between 3 and 30 characters")
-		}
-
-		existingUser, err := h.repo.GetByUsername(ctx, *req.Username)
-		if err == nil && existingUser.ID != userID {
-			return nil, status.Error(codes.AlreadyExists, "username already taken")
+			return nil, rpcerror.InvalidField("username", "username must be between 3 and 30 characters")
 		}
 	}
 
@@ -144,32 +120,45 @@ func (h *UserHandler) UpdateProfile(ctx context.Context, req *pb.UpdateProfileRe
 	}
 
 	updateInput := &models.UpdateUserInput{
-		Username: req.Username,
-		Email:    req.Email,
-		Bio:      req.Bio,
+		Username:  req.Username,
+		Email:     req.Email,
+		Bio:       req.Bio,
+		IsPrivate: req.IsPrivate,
+		// Checked by Update along with the username
+		UsernamePolicy: usernamePolicy,
+	}
+	if violations := profileFields(req, updateInput, time.Now()); len(violations) > 0 {
+		return nil, rpcerror.InvalidFields(violations...)
+	}
+
+	before, err := h.repo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, "user not found")
 	}
 
 	user, err := h.repo.Update(ctx, userID, updateInput)
 	if err != nil {
+		if refused := usernameChangeError(err); refused != nil {
+			return nil, refused
+		}
 		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to update profile: %v", err))
 	}
+	h.auditProfileUpdate(ctx, before, user)
+	h.publishUsernameChanged(ctx, before, user)
 
-	pbUser := &pb.User{
-		Id:             user.ID.String(),
-		Username:       user.Username,
-		Email:          user.Email,
-		CreatedAt:      timestamppb.New(user.CreatedAt),
-		UpdatedAt:      timestamppb.New(user.UpdatedAt),
-		FollowersCount: user.FollowersCount,
-		FollowingCount: user.FollowingCount,
-		PostsCount:     user.PostsCount,
+	event := events.UserUpdatedEvent{
+		UserID:      user.ID,
+		Username:    user.Username,
+		DisplayName: user.DisplayName,
+		Bio:         user.Bio,
+		IsPrivate:   user.IsPrivate,
+		UpdatedAt:   user.UpdatedAt,
 	}
-
-	if user.Bio != nil {
-		pbUser.Bio = user.Bio
+	if err := h.publisher.PublishUserUpdated(ctx, event); err != nil {
+		logging.FromContext(ctx).Error().Err(err).Msg("failed to publish user updated event")
 	}
 
-	return pbUser, nil
+	return userToProto(ctx, user), nil
 }
 
 func (h *UserHandler) GetUsersByIds(ctx context.Context, req *pb.GetUsersByIdsRequest) (*pb.GetUsersByIdsResponse, error) {
@@ -181,7 +170,7 @@ func (h *UserHandler) GetUsersByIds(ctx context.Context, req *pb.GetUsersByIdsRe
 	for _, idStr := range req.UserIds {
 		id, err := uuid.Parse(idStr)
 		if err != nil {
-			return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("invalid user_id format: %s", idStr))
+			return nil, rpcerror.InvalidField("user_ids", fmt.Sprintf("invalid user_id format: %s", idStr))
 		}
 		userIDs = append(userIDs, id)
 	}
@@ -191,30 +180,15 @@ func (h *UserHandler) GetUsersByIds(ctx context.Context, req *pb.GetUsersByIdsRe
 		return nil, status.Error(codes.Internal, "failed to get users")
 	}
 
+	viewer := viewerID(ctx)
 	pbUsers := make([]*pb.User, 0, len(users))
 	for _, user := range users {
-		pbUser := &pb.User{
-			Id:             user.ID.String(),
-			Username:       user.Username,
-			Email:          user.Email,
-			CreatedAt:      timestamppb.New(user.CreatedAt),
-			UpdatedAt:      timestamppb.New(user.UpdatedAt),
-			FollowersCount: user.FollowersCount,
-			FollowingCount: user.FollowingCount,
-			PostsCount:     user.PostsCount,
-		}
+		pbUser := userToProto(ctx, user)
 
-		if user.Bio != nil {
-			pbUser.Bio = user.Bio
-		}
-
-		if req.RequestingUserId != nil && *req.RequestingUserId != "" {
-			requestingUserID, err := uuid.Parse(*req.RequestingUserId)
-			if err == nil && requestingUserID != user.ID {
-				isFollowing, err := h.repo.CheckFollowStatus(ctx, user.ID, requestingUserID)
-				if err == nil {
-					pbUser.IsFollowing = &isFollowing
-				}
+		if viewer != nil && *viewer != user.ID {
+			isFollowing, err := h.repo.CheckFollowStatus(ctx, user.ID, *viewer)
+			if err == nil {
+				pbUser.IsFollowing = &isFollowing
 			}
 		}
 
@@ -224,44 +198,162 @@ func (h *UserHandler) GetUsersByIds(ctx context.Context, req *pb.GetUsersByIdsRe
 	return &pb.GetUsersByIdsResponse{Users: pbUsers}, nil
 }
 
-func (h *UserHandler) IncrementPostsCount(ctx context.Context, req *pb.IncrementPostsCountRequest) (*pb.Response, error) {
-	if req.UserId == "" {
-		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+// maxUsernameLookup bounds GetUsersByUsernames; posts and comments mention
+// far fewer users than this
+const maxUsernameLookup = 100
+
+// GetUsersByUsernames resolves usernames to users, for example to turn
+// @mentions into user ids. A username a user has changed still finds them,
+// as a former username, until its reservation ends, so older mentions and
+// profile links keep working. Unknown usernames are left out of the
+// response.
+func (h *UserHandler) GetUsersByUsernames(ctx context.Context, req *pb.GetUsersByUsernamesRequest) (*pb.GetUsersByUsernamesResponse, error) {
+	if len(req.Usernames) == 0 {
+		return &pb.GetUsersByUsernamesResponse{Users: []*pb.User{}}, nil
+	}
+	if len(req.Usernames) > maxUsernameLookup {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d usernames can be looked up at once", maxUsernameLookup)
+	}
+
+	users, err := h.repo.GetByUsernames(ctx, req.Usernames)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to get users")
 	}
 
+	pbUsers := make([]*pb.User, 0, len(users))
+	found := make(map[string]bool, len(users))
+	for _, user := range users {
+		pbUsers = append(pbUsers, publicUserToProto(ctx, user))
+		found[strings.ToLower(user.Username)] = true
+	}
+
+	// Usernames no user has now may be reserved former usernames
+	var missing []string
+	for _, username := range req.Usernames {
+		if !found[strings.ToLower(username)] {
+			missing = append(missing, username)
+		}
+	}
+	formers, err := h.repo.GetByFormerUsernames(ctx, missing)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to get users")
+	}
+
+	pbFormers := make([]*pb.FormerUsername, 0, len(formers))
+	for _, former := range formers {
+		pbFormers = append(pbFormers, &pb.FormerUsername{
+			Username: former.Username,
+			User:     publicUserToProto(ctx, &former.User),
+		})
+	}
+
+	return &pb.GetUsersByUsernamesResponse{Users: pbUsers, FormerUsernames: pbFormers}, nil
+}
+
+// publicUserToProto converts a user found by username, which never shows
+// the email or birth date
+func publicUserToProto(ctx context.Context, user *models.User) *pb.User {
+	pbUser := userToProto(ctx, user)
+	pbUser.Email = ""
+	pbUser.BirthDate = nil
+	return pbUser
+}
+
+func (h *UserHandler) SetUserCounters(ctx context.Context, req *pb.SetUserCountersRequest) (*pb.Response, error) {
 	userID, err := uuid.Parse(req.UserId)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid user_id format")
+		return nil, rpcerror.InvalidField("user_id", "invalid user_id format")
 	}
 
-	err = h.repo.IncrementPostsCount(ctx, userID)
+	if req.FollowersCount < 0 || req.FollowingCount < 0 || req.PostsCount < 0 {
+		return nil, status.Error(codes.InvalidArgument, "counters must not be negative")
+	}
+
+	err = h.repo.SetCounters(ctx, userID, req.FollowersCount, req.FollowingCount, req.PostsCount)
 	if err != nil {
-		return nil, status.Error(codes.Internal, "failed to increment posts count")
+		return nil, status.Error(codes.Internal, "failed to set user counters")
 	}
 
 	return &pb.Response{
 		Success: true,
-		Message: "posts count incremented successfully",
+		Message: "user counters updated successfully",
 	}, nil
 }
 
-func (h *UserHandler) DecrementPostsCount(ctx context.Context, req *pb.DecrementPostsCountRequest) (*pb.Response, error) {
-	if req.UserId == "" {
-		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+// ImportProfiles stores profiles migrated from another platform under the
+// ids already assigned to them by auth-service
+func (h *UserHandler) ImportProfiles(ctx context.Context, req *pb.ImportProfilesRequest) (*pb.ImportProfilesResponse, error) {
+	if len(req.Profiles) > maxImportBatch {
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("at most %d profiles can be imported per request", maxImportBatch))
 	}
 
-	userID, err := uuid.Parse(req.UserId)
-	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid user_id format")
+	users := make([]models.User, 0, len(req.Profiles))
+	for _, p := range req.Profiles {
+		userID, err := uuid.Parse(p.Id)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("invalid profile id %q", p.Id))
+		}
+		if p.Username == "" || p.Email == "" || p.CreatedAt == nil {
+			return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("username, email and created_at are required for profile %s", p.Id))
+		}
+
+		users = append(users, models.User{
+			ID:        userID,
+			Username:  p.Username,
+			Email:     p.Email,
+			Bio:       p.Bio,
+			CreatedAt: p.CreatedAt.AsTime(),
+		})
 	}
 
-	err = h.repo.DecrementPostsCount(ctx, userID)
+	created, err := h.repo.ImportProfiles(ctx, users)
 	if err != nil {
-		return nil, status.Error(codes.Internal, "failed to decrement posts count")
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to import profiles: %v", err))
 	}
 
-	return &pb.Response{
-		Success: true,
-		Message: "posts count decremented successfully",
+	return &pb.ImportProfilesResponse{
+		Created: created,
+		Skipped: int32(len(users)) - created,
 	}, nil
 }
+
+func (h *UserHandler) ListPublicProfiles(ctx context.Context, req *pb.ListPublicProfilesRequest) (*pb.ListPublicProfilesResponse, error) {
+	limit := req.Limit
+	if limit <= 0 {
+		limit = 1000
+	}
+	if limit > 10000 {
+		limit = 10000
+	}
+
+	var afterID *uuid.UUID
+	if req.AfterId != nil && *req.AfterId != "" {
+		id, err := uuid.Parse(*req.AfterId)
+		if err != nil {
+			return nil, rpcerror.InvalidField("after_id", "invalid after_id format")
+		}
+		afterID = &id
+	}
+
+	users, err := h.repo.ListPublicProfiles(ctx, afterID, limit)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to list public profiles")
+	}
+
+	profiles := make([]*pb.PublicProfile, 0, len(users))
+	for _, user := range users {
+		profiles = append(profiles, &pb.PublicProfile{
+			Id:        user.ID.String(),
+			Username:  user.Username,
+			UpdatedAt: timestamppb.New(user.UpdatedAt),
+		})
+	}
+
+	resp := &pb.ListPublicProfilesResponse{Profiles: profiles}
+	if int32(len(users)) == limit {
+		next := users[len(users)-1].ID.String()
+		resp.NextAfterId = &next
+	}
+
+	return resp, nil
+}
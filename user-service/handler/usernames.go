@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"shared/logging"
+	"user-service/events"
+	models "user-service/model"
+	"user-service/repository"
+	"user-service/rpcerror"
+)
+
+// usernamePolicy lets users change their username once every 30 days, and
+// keeps the username they gave up theirs for 14 days, during which mentions
+// and profile links using it still lead to them
+var usernamePolicy = models.UsernamePolicy{
+	Cooldown:    30 * 24 * time.Hour,
+	Reservation: 14 * 24 * time.Hour,
+}
+
+// Reasons of the ErrorInfo detail of a refused username change
+const (
+	ReasonUsernameReserved       = "USERNAME_RESERVED"
+	ReasonUsernameChangeCooldown = "USERNAME_CHANGE_COOLDOWN"
+)
+
+// usernameChangeError turns the errors of a refused username change into
+// gRPC errors, and returns nil for any other error
+func usernameChangeError(err error) error {
+	var cooldown *repository.UsernameCooldownError
+	switch {
+	case errors.Is(err, repository.ErrUsernameTaken):
+		return status.Error(codes.AlreadyExists, "username already taken")
+	case errors.Is(err, repository.ErrUsernameReserved):
+		return rpcerror.New(codes.AlreadyExists, ReasonUsernameReserved, "username is reserved by its former owner")
+	case errors.As(err, &cooldown):
+		return cooldownError(cooldown.Until)
+	}
+	return nil
+}
+
+// cooldownError is a FailedPrecondition error carrying an ErrorInfo with the
+// reason and a RetryInfo with the time left, for clients to show when the
+// username may be changed again
+func cooldownError(until time.Time) error {
+	// Round up, so clients retrying when told are not refused again
+	retryAfter := time.Duration(math.Ceil(time.Until(until).Seconds())) * time.Second
+
+	msg := fmt.Sprintf("username can be changed again after %s", until.UTC().Format(time.RFC3339))
+	st, err := status.New(codes.FailedPrecondition, msg).WithDetails(
+		&errdetails.ErrorInfo{Reason: ReasonUsernameChangeCooldown, Domain: rpcerror.Domain},
+		&errdetails.RetryInfo{RetryDelay: durationpb.New(retryAfter)},
+	)
+	if err != nil {
+		return status.Error(codes.FailedPrecondition, msg)
+	}
+	return st.Err()
+}
+
+// publishUsernameChanged tells auth-service about a changed username. A
+// change of case only reserves nothing.
+func (h *UserHandler) publishUsernameChanged(ctx context.Context, before, after *models.User) {
+	if before.Username == after.Username {
+		return
+	}
+
+	event := events.UsernameChangedEvent{
+		UserID:      after.ID,
+		OldUsername: before.Username,
+		NewUsername: after.Username,
+		ChangedAt:   after.UpdatedAt,
+	}
+	if !strings.EqualFold(before.Username, after.Username) {
+		// The reservation starts with the transaction that set UpdatedAt
+		reservedUntil := after.UpdatedAt.Add(usernamePolicy.Reservation)
+		event.ReservedUntil = &reservedUntil
+	}
+	if err := h.publisher.PublishUsernameChanged(ctx, event); err != nil {
+		logging.FromContext(ctx).Error().Err(err).Msg("failed to publish username changed event")
+	}
+}
@@ -0,0 +1,90 @@
+// Package imaging resizes uploaded profile images in the background and
+// sweeps images no profile shows. Every replica runs a worker; an image is
+// claimed before it is resized, so each is resized once.
+package imaging
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+const (
+	// pollInterval is how often pending images are looked for once none
+	// are left
+	pollInterval = 2 * time.Second
+	// sweepInterval is how often unused images are swept
+	sweepInterval = 10 * time.Minute
+	// sweepBatchSize is how many unused images are swept per query
+	sweepBatchSize = 100
+)
+
+// Processor resizes and sweeps profile images
+type Processor interface {
+	// ProcessProfileImage resizes the oldest pending image, reporting
+	// whether there was one
+	ProcessProfileImage(ctx context.Context) (bool, error)
+	SweepProfileImages(ctx context.Context, limit int32) (int, error)
+}
+
+type Worker struct {
+	processor Processor
+}
+
+func New(processor Processor) *Worker {
+	return &Worker{processor: processor}
+}
+
+// Run resizes pending images as they come and sweeps unused ones until ctx
+// is cancelled
+func (w *Worker) Run(ctx context.Context) {
+	poll := time.NewTicker(pollInterval)
+	defer poll.Stop()
+	sweep := time.NewTicker(sweepInterval)
+	defer sweep.Stop()
+
+	for {
+		w.process(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-poll.C:
+		case <-sweep.C:
+			w.sweep(ctx)
+		}
+	}
+}
+
+// process resizes pending images one by one until none is left
+func (w *Worker) process(ctx context.Context) {
+	for ctx.Err() == nil {
+		found, err := w.processor.ProcessProfileImage(ctx)
+		if err != nil {
+			log.Printf("Failed to process profile image: %v", err)
+			return
+		}
+		if !found {
+			return
+		}
+	}
+}
+
+// sweep deletes unused images batch by batch until a batch comes up short
+func (w *Worker) sweep(ctx context.Context) {
+	total := 0
+	for ctx.Err() == nil {
+		swept, err := w.processor.SweepProfileImages(ctx, sweepBatchSize)
+		total += swept
+		if err != nil {
+			log.Printf("Failed to sweep profile images: %v", err)
+			break
+		}
+		if swept < sweepBatchSize {
+			break
+		}
+	}
+	if total > 0 {
+		log.Printf("Swept %d unused profile images", total)
+	}
+}
@@ -0,0 +1,107 @@
+// Package media validates uploaded profile images, crops and resizes them,
+// and keeps them in a store
+package media
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/google/uuid"
+)
+
+// SniffLen is how many leading bytes DetectType needs
+const SniffLen = 512
+
+// MaxUploadBytes is the largest profile image accepted
+const MaxUploadBytes = 10 << 20
+
+// allowedTypes are the content types accepted, those the image package can
+// decode for resizing
+var allowedTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/gif":  true,
+}
+
+// ErrNotFound is returned by Store.Open for an unknown file
+var ErrNotFound = errors.New("image not found")
+
+// DetectType returns the content type of a file from its first bytes. The
+// client's claimed type is never trusted, so a script renamed to .png is
+// rejected.
+func DetectType(head []byte) (string, error) {
+	mimeType := http.DetectContentType(head)
+	if !allowedTypes[mimeType] {
+		return "", fmt.Errorf("unsupported image type %s; use JPEG, PNG or GIF", mimeType)
+	}
+	return mimeType, nil
+}
+
+// Store keeps the bytes of profile images, keyed by image ID
+type Store interface {
+	// Save writes r under id, replacing any file there, and returns the
+	// number of bytes written
+	Save(id uuid.UUID, r io.Reader) (int64, error)
+	Open(id uuid.UUID) (io.ReadCloser, error)
+	Delete(id uuid.UUID) error
+}
+
+type fileStore struct {
+	dir string
+}
+
+// NewFileStore stores files in dir, which is created if missing. Replicas
+// must share the directory, e.g. through a common volume.
+func NewFileStore(dir string) (Store, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create profile image directory: %w", err)
+	}
+	return &fileStore{dir: dir}, nil
+}
+
+func (s *fileStore) path(id uuid.UUID) string {
+	return filepath.Join(s.dir, id.String())
+}
+
+// Save writes to a temporary file first so a failed write never leaves a
+// partial file under the final name, and a replaced file is swapped whole
+func (s *fileStore) Save(id uuid.UUID, r io.Reader) (int64, error) {
+	tmp, err := os.CreateTemp(s.dir, ".upload-*")
+	if err != nil {
+		return 0, err
+	}
+	defer os.Remove(tmp.Name())
+
+	n, err := io.Copy(tmp, r)
+	if err != nil {
+		tmp.Close()
+		return 0, err
+	}
+	if err := tmp.Close(); err != nil {
+		return 0, err
+	}
+	if err := os.Rename(tmp.Name(), s.path(id)); err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+
+func (s *fileStore) Open(id uuid.UUID) (io.ReadCloser, error) {
+	f, err := os.Open(s.path(id))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	return f, err
+}
+
+func (s *fileStore) Delete(id uuid.UUID) error {
+	err := os.Remove(s.path(id))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
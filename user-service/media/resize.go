@@ -0,0 +1,127 @@
+package media
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/gif"
+	"image/jpeg"
+	_ "image/png"
+	"io"
+)
+
+// Shape is what a kind of profile image is cropped and resized to
+type Shape struct {
+	// Width and Height are the largest size kept, and give the aspect ratio
+	// images are cropped to. Smaller images are not enlarged.
+	Width, Height int
+	// MinWidth and MinHeight are the smallest size accepted
+	MinWidth, MinHeight int
+}
+
+var (
+	AvatarShape = Shape{Width: 400, Height: 400, MinWidth: 100, MinHeight: 100}
+	CoverShape  = Shape{Width: 1500, Height: 500, MinWidth: 600, MinHeight: 200}
+)
+
+// OutputType is the content type of resized images
+const OutputType = "image/jpeg"
+
+const jpegQuality = 85
+
+// maxPixels caps the decoded size of an image, so a small file claiming huge
+// dimensions cannot exhaust memory
+const maxPixels = 40_000_000
+
+// ErrInvalidImage is wrapped by the errors of images that are rejected
+var ErrInvalidImage = errors.New("invalid image")
+
+// Check reads the header of an image and rejects it when it cannot be
+// decoded or its dimensions do not suit shape
+func Check(r io.Reader, shape Shape) error {
+	cfg, _, err := image.DecodeConfig(r)
+	if err != nil {
+		return fmt.Errorf("%w: the file is not a readable image", ErrInvalidImage)
+	}
+	if cfg.Width < shape.MinWidth || cfg.Height < shape.MinHeight {
+		return fmt.Errorf("%w: the image must be at least %dx%d pixels", ErrInvalidImage, shape.MinWidth, shape.MinHeight)
+	}
+	if cfg.Width*cfg.Height > maxPixels {
+		return fmt.Errorf("%w: the image must be at most %d megapixels", ErrInvalidImage, maxPixels/1_000_000)
+	}
+	return nil
+}
+
+// Resize crops an image around its centre to the aspect ratio of shape,
+// scales it down to fit and writes it to w as a JPEG. Animated GIFs keep
+// their first frame.
+func Resize(w io.Writer, data []byte, shape Shape) error {
+	if err := Check(bytes.NewReader(data), shape); err != nil {
+		return err
+	}
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidImage, err)
+	}
+
+	crop := cropRect(src.Bounds(), shape)
+	width := min(shape.Width, crop.Dx())
+	height := max(width*shape.Height/shape.Width, 1)
+
+	return jpeg.Encode(w, scale(src, crop, width, height), &jpeg.Options{Quality: jpegQuality})
+}
+
+// cropRect is the largest centred part of bounds with the aspect ratio of
+// shape
+func cropRect(bounds image.Rectangle, shape Shape) image.Rectangle {
+	w, h := bounds.Dx(), bounds.Dy()
+	if w*shape.Height > h*shape.Width {
+		w = h * shape.Width / shape.Height
+	} else {
+		h = w * shape.Height / shape.Width
+	}
+	x := bounds.Min.X + (bounds.Dx()-w)/2
+	y := bounds.Min.Y + (bounds.Dy()-h)/2
+	return image.Rect(x, y, x+w, y+h)
+}
+
+// scale shrinks the crop of src to width x height by averaging the source
+// pixels each destination pixel covers. Transparent areas are laid over
+// white, as JPEG has no alpha channel.
+func scale(src image.Image, crop image.Rectangle, width, height int) *image.RGBA {
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	cw, ch := crop.Dx(), crop.Dy()
+
+	for y := 0; y < height; y++ {
+		y0 := crop.Min.Y + y*ch/height
+		y1 := max(crop.Min.Y+(y+1)*ch/height, y0+1)
+		for x := 0; x < width; x++ {
+			x0 := crop.Min.X + x*cw/width
+			x1 := max(crop.Min.X+(x+1)*cw/width, x0+1)
+
+			// Sums of 16-bit premultiplied components
+			var r, g, b, a uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					pr, pg, pb, pa := src.At(sx, sy).RGBA()
+					r += uint64(pr)
+					g += uint64(pg)
+					b += uint64(pb)
+					a += uint64(pa)
+				}
+			}
+
+			n := uint64((x1 - x0) * (y1 - y0))
+			white := 0xffff*n - a
+			dst.SetRGBA(x, y, color.RGBA{
+				R: uint8((r + white) / n >> 8),
+				G: uint8((g + white) / n >> 8),
+				B: uint8((b + white) / n >> 8),
+				A: 0xff,
+			})
+		}
+	}
+	return dst
+}
@@ -18,6 +18,7 @@ CREATE TABLE IF NOT EXISTS user_service_users (
     followers_count INTEGER NOT NULL DEFAULT 0,
     following_count INTEGER NOT NULL DEFAULT 0,
     posts_count INTEGER NOT NULL DEFAULT 0,
+    is_private BOOLEAN NOT NULL DEFAULT FALSE,
     CONSTRAINT user_service_username_not_empty CHECK (username <> ''),
     CONSTRAINT user_service_email_not_empty CHECK (email <> ''),
     CONSTRAINT user_service_followers_count_positive CHECK (followers_count >= 0),
@@ -25,6 +26,9 @@ CREATE TABLE IF NOT EXISTS user_service_users (
     CONSTRAINT user_service_posts_count_positive CHECK (posts_count >= 0)
 );
 
+-- Added after the table was first created
+ALTER TABLE user_service_users ADD COLUMN IF NOT EXISTS is_private BOOLEAN NOT NULL DEFAULT FALSE;
+
 -- ========================================
 -- Indexes
 -- ========================================
@@ -43,7 +47,7 @@ BEGIN
 END;
 $$ LANGUAGE plpgsql;
 
-CREATE TRIGGER trigger_user_service_users_updated_at
+CREATE OR REPLACE TRIGGER trigger_user_service_users_updated_at
     BEFORE UPDATE ON user_service_users
     FOR EACH ROW
     EXECUTE FUNCTION user_service_update_updated_at_column();
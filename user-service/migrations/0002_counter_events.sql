@@ -0,0 +1,12 @@
+-- ========================================
+-- Counter Events
+-- ========================================
+-- Follow events already applied to the counters of users, so a redelivered
+-- event is counted once. Rows outlive the event stream's retention and are
+-- then pruned.
+CREATE TABLE IF NOT EXISTS user_service_counter_events (
+    event_id VARCHAR(255) PRIMARY KEY,
+    applied_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_counter_events_applied_at ON user_service_counter_events(applied_at);
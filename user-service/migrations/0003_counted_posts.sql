@@ -0,0 +1,14 @@
+-- ========================================
+-- Counted Posts
+-- ========================================
+-- Posts counted into posts_count, so each post is counted once however often
+-- its events are delivered or replayed. A post deleted before it was counted
+-- is kept as a tombstone, so a late post.created does not count it; deleted
+-- posts are pruned once their events can no longer be delivered.
+CREATE TABLE IF NOT EXISTS user_service_counted_posts (
+    post_id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_counted_posts_deleted_at ON user_service_counted_posts(deleted_at) WHERE deleted_at IS NOT NULL;
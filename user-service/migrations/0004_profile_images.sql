@@ -0,0 +1,28 @@
+-- ========================================
+-- Profile Images
+-- ========================================
+-- Avatars and cover images. An upload is stored as received and PENDING
+-- until a worker crops and resizes it; the profile then points at it through
+-- avatar_id or cover_id. Images no profile points at are swept, with their
+-- files, once they are an hour old.
+CREATE TABLE IF NOT EXISTS user_service_profile_images (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    kind VARCHAR(10) NOT NULL,
+    status VARCHAR(10) NOT NULL DEFAULT 'PENDING',
+    mime_type VARCHAR(50) NOT NULL,
+    size_bytes BIGINT NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    claimed_at TIMESTAMP WITH TIME ZONE,
+    error TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    processed_at TIMESTAMP WITH TIME ZONE,
+    CONSTRAINT user_service_profile_image_kind CHECK (kind IN ('AVATAR', 'COVER')),
+    CONSTRAINT user_service_profile_image_status CHECK (status IN ('PENDING', 'READY', 'FAILED'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_profile_images_pending ON user_service_profile_images(created_at) WHERE status = 'PENDING';
+CREATE INDEX IF NOT EXISTS idx_user_profile_images_created_at ON user_service_profile_images(created_at) WHERE status <> 'PENDING';
+
+ALTER TABLE user_service_users ADD COLUMN IF NOT EXISTS avatar_id UUID;
+ALTER TABLE user_service_users ADD COLUMN IF NOT EXISTS cover_id UUID;
@@ -0,0 +1,12 @@
+-- ========================================
+-- Profile Fields
+-- ========================================
+-- Optional profile details, and flags hiding the email and birth date from
+-- everyone but the user
+ALTER TABLE user_service_users ADD COLUMN IF NOT EXISTS display_name VARCHAR(50);
+ALTER TABLE user_service_users ADD COLUMN IF NOT EXISTS location VARCHAR(100);
+ALTER TABLE user_service_users ADD COLUMN IF NOT EXISTS website VARCHAR(200);
+ALTER TABLE user_service_users ADD COLUMN IF NOT EXISTS birth_date DATE;
+ALTER TABLE user_service_users ADD COLUMN IF NOT EXISTS pronouns VARCHAR(30);
+ALTER TABLE user_service_users ADD COLUMN IF NOT EXISTS hide_email BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE user_service_users ADD COLUMN IF NOT EXISTS hide_birth_date BOOLEAN NOT NULL DEFAULT FALSE;
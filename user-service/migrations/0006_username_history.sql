@@ -0,0 +1,17 @@
+-- ========================================
+-- Username History
+-- ========================================
+-- The usernames users had before changing them. A former username stays
+-- reserved for its user until reserved_until, so nobody else can take it
+-- while mentions and profile links still lead to the user through it, and
+-- the latest change sets when the user may change their username again.
+CREATE TABLE IF NOT EXISTS user_service_username_history (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES user_service_users(id) ON DELETE CASCADE,
+    username VARCHAR(255) NOT NULL,
+    changed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    reserved_until TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_username_history_username ON user_service_username_history(LOWER(username), reserved_until);
+CREATE INDEX IF NOT EXISTS idx_user_username_history_user_id ON user_service_username_history(user_id, changed_at DESC);
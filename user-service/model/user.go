@@ -7,15 +7,25 @@ import (
 )
 
 type User struct {
-	ID             uuid.UUID `json:"id" db:"id"`
-	Username       string    `json:"username" db:"username"`
-	Email          string    `json:"email" db:"email"`
-	Bio            *string   `json:"bio,omitempty" db:"bio"`
-	CreatedAt      time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt      time.Time `json:"updated_at" db:"updated_at"`
-	FollowersCount int32     `json:"followers_count" db:"followers_count"`
-	FollowingCount int32     `json:"following_count" db:"following_count"`
-	PostsCount     int32     `json:"posts_count" db:"posts_count"`
+	ID             uuid.UUID  `json:"id" db:"id"`
+	Username       string     `json:"username" db:"username"`
+	Email          string     `json:"email" db:"email"`
+	Bio            *string    `json:"bio,omitempty" db:"bio"`
+	CreatedAt      time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at" db:"updated_at"`
+	FollowersCount int32      `json:"followers_count" db:"followers_count"`
+	FollowingCount int32      `json:"following_count" db:"following_count"`
+	PostsCount     int32      `json:"posts_count" db:"posts_count"`
+	IsPrivate      bool       `json:"is_private" db:"is_private"`
+	AvatarID       *uuid.UUID `json:"avatar_id,omitempty" db:"avatar_id"`
+	CoverID        *uuid.UUID `json:"cover_id,omitempty" db:"cover_id"`
+	DisplayName    *string    `json:"display_name,omitempty" db:"display_name"`
+	Location       *string    `json:"location,omitempty" db:"location"`
+	Website        *string    `json:"website,omitempty" db:"website"`
+	BirthDate      *time.Time `json:"birth_date,omitempty" db:"birth_date"`
+	Pronouns       *string    `json:"pronouns,omitempty" db:"pronouns"`
+	HideEmail      bool       `json:"hide_email" db:"hide_email"`
+	HideBirthDate  bool       `json:"hide_birth_date" db:"hide_birth_date"`
 }
 
 type UserProfile struct {
@@ -23,8 +33,78 @@ type UserProfile struct {
 	IsFollowing *bool `json:"is_following,omitempty"`
 }
 
+// UpdateUserInput holds the fields to change. An empty DisplayName,
+// Location, Website, BirthDate or Pronouns clears the field.
 type UpdateUserInput struct {
-	Username *string `json:"username,omitempty"`
-	Email    *string `json:"email,omitempty"`
-	Bio      *string `json:"bio,omitempty"`
+	Username      *string `json:"username,omitempty"`
+	Email         *string `json:"email,omitempty"`
+	Bio           *string `json:"bio,omitempty"`
+	IsPrivate     *bool   `json:"is_private,omitempty"`
+	DisplayName   *string `json:"display_name,omitempty"`
+	Location      *string `json:"location,omitempty"`
+	Website       *string `json:"website,omitempty"`
+	BirthDate     *string `json:"birth_date,omitempty"` // YYYY-MM-DD
+	Pronouns      *string `json:"pronouns,omitempty"`
+	HideEmail     *bool   `json:"hide_email,omitempty"`
+	HideBirthDate *bool   `json:"hide_birth_date,omitempty"`
+
+	// UsernamePolicy applies when Username changes
+	UsernamePolicy UsernamePolicy `json:"-"`
+}
+
+// UsernamePolicy limits how often a user may change their username, and how
+// long a former username stays reserved for them. Changes of case only are
+// not limited, nor is the former username reserved.
+type UsernamePolicy struct {
+	Cooldown    time.Duration
+	Reservation time.Duration
+}
+
+// FormerUsername is a user found by a username they have changed, while it
+// is still reserved for them
+type FormerUsername struct {
+	Username string `db:"former_username"`
+	User
+}
+
+// FollowCounters are the follower and following counters of a user
+type FollowCounters struct {
+	UserID         uuid.UUID `db:"id"`
+	FollowersCount int32     `db:"followers_count"`
+	FollowingCount int32     `db:"following_count"`
+}
+
+// PostsCounter is the post counter of a user
+type PostsCounter struct {
+	UserID     uuid.UUID `db:"id"`
+	PostsCount int32     `db:"posts_count"`
+}
+
+// Kinds of profile image
+const (
+	ProfileImageAvatar = "AVATAR"
+	ProfileImageCover  = "COVER"
+)
+
+// Statuses of a profile image
+const (
+	ProfileImagePending = "PENDING"
+	ProfileImageReady   = "READY"
+	ProfileImageFailed  = "FAILED"
+)
+
+// ProfileImage is an uploaded avatar or cover image. MimeType and SizeBytes
+// describe the file as uploaded until it is ready, and the resized file
+// after.
+type ProfileImage struct {
+	ID          uuid.UUID  `json:"id" db:"id"`
+	UserID      uuid.UUID  `json:"user_id" db:"user_id"`
+	Kind        string     `json:"kind" db:"kind"`
+	Status      string     `json:"status" db:"status"`
+	MimeType    string     `json:"mime_type" db:"mime_type"`
+	SizeBytes   int64      `json:"size_bytes" db:"size_bytes"`
+	Attempts    int32      `json:"attempts" db:"attempts"`
+	Error       *string    `json:"error,omitempty" db:"error"`
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+	ProcessedAt *time.Time `json:"processed_at,omitempty" db:"processed_at"`
 }
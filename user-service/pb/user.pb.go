@@ -22,10 +22,112 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type ProfileImageKind int32
+
+const (
+	ProfileImageKind_PROFILE_IMAGE_KIND_UNSPECIFIED ProfileImageKind = 0
+	ProfileImageKind_PROFILE_IMAGE_KIND_AVATAR      ProfileImageKind = 1 // Cropped square, at most 400x400
+	ProfileImageKind_PROFILE_IMAGE_KIND_COVER       ProfileImageKind = 2 // Cropped to 3:1, at most 1500x500
+)
+
+// Enum value maps for ProfileImageKind.
+var (
+	ProfileImageKind_name = map[int32]string{
+		0: "PROFILE_IMAGE_KIND_UNSPECIFIED",
+		1: "PROFILE_IMAGE_KIND_AVATAR",
+		2: "PROFILE_IMAGE_KIND_COVER",
+	}
+	ProfileImageKind_value = map[string]int32{
+		"PROFILE_IMAGE_KIND_UNSPECIFIED": 0,
+		"PROFILE_IMAGE_KIND_AVATAR":      1,
+		"PROFILE_IMAGE_KIND_COVER":       2,
+	}
+)
+
+func (x ProfileImageKind) Enum() *ProfileImageKind {
+	p := new(ProfileImageKind)
+	*p = x
+	return p
+}
+
+func (x ProfileImageKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ProfileImageKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_user_proto_enumTypes[0].Descriptor()
+}
+
+func (ProfileImageKind) Type() protoreflect.EnumType {
+	return &file_proto_user_proto_enumTypes[0]
+}
+
+func (x ProfileImageKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ProfileImageKind.Descriptor instead.
+func (ProfileImageKind) EnumDescriptor() ([]byte, []int) {
+	return file_proto_user_proto_rawDescGZIP(), []int{0}
+}
+
+type ProfileImageStatus int32
+
+const (
+	ProfileImageStatus_PROFILE_IMAGE_STATUS_UNSPECIFIED ProfileImageStatus = 0
+	ProfileImageStatus_PROFILE_IMAGE_STATUS_PENDING     ProfileImageStatus = 1 // Waiting to be resized
+	ProfileImageStatus_PROFILE_IMAGE_STATUS_READY       ProfileImageStatus = 2 // Shown on the profile
+	ProfileImageStatus_PROFILE_IMAGE_STATUS_FAILED      ProfileImageStatus = 3
+)
+
+// Enum value maps for ProfileImageStatus.
+var (
+	ProfileImageStatus_name = map[int32]string{
+		0: "PROFILE_IMAGE_STATUS_UNSPECIFIED",
+		1: "PROFILE_IMAGE_STATUS_PENDING",
+		2: "PROFILE_IMAGE_STATUS_READY",
+		3: "PROFILE_IMAGE_STATUS_FAILED",
+	}
+	ProfileImageStatus_value = map[string]int32{
+		"PROFILE_IMAGE_STATUS_UNSPECIFIED": 0,
+		"PROFILE_IMAGE_STATUS_PENDING":     1,
+		"PROFILE_IMAGE_STATUS_READY":       2,
+		"PROFILE_IMAGE_STATUS_FAILED":      3,
+	}
+)
+
+func (x ProfileImageStatus) Enum() *ProfileImageStatus {
+	p := new(ProfileImageStatus)
+	*p = x
+	return p
+}
+
+func (x ProfileImageStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ProfileImageStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_user_proto_enumTypes[1].Descriptor()
+}
+
+func (ProfileImageStatus) Type() protoreflect.EnumType {
+	return &file_proto_user_proto_enumTypes[1]
+}
+
+func (x ProfileImageStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ProfileImageStatus.Descriptor instead.
+func (ProfileImageStatus) EnumDescriptor() ([]byte, []int) {
+	return file_proto_user_proto_rawDescGZIP(), []int{1}
+}
+
 type GetMeRequest struct {
-	state            protoimpl.MessageState `protogen:"open.v1"`
-	UserId           string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	RequestingUserId *string                `protobuf:"bytes,2,opt,name=requesting_user_id,json=requestingUserId,proto3,oneof" json:"requesting_user_id,omitempty"` // For isFollowing field
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // Ignored for callers with a token; defaults to the caller
+	// Ignored: the viewer is the user of the request's token, if any
+	RequestingUserId *string `protobuf:"bytes,2,opt,name=requesting_user_id,json=requestingUserId,proto3,oneof" json:"requesting_user_id,omitempty"`
 	unknownFields    protoimpl.UnknownFields
 	sizeCache        protoimpl.SizeCache
 }
@@ -75,9 +177,10 @@ func (x *GetMeRequest) GetRequestingUserId() string {
 }
 
 type GetProfileRequest struct {
-	state            protoimpl.MessageState `protogen:"open.v1"`
-	UserId           string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	RequestingUserId *string                `protobuf:"bytes,2,opt,name=requesting_user_id,json=requestingUserId,proto3,oneof" json:"requesting_user_id,omitempty"` // For isFollowing field
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Ignored: the viewer is the user of the request's token, if any
+	RequestingUserId *string `protobuf:"bytes,2,opt,name=requesting_user_id,json=requestingUserId,proto3,oneof" json:"requesting_user_id,omitempty"`
 	unknownFields    protoimpl.UnknownFields
 	sizeCache        protoimpl.SizeCache
 }
@@ -127,11 +230,21 @@ func (x *GetProfileRequest) GetRequestingUserId() string {
 }
 
 type UpdateProfileRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Username      *string                `protobuf:"bytes,2,opt,name=username,proto3,oneof" json:"username,omitempty"`
-	Email         *string                `protobuf:"bytes,3,opt,name=email,proto3,oneof" json:"email,omitempty"`
-	Bio           *string                `protobuf:"bytes,4,opt,name=bio,proto3,oneof" json:"bio,omitempty"`
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	UserId    string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // Ignored for callers with a token; defaults to the caller
+	Username  *string                `protobuf:"bytes,2,opt,name=username,proto3,oneof" json:"username,omitempty"`
+	Email     *string                `protobuf:"bytes,3,opt,name=email,proto3,oneof" json:"email,omitempty"`
+	Bio       *string                `protobuf:"bytes,4,opt,name=bio,proto3,oneof" json:"bio,omitempty"`
+	IsPrivate *bool                  `protobuf:"varint,5,opt,name=is_private,json=isPrivate,proto3,oneof" json:"is_private,omitempty"`
+	// An empty string clears each of these
+	DisplayName *string `protobuf:"bytes,6,opt,name=display_name,json=displayName,proto3,oneof" json:"display_name,omitempty"` // At most 50 characters
+	Location    *string `protobuf:"bytes,7,opt,name=location,proto3,oneof" json:"location,omitempty"`                          // At most 100 characters
+	Website     *string `protobuf:"bytes,8,opt,name=website,proto3,oneof" json:"website,omitempty"`                            // An http or https URL
+	BirthDate   *string `protobuf:"bytes,9,opt,name=birth_date,json=birthDate,proto3,oneof" json:"birth_date,omitempty"`       // YYYY-MM-DD; users must be at least 13
+	Pronouns    *string `protobuf:"bytes,10,opt,name=pronouns,proto3,oneof" json:"pronouns,omitempty"`                         // At most 30 characters
+	// Hide email and birth_date from everyone but the user
+	HideEmail     *bool `protobuf:"varint,11,opt,name=hide_email,json=hideEmail,proto3,oneof" json:"hide_email,omitempty"`
+	HideBirthDate *bool `protobuf:"varint,12,opt,name=hide_birth_date,json=hideBirthDate,proto3,oneof" json:"hide_birth_date,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -194,10 +307,67 @@ func (x *UpdateProfileRequest) GetBio() string {
 	return ""
 }
 
+func (x *UpdateProfileRequest) GetIsPrivate() bool {
+	if x != nil && x.IsPrivate != nil {
+		return *x.IsPrivate
+	}
+	return false
+}
+
+func (x *UpdateProfileRequest) GetDisplayName() string {
+	if x != nil && x.DisplayName != nil {
+		return *x.DisplayName
+	}
+	return ""
+}
+
+func (x *UpdateProfileRequest) GetLocation() string {
+	if x != nil && x.Location != nil {
+		return *x.Location
+	}
+	return ""
+}
+
+func (x *UpdateProfileRequest) GetWebsite() string {
+	if x != nil && x.Website != nil {
+		return *x.Website
+	}
+	return ""
+}
+
+func (x *UpdateProfileRequest) GetBirthDate() string {
+	if x != nil && x.BirthDate != nil {
+		return *x.BirthDate
+	}
+	return ""
+}
+
+func (x *UpdateProfileRequest) GetPronouns() string {
+	if x != nil && x.Pronouns != nil {
+		return *x.Pronouns
+	}
+	return ""
+}
+
+func (x *UpdateProfileRequest) GetHideEmail() bool {
+	if x != nil && x.HideEmail != nil {
+		return *x.HideEmail
+	}
+	return false
+}
+
+func (x *UpdateProfileRequest) GetHideBirthDate() bool {
+	if x != nil && x.HideBirthDate != nil {
+		return *x.HideBirthDate
+	}
+	return false
+}
+
 type GetUsersByIdsRequest struct {
-	state            protoimpl.MessageState `protogen:"open.v1"`
-	UserIds          []string               `protobuf:"bytes,1,rep,name=user_ids,json=userIds,proto3" json:"user_ids,omitempty"`
-	RequestingUserId *string                `protobuf:"bytes,2,opt,name=requesting_user_id,json=requestingUserId,proto3,oneof" json:"requesting_user_id,omitempty"`
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	UserIds []string               `protobuf:"bytes,1,rep,name=user_ids,json=userIds,proto3" json:"user_ids,omitempty"`
+	// Ignored: the viewer is the user of the request's token, if any
+	RequestingUserId *string `protobuf:"bytes,2,opt,name=requesting_user_id,json=requestingUserId,proto3,oneof" json:"requesting_user_id,omitempty"`
 	unknownFields    protoimpl.UnknownFields
 	sizeCache        protoimpl.SizeCache
 }
@@ -290,27 +460,27 @@ func (x *GetUsersByIdsResponse) GetUsers() []*User {
 	return nil
 }
 
-type IncrementPostsCountRequest struct {
+type GetUsersByUsernamesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Usernames     []string               `protobuf:"bytes,1,rep,name=usernames,proto3" json:"usernames,omitempty"` // Matched case-insensitively
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *IncrementPostsCountRequest) Reset() {
-	*x = IncrementPostsCountRequest{}
+func (x *GetUsersByUsernamesRequest) Reset() {
+	*x = GetUsersByUsernamesRequest{}
 	mi := &file_proto_user_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *IncrementPostsCountRequest) String() string {
+func (x *GetUsersByUsernamesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*IncrementPostsCountRequest) ProtoMessage() {}
+func (*GetUsersByUsernamesRequest) ProtoMessage() {}
 
-func (x *IncrementPostsCountRequest) ProtoReflect() protoreflect.Message {
+func (x *GetUsersByUsernamesRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_user_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -322,39 +492,42 @@ func (x *IncrementPostsCountRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use IncrementPostsCountRequest.ProtoReflect.Descriptor instead.
-func (*IncrementPostsCountRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetUsersByUsernamesRequest.ProtoReflect.Descriptor instead.
+func (*GetUsersByUsernamesRequest) Descriptor() ([]byte, []int) {
 	return file_proto_user_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *IncrementPostsCountRequest) GetUserId() string {
+func (x *GetUsersByUsernamesRequest) GetUsernames() []string {
 	if x != nil {
-		return x.UserId
+		return x.Usernames
 	}
-	return ""
+	return nil
 }
 
-type DecrementPostsCountRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type GetUsersByUsernamesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Users []*User                `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"` // Only users that exist; email is never set
+	// Users found by a username they have changed, while it is still reserved
+	// for them, for usernames no user has now
+	FormerUsernames []*FormerUsername `protobuf:"bytes,2,rep,name=former_usernames,json=formerUsernames,proto3" json:"former_usernames,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
-func (x *DecrementPostsCountRequest) Reset() {
-	*x = DecrementPostsCountRequest{}
+func (x *GetUsersByUsernamesResponse) Reset() {
+	*x = GetUsersByUsernamesResponse{}
 	mi := &file_proto_user_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DecrementPostsCountRequest) String() string {
+func (x *GetUsersByUsernamesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DecrementPostsCountRequest) ProtoMessage() {}
+func (*GetUsersByUsernamesResponse) ProtoMessage() {}
 
-func (x *DecrementPostsCountRequest) ProtoReflect() protoreflect.Message {
+func (x *GetUsersByUsernamesResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_user_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -366,48 +539,48 @@ func (x *DecrementPostsCountRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DecrementPostsCountRequest.ProtoReflect.Descriptor instead.
-func (*DecrementPostsCountRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetUsersByUsernamesResponse.ProtoReflect.Descriptor instead.
+func (*GetUsersByUsernamesResponse) Descriptor() ([]byte, []int) {
 	return file_proto_user_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *DecrementPostsCountRequest) GetUserId() string {
+func (x *GetUsersByUsernamesResponse) GetUsers() []*User {
 	if x != nil {
-		return x.UserId
+		return x.Users
 	}
-	return ""
+	return nil
 }
 
-type User struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Username       string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
-	Email          string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
-	Bio            *string                `protobuf:"bytes,4,opt,name=bio,proto3,oneof" json:"bio,omitempty"`
-	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt      *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	FollowersCount int32                  `protobuf:"varint,7,opt,name=followers_count,json=followersCount,proto3" json:"followers_count,omitempty"`
-	FollowingCount int32                  `protobuf:"varint,8,opt,name=following_count,json=followingCount,proto3" json:"following_count,omitempty"`
-	PostsCount     int32                  `protobuf:"varint,9,opt,name=posts_count,json=postsCount,proto3" json:"posts_count,omitempty"`
-	IsFollowing    *bool                  `protobuf:"varint,10,opt,name=is_following,json=isFollowing,proto3,oneof" json:"is_following,omitempty"` // Only set if requesting_user_id provided
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+func (x *GetUsersByUsernamesResponse) GetFormerUsernames() []*FormerUsername {
+	if x != nil {
+		return x.FormerUsernames
+	}
+	return nil
 }
 
-func (x *User) Reset() {
-	*x = User{}
+// FormerUsername is a user found by a username they had before
+type FormerUsername struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"` // The former username, as the user had it
+	User          *User                  `protobuf:"bytes,2,opt,name=user,proto3" json:"user,omitempty"`         // The user, under their current username
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FormerUsername) Reset() {
+	*x = FormerUsername{}
 	mi := &file_proto_user_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *User) String() string {
+func (x *FormerUsername) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*User) ProtoMessage() {}
+func (*FormerUsername) ProtoMessage() {}
 
-func (x *User) ProtoReflect() protoreflect.Message {
+func (x *FormerUsername) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_user_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -419,104 +592,152 @@ func (x *User) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use User.ProtoReflect.Descriptor instead.
-func (*User) Descriptor() ([]byte, []int) {
+// Deprecated: Use FormerUsername.ProtoReflect.Descriptor instead.
+func (*FormerUsername) Descriptor() ([]byte, []int) {
 	return file_proto_user_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *User) GetId() string {
+func (x *FormerUsername) GetUsername() string {
 	if x != nil {
-		return x.Id
+		return x.Username
 	}
 	return ""
 }
 
-func (x *User) GetUsername() string {
+func (x *FormerUsername) GetUser() *User {
 	if x != nil {
-		return x.Username
+		return x.User
 	}
-	return ""
+	return nil
 }
 
-func (x *User) GetEmail() string {
-	if x != nil {
-		return x.Email
-	}
-	return ""
+type SetUserCountersRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	UserId         string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	FollowersCount int32                  `protobuf:"varint,2,opt,name=followers_count,json=followersCount,proto3" json:"followers_count,omitempty"`
+	FollowingCount int32                  `protobuf:"varint,3,opt,name=following_count,json=followingCount,proto3" json:"following_count,omitempty"`
+	PostsCount     int32                  `protobuf:"varint,4,opt,name=posts_count,json=postsCount,proto3" json:"posts_count,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
-func (x *User) GetBio() string {
-	if x != nil && x.Bio != nil {
-		return *x.Bio
-	}
-	return ""
+func (x *SetUserCountersRequest) Reset() {
+	*x = SetUserCountersRequest{}
+	mi := &file_proto_user_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *User) GetCreatedAt() *timestamppb.Timestamp {
+func (x *SetUserCountersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetUserCountersRequest) ProtoMessage() {}
+
+func (x *SetUserCountersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_proto_msgTypes[8]
 	if x != nil {
-		return x.CreatedAt
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *User) GetUpdatedAt() *timestamppb.Timestamp {
+// Deprecated: Use SetUserCountersRequest.ProtoReflect.Descriptor instead.
+func (*SetUserCountersRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SetUserCountersRequest) GetUserId() string {
 	if x != nil {
-		return x.UpdatedAt
+		return x.UserId
 	}
-	return nil
+	return ""
 }
 
-func (x *User) GetFollowersCount() int32 {
+func (x *SetUserCountersRequest) GetFollowersCount() int32 {
 	if x != nil {
 		return x.FollowersCount
 	}
 	return 0
 }
 
-func (x *User) GetFollowingCount() int32 {
+func (x *SetUserCountersRequest) GetFollowingCount() int32 {
 	if x != nil {
 		return x.FollowingCount
 	}
 	return 0
 }
 
-func (x *User) GetPostsCount() int32 {
+func (x *SetUserCountersRequest) GetPostsCount() int32 {
 	if x != nil {
 		return x.PostsCount
 	}
 	return 0
 }
 
-func (x *User) GetIsFollowing() bool {
-	if x != nil && x.IsFollowing != nil {
-		return *x.IsFollowing
+type ReconcileFollowCountersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReconcileFollowCountersRequest) Reset() {
+	*x = ReconcileFollowCountersRequest{}
+	mi := &file_proto_user_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReconcileFollowCountersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReconcileFollowCountersRequest) ProtoMessage() {}
+
+func (x *ReconcileFollowCountersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return false
+	return mi.MessageOf(x)
 }
 
-type Response struct {
+// Deprecated: Use ReconcileFollowCountersRequest.ProtoReflect.Descriptor instead.
+func (*ReconcileFollowCountersRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_proto_rawDescGZIP(), []int{9}
+}
+
+type ReconcileFollowCountersResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Checked       int32                  `protobuf:"varint,1,opt,name=checked,proto3" json:"checked,omitempty"`
+	Corrected     int32                  `protobuf:"varint,2,opt,name=corrected,proto3" json:"corrected,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Response) Reset() {
-	*x = Response{}
-	mi := &file_proto_user_proto_msgTypes[8]
+func (x *ReconcileFollowCountersResponse) Reset() {
+	*x = ReconcileFollowCountersResponse{}
+	mi := &file_proto_user_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Response) String() string {
+func (x *ReconcileFollowCountersResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Response) ProtoMessage() {}
+func (*ReconcileFollowCountersResponse) ProtoMessage() {}
 
-func (x *Response) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_user_proto_msgTypes[8]
+func (x *ReconcileFollowCountersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -527,77 +748,1173 @@ func (x *Response) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Response.ProtoReflect.Descriptor instead.
-func (*Response) Descriptor() ([]byte, []int) {
-	return file_proto_user_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use ReconcileFollowCountersResponse.ProtoReflect.Descriptor instead.
+func (*ReconcileFollowCountersResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *Response) GetSuccess() bool {
+func (x *ReconcileFollowCountersResponse) GetChecked() int32 {
 	if x != nil {
-		return x.Success
+		return x.Checked
 	}
-	return false
+	return 0
 }
 
-func (x *Response) GetMessage() string {
+func (x *ReconcileFollowCountersResponse) GetCorrected() int32 {
 	if x != nil {
-		return x.Message
+		return x.Corrected
 	}
-	return ""
+	return 0
 }
 
-var File_proto_user_proto protoreflect.FileDescriptor
+type ReconcilePostCountersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_proto_user_proto_rawDesc = "" +
-	"\n" +
-	"\x10proto/user.proto\x12\x04user\x1a\x1fgoogle/protobuf/timestamp.proto\"q\n" +
-	"\fGetMeRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\x121\n" +
-	"\x12requesting_user_id\x18\x02 \x01(\tH\x00R\x10requestingUserId\x88\x01\x01B\x15\n" +
-	"\x13_requesting_user_id\"v\n" +
-	"\x11GetProfileRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\x121\n" +
-	"\x12requesting_user_id\x18\x02 \x01(\tH\x00R\x10requestingUserId\x88\x01\x01B\x15\n" +
-	"\x13_requesting_user_id\"\xa1\x01\n" +
-	"\x14UpdateProfileRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1f\n" +
-	"\busername\x18\x02 \x01(\tH\x00R\busername\x88\x01\x01\x12\x19\n" +
-	"\x05email\x18\x03 \x01(\tH\x01R\x05email\x88\x01\x01\x12\x15\n" +
-	"\x03bio\x18\x04 \x01(\tH\x02R\x03bio\x88\x01\x01B\v\n" +
-	"\t_usernameB\b\n" +
-	"\x06_emailB\x06\n" +
-	"\x04_bio\"{\n" +
-	"\x14GetUsersByIdsRequest\x12\x19\n" +
-	"\buser_ids\x18\x01 \x03(\tR\auserIds\x121\n" +
-	"\x12requesting_user_id\x18\x02 \x01(\tH\x00R\x10requestingUserId\x88\x01\x01B\x15\n" +
-	"\x13_requesting_user_id\"9\n" +
-	"\x15GetUsersByIdsResponse\x12 \n" +
-	"\x05users\x18\x01 \x03(\v2\n" +
-	".user.UserR\x05users\"5\n" +
-	"\x1aIncrementPostsCountRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\"5\n" +
-	"\x1aDecrementPostsCountRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\"\x89\x03\n" +
-	"\x04User\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
-	"\busername\x18\x02 \x01(\tR\busername\x12\x14\n" +
-	"\x05email\x18\x03 \x01(\tR\x05email\x12\x15\n" +
-	"\x03bio\x18\x04 \x01(\tH\x00R\x03bio\x88\x01\x01\x129\n" +
-	"\n" +
-	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
-	"\n" +
-	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12'\n" +
-	"\x0ffollowers_count\x18\a \x01(\x05R\x0efollowersCount\x12'\n" +
-	"\x0ffollowing_count\x18\b \x01(\x05R\x0efollowingCount\x12\x1f\n" +
-	"\vposts_count\x18\t \x01(\x05R\n" +
-	"postsCount\x12&\n" +
-	"\fis_following\x18\n" +
-	" \x01(\bH\x01R\visFollowing\x88\x01\x01B\x06\n" +
-	"\x04_bioB\x0f\n" +
-	"\r_is_following\">\n" +
-	"\bResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage2\xfe\x02\n" +
+func (x *ReconcilePostCountersRequest) Reset() {
+	*x = ReconcilePostCountersRequest{}
+	mi := &file_proto_user_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReconcilePostCountersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReconcilePostCountersRequest) ProtoMessage() {}
+
+func (x *ReconcilePostCountersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReconcilePostCountersRequest.ProtoReflect.Descriptor instead.
+func (*ReconcilePostCountersRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_proto_rawDescGZIP(), []int{11}
+}
+
+type ReconcilePostCountersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Checked       int32                  `protobuf:"varint,1,opt,name=checked,proto3" json:"checked,omitempty"`
+	Corrected     int32                  `protobuf:"varint,2,opt,name=corrected,proto3" json:"corrected,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReconcilePostCountersResponse) Reset() {
+	*x = ReconcilePostCountersResponse{}
+	mi := &file_proto_user_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReconcilePostCountersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReconcilePostCountersResponse) ProtoMessage() {}
+
+func (x *ReconcilePostCountersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReconcilePostCountersResponse.ProtoReflect.Descriptor instead.
+func (*ReconcilePostCountersResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ReconcilePostCountersResponse) GetChecked() int32 {
+	if x != nil {
+		return x.Checked
+	}
+	return 0
+}
+
+func (x *ReconcilePostCountersResponse) GetCorrected() int32 {
+	if x != nil {
+		return x.Corrected
+	}
+	return 0
+}
+
+type ImportedProfile struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Email         string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	Bio           *string                `protobuf:"bytes,4,opt,name=bio,proto3,oneof" json:"bio,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportedProfile) Reset() {
+	*x = ImportedProfile{}
+	mi := &file_proto_user_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportedProfile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportedProfile) ProtoMessage() {}
+
+func (x *ImportedProfile) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportedProfile.ProtoReflect.Descriptor instead.
+func (*ImportedProfile) Descriptor() ([]byte, []int) {
+	return file_proto_user_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ImportedProfile) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ImportedProfile) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *ImportedProfile) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *ImportedProfile) GetBio() string {
+	if x != nil && x.Bio != nil {
+		return *x.Bio
+	}
+	return ""
+}
+
+func (x *ImportedProfile) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type ImportProfilesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Profiles      []*ImportedProfile     `protobuf:"bytes,1,rep,name=profiles,proto3" json:"profiles,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportProfilesRequest) Reset() {
+	*x = ImportProfilesRequest{}
+	mi := &file_proto_user_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportProfilesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportProfilesRequest) ProtoMessage() {}
+
+func (x *ImportProfilesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportProfilesRequest.ProtoReflect.Descriptor instead.
+func (*ImportProfilesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ImportProfilesRequest) GetProfiles() []*ImportedProfile {
+	if x != nil {
+		return x.Profiles
+	}
+	return nil
+}
+
+type ImportProfilesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Created       int32                  `protobuf:"varint,1,opt,name=created,proto3" json:"created,omitempty"`
+	Skipped       int32                  `protobuf:"varint,2,opt,name=skipped,proto3" json:"skipped,omitempty"` // Profiles whose id, username or email already exists
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportProfilesResponse) Reset() {
+	*x = ImportProfilesResponse{}
+	mi := &file_proto_user_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportProfilesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportProfilesResponse) ProtoMessage() {}
+
+func (x *ImportProfilesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportProfilesResponse.ProtoReflect.Descriptor instead.
+func (*ImportProfilesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ImportProfilesResponse) GetCreated() int32 {
+	if x != nil {
+		return x.Created
+	}
+	return 0
+}
+
+func (x *ImportProfilesResponse) GetSkipped() int32 {
+	if x != nil {
+		return x.Skipped
+	}
+	return 0
+}
+
+type ListPublicProfilesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	AfterId       *string                `protobuf:"bytes,2,opt,name=after_id,json=afterId,proto3,oneof" json:"after_id,omitempty"` // Keyset cursor: last profile id of the previous page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPublicProfilesRequest) Reset() {
+	*x = ListPublicProfilesRequest{}
+	mi := &file_proto_user_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPublicProfilesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPublicProfilesRequest) ProtoMessage() {}
+
+func (x *ListPublicProfilesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPublicProfilesRequest.ProtoReflect.Descriptor instead.
+func (*ListPublicProfilesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListPublicProfilesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListPublicProfilesRequest) GetAfterId() string {
+	if x != nil && x.AfterId != nil {
+		return *x.AfterId
+	}
+	return ""
+}
+
+type PublicProfile struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PublicProfile) Reset() {
+	*x = PublicProfile{}
+	mi := &file_proto_user_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PublicProfile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublicProfile) ProtoMessage() {}
+
+func (x *PublicProfile) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublicProfile.ProtoReflect.Descriptor instead.
+func (*PublicProfile) Descriptor() ([]byte, []int) {
+	return file_proto_user_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *PublicProfile) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PublicProfile) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *PublicProfile) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type ListPublicProfilesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Profiles      []*PublicProfile       `protobuf:"bytes,1,rep,name=profiles,proto3" json:"profiles,omitempty"`
+	NextAfterId   *string                `protobuf:"bytes,2,opt,name=next_after_id,json=nextAfterId,proto3,oneof" json:"next_after_id,omitempty"` // Unset when there are no more profiles
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPublicProfilesResponse) Reset() {
+	*x = ListPublicProfilesResponse{}
+	mi := &file_proto_user_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPublicProfilesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPublicProfilesResponse) ProtoMessage() {}
+
+func (x *ListPublicProfilesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPublicProfilesResponse.ProtoReflect.Descriptor instead.
+func (*ListPublicProfilesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListPublicProfilesResponse) GetProfiles() []*PublicProfile {
+	if x != nil {
+		return x.Profiles
+	}
+	return nil
+}
+
+func (x *ListPublicProfilesResponse) GetNextAfterId() string {
+	if x != nil && x.NextAfterId != nil {
+		return *x.NextAfterId
+	}
+	return ""
+}
+
+type User struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Username       string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Email          string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	Bio            *string                `protobuf:"bytes,4,opt,name=bio,proto3,oneof" json:"bio,omitempty"`
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt      *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	FollowersCount int32                  `protobuf:"varint,7,opt,name=followers_count,json=followersCount,proto3" json:"followers_count,omitempty"`
+	FollowingCount int32                  `protobuf:"varint,8,opt,name=following_count,json=followingCount,proto3" json:"following_count,omitempty"`
+	PostsCount     int32                  `protobuf:"varint,9,opt,name=posts_count,json=postsCount,proto3" json:"posts_count,omitempty"`
+	IsFollowing    *bool                  `protobuf:"varint,10,opt,name=is_following,json=isFollowing,proto3,oneof" json:"is_following,omitempty"` // Only set for requests made with a user's token
+	IsPrivate      bool                   `protobuf:"varint,11,opt,name=is_private,json=isPrivate,proto3" json:"is_private,omitempty"`             // Posts are only visible to approved followers
+	AvatarId       *string                `protobuf:"bytes,12,opt,name=avatar_id,json=avatarId,proto3,oneof" json:"avatar_id,omitempty"`           // Each new avatar has a new id
+	CoverId        *string                `protobuf:"bytes,13,opt,name=cover_id,json=coverId,proto3,oneof" json:"cover_id,omitempty"`
+	DisplayName    *string                `protobuf:"bytes,14,opt,name=display_name,json=displayName,proto3,oneof" json:"display_name,omitempty"`
+	Location       *string                `protobuf:"bytes,15,opt,name=location,proto3,oneof" json:"location,omitempty"`
+	Website        *string                `protobuf:"bytes,16,opt,name=website,proto3,oneof" json:"website,omitempty"`
+	BirthDate      *string                `protobuf:"bytes,17,opt,name=birth_date,json=birthDate,proto3,oneof" json:"birth_date,omitempty"` // YYYY-MM-DD; unset when hidden from the caller
+	Pronouns       *string                `protobuf:"bytes,18,opt,name=pronouns,proto3,oneof" json:"pronouns,omitempty"`
+	HideEmail      bool                   `protobuf:"varint,19,opt,name=hide_email,json=hideEmail,proto3" json:"hide_email,omitempty"` // email is empty for other callers when set
+	HideBirthDate  bool                   `protobuf:"varint,20,opt,name=hide_birth_date,json=hideBirthDate,proto3" json:"hide_birth_date,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *User) Reset() {
+	*x = User{}
+	mi := &file_proto_user_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *User) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*User) ProtoMessage() {}
+
+func (x *User) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use User.ProtoReflect.Descriptor instead.
+func (*User) Descriptor() ([]byte, []int) {
+	return file_proto_user_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *User) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *User) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *User) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *User) GetBio() string {
+	if x != nil && x.Bio != nil {
+		return *x.Bio
+	}
+	return ""
+}
+
+func (x *User) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *User) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *User) GetFollowersCount() int32 {
+	if x != nil {
+		return x.FollowersCount
+	}
+	return 0
+}
+
+func (x *User) GetFollowingCount() int32 {
+	if x != nil {
+		return x.FollowingCount
+	}
+	return 0
+}
+
+func (x *User) GetPostsCount() int32 {
+	if x != nil {
+		return x.PostsCount
+	}
+	return 0
+}
+
+func (x *User) GetIsFollowing() bool {
+	if x != nil && x.IsFollowing != nil {
+		return *x.IsFollowing
+	}
+	return false
+}
+
+func (x *User) GetIsPrivate() bool {
+	if x != nil {
+		return x.IsPrivate
+	}
+	return false
+}
+
+func (x *User) GetAvatarId() string {
+	if x != nil && x.AvatarId != nil {
+		return *x.AvatarId
+	}
+	return ""
+}
+
+func (x *User) GetCoverId() string {
+	if x != nil && x.CoverId != nil {
+		return *x.CoverId
+	}
+	return ""
+}
+
+func (x *User) GetDisplayName() string {
+	if x != nil && x.DisplayName != nil {
+		return *x.DisplayName
+	}
+	return ""
+}
+
+func (x *User) GetLocation() string {
+	if x != nil && x.Location != nil {
+		return *x.Location
+	}
+	return ""
+}
+
+func (x *User) GetWebsite() string {
+	if x != nil && x.Website != nil {
+		return *x.Website
+	}
+	return ""
+}
+
+func (x *User) GetBirthDate() string {
+	if x != nil && x.BirthDate != nil {
+		return *x.BirthDate
+	}
+	return ""
+}
+
+func (x *User) GetPronouns() string {
+	if x != nil && x.Pronouns != nil {
+		return *x.Pronouns
+	}
+	return ""
+}
+
+func (x *User) GetHideEmail() bool {
+	if x != nil {
+		return x.HideEmail
+	}
+	return false
+}
+
+func (x *User) GetHideBirthDate() bool {
+	if x != nil {
+		return x.HideBirthDate
+	}
+	return false
+}
+
+type UploadProfileImageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Kind          ProfileImageKind       `protobuf:"varint,1,opt,name=kind,proto3,enum=user.ProfileImageKind" json:"kind,omitempty"` // Read from the first message only
+	Chunk         []byte                 `protobuf:"bytes,2,opt,name=chunk,proto3" json:"chunk,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadProfileImageRequest) Reset() {
+	*x = UploadProfileImageRequest{}
+	mi := &file_proto_user_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadProfileImageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadProfileImageRequest) ProtoMessage() {}
+
+func (x *UploadProfileImageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadProfileImageRequest.ProtoReflect.Descriptor instead.
+func (*UploadProfileImageRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *UploadProfileImageRequest) GetKind() ProfileImageKind {
+	if x != nil {
+		return x.Kind
+	}
+	return ProfileImageKind_PROFILE_IMAGE_KIND_UNSPECIFIED
+}
+
+func (x *UploadProfileImageRequest) GetChunk() []byte {
+	if x != nil {
+		return x.Chunk
+	}
+	return nil
+}
+
+type ProfileImage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Kind          ProfileImageKind       `protobuf:"varint,2,opt,name=kind,proto3,enum=user.ProfileImageKind" json:"kind,omitempty"`
+	Status        ProfileImageStatus     `protobuf:"varint,3,opt,name=status,proto3,enum=user.ProfileImageStatus" json:"status,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProfileImage) Reset() {
+	*x = ProfileImage{}
+	mi := &file_proto_user_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProfileImage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProfileImage) ProtoMessage() {}
+
+func (x *ProfileImage) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProfileImage.ProtoReflect.Descriptor instead.
+func (*ProfileImage) Descriptor() ([]byte, []int) {
+	return file_proto_user_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ProfileImage) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ProfileImage) GetKind() ProfileImageKind {
+	if x != nil {
+		return x.Kind
+	}
+	return ProfileImageKind_PROFILE_IMAGE_KIND_UNSPECIFIED
+}
+
+func (x *ProfileImage) GetStatus() ProfileImageStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ProfileImageStatus_PROFILE_IMAGE_STATUS_UNSPECIFIED
+}
+
+func (x *ProfileImage) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type GetProfileImageContentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ImageId       string                 `protobuf:"bytes,1,opt,name=image_id,json=imageId,proto3" json:"image_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProfileImageContentRequest) Reset() {
+	*x = GetProfileImageContentRequest{}
+	mi := &file_proto_user_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProfileImageContentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProfileImageContentRequest) ProtoMessage() {}
+
+func (x *GetProfileImageContentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProfileImageContentRequest.ProtoReflect.Descriptor instead.
+func (*GetProfileImageContentRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetProfileImageContentRequest) GetImageId() string {
+	if x != nil {
+		return x.ImageId
+	}
+	return ""
+}
+
+// The first chunk carries mime_type and size_bytes
+type ProfileImageChunk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MimeType      string                 `protobuf:"bytes,1,opt,name=mime_type,json=mimeType,proto3" json:"mime_type,omitempty"`
+	SizeBytes     int64                  `protobuf:"varint,2,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
+	Chunk         []byte                 `protobuf:"bytes,3,opt,name=chunk,proto3" json:"chunk,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProfileImageChunk) Reset() {
+	*x = ProfileImageChunk{}
+	mi := &file_proto_user_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProfileImageChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProfileImageChunk) ProtoMessage() {}
+
+func (x *ProfileImageChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProfileImageChunk.ProtoReflect.Descriptor instead.
+func (*ProfileImageChunk) Descriptor() ([]byte, []int) {
+	return file_proto_user_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ProfileImageChunk) GetMimeType() string {
+	if x != nil {
+		return x.MimeType
+	}
+	return ""
+}
+
+func (x *ProfileImageChunk) GetSizeBytes() int64 {
+	if x != nil {
+		return x.SizeBytes
+	}
+	return 0
+}
+
+func (x *ProfileImageChunk) GetChunk() []byte {
+	if x != nil {
+		return x.Chunk
+	}
+	return nil
+}
+
+type Response struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Response) Reset() {
+	*x = Response{}
+	mi := &file_proto_user_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Response) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Response) ProtoMessage() {}
+
+func (x *Response) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Response.ProtoReflect.Descriptor instead.
+func (*Response) Descriptor() ([]byte, []int) {
+	return file_proto_user_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *Response) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *Response) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type ExportMyDataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportMyDataRequest) Reset() {
+	*x = ExportMyDataRequest{}
+	mi := &file_proto_user_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportMyDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportMyDataRequest) ProtoMessage() {}
+
+func (x *ExportMyDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportMyDataRequest.ProtoReflect.Descriptor instead.
+func (*ExportMyDataRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_proto_rawDescGZIP(), []int{25}
+}
+
+type DataExport struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"` // JSON document
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DataExport) Reset() {
+	*x = DataExport{}
+	mi := &file_proto_user_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DataExport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DataExport) ProtoMessage() {}
+
+func (x *DataExport) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DataExport.ProtoReflect.Descriptor instead.
+func (*DataExport) Descriptor() ([]byte, []int) {
+	return file_proto_user_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *DataExport) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+var File_proto_user_proto protoreflect.FileDescriptor
+
+const file_proto_user_proto_rawDesc = "" +
+	"\n" +
+	"\x10proto/user.proto\x12\x04user\x1a\x1fgoogle/protobuf/timestamp.proto\"q\n" +
+	"\fGetMeRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x121\n" +
+	"\x12requesting_user_id\x18\x02 \x01(\tH\x00R\x10requestingUserId\x88\x01\x01B\x15\n" +
+	"\x13_requesting_user_id\"v\n" +
+	"\x11GetProfileRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x121\n" +
+	"\x12requesting_user_id\x18\x02 \x01(\tH\x00R\x10requestingUserId\x88\x01\x01B\x15\n" +
+	"\x13_requesting_user_id\"\xbb\x04\n" +
+	"\x14UpdateProfileRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1f\n" +
+	"\busername\x18\x02 \x01(\tH\x00R\busername\x88\x01\x01\x12\x19\n" +
+	"\x05email\x18\x03 \x01(\tH\x01R\x05email\x88\x01\x01\x12\x15\n" +
+	"\x03bio\x18\x04 \x01(\tH\x02R\x03bio\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"is_private\x18\x05 \x01(\bH\x03R\tisPrivate\x88\x01\x01\x12&\n" +
+	"\fdisplay_name\x18\x06 \x01(\tH\x04R\vdisplayName\x88\x01\x01\x12\x1f\n" +
+	"\blocation\x18\a \x01(\tH\x05R\blocation\x88\x01\x01\x12\x1d\n" +
+	"\awebsite\x18\b \x01(\tH\x06R\awebsite\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"birth_date\x18\t \x01(\tH\aR\tbirthDate\x88\x01\x01\x12\x1f\n" +
+	"\bpronouns\x18\n" +
+	" \x01(\tH\bR\bpronouns\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"hide_email\x18\v \x01(\bH\tR\thideEmail\x88\x01\x01\x12+\n" +
+	"\x0fhide_birth_date\x18\f \x01(\bH\n" +
+	"R\rhideBirthDate\x88\x01\x01B\v\n" +
+	"\t_usernameB\b\n" +
+	"\x06_emailB\x06\n" +
+	"\x04_bioB\r\n" +
+	"\v_is_privateB\x0f\n" +
+	"\r_display_nameB\v\n" +
+	"\t_locationB\n" +
+	"\n" +
+	"\b_websiteB\r\n" +
+	"\v_birth_dateB\v\n" +
+	"\t_pronounsB\r\n" +
+	"\v_hide_emailB\x12\n" +
+	"\x10_hide_birth_date\"{\n" +
+	"\x14GetUsersByIdsRequest\x12\x19\n" +
+	"\buser_ids\x18\x01 \x03(\tR\auserIds\x121\n" +
+	"\x12requesting_user_id\x18\x02 \x01(\tH\x00R\x10requestingUserId\x88\x01\x01B\x15\n" +
+	"\x13_requesting_user_id\"9\n" +
+	"\x15GetUsersByIdsResponse\x12 \n" +
+	"\x05users\x18\x01 \x03(\v2\n" +
+	".user.UserR\x05users\":\n" +
+	"\x1aGetUsersByUsernamesRequest\x12\x1c\n" +
+	"\tusernames\x18\x01 \x03(\tR\tusernames\"\x80\x01\n" +
+	"\x1bGetUsersByUsernamesResponse\x12 \n" +
+	"\x05users\x18\x01 \x03(\v2\n" +
+	".user.UserR\x05users\x12?\n" +
+	"\x10former_usernames\x18\x02 \x03(\v2\x14.user.FormerUsernameR\x0fformerUsernames\"L\n" +
+	"\x0eFormerUsername\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\x12\x1e\n" +
+	"\x04user\x18\x02 \x01(\v2\n" +
+	".user.UserR\x04user\"\xa4\x01\n" +
+	"\x16SetUserCountersRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12'\n" +
+	"\x0ffollowers_count\x18\x02 \x01(\x05R\x0efollowersCount\x12'\n" +
+	"\x0ffollowing_count\x18\x03 \x01(\x05R\x0efollowingCount\x12\x1f\n" +
+	"\vposts_count\x18\x04 \x01(\x05R\n" +
+	"postsCount\" \n" +
+	"\x1eReconcileFollowCountersRequest\"Y\n" +
+	"\x1fReconcileFollowCountersResponse\x12\x18\n" +
+	"\achecked\x18\x01 \x01(\x05R\achecked\x12\x1c\n" +
+	"\tcorrected\x18\x02 \x01(\x05R\tcorrected\"\x1e\n" +
+	"\x1cReconcilePostCountersRequest\"W\n" +
+	"\x1dReconcilePostCountersResponse\x12\x18\n" +
+	"\achecked\x18\x01 \x01(\x05R\achecked\x12\x1c\n" +
+	"\tcorrected\x18\x02 \x01(\x05R\tcorrected\"\xad\x01\n" +
+	"\x0fImportedProfile\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x14\n" +
+	"\x05email\x18\x03 \x01(\tR\x05email\x12\x15\n" +
+	"\x03bio\x18\x04 \x01(\tH\x00R\x03bio\x88\x01\x01\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAtB\x06\n" +
+	"\x04_bio\"J\n" +
+	"\x15ImportProfilesRequest\x121\n" +
+	"\bprofiles\x18\x01 \x03(\v2\x15.user.ImportedProfileR\bprofiles\"L\n" +
+	"\x16ImportProfilesResponse\x12\x18\n" +
+	"\acreated\x18\x01 \x01(\x05R\acreated\x12\x18\n" +
+	"\askipped\x18\x02 \x01(\x05R\askipped\"^\n" +
+	"\x19ListPublicProfilesRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12\x1e\n" +
+	"\bafter_id\x18\x02 \x01(\tH\x00R\aafterId\x88\x01\x01B\v\n" +
+	"\t_after_id\"v\n" +
+	"\rPublicProfile\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x129\n" +
+	"\n" +
+	"updated_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\x88\x01\n" +
+	"\x1aListPublicProfilesResponse\x12/\n" +
+	"\bprofiles\x18\x01 \x03(\v2\x13.user.PublicProfileR\bprofiles\x12'\n" +
+	"\rnext_after_id\x18\x02 \x01(\tH\x00R\vnextAfterId\x88\x01\x01B\x10\n" +
+	"\x0e_next_after_id\"\xbf\x06\n" +
+	"\x04User\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x14\n" +
+	"\x05email\x18\x03 \x01(\tR\x05email\x12\x15\n" +
+	"\x03bio\x18\x04 \x01(\tH\x00R\x03bio\x88\x01\x01\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12'\n" +
+	"\x0ffollowers_count\x18\a \x01(\x05R\x0efollowersCount\x12'\n" +
+	"\x0ffollowing_count\x18\b \x01(\x05R\x0efollowingCount\x12\x1f\n" +
+	"\vposts_count\x18\t \x01(\x05R\n" +
+	"postsCount\x12&\n" +
+	"\fis_following\x18\n" +
+	" \x01(\bH\x01R\visFollowing\x88\x01\x01\x12\x1d\n" +
+	"\n" +
+	"is_private\x18\v \x01(\bR\tisPrivate\x12 \n" +
+	"\tavatar_id\x18\f \x01(\tH\x02R\bavatarId\x88\x01\x01\x12\x1e\n" +
+	"\bcover_id\x18\r \x01(\tH\x03R\acoverId\x88\x01\x01\x12&\n" +
+	"\fdisplay_name\x18\x0e \x01(\tH\x04R\vdisplayName\x88\x01\x01\x12\x1f\n" +
+	"\blocation\x18\x0f \x01(\tH\x05R\blocation\x88\x01\x01\x12\x1d\n" +
+	"\awebsite\x18\x10 \x01(\tH\x06R\awebsite\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"birth_date\x18\x11 \x01(\tH\aR\tbirthDate\x88\x01\x01\x12\x1f\n" +
+	"\bpronouns\x18\x12 \x01(\tH\bR\bpronouns\x88\x01\x01\x12\x1d\n" +
+	"\n" +
+	"hide_email\x18\x13 \x01(\bR\thideEmail\x12&\n" +
+	"\x0fhide_birth_date\x18\x14 \x01(\bR\rhideBirthDateB\x06\n" +
+	"\x04_bioB\x0f\n" +
+	"\r_is_followingB\f\n" +
+	"\n" +
+	"_avatar_idB\v\n" +
+	"\t_cover_idB\x0f\n" +
+	"\r_display_nameB\v\n" +
+	"\t_locationB\n" +
+	"\n" +
+	"\b_websiteB\r\n" +
+	"\v_birth_dateB\v\n" +
+	"\t_pronouns\"]\n" +
+	"\x19UploadProfileImageRequest\x12*\n" +
+	"\x04kind\x18\x01 \x01(\x0e2\x16.user.ProfileImageKindR\x04kind\x12\x14\n" +
+	"\x05chunk\x18\x02 \x01(\fR\x05chunk\"\xb7\x01\n" +
+	"\fProfileImage\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12*\n" +
+	"\x04kind\x18\x02 \x01(\x0e2\x16.user.ProfileImageKindR\x04kind\x120\n" +
+	"\x06status\x18\x03 \x01(\x0e2\x18.user.ProfileImageStatusR\x06status\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\":\n" +
+	"\x1dGetProfileImageContentRequest\x12\x19\n" +
+	"\bimage_id\x18\x01 \x01(\tR\aimageId\"e\n" +
+	"\x11ProfileImageChunk\x12\x1b\n" +
+	"\tmime_type\x18\x01 \x01(\tR\bmimeType\x12\x1d\n" +
+	"\n" +
+	"size_bytes\x18\x02 \x01(\x03R\tsizeBytes\x12\x14\n" +
+	"\x05chunk\x18\x03 \x01(\fR\x05chunk\">\n" +
+	"\bResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\x15\n" +
+	"\x13ExportMyDataRequest\" \n" +
+	"\n" +
+	"DataExport\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data*s\n" +
+	"\x10ProfileImageKind\x12\"\n" +
+	"\x1ePROFILE_IMAGE_KIND_UNSPECIFIED\x10\x00\x12\x1d\n" +
+	"\x19PROFILE_IMAGE_KIND_AVATAR\x10\x01\x12\x1c\n" +
+	"\x18PROFILE_IMAGE_KIND_COVER\x10\x02*\x9d\x01\n" +
+	"\x12ProfileImageStatus\x12$\n" +
+	" PROFILE_IMAGE_STATUS_UNSPECIFIED\x10\x00\x12 \n" +
+	"\x1cPROFILE_IMAGE_STATUS_PENDING\x10\x01\x12\x1e\n" +
+	"\x1aPROFILE_IMAGE_STATUS_READY\x10\x02\x12\x1f\n" +
+	"\x1bPROFILE_IMAGE_STATUS_FAILED\x10\x032\xdd\a\n" +
 	"\vUserService\x12'\n" +
 	"\x05GetMe\x12\x12.user.GetMeRequest\x1a\n" +
 	".user.User\x121\n" +
@@ -606,9 +1923,16 @@ const file_proto_user_proto_rawDesc = "" +
 	".user.User\x127\n" +
 	"\rUpdateProfile\x12\x1a.user.UpdateProfileRequest\x1a\n" +
 	".user.User\x12H\n" +
-	"\rGetUsersByIds\x12\x1a.user.GetUsersByIdsRequest\x1a\x1b.user.GetUsersByIdsResponse\x12G\n" +
-	"\x13IncrementPostsCount\x12 .user.IncrementPostsCountRequest\x1a\x0e.user.Response\x12G\n" +
-	"\x13DecrementPostsCount\x12 .user.DecrementPostsCountRequest\x1a\x0e.user.ResponseB\x04Z\x02./b\x06proto3"
+	"\rGetUsersByIds\x12\x1a.user.GetUsersByIdsRequest\x1a\x1b.user.GetUsersByIdsResponse\x12Z\n" +
+	"\x13GetUsersByUsernames\x12 .user.GetUsersByUsernamesRequest\x1a!.user.GetUsersByUsernamesResponse\x12W\n" +
+	"\x12ListPublicProfiles\x12\x1f.user.ListPublicProfilesRequest\x1a .user.ListPublicProfilesResponse\x12K\n" +
+	"\x12UploadProfileImage\x12\x1f.user.UploadProfileImageRequest\x1a\x12.user.ProfileImage(\x01\x12X\n" +
+	"\x16GetProfileImageContent\x12#.user.GetProfileImageContentRequest\x1a\x17.user.ProfileImageChunk0\x01\x12;\n" +
+	"\fExportMyData\x12\x19.user.ExportMyDataRequest\x1a\x10.user.DataExport\x12?\n" +
+	"\x0fSetUserCounters\x12\x1c.user.SetUserCountersRequest\x1a\x0e.user.Response\x12f\n" +
+	"\x17ReconcileFollowCounters\x12$.user.ReconcileFollowCountersRequest\x1a%.user.ReconcileFollowCountersResponse\x12`\n" +
+	"\x15ReconcilePostCounters\x12\".user.ReconcilePostCountersRequest\x1a#.user.ReconcilePostCountersResponse\x12K\n" +
+	"\x0eImportProfiles\x12\x1b.user.ImportProfilesRequest\x1a\x1c.user.ImportProfilesResponseB\x04Z\x02./b\x06proto3"
 
 var (
 	file_proto_user_proto_rawDescOnce sync.Once
@@ -622,40 +1946,86 @@ func file_proto_user_proto_rawDescGZIP() []byte {
 	return file_proto_user_proto_rawDescData
 }
 
-var file_proto_user_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_proto_user_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_proto_user_proto_msgTypes = make([]protoimpl.MessageInfo, 27)
 var file_proto_user_proto_goTypes = []any{
-	(*GetMeRequest)(nil),               // 0: user.GetMeRequest
-	(*GetProfileRequest)(nil),          // 1: user.GetProfileRequest
-	(*UpdateProfileRequest)(nil),       // 2: user.UpdateProfileRequest
-	(*GetUsersByIdsRequest)(nil),       // 3: user.GetUsersByIdsRequest
-	(*GetUsersByIdsResponse)(nil),      // 4: user.GetUsersByIdsResponse
-	(*IncrementPostsCountRequest)(nil), // 5: user.IncrementPostsCountRequest
-	(*DecrementPostsCountRequest)(nil), // 6: user.DecrementPostsCountRequest
-	(*User)(nil),                       // 7: user.User
-	(*Response)(nil),                   // 8: user.Response
-	(*timestamppb.Timestamp)(nil),      // 9: google.protobuf.Timestamp
+	(ProfileImageKind)(0),                   // 0: user.ProfileImageKind
+	(ProfileImageStatus)(0),                 // 1: user.ProfileImageStatus
+	(*GetMeRequest)(nil),                    // 2: user.GetMeRequest
+	(*GetProfileRequest)(nil),               // 3: user.GetProfileRequest
+	(*UpdateProfileRequest)(nil),            // 4: user.UpdateProfileRequest
+	(*GetUsersByIdsRequest)(nil),            // 5: user.GetUsersByIdsRequest
+	(*GetUsersByIdsResponse)(nil),           // 6: user.GetUsersByIdsResponse
+	(*GetUsersByUsernamesRequest)(nil),      // 7: user.GetUsersByUsernamesRequest
+	(*GetUsersByUsernamesResponse)(nil),     // 8: user.GetUsersByUsernamesResponse
+	(*FormerUsername)(nil),                  // 9: user.FormerUsername
+	(*SetUserCountersRequest)(nil),          // 10: user.SetUserCountersRequest
+	(*ReconcileFollowCountersRequest)(nil),  // 11: user.ReconcileFollowCountersRequest
+	(*ReconcileFollowCountersResponse)(nil), // 12: user.ReconcileFollowCountersResponse
+	(*ReconcilePostCountersRequest)(nil),    // 13: user.ReconcilePostCountersRequest
+	(*ReconcilePostCountersResponse)(nil),   // 14: user.ReconcilePostCountersResponse
+	(*ImportedProfile)(nil),                 // 15: user.ImportedProfile
+	(*ImportProfilesRequest)(nil),           // 16: user.ImportProfilesRequest
+	(*ImportProfilesResponse)(nil),          // 17: user.ImportProfilesResponse
+	(*ListPublicProfilesRequest)(nil),       // 18: user.ListPublicProfilesRequest
+	(*PublicProfile)(nil),                   // 19: user.PublicProfile
+	(*ListPublicProfilesResponse)(nil),      // 20: user.ListPublicProfilesResponse
+	(*User)(nil),                            // 21: user.User
+	(*UploadProfileImageRequest)(nil),       // 22: user.UploadProfileImageRequest
+	(*ProfileImage)(nil),                    // 23: user.ProfileImage
+	(*GetProfileImageContentRequest)(nil),   // 24: user.GetProfileImageContentRequest
+	(*ProfileImageChunk)(nil),               // 25: user.ProfileImageChunk
+	(*Response)(nil),                        // 26: user.Response
+	(*ExportMyDataRequest)(nil),             // 27: user.ExportMyDataRequest
+	(*DataExport)(nil),                      // 28: user.DataExport
+	(*timestamppb.Timestamp)(nil),           // 29: google.protobuf.Timestamp
 }
 var file_proto_user_proto_depIdxs = []int32{
-	7, // 0: user.GetUsersByIdsResponse.users:type_name -> user.User
-	9, // 1: user.User.created_at:type_name -> google.protobuf.Timestamp
-	9, // 2: user.User.updated_at:type_name -> google.protobuf.Timestamp
-	0, // 3: user.UserService.GetMe:input_type -> user.GetMeRequest
-	1, // 4: user.UserService.GetProfile:input_type -> user.GetProfileRequest
-	2, // 5: user.UserService.UpdateProfile:input_type -> user.UpdateProfileRequest
-	3, // 6: user.UserService.GetUsersByIds:input_type -> user.GetUsersByIdsRequest
-	5, // 7: user.UserService.IncrementPostsCount:input_type -> user.IncrementPostsCountRequest
-	6, // 8: user.UserService.DecrementPostsCount:input_type -> user.DecrementPostsCountRequest
-	7, // 9: user.UserService.GetMe:output_type -> user.User
-	7, // 10: user.UserService.GetProfile:output_type -> user.User
-	7, // 11: user.UserService.UpdateProfile:output_type -> user.User
-	4, // 12: user.UserService.GetUsersByIds:output_type -> user.GetUsersByIdsResponse
-	8, // 13: user.UserService.IncrementPostsCount:output_type -> user.Response
-	8, // 14: user.UserService.DecrementPostsCount:output_type -> user.Response
-	9, // [9:15] is the sub-list for method output_type
-	3, // [3:9] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	21, // 0: user.GetUsersByIdsResponse.users:type_name -> user.User
+	21, // 1: user.GetUsersByUsernamesResponse.users:type_name -> user.User
+	9,  // 2: user.GetUsersByUsernamesResponse.former_usernames:type_name -> user.FormerUsername
+	21, // 3: user.FormerUsername.user:type_name -> user.User
+	29, // 4: user.ImportedProfile.created_at:type_name -> google.protobuf.Timestamp
+	15, // 5: user.ImportProfilesRequest.profiles:type_name -> user.ImportedProfile
+	29, // 6: user.PublicProfile.updated_at:type_name -> google.protobuf.Timestamp
+	19, // 7: user.ListPublicProfilesResponse.profiles:type_name -> user.PublicProfile
+	29, // 8: user.User.created_at:type_name -> google.protobuf.Timestamp
+	29, // 9: user.User.updated_at:type_name -> google.protobuf.Timestamp
+	0,  // 10: user.UploadProfileImageRequest.kind:type_name -> user.ProfileImageKind
+	0,  // 11: user.ProfileImage.kind:type_name -> user.ProfileImageKind
+	1,  // 12: user.ProfileImage.status:type_name -> user.ProfileImageStatus
+	29, // 13: user.ProfileImage.created_at:type_name -> google.protobuf.Timestamp
+	2,  // 14: user.UserService.GetMe:input_type -> user.GetMeRequest
+	3,  // 15: user.UserService.GetProfile:input_type -> user.GetProfileRequest
+	4,  // 16: user.UserService.UpdateProfile:input_type -> user.UpdateProfileRequest
+	5,  // 17: user.UserService.GetUsersByIds:input_type -> user.GetUsersByIdsRequest
+	7,  // 18: user.UserService.GetUsersByUsernames:input_type -> user.GetUsersByUsernamesRequest
+	18, // 19: user.UserService.ListPublicProfiles:input_type -> user.ListPublicProfilesRequest
+	22, // 20: user.UserService.UploadProfileImage:input_type -> user.UploadProfileImageRequest
+	24, // 21: user.UserService.GetProfileImageContent:input_type -> user.GetProfileImageContentRequest
+	27, // 22: user.UserService.ExportMyData:input_type -> user.ExportMyDataRequest
+	10, // 23: user.UserService.SetUserCounters:input_type -> user.SetUserCountersRequest
+	11, // 24: user.UserService.ReconcileFollowCounters:input_type -> user.ReconcileFollowCountersRequest
+	13, // 25: user.UserService.ReconcilePostCounters:input_type -> user.ReconcilePostCountersRequest
+	16, // 26: user.UserService.ImportProfiles:input_type -> user.ImportProfilesRequest
+	21, // 27: user.UserService.GetMe:output_type -> user.User
+	21, // 28: user.UserService.GetProfile:output_type -> user.User
+	21, // 29: user.UserService.UpdateProfile:output_type -> user.User
+	6,  // 30: user.UserService.GetUsersByIds:output_type -> user.GetUsersByIdsResponse
+	8,  // 31: user.UserService.GetUsersByUsernames:output_type -> user.GetUsersByUsernamesResponse
+	20, // 32: user.UserService.ListPublicProfiles:output_type -> user.ListPublicProfilesResponse
+	23, // 33: user.UserService.UploadProfileImage:output_type -> user.ProfileImage
+	25, // 34: user.UserService.GetProfileImageContent:output_type -> user.ProfileImageChunk
+	28, // 35: user.UserService.ExportMyData:output_type -> user.DataExport
+	26, // 36: user.UserService.SetUserCounters:output_type -> user.Response
+	12, // 37: user.UserService.ReconcileFollowCounters:output_type -> user.ReconcileFollowCountersResponse
+	14, // 38: user.UserService.ReconcilePostCounters:output_type -> user.ReconcilePostCountersResponse
+	17, // 39: user.UserService.ImportProfiles:output_type -> user.ImportProfilesResponse
+	27, // [27:40] is the sub-list for method output_type
+	14, // [14:27] is the sub-list for method input_type
+	14, // [14:14] is the sub-list for extension type_name
+	14, // [14:14] is the sub-list for extension extendee
+	0,  // [0:14] is the sub-list for field type_name
 }
 
 func init() { file_proto_user_proto_init() }
@@ -667,19 +2037,23 @@ func file_proto_user_proto_init() {
 	file_proto_user_proto_msgTypes[1].OneofWrappers = []any{}
 	file_proto_user_proto_msgTypes[2].OneofWrappers = []any{}
 	file_proto_user_proto_msgTypes[3].OneofWrappers = []any{}
-	file_proto_user_proto_msgTypes[7].OneofWrappers = []any{}
+	file_proto_user_proto_msgTypes[13].OneofWrappers = []any{}
+	file_proto_user_proto_msgTypes[16].OneofWrappers = []any{}
+	file_proto_user_proto_msgTypes[18].OneofWrappers = []any{}
+	file_proto_user_proto_msgTypes[19].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_user_proto_rawDesc), len(file_proto_user_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   9,
+			NumEnums:      2,
+			NumMessages:   27,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_proto_user_proto_goTypes,
 		DependencyIndexes: file_proto_user_proto_depIdxs,
+		EnumInfos:         file_proto_user_proto_enumTypes,
 		MessageInfos:      file_proto_user_proto_msgTypes,
 	}.Build()
 	File_proto_user_proto = out.File
@@ -19,12 +19,19 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	UserService_GetMe_FullMethodName               = "/user.UserService/GetMe"
-	UserService_GetProfile_FullMethodName          = "/user.UserService/GetProfile"
-	UserService_UpdateProfile_FullMethodName       = "/user.UserService/UpdateProfile"
-	UserService_GetUsersByIds_FullMethodName       = "/user.UserService/GetUsersByIds"
-	UserService_IncrementPostsCount_FullMethodName = "/user.UserService/IncrementPostsCount"
-	UserService_DecrementPostsCount_FullMethodName = "/user.UserService/DecrementPostsCount"
+	UserService_GetMe_FullMethodName                   = "/user.UserService/GetMe"
+	UserService_GetProfile_FullMethodName              = "/user.UserService/GetProfile"
+	UserService_UpdateProfile_FullMethodName           = "/user.UserService/UpdateProfile"
+	UserService_GetUsersByIds_FullMethodName           = "/user.UserService/GetUsersByIds"
+	UserService_GetUsersByUsernames_FullMethodName     = "/user.UserService/GetUsersByUsernames"
+	UserService_ListPublicProfiles_FullMethodName      = "/user.UserService/ListPublicProfiles"
+	UserService_UploadProfileImage_FullMethodName      = "/user.UserService/UploadProfileImage"
+	UserService_GetProfileImageContent_FullMethodName  = "/user.UserService/GetProfileImageContent"
+	UserService_ExportMyData_FullMethodName            = "/user.UserService/ExportMyData"
+	UserService_SetUserCounters_FullMethodName         = "/user.UserService/SetUserCounters"
+	UserService_ReconcileFollowCounters_FullMethodName = "/user.UserService/ReconcileFollowCounters"
+	UserService_ReconcilePostCounters_FullMethodName   = "/user.UserService/ReconcilePostCounters"
+	UserService_ImportProfiles_FullMethodName          = "/user.UserService/ImportProfiles"
 )
 
 // UserServiceClient is the client API for UserService service.
@@ -35,8 +42,25 @@ type UserServiceClient interface {
 	GetProfile(ctx context.Context, in *GetProfileRequest, opts ...grpc.CallOption) (*User, error)
 	UpdateProfile(ctx context.Context, in *UpdateProfileRequest, opts ...grpc.CallOption) (*User, error)
 	GetUsersByIds(ctx context.Context, in *GetUsersByIdsRequest, opts ...grpc.CallOption) (*GetUsersByIdsResponse, error)
-	IncrementPostsCount(ctx context.Context, in *IncrementPostsCountRequest, opts ...grpc.CallOption) (*Response, error)
-	DecrementPostsCount(ctx context.Context, in *DecrementPostsCountRequest, opts ...grpc.CallOption) (*Response, error)
+	GetUsersByUsernames(ctx context.Context, in *GetUsersByUsernamesRequest, opts ...grpc.CallOption) (*GetUsersByUsernamesResponse, error)
+	ListPublicProfiles(ctx context.Context, in *ListPublicProfilesRequest, opts ...grpc.CallOption) (*ListPublicProfilesResponse, error)
+	// Avatars and cover images. An upload is checked as it is received, then
+	// cropped and resized in the background; the profile shows it once ready.
+	UploadProfileImage(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[UploadProfileImageRequest, ProfileImage], error)
+	GetProfileImageContent(ctx context.Context, in *GetProfileImageContentRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ProfileImageChunk], error)
+	// The caller's profile, for their data export
+	ExportMyData(ctx context.Context, in *ExportMyDataRequest, opts ...grpc.CallOption) (*DataExport, error)
+	// Admin operations (require the ADMIN role)
+	SetUserCounters(ctx context.Context, in *SetUserCountersRequest, opts ...grpc.CallOption) (*Response, error)
+	// ReconcileFollowCounters recounts the follows of every user from
+	// follow-service and corrects counters that have drifted. It is run on a
+	// schedule by scheduler-service.
+	ReconcileFollowCounters(ctx context.Context, in *ReconcileFollowCountersRequest, opts ...grpc.CallOption) (*ReconcileFollowCountersResponse, error)
+	// ReconcilePostCounters recounts the posts of every user from post-service
+	// and corrects counters that have drifted. It is run on a schedule by
+	// scheduler-service.
+	ReconcilePostCounters(ctx context.Context, in *ReconcilePostCountersRequest, opts ...grpc.CallOption) (*ReconcilePostCountersResponse, error)
+	ImportProfiles(ctx context.Context, in *ImportProfilesRequest, opts ...grpc.CallOption) (*ImportProfilesResponse, error)
 }
 
 type userServiceClient struct {
@@ -87,20 +111,102 @@ func (c *userServiceClient) GetUsersByIds(ctx context.Context, in *GetUsersByIds
 	return out, nil
 }
 
-func (c *userServiceClient) IncrementPostsCount(ctx context.Context, in *IncrementPostsCountRequest, opts ...grpc.CallOption) (*Response, error) {
+func (c *userServiceClient) GetUsersByUsernames(ctx context.Context, in *GetUsersByUsernamesRequest, opts ...grpc.CallOption) (*GetUsersByUsernamesResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(Response)
-	err := c.cc.Invoke(ctx, UserService_IncrementPostsCount_FullMethodName, in, out, cOpts...)
+	out := new(GetUsersByUsernamesResponse)
+	err := c.cc.Invoke(ctx, UserService_GetUsersByUsernames_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ListPublicProfiles(ctx context.Context, in *ListPublicProfilesRequest, opts ...grpc.CallOption) (*ListPublicProfilesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPublicProfilesResponse)
+	err := c.cc.Invoke(ctx, UserService_ListPublicProfiles_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *userServiceClient) DecrementPostsCount(ctx context.Context, in *DecrementPostsCountRequest, opts ...grpc.CallOption) (*Response, error) {
+func (c *userServiceClient) UploadProfileImage(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[UploadProfileImageRequest, ProfileImage], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &UserService_ServiceDesc.Streams[0], UserService_UploadProfileImage_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[UploadProfileImageRequest, ProfileImage]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type UserService_UploadProfileImageClient = grpc.ClientStreamingClient[UploadProfileImageRequest, ProfileImage]
+
+func (c *userServiceClient) GetProfileImageContent(ctx context.Context, in *GetProfileImageContentRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ProfileImageChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &UserService_ServiceDesc.Streams[1], UserService_GetProfileImageContent_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[GetProfileImageContentRequest, ProfileImageChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type UserService_GetProfileImageContentClient = grpc.ServerStreamingClient[ProfileImageChunk]
+
+func (c *userServiceClient) ExportMyData(ctx context.Context, in *ExportMyDataRequest, opts ...grpc.CallOption) (*DataExport, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DataExport)
+	err := c.cc.Invoke(ctx, UserService_ExportMyData_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) SetUserCounters(ctx context.Context, in *SetUserCountersRequest, opts ...grpc.CallOption) (*Response, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(Response)
-	err := c.cc.Invoke(ctx, UserService_DecrementPostsCount_FullMethodName, in, out, cOpts...)
+	err := c.cc.Invoke(ctx, UserService_SetUserCounters_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ReconcileFollowCounters(ctx context.Context, in *ReconcileFollowCountersRequest, opts ...grpc.CallOption) (*ReconcileFollowCountersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReconcileFollowCountersResponse)
+	err := c.cc.Invoke(ctx, UserService_ReconcileFollowCounters_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ReconcilePostCounters(ctx context.Context, in *ReconcilePostCountersRequest, opts ...grpc.CallOption) (*ReconcilePostCountersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReconcilePostCountersResponse)
+	err := c.cc.Invoke(ctx, UserService_ReconcilePostCounters_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ImportProfiles(ctx context.Context, in *ImportProfilesRequest, opts ...grpc.CallOption) (*ImportProfilesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ImportProfilesResponse)
+	err := c.cc.Invoke(ctx, UserService_ImportProfiles_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -115,8 +221,25 @@ type UserServiceServer interface {
 	GetProfile(context.Context, *GetProfileRequest) (*User, error)
 	UpdateProfile(context.Context, *UpdateProfileRequest) (*User, error)
 	GetUsersByIds(context.Context, *GetUsersByIdsRequest) (*GetUsersByIdsResponse, error)
-	IncrementPostsCount(context.Context, *IncrementPostsCountRequest) (*Response, error)
-	DecrementPostsCount(context.Context, *DecrementPostsCountRequest) (*Response, error)
+	GetUsersByUsernames(context.Context, *GetUsersByUsernamesRequest) (*GetUsersByUsernamesResponse, error)
+	ListPublicProfiles(context.Context, *ListPublicProfilesRequest) (*ListPublicProfilesResponse, error)
+	// Avatars and cover images. An upload is checked as it is received, then
+	// cropped and resized in the background; the profile shows it once ready.
+	UploadProfileImage(grpc.ClientStreamingServer[UploadProfileImageRequest, ProfileImage]) error
+	GetProfileImageContent(*GetProfileImageContentRequest, grpc.ServerStreamingServer[ProfileImageChunk]) error
+	// The caller's profile, for their data export
+	ExportMyData(context.Context, *ExportMyDataRequest) (*DataExport, error)
+	// Admin operations (require the ADMIN role)
+	SetUserCounters(context.Context, *SetUserCountersRequest) (*Response, error)
+	// ReconcileFollowCounters recounts the follows of every user from
+	// follow-service and corrects counters that have drifted. It is run on a
+	// schedule by scheduler-service.
+	ReconcileFollowCounters(context.Context, *ReconcileFollowCountersRequest) (*ReconcileFollowCountersResponse, error)
+	// ReconcilePostCounters recounts the posts of every user from post-service
+	// and corrects counters that have drifted. It is run on a schedule by
+	// scheduler-service.
+	ReconcilePostCounters(context.Context, *ReconcilePostCountersRequest) (*ReconcilePostCountersResponse, error)
+	ImportProfiles(context.Context, *ImportProfilesRequest) (*ImportProfilesResponse, error)
 	mustEmbedUnimplementedUserServiceServer()
 }
 
@@ -139,11 +262,32 @@ func (UnimplementedUserServiceServer) UpdateProfile(context.Context, *UpdateProf
 func (UnimplementedUserServiceServer) GetUsersByIds(context.Context, *GetUsersByIdsRequest) (*GetUsersByIdsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetUsersByIds not implemented")
 }
-func (UnimplementedUserServiceServer) IncrementPostsCount(context.Context, *IncrementPostsCountRequest) (*Response, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method IncrementPostsCount not implemented")
+func (UnimplementedUserServiceServer) GetUsersByUsernames(context.Context, *GetUsersByUsernamesRequest) (*GetUsersByUsernamesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUsersByUsernames not implemented")
+}
+func (UnimplementedUserServiceServer) ListPublicProfiles(context.Context, *ListPublicProfilesRequest) (*ListPublicProfilesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPublicProfiles not implemented")
+}
+func (UnimplementedUserServiceServer) UploadProfileImage(grpc.ClientStreamingServer[UploadProfileImageRequest, ProfileImage]) error {
+	return status.Errorf(codes.Unimplemented, "method UploadProfileImage not implemented")
 }
-func (UnimplementedUserServiceServer) DecrementPostsCount(context.Context, *DecrementPostsCountRequest) (*Response, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DecrementPostsCount not implemented")
+func (UnimplementedUserServiceServer) GetProfileImageContent(*GetProfileImageContentRequest, grpc.ServerStreamingServer[ProfileImageChunk]) error {
+	return status.Errorf(codes.Unimplemented, "method GetProfileImageContent not implemented")
+}
+func (UnimplementedUserServiceServer) ExportMyData(context.Context, *ExportMyDataRequest) (*DataExport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportMyData not implemented")
+}
+func (UnimplementedUserServiceServer) SetUserCounters(context.Context, *SetUserCountersRequest) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetUserCounters not implemented")
+}
+func (UnimplementedUserServiceServer) ReconcileFollowCounters(context.Context, *ReconcileFollowCountersRequest) (*ReconcileFollowCountersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReconcileFollowCounters not implemented")
+}
+func (UnimplementedUserServiceServer) ReconcilePostCounters(context.Context, *ReconcilePostCountersRequest) (*ReconcilePostCountersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReconcilePostCounters not implemented")
+}
+func (UnimplementedUserServiceServer) ImportProfiles(context.Context, *ImportProfilesRequest) (*ImportProfilesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportProfiles not implemented")
 }
 func (UnimplementedUserServiceServer) mustEmbedUnimplementedUserServiceServer() {}
 func (UnimplementedUserServiceServer) testEmbeddedByValue()                     {}
@@ -238,38 +382,146 @@ func _UserService_GetUsersByIds_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
-func _UserService_IncrementPostsCount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(IncrementPostsCountRequest)
+func _UserService_GetUsersByUsernames_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUsersByUsernamesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetUsersByUsernames(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetUsersByUsernames_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetUsersByUsernames(ctx, req.(*GetUsersByUsernamesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ListPublicProfiles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPublicProfilesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(UserServiceServer).IncrementPostsCount(ctx, in)
+		return srv.(UserServiceServer).ListPublicProfiles(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: UserService_IncrementPostsCount_FullMethodName,
+		FullMethod: UserService_ListPublicProfiles_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UserServiceServer).IncrementPostsCount(ctx, req.(*IncrementPostsCountRequest))
+		return srv.(UserServiceServer).ListPublicProfiles(ctx, req.(*ListPublicProfilesRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _UserService_DecrementPostsCount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DecrementPostsCountRequest)
+func _UserService_UploadProfileImage_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(UserServiceServer).UploadProfileImage(&grpc.GenericServerStream[UploadProfileImageRequest, ProfileImage]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type UserService_UploadProfileImageServer = grpc.ClientStreamingServer[UploadProfileImageRequest, ProfileImage]
+
+func _UserService_GetProfileImageContent_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetProfileImageContentRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(UserServiceServer).GetProfileImageContent(m, &grpc.GenericServerStream[GetProfileImageContentRequest, ProfileImageChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type UserService_GetProfileImageContentServer = grpc.ServerStreamingServer[ProfileImageChunk]
+
+func _UserService_ExportMyData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportMyDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ExportMyData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ExportMyData_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ExportMyData(ctx, req.(*ExportMyDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_SetUserCounters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetUserCountersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).SetUserCounters(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_SetUserCounters_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).SetUserCounters(ctx, req.(*SetUserCountersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ReconcileFollowCounters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReconcileFollowCountersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ReconcileFollowCounters(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ReconcileFollowCounters_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ReconcileFollowCounters(ctx, req.(*ReconcileFollowCountersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ReconcilePostCounters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReconcilePostCountersRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(UserServiceServer).DecrementPostsCount(ctx, in)
+		return srv.(UserServiceServer).ReconcilePostCounters(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: UserService_DecrementPostsCount_FullMethodName,
+		FullMethod: UserService_ReconcilePostCounters_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UserServiceServer).DecrementPostsCount(ctx, req.(*DecrementPostsCountRequest))
+		return srv.(UserServiceServer).ReconcilePostCounters(ctx, req.(*ReconcilePostCountersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ImportProfiles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportProfilesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ImportProfiles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ImportProfiles_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ImportProfiles(ctx, req.(*ImportProfilesRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -298,14 +550,45 @@ var UserService_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _UserService_GetUsersByIds_Handler,
 		},
 		{
-			MethodName: "IncrementPostsCount",
-			Handler:    _UserService_IncrementPostsCount_Handler,
+			MethodName: "GetUsersByUsernames",
+			Handler:    _UserService_GetUsersByUsernames_Handler,
+		},
+		{
+			MethodName: "ListPublicProfiles",
+			Handler:    _UserService_ListPublicProfiles_Handler,
+		},
+		{
+			MethodName: "ExportMyData",
+			Handler:    _UserService_ExportMyData_Handler,
+		},
+		{
+			MethodName: "SetUserCounters",
+			Handler:    _UserService_SetUserCounters_Handler,
+		},
+		{
+			MethodName: "ReconcileFollowCounters",
+			Handler:    _UserService_ReconcileFollowCounters_Handler,
+		},
+		{
+			MethodName: "ReconcilePostCounters",
+			Handler:    _UserService_ReconcilePostCounters_Handler,
+		},
+		{
+			MethodName: "ImportProfiles",
+			Handler:    _UserService_ImportProfiles_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "UploadProfileImage",
+			Handler:       _UserService_UploadProfileImage_Handler,
+			ClientStreams: true,
 		},
 		{
-			MethodName: "DecrementPostsCount",
-			Handler:    _UserService_DecrementPostsCount_Handler,
+			StreamName:    "GetProfileImageContent",
+			Handler:       _UserService_GetProfileImageContent_Handler,
+			ServerStreams: true,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/user.proto",
 }
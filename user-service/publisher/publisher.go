@@ -0,0 +1,59 @@
+package publisher
+
+import (
+	"context"
+	"encoding/json"
+	"shared/logging"
+	"user-service/events"
+	natsClient "user-service/nats"
+)
+
+type EventPublisher struct {
+	nats *natsClient.Client
+}
+
+func NewEventPublisher(nats *natsClient.Client) *EventPublisher {
+	return &EventPublisher{nats: nats}
+}
+
+func (p *EventPublisher) PublishUserUpdated(ctx context.Context, event events.UserUpdatedEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	if err := p.nats.Publish(ctx, events.UserUpdated, data); err != nil {
+		return err
+	}
+
+	logging.FromContext(ctx).Info().Str("subject", events.UserUpdated).Stringer("user_id", event.UserID).Msg("published event")
+	return nil
+}
+
+func (p *EventPublisher) PublishUsernameChanged(ctx context.Context, event events.UsernameChangedEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	if err := p.nats.Publish(ctx, events.UsernameChanged, data); err != nil {
+		return err
+	}
+
+	logging.FromContext(ctx).Info().Str("subject", events.UsernameChanged).Stringer("user_id", event.UserID).Msg("published event")
+	return nil
+}
+
+func (p *EventPublisher) PublishAuditRecorded(ctx context.Context, event events.AuditRecordedEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	if err := p.nats.Publish(ctx, events.AuditRecorded, data); err != nil {
+		return err
+	}
+
+	logging.FromContext(ctx).Info().Str("subject", events.AuditRecorded).Str("action", event.Action).Msg("published event")
+	return nil
+}
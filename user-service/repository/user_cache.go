@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"user-service/model"
+)
+
+const (
+	// profileCachePrefix keys cached profiles by user id
+	profileCachePrefix = "user:profile:"
+
+	// loadTimeout bounds a shared cache load, which no longer follows the
+	// cancellation of the request that started it
+	loadTimeout = 10 * time.Second
+)
+
+// CacheStats counts profile lookups answered from Redis and those that went
+// to the database
+type CacheStats struct {
+	Hits   int64
+	Misses int64
+}
+
+// profileCache is a read-through cache of profiles in Redis. Every write to a
+// profile drops its entry, and entries expire after ttl, which bounds how
+// long a profile read from a lagging replica can be served. Redis errors
+// count as misses, so profiles are read from the database when Redis is down.
+// A nil client caches nothing.
+type profileCache struct {
+	redis *redis.Client
+	ttl   time.Duration
+
+	hits   atomic.Int64
+	misses atomic.Int64
+}
+
+func profileCacheKey(userID uuid.UUID) string {
+	return profileCachePrefix + userID.String()
+}
+
+// get returns the cached profiles of userIDs and the ids not cached
+func (c *profileCache) get(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID]*models.User, []uuid.UUID) {
+	cached := make(map[uuid.UUID]*models.User, len(userIDs))
+	if c.redis == nil {
+		return cached, userIDs
+	}
+
+	keys := make([]string, len(userIDs))
+	for i, id := range userIDs {
+		keys[i] = profileCacheKey(id)
+	}
+	values, err := c.redis.MGet(ctx, keys...).Result()
+	if err != nil {
+		c.misses.Add(int64(len(userIDs)))
+		return cached, userIDs
+	}
+
+	var missing []uuid.UUID
+	for i, value := range values {
+		data, ok := value.(string)
+		var user models.User
+		if !ok || json.Unmarshal([]byte(data), &user) != nil {
+			missing = append(missing, userIDs[i])
+			continue
+		}
+		cached[userIDs[i]] = &user
+	}
+
+	c.hits.Add(int64(len(cached)))
+	c.misses.Add(int64(len(missing)))
+	return cached, missing
+}
+
+// set caches profiles loaded from the database
+func (c *profileCache) set(ctx context.Context, users ...*models.User) {
+	if c.redis == nil || len(users) == 0 {
+		return
+	}
+
+	pipe := c.redis.Pipeline()
+	for _, user := range users {
+		if data, err := json.Marshal(user); err == nil {
+			pipe.Set(ctx, profileCacheKey(user.ID), data, c.ttl)
+		}
+	}
+	_, _ = pipe.Exec(ctx)
+}
+
+// invalidate drops the cached profiles of users whose row changed
+func (c *profileCache) invalidate(ctx context.Context, userIDs ...uuid.UUID) {
+	if c.redis == nil || len(userIDs) == 0 {
+		return
+	}
+
+	keys := make([]string, len(userIDs))
+	for i, id := range userIDs {
+		keys[i] = profileCacheKey(id)
+	}
+	if err := c.redis.Del(ctx, keys...).Err(); err != nil {
+		log.Printf("Failed to invalidate cached profiles %v: %v", keys, err)
+	}
+}
+
+// CacheStats returns the profile cache's hits and misses since start
+func (r *userRepository) CacheStats() CacheStats {
+	return CacheStats{
+		Hits:   r.cache.hits.Load(),
+		Misses: r.cache.misses.Load(),
+	}
+}
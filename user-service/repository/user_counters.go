@@ -0,0 +1,219 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"user-service/model"
+)
+
+// ApplyFollowEvent adds delta to the following counter of followerID and the
+// followers counter of followingID, unless the event was applied before. It
+// reports whether the event was applied now. The event is recorded even if
+// either user is gone, so that it is not retried.
+func (r *userRepository) ApplyFollowEvent(ctx context.Context, eventID string, followerID, followingID uuid.UUID, delta int32) (bool, error) {
+	var applied bool
+	err := r.db.WithTx(ctx, func(ctx context.Context) error {
+		result, err := r.db.Conn(ctx).ExecContext(ctx, `
+			INSERT INTO user_service_counter_events (event_id) VALUES ($1)
+			ON CONFLICT (event_id) DO NOTHING
+		`, eventID)
+		if err != nil {
+			return fmt.Errorf("failed to record counter event: %w", err)
+		}
+		if n, err := result.RowsAffected(); err != nil || n == 0 {
+			return err
+		}
+		applied = true
+
+		_, err = r.db.Conn(ctx).ExecContext(ctx, `
+			UPDATE user_service_users
+			SET following_count = GREATEST(following_count + CASE WHEN id = $1 THEN $3 ELSE 0 END, 0),
+				followers_count = GREATEST(followers_count + CASE WHEN id = $2 THEN $3 ELSE 0 END, 0)
+			WHERE id IN ($1, $2)
+		`, followerID, followingID, delta)
+		if err != nil {
+			return fmt.Errorf("failed to update counters: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	if applied {
+		r.cache.invalidate(ctx, followerID, followingID)
+	}
+	return applied, nil
+}
+
+// ApplyPostEvent counts a post into its author's posts_count for a positive
+// delta and off it for a negative one. Each post is counted on once and off
+// once, in whichever order its events arrive: a post deleted before it was
+// counted leaves a tombstone, so it is never counted. It reports whether the
+// counter changed.
+func (r *userRepository) ApplyPostEvent(ctx context.Context, postID, userID uuid.UUID, delta int32) (bool, error) {
+	var applied bool
+	err := r.db.WithTx(ctx, func(ctx context.Context) error {
+		var result sql.Result
+		var err error
+		if delta > 0 {
+			result, err = r.db.Conn(ctx).ExecContext(ctx, `
+				INSERT INTO user_service_counted_posts (post_id, user_id) VALUES ($1, $2)
+				ON CONFLICT (post_id) DO NOTHING
+			`, postID, userID)
+		} else {
+			result, err = r.db.Conn(ctx).ExecContext(ctx, `
+				UPDATE user_service_counted_posts
+				SET deleted_at = NOW()
+				WHERE post_id = $1 AND deleted_at IS NULL
+			`, postID)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to record counted post: %w", err)
+		}
+		n, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			if delta < 0 {
+				// Never counted, or counted off already
+				_, err = r.db.Conn(ctx).ExecContext(ctx, `
+					INSERT INTO user_service_counted_posts (post_id, user_id, deleted_at) VALUES ($1, $2, NOW())
+					ON CONFLICT (post_id) DO NOTHING
+				`, postID, userID)
+				if err != nil {
+					return fmt.Errorf("failed to record deleted post: %w", err)
+				}
+			}
+			return nil
+		}
+		applied = true
+
+		_, err = r.db.Conn(ctx).ExecContext(ctx, `
+			UPDATE user_service_users
+			SET posts_count = GREATEST(posts_count + $2, 0)
+			WHERE id = $1
+		`, userID, delta)
+		if err != nil {
+			return fmt.Errorf("failed to update posts count: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	if applied {
+		r.cache.invalidate(ctx, userID)
+	}
+	return applied, nil
+}
+
+// PruneCounterEvents forgets events applied, and posts deleted, before
+// appliedBefore and returns how many were forgotten
+func (r *userRepository) PruneCounterEvents(ctx context.Context, appliedBefore time.Time) (int64, error) {
+	result, err := r.db.Conn(ctx).ExecContext(ctx, `DELETE FROM user_service_counter_events WHERE applied_at < $1`, appliedBefore)
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune counter events: %w", err)
+	}
+	events, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	result, err = r.db.Conn(ctx).ExecContext(ctx, `DELETE FROM user_service_counted_posts WHERE deleted_at < $1`, appliedBefore)
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune deleted posts: %w", err)
+	}
+	posts, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return events + posts, nil
+}
+
+// ListFollowCounters returns the follow counters of up to limit users with
+// IDs after after, in ID order
+func (r *userRepository) ListFollowCounters(ctx context.Context, after uuid.UUID, limit int32) ([]models.FollowCounters, error) {
+	query := `
+		SELECT id, followers_count, following_count
+		FROM user_service_users
+		WHERE id > $1
+		ORDER BY id
+		LIMIT $2
+	`
+	var counters []models.FollowCounters
+	// Read from the primary, as CorrectFollowCounters compares against what
+	// is read
+	if err := r.db.Conn(ctx).SelectContext(ctx, &counters, query, after, limit); err != nil {
+		return nil, fmt.Errorf("failed to list counters: %w", err)
+	}
+	return counters, nil
+}
+
+// CorrectFollowCounters overwrites a user's follow counters with correct if
+// they still hold seen. Counters an event changed in the meantime are left
+// for the next reconciliation, since correct may not include that event. It
+// reports whether the counters were changed.
+func (r *userRepository) CorrectFollowCounters(ctx context.Context, seen, correct models.FollowCounters) (bool, error) {
+	result, err := r.db.Conn(ctx).ExecContext(ctx, `
+		UPDATE user_service_users
+		SET followers_count = $4, following_count = $5
+		WHERE id = $1 AND followers_count = $2 AND following_count = $3
+	`, seen.UserID, seen.FollowersCount, seen.FollowingCount, correct.FollowersCount, correct.FollowingCount)
+	if err != nil {
+		return false, fmt.Errorf("failed to correct counters: %w", err)
+	}
+	changed, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	if changed > 0 {
+		r.cache.invalidate(ctx, seen.UserID)
+	}
+	return changed > 0, nil
+}
+
+// ListPostsCounters returns the post counters of up to limit users with IDs
+// after after, in ID order
+func (r *userRepository) ListPostsCounters(ctx context.Context, after uuid.UUID, limit int32) ([]models.PostsCounter, error) {
+	query := `
+		SELECT id, posts_count
+		FROM user_service_users
+		WHERE id > $1
+		ORDER BY id
+		LIMIT $2
+	`
+	var counters []models.PostsCounter
+	// Read from the primary, as CorrectPostsCounter compares against what is
+	// read
+	if err := r.db.Conn(ctx).SelectContext(ctx, &counters, query, after, limit); err != nil {
+		return nil, fmt.Errorf("failed to list counters: %w", err)
+	}
+	return counters, nil
+}
+
+// CorrectPostsCounter overwrites a user's posts_count with correct if it
+// still holds seen, as CorrectFollowCounters does. It reports whether the
+// counter was changed.
+func (r *userRepository) CorrectPostsCounter(ctx context.Context, seen, correct models.PostsCounter) (bool, error) {
+	result, err := r.db.Conn(ctx).ExecContext(ctx, `
+		UPDATE user_service_users
+		SET posts_count = $3
+		WHERE id = $1 AND posts_count = $2
+	`, seen.UserID, seen.PostsCount, correct.PostsCount)
+	if err != nil {
+		return false, fmt.Errorf("failed to correct counter: %w", err)
+	}
+	changed, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	if changed > 0 {
+		r.cache.invalidate(ctx, seen.UserID)
+	}
+	return changed > 0, nil
+}
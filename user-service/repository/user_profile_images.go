@@ -0,0 +1,173 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"user-service/model"
+)
+
+// ErrProfileImageNotFound is returned by GetProfileImage for an unknown image
+var ErrProfileImageNotFound = errors.New("profile image not found")
+
+// profileImageColumns are the columns of user_service_users pointing at the
+// image shown for each kind
+var profileImageColumns = map[string]string{
+	models.ProfileImageAvatar: "avatar_id",
+	models.ProfileImageCover:  "cover_id",
+}
+
+const profileImageFields = `id, user_id, kind, status, mime_type, size_bytes, attempts, error, created_at, processed_at`
+
+// CreateProfileImage records an upload waiting to be resized
+func (r *userRepository) CreateProfileImage(ctx context.Context, img *models.ProfileImage) error {
+	query := `
+		INSERT INTO user_service_profile_images (id, user_id, kind, status, mime_type, size_bytes, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+	_, err := r.db.ExecContext(ctx, query, img.ID, img.UserID, img.Kind, img.Status, img.MimeType, img.SizeBytes, img.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create profile image: %w", err)
+	}
+	return nil
+}
+
+func (r *userRepository) GetProfileImage(ctx context.Context, imageID uuid.UUID) (*models.ProfileImage, error) {
+	var img models.ProfileImage
+	err := r.db.ReadDB().GetContext(ctx, &img, `SELECT `+profileImageFields+` FROM user_service_profile_images WHERE id = $1`, imageID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrProfileImageNotFound
+		}
+		return nil, fmt.Errorf("failed to get profile image: %w", err)
+	}
+	return &img, nil
+}
+
+// ClaimProfileImage takes the oldest pending image for a worker to resize
+// and counts the attempt. An image claimed before claimedBefore is taken
+// again, as its worker is assumed to have died. It returns nil when no image
+// is pending.
+func (r *userRepository) ClaimProfileImage(ctx context.Context, claimedBefore time.Time) (*models.ProfileImage, error) {
+	query := `
+		UPDATE user_service_profile_images
+		SET attempts = attempts + 1, claimed_at = NOW()
+		WHERE id = (
+			SELECT id FROM user_service_profile_images
+			WHERE status = 'PENDING' AND (claimed_at IS NULL OR claimed_at < $1)
+			ORDER BY created_at
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + profileImageFields
+
+	var img models.ProfileImage
+	err := r.db.GetContext(ctx, &img, query, claimedBefore)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to claim profile image: %w", err)
+	}
+	return &img, nil
+}
+
+// ShowProfileImage marks a resized image ready and shows it on its user's
+// profile, unless the profile already shows a newer image of the same kind.
+// It reports whether the profile changed.
+func (r *userRepository) ShowProfileImage(ctx context.Context, img *models.ProfileImage, mimeType string, sizeBytes int64) (bool, error) {
+	column, ok := profileImageColumns[img.Kind]
+	if !ok {
+		return false, fmt.Errorf("unknown profile image kind %q", img.Kind)
+	}
+
+	var shown bool
+	err := r.db.WithTx(ctx, func(ctx context.Context) error {
+		result, err := r.db.Conn(ctx).ExecContext(ctx, `
+			UPDATE user_service_profile_images
+			SET status = 'READY', mime_type = $2, size_bytes = $3, error = NULL, processed_at = NOW()
+			WHERE id = $1 AND status = 'PENDING'
+		`, img.ID, mimeType, sizeBytes)
+		if err != nil {
+			return fmt.Errorf("failed to mark profile image ready: %w", err)
+		}
+		n, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			// Finished already by a worker that claimed it before
+			return nil
+		}
+
+		result, err = r.db.Conn(ctx).ExecContext(ctx, `
+			UPDATE user_service_users u
+			SET `+column+` = $2
+			WHERE u.id = $1 AND NOT EXISTS (
+				SELECT 1 FROM user_service_profile_images i
+				WHERE i.id = u.`+column+` AND i.created_at > $3
+			)
+		`, img.UserID, img.ID, img.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to show profile image: %w", err)
+		}
+		n, err = result.RowsAffected()
+		shown = n > 0
+		return err
+	})
+	if err != nil {
+		return false, err
+	}
+	if shown {
+		r.cache.invalidate(ctx, img.UserID)
+	}
+	return shown, nil
+}
+
+// FailProfileImage marks a pending image as failed, with the reason
+func (r *userRepository) FailProfileImage(ctx context.Context, imageID uuid.UUID, reason string) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE user_service_profile_images
+		SET status = 'FAILED', error = $2, processed_at = NOW()
+		WHERE id = $1 AND status = 'PENDING'
+	`, imageID, reason)
+	if err != nil {
+		return fmt.Errorf("failed to mark profile image failed: %w", err)
+	}
+	return nil
+}
+
+// ListUnusedProfileImages returns images created before createdBefore that
+// are no longer pending and that no profile shows: replaced, failed, or of a
+// deleted user
+func (r *userRepository) ListUnusedProfileImages(ctx context.Context, createdBefore time.Time, limit int32) ([]uuid.UUID, error) {
+	query := `
+		SELECT i.id
+		FROM user_service_profile_images i
+		WHERE i.status <> 'PENDING' AND i.created_at < $1
+			AND NOT EXISTS (
+				SELECT 1 FROM user_service_users u
+				WHERE u.id = i.user_id AND i.id IN (u.avatar_id, u.cover_id)
+			)
+		ORDER BY i.created_at
+		LIMIT $2
+	`
+
+	var ids []uuid.UUID
+	if err := r.db.SelectContext(ctx, &ids, query, createdBefore, limit); err != nil {
+		return nil, fmt.Errorf("failed to list unused profile images: %w", err)
+	}
+	return ids, nil
+}
+
+func (r *userRepository) DeleteProfileImage(ctx context.Context, imageID uuid.UUID) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM user_service_profile_images WHERE id = $1`, imageID)
+	if err != nil {
+		return fmt.Errorf("failed to delete profile image: %w", err)
+	}
+	return nil
+}
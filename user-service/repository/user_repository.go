@@ -4,9 +4,14 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
-	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/redis/go-redis/v9"
+	"golang.org/x/sync/singleflight"
+	"user-service/db"
 	"user-service/model"
 )
 
@@ -16,23 +21,79 @@ type UserRepository interface {
 	GetByUsername(ctx context.Context, username string) (*models.User, error)
 	Update(ctx context.Context, userID uuid.UUID, input *models.UpdateUserInput) (*models.User, error)
 	GetByIDs(ctx context.Context, userIDs []uuid.UUID) ([]*models.User, error)
-	IncrementPostsCount(ctx context.Context, userID uuid.UUID) error
-	DecrementPostsCount(ctx context.Context, userID uuid.UUID) error
+	GetByUsernames(ctx context.Context, usernames []string) ([]*models.User, error)
+	GetByFormerUsernames(ctx context.Context, usernames []string) ([]*models.FormerUsername, error)
+	SetCounters(ctx context.Context, userID uuid.UUID, followersCount, followingCount, postsCount int32) error
+	ApplyFollowEvent(ctx context.Context, eventID string, followerID, followingID uuid.UUID, delta int32) (bool, error)
+	PruneCounterEvents(ctx context.Context, appliedBefore time.Time) (int64, error)
+	ListFollowCounters(ctx context.Context, after uuid.UUID, limit int32) ([]models.FollowCounters, error)
+	CorrectFollowCounters(ctx context.Context, seen, correct models.FollowCounters) (bool, error)
+	ApplyPostEvent(ctx context.Context, postID, userID uuid.UUID, delta int32) (bool, error)
+	ListPostsCounters(ctx context.Context, after uuid.UUID, limit int32) ([]models.PostsCounter, error)
+	CorrectPostsCounter(ctx context.Context, seen, correct models.PostsCounter) (bool, error)
 	CheckFollowStatus(ctx context.Context, userID, followerID uuid.UUID) (bool, error)
+	ListPublicProfiles(ctx context.Context, afterID *uuid.UUID, limit int32) ([]*models.User, error)
+	ImportProfiles(ctx context.Context, users []models.User) (int32, error)
+	CreateProfileImage(ctx context.Context, img *models.ProfileImage) error
+	GetProfileImage(ctx context.Context, imageID uuid.UUID) (*models.ProfileImage, error)
+	ClaimProfileImage(ctx context.Context, claimedBefore time.Time) (*models.ProfileImage, error)
+	ShowProfileImage(ctx context.Context, img *models.ProfileImage, mimeType string, sizeBytes int64) (bool, error)
+	FailProfileImage(ctx context.Context, imageID uuid.UUID, reason string) error
+	ListUnusedProfileImages(ctx context.Context, createdBefore time.Time, limit int32) ([]uuid.UUID, error)
+	DeleteProfileImage(ctx context.Context, imageID uuid.UUID) error
+	Delete(ctx context.Context, userID uuid.UUID) error
+	CacheStats() CacheStats
 }
 
 type userRepository struct {
-	db *sqlx.DB
+	db    *database.DB
+	cache *profileCache
+
+	// loads collapses concurrent cache misses for the same profile into one
+	// query
+	loads singleflight.Group
 }
 
-func NewUserRepository(db *sqlx.DB) UserRepository {
-	return &userRepository{db: db}
+// NewUserRepository creates a repository caching profiles in redis for
+// cacheTTL. redis may be nil, which caches nothing.
+func NewUserRepository(db *database.DB, redis *redis.Client, cacheTTL time.Duration) UserRepository {
+	return &userRepository{
+		db:    db,
+		cache: &profileCache{redis: redis, ttl: cacheTTL},
+	}
 }
 
+// GetByID returns a user's profile, from the cache when it holds it
 func (r *userRepository) GetByID(ctx context.Context, userID uuid.UUID) (*models.User, error) {
+	if cached, _ := r.cache.get(ctx, []uuid.UUID{userID}); cached[userID] != nil {
+		return cached[userID], nil
+	}
+
+	v, err, _ := r.loads.Do(userID.String(), func() (interface{}, error) {
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), loadTimeout)
+		defer cancel()
+
+		user, err := r.loadByID(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		r.cache.set(ctx, user)
+		return user, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Callers sharing a load get copies, so they can change theirs
+	user := *v.(*models.User)
+	return &user, nil
+}
+
+func (r *userRepository) loadByID(ctx context.Context, userID uuid.UUID) (*models.User, error) {
 	query := `
 		SELECT id, username, email, bio, created_at, updated_at, 
-		       followers_count, following_count, posts_count
+		       followers_count, following_count, posts_count, is_private, avatar_id, cover_id,
+		       display_name, location, website, birth_date, pronouns, hide_email, hide_birth_date
 		FROM user_service_users
 		WHERE id = $1
 	`
@@ -52,7 +113,8 @@ func (r *userRepository) GetByID(ctx context.Context, userID uuid.UUID) (*models
 func (r *userRepository) GetByEmail(ctx context.Context, email string) (*models.User, error) {
 	query := `
 		SELECT id, username, email, bio, created_at, updated_at,
-		       followers_count, following_count, posts_count
+		       followers_count, following_count, posts_count, is_private, avatar_id, cover_id,
+		       display_name, location, website, birth_date, pronouns, hide_email, hide_birth_date
 		FROM user_service_users
 		WHERE email = $1
 	`
@@ -72,7 +134,8 @@ func (r *userRepository) GetByEmail(ctx context.Context, email string) (*models.
 func (r *userRepository) GetByUsername(ctx context.Context, username string) (*models.User, error) {
 	query := `
 		SELECT id, username, email, bio, created_at, updated_at,
-		       followers_count, following_count, posts_count
+		       followers_count, following_count, posts_count, is_private, avatar_id, cover_id,
+		       display_name, location, website, birth_date, pronouns, hide_email, hide_birth_date
 		FROM user_service_users
 		WHERE username = $1
 	`
@@ -89,6 +152,9 @@ func (r *userRepository) GetByUsername(ctx context.Context, username string) (*m
 	return &user, nil
 }
 
+// Update changes the fields set in input. A new username is checked against
+// the usernames of other users and the policy, in the same transaction; see
+// changeUsername.
 func (r *userRepository) Update(ctx context.Context, userID uuid.UUID, input *models.UpdateUserInput) (*models.User, error) {
 	query := "UPDATE user_service_users SET updated_at = NOW()"
 	args := []interface{}{}
@@ -112,76 +178,147 @@ func (r *userRepository) Update(ctx context.Context, userID uuid.UUID, input *mo
 		argCount++
 	}
 
-	query += fmt.Sprintf(" WHERE id = $%d RETURNING id, username, email, bio, created_at, updated_at, followers_count, following_count, posts_count", argCount)
+	if input.IsPrivate != nil {
+		query += fmt.Sprintf(", is_private = $%d", argCount)
+		args = append(args, *input.IsPrivate)
+		argCount++
+	}
+
+	// Empty strings clear the optional fields
+	optional := []struct {
+		column string
+		value  *string
+	}{
+		{"display_name", input.DisplayName},
+		{"location", input.Location},
+		{"website", input.Website},
+		{"birth_date", input.BirthDate},
+		{"pronouns", input.Pronouns},
+	}
+	for _, field := range optional {
+		if field.value != nil {
+			cast := ""
+			if field.column == "birth_date" {
+				cast = "::date"
+			}
+			query += fmt.Sprintf(", %s = NULLIF($%d, '')%s", field.column, argCount, cast)
+			args = append(args, *field.value)
+			argCount++
+		}
+	}
+
+	if input.HideEmail != nil {
+		query += fmt.Sprintf(", hide_email = $%d", argCount)
+		args = append(args, *input.HideEmail)
+		argCount++
+	}
+
+	if input.HideBirthDate != nil {
+		query += fmt.Sprintf(", hide_birth_date = $%d", argCount)
+		args = append(args, *input.HideBirthDate)
+		argCount++
+	}
+
+	query += fmt.Sprintf(" WHERE id = $%d RETURNING id, username, email, bio, created_at, updated_at, followers_count, following_count, posts_count, is_private, avatar_id, cover_id, display_name, location, website, birth_date, pronouns, hide_email, hide_birth_date", argCount)
 	args = append(args, userID)
 
 	var user models.User
-	err := r.db.GetContext(ctx, &user, query, args...)
-	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("user not found")
+	err := r.db.WithTx(ctx, func(ctx context.Context) error {
+		if input.Username != nil {
+			if err := r.changeUsername(ctx, userID, *input.Username, input.UsernamePolicy); err != nil {
+				return err
+			}
 		}
-		return nil, fmt.Errorf("failed to update user: %w", err)
+
+		err := r.db.Conn(ctx).GetContext(ctx, &user, query, args...)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return fmt.Errorf("user not found")
+			}
+			return fmt.Errorf("failed to update user: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
+	r.cache.invalidate(ctx, userID)
 
 	return &user, nil
 }
 
+// GetByIDs returns the profiles of the users that exist, taking those the
+// cache holds from it and reading the rest in one query
 func (r *userRepository) GetByIDs(ctx context.Context, userIDs []uuid.UUID) ([]*models.User, error) {
 	if len(userIDs) == 0 {
 		return []*models.User{}, nil
 	}
 
+	cached, missing := r.cache.get(ctx, userIDs)
+	users := make([]*models.User, 0, len(userIDs))
+	for _, user := range cached {
+		users = append(users, user)
+	}
+	if len(missing) == 0 {
+		return users, nil
+	}
+
 	query := `
 		SELECT id, username, email, bio, created_at, updated_at,
-		       followers_count, following_count, posts_count
+		       followers_count, following_count, posts_count, is_private, avatar_id, cover_id,
+		       display_name, location, website, birth_date, pronouns, hide_email, hide_birth_date
 		FROM user_service_users
 		WHERE id = ANY($1)
 	`
 
-	var users []*models.User
-	err := r.db.SelectContext(ctx, &users, query, userIDs)
+	var loaded []*models.User
+	err := r.db.ReadDB().SelectContext(ctx, &loaded, query, pq.Array(missing))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get users by IDs: %w", err)
 	}
+	r.cache.set(ctx, loaded...)
 
-	return users, nil
+	return append(users, loaded...), nil
 }
 
-func (r *userRepository) IncrementPostsCount(ctx context.Context, userID uuid.UUID) error {
-	query := `
-		UPDATE user_service_users
-		SET posts_count = posts_count + 1, updated_at = NOW()
-		WHERE id = $1
-	`
-
-	result, err := r.db.ExecContext(ctx, query, userID)
-	if err != nil {
-		return fmt.Errorf("failed to increment posts count: %w", err)
+// GetByUsernames looks users up by username, ignoring case
+func (r *userRepository) GetByUsernames(ctx context.Context, usernames []string) ([]*models.User, error) {
+	if len(usernames) == 0 {
+		return []*models.User{}, nil
 	}
 
-	rows, err := result.RowsAffected()
-	if err != nil {
-		return fmt.Errorf("failed to get rows affected: %w", err)
+	lowered := make([]string, len(usernames))
+	for i, username := range usernames {
+		lowered[i] = strings.ToLower(username)
 	}
 
-	if rows == 0 {
-		return fmt.Errorf("user not found")
+	query := `
+		SELECT id, username, email, bio, created_at, updated_at,
+		       followers_count, following_count, posts_count, is_private, avatar_id, cover_id,
+		       display_name, location, website, birth_date, pronouns, hide_email, hide_birth_date
+		FROM user_service_users
+		WHERE LOWER(username) = ANY($1)
+	`
+
+	var users []*models.User
+	err := r.db.ReadDB().SelectContext(ctx, &users, query, pq.Array(lowered))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get users by usernames: %w", err)
 	}
 
-	return nil
+	return users, nil
 }
 
-func (r *userRepository) DecrementPostsCount(ctx context.Context, userID uuid.UUID) error {
+func (r *userRepository) SetCounters(ctx context.Context, userID uuid.UUID, followersCount, followingCount, postsCount int32) error {
 	query := `
 		UPDATE user_service_users
-		SET posts_count = GREATEST(posts_count - 1, 0), updated_at = NOW()
+		SET followers_count = $2, following_count = $3, posts_count = $4, updated_at = NOW()
 		WHERE id = $1
 	`
 
-	result, err := r.db.ExecContext(ctx, query, userID)
+	result, err := r.db.ExecContext(ctx, query, userID, followersCount, followingCount, postsCount)
 	if err != nil {
-		return fmt.Errorf("failed to decrement posts count: %w", err)
+		return fmt.Errorf("failed to set counters: %w", err)
 	}
 
 	rows, err := result.RowsAffected()
@@ -192,10 +329,40 @@ func (r *userRepository) DecrementPostsCount(ctx context.Context, userID uuid.UU
 	if rows == 0 {
 		return fmt.Errorf("user not found")
 	}
+	r.cache.invalidate(ctx, userID)
 
 	return nil
 }
 
+// ImportProfiles inserts migrated profiles with their original ids and
+// creation times. Profiles clashing with an existing id, username or email are
+// skipped. It returns how many were created.
+func (r *userRepository) ImportProfiles(ctx context.Context, users []models.User) (int32, error) {
+	query := `
+		INSERT INTO user_service_users (id, username, email, bio, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $5)
+		ON CONFLICT DO NOTHING
+	`
+
+	var created int32
+	err := r.db.WithTx(ctx, func(ctx context.Context) error {
+		for _, u := range users {
+			result, err := r.db.Conn(ctx).ExecContext(ctx, query, u.ID, u.Username, u.Email, u.Bio, u.CreatedAt)
+			if err != nil {
+				return fmt.Errorf("failed to import profile %s: %w", u.ID, err)
+			}
+			if n, _ := result.RowsAffected(); n > 0 {
+				created++
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return created, nil
+}
+
 func (r *userRepository) CheckFollowStatus(ctx context.Context, userID, followerID uuid.UUID) (bool, error) {
 	query := `
 		SELECT EXISTS(
@@ -212,3 +379,37 @@ func (r *userRepository) CheckFollowStatus(ctx context.Context, userID, follower
 
 	return exists, nil
 }
+
+// ListPublicProfiles pages through every profile that may be indexed publicly,
+// ordered by id so callers can walk the whole table with a keyset cursor.
+// Private accounts are left out.
+func (r *userRepository) ListPublicProfiles(ctx context.Context, afterID *uuid.UUID, limit int32) ([]*models.User, error) {
+	query := `
+		SELECT id, username, email, bio, created_at, updated_at,
+		       followers_count, following_count, posts_count, is_private, avatar_id, cover_id,
+		       display_name, location, website, birth_date, pronouns, hide_email, hide_birth_date
+		FROM user_service_users
+		WHERE ($1::uuid IS NULL OR id > $1) AND NOT is_private
+		ORDER BY id
+		LIMIT $2
+	`
+
+	var users []*models.User
+	err := r.db.ReadDB().SelectContext(ctx, &users, query, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list public profiles: %w", err)
+	}
+
+	return users, nil
+}
+
+// Delete removes a user's profile. Deleting a profile that is already gone
+// is not an error, so a redelivered deletion succeeds.
+func (r *userRepository) Delete(ctx context.Context, userID uuid.UUID) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM user_service_users WHERE id = $1`, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete user: %w", err)
+	}
+	r.cache.invalidate(ctx, userID)
+	return nil
+}
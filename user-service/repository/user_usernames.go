@@ -0,0 +1,158 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"user-service/model"
+)
+
+var (
+	// ErrUsernameTaken is returned by Update for a username another user
+	// has, in any case
+	ErrUsernameTaken = errors.New("username already taken")
+	// ErrUsernameReserved is returned by Update for a former username of
+	// another user that is still reserved for them
+	ErrUsernameReserved = errors.New("username is reserved")
+)
+
+// UsernameCooldownError is returned by Update for a username change sooner
+// after the last one than the policy allows
+type UsernameCooldownError struct {
+	Until time.Time
+}
+
+func (e *UsernameCooldownError) Error() string {
+	return fmt.Sprintf("username can be changed again after %s", e.Until.UTC().Format(time.RFC3339))
+}
+
+// changeUsername sets the username of a user within the transaction of
+// Update, keeping the former username in the history, reserved for the user
+// for policy.Reservation. Both usernames are locked for the transaction, so
+// a username given up cannot be taken by another change before it is
+// reserved. A user taking back a former username frees its reservation.
+func (r *userRepository) changeUsername(ctx context.Context, userID uuid.UUID, username string, policy models.UsernamePolicy) error {
+	conn := r.db.Conn(ctx)
+
+	var current string
+	err := conn.GetContext(ctx, &current, `SELECT username FROM user_service_users WHERE id = $1 FOR UPDATE`, userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("user not found")
+		}
+		return fmt.Errorf("failed to get username: %w", err)
+	}
+	if current == username {
+		return nil
+	}
+
+	// Lock in order, so changes swapping two usernames cannot deadlock
+	locks := []string{strings.ToLower(current), strings.ToLower(username)}
+	_, err = conn.ExecContext(ctx, `
+		SELECT pg_advisory_xact_lock(hashtext('user_service_username:' || name))
+		FROM (SELECT DISTINCT unnest($1::text[]) AS name ORDER BY name) names
+	`, pq.Array(locks))
+	if err != nil {
+		return fmt.Errorf("failed to lock usernames: %w", err)
+	}
+
+	var taken bool
+	err = conn.GetContext(ctx, &taken, `
+		SELECT EXISTS (SELECT 1 FROM user_service_users WHERE LOWER(username) = LOWER($2) AND id <> $1)
+	`, userID, username)
+	if err != nil {
+		return fmt.Errorf("failed to check username: %w", err)
+	}
+	if taken {
+		return ErrUsernameTaken
+	}
+
+	var reserved bool
+	err = conn.GetContext(ctx, &reserved, `
+		SELECT EXISTS (
+			SELECT 1 FROM user_service_username_history
+			WHERE LOWER(username) = LOWER($2) AND reserved_until > NOW() AND user_id <> $1
+		)
+	`, userID, username)
+	if err != nil {
+		return fmt.Errorf("failed to check username reservations: %w", err)
+	}
+	if reserved {
+		return ErrUsernameReserved
+	}
+
+	if strings.EqualFold(current, username) {
+		return nil
+	}
+
+	var until time.Time
+	err = conn.GetContext(ctx, &until, `
+		SELECT MAX(changed_at) + $2 * INTERVAL '1 second'
+		FROM user_service_username_history
+		WHERE user_id = $1
+		HAVING MAX(changed_at) + $2 * INTERVAL '1 second' > NOW()
+	`, userID, policy.Cooldown.Seconds())
+	if err == nil {
+		return &UsernameCooldownError{Until: until}
+	}
+	if err != sql.ErrNoRows {
+		return fmt.Errorf("failed to get last username change: %w", err)
+	}
+
+	_, err = conn.ExecContext(ctx, `
+		DELETE FROM user_service_username_history
+		WHERE user_id = $1 AND LOWER(username) = LOWER($2)
+	`, userID, username)
+	if err != nil {
+		return fmt.Errorf("failed to free reserved username: %w", err)
+	}
+
+	_, err = conn.ExecContext(ctx, `
+		INSERT INTO user_service_username_history (user_id, username, changed_at, reserved_until)
+		VALUES ($1, $2, NOW(), NOW() + $3 * INTERVAL '1 second')
+	`, userID, current, policy.Reservation.Seconds())
+	if err != nil {
+		return fmt.Errorf("failed to record username change: %w", err)
+	}
+	return nil
+}
+
+// GetByFormerUsernames finds the users whose former usernames, still
+// reserved for them, match usernames case-insensitively. A username held by
+// several users in turn finds the user who gave it up last.
+func (r *userRepository) GetByFormerUsernames(ctx context.Context, usernames []string) ([]*models.FormerUsername, error) {
+	if len(usernames) == 0 {
+		return []*models.FormerUsername{}, nil
+	}
+
+	lowered := make([]string, len(usernames))
+	for i, username := range usernames {
+		lowered[i] = strings.ToLower(username)
+	}
+
+	query := `
+		SELECT DISTINCT ON (LOWER(h.username))
+		       h.username AS former_username,
+		       u.id, u.username, u.email, u.bio, u.created_at, u.updated_at,
+		       u.followers_count, u.following_count, u.posts_count, u.is_private, u.avatar_id, u.cover_id,
+		       u.display_name, u.location, u.website, u.birth_date, u.pronouns, u.hide_email, u.hide_birth_date
+		FROM user_service_username_history h
+		JOIN user_service_users u ON u.id = h.user_id
+		WHERE LOWER(h.username) = ANY($1) AND h.reserved_until > NOW()
+		ORDER BY LOWER(h.username), h.changed_at DESC
+	`
+
+	var users []*models.FormerUsername
+	err := r.db.ReadDB().SelectContext(ctx, &users, query, pq.Array(lowered))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get users by former usernames: %w", err)
+	}
+
+	return users, nil
+}
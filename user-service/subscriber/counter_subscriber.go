@@ -0,0 +1,175 @@
+package subscriber
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nats-io/nats.go"
+	"shared/logging"
+	"shared/tracing"
+	"user-service/events"
+	natsClient "user-service/nats"
+)
+
+const (
+	// counterEventRetention is how long applied events are remembered. It
+	// outlasts the stream's retention, so no copy of a forgotten event can
+	// be delivered again.
+	counterEventRetention = 8 * 24 * time.Hour
+	// counterPruneInterval is how often applied events are forgotten
+	counterPruneInterval = time.Hour
+)
+
+// CounterApplier keeps the follower, following and post counters of users
+type CounterApplier interface {
+	ApplyFollowEvent(ctx context.Context, eventID string, followerID, followingID uuid.UUID, delta int32) error
+	ApplyPostEvent(ctx context.Context, postID, userID uuid.UUID, delta int32) error
+	PruneCounterEvents(ctx context.Context, appliedBefore time.Time) (int64, error)
+}
+
+// followDeltas are the changes each counted subject makes to the counters
+var followDeltas = map[string]int32{
+	events.UserFollowed:   1,
+	events.UserUnfollowed: -1,
+}
+
+// postDeltas are the changes each counted subject makes to posts_count
+var postDeltas = map[string]int32{
+	events.PostCreated: 1,
+	events.PostDeleted: -1,
+}
+
+// CounterSubscriber counts follows and posts into the counters of users from
+// the events of follow-service and post-service. Each follow event is applied
+// once, by its ID, and each post is counted once, however often its events
+// are delivered.
+type CounterSubscriber struct {
+	natsClient *natsClient.Client
+	applier    CounterApplier
+	ctx        context.Context
+}
+
+func NewCounterSubscriber(natsClient *natsClient.Client, applier CounterApplier, ctx context.Context) *CounterSubscriber {
+	return &CounterSubscriber{
+		natsClient: natsClient,
+		applier:    applier,
+		ctx:        ctx,
+	}
+}
+
+// Start subscribes in the background, retrying until the stream, created by
+// notification-service, exists, and prunes applied events until the
+// subscriber's context is cancelled
+func (s *CounterSubscriber) Start() {
+	consumers := map[string]struct {
+		durable string
+		handler nats.MsgHandler
+	}{
+		events.UserFollowed:   {"user-service-counters-followed", s.handleFollow},
+		events.UserUnfollowed: {"user-service-counters-unfollowed", s.handleFollow},
+		events.PostCreated:    {"user-service-counters-post-created", s.handlePost},
+		events.PostDeleted:    {"user-service-counters-post-deleted", s.handlePost},
+	}
+	for subject, consumer := range consumers {
+		go s.subscribe(subject, consumer.durable, consumer.handler)
+	}
+	go s.prune()
+}
+
+func (s *CounterSubscriber) subscribe(subject, durable string, handler nats.MsgHandler) {
+	for {
+		_, err := s.natsClient.SubscribeDurable(subject, durable, "user-workers", handler)
+		if err == nil {
+			log.Printf("Counter subscriber for %s started successfully", subject)
+			return
+		}
+
+		log.Printf("Failed to start counter subscriber for %s, retrying: %v", subject, err)
+		select {
+		case <-time.After(subscribeRetry):
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
+
+func (s *CounterSubscriber) handleFollow(msg *nats.Msg) {
+	ctx, span := tracing.StartProcess(s.ctx, msg.Subject, msg.Header)
+	ctx = logging.Extract(ctx, msg.Subject, msg.Header)
+	defer span.End()
+
+	var event events.FollowEvent
+	if err := natsClient.DecodeEvent(msg, &event); err != nil {
+		logging.FromContext(ctx).Error().Err(err).Msg("failed to decode follow event")
+		msg.Nak()
+		return
+	}
+
+	if err := s.applier.ApplyFollowEvent(ctx, eventID(msg, event), event.FollowerID, event.FollowingID, followDeltas[msg.Subject]); err != nil {
+		logging.FromContext(ctx).Error().Err(err).Stringer("follower_id", event.FollowerID).Msg("failed to apply follow event")
+		msg.Nak()
+		return
+	}
+
+	msg.Ack()
+}
+
+func (s *CounterSubscriber) handlePost(msg *nats.Msg) {
+	ctx, span := tracing.StartProcess(s.ctx, msg.Subject, msg.Header)
+	ctx = logging.Extract(ctx, msg.Subject, msg.Header)
+	defer span.End()
+
+	var event events.PostEvent
+	if err := natsClient.DecodeEvent(msg, &event); err != nil {
+		logging.FromContext(ctx).Error().Err(err).Msg("failed to decode post event")
+		msg.Nak()
+		return
+	}
+
+	if err := s.applier.ApplyPostEvent(ctx, event.PostID, event.UserID, postDeltas[msg.Subject]); err != nil {
+		logging.FromContext(ctx).Error().Err(err).Stringer("post_id", event.PostID).Msg("failed to apply post event")
+		msg.Nak()
+		return
+	}
+
+	msg.Ack()
+}
+
+// eventID identifies an event by the message ID it was published with.
+// follow-service publishes without one, so an event is otherwise identified
+// by its pair of users and the time the follow was created or removed.
+func eventID(msg *nats.Msg, event events.FollowEvent) string {
+	if id := msg.Header.Get(nats.MsgIdHdr); id != "" {
+		return id
+	}
+	at := event.CreatedAt
+	if msg.Subject == events.UserUnfollowed {
+		at = event.DeletedAt
+	}
+	return fmt.Sprintf("%s:%s:%s:%s", msg.Subject, event.FollowerID, event.FollowingID, at.UTC().Format(time.RFC3339Nano))
+}
+
+func (s *CounterSubscriber) prune() {
+	ticker := time.NewTicker(counterPruneInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		n, err := s.applier.PruneCounterEvents(s.ctx, time.Now().Add(-counterEventRetention))
+		if err != nil {
+			log.Printf("Failed to prune counter events: %v", err)
+			continue
+		}
+		if n > 0 {
+			log.Printf("Pruned %d applied counter events and deleted posts", n)
+		}
+	}
+}